@@ -9,6 +9,7 @@ import (
 	"github.com/argoproj/pkg/stats"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -19,6 +20,11 @@ import (
 
 	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/controller"
+	"github.com/argoproj/argo-cd/controller/applicationset"
+	"github.com/argoproj/argo-cd/controller/metrics"
+	"github.com/argoproj/argo-cd/controller/notification"
+	"github.com/argoproj/argo-cd/controller/registration"
+	"github.com/argoproj/argo-cd/controller/sharding"
 	"github.com/argoproj/argo-cd/errors"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
@@ -26,6 +32,7 @@ import (
 	"github.com/argoproj/argo-cd/util/cli"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/settings"
+	"github.com/argoproj/argo-cd/util/tracing"
 )
 
 const (
@@ -44,9 +51,15 @@ func newCommand() *cobra.Command {
 		selfHealTimeoutSeconds   int
 		statusProcessors         int
 		operationProcessors      int
+		appSetProcessors         int
 		logLevel                 string
+		logFormat                string
 		glogLevel                int
 		metricsPort              int
+		metricsHost              string
+		metricsAppLabels         []string
+		metricsMaxAppLabelValues int
+		otlpAddress              string
 		kubectlParallelismLimit  int64
 		cacheSrc                 func() (*appstatecache.Cache, error)
 	)
@@ -55,7 +68,9 @@ func newCommand() *cobra.Command {
 		Short: "application-controller is a controller to operate on applications CRD",
 		RunE: func(c *cobra.Command, args []string) error {
 			cli.SetLogLevel(logLevel)
+			cli.SetLogFormat(logFormat)
 			cli.SetGLogLevel(glogLevel)
+			tracing.Init("argocd-application-controller", otlpAddress)
 
 			config, err := clientConfig.ClientConfig()
 			errors.CheckError(err)
@@ -76,6 +91,11 @@ func newCommand() *cobra.Command {
 			cache, err := cacheSrc()
 			errors.CheckError(err)
 
+			replicas, err := sharding.GetReplicas()
+			errors.CheckError(err)
+			shard, err := sharding.InferShard(replicas)
+			errors.CheckError(err)
+
 			settingsMgr := settings.NewSettingsManager(ctx, kubeClient, namespace)
 			kubectl := &kube.KubectlCmd{}
 			appController, err := controller.NewApplicationController(
@@ -88,17 +108,28 @@ func newCommand() *cobra.Command {
 				kubectl,
 				resyncDuration,
 				time.Duration(selfHealTimeoutSeconds)*time.Second,
+				metricsHost,
 				metricsPort,
-				kubectlParallelismLimit)
+				metricsAppLabels,
+				metricsMaxAppLabelValues,
+				kubectlParallelismLimit,
+				replicas,
+				shard)
 			errors.CheckError(err)
 
 			vers := common.GetVersion()
-			log.Infof("Application Controller (version: %s, built: %s) starting (namespace: %s)", vers.Version, vers.BuildDate, namespace)
+			log.Infof("Application Controller (version: %s, built: %s) starting (namespace: %s, shard: %d of %d)", vers.Version, vers.BuildDate, namespace, shard, replicas)
 			stats.RegisterStackDumper()
 			stats.StartStatsTicker(10 * time.Minute)
 			stats.RegisterHeapDumper("memprofile")
 
 			go appController.Run(ctx, statusProcessors, operationProcessors)
+			// application sets, notifications and registrations are not sharded, the first shard processes all of them
+			if shard == 0 {
+				go applicationset.NewApplicationSetController(namespace, settingsMgr, kubeClient, dynamic.NewForConfigOrDie(config), appClient, repoClientset, resyncDuration).Run(ctx, appSetProcessors)
+				go notification.NewNotificationController(namespace, settingsMgr, appClient, resyncDuration).Run(ctx, 1)
+				go registration.NewRegistrationController(namespace, settingsMgr, kubeClient, appClient, resyncDuration).Run(ctx, 1)
+			}
 
 			// Wait forever
 			select {}
@@ -111,10 +142,16 @@ func newCommand() *cobra.Command {
 	command.Flags().IntVar(&repoServerTimeoutSeconds, "repo-server-timeout-seconds", 60, "Repo server RPC call timeout seconds.")
 	command.Flags().IntVar(&statusProcessors, "status-processors", 1, "Number of application status processors")
 	command.Flags().IntVar(&operationProcessors, "operation-processors", 1, "Number of application operation processors")
+	command.Flags().IntVar(&appSetProcessors, "applicationset-processors", 1, "Number of application set processors")
 	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.Flags().StringVar(&logFormat, "logformat", cli.LogFormatText, "Set the logging format. One of: text|json")
 	command.Flags().IntVar(&glogLevel, "gloglevel", 0, "Set the glog logging level")
 	command.Flags().IntVar(&metricsPort, "metrics-port", common.DefaultPortArgoCDMetrics, "Start metrics server on given port")
+	command.Flags().StringVar(&metricsHost, "metrics-address", "", "Listen on given address for the metrics server, e.g. an IPv4 or IPv6 address (default all IPv4 and IPv6 interfaces)")
+	command.Flags().StringSliceVar(&metricsAppLabels, "metrics-application-labels", []string{}, "Labels of applications added to the application metrics, e.g. team,service-tier")
+	command.Flags().IntVar(&metricsMaxAppLabelValues, "metrics-application-labels-max-values", metrics.DefaultMaxAppLabelValues, "Maximum number of values of each application label added to the metrics. Further values are reported as _other. Any value less than 1 means no limit.")
 	command.Flags().IntVar(&selfHealTimeoutSeconds, "self-heal-timeout-seconds", 5, "Specifies timeout between application self heal attempts")
+	command.Flags().StringVar(&otlpAddress, "otlp-address", "", "OpenTelemetry collector address to send traces to, e.g. otel-collector:4318. Tracing is disabled if empty.")
 	command.Flags().Int64Var(&kubectlParallelismLimit, "kubectl-parallelism-limit", 20, "Number of allowed concurrent kubectl fork/execs. Any value less the 1 means no limit.")
 
 	cacheSrc = appstatecache.AddCacheFlagsToCmd(&command)
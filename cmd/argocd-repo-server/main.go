@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/argoproj/pkg/stats"
@@ -16,29 +17,44 @@ import (
 	"github.com/argoproj/argo-cd/reposerver"
 	reposervercache "github.com/argoproj/argo-cd/reposerver/cache"
 	"github.com/argoproj/argo-cd/reposerver/metrics"
+	"github.com/argoproj/argo-cd/reposerver/repository"
+	"github.com/argoproj/argo-cd/util/attestation"
 	"github.com/argoproj/argo-cd/util/cli"
+	"github.com/argoproj/argo-cd/util/healthz"
+	logutils "github.com/argoproj/argo-cd/util/log"
 	"github.com/argoproj/argo-cd/util/tls"
+	"github.com/argoproj/argo-cd/util/tracing"
+	"github.com/argoproj/argo-cd/util/vault"
 )
 
 const (
 	// CLIName is the name of the CLI
 	cliName = "argocd-repo-server"
+	// logLevelsInterval is the interval of the checks of the log levels mounted from argocd-cm
+	logLevelsInterval = 30 * time.Second
 )
 
 func newCommand() *cobra.Command {
 	var (
 		logLevel               string
+		logFormat              string
 		parallelismLimit       int64
 		listenPort             int
 		metricsPort            int
+		listenHost             string
+		otlpAddress            string
 		cacheSrc               func() (*reposervercache.Cache, error)
 		tlsConfigCustomizerSrc func() (tls.ConfigCustomizer, error)
+		secretReaderSrc        func() (vault.SecretReader, error)
+		signerSrc              func() (*attestation.Signer, error)
 	)
 	var command = cobra.Command{
 		Use:   cliName,
 		Short: "Run argocd-repo-server",
 		RunE: func(c *cobra.Command, args []string) error {
 			cli.SetLogLevel(logLevel)
+			cli.SetLogFormat(logFormat)
+			tracing.Init("argocd-repo-server", otlpAddress)
 
 			tlsConfigCustomizer, err := tlsConfigCustomizerSrc()
 			errors.CheckError(err)
@@ -46,16 +62,27 @@ func newCommand() *cobra.Command {
 			cache, err := cacheSrc()
 			errors.CheckError(err)
 
+			secretReader, err := secretReaderSrc()
+			errors.CheckError(err)
+
+			signer, err := signerSrc()
+			errors.CheckError(err)
+
 			metricsServer := metrics.NewMetricsServer()
-			server, err := reposerver.NewServer(metricsServer, cache, tlsConfigCustomizer, parallelismLimit)
+			server, err := reposerver.NewServer(metricsServer, cache, tlsConfigCustomizer, parallelismLimit, secretReader, signer)
 			errors.CheckError(err)
 
 			grpc := server.CreateGRPC()
-			listener, err := net.Listen("tcp", fmt.Sprintf(":%d", listenPort))
+			listener, err := net.Listen("tcp", net.JoinHostPort(listenHost, strconv.Itoa(listenPort)))
 			errors.CheckError(err)
 
 			http.Handle("/metrics", metricsServer.GetHandler())
-			go func() { errors.CheckError(http.ListenAndServe(fmt.Sprintf(":%d", metricsPort), nil)) }()
+			healthz.ServeHealthCheck(http.DefaultServeMux, func() error { return nil },
+				healthz.Check{Name: healthz.CheckRedis, Check: cache.Ping})
+			go func() {
+				errors.CheckError(http.ListenAndServe(net.JoinHostPort(listenHost, strconv.Itoa(metricsPort)), nil))
+			}()
+			go logutils.WatchLevelsFile(common.DefaultPathRepoServerLogLevels, logLevelsInterval)
 
 			log.Infof("argocd-repo-server %s serving on %s", common.GetVersion(), listener.Addr())
 			stats.RegisterStackDumper()
@@ -68,15 +95,21 @@ func newCommand() *cobra.Command {
 	}
 
 	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.Flags().StringVar(&logFormat, "logformat", cli.LogFormatText, "Set the logging format. One of: text|json")
 	command.Flags().Int64Var(&parallelismLimit, "parallelismlimit", 0, "Limit on number of concurrent manifests generate requests. Any value less the 1 means no limit.")
+	command.Flags().StringVar(&listenHost, "address", "", "Listen on given address for incoming connections and for the metrics server, e.g. an IPv4 or IPv6 address (default all IPv4 and IPv6 interfaces)")
 	command.Flags().IntVar(&listenPort, "port", common.DefaultPortRepoServer, "Listen on given port for incoming connections")
 	command.Flags().IntVar(&metricsPort, "metrics-port", common.DefaultPortRepoServerMetrics, "Start metrics server on given port")
+	command.Flags().StringVar(&otlpAddress, "otlp-address", "", "OpenTelemetry collector address to send traces to, e.g. otel-collector:4318. Tracing is disabled if empty.")
 	tlsConfigCustomizerSrc = tls.AddTLSFlagsToCmd(&command)
 	cacheSrc = reposervercache.AddCacheFlagsToCmd(&command)
+	secretReaderSrc = vault.AddVaultFlagsToCmd(&command)
+	signerSrc = attestation.AddAttestationFlagsToCmd(&command)
 	return &command
 }
 
 func main() {
+	repository.EvaluateJsonnetIfRequested()
 	if err := newCommand().Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
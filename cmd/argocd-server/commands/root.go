@@ -17,15 +17,18 @@ import (
 	servercache "github.com/argoproj/argo-cd/server/cache"
 	"github.com/argoproj/argo-cd/util/cli"
 	"github.com/argoproj/argo-cd/util/tls"
+	"github.com/argoproj/argo-cd/util/tracing"
 )
 
 // NewCommand returns a new instance of an argocd command
 func NewCommand() *cobra.Command {
 	var (
 		insecure                 bool
+		listenHost               string
 		listenPort               int
 		metricsPort              int
 		logLevel                 string
+		logFormat                string
 		glogLevel                int
 		clientConfig             clientcmd.ClientConfig
 		repoServerTimeoutSeconds int
@@ -37,6 +40,10 @@ func NewCommand() *cobra.Command {
 		tlsConfigCustomizerSrc   func() (tls.ConfigCustomizer, error)
 		cacheSrc                 func() (*servercache.Cache, error)
 		frameOptions             string
+		rateLimitQPS             float64
+		rateLimitBurst           int
+		webhookParallelism       int
+		otlpAddress              string
 	)
 	var command = &cobra.Command{
 		Use:   cliName,
@@ -44,7 +51,9 @@ func NewCommand() *cobra.Command {
 		Long:  "Run the argocd API server",
 		Run: func(c *cobra.Command, args []string) {
 			cli.SetLogLevel(logLevel)
+			cli.SetLogFormat(logFormat)
 			cli.SetGLogLevel(glogLevel)
+			tracing.Init("argocd-server", otlpAddress)
 
 			config, err := clientConfig.ClientConfig()
 			errors.CheckError(err)
@@ -65,6 +74,7 @@ func NewCommand() *cobra.Command {
 
 			argoCDOpts := server.ArgoCDServerOpts{
 				Insecure:            insecure,
+				ListenHost:          listenHost,
 				ListenPort:          listenPort,
 				MetricsPort:         metricsPort,
 				Namespace:           namespace,
@@ -78,6 +88,9 @@ func NewCommand() *cobra.Command {
 				TLSConfigCustomizer: tlsConfigCustomizer,
 				Cache:               cache,
 				XFrameOptions:       frameOptions,
+				RateLimitQPS:        rateLimitQPS,
+				RateLimitBurst:      rateLimitBurst,
+				WebhookParallelism:  webhookParallelism,
 			}
 
 			stats.RegisterStackDumper()
@@ -99,15 +112,21 @@ func NewCommand() *cobra.Command {
 	command.Flags().StringVar(&staticAssetsDir, "staticassets", "", "Static assets directory path")
 	command.Flags().StringVar(&baseHRef, "basehref", "/", "Value for base href in index.html. Used if Argo CD is running behind reverse proxy under subpath different from /")
 	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.Flags().StringVar(&logFormat, "logformat", cli.LogFormatText, "Set the logging format. One of: text|json")
 	command.Flags().IntVar(&glogLevel, "gloglevel", 0, "Set the glog logging level")
 	command.Flags().StringVar(&repoServerAddress, "repo-server", common.DefaultRepoServerAddr, "Repo server address")
 	command.Flags().StringVar(&dexServerAddress, "dex-server", common.DefaultDexServerAddr, "Dex server address")
 	command.Flags().BoolVar(&disableAuth, "disable-auth", false, "Disable client authentication")
 	command.AddCommand(cli.NewVersionCmd(cliName))
+	command.Flags().StringVar(&listenHost, "address", "", "Listen on given address for the API and metrics servers, e.g. an IPv4 or IPv6 address (default all IPv4 and IPv6 interfaces)")
 	command.Flags().IntVar(&listenPort, "port", common.DefaultPortAPIServer, "Listen on given port")
 	command.Flags().IntVar(&metricsPort, "metrics-port", common.DefaultPortArgoCDAPIServerMetrics, "Start metrics on given port")
 	command.Flags().IntVar(&repoServerTimeoutSeconds, "repo-server-timeout-seconds", 60, "Repo server RPC call timeout seconds.")
 	command.Flags().StringVar(&frameOptions, "x-frame-options", "sameorigin", "Set X-Frame-Options header in HTTP responses to `value`. To disable, set to \"\".")
+	command.Flags().Float64Var(&rateLimitQPS, "rate-limit-qps", 0, "Maximum number of API requests per second allowed for every account, and for every client IP address of unauthenticated requests. Requests over the limit are rejected with HTTP 429. Zero disables rate limiting. The limit is enforced by every API server replica separately, so the effective limit is multiplied by the number of replicas.")
+	command.Flags().IntVar(&rateLimitBurst, "rate-limit-burst", 20, "Maximum number of API requests an account or client IP address may issue in a single burst to every API server replica")
+	command.Flags().IntVar(&webhookParallelism, "webhook-parallelism-limit", 50, "Number of workers which process the queued webhook events, i.e. refresh the affected applications")
+	command.Flags().StringVar(&otlpAddress, "otlp-address", "", "OpenTelemetry collector address to send traces to, e.g. otel-collector:4318. Tracing is disabled if empty.")
 	tlsConfigCustomizerSrc = tls.AddTLSFlagsToCmd(command)
 	cacheSrc = servercache.AddCacheFlagsToCmd(command)
 	return command
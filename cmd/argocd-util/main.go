@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,6 +16,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +29,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/errors"
 	"github.com/argoproj/argo-cd/util/cli"
+	"github.com/argoproj/argo-cd/util/crypto"
 	"github.com/argoproj/argo-cd/util/db"
 	"github.com/argoproj/argo-cd/util/dex"
 	"github.com/argoproj/argo-cd/util/kube"
@@ -43,6 +46,8 @@ const (
 	cliName = "argocd-util"
 	// YamlSeparator separates sections of a YAML file
 	yamlSeparator = "---\n"
+	// annotationKeyEncrypted marks the exported secrets whose data is encrypted
+	annotationKeyEncrypted = "argocd.argoproj.io/export-encrypted"
 )
 
 var (
@@ -73,6 +78,7 @@ func NewCommand() *cobra.Command {
 	command.AddCommand(NewExportCommand())
 	command.AddCommand(NewClusterConfig())
 	command.AddCommand(NewProjectsCommand())
+	command.AddCommand(NewSettingsCommand())
 
 	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
 	return command
@@ -206,9 +212,10 @@ func NewGenDexConfigCommand() *cobra.Command {
 // NewImportCommand defines a new command for exporting Kubernetes and Argo CD resources.
 func NewImportCommand() *cobra.Command {
 	var (
-		clientConfig clientcmd.ClientConfig
-		prune        bool
-		dryRun       bool
+		clientConfig      clientcmd.ClientConfig
+		prune             bool
+		dryRun            bool
+		encryptionKeyFile string
 	)
 	var command = cobra.Command{
 		Use:   "import SOURCE",
@@ -278,7 +285,14 @@ func NewImportCommand() *cobra.Command {
 			// Create or replace existing object
 			backupObjects, err := kube.SplitYAML(string(input))
 			errors.CheckError(err)
+			encryptionKey := readEncryptionKey(encryptionKeyFile)
 			for _, bakObj := range backupObjects {
+				if bakObj.GetKind() == "Secret" && bakObj.GetAnnotations()[annotationKeyEncrypted] == "true" {
+					if encryptionKey == nil {
+						log.Fatalf("Secret %s is encrypted, please specify --encryption-key-file", bakObj.GetName())
+					}
+					errors.CheckError(decryptSecret(bakObj, encryptionKey))
+				}
 				gvk := bakObj.GroupVersionKind()
 				key := kube.ResourceKey{Group: gvk.Group, Kind: gvk.Kind, Name: bakObj.GetName()}
 				liveObj, exists := pruneObjects[key]
@@ -319,6 +333,8 @@ func NewImportCommand() *cobra.Command {
 					switch key.Kind {
 					case "Secret":
 						dynClient = acdClients.secrets
+					case "ConfigMap":
+						dynClient = acdClients.configMaps
 					case "AppProject":
 						dynClient = acdClients.projects
 					case "Application":
@@ -340,7 +356,8 @@ func NewImportCommand() *cobra.Command {
 
 	clientConfig = cli.AddKubectlFlagsToCmd(&command)
 	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print what will be performed")
-	command.Flags().BoolVar(&prune, "prune", false, "Prune secrets, applications and projects which do not appear in the backup")
+	command.Flags().BoolVar(&prune, "prune", false, "Prune config maps, secrets, applications and projects which do not appear in the backup")
+	command.Flags().StringVar(&encryptionKeyFile, "encryption-key-file", "", "File with the key the secrets of the backup were encrypted with")
 
 	return &command
 }
@@ -366,8 +383,9 @@ func newArgoCDClientsets(config *rest.Config, namespace string) *argoCDClientset
 // NewExportCommand defines a new command for exporting Kubernetes and Argo CD resources.
 func NewExportCommand() *cobra.Command {
 	var (
-		clientConfig clientcmd.ClientConfig
-		out          string
+		clientConfig      clientcmd.ClientConfig
+		out               string
+		encryptionKeyFile string
 	)
 	var command = cobra.Command{
 		Use:   "export",
@@ -394,25 +412,28 @@ func NewExportCommand() *cobra.Command {
 				}()
 			}
 
+			encryptionKey := readEncryptionKey(encryptionKeyFile)
 			acdClients := newArgoCDClientsets(config, namespace)
 			acdConfigMap, err := acdClients.configMaps.Get(common.ArgoCDConfigMapName, metav1.GetOptions{})
 			errors.CheckError(err)
 			export(writer, *acdConfigMap)
-			acdRBACConfigMap, err := acdClients.configMaps.Get(common.ArgoCDRBACConfigMapName, metav1.GetOptions{})
-			errors.CheckError(err)
-			export(writer, *acdRBACConfigMap)
-			acdKnownHostsConfigMap, err := acdClients.configMaps.Get(common.ArgoCDKnownHostsConfigMapName, metav1.GetOptions{})
-			errors.CheckError(err)
-			export(writer, *acdKnownHostsConfigMap)
-			acdTLSCertsConfigMap, err := acdClients.configMaps.Get(common.ArgoCDTLSCertsConfigMapName, metav1.GetOptions{})
-			errors.CheckError(err)
-			export(writer, *acdTLSCertsConfigMap)
+			for _, name := range []string{common.ArgoCDRBACConfigMapName, common.ArgoCDKnownHostsConfigMapName, common.ArgoCDTLSCertsConfigMapName} {
+				cm, err := acdClients.configMaps.Get(name, metav1.GetOptions{})
+				if apierr.IsNotFound(err) {
+					continue
+				}
+				errors.CheckError(err)
+				export(writer, *cm)
+			}
 
 			referencedSecrets := getReferencedSecrets(*acdConfigMap)
 			secrets, err := acdClients.secrets.List(metav1.ListOptions{})
 			errors.CheckError(err)
 			for _, secret := range secrets.Items {
 				if isArgoCDSecret(referencedSecrets, secret) {
+					if encryptionKey != nil {
+						errors.CheckError(encryptSecret(&secret, encryptionKey))
+					}
 					export(writer, secret)
 				}
 			}
@@ -431,10 +452,83 @@ func NewExportCommand() *cobra.Command {
 
 	clientConfig = cli.AddKubectlFlagsToCmd(&command)
 	command.Flags().StringVarP(&out, "out", "o", "-", "Output to the specified file instead of stdout")
+	command.Flags().StringVar(&encryptionKeyFile, "encryption-key-file", "", "Encrypt the data of the exported secrets with the key in the file")
 
 	return &command
 }
 
+// readEncryptionKey returns the key derived from the content of the key file, or nil if no file is given
+func readEncryptionKey(path string) []byte {
+	if path == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	errors.CheckError(err)
+	if len(data) == 0 {
+		log.Fatalf("Encryption key file %s is empty", path)
+	}
+	return crypto.KeyFromPassphrase(data)
+}
+
+// encryptSecret encrypts the values of the data of the secret and marks the secret as encrypted
+func encryptSecret(un *unstructured.Unstructured, key []byte) error {
+	err := transformSecretData(un, func(value []byte) ([]byte, error) {
+		return crypto.Encrypt(value, key)
+	})
+	if err != nil {
+		return err
+	}
+	annotations := un.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[annotationKeyEncrypted] = "true"
+	un.SetAnnotations(annotations)
+	return nil
+}
+
+// decryptSecret decrypts the values of the data of a secret encrypted by encryptSecret
+func decryptSecret(un *unstructured.Unstructured, key []byte) error {
+	err := transformSecretData(un, func(value []byte) ([]byte, error) {
+		return crypto.Decrypt(value, key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret %s: %v", un.GetName(), err)
+	}
+	annotations := un.GetAnnotations()
+	delete(annotations, annotationKeyEncrypted)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	un.SetAnnotations(annotations)
+	return nil
+}
+
+// transformSecretData replaces the values of the data of the secret with their transformed values
+func transformSecretData(un *unstructured.Unstructured, transform func(value []byte) ([]byte, error)) error {
+	data, _, err := unstructured.NestedStringMap(un.Object, "data")
+	if err != nil {
+		return err
+	}
+	for k, v := range data {
+		value, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return err
+		}
+		value, err = transform(value)
+		if err != nil {
+			return err
+		}
+		data[k] = base64.StdEncoding.EncodeToString(value)
+	}
+	if len(data) > 0 {
+		if err := unstructured.SetNestedStringMap(un.Object, data, "data"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getReferencedSecrets examines the argocd-cm config for any referenced repo secrets and returns a
 // map of all referenced secrets.
 func getReferencedSecrets(un unstructured.Unstructured) map[string]bool {
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/util/crypto"
 )
 
 var textToRedact = `
@@ -88,3 +92,22 @@ web:
 func TestSecretsRedactor(t *testing.T) {
 	assert.Equal(t, expectedRedaction, redactor(textToRedact))
 }
+
+func TestEncryptDecryptSecret(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "argocd-secret"},
+		"data":       map[string]interface{}{"server.secretkey": base64.StdEncoding.EncodeToString([]byte("abc"))},
+	}}
+	orig := secret.DeepCopy()
+	key := crypto.KeyFromPassphrase([]byte("key"))
+
+	assert.NoError(t, encryptSecret(secret, key))
+	assert.Equal(t, "true", secret.GetAnnotations()[annotationKeyEncrypted])
+	assert.NotEqual(t, orig.Object["data"], secret.Object["data"])
+
+	assert.Error(t, decryptSecret(secret.DeepCopy(), crypto.KeyFromPassphrase([]byte("other"))))
+	assert.NoError(t, decryptSecret(secret, key))
+	assert.Equal(t, orig, secret)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/errors"
+	"github.com/argoproj/argo-cd/server/badge"
+	"github.com/argoproj/argo-cd/util/cli"
+	"github.com/argoproj/argo-cd/util/settings"
+	"github.com/argoproj/argo-cd/util/settings/validation"
+)
+
+type settingsOpts struct {
+	argocdCMPath        string
+	argocdRBACCMPath    string
+	argocdSecretPath    string
+	loadClusterSettings bool
+	clientConfig        clientcmd.ClientConfig
+}
+
+// NewSettingsCommand returns a new instance of an `argocd-util settings` command
+func NewSettingsCommand() *cobra.Command {
+	var opts settingsOpts
+	var command = &cobra.Command{
+		Use:   "settings",
+		Short: "Provides set of commands for settings validation and troubleshooting",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+		},
+	}
+	opts.clientConfig = cli.AddKubectlFlagsToCmd(command)
+	command.PersistentFlags().StringVar(&opts.argocdCMPath, "argocd-cm-path", "", "Path to local argocd-cm.yaml file")
+	command.PersistentFlags().StringVar(&opts.argocdRBACCMPath, "argocd-rbac-cm-path", "", "Path to local argocd-rbac-cm.yaml file")
+	command.PersistentFlags().StringVar(&opts.argocdSecretPath, "argocd-secret-path", "", "Path to local argocd-secret.yaml file")
+	command.PersistentFlags().BoolVar(&opts.loadClusterSettings, "load-cluster-settings", false, "Load the settings of the cluster, which are overridden by the settings of the local files")
+	command.AddCommand(NewValidateSettingsCommand(&opts))
+	command.AddCommand(NewBadgeTokenCommand(&opts))
+	return command
+}
+
+// NewValidateSettingsCommand returns a new instance of an `argocd-util settings validate` command
+func NewValidateSettingsCommand(opts *settingsOpts) *cobra.Command {
+	groupNames := validation.Groups()
+
+	var command = &cobra.Command{
+		Use:   "validate [GROUP...]",
+		Short: "Validate the settings of the argocd-cm, argocd-rbac-cm and argocd-secret",
+		Long:  fmt.Sprintf("Validate the settings of the argocd-cm, argocd-rbac-cm and argocd-secret. All groups are validated unless some are given. Groups: %s", strings.Join(groupNames, ", ")),
+		Example: `  # Validate the settings of the cluster
+  argocd-util settings validate --load-cluster-settings
+
+  # Validate the resource overrides of a local argocd-cm, before deploying it
+  argocd-util settings validate resource-overrides --argocd-cm-path ./argocd-cm.yaml`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) == 0 {
+				args = groupNames
+			}
+			for _, name := range args {
+				if _, ok := validation.Validators[name]; !ok {
+					errors.CheckError(fmt.Errorf("unknown settings group '%s', must be one of: %s", name, strings.Join(groupNames, ", ")))
+				}
+			}
+			mgr, err := opts.newSettingsManager(context.Background())
+			errors.CheckError(err)
+
+			valid := true
+			for _, name := range args {
+				summary, err := validation.Validators[name](mgr)
+				if err != nil {
+					valid = false
+					fmt.Printf("❌ %s\n%s\n\n", name, err.Error())
+				} else {
+					fmt.Printf("✅ %s\n%s\n\n", name, summary)
+				}
+			}
+			if !valid {
+				os.Exit(1)
+			}
+		},
+	}
+	return command
+}
+
+// NewBadgeTokenCommand returns a new instance of an `argocd-util settings badge-token` command
+func NewBadgeTokenCommand(opts *settingsOpts) *cobra.Command {
+	var (
+		appName  string
+		projects []string
+	)
+	var command = &cobra.Command{
+		Use:   "badge-token",
+		Short: "Generate the token of the status badge of an application or of projects",
+		Long:  "Generate the token of the status badge of an application or of projects, which is required by the badge endpoint if statusbadge.requireToken is enabled. The token is signed by the server secret key of the argocd-secret, so it is invalidated when that key changes.",
+		Example: `  # Generate the token of the badge of an application
+  argocd-util settings badge-token --load-cluster-settings --app guestbook
+
+  # Generate the token of the aggregate badge of the applications of two projects
+  argocd-util settings badge-token --load-cluster-settings --project default --project infra`,
+		Run: func(c *cobra.Command, args []string) {
+			if (appName == "") == (len(projects) == 0) {
+				errors.CheckError(fmt.Errorf("either --app or --project is required"))
+			}
+			if !opts.loadClusterSettings && opts.argocdSecretPath == "" {
+				errors.CheckError(fmt.Errorf("the server secret key is required, use --load-cluster-settings or --argocd-secret-path"))
+			}
+			mgr, err := opts.newSettingsManager(context.Background())
+			errors.CheckError(err)
+			argoCDSettings, err := mgr.GetSettings()
+			errors.CheckError(err)
+
+			params := url.Values{}
+			var token string
+			if appName != "" {
+				token = badge.ApplicationToken(argoCDSettings.ServerSignature, appName)
+				params.Set("name", appName)
+			} else {
+				token = badge.ProjectToken(argoCDSettings.ServerSignature, projects...)
+				params["project"] = projects
+			}
+			params.Set("token", token)
+			fmt.Println(token)
+			fmt.Printf("%s/api/badge?%s\n", strings.TrimRight(argoCDSettings.URL, "/"), params.Encode())
+		},
+	}
+	command.Flags().StringVar(&appName, "app", "", "Name of the application of the badge")
+	command.Flags().StringArrayVar(&projects, "project", []string{}, "Projects of the aggregate badge, in the order of the project parameters of the badge URL")
+	return command
+}
+
+// newSettingsManager returns a settings manager over the settings of the cluster or the local files
+func (opts *settingsOpts) newSettingsManager(ctx context.Context) (*settings.SettingsManager, error) {
+	namespace, _, err := opts.clientConfig.Namespace()
+	if err != nil {
+		return nil, err
+	}
+	argocdCM := &corev1.ConfigMap{}
+	argocdRBACCM := &corev1.ConfigMap{}
+	argocdSecret := &corev1.Secret{Data: map[string][]byte{
+		// the secret key is only required so that the settings can be loaded without a secret
+		"server.secretkey": []byte("validation"),
+	}}
+	if opts.loadClusterSettings {
+		config, err := opts.clientConfig.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		kubeClient := kubernetes.NewForConfigOrDie(config)
+		if argocdCM, err = kubeClient.CoreV1().ConfigMaps(namespace).Get(common.ArgoCDConfigMapName, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+		if cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(common.ArgoCDRBACConfigMapName, metav1.GetOptions{}); err == nil {
+			argocdRBACCM = cm
+		} else if !apierr.IsNotFound(err) {
+			return nil, err
+		}
+		if argocdSecret, err = kubeClient.CoreV1().Secrets(namespace).Get(common.ArgoCDSecretName, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	for path, obj := range map[string]interface{}{opts.argocdCMPath: argocdCM, opts.argocdRBACCMPath: argocdRBACCM, opts.argocdSecretPath: argocdSecret} {
+		if path == "" {
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = yaml.Unmarshal(data, obj); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %v", path, err)
+		}
+	}
+	return newSettingsManagerFromObjects(ctx, namespace, argocdCM, argocdRBACCM, argocdSecret), nil
+}
+
+func newSettingsManagerFromObjects(ctx context.Context, namespace string, argocdCM *corev1.ConfigMap, argocdRBACCM *corev1.ConfigMap, argocdSecret *corev1.Secret) *settings.SettingsManager {
+	setObjectMeta := func(meta *metav1.ObjectMeta, name string) {
+		meta.Name = name
+		meta.Namespace = namespace
+		if meta.Labels == nil {
+			meta.Labels = map[string]string{}
+		}
+		// the settings manager only watches the resources labeled as part of Argo CD
+		meta.Labels["app.kubernetes.io/part-of"] = "argocd"
+		meta.ResourceVersion = ""
+	}
+	setObjectMeta(&argocdCM.ObjectMeta, common.ArgoCDConfigMapName)
+	setObjectMeta(&argocdRBACCM.ObjectMeta, common.ArgoCDRBACConfigMapName)
+	setObjectMeta(&argocdSecret.ObjectMeta, common.ArgoCDSecretName)
+	// string data of the local files takes precedence, like when it is applied
+	for k, v := range argocdSecret.StringData {
+		if argocdSecret.Data == nil {
+			argocdSecret.Data = map[string][]byte{}
+		}
+		argocdSecret.Data[k] = []byte(v)
+	}
+	clientset := fake.NewSimpleClientset([]runtime.Object{argocdCM, argocdRBACCM, argocdSecret}...)
+	return settings.NewSettingsManager(ctx, clientset, namespace)
+}
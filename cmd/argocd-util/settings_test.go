@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-cd/util/settings"
+	"github.com/argoproj/argo-cd/util/settings/validation"
+)
+
+func newTestSettingsManager(cmData map[string]string, rbacCMData map[string]string) *settings.SettingsManager {
+	return newSettingsManagerFromObjects(context.Background(), "argocd",
+		&corev1.ConfigMap{Data: cmData},
+		&corev1.ConfigMap{Data: rbacCMData},
+		&corev1.Secret{Data: map[string][]byte{"server.secretkey": []byte("test")}})
+}
+
+func TestNewSettingsManagerFromObjects(t *testing.T) {
+	summary, err := validation.Validators["general"](newTestSettingsManager(map[string]string{"url": "https://argocd.example.com"}, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Argo CD URL: https://argocd.example.com", summary)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/cli"
 	"github.com/argoproj/argo-cd/util/localconfig"
+	"github.com/argoproj/argo-cd/util/password"
+	"github.com/argoproj/argo-cd/util/settings"
 )
 
 func NewAccountCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
@@ -42,6 +44,14 @@ func NewAccountCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	command.AddCommand(NewAccountGenerateTokenCommand(clientOpts))
 	command.AddCommand(NewAccountGetCommand(clientOpts))
 	command.AddCommand(NewAccountDeleteTokenCommand(clientOpts))
+	command.AddCommand(NewAccountListSessionsCommand(clientOpts))
+	command.AddCommand(NewAccountDeleteSessionCommand(clientOpts))
+	command.AddCommand(NewAccountRevokeSessionsCommand(clientOpts))
+	command.AddCommand(NewAccountCreateCommand(clientOpts))
+	command.AddCommand(NewAccountEnableCommand(clientOpts, true))
+	command.AddCommand(NewAccountEnableCommand(clientOpts, false))
+	command.AddCommand(NewAccountDeleteCommand(clientOpts))
+	command.AddCommand(NewAccountBcryptCommand())
 	return command
 }
 
@@ -60,8 +70,15 @@ func NewAccountUpdatePasswordCommand(clientOpts *argocdclient.ClientOptions) *co
 				os.Exit(1)
 			}
 
+			acdClient := argocdclient.NewClientOrDie(clientOpts)
+			currentAccount := getCurrentAccount(acdClient)
 			if currentPassword == "" {
-				fmt.Print("*** Enter current password: ")
+				if account == "" || account == currentAccount {
+					fmt.Print("*** Enter current password: ")
+				} else {
+					// changing the password of another account requires the password of the logged in account
+					fmt.Printf("*** Enter password of currently logged in user (%s): ", currentAccount)
+				}
 				password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
 				errors.CheckError(err)
 				currentPassword = string(password)
@@ -79,7 +96,6 @@ func NewAccountUpdatePasswordCommand(clientOpts *argocdclient.ClientOptions) *co
 				Name:            account,
 			}
 
-			acdClient := argocdclient.NewClientOrDie(clientOpts)
 			conn, usrIf := acdClient.NewAccountClientOrDie()
 			defer util.Close(conn)
 
@@ -88,7 +104,7 @@ func NewAccountUpdatePasswordCommand(clientOpts *argocdclient.ClientOptions) *co
 			errors.CheckError(err)
 			fmt.Printf("Password updated\n")
 
-			if account == "" || account == getCurrentAccount(acdClient) {
+			if account == "" || account == currentAccount {
 				// Get a new JWT token after updating the password
 				localCfg, err := localconfig.ReadLocalConfig(clientOpts.ConfigPath)
 				errors.CheckError(err)
@@ -108,12 +124,44 @@ func NewAccountUpdatePasswordCommand(clientOpts *argocdclient.ClientOptions) *co
 		},
 	}
 
-	command.Flags().StringVar(&currentPassword, "current-password", "", "current password you wish to change")
+	command.Flags().StringVar(&currentPassword, "current-password", "", "current password you wish to change, or the password of the logged in user when updating another account")
 	command.Flags().StringVar(&newPassword, "new-password", "", "new password you want to update to")
 	command.Flags().StringVar(&account, "account", "", "an account name that should be updated. Defaults to current user account")
 	return command
 }
 
+// NewAccountBcryptCommand returns a new instance of an `argocd account bcrypt` command
+func NewAccountBcryptCommand() *cobra.Command {
+	var plaintext string
+	var command = &cobra.Command{
+		Use:   "bcrypt",
+		Short: "Generate bcrypt hash for a password",
+		Long:  "Generate bcrypt hash for a password, e.g. for the admin.password key in the argocd-secret secret or the password of a local account",
+		Example: `  # Generate the bcrypt hash of a password entered on the terminal
+  argocd account bcrypt
+
+  # Reset the admin password by patching the argocd-secret secret
+  kubectl -n argocd patch secret argocd-secret \
+    -p '{"stringData": {"admin.password": "'$(argocd account bcrypt --password mypassword)'", "admin.passwordMtime": "'$(date +%FT%T%Z)'"}}'`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if plaintext == "" {
+				var err error
+				plaintext, err = cli.ReadAndConfirmPassword()
+				errors.CheckError(err)
+			}
+			hash, err := password.HashPassword(plaintext)
+			errors.CheckError(err)
+			fmt.Println(hash)
+		},
+	}
+	command.Flags().StringVar(&plaintext, "password", "", "Password for which bcrypt hash is generated. Read from the terminal if not given")
+	return command
+}
+
 func NewAccountGetUserInfoCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
 		output string
@@ -298,6 +346,131 @@ argocd account get --account <account-name>`,
 	return cmd
 }
 
+func NewAccountListSessionsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output  string
+		account string
+	)
+	cmd := &cobra.Command{
+		Use:   "list-sessions",
+		Short: "Lists the active login sessions of an account",
+		Example: `# List the sessions of the currently logged in account
+argocd account list-sessions
+
+# List the sessions of the account with the specified name
+argocd account list-sessions --account <account-name>`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+
+			clientset := argocdclient.NewClientOrDie(clientOpts)
+			conn, client := clientset.NewAccountClientOrDie()
+			defer util.Close(conn)
+			if account == "" {
+				account = getCurrentAccount(clientset)
+			}
+			response, err := client.ListSessions(context.Background(), &accountpkg.ListSessionsRequest{Name: account})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResourceList(response.Items, output, false)
+				errors.CheckError(err)
+			case "wide", "":
+				printSessionsTable(response.Items)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	cmd.Flags().StringVarP(&account, "account", "a", "", "Account name. Defaults to the current account.")
+	return cmd
+}
+
+func printSessionsTable(items []*accountpkg.Session) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tISSUED AT\tEXPIRING AT\tLAST USED\n")
+	for _, s := range items {
+		expiresAtFormatted := "never"
+		if s.ExpiresAt > 0 {
+			expiresAtFormatted = time.Unix(s.ExpiresAt, 0).Format(time.RFC3339)
+		}
+		lastUsedFormatted := "never"
+		if s.LastUsedAt > 0 {
+			lastUsedFormatted = time.Unix(s.LastUsedAt, 0).Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Id, time.Unix(s.IssuedAt, 0).Format(time.RFC3339), expiresAtFormatted, lastUsedFormatted)
+	}
+	_ = w.Flush()
+}
+
+func NewAccountDeleteSessionCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		account string
+	)
+	cmd := &cobra.Command{
+		Use:   "delete-session ID",
+		Short: "Deletes a login session of an account",
+		Example: `# Delete a session of the currently logged in account
+argocd account delete-session ID
+
+# Delete a session of the account with the specified name
+argocd account delete-session ID --account <account-name>`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			id := args[0]
+
+			clientset := argocdclient.NewClientOrDie(clientOpts)
+			conn, client := clientset.NewAccountClientOrDie()
+			defer util.Close(conn)
+			if account == "" {
+				account = getCurrentAccount(clientset)
+			}
+			_, err := client.DeleteSession(context.Background(), &accountpkg.DeleteSessionRequest{Name: account, Id: id})
+			errors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringVarP(&account, "account", "a", "", "Account name. Defaults to the current account.")
+	return cmd
+}
+
+func NewAccountRevokeSessionsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		account string
+	)
+	cmd := &cobra.Command{
+		Use:   "revoke-sessions",
+		Short: "Revokes all sessions and tokens of an account",
+		Example: `# Revoke all sessions of the currently logged in account
+argocd account revoke-sessions
+
+# Revoke all sessions of the account with the specified name
+argocd account revoke-sessions --account <account-name>`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+
+			clientset := argocdclient.NewClientOrDie(clientOpts)
+			conn, client := clientset.NewAccountClientOrDie()
+			defer util.Close(conn)
+			if account == "" {
+				account = getCurrentAccount(clientset)
+			}
+			_, err := client.RevokeSessions(context.Background(), &accountpkg.RevokeSessionsRequest{Name: account})
+			errors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringVarP(&account, "account", "a", "", "Account name. Defaults to the current account.")
+	return cmd
+}
+
 func printAccountDetails(acc *accountpkg.Account) {
 	fmt.Printf(printOpFmtStr, "Name:", acc.Name)
 	fmt.Printf(printOpFmtStr, "Enabled:", strconv.FormatBool(acc.Enabled))
@@ -307,7 +480,7 @@ func printAccountDetails(acc *accountpkg.Account) {
 		fmt.Println("NONE")
 	} else {
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "ID\tISSUED AT\tEXPIRING AT\n")
+		fmt.Fprintf(w, "ID\tNAME\tISSUED AT\tEXPIRING AT\tLAST USED\n")
 		for _, t := range acc.Tokens {
 			expiresAtFormatted := "never"
 			if t.ExpiresAt > 0 {
@@ -318,7 +491,12 @@ func printAccountDetails(acc *accountpkg.Account) {
 				}
 			}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\n", t.Id, time.Unix(t.IssuedAt, 0).Format(time.RFC3339), expiresAtFormatted)
+			lastUsedFormatted := "never"
+			if t.LastUsedAt > 0 {
+				lastUsedFormatted = time.Unix(t.LastUsedAt, 0).Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Id, t.Name, time.Unix(t.IssuedAt, 0).Format(time.RFC3339), expiresAtFormatted, lastUsedFormatted)
 		}
 		_ = w.Flush()
 	}
@@ -328,6 +506,7 @@ func NewAccountGenerateTokenCommand(clientOpts *argocdclient.ClientOptions) *cob
 	var (
 		account   string
 		expiresIn string
+		name      string
 	)
 	cmd := &cobra.Command{
 		Use:   "generate-token",
@@ -336,7 +515,10 @@ func NewAccountGenerateTokenCommand(clientOpts *argocdclient.ClientOptions) *cob
 argocd account generate-token
 
 # Generate token for the account with the specified name
-argocd account generate-token --account <account-name>`,
+argocd account generate-token --account <account-name>
+
+# Generate a named token which expires in 30 days
+argocd account generate-token --account <account-name> --name ci --expires-in 30d`,
 		Run: func(c *cobra.Command, args []string) {
 
 			clientset := argocdclient.NewClientOrDie(clientOpts)
@@ -350,6 +532,7 @@ argocd account generate-token --account <account-name>`,
 			response, err := client.CreateToken(context.Background(), &accountpkg.CreateTokenRequest{
 				Name:      account,
 				ExpiresIn: int64(expiresIn.Seconds()),
+				TokenName: name,
 			})
 			errors.CheckError(err)
 			fmt.Println(response.Token)
@@ -357,6 +540,7 @@ argocd account generate-token --account <account-name>`,
 	}
 	cmd.Flags().StringVarP(&account, "account", "a", "", "Account name. Defaults to the current account.")
 	cmd.Flags().StringVarP(&expiresIn, "expires-in", "e", "0s", "Duration before the token will expire. (Default: No expiration)")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the token. Must be unique within the account.")
 	return cmd
 }
 
@@ -365,11 +549,14 @@ func NewAccountDeleteTokenCommand(clientOpts *argocdclient.ClientOptions) *cobra
 		account string
 	)
 	cmd := &cobra.Command{
-		Use:   "delete-token",
+		Use:   "delete-token ID|NAME",
 		Short: "Deletes account token",
 		Example: `# Delete token of the currently logged in account
 argocd account delete-token ID
 
+# Delete token by name
+argocd account delete-token ci
+
 # Delete token of the account with the specified name
 argocd account generate-token --account <account-name>`,
 		Run: func(c *cobra.Command, args []string) {
@@ -392,3 +579,87 @@ argocd account generate-token --account <account-name>`,
 	cmd.Flags().StringVarP(&account, "account", "a", "", "Account name. Defaults to the current account.")
 	return cmd
 }
+
+func NewAccountCreateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		capabilities []string
+		disabled     bool
+		password     string
+	)
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a local account",
+		Example: `# Create an account which can only generate API tokens
+argocd account create ci-bot --capabilities apiKey
+
+# Create an account which can log in with a password
+argocd account create alice --capabilities login --password <password>`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+
+			conn, client := argocdclient.NewClientOrDie(clientOpts).NewAccountClientOrDie()
+			defer util.Close(conn)
+			acc, err := client.CreateAccount(context.Background(), &accountpkg.CreateAccountRequest{
+				Name:         args[0],
+				Capabilities: capabilities,
+				Disabled:     disabled,
+				Password:     password,
+			})
+			errors.CheckError(err)
+			fmt.Printf("account '%s' created\n", acc.Name)
+		},
+	}
+	cmd.Flags().StringSliceVar(&capabilities, "capabilities", []string{string(settings.AccountCapabilityLogin)}, "Account capabilities. Any of: login,apiKey")
+	cmd.Flags().BoolVar(&disabled, "disabled", false, "Create the account disabled")
+	cmd.Flags().StringVar(&password, "password", "", "Password of the account. Accounts without password cannot log in.")
+	return cmd
+}
+
+func NewAccountEnableCommand(clientOpts *argocdclient.ClientOptions, enabled bool) *cobra.Command {
+	use, short := "enable", "Enable a local account"
+	if !enabled {
+		use, short = "disable", "Disable a local account. Tokens and sessions of disabled accounts are rejected."
+	}
+	return &cobra.Command{
+		Use:   fmt.Sprintf("%s NAME", use),
+		Short: short,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+
+			conn, client := argocdclient.NewClientOrDie(clientOpts).NewAccountClientOrDie()
+			defer util.Close(conn)
+			ctx := context.Background()
+			acc, err := client.GetAccount(ctx, &accountpkg.GetAccountRequest{Name: args[0]})
+			errors.CheckError(err)
+			_, err = client.UpdateAccount(ctx, &accountpkg.UpdateAccountRequest{Name: acc.Name, Enabled: enabled, Capabilities: acc.Capabilities})
+			errors.CheckError(err)
+			fmt.Printf("account '%s' %sd\n", acc.Name, use)
+		},
+	}
+}
+
+func NewAccountDeleteCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete NAME",
+		Short:   "Delete a local account and all of its tokens",
+		Example: "argocd account delete ci-bot",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+
+			conn, client := argocdclient.NewClientOrDie(clientOpts).NewAccountClientOrDie()
+			defer util.Close(conn)
+			_, err := client.DeleteAccount(context.Background(), &accountpkg.DeleteAccountRequest{Name: args[0]})
+			errors.CheckError(err)
+			fmt.Printf("account '%s' deleted\n", args[0])
+		},
+	}
+}
@@ -0,0 +1,302 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/cmd/argocd/commands/headless"
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	settingspkg "github.com/argoproj/argo-cd/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util"
+	logutils "github.com/argoproj/argo-cd/util/log"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// NewAdminCommand returns a new instance of an `argocd admin` command
+func NewAdminCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "admin",
+		Short: "Perform administrative tasks",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAdminSettingsCommand(clientOpts))
+	command.AddCommand(NewAdminDashboardCommand(clientOpts))
+	command.AddCommand(NewAdminLogLevelCommand(clientOpts))
+	command.AddCommand(NewAdminComponentStatusCommand(clientOpts))
+	command.AddCommand(NewAdminSupportBundleCommand(clientOpts))
+	command.AddCommand(NewAdminControllerCommand(clientOpts))
+	return command
+}
+
+// NewAdminDashboardCommand returns a new instance of an `argocd admin dashboard` command
+func NewAdminDashboardCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		port            int
+		address         string
+		staticAssetsDir string
+	)
+	var command = &cobra.Command{
+		Use:   "dashboard",
+		Short: "Run the API server and web UI locally, against the Argo CD installation of the current kubeconfig context",
+		Long: `Run the API server and web UI locally, against the Argo CD installation of the current kubeconfig context.
+
+The API server talks directly to the Kubernetes API, and reaches the repo server and Redis of the installation using
+port forwarding, so it also works for headless installations without an argocd-server. Authentication is disabled, so
+access is controlled by the Kubernetes RBAC of the kubeconfig user.`,
+		Example: `  # Serve the UI built in ui/dist/app on http://localhost:8080
+  argocd admin dashboard --staticassets ui/dist/app`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if staticAssetsDir == "" {
+				errors.CheckError(fmt.Errorf("--staticassets is required, since the web UI is not bundled with the CLI"))
+			}
+			serverAddr, err := headless.StartLocalServer(context.Background(), clientOpts, address, port, staticAssetsDir)
+			errors.CheckError(err)
+			fmt.Printf("Argo CD UI is available at http://%s\n", serverAddr)
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+			<-sigCh
+		},
+	}
+	command.Flags().IntVar(&port, "port", common.DefaultPortAPIServer, "Listen on given port")
+	command.Flags().StringVar(&address, "address", "localhost", "Listen on given address")
+	command.Flags().StringVar(&staticAssetsDir, "staticassets", "", "Directory of the static assets of the built web UI")
+	return command
+}
+
+// NewAdminSettingsCommand returns a new instance of an `argocd admin settings` command
+func NewAdminSettingsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "settings",
+		Short: "Check Argo CD settings",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAdminSettingsRBACCommand(clientOpts))
+	return command
+}
+
+// NewAdminSettingsRBACCommand returns a new instance of an `argocd admin settings rbac` command
+func NewAdminSettingsRBACCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "rbac",
+		Short: "Validate and test RBAC policies",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAdminSettingsRBACValidateCommand(clientOpts))
+	command.AddCommand(NewAdminSettingsRBACCanCommand(clientOpts))
+	return command
+}
+
+// readPolicyFile returns the content of the policy file, or an empty policy if no file is given
+func readPolicyFile(policyFile string) string {
+	if policyFile == "" {
+		return ""
+	}
+	data, err := ioutil.ReadFile(policyFile)
+	errors.CheckError(err)
+	if len(data) == 0 {
+		errors.CheckError(fmt.Errorf("policy file %s is empty", policyFile))
+	}
+	return string(data)
+}
+
+// NewAdminSettingsRBACValidateCommand returns a new instance of an `argocd admin settings rbac validate` command
+func NewAdminSettingsRBACValidateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var policyFile string
+	var command = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate an RBAC policy",
+		Example: `  # Validate the policy configured in argocd-rbac-cm
+  argocd admin settings rbac validate
+
+  # Validate a policy before configuring it
+  argocd admin settings rbac validate --policy-file policy.csv`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			policy := readPolicyFile(policyFile)
+			conn, settingsIf := argocdclient.NewClientOrDie(clientOpts).NewSettingsClientOrDie()
+			defer util.Close(conn)
+
+			res, err := settingsIf.ValidateRBACPolicy(context.Background(), &settingspkg.RBACPolicyValidateRequest{Policy: policy})
+			errors.CheckError(err)
+			if len(res.Errors) == 0 {
+				fmt.Println("Policy is valid")
+				return
+			}
+			for _, policyErr := range res.Errors {
+				if policyErr.Line > 0 {
+					fmt.Printf("line %d: %s\n", policyErr.Line, policyErr.Message)
+				} else {
+					fmt.Println(policyErr.Message)
+				}
+			}
+			os.Exit(1)
+		},
+	}
+	command.Flags().StringVar(&policyFile, "policy-file", "", "Path to a CSV policy to validate instead of the configured policy")
+	return command
+}
+
+// NewAdminSettingsRBACCanCommand returns a new instance of an `argocd admin settings rbac can` command
+func NewAdminSettingsRBACCanCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		policyFile  string
+		defaultRole string
+	)
+	var command = &cobra.Command{
+		Use:   "can SUBJECT ACTION RESOURCE [OBJECT]",
+		Short: "Check whether an RBAC policy permits a subject to perform an action",
+		Example: fmt.Sprintf(`  # Can the group my-org:team1 sync the applications of project-a?
+  argocd admin settings rbac can my-org:team1 sync applications 'project-a/*'
+
+  # Can role:deployer update a project, according to a policy which is not configured yet?
+  argocd admin settings rbac can role:deployer update projects default --policy-file policy.csv
+
+Actions: %v
+Resources: %v
+`, rbacpolicy.Actions, rbacpolicy.Resources),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 3 && len(args) != 4 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			object := ""
+			if len(args) == 4 {
+				object = args[3]
+			}
+			policy := readPolicyFile(policyFile)
+			if policy == "" && defaultRole != "" {
+				errors.CheckError(fmt.Errorf("--default-role requires --policy-file"))
+			}
+			conn, settingsIf := argocdclient.NewClientOrDie(clientOpts).NewSettingsClientOrDie()
+			defer util.Close(conn)
+
+			res, err := settingsIf.CanRBACPolicy(context.Background(), &settingspkg.RBACPolicyCanRequest{
+				Policy:      policy,
+				DefaultRole: defaultRole,
+				Subject:     args[0],
+				Action:      args[1],
+				Resource:    args[2],
+				Object:      object,
+			})
+			errors.CheckError(err)
+			if res.Allowed {
+				fmt.Println("yes")
+			} else {
+				fmt.Println("no")
+			}
+		},
+	}
+	command.Flags().StringVar(&policyFile, "policy-file", "", "Path to a CSV policy to check instead of the configured policy")
+	command.Flags().StringVar(&defaultRole, "default-role", "", "Default role of the policy file")
+	return command
+}
+
+// NewAdminLogLevelCommand returns a new instance of an `argocd admin loglevel` command
+func NewAdminLogLevelCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "loglevel",
+		Short: "Get and set the log levels of the components at runtime",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAdminLogLevelGetCommand(clientOpts))
+	command.AddCommand(NewAdminLogLevelSetCommand(clientOpts))
+	return command
+}
+
+func printLogLevels(logLevels *settingspkg.LogLevels) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COMPONENT\tLEVELS\n")
+	for _, component := range settings.LogLevelComponents {
+		levels, ok := logLevels.Components[component]
+		if !ok {
+			levels = "(flags)"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", component, levels)
+	}
+	_ = w.Flush()
+}
+
+// NewAdminLogLevelGetCommand returns a new instance of an `argocd admin loglevel get` command
+func NewAdminLogLevelGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "get",
+		Short: "Get the log levels overriding the levels set by the flags of the components",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, settingsIf := argocdclient.NewClientOrDie(clientOpts).NewSettingsClientOrDie()
+			defer util.Close(conn)
+
+			logLevels, err := settingsIf.GetLogLevels(context.Background(), &settingspkg.LogLevelsQuery{})
+			errors.CheckError(err)
+			printLogLevels(logLevels)
+		},
+	}
+	return command
+}
+
+// NewAdminLogLevelSetCommand returns a new instance of an `argocd admin loglevel set` command
+func NewAdminLogLevelSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "set COMPONENT [LEVELS]",
+		Short: "Set the log levels of a component, or restore the levels set by its flags if no levels are given",
+		Example: fmt.Sprintf(`  # Log the git client of the repo server at debug level, and everything else at info level
+  argocd admin loglevel set repo-server info,git=debug
+
+  # Restore the log levels set by the flags of the repo server
+  argocd admin loglevel set repo-server
+
+Components: %v
+Modules: %v
+`, settings.LogLevelComponents, logutils.Modules),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 && len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			levels := ""
+			if len(args) == 2 {
+				levels = args[1]
+			}
+			conn, settingsIf := argocdclient.NewClientOrDie(clientOpts).NewSettingsClientOrDie()
+			defer util.Close(conn)
+
+			logLevels, err := settingsIf.UpdateLogLevels(context.Background(), &settingspkg.LogLevelsUpdateRequest{Component: args[0], Levels: levels})
+			errors.CheckError(err)
+			printLogLevels(logLevels)
+		},
+	}
+	return command
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/controller/metrics"
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+)
+
+const controllerComponent = "argocd-application-controller"
+
+// controllerPodStats is the load of a pod of the application controller
+type controllerPodStats struct {
+	Pod   string                   `json:"pod"`
+	Stats *metrics.ControllerStats `json:"stats,omitempty"`
+	// Error is set if the stats of the pod could not be requested
+	Error string `json:"error,omitempty"`
+}
+
+// NewAdminControllerCommand returns a new instance of an `argocd admin controller` command
+func NewAdminControllerCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "controller",
+		Short: "Inspect the application controller",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAdminControllerStatsCommand(clientOpts))
+	return command
+}
+
+// NewAdminControllerStatsCommand returns a new instance of an `argocd admin controller stats` command
+func NewAdminControllerStatsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var output string
+	var command = &cobra.Command{
+		Use:   "stats",
+		Short: "Print the load of the application controller of the installation of the current kubeconfig context",
+		Long: `Print the load of the application controller of the installation of the current kubeconfig context.
+
+The stats of every pod of the application controller are requested from its metrics port using port forwarding. They
+are the number of applications and pending operations, the depth of the work queues and the time their oldest item has
+been waiting, and the number of watched resources and the duration of the last sync of each cluster cache.`,
+		Example: `  # Print the load of the application controller
+  argocd admin controller stats
+
+  # Print the load of the application controller as JSON
+  argocd admin controller stats -o json`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			stats, err := getControllerStats(clientOpts.PortForwardNamespace)
+			errors.CheckError(err)
+			switch output {
+			case "json":
+				jsonBytes, err := json.MarshalIndent(stats, "", "  ")
+				errors.CheckError(err)
+				fmt.Println(string(jsonBytes))
+			case "wide", "":
+				printControllerStats(stats)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|wide")
+	return command
+}
+
+// getControllerStats requests the stats of all pods of the application controller in a namespace, using port forwarding
+func getControllerStats(namespace string) ([]controllerPodStats, error) {
+	kubeClientset, namespace, err := newInstallationClientset(namespace)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kubeClientset.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=" + controllerComponent})
+	if err != nil {
+		return nil, err
+	}
+	httpClient := newComponentHTTPClient()
+	c := component{name: controllerComponent, metricsPort: common.DefaultPortArgoCDMetrics}
+	var res []controllerPodStats
+	for _, pod := range pods.Items {
+		podStats := controllerPodStats{Pod: pod.Name}
+		port, err := argocdclient.PortForwardPod(c.metricsPort, pod.Name, namespace)
+		if err == nil {
+			var stats metrics.ControllerStats
+			if err = getPodJSON(httpClient, c, port, metrics.StatsPath, &stats); err == nil {
+				podStats.Stats = &stats
+			}
+		}
+		if err != nil {
+			podStats.Error = err.Error()
+		}
+		res = append(res, podStats)
+	}
+	return res, nil
+}
+
+func printControllerStats(stats []controllerPodStats) {
+	for i, podStats := range stats {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Pod:                %s\n", podStats.Pod)
+		if podStats.Error != "" {
+			fmt.Printf("Error:              %s\n", podStats.Error)
+			continue
+		}
+		fmt.Printf("Shard:              %d\n", podStats.Stats.Shard)
+		fmt.Printf("Applications:       %d\n", podStats.Stats.Applications)
+		fmt.Printf("Pending operations: %d\n", podStats.Stats.PendingOperations)
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "QUEUE\tDEPTH\tOLDEST ITEM AGE\n")
+		for _, queue := range podStats.Stats.Queues {
+			fmt.Fprintf(w, "%s\t%d\t%v\n", queue.Name, queue.Depth, time.Duration(queue.OldestItemAgeSeconds*float64(time.Second)).Round(time.Second))
+		}
+		_ = w.Flush()
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "CLUSTER\tVERSION\tAPIS\tRESOURCES\tLAST SYNC\tSYNC DURATION\tWATCH ERRORS\n")
+		for _, cluster := range podStats.Stats.Clusters {
+			lastSync := "Never"
+			if cluster.LastCacheSyncTime != nil {
+				lastSync = cluster.LastCacheSyncTime.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%v\t%d\n", cluster.Server, cluster.K8SVersion, cluster.APIsCount, cluster.ResourcesCount, lastSync, cluster.SyncDuration.Round(time.Millisecond), cluster.WatchErrorsCount)
+		}
+		_ = w.Flush()
+	}
+}
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	"github.com/argoproj/argo-cd/util/healthz"
+)
+
+const componentStatusTimeout = 30 * time.Second
+
+// component is a component of an Argo CD installation which serves full health checks
+type component struct {
+	name string
+	// healthzPort is the port of the pods of the component serving /healthz
+	healthzPort int
+	// tls is true if the health checks might be served with TLS
+	tls bool
+	// metricsPort is the port of the pods of the component serving /metrics
+	metricsPort int
+}
+
+var components = []component{
+	{name: "argocd-server", healthzPort: common.DefaultPortAPIServer, tls: true, metricsPort: common.DefaultPortArgoCDAPIServerMetrics},
+	{name: "argocd-repo-server", healthzPort: common.DefaultPortRepoServerMetrics, metricsPort: common.DefaultPortRepoServerMetrics},
+	{name: "argocd-application-controller", healthzPort: common.DefaultPortArgoCDMetrics, metricsPort: common.DefaultPortArgoCDMetrics},
+}
+
+// podStatus is the health of a pod of a component and of its dependencies
+type podStatus struct {
+	Component string                `json:"component"`
+	Pod       string                `json:"pod"`
+	Healthy   bool                  `json:"healthy"`
+	Checks    []healthz.CheckResult `json:"checks,omitempty"`
+	// Error is set if the health checks of the pod could not be requested
+	Error string `json:"error,omitempty"`
+}
+
+// newInstallationClientset returns a Kubernetes clientset of the current kubeconfig context, and the namespace of the
+// installation, which defaults to the namespace of the context
+func newInstallationClientset(namespace string) (kubernetes.Interface, string, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, "", err
+		}
+	}
+	kubeClientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	return kubeClientset, namespace, nil
+}
+
+// newComponentHTTPClient returns a client of the port forwarded endpoints of the components, which accepts the
+// self-signed certificate of the API server
+func newComponentHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   componentStatusTimeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+}
+
+// getComponentStatuses runs the full health checks of all pods of the components in a namespace, using port forwarding
+func getComponentStatuses(namespace string) ([]podStatus, error) {
+	kubeClientset, namespace, err := newInstallationClientset(namespace)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := newComponentHTTPClient()
+
+	var statuses []podStatus
+	for _, c := range components {
+		pods, err := kubeClientset.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=" + c.name})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			status := podStatus{Component: c.name, Pod: pod.Name}
+			if health, err := getPodHealth(httpClient, c, pod.Name, namespace); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Healthy = health.Healthy
+				status.Checks = health.Checks
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+func getPodHealth(httpClient *http.Client, c component, podName string, namespace string) (*healthz.Status, error) {
+	port, err := argocdclient.PortForwardPod(c.healthzPort, podName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var health healthz.Status
+	if err := getPodJSON(httpClient, c, port, "/healthz?full=true", &health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+// getPodJSON requests a path of the health check port of a pod, forwarded to the local port, and decodes the response
+func getPodJSON(httpClient *http.Client, c component, port int, path string, v interface{}) error {
+	if c.tls {
+		// the API server serves plain HTTP only if it runs with --insecure
+		if err := getJSON(httpClient, fmt.Sprintf("https://localhost:%d%s", port, path), v); err == nil {
+			return nil
+		}
+	}
+	return getJSON(httpClient, fmt.Sprintf("http://localhost:%d%s", port, path), v)
+}
+
+func getJSON(httpClient *http.Client, url string, v interface{}) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("unexpected response with status %s: %v", resp.Status, err)
+	}
+	return nil
+}
+
+// NewAdminComponentStatusCommand returns a new instance of an `argocd admin component-status` command
+func NewAdminComponentStatusCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var output string
+	var command = &cobra.Command{
+		Use:   "component-status",
+		Short: "Check the health of the components of the installation of the current kubeconfig context and of their dependencies",
+		Long: `Check the health of the components of the installation of the current kubeconfig context and of their dependencies.
+
+The full health checks of every pod of the API server, the repo server and the application controller are requested
+using port forwarding. They verify the access to the Kubernetes API, the connectivity to Redis and the reachability of
+the repo server. Exits with status 1 if a component is unhealthy.`,
+		Example: `  # Summarize the health of the components
+  argocd admin component-status
+
+  # Save the health of the components for a support request
+  argocd admin component-status -o json > component-status.json`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			statuses, err := getComponentStatuses(clientOpts.PortForwardNamespace)
+			errors.CheckError(err)
+			switch output {
+			case "json":
+				jsonBytes, err := json.MarshalIndent(statuses, "", "  ")
+				errors.CheckError(err)
+				fmt.Println(string(jsonBytes))
+			case "wide", "":
+				printComponentStatuses(statuses)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+			for _, status := range statuses {
+				if !status.Healthy {
+					os.Exit(1)
+				}
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|wide")
+	return command
+}
+
+func printComponentStatuses(statuses []podStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COMPONENT\tPOD\tCHECK\tSTATUS\tMESSAGE\n")
+	for _, status := range statuses {
+		if status.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.Component, status.Pod, "-", "Unknown", status.Error)
+			continue
+		}
+		for _, check := range status.Checks {
+			checkStatus := "Healthy"
+			if !check.Healthy {
+				checkStatus = "Unhealthy"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.Component, status.Pod, check.Name, checkStatus, check.Message)
+		}
+	}
+	_ = w.Flush()
+}
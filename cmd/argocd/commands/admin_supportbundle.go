@@ -0,0 +1,427 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-redis/redis"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	versionpkg "github.com/argoproj/argo-cd/pkg/apiclient/version"
+	repoapiclient "github.com/argoproj/argo-cd/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/tgz"
+)
+
+const (
+	redactedValue = "<redacted>"
+	redisPort     = 6379
+)
+
+// supportBundleComponents are the components whose pods' logs are added to the support bundle
+var supportBundleComponents = []string{
+	"argocd-server",
+	"argocd-repo-server",
+	"argocd-application-controller",
+	"argocd-dex-server",
+	"argocd-redis",
+}
+
+// sensitiveKeyRegexp matches the keys of settings which might hold credentials
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(secret|password|passwd|token|privatekey|private_key|apikey|api_key|clientsecret)`)
+
+// supportBundleVersions are the versions of the CLI, of the components and of the images of their pods
+type supportBundleVersions struct {
+	Client     common.Version             `json:"client"`
+	Server     *versionpkg.VersionMessage `json:"server,omitempty"`
+	RepoServer *versionpkg.VersionMessage `json:"repoServer,omitempty"`
+	// Images are the images of the containers by pod name
+	Images map[string][]string `json:"images"`
+}
+
+// supportBundle collects the files of a support bundle in a directory. Failures to collect a file are recorded in
+// errors.txt instead of aborting the bundle, since issues are often filed about partially broken installations.
+type supportBundle struct {
+	dir           string
+	namespace     string
+	kubeClientset kubernetes.Interface
+	httpClient    *http.Client
+	logLines      int64
+	errors        []string
+}
+
+func (b *supportBundle) addError(step string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", step, err))
+}
+
+func (b *supportBundle) writeFile(name string, data []byte) {
+	path := filepath.Join(b.dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err == nil {
+		err = ioutil.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		b.addError(name, err)
+	}
+}
+
+func (b *supportBundle) writeJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	b.writeFile(name, data)
+}
+
+func (b *supportBundle) listPods(componentName string) []corev1.Pod {
+	pods, err := b.kubeClientset.CoreV1().Pods(b.namespace).List(metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=" + componentName})
+	if err != nil {
+		b.addError(fmt.Sprintf("list pods of %s", componentName), err)
+		return nil
+	}
+	return pods.Items
+}
+
+func (b *supportBundle) collectVersions() {
+	versions := supportBundleVersions{Client: common.GetVersion(), Images: make(map[string][]string)}
+	for _, componentName := range supportBundleComponents {
+		for _, pod := range b.listPods(componentName) {
+			for _, container := range pod.Spec.Containers {
+				versions.Images[pod.Name] = append(versions.Images[pod.Name], container.Image)
+			}
+		}
+	}
+	for _, c := range components {
+		pods := b.listPods(c.name)
+		if len(pods) == 0 {
+			continue
+		}
+		var err error
+		switch c.name {
+		case "argocd-server":
+			versions.Server, err = b.getServerVersion(c, pods[0].Name)
+		case "argocd-repo-server":
+			versions.RepoServer, err = b.getRepoServerVersion(pods[0].Name)
+		}
+		if err != nil {
+			b.addError(fmt.Sprintf("version of %s", pods[0].Name), err)
+		}
+	}
+	b.writeJSON("versions.json", versions)
+}
+
+func (b *supportBundle) getServerVersion(c component, podName string) (*versionpkg.VersionMessage, error) {
+	port, err := argocdclient.PortForwardPod(common.DefaultPortAPIServer, podName, b.namespace)
+	if err != nil {
+		return nil, err
+	}
+	var version versionpkg.VersionMessage
+	if err := getPodJSON(b.httpClient, c, port, "/api/version", &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (b *supportBundle) getRepoServerVersion(podName string) (*versionpkg.VersionMessage, error) {
+	port, err := argocdclient.PortForwardPod(common.DefaultPortRepoServer, podName, b.namespace)
+	if err != nil {
+		return nil, err
+	}
+	conn, versionClient, err := repoapiclient.NewRepoServerClientset(fmt.Sprintf("localhost:%d", port), int(componentStatusTimeout.Seconds())).NewVersionClient()
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), componentStatusTimeout)
+	defer cancel()
+	return versionClient.Version(ctx, &empty.Empty{})
+}
+
+func (b *supportBundle) collectComponentStatus() {
+	statuses, err := getComponentStatuses(b.namespace)
+	if err != nil {
+		b.addError("component status", err)
+		return
+	}
+	b.writeJSON("component-status.json", statuses)
+}
+
+func (b *supportBundle) collectSettings() {
+	for _, name := range []string{common.ArgoCDConfigMapName, common.ArgoCDRBACConfigMapName} {
+		cm, err := b.kubeClientset.CoreV1().ConfigMaps(b.namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			b.addError(fmt.Sprintf("get config map %s", name), err)
+			continue
+		}
+		b.writeYAML(filepath.Join("settings", name+".yaml"), redactConfigMap(cm))
+	}
+	secret, err := b.kubeClientset.CoreV1().Secrets(b.namespace).Get(common.ArgoCDSecretName, metav1.GetOptions{})
+	if err != nil {
+		b.addError(fmt.Sprintf("get secret %s", common.ArgoCDSecretName), err)
+		return
+	}
+	b.writeYAML(filepath.Join("settings", common.ArgoCDSecretName+".yaml"), redactSecret(secret))
+}
+
+func (b *supportBundle) writeYAML(name string, v interface{}) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	b.writeFile(name, data)
+}
+
+func (b *supportBundle) collectLogs() {
+	for _, componentName := range supportBundleComponents {
+		for _, pod := range b.listPods(componentName) {
+			for _, container := range pod.Spec.Containers {
+				name := filepath.Join("logs", pod.Name, container.Name+".log")
+				logs, err := b.kubeClientset.CoreV1().Pods(b.namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+					Container: container.Name,
+					TailLines: &b.logLines,
+				}).DoRaw()
+				if err != nil {
+					b.addError(name, err)
+					continue
+				}
+				b.writeFile(name, logs)
+			}
+		}
+	}
+}
+
+func (b *supportBundle) collectMetrics() {
+	for _, c := range components {
+		for _, pod := range b.listPods(c.name) {
+			name := filepath.Join("metrics", pod.Name+".txt")
+			metrics, err := b.getMetrics(c, pod.Name)
+			if err != nil {
+				b.addError(name, err)
+				continue
+			}
+			b.writeFile(name, metrics)
+			if c.name == "argocd-application-controller" {
+				b.writeFile(filepath.Join("queue-depths", pod.Name+".txt"), filterMetrics(metrics, "workqueue_depth", "workqueue_unfinished_work_seconds"))
+			}
+		}
+	}
+}
+
+func (b *supportBundle) getMetrics(c component, podName string) ([]byte, error) {
+	port, err := argocdclient.PortForwardPod(c.metricsPort, podName, b.namespace)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.httpClient.Get(fmt.Sprintf("http://localhost:%d/metrics", port))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response with status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (b *supportBundle) collectCacheStats() {
+	pods := b.listPods("argocd-redis")
+	if len(pods) == 0 {
+		return
+	}
+	port, err := argocdclient.PortForwardPod(redisPort, pods[0].Name, b.namespace)
+	if err != nil {
+		b.addError("cache stats", err)
+		return
+	}
+	client := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("localhost:%d", port)})
+	defer util.Close(client)
+	info, err := client.Info().Result()
+	if err != nil {
+		b.addError("cache stats", err)
+		return
+	}
+	b.writeFile("redis-info.txt", []byte(info))
+}
+
+// filterMetrics returns the lines of metrics in the Prometheus text format which belong to the given metrics
+func filterMetrics(metrics []byte, names ...string) []byte {
+	var res []string
+	scanner := bufio.NewScanner(strings.NewReader(string(metrics)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, name := range names {
+			if strings.HasPrefix(line, name+"{") || strings.HasPrefix(line, name+" ") {
+				res = append(res, line)
+				break
+			}
+		}
+	}
+	return []byte(strings.Join(res, "\n") + "\n")
+}
+
+// redactConfigMap returns a copy of a config map whose settings which might hold credentials are redacted. Values of
+// YAML settings, e.g. the OIDC configuration, are redacted by their keys too.
+func redactConfigMap(cm *corev1.ConfigMap) *corev1.ConfigMap {
+	cm = cm.DeepCopy()
+	cm.ManagedFields = nil
+	delete(cm.Annotations, corev1.LastAppliedConfigAnnotation)
+	for key, value := range cm.Data {
+		if isSensitiveKey(key) {
+			cm.Data[key] = redactedValue
+			continue
+		}
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+			continue
+		}
+		if _, ok := parsed.(string); ok {
+			continue
+		}
+		redacted, changed := redactValue(parsed)
+		if !changed {
+			continue
+		}
+		data, err := yaml.Marshal(redacted)
+		if err != nil {
+			cm.Data[key] = redactedValue
+			continue
+		}
+		cm.Data[key] = string(data)
+	}
+	return cm
+}
+
+// redactSecret returns a copy of a secret whose values are all redacted, so only its keys are kept
+func redactSecret(secret *corev1.Secret) *corev1.Secret {
+	secret = secret.DeepCopy()
+	secret.ManagedFields = nil
+	delete(secret.Annotations, corev1.LastAppliedConfigAnnotation)
+	secret.StringData = nil
+	data := make(map[string]string)
+	for key := range secret.Data {
+		data[key] = redactedValue
+	}
+	secret.Data = nil
+	secret.StringData = data
+	return secret
+}
+
+// redactValue redacts the values with sensitive keys of parsed YAML, and returns whether any value was redacted.
+// References to secrets, like `$dex.github.clientSecret`, are kept.
+func redactValue(value interface{}) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				if s, ok := item.(string); !ok || !strings.HasPrefix(s, "$") {
+					v[key] = redactedValue
+					changed = true
+				}
+				continue
+			}
+			if redacted, itemChanged := redactValue(item); itemChanged {
+				v[key] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if redacted, itemChanged := redactValue(item); itemChanged {
+				v[i] = redacted
+				changed = true
+			}
+		}
+	}
+	return value, changed
+}
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeyRegexp.MatchString(key)
+}
+
+// NewAdminSupportBundleCommand returns a new instance of an `argocd admin support-bundle` command
+func NewAdminSupportBundleCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		outputFile string
+		logLines   int64
+	)
+	var command = &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostics of the installation of the current kubeconfig context into an archive for filing issues",
+		Long: `Collect diagnostics of the installation of the current kubeconfig context into an archive for filing issues.
+
+The archive contains the versions of the components, their health, the settings with credentials redacted, the recent
+logs of their pods, snapshots of their metrics including the queue depths of the application controller, and the
+statistics of the Redis cache. Failures to collect a part are recorded in errors.txt of the archive.`,
+		Example: `  # Collect a support bundle
+  argocd admin support-bundle
+
+  # Collect a support bundle with the last 5000 lines of the logs of every pod
+  argocd admin support-bundle --log-lines 5000 --output-file bundle.tar.gz`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if outputFile == "" {
+				outputFile = fmt.Sprintf("argocd-support-bundle-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+			}
+			kubeClientset, namespace, err := newInstallationClientset(clientOpts.PortForwardNamespace)
+			errors.CheckError(err)
+			dir, err := ioutil.TempDir("", "argocd-support-bundle")
+			errors.CheckError(err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			bundle := &supportBundle{
+				dir:           dir,
+				namespace:     namespace,
+				kubeClientset: kubeClientset,
+				httpClient:    newComponentHTTPClient(),
+				logLines:      logLines,
+			}
+			bundle.collectVersions()
+			bundle.collectComponentStatus()
+			bundle.collectSettings()
+			bundle.collectLogs()
+			bundle.collectMetrics()
+			bundle.collectCacheStats()
+			if len(bundle.errors) > 0 {
+				bundle.writeFile("errors.txt", []byte(strings.Join(bundle.errors, "\n")+"\n"))
+			}
+
+			data, err := tgz.Compress(dir)
+			errors.CheckError(err)
+			errors.CheckError(ioutil.WriteFile(outputFile, data, 0644))
+			fmt.Printf("Support bundle written to %s\n", outputFile)
+			for _, msg := range bundle.errors {
+				fmt.Fprintf(os.Stderr, "WARNING: %s\n", msg)
+			}
+		},
+	}
+	command.Flags().StringVar(&outputFile, "output-file", "", "Path of the archive (default argocd-support-bundle-<timestamp>.tar.gz)")
+	command.Flags().Int64Var(&logLines, "log-lines", 1000, "Number of recent lines of the logs of every container")
+	return command
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_redactConfigMap(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm"},
+		Data: map[string]string{
+			"url":                        "https://argocd.example.com",
+			"webhook.github.secret":      "abc",
+			"repositories":               "- url: https://github.com/argoproj/argocd-example-apps\n",
+			"oidc.config":                "name: Okta\nclientID: argocd\nclientSecret: $oidc.okta.clientSecret\n",
+			"dex.config":                 "connectors:\n- type: github\n  config:\n    clientSecret: plain\n",
+			"notifications.slack.tokens": "foo",
+		},
+	}
+	redacted := redactConfigMap(cm)
+
+	assert.Equal(t, "https://argocd.example.com", redacted.Data["url"])
+	assert.Equal(t, redactedValue, redacted.Data["webhook.github.secret"])
+	assert.Equal(t, redactedValue, redacted.Data["notifications.slack.tokens"])
+	// YAML settings without credentials and references to secrets are kept unchanged
+	assert.Equal(t, cm.Data["repositories"], redacted.Data["repositories"])
+	assert.Equal(t, cm.Data["oidc.config"], redacted.Data["oidc.config"])
+	assert.Equal(t, "connectors:\n- config:\n    clientSecret: <redacted>\n  type: github\n", redacted.Data["dex.config"])
+	// the original config map is not modified
+	assert.Equal(t, "abc", cm.Data["webhook.github.secret"])
+}
+
+func Test_redactSecret(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"admin.password": []byte("secret"), "server.secretkey": []byte("key")}}
+	redacted := redactSecret(secret)
+	assert.Nil(t, redacted.Data)
+	assert.Equal(t, map[string]string{"admin.password": redactedValue, "server.secretkey": redactedValue}, redacted.StringData)
+}
+
+func Test_filterMetrics(t *testing.T) {
+	metrics := `# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{name="app_reconciliation_queue"} 3
+workqueue_depth_foo 1
+workqueue_adds_total{name="app_reconciliation_queue"} 100
+`
+	assert.Equal(t, "workqueue_depth{name=\"app_reconciliation_queue\"} 3\n", string(filterMetrics([]byte(metrics), "workqueue_depth")))
+}
@@ -1,11 +1,11 @@
 package commands
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"reflect"
@@ -19,8 +19,13 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -33,11 +38,13 @@ import (
 	clusterpkg "github.com/argoproj/argo-cd/pkg/apiclient/cluster"
 	projectpkg "github.com/argoproj/argo-cd/pkg/apiclient/project"
 	settingspkg "github.com/argoproj/argo-cd/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/pkg/apis/application"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	repoapiclient "github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/reposerver/repository"
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/argo"
+	"github.com/argoproj/argo-cd/util/attestation"
 	"github.com/argoproj/argo-cd/util/cli"
 	"github.com/argoproj/argo-cd/util/config"
 	"github.com/argoproj/argo-cd/util/diff"
@@ -47,6 +54,7 @@ import (
 	"github.com/argoproj/argo-cd/util/resource/ignore"
 	"github.com/argoproj/argo-cd/util/templates"
 	"github.com/argoproj/argo-cd/util/text/label"
+	"github.com/argoproj/argo-cd/util/tgz"
 )
 
 var (
@@ -75,20 +83,28 @@ func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.AddCommand(NewApplicationCreateCommand(clientOpts))
 	command.AddCommand(NewApplicationGetCommand(clientOpts))
 	command.AddCommand(NewApplicationDiffCommand(clientOpts))
+	command.AddCommand(NewApplicationDiffDetailsCommand(clientOpts))
 	command.AddCommand(NewApplicationSetCommand(clientOpts))
 	command.AddCommand(NewApplicationUnsetCommand(clientOpts))
 	command.AddCommand(NewApplicationSyncCommand(clientOpts))
 	command.AddCommand(NewApplicationHistoryCommand(clientOpts))
+	command.AddCommand(NewApplicationSyncTimelineCommand(clientOpts))
 	command.AddCommand(NewApplicationRollbackCommand(clientOpts))
+	command.AddCommand(NewApplicationWriteBackCommand(clientOpts))
 	command.AddCommand(NewApplicationListCommand(clientOpts))
 	command.AddCommand(NewApplicationDeleteCommand(clientOpts))
 	command.AddCommand(NewApplicationWaitCommand(clientOpts))
 	command.AddCommand(NewApplicationManifestsCommand(clientOpts))
+	command.AddCommand(NewApplicationLogsCommand(clientOpts))
+	command.AddCommand(NewApplicationEventsCommand(clientOpts))
+	command.AddCommand(NewApplicationLinksCommand(clientOpts))
+	command.AddCommand(NewApplicationAttestationCommand(clientOpts))
 	command.AddCommand(NewApplicationTerminateOpCommand(clientOpts))
 	command.AddCommand(NewApplicationEditCommand(clientOpts))
 	command.AddCommand(NewApplicationPatchCommand(clientOpts))
 	command.AddCommand(NewApplicationPatchResourceCommand(clientOpts))
 	command.AddCommand(NewApplicationResourceActionsCommand(clientOpts))
+	command.AddCommand(NewApplicationListResourcesCommand(clientOpts))
 	return command
 }
 
@@ -122,31 +138,26 @@ func NewApplicationCreateCommand(clientOpts *argocdclient.ClientOptions) *cobra.
 
 	# Create a app using a custom tool:
 	argocd app create ksane --repo https://github.com/argoproj/argocd-example-apps.git --path plugins/kasane --dest-namespace default --dest-server https://kubernetes.default.svc --config-management-plugin kasane
+
+	# Create or update the apps of a multi-document YAML file, or of stdin
+	argocd app create -f apps.yaml --upsert
+	cat apps.yaml | argocd app create -f - --upsert
 `,
 		Run: func(c *cobra.Command, args []string) {
-			var app argoappv1.Application
 			argocdClient := argocdclient.NewClientOrDie(clientOpts)
-			if fileURL == "-" {
-				// read stdin
-				reader := bufio.NewReader(os.Stdin)
-				err := config.UnmarshalReader(reader, &app)
-				if err != nil {
-					log.Fatalf("unable to read manifest from stdin: %v", err)
-				}
-			} else if fileURL != "" {
-				// read uri
-				parsedURL, err := url.ParseRequestURI(fileURL)
-				if err != nil || !(parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
-					err = config.UnmarshalLocalFile(fileURL, &app)
-				} else {
-					err = config.UnmarshalRemoteFile(fileURL, &app)
-				}
+			var apps []argoappv1.Application
+			if fileURL != "" {
+				var err error
+				apps, err = readAppsFromFile(fileURL)
 				errors.CheckError(err)
-				if len(args) == 1 && args[0] != app.Name {
-					log.Fatalf("app name '%s' does not match app spec metadata.name '%s'", args[0], app.Name)
+				if len(apps) == 0 {
+					log.Fatalf("no applications found in %s", fileURL)
+				}
+				if len(args) == 1 && (len(apps) != 1 || args[0] != apps[0].Name) {
+					log.Fatalf("app name '%s' does not match app spec metadata.name '%s'", args[0], apps[0].Name)
 				}
-				if appName != "" && appName != app.Name {
-					log.Fatalf("--name argument '%s' does not match app spec metadata.name '%s'", appName, app.Name)
+				if appName != "" && (len(apps) != 1 || appName != apps[0].Name) {
+					log.Fatalf("--name argument '%s' does not match app spec metadata.name '%s'", appName, apps[0].Name)
 				}
 			} else {
 				// read arguments
@@ -156,7 +167,7 @@ func NewApplicationCreateCommand(clientOpts *argocdclient.ClientOptions) *cobra.
 					}
 					appName = args[0]
 				}
-				app = argoappv1.Application{
+				app := argoappv1.Application{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: appName,
 					},
@@ -164,26 +175,31 @@ func NewApplicationCreateCommand(clientOpts *argocdclient.ClientOptions) *cobra.
 				setAppSpecOptions(c.Flags(), &app.Spec, &appOpts)
 				setParameterOverrides(&app, appOpts.parameters)
 				setLabels(&app, labels)
+				apps = append(apps, app)
 			}
-			if app.Name == "" {
-				c.HelpFunc()(c, args)
-				os.Exit(1)
+			for _, app := range apps {
+				if app.Name == "" {
+					c.HelpFunc()(c, args)
+					os.Exit(1)
+				}
 			}
 
 			conn, appIf := argocdClient.NewApplicationClientOrDie()
 			defer util.Close(conn)
-			appCreateRequest := applicationpkg.ApplicationCreateRequest{
-				Application: app,
-				Upsert:      &upsert,
+			for _, app := range apps {
+				appCreateRequest := applicationpkg.ApplicationCreateRequest{
+					Application: app,
+					Upsert:      &upsert,
+				}
+				created, err := appIf.Create(context.Background(), &appCreateRequest)
+				errors.CheckError(err)
+				fmt.Printf("application '%s' created\n", created.ObjectMeta.Name)
 			}
-			created, err := appIf.Create(context.Background(), &appCreateRequest)
-			errors.CheckError(err)
-			fmt.Printf("application '%s' created\n", created.ObjectMeta.Name)
 		},
 	}
 	command.Flags().StringVar(&appName, "name", "", "A name for the app, ignored if a file is set (DEPRECATED)")
 	command.Flags().BoolVar(&upsert, "upsert", false, "Allows to override application with the same name even if supplied application spec is different from existing spec")
-	command.Flags().StringVarP(&fileURL, "file", "f", "", "Filename or URL to Kubernetes manifests for the app")
+	command.Flags().StringVarP(&fileURL, "file", "f", "", "Filename, URL or - for stdin, of the Kubernetes manifests of one or more apps")
 	command.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "Labels to apply to the app")
 	// Only complete files with appropriate extension.
 	err := command.Flags().SetAnnotation("file", cobra.BashCompFilenameExt, []string{"json", "yaml", "yml"})
@@ -249,7 +265,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 			case "yaml", "json":
 				err := PrintResource(app, output)
 				errors.CheckError(err)
-			case "wide", "":
+			case "wide", "tree", "":
 				aURL := appURL(acdClient, app.Name)
 				printAppSummaryTable(app, aURL, windows)
 
@@ -267,7 +283,14 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 				if showParams {
 					printParams(app)
 				}
-				if len(app.Status.Resources) > 0 {
+				if output == "tree" {
+					tree, err := appIf.ResourceTree(context.Background(), &applicationpkg.ResourcesQuery{ApplicationName: &app.Name})
+					errors.CheckError(err)
+					fmt.Println()
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					printTreeView(w, app, tree)
+					_ = w.Flush()
+				} else if len(app.Status.Resources) > 0 {
 					fmt.Println()
 					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 					printAppResources(w, app)
@@ -278,7 +301,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 			}
 		},
 	}
-	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|tree")
 	command.Flags().BoolVar(&showOperation, "show-operation", false, "Show application operation")
 	command.Flags().BoolVar(&showParams, "show-params", false, "Show application parameters and overrides")
 	command.Flags().BoolVar(&refresh, "refresh", false, "Refresh application data when retrieving")
@@ -436,16 +459,41 @@ func printParams(app *argoappv1.Application) {
 func NewApplicationSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
 		appOpts appOptions
+		fileURL string
+		upsert  bool
 	)
 	var command = &cobra.Command{
 		Use:   "set APPNAME",
 		Short: "Set application parameters",
+		Example: `  # Set the revision of an app
+  argocd app set guestbook --revision v1.0.0
+
+  # Replace the spec of the apps of a multi-document YAML file, creating the ones which do not exist yet
+  argocd app set -f apps.yaml --upsert
+  cat apps.yaml | argocd app set -f - --upsert`,
 		Run: func(c *cobra.Command, args []string) {
+			ctx := context.Background()
+			if fileURL != "" {
+				if len(args) != 0 {
+					c.HelpFunc()(c, args)
+					os.Exit(1)
+				}
+				apps, err := readAppsFromFile(fileURL)
+				errors.CheckError(err)
+				if len(apps) == 0 {
+					log.Fatalf("no applications found in %s", fileURL)
+				}
+				conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+				defer util.Close(conn)
+				for i := range apps {
+					setAppFromFile(ctx, appIf, &apps[i], upsert)
+				}
+				return
+			}
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
 				os.Exit(1)
 			}
-			ctx := context.Background()
 			appName := args[0]
 			argocdClient := argocdclient.NewClientOrDie(clientOpts)
 			conn, appIf := argocdClient.NewApplicationClientOrDie()
@@ -467,9 +515,87 @@ func NewApplicationSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 		},
 	}
 	addAppFlags(command, &appOpts)
+	command.Flags().StringVarP(&fileURL, "file", "f", "", "Filename, URL or - for stdin, of the Kubernetes manifests of one or more apps whose spec to set. Other flags are ignored when set")
+	command.Flags().BoolVar(&upsert, "upsert", false, "Create the apps of --file which do not exist yet")
 	return command
 }
 
+// setAppFromFile replaces the spec of an existing app with the one read from a file, or creates the app if upsert is set
+func setAppFromFile(ctx context.Context, appIf applicationpkg.ApplicationServiceClient, app *argoappv1.Application, upsert bool) {
+	if app.Name == "" {
+		log.Fatal("app spec is missing metadata.name")
+	}
+	_, err := appIf.Get(ctx, &applicationpkg.ApplicationQuery{Name: &app.Name})
+	if status.Code(err) == codes.NotFound {
+		if !upsert {
+			log.Fatalf("application '%s' does not exist, use --upsert to create it", app.Name)
+		}
+		created, err := appIf.Create(ctx, &applicationpkg.ApplicationCreateRequest{Application: *app, Upsert: &upsert})
+		errors.CheckError(err)
+		fmt.Printf("application '%s' created\n", created.Name)
+		return
+	}
+	errors.CheckError(err)
+	_, err = appIf.UpdateSpec(ctx, &applicationpkg.ApplicationUpdateSpecRequest{
+		Name: &app.Name,
+		Spec: app.Spec,
+	})
+	errors.CheckError(err)
+	fmt.Printf("application '%s' updated\n", app.Name)
+}
+
+// readAppsFromFile reads the apps of a local file, a URL or, when fileURL is "-", stdin
+func readAppsFromFile(fileURL string) ([]argoappv1.Application, error) {
+	var data []byte
+	var err error
+	if fileURL == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read manifest from stdin: %v", err)
+		}
+	} else if parsedURL, urlErr := url.ParseRequestURI(fileURL); urlErr == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
+		data, err = config.ReadRemoteFile(fileURL)
+	} else {
+		data, err = ioutil.ReadFile(fileURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalApps(data)
+}
+
+// unmarshalApps converts a YAML or JSON stream of apps into applications. The stream may hold several YAML documents,
+// as well as lists of apps.
+func unmarshalApps(data []byte) ([]argoappv1.Application, error) {
+	objs, err := kube.SplitYAML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var apps []argoappv1.Application
+	for _, obj := range objs {
+		items := []unstructured.Unstructured{*obj}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			items = list.Items
+		}
+		for _, item := range items {
+			if kind := item.GetKind(); kind != "" && kind != application.ApplicationKind {
+				return nil, fmt.Errorf("unexpected kind '%s' of '%s', only %s resources are supported", item.GetKind(), item.GetName(), application.ApplicationKind)
+			}
+			var app argoappv1.Application
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &app)
+			if err != nil {
+				return nil, err
+			}
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
 func setAppSpecOptions(flags *pflag.FlagSet, spec *argoappv1.ApplicationSpec, appOpts *appOptions) int {
 	visited := 0
 	flags.Visit(func(f *pflag.Flag) {
@@ -514,6 +640,27 @@ func setAppSpecOptions(flags *pflag.FlagSet, spec *argoappv1.ApplicationSpec, ap
 			setKustomizeOpt(&spec.Source, kustomizeOpts{nameSuffix: appOpts.nameSuffix})
 		case "kustomize-image":
 			setKustomizeOpt(&spec.Source, kustomizeOpts{images: appOpts.kustomizeImages})
+		case "cue-package":
+			setCUEOpt(&spec.Source, cueOpts{pkg: &appOpts.cuePackage})
+		case "cue-expression":
+			setCUEOpt(&spec.Source, cueOpts{expression: &appOpts.cueExpression})
+		case "cue-tag":
+			setCUEOpt(&spec.Source, cueOpts{tags: appOpts.cueTags})
+		case "tanka-name":
+			if spec.Source.Tanka == nil {
+				spec.Source.Tanka = &argoappv1.ApplicationSourceTanka{}
+			}
+			spec.Source.Tanka.Name = appOpts.tankaName
+		case "helmfile-environment":
+			if spec.Source.Helmfile == nil {
+				spec.Source.Helmfile = &argoappv1.ApplicationSourceHelmfile{}
+			}
+			spec.Source.Helmfile.Environment = appOpts.helmfileEnvironment
+		case "helmfile-selector":
+			if spec.Source.Helmfile == nil {
+				spec.Source.Helmfile = &argoappv1.ApplicationSourceHelmfile{}
+			}
+			spec.Source.Helmfile.Selectors = appOpts.helmfileSelectors
 		case "jsonnet-tla-str":
 			setJsonnetOpt(&spec.Source, appOpts.jsonnetTlaStr, false)
 		case "jsonnet-tla-code":
@@ -649,6 +796,39 @@ func setHelmOpt(src *argoappv1.ApplicationSource, opts helmOpts) {
 	}
 }
 
+type cueOpts struct {
+	pkg        *string
+	expression *string
+	tags       []string
+}
+
+func setCUEOpt(src *argoappv1.ApplicationSource, opts cueOpts) {
+	if src.CUE == nil {
+		src.CUE = &argoappv1.ApplicationSourceCUE{}
+	}
+	if opts.pkg != nil {
+		src.CUE.Package = *opts.pkg
+	}
+	if opts.expression != nil {
+		src.CUE.Expression = *opts.expression
+	}
+	for _, text := range opts.tags {
+		tag, err := argoappv1.NewCUETag(text)
+		errors.CheckError(err)
+		found := false
+		for i := range src.CUE.Tags {
+			if src.CUE.Tags[i].Name == tag.Name {
+				src.CUE.Tags[i] = *tag
+				found = true
+				break
+			}
+		}
+		if !found {
+			src.CUE.Tags = append(src.CUE.Tags, *tag)
+		}
+	}
+}
+
 func setJsonnetOpt(src *argoappv1.ApplicationSource, tlaParameters []string, code bool) {
 	if src.Directory == nil {
 		src.Directory = &argoappv1.ApplicationSourceDirectory{}
@@ -696,6 +876,12 @@ type appOptions struct {
 	jsonnetExtVarStr       []string
 	jsonnetExtVarCode      []string
 	kustomizeImages        []string
+	cuePackage             string
+	cueExpression          string
+	cueTags                []string
+	tankaName              string
+	helmfileEnvironment    string
+	helmfileSelectors      []string
 }
 
 func addAppFlags(command *cobra.Command, opts *appOptions) {
@@ -727,19 +913,36 @@ func addAppFlags(command *cobra.Command, opts *appOptions) {
 	command.Flags().StringArrayVar(&opts.jsonnetExtVarStr, "jsonnet-ext-var-str", []string{}, "Jsonnet string ext var")
 	command.Flags().StringArrayVar(&opts.jsonnetExtVarCode, "jsonnet-ext-var-code", []string{}, "Jsonnet ext var")
 	command.Flags().StringArrayVar(&opts.kustomizeImages, "kustomize-image", []string{}, "Kustomize images (e.g. --kustomize-image node:8.15.0 --kustomize-image mysql=mariadb,alpine@sha256:24a0c4b4a4c0eb97a1aabb8e29f18e917d05abfe1b7a7c07857230879ce7d3d)")
+	command.Flags().StringVar(&opts.cuePackage, "cue-package", "", "CUE package to export (e.g. ./prod)")
+	command.Flags().StringVar(&opts.cueExpression, "cue-expression", "", "CUE expression to export (e.g. objects)")
+	command.Flags().StringArrayVar(&opts.cueTags, "cue-tag", []string{}, "CUE tag values (can be repeated to set several values: --cue-tag env=prod --cue-tag region=eu)")
+	command.Flags().StringVar(&opts.tankaName, "tanka-name", "", "Name of the inline Tanka environment to show")
+	command.Flags().StringVar(&opts.helmfileEnvironment, "helmfile-environment", "", "Helmfile environment to render")
+	command.Flags().StringArrayVar(&opts.helmfileSelectors, "helmfile-selector", []string{}, "Helmfile release selectors (can be repeated: --helmfile-selector tier=frontend --helmfile-selector name=guestbook)")
 }
 
 // NewApplicationUnsetCommand returns a new instance of an `argocd app unset` command
 func NewApplicationUnsetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
-	var (
-		parameters  []string
-		valuesFiles []string
-	)
+	var opts unsetOpts
 	var command = &cobra.Command{
-		Use:   "unset APPNAME -p COMPONENT=PARAM",
+		Use:   "unset APPNAME parameters",
 		Short: "Unset application parameters",
+		Example: `  # Unset kustomize override kustomize image
+  argocd app unset my-app --kustomize-image=alpine
+
+  # Unset kustomize override prefix
+  argocd app unset my-app --nameprefix
+
+  # Unset kustomize override common label
+  argocd app unset my-app --kustomize-common-label=env
+
+  # Unset parameter override
+  argocd app unset my-app -p COMPONENT=PARAM
+
+  # Unset helm values files and the inline values block
+  argocd app unset my-app --values values-prod.yaml --values-literal`,
 		Run: func(c *cobra.Command, args []string) {
-			if len(args) != 1 || (len(parameters) == 0 && len(valuesFiles) == 0) {
+			if len(args) != 1 || opts.IsZero() {
 				c.HelpFunc()(c, args)
 				os.Exit(1)
 			}
@@ -749,48 +952,10 @@ func NewApplicationUnsetCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
 			app, err := appIf.Get(context.Background(), &applicationpkg.ApplicationQuery{Name: &appName})
 			errors.CheckError(err)
 
-			updated := false
-			if app.Spec.Source.Ksonnet != nil {
-				for _, paramStr := range parameters {
-					parts := strings.SplitN(paramStr, "=", 2)
-					if len(parts) != 2 {
-						log.Fatalf("Expected parameter of the form: component=param. Received: %s", paramStr)
-					}
-					overrides := app.Spec.Source.Ksonnet.Parameters
-					for i, override := range overrides {
-						if override.Component == parts[0] && override.Name == parts[1] {
-							app.Spec.Source.Ksonnet.Parameters = append(overrides[0:i], overrides[i+1:]...)
-							updated = true
-							break
-						}
-					}
-				}
-			}
-			if app.Spec.Source.Helm != nil {
-				for _, paramStr := range parameters {
-					helmParams := app.Spec.Source.Helm.Parameters
-					for i, p := range helmParams {
-						if p.Name == paramStr {
-							app.Spec.Source.Helm.Parameters = append(helmParams[0:i], helmParams[i+1:]...)
-							updated = true
-							break
-						}
-					}
-				}
-				specValueFiles := app.Spec.Source.Helm.ValueFiles
-				for _, valuesFile := range valuesFiles {
-					for i, vf := range specValueFiles {
-						if vf == valuesFile {
-							specValueFiles = append(specValueFiles[0:i], specValueFiles[i+1:]...)
-							updated = true
-							break
-						}
-					}
-				}
-				setHelmOpt(&app.Spec.Source, helmOpts{valueFiles: specValueFiles})
-				if !updated {
-					return
-				}
+			updated, err := unset(&app.Spec.Source, opts)
+			errors.CheckError(err)
+			if !updated {
+				return
 			}
 
 			_, err = appIf.UpdateSpec(context.Background(), &applicationpkg.ApplicationUpdateSpecRequest{
@@ -800,11 +965,121 @@ func NewApplicationUnsetCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
 			errors.CheckError(err)
 		},
 	}
-	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{}, "unset a parameter override (e.g. -p guestbook=image)")
-	command.Flags().StringArrayVar(&valuesFiles, "values", []string{}, "unset one or more helm values files")
+	command.Flags().StringArrayVarP(&opts.parameters, "parameter", "p", []string{}, "Unset a parameter override (e.g. -p guestbook=image)")
+	command.Flags().StringArrayVar(&opts.valuesFiles, "values", []string{}, "Unset one or more Helm values files")
+	command.Flags().BoolVar(&opts.valuesLiteral, "values-literal", false, "Unset literal Helm values block")
+	command.Flags().BoolVar(&opts.nameSuffix, "namesuffix", false, "Kustomize namesuffix")
+	command.Flags().BoolVar(&opts.namePrefix, "nameprefix", false, "Kustomize nameprefix")
+	command.Flags().StringArrayVar(&opts.kustomizeImages, "kustomize-image", []string{}, "Kustomize images name (e.g. --kustomize-image node --kustomize-image mysql)")
+	command.Flags().StringArrayVar(&opts.kustomizeCommonLabels, "kustomize-common-label", []string{}, "Kustomize common label keys to unset (e.g. --kustomize-common-label env)")
 	return command
 }
 
+type unsetOpts struct {
+	parameters            []string
+	valuesFiles           []string
+	valuesLiteral         bool
+	nameSuffix            bool
+	namePrefix            bool
+	kustomizeImages       []string
+	kustomizeCommonLabels []string
+}
+
+// IsZero returns true when no override is requested to be unset
+func (o *unsetOpts) IsZero() bool {
+	return len(o.parameters) == 0 && len(o.valuesFiles) == 0 && !o.valuesLiteral && !o.nameSuffix && !o.namePrefix &&
+		len(o.kustomizeImages) == 0 && len(o.kustomizeCommonLabels) == 0
+}
+
+// unset removes the requested overrides from the application source and returns whether the source was modified
+func unset(source *argoappv1.ApplicationSource, opts unsetOpts) (bool, error) {
+	updated := false
+	if source.Ksonnet != nil {
+		for _, paramStr := range opts.parameters {
+			parts := strings.SplitN(paramStr, "=", 2)
+			if len(parts) != 2 {
+				return false, fmt.Errorf("Expected parameter of the form: component=param. Received: %s", paramStr)
+			}
+			overrides := source.Ksonnet.Parameters
+			for i, override := range overrides {
+				if override.Component == parts[0] && override.Name == parts[1] {
+					source.Ksonnet.Parameters = append(overrides[0:i], overrides[i+1:]...)
+					updated = true
+					break
+				}
+			}
+		}
+	}
+	if source.Helm != nil {
+		for _, paramStr := range opts.parameters {
+			helmParams := source.Helm.Parameters
+			for i, p := range helmParams {
+				if p.Name == paramStr {
+					source.Helm.Parameters = append(helmParams[0:i], helmParams[i+1:]...)
+					updated = true
+					break
+				}
+			}
+		}
+		for _, valuesFile := range opts.valuesFiles {
+			specValueFiles := source.Helm.ValueFiles
+			for i, vf := range specValueFiles {
+				if vf == valuesFile {
+					source.Helm.ValueFiles = append(specValueFiles[0:i], specValueFiles[i+1:]...)
+					updated = true
+					break
+				}
+			}
+		}
+		if opts.valuesLiteral && source.Helm.Values != "" {
+			source.Helm.Values = ""
+			updated = true
+		}
+		if source.Helm.IsZero() {
+			source.Helm = nil
+		}
+	}
+	if source.Kustomize != nil {
+		if opts.namePrefix && source.Kustomize.NamePrefix != "" {
+			source.Kustomize.NamePrefix = ""
+			updated = true
+		}
+		if opts.nameSuffix && source.Kustomize.NameSuffix != "" {
+			source.Kustomize.NameSuffix = ""
+			updated = true
+		}
+		for _, name := range opts.kustomizeImages {
+			images := source.Kustomize.Images
+			for i, image := range images {
+				if kustomizeImageName(image) == name {
+					source.Kustomize.Images = append(images[0:i], images[i+1:]...)
+					updated = true
+					break
+				}
+			}
+		}
+		for _, key := range opts.kustomizeCommonLabels {
+			if _, ok := source.Kustomize.CommonLabels[key]; ok {
+				delete(source.Kustomize.CommonLabels, key)
+				updated = true
+			}
+		}
+		if source.Kustomize.IsZero() {
+			source.Kustomize = nil
+		}
+	}
+	return updated, nil
+}
+
+// kustomizeImageName returns the name of the image an override applies to, e.g. "nginx" for "nginx:1.17" or
+// "nginx=my-registry/nginx:1.17"
+func kustomizeImageName(image argoappv1.KustomizeImage) string {
+	if i := strings.IndexAny(string(image), "=:@"); i >= 0 {
+		return string(image)[:i]
+	}
+	return string(image)
+}
+
 // targetObjects deserializes the list of target states into unstructured objects
 func targetObjects(resources []*argoappv1.ResourceDiff) ([]*unstructured.Unstructured, error) {
 	objs := make([]*unstructured.Unstructured, len(resources))
@@ -844,7 +1119,7 @@ func getLocalObjects(app *argoappv1.Application, local, appLabelKey, kubeVersion
 }
 
 func getLocalObjectsString(app *argoappv1.Application, local, appLabelKey, kubeVersion string, kustomizeOptions *argoappv1.KustomizeOptions) []string {
-	res, err := repository.GenerateManifests(local, "/", app.Spec.Source.TargetRevision, &repoapiclient.ManifestRequest{
+	res, err := repository.GenerateManifests(context.Background(), local, "/", app.Spec.Source.TargetRevision, &repoapiclient.ManifestRequest{
 		Repo:              &argoappv1.Repository{Repo: app.Spec.Source.RepoURL},
 		AppLabelKey:       appLabelKey,
 		AppLabelValue:     app.Name,
@@ -858,6 +1133,23 @@ func getLocalObjectsString(app *argoappv1.Application, local, appLabelKey, kubeV
 	return res.Manifests
 }
 
+// getServerSideLocalObjects uploads the local directory of the application and returns the manifests which the
+// repo server generates from it
+func getServerSideLocalObjects(appIf applicationpkg.ApplicationServiceClient, appName, local string) []*unstructured.Unstructured {
+	files, err := tgz.Compress(local)
+	errors.CheckError(err)
+	res, err := appIf.GetManifestsWithFiles(context.Background(), &applicationpkg.ApplicationManifestQueryWithFiles{Name: &appName, Files: files})
+	errors.CheckError(err)
+	objs := make([]*unstructured.Unstructured, len(res.Manifests))
+	for i := range res.Manifests {
+		obj := unstructured.Unstructured{}
+		err := json.Unmarshal([]byte(res.Manifests[i]), &obj)
+		errors.CheckError(err)
+		objs[i] = &obj
+	}
+	return objs
+}
+
 type resourceInfoProvider struct {
 	namespacedByGk map[schema.GroupKind]bool
 }
@@ -891,9 +1183,10 @@ func groupLocalObjs(localObs []*unstructured.Unstructured, liveObjs []*unstructu
 // NewApplicationDiffCommand returns a new instance of an `argocd app diff` command
 func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		refresh     bool
-		hardRefresh bool
-		local       string
+		refresh            bool
+		hardRefresh        bool
+		local              string
+		serverSideGenerate bool
 	)
 	shortDesc := "Perform a diff against the target and live state."
 	var command = &cobra.Command{
@@ -928,11 +1221,17 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 			errors.CheckError(err)
 
 			if local != "" {
-				conn, clusterIf := clientset.NewClusterClientOrDie()
-				defer util.Close(conn)
-				cluster, err := clusterIf.Get(context.Background(), &clusterpkg.ClusterQuery{Server: app.Spec.Destination.Server})
-				errors.CheckError(err)
-				localObjs := groupLocalObjs(getLocalObjects(app, local, argoSettings.AppLabelKey, cluster.ServerVersion, argoSettings.KustomizeOptions), liveObjs, app.Spec.Destination.Namespace)
+				var objs []*unstructured.Unstructured
+				if serverSideGenerate {
+					objs = getServerSideLocalObjects(appIf, appName, local)
+				} else {
+					conn, clusterIf := clientset.NewClusterClientOrDie()
+					defer util.Close(conn)
+					cluster, err := clusterIf.Get(context.Background(), &clusterpkg.ClusterQuery{Server: app.Spec.Destination.Server})
+					errors.CheckError(err)
+					objs = getLocalObjects(app, local, argoSettings.AppLabelKey, cluster.ServerVersion, argoSettings.KustomizeOptions)
+				}
+				localObjs := groupLocalObjs(objs, liveObjs, app.Spec.Destination.Namespace)
 				for _, res := range resources.Items {
 					var live = &unstructured.Unstructured{}
 					err := json.Unmarshal([]byte(res.NormalizedLiveState), &live)
@@ -1039,9 +1338,80 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().BoolVar(&refresh, "refresh", false, "Refresh application data when retrieving")
 	command.Flags().BoolVar(&hardRefresh, "hard-refresh", false, "Refresh application data as well as target manifests cache")
 	command.Flags().StringVar(&local, "local", "", "Compare live app to a local manifests")
+	command.Flags().BoolVar(&serverSideGenerate, "server-side-generate", false, "Upload the local files so that the repo server generates the manifests with its tools, used with --local")
 	return command
 }
 
+// NewApplicationDiffDetailsCommand returns a new instance of an `argocd app diff-details` command
+func NewApplicationDiffDetailsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		resourceName string
+		namespace    string
+		kind         string
+		group        string
+		output       string
+	)
+	var command = &cobra.Command{
+		Use:   "diff-details APPNAME",
+		Short: "Print the differences of the fields of the managed resources of an application, and the normalizations ignoring differences",
+		Example: `  # Print why the resources of an application are, or are not, OutOfSync
+  argocd app diff-details guestbook
+
+  # Print the differences of the fields of a deployment
+  argocd app diff-details guestbook --group apps --kind Deployment --resource-name guestbook-ui`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			res, err := appIf.ResourceDiffDetails(context.Background(), &applicationpkg.ResourcesQuery{
+				ApplicationName: &appName,
+				Name:            resourceName,
+				Namespace:       namespace,
+				Kind:            kind,
+				Group:           group,
+			})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResourceList(res.Items, output, false)
+				errors.CheckError(err)
+			case "wide", "":
+				printResourceDiffDetails(res.Items)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVar(&resourceName, "resource-name", "", "Name of resource")
+	command.Flags().StringVar(&kind, "kind", "", "Kind")
+	command.Flags().StringVar(&group, "group", "", "Group")
+	command.Flags().StringVar(&namespace, "namespace", "", "Namespace")
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
+
+// printResourceDiffDetails prints the differing and the ignored fields of resources as table
+func printResourceDiffDetails(items []*argoappv1.ResourceDiffDetails) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "GROUP\tKIND\tNAMESPACE\tNAME\tFIELD\tSTATUS\tTARGET\tLIVE\tIGNORED BY\n")
+	for _, item := range items {
+		if item.Modified && len(item.Diffs) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.Group, item.Kind, item.Namespace, item.Name, "", "OutOfSync", "", "", "")
+		}
+		for _, d := range item.Diffs {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.Group, item.Kind, item.Namespace, item.Name, d.Path, "OutOfSync", d.TargetValue, d.LiveValue, "")
+		}
+		for _, d := range item.Suppressed {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.Group, item.Kind, item.Namespace, item.Name, d.Path, "Ignored", d.TargetValue, d.LiveValue, d.SuppressedBy)
+		}
+	}
+	_ = w.Flush()
+}
+
 // NewApplicationDeleteCommand returns a new instance of an `argocd app delete` command
 func NewApplicationDeleteCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
@@ -1114,9 +1484,14 @@ func printApplicationTable(apps []argoappv1.Application, output *string) {
 // NewApplicationListCommand returns a new instance of an `argocd app list` command
 func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		output   string
-		selector string
-		projects []string
+		output        string
+		selector      string
+		projects      []string
+		health        []string
+		syncStatuses  []string
+		destServer    string
+		destNamespace string
+		chunkSize     int64
 	)
 	var command = &cobra.Command{
 		Use:   "list",
@@ -1125,15 +1500,31 @@ func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app list
 
   # List apps by label, in this example we listing apps that are children of another app (aka app-of-apps)
-  argocd app list -l app.kubernetes.io/instance=my-app`,
+  argocd app list -l app.kubernetes.io/instance=my-app
+
+  # List degraded or out of sync apps deployed to a namespace
+  argocd app list --health Degraded --sync OutOfSync --dest-namespace my-namespace`,
 		Run: func(c *cobra.Command, args []string) {
 			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
 			defer util.Close(conn)
-			apps, err := appIf.List(context.Background(), &applicationpkg.ApplicationQuery{Selector: selector})
-			errors.CheckError(err)
-			appList := apps.Items
-			if len(projects) != 0 {
-				appList = argo.FilterByProjects(appList, projects)
+			query := applicationpkg.ApplicationQuery{
+				Selector:       selector,
+				Projects:       projects,
+				HealthStatuses: health,
+				SyncStatuses:   syncStatuses,
+				DestServer:     destServer,
+				DestNamespace:  destNamespace,
+				Limit:          chunkSize,
+			}
+			appList := make([]argoappv1.Application, 0)
+			for {
+				apps, err := appIf.List(context.Background(), &query)
+				errors.CheckError(err)
+				appList = append(appList, apps.Items...)
+				if apps.Continue == "" {
+					break
+				}
+				query.Continue = apps.Continue
 			}
 			switch output {
 			case "yaml", "json":
@@ -1151,6 +1542,11 @@ func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|name|json|yaml")
 	command.Flags().StringVarP(&selector, "selector", "l", "", "List apps by label")
 	command.Flags().StringArrayVarP(&projects, "project", "p", []string{}, "Filter by project name")
+	command.Flags().StringArrayVar(&health, "health", []string{}, "Filter by health status")
+	command.Flags().StringArrayVar(&syncStatuses, "sync", []string{}, "Filter by sync status")
+	command.Flags().StringVar(&destServer, "dest-server", "", "Filter by destination server")
+	command.Flags().StringVar(&destNamespace, "dest-namespace", "", "Filter by destination namespace")
+	command.Flags().Int64Var(&chunkSize, "chunk-size", 0, "Fetch applications from the server in pages of the given size (0 means no paging)")
 	return command
 }
 
@@ -1215,6 +1611,39 @@ func parseSelectedResources(resources []string) []argoappv1.SyncOperationResourc
 	return selectedResources
 }
 
+// parseLabelSelectors parses the label selectors which resources to sync should match
+func parseLabelSelectors(selectors []string) ([]k8slabels.Selector, error) {
+	var res []k8slabels.Selector
+	for _, s := range selectors {
+		selector, err := k8slabels.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector '%s': %v", s, err)
+		}
+		res = append(res, selector)
+	}
+	return res, nil
+}
+
+// getResourcesByLabels returns the GROUP:KIND:NAME of every manifest whose labels match any of the given selectors
+func getResourcesByLabels(manifests []string, selectors []k8slabels.Selector) ([]string, error) {
+	var resources []string
+	for _, mfst := range manifests {
+		obj, err := argoappv1.UnmarshalToUnstructured(mfst)
+		if err != nil {
+			return nil, err
+		}
+		objLabels := k8slabels.Set(obj.GetLabels())
+		for _, selector := range selectors {
+			if selector.Matches(objLabels) {
+				gvk := obj.GroupVersionKind()
+				resources = append(resources, strings.Join([]string{gvk.Group, gvk.Kind, obj.GetName()}, resourceFieldDelimiter))
+				break
+			}
+		}
+	}
+	return resources, nil
+}
+
 // NewApplicationWaitCommand returns a new instance of an `argocd app wait` command
 func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
@@ -1222,6 +1651,7 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		watchHealth     bool
 		watchSuspended  bool
 		watchOperations bool
+		watchRevision   string
 		timeout         uint
 		selector        string
 		resources       []string
@@ -1229,6 +1659,9 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	var command = &cobra.Command{
 		Use:   "wait [APPNAME.. | -l selector]",
 		Short: "Wait for an application to reach a synced and healthy state",
+		Long: fmt.Sprintf(`Wait for an application to reach a synced and healthy state.
+All of the given conditions must be met. Returns the following exit codes: %d when the conditions are met,
+%d on general errors, %d when timed out and %d when the application became degraded`, 0, 1, waitExitCodeTimeout, waitExitCodeDegraded),
 		Example: `  # Wait for an app
   argocd app wait my-app
 
@@ -1236,7 +1669,10 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app wait my-app other-app
 
   # Wait for apps by label, in this example we waiting for apps that are children of another app (aka app-of-apps)
-  argocd app wait -l app.kubernetes.io/instance=apps`,
+  argocd app wait -l app.kubernetes.io/instance=apps
+
+  # Wait for an app to be synced to a specific commit and healthy
+  argocd app wait my-app --sync --health --revision 2b7f9ab`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) == 0 && selector == "" {
 				c.HelpFunc()(c, args)
@@ -1261,7 +1697,11 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				}
 			}
 			for _, appName := range appNames {
-				_, err := waitOnApplicationStatus(acdClient, appName, timeout, watchSync, watchHealth, watchOperations, watchSuspended, selectedResources)
+				_, err := waitOnApplicationStatus(acdClient, appName, timeout, watchSync, watchHealth, watchOperations, watchSuspended, watchRevision, selectedResources)
+				if waitErr, ok := err.(*appWaitError); ok {
+					log.Error(waitErr)
+					os.Exit(waitErr.exitCode)
+				}
 				errors.CheckError(err)
 			}
 		},
@@ -1272,6 +1712,7 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringVarP(&selector, "selector", "l", "", "Wait for apps by label")
 	command.Flags().StringArrayVar(&resources, "resource", []string{}, fmt.Sprintf("Sync only specific resources as GROUP%sKIND%sNAME. Fields may be blank. This option may be specified repeatedly", resourceFieldDelimiter, resourceFieldDelimiter))
 	command.Flags().BoolVar(&watchOperations, "operation", false, "Wait for pending operations")
+	command.Flags().StringVar(&watchRevision, "revision", "", "Wait for the app to be synced to the revision, which may be an abbreviated commit SHA")
 	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
 	return command
 }
@@ -1314,7 +1755,11 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   # Sync a specific resource
   # Resource should be formatted as GROUP:KIND:NAME. If no GROUP is specified then :KIND:NAME
   argocd app sync my-app --resource :Service:my-service
-  argocd app sync my-app --resource argoproj.io:Rollout:my-rollout`,
+  argocd app sync my-app --resource argoproj.io:Rollout:my-rollout
+
+  # Sync only the resources matching a label selector
+  argocd app sync my-app --label app.kubernetes.io/component=frontend
+  argocd app sync my-app --label 'tier in (web,cache),track!=canary'`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) == 0 && selector == "" {
 				c.HelpFunc()(c, args)
@@ -1324,7 +1769,7 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 			conn, appIf := acdClient.NewApplicationClientOrDie()
 			defer util.Close(conn)
 
-			selectedLabels, err := label.Parse(labels)
+			labelSelectors, err := parseLabelSelectors(labels)
 			errors.CheckError(err)
 
 			appNames := args
@@ -1342,39 +1787,28 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 
 			for _, appName := range appNames {
 
-				if len(selectedLabels) > 0 {
-					ctx := context.Background()
-
+				appResources := append([]string{}, resources...)
+				if len(labelSelectors) > 0 {
 					q := applicationpkg.ApplicationManifestQuery{
 						Name:     &appName,
 						Revision: revision,
 					}
+					res, err := appIf.GetManifests(context.Background(), &q)
+					errors.CheckError(err)
 
-					res, err := appIf.GetManifests(ctx, &q)
-					if err != nil {
-						log.Fatal(err)
-					}
-
-					for _, mfst := range res.Manifests {
-						obj, err := argoappv1.UnmarshalToUnstructured(mfst)
-						errors.CheckError(err)
-						for key, selectedValue := range selectedLabels {
-							if objectValue, ok := obj.GetLabels()[key]; ok && selectedValue == objectValue {
-								gvk := obj.GroupVersionKind()
-								resources = append(resources, fmt.Sprintf("%s:%s:%s", gvk.Group, gvk.Kind, obj.GetName()))
-							}
-						}
-					}
+					labeledResources, err := getResourcesByLabels(res.Manifests, labelSelectors)
+					errors.CheckError(err)
+					appResources = append(appResources, labeledResources...)
 
 					// If labels are provided and none are found return error only if specific resources were also not
 					// specified.
-					if len(resources) == 0 {
+					if len(appResources) == 0 {
 						log.Fatalf("No matching resources found for labels: %v", labels)
 						return
 					}
 				}
 
-				selectedResources := parseSelectedResources(resources)
+				selectedResources := parseSelectedResources(appResources)
 
 				var localObjsStrings []string
 				if local != "" {
@@ -1421,7 +1855,7 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				errors.CheckError(err)
 
 				if !async {
-					app, err := waitOnApplicationStatus(acdClient, appName, timeout, false, false, true, false, selectedResources)
+					app, err := waitOnApplicationStatus(acdClient, appName, timeout, false, false, true, false, "", selectedResources)
 					errors.CheckError(err)
 
 					// Only get resources to be pruned if sync was application-wide
@@ -1444,7 +1878,7 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringVar(&revision, "revision", "", "Sync to a specific revision. Preserves parameter overrides")
 	command.Flags().StringArrayVar(&resources, "resource", []string{}, fmt.Sprintf("Sync only specific resources as GROUP%sKIND%sNAME. Fields may be blank. This option may be specified repeatedly", resourceFieldDelimiter, resourceFieldDelimiter))
 	command.Flags().StringVarP(&selector, "selector", "l", "", "Sync apps that match this label")
-	command.Flags().StringArrayVar(&labels, "label", []string{}, fmt.Sprintf("Sync only specific resources with a label. This option may be specified repeatedly."))
+	command.Flags().StringArrayVar(&labels, "label", []string{}, "Sync only resources matching a label selector, e.g. key=value or 'key in (v1,v2)'. Resources matching any of the selectors are synced. This option may be specified repeatedly")
 	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
 	command.Flags().StringVar(&strategy, "strategy", "", "Sync strategy (one of: apply|hook)")
 	command.Flags().BoolVar(&force, "force", false, "Use a force apply")
@@ -1577,9 +2011,37 @@ func checkResourceStatus(watchSync bool, watchHealth bool, watchOperation bool,
 	return synced && healthCheckPassed && operational
 }
 
+// checkRevision returns whether the app is synced to the given revision, which may be an abbreviated commit SHA
+func checkRevision(watchRevision string, syncStatus argoappv1.SyncStatus) bool {
+	if watchRevision == "" {
+		return true
+	}
+	if syncStatus.Status != argoappv1.SyncStatusCodeSynced {
+		return false
+	}
+	return syncStatus.Revision == watchRevision || git.IsCommitSHA(syncStatus.Revision) && git.IsTruncatedCommitSHA(watchRevision) && strings.HasPrefix(syncStatus.Revision, watchRevision)
+}
+
+const (
+	// waitExitCodeTimeout is the exit code of `argocd app wait` when it timed out
+	waitExitCodeTimeout = 2
+	// waitExitCodeDegraded is the exit code of `argocd app wait` when the application became degraded
+	waitExitCodeDegraded = 3
+)
+
+// appWaitError is an error waiting for an application which maps to a distinct exit code
+type appWaitError struct {
+	exitCode int
+	message  string
+}
+
+func (e *appWaitError) Error() string {
+	return e.message
+}
+
 const waitFormatString = "%s\t%5s\t%10s\t%10s\t%20s\t%8s\t%7s\t%10s\t%s\n"
 
-func waitOnApplicationStatus(acdClient apiclient.Client, appName string, timeout uint, watchSync bool, watchHealth bool, watchOperation bool, watchSuspended bool, selectedResources []argoappv1.SyncOperationResource) (*argoappv1.Application, error) {
+func waitOnApplicationStatus(acdClient apiclient.Client, appName string, timeout uint, watchSync bool, watchHealth bool, watchOperation bool, watchSuspended bool, watchRevision string, selectedResources []argoappv1.SyncOperationResource) (*argoappv1.Application, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -1652,7 +2114,7 @@ func waitOnApplicationStatus(acdClient apiclient.Client, appName string, timeout
 			selectedResourcesAreReady = checkResourceStatus(watchSync, watchHealth, watchOperation, watchSuspended, app.Status.Health.Status, string(app.Status.Sync.Status), appEvent.Application.Operation)
 		}
 
-		if selectedResourcesAreReady {
+		if selectedResourcesAreReady && checkRevision(watchRevision, app.Status.Sync) {
 			printFinalStatus(app)
 			return app, nil
 		}
@@ -1664,7 +2126,7 @@ func waitOnApplicationStatus(acdClient apiclient.Client, appName string, timeout
 			if prevState, found := prevStates[stateKey]; found {
 				if watchHealth && prevState.Health != argoappv1.HealthStatusUnknown && prevState.Health != argoappv1.HealthStatusDegraded && newState.Health == argoappv1.HealthStatusDegraded {
 					printFinalStatus(app)
-					return nil, fmt.Errorf("application '%s' health state has transitioned from %s to %s", appName, prevState.Health, newState.Health)
+					return nil, &appWaitError{exitCode: waitExitCodeDegraded, message: fmt.Sprintf("application '%s' health state has transitioned from %s to %s", appName, prevState.Health, newState.Health)}
 				}
 				doPrint = prevState.Merge(newState)
 			} else {
@@ -1678,7 +2140,7 @@ func waitOnApplicationStatus(acdClient apiclient.Client, appName string, timeout
 		_ = w.Flush()
 	}
 	printFinalStatus(app)
-	return nil, fmt.Errorf("timed out (%ds) waiting for app %q match desired state", timeout, appName)
+	return nil, &appWaitError{exitCode: waitExitCodeTimeout, message: fmt.Sprintf("timed out (%ds) waiting for app %q match desired state", timeout, appName)}
 }
 
 // setParameterOverrides updates an existing or appends a new parameter override in the application
@@ -1776,10 +2238,16 @@ func printApplicationHistoryTable(revHistory []argoappv1.RevisionHistory) {
 func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
 		output string
+		id     int64
 	)
 	var command = &cobra.Command{
 		Use:   "history APPNAME",
 		Short: "Show application deployment history",
+		Example: `  # List the deployment history of an app
+  argocd app history guestbook
+
+  # Show the full source of a deployment history entry
+  argocd app history guestbook --id 3 -o yaml`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
@@ -1788,6 +2256,23 @@ func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra
 			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
 			defer util.Close(conn)
 			appName := args[0]
+			if c.Flags().Changed("id") {
+				entry, err := appIf.RevisionHistory(context.Background(), &applicationpkg.RevisionHistoryQuery{Name: &appName, Id: &id})
+				errors.CheckError(err)
+				switch output {
+				case "yaml", "json":
+					err := PrintResource(entry, output)
+					errors.CheckError(err)
+				case "wide", "":
+					printApplicationHistoryTable([]argoappv1.RevisionHistory{*entry})
+					fmt.Println()
+					err := PrintResource(entry.Source, "yaml")
+					errors.CheckError(err)
+				default:
+					log.Fatalf("Unknown output format: %s", output)
+				}
+				return
+			}
 			app, err := appIf.Get(context.Background(), &applicationpkg.ApplicationQuery{Name: &appName})
 			errors.CheckError(err)
 			if output == "id" {
@@ -1797,15 +2282,77 @@ func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra
 			}
 		},
 	}
-	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|id")
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|id, or json|yaml together with --id")
+	command.Flags().Int64Var(&id, "id", 0, "Show the deployment history entry with the given id, including its full source")
 	return command
 }
 
+// NewApplicationSyncTimelineCommand returns a new instance of an `argocd app sync-timeline` command
+func NewApplicationSyncTimelineCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var output string
+	var command = &cobra.Command{
+		Use:   "sync-timeline APPNAME",
+		Short: "Show when the resources and hooks of the last sync of an application were applied and finished",
+		Example: `  # Show the timeline of the last sync of an app
+  argocd app sync-timeline guestbook
+
+  # Show the timeline as JSON, e.g. to find the slowest resources
+  argocd app sync-timeline guestbook -o json`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			appName := args[0]
+			timeline, err := appIf.SyncTimeline(context.Background(), &applicationpkg.SyncTimelineQuery{Name: &appName})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResource(timeline, output)
+				errors.CheckError(err)
+			case "wide", "":
+				printSyncTimeline(timeline)
+			default:
+				log.Fatalf("Unknown output format: %s", output)
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
+
+// printSyncTimeline prints the timeline of a sync operation. The times are relative to the start of the operation.
+func printSyncTimeline(timeline *argoappv1.SyncTimeline) {
+	fmt.Printf(printOpFmtStr, "Phase:", timeline.Phase)
+	fmt.Printf(printOpFmtStr, "Revision:", timeline.Revision)
+	fmt.Printf(printOpFmtStr, "Started:", timeline.StartedAt)
+	if timeline.FinishedAt != nil {
+		fmt.Printf(printOpFmtStr, "Duration:", timeline.FinishedAt.Sub(timeline.StartedAt.Time))
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "APPLIED\tDURATION\tPHASE\tKIND\tNAMESPACE\tNAME\tDESTINATION\tSTATUS\tHOOK\n")
+	for _, e := range timeline.Entries {
+		applied, duration := "-", "-"
+		if e.AppliedAt != nil {
+			applied = "+" + (time.Duration(e.WaitSeconds) * time.Second).String()
+			if e.FinishedAt != nil {
+				duration = (time.Duration(e.DurationSeconds) * time.Second).String()
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", applied, duration, e.SyncPhase, e.Kind, e.Namespace, e.Name, e.Destination, e.Status, e.HookPhase)
+	}
+	_ = w.Flush()
+}
+
 // NewApplicationRollbackCommand returns a new instance of an `argocd app rollback` command
 func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
 		prune   bool
 		timeout uint
+		preview bool
 	)
 	var command = &cobra.Command{
 		Use:   "rollback APPNAME ID",
@@ -1835,6 +2382,17 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 				log.Fatalf("Application '%s' does not have deployment id '%d' in history\n", app.ObjectMeta.Name, depID)
 			}
 
+			if preview {
+				res, err := appIf.RollbackPreview(ctx, &applicationpkg.ApplicationRollbackRequest{
+					Name:  &appName,
+					ID:    int64(depID),
+					Prune: prune,
+				})
+				errors.CheckError(err)
+				printRollbackPreview(res.Items)
+				return
+			}
+
 			_, err = appIf.Rollback(ctx, &applicationpkg.ApplicationRollbackRequest{
 				Name:  &appName,
 				ID:    int64(depID),
@@ -1842,15 +2400,86 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 			})
 			errors.CheckError(err)
 
-			_, err = waitOnApplicationStatus(acdClient, appName, timeout, false, false, true, false, nil)
+			_, err = waitOnApplicationStatus(acdClient, appName, timeout, false, false, true, false, "", nil)
 			errors.CheckError(err)
 		},
 	}
 	command.Flags().BoolVar(&prune, "prune", false, "Allow deleting unexpected resources")
 	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
+	command.Flags().BoolVar(&preview, "preview", false, "Print the changes the rollback would make to the live state without performing the rollback")
 	return command
 }
 
+// NewApplicationWriteBackCommand returns a new instance of an `argocd app write-back` command
+func NewApplicationWriteBackCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		helmSets        []string
+		kustomizeImages []string
+		message         string
+	)
+	var command = &cobra.Command{
+		Use:   "write-back APPNAME",
+		Short: "Commit parameter overrides to the .argocd-source file of an application in the branch it tracks",
+		Example: `  # Update the image tag of a helm application
+  argocd app write-back guestbook --helm-set image.tag=v1.2.0
+
+  # Update the image of a kustomize application
+  argocd app write-back guestbook --kustomize-image gcr.io/heptio-images/ks-guestbook-demo:0.2 --message "Deploy 0.2"`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			var helmParameters []argoappv1.HelmParameter
+			for _, text := range helmSets {
+				p, err := argoappv1.NewHelmParameter(text, false)
+				errors.CheckError(err)
+				helmParameters = append(helmParameters, *p)
+			}
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			res, err := appIf.WriteBack(context.Background(), &applicationpkg.ApplicationWriteBackRequest{
+				Name:            &appName,
+				HelmParameters:  helmParameters,
+				KustomizeImages: kustomizeImages,
+				Message:         message,
+			})
+			errors.CheckError(err)
+			if res.Changed {
+				fmt.Printf("Committed parameters of application '%s' in revision %s\n", appName, res.Revision)
+			} else {
+				fmt.Printf("Revision %s already contains the parameters of application '%s'\n", res.Revision, appName)
+			}
+		},
+	}
+	command.Flags().StringArrayVar(&helmSets, "helm-set", []string{}, "Helm parameters to commit (can be repeated: --helm-set key1=val1 --helm-set key2=val2)")
+	command.Flags().StringArrayVar(&kustomizeImages, "kustomize-image", []string{}, "Kustomize images to commit (e.g. --kustomize-image node:8.15.0 --kustomize-image mysql=mariadb)")
+	command.Flags().StringVar(&message, "message", "", "Commit message")
+	return command
+}
+
+// printRollbackPreview prints the differences between the live state and the state after a rollback
+func printRollbackPreview(items []*argoappv1.ResourceDiff) {
+	if len(items) == 0 {
+		fmt.Println("Rollback does not change any resources")
+		return
+	}
+	for _, item := range items {
+		fmt.Printf("===== %s/%s %s/%s ======\n", item.Group, item.Kind, item.Namespace, item.Name)
+		var live, target *unstructured.Unstructured
+		errors.CheckError(json.Unmarshal([]byte(item.LiveState), &live))
+		errors.CheckError(json.Unmarshal([]byte(item.TargetState), &target))
+		if live != nil && target != nil {
+			live = &unstructured.Unstructured{}
+			errors.CheckError(json.Unmarshal([]byte(item.NormalizedLiveState), live))
+			target = &unstructured.Unstructured{}
+			errors.CheckError(json.Unmarshal([]byte(item.PredictedLiveState), target))
+		}
+		_ = diff.PrintDiff(item.Name, live, target)
+	}
+}
+
 const printOpFmtStr = "%-20s%s\n"
 const defaultCheckTimeoutSeconds = 0
 
@@ -1863,6 +2492,9 @@ func printOperationResult(opState *argoappv1.OperationState) {
 		fmt.Printf(printOpFmtStr, "Sync Revision:", opState.SyncResult.Revision)
 	}
 	fmt.Printf(printOpFmtStr, "Phase:", opState.Phase)
+	if opState.QueuePosition > 0 {
+		fmt.Printf(printOpFmtStr, "Queue Position:", strconv.FormatInt(opState.QueuePosition, 10))
+	}
 	fmt.Printf(printOpFmtStr, "Start:", opState.StartedAt)
 	fmt.Printf(printOpFmtStr, "Finished:", opState.FinishedAt)
 	var duration time.Duration
@@ -1880,12 +2512,19 @@ func printOperationResult(opState *argoappv1.OperationState) {
 // NewApplicationManifestsCommand returns a new instance of an `argocd app manifests` command
 func NewApplicationManifestsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		source   string
-		revision string
+		source     string
+		revision   string
+		parameters []string
 	)
 	var command = &cobra.Command{
 		Use:   "manifests APPNAME",
 		Short: "Print manifests of an application",
+		Example: `  # Print the manifests of the target revision of an application
+  argocd app manifests my-app
+
+  # Preview the manifests of an application at the head of a branch with overridden Helm parameters, without
+  # modifying the application
+  argocd app manifests my-app --revision feature-branch --set image.tag=v1.2.3 --set replicaCount=3`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
@@ -1901,10 +2540,11 @@ func NewApplicationManifestsCommand(clientOpts *argocdclient.ClientOptions) *cob
 			var unstructureds []*unstructured.Unstructured
 			switch source {
 			case "git":
-				if revision != "" {
+				if revision != "" || len(parameters) > 0 {
 					q := applicationpkg.ApplicationManifestQuery{
-						Name:     &appName,
-						Revision: revision,
+						Name:       &appName,
+						Revision:   revision,
+						Parameters: parameters,
 					}
 					res, err := appIf.GetManifests(ctx, &q)
 					errors.CheckError(err)
@@ -1936,14 +2576,302 @@ func NewApplicationManifestsCommand(clientOpts *argocdclient.ClientOptions) *cob
 	}
 	command.Flags().StringVar(&source, "source", "git", "Source of manifests. One of: live|git")
 	command.Flags().StringVar(&revision, "revision", "", "Show manifests at a specific revision")
+	command.Flags().StringArrayVar(&parameters, "set", []string{}, "Show manifests with a parameter override, e.g. name=value for Helm or component=param=value for Ksonnet applications (can be repeated)")
+	return command
+}
+
+// NewApplicationLogsCommand returns a new instance of an `argocd app logs` command
+func NewApplicationLogsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		podName       string
+		group         string
+		kind          string
+		resourceName  string
+		namespace     string
+		container     string
+		labelSelector string
+		sinceSeconds  int64
+		sinceTime     string
+		tailLines     int64
+		follow        bool
+		previous      bool
+		filter        string
+	)
+	var command = &cobra.Command{
+		Use:   "logs APPNAME",
+		Short: "Print the logs of the pods of an application",
+		Example: `  # Print the logs of a pod of an application
+  argocd app logs guestbook --pod guestbook-ui-6b5c7d9f4-2t8xk
+
+  # Follow the logs of all pods of a deployment, starting with the last 10 lines of every pod
+  argocd app logs guestbook --group apps --kind Deployment --name guestbook-ui --follow --tail 10
+
+  # Print the lines containing 'error' of the previous container instances of all pods of an application
+  argocd app logs guestbook --previous --filter error`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			query := applicationpkg.ApplicationPodLogsQuery{
+				Name:          &appName,
+				Namespace:     namespace,
+				Container:     container,
+				SinceSeconds:  sinceSeconds,
+				TailLines:     tailLines,
+				Follow:        follow,
+				Previous:      previous,
+				Filter:        filter,
+				Group:         group,
+				Kind:          kind,
+				ResourceName:  resourceName,
+				LabelSelector: labelSelector,
+			}
+			if podName != "" {
+				query.PodName = &podName
+			}
+			if sinceTime != "" {
+				t, err := time.Parse(time.RFC3339, sinceTime)
+				errors.CheckError(err)
+				mt := metav1.NewTime(t)
+				query.SinceTime = &mt
+			}
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			stream, err := appIf.PodLogs(context.Background(), &query)
+			errors.CheckError(err)
+			for {
+				entry, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				errors.CheckError(err)
+				if podName != "" {
+					fmt.Println(entry.Content)
+				} else {
+					fmt.Printf("%s %s\n", entry.PodName, entry.Content)
+				}
+			}
+		},
+	}
+	command.Flags().StringVar(&podName, "pod", "", "Name of the pod to print the logs of")
+	command.Flags().StringVar(&group, "group", "", "Group of the resource to print the logs of the pods of")
+	command.Flags().StringVar(&kind, "kind", "", "Kind of the resource to print the logs of the pods of")
+	command.Flags().StringVar(&resourceName, "name", "", "Name of the resource to print the logs of the pods of")
+	command.Flags().StringVar(&namespace, "namespace", "", "Namespace of the pods")
+	command.Flags().StringVarP(&container, "container", "c", "", "Name of the container")
+	command.Flags().StringVarP(&labelSelector, "selector", "l", "", "Only print the logs of the pods matching the label selector")
+	command.Flags().Int64Var(&sinceSeconds, "since-seconds", 0, "Only print the logs newer than a relative duration in seconds")
+	command.Flags().StringVar(&sinceTime, "since-time", "", "Only print the logs newer than a date (RFC3339)")
+	command.Flags().Int64Var(&tailLines, "tail", 0, "Number of lines of the most recent logs to print for every pod")
+	command.Flags().BoolVarP(&follow, "follow", "f", false, "Stream the logs")
+	command.Flags().BoolVarP(&previous, "previous", "p", false, "Print the logs of the previous container instances")
+	command.Flags().StringVar(&filter, "filter", "", "Only print the log lines matching the regular expression")
+	return command
+}
+
+// NewApplicationEventsCommand returns a new instance of an `argocd app events` command
+func NewApplicationEventsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		fieldSelector string
+		namespace     string
+		watch         bool
+	)
+	var command = &cobra.Command{
+		Use:   "events APPNAME",
+		Short: "Print the events of an application and of all of its resources",
+		Example: `  # Print the warning events of an application and its resources
+  argocd app events guestbook --field-selector type=Warning
+
+  # Stream the events of the resources of an application in a namespace
+  argocd app events guestbook --namespace default --watch`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			query := applicationpkg.ApplicationTreeEventsQuery{
+				Name:              &appName,
+				FieldSelector:     fieldSelector,
+				ResourceNamespace: namespace,
+			}
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			printEvent := func(event *corev1.Event) {
+				object := event.InvolvedObject
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%s\t%s\n", event.LastTimestamp.Format(time.RFC3339), event.Type, event.Reason, object.Kind, object.Name, object.Namespace, event.Message)
+			}
+			_, _ = fmt.Fprintf(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tNAMESPACE\tMESSAGE\n")
+			if !watch {
+				events, err := appIf.ListTreeEvents(context.Background(), &query)
+				errors.CheckError(err)
+				for i := range events.Items {
+					printEvent(&events.Items[i])
+				}
+				_ = w.Flush()
+				return
+			}
+			stream, err := appIf.WatchTreeEvents(context.Background(), &query)
+			errors.CheckError(err)
+			_ = w.Flush()
+			for {
+				update, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				errors.CheckError(err)
+				printEvent(&update.Event)
+				_ = w.Flush()
+			}
+		},
+	}
+	command.Flags().StringVar(&fieldSelector, "field-selector", "", "Only print the events matching the field selector, e.g. type=Warning")
+	command.Flags().StringVar(&namespace, "namespace", "", "Only print the events of the resources in the namespace")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "Stream the events")
+	return command
+}
+
+// NewApplicationLinksCommand returns a new instance of an `argocd app links` command
+func NewApplicationLinksCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		resourceName string
+		kind         string
+		group        string
+		namespace    string
+	)
+	var command = &cobra.Command{
+		Use:   "links APPNAME",
+		Short: "Print the deep links of an application or of one of its resources",
+		Example: `  # Print the deep links of an application
+  argocd app links guestbook
+
+  # Print the deep links of a pod of an application
+  argocd app links guestbook --kind Pod --namespace default --resource-name guestbook-ui-7c5d9`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			var links []applicationpkg.LinkInfo
+			if kind != "" || resourceName != "" {
+				res, err := appIf.GetResource(context.Background(), &applicationpkg.ApplicationResourceRequest{
+					Name:         &appName,
+					Namespace:    namespace,
+					ResourceName: resourceName,
+					Group:        group,
+					Kind:         kind,
+				})
+				errors.CheckError(err)
+				links = res.Links
+			} else {
+				res, err := appIf.ListLinks(context.Background(), &applicationpkg.ListAppLinksRequest{Name: &appName})
+				errors.CheckError(err)
+				links = res.Items
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "TITLE\tURL\tDESCRIPTION\n")
+			for _, link := range links {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", link.Title, link.Url, link.Description)
+			}
+			_ = w.Flush()
+		},
+	}
+	command.Flags().StringVar(&resourceName, "resource-name", "", "Name of resource")
+	command.Flags().StringVar(&kind, "kind", "", "Kind")
+	command.Flags().StringVar(&group, "group", "", "Group")
+	command.Flags().StringVar(&namespace, "namespace", "", "Namespace")
+	return command
+}
+
+// NewApplicationAttestationCommand returns a new instance of an `argocd app attestation` command
+func NewApplicationAttestationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		revision      string
+		publicKeyPath string
+		output        string
+	)
+	var command = &cobra.Command{
+		Use:   "attestation APPNAME",
+		Short: "Print the signed provenance of the manifests of an application",
+		Long: `Print the signed provenance of the manifests of an application.
+
+The repo server records the repository, revision, parameters and tool versions the manifests were generated with in an
+in-toto statement with a SLSA provenance predicate, signed in a DSSE envelope, if it runs with --attestation-signing-key.
+With --public-key, the signature and the digests of the manifests are verified, except the ones of secrets, whose data is
+hidden by the API server.`,
+		Example: `  # Print the provenance of the manifests of the target revision of an application
+  argocd app attestation guestbook
+
+  # Verify the provenance of the manifests of a revision
+  argocd app attestation guestbook --revision 8c1f5e2 --public-key attestation.pub`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			res, err := appIf.GetManifests(context.Background(), &applicationpkg.ApplicationManifestQuery{Name: &appName, Revision: revision})
+			errors.CheckError(err)
+			if res.Attestation == "" {
+				log.Fatal("The manifests are not attested, the repo server does not sign attestations")
+			}
+			var envelope attestation.Envelope
+			errors.CheckError(json.Unmarshal([]byte(res.Attestation), &envelope))
+			var statement *attestation.Statement
+			if publicKeyPath != "" {
+				keyPEM, err := ioutil.ReadFile(publicKeyPath)
+				errors.CheckError(err)
+				publicKey, err := attestation.ParsePublicKey(keyPEM)
+				errors.CheckError(err)
+				statement, err = attestation.Verify(&envelope, publicKey)
+				errors.CheckError(err)
+				errors.CheckError(statement.VerifyManifests(res.Manifests, func(manifest string) bool {
+					obj, err := argoappv1.UnmarshalToUnstructured(manifest)
+					return err == nil && obj.GetKind() == kube.SecretKind && obj.GroupVersionKind().Group == ""
+				}))
+			} else {
+				statement, err = envelope.Statement()
+				errors.CheckError(err)
+			}
+			var data interface{}
+			switch output {
+			case "statement", "":
+				data = statement
+			case "envelope":
+				data = envelope
+			default:
+				log.Fatalf("Unknown output format: %s", output)
+			}
+			jsonBytes, err := json.MarshalIndent(data, "", "  ")
+			errors.CheckError(err)
+			fmt.Println(string(jsonBytes))
+		},
+	}
+	command.Flags().StringVar(&revision, "revision", "", "Revision of the manifests (default the target revision of the application)")
+	command.Flags().StringVar(&publicKeyPath, "public-key", "", "Path of the PEM encoded public key to verify the attestation with")
+	command.Flags().StringVarP(&output, "output", "o", "statement", "Output format. One of: statement|envelope")
 	return command
 }
 
 // NewApplicationTerminateOpCommand returns a new instance of an `argocd app terminate-op` command
 func NewApplicationTerminateOpCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var force bool
 	var command = &cobra.Command{
 		Use:   "terminate-op APPNAME",
 		Short: "Terminate running operation of an application",
+		Example: `  # Request the controller to terminate the running operation of an app
+  argocd app terminate-op guestbook
+
+  # Terminate an operation which is stuck, e.g. after a controller crash, deleting its hooks and marking it as failed
+  argocd app terminate-op guestbook --force`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
@@ -1953,11 +2881,16 @@ func NewApplicationTerminateOpCommand(clientOpts *argocdclient.ClientOptions) *c
 			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
 			defer util.Close(conn)
 			ctx := context.Background()
-			_, err := appIf.TerminateOperation(ctx, &applicationpkg.OperationTerminateRequest{Name: &appName})
+			_, err := appIf.TerminateOperation(ctx, &applicationpkg.OperationTerminateRequest{Name: &appName, Force: force})
 			errors.CheckError(err)
-			fmt.Printf("Application '%s' operation terminating\n", appName)
+			if force {
+				fmt.Printf("Application '%s' operation terminated\n", appName)
+			} else {
+				fmt.Printf("Application '%s' operation terminating\n", appName)
+			}
 		},
 	}
+	command.Flags().BoolVar(&force, "force", false, "Terminate the operation immediately, deleting its hook resources and clearing the operation state, instead of waiting for the controller")
 	return command
 }
 
@@ -2013,7 +2946,10 @@ func NewApplicationPatchCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
 	argocd app patch myapplication --patch='[{"op": "replace", "path": "/spec/source/path", "value": "newPath"}]' --type json
 
 	# Update an application's repository target revision using merge patch
-	argocd app patch myapplication --patch '{"spec": { "source": { "targetRevision": "master" } }}' --type merge`,
+	argocd app patch myapplication --patch '{"spec": { "source": { "targetRevision": "master" } }}' --type merge
+
+	# Add a sync option to an application using strategic merge patch
+	argocd app patch myapplication --patch '{"spec": { "syncPolicy": { "syncOptions": ["CreateNamespace=true"] } }}' --type strategic`,
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
@@ -2038,7 +2974,7 @@ func NewApplicationPatchCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
 	}
 
 	command.Flags().StringVar(&patch, "patch", "", "Patch body")
-	command.Flags().StringVar(&patchType, "type", "json", "The type of patch being provided; one of [json merge]")
+	command.Flags().StringVar(&patchType, "type", "json", "The type of patch being provided; one of [json merge strategic]")
 	return &command
 }
 
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util"
+)
+
+const (
+	treeBranch     = "├─"
+	treeLastBranch = "└─"
+	treeIndent     = "│ "
+	treeLastIndent = "  "
+)
+
+// NewApplicationListResourcesCommand returns a new instance of an `argocd app resources` command
+func NewApplicationListResourcesCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var orphaned bool
+	var command = &cobra.Command{
+		Use:   "resources APPNAME",
+		Short: "List the top level resources of an application, as well as its orphaned resources",
+		Example: `  # List the resources of an app
+  argocd app resources guestbook
+
+  # List only the orphaned resources of an app
+  argocd app resources guestbook --orphaned`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			tree, err := appIf.ResourceTree(context.Background(), &applicationpkg.ResourcesQuery{ApplicationName: &appName})
+			errors.CheckError(err)
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			printResources(w, tree, orphaned)
+			_ = w.Flush()
+		},
+	}
+	command.Flags().BoolVar(&orphaned, "orphaned", false, "List only the orphaned resources")
+	return command
+}
+
+func printResources(w io.Writer, tree *argoappv1.ApplicationTree, orphanedOnly bool) {
+	_, _ = fmt.Fprintf(w, "GROUP\tKIND\tNAMESPACE\tNAME\tORPHANED\n")
+	if !orphanedOnly {
+		for _, node := range sortedNodes(tree.Nodes) {
+			if len(node.ParentRefs) == 0 {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\tNo\n", node.Group, node.Kind, node.Namespace, node.Name)
+			}
+		}
+	}
+	for _, node := range sortedNodes(tree.OrphanedNodes) {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\tYes\n", node.Group, node.Kind, node.Namespace, node.Name)
+	}
+}
+
+// printTreeView prints the resources of an app as a tree of parent and child resources, with the sync status of the
+// resources managed by the app and the health of every resource
+func printTreeView(w io.Writer, app *argoappv1.Application, tree *argoappv1.ApplicationTree) {
+	children := make(map[string][]argoappv1.ResourceNode)
+	uids := make(map[string]bool)
+	for _, node := range tree.Nodes {
+		uids[node.UID] = true
+	}
+	var roots []argoappv1.ResourceNode
+	for _, node := range tree.Nodes {
+		isRoot := true
+		for _, parent := range node.ParentRefs {
+			if uids[parent.UID] {
+				children[parent.UID] = append(children[parent.UID], node)
+				isRoot = false
+			}
+		}
+		if isRoot {
+			roots = append(roots, node)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "NAME\tNAMESPACE\tSTATUS\tHEALTH\tMESSAGE\n")
+	for _, res := range app.Status.Resources {
+		// resources which are missing in the cluster are not part of the tree
+		if tree.FindNode(res.Group, res.Kind, res.Namespace, res.Name) == nil {
+			health, message := "", ""
+			if res.Health != nil {
+				health, message = res.Health.Status, res.Health.Message
+			}
+			_, _ = fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\t%s\n", res.Kind, res.Name, res.Namespace, res.Status, health, message)
+		}
+	}
+	visited := make(map[string]bool)
+	var printNode func(node argoappv1.ResourceNode, prefix string, branch string)
+	printNode = func(node argoappv1.ResourceNode, prefix string, branch string) {
+		if visited[node.UID] {
+			return
+		}
+		visited[node.UID] = true
+		status := ""
+		for _, res := range app.Status.Resources {
+			if res.Group == node.Group && res.Kind == node.Kind && res.Namespace == node.Namespace && res.Name == node.Name {
+				status = string(res.Status)
+			}
+		}
+		health, message := "", ""
+		if node.Health != nil {
+			health, message = node.Health.Status, node.Health.Message
+		}
+		_, _ = fmt.Fprintf(w, "%s%s%s/%s\t%s\t%s\t%s\t%s\n", prefix, branch, node.Kind, node.Name, node.Namespace, status, health, message)
+
+		childPrefix := prefix
+		switch branch {
+		case treeBranch:
+			childPrefix += treeIndent
+		case treeLastBranch:
+			childPrefix += treeLastIndent
+		}
+		nodeChildren := sortedNodes(children[node.UID])
+		for i, child := range nodeChildren {
+			childBranch := treeBranch
+			if i == len(nodeChildren)-1 {
+				childBranch = treeLastBranch
+			}
+			printNode(child, childPrefix, childBranch)
+		}
+	}
+	for _, root := range sortedNodes(roots) {
+		printNode(root, "", "")
+	}
+}
+
+func sortedNodes(nodes []argoappv1.ResourceNode) []argoappv1.ResourceNode {
+	res := append([]argoappv1.ResourceNode{}, nodes...)
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
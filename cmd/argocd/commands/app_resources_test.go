@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func testTree() *v1alpha1.ApplicationTree {
+	return &v1alpha1.ApplicationTree{
+		Nodes: []v1alpha1.ResourceNode{{
+			ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook", UID: "1"},
+			Health:      &v1alpha1.HealthStatus{Status: "Healthy"},
+		}, {
+			ResourceRef: v1alpha1.ResourceRef{Kind: "Pod", Namespace: "default", Name: "guestbook-1-a", UID: "3"},
+			ParentRefs:  []v1alpha1.ResourceRef{{Group: "apps", Kind: "ReplicaSet", Namespace: "default", Name: "guestbook-1", UID: "2"}},
+			Health:      &v1alpha1.HealthStatus{Status: "Progressing", Message: "Pulling image"},
+		}, {
+			ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "ReplicaSet", Namespace: "default", Name: "guestbook-1", UID: "2"},
+			ParentRefs:  []v1alpha1.ResourceRef{{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook", UID: "1"}},
+		}, {
+			ResourceRef: v1alpha1.ResourceRef{Kind: "Pod", Namespace: "default", Name: "guestbook-1-b", UID: "4"},
+			ParentRefs:  []v1alpha1.ResourceRef{{Group: "apps", Kind: "ReplicaSet", Namespace: "default", Name: "guestbook-1", UID: "2"}},
+		}},
+		OrphanedNodes: []v1alpha1.ResourceNode{{
+			ResourceRef: v1alpha1.ResourceRef{Kind: "ConfigMap", Namespace: "default", Name: "leftover", UID: "5"},
+		}},
+	}
+}
+
+func Test_printTreeView(t *testing.T) {
+	app := &v1alpha1.Application{Status: v1alpha1.ApplicationStatus{Resources: []v1alpha1.ResourceStatus{
+		{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeSynced},
+		{Kind: "Service", Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeOutOfSync, Health: &v1alpha1.HealthStatus{Status: "Missing"}},
+	}}}
+
+	var out bytes.Buffer
+	w := tabwriter.NewWriter(&out, 0, 0, 1, ' ', 0)
+	printTreeView(w, app, testTree())
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, `NAME                     NAMESPACE STATUS    HEALTH      MESSAGE
+Service/guestbook        default   OutOfSync Missing     
+Deployment/guestbook     default   Synced    Healthy     
+└─ReplicaSet/guestbook-1 default                         
+  ├─Pod/guestbook-1-a    default             Progressing Pulling image
+  └─Pod/guestbook-1-b    default                         
+`, out.String())
+}
+
+func Test_printResources(t *testing.T) {
+	var out bytes.Buffer
+	w := tabwriter.NewWriter(&out, 0, 0, 1, ' ', 0)
+	printResources(w, testTree(), false)
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, `GROUP KIND       NAMESPACE NAME      ORPHANED
+apps  Deployment default   guestbook No
+      ConfigMap  default   leftover  Yes
+`, out.String())
+
+	out.Reset()
+	printResources(w, testTree(), true)
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, `GROUP KIND      NAMESPACE NAME     ORPHANED
+      ConfigMap default   leftover Yes
+`, out.String())
+}
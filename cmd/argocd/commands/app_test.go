@@ -102,3 +102,139 @@ func Test_setAppSpecOptions(t *testing.T) {
 		assert.Nil(t, f.spec.SyncPolicy)
 	})
 }
+
+func Test_checkRevision(t *testing.T) {
+	sha := "2b7f9ab1b0c5c1b5a87c6c9e4d3a2f1e0d9c8b7a"
+	synced := v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeSynced, Revision: sha}
+	assert.True(t, checkRevision("", synced))
+	assert.True(t, checkRevision(sha, synced))
+	assert.True(t, checkRevision("2b7f9ab", synced))
+	assert.False(t, checkRevision("2b7f", synced))
+	assert.False(t, checkRevision("3c8f9ab", synced))
+	assert.False(t, checkRevision(sha, v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeOutOfSync, Revision: sha}))
+	assert.True(t, checkRevision("1.2.0", v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeSynced, Revision: "1.2.0"}))
+}
+
+func Test_getResourcesByLabels(t *testing.T) {
+	manifests := []string{
+		`{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "web", "labels": {"tier": "web"}}}`,
+		`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "web", "labels": {"tier": "web", "track": "canary"}}}`,
+		`{"apiVersion": "apps/v1", "kind": "StatefulSet", "metadata": {"name": "db", "labels": {"tier": "db"}}}`,
+	}
+
+	selectors, err := parseLabelSelectors([]string{"tier=web,track!=canary"})
+	assert.NoError(t, err)
+	resources, err := getResourcesByLabels(manifests, selectors)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{":Service:web"}, resources)
+
+	selectors, err = parseLabelSelectors([]string{"tier in (web,db)", "tier=web"})
+	assert.NoError(t, err)
+	resources, err = getResourcesByLabels(manifests, selectors)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{":Service:web", "apps:Deployment:web", "apps:StatefulSet:db"}, resources)
+
+	_, err = parseLabelSelectors([]string{"tier in web"})
+	assert.Error(t, err)
+}
+
+func Test_unmarshalApps(t *testing.T) {
+	t.Run("MultiDocument", func(t *testing.T) {
+		apps, err := unmarshalApps([]byte(`apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: guestbook
+spec:
+  project: default
+---
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: helm-guestbook
+spec:
+  source:
+    path: helm-guestbook
+`))
+		assert.NoError(t, err)
+		if assert.Len(t, apps, 2) {
+			assert.Equal(t, "guestbook", apps[0].Name)
+			assert.Equal(t, "default", apps[0].Spec.Project)
+			assert.Equal(t, "helm-guestbook", apps[1].Name)
+			assert.Equal(t, "helm-guestbook", apps[1].Spec.Source.Path)
+		}
+	})
+	t.Run("JSONList", func(t *testing.T) {
+		apps, err := unmarshalApps([]byte(`{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "argoproj.io/v1alpha1", "kind": "Application", "metadata": {"name": "guestbook"}}
+]}`))
+		assert.NoError(t, err)
+		if assert.Len(t, apps, 1) {
+			assert.Equal(t, "guestbook", apps[0].Name)
+		}
+	})
+	t.Run("UnexpectedKind", func(t *testing.T) {
+		_, err := unmarshalApps([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "guestbook"}}`))
+		assert.Error(t, err)
+	})
+}
+
+func Test_unset(t *testing.T) {
+	t.Run("Helm", func(t *testing.T) {
+		source := &v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{
+			ValueFiles: []string{"values-prod.yaml"},
+			Values:     "foo: bar",
+			Parameters: []v1alpha1.HelmParameter{{Name: "image.tag", Value: "v1"}, {Name: "replicas", Value: "2"}},
+		}}
+		updated, err := unset(source, unsetOpts{parameters: []string{"image.tag"}})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Equal(t, []v1alpha1.HelmParameter{{Name: "replicas", Value: "2"}}, source.Helm.Parameters)
+
+		updated, err = unset(source, unsetOpts{valuesFiles: []string{"values-prod.yaml"}, valuesLiteral: true})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Empty(t, source.Helm.ValueFiles)
+		assert.Empty(t, source.Helm.Values)
+
+		updated, err = unset(source, unsetOpts{valuesFiles: []string{"values-dev.yaml"}})
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		updated, err = unset(source, unsetOpts{parameters: []string{"replicas"}})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Nil(t, source.Helm)
+	})
+	t.Run("Kustomize", func(t *testing.T) {
+		source := &v1alpha1.ApplicationSource{Kustomize: &v1alpha1.ApplicationSourceKustomize{
+			NamePrefix:   "prefix-",
+			NameSuffix:   "-suffix",
+			Images:       v1alpha1.KustomizeImages{"nginx:1.17", "mysql=my-registry/mysql:8"},
+			CommonLabels: map[string]string{"env": "prod", "team": "a"},
+		}}
+		updated, err := unset(source, unsetOpts{namePrefix: true, kustomizeImages: []string{"mysql"}, kustomizeCommonLabels: []string{"env"}})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Empty(t, source.Kustomize.NamePrefix)
+		assert.Equal(t, "-suffix", source.Kustomize.NameSuffix)
+		assert.Equal(t, v1alpha1.KustomizeImages{"nginx:1.17"}, source.Kustomize.Images)
+		assert.Equal(t, map[string]string{"team": "a"}, source.Kustomize.CommonLabels)
+
+		updated, err = unset(source, unsetOpts{kustomizeImages: []string{"ngin"}})
+		assert.NoError(t, err)
+		assert.False(t, updated)
+	})
+	t.Run("Ksonnet", func(t *testing.T) {
+		source := &v1alpha1.ApplicationSource{Ksonnet: &v1alpha1.ApplicationSourceKsonnet{
+			Parameters: []v1alpha1.KsonnetParameter{{Component: "guestbook", Name: "image", Value: "v1"}},
+		}}
+		_, err := unset(source, unsetOpts{parameters: []string{"image"}})
+		assert.Error(t, err)
+
+		updated, err := unset(source, unsetOpts{parameters: []string{"guestbook=image"}})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Empty(t, source.Ksonnet.Parameters)
+	})
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/errors"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	applicationtemplatepkg "github.com/argoproj/argo-cd/pkg/apiclient/applicationtemplate"
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util"
+)
+
+// NewApplicationTemplateCommand returns a new instance of an `argocd apptemplate` command
+func NewApplicationTemplateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "apptemplate",
+		Short: "List application templates and create applications from them",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+
+	command.AddCommand(NewApplicationTemplateListCommand(clientOpts))
+	command.AddCommand(NewApplicationTemplateGetCommand(clientOpts))
+	command.AddCommand(NewApplicationTemplateInstantiateCommand(clientOpts))
+	return command
+}
+
+// NewApplicationTemplateListCommand returns a new instance of an `argocd apptemplate list` command
+func NewApplicationTemplateListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output string
+	)
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List application templates",
+		Run: func(c *cobra.Command, args []string) {
+			conn, templateIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationTemplateClientOrDie()
+			defer util.Close(conn)
+			templates, err := templateIf.List(context.Background(), &applicationtemplatepkg.ApplicationTemplateQuery{})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResourceList(templates.Items, output, false)
+				errors.CheckError(err)
+			case "name":
+				for _, tmpl := range templates.Items {
+					fmt.Println(tmpl.Name)
+				}
+			case "wide", "":
+				printApplicationTemplateTable(templates.Items)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|name")
+	return command
+}
+
+// NewApplicationTemplateGetCommand returns a new instance of an `argocd apptemplate get` command
+func NewApplicationTemplateGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output string
+	)
+	var command = &cobra.Command{
+		Use:   "get TEMPLATE",
+		Short: "Get the details of an application template, including its parameters",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, templateIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationTemplateClientOrDie()
+			defer util.Close(conn)
+			tmpl, err := templateIf.Get(context.Background(), &applicationtemplatepkg.ApplicationTemplateQuery{Name: args[0]})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResource(tmpl, output)
+				errors.CheckError(err)
+			case "wide", "":
+				fmt.Printf(printOpFmtStr, "Name:", tmpl.Name)
+				fmt.Printf(printOpFmtStr, "Description:", tmpl.Spec.Description)
+				fmt.Printf(printOpFmtStr, "Project:", tmpl.Spec.Template.Spec.GetProject())
+				fmt.Println()
+				printApplicationTemplateParameters(tmpl.Spec.Parameters)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
+
+// NewApplicationTemplateInstantiateCommand returns a new instance of an `argocd apptemplate instantiate` command
+func NewApplicationTemplateInstantiateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		params []string
+		dryRun bool
+		output string
+	)
+	var command = &cobra.Command{
+		Use:   "instantiate TEMPLATE APPNAME",
+		Short: "Create an application from an application template",
+		Example: `  # Create the application my-service from the template web-service
+  argocd apptemplate instantiate web-service my-service --param image=nginx:1.19 --param replicas=2
+
+  # Print the application without creating it
+  argocd apptemplate instantiate web-service my-service --param image=nginx:1.19 --dry-run -o yaml`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			values := make(map[string]string)
+			for _, param := range params {
+				parts := strings.SplitN(param, "=", 2)
+				if len(parts) != 2 {
+					errors.CheckError(fmt.Errorf("expected parameter of the form: param=value, but got: %s", param))
+				}
+				values[parts[0]] = parts[1]
+			}
+			conn, templateIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationTemplateClientOrDie()
+			defer util.Close(conn)
+			app, err := templateIf.Instantiate(context.Background(), &applicationtemplatepkg.ApplicationTemplateInstantiateRequest{
+				Template:   args[0],
+				Name:       args[1],
+				Parameters: values,
+				DryRun:     dryRun,
+			})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				err := PrintResource(app, output)
+				errors.CheckError(err)
+			case "":
+				if dryRun {
+					fmt.Printf("application '%s' is valid\n", app.Name)
+				} else {
+					fmt.Printf("application '%s' created\n", app.Name)
+				}
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringArrayVarP(&params, "param", "p", []string{}, "set a parameter of the template (e.g. -p replicas=2)")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "render the application without creating it")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml")
+	return command
+}
+
+// printApplicationTemplateTable prints a table of application templates
+func printApplicationTemplateTable(templates []argoappv1.ApplicationTemplate) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tPROJECT\tPARAMETERS\tDESCRIPTION\n")
+	for _, tmpl := range templates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", tmpl.Name, tmpl.Spec.Template.Spec.GetProject(), len(tmpl.Spec.Parameters), tmpl.Spec.Description)
+	}
+	_ = w.Flush()
+}
+
+// printApplicationTemplateParameters prints a table of the parameters of an application template
+func printApplicationTemplateParameters(params []argoappv1.ApplicationTemplateParameter) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PARAMETER\tREQUIRED\tDEFAULT\tALLOWED\tDESCRIPTION\n")
+	for _, param := range params {
+		allowed := strings.Join(param.Enum, ",")
+		if param.Pattern != "" {
+			allowed = param.Pattern
+		}
+		fmt.Fprintf(w, "%s\t%v\t%s\t%s\t%s\n", param.Name, param.Required, param.Default, allowed, param.Description)
+	}
+	_ = w.Flush()
+}
@@ -17,7 +17,7 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	certutil "github.com/argoproj/argo-cd/util/cert"
 
-	"crypto/x509"
+	"crypto/sha256"
 )
 
 // NewCertCommand returns a new instance of an `argocd repo` command
@@ -60,6 +60,7 @@ func NewCertAddTLSCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 	var (
 		fromFile string
 		upsert   bool
+		dryRun   bool
 	)
 	var command = &cobra.Command{
 		Use:   "add-tls SERVERNAME",
@@ -87,34 +88,44 @@ func NewCertAddTLSCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 			errors.CheckError(err)
 
 			certificateList := make([]appsv1.RepositoryCertificate, 0)
+			serverName := args[0]
 
-			subjectMap := make(map[string]*x509.Certificate)
-
+			// We want to make sure to only send valid certificate data to the
+			// server, so we decode each certificate into X509 structure before
+			// further processing it. Certificates appearing more than once in
+			// the input, as they often do in concatenated bundles, are only
+			// sent once.
+			var pemCertificates []string
+			var subjects []string
+			fingerprints := make(map[[sha256.Size]byte]bool)
 			for _, entry := range certificateArray {
-				// We want to make sure to only send valid certificate data to the
-				// server, so we decode the certificate into X509 structure before
-				// further processing it.
 				x509cert, err := certutil.DecodePEMCertificateToX509(entry)
 				errors.CheckError(err)
-
-				// TODO: We need a better way to detect duplicates sent in the stream,
-				// maybe by using fingerprints? For now, no two certs with the same
-				// subject may be sent.
-				if subjectMap[x509cert.Subject.String()] != nil {
-					fmt.Printf("ERROR: Cert with subject '%s' already seen in the input stream.\n", x509cert.Subject.String())
+				fingerprint := sha256.Sum256(x509cert.Raw)
+				if fingerprints[fingerprint] {
+					fmt.Printf("Skipping duplicate certificate with subject '%s'\n", x509cert.Subject.String())
 					continue
-				} else {
-					subjectMap[x509cert.Subject.String()] = x509cert
 				}
+				fingerprints[fingerprint] = true
+				pemCertificates = append(pemCertificates, entry)
+				subjects = append(subjects, x509cert.Subject.String())
 			}
 
-			serverName := args[0]
-
-			if len(certificateArray) > 0 {
+			if len(pemCertificates) > 0 {
+				if dryRun {
+					existing, err := certIf.ListCertificates(context.Background(), &certificatepkg.RepositoryCertificateQuery{HostNamePattern: serverName, CertType: "https"})
+					errors.CheckError(err)
+					var desired []appsv1.RepositoryCertificate
+					for _, subject := range subjects {
+						desired = append(desired, appsv1.RepositoryCertificate{ServerName: serverName, CertType: "https", CertInfo: subject})
+					}
+					printCertDiff(existing.Items, desired, upsert)
+					return
+				}
 				certificateList = append(certificateList, appsv1.RepositoryCertificate{
 					ServerName: serverName,
 					CertType:   "https",
-					CertData:   []byte(strings.Join(certificateArray, "\n")),
+					CertData:   []byte(strings.Join(pemCertificates, "\n")),
 				})
 				certificates, err := certIf.CreateCertificate(context.Background(), &certificatepkg.RepositoryCertificateCreateRequest{
 					Certificates: &appsv1.RepositoryCertificateList{
@@ -131,6 +142,7 @@ func NewCertAddTLSCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 	}
 	command.Flags().StringVar(&fromFile, "from", "", "read TLS certificate data from file (default is to read from stdin)")
 	command.Flags().BoolVar(&upsert, "upsert", false, "Replace existing TLS certificate if certificate is different in input")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes which would be made to the existing certificates of the server without making them")
 	return command
 }
 
@@ -140,6 +152,7 @@ func NewCertAddSSHCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 		fromFile     string
 		batchProcess bool
 		upsert       bool
+		dryRun       bool
 		certificates []appsv1.RepositoryCertificate
 	)
 
@@ -173,23 +186,40 @@ func NewCertAddSSHCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 				errors.CheckError(fmt.Errorf("No valid SSH known hosts data found."))
 			}
 
+			seen := make(map[string]bool)
 			for _, knownHostsEntry := range sshKnownHostsLists {
 				_, certSubType, certData, err := certutil.TokenizeSSHKnownHostsEntry(knownHostsEntry)
 				errors.CheckError(err)
-				hostnameList, _, err := certutil.KnownHostsLineToPublicKey(knownHostsEntry)
+				hostnameList, publicKey, err := certutil.KnownHostsLineToPublicKey(knownHostsEntry)
 				errors.CheckError(err)
+				fingerprint := "SHA256:" + certutil.SSHFingerprintSHA256(publicKey)
 				// Each key could be valid for multiple hostnames
 				for _, hostname := range hostnameList {
+					// Known hosts files collected from many machines usually contain the
+					// same entry several times, which is only sent once.
+					key := fmt.Sprintf("%s %s %s", hostname, certSubType, fingerprint)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
 					certificate := appsv1.RepositoryCertificate{
 						ServerName:  hostname,
 						CertType:    "ssh",
 						CertSubType: certSubType,
 						CertData:    certData,
+						CertInfo:    fingerprint,
 					}
 					certificates = append(certificates, certificate)
 				}
 			}
 
+			if dryRun {
+				existing, err := certIf.ListCertificates(context.Background(), &certificatepkg.RepositoryCertificateQuery{CertType: "ssh"})
+				errors.CheckError(err)
+				printCertDiff(existing.Items, certificates, upsert)
+				return
+			}
+
 			certList := &appsv1.RepositoryCertificateList{Items: certificates}
 			response, err := certIf.CreateCertificate(context.Background(), &certificatepkg.RepositoryCertificateCreateRequest{
 				Certificates: certList,
@@ -202,6 +232,7 @@ func NewCertAddSSHCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 	command.Flags().StringVar(&fromFile, "from", "", "Read SSH known hosts data from file (default is to read from stdin)")
 	command.Flags().BoolVar(&batchProcess, "batch", false, "Perform batch processing by reading in SSH known hosts data (mandatory flag)")
 	command.Flags().BoolVar(&upsert, "upsert", false, "Replace existing SSH server public host keys if key is different in input")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes which would be made to the existing SSH known host entries without making them")
 	return command
 }
 
@@ -320,3 +351,74 @@ func printCertTable(certs []appsv1.RepositoryCertificate, sortOrder string) {
 	}
 	_ = w.Flush()
 }
+
+// certDiffKey returns the key under which existing and new certificates are compared. SSH known host entries are
+// unique per host name and key type, while all TLS certificates of a server are stored as a single bundle.
+func certDiffKey(cert appsv1.RepositoryCertificate) string {
+	if cert.CertType == "ssh" {
+		return fmt.Sprintf("%s/%s/%s", cert.CertType, cert.ServerName, cert.CertSubType)
+	}
+	return fmt.Sprintf("%s/%s", cert.CertType, cert.ServerName)
+}
+
+// certDiff returns the changes adding the desired certificates would make to the existing ones, as lists of the keys
+// which would be added, updated, and left unchanged
+func certDiff(existing []appsv1.RepositoryCertificate, desired []appsv1.RepositoryCertificate) ([]string, []string, []string) {
+	infos := func(certs []appsv1.RepositoryCertificate) map[string][]string {
+		res := make(map[string][]string)
+		for _, cert := range certs {
+			key := certDiffKey(cert)
+			res[key] = append(res[key], cert.CertInfo)
+		}
+		for _, v := range res {
+			sort.Strings(v)
+		}
+		return res
+	}
+	existingInfos := infos(existing)
+	desiredInfos := infos(desired)
+	var keys []string
+	for key := range desiredInfos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var added, updated, unchanged []string
+	for _, key := range keys {
+		existingInfo, ok := existingInfos[key]
+		switch {
+		case !ok:
+			added = append(added, key)
+		case strings.Join(existingInfo, "\n") == strings.Join(desiredInfos[key], "\n"):
+			unchanged = append(unchanged, key)
+		default:
+			updated = append(updated, key)
+		}
+	}
+	return added, updated, unchanged
+}
+
+// Print the changes adding the desired certificates would make to the existing ones
+func printCertDiff(existing []appsv1.RepositoryCertificate, desired []appsv1.RepositoryCertificate, upsert bool) {
+	added, updated, unchanged := certDiff(existing, desired)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ACTION\tCERTIFICATE\n")
+	for _, key := range added {
+		fmt.Fprintf(w, "add\t%s\n", key)
+	}
+	for _, key := range updated {
+		if upsert {
+			fmt.Fprintf(w, "update\t%s\n", key)
+		} else {
+			fmt.Fprintf(w, "conflict\t%s\n", key)
+		}
+	}
+	for _, key := range unchanged {
+		fmt.Fprintf(w, "unchanged\t%s\n", key)
+	}
+	_ = w.Flush()
+	fmt.Printf("%d to add, %d to update, %d unchanged\n", len(added), len(updated), len(unchanged))
+	if len(updated) > 0 && !upsert {
+		fmt.Println("Existing entries which differ from the input would be rejected, use --upsert to replace them")
+	}
+}
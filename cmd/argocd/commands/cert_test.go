@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func Test_certDiff(t *testing.T) {
+	existing := []appsv1.RepositoryCertificate{
+		{ServerName: "github.com", CertType: "ssh", CertSubType: "ssh-rsa", CertInfo: "SHA256:abc"},
+		{ServerName: "gitlab.com", CertType: "ssh", CertSubType: "ssh-rsa", CertInfo: "SHA256:def"},
+		{ServerName: "git.example.com", CertType: "https", CertSubType: "rsa", CertInfo: "CN=a"},
+		{ServerName: "git.example.com", CertType: "https", CertSubType: "rsa", CertInfo: "CN=b"},
+	}
+	desired := []appsv1.RepositoryCertificate{
+		{ServerName: "github.com", CertType: "ssh", CertSubType: "ssh-rsa", CertInfo: "SHA256:abc"},
+		{ServerName: "github.com", CertType: "ssh", CertSubType: "ecdsa-sha2-nistp256", CertInfo: "SHA256:ghi"},
+		{ServerName: "gitlab.com", CertType: "ssh", CertSubType: "ssh-rsa", CertInfo: "SHA256:jkl"},
+		{ServerName: "git.example.com", CertType: "https", CertInfo: "CN=b"},
+		{ServerName: "git.example.com", CertType: "https", CertInfo: "CN=a"},
+	}
+	added, updated, unchanged := certDiff(existing, desired)
+	assert.Equal(t, []string{"ssh/github.com/ecdsa-sha2-nistp256"}, added)
+	assert.Equal(t, []string{"ssh/gitlab.com/ssh-rsa"}, updated)
+	assert.Equal(t, []string{"https/git.example.com", "ssh/github.com/ssh-rsa"}, unchanged)
+
+	added, updated, _ = certDiff(existing, []appsv1.RepositoryCertificate{{ServerName: "git.example.com", CertType: "https", CertInfo: "CN=a"}})
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"https/git.example.com"}, updated)
+}
@@ -6,11 +6,13 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -58,13 +60,16 @@ func NewClusterCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clientc
 // NewClusterAddCommand returns a new instance of an `argocd cluster add` command
 func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clientcmd.PathOptions) *cobra.Command {
 	var (
-		inCluster       bool
-		upsert          bool
-		serviceAccount  string
-		awsRoleArn      string
-		awsClusterName  string
-		systemNamespace string
-		namespaces      []string
+		inCluster        bool
+		upsert           bool
+		serviceAccount   string
+		awsRoleArn       string
+		awsClusterName   string
+		systemNamespace  string
+		namespaces       []string
+		clusterResources bool
+		shard            int64
+		maxSyncs         int64
 	)
 	var command = &cobra.Command{
 		Use:   "add CONTEXT",
@@ -90,6 +95,9 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 			clientConfig := clientcmd.NewDefaultClientConfig(*config, &overrides)
 			conf, err := clientConfig.ClientConfig()
 			errors.CheckError(err)
+			if clusterResources && len(namespaces) == 0 {
+				log.Fatal("--cluster-resources requires --namespace")
+			}
 
 			managerBearerToken := ""
 			var awsAuthConf *argoappv1.AWSAuthConfig
@@ -112,6 +120,11 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 			conn, clusterIf := argocdclient.NewClientOrDie(clientOpts).NewClusterClientOrDie()
 			defer util.Close(conn)
 			clst := newCluster(contextName, namespaces, conf, managerBearerToken, awsAuthConf)
+			clst.ClusterResources = clusterResources
+			if c.Flags().Changed("shard") {
+				clst.Shard = &shard
+			}
+			clst.MaxConcurrentSyncs = maxSyncs
 			if inCluster {
 				clst.Server = common.KubernetesInternalAPIServerAddr
 			}
@@ -131,7 +144,16 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 	command.Flags().StringVar(&awsClusterName, "aws-cluster-name", "", "AWS Cluster name if set then aws cli eks token command will be used to access cluster")
 	command.Flags().StringVar(&awsRoleArn, "aws-role-arn", "", "Optional AWS role arn. If set then AWS IAM Authenticator assume a role to perform cluster operations instead of the default AWS credential provider chain.")
 	command.Flags().StringVar(&systemNamespace, "system-namespace", common.DefaultSystemNamespace, "Use different system namespace")
-	command.Flags().StringArrayVar(&namespaces, "namespace", nil, "List of namespaces which are allowed to manage")
+	command.Flags().StringSliceVar(&namespaces, "namespace", nil, "Comma separated list of namespaces which are allowed to manage. If set, a namespace-scoped service account role is installed in each of them instead of a cluster role, and only those namespaces are watched. May be specified repeatedly (alias --namespaces)")
+	command.Flags().BoolVar(&clusterResources, "cluster-resources", false, "Manage cluster level resources as well when --namespace is set. The service account must be granted permissions on them separately")
+	command.Flags().Int64Var(&shard, "shard", -1, "Shard of the application controller which manages the cluster. If not set, the shard is derived from the server URL")
+	command.Flags().Int64Var(&maxSyncs, "max-concurrent-syncs", 0, "Maximum number of sync operations to the cluster which run at the same time, the others are queued (0 means unlimited)")
+	command.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "namespaces" {
+			name = "namespace"
+		}
+		return pflag.NormalizedName(name)
+	})
 	return command
 }
 
@@ -259,6 +281,30 @@ func printClusterDetails(clusters []argoappv1.Cluster) {
 		fmt.Printf("  Server Name:           %s\n", strWithDefault(cluster.Name, "-"))
 		fmt.Printf("  Server Version:        %s\n", cluster.ServerVersion)
 		fmt.Printf("  Namespaces:        	 %s\n", formatNamespaces(cluster))
+		if len(cluster.Namespaces) > 0 {
+			fmt.Printf("  Cluster Resources:     %v\n", cluster.ClusterResources)
+		}
+		if cluster.MaxConcurrentSyncs > 0 {
+			fmt.Printf("  Max Concurrent Syncs:  %d\n", cluster.MaxConcurrentSyncs)
+		}
+		fmt.Printf("\nController\n\n")
+		fmt.Printf("  Shard:                 %s\n", formatShard(cluster))
+		if cluster.Info.ReportedAt == nil {
+			fmt.Printf("  Replica:               not reported\n")
+		} else {
+			fmt.Printf("  Replica:               %s\n", cluster.Info.Replica)
+			fmt.Printf("  Applications:          %d\n", cluster.Info.ApplicationsCount)
+			fmt.Printf("  Cache status:          %s\n", cluster.Info.CacheInfo.Status)
+			if cluster.Info.CacheInfo.Message != "" {
+				fmt.Printf("  Cache message:         %s\n", cluster.Info.CacheInfo.Message)
+			}
+			fmt.Printf("  Resources:             %d\n", cluster.Info.CacheInfo.ResourcesCount)
+			fmt.Printf("  APIs:                  %d\n", cluster.Info.CacheInfo.APIsCount)
+			fmt.Printf("  Watch errors:          %d\n", cluster.Info.CacheInfo.WatchErrorsCount)
+			if cluster.Info.CacheInfo.LastWatchError != "" {
+				fmt.Printf("  Last watch error:      %s\n", cluster.Info.CacheInfo.LastWatchError)
+			}
+		}
 		fmt.Printf("\nTLS configuration\n\n")
 		fmt.Printf("  Client cert:           %v\n", string(cluster.Config.TLSClientConfig.CertData) != "")
 		fmt.Printf("  Cert validation:       %v\n", !cluster.Config.TLSClientConfig.Insecure)
@@ -302,17 +348,37 @@ func NewClusterRemoveCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comm
 // Print table of cluster information
 func printClusterTable(clusters []argoappv1.Cluster) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintf(w, "SERVER\tNAME\tVERSION\tSTATUS\tMESSAGE\n")
+	_, _ = fmt.Fprintf(w, "SERVER\tNAME\tVERSION\tSTATUS\tSHARD\tAPPS\tCACHE\tRESOURCES\tWATCH ERRORS\tMESSAGE\n")
 	for _, c := range clusters {
 		server := c.Server
 		if len(c.Namespaces) > 0 {
 			server = fmt.Sprintf("%s (%d namespaces)", c.Server, len(c.Namespaces))
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", server, c.Name, c.ServerVersion, c.ConnectionState.Status, c.ConnectionState.Message)
+		apps, cache, resources, watchErrors := "-", "-", "-", "-"
+		if c.Info.ReportedAt != nil {
+			apps = strconv.FormatInt(c.Info.ApplicationsCount, 10)
+			cache = c.Info.CacheInfo.Status
+			resources = strconv.FormatInt(c.Info.CacheInfo.ResourcesCount, 10)
+			watchErrors = strconv.FormatInt(c.Info.CacheInfo.WatchErrorsCount, 10)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", server, c.Name, c.ServerVersion, c.ConnectionState.Status, formatShard(c), apps, cache, resources, watchErrors, c.ConnectionState.Message)
 	}
 	_ = w.Flush()
 }
 
+// formatShard formats the shard of the application controller which manages a cluster, as reported by the controller
+// or as assigned to the cluster
+func formatShard(c argoappv1.Cluster) string {
+	switch {
+	case c.Info.ReportedAt != nil:
+		return strconv.FormatInt(c.Info.Shard, 10)
+	case c.Shard != nil:
+		return fmt.Sprintf("%d (assigned)", *c.Shard)
+	default:
+		return "-"
+	}
+}
+
 // Print list of cluster servers
 func printClusterServers(clusters []argoappv1.Cluster) {
 	for _, c := range clusters {
@@ -0,0 +1,121 @@
+package headless
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/common"
+	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	repoapiclient "github.com/argoproj/argo-cd/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/server"
+	servercache "github.com/argoproj/argo-cd/server/cache"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
+)
+
+const (
+	repoServerTimeoutSeconds = 60
+	serverStartTimeout       = 30 * time.Second
+)
+
+// StartLocalServer starts an Argo CD API server in the CLI process, with authentication disabled, and points the client
+// options at it. The server uses the Kubernetes API of the current kubeconfig context directly, while the repo server and
+// Redis of the installation are reached using port forwarding. A random port is used if port is zero. Returns the server
+// address. The web UI is served as well if the directory of its static assets is given.
+func StartLocalServer(ctx context.Context, clientOpts *argocdclient.ClientOptions, address string, port int, staticAssetsDir string) (string, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return "", err
+	}
+	restConfig.QPS = common.K8sClientConfigQPS
+	restConfig.Burst = common.K8sClientConfigBurst
+	namespace := clientOpts.PortForwardNamespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	repoServerPort, err := argocdclient.PortForward(common.DefaultPortRepoServer, "app.kubernetes.io/name=argocd-repo-server", namespace)
+	if err != nil {
+		return "", err
+	}
+	redisPort, err := argocdclient.PortForward(6379, "app.kubernetes.io/name=argocd-redis", namespace)
+	if err != nil {
+		return "", err
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("localhost:%d", redisPort)})
+	cache := servercache.NewCache(
+		appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewRedisCache(redisClient, 24*time.Hour)), time.Hour),
+		time.Hour,
+		3*time.Minute,
+	)
+
+	if port == 0 {
+		if port, err = freePort(address); err != nil {
+			return "", err
+		}
+	}
+	metricsPort, err := freePort(address)
+	if err != nil {
+		return "", err
+	}
+
+	srv := server.NewServer(ctx, server.ArgoCDServerOpts{
+		DisableAuth:     true,
+		Insecure:        true,
+		ListenHost:      address,
+		ListenPort:      port,
+		MetricsPort:     metricsPort,
+		Namespace:       namespace,
+		StaticAssetsDir: staticAssetsDir,
+		BaseHRef:        "/",
+		KubeClientset:   kubernetes.NewForConfigOrDie(restConfig),
+		AppClientset:    appclientset.NewForConfigOrDie(restConfig),
+		RepoClientset:   repoapiclient.NewRepoServerClientset(fmt.Sprintf("localhost:%d", repoServerPort), repoServerTimeoutSeconds),
+		Cache:           cache,
+		XFrameOptions:   "sameorigin",
+	})
+	go srv.Run(ctx, port, metricsPort)
+
+	serverAddr := net.JoinHostPort(address, strconv.Itoa(port))
+	err = wait.PollImmediate(100*time.Millisecond, serverStartTimeout, func() (bool, error) {
+		conn, err := net.Dial("tcp", serverAddr)
+		if err != nil {
+			return false, nil
+		}
+		_ = conn.Close()
+		return true, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("local API server did not start listening on %s: %v", serverAddr, err)
+	}
+
+	clientOpts.Core = true
+	clientOpts.ServerAddr = serverAddr
+	clientOpts.PlainText = true
+	clientOpts.PortForward = false
+	clientOpts.PortForwardNamespace = ""
+	return serverAddr, nil
+}
+
+// freePort returns a port which is free on the given address
+func freePort(address string) (int, error) {
+	ln, err := net.Listen("tcp", net.JoinHostPort(address, "0"))
+	if err != nil {
+		return 0, err
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	return port, ln.Close()
+}
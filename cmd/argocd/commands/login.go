@@ -30,11 +30,12 @@ import (
 // NewLoginCommand returns a new instance of `argocd login` command
 func NewLoginCommand(globalClientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		ctxName  string
-		username string
-		password string
-		sso      bool
-		ssoPort  int
+		ctxName     string
+		username    string
+		password    string
+		sso         bool
+		ssoPort     int
+		ssoProvider string
 	)
 	var command = &cobra.Command{
 		Use:   "login SERVER",
@@ -90,9 +91,11 @@ func NewLoginCommand(globalClientOpts *argocdclient.ClientOptions) *cobra.Comman
 				ctx = oidc.ClientContext(ctx, httpClient)
 				acdSet, err := setIf.Get(ctx, &settingspkg.SettingsQuery{})
 				errors.CheckError(err)
-				oauth2conf, provider, err := acdClient.OIDCConfig(ctx, acdSet)
+				oidcSettings, err := argocdclient.OIDCProviderConfig(acdSet, ssoProvider)
 				errors.CheckError(err)
-				tokenString, refreshToken = oauth2Login(ctx, ssoPort, acdSet.GetOIDCConfig(), oauth2conf, provider)
+				oauth2conf, provider, err := acdClient.OIDCConfig(ctx, acdSet, ssoProvider)
+				errors.CheckError(err)
+				tokenString, refreshToken = oauth2Login(ctx, ssoPort, oidcSettings, oauth2conf, provider)
 			}
 
 			parser := &jwt.Parser{
@@ -139,6 +142,7 @@ func NewLoginCommand(globalClientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.Flags().StringVar(&password, "password", "", "the password of an account to authenticate")
 	command.Flags().BoolVar(&sso, "sso", false, "perform SSO login")
 	command.Flags().IntVar(&ssoPort, "sso-port", DefaultSSOLocalPort, "port to run local OAuth2 login application")
+	command.Flags().StringVar(&ssoProvider, "sso-provider", "", "name of the OIDC provider to perform SSO login with, if the server is configured with several")
 	return command
 }
 
@@ -168,6 +172,8 @@ func oauth2Login(ctx context.Context, port int, oidcSettings *settingspkg.OIDCCo
 	completionChan := make(chan string)
 	// stateNonce is an OAuth2 state nonce
 	stateNonce := rand.RandString(10)
+	// codeVerifier is the PKCE code verifier, used when the authorization code flow is protected by PKCE
+	codeVerifier := ""
 	var tokenString string
 	var refreshToken string
 
@@ -215,7 +221,7 @@ func oauth2Login(ctx context.Context, port int, oidcSettings *settingspkg.OIDCCo
 				handleErr(w, fmt.Sprintf("no code in request: %q", r.Form))
 				return
 			}
-			tok, err := oauth2conf.Exchange(ctx, code)
+			tok, err := oidcutil.ExchangeCode(ctx, oauth2conf, code, codeVerifier)
 			if err != nil {
 				handleErr(w, err.Error())
 				return
@@ -251,6 +257,11 @@ func oauth2Login(ctx context.Context, port int, oidcSettings *settingspkg.OIDCCo
 
 	switch grantType {
 	case oidcutil.GrantTypeAuthorizationCode:
+		if oidcutil.UsePKCE(oidcConf, oidcSettings.GetEnablePKCEAuthentication()) {
+			codeVerifier, err = oidcutil.GenerateCodeVerifier()
+			errors.CheckError(err)
+			opts = oidcutil.AppendPKCEAuthCodeParameters(opts, codeVerifier)
+		}
 		url = oauth2conf.AuthCodeURL(stateNonce, opts...)
 	case oidcutil.GrantTypeImplicit:
 		url = oidcutil.ImplicitFlowURL(oauth2conf, stateNonce, opts...)
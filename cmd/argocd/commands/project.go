@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -36,6 +37,15 @@ type projectOpts struct {
 	sources                  []string
 	orphanedResourcesEnabled bool
 	orphanedResourcesWarn    bool
+	maxApplications          int64
+	maxDestinations          int64
+	maxResourcesPerApp       int64
+	maxConcurrentSyncs       int64
+	decryptSOPS              bool
+	manifestTimeoutSeconds   int64
+	manifestCPUSeconds       int64
+	manifestMemory           string
+	vaultPaths               []string
 }
 
 type policyOpts struct {
@@ -79,6 +89,8 @@ func NewProjectCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	command.AddCommand(NewProjectEditCommand(clientOpts))
 	command.AddCommand(NewProjectAddDestinationCommand(clientOpts))
 	command.AddCommand(NewProjectRemoveDestinationCommand(clientOpts))
+	command.AddCommand(NewProjectAddDestinationServiceAccountCommand(clientOpts))
+	command.AddCommand(NewProjectRemoveDestinationServiceAccountCommand(clientOpts))
 	command.AddCommand(NewProjectAddSourceCommand(clientOpts))
 	command.AddCommand(NewProjectRemoveSourceCommand(clientOpts))
 	command.AddCommand(NewProjectAllowClusterResourceCommand(clientOpts))
@@ -86,6 +98,8 @@ func NewProjectCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	command.AddCommand(NewProjectAllowNamespaceResourceCommand(clientOpts))
 	command.AddCommand(NewProjectDenyNamespaceResourceCommand(clientOpts))
 	command.AddCommand(NewProjectWindowsCommand(clientOpts))
+	command.AddCommand(NewProjectFreezeCommand(clientOpts))
+	command.AddCommand(NewProjectUnfreezeCommand(clientOpts))
 	return command
 }
 
@@ -96,6 +110,15 @@ func addProjFlags(command *cobra.Command, opts *projectOpts) {
 	command.Flags().StringArrayVarP(&opts.sources, "src", "s", []string{}, "Permitted source repository URL")
 	command.Flags().BoolVar(&opts.orphanedResourcesEnabled, "orphaned-resources", false, "Enables orphaned resources monitoring")
 	command.Flags().BoolVar(&opts.orphanedResourcesWarn, "orphaned-resources-warn", false, "Specifies if applications should be a warning condition when orphaned resources detected")
+	command.Flags().Int64Var(&opts.maxApplications, "max-applications", 0, "Maximum number of applications in the project (0 means unlimited)")
+	command.Flags().Int64Var(&opts.maxDestinations, "max-destinations", 0, "Maximum number of distinct destinations of the applications in the project (0 means unlimited)")
+	command.Flags().Int64Var(&opts.maxResourcesPerApp, "max-resources-per-app", 0, "Maximum number of resources an application in the project may manage (0 means unlimited)")
+	command.Flags().Int64Var(&opts.maxConcurrentSyncs, "max-concurrent-syncs", 0, "Maximum number of sync operations of the applications in the project which run at the same time, the others are queued (0 means unlimited)")
+	command.Flags().BoolVar(&opts.decryptSOPS, "decrypt-sops", false, "Permits the decryption of SOPS-encrypted files in the directory sources of the applications in the project")
+	command.Flags().Int64Var(&opts.manifestTimeoutSeconds, "manifest-generation-timeout", 0, "Maximum duration in seconds of the manifest generation of an application in the project (0 means unlimited)")
+	command.Flags().Int64Var(&opts.manifestCPUSeconds, "manifest-generation-cpu", 0, "Maximum CPU time in seconds of each command run to generate the manifests of an application in the project (0 means unlimited)")
+	command.Flags().StringVar(&opts.manifestMemory, "manifest-generation-memory", "", "Maximum memory of each command run to generate the manifests of an application in the project, e.g. 1Gi (empty means unlimited)")
+	command.Flags().StringArrayVar(&opts.vaultPaths, "vault-path", []string{}, "Permitted Vault secret path (glob pattern, e.g. secret/data/my-team/*) of the secret placeholders of the applications in the project")
 }
 
 func getOrphanedResourcesSettings(c *cobra.Command, opts projectOpts) *v1alpha1.OrphanedResourcesMonitorSettings {
@@ -110,6 +133,52 @@ func getOrphanedResourcesSettings(c *cobra.Command, opts projectOpts) *v1alpha1.
 	return nil
 }
 
+// getProjectQuota returns the given quota updated with the quota flags which are set, or nil if no limit is set
+func getProjectQuota(c *cobra.Command, opts projectOpts, quota *v1alpha1.ProjectQuota) *v1alpha1.ProjectQuota {
+	res := v1alpha1.ProjectQuota{}
+	if quota != nil {
+		res = *quota
+	}
+	if c.Flag("max-applications").Changed {
+		res.MaxApplications = opts.maxApplications
+	}
+	if c.Flag("max-destinations").Changed {
+		res.MaxDestinations = opts.maxDestinations
+	}
+	if c.Flag("max-resources-per-app").Changed {
+		res.MaxResourcesPerApplication = opts.maxResourcesPerApp
+	}
+	if c.Flag("max-concurrent-syncs").Changed {
+		res.MaxConcurrentSyncs = opts.maxConcurrentSyncs
+	}
+	if res == (v1alpha1.ProjectQuota{}) {
+		return nil
+	}
+	return &res
+}
+
+// getManifestGenerationLimits returns the given limits updated with the manifest generation flags which are set, or nil
+// if no limit is set
+func getManifestGenerationLimits(c *cobra.Command, opts projectOpts, limits *v1alpha1.ManifestGenerationLimits) *v1alpha1.ManifestGenerationLimits {
+	res := v1alpha1.ManifestGenerationLimits{}
+	if limits != nil {
+		res = *limits
+	}
+	if c.Flag("manifest-generation-timeout").Changed {
+		res.TimeoutSeconds = opts.manifestTimeoutSeconds
+	}
+	if c.Flag("manifest-generation-cpu").Changed {
+		res.CPUSeconds = opts.manifestCPUSeconds
+	}
+	if c.Flag("manifest-generation-memory").Changed {
+		res.Memory = opts.manifestMemory
+	}
+	if res == (v1alpha1.ManifestGenerationLimits{}) {
+		return nil
+	}
+	return &res
+}
+
 func addPolicyFlags(command *cobra.Command, opts *policyOpts) {
 	command.Flags().StringVarP(&opts.action, "action", "a", "", "Action to grant/deny permission on (e.g. get, create, list, update, delete)")
 	command.Flags().StringVarP(&opts.permission, "permission", "p", "allow", "Whether to allow or deny access to object with the action.  This can only be 'allow' or 'deny'")
@@ -162,10 +231,14 @@ func NewProjectCreateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comm
 				proj = v1alpha1.AppProject{
 					ObjectMeta: v1.ObjectMeta{Name: projName},
 					Spec: v1alpha1.AppProjectSpec{
-						Description:       opts.description,
-						Destinations:      opts.GetDestinations(),
-						SourceRepos:       opts.sources,
-						OrphanedResources: getOrphanedResourcesSettings(c, opts),
+						Description:              opts.description,
+						Destinations:             opts.GetDestinations(),
+						SourceRepos:              opts.sources,
+						OrphanedResources:        getOrphanedResourcesSettings(c, opts),
+						Quota:                    getProjectQuota(c, opts, nil),
+						DecryptSOPS:              opts.decryptSOPS,
+						ManifestGenerationLimits: getManifestGenerationLimits(c, opts, nil),
+						VaultPaths:               opts.vaultPaths,
 					},
 				}
 			}
@@ -217,6 +290,14 @@ func NewProjectSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 					proj.Spec.SourceRepos = opts.sources
 				case "orphaned-resources", "orphaned-resources-warn":
 					proj.Spec.OrphanedResources = getOrphanedResourcesSettings(c, opts)
+				case "max-applications", "max-destinations", "max-resources-per-app", "max-concurrent-syncs":
+					proj.Spec.Quota = getProjectQuota(c, opts, proj.Spec.Quota)
+				case "decrypt-sops":
+					proj.Spec.DecryptSOPS = opts.decryptSOPS
+				case "manifest-generation-timeout", "manifest-generation-cpu", "manifest-generation-memory":
+					proj.Spec.ManifestGenerationLimits = getManifestGenerationLimits(c, opts, proj.Spec.ManifestGenerationLimits)
+				case "vault-path":
+					proj.Spec.VaultPaths = opts.vaultPaths
 				}
 			})
 			if visited == 0 {
@@ -265,6 +346,63 @@ func NewProjectAddDestinationCommand(clientOpts *argocdclient.ClientOptions) *co
 	return command
 }
 
+// NewProjectFreezeCommand returns a new instance of an `argocd proj freeze` command
+func NewProjectFreezeCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var reason string
+	var command = &cobra.Command{
+		Use:   "freeze PROJECT",
+		Short: "Block the manual and automated syncs of all applications of a project",
+		Example: `  # Freeze the syncs of the apps of project-a during an incident
+  argocd proj freeze project-a --reason "incident INC-123"`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			proj, err := projIf.Get(context.Background(), &projectpkg.ProjectQuery{Name: projName})
+			errors.CheckError(err)
+
+			proj.Spec.SyncFreeze = &v1alpha1.SyncFreeze{Reason: reason}
+			_, err = projIf.Update(context.Background(), &projectpkg.ProjectUpdateRequest{Project: proj})
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVar(&reason, "reason", "", "Reason shown in the errors of the blocked syncs")
+	return command
+}
+
+// NewProjectUnfreezeCommand returns a new instance of an `argocd proj unfreeze` command
+func NewProjectUnfreezeCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "unfreeze PROJECT",
+		Short: "Allow the syncs of the applications of a frozen project again",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			proj, err := projIf.Get(context.Background(), &projectpkg.ProjectQuery{Name: projName})
+			errors.CheckError(err)
+
+			if proj.Spec.SyncFreeze == nil {
+				log.Fatal("Syncs of the project are not frozen")
+			}
+			proj.Spec.SyncFreeze = nil
+			_, err = projIf.Update(context.Background(), &projectpkg.ProjectUpdateRequest{Project: proj})
+			errors.CheckError(err)
+		},
+	}
+	return command
+}
+
 // NewProjectRemoveDestinationCommand returns a new instance of an `argocd proj remove-destination` command
 func NewProjectRemoveDestinationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var command = &cobra.Command{
@@ -304,6 +442,87 @@ func NewProjectRemoveDestinationCommand(clientOpts *argocdclient.ClientOptions)
 	return command
 }
 
+// NewProjectAddDestinationServiceAccountCommand returns a new instance of an `argocd proj add-destination-service-account` command
+func NewProjectAddDestinationServiceAccountCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "add-destination-service-account PROJECT SERVER NAMESPACE SERVICE-ACCOUNT",
+		Short: "Add a service account the controller impersonates when syncing to a destination",
+		Example: `  # Sync the apps of the project to the guestbook namespace as the deployer service account of the namespace
+  argocd proj add-destination-service-account my-project https://kubernetes.default.svc guestbook deployer
+
+  # Sync the apps of the project to all namespaces of a cluster as the deployer service account of the argocd namespace
+  argocd proj add-destination-service-account my-project https://my-cluster '*' argocd:deployer`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 4 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			server := args[1]
+			namespace := args[2]
+			serviceAccount := args[3]
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			proj, err := projIf.Get(context.Background(), &projectpkg.ProjectQuery{Name: projName})
+			errors.CheckError(err)
+
+			for _, item := range proj.Spec.DestinationServiceAccounts {
+				if item.Namespace == namespace && item.Server == server {
+					log.Fatal("Specified destination already has a service account in project")
+				}
+			}
+			proj.Spec.DestinationServiceAccounts = append(proj.Spec.DestinationServiceAccounts, v1alpha1.ApplicationDestinationServiceAccount{
+				Server:                server,
+				Namespace:             namespace,
+				DefaultServiceAccount: serviceAccount,
+			})
+			_, err = projIf.Update(context.Background(), &projectpkg.ProjectUpdateRequest{Project: proj})
+			errors.CheckError(err)
+		},
+	}
+	return command
+}
+
+// NewProjectRemoveDestinationServiceAccountCommand returns a new instance of an `argocd proj remove-destination-service-account` command
+func NewProjectRemoveDestinationServiceAccountCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "remove-destination-service-account PROJECT SERVER NAMESPACE",
+		Short: "Remove the service account of a destination",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 3 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			server := args[1]
+			namespace := args[2]
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			proj, err := projIf.Get(context.Background(), &projectpkg.ProjectQuery{Name: projName})
+			errors.CheckError(err)
+
+			index := -1
+			for i, item := range proj.Spec.DestinationServiceAccounts {
+				if item.Namespace == namespace && item.Server == server {
+					index = i
+					break
+				}
+			}
+			if index == -1 {
+				log.Fatal("Specified destination does not have a service account in project")
+			} else {
+				proj.Spec.DestinationServiceAccounts = append(proj.Spec.DestinationServiceAccounts[:index], proj.Spec.DestinationServiceAccounts[index+1:]...)
+				_, err = projIf.Update(context.Background(), &projectpkg.ProjectUpdateRequest{Project: proj})
+				errors.CheckError(err)
+			}
+		},
+	}
+
+	return command
+}
+
 // NewProjectAddSourceCommand returns a new instance of an `argocd proj add-src` command
 func NewProjectAddSourceCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var command = &cobra.Command{
@@ -586,6 +805,39 @@ func printProjectLine(w io.Writer, p *v1alpha1.AppProject) {
 	fmt.Fprintf(w, "%s\t%s\t%v\t%v\t%v\t%v\t%v\n", p.Name, p.Spec.Description, destinations, sourceRepos, clusterWhitelist, namespaceBlacklist, formatOrphanedResources(p))
 }
 
+func formatProjectQuota(p *v1alpha1.AppProject) string {
+	quota := p.Spec.Quota
+	if quota == nil {
+		return "<none>"
+	}
+	formatLimit := func(limit int64) string {
+		if limit == 0 {
+			return "unlimited"
+		}
+		return strconv.FormatInt(limit, 10)
+	}
+	return fmt.Sprintf("applications=%s, destinations=%s, resources per application=%s, concurrent syncs=%s",
+		formatLimit(quota.MaxApplications), formatLimit(quota.MaxDestinations), formatLimit(quota.MaxResourcesPerApplication), formatLimit(quota.MaxConcurrentSyncs))
+}
+
+func formatManifestGenerationLimits(p *v1alpha1.AppProject) string {
+	limits := p.Spec.ManifestGenerationLimits
+	if limits == nil {
+		return "<none>"
+	}
+	formatLimit := func(limit int64) string {
+		if limit == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprintf("%ds", limit)
+	}
+	memory := limits.Memory
+	if memory == "" {
+		memory = "unlimited"
+	}
+	return fmt.Sprintf("timeout=%s, cpu=%s, memory=%s", formatLimit(limits.TimeoutSeconds), formatLimit(limits.CPUSeconds), memory)
+}
+
 func printProject(p *v1alpha1.AppProject) {
 	const printProjFmtStr = "%-34s%s\n"
 
@@ -602,6 +854,15 @@ func printProject(p *v1alpha1.AppProject) {
 		fmt.Printf(printProjFmtStr, "", fmt.Sprintf("%s,%s", p.Spec.Destinations[i].Server, p.Spec.Destinations[i].Namespace))
 	}
 
+	// Print destination service accounts
+	if len(p.Spec.DestinationServiceAccounts) > 0 {
+		sa := p.Spec.DestinationServiceAccounts
+		fmt.Printf(printProjFmtStr, "Destination Service Accounts:", fmt.Sprintf("%s,%s,%s", sa[0].Server, sa[0].Namespace, sa[0].DefaultServiceAccount))
+		for i := 1; i < len(sa); i++ {
+			fmt.Printf(printProjFmtStr, "", fmt.Sprintf("%s,%s,%s", sa[i].Server, sa[i].Namespace, sa[i].DefaultServiceAccount))
+		}
+	}
+
 	// Print sources
 	src0 := "<none>"
 	if len(p.Spec.SourceRepos) > 0 {
@@ -632,6 +893,17 @@ func printProject(p *v1alpha1.AppProject) {
 		fmt.Printf(printProjFmtStr, "", fmt.Sprintf("%s/%s", p.Spec.NamespaceResourceBlacklist[i].Group, p.Spec.NamespaceResourceBlacklist[i].Kind))
 	}
 	fmt.Printf(printProjFmtStr, "Orphaned Resources:", formatOrphanedResources(p))
+	fmt.Printf(printProjFmtStr, "Quota:", formatProjectQuota(p))
+	fmt.Printf(printProjFmtStr, "Manifest Generation Limits:", formatManifestGenerationLimits(p))
+	if p.Spec.DecryptSOPS {
+		fmt.Printf(printProjFmtStr, "SOPS Decryption:", "enabled")
+	}
+	if len(p.Spec.VaultPaths) > 0 {
+		fmt.Printf(printProjFmtStr, "Vault Paths:", strings.Join(p.Spec.VaultPaths, ","))
+	}
+	if msg := p.SyncFreezeMessage(); msg != "" {
+		fmt.Printf(printProjFmtStr, "Sync Freeze:", msg)
+	}
 
 }
 
@@ -37,6 +37,8 @@ func NewProjectRoleCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	roleCommand.AddCommand(NewProjectRoleDeleteCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectRoleCreateTokenCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectRoleDeleteTokenCommand(clientOpts))
+	roleCommand.AddCommand(NewProjectRoleRevokeTokensCommand(clientOpts))
+	roleCommand.AddCommand(NewProjectRoleRotateTokenCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectRoleAddPolicyCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectRoleRemovePolicyCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectRoleAddGroupCommand(clientOpts))
@@ -227,7 +229,7 @@ func NewProjectRoleCreateTokenCommand(clientOpts *argocdclient.ClientOptions) *c
 // NewProjectRoleDeleteTokenCommand returns a new instance of an `argocd proj role delete-token` command
 func NewProjectRoleDeleteTokenCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var command = &cobra.Command{
-		Use:   "delete-token PROJECT ROLE-NAME ISSUED-AT",
+		Use:   "delete-token PROJECT ROLE-NAME ID|ISSUED-AT",
 		Short: "Delete a project token",
 		Run: func(c *cobra.Command, args []string) {
 			if len(args) != 3 {
@@ -236,13 +238,90 @@ func NewProjectRoleDeleteTokenCommand(clientOpts *argocdclient.ClientOptions) *c
 			}
 			projName := args[0]
 			roleName := args[1]
-			issuedAt, err := strconv.ParseInt(args[2], 10, 64)
+			id, issuedAt := parseProjectTokenID(args[2])
+
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			_, err := projIf.DeleteToken(context.Background(), &projectpkg.ProjectTokenDeleteRequest{Project: projName, Role: roleName, Iat: issuedAt, Id: id})
 			errors.CheckError(err)
+		},
+	}
+	return command
+}
+
+// parseProjectTokenID returns the ID or, for tokens without ID, the issue time of a project token
+func parseProjectTokenID(arg string) (string, int64) {
+	if issuedAt, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return "", issuedAt
+	}
+	return arg, 0
+}
+
+// NewProjectRoleRotateTokenCommand returns a new instance of an `argocd proj role rotate-token` command
+func NewProjectRoleRotateTokenCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		expiresIn   string
+		gracePeriod string
+	)
+	var command = &cobra.Command{
+		Use:   "rotate-token PROJECT ROLE-NAME ID|ISSUED-AT",
+		Short: "Replace a project token by a new token of the same role",
+		Example: `# Replace a token immediately
+argocd proj role rotate-token my-project ci 5f8a1c6e-5a5c-4e0b-9c1d-8c3a7e0f1a2b
+
+# Keep the replaced token valid for another hour while clients switch to the new token
+argocd proj role rotate-token my-project ci 5f8a1c6e-5a5c-4e0b-9c1d-8c3a7e0f1a2b --grace-period 1h`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 3 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			roleName := args[1]
+			id, issuedAt := parseProjectTokenID(args[2])
+			expiresInDuration, err := timeutil.ParseDuration(expiresIn)
+			errors.CheckError(err)
+			gracePeriodDuration, err := timeutil.ParseDuration(gracePeriod)
+			errors.CheckError(err)
+
+			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
+			defer util.Close(conn)
+
+			token, err := projIf.RotateToken(context.Background(), &projectpkg.ProjectTokenRotateRequest{
+				Project:     projName,
+				Role:        roleName,
+				Id:          id,
+				Iat:         issuedAt,
+				ExpiresIn:   int64(expiresInDuration.Seconds()),
+				GracePeriod: int64(gracePeriodDuration.Seconds()),
+			})
+			errors.CheckError(err)
+			fmt.Println(token.Token)
+		},
+	}
+	command.Flags().StringVarP(&expiresIn, "expires-in", "e", "0s", "Duration before the new token will expire. (Default: Lifetime of the replaced token)")
+	command.Flags().StringVar(&gracePeriod, "grace-period", "0s", "Duration during which the replaced token remains valid")
+	return command
+}
+
+// NewProjectRoleRevokeTokensCommand returns a new instance of an `argocd proj role revoke-tokens` command
+func NewProjectRoleRevokeTokensCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "revoke-tokens PROJECT ROLE-NAME",
+		Short: "Delete and immediately revoke all tokens of a project role",
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			roleName := args[1]
 
 			conn, projIf := argocdclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
 			defer util.Close(conn)
 
-			_, err = projIf.DeleteToken(context.Background(), &projectpkg.ProjectTokenDeleteRequest{Project: projName, Role: roleName, Iat: issuedAt})
+			_, err := projIf.RevokeTokens(context.Background(), &projectpkg.ProjectTokensRevokeRequest{Project: projName, Role: roleName})
 			errors.CheckError(err)
 		},
 	}
@@ -337,7 +416,11 @@ func NewProjectRoleGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 				if token.ExpiresAt > 0 {
 					expiresAt = humanizeTimestamp(token.ExpiresAt)
 				}
-				fmt.Fprintf(w, "%d\t%s\t%s\n", token.IssuedAt, humanizeTimestamp(token.IssuedAt), expiresAt)
+				id := token.ID
+				if id == "" {
+					id = strconv.FormatInt(token.IssuedAt, 10)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", id, humanizeTimestamp(token.IssuedAt), expiresAt)
 			}
 			_ = w.Flush()
 		},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"fmt"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/argoproj/argo-cd/errors"
 	argocdclient "github.com/argoproj/argo-cd/pkg/apiclient"
+	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
 	projectpkg "github.com/argoproj/argo-cd/pkg/apiclient/project"
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util"
@@ -35,6 +37,7 @@ func NewProjectWindowsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 	roleCommand.AddCommand(NewProjectWindowsDeleteCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectWindowsListCommand(clientOpts))
 	roleCommand.AddCommand(NewProjectWindowsUpdateCommand(clientOpts))
+	roleCommand.AddCommand(NewProjectWindowsStateCommand(clientOpts))
 	return roleCommand
 }
 
@@ -267,6 +270,77 @@ func NewProjectWindowsListCommand(clientOpts *argocdclient.ClientOptions) *cobra
 	return command
 }
 
+// NewProjectWindowsStateCommand returns a new instance of an `argocd proj windows state` command
+func NewProjectWindowsStateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "state PROJECT APPNAME",
+		Short: "Show whether an application of a project can currently be synced, and which sync windows decide it",
+		Example: `  # Check whether the guestbook app of the default project can be synced right now
+  argocd proj windows state default guestbook`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			projName := args[0]
+			appName := args[1]
+			conn, appIf := argocdclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+
+			app, err := appIf.Get(context.Background(), &applicationpkg.ApplicationQuery{Name: &appName})
+			errors.CheckError(err)
+			if app.Spec.Project != projName {
+				log.Fatalf("Application '%s' belongs to project '%s', not '%s'", appName, app.Spec.Project, projName)
+			}
+			res, err := appIf.GetApplicationSyncWindows(context.Background(), &applicationpkg.ApplicationSyncWindowsQuery{Name: &appName})
+			errors.CheckError(err)
+			printSyncWindowsState(appName, projName, toSyncWindows(res.AssignedWindows))
+		},
+	}
+	return command
+}
+
+// toSyncWindows converts the sync windows assigned to an application back into project sync windows, so that their
+// state can be evaluated
+func toSyncWindows(windows []*applicationpkg.ApplicationSyncWindow) *v1alpha1.SyncWindows {
+	var res v1alpha1.SyncWindows
+	for _, w := range windows {
+		res = append(res, &v1alpha1.SyncWindow{
+			Kind:       w.GetKind(),
+			Schedule:   w.GetSchedule(),
+			Duration:   w.GetDuration(),
+			ManualSync: w.GetManualSync(),
+		})
+	}
+	return &res
+}
+
+// Print the sync window state of an application, followed by a table of the sync windows assigned to it
+func printSyncWindowsState(appName string, projName string, windows *v1alpha1.SyncWindows) {
+	fmt.Printf(printOpFmtStr, "Application:", appName)
+	fmt.Printf(printOpFmtStr, "Project:", projName)
+	fmt.Printf(printOpFmtStr, "Manual Sync:", formatSyncState(windows.CanSyncWithReason(true)))
+	fmt.Printf(printOpFmtStr, "Automated Sync:", formatSyncState(windows.CanSyncWithReason(false)))
+	if !windows.HasWindows() {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmtStr := "%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtStr, "STATUS", "KIND", "SCHEDULE", "DURATION", "MANUALSYNC")
+	for _, window := range *windows {
+		fmt.Fprintf(w, fmtStr, formatBoolOutput(window.Active()), window.Kind, window.Schedule, window.Duration, formatManualOutput(window.ManualSync))
+	}
+	_ = w.Flush()
+}
+
+func formatSyncState(canSync bool, reason string) string {
+	if canSync {
+		return fmt.Sprintf("Allowed (%s)", reason)
+	}
+	return fmt.Sprintf("Blocked (%s)", reason)
+}
+
 // Print table of sync window data
 func printSyncWindows(proj *v1alpha1.AppProject) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -65,9 +65,12 @@ func NewReloginCommand(globalClientOpts *argocdclient.ClientOptions) *cobra.Comm
 				ctx = oidc.ClientContext(ctx, httpClient)
 				acdSet, err := setIf.Get(ctx, &settingspkg.SettingsQuery{})
 				errors.CheckError(err)
-				oauth2conf, provider, err := acdClient.OIDCConfig(ctx, acdSet)
+				ssoProvider := argocdclient.OIDCProviderNameForIssuer(acdSet, claims.Issuer)
+				oidcSettings, err := argocdclient.OIDCProviderConfig(acdSet, ssoProvider)
 				errors.CheckError(err)
-				tokenString, refreshToken = oauth2Login(ctx, ssoPort, acdSet.GetOIDCConfig(), oauth2conf, provider)
+				oauth2conf, provider, err := acdClient.OIDCConfig(ctx, acdSet, ssoProvider)
+				errors.CheckError(err)
+				tokenString, refreshToken = oauth2Login(ctx, ssoPort, oidcSettings, oauth2conf, provider)
 			}
 
 			localCfg.UpsertUser(localconfig.User{
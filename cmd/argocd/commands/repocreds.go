@@ -52,6 +52,12 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 
   # Add credentials with SSH private key authentication to use for all repositories under ssh://git@git.example.com/repos
   argocd repocreds add ssh://git@git.example.com/repos/ --ssh-private-key-path ~/.ssh/id_rsa
+
+  # Add credentials to use for the repositories of all the organizations of https://git.example.com named *-infra
+  argocd repocreds add 'https://git.example.com/*/*-infra' --match-type glob --username git --password secret
+
+  # Add credentials taking precedence over all the other matching credentials
+  argocd repocreds add 'https://git\.example\.com/(team-a|team-b)/.*' --match-type regex --priority 10 --username git --password secret
 `
 
 	var command = &cobra.Command{
@@ -67,9 +73,13 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 			// Repository URL
 			repo.URL = args[0]
 
+			// The type of the URL can only be checked for prefixes, glob patterns and regular expressions may match
+			// either type
+			isPrefix := repo.MatchType == "" || repo.MatchType == appsv1.RepoCredsMatchTypePrefix
+
 			// Specifying ssh-private-key-path is only valid for SSH repositories
 			if sshPrivateKeyPath != "" {
-				if ok, _ := git.IsSSHURL(repo.URL); ok {
+				if ok, _ := git.IsSSHURL(repo.URL); ok || !isPrefix {
 					keyData, err := ioutil.ReadFile(sshPrivateKeyPath)
 					if err != nil {
 						log.Fatal(err)
@@ -90,7 +100,7 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 
 			// Specifying tls-client-cert-path is only valid for HTTPS repositories
 			if tlsClientCertPath != "" {
-				if git.IsHTTPSURL(repo.URL) {
+				if git.IsHTTPSURL(repo.URL) || !isPrefix {
 					tlsCertData, err := ioutil.ReadFile(tlsClientCertPath)
 					errors.CheckError(err)
 					tlsCertKey, err := ioutil.ReadFile(tlsClientCertKeyPath)
@@ -128,6 +138,8 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 	command.Flags().StringVar(&tlsClientCertPath, "tls-client-cert-path", "", "path to the TLS client cert (must be PEM format)")
 	command.Flags().StringVar(&tlsClientCertKeyPath, "tls-client-cert-key-path", "", "path to the TLS client cert's key path (must be PEM format)")
 	command.Flags().BoolVar(&upsert, "upsert", false, "Override an existing repository with the same name even if the spec differs")
+	command.Flags().StringVar(&repo.MatchType, "match-type", "", "how the URL matches the URLs of repositories. One of: prefix|glob|regex (default prefix)")
+	command.Flags().Int64Var(&repo.Priority, "priority", 0, "precedence of the credentials over other matching credentials, the highest wins")
 	return command
 }
 
@@ -155,12 +167,15 @@ func NewRepoCredsRemoveCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 // Print the repository credentials as table
 func printRepoCredsTable(repos []appsv1.RepoCreds) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "URL PATTERN\tUSERNAME\tSSH_CREDS\tTLS_CREDS\n")
+	fmt.Fprintf(w, "URL PATTERN\tMATCH\tPRIORITY\tUSERNAME\tSSH_CREDS\tTLS_CREDS\n")
 	for _, r := range repos {
 		if r.Username == "" {
 			r.Username = "-"
 		}
-		fmt.Fprintf(w, "%s\t%s\t%v\t%v\n", r.URL, r.Username, r.SSHPrivateKey != "", r.TLSClientCertData != "")
+		if r.MatchType == "" {
+			r.MatchType = appsv1.RepoCredsMatchTypePrefix
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%v\t%v\n", r.URL, r.MatchType, r.Priority, r.Username, r.SSHPrivateKey != "", r.TLSClientCertData != "")
 	}
 	_ = w.Flush()
 }
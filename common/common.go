@@ -24,6 +24,10 @@ const (
 	ArgoCDKnownHostsConfigMapName = "argocd-ssh-known-hosts-cm"
 	// Contains TLS certificate data for connecting repositories. Will get mounted as volume to pods
 	ArgoCDTLSCertsConfigMapName = "argocd-tls-certs-cm"
+	// Contains the triggers, templates, services and subscriptions of notifications
+	ArgoCDNotificationsConfigMapName = "argocd-notifications-cm"
+	// Contains the credentials referenced by the notification services
+	ArgoCDNotificationsSecretName = "argocd-notifications-secret"
 )
 
 // Some default configurables
@@ -49,6 +53,8 @@ const (
 	DefaultPathSSHConfig = "/app/config/ssh"
 	// Default name for the SSH known hosts file
 	DefaultSSHKnownHostsName = "ssh_known_hosts"
+	// The default path of the log levels of the repo server, mounted from argocd-cm
+	DefaultPathRepoServerLogLevels = "/app/config/loglevel/repo-server"
 )
 
 // Argo CD application related constants
@@ -65,6 +71,8 @@ const (
 	AuthCookieName = "argocd.token"
 	// RevisionHistoryLimit is the max number of successful sync to keep in history
 	RevisionHistoryLimit = 10
+	// RevisionHistoryFullSourceLimit is the number of newest history entries which keep their source uncompressed
+	RevisionHistoryFullSourceLimit = 3
 )
 
 // Dex related constants
@@ -112,12 +120,22 @@ const (
 	// AnnotationKeyRefresh is the annotation key which indicates that app needs to be refreshed. Removed by application controller after app is refreshed.
 	// Might take values 'normal'/'hard'. Value 'hard' means manifest cache and target cluster state cache should be invalidated before refresh.
 	AnnotationKeyRefresh = "argocd.argoproj.io/refresh"
+	// AnnotationKeyApplicationSetRefresh is the annotation key which is set to the current time to request the applications of an ApplicationSet to be regenerated
+	AnnotationKeyApplicationSetRefresh = "argocd.argoproj.io/applicationset-refresh"
+	// AnnotationKeyApplicationTemplate is the name of the ApplicationTemplate an application was instantiated from
+	AnnotationKeyApplicationTemplate = "argocd.argoproj.io/application-template"
+	// AnnotationKeyNotified contains the notifications which were sent for the current state of an application
+	AnnotationKeyNotified = "notified.notifications.argoproj.io"
+	// AnnotationKeyNotificationSubscribePrefix is the prefix of the annotations subscribing recipients to a trigger of an application, in the format <prefix><trigger>.<service>: <recipient>;<recipient>
+	AnnotationKeyNotificationSubscribePrefix = "notifications.argoproj.io/subscribe."
 	// AnnotationKeyManagedBy is annotation name which indicates that k8s resource is managed by an application.
 	AnnotationKeyManagedBy = "managed-by"
 	// AnnotationValueManagedByArgoCD is a 'managed-by' annotation value for resources managed by Argo CD
 	AnnotationValueManagedByArgoCD = "argocd.argoproj.io"
 	// ResourcesFinalizerName the finalizer value which we inject to finalize deletion of an application
 	ResourcesFinalizerName = "resources-finalizer.argocd.argoproj.io"
+	// RegistrationFinalizerName is the finalizer which unregisters the repository or cluster of a deleted RepositoryRegistration or ClusterRegistration
+	RegistrationFinalizerName = "registration-finalizer.argocd.argoproj.io"
 )
 
 // Environment variables for tuning and debugging Argo CD
@@ -141,6 +159,13 @@ const (
 	EnvK8sClientQPS = "ARGOCD_K8S_CLIENT_QPS"
 	// EnvK8sClientBurst is the burst value used for the kubernetes client (default: twice the client QPS)
 	EnvK8sClientBurst = "ARGOCD_K8S_CLIENT_BURST"
+	// EnvFIPSMode restricts the cryptography to FIPS-approved algorithms if set to true, see util/fips
+	EnvFIPSMode = "ARGOCD_FIPS_MODE"
+	// EnvControllerReplicas is the number of replicas of the application controller the clusters are sharded across
+	EnvControllerReplicas = "ARGOCD_CONTROLLER_REPLICAS"
+	// EnvControllerShard is the shard of an application controller replica. If omitted, the shard is the ordinal of
+	// the replica in its StatefulSet.
+	EnvControllerShard = "ARGOCD_CONTROLLER_SHARD"
 )
 
 const (
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net"
 	"reflect"
 	"runtime/debug"
 	"strconv"
@@ -23,7 +24,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 
 	// make sure to register workqueue prometheus metrics
 	_ "k8s.io/kubernetes/pkg/util/workqueue/prometheus"
@@ -31,6 +31,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	statecache "github.com/argoproj/argo-cd/controller/cache"
 	"github.com/argoproj/argo-cd/controller/metrics"
+	"github.com/argoproj/argo-cd/controller/sharding"
 	"github.com/argoproj/argo-cd/errors"
 	"github.com/argoproj/argo-cd/pkg/apis/application"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
@@ -44,8 +45,14 @@ import (
 	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 	"github.com/argoproj/argo-cd/util/db"
 	"github.com/argoproj/argo-cd/util/diff"
+	"github.com/argoproj/argo-cd/util/export"
+	grpc_util "github.com/argoproj/argo-cd/util/grpc"
+	"github.com/argoproj/argo-cd/util/health"
+	"github.com/argoproj/argo-cd/util/healthz"
 	"github.com/argoproj/argo-cd/util/kube"
+	logutils "github.com/argoproj/argo-cd/util/log"
 	settings_util "github.com/argoproj/argo-cd/util/settings"
+	"github.com/argoproj/argo-cd/util/settings/validation"
 )
 
 const (
@@ -82,10 +89,10 @@ type ApplicationController struct {
 	applicationClientset appclientset.Interface
 	auditLogger          *argo.AuditLogger
 	// queue contains app namespace/name
-	appRefreshQueue workqueue.RateLimitingInterface
+	appRefreshQueue *trackedQueue
 	// queue contains app namespace/name/comparisonType and used to request app refresh with the predefined comparison type
-	appComparisonTypeRefreshQueue workqueue.RateLimitingInterface
-	appOperationQueue             workqueue.RateLimitingInterface
+	appComparisonTypeRefreshQueue *trackedQueue
+	appOperationQueue             *trackedQueue
 	appInformer                   cache.SharedIndexInformer
 	appLister                     applisters.ApplicationLister
 	projInformer                  cache.SharedIndexInformer
@@ -99,7 +106,14 @@ type ApplicationController struct {
 	refreshRequestedApps          map[string]CompareWith
 	refreshRequestedAppsMutex     *sync.Mutex
 	metricsServer                 *metrics.MetricsServer
+	settingsWatcher               *validation.Watcher
 	kubectlSemaphore              *semaphore.Weighted
+	eventExporter                 *export.Exporter
+	// shard is the shard of the controller, and clusterFilter filters the clusters managed by the shard
+	shard         int
+	clusterFilter func(cluster *appv1.Cluster) bool
+	// operationQueue tracks the sync operations admitted by the concurrency limits
+	operationQueue *operationQueue
 }
 
 type ApplicationControllerConfig struct {
@@ -118,8 +132,13 @@ func NewApplicationController(
 	kubectl kube.Kubectl,
 	appResyncPeriod time.Duration,
 	selfHealTimeout time.Duration,
+	metricsHost string,
 	metricsPort int,
+	metricsAppLabels []string,
+	metricsMaxAppLabelValues int,
 	kubectlParallelismLimit int64,
+	replicas int,
+	shard int,
 ) (*ApplicationController, error) {
 	log.Infof("appResyncPeriod=%v", appResyncPeriod)
 	db := db.NewDB(namespace, settingsMgr, kubeClientset)
@@ -130,9 +149,9 @@ func NewApplicationController(
 		kubectl:                       kubectl,
 		applicationClientset:          applicationClientset,
 		repoClientset:                 repoClientset,
-		appRefreshQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "app_reconciliation_queue"),
-		appOperationQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "app_operation_processing_queue"),
-		appComparisonTypeRefreshQueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		appRefreshQueue:               newTrackedQueue("app_reconciliation_queue"),
+		appOperationQueue:             newTrackedQueue("app_operation_processing_queue"),
+		appComparisonTypeRefreshQueue: newTrackedQueue("app_comparison_type_refresh_queue"),
 		db:                            db,
 		statusRefreshTimeout:          appResyncPeriod,
 		refreshRequestedApps:          make(map[string]CompareWith),
@@ -140,6 +159,10 @@ func NewApplicationController(
 		auditLogger:                   argo.NewAuditLogger(namespace, kubeClientset, "argocd-application-controller"),
 		settingsMgr:                   settingsMgr,
 		selfHealTimeout:               selfHealTimeout,
+		eventExporter:                 export.NewExporter(namespace, settingsMgr),
+		shard:                         shard,
+		clusterFilter:                 sharding.GetClusterFilter(replicas, shard),
+		operationQueue:                newOperationQueue(),
 	}
 	if kubectlParallelismLimit > 0 {
 		ctrl.kubectlSemaphore = semaphore.NewWeighted(kubectlParallelismLimit)
@@ -151,13 +174,23 @@ func NewApplicationController(
 	}
 	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
 	projInformer := v1alpha1.NewAppProjectInformer(applicationClientset, namespace, appResyncPeriod, indexers)
-	metricsAddr := fmt.Sprintf("0.0.0.0:%d", metricsPort)
-	ctrl.metricsServer = metrics.NewMetricsServer(metricsAddr, appLister, func() error {
+	// an empty host listens on all IPv4 and IPv6 interfaces
+	metricsAddr := net.JoinHostPort(metricsHost, strconv.Itoa(metricsPort))
+	checkKubernetes := func() error {
 		_, err := kubeClientset.Discovery().ServerVersion()
 		return err
-	})
-	stateCache := statecache.NewLiveStateCache(db, appInformer, ctrl.settingsMgr, kubectl, ctrl.metricsServer, ctrl.handleObjectUpdated)
-	appStateManager := NewAppStateManager(db, applicationClientset, repoClientset, namespace, kubectl, ctrl.settingsMgr, stateCache, projInformer, ctrl.metricsServer)
+	}
+	ctrl.settingsWatcher = validation.NewWatcher(settingsMgr)
+	ctrl.metricsServer = metrics.NewMetricsServer(metricsAddr, appLister, checkKubernetes, []healthz.Check{
+		{Name: healthz.CheckKubernetes, Check: checkKubernetes},
+		{Name: healthz.CheckConfig, Check: ctrl.settingsWatcher.Check},
+		{Name: healthz.CheckRedis, Check: argoCache.Ping},
+		{Name: healthz.CheckRepoServer, Check: func() error {
+			return apiclient.Ping(repoClientset, healthz.CheckTimeout)
+		}},
+	}, metricsAppLabels, metricsMaxAppLabelValues)
+	stateCache := statecache.NewLiveStateCache(db, appInformer, ctrl.settingsMgr, kubectl, ctrl.metricsServer, ctrl.handleObjectUpdated, ctrl.clusterFilter)
+	appStateManager := NewAppStateManager(db, applicationClientset, repoClientset, namespace, kubectl, ctrl.settingsMgr, stateCache, projInformer, appLister, ctrl.metricsServer, argoCache)
 	ctrl.appInformer = appInformer
 	ctrl.appLister = appLister
 	ctrl.projInformer = projInformer
@@ -194,7 +227,7 @@ func isSelfReferencedApp(app *appv1.Application, ref v1.ObjectReference) bool {
 }
 
 func (ctrl *ApplicationController) getAppProj(app *appv1.Application) (*appv1.AppProject, error) {
-	return argo.GetAppProject(&app.Spec, applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()), ctrl.namespace)
+	return argo.GetAppProject(&app.Spec, applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()), ctrl.namespace, ctrl.settingsMgr)
 }
 
 func (ctrl *ApplicationController) handleObjectUpdated(managedByApp map[string]bool, ref v1.ObjectReference) {
@@ -261,7 +294,7 @@ func isKnownOrphanedResourceExclusion(key kube.ResourceKey) bool {
 func (ctrl *ApplicationController) getResourceTree(a *appv1.Application, managedResources []*appv1.ResourceDiff) (*appv1.ApplicationTree, error) {
 	nodes := make([]appv1.ResourceNode, 0)
 
-	proj, err := argo.GetAppProject(&a.Spec, applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()), ctrl.namespace)
+	proj, err := argo.GetAppProject(&a.Spec, applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()), ctrl.namespace, ctrl.settingsMgr)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +388,10 @@ func (ctrl *ApplicationController) managedResources(comparisonResult *comparison
 		resDiff := res.Diff
 		if res.Kind == kube.SecretKind && res.Group == "" {
 			var err error
-			target, live, err = diff.HideSecretData(res.Target, res.Live)
+			if comparisonResult.hideGeneratedSecretData {
+				target, live = argo.HideGeneratedSecretData(target, live)
+			}
+			target, live, err = diff.HideSecretData(target, live)
 			if err != nil {
 				return nil, err
 			}
@@ -406,6 +442,8 @@ func (ctrl *ApplicationController) Run(ctx context.Context, statusProcessors int
 	defer ctrl.appOperationQueue.ShutDown()
 
 	ctrl.metricsServer.RegisterClustersInfoSource(ctx, ctrl.stateCache)
+	ctrl.metricsServer.RegisterStatsSource(ctrl)
+	ctrl.metricsServer.RegisterCollector(ctrl.settingsWatcher)
 	go ctrl.appInformer.Run(ctx.Done())
 	go ctrl.projInformer.Run(ctx.Done())
 
@@ -416,6 +454,9 @@ func (ctrl *ApplicationController) Run(ctx context.Context, statusProcessors int
 
 	go func() { errors.CheckError(ctrl.stateCache.Run(ctx)) }()
 	go func() { errors.CheckError(ctrl.metricsServer.ListenAndServe()) }()
+	go ctrl.watchLogLevels(ctx)
+	go ctrl.reportClusterInfo(ctx)
+	go ctrl.settingsWatcher.Run(ctx)
 
 	for i := 0; i < statusProcessors; i++ {
 		go wait.Until(func() {
@@ -438,6 +479,35 @@ func (ctrl *ApplicationController) Run(ctx context.Context, statusProcessors int
 	<-ctx.Done()
 }
 
+// watchLogLevels applies the log levels of the application controller configured in argocd-cm
+func (ctrl *ApplicationController) watchLogLevels(ctx context.Context) {
+	updateCh := make(chan *settings_util.ArgoCDSettings, 1)
+	ctrl.settingsMgr.Subscribe(updateCh)
+
+	overrideLogLevels := func(logLevels map[string]string) {
+		if err := logutils.OverrideLevels(logLevels[settings_util.LogLevelComponentController]); err != nil {
+			log.Warnf("Invalid log levels of the application controller: %v", err)
+		}
+	}
+	if logLevels, err := ctrl.settingsMgr.GetLogLevels(); err != nil {
+		log.Warnf("Failed to read log levels: %v", err)
+	} else {
+		overrideLogLevels(logLevels)
+	}
+
+	done := false
+	for !done {
+		select {
+		case newSettings := <-updateCh:
+			overrideLogLevels(newSettings.LogLevels)
+		case <-ctx.Done():
+			done = true
+		}
+	}
+	ctrl.settingsMgr.Unsubscribe(updateCh)
+	close(updateCh)
+}
+
 func (ctrl *ApplicationController) requestAppRefresh(appName string, compareWith *CompareWith, after *time.Duration) {
 	key := fmt.Sprintf("%s/%s", ctrl.namespace, appName)
 
@@ -495,6 +565,9 @@ func (ctrl *ApplicationController) processAppOperationQueueItem() (processNext b
 		log.Warnf("Key '%s' in index is not an application", appKey)
 		return
 	}
+	if !ctrl.canProcessApp(app) {
+		return
+	}
 	if app.Operation != nil {
 		ctrl.processRequestedAppOperation(app)
 	} else if app.DeletionTimestamp != nil && app.CascadedDeletion() {
@@ -655,9 +728,23 @@ func (ctrl *ApplicationController) setAppCondition(app *appv1.Application, condi
 	}
 }
 
+// withCorrelationID adds the correlation ID of the request which initiated an operation, if any, to a log entry
+func withCorrelationID(logCtx *log.Entry, op appv1.Operation) *log.Entry {
+	if op.CorrelationID == "" {
+		return logCtx
+	}
+	return logCtx.WithField(grpc_util.CorrelationIDLogField, op.CorrelationID)
+}
+
 func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Application) {
 	logCtx := log.WithField("application", app.Name)
 	var state *appv1.OperationState
+	// Release the admission of completed operations, including the ones which failed because of a panic
+	defer func() {
+		if state != nil && state.Phase.Completed() {
+			ctrl.operationQueue.release(app.Name)
+		}
+	}()
 	// Recover from any unexpected panics and automatically set the status to be failed
 	defer func() {
 		if r := recover(); r != nil {
@@ -687,11 +774,25 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 		}
 		app = freshApp
 		state = app.Status.OperationState.DeepCopy()
-		logCtx.Infof("Resuming in-progress operation. phase: %s, message: %s", state.Phase, state.Message)
+		logCtx = withCorrelationID(logCtx, state.Operation)
+		if state.Phase == appv1.OperationTerminating && state.QueuePosition > 0 {
+			state.Phase = appv1.OperationFailed
+			state.QueuePosition = 0
+			state.Message = "operation was terminated while queued"
+			ctrl.setOperationState(app, state)
+			return
+		}
+		if state.Phase != appv1.OperationQueued {
+			logCtx.Infof("Resuming in-progress operation. phase: %s, message: %s", state.Phase, state.Message)
+		} else if !ctrl.startOperation(app, state, logCtx) {
+			return
+		}
 	} else {
 		state = &appv1.OperationState{Phase: appv1.OperationRunning, Operation: *app.Operation, StartedAt: metav1.Now()}
-		ctrl.setOperationState(app, state)
-		logCtx.Infof("Initialized new operation: %v", *app.Operation)
+		logCtx = withCorrelationID(logCtx, state.Operation)
+		if !ctrl.startOperation(app, state, logCtx) {
+			return
+		}
 	}
 
 	ctrl.appStateManager.SyncAppState(app, state)
@@ -724,6 +825,32 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 	}
 }
 
+// startOperation starts a new or queued operation, unless it exceeds the concurrency limits of the sync operations. The
+// operations which are not started are queued, and retried once running operations complete.
+func (ctrl *ApplicationController) startOperation(app *appv1.Application, state *appv1.OperationState, logCtx *log.Entry) bool {
+	admitted, err := ctrl.admitOperation(app, state)
+	if err != nil {
+		logCtx.Errorf("Failed to check the concurrency limits of the operation: %v", err)
+		ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.Name), queuedOperationRecheckInterval)
+		return false
+	}
+	if !admitted {
+		ctrl.setOperationState(app, state)
+		logCtx.Infof("Queued operation: %s", state.Message)
+		ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.Name), queuedOperationRecheckInterval)
+		return false
+	}
+	if state.Phase == appv1.OperationQueued {
+		state.Phase = appv1.OperationRunning
+		state.Message = ""
+		state.QueuePosition = 0
+		state.StartedAt = metav1.Now()
+	}
+	ctrl.setOperationState(app, state)
+	logCtx.Infof("Initialized new operation: %v", state.Operation)
+	return true
+}
+
 func (ctrl *ApplicationController) setOperationState(app *appv1.Application, state *appv1.OperationState) {
 	util.RetryUntilSucceed(func() error {
 		if state.Phase == "" {
@@ -782,6 +909,7 @@ func (ctrl *ApplicationController) setOperationState(app *appv1.Application, sta
 			}
 			ctrl.auditLogger.LogAppEvent(app, eventInfo, strings.Join(messages, " "))
 			ctrl.metricsServer.IncSync(app, state)
+			ctrl.eventExporter.Export(app, state)
 		}
 		return nil
 	}, "Update application operation state", context.Background(), updateOperationStateTimeout)
@@ -815,6 +943,9 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		log.Warnf("Key '%s' in index is not an application", appKey)
 		return
 	}
+	if !ctrl.canProcessApp(origApp) {
+		return
+	}
 	needRefresh, refreshType, comparisonLevel := ctrl.needRefreshAppStatus(origApp, ctrl.statusRefreshTimeout)
 
 	if !needRefresh {
@@ -885,11 +1016,15 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 	}
 
 	observedAt := metav1.Now()
-	compareResult := ctrl.appStateManager.CompareAppState(app, project, revision, app.Spec.Source, refreshType == appv1.RefreshTypeHard, localManifests)
+	ctx := context.Background()
+	noCache := refreshType == appv1.RefreshTypeHard
+	compareResult := ctrl.appStateManager.CompareAppState(ctx, app, project, revision, app.Spec.Source, noCache, localManifests)
 	for k, v := range compareResult.timings {
 		logCtx = logCtx.WithField(k, v.Milliseconds())
 	}
 
+	destinations, syncStatus, healthStatus := ctrl.compareAppDestinations(ctx, app, project, compareResult, noCache, localManifests)
+
 	ctrl.normalizeApplication(origApp, app)
 
 	tree, err := ctrl.setAppManagedResources(app, compareResult)
@@ -899,8 +1034,10 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		app.Status.Summary = tree.GetSummary()
 	}
 
-	if project.Spec.SyncWindows.Matches(app).CanSync(false) {
-		syncErrCond := ctrl.autoSync(app, compareResult.syncStatus, compareResult.resources)
+	if msg := project.SyncFreezeMessage(); msg != "" {
+		logCtx.Infof("Sync prevented by freeze: %s", msg)
+	} else if project.Spec.SyncWindows.Matches(app).CanSync(false) {
+		syncErrCond := ctrl.autoSync(app, syncStatus, compareResult.resources)
 		if syncErrCond != nil {
 			app.Status.SetConditions(
 				[]appv1.ApplicationCondition{*syncErrCond},
@@ -920,14 +1057,56 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		app.Status.ReconciledAt = &observedAt
 	}
 	app.Status.ObservedAt = &observedAt
-	app.Status.Sync = *compareResult.syncStatus
-	app.Status.Health = *compareResult.healthStatus
+	app.Status.Sync = *syncStatus
+	app.Status.Health = *healthStatus
+	app.Status.Destinations = destinations
 	app.Status.Resources = compareResult.resources
 	app.Status.SourceType = compareResult.appSourceType
 	ctrl.persistAppStatus(origApp, &app.Status)
 	return
 }
 
+// compareAppDestinations compares the additional destinations of an application to the revision resolved for its
+// primary destination. It returns the status of every destination, and the sync and health status aggregated over them:
+// the application is out of sync if any destination is, and its health is the worst health of its destinations.
+func (ctrl *ApplicationController) compareAppDestinations(ctx context.Context, app *appv1.Application, project *appv1.AppProject, primary *comparisonResult, noCache bool, localManifests []string) ([]appv1.ApplicationDestinationStatus, *appv1.SyncStatus, *appv1.HealthStatus) {
+	if len(app.Spec.Destinations) == 0 {
+		return nil, primary.syncStatus, primary.healthStatus
+	}
+	syncStatus := primary.syncStatus.DeepCopy()
+	healthStatus := primary.healthStatus.DeepCopy()
+	revision := primary.syncStatus.Revision
+	if revision == "" {
+		revision = app.Spec.Source.TargetRevision
+	}
+	statuses := []appv1.ApplicationDestinationStatus{{
+		Destination: app.Spec.Destination,
+		Sync:        primary.syncStatus.Status,
+		Health:      primary.healthStatus.Status,
+	}}
+	for _, destination := range app.Spec.Destinations {
+		destApp := newDestinationApp(app, destination)
+		destApp.Status.Conditions = nil
+		compareResult := ctrl.appStateManager.CompareAppState(ctx, destApp, project, revision, app.Spec.Source, noCache, localManifests)
+		status := appv1.ApplicationDestinationStatus{
+			Destination: destination,
+			Sync:        compareResult.syncStatus.Status,
+			Health:      compareResult.healthStatus.Status,
+		}
+		if errConditions := destApp.Status.GetConditions(map[appv1.ApplicationConditionType]bool{appv1.ApplicationConditionComparisonError: true}); len(errConditions) > 0 {
+			status.Message = argo.FormatAppConditions(errConditions)
+		}
+		statuses = append(statuses, status)
+		if syncStatus.Status == appv1.SyncStatusCodeSynced {
+			syncStatus.Status = status.Sync
+		}
+		if health.IsWorse(healthStatus.Status, status.Health) {
+			healthStatus = compareResult.healthStatus.DeepCopy()
+		}
+	}
+	return statuses, syncStatus, healthStatus
+}
+
 // needRefreshAppStatus answers if application status needs to be refreshed.
 // Returns true if application never been compared, has changed or comparison result has expired.
 // Additionally returns whether full refresh was requested or not.
@@ -1193,6 +1372,9 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 	informer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
+				if !ctrl.canProcessApp(obj) {
+					return
+				}
 				key, err := cache.MetaNamespaceKeyFunc(obj)
 				if err == nil {
 					ctrl.appRefreshQueue.Add(key)
@@ -1200,6 +1382,9 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 				}
 			},
 			UpdateFunc: func(old, new interface{}) {
+				if !ctrl.canProcessApp(new) {
+					return
+				}
 				key, err := cache.MetaNamespaceKeyFunc(new)
 				if err != nil {
 					return
@@ -1213,6 +1398,10 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 				}
 				ctrl.requestAppRefresh(newApp.Name, compareWith, nil)
 				ctrl.appOperationQueue.Add(key)
+				if oldOK && newOK && isOperationInProgress(oldApp) && !isOperationInProgress(newApp) {
+					// give the operations queued by the concurrency limits a chance to start
+					ctrl.requeueQueuedOperations()
+				}
 			},
 			DeleteFunc: func(obj interface{}) {
 				// IndexerInformer uses a delta queue, therefore for deletes we have to use this
@@ -1244,6 +1433,26 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 	return informer, lister, err
 }
 
+// canProcessApp returns whether an application is deployed to a cluster managed by the shard of the controller. The
+// applications whose cluster doesn't exist are processed by the shard their server URL is sharded to, which reports
+// the missing cluster.
+func (ctrl *ApplicationController) canProcessApp(obj interface{}) bool {
+	app, ok := obj.(*appv1.Application)
+	if !ok {
+		return false
+	}
+	cluster, err := ctrl.db.GetCluster(context.Background(), app.Spec.Destination.Server)
+	if err != nil {
+		cluster = &appv1.Cluster{Server: app.Spec.Destination.Server}
+	}
+	return ctrl.clusterFilter(cluster)
+}
+
+// toAppKey returns the key of an application in the queues
+func (ctrl *ApplicationController) toAppKey(appName string) string {
+	return ctrl.namespace + "/" + appName
+}
+
 func isOperationInProgress(app *appv1.Application) bool {
 	return app.Status.OperationState != nil && !app.Status.OperationState.Phase.Completed()
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/argoproj/argo-cd/common"
 	mockstatecache "github.com/argoproj/argo-cd/controller/cache/mocks"
+	"github.com/argoproj/argo-cd/controller/metrics"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
@@ -96,7 +97,12 @@ func newFakeController(data *fakeData) *ApplicationController {
 		kubectl,
 		time.Minute,
 		time.Minute,
+		"",
 		common.DefaultPortArgoCDMetrics,
+		nil,
+		metrics.DefaultMaxAppLabelValues,
+		0,
+		1,
 		0,
 	)
 	if err != nil {
@@ -0,0 +1,397 @@
+package applicationset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	v1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/pkg/apis/application"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	appinformers "github.com/argoproj/argo-cd/pkg/client/informers/externalversions"
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// ApplicationSetController creates, updates and deletes the applications of ApplicationSets
+type ApplicationSetController struct {
+	namespace            string
+	kubeClientset        kubernetes.Interface
+	dynamicClientset     dynamic.Interface
+	applicationClientset appclientset.Interface
+	repoClientset        apiclient.Clientset
+	db                   db.ArgoDB
+	resyncPeriod         time.Duration
+	appSetInformer       cache.SharedIndexInformer
+	appSetLister         applisters.ApplicationSetLister
+	appInformer          cache.SharedIndexInformer
+	appLister            applisters.ApplicationLister
+	clusterInformer      cache.SharedIndexInformer
+	appSetQueue          workqueue.RateLimitingInterface
+	scmCache             map[string]scmCacheEntry
+	scmCacheLock         sync.Mutex
+}
+
+// NewApplicationSetController creates a new instance of ApplicationSetController. Every ApplicationSet is reconciled
+// when it or one of its applications change, and at least once per resync period, which picks up changes of clusters
+// and repositories.
+func NewApplicationSetController(
+	namespace string,
+	settingsMgr *settings.SettingsManager,
+	kubeClientset kubernetes.Interface,
+	dynamicClientset dynamic.Interface,
+	applicationClientset appclientset.Interface,
+	repoClientset apiclient.Clientset,
+	resyncPeriod time.Duration,
+) *ApplicationSetController {
+	ctrl := ApplicationSetController{
+		namespace:            namespace,
+		kubeClientset:        kubeClientset,
+		dynamicClientset:     dynamicClientset,
+		applicationClientset: applicationClientset,
+		repoClientset:        repoClientset,
+		db:                   db.NewDB(namespace, settingsMgr, kubeClientset),
+		resyncPeriod:         resyncPeriod,
+		appSetQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "appset_reconciliation_queue"),
+		scmCache:             make(map[string]scmCacheEntry),
+	}
+	informerFactory := appinformers.NewFilteredSharedInformerFactory(
+		applicationClientset,
+		resyncPeriod,
+		namespace,
+		func(options *metav1.ListOptions) {},
+	)
+	ctrl.appSetInformer = informerFactory.Argoproj().V1alpha1().ApplicationSets().Informer()
+	ctrl.appSetLister = informerFactory.Argoproj().V1alpha1().ApplicationSets().Lister()
+	ctrl.appInformer = informerFactory.Argoproj().V1alpha1().Applications().Informer()
+	ctrl.appLister = informerFactory.Argoproj().V1alpha1().Applications().Lister()
+
+	ctrl.appSetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				ctrl.appSetQueue.Add(key)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				if refreshRequested(old.(*appv1.ApplicationSet), new.(*appv1.ApplicationSet)) {
+					ctrl.invalidateCachedParams(new.(*appv1.ApplicationSet))
+				}
+				ctrl.appSetQueue.Add(key)
+			}
+		},
+	})
+	// the ApplicationSets with cluster generators are reconciled as soon as a cluster is added, changed or removed
+	ctrl.clusterInformer = v1informers.NewFilteredSecretInformer(kubeClientset, namespace, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.LabelSelector = fmt.Sprintf("%s=%s", common.LabelKeySecretType, common.LabelValueSecretTypeCluster)
+	})
+	ctrl.clusterInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { ctrl.enqueueClusterGenerators() },
+		UpdateFunc: func(old, new interface{}) { ctrl.enqueueClusterGenerators() },
+		DeleteFunc: func(obj interface{}) { ctrl.enqueueClusterGenerators() },
+	})
+	ctrl.appInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueueOwner,
+		UpdateFunc: func(old, new interface{}) { ctrl.enqueueOwner(new) },
+		DeleteFunc: ctrl.enqueueOwner,
+	})
+	return &ctrl
+}
+
+// enqueueOwner requests the reconciliation of the ApplicationSet which owns an application, if any
+func (ctrl *ApplicationSetController) enqueueOwner(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	app, ok := obj.(*appv1.Application)
+	if !ok {
+		return
+	}
+	if owner := metav1.GetControllerOf(app); owner != nil && owner.Kind == application.ApplicationSetKind {
+		ctrl.appSetQueue.Add(fmt.Sprintf("%s/%s", app.Namespace, owner.Name))
+	}
+}
+
+// refreshRequested returns whether the refresh annotation of an ApplicationSet changed, which requests its
+// applications to be regenerated from the current state of their sources
+func refreshRequested(old *appv1.ApplicationSet, new *appv1.ApplicationSet) bool {
+	refresh, ok := new.Annotations[common.AnnotationKeyApplicationSetRefresh]
+	return ok && refresh != old.Annotations[common.AnnotationKeyApplicationSetRefresh]
+}
+
+// enqueueClusterGenerators requests the reconciliation of the ApplicationSets which have cluster generators
+func (ctrl *ApplicationSetController) enqueueClusterGenerators() {
+	appSets, err := ctrl.appSetLister.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list ApplicationSets: %v", err)
+		return
+	}
+	for _, appSet := range appSets {
+		for _, generator := range appSet.Spec.LeafGenerators() {
+			if generator.Clusters != nil {
+				if key, err := cache.MetaNamespaceKeyFunc(appSet); err == nil {
+					ctrl.appSetQueue.Add(key)
+				}
+				break
+			}
+		}
+	}
+}
+
+// Run starts the controller and blocks until the context is done
+func (ctrl *ApplicationSetController) Run(ctx context.Context, workers int) {
+	defer runtime.HandleCrash()
+	defer ctrl.appSetQueue.ShutDown()
+
+	go ctrl.appSetInformer.Run(ctx.Done())
+	go ctrl.appInformer.Run(ctx.Done())
+	go ctrl.clusterInformer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), ctrl.appSetInformer.HasSynced, ctrl.appInformer.HasSynced, ctrl.clusterInformer.HasSynced) {
+		log.Error("Timed out waiting for caches to sync")
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for ctrl.processAppSetQueueItem() {
+			}
+		}, time.Second, ctx.Done())
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *ApplicationSetController) processAppSetQueueItem() (processNext bool) {
+	key, shutdown := ctrl.appSetQueue.Get()
+	if shutdown {
+		processNext = false
+		return
+	}
+	processNext = true
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Recovered from panic: %+v\n%s", r, debug.Stack())
+		}
+		ctrl.appSetQueue.Done(key)
+	}()
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		log.Errorf("Failed to split key %s: %v", key, err)
+		return
+	}
+	appSet, err := ctrl.appSetLister.ApplicationSets(namespace).Get(name)
+	if apierr.IsNotFound(err) {
+		// the applications are garbage collected by kubernetes using their owner references
+		ctrl.appSetQueue.Forget(key)
+		return
+	}
+	if err != nil {
+		log.Errorf("Failed to get ApplicationSet %s: %v", key, err)
+		ctrl.appSetQueue.AddRateLimited(key)
+		return
+	}
+	if err := ctrl.reconcile(appSet.DeepCopy()); err != nil {
+		log.WithField("applicationset", name).Warnf("Failed to reconcile ApplicationSet: %v", err)
+		ctrl.appSetQueue.AddRateLimited(key)
+		return
+	}
+	ctrl.appSetQueue.Forget(key)
+	return
+}
+
+// reconcile creates the missing applications of an ApplicationSet, updates the ones which differ from their template
+// and deletes the ones which are no longer generated, as far as its sync policy allows. With the RollingSync strategy,
+// the applications are created and updated step by step. Errors and withheld changes are recorded in the status of the
+// ApplicationSet.
+func (ctrl *ApplicationSetController) reconcile(appSet *appv1.ApplicationSet) error {
+	logCtx := log.WithField("applicationset", appSet.Name)
+	var errs []string
+	var rollout *appv1.ApplicationSetCondition
+	allowUpdate, allowDelete, err := syncPolicy(appSet)
+	var desired map[string]*appv1.Application
+	if err == nil {
+		desired, err = ctrl.generateApplications(appSet)
+	}
+	var steps [][]*appv1.Application
+	if err == nil {
+		steps, err = rolloutSteps(appSet, desired)
+	}
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		var rolloutErrs []string
+		if rolloutErrs, rollout, err = ctrl.rollout(appSet, steps, allowUpdate); err != nil {
+			return err
+		}
+		errs = append(errs, rolloutErrs...)
+		apps, err := ctrl.appLister.Applications(appSet.Namespace).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		appIf := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(appSet.Namespace)
+		for _, app := range apps {
+			if _, ok := desired[app.Name]; ok || !allowDelete || !metav1.IsControlledBy(app, appSet) || app.DeletionTimestamp != nil {
+				continue
+			}
+			if err := appIf.Delete(app.Name, &metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("failed to delete application %s: %v", app.Name, err))
+			} else {
+				logCtx.Infof("Deleted application %s", app.Name)
+			}
+		}
+	}
+	return ctrl.setConditions(appSet, errs, rollout)
+}
+
+// rollout creates the applications of the steps of a rollout, and updates them unless updates are not allowed, until a
+// step has applications which are not synced and healthy. The changes of the applications of the following steps are withheld, which is reported by the
+// returned condition.
+func (ctrl *ApplicationSetController) rollout(appSet *appv1.ApplicationSet, steps [][]*appv1.Application, allowUpdate bool) ([]string, *appv1.ApplicationSetCondition, error) {
+	logCtx := log.WithField("applicationset", appSet.Name)
+	appIf := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(appSet.Namespace)
+	var errs []string
+	var blocked *appv1.ApplicationSetCondition
+	withheld := false
+	for i, step := range steps {
+		var waiting, failed []string
+		for _, app := range step {
+			current, err := ctrl.appLister.Applications(appSet.Namespace).Get(app.Name)
+			if err != nil && !apierr.IsNotFound(err) {
+				return nil, nil, err
+			}
+			if current != nil && !metav1.IsControlledBy(current, appSet) {
+				errs = append(errs, fmt.Sprintf("application %s already exists and is not managed by the ApplicationSet", app.Name))
+				continue
+			}
+			if current != nil {
+				ignored, err := ignoreDifferences(appSet, current, app)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to ignore differences of application %s: %v", app.Name, err))
+					continue
+				}
+				app = ignored
+			}
+			changed := current == nil || allowUpdate && !applicationEqual(current, app)
+			if blocked != nil {
+				withheld = withheld || changed
+				continue
+			}
+			switch {
+			case current == nil:
+				if _, err := appIf.Create(app); err != nil {
+					errs = append(errs, fmt.Sprintf("failed to create application %s: %v", app.Name, err))
+				} else {
+					logCtx.Infof("Created application %s", app.Name)
+				}
+				waiting = append(waiting, fmt.Sprintf("application %s was created", app.Name))
+				continue
+			case changed:
+				updated := current.DeepCopy()
+				updated.Spec = app.Spec
+				updated.Labels = app.Labels
+				updated.Annotations = app.Annotations
+				updated.Finalizers = app.Finalizers
+				if current, err = appIf.Update(updated); err != nil {
+					errs = append(errs, fmt.Sprintf("failed to update application %s: %v", app.Name, err))
+					waiting = append(waiting, fmt.Sprintf("application %s is not updated", app.Name))
+					continue
+				}
+				logCtx.Infof("Updated application %s", app.Name)
+			}
+			if message, ok := rolloutStatus(current); ok {
+				failed = append(failed, message)
+			} else if message != "" {
+				waiting = append(waiting, message)
+			}
+		}
+		if appSet.Spec.Strategy == nil || appSet.Spec.Strategy.Type != appv1.ApplicationSetStrategyTypeRollingSync || blocked != nil {
+			continue
+		}
+		if len(failed) > 0 {
+			blocked = &appv1.ApplicationSetCondition{
+				Type:    appv1.ApplicationSetConditionRolloutPaused,
+				Message: fmt.Sprintf("step %d of %d failed: %s", i+1, len(steps), strings.Join(failed, "; ")),
+			}
+		} else if len(waiting) > 0 {
+			blocked = &appv1.ApplicationSetCondition{
+				Type:    appv1.ApplicationSetConditionRolloutProgressing,
+				Message: fmt.Sprintf("waiting for step %d of %d: %s", i+1, len(steps), strings.Join(waiting, "; ")),
+			}
+		}
+	}
+	if !withheld {
+		return errs, nil, nil
+	}
+	return errs, blocked, nil
+}
+
+// generateApplications renders the applications of an ApplicationSet, keyed by name
+func (ctrl *ApplicationSetController) generateApplications(appSet *appv1.ApplicationSet) (map[string]*appv1.Application, error) {
+	paramSets, err := ctrl.generateParams(appSet)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*appv1.Application)
+	for _, params := range paramSets {
+		app, err := renderApplication(appSet, params)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := res[app.Name]; ok {
+			return nil, fmt.Errorf("more than one application is named %s", app.Name)
+		}
+		res[app.Name] = app
+	}
+	return res, nil
+}
+
+// setConditions updates the ErrorOccurred and rollout conditions of an ApplicationSet, if they changed
+func (ctrl *ApplicationSetController) setConditions(appSet *appv1.ApplicationSet, errs []string, rollout *appv1.ApplicationSetCondition) error {
+	var conditions []appv1.ApplicationSetCondition
+	if len(errs) > 0 {
+		conditions = append(conditions, appv1.ApplicationSetCondition{
+			Type:    appv1.ApplicationSetConditionErrorOccurred,
+			Message: strings.Join(errs, "; "),
+		})
+	}
+	if rollout != nil {
+		conditions = append(conditions, *rollout)
+	}
+	for i := range conditions {
+		condition := &conditions[i]
+		for _, existing := range appSet.Status.Conditions {
+			if existing.Type == condition.Type && existing.Message == condition.Message {
+				condition.LastTransitionTime = existing.LastTransitionTime
+			}
+		}
+		if condition.LastTransitionTime == nil {
+			now := metav1.Now()
+			condition.LastTransitionTime = &now
+		}
+	}
+	if reflect.DeepEqual(appSet.Status.Conditions, conditions) {
+		return nil
+	}
+	appSet.Status.Conditions = conditions
+	_, err := ctrl.applicationClientset.ArgoprojV1alpha1().ApplicationSets(appSet.Namespace).Update(appSet)
+	return err
+}
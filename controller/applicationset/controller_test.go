@@ -0,0 +1,756 @@
+package applicationset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-cd/reposerver/apiclient"
+	mockrepoclient "github.com/argoproj/argo-cd/reposerver/apiclient/mocks"
+	mockreposerver "github.com/argoproj/argo-cd/reposerver/mocks"
+	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+var fakeAppSet = `
+apiVersion: argoproj.io/v1alpha1
+kind: ApplicationSet
+metadata:
+  name: guestbook
+  namespace: ` + test.FakeArgoCDNamespace + `
+  uid: "123"
+spec:
+  generators:
+  - list:
+      elements:
+      - cluster: staging
+        url: https://staging.example.com
+        values:
+          revision: develop
+      - cluster: production
+        url: https://production.example.com
+        values:
+          revision: master
+  template:
+    metadata:
+      name: '{{cluster}}-guestbook'
+      labels:
+        env: '{{cluster}}'
+    spec:
+      project: default
+      source:
+        repoURL: https://github.com/argoproj/argocd-example-apps.git
+        path: guestbook
+        targetRevision: '{{values.revision}}'
+      destination:
+        server: '{{url}}'
+        namespace: guestbook
+`
+
+func newFakeAppSet() *appv1.ApplicationSet {
+	var appSet appv1.ApplicationSet
+	err := yaml.Unmarshal([]byte(fakeAppSet), &appSet)
+	if err != nil {
+		panic(err)
+	}
+	return &appSet
+}
+
+func newFakeClusterSecret(name string, server string, labels map[string]string) *corev1.Secret {
+	secretLabels := map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeCluster}
+	for k, v := range labels {
+		secretLabels[k] = v
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-" + name, Namespace: test.FakeArgoCDNamespace, Labels: secretLabels},
+		Data:       map[string][]byte{"name": []byte(name), "server": []byte(server)},
+	}
+}
+
+type fakeCloser struct{}
+
+func (f *fakeCloser) Close() error { return nil }
+
+func newFakeController(repoClient *mockrepoclient.RepoServerServiceClient, kubeObjects []runtime.Object, objects ...runtime.Object) *ApplicationSetController {
+	kubeClient := fake.NewSimpleClientset(append(kubeObjects, test.NewFakeConfigMap(), test.NewFakeSecret())...)
+	if repoClient == nil {
+		repoClient = &mockrepoclient.RepoServerServiceClient{}
+	}
+	repoClientset := mockreposerver.Clientset{}
+	repoClientset.On("NewRepoServerClient").Return(&fakeCloser{}, repoClient, nil)
+	ctrl := NewApplicationSetController(
+		test.FakeArgoCDNamespace,
+		settings.NewSettingsManager(context.Background(), kubeClient, test.FakeArgoCDNamespace),
+		kubeClient,
+		dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		appclientset.NewSimpleClientset(objects...),
+		&repoClientset,
+		time.Minute,
+	)
+	cancelAppSet := test.StartInformer(ctrl.appSetInformer)
+	defer cancelAppSet()
+	cancelApp := test.StartInformer(ctrl.appInformer)
+	defer cancelApp()
+	return ctrl
+}
+
+func getApps(t *testing.T, ctrl *ApplicationSetController) map[string]appv1.Application {
+	list, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).List(metav1.ListOptions{})
+	assert.NoError(t, err)
+	apps := make(map[string]appv1.Application)
+	for _, app := range list.Items {
+		apps[app.Name] = app
+	}
+	return apps
+}
+
+func TestReconcile_CreatesApplications(t *testing.T) {
+	appSet := newFakeAppSet()
+	ctrl := newFakeController(nil, nil, appSet)
+
+	assert.NoError(t, ctrl.reconcile(appSet))
+
+	apps := getApps(t, ctrl)
+	assert.Len(t, apps, 2)
+	staging := apps["staging-guestbook"]
+	assert.Equal(t, "https://staging.example.com", staging.Spec.Destination.Server)
+	assert.Equal(t, "develop", staging.Spec.Source.TargetRevision)
+	assert.Equal(t, map[string]string{"env": "staging"}, staging.Labels)
+	assert.True(t, metav1.IsControlledBy(&staging, appSet))
+	assert.Equal(t, "master", apps["production-guestbook"].Spec.Source.TargetRevision)
+	assert.Empty(t, appSet.Status.Conditions)
+}
+
+func TestReconcile_UpdatesAndDeletesApplications(t *testing.T) {
+	appSet := newFakeAppSet()
+	outdated, err := renderApplication(appSet, map[string]string{"cluster": "staging", "url": "https://staging.example.com", "values.revision": "v1"})
+	assert.NoError(t, err)
+	stale, err := renderApplication(appSet, map[string]string{"cluster": "qa", "url": "https://qa.example.com"})
+	assert.NoError(t, err)
+	unmanaged := stale.DeepCopy()
+	unmanaged.Name = "unmanaged"
+	unmanaged.OwnerReferences = nil
+	appSet.Spec.Generators[0].List.Elements = appSet.Spec.Generators[0].List.Elements[:1]
+	ctrl := newFakeController(nil, nil, appSet, outdated, stale, unmanaged)
+
+	assert.NoError(t, ctrl.reconcile(appSet))
+
+	apps := getApps(t, ctrl)
+	assert.Len(t, apps, 2)
+	assert.Equal(t, "develop", apps["staging-guestbook"].Spec.Source.TargetRevision)
+	assert.Contains(t, apps, "unmanaged")
+}
+
+func TestReconcile_SyncPolicy(t *testing.T) {
+	for policy, expected := range map[string]struct {
+		revision string
+		deleted  bool
+	}{
+		appv1.ApplicationsSyncPolicyCreateOnly:   {"v1", false},
+		appv1.ApplicationsSyncPolicyCreateUpdate: {"develop", false},
+		appv1.ApplicationsSyncPolicyCreateDelete: {"v1", true},
+		appv1.ApplicationsSyncPolicySync:         {"develop", true},
+	} {
+		appSet := newFakeAppSet()
+		appSet.Spec.SyncPolicy = &appv1.ApplicationSetSyncPolicy{ApplicationsSync: policy}
+		outdated, err := renderApplication(appSet, map[string]string{"cluster": "staging", "url": "https://staging.example.com", "values.revision": "v1"})
+		assert.NoError(t, err)
+		stale, err := renderApplication(appSet, map[string]string{"cluster": "qa", "url": "https://qa.example.com"})
+		assert.NoError(t, err)
+		ctrl := newFakeController(nil, nil, appSet, outdated, stale)
+
+		assert.NoError(t, ctrl.reconcile(appSet))
+
+		apps := getApps(t, ctrl)
+		assert.Contains(t, apps, "production-guestbook", policy)
+		assert.Equal(t, expected.revision, apps["staging-guestbook"].Spec.Source.TargetRevision, policy)
+		_, exists := apps["qa-guestbook"]
+		assert.Equal(t, expected.deleted, !exists, policy)
+	}
+}
+
+func TestReconcile_IgnoreApplicationDifferences(t *testing.T) {
+	appSet := newFakeAppSet()
+	appSet.Spec.IgnoreApplicationDifferences = []appv1.ApplicationSetIgnoreDifferences{
+		{JSONPointers: []string{"/spec/source/targetRevision"}},
+		{Name: "production-guestbook", JSONPointers: []string{"/metadata/labels/env"}},
+	}
+	staging, err := renderApplication(appSet, map[string]string{"cluster": "staging", "url": "https://staging.example.com", "values.revision": "pinned"})
+	assert.NoError(t, err)
+	staging.Spec.Destination.Namespace = "changed"
+	production, err := renderApplication(appSet, map[string]string{"cluster": "production", "url": "https://production.example.com", "values.revision": "pinned"})
+	assert.NoError(t, err)
+	production.Labels = nil
+	ctrl := newFakeController(nil, nil, appSet, staging, production)
+
+	assert.NoError(t, ctrl.reconcile(appSet))
+
+	apps := getApps(t, ctrl)
+	assert.Equal(t, "pinned", apps["staging-guestbook"].Spec.Source.TargetRevision)
+	assert.Equal(t, "guestbook", apps["staging-guestbook"].Spec.Destination.Namespace)
+	assert.Equal(t, "pinned", apps["production-guestbook"].Spec.Source.TargetRevision)
+	assert.Empty(t, apps["production-guestbook"].Labels)
+	assert.Empty(t, appSet.Status.Conditions)
+}
+
+func TestReconcile_ErrorCondition(t *testing.T) {
+	appSet := newFakeAppSet()
+	appSet.Spec.Template.Name = "guestbook"
+	ctrl := newFakeController(nil, nil, appSet)
+
+	assert.NoError(t, ctrl.reconcile(appSet))
+
+	assert.Len(t, getApps(t, ctrl), 0)
+	updated, err := ctrl.applicationClientset.ArgoprojV1alpha1().ApplicationSets(test.FakeArgoCDNamespace).Get(appSet.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, updated.Status.Conditions, 1) {
+		assert.Equal(t, appv1.ApplicationSetConditionErrorOccurred, updated.Status.Conditions[0].Type)
+		assert.Equal(t, "more than one application is named guestbook", updated.Status.Conditions[0].Message)
+	}
+}
+
+func TestReconcile_ExistingUnmanagedApplication(t *testing.T) {
+	appSet := newFakeAppSet()
+	existing := &appv1.Application{ObjectMeta: metav1.ObjectMeta{Name: "staging-guestbook", Namespace: test.FakeArgoCDNamespace}}
+	ctrl := newFakeController(nil, nil, appSet, existing)
+
+	assert.NoError(t, ctrl.reconcile(appSet))
+
+	apps := getApps(t, ctrl)
+	assert.Len(t, apps, 2)
+	assert.Empty(t, apps["staging-guestbook"].Spec.Source.RepoURL)
+	if assert.Len(t, appSet.Status.Conditions, 1) {
+		assert.Contains(t, appSet.Status.Conditions[0].Message, "application staging-guestbook already exists and is not managed by the ApplicationSet")
+	}
+}
+
+func TestReconcile_RollingSync(t *testing.T) {
+	newAppSet := func() *appv1.ApplicationSet {
+		appSet := newFakeAppSet()
+		appSet.Spec.Strategy = &appv1.ApplicationSetStrategy{
+			Type: appv1.ApplicationSetStrategyTypeRollingSync,
+			RollingSync: &appv1.ApplicationSetRolloutStrategy{Steps: []appv1.ApplicationSetRolloutStep{
+				{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}}},
+				{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}}},
+			}},
+		}
+		return appSet
+	}
+	newApp := func(appSet *appv1.ApplicationSet, cluster string, revision string, health appv1.HealthStatusCode) *appv1.Application {
+		app, err := renderApplication(appSet, map[string]string{"cluster": cluster, "url": "https://" + cluster + ".example.com", "values.revision": revision})
+		assert.NoError(t, err)
+		app.Status.Sync = appv1.SyncStatus{Status: appv1.SyncStatusCodeSynced, ComparedTo: appv1.ComparedTo{Source: app.Spec.Source, Destination: app.Spec.Destination}}
+		app.Status.Health.Status = health
+		return app
+	}
+
+	t.Run("WaitsForPreviousStep", func(t *testing.T) {
+		appSet := newAppSet()
+		ctrl := newFakeController(nil, nil, appSet, newApp(appSet, "staging", "v1", appv1.HealthStatusHealthy), newApp(appSet, "production", "v1", appv1.HealthStatusHealthy))
+
+		assert.NoError(t, ctrl.reconcile(appSet))
+
+		apps := getApps(t, ctrl)
+		assert.Equal(t, "develop", apps["staging-guestbook"].Spec.Source.TargetRevision)
+		assert.Equal(t, "v1", apps["production-guestbook"].Spec.Source.TargetRevision)
+		if assert.Len(t, appSet.Status.Conditions, 1) {
+			assert.Equal(t, appv1.ApplicationSetConditionRolloutProgressing, appSet.Status.Conditions[0].Type)
+			assert.Equal(t, "waiting for step 1 of 2: application staging-guestbook is not refreshed yet", appSet.Status.Conditions[0].Message)
+		}
+	})
+
+	t.Run("UpdatesNextStep", func(t *testing.T) {
+		appSet := newAppSet()
+		ctrl := newFakeController(nil, nil, appSet, newApp(appSet, "staging", "develop", appv1.HealthStatusHealthy), newApp(appSet, "production", "v1", appv1.HealthStatusHealthy))
+
+		assert.NoError(t, ctrl.reconcile(appSet))
+
+		assert.Equal(t, "master", getApps(t, ctrl)["production-guestbook"].Spec.Source.TargetRevision)
+		assert.Empty(t, appSet.Status.Conditions)
+	})
+
+	t.Run("PausesOnFailure", func(t *testing.T) {
+		appSet := newAppSet()
+		ctrl := newFakeController(nil, nil, appSet, newApp(appSet, "staging", "develop", appv1.HealthStatusDegraded), newApp(appSet, "production", "v1", appv1.HealthStatusHealthy))
+
+		assert.NoError(t, ctrl.reconcile(appSet))
+
+		assert.Equal(t, "v1", getApps(t, ctrl)["production-guestbook"].Spec.Source.TargetRevision)
+		if assert.Len(t, appSet.Status.Conditions, 1) {
+			assert.Equal(t, appv1.ApplicationSetConditionRolloutPaused, appSet.Status.Conditions[0].Type)
+			assert.Equal(t, "step 1 of 2 failed: application staging-guestbook is Degraded", appSet.Status.Conditions[0].Message)
+		}
+	})
+
+	t.Run("InvalidStrategy", func(t *testing.T) {
+		appSet := newAppSet()
+		appSet.Spec.Strategy.RollingSync = nil
+		ctrl := newFakeController(nil, nil, appSet)
+
+		assert.NoError(t, ctrl.reconcile(appSet))
+
+		assert.Len(t, getApps(t, ctrl), 0)
+		if assert.Len(t, appSet.Status.Conditions, 1) {
+			assert.Equal(t, "the RollingSync strategy has no steps", appSet.Status.Conditions[0].Message)
+		}
+	})
+}
+
+func TestGenerateParams_Clusters(t *testing.T) {
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Clusters: &appv1.ClusterGenerator{Values: map[string]string{"revision": "master"}}}}
+	staging := newFakeClusterSecret("staging", "https://staging.example.com", map[string]string{"env": "staging"})
+	staging.Annotations = map[string]string{"owner": "team-a"}
+	ctrl := newFakeController(nil, []runtime.Object{
+		staging,
+		newFakeClusterSecret("production", "https://production.example.com", map[string]string{"env": "production"}),
+	})
+	stagingParams := map[string]string{
+		"name":                "staging",
+		"server":              "https://staging.example.com",
+		"metadata.labels.env": "staging",
+		"metadata.labels." + common.LabelKeySecretType: common.LabelValueSecretTypeCluster,
+		"metadata.annotations.owner":                   "team-a",
+		"values.revision":                              "master",
+	}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []map[string]string{
+		stagingParams,
+		{
+			"name":                "production",
+			"server":              "https://production.example.com",
+			"metadata.labels.env": "production",
+			"metadata.labels." + common.LabelKeySecretType: common.LabelValueSecretTypeCluster,
+			"values.revision": "master",
+		},
+		{"name": "in-cluster", "server": common.KubernetesInternalAPIServerAddr, "values.revision": "master"},
+	}, params)
+
+	appSet.Spec.Generators[0].Clusters.Selector = metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}}
+	params, err = ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{stagingParams}, params)
+
+	appSet.Spec.Generators[0].Clusters.Selector = metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"staging"}},
+	}}
+	params, err = ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	if assert.Len(t, params, 1) {
+		assert.Equal(t, "production", params[0]["name"])
+	}
+}
+
+func TestGenerateParams_Invalid(t *testing.T) {
+	appSet := newFakeAppSet()
+	ctrl := newFakeController(nil, nil)
+
+	appSet.Spec.Generators = nil
+	_, err := ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "ApplicationSet has no generators")
+
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{}}
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: no generator type is set")
+
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{List: &appv1.ListGenerator{}, Git: &appv1.GitGenerator{}}}
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: more than one generator type is set")
+}
+
+func TestGenerateParams_GitDirectories(t *testing.T) {
+	repoClient := mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("GetGitDirectories", mock.Anything, &apiclient.GitFilesRequest{Repo: &appv1.Repository{Repo: "https://example.com/apps.git"}, Revision: "HEAD", Path: "apps/*"}).
+		Return(&apiclient.GitDirectoriesResponse{Paths: []string{"apps/guestbook", "apps/helm-guestbook", "apps/excluded"}}, nil)
+	repoClient.On("GetGitDirectories", mock.Anything, &apiclient.GitFilesRequest{Repo: &appv1.Repository{Repo: "https://example.com/apps.git"}, Revision: "HEAD", Path: "apps/excluded"}).
+		Return(&apiclient.GitDirectoriesResponse{Paths: []string{"apps/excluded"}}, nil)
+	ctrl := newFakeController(&repoClient, nil)
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Git: &appv1.GitGenerator{
+		RepoURL:     "https://example.com/apps.git",
+		Directories: []appv1.GitDirectoryGeneratorItem{{Path: "apps/*"}, {Path: "apps/excluded", Exclude: true}},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"path": "apps/guestbook", "path.basename": "guestbook"},
+		{"path": "apps/helm-guestbook", "path.basename": "helm-guestbook"},
+	}, params)
+}
+
+func TestGenerateParams_GitFiles(t *testing.T) {
+	repoClient := mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("GetGitFiles", mock.Anything, mock.Anything).Return(&apiclient.GitFilesResponse{Files: map[string][]byte{
+		"clusters/staging/config.json": []byte(`{"cluster": {"name": "staging", "replicas": 2, "tags": ["a", "b"]}}`),
+		"clusters/regions.yaml":        []byte("- region: us\n- region: eu\n"),
+	}}, nil)
+	ctrl := newFakeController(&repoClient, nil)
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Git: &appv1.GitGenerator{
+		RepoURL: "https://example.com/apps.git",
+		Files:   []appv1.GitFileGeneratorItem{{Path: "clusters/**"}},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"region": "us", "path": "clusters", "path.basename": "clusters"},
+		{"region": "eu", "path": "clusters", "path.basename": "clusters"},
+		{"cluster.name": "staging", "cluster.replicas": "2", "cluster.tags.0": "a", "cluster.tags.1": "b", "path": "clusters/staging", "path.basename": "staging"},
+	}, params)
+}
+
+func TestFileParams_Invalid(t *testing.T) {
+	_, err := fileParams("config.yaml", []byte("- a\n- b\n"))
+	assert.EqualError(t, err, "config.yaml must contain an object or a list of objects")
+}
+
+func TestRenderApplication_EscapesValues(t *testing.T) {
+	appSet := newFakeAppSet()
+	app, err := renderApplication(appSet, map[string]string{"cluster": "staging", "url": "https://staging.example.com", "values.revision": `"quoted"`})
+	assert.NoError(t, err)
+	assert.Equal(t, `"quoted"`, app.Spec.Source.TargetRevision)
+	assert.Equal(t, test.FakeArgoCDNamespace, app.Namespace)
+
+	app, err = renderApplication(appSet, map[string]string{"cluster": "staging"})
+	assert.NoError(t, err)
+	assert.Equal(t, "{{url}}", app.Spec.Destination.Server)
+}
+
+func TestRenderApplication_GoTemplate(t *testing.T) {
+	appSet := newFakeAppSet()
+	appSet.Spec.GoTemplate = true
+	appSet.Spec.Template.Name = `{{.path.basename | lower}}-{{.cluster}}`
+	appSet.Spec.Template.Labels = map[string]string{"{{.cluster}}": `{{default "none" .values.team}}`}
+	appSet.Spec.Template.Spec.Source.Path = "{{.path.path}}"
+	appSet.Spec.Template.Spec.Destination.Server = "https://{{.cluster}}.example.com"
+	appSet.Spec.Template.Spec.Source.TargetRevision = `{{if eq .cluster "production"}}stable{{else}}HEAD{{end}}`
+	templatePatch := `
+{{- if eq .cluster "production" }}
+spec:
+  syncPolicy:
+    automated:
+      prune: true
+{{- end }}
+`
+	appSet.Spec.TemplatePatch = &templatePatch
+
+	app, err := renderApplication(appSet, map[string]string{"cluster": "production", "path": "apps/Guestbook", "path.basename": "Guestbook", "values.team": ""})
+	assert.NoError(t, err)
+	assert.Equal(t, "guestbook-production", app.Name)
+	assert.Equal(t, map[string]string{"production": "none"}, app.Labels)
+	assert.Equal(t, "apps/Guestbook", app.Spec.Source.Path)
+	assert.Equal(t, "stable", app.Spec.Source.TargetRevision)
+	assert.Equal(t, "https://github.com/argoproj/argocd-example-apps.git", app.Spec.Source.RepoURL)
+	if assert.NotNil(t, app.Spec.SyncPolicy) && assert.NotNil(t, app.Spec.SyncPolicy.Automated) {
+		assert.True(t, app.Spec.SyncPolicy.Automated.Prune)
+	}
+
+	app, err = renderApplication(appSet, map[string]string{"cluster": "staging", "path": "apps/guestbook", "path.basename": "guestbook", "values.team": "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, "HEAD", app.Spec.Source.TargetRevision)
+	assert.Equal(t, map[string]string{"staging": "web"}, app.Labels)
+	assert.Nil(t, app.Spec.SyncPolicy)
+
+	_, err = renderApplication(appSet, map[string]string{"cluster": "staging"})
+	assert.Error(t, err)
+
+	appSet.Spec.GoTemplate = false
+	_, err = renderApplication(appSet, map[string]string{"cluster": "staging"})
+	assert.EqualError(t, err, "templatePatch requires goTemplate")
+}
+
+func TestNestParams(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"path":     map[string]interface{}{"path": "apps/guestbook", "basename": "guestbook"},
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"env": "prod"}},
+		"name":     "guestbook",
+	}, nestParams(map[string]string{"path": "apps/guestbook", "path.basename": "guestbook", "metadata.labels.env": "prod", "name": "guestbook"}))
+}
+
+func TestGenerateParams_ClusterDecisionResource(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ocm-placement", Namespace: test.FakeArgoCDNamespace},
+		Data:       map[string]string{"apiVersion": "cluster.open-cluster-management.io/v1alpha1", "kind": "PlacementDecision", "statusListKey": "decisions"},
+	}
+	decision := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.open-cluster-management.io/v1alpha1",
+		"kind":       "PlacementDecision",
+		"metadata":   map[string]interface{}{"name": "guestbook", "namespace": test.FakeArgoCDNamespace, "labels": map[string]interface{}{"app": "guestbook"}},
+		"status": map[string]interface{}{"decisions": []interface{}{
+			map[string]interface{}{"clusterName": "production", "reason": "capacity"},
+			map[string]interface{}{"clusterName": "unknown"},
+		}},
+	}}
+	ctrl := newFakeController(nil, []runtime.Object{cm, newFakeClusterSecret("production", "https://production.example.com", nil)})
+	ctrl.dynamicClientset = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), decision)
+	ctrl.kubeClientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "cluster.open-cluster-management.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "placementdecisions/status", Kind: "PlacementDecision"}, {Name: "placementdecisions", Kind: "PlacementDecision", Namespaced: true}},
+	}}
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{ClusterDecisionResource: &appv1.ClusterDecisionResourceGenerator{
+		ConfigMapRef:  "ocm-placement",
+		LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "guestbook"}},
+		Values:        map[string]string{"env": "production"},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{
+		"clusterName": "production",
+		"reason":      "capacity",
+		"name":        "production",
+		"server":      "https://production.example.com",
+		"values.env":  "production",
+	}}, params)
+
+	appSet.Spec.Generators[0].ClusterDecisionResource.Name = "missing"
+	_, err = ctrl.generateParams(appSet)
+	assert.Error(t, err)
+
+	cm.Data["statusListKey"] = ""
+	_, err = ctrl.kubeClientset.CoreV1().ConfigMaps(test.FakeArgoCDNamespace).Update(cm)
+	assert.NoError(t, err)
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: config map ocm-placement has no statusListKey")
+}
+
+func TestGenerateParams_Matrix(t *testing.T) {
+	repoClient := mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("GetGitDirectories", mock.Anything, mock.Anything).Return(&apiclient.GitDirectoriesResponse{Paths: []string{"apps/guestbook", "apps/helm-guestbook"}}, nil)
+	ctrl := newFakeController(&repoClient, nil)
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Matrix: &appv1.MatrixGenerator{Generators: []appv1.ApplicationSetNestedGenerator{
+		{List: &appv1.ListGenerator{Elements: []appv1.ListGeneratorElement{
+			{Cluster: "staging", URL: "https://staging.example.com"},
+			{Cluster: "production", URL: "https://production.example.com"},
+		}}},
+		{Git: &appv1.GitGenerator{RepoURL: "https://example.com/apps.git", Directories: []appv1.GitDirectoryGeneratorItem{{Path: "apps/*"}}}},
+	}}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"cluster": "staging", "url": "https://staging.example.com", "path": "apps/guestbook", "path.basename": "guestbook"},
+		{"cluster": "staging", "url": "https://staging.example.com", "path": "apps/helm-guestbook", "path.basename": "helm-guestbook"},
+		{"cluster": "production", "url": "https://production.example.com", "path": "apps/guestbook", "path.basename": "guestbook"},
+		{"cluster": "production", "url": "https://production.example.com", "path": "apps/helm-guestbook", "path.basename": "helm-guestbook"},
+	}, params)
+
+	appSet.Spec.Generators[0].Matrix.Generators[1] = appv1.ApplicationSetNestedGenerator{List: &appv1.ListGenerator{Elements: []appv1.ListGeneratorElement{{Cluster: "qa", URL: "https://staging.example.com"}}}}
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: generators produce different values of parameter cluster")
+
+	appSet.Spec.Generators[0].Matrix.Generators = appSet.Spec.Generators[0].Matrix.Generators[:1]
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: at least two generators are required")
+}
+
+func TestGenerateParams_Merge(t *testing.T) {
+	repoClient := mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("GetGitFiles", mock.Anything, mock.Anything).Return(&apiclient.GitFilesResponse{Files: map[string][]byte{
+		"overrides/production.yaml": []byte("server: https://production.example.com\nvalues:\n  revision: stable\n"),
+		"overrides/unknown.yaml":    []byte("server: https://unknown.example.com\nvalues:\n  revision: stable\n"),
+	}}, nil)
+	ctrl := newFakeController(&repoClient, []runtime.Object{
+		newFakeClusterSecret("staging", "https://staging.example.com", nil),
+		newFakeClusterSecret("production", "https://production.example.com", nil),
+	})
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Merge: &appv1.MergeGenerator{
+		MergeKeys: []string{"server"},
+		Generators: []appv1.ApplicationSetNestedGenerator{
+			{Clusters: &appv1.ClusterGenerator{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeCluster}},
+				Values:   map[string]string{"revision": "master", "replicas": "1"},
+			}},
+			{Git: &appv1.GitGenerator{RepoURL: "https://example.com/config.git", Files: []appv1.GitFileGeneratorItem{{Path: "overrides/*.yaml"}}}},
+		},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	revisions := make(map[string]string)
+	for _, p := range params {
+		revisions[p["name"]] = p["values.revision"]
+		assert.Equal(t, "1", p["values.replicas"])
+	}
+	assert.Equal(t, map[string]string{"staging": "master", "production": "stable"}, revisions)
+
+	appSet.Spec.Generators[0].Merge.MergeKeys = []string{"name"}
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: generator 1: parameter set has no merge key name")
+}
+
+func TestGenerateParams_SCMProvider(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/orgs/argoproj/repos":
+			_, _ = fmt.Fprint(w, `[{"name": "argo-cd", "clone_url": "https://github.com/argoproj/argo-cd.git", "default_branch": "master", "topics": ["gitops", "cd"]},
+				{"name": "other", "clone_url": "https://github.com/argoproj/other.git", "default_branch": "master"}]`)
+		case "/repos/argoproj/argo-cd/branches/master":
+			_, _ = fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: test.FakeArgoCDNamespace},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}
+	ctrl := newFakeController(nil, []runtime.Object{tokenSecret})
+	repositoryMatch := "^argo"
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{SCMProvider: &appv1.SCMProviderGenerator{
+		Github: &appv1.SCMProviderGeneratorGithub{
+			Organization: "argoproj",
+			API:          server.URL,
+			TokenRef:     &appv1.SecretKeyRef{SecretName: "github-token", Key: "token"},
+		},
+		Filters: []appv1.SCMProviderGeneratorFilter{{RepositoryMatch: &repositoryMatch}},
+		Values:  map[string]string{"env": "preview"},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{
+		"organization": "argoproj",
+		"repository":   "argo-cd",
+		"url":          "https://github.com/argoproj/argo-cd.git",
+		"branch":       "master",
+		"sha":          "abc",
+		"labels":       "gitops,cd",
+		"values.env":   "preview",
+	}}, params)
+	assert.Equal(t, 2, requests)
+
+	// the provider is not scanned again until the requeue time passed
+	_, err = ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	appSet.Spec.Generators[0].SCMProvider.Github.TokenRef.Key = "missing"
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: secret github-token has no key missing")
+}
+
+func TestGenerateParams_PullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/argoproj/argo-cd/pulls":
+			_, _ = fmt.Fprint(w, `[{"number": 12, "head": {"ref": "feature/New_UI", "sha": "0123456789abcdef"}, "labels": [{"name": "preview"}]},
+				{"number": 13, "head": {"ref": "fix/crash", "sha": "fedcba9876543210"}, "labels": [{"name": "preview"}]}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctrl := newFakeController(nil, nil)
+	branchMatch := "^feature/"
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{PullRequest: &appv1.PullRequestGenerator{
+		Github:  &appv1.PullRequestGeneratorGithub{Owner: "argoproj", Repo: "argo-cd", API: server.URL, Labels: []string{"preview"}},
+		Filters: []appv1.PullRequestGeneratorFilter{{BranchMatch: &branchMatch}},
+		Values:  map[string]string{"env": "preview"},
+	}}}
+
+	params, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{
+		"number":         "12",
+		"branch":         "feature/New_UI",
+		"branch_slug":    "feature-new-ui",
+		"head_sha":       "0123456789abcdef",
+		"head_short_sha": "01234567",
+		"values.env":     "preview",
+	}}, params)
+
+	appSet.Spec.Generators[0].PullRequest.Github = nil
+	_, err = ctrl.generateParams(appSet)
+	assert.EqualError(t, err, "generator 0: no pull request provider is set")
+}
+
+func TestBranchSlug(t *testing.T) {
+	assert.Equal(t, "feature-new-ui", branchSlug("Feature/New_UI"))
+	assert.Equal(t, "fix-1", branchSlug("--fix#1--"))
+	assert.Len(t, branchSlug(strings.Repeat("a", 60)), maxBranchSlugLength)
+}
+
+func TestInvalidateCachedParams(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprint(w, `[{"number": 12, "head": {"ref": "feature", "sha": "0123456789abcdef"}}]`)
+	}))
+	defer server.Close()
+	ctrl := newFakeController(nil, nil)
+	appSet := newFakeAppSet()
+	appSet.Spec.Generators = []appv1.ApplicationSetGenerator{{Matrix: &appv1.MatrixGenerator{Generators: []appv1.ApplicationSetNestedGenerator{
+		{PullRequest: &appv1.PullRequestGenerator{Github: &appv1.PullRequestGeneratorGithub{Owner: "argoproj", Repo: "argo-cd", API: server.URL}}},
+		{List: &appv1.ListGenerator{Elements: []appv1.ListGeneratorElement{{Cluster: "staging", URL: "https://staging.example.com"}}}},
+	}}}}
+
+	_, err := ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	_, err = ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	ctrl.invalidateCachedParams(appSet)
+	_, err = ctrl.generateParams(appSet)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRefreshRequested(t *testing.T) {
+	old := newFakeAppSet()
+	new := old.DeepCopy()
+	assert.False(t, refreshRequested(old, new))
+	new.Annotations = map[string]string{common.AnnotationKeyApplicationSetRefresh: "2021-01-01T00:00:00Z"}
+	assert.True(t, refreshRequested(old, new))
+	assert.False(t, refreshRequested(new, new))
+}
+
+func TestEnqueueClusterGenerators(t *testing.T) {
+	clusters := newFakeAppSet()
+	clusters.Spec.Generators = []appv1.ApplicationSetGenerator{{Clusters: &appv1.ClusterGenerator{}}}
+	list := newFakeAppSet()
+	list.Name = "list"
+	list.UID = "456"
+	ctrl := newFakeController(nil, nil, clusters, list)
+	for ctrl.appSetQueue.Len() > 0 {
+		key, _ := ctrl.appSetQueue.Get()
+		ctrl.appSetQueue.Done(key)
+	}
+
+	ctrl.enqueueClusterGenerators()
+	assert.Equal(t, 1, ctrl.appSetQueue.Len())
+	key, _ := ctrl.appSetQueue.Get()
+	assert.Equal(t, test.FakeArgoCDNamespace+"/"+clusters.Name, key)
+}
@@ -0,0 +1,701 @@
+package applicationset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/scm"
+)
+
+const (
+	// inClusterName is the name parameter of the cluster Argo CD runs in, unless it is configured by a cluster secret
+	inClusterName = "in-cluster"
+	// defaultSCMProviderRequeueAfter is the default minimum time between scans of an SCM provider
+	defaultSCMProviderRequeueAfter = 30 * time.Minute
+	// defaultPullRequestRequeueAfter is the default minimum time between listings of the pull requests of a repository
+	defaultPullRequestRequeueAfter = 5 * time.Minute
+	// maxBranchSlugLength keeps branch slugs short enough to be part of resource names
+	maxBranchSlugLength = 50
+)
+
+var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+// scmCacheEntry contains the parameter sets of the last run of a generator which calls the API of an SCM provider
+type scmCacheEntry struct {
+	params  []map[string]string
+	expires time.Time
+}
+
+// generateParams returns the parameter sets produced by all the generators of an ApplicationSet
+func (ctrl *ApplicationSetController) generateParams(appSet *appv1.ApplicationSet) ([]map[string]string, error) {
+	if len(appSet.Spec.Generators) == 0 {
+		return nil, fmt.Errorf("ApplicationSet has no generators")
+	}
+	var res []map[string]string
+	for i, generator := range appSet.Spec.Generators {
+		params, err := ctrl.generate(generator)
+		if err != nil {
+			return nil, fmt.Errorf("generator %d: %v", i, err)
+		}
+		res = append(res, params...)
+	}
+	return res, nil
+}
+
+// generate returns the parameter sets produced by a generator
+func (ctrl *ApplicationSetController) generate(generator appv1.ApplicationSetGenerator) ([]map[string]string, error) {
+	types := 0
+	for _, set := range []bool{generator.List != nil, generator.Clusters != nil, generator.Git != nil, generator.Matrix != nil, generator.Merge != nil, generator.SCMProvider != nil, generator.PullRequest != nil, generator.ClusterDecisionResource != nil} {
+		if set {
+			types++
+		}
+	}
+	switch {
+	case types > 1:
+		return nil, fmt.Errorf("more than one generator type is set")
+	case generator.List != nil:
+		return generateListParams(generator.List), nil
+	case generator.Clusters != nil:
+		return ctrl.generateClusterParams(generator.Clusters)
+	case generator.Git != nil:
+		return ctrl.generateGitParams(generator.Git)
+	case generator.Matrix != nil:
+		return ctrl.generateMatrixParams(generator.Matrix)
+	case generator.Merge != nil:
+		return ctrl.generateMergeParams(generator.Merge)
+	case generator.SCMProvider != nil:
+		return ctrl.generateSCMProviderParams(generator.SCMProvider)
+	case generator.PullRequest != nil:
+		return ctrl.generatePullRequestParams(generator.PullRequest)
+	case generator.ClusterDecisionResource != nil:
+		return ctrl.generateClusterDecisionResourceParams(generator.ClusterDecisionResource)
+	default:
+		return nil, fmt.Errorf("no generator type is set")
+	}
+}
+
+// generateNested returns the parameter sets produced by each of the generators of a matrix or merge generator
+func (ctrl *ApplicationSetController) generateNested(generators []appv1.ApplicationSetNestedGenerator) ([][]map[string]string, error) {
+	if len(generators) < 2 {
+		return nil, fmt.Errorf("at least two generators are required")
+	}
+	var res [][]map[string]string
+	for i, nested := range generators {
+		params, err := ctrl.generate(appv1.ApplicationSetGenerator{List: nested.List, Clusters: nested.Clusters, Git: nested.Git, SCMProvider: nested.SCMProvider, PullRequest: nested.PullRequest, ClusterDecisionResource: nested.ClusterDecisionResource})
+		if err != nil {
+			return nil, fmt.Errorf("generator %d: %v", i, err)
+		}
+		res = append(res, params)
+	}
+	return res, nil
+}
+
+func (ctrl *ApplicationSetController) generateMatrixParams(generator *appv1.MatrixGenerator) ([]map[string]string, error) {
+	paramSets, err := ctrl.generateNested(generator.Generators)
+	if err != nil {
+		return nil, err
+	}
+	res := []map[string]string{{}}
+	for _, params := range paramSets {
+		var combinations []map[string]string
+		for _, combination := range res {
+			for _, p := range params {
+				merged := make(map[string]string)
+				for k, v := range combination {
+					merged[k] = v
+				}
+				for k, v := range p {
+					if existing, ok := merged[k]; ok && existing != v {
+						return nil, fmt.Errorf("generators produce different values of parameter %s", k)
+					}
+					merged[k] = v
+				}
+				combinations = append(combinations, merged)
+			}
+		}
+		res = combinations
+	}
+	return res, nil
+}
+
+func (ctrl *ApplicationSetController) generateMergeParams(generator *appv1.MergeGenerator) ([]map[string]string, error) {
+	if len(generator.MergeKeys) == 0 {
+		return nil, fmt.Errorf("merge generator has no merge keys")
+	}
+	paramSets, err := ctrl.generateNested(generator.Generators)
+	if err != nil {
+		return nil, err
+	}
+	mergeKey := func(params map[string]string) (string, error) {
+		var values []string
+		for _, key := range generator.MergeKeys {
+			value, ok := params[key]
+			if !ok {
+				return "", fmt.Errorf("parameter set has no merge key %s", key)
+			}
+			values = append(values, value)
+		}
+		data, err := json.Marshal(values)
+		return string(data), err
+	}
+	var res []map[string]string
+	byKey := make(map[string][]map[string]string)
+	for _, params := range paramSets[0] {
+		key, err := mergeKey(params)
+		if err != nil {
+			return nil, fmt.Errorf("generator 0: %v", err)
+		}
+		merged := make(map[string]string)
+		for k, v := range params {
+			merged[k] = v
+		}
+		byKey[key] = append(byKey[key], merged)
+		res = append(res, merged)
+	}
+	for i, params := range paramSets[1:] {
+		for _, p := range params {
+			key, err := mergeKey(p)
+			if err != nil {
+				return nil, fmt.Errorf("generator %d: %v", i+1, err)
+			}
+			for _, merged := range byKey[key] {
+				for k, v := range p {
+					merged[k] = v
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func generateListParams(generator *appv1.ListGenerator) []map[string]string {
+	var res []map[string]string
+	for _, element := range generator.Elements {
+		params := map[string]string{
+			"cluster": element.Cluster,
+			"url":     element.URL,
+		}
+		addValues(params, element.Values)
+		res = append(res, params)
+	}
+	return res
+}
+
+func (ctrl *ApplicationSetController) generateClusterParams(generator *appv1.ClusterGenerator) ([]map[string]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&generator.Selector)
+	if err != nil {
+		return nil, err
+	}
+	req, err := labels.NewRequirement(common.LabelKeySecretType, selection.Equals, []string{common.LabelValueSecretTypeCluster})
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := ctrl.kubeClientset.CoreV1().Secrets(ctrl.namespace).List(metav1.ListOptions{LabelSelector: selector.Add(*req).String()})
+	if err != nil {
+		return nil, err
+	}
+	var res []map[string]string
+	hasInCluster := false
+	for _, secret := range secrets.Items {
+		params := map[string]string{
+			"name":   string(secret.Data["name"]),
+			"server": string(secret.Data["server"]),
+		}
+		for k, v := range secret.Labels {
+			params["metadata.labels."+k] = v
+		}
+		for k, v := range secret.Annotations {
+			params["metadata.annotations."+k] = v
+		}
+		if params["server"] == common.KubernetesInternalAPIServerAddr {
+			hasInCluster = true
+		}
+		addValues(params, generator.Values)
+		res = append(res, params)
+	}
+	// the cluster Argo CD runs in has no secret unless its settings were changed, so it has no labels either
+	if !hasInCluster && selector.Empty() {
+		params := map[string]string{
+			"name":   inClusterName,
+			"server": common.KubernetesInternalAPIServerAddr,
+		}
+		addValues(params, generator.Values)
+		res = append(res, params)
+	}
+	return res, nil
+}
+
+func (ctrl *ApplicationSetController) generateClusterDecisionResourceParams(generator *appv1.ClusterDecisionResourceGenerator) ([]map[string]string, error) {
+	cm, err := ctrl.kubeClientset.CoreV1().ConfigMaps(ctrl.namespace).Get(generator.ConfigMapRef, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"apiVersion", "kind", "statusListKey"} {
+		if cm.Data[key] == "" {
+			return nil, fmt.Errorf("config map %s has no %s", cm.Name, key)
+		}
+	}
+	matchKey := cm.Data["matchKey"]
+	if matchKey == "" {
+		matchKey = "clusterName"
+	}
+	resourceIf, err := ctrl.resourceInterface(cm.Data["apiVersion"], cm.Data["kind"])
+	if err != nil {
+		return nil, err
+	}
+	var resources []unstructured.Unstructured
+	if generator.Name != "" {
+		resource, err := resourceIf.Get(generator.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, *resource)
+	} else {
+		selector, err := metav1.LabelSelectorAsSelector(&generator.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		list, err := resourceIf.List(metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		resources = list.Items
+	}
+
+	clusters, err := ctrl.generateClusterParams(&appv1.ClusterGenerator{})
+	if err != nil {
+		return nil, err
+	}
+	servers := make(map[string]string)
+	for _, cluster := range clusters {
+		servers[cluster["name"]] = cluster["server"]
+	}
+	var res []map[string]string
+	for _, resource := range resources {
+		items, _, err := unstructured.NestedSlice(resource.Object, "status", cm.Data["statusListKey"])
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %v", resource.GetKind(), resource.GetName(), err)
+		}
+		for _, item := range items {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name := fmt.Sprintf("%v", fields[matchKey])
+			server, ok := servers[name]
+			if !ok {
+				log.Warnf("%s %s lists cluster %s, which is not managed by Argo CD", resource.GetKind(), resource.GetName(), name)
+				continue
+			}
+			params := make(map[string]string)
+			flatten(params, "", fields)
+			params["name"] = name
+			params["server"] = server
+			addValues(params, generator.Values)
+			res = append(res, params)
+		}
+	}
+	return res, nil
+}
+
+// resourceInterface returns the client of the resources of a kind in the namespace of Argo CD
+func (ctrl *ApplicationSetController) resourceInterface(apiVersion string, kind string) (dynamic.ResourceInterface, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := ctrl.kubeClientset.Discovery().ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind && !strings.Contains(resource.Name, "/") {
+			return ctrl.dynamicClientset.Resource(gv.WithResource(resource.Name)).Namespace(ctrl.namespace), nil
+		}
+	}
+	return nil, fmt.Errorf("the server doesn't have a resource of kind %s in %s", kind, apiVersion)
+}
+
+func (ctrl *ApplicationSetController) generateGitParams(generator *appv1.GitGenerator) ([]map[string]string, error) {
+	if len(generator.Directories) == 0 && len(generator.Files) == 0 {
+		return nil, fmt.Errorf("git generator has neither directories nor files")
+	}
+	repo, err := ctrl.db.GetRepository(context.Background(), generator.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	conn, repoClient, err := ctrl.repoClientset.NewRepoServerClient()
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(conn)
+	revision := generator.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	var res []map[string]string
+	if len(generator.Directories) > 0 {
+		included := make(map[string]bool)
+		excluded := make(map[string]bool)
+		for _, item := range generator.Directories {
+			dirs, err := repoClient.GetGitDirectories(context.Background(), &apiclient.GitFilesRequest{Repo: repo, Revision: revision, Path: item.Path})
+			if err != nil {
+				return nil, err
+			}
+			for _, dir := range dirs.Paths {
+				if item.Exclude {
+					excluded[dir] = true
+				} else {
+					included[dir] = true
+				}
+			}
+		}
+		var dirs []string
+		for dir := range included {
+			if !excluded[dir] {
+				dirs = append(dirs, dir)
+			}
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			res = append(res, pathParams(dir))
+		}
+	}
+	for _, item := range generator.Files {
+		files, err := repoClient.GetGitFiles(context.Background(), &apiclient.GitFilesRequest{Repo: repo, Revision: revision, Path: item.Path})
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for path := range files.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			params, err := fileParams(path, files.Files[path])
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, params...)
+		}
+	}
+	return res, nil
+}
+
+func (ctrl *ApplicationSetController) generateSCMProviderParams(generator *appv1.SCMProviderGenerator) ([]map[string]string, error) {
+	return ctrl.cachedParams(generator, generator.RequeueAfterSeconds, defaultSCMProviderRequeueAfter, func() ([]map[string]string, error) {
+		provider, err := ctrl.newSCMProvider(generator)
+		if err != nil {
+			return nil, err
+		}
+		var filters []scm.Filter
+		for _, f := range generator.Filters {
+			filter := scm.Filter{PathsExist: f.PathsExist}
+			for _, match := range []struct {
+				expr   *string
+				regexp **regexp.Regexp
+			}{{f.RepositoryMatch, &filter.RepositoryMatch}, {f.LabelMatch, &filter.LabelMatch}, {f.BranchMatch, &filter.BranchMatch}} {
+				if match.expr == nil {
+					continue
+				}
+				if *match.regexp, err = regexp.Compile(*match.expr); err != nil {
+					return nil, err
+				}
+			}
+			filters = append(filters, filter)
+		}
+		cloneProtocol := generator.CloneProtocol
+		if cloneProtocol == "" {
+			cloneProtocol = scm.CloneProtocolHTTPS
+		}
+		if cloneProtocol != scm.CloneProtocolHTTPS && cloneProtocol != scm.CloneProtocolSSH {
+			return nil, fmt.Errorf("unknown clone protocol %s", cloneProtocol)
+		}
+		repos, err := scm.ListRepos(context.Background(), provider, filters, cloneProtocol, generator.AllBranches)
+		if err != nil {
+			return nil, err
+		}
+		var res []map[string]string
+		for _, repo := range repos {
+			params := map[string]string{
+				"organization": repo.Organization,
+				"repository":   repo.Repository,
+				"url":          repo.URL,
+				"branch":       repo.Branch,
+				"sha":          repo.SHA,
+				"labels":       strings.Join(repo.Labels, ","),
+			}
+			addValues(params, generator.Values)
+			res = append(res, params)
+		}
+		return res, nil
+	})
+}
+
+// cachedParams returns the parameter sets of the last run of a generator which calls the API of an SCM provider, until
+// its requeue time passed. If the rate limit of the API is exceeded, the parameter sets of the last run are kept until
+// the rate limit is reset.
+func (ctrl *ApplicationSetController) cachedParams(generator interface{}, requeueAfterSeconds *int64, defaultRequeueAfter time.Duration, generate func() ([]map[string]string, error)) ([]map[string]string, error) {
+	key, err := scmCacheKey(generator)
+	if err != nil {
+		return nil, err
+	}
+	ctrl.scmCacheLock.Lock()
+	cached, isCached := ctrl.scmCache[key]
+	ctrl.scmCacheLock.Unlock()
+	if isCached && time.Now().Before(cached.expires) {
+		return cached.params, nil
+	}
+
+	res, err := generate()
+	if rateLimitErr, ok := err.(*scm.RateLimitError); ok && isCached {
+		log.Warnf("Failed to call SCM provider API: %v", err)
+		ctrl.scmCacheLock.Lock()
+		ctrl.scmCache[key] = scmCacheEntry{params: cached.params, expires: rateLimitErr.Reset}
+		ctrl.scmCacheLock.Unlock()
+		return cached.params, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	requeueAfter := defaultRequeueAfter
+	if requeueAfterSeconds != nil {
+		requeueAfter = time.Duration(*requeueAfterSeconds) * time.Second
+	}
+	ctrl.scmCacheLock.Lock()
+	ctrl.scmCache[key] = scmCacheEntry{params: res, expires: time.Now().Add(requeueAfter)}
+	ctrl.scmCacheLock.Unlock()
+	return res, nil
+}
+
+// invalidateCachedParams removes the cached parameter sets of the SCM provider and pull request generators of an
+// ApplicationSet, so that they call the API of their provider when the ApplicationSet is refreshed, e.g. by a webhook
+func (ctrl *ApplicationSetController) invalidateCachedParams(appSet *appv1.ApplicationSet) {
+	ctrl.scmCacheLock.Lock()
+	defer ctrl.scmCacheLock.Unlock()
+	for _, generator := range appSet.Spec.LeafGenerators() {
+		var cached interface{}
+		switch {
+		case generator.SCMProvider != nil:
+			cached = generator.SCMProvider
+		case generator.PullRequest != nil:
+			cached = generator.PullRequest
+		default:
+			continue
+		}
+		if key, err := scmCacheKey(cached); err == nil {
+			delete(ctrl.scmCache, key)
+		}
+	}
+}
+
+func scmCacheKey(generator interface{}) (string, error) {
+	data, err := json.Marshal(generator)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%T:%s", generator, string(data)), nil
+}
+
+// newSCMProvider returns the provider of an SCM provider generator, authenticated with its token
+func (ctrl *ApplicationSetController) newSCMProvider(generator *appv1.SCMProviderGenerator) (scm.Provider, error) {
+	switch {
+	case generator.Github != nil && generator.Gitlab != nil:
+		return nil, fmt.Errorf("more than one SCM provider is set")
+	case generator.Github != nil:
+		token, err := ctrl.getSecretKey(generator.Github.TokenRef)
+		if err != nil {
+			return nil, err
+		}
+		return scm.NewGithubProvider(generator.Github.API, generator.Github.Organization, token), nil
+	case generator.Gitlab != nil:
+		token, err := ctrl.getSecretKey(generator.Gitlab.TokenRef)
+		if err != nil {
+			return nil, err
+		}
+		return scm.NewGitlabProvider(generator.Gitlab.API, generator.Gitlab.Group, generator.Gitlab.IncludeSubgroups, token), nil
+	default:
+		return nil, fmt.Errorf("no SCM provider is set")
+	}
+}
+
+func (ctrl *ApplicationSetController) generatePullRequestParams(generator *appv1.PullRequestGenerator) ([]map[string]string, error) {
+	return ctrl.cachedParams(generator, generator.RequeueAfterSeconds, defaultPullRequestRequeueAfter, func() ([]map[string]string, error) {
+		service, err := ctrl.newPullRequestService(generator)
+		if err != nil {
+			return nil, err
+		}
+		var branchMatches []*regexp.Regexp
+		for _, f := range generator.Filters {
+			var branchMatch *regexp.Regexp
+			if f.BranchMatch != nil {
+				if branchMatch, err = regexp.Compile(*f.BranchMatch); err != nil {
+					return nil, err
+				}
+			}
+			branchMatches = append(branchMatches, branchMatch)
+		}
+		pulls, err := service.List(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		var res []map[string]string
+		for _, pull := range pulls {
+			matches := len(branchMatches) == 0
+			for _, branchMatch := range branchMatches {
+				if branchMatch == nil || branchMatch.MatchString(pull.Branch) {
+					matches = true
+					break
+				}
+			}
+			if !matches {
+				continue
+			}
+			shortSHA := pull.HeadSHA
+			if len(shortSHA) > 8 {
+				shortSHA = shortSHA[:8]
+			}
+			params := map[string]string{
+				"number":         strconv.Itoa(pull.Number),
+				"branch":         pull.Branch,
+				"branch_slug":    branchSlug(pull.Branch),
+				"head_sha":       pull.HeadSHA,
+				"head_short_sha": shortSHA,
+			}
+			addValues(params, generator.Values)
+			res = append(res, params)
+		}
+		return res, nil
+	})
+}
+
+// newPullRequestService returns the pull request service of a pull request generator, authenticated with its token
+func (ctrl *ApplicationSetController) newPullRequestService(generator *appv1.PullRequestGenerator) (scm.PullRequestService, error) {
+	switch {
+	case generator.Github != nil && generator.Gitlab != nil:
+		return nil, fmt.Errorf("more than one pull request provider is set")
+	case generator.Github != nil:
+		token, err := ctrl.getSecretKey(generator.Github.TokenRef)
+		if err != nil {
+			return nil, err
+		}
+		return scm.NewGithubPullRequestService(generator.Github.API, generator.Github.Owner, generator.Github.Repo, token, generator.Github.Labels), nil
+	case generator.Gitlab != nil:
+		token, err := ctrl.getSecretKey(generator.Gitlab.TokenRef)
+		if err != nil {
+			return nil, err
+		}
+		return scm.NewGitlabPullRequestService(generator.Gitlab.API, generator.Gitlab.Project, token, generator.Gitlab.Labels), nil
+	default:
+		return nil, fmt.Errorf("no pull request provider is set")
+	}
+}
+
+// branchSlug returns the lowercase name of a branch with runs of characters other than letters and digits replaced by
+// dashes, so that it can be used in resource names
+func branchSlug(branch string) string {
+	slug := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(branch), "-"), "-")
+	if len(slug) > maxBranchSlugLength {
+		slug = strings.TrimRight(slug[:maxBranchSlugLength], "-")
+	}
+	return slug
+}
+
+// getSecretKey returns the value of a secret key, or an empty string if the reference is nil
+func (ctrl *ApplicationSetController) getSecretKey(ref *appv1.SecretKeyRef) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	secret, err := ctrl.kubeClientset.CoreV1().Secrets(ctrl.namespace).Get(ref.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", ref.SecretName, ref.Key)
+	}
+	return string(value), nil
+}
+
+// pathParams returns the path and path.basename parameters of a directory
+func pathParams(dir string) map[string]string {
+	return map[string]string{
+		"path":          dir,
+		"path.basename": filepath.Base(dir),
+	}
+}
+
+// fileParams returns a parameter set for the object in a JSON or YAML file, or for each of its objects if the file
+// contains a list, with the path parameters of the directory of the file
+func fileParams(path string, data []byte) ([]map[string]string, error) {
+	var content interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	var objects []interface{}
+	if list, ok := content.([]interface{}); ok {
+		objects = list
+	} else {
+		objects = []interface{}{content}
+	}
+	var res []map[string]string
+	for _, obj := range objects {
+		if _, ok := obj.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%s must contain an object or a list of objects", path)
+		}
+		params := make(map[string]string)
+		flatten(params, "", obj)
+		for k, v := range pathParams(filepath.Dir(path)) {
+			params[k] = v
+		}
+		res = append(res, params)
+	}
+	return res, nil
+}
+
+// flatten adds the fields of an object to the parameters, with nested field names joined by dots
+func flatten(params map[string]string, prefix string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			flatten(params, prefix+k+".", v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			flatten(params, fmt.Sprintf("%s%d.", prefix, i), v)
+		}
+	case nil:
+		params[prefix[:len(prefix)-1]] = ""
+	case float64:
+		params[prefix[:len(prefix)-1]] = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		params[prefix[:len(prefix)-1]] = fmt.Sprintf("%v", value)
+	}
+}
+
+func addValues(params map[string]string, values map[string]string) {
+	for k, v := range values {
+		params["values."+k] = v
+	}
+}
@@ -0,0 +1,134 @@
+package applicationset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// syncPolicy returns whether the sync policy of an ApplicationSet allows to update and to delete applications
+func syncPolicy(appSet *appv1.ApplicationSet) (allowUpdate bool, allowDelete bool, err error) {
+	policy := appv1.ApplicationsSyncPolicySync
+	if appSet.Spec.SyncPolicy != nil && appSet.Spec.SyncPolicy.ApplicationsSync != "" {
+		policy = appSet.Spec.SyncPolicy.ApplicationsSync
+	}
+	switch policy {
+	case appv1.ApplicationsSyncPolicyCreateOnly:
+		return false, false, nil
+	case appv1.ApplicationsSyncPolicyCreateUpdate:
+		return true, false, nil
+	case appv1.ApplicationsSyncPolicyCreateDelete:
+		return false, true, nil
+	case appv1.ApplicationsSyncPolicySync:
+		return true, true, nil
+	default:
+		return false, false, fmt.Errorf("unknown applications sync policy %s", policy)
+	}
+}
+
+// ignoreDifferences returns a copy of a rendered application with the ignored fields of the existing application
+func ignoreDifferences(appSet *appv1.ApplicationSet, current *appv1.Application, app *appv1.Application) (*appv1.Application, error) {
+	var pointers []string
+	for _, ignore := range appSet.Spec.IgnoreApplicationDifferences {
+		if ignore.Name == "" || ignore.Name == app.Name {
+			pointers = append(pointers, ignore.JSONPointers...)
+		}
+	}
+	if len(pointers) == 0 {
+		return app, nil
+	}
+	var currentDoc, doc interface{}
+	for _, item := range []struct {
+		app *appv1.Application
+		doc *interface{}
+	}{{current, &currentDoc}, {app, &doc}} {
+		data, err := json.Marshal(item.app)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, item.doc); err != nil {
+			return nil, err
+		}
+	}
+	for _, pointer := range pointers {
+		if !strings.HasPrefix(pointer, "/") {
+			return nil, fmt.Errorf("invalid JSON pointer %s", pointer)
+		}
+		var tokens []string
+		for _, token := range strings.Split(pointer[1:], "/") {
+			tokens = append(tokens, strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1))
+		}
+		value, ok := getPointer(currentDoc, tokens)
+		doc = setPointer(doc, tokens, value, ok)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var res appv1.Application
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// getPointer returns the value of a JSON document at the tokens of a JSON pointer, if it exists
+func getPointer(doc interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			doc = value
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			doc = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// setPointer sets the value of a JSON document at the tokens of a JSON pointer, creating missing objects, or removes
+// it if the value doesn't exist. Array elements which don't exist are left as they are.
+func setPointer(doc interface{}, tokens []string, value interface{}, exists bool) interface{} {
+	if len(tokens) == 0 {
+		return value
+	}
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[tokens[0]]
+		if !ok && !exists {
+			return node
+		}
+		if len(tokens) == 1 && !exists {
+			delete(node, tokens[0])
+			return node
+		}
+		node[tokens[0]] = setPointer(child, tokens[1:], value, exists)
+		return node
+	case []interface{}:
+		i, err := strconv.Atoi(tokens[0])
+		if err != nil || i < 0 || i >= len(node) {
+			return node
+		}
+		node[i] = setPointer(node[i], tokens[1:], value, exists)
+		return node
+	case nil:
+		if !exists {
+			return nil
+		}
+		return map[string]interface{}{tokens[0]: setPointer(nil, tokens[1:], value, exists)}
+	default:
+		return doc
+	}
+}
@@ -0,0 +1,83 @@
+package applicationset
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// rolloutSteps returns the applications of each step of the strategy of an ApplicationSet, sorted by name. The
+// applications which match none of the steps are in an additional last step. Without the RollingSync strategy, all
+// the applications are in a single step.
+func rolloutSteps(appSet *appv1.ApplicationSet, apps map[string]*appv1.Application) ([][]*appv1.Application, error) {
+	var selectors []labels.Selector
+	if strategy := appSet.Spec.Strategy; strategy != nil {
+		switch strategy.Type {
+		case "", appv1.ApplicationSetStrategyTypeAllAtOnce:
+		case appv1.ApplicationSetStrategyTypeRollingSync:
+			if strategy.RollingSync == nil || len(strategy.RollingSync.Steps) == 0 {
+				return nil, fmt.Errorf("the RollingSync strategy has no steps")
+			}
+			for i := range strategy.RollingSync.Steps {
+				selector, err := metav1.LabelSelectorAsSelector(&strategy.RollingSync.Steps[i].Selector)
+				if err != nil {
+					return nil, fmt.Errorf("step %d: %v", i+1, err)
+				}
+				selectors = append(selectors, selector)
+			}
+		default:
+			return nil, fmt.Errorf("unknown strategy type %s", strategy.Type)
+		}
+	}
+	var names []string
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	steps := make([][]*appv1.Application, len(selectors)+1)
+	for _, name := range names {
+		app := apps[name]
+		step := len(selectors)
+		for i, selector := range selectors {
+			if selector.Matches(labels.Set(app.Labels)) {
+				step = i
+				break
+			}
+		}
+		steps[step] = append(steps[step], app)
+	}
+	if len(selectors) > 0 && len(steps[len(selectors)]) == 0 {
+		steps = steps[:len(selectors)]
+	}
+	return steps, nil
+}
+
+// rolloutStatus returns why an application is not synced and healthy yet, if it is not, and whether it failed
+func rolloutStatus(app *appv1.Application) (string, bool) {
+	if app.Status.Health.Status == appv1.HealthStatusDegraded {
+		return fmt.Sprintf("application %s is %s", app.Name, app.Status.Health.Status), true
+	}
+	if !app.Spec.Source.Equals(app.Status.Sync.ComparedTo.Source) || !app.Spec.Destination.Equals(app.Status.Sync.ComparedTo.Destination) {
+		return fmt.Sprintf("application %s is not refreshed yet", app.Name), false
+	}
+	if state := app.Status.OperationState; state != nil && app.Operation == nil && (state.Phase == appv1.OperationFailed || state.Phase == appv1.OperationError) {
+		return fmt.Sprintf("sync of application %s failed: %s", app.Name, state.Message), true
+	}
+	if app.Operation != nil || app.Status.Sync.Status != appv1.SyncStatusCodeSynced || app.Status.Health.Status != appv1.HealthStatusHealthy {
+		return fmt.Sprintf("application %s is %s and %s", app.Name, app.Status.Sync.Status, app.Status.Health.Status), false
+	}
+	return "", false
+}
+
+// applicationEqual returns whether the spec and metadata of an application match the rendered application
+func applicationEqual(current *appv1.Application, app *appv1.Application) bool {
+	return reflect.DeepEqual(current.Spec, app.Spec) &&
+		reflect.DeepEqual(current.Labels, app.Labels) &&
+		reflect.DeepEqual(current.Annotations, app.Annotations) &&
+		reflect.DeepEqual(current.Finalizers, app.Finalizers)
+}
@@ -0,0 +1,185 @@
+package applicationset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/gotemplate"
+)
+
+var paramRegex = regexp.MustCompile(`{{\s*([\w.\-]+)\s*}}`)
+
+// renderApplication renders the application template of an ApplicationSet with a parameter set. References to unknown
+// parameters are left as they are, unless the template is a Go template.
+func renderApplication(appSet *appv1.ApplicationSet, params map[string]string) (*appv1.Application, error) {
+	data, err := json.Marshal(appSet.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	if appSet.Spec.GoTemplate {
+		nested := nestParams(params)
+		if data, err = renderGoTemplate(data, nested); err != nil {
+			return nil, err
+		}
+		if appSet.Spec.TemplatePatch != nil {
+			if data, err = patchTemplate(data, *appSet.Spec.TemplatePatch, nested); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if appSet.Spec.TemplatePatch != nil {
+			return nil, fmt.Errorf("templatePatch requires goTemplate")
+		}
+		data = paramRegex.ReplaceAllFunc(data, func(ref []byte) []byte {
+			value, ok := params[string(paramRegex.FindSubmatch(ref)[1])]
+			if !ok {
+				return ref
+			}
+			// the reference is always inside of a JSON string, so the value is escaped without its quotes
+			escaped, _ := json.Marshal(value)
+			return escaped[1 : len(escaped)-1]
+		})
+	}
+	var tmpl appv1.ApplicationSetTemplate
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return nil, err
+	}
+	if tmpl.Name == "" {
+		return nil, fmt.Errorf("application template has no name")
+	}
+	app := &appv1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       application.ApplicationKind,
+			APIVersion: appv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        tmpl.Name,
+			Namespace:   appSet.Namespace,
+			Labels:      tmpl.Labels,
+			Annotations: tmpl.Annotations,
+			Finalizers:  tmpl.Finalizers,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(appSet, appv1.ApplicationSetSchemaGroupVersionKind),
+			},
+		},
+		Spec: tmpl.Spec,
+	}
+	return app, nil
+}
+
+// renderGoTemplate renders all the strings of a JSON document, including the keys of objects, as Go templates
+func renderGoTemplate(data []byte, params map[string]interface{}) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var render func(value interface{}) (interface{}, error)
+	render = func(value interface{}) (interface{}, error) {
+		switch value := value.(type) {
+		case string:
+			return executeGoTemplate(value, params)
+		case []interface{}:
+			res := make([]interface{}, len(value))
+			for i := range value {
+				item, err := render(value[i])
+				if err != nil {
+					return nil, err
+				}
+				res[i] = item
+			}
+			return res, nil
+		case map[string]interface{}:
+			res := make(map[string]interface{}, len(value))
+			for k, v := range value {
+				key, err := executeGoTemplate(k, params)
+				if err != nil {
+					return nil, err
+				}
+				if res[key], err = render(v); err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		default:
+			return value, nil
+		}
+	}
+	doc, err := render(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// patchTemplate merges the rendered Go template of a YAML patch into a rendered application template
+func patchTemplate(data []byte, patch string, params map[string]interface{}) ([]byte, error) {
+	rendered, err := executeGoTemplate(patch, params)
+	if err != nil {
+		return nil, err
+	}
+	patchData, err := yaml.YAMLToJSON([]byte(rendered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templatePatch: %v", err)
+	}
+	res, err := strategicpatch.StrategicMergePatch(data, patchData, appv1.ApplicationSetTemplate{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply templatePatch: %v", err)
+	}
+	return res, nil
+}
+
+// executeGoTemplate renders a Go template. References to unknown parameters are errors.
+func executeGoTemplate(text string, params map[string]interface{}) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("").Funcs(gotemplate.FuncMap()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %v", text, err)
+	}
+	return buf.String(), nil
+}
+
+// nestParams converts the parameters of a parameter set into nested maps by splitting their names at dots. A
+// parameter which is also a prefix of other parameters is nested under its own name, e.g. path as path.path next to
+// path.basename.
+func nestParams(params map[string]string) map[string]interface{} {
+	res := make(map[string]interface{})
+	for name, value := range params {
+		segments := strings.Split(name, ".")
+		node := res
+		for _, segment := range segments[:len(segments)-1] {
+			switch child := node[segment].(type) {
+			case map[string]interface{}:
+				node = child
+			case string:
+				node[segment] = map[string]interface{}{segment: child}
+				node = node[segment].(map[string]interface{})
+			default:
+				node[segment] = make(map[string]interface{})
+				node = node[segment].(map[string]interface{})
+			}
+		}
+		last := segments[len(segments)-1]
+		if child, ok := node[last].(map[string]interface{}); ok {
+			child[last] = value
+		} else {
+			node[last] = value
+		}
+	}
+	return res
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/argoproj/argo-cd/controller/metrics"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/argo"
 	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/diff"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/settings"
 )
@@ -25,6 +27,9 @@ type cacheSettings struct {
 	ResourceOverrides   map[string]appv1.ResourceOverride
 	AppInstanceLabelKey string
 	ResourcesFilter     *settings.ResourcesFilter
+	// ResourceUpdatesNormalizer removes the fields of resources whose updates don't trigger the refresh of their
+	// applications, or is nil if the ignored resource updates are disabled
+	ResourceUpdatesNormalizer diff.Normalizer
 }
 
 type LiveStateCache interface {
@@ -65,7 +70,8 @@ func NewLiveStateCache(
 	settingsMgr *settings.SettingsManager,
 	kubectl kube.Kubectl,
 	metricsServer *metrics.MetricsServer,
-	onObjectUpdated ObjectUpdatedHandler) LiveStateCache {
+	onObjectUpdated ObjectUpdatedHandler,
+	clusterFilter func(cluster *appv1.Cluster) bool) LiveStateCache {
 
 	return &liveStateCache{
 		appInformer:       appInformer,
@@ -77,6 +83,7 @@ func NewLiveStateCache(
 		settingsMgr:       settingsMgr,
 		metricsServer:     metricsServer,
 		cacheSettingsLock: &sync.Mutex{},
+		clusterFilter:     clusterFilter,
 	}
 }
 
@@ -91,6 +98,8 @@ type liveStateCache struct {
 	metricsServer     *metrics.MetricsServer
 	cacheSettingsLock *sync.Mutex
 	cacheSettings     *cacheSettings
+	// clusterFilter filters the clusters managed by the shard of the controller
+	clusterFilter func(cluster *appv1.Cluster) bool
 }
 
 func (c *liveStateCache) loadCacheSettings() (*cacheSettings, error) {
@@ -106,7 +115,23 @@ func (c *liveStateCache) loadCacheSettings() (*cacheSettings, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &cacheSettings{AppInstanceLabelKey: appInstanceLabelKey, ResourceOverrides: resourceOverrides, ResourcesFilter: resourcesFilter}, nil
+	ignoreResourceUpdatesEnabled, err := c.settingsMgr.GetIgnoreResourceUpdatesEnabled()
+	if err != nil {
+		return nil, err
+	}
+	var resourceUpdatesNormalizer diff.Normalizer
+	if ignoreResourceUpdatesEnabled {
+		resourceUpdatesNormalizer, err = argo.NewIgnoreResourceUpdatesNormalizer(resourceOverrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &cacheSettings{
+		AppInstanceLabelKey:       appInstanceLabelKey,
+		ResourceOverrides:         resourceOverrides,
+		ResourcesFilter:           resourcesFilter,
+		ResourceUpdatesNormalizer: resourceUpdatesNormalizer,
+	}, nil
 }
 
 func (c *liveStateCache) getCluster(server string) (*clusterInfo, error) {
@@ -219,8 +244,14 @@ func (c *liveStateCache) GetVersionsInfo(serverURL string) (string, []metav1.API
 
 func isClusterHasApps(apps []interface{}, cluster *appv1.Cluster) bool {
 	for _, obj := range apps {
-		if app, ok := obj.(*appv1.Application); ok && app.Spec.Destination.Server == cluster.Server {
-			return true
+		app, ok := obj.(*appv1.Application)
+		if !ok {
+			continue
+		}
+		for _, destination := range app.Spec.GetDestinations() {
+			if destination.Server == cluster.Server {
+				return true
+			}
 		}
 	}
 	return false
@@ -288,7 +319,7 @@ func (c *liveStateCache) Run(ctx context.Context) error {
 				}
 			} else {
 				c.lock.Unlock()
-				if event.Type == watch.Added && isClusterHasApps(c.appInformer.GetStore().List(), event.Cluster) {
+				if event.Type == watch.Added && c.clusterFilter(event.Cluster) && isClusterHasApps(c.appInformer.GetStore().List(), event.Cluster) {
 					go func() {
 						// warm up cache for cluster with apps
 						_, _ = c.getSyncedCluster(event.Cluster.Server)
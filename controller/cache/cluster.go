@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"sort"
@@ -21,6 +23,7 @@ import (
 	"github.com/argoproj/argo-cd/controller/metrics"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/diff"
 	"github.com/argoproj/argo-cd/util/health"
 	"github.com/argoproj/argo-cd/util/kube"
 )
@@ -38,11 +41,15 @@ type apiMeta struct {
 }
 
 type clusterInfo struct {
-	syncTime      *time.Time
-	syncError     error
-	apisMeta      map[schema.GroupKind]*apiMeta
-	serverVersion string
-	apiGroups     []metav1.APIGroup
+	syncTime     *time.Time
+	syncDuration time.Duration
+	syncError    error
+	// watchErrorsCount is the number of errors of the watches since the last full sync, and lastWatchError the last one
+	watchErrorsCount int64
+	lastWatchError   error
+	apisMeta         map[schema.GroupKind]*apiMeta
+	serverVersion    string
+	apiGroups        []metav1.APIGroup
 	// namespacedResources is a simple map which indicates a groupKind is namespaced
 	namespacedResources map[schema.GroupKind]bool
 
@@ -155,9 +162,44 @@ func (c *clusterInfo) createObjInfo(un *unstructured.Unstructured, appInstanceLa
 	}
 
 	nodeInfo.health, _ = health.GetResourceHealth(un, c.cacheSettingsSrc().ResourceOverrides)
+	if normalizer := c.cacheSettingsSrc().ResourceUpdatesNormalizer; normalizer != nil {
+		nodeInfo.updateHash = getResourceUpdateHash(un, normalizer)
+	}
 	return nodeInfo
 }
 
+// getResourceUpdateHash returns the hash of a resource without the fields whose updates are ignored, nor the fields
+// which change with every update. It returns an empty hash if the resource can't be normalized.
+func getResourceUpdateHash(un *unstructured.Unstructured, normalizer diff.Normalizer) string {
+	un = un.DeepCopy()
+	unstructured.RemoveNestedField(un.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(un.Object, "metadata", "managedFields")
+	if err := normalizer.Normalize(un); err != nil {
+		return ""
+	}
+	if len(un.GetAnnotations()) == 0 {
+		// the removal of ignored annotations might leave an empty map
+		unstructured.RemoveNestedField(un.Object, "metadata", "annotations")
+	}
+	data, err := json.Marshal(un)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// isIgnoredResourceUpdate returns whether the update of a resource only changed the fields whose updates are ignored,
+// and not its health either
+func isIgnoredResourceUpdate(oldNode, newNode *node) bool {
+	if oldNode.updateHash == "" || oldNode.updateHash != newNode.updateHash {
+		return false
+	}
+	if oldNode.health == nil || newNode.health == nil {
+		return oldNode.health == newNode.health
+	}
+	return oldNode.health.Status == newNode.health.Status && oldNode.health.Message == newNode.health.Message
+}
+
 func (c *clusterInfo) setNode(n *node) {
 	key := n.resourceKey()
 	c.nodes[key] = n
@@ -255,6 +297,11 @@ func runSynced(lock sync.Locker, action func() error) error {
 
 func (c *clusterInfo) watchEvents(ctx context.Context, api kube.APIResourceInfo, info *apiMeta, resClient dynamic.ResourceInterface, ns string) {
 	util.RetryUntilSucceed(func() (err error) {
+		defer func() {
+			if err != nil {
+				c.recordWatchError(err)
+			}
+		}()
 		defer func() {
 			if r := recover(); r != nil {
 				err = fmt.Errorf("Recovered from panic: %+v\n%s", r, debug.Stack())
@@ -326,6 +373,14 @@ func (c *clusterInfo) watchEvents(ctx context.Context, api kube.APIResourceInfo,
 	}, fmt.Sprintf("watch %s on %s", api.GroupKind, c.cluster.Server), ctx, watchResourcesRetryTimeout)
 }
 
+// recordWatchError counts the errors of the watches of the cluster
+func (c *clusterInfo) recordWatchError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.watchErrorsCount++
+	c.lastWatchError = err
+}
+
 func (c *clusterInfo) processApi(client dynamic.Interface, api kube.APIResourceInfo, callback func(resClient dynamic.ResourceInterface, ns string) error) error {
 	resClient := client.Resource(api.GroupVersionResource)
 	if len(c.cluster.Namespaces) == 0 {
@@ -333,6 +388,9 @@ func (c *clusterInfo) processApi(client dynamic.Interface, api kube.APIResourceI
 	}
 
 	if !api.Meta.Namespaced {
+		if c.cluster.ClusterResources {
+			return callback(resClient, "")
+		}
 		return nil
 	}
 
@@ -354,6 +412,8 @@ func (c *clusterInfo) sync() (err error) {
 	}
 	c.apisMeta = make(map[schema.GroupKind]*apiMeta)
 	c.nodes = make(map[kube.ResourceKey]*node)
+	c.watchErrorsCount = 0
+	c.lastWatchError = nil
 	config := c.cluster.RESTConfig()
 	version, err := c.kubectl.GetServerVersion(config)
 	if err != nil {
@@ -416,9 +476,11 @@ func (c *clusterInfo) ensureSynced() error {
 	if c.synced() {
 		return c.syncError
 	}
+	start := time.Now()
 	err := c.sync()
 	syncTime := time.Now()
 	c.syncTime = &syncTime
+	c.syncDuration = syncTime.Sub(start)
 	c.syncError = err
 	return c.syncError
 }
@@ -577,6 +639,10 @@ func (c *clusterInfo) onNodeUpdated(exists bool, existingNode *node, un *unstruc
 	}
 	newObj := c.createObjInfo(un, c.cacheSettingsSrc().AppInstanceLabelKey)
 	c.setNode(newObj)
+	if exists && isIgnoredResourceUpdate(existingNode, newObj) {
+		// the cached resource is up to date, but its applications don't need a refresh
+		return
+	}
 	nodes = append(nodes, newObj)
 	toNotify := make(map[string]bool)
 	for i := range nodes {
@@ -615,13 +681,22 @@ var (
 func (c *clusterInfo) getClusterInfo() metrics.ClusterInfo {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	return metrics.ClusterInfo{
+	info := metrics.ClusterInfo{
 		APIsCount:         len(c.apisMeta),
 		K8SVersion:        c.serverVersion,
 		ResourcesCount:    len(c.nodes),
 		Server:            c.cluster.Server,
 		LastCacheSyncTime: c.syncTime,
+		SyncDuration:      c.syncDuration,
+		WatchErrorsCount:  c.watchErrorsCount,
+	}
+	if c.syncError != nil {
+		info.SyncError = c.syncError.Error()
+	}
+	if c.lastWatchError != nil {
+		info.LastWatchError = c.lastWatchError.Error()
 	}
+	return info
 }
 
 // skipAppRequeing checks if the object is an API type which we want to skip requeuing against.
@@ -21,6 +21,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/errors"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/argo"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/kube/kubetest"
 )
@@ -225,6 +226,40 @@ func TestEnsureSyncedSingleNamespace(t *testing.T) {
 	assert.ElementsMatch(t, []string{"helm-guestbook1"}, names)
 }
 
+func TestEnsureSyncedSingleNamespaceClusterResources(t *testing.T) {
+	ns := strToUnstructured(`
+  apiVersion: v1
+  kind: Namespace
+  metadata: {"name": "default1"}
+`)
+	deploy := strToUnstructured(`
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata: {"name": "helm-guestbook1", "namespace": "default1"}
+`)
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), ns, deploy)
+	apiResources := []kube.APIResourceInfo{{
+		GroupKind:            schema.GroupKind{Group: "", Kind: "Namespace"},
+		GroupVersionResource: schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"},
+		Meta:                 metav1.APIResource{Namespaced: false},
+	}, {
+		GroupKind:            schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		Meta:                 metav1.APIResource{Namespaced: true},
+	}}
+
+	cluster := newClusterExt(&kubetest.MockKubectlCmd{APIResources: apiResources, DynamicClient: client})
+	cluster.cluster.Namespaces = []string{"default1"}
+	assert.NoError(t, cluster.ensureSynced())
+	assert.Len(t, cluster.nodes, 1)
+
+	cluster = newClusterExt(&kubetest.MockKubectlCmd{APIResources: apiResources, DynamicClient: client})
+	cluster.cluster.Namespaces = []string{"default1"}
+	cluster.cluster.ClusterResources = true
+	assert.NoError(t, cluster.ensureSynced())
+	assert.Len(t, cluster.nodes, 2)
+}
+
 func TestGetNamespaceResources(t *testing.T) {
 	defaultNamespaceTopLevel1 := strToUnstructured(`
   apiVersion: apps/v1
@@ -465,6 +500,45 @@ func TestUpdateAppResource(t *testing.T) {
 	assert.Contains(t, updatesReceived, "helm-guestbook: false")
 }
 
+func TestIgnoreResourceUpdates(t *testing.T) {
+	updatesReceived := 0
+	cluster := newCluster(testPod, testRS, testDeploy)
+	normalizer, err := argo.NewIgnoreResourceUpdatesNormalizer(map[string]appv1.ResourceOverride{
+		"*/*": {IgnoreResourceUpdates: "jsonPointers:\n- /status\n- /metadata/annotations/noisy"},
+	})
+	assert.NoError(t, err)
+	cluster.cacheSettingsSrc = func() *cacheSettings {
+		return &cacheSettings{AppInstanceLabelKey: common.LabelKeyAppInstance, ResourceUpdatesNormalizer: normalizer}
+	}
+	cluster.onObjectUpdated = func(managedByApp map[string]bool, _ corev1.ObjectReference) {
+		updatesReceived++
+	}
+	assert.NoError(t, cluster.ensureSynced())
+	updatesReceived = 0
+
+	deploy := testDeploy.DeepCopy()
+	deploy.SetResourceVersion("234")
+	deploy.SetAnnotations(map[string]string{"noisy": "1"})
+	cluster.processEvent(watch.Modified, deploy)
+	assert.Equal(t, 0, updatesReceived)
+	// the cached resource is updated nonetheless
+	assert.Equal(t, "234", cluster.nodes[kube.GetResourceKey(deploy)].resourceVersion)
+
+	deploy = deploy.DeepCopy()
+	deploy.SetLabels(map[string]string{common.LabelKeyAppInstance: "helm-guestbook", "foo": "bar"})
+	cluster.processEvent(watch.Modified, deploy)
+	assert.Equal(t, 1, updatesReceived)
+
+	t.Run("Disabled", func(t *testing.T) {
+		cluster.cacheSettingsSrc = func() *cacheSettings {
+			return &cacheSettings{AppInstanceLabelKey: common.LabelKeyAppInstance}
+		}
+		updatesReceived = 0
+		cluster.processEvent(watch.Modified, deploy.DeepCopy())
+		assert.Equal(t, 1, updatesReceived)
+	})
+}
+
 func TestCircularReference(t *testing.T) {
 	dep := testDeploy.DeepCopy()
 	dep.SetOwnerReferences([]metav1.OwnerReference{{
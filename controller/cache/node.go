@@ -24,6 +24,9 @@ type node struct {
 	networkingInfo *appv1.ResourceNetworkingInfo
 	images         []string
 	health         *appv1.HealthStatus
+	// updateHash is the hash of the resource without the fields whose updates are ignored, if the ignored resource
+	// updates are enabled
+	updateHash string
 }
 
 func (n *node) isRootAppNode() bool {
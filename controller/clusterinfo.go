@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-cd/controller/metrics"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// clusterInfoReportInterval is the interval the controller reports the state of the clusters of its shard at
+const clusterInfoReportInterval = 10 * time.Second
+
+// reportClusterInfo periodically reports the state of the clusters managed by the shard of the controller to the API
+// server, through the cache
+func (ctrl *ApplicationController) reportClusterInfo(ctx context.Context) {
+	ticker := time.NewTicker(clusterInfoReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		infos, err := ctrl.getClusterInfo()
+		if err != nil {
+			log.Warnf("Failed to get the state of the clusters: %v", err)
+			continue
+		}
+		for server, info := range infos {
+			if err := ctrl.cache.SetClusterInfo(server, info); err != nil {
+				log.Warnf("Failed to report the state of cluster %s: %v", server, err)
+			}
+		}
+	}
+}
+
+// getClusterInfo returns the state of the clusters managed by the shard of the controller, by server URL
+func (ctrl *ApplicationController) getClusterInfo() (map[string]*appv1.ClusterInfo, error) {
+	clusters, err := ctrl.db.ListClusters(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	appsCount := make(map[string]int64)
+	for _, app := range apps {
+		servers := make(map[string]bool)
+		for _, destination := range app.Spec.GetDestinations() {
+			servers[destination.Server] = true
+		}
+		for server := range servers {
+			appsCount[server]++
+		}
+	}
+	cacheInfos := make(map[string]metrics.ClusterInfo)
+	for _, info := range ctrl.stateCache.GetClustersInfo() {
+		cacheInfos[info.Server] = info
+	}
+	replica, _ := os.Hostname()
+	now := metav1.Now()
+
+	res := make(map[string]*appv1.ClusterInfo)
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !ctrl.clusterFilter(cluster) {
+			continue
+		}
+		info := &appv1.ClusterInfo{
+			Shard:             int64(ctrl.shard),
+			Replica:           replica,
+			ApplicationsCount: appsCount[cluster.Server],
+			ReportedAt:        &now,
+			CacheInfo:         appv1.ClusterCacheInfo{Status: appv1.ClusterCacheStatusNotCached},
+		}
+		if cacheInfo, ok := cacheInfos[cluster.Server]; ok && cacheInfo.LastCacheSyncTime != nil {
+			lastSync := metav1.NewTime(*cacheInfo.LastCacheSyncTime)
+			info.CacheInfo = appv1.ClusterCacheInfo{
+				Status:            appv1.ClusterCacheStatusSynced,
+				Message:           cacheInfo.SyncError,
+				ResourcesCount:    int64(cacheInfo.ResourcesCount),
+				APIsCount:         int64(cacheInfo.APIsCount),
+				LastCacheSyncTime: &lastSync,
+				WatchErrorsCount:  cacheInfo.WatchErrorsCount,
+				LastWatchError:    cacheInfo.LastWatchError,
+			}
+			if cacheInfo.SyncError != "" {
+				info.CacheInfo.Status = appv1.ClusterCacheStatusFailed
+			}
+		}
+		res[cluster.Server] = info
+	}
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mockstatecache "github.com/argoproj/argo-cd/controller/cache/mocks"
+	"github.com/argoproj/argo-cd/controller/metrics"
+	"github.com/argoproj/argo-cd/controller/sharding"
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestGetClusterInfo(t *testing.T) {
+	app := newFakeApp()
+	otherApp := newFakeApp()
+	otherApp.Name = "other-app"
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, otherApp}})
+	syncTime := time.Now()
+	ctrl.stateCache.(*mockstatecache.LiveStateCache).On("GetClustersInfo").Return([]metrics.ClusterInfo{{
+		Server:            "https://localhost:6443",
+		ResourcesCount:    10,
+		APIsCount:         3,
+		LastCacheSyncTime: &syncTime,
+		WatchErrorsCount:  2,
+		LastWatchError:    "watch closed",
+	}})
+
+	infos, err := ctrl.getClusterInfo()
+	assert.NoError(t, err)
+	info, ok := infos["https://localhost:6443"]
+	if assert.True(t, ok) {
+		assert.Equal(t, int64(0), info.Shard)
+		assert.Equal(t, int64(2), info.ApplicationsCount)
+		assert.Equal(t, argoappv1.ClusterCacheStatusSynced, info.CacheInfo.Status)
+		assert.Equal(t, int64(10), info.CacheInfo.ResourcesCount)
+		assert.Equal(t, int64(2), info.CacheInfo.WatchErrorsCount)
+		assert.Equal(t, "watch closed", info.CacheInfo.LastWatchError)
+		assert.NotNil(t, info.ReportedAt)
+	}
+
+	t.Run("OtherShard", func(t *testing.T) {
+		cluster := &argoappv1.Cluster{Server: "https://localhost:6443"}
+		ctrl.shard = (sharding.GetClusterShard(cluster, 2) + 1) % 2
+		ctrl.clusterFilter = sharding.GetClusterFilter(2, ctrl.shard)
+		infos, err := ctrl.getClusterInfo()
+		assert.NoError(t, err)
+		assert.NotContains(t, infos, "https://localhost:6443")
+		assert.False(t, ctrl.canProcessApp(app))
+	})
+}
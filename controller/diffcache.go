@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/reposerver/apiclient"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
+	"github.com/argoproj/argo-cd/util/diff"
+	kubeutil "github.com/argoproj/argo-cd/util/kube"
+)
+
+// diffCacheKey returns the key of the comparison of the target and the live state of the resources of an application.
+// Like the key of the manifests in the cache of the repo server, it includes the resolved revision and the source of the
+// manifests, and in addition the comparison settings and the resourceVersions of the live resources, so that the diffs
+// are computed again only once the manifests, the settings or the live state change. It returns an empty key if the
+// comparison can't be cached, e.g. of local manifests or of live resources without resourceVersion.
+func diffCacheKey(
+	app *v1alpha1.Application,
+	project *v1alpha1.AppProject,
+	source v1alpha1.ApplicationSource,
+	manifestInfo *apiclient.ManifestResponse,
+	appLabelKey string,
+	resourceOverrides map[string]v1alpha1.ResourceOverride,
+	hideGeneratedSecretData bool,
+	targetObjs []*unstructured.Unstructured,
+	liveObjs []*unstructured.Unstructured,
+) string {
+	if manifestInfo == nil || manifestInfo.Revision == "" {
+		return ""
+	}
+	resources := make([]string, len(targetObjs))
+	for i := range targetObjs {
+		var targetKey, liveKey kubeutil.ResourceKey
+		var resourceVersion string
+		if targetObjs[i] != nil {
+			targetKey = kubeutil.GetResourceKey(targetObjs[i])
+		}
+		if liveObjs[i] != nil {
+			liveKey = kubeutil.GetResourceKey(liveObjs[i])
+			resourceVersion = liveObjs[i].GetResourceVersion()
+			if resourceVersion == "" {
+				return ""
+			}
+		}
+		resources[i] = fmt.Sprintf("%s|%s|%s", targetKey.String(), liveKey.String(), resourceVersion)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"revision":                manifestInfo.Revision,
+		"source":                  source,
+		"appLabelKey":             appLabelKey,
+		"decryptSOPS":             project.Spec.DecryptSOPS,
+		"vaultPaths":              project.Spec.VaultPaths,
+		"destination":             app.Spec.Destination,
+		"ignoreDifferences":       app.Spec.IgnoreDifferences,
+		"resourceOverrides":       resourceOverrides,
+		"hideGeneratedSecretData": hideGeneratedSecretData,
+		"resources":               resources,
+	})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// getCachedDiffs returns the results of the last comparison of the resources of an application if it compared the
+// same state
+func (m *appStateManager) getCachedDiffs(app *v1alpha1.Application, key string, count int) *diff.DiffResultList {
+	if key == "" {
+		return nil
+	}
+	var cached appstatecache.AppDiffs
+	if err := m.cache.GetAppDiffs(app.Name, app.Spec.Destination, &cached); err != nil {
+		if err != appstatecache.ErrCacheMiss {
+			log.WithField("application", app.Name).Warnf("Failed to get the cached diffs: %v", err)
+		}
+		return nil
+	}
+	if cached.Key != key || len(cached.Diffs) != count {
+		return nil
+	}
+	res := &diff.DiffResultList{Diffs: make([]diff.DiffResult, count)}
+	for i, cachedDiff := range cached.Diffs {
+		diffRes, err := diff.NewDiffResult(cachedDiff.Modified, cachedDiff.PredictedLive, cachedDiff.NormalizedLive)
+		if err != nil {
+			return nil
+		}
+		res.Diffs[i] = *diffRes
+		if diffRes.Modified {
+			res.Modified = true
+		}
+	}
+	return res
+}
+
+// setCachedDiffs records the results of the comparison of the resources of an application
+func (m *appStateManager) setCachedDiffs(app *v1alpha1.Application, key string, diffResults *diff.DiffResultList) {
+	if key == "" {
+		return
+	}
+	cached := appstatecache.AppDiffs{Key: key, Diffs: make([]appstatecache.ResourceDiffResult, len(diffResults.Diffs))}
+	for i, diffRes := range diffResults.Diffs {
+		cached.Diffs[i] = appstatecache.ResourceDiffResult{Modified: diffRes.Modified, PredictedLive: diffRes.PredictedLive, NormalizedLive: diffRes.NormalizedLive}
+	}
+	if err := m.cache.SetAppDiffs(app.Name, app.Spec.Destination, &cached); err != nil {
+		log.WithField("application", app.Name).Warnf("Failed to cache the diffs: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"regexp"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+const (
+	// DefaultMaxAppLabelValues is the default maximum number of values of each application label added to the metrics
+	DefaultMaxAppLabelValues = 50
+	// otherAppLabelValue replaces the values of an application label exceeding the maximum number of values
+	otherAppLabelValue = "_other"
+)
+
+var invalidMetricLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// appLabels are the labels of applications which are added to the application metrics. The number of values of each
+// label is limited so that a label like a commit SHA doesn't create an unbounded number of time series.
+type appLabels struct {
+	names        []string
+	metricLabels []string
+	maxValues    int
+
+	lock   sync.Mutex
+	values map[string]map[string]bool
+}
+
+func newAppLabels(names []string, maxValues int) *appLabels {
+	l := &appLabels{maxValues: maxValues, values: make(map[string]map[string]bool)}
+	metricLabels := make(map[string]bool)
+	for _, name := range names {
+		metricLabel := "label_" + invalidMetricLabelChars.ReplaceAllString(name, "_")
+		if metricLabels[metricLabel] {
+			log.Warnf("Ignoring application label %s of the metrics: the metric label %s is already used", name, metricLabel)
+			continue
+		}
+		metricLabels[metricLabel] = true
+		l.names = append(l.names, name)
+		l.metricLabels = append(l.metricLabels, metricLabel)
+		l.values[name] = make(map[string]bool)
+	}
+	return l
+}
+
+// labelsOf returns the values of the labels of an application added to the metrics, or empty values if the application
+// is nil
+func (l *appLabels) labelsOf(app *argoappv1.Application) []string {
+	res := make([]string, len(l.names))
+	if app == nil {
+		return res
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for i, name := range l.names {
+		value := app.Labels[name]
+		if value != "" && l.maxValues > 0 && !l.values[name][value] {
+			if len(l.values[name]) >= l.maxValues {
+				log.Debugf("Application label %s of %s exceeds %d values, reported as %s", name, app.Name, l.maxValues, otherAppLabelValue)
+				value = otherAppLabelValue
+			} else {
+				l.values[name][value] = true
+			}
+		}
+		res[i] = value
+	}
+	return res
+}
@@ -39,14 +39,34 @@ var (
 		descClusterDefaultLabels,
 		nil,
 	)
+	descClusterWatchErrors = prometheus.NewDesc(
+		"argocd_cluster_watch_errors",
+		"Number of errors of the watches of the cluster since the last full sync of the cluster cache.",
+		descClusterDefaultLabels,
+		nil,
+	)
+	descClusterCacheSyncDuration = prometheus.NewDesc(
+		"argocd_cluster_cache_sync_duration_seconds",
+		"Duration of the last full sync of the cluster cache in seconds.",
+		descClusterDefaultLabels,
+		nil,
+	)
 )
 
 type ClusterInfo struct {
-	Server            string
-	K8SVersion        string
-	ResourcesCount    int
-	APIsCount         int
-	LastCacheSyncTime *time.Time
+	Server            string     `json:"server"`
+	K8SVersion        string     `json:"k8sVersion"`
+	ResourcesCount    int        `json:"resourcesCount"`
+	APIsCount         int        `json:"apisCount"`
+	LastCacheSyncTime *time.Time `json:"lastCacheSyncTime,omitempty"`
+	// SyncDuration is the duration of the last full sync of the cluster cache, which lists all watched resources
+	SyncDuration time.Duration `json:"syncDuration"`
+	// SyncError is the error of the last full sync of the cluster cache, if it failed
+	SyncError string `json:"syncError,omitempty"`
+	// WatchErrorsCount is the number of errors of the watches of the cluster since the last full sync
+	WatchErrorsCount int64 `json:"watchErrorsCount"`
+	// LastWatchError is the last error of the watches of the cluster
+	LastWatchError string `json:"lastWatchError,omitempty"`
 }
 
 type HasClustersInfo interface {
@@ -81,6 +101,8 @@ func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- descClusterCacheResources
 	ch <- descClusterAPIs
 	ch <- descClusterCacheAgeSeconds
+	ch <- descClusterCacheSyncDuration
+	ch <- descClusterWatchErrors
 }
 
 func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
@@ -95,5 +117,7 @@ func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
 			cacheAgeSeconds = int(now.Sub(*c.LastCacheSyncTime).Seconds())
 		}
 		ch <- prometheus.MustNewConstMetric(descClusterCacheAgeSeconds, prometheus.GaugeValue, float64(cacheAgeSeconds), defaultValues...)
+		ch <- prometheus.MustNewConstMetric(descClusterCacheSyncDuration, prometheus.GaugeValue, c.SyncDuration.Seconds(), defaultValues...)
+		ch <- prometheus.MustNewConstMetric(descClusterWatchErrors, prometheus.GaugeValue, float64(c.WatchErrorsCount), defaultValues...)
 	}
 }
@@ -19,6 +19,7 @@ import (
 
 type MetricsServer struct {
 	*http.Server
+	mux                     *http.ServeMux
 	syncCounter             *prometheus.CounterVec
 	kubectlExecCounter      *prometheus.CounterVec
 	kubectlExecPendingGauge *prometheus.GaugeVec
@@ -26,6 +27,7 @@ type MetricsServer struct {
 	clusterEventsCounter    *prometheus.CounterVec
 	reconcileHistogram      *prometheus.HistogramVec
 	registry                *prometheus.Registry
+	appLabels               *appLabels
 }
 
 const (
@@ -40,50 +42,6 @@ const (
 var (
 	descAppDefaultLabels = []string{"namespace", "name", "project"}
 
-	descAppInfo = prometheus.NewDesc(
-		"argocd_app_info",
-		"Information about application.",
-		append(descAppDefaultLabels, "repo", "dest_server", "dest_namespace", "sync_status", "health_status", "operation"),
-		nil,
-	)
-	// DEPRECATED
-	descAppCreated = prometheus.NewDesc(
-		"argocd_app_created_time",
-		"Creation time in unix timestamp for an application.",
-		descAppDefaultLabels,
-		nil,
-	)
-	// DEPRECATED: superceded by sync_status label in argocd_app_info
-	descAppSyncStatusCode = prometheus.NewDesc(
-		"argocd_app_sync_status",
-		"The application current sync status.",
-		append(descAppDefaultLabels, "sync_status"),
-		nil,
-	)
-	// DEPRECATED: superceded by health_status label in argocd_app_info
-	descAppHealthStatus = prometheus.NewDesc(
-		"argocd_app_health_status",
-		"The application current health status.",
-		append(descAppDefaultLabels, "health_status"),
-		nil,
-	)
-
-	syncCounter = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "argocd_app_sync_total",
-			Help: "Number of application syncs.",
-		},
-		append(descAppDefaultLabels, "dest_server", "phase"),
-	)
-
-	k8sRequestCounter = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "argocd_app_k8s_request_total",
-			Help: "Number of kubernetes requests executed during application reconciliation.",
-		},
-		append(descAppDefaultLabels, "server", "response_code", "verb", "resource_kind", "resource_namespace"),
-	)
-
 	kubectlExecCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "argocd_kubectl_exec_total",
 		Help: "Number of kubectl executions",
@@ -94,33 +52,70 @@ var (
 		Help: "Number of pending kubectl executions",
 	}, []string{"command"})
 
-	reconcileHistogram = prometheus.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name: "argocd_app_reconcile",
-			Help: "Application reconciliation performance.",
-			// Buckets chosen after observing a ~2100ms mean reconcile time
-			Buckets: []float64{0.25, .5, 1, 2, 4, 8, 16},
-		},
-		append(descAppDefaultLabels, "dest_server"),
-	)
-
 	clusterEventsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "argocd_cluster_events_total",
 		Help: "Number of processes k8s resource events.",
 	}, append(descClusterDefaultLabels, "group", "kind"))
 )
 
-// NewMetricsServer returns a new prometheus server which collects application metrics
-func NewMetricsServer(addr string, appLister applister.ApplicationLister, healthCheck func() error) *MetricsServer {
+// appMetricLabels returns the labels of an application metric, which are the default labels, the labels of the
+// application added to the metrics and the labels of the metric itself
+func appMetricLabels(appLabels *appLabels, labels ...string) []string {
+	res := append([]string{}, descAppDefaultLabels...)
+	res = append(res, appLabels.metricLabels...)
+	return append(res, labels...)
+}
+
+// appLabelValues returns the label values of an application metric, in the order of appMetricLabels
+func appLabelValues(appLabels *appLabels, app *argoappv1.Application, values ...string) []string {
+	var res []string
+	if app != nil {
+		res = []string{app.Namespace, app.Name, app.Spec.GetProject()}
+	} else {
+		res = make([]string, len(descAppDefaultLabels))
+	}
+	res = append(res, appLabels.labelsOf(app)...)
+	return append(res, values...)
+}
+
+// NewMetricsServer returns a new prometheus server which collects application metrics. The application labels are
+// added to the application metrics, with at most maxAppLabelValues values each. The checks of the dependencies are run
+// by full health checks.
+func NewMetricsServer(addr string, appLister applister.ApplicationLister, healthCheck func() error, dependencies []healthz.Check, appLabelNames []string, maxAppLabelValues int) *MetricsServer {
 	mux := http.NewServeMux()
-	registry := NewAppRegistry(appLister)
+	appLabels := newAppLabels(appLabelNames, maxAppLabelValues)
+	registry := NewAppRegistry(appLister, appLabels)
 	mux.Handle(MetricsPath, promhttp.HandlerFor(prometheus.Gatherers{
 		// contains app controller specific metrics
 		registry,
 		// contains process, golang and controller workqueues metrics
 		prometheus.DefaultGatherer,
 	}, promhttp.HandlerOpts{}))
-	healthz.ServeHealthCheck(mux, healthCheck)
+	healthz.ServeHealthCheck(mux, healthCheck, dependencies...)
+
+	syncCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_app_sync_total",
+			Help: "Number of application syncs.",
+		},
+		appMetricLabels(appLabels, "dest_server", "phase"),
+	)
+	k8sRequestCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_app_k8s_request_total",
+			Help: "Number of kubernetes requests executed during application reconciliation.",
+		},
+		appMetricLabels(appLabels, "server", "response_code", "verb", "resource_kind", "resource_namespace"),
+	)
+	reconcileHistogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "argocd_app_reconcile",
+			Help: "Application reconciliation performance.",
+			// Buckets chosen after observing a ~2100ms mean reconcile time
+			Buckets: []float64{0.25, .5, 1, 2, 4, 8, 16},
+		},
+		appMetricLabels(appLabels, "dest_server"),
+	)
 
 	registry.MustRegister(syncCounter)
 	registry.MustRegister(k8sRequestCounter)
@@ -131,6 +126,7 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, health
 
 	return &MetricsServer{
 		registry: registry,
+		mux:      mux,
 		Server: &http.Server{
 			Addr:    addr,
 			Handler: mux,
@@ -141,6 +137,7 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, health
 		kubectlExecPendingGauge: kubectlExecPendingGauge,
 		reconcileHistogram:      reconcileHistogram,
 		clusterEventsCounter:    clusterEventsCounter,
+		appLabels:               appLabels,
 	}
 }
 
@@ -150,12 +147,17 @@ func (m *MetricsServer) RegisterClustersInfoSource(ctx context.Context, source H
 	m.registry.MustRegister(collector)
 }
 
+// RegisterCollector registers an additional collector of the metrics of the controller
+func (m *MetricsServer) RegisterCollector(collector prometheus.Collector) {
+	m.registry.MustRegister(collector)
+}
+
 // IncSync increments the sync counter for an application
 func (m *MetricsServer) IncSync(app *argoappv1.Application, state *argoappv1.OperationState) {
 	if !state.Phase.Completed() {
 		return
 	}
-	m.syncCounter.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), app.Spec.Destination.Server, string(state.Phase)).Inc()
+	m.syncCounter.WithLabelValues(appLabelValues(m.appLabels, app, app.Spec.Destination.Server, string(state.Phase))...).Inc()
 }
 
 func (m *MetricsServer) IncKubectlExec(command string) {
@@ -177,46 +179,73 @@ func (m *MetricsServer) IncClusterEventsCount(server, group, kind string) {
 
 // IncKubernetesRequest increments the kubernetes requests counter for an application
 func (m *MetricsServer) IncKubernetesRequest(app *argoappv1.Application, server, statusCode, verb, resourceKind, resourceNamespace string) {
-	var namespace, name, project string
-	if app != nil {
-		namespace = app.Namespace
-		name = app.Name
-		project = app.Spec.GetProject()
-	}
-	m.k8sRequestCounter.WithLabelValues(
-		namespace, name, project, server, statusCode,
-		verb, resourceKind, resourceNamespace,
-	).Inc()
+	m.k8sRequestCounter.WithLabelValues(appLabelValues(m.appLabels, app,
+		server, statusCode, verb, resourceKind, resourceNamespace,
+	)...).Inc()
 }
 
 // IncReconcile increments the reconcile counter for an application
 func (m *MetricsServer) IncReconcile(app *argoappv1.Application, duration time.Duration) {
-	m.reconcileHistogram.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), app.Spec.Destination.Server).Observe(duration.Seconds())
+	m.reconcileHistogram.WithLabelValues(appLabelValues(m.appLabels, app, app.Spec.Destination.Server)...).Observe(duration.Seconds())
 }
 
 type appCollector struct {
-	store applister.ApplicationLister
+	store     applister.ApplicationLister
+	appLabels *appLabels
+
+	descAppInfo *prometheus.Desc
+	// DEPRECATED
+	descAppCreated *prometheus.Desc
+	// DEPRECATED: superceded by sync_status label in argocd_app_info
+	descAppSyncStatusCode *prometheus.Desc
+	// DEPRECATED: superceded by health_status label in argocd_app_info
+	descAppHealthStatus *prometheus.Desc
 }
 
 // NewAppCollector returns a prometheus collector for application metrics
-func NewAppCollector(appLister applister.ApplicationLister) prometheus.Collector {
+func NewAppCollector(appLister applister.ApplicationLister, appLabels *appLabels) prometheus.Collector {
 	return &appCollector{
-		store: appLister,
+		store:     appLister,
+		appLabels: appLabels,
+		descAppInfo: prometheus.NewDesc(
+			"argocd_app_info",
+			"Information about application.",
+			appMetricLabels(appLabels, "repo", "dest_server", "dest_namespace", "sync_status", "health_status", "operation"),
+			nil,
+		),
+		descAppCreated: prometheus.NewDesc(
+			"argocd_app_created_time",
+			"Creation time in unix timestamp for an application.",
+			appMetricLabels(appLabels),
+			nil,
+		),
+		descAppSyncStatusCode: prometheus.NewDesc(
+			"argocd_app_sync_status",
+			"The application current sync status.",
+			appMetricLabels(appLabels, "sync_status"),
+			nil,
+		),
+		descAppHealthStatus: prometheus.NewDesc(
+			"argocd_app_health_status",
+			"The application current health status.",
+			appMetricLabels(appLabels, "health_status"),
+			nil,
+		),
 	}
 }
 
 // NewAppRegistry creates a new prometheus registry that collects applications
-func NewAppRegistry(appLister applister.ApplicationLister) *prometheus.Registry {
+func NewAppRegistry(appLister applister.ApplicationLister, appLabels *appLabels) *prometheus.Registry {
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(NewAppCollector(appLister))
+	registry.MustRegister(NewAppCollector(appLister, appLabels))
 	return registry
 }
 
 // Describe implements the prometheus.Collector interface
 func (c *appCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- descAppInfo
-	ch <- descAppSyncStatusCode
-	ch <- descAppHealthStatus
+	ch <- c.descAppInfo
+	ch <- c.descAppSyncStatusCode
+	ch <- c.descAppHealthStatus
 }
 
 // Collect implements the prometheus.Collector interface
@@ -227,7 +256,7 @@ func (c *appCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 	for _, app := range apps {
-		collectApps(ch, app)
+		c.collectApps(ch, app)
 	}
 }
 
@@ -238,11 +267,9 @@ func boolFloat64(b bool) float64 {
 	return 0
 }
 
-func collectApps(ch chan<- prometheus.Metric, app *argoappv1.Application) {
+func (c *appCollector) collectApps(ch chan<- prometheus.Metric, app *argoappv1.Application) {
 	addConstMetric := func(desc *prometheus.Desc, t prometheus.ValueType, v float64, lv ...string) {
-		project := app.Spec.GetProject()
-		lv = append([]string{app.Namespace, app.Name, project}, lv...)
-		ch <- prometheus.MustNewConstMetric(desc, t, v, lv...)
+		ch <- prometheus.MustNewConstMetric(desc, t, v, appLabelValues(c.appLabels, app, lv...)...)
 	}
 	addGauge := func(desc *prometheus.Desc, v float64, lv ...string) {
 		addConstMetric(desc, prometheus.GaugeValue, v, lv...)
@@ -263,21 +290,21 @@ func collectApps(ch chan<- prometheus.Metric, app *argoappv1.Application) {
 		healthStatus = argoappv1.HealthStatusUnknown
 	}
 
-	addGauge(descAppInfo, 1, git.NormalizeGitURL(app.Spec.Source.RepoURL), app.Spec.Destination.Server, app.Spec.Destination.Namespace, string(syncStatus), healthStatus, operation)
+	addGauge(c.descAppInfo, 1, git.NormalizeGitURL(app.Spec.Source.RepoURL), app.Spec.Destination.Server, app.Spec.Destination.Namespace, string(syncStatus), healthStatus, operation)
 
 	// Deprecated controller metrics
 	if os.Getenv(EnvVarLegacyControllerMetrics) == "true" {
-		addGauge(descAppCreated, float64(app.CreationTimestamp.Unix()))
-
-		addGauge(descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeSynced), string(argoappv1.SyncStatusCodeSynced))
-		addGauge(descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeOutOfSync), string(argoappv1.SyncStatusCodeOutOfSync))
-		addGauge(descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeUnknown || syncStatus == ""), string(argoappv1.SyncStatusCodeUnknown))
-
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusUnknown || healthStatus == ""), argoappv1.HealthStatusUnknown)
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusProgressing), argoappv1.HealthStatusProgressing)
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusSuspended), argoappv1.HealthStatusSuspended)
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusHealthy), argoappv1.HealthStatusHealthy)
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusDegraded), argoappv1.HealthStatusDegraded)
-		addGauge(descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusMissing), argoappv1.HealthStatusMissing)
+		addGauge(c.descAppCreated, float64(app.CreationTimestamp.Unix()))
+
+		addGauge(c.descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeSynced), string(argoappv1.SyncStatusCodeSynced))
+		addGauge(c.descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeOutOfSync), string(argoappv1.SyncStatusCodeOutOfSync))
+		addGauge(c.descAppSyncStatusCode, boolFloat64(syncStatus == argoappv1.SyncStatusCodeUnknown || syncStatus == ""), string(argoappv1.SyncStatusCodeUnknown))
+
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusUnknown || healthStatus == ""), argoappv1.HealthStatusUnknown)
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusProgressing), argoappv1.HealthStatusProgressing)
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusSuspended), argoappv1.HealthStatusSuspended)
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusHealthy), argoappv1.HealthStatusHealthy)
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusDegraded), argoappv1.HealthStatusDegraded)
+		addGauge(c.descAppHealthStatus, boolFloat64(healthStatus == argoappv1.HealthStatusMissing), argoappv1.HealthStatusMissing)
 	}
 }
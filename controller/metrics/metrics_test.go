@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -145,7 +146,7 @@ func newFakeLister(fakeAppYAMLs ...string) (context.CancelFunc, applister.Applic
 func testApp(t *testing.T, fakeAppYAMLs []string, expectedResponse string) {
 	cancel, appLister := newFakeLister(fakeAppYAMLs...)
 	defer cancel()
-	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck)
+	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck, nil, nil, DefaultMaxAppLabelValues)
 	req, err := http.NewRequest("GET", "/metrics", nil)
 	assert.NoError(t, err)
 	rr := httptest.NewRecorder()
@@ -216,7 +217,7 @@ argocd_app_sync_status{name="my-app",namespace="argocd",project="important-proje
 func TestMetricsSyncCounter(t *testing.T) {
 	cancel, appLister := newFakeLister()
 	defer cancel()
-	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck)
+	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck, nil, nil, DefaultMaxAppLabelValues)
 
 	appSyncTotal := `
 # HELP argocd_app_sync_total Number of application syncs.
@@ -256,7 +257,7 @@ func assertMetricsPrinted(t *testing.T, expectedLines, body string) {
 func TestReconcileMetrics(t *testing.T) {
 	cancel, appLister := newFakeLister()
 	defer cancel()
-	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck)
+	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck, nil, nil, DefaultMaxAppLabelValues)
 	appReconcileMetrics := `
 # HELP argocd_app_reconcile Application reconciliation performance.
 # TYPE argocd_app_reconcile histogram
@@ -283,3 +284,47 @@ argocd_app_reconcile_count{dest_server="https://localhost:6443",name="my-app",na
 	log.Println(body)
 	assertMetricsPrinted(t, appReconcileMetrics, body)
 }
+
+func TestAppLabelMetrics(t *testing.T) {
+	appWithLabels := func(name, team string) string {
+		return fmt.Sprintf(`
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: %s
+  namespace: argocd
+  labels:
+    team: %s
+    service-tier: critical
+spec:
+  destination:
+    namespace: dummy-namespace
+    server: https://localhost:6443
+  project: important-project
+  source:
+    path: some/path
+    repoURL: https://github.com/argoproj/argocd-example-apps.git
+`, name, team)
+	}
+	cancel, appLister := newFakeLister(appWithLabels("my-app", "payments"), appWithLabels("my-app-2", "search"))
+	defer cancel()
+	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck, nil, []string{"team", "service-tier"}, 1)
+
+	app := newFakeApp(appWithLabels("my-app", "payments"))
+	metricsServ.IncSync(app, &argoappv1.OperationState{Phase: argoappv1.OperationSucceeded})
+	metricsServ.IncKubernetesRequest(nil, "https://localhost:6443", "200", "get", "Pod", "default")
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, req)
+	assert.Equal(t, rr.Code, http.StatusOK)
+	body := rr.Body.String()
+	log.Println(body)
+	assertMetricsPrinted(t, `
+argocd_app_info{dest_namespace="dummy-namespace",dest_server="https://localhost:6443",health_status="Unknown",label_service_tier="critical",label_team="payments",name="my-app",namespace="argocd",operation="",project="important-project",repo="https://github.com/argoproj/argocd-example-apps",sync_status="Unknown"} 1
+argocd_app_info{dest_namespace="dummy-namespace",dest_server="https://localhost:6443",health_status="Unknown",label_service_tier="critical",label_team="_other",name="my-app-2",namespace="argocd",operation="",project="important-project",repo="https://github.com/argoproj/argocd-example-apps",sync_status="Unknown"} 1
+argocd_app_sync_total{dest_server="https://localhost:6443",label_service_tier="critical",label_team="payments",name="my-app",namespace="argocd",phase="Succeeded",project="important-project"} 1
+argocd_app_k8s_request_total{label_service_tier="",label_team="",name="",namespace="",project="",resource_kind="Pod",resource_namespace="default",response_code="200",server="https://localhost:6443",verb="get"} 1
+`, body)
+}
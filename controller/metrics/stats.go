@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// StatsPath is the endpoint serving the load of the controller as JSON, for capacity planning
+const StatsPath = "/api/stats"
+
+var (
+	descQueueOldestItemAge = prometheus.NewDesc(
+		"argocd_app_controller_queue_oldest_item_age_seconds",
+		"Time the oldest item of a work queue of the controller has been waiting to be processed, in seconds.",
+		[]string{"queue"},
+		nil,
+	)
+	descAppPendingOperations = prometheus.NewDesc(
+		"argocd_app_pending_operations",
+		"Number of applications with a requested or running operation.",
+		nil,
+		nil,
+	)
+)
+
+// QueueStats is the load of a work queue of the controller
+type QueueStats struct {
+	Name  string `json:"name"`
+	Depth int    `json:"depth"`
+	// OldestItemAgeSeconds is the time the oldest item has been waiting to be processed, 0 if the queue is empty.
+	// Items which are scheduled to be added later are not waiting yet.
+	OldestItemAgeSeconds float64 `json:"oldestItemAgeSeconds"`
+}
+
+// ControllerStats is a snapshot of the load of the controller
+type ControllerStats struct {
+	// Shard is the shard of the controller
+	Shard             int           `json:"shard"`
+	Applications      int           `json:"applications"`
+	PendingOperations int           `json:"pendingOperations"`
+	Queues            []QueueStats  `json:"queues"`
+	Clusters          []ClusterInfo `json:"clusters"`
+}
+
+type HasStats interface {
+	GetStats() ControllerStats
+}
+
+type statsCollector struct {
+	source HasStats
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descQueueOldestItemAge
+	ch <- descAppPendingOperations
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.source.GetStats()
+	for _, queue := range stats.Queues {
+		ch <- prometheus.MustNewConstMetric(descQueueOldestItemAge, prometheus.GaugeValue, queue.OldestItemAgeSeconds, queue.Name)
+	}
+	ch <- prometheus.MustNewConstMetric(descAppPendingOperations, prometheus.GaugeValue, float64(stats.PendingOperations))
+}
+
+// RegisterStatsSource exposes the load of the controller as metrics, and serves it as JSON on StatsPath
+func (m *MetricsServer) RegisterStatsSource(source HasStats) {
+	m.registry.MustRegister(&statsCollector{source: source})
+	m.mux.HandleFunc(StatsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(source.GetStats()); err != nil {
+			log.Warnf("Failed to write controller stats: %v", err)
+		}
+	})
+}
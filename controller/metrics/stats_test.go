@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStatsSource struct {
+	stats ControllerStats
+}
+
+func (s fakeStatsSource) GetStats() ControllerStats {
+	return s.stats
+}
+
+func TestStats(t *testing.T) {
+	cancel, appLister := newFakeLister()
+	defer cancel()
+	metricsServ := NewMetricsServer("localhost:8082", appLister, noOpHealthCheck, nil, nil, DefaultMaxAppLabelValues)
+	stats := ControllerStats{
+		Applications:      3,
+		PendingOperations: 1,
+		Queues:            []QueueStats{{Name: "app_reconciliation_queue", Depth: 2, OldestItemAgeSeconds: 1.5}},
+		Clusters:          []ClusterInfo{{Server: "https://localhost:6443", ResourcesCount: 10, APIsCount: 2}},
+	}
+	metricsServ.RegisterStatsSource(fakeStatsSource{stats: stats})
+
+	rr := httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, httptest.NewRequest("GET", MetricsPath, nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assertMetricsPrinted(t, `
+argocd_app_controller_queue_oldest_item_age_seconds{queue="app_reconciliation_queue"} 1.5
+argocd_app_pending_operations 1
+`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, httptest.NewRequest("GET", StatsPath, nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var served ControllerStats
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &served))
+	assert.Equal(t, stats, served)
+}
@@ -0,0 +1,228 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-cd/common"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	appinformers "github.com/argoproj/argo-cd/pkg/client/informers/externalversions"
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/notification"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// NotificationController sends the notifications applications are subscribed to when their state changes
+type NotificationController struct {
+	namespace            string
+	settingsMgr          *settings.SettingsManager
+	applicationClientset appclientset.Interface
+	appInformer          cache.SharedIndexInformer
+	appLister            applisters.ApplicationLister
+	projInformer         cache.SharedIndexInformer
+	projLister           applisters.AppProjectLister
+	appQueue             workqueue.RateLimitingInterface
+	// cfg is the parsed configuration of the resource versions of the config map and secret in cfgVersion
+	cfg        *notification.Config
+	cfgVersion string
+	cfgLock    sync.Mutex
+}
+
+// NewNotificationController creates a new instance of NotificationController. The notifications are configured by the
+// argocd-notifications-cm config map, and no notifications are sent if it doesn't exist.
+func NewNotificationController(
+	namespace string,
+	settingsMgr *settings.SettingsManager,
+	applicationClientset appclientset.Interface,
+	resyncPeriod time.Duration,
+) *NotificationController {
+	ctrl := NotificationController{
+		namespace:            namespace,
+		settingsMgr:          settingsMgr,
+		applicationClientset: applicationClientset,
+		appQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "notification_queue"),
+	}
+	informerFactory := appinformers.NewFilteredSharedInformerFactory(
+		applicationClientset,
+		resyncPeriod,
+		namespace,
+		func(options *metav1.ListOptions) {},
+	)
+	ctrl.appInformer = informerFactory.Argoproj().V1alpha1().Applications().Informer()
+	ctrl.appLister = informerFactory.Argoproj().V1alpha1().Applications().Lister()
+	ctrl.projInformer = informerFactory.Argoproj().V1alpha1().AppProjects().Informer()
+	ctrl.projLister = informerFactory.Argoproj().V1alpha1().AppProjects().Lister()
+	ctrl.appInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				ctrl.appQueue.Add(key)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				ctrl.appQueue.Add(key)
+			}
+		},
+	})
+	return &ctrl
+}
+
+// Run starts the controller and blocks until the context is done
+func (ctrl *NotificationController) Run(ctx context.Context, workers int) {
+	defer runtime.HandleCrash()
+	defer ctrl.appQueue.ShutDown()
+
+	go ctrl.appInformer.Run(ctx.Done())
+	go ctrl.projInformer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), ctrl.appInformer.HasSynced, ctrl.projInformer.HasSynced) {
+		log.Error("Timed out waiting for caches to sync")
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for ctrl.processAppQueueItem() {
+			}
+		}, time.Second, ctx.Done())
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *NotificationController) processAppQueueItem() (processNext bool) {
+	key, shutdown := ctrl.appQueue.Get()
+	if shutdown {
+		processNext = false
+		return
+	}
+	processNext = true
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Recovered from panic: %+v\n%s", r, debug.Stack())
+		}
+		ctrl.appQueue.Done(key)
+	}()
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		log.Errorf("Failed to split key %s: %v", key, err)
+		return
+	}
+	app, err := ctrl.appLister.Applications(namespace).Get(name)
+	if apierr.IsNotFound(err) {
+		ctrl.appQueue.Forget(key)
+		return
+	}
+	if err != nil {
+		log.Errorf("Failed to get application %s: %v", key, err)
+		ctrl.appQueue.AddRateLimited(key)
+		return
+	}
+	cfg, err := ctrl.getConfig()
+	if err != nil {
+		log.Warnf("Failed to get notifications config: %v", err)
+		ctrl.appQueue.AddRateLimited(key)
+		return
+	}
+	if cfg == nil {
+		ctrl.appQueue.Forget(key)
+		return
+	}
+	logCtx := log.WithField("application", name)
+	proj, err := ctrl.projLister.AppProjects(namespace).Get(app.Spec.GetProject())
+	if apierr.IsNotFound(err) {
+		proj, err = nil, nil
+	}
+	if err != nil {
+		logCtx.Warnf("Failed to get project: %v", err)
+		ctrl.appQueue.AddRateLimited(key)
+		return
+	}
+	state := notification.ParseState(app.Annotations[common.AnnotationKeyNotified])
+	notifyErr := cfg.Notify(app, proj, state)
+	if notifyErr != nil {
+		logCtx.Warnf("Failed to notify: %v", notifyErr)
+	}
+	if err := ctrl.setState(namespace, name, app.Annotations[common.AnnotationKeyNotified], state); err != nil {
+		logCtx.Warnf("Failed to update notification state: %v", err)
+		ctrl.appQueue.AddRateLimited(key)
+		return
+	}
+	if notifyErr != nil {
+		ctrl.appQueue.AddRateLimited(key)
+		return
+	}
+	ctrl.appQueue.Forget(key)
+	return
+}
+
+// setState updates the notification state annotation of an application, if it changed
+func (ctrl *NotificationController) setState(namespace string, name string, annotation string, state notification.State) error {
+	updated := ""
+	if len(state) > 0 {
+		updated = state.String()
+	}
+	if updated == annotation {
+		return nil
+	}
+	// an empty state removes the annotation
+	var value interface{}
+	if updated != "" {
+		value = updated
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{common.AnnotationKeyNotified: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = ctrl.applicationClientset.ArgoprojV1alpha1().Applications(namespace).Patch(name, types.MergePatchType, patch)
+	return err
+}
+
+// getConfig returns the parsed notifications config, or nil if the config map doesn't exist
+func (ctrl *NotificationController) getConfig() (*notification.Config, error) {
+	cm, err := ctrl.settingsMgr.GetConfigMapByName(common.ArgoCDNotificationsConfigMapName)
+	if apierr.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := ctrl.settingsMgr.GetSecretsLister()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := secrets.Secrets(ctrl.namespace).Get(common.ArgoCDNotificationsSecretName)
+	if apierr.IsNotFound(err) {
+		secret, err = &corev1.Secret{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	version := fmt.Sprintf("%s/%s", cm.ResourceVersion, secret.ResourceVersion)
+	ctrl.cfgLock.Lock()
+	defer ctrl.cfgLock.Unlock()
+	if ctrl.cfg == nil || ctrl.cfgVersion != version {
+		if ctrl.cfg, err = notification.ParseConfig(cm, secret); err != nil {
+			return nil, err
+		}
+		ctrl.cfgVersion = version
+	}
+	return ctrl.cfg, nil
+}
@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/notification"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+func newFakeController(kubeObjects []runtime.Object, objects ...runtime.Object) *NotificationController {
+	kubeClient := fake.NewSimpleClientset(append(kubeObjects, test.NewFakeConfigMap(), test.NewFakeSecret())...)
+	ctrl := NewNotificationController(
+		test.FakeArgoCDNamespace,
+		settings.NewSettingsManager(context.Background(), kubeClient, test.FakeArgoCDNamespace),
+		appclientset.NewSimpleClientset(objects...),
+		time.Minute,
+	)
+	cancel := test.StartInformer(ctrl.appInformer)
+	defer cancel()
+	return ctrl
+}
+
+func newFakeNotificationsConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDNotificationsConfigMapName,
+			Namespace: test.FakeArgoCDNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: data,
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	ctrl := newFakeController(nil)
+	cfg, err := ctrl.getConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	ctrl = newFakeController([]runtime.Object{newFakeNotificationsConfigMap(map[string]string{"context": "argocdUrl: https://argocd.example.com"})})
+	cfg, err = ctrl.getConfig()
+	assert.NoError(t, err)
+	if assert.NotNil(t, cfg) {
+		assert.Equal(t, "https://argocd.example.com", cfg.Context["argocdUrl"])
+	}
+	cached, err := ctrl.getConfig()
+	assert.NoError(t, err)
+	assert.True(t, cfg == cached)
+}
+
+func TestSetState(t *testing.T) {
+	app := &appv1.Application{ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: test.FakeArgoCDNamespace}}
+	ctrl := newFakeController(nil, app)
+	appIf := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace)
+	state := notification.State{"on-sync-failed:[0]:slack:team": 1600000000}
+
+	assert.NoError(t, ctrl.setState(app.Namespace, app.Name, "", state))
+	updated, err := appIf.Get(app.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, state.String(), updated.Annotations[common.AnnotationKeyNotified])
+
+	assert.NoError(t, ctrl.setState(app.Namespace, app.Name, state.String(), notification.State{}))
+	updated, err = appIf.Get(app.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, updated.Annotations, common.AnnotationKeyNotified)
+}
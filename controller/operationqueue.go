@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// queuedOperationRecheckInterval is the interval the queued operations are checked at, in addition to the completion
+// of the running operations
+const queuedOperationRecheckInterval = 30 * time.Second
+
+// operationQueue records the sync operations which were admitted by the concurrency limits, until the informer reports
+// them as running
+type operationQueue struct {
+	lock     sync.Mutex
+	admitted map[string]bool
+}
+
+func newOperationQueue() *operationQueue {
+	return &operationQueue{admitted: make(map[string]bool)}
+}
+
+// release forgets the admission of the operation of an application
+func (q *operationQueue) release(appName string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.admitted, appName)
+}
+
+// prune forgets the admissions of the applications which were deleted or have no operation anymore, e.g. because the
+// operation was terminated forcibly
+func (q *operationQueue) prune(apps []*appv1.Application) {
+	withOperation := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		if app.Operation != nil {
+			withOperation[app.Name] = true
+		}
+	}
+	for appName := range q.admitted {
+		if !withOperation[appName] {
+			delete(q.admitted, appName)
+		}
+	}
+}
+
+// concurrencyLimit is a limit of the number of concurrent sync operations of a group of applications
+type concurrencyLimit struct {
+	description string
+	limit       int64
+	matches     func(app *appv1.Application) bool
+}
+
+// getConcurrencyLimits returns the limits of the number of concurrent sync operations which apply to an application,
+// i.e. the one of its project and the ones of its destination clusters
+func (ctrl *ApplicationController) getConcurrencyLimits(app *appv1.Application) ([]concurrencyLimit, error) {
+	var res []concurrencyLimit
+	proj, err := ctrl.getAppProj(app)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Spec.Quota != nil && proj.Spec.Quota.MaxConcurrentSyncs > 0 {
+		res = append(res, concurrencyLimit{
+			description: fmt.Sprintf("project '%s'", proj.Name),
+			limit:       proj.Spec.Quota.MaxConcurrentSyncs,
+			matches: func(a *appv1.Application) bool {
+				return a.Spec.GetProject() == proj.Name
+			},
+		})
+	}
+	servers := make(map[string]bool)
+	for _, destination := range app.Spec.GetDestinations() {
+		if servers[destination.Server] {
+			continue
+		}
+		servers[destination.Server] = true
+		cluster, err := ctrl.db.GetCluster(context.Background(), destination.Server)
+		if err != nil || cluster.MaxConcurrentSyncs <= 0 {
+			continue
+		}
+		server := destination.Server
+		res = append(res, concurrencyLimit{
+			description: fmt.Sprintf("cluster '%s'", server),
+			limit:       cluster.MaxConcurrentSyncs,
+			matches: func(a *appv1.Application) bool {
+				for _, destination := range a.Spec.GetDestinations() {
+					if destination.Server == server {
+						return true
+					}
+				}
+				return false
+			},
+		})
+	}
+	return res, nil
+}
+
+// admitOperation returns whether the sync operation of an application may start. The operations which would exceed
+// the concurrency limits of the project or of the destination clusters of the application, or which were queued after
+// the operations waiting for the same limits, are queued: the state is updated with the position of the operation in
+// the queue and the limits it waits for.
+func (ctrl *ApplicationController) admitOperation(app *appv1.Application, state *appv1.OperationState) (bool, error) {
+	if state.Operation.Sync == nil {
+		return true, nil
+	}
+	limits, err := ctrl.getConcurrencyLimits(app)
+	if err != nil || len(limits) == 0 {
+		return true, err
+	}
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name < apps[j].Name
+	})
+
+	q := ctrl.operationQueue
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.prune(apps)
+	var position int64
+	var waitingFor []string
+	for _, limit := range limits {
+		var running, earlier int64
+		for _, other := range apps {
+			if other.Name == app.Name || !limit.matches(other) {
+				continue
+			}
+			if q.admitted[other.Name] || isOperationRunning(other) {
+				running++
+			} else if isOperationQueued(other) && queuedBefore(other.Status.OperationState, other.Name, state, app.Name) {
+				earlier++
+			}
+		}
+		if running+earlier >= limit.limit {
+			if earlier+1 > position {
+				position = earlier + 1
+			}
+			waitingFor = append(waitingFor, fmt.Sprintf("%d of %d concurrent syncs of %s are running", running, limit.limit, limit.description))
+		}
+	}
+	if position == 0 {
+		q.admitted[app.Name] = true
+		return true, nil
+	}
+	state.Phase = appv1.OperationQueued
+	state.QueuePosition = position
+	state.Message = fmt.Sprintf("operation is queued at position %d: %s", position, strings.Join(waitingFor, ", "))
+	return false, nil
+}
+
+// isOperationRunning returns whether an application has a sync operation which was started and is not completed
+func isOperationRunning(app *appv1.Application) bool {
+	return isOperationInProgress(app) && !isOperationQueued(app)
+}
+
+// isOperationQueued returns whether the operation of an application waits for the concurrency limits
+func isOperationQueued(app *appv1.Application) bool {
+	return app.Operation != nil && app.Status.OperationState != nil && app.Status.OperationState.Phase == appv1.OperationQueued
+}
+
+// queuedBefore returns whether an operation was queued before another one. Operations queued at the same time are
+// ordered by the names of their applications.
+func queuedBefore(state *appv1.OperationState, appName string, other *appv1.OperationState, otherAppName string) bool {
+	if !state.StartedAt.Equal(&other.StartedAt) {
+		return state.StartedAt.Before(&other.StartedAt)
+	}
+	return appName < otherAppName
+}
+
+// requeueQueuedOperations requests the processing of the queued operations, which might be admitted once a running
+// operation completed. The queued operations are also retried periodically, since the limits might change.
+func (ctrl *ApplicationController) requeueQueuedOperations() {
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, app := range apps {
+		if isOperationQueued(app) && ctrl.canProcessApp(app) {
+			ctrl.appOperationQueue.Add(ctrl.toAppKey(app.Name))
+		}
+	}
+}
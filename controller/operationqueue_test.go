@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/test"
+)
+
+func newFakeSyncingApp(name string, phase argoappv1.OperationPhase, startedAt time.Time) *argoappv1.Application {
+	app := newFakeApp()
+	app.Name = name
+	app.Operation = &argoappv1.Operation{Sync: &argoappv1.SyncOperation{}}
+	app.Status.OperationState = &argoappv1.OperationState{Operation: *app.Operation, Phase: phase, StartedAt: metav1.NewTime(startedAt)}
+	return app
+}
+
+func newFakeProj(maxConcurrentSyncs int64) *argoappv1.AppProject {
+	return &argoappv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: test.FakeArgoCDNamespace},
+		Spec: argoappv1.AppProjectSpec{
+			SourceRepos:  []string{"*"},
+			Destinations: []argoappv1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+			Quota:        &argoappv1.ProjectQuota{MaxConcurrentSyncs: maxConcurrentSyncs},
+		},
+	}
+}
+
+func TestAdmitOperation(t *testing.T) {
+	now := time.Now()
+	running := newFakeSyncingApp("running", argoappv1.OperationRunning, now.Add(-time.Minute))
+	queued := newFakeSyncingApp("queued", argoappv1.OperationQueued, now.Add(-30*time.Second))
+	unlimited := newFakeSyncingApp("unlimited", argoappv1.OperationRunning, now.Add(-time.Minute))
+	unlimited.Spec.Project = "unlimited"
+	unlimitedProj := newFakeProj(0)
+	unlimitedProj.Name = "unlimited"
+
+	t.Run("Unlimited", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, newFakeProj(0)}})
+		app := newFakeSyncingApp("my-app", argoappv1.OperationRunning, now)
+		admitted, err := ctrl.admitOperation(app, app.Status.OperationState)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+
+	t.Run("Admitted", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, unlimited, newFakeProj(2), unlimitedProj}})
+		app := newFakeSyncingApp("my-app", argoappv1.OperationRunning, now)
+		admitted, err := ctrl.admitOperation(app, app.Status.OperationState)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+		assert.True(t, ctrl.operationQueue.admitted["my-app"])
+
+		// the admitted operation counts as running until the informer reports it
+		other := newFakeSyncingApp("other", argoappv1.OperationRunning, now)
+		ctrl.operationQueue.admitted[app.Name] = true
+		_, err = ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Create(app)
+		assert.NoError(t, err)
+		assert.NoError(t, ctrl.appInformer.GetIndexer().Add(app))
+		admitted, err = ctrl.admitOperation(other, other.Status.OperationState)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+
+		ctrl.operationQueue.release(app.Name)
+		assert.False(t, ctrl.operationQueue.admitted["my-app"])
+	})
+
+	t.Run("QueuedBehindRunning", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, newFakeProj(1)}})
+		app := newFakeSyncingApp("my-app", argoappv1.OperationRunning, now)
+		state := app.Status.OperationState
+		admitted, err := ctrl.admitOperation(app, state)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+		assert.Equal(t, argoappv1.OperationQueued, state.Phase)
+		assert.Equal(t, int64(1), state.QueuePosition)
+		assert.Contains(t, state.Message, "1 of 1 concurrent syncs of project 'default' are running")
+	})
+
+	t.Run("QueuedBehindQueued", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, queued, newFakeProj(2)}})
+		app := newFakeSyncingApp("my-app", argoappv1.OperationQueued, now)
+		state := app.Status.OperationState
+		admitted, err := ctrl.admitOperation(app, state)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+		assert.Equal(t, int64(2), state.QueuePosition)
+
+		// the earliest queued operation starts first
+		admitted, err = ctrl.admitOperation(queued, queued.Status.OperationState.DeepCopy())
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+
+	t.Run("ClusterLimit", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, unlimited, newFakeProj(0), unlimitedProj}})
+		cluster, err := ctrl.db.GetCluster(context.Background(), "https://localhost:6443")
+		assert.NoError(t, err)
+		cluster.MaxConcurrentSyncs = 2
+		_, err = ctrl.db.UpdateCluster(context.Background(), cluster)
+		assert.NoError(t, err)
+		assert.NoError(t, ctrl.settingsMgr.ResyncInformers())
+
+		app := newFakeSyncingApp("my-app", argoappv1.OperationRunning, now)
+		state := app.Status.OperationState
+		admitted, err := ctrl.admitOperation(app, state)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+		assert.Contains(t, state.Message, "2 of 2 concurrent syncs of cluster 'https://localhost:6443' are running")
+	})
+
+	t.Run("NotSync", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, newFakeProj(1)}})
+		state := &argoappv1.OperationState{Phase: argoappv1.OperationRunning}
+		admitted, err := ctrl.admitOperation(newFakeApp(), state)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+}
+
+func TestProcessRequestedAppOperationQueued(t *testing.T) {
+	running := newFakeSyncingApp("running", argoappv1.OperationRunning, time.Now())
+	app := newFakeApp()
+	app.Operation = &argoappv1.Operation{Sync: &argoappv1.SyncOperation{}}
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{running, app, newFakeProj(1)}})
+	appIf := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace)
+
+	ctrl.processRequestedAppOperation(app)
+	app, err := appIf.Get(app.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, argoappv1.OperationQueued, app.Status.OperationState.Phase)
+	assert.Equal(t, int64(1), app.Status.OperationState.QueuePosition)
+
+	app.Status.OperationState.Phase = argoappv1.OperationTerminating
+	app, err = appIf.Update(app)
+	assert.NoError(t, err)
+	ctrl.processRequestedAppOperation(app)
+	app, err = appIf.Get(app.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, argoappv1.OperationFailed, app.Status.OperationState.Phase)
+	assert.Equal(t, "operation was terminated while queued", app.Status.OperationState.Message)
+	assert.Nil(t, app.Operation)
+}
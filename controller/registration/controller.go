@@ -0,0 +1,501 @@
+package registration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	appinformers "github.com/argoproj/argo-cd/pkg/client/informers/externalversions"
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/argo"
+	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/git"
+	"github.com/argoproj/argo-cd/util/kube"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// registrationKey is the key of a RepositoryRegistration or a ClusterRegistration in the queue of the controller
+type registrationKey struct {
+	kind string
+	key  string
+}
+
+const (
+	repositoryKind = "repository"
+	clusterKind    = "cluster"
+)
+
+// RegistrationController registers the repositories of RepositoryRegistrations and the clusters of
+// ClusterRegistrations, unregisters them when the resources are deleted and reports the state of the connection to
+// them in the status of the resources
+type RegistrationController struct {
+	namespace            string
+	kubeClientset        kubernetes.Interface
+	applicationClientset appclientset.Interface
+	db                   db.ArgoDB
+	repoRegInformer      cache.SharedIndexInformer
+	repoRegLister        applisters.RepositoryRegistrationLister
+	clusterRegInformer   cache.SharedIndexInformer
+	clusterRegLister     applisters.ClusterRegistrationLister
+	queue                workqueue.RateLimitingInterface
+	// testRepo and testCluster check the connections to repositories and clusters
+	testRepo    func(repo *appv1.Repository) error
+	testCluster func(cluster *appv1.Cluster) error
+}
+
+// NewRegistrationController creates a new instance of RegistrationController. Every registration is reconciled when
+// it changes and at least once per resync period, which checks the connection once more and picks up changes of the
+// secrets it references.
+func NewRegistrationController(
+	namespace string,
+	settingsMgr *settings.SettingsManager,
+	kubeClientset kubernetes.Interface,
+	applicationClientset appclientset.Interface,
+	resyncPeriod time.Duration,
+) *RegistrationController {
+	kubectl := &kube.KubectlCmd{}
+	ctrl := RegistrationController{
+		namespace:            namespace,
+		kubeClientset:        kubeClientset,
+		applicationClientset: applicationClientset,
+		db:                   db.NewDB(namespace, settingsMgr, kubeClientset),
+		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "registration_queue"),
+		testRepo:             argo.TestRepo,
+		testCluster: func(cluster *appv1.Cluster) error {
+			_, err := kubectl.GetServerVersion(cluster.RESTConfig())
+			return err
+		},
+	}
+	informerFactory := appinformers.NewFilteredSharedInformerFactory(
+		applicationClientset,
+		resyncPeriod,
+		namespace,
+		func(options *metav1.ListOptions) {},
+	)
+	ctrl.repoRegInformer = informerFactory.Argoproj().V1alpha1().RepositoryRegistrations().Informer()
+	ctrl.repoRegLister = informerFactory.Argoproj().V1alpha1().RepositoryRegistrations().Lister()
+	ctrl.clusterRegInformer = informerFactory.Argoproj().V1alpha1().ClusterRegistrations().Informer()
+	ctrl.clusterRegLister = informerFactory.Argoproj().V1alpha1().ClusterRegistrations().Lister()
+
+	for kind, informer := range map[string]cache.SharedIndexInformer{repositoryKind: ctrl.repoRegInformer, clusterKind: ctrl.clusterRegInformer} {
+		kind := kind
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+					ctrl.queue.Add(registrationKey{kind: kind, key: key})
+				}
+			},
+			UpdateFunc: func(old, new interface{}) {
+				if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+					ctrl.queue.Add(registrationKey{kind: kind, key: key})
+				}
+			},
+		})
+	}
+	return &ctrl
+}
+
+// Run starts the controller and blocks until the context is done
+func (ctrl *RegistrationController) Run(ctx context.Context, workers int) {
+	defer runtime.HandleCrash()
+	defer ctrl.queue.ShutDown()
+
+	go ctrl.repoRegInformer.Run(ctx.Done())
+	go ctrl.clusterRegInformer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), ctrl.repoRegInformer.HasSynced, ctrl.clusterRegInformer.HasSynced) {
+		log.Error("Timed out waiting for caches to sync")
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for ctrl.processQueueItem() {
+			}
+		}, time.Second, ctx.Done())
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *RegistrationController) processQueueItem() (processNext bool) {
+	obj, shutdown := ctrl.queue.Get()
+	if shutdown {
+		processNext = false
+		return
+	}
+	processNext = true
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Recovered from panic: %+v\n%s", r, debug.Stack())
+		}
+		ctrl.queue.Done(obj)
+	}()
+	key := obj.(registrationKey)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.key)
+	if err != nil {
+		log.Errorf("Failed to split key %s: %v", key.key, err)
+		return
+	}
+	switch key.kind {
+	case repositoryKind:
+		var reg *appv1.RepositoryRegistration
+		if reg, err = ctrl.repoRegLister.RepositoryRegistrations(namespace).Get(name); err == nil {
+			err = ctrl.reconcileRepository(reg.DeepCopy())
+		}
+	case clusterKind:
+		var reg *appv1.ClusterRegistration
+		if reg, err = ctrl.clusterRegLister.ClusterRegistrations(namespace).Get(name); err == nil {
+			err = ctrl.reconcileCluster(reg.DeepCopy())
+		}
+	}
+	if apierr.IsNotFound(err) {
+		// the registrations are unregistered by their finalizer before they are gone
+		ctrl.queue.Forget(obj)
+		return
+	}
+	if err != nil {
+		log.WithField(key.kind+"registration", name).Warnf("Failed to reconcile registration: %v", err)
+		ctrl.queue.AddRateLimited(obj)
+		return
+	}
+	ctrl.queue.Forget(obj)
+	return
+}
+
+// reconcileRepository registers or updates the repository of a RepositoryRegistration and checks the connection to
+// it, or unregisters the repository if the RepositoryRegistration is deleted
+func (ctrl *RegistrationController) reconcileRepository(reg *appv1.RepositoryRegistration) error {
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().RepositoryRegistrations(reg.Namespace)
+	if reg.DeletionTimestamp != nil {
+		if !hasFinalizer(reg.Finalizers) {
+			return nil
+		}
+		if reg.Status.Registered != "" {
+			if err := ctrl.db.DeleteRepository(context.Background(), reg.Status.Registered); err != nil && !isNotFound(err) {
+				return err
+			}
+			log.WithField("repositoryregistration", reg.Name).Infof("Unregistered repository %s", reg.Status.Registered)
+		}
+		reg.Finalizers = removeFinalizer(reg.Finalizers)
+		_, err := regIf.Update(reg)
+		return err
+	}
+	if !hasFinalizer(reg.Finalizers) {
+		reg.Finalizers = append(reg.Finalizers, common.RegistrationFinalizerName)
+		updated, err := regIf.Update(reg)
+		if err != nil {
+			return err
+		}
+		reg = updated
+	}
+
+	claim := func(registered string) error {
+		reg.Status.Registered = registered
+		updated, err := regIf.Update(reg)
+		if err != nil {
+			return err
+		}
+		reg = updated
+		return nil
+	}
+	regStatus := reg.Status.DeepCopy()
+	repo, err := ctrl.getRepository(reg)
+	if err == nil {
+		err = ctrl.registerRepository(reg.Name, regStatus, repo, claim)
+	}
+	if err != nil {
+		regStatus.SetCondition(appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusFalse, err.Error())
+		regStatus.RemoveCondition(appv1.RegistrationConditionConnected)
+	} else {
+		regStatus.SetCondition(appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, fmt.Sprintf("repository %s is registered", repo.Repo))
+		if err := ctrl.testRepo(repo); err != nil {
+			regStatus.SetCondition(appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusFalse, err.Error())
+		} else {
+			regStatus.SetCondition(appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "repository is accessible")
+		}
+	}
+	if reflect.DeepEqual(&reg.Status, regStatus) {
+		return nil
+	}
+	reg.Status = *regStatus
+	_, err = regIf.Update(reg)
+	return err
+}
+
+// getRepository returns the repository of a RepositoryRegistration, with the credentials of its secret
+func (ctrl *RegistrationController) getRepository(reg *appv1.RepositoryRegistration) (*appv1.Repository, error) {
+	if reg.Spec.Repo == "" {
+		return nil, fmt.Errorf("the URL of the repository is missing")
+	}
+	repo := &appv1.Repository{
+		Repo:          reg.Spec.Repo,
+		Type:          reg.Spec.Type,
+		Name:          reg.Spec.Name,
+		Insecure:      reg.Spec.Insecure,
+		EnableLFS:     reg.Spec.EnableLFS,
+		CloudIdentity: reg.Spec.CloudIdentity,
+	}
+	if reg.Spec.CredentialsSecret == "" {
+		return repo, nil
+	}
+	secret, err := ctrl.kubeClientset.CoreV1().Secrets(ctrl.namespace).Get(reg.Spec.CredentialsSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the credentials secret %s: %v", reg.Spec.CredentialsSecret, err)
+	}
+	repo.Username = string(secret.Data["username"])
+	repo.Password = string(secret.Data["password"])
+	repo.SSHPrivateKey = string(secret.Data["sshPrivateKey"])
+	repo.TLSClientCertData = string(secret.Data["tlsClientCertData"])
+	repo.TLSClientCertKey = string(secret.Data["tlsClientCertKey"])
+	return repo, nil
+}
+
+// registerRepository creates or updates a repository. The repository which was registered before is unregistered if
+// the URL changed. Repositories which were not registered from the registration are not taken over. The registration
+// claims the repository with the claim function, which persists it as registered, before creating it, so that the
+// repository is adopted if the status of the registration can't be updated after the creation.
+func (ctrl *RegistrationController) registerRepository(regName string, regStatus *appv1.RegistrationStatus, repo *appv1.Repository, claim func(registered string) error) error {
+	ctx := context.Background()
+	logCtx := log.WithField("repositoryregistration", regName)
+	if regStatus.Registered != "" && !git.SameURL(regStatus.Registered, repo.Repo) {
+		if err := ctrl.db.DeleteRepository(ctx, regStatus.Registered); err != nil && !isNotFound(err) {
+			return err
+		}
+		logCtx.Infof("Unregistered repository %s", regStatus.Registered)
+		regStatus.Registered = ""
+	}
+	repos, err := ctrl.db.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	var existing *appv1.Repository
+	for _, r := range repos {
+		if git.SameURL(r.Repo, repo.Repo) {
+			existing = r
+		}
+	}
+	switch {
+	case existing == nil:
+		if err := claim(repo.Repo); err != nil {
+			return err
+		}
+		if _, err := ctrl.db.CreateRepository(ctx, repo); err != nil {
+			return err
+		}
+		logCtx.Infof("Registered repository %s", repo.Repo)
+	case regStatus.Registered == "":
+		return fmt.Errorf("repository %s is already registered otherwise", repo.Repo)
+	case repositoryChanged(existing, repo):
+		if _, err := ctrl.db.UpdateRepository(ctx, repo); err != nil {
+			return err
+		}
+		logCtx.Infof("Updated repository %s", repo.Repo)
+	}
+	regStatus.Registered = repo.Repo
+	return nil
+}
+
+// repositoryChanged returns whether a registered repository differs from the one of its registration in any of the
+// settings which can be updated
+func repositoryChanged(existing *appv1.Repository, repo *appv1.Repository) bool {
+	if existing.IsInsecure() != repo.IsInsecure() || existing.EnableLFS != repo.EnableLFS || existing.CloudIdentity != repo.CloudIdentity {
+		return true
+	}
+	// the credentials the repository inherits from the credential templates are not its own
+	if existing.InheritedCreds {
+		return repo.HasCredentials()
+	}
+	return existing.Username != repo.Username ||
+		existing.Password != repo.Password ||
+		existing.SSHPrivateKey != repo.SSHPrivateKey ||
+		existing.TLSClientCertData != repo.TLSClientCertData ||
+		existing.TLSClientCertKey != repo.TLSClientCertKey
+}
+
+// reconcileCluster registers or updates the cluster of a ClusterRegistration and checks the connection to it, or
+// unregisters the cluster if the ClusterRegistration is deleted
+func (ctrl *RegistrationController) reconcileCluster(reg *appv1.ClusterRegistration) error {
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().ClusterRegistrations(reg.Namespace)
+	if reg.DeletionTimestamp != nil {
+		if !hasFinalizer(reg.Finalizers) {
+			return nil
+		}
+		if reg.Status.Registered != "" {
+			if err := ctrl.db.DeleteCluster(context.Background(), reg.Status.Registered); err != nil {
+				return err
+			}
+			log.WithField("clusterregistration", reg.Name).Infof("Unregistered cluster %s", reg.Status.Registered)
+		}
+		reg.Finalizers = removeFinalizer(reg.Finalizers)
+		_, err := regIf.Update(reg)
+		return err
+	}
+	if !hasFinalizer(reg.Finalizers) {
+		reg.Finalizers = append(reg.Finalizers, common.RegistrationFinalizerName)
+		updated, err := regIf.Update(reg)
+		if err != nil {
+			return err
+		}
+		reg = updated
+	}
+
+	claim := func(registered string) error {
+		reg.Status.Registered = registered
+		updated, err := regIf.Update(reg)
+		if err != nil {
+			return err
+		}
+		reg = updated
+		return nil
+	}
+	regStatus := reg.Status.DeepCopy()
+	cluster, err := ctrl.getCluster(reg)
+	if err == nil {
+		err = ctrl.registerCluster(reg.Name, regStatus, cluster, claim)
+	}
+	if err != nil {
+		regStatus.SetCondition(appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusFalse, err.Error())
+		regStatus.RemoveCondition(appv1.RegistrationConditionConnected)
+	} else {
+		regStatus.SetCondition(appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, fmt.Sprintf("cluster %s is registered", cluster.Server))
+		if err := ctrl.testCluster(cluster); err != nil {
+			regStatus.SetCondition(appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusFalse, err.Error())
+		} else {
+			regStatus.SetCondition(appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "cluster is reachable")
+		}
+	}
+	if reflect.DeepEqual(&reg.Status, regStatus) {
+		return nil
+	}
+	reg.Status = *regStatus
+	_, err = regIf.Update(reg)
+	return err
+}
+
+// getCluster returns the cluster of a ClusterRegistration, with the configuration of its secret
+func (ctrl *RegistrationController) getCluster(reg *appv1.ClusterRegistration) (*appv1.Cluster, error) {
+	if reg.Spec.Server == "" {
+		return nil, fmt.Errorf("the server of the cluster is missing")
+	}
+	cluster := &appv1.Cluster{
+		Server:             reg.Spec.Server,
+		Name:               reg.Spec.Name,
+		Namespaces:         reg.Spec.Namespaces,
+		ClusterResources:   reg.Spec.ClusterResources,
+		Shard:              reg.Spec.Shard,
+		MaxConcurrentSyncs: reg.Spec.MaxConcurrentSyncs,
+	}
+	if cluster.Name == "" {
+		cluster.Name = cluster.Server
+	}
+	if reg.Spec.ConfigSecret == "" {
+		return cluster, nil
+	}
+	secret, err := ctrl.kubeClientset.CoreV1().Secrets(ctrl.namespace).Get(reg.Spec.ConfigSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the config secret %s: %v", reg.Spec.ConfigSecret, err)
+	}
+	if err := json.Unmarshal(secret.Data["config"], &cluster.Config); err != nil {
+		return nil, fmt.Errorf("invalid config of the config secret %s: %v", reg.Spec.ConfigSecret, err)
+	}
+	return cluster, nil
+}
+
+// registerCluster creates or updates a cluster. The cluster which was registered before is unregistered if the server
+// changed. Clusters which were not registered from the registration are not taken over. Like repositories, the
+// registration claims the cluster before creating it.
+func (ctrl *RegistrationController) registerCluster(regName string, regStatus *appv1.RegistrationStatus, cluster *appv1.Cluster, claim func(registered string) error) error {
+	ctx := context.Background()
+	logCtx := log.WithField("clusterregistration", regName)
+	if regStatus.Registered != "" && regStatus.Registered != cluster.Server {
+		if err := ctrl.db.DeleteCluster(ctx, regStatus.Registered); err != nil {
+			return err
+		}
+		logCtx.Infof("Unregistered cluster %s", regStatus.Registered)
+		regStatus.Registered = ""
+	}
+	existing, err := ctrl.db.GetCluster(ctx, cluster.Server)
+	if isNotFound(err) || err == nil && cluster.Server == common.KubernetesInternalAPIServerAddr && existing.Name == "" {
+		// the implicit in-cluster cluster, which has no name, is not registered by a secret
+		existing, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case existing == nil:
+		if err := claim(cluster.Server); err != nil {
+			return err
+		}
+		if _, err := ctrl.db.CreateCluster(ctx, cluster); err != nil {
+			return err
+		}
+		logCtx.Infof("Registered cluster %s", cluster.Server)
+	case regStatus.Registered == "":
+		return fmt.Errorf("cluster %s is already registered otherwise", cluster.Server)
+	case clusterChanged(existing, cluster):
+		if _, err := ctrl.db.UpdateCluster(ctx, cluster); err != nil {
+			return err
+		}
+		logCtx.Infof("Updated cluster %s", cluster.Server)
+	}
+	regStatus.Registered = cluster.Server
+	return nil
+}
+
+// clusterChanged returns whether a registered cluster differs from the one of its registration
+func clusterChanged(existing *appv1.Cluster, cluster *appv1.Cluster) bool {
+	if len(existing.Namespaces) != 0 || len(cluster.Namespaces) != 0 {
+		if !reflect.DeepEqual(existing.Namespaces, cluster.Namespaces) {
+			return true
+		}
+	}
+	return existing.Name != cluster.Name ||
+		existing.ClusterResources != cluster.ClusterResources ||
+		existing.MaxConcurrentSyncs != cluster.MaxConcurrentSyncs ||
+		!reflect.DeepEqual(existing.Shard, cluster.Shard) ||
+		!reflect.DeepEqual(existing.Config, cluster.Config)
+}
+
+func hasFinalizer(finalizers []string) bool {
+	for _, finalizer := range finalizers {
+		if finalizer == common.RegistrationFinalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string) []string {
+	var res []string
+	for _, finalizer := range finalizers {
+		if finalizer != common.RegistrationFinalizerName {
+			res = append(res, finalizer)
+		}
+	}
+	return res
+}
+
+func isNotFound(err error) bool {
+	if errorStatus, ok := status.FromError(err); ok && errorStatus.Code() == codes.NotFound {
+		return true
+	}
+	return apierr.IsNotFound(err)
+}
@@ -0,0 +1,288 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// newFakeController returns a controller and its settings manager, whose informers need to be resynced to pick up the
+// secrets the controller creates
+func newFakeController(kubeObjects []runtime.Object, objects ...runtime.Object) (*RegistrationController, *settings.SettingsManager) {
+	kubeClient := fake.NewSimpleClientset(append(kubeObjects, test.NewFakeConfigMap(), test.NewFakeSecret())...)
+	settingsMgr := settings.NewSettingsManager(context.Background(), kubeClient, test.FakeArgoCDNamespace)
+	ctrl := NewRegistrationController(
+		test.FakeArgoCDNamespace,
+		settingsMgr,
+		kubeClient,
+		appclientset.NewSimpleClientset(objects...),
+		0,
+	)
+	ctrl.testRepo = func(repo *appv1.Repository) error { return nil }
+	ctrl.testCluster = func(cluster *appv1.Cluster) error { return nil }
+	return ctrl, settingsMgr
+}
+
+func newFakeSecret(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: test.FakeArgoCDNamespace}, Data: map[string][]byte{}}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func assertCondition(t *testing.T, regStatus appv1.RegistrationStatus, conditionType string, conditionStatus string, message string) {
+	condition := regStatus.GetCondition(conditionType)
+	if assert.NotNil(t, condition, conditionType) {
+		assert.Equal(t, conditionStatus, condition.Status, conditionType)
+		assert.Contains(t, condition.Message, message, conditionType)
+		assert.NotNil(t, condition.LastTransitionTime, conditionType)
+	}
+}
+
+func TestReconcileRepository(t *testing.T) {
+	reg := &appv1.RepositoryRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: test.FakeArgoCDNamespace},
+		Spec:       appv1.RepositoryRegistrationSpec{Repo: "https://github.com/argoproj/argocd-example-apps.git", CredentialsSecret: "guestbook-creds"},
+	}
+	ctrl, settingsMgr := newFakeController([]runtime.Object{newFakeSecret("guestbook-creds", map[string]string{"username": "admin", "password": "secret"})}, reg)
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().RepositoryRegistrations(test.FakeArgoCDNamespace)
+	reconcile := func(name string) *appv1.RepositoryRegistration {
+		reg, err := regIf.Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, ctrl.reconcileRepository(reg))
+		reg, err = regIf.Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, settingsMgr.ResyncInformers())
+		return reg
+	}
+
+	reg = reconcile("guestbook")
+	assert.Equal(t, []string{common.RegistrationFinalizerName}, reg.Finalizers)
+	assert.Equal(t, reg.Spec.Repo, reg.Status.Registered)
+	assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+	assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "is accessible")
+	repo, err := ctrl.db.GetRepository(context.Background(), reg.Spec.Repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", repo.Username)
+	assert.Equal(t, "secret", repo.Password)
+
+	t.Run("ConnectionFailed", func(t *testing.T) {
+		ctrl.testRepo = func(repo *appv1.Repository) error { return errors.New("authentication required") }
+		defer func() { ctrl.testRepo = func(repo *appv1.Repository) error { return nil } }()
+		reg := reconcile("guestbook")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusFalse, "authentication required")
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		reg, err := regIf.Get("guestbook", metav1.GetOptions{})
+		assert.NoError(t, err)
+		reg.Spec.EnableLFS = true
+		_, err = regIf.Update(reg)
+		assert.NoError(t, err)
+		reg = reconcile("guestbook")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "is accessible")
+		repo, err := ctrl.db.GetRepository(context.Background(), reg.Spec.Repo)
+		assert.NoError(t, err)
+		assert.True(t, repo.EnableLFS)
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		_, err := regIf.Create(&appv1.RepositoryRegistration{
+			ObjectMeta: metav1.ObjectMeta{Name: "duplicate", Namespace: test.FakeArgoCDNamespace},
+			Spec:       appv1.RepositoryRegistrationSpec{Repo: "https://github.com/argoproj/argocd-example-apps"},
+		})
+		assert.NoError(t, err)
+		reg := reconcile("duplicate")
+		assert.Empty(t, reg.Status.Registered)
+		assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusFalse, "already registered otherwise")
+		assert.Nil(t, reg.Status.GetCondition(appv1.RegistrationConditionConnected))
+	})
+
+	t.Run("MissingSecret", func(t *testing.T) {
+		_, err := regIf.Create(&appv1.RepositoryRegistration{
+			ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: test.FakeArgoCDNamespace},
+			Spec:       appv1.RepositoryRegistrationSpec{Repo: "https://github.com/argoproj/private.git", CredentialsSecret: "missing"},
+		})
+		assert.NoError(t, err)
+		reg := reconcile("private")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusFalse, "failed to get the credentials secret missing")
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		reg, err := regIf.Get("guestbook", metav1.GetOptions{})
+		assert.NoError(t, err)
+		now := metav1.Now()
+		reg.DeletionTimestamp = &now
+		assert.NoError(t, ctrl.reconcileRepository(reg))
+		assert.NoError(t, settingsMgr.ResyncInformers())
+		reg, err = regIf.Get("guestbook", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, reg.Finalizers)
+		repos, err := ctrl.db.ListRepositories(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, repos)
+	})
+}
+
+// failFirstStatusUpdate fails the first update of a registration which reports the conditions of the registration
+func failFirstStatusUpdate(ctrl *RegistrationController, resource string) {
+	failed := false
+	ctrl.applicationClientset.(*appclientset.Clientset).PrependReactor("update", resource, func(action kubetesting.Action) (bool, runtime.Object, error) {
+		var conditions []appv1.RegistrationCondition
+		switch reg := action.(kubetesting.UpdateAction).GetObject().(type) {
+		case *appv1.RepositoryRegistration:
+			conditions = reg.Status.Conditions
+		case *appv1.ClusterRegistration:
+			conditions = reg.Status.Conditions
+		}
+		if failed || len(conditions) == 0 {
+			return false, nil, nil
+		}
+		failed = true
+		return true, nil, errors.New("the object has been modified")
+	})
+}
+
+func TestReconcileRepositoryStatusUpdateFailed(t *testing.T) {
+	reg := &appv1.RepositoryRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: test.FakeArgoCDNamespace},
+		Spec:       appv1.RepositoryRegistrationSpec{Repo: "https://github.com/argoproj/argocd-example-apps.git"},
+	}
+	ctrl, settingsMgr := newFakeController(nil, reg)
+	failFirstStatusUpdate(ctrl, "repositoryregistrations")
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().RepositoryRegistrations(test.FakeArgoCDNamespace)
+
+	assert.Error(t, ctrl.reconcileRepository(reg))
+	assert.NoError(t, settingsMgr.ResyncInformers())
+	_, err := ctrl.db.GetRepository(context.Background(), reg.Spec.Repo)
+	assert.NoError(t, err)
+
+	// the repository the registration created is adopted on retry
+	reg, err = regIf.Get("guestbook", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, ctrl.reconcileRepository(reg))
+	reg, err = regIf.Get("guestbook", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, reg.Spec.Repo, reg.Status.Registered)
+	assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+}
+
+func TestReconcileCluster(t *testing.T) {
+	shard := int64(1)
+	reg := &appv1.ClusterRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: test.FakeArgoCDNamespace},
+		Spec:       appv1.ClusterRegistrationSpec{Server: "https://production.example.com", Shard: &shard, ConfigSecret: "production-config"},
+	}
+	ctrl, settingsMgr := newFakeController([]runtime.Object{newFakeSecret("production-config", map[string]string{"config": `{"bearerToken": "token"}`})}, reg)
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().ClusterRegistrations(test.FakeArgoCDNamespace)
+	reconcile := func(name string) *appv1.ClusterRegistration {
+		reg, err := regIf.Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, ctrl.reconcileCluster(reg))
+		reg, err = regIf.Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, settingsMgr.ResyncInformers())
+		return reg
+	}
+
+	reg = reconcile("production")
+	assert.Equal(t, []string{common.RegistrationFinalizerName}, reg.Finalizers)
+	assert.Equal(t, reg.Spec.Server, reg.Status.Registered)
+	assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+	assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "is reachable")
+	cluster, err := ctrl.db.GetCluster(context.Background(), reg.Spec.Server)
+	assert.NoError(t, err)
+	assert.Equal(t, reg.Spec.Server, cluster.Name)
+	assert.Equal(t, "token", cluster.Config.BearerToken)
+	assert.Equal(t, &shard, cluster.Shard)
+
+	t.Run("ConnectionFailed", func(t *testing.T) {
+		ctrl.testCluster = func(cluster *appv1.Cluster) error { return errors.New("connection refused") }
+		defer func() { ctrl.testCluster = func(cluster *appv1.Cluster) error { return nil } }()
+		reg := reconcile("production")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusFalse, "connection refused")
+	})
+
+	t.Run("ServerChanged", func(t *testing.T) {
+		reg, err := regIf.Get("production", metav1.GetOptions{})
+		assert.NoError(t, err)
+		reg.Spec.Server = "https://prod.example.com"
+		reg.Spec.Namespaces = []string{"guestbook"}
+		_, err = regIf.Update(reg)
+		assert.NoError(t, err)
+		reg = reconcile("production")
+		assert.Equal(t, "https://prod.example.com", reg.Status.Registered)
+		assertCondition(t, reg.Status, appv1.RegistrationConditionConnected, appv1.RegistrationConditionStatusTrue, "is reachable")
+		_, err = ctrl.db.GetCluster(context.Background(), "https://production.example.com")
+		assert.Error(t, err)
+		cluster, err := ctrl.db.GetCluster(context.Background(), "https://prod.example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"guestbook"}, cluster.Namespaces)
+	})
+
+	t.Run("InCluster", func(t *testing.T) {
+		_, err := regIf.Create(&appv1.ClusterRegistration{
+			ObjectMeta: metav1.ObjectMeta{Name: "in-cluster", Namespace: test.FakeArgoCDNamespace},
+			Spec:       appv1.ClusterRegistrationSpec{Server: common.KubernetesInternalAPIServerAddr, Name: "in-cluster", Namespaces: []string{"guestbook"}},
+		})
+		assert.NoError(t, err)
+		reg := reconcile("in-cluster")
+		assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+		cluster, err := ctrl.db.GetCluster(context.Background(), common.KubernetesInternalAPIServerAddr)
+		assert.NoError(t, err)
+		assert.Equal(t, "in-cluster", cluster.Name)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		reg, err := regIf.Get("production", metav1.GetOptions{})
+		assert.NoError(t, err)
+		now := metav1.Now()
+		reg.DeletionTimestamp = &now
+		assert.NoError(t, ctrl.reconcileCluster(reg))
+		assert.NoError(t, settingsMgr.ResyncInformers())
+		reg, err = regIf.Get("production", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, reg.Finalizers)
+		_, err = ctrl.db.GetCluster(context.Background(), "https://prod.example.com")
+		assert.Error(t, err)
+	})
+}
+
+func TestReconcileClusterStatusUpdateFailed(t *testing.T) {
+	reg := &appv1.ClusterRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: test.FakeArgoCDNamespace},
+		Spec:       appv1.ClusterRegistrationSpec{Server: "https://production.example.com"},
+	}
+	ctrl, settingsMgr := newFakeController(nil, reg)
+	failFirstStatusUpdate(ctrl, "clusterregistrations")
+	regIf := ctrl.applicationClientset.ArgoprojV1alpha1().ClusterRegistrations(test.FakeArgoCDNamespace)
+
+	assert.Error(t, ctrl.reconcileCluster(reg))
+	assert.NoError(t, settingsMgr.ResyncInformers())
+	_, err := ctrl.db.GetCluster(context.Background(), reg.Spec.Server)
+	assert.NoError(t, err)
+
+	// the cluster the registration created is adopted on retry
+	reg, err = regIf.Get("production", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, ctrl.reconcileCluster(reg))
+	reg, err = regIf.Get("production", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, reg.Spec.Server, reg.Status.Registered)
+	assertCondition(t, reg.Status, appv1.RegistrationConditionRegistered, appv1.RegistrationConditionStatusTrue, "is registered")
+}
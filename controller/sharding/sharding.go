@@ -0,0 +1,66 @@
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// GetReplicas returns the number of replicas of the application controller the clusters are sharded across
+func GetReplicas() (int, error) {
+	value := os.Getenv(common.EnvControllerReplicas)
+	if value == "" {
+		return 1, nil
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil || replicas < 1 {
+		return 0, fmt.Errorf("invalid %s '%s': must be a positive number", common.EnvControllerReplicas, value)
+	}
+	return replicas, nil
+}
+
+// InferShard returns the shard of the application controller replica, which is either set explicitly or is the
+// ordinal suffix of the host name of a replica of a StatefulSet, e.g. 2 for argocd-application-controller-2
+func InferShard(replicas int) (int, error) {
+	value := os.Getenv(common.EnvControllerShard)
+	source := common.EnvControllerShard
+	if value == "" {
+		if replicas == 1 {
+			return 0, nil
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			return 0, err
+		}
+		value = hostname[strings.LastIndex(hostname, "-")+1:]
+		source = fmt.Sprintf("ordinal of host name %s", hostname)
+	}
+	shard, err := strconv.Atoi(value)
+	if err != nil || shard < 0 || shard >= replicas {
+		return 0, fmt.Errorf("invalid shard '%s' (%s): must be a number between 0 and %d", value, source, replicas-1)
+	}
+	return shard, nil
+}
+
+// GetClusterShard returns the shard of the application controller which manages a cluster: the shard assigned to the
+// cluster, or the one derived from the hash of its server URL
+func GetClusterShard(cluster *v1alpha1.Cluster, replicas int) int {
+	if cluster.Shard != nil && *cluster.Shard >= 0 && *cluster.Shard < int64(replicas) {
+		return int(*cluster.Shard)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cluster.Server))
+	return int(h.Sum32() % uint32(replicas))
+}
+
+// GetClusterFilter returns a filter of the clusters managed by an application controller shard
+func GetClusterFilter(replicas int, shard int) func(cluster *v1alpha1.Cluster) bool {
+	return func(cluster *v1alpha1.Cluster) bool {
+		return GetClusterShard(cluster, replicas) == shard
+	}
+}
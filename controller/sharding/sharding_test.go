@@ -0,0 +1,66 @@
+package sharding
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestGetClusterShard(t *testing.T) {
+	cluster := &v1alpha1.Cluster{Server: "https://kubernetes.default.svc"}
+	assert.Equal(t, 0, GetClusterShard(cluster, 1))
+	shard := GetClusterShard(cluster, 3)
+	assert.Equal(t, shard, GetClusterShard(cluster, 3))
+	assert.True(t, shard >= 0 && shard < 3)
+
+	assigned := int64(2)
+	cluster.Shard = &assigned
+	assert.Equal(t, 2, GetClusterShard(cluster, 3))
+	// the assigned shard is ignored if there are not enough replicas
+	assert.Equal(t, 0, GetClusterShard(cluster, 1))
+
+	filter := GetClusterFilter(3, 2)
+	assert.True(t, filter(cluster))
+	assert.False(t, GetClusterFilter(3, 1)(cluster))
+}
+
+func TestInferShard(t *testing.T) {
+	defer func() { _ = os.Unsetenv(common.EnvControllerShard) }()
+
+	shard, err := InferShard(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, shard)
+
+	_ = os.Setenv(common.EnvControllerShard, "2")
+	shard, err = InferShard(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, shard)
+
+	_, err = InferShard(2)
+	assert.Error(t, err)
+
+	_ = os.Setenv(common.EnvControllerShard, "first")
+	_, err = InferShard(3)
+	assert.Error(t, err)
+}
+
+func TestGetReplicas(t *testing.T) {
+	defer func() { _ = os.Unsetenv(common.EnvControllerReplicas) }()
+
+	replicas, err := GetReplicas()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replicas)
+
+	_ = os.Setenv(common.EnvControllerReplicas, "3")
+	replicas, err = GetReplicas()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, replicas)
+
+	_ = os.Setenv(common.EnvControllerReplicas, "0")
+	_, err = GetReplicas()
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/yudai/gojsondiff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
@@ -20,11 +21,14 @@ import (
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/argo"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 	"github.com/argoproj/argo-cd/util/db"
 	"github.com/argoproj/argo-cd/util/diff"
+	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/health"
 	hookutil "github.com/argoproj/argo-cd/util/hook"
 	kubeutil "github.com/argoproj/argo-cd/util/kube"
@@ -32,6 +36,7 @@ import (
 	"github.com/argoproj/argo-cd/util/resource/ignore"
 	"github.com/argoproj/argo-cd/util/settings"
 	"github.com/argoproj/argo-cd/util/stats"
+	"github.com/argoproj/argo-cd/util/tracing"
 )
 
 type managedResource struct {
@@ -60,7 +65,7 @@ type ResourceInfoProvider interface {
 
 // AppStateManager defines methods which allow to compare application spec and actual application state.
 type AppStateManager interface {
-	CompareAppState(app *v1alpha1.Application, project *appv1.AppProject, revision string, source v1alpha1.ApplicationSource, noCache bool, localObjects []string) *comparisonResult
+	CompareAppState(ctx context.Context, app *v1alpha1.Application, project *appv1.AppProject, revision string, source v1alpha1.ApplicationSource, noCache bool, localObjects []string) *comparisonResult
 	SyncAppState(app *v1alpha1.Application, state *v1alpha1.OperationState)
 }
 
@@ -72,6 +77,10 @@ type comparisonResult struct {
 	hooks            []*unstructured.Unstructured
 	diffNormalizer   diff.Normalizer
 	appSourceType    v1alpha1.ApplicationSourceType
+	// hideGeneratedSecretData is true if the data of secrets generated by controllers is excluded from diffs
+	hideGeneratedSecretData bool
+	// sourceOverrides are the parameter overrides of the .argocd-source files of the application, if any
+	sourceOverrides *v1alpha1.ApplicationSource
 	// timings maps phases of comparison to the duration it took to complete (for statistical purposes)
 	timings map[string]time.Duration
 }
@@ -93,13 +102,15 @@ type appStateManager struct {
 	settingsMgr    *settings.SettingsManager
 	appclientset   appclientset.Interface
 	projInformer   cache.SharedIndexInformer
+	appLister      applisters.ApplicationLister
 	kubectl        kubeutil.Kubectl
 	repoClientset  apiclient.Clientset
 	liveStateCache statecache.LiveStateCache
+	cache          *appstatecache.Cache
 	namespace      string
 }
 
-func (m *appStateManager) getRepoObjs(app *v1alpha1.Application, source v1alpha1.ApplicationSource, appLabelKey, revision string, noCache bool) ([]*unstructured.Unstructured, []*unstructured.Unstructured, *apiclient.ManifestResponse, error) {
+func (m *appStateManager) getRepoObjs(ctx context.Context, app *v1alpha1.Application, source v1alpha1.ApplicationSource, appLabelKey, revision string, noCache bool, proj *v1alpha1.AppProject) ([]*unstructured.Unstructured, []*unstructured.Unstructured, *apiclient.ManifestResponse, error) {
 	ts := stats.NewTimingStats()
 	helmRepos, err := m.db.ListHelmRepositories(context.Background())
 	if err != nil {
@@ -147,7 +158,7 @@ func (m *appStateManager) getRepoObjs(app *v1alpha1.Application, source v1alpha1
 		}
 	}
 	ts.AddCheckpoint("version_ms")
-	manifestInfo, err := repoClient.GenerateManifest(context.Background(), &apiclient.ManifestRequest{
+	manifestInfo, err := repoClient.GenerateManifest(ctx, &apiclient.ManifestRequest{
 		Repo:              repo,
 		Repos:             helmRepos,
 		Revision:          revision,
@@ -160,8 +171,12 @@ func (m *appStateManager) getRepoObjs(app *v1alpha1.Application, source v1alpha1
 		KustomizeOptions: &appv1.KustomizeOptions{
 			BuildOptions: buildOptions,
 		},
-		KubeVersion: serverVersion,
-		ApiVersions: apiVersions,
+		KubeVersion:    serverVersion,
+		ApiVersions:    apiVersions,
+		ResolveSecrets: true,
+		DecryptSOPS:    proj.Spec.DecryptSOPS,
+		Limits:         proj.Spec.ManifestGenerationLimits,
+		VaultPaths:     proj.Spec.VaultPaths,
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -240,6 +255,44 @@ func DeduplicateTargetObjects(
 	return result, conditions, nil
 }
 
+// newDestinationApp returns a copy of an application which targets one of its destinations. The other destinations are
+// kept as the additional destinations of the copy, so that its comparison leaves out their resources.
+func newDestinationApp(app *v1alpha1.Application, destination v1alpha1.ApplicationDestination) *v1alpha1.Application {
+	destApp := app.DeepCopy()
+	destApp.Spec.Destination = destination
+	destApp.Spec.Destinations = nil
+	for _, other := range app.Spec.GetDestinations() {
+		if other != destination {
+			destApp.Spec.Destinations = append(destApp.Spec.Destinations, other)
+		}
+	}
+	return destApp
+}
+
+// filterOtherDestinationsLiveResources removes the live resources which belong to the other destinations of an
+// application on the same cluster, i.e. which are in the namespace of another destination and not in the target state.
+// Otherwise every destination would consider the resources of the others as extraneous and prune them.
+func filterOtherDestinationsLiveResources(app *v1alpha1.Application, targetObjs []*unstructured.Unstructured, liveObjsByKey map[kubeutil.ResourceKey]*unstructured.Unstructured) {
+	otherNamespaces := make(map[string]bool)
+	for _, destination := range app.Spec.Destinations {
+		if destination.Server == app.Spec.Destination.Server && destination.Namespace != app.Spec.Destination.Namespace {
+			otherNamespaces[destination.Namespace] = true
+		}
+	}
+	if len(otherNamespaces) == 0 {
+		return
+	}
+	targetKeys := make(map[kubeutil.ResourceKey]bool)
+	for i := range targetObjs {
+		targetKeys[kubeutil.GetResourceKey(targetObjs[i])] = true
+	}
+	for key := range liveObjsByKey {
+		if key.Namespace != "" && otherNamespaces[key.Namespace] && !targetKeys[key] {
+			delete(liveObjsByKey, key)
+		}
+	}
+}
+
 // dedupLiveResources handles removes live resource duplicates with the same UID. Duplicates are created in a separate resource groups.
 // E.g. apps/Deployment produces duplicate in extensions/Deployment, authorization.openshift.io/ClusterRole produces duplicate in rbac.authorization.k8s.io/ClusterRole etc.
 // The method removes such duplicates unless it was defined in git ( exists in target resources list ). At least one duplicate stays.
@@ -299,9 +352,16 @@ func (m *appStateManager) getComparisonSettings(app *appv1.Application) (string,
 // CompareAppState compares application git state to the live app state, using the specified
 // revision and supplied source. If revision or overrides are empty, then compares against
 // revision and overrides in the app spec.
-func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *appv1.AppProject, revision string, source v1alpha1.ApplicationSource, noCache bool, localManifests []string) *comparisonResult {
+func (m *appStateManager) CompareAppState(ctx context.Context, app *v1alpha1.Application, project *appv1.AppProject, revision string, source v1alpha1.ApplicationSource, noCache bool, localManifests []string) *comparisonResult {
 	ts := stats.NewTimingStats()
+	ctx, span := tracing.StartSpanFromContext(ctx, "compare app state")
+	span.SetBaggageItem("application", app.Name)
+	defer span.Finish()
 	appLabelKey, resourceOverrides, diffNormalizer, resFilter, err := m.getComparisonSettings(app)
+	var hideGeneratedSecretData bool
+	if err == nil {
+		hideGeneratedSecretData, err = m.settingsMgr.GetHideGeneratedSecretData()
+	}
 	ts.AddCheckpoint("settings_ms")
 
 	// return unknown comparison result if basic comparison settings cannot be loaded
@@ -320,6 +380,9 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 	conditions := make([]v1alpha1.ApplicationCondition, 0)
 
 	logCtx := log.WithField("application", app.Name)
+	if correlationID := grpc_util.CorrelationID(ctx); correlationID != "" {
+		logCtx = logCtx.WithField(grpc_util.CorrelationIDLogField, correlationID)
+	}
 	logCtx.Infof("Comparing app state (cluster: %s, namespace: %s)", app.Spec.Destination.Server, app.Spec.Destination.Namespace)
 
 	var targetObjs []*unstructured.Unstructured
@@ -328,7 +391,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 	now := metav1.Now()
 
 	if len(localManifests) == 0 {
-		targetObjs, hooks, manifestInfo, err = m.getRepoObjs(app, source, appLabelKey, revision, noCache)
+		targetObjs, hooks, manifestInfo, err = m.getRepoObjs(ctx, app, source, appLabelKey, revision, noCache, project)
 		if err != nil {
 			targetObjs = make([]*unstructured.Unstructured, 0)
 			conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: err.Error(), LastTransitionTime: &now})
@@ -364,6 +427,19 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 	}
 	ts.AddCheckpoint("dedup_ms")
 
+	if err := project.ValidateResourceQuota(len(targetObjs)); err != nil {
+		conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionQuotaExceededError, Message: err.Error(), LastTransitionTime: &now})
+	}
+	// the application and destination quotas are validated by the API server, but apps may be created without it
+	if project.Spec.Quota != nil {
+		apps, err := m.appLister.List(labels.Everything())
+		if err != nil {
+			conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: err.Error(), LastTransitionTime: &now})
+		} else if err := project.ValidateAdmittedApplicationQuota(app, apps); err != nil {
+			conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionQuotaExceededError, Message: err.Error(), LastTransitionTime: &now})
+		}
+	}
+
 	liveObjByKey, err := m.liveStateCache.GetManagedLiveObjs(app, targetObjs)
 	if err != nil {
 		liveObjByKey = make(map[kubeutil.ResourceKey]*unstructured.Unstructured)
@@ -371,6 +447,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 		failedToLoadObjs = true
 	}
 	dedupLiveResources(targetObjs, liveObjByKey)
+	filterOtherDestinationsLiveResources(app, targetObjs, liveObjByKey)
 	// filter out all resources which are not permitted in the application project
 	for k, v := range liveObjByKey {
 		if !project.IsLiveResourcePermitted(v, app.Spec.Destination.Server) {
@@ -417,7 +494,30 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 	}
 
 	// Do the actual comparison
-	diffResults, err := diff.DiffArray(targetObjs, managedLiveObj, diffNormalizer)
+	_, diffSpan := tracing.StartSpanFromContext(ctx, "diff")
+	diffTargetObjs, diffLiveObjs := targetObjs, managedLiveObj
+	if hideGeneratedSecretData {
+		// the data of generated secrets is compared neither here nor when syncing, so the objects to sync are unchanged
+		diffTargetObjs = make([]*unstructured.Unstructured, len(targetObjs))
+		diffLiveObjs = make([]*unstructured.Unstructured, len(managedLiveObj))
+		for i := range targetObjs {
+			diffTargetObjs[i], diffLiveObjs[i] = argo.HideGeneratedSecretData(targetObjs[i], managedLiveObj[i])
+		}
+	}
+	// the diffs of an unchanged state are reused, unless a hard refresh is requested
+	diffKey := diffCacheKey(app, project, source, manifestInfo, appLabelKey, resourceOverrides, hideGeneratedSecretData, targetObjs, managedLiveObj)
+	var diffResults *diff.DiffResultList
+	if !noCache {
+		diffResults = m.getCachedDiffs(app, diffKey, len(targetObjs))
+	}
+	if diffResults != nil {
+		err = nil
+		diffSpan.SetBaggageItem("cached", true)
+	} else if diffResults, err = diff.DiffArray(diffTargetObjs, diffLiveObjs, diffNormalizer); err == nil {
+		m.setCachedDiffs(app, diffKey, diffResults)
+	}
+	diffSpan.SetError(err)
+	diffSpan.Finish()
 	if err != nil {
 		diffResults = &diff.DiffResultList{}
 		failedToLoadObjs = true
@@ -519,26 +619,33 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *ap
 		return !isSelfReferencedApp(app, kubeutil.GetObjectRef(obj))
 	})
 
+	if err != nil {
+		conditions = append(conditions, appv1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: err.Error(), LastTransitionTime: &now})
+	}
+	healthStatus, err = health.SetProgressingDeadlines(resourceSummaries, app.Status.Resources, GetLiveObjs(managedResources), resourceOverrides, healthStatus, now)
 	if err != nil {
 		conditions = append(conditions, appv1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: err.Error(), LastTransitionTime: &now})
 	}
 
 	compRes := comparisonResult{
-		syncStatus:       &syncStatus,
-		healthStatus:     healthStatus,
-		resources:        resourceSummaries,
-		managedResources: managedResources,
-		hooks:            hooks,
-		diffNormalizer:   diffNormalizer,
+		syncStatus:              &syncStatus,
+		healthStatus:            healthStatus,
+		resources:               resourceSummaries,
+		managedResources:        managedResources,
+		hooks:                   hooks,
+		diffNormalizer:          diffNormalizer,
+		hideGeneratedSecretData: hideGeneratedSecretData,
 	}
 	if manifestInfo != nil {
 		compRes.appSourceType = v1alpha1.ApplicationSourceType(manifestInfo.SourceType)
+		compRes.sourceOverrides = manifestInfo.SourceOverrides
 	}
 	app.Status.SetConditions(conditions, map[appv1.ApplicationConditionType]bool{
 		appv1.ApplicationConditionComparisonError:         true,
 		appv1.ApplicationConditionSharedResourceWarning:   true,
 		appv1.ApplicationConditionRepeatedResourceWarning: true,
 		appv1.ApplicationConditionExcludedResourceWarning: true,
+		appv1.ApplicationConditionQuotaExceededError:      true,
 	})
 	ts.AddCheckpoint("health_ms")
 	compRes.timings = ts.Timings()
@@ -550,7 +657,8 @@ func (m *appStateManager) persistRevisionHistory(app *v1alpha1.Application, revi
 	if len(app.Status.History) > 0 {
 		nextID = app.Status.History[len(app.Status.History)-1].ID + 1
 	}
-	app.Status.History = append(app.Status.History, v1alpha1.RevisionHistory{
+	// the history is copied since the sources of older entries are compressed in place below
+	app.Status.History = append(app.Status.History.DeepCopy(), v1alpha1.RevisionHistory{
 		Revision:   revision,
 		DeployedAt: metav1.NewTime(time.Now().UTC()),
 		ID:         nextID,
@@ -558,6 +666,11 @@ func (m *appStateManager) persistRevisionHistory(app *v1alpha1.Application, revi
 	})
 
 	app.Status.History = app.Status.History.Trunc(app.Spec.GetRevisionHistoryLimit())
+	// only the newest entries keep their source uncompressed, so frequently synced apps with large sources (e.g. helm
+	// values) do not grow the status of the application unnecessarily
+	if err := app.Status.History.CompressSources(common.RevisionHistoryFullSourceLimit); err != nil {
+		return err
+	}
 
 	patch, err := json.Marshal(map[string]map[string][]v1alpha1.RevisionHistory{
 		"status": {
@@ -581,10 +694,13 @@ func NewAppStateManager(
 	settingsMgr *settings.SettingsManager,
 	liveStateCache statecache.LiveStateCache,
 	projInformer cache.SharedIndexInformer,
+	appLister applisters.ApplicationLister,
 	metricsServer *metrics.MetricsServer,
+	appStateCache *appstatecache.Cache,
 ) AppStateManager {
 	return &appStateManager{
 		liveStateCache: liveStateCache,
+		cache:          appStateCache,
 		db:             db,
 		appclientset:   appclientset,
 		kubectl:        kubectl,
@@ -592,6 +708,7 @@ func NewAppStateManager(
 		namespace:      namespace,
 		settingsMgr:    settingsMgr,
 		projInformer:   projInformer,
+		appLister:      appLister,
 		metricsServer:  metricsServer,
 	}
 }
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/test"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 	"github.com/argoproj/argo-cd/util/kube"
 )
 
@@ -30,7 +32,7 @@ func TestCompareAppStateEmpty(t *testing.T) {
 		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 	assert.NotNil(t, compRes)
 	assert.NotNil(t, compRes.syncStatus)
 	assert.Equal(t, argoappv1.SyncStatusCodeSynced, compRes.syncStatus.Status)
@@ -53,7 +55,7 @@ func TestCompareAppStateMissing(t *testing.T) {
 		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 	assert.NotNil(t, compRes)
 	assert.NotNil(t, compRes.syncStatus)
 	assert.Equal(t, argoappv1.SyncStatusCodeOutOfSync, compRes.syncStatus.Status)
@@ -62,6 +64,68 @@ func TestCompareAppStateMissing(t *testing.T) {
 	assert.Len(t, app.Status.Conditions, 0)
 }
 
+// TestCompareAppStateQuotaExceeded tests when the app has more resources than its project permits
+func TestCompareAppStateQuotaExceeded(t *testing.T) {
+	app := newFakeApp()
+	data := fakeData{
+		apps: []runtime.Object{app},
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{test.PodManifest},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+	}
+	ctrl := newFakeController(&data)
+	proj := defaultProj.DeepCopy()
+	proj.Spec.Quota = &argoappv1.ProjectQuota{MaxResourcesPerApplication: 1}
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, proj, "", app.Spec.Source, false, nil)
+	assert.NotNil(t, compRes)
+	assert.Len(t, app.Status.Conditions, 0)
+
+	data.manifestResponse.Manifests = []string{test.PodManifest, test.ServiceManifest}
+	ctrl = newFakeController(&data)
+	compRes = ctrl.appStateManager.CompareAppState(context.Background(), app, proj, "", app.Spec.Source, false, nil)
+	assert.NotNil(t, compRes)
+	conditions := app.Status.GetConditions(map[argoappv1.ApplicationConditionType]bool{argoappv1.ApplicationConditionQuotaExceededError: true})
+	assert.Len(t, conditions, 1)
+}
+
+// TestCompareAppStateApplicationQuotaExceeded tests when the app has been created after its project reached the quota
+func TestCompareAppStateApplicationQuotaExceeded(t *testing.T) {
+	app := newFakeApp()
+	app.CreationTimestamp = metav1.Unix(1, 0)
+	otherApp := newFakeApp()
+	otherApp.Name = "other-app"
+	otherApp.CreationTimestamp = metav1.Unix(2, 0)
+	data := fakeData{
+		apps: []runtime.Object{app, otherApp},
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+	}
+	ctrl := newFakeController(&data)
+	proj := defaultProj.DeepCopy()
+	proj.Spec.Quota = &argoappv1.ProjectQuota{MaxApplications: 1}
+	quotaConditions := map[argoappv1.ApplicationConditionType]bool{argoappv1.ApplicationConditionQuotaExceededError: true}
+
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, proj, "", app.Spec.Source, false, nil)
+	assert.NotNil(t, compRes)
+	assert.Len(t, app.Status.GetConditions(quotaConditions), 0)
+
+	compRes = ctrl.appStateManager.CompareAppState(context.Background(), otherApp, proj, "", otherApp.Spec.Source, false, nil)
+	assert.NotNil(t, compRes)
+	conditions := otherApp.Status.GetConditions(quotaConditions)
+	if assert.Len(t, conditions, 1) {
+		assert.Equal(t, "project 'default' is limited to 1 applications", conditions[0].Message)
+	}
+}
+
 // TestCompareAppStateExtra tests when there is an extra object in live but not defined in git
 func TestCompareAppStateExtra(t *testing.T) {
 	pod := test.NewPod()
@@ -80,7 +144,7 @@ func TestCompareAppStateExtra(t *testing.T) {
 		},
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 	assert.NotNil(t, compRes)
 	assert.Equal(t, argoappv1.SyncStatusCodeOutOfSync, compRes.syncStatus.Status)
 	assert.Equal(t, 1, len(compRes.resources))
@@ -106,7 +170,7 @@ func TestCompareAppStateHook(t *testing.T) {
 		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 	assert.NotNil(t, compRes)
 	assert.Equal(t, argoappv1.SyncStatusCodeSynced, compRes.syncStatus.Status)
 	assert.Equal(t, 0, len(compRes.resources))
@@ -132,7 +196,7 @@ func TestCompareAppStateCompareOptionIgnoreExtraneous(t *testing.T) {
 	}
 	ctrl := newFakeController(&data)
 
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.NotNil(t, compRes)
 	assert.Equal(t, argoappv1.SyncStatusCodeSynced, compRes.syncStatus.Status)
@@ -160,7 +224,7 @@ func TestCompareAppStateExtraHook(t *testing.T) {
 		},
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.NotNil(t, compRes)
 	assert.Equal(t, argoappv1.SyncStatusCodeSynced, compRes.syncStatus.Status)
@@ -170,6 +234,56 @@ func TestCompareAppStateExtraHook(t *testing.T) {
 	assert.Equal(t, 0, len(app.Status.Conditions))
 }
 
+// TestCompareAppStateCachedDiffs tests that the diffs are reused until the live state changes
+func TestCompareAppStateCachedDiffs(t *testing.T) {
+	target := test.NewPod()
+	target.SetNamespace(test.FakeDestNamespace)
+	target.SetAnnotations(map[string]string{"foo": "bar"})
+	live := test.NewPod()
+	live.SetNamespace(test.FakeDestNamespace)
+	live.SetResourceVersion("1")
+	app := newFakeApp()
+	data := fakeData{
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{toJSON(t, target)},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+	}
+	ctrl := newFakeController(&data)
+	compare := func(noCache bool) argoappv1.SyncStatusCode {
+		// the comparison removes the managed resources from the live objects it got
+		data.managedLiveObjs[kube.GetResourceKey(live)] = live
+		return ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, noCache, nil).syncStatus.Status
+	}
+	assert.Equal(t, argoappv1.SyncStatusCodeOutOfSync, compare(false))
+
+	var cached appstatecache.AppDiffs
+	assert.NoError(t, ctrl.cache.GetAppDiffs(app.Name, app.Spec.Destination, &cached))
+	if assert.Len(t, cached.Diffs, 1) {
+		assert.True(t, cached.Diffs[0].Modified)
+	}
+
+	// tamper with the cached diff to tell whether it is used
+	cached.Diffs[0].Modified = false
+	cached.Diffs[0].PredictedLive = cached.Diffs[0].NormalizedLive
+	assert.NoError(t, ctrl.cache.SetAppDiffs(app.Name, app.Spec.Destination, &cached))
+	assert.Equal(t, argoappv1.SyncStatusCodeSynced, compare(false))
+
+	t.Run("HardRefresh", func(t *testing.T) {
+		assert.NoError(t, ctrl.cache.SetAppDiffs(app.Name, app.Spec.Destination, &cached))
+		assert.Equal(t, argoappv1.SyncStatusCodeOutOfSync, compare(true))
+	})
+
+	t.Run("LiveStateChanged", func(t *testing.T) {
+		assert.NoError(t, ctrl.cache.SetAppDiffs(app.Name, app.Spec.Destination, &cached))
+		live.SetResourceVersion("2")
+		assert.Equal(t, argoappv1.SyncStatusCodeOutOfSync, compare(false))
+	})
+}
+
 func toJSON(t *testing.T, obj *unstructured.Unstructured) string {
 	data, err := json.Marshal(obj)
 	assert.NoError(t, err)
@@ -197,7 +311,7 @@ func TestCompareAppStateDuplicatedNamespacedResources(t *testing.T) {
 		},
 	}
 	ctrl := newFakeController(&data)
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.NotNil(t, compRes)
 	assert.Equal(t, 1, len(app.Status.Conditions))
@@ -248,7 +362,7 @@ func TestSetHealth(t *testing.T) {
 		},
 	})
 
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.Equal(t, compRes.healthStatus.Status, argoappv1.HealthStatusHealthy)
 }
@@ -280,7 +394,7 @@ func TestSetHealthSelfReferencedApp(t *testing.T) {
 		},
 	})
 
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.Equal(t, compRes.healthStatus.Status, argoappv1.HealthStatusHealthy)
 }
@@ -350,7 +464,7 @@ func TestReturnUnknownComparisonStateOnSettingLoadError(t *testing.T) {
 		},
 	})
 
-	compRes := ctrl.appStateManager.CompareAppState(app, &defaultProj, "", app.Spec.Source, false, nil)
+	compRes := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
 
 	assert.Equal(t, argoappv1.HealthStatusUnknown, compRes.healthStatus.Status)
 	assert.Equal(t, argoappv1.SyncStatusCodeUnknown, compRes.syncStatus.Status)
@@ -438,3 +552,35 @@ func Test_appStateManager_persistRevisionHistory(t *testing.T) {
 	addHistory()
 	assert.Len(t, app.Status.History, 9)
 }
+
+func TestAppRevisionHistoryCompression(t *testing.T) {
+	app := newFakeApp()
+	app.Status.History = nil
+	ctrl := newFakeController(&fakeData{
+		apps: []runtime.Object{app},
+	})
+	manager := ctrl.appStateManager.(*appStateManager)
+	source := argoappv1.ApplicationSource{
+		RepoURL: "https://github.com/argoproj/argocd-example-apps",
+		Path:    "helm-guestbook",
+		Helm:    &argoappv1.ApplicationSourceHelm{Values: "replicaCount: 2"},
+	}
+	for i := 0; i < common.RevisionHistoryFullSourceLimit+2; i++ {
+		err := manager.persistRevisionHistory(app, "my-revision", source)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, app.Status.History, common.RevisionHistoryFullSourceLimit+2)
+	for i, h := range app.Status.History {
+		if i < 2 {
+			assert.NotNil(t, h.CompressedSource)
+			assert.Nil(t, h.Source.Helm)
+			assert.Equal(t, source.RepoURL, h.Source.RepoURL)
+		} else {
+			assert.Nil(t, h.CompressedSource)
+			assert.Equal(t, source, h.Source)
+		}
+		fullSource, err := h.FullSource()
+		assert.NoError(t, err)
+		assert.Equal(t, source, fullSource)
+	}
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-cd/controller/metrics"
+)
+
+// trackedQueue is a work queue which tracks since when its items are waiting to be processed, so that the age of the
+// oldest item can be reported. Only items which are ready to be processed are tracked: items added after a delay or
+// a rate limit are tracked once they are added without delay.
+type trackedQueue struct {
+	workqueue.RateLimitingInterface
+	name string
+
+	lock  sync.Mutex
+	added map[interface{}]time.Time
+}
+
+func newTrackedQueue(name string) *trackedQueue {
+	return &trackedQueue{
+		RateLimitingInterface: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), name),
+		name:                  name,
+		added:                 make(map[interface{}]time.Time),
+	}
+}
+
+func (q *trackedQueue) Add(item interface{}) {
+	q.lock.Lock()
+	if _, ok := q.added[item]; !ok {
+		q.added[item] = time.Now()
+	}
+	q.lock.Unlock()
+	q.RateLimitingInterface.Add(item)
+}
+
+func (q *trackedQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *trackedQueue) Get() (interface{}, bool) {
+	item, shutdown := q.RateLimitingInterface.Get()
+	q.lock.Lock()
+	delete(q.added, item)
+	q.lock.Unlock()
+	return item, shutdown
+}
+
+// stats returns the depth of the queue and the age of its oldest tracked item
+func (q *trackedQueue) stats() metrics.QueueStats {
+	stats := metrics.QueueStats{Name: q.name, Depth: q.Len()}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for _, added := range q.added {
+		if age := time.Since(added).Seconds(); age > stats.OldestItemAgeSeconds {
+			stats.OldestItemAgeSeconds = age
+		}
+	}
+	return stats
+}
+
+// GetStats returns a snapshot of the load of the controller: the number of applications and pending operations of its
+// shard, the work queues and the cluster caches
+func (ctrl *ApplicationController) GetStats() metrics.ControllerStats {
+	stats := metrics.ControllerStats{Shard: ctrl.shard, Clusters: ctrl.stateCache.GetClustersInfo()}
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list applications: %v", err)
+	}
+	for _, app := range apps {
+		if !ctrl.canProcessApp(app) {
+			continue
+		}
+		stats.Applications++
+		if app.Operation != nil {
+			stats.PendingOperations++
+		}
+	}
+	for _, queue := range []*trackedQueue{ctrl.appRefreshQueue, ctrl.appOperationQueue, ctrl.appComparisonTypeRefreshQueue} {
+		stats.Queues = append(stats.Queues, queue.stats())
+	}
+	return stats
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mockstatecache "github.com/argoproj/argo-cd/controller/cache/mocks"
+	"github.com/argoproj/argo-cd/controller/metrics"
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestTrackedQueue(t *testing.T) {
+	q := newTrackedQueue("test_queue")
+	defer q.ShutDown()
+	assert.Equal(t, 0.0, q.stats().OldestItemAgeSeconds)
+
+	q.Add("argocd/app-1")
+	q.AddAfter("argocd/app-2", time.Hour)
+	q.AddAfter("argocd/app-3", 0)
+	q.added["argocd/app-1"] = time.Now().Add(-time.Minute)
+	stats := q.stats()
+	assert.Equal(t, "test_queue", stats.Name)
+	assert.Equal(t, 2, stats.Depth)
+	assert.InDelta(t, 60, stats.OldestItemAgeSeconds, 1)
+
+	item, _ := q.Get()
+	assert.Equal(t, "argocd/app-1", item)
+	q.Done(item)
+	assert.Less(t, q.stats().OldestItemAgeSeconds, 1.0)
+}
+
+func TestGetStats(t *testing.T) {
+	app := newFakeApp()
+	app.Operation = &argoappv1.Operation{Sync: &argoappv1.SyncOperation{}}
+	otherApp := newFakeApp()
+	otherApp.Name = "other-app"
+	otherApp.Operation = nil
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, otherApp}})
+	clusters := []metrics.ClusterInfo{{Server: "https://localhost:6443", ResourcesCount: 10}}
+	ctrl.stateCache.(*mockstatecache.LiveStateCache).On("GetClustersInfo").Return(clusters)
+	ctrl.appRefreshQueue.Add("argocd/refreshed-app")
+
+	stats := ctrl.GetStats()
+	assert.Equal(t, 2, stats.Applications)
+	assert.Equal(t, 1, stats.PendingOperations)
+	assert.Equal(t, clusters, stats.Clusters)
+	assert.Len(t, stats.Queues, 3)
+	assert.Equal(t, "app_reconciliation_queue", stats.Queues[0].Name)
+	assert.Equal(t, ctrl.appRefreshQueue.Len(), stats.Queues[0].Depth)
+	assert.Greater(t, stats.Queues[0].OldestItemAgeSeconds, 0.0)
+}
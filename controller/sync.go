@@ -28,11 +28,13 @@ import (
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	listersv1alpha1 "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util/argo"
+	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/health"
 	"github.com/argoproj/argo-cd/util/hook"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/rand"
 	"github.com/argoproj/argo-cd/util/resource"
+	"github.com/argoproj/argo-cd/util/tracing"
 )
 
 const (
@@ -58,11 +60,28 @@ type syncContext struct {
 	syncResources       []v1alpha1.SyncOperationResource
 	opState             *v1alpha1.OperationState
 	log                 *log.Entry
+	// ctx contains the span of the sync operation
+	ctx context.Context
 	// lock to protect concurrent updates of the result list
 	lock sync.Mutex
 }
 
 func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha1.OperationState) {
+	ctx := grpc_util.ContextWithCorrelationID(context.Background(), state.Operation.CorrelationID)
+	ctx, span := tracing.StartSpanFromContext(ctx, "sync app state")
+	span.SetBaggageItem("application", app.Name)
+	defer span.Finish()
+
+	if len(app.Spec.Destinations) > 0 {
+		m.syncAppStateToDestinations(ctx, app, state)
+	} else {
+		m.syncAppState(ctx, app, state, true)
+	}
+}
+
+// syncAppState syncs an application to its destination. The sync is recorded in the history of the application if
+// recordHistory is true and the sync succeeded.
+func (m *appStateManager) syncAppState(ctx context.Context, app *v1alpha1.Application, state *v1alpha1.OperationState, recordHistory bool) {
 	// Sync requests might be requested with ambiguous revisions (e.g. master, HEAD, v1.2.3).
 	// This can change meaning when resuming operations (e.g a hook sync). After calculating a
 	// concrete git commit SHA, the SHA is remembered in the status.operationState.syncResult field.
@@ -107,19 +126,25 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha
 		revision = syncOp.Revision
 	}
 
-	proj, err := argo.GetAppProject(&app.Spec, listersv1alpha1.NewAppProjectLister(m.projInformer.GetIndexer()), m.namespace)
+	proj, err := argo.GetAppProject(&app.Spec, listersv1alpha1.NewAppProjectLister(m.projInformer.GetIndexer()), m.namespace, m.settingsMgr)
 	if err != nil {
 		state.Phase = v1alpha1.OperationError
 		state.Message = fmt.Sprintf("Failed to load application project: %v", err)
 		return
 	}
+	if msg := proj.SyncFreezeMessage(); msg != "" {
+		state.Phase = v1alpha1.OperationFailed
+		state.Message = msg
+		return
+	}
 
-	compareResult := m.CompareAppState(app, proj, revision, source, false, syncOp.Manifests)
+	compareResult := m.CompareAppState(ctx, app, proj, revision, source, false, syncOp.Manifests)
 
 	// If there are any comparison or spec errors error conditions do not perform the operation
 	if errConditions := app.Status.GetConditions(map[v1alpha1.ApplicationConditionType]bool{
-		v1alpha1.ApplicationConditionComparisonError:  true,
-		v1alpha1.ApplicationConditionInvalidSpecError: true,
+		v1alpha1.ApplicationConditionComparisonError:    true,
+		v1alpha1.ApplicationConditionInvalidSpecError:   true,
+		v1alpha1.ApplicationConditionQuotaExceededError: true,
 	}); len(errConditions) > 0 {
 		state.Phase = v1alpha1.OperationError
 		state.Message = argo.FormatAppConditions(errConditions)
@@ -137,7 +162,13 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha
 		return
 	}
 
-	restConfig := metrics.AddMetricsTransportWrapper(m.metricsServer, app, clst.RESTConfig())
+	restConfig := clst.RESTConfig()
+	if serviceAccount, ok := proj.GetDestinationServiceAccount(app.Spec.Destination); ok {
+		// apply the resources with the permissions of the service account configured for the destination
+		log.WithField("application", app.Name).Infof("Impersonating %s to sync", serviceAccount)
+		restConfig.Impersonate = rest.ImpersonationConfig{UserName: serviceAccount}
+	}
+	restConfig = metrics.AddMetricsTransportWrapper(m.metricsServer, app, restConfig)
 	dynamicIf, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
 		state.Phase = v1alpha1.OperationError
@@ -183,10 +214,11 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha
 		syncRes:             syncRes,
 		syncResources:       syncResources,
 		opState:             state,
-		log:                 log.WithFields(log.Fields{"application": app.Name, "syncId": syncId}),
+		log:                 withCorrelationID(log.WithFields(log.Fields{"application": app.Name, "syncId": syncId}), state.Operation),
 	}
 
 	start := time.Now()
+	syncCtx.ctx = ctx
 
 	if state.Phase == v1alpha1.OperationTerminating {
 		syncCtx.terminate()
@@ -196,7 +228,12 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha
 
 	syncCtx.log.WithField("duration", time.Since(start)).Info("sync/terminate complete")
 
-	if !syncOp.DryRun && !syncCtx.isSelectiveSync() && syncCtx.opState.Phase.Successful() {
+	if recordHistory && !syncOp.DryRun && !syncCtx.isSelectiveSync() && syncCtx.opState.Phase.Successful() {
+		// the history records the overrides of the .argocd-source files, so rollbacks restore them
+		if compareResult.sourceOverrides != nil {
+			source = *source.DeepCopy()
+			source.MergeOverrides(*compareResult.sourceOverrides)
+		}
 		err := m.persistRevisionHistory(app, compareResult.syncStatus.Revision, source)
 		if err != nil {
 			syncCtx.setOperationPhase(v1alpha1.OperationError, fmt.Sprintf("failed to record sync to history: %v", err))
@@ -204,6 +241,84 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, state *v1alpha
 	}
 }
 
+// syncAppStateToDestinations syncs an application to each of its destinations. The destinations are synced
+// independently, so a destination which is waiting for hooks or health doesn't hold back the others, and the operation
+// completes once every destination completed. All destinations are synced to the revision resolved for the first one.
+func (m *appStateManager) syncAppStateToDestinations(ctx context.Context, app *v1alpha1.Application, state *v1alpha1.OperationState) {
+	if state.Operation.Sync == nil {
+		state.Phase = v1alpha1.OperationFailed
+		state.Message = "Invalid operation request: no operation specified"
+		return
+	}
+	syncOp := *state.Operation.Sync
+
+	destinations := app.Spec.GetDestinations()
+	if len(state.Destinations) != len(destinations) {
+		state.Destinations = make([]v1alpha1.DestinationOperationState, len(destinations))
+		for i := range destinations {
+			state.Destinations[i] = v1alpha1.DestinationOperationState{Destination: destinations[i], Phase: v1alpha1.OperationRunning}
+		}
+	}
+
+	for i := range state.Destinations {
+		destState := &state.Destinations[i]
+		if destState.Phase.Completed() {
+			continue
+		}
+		if primary := state.Destinations[0].SyncResult; i > 0 && destState.SyncResult == nil && primary != nil {
+			destState.SyncResult = &v1alpha1.SyncOperationResult{Revision: primary.Revision, Source: primary.Source}
+		}
+		destApp := newDestinationApp(app, destState.Destination)
+		destOpState := &v1alpha1.OperationState{
+			Operation:  state.Operation,
+			Phase:      destState.Phase,
+			Message:    destState.Message,
+			SyncResult: destState.SyncResult,
+			StartedAt:  state.StartedAt,
+		}
+		if state.Phase == v1alpha1.OperationTerminating {
+			destOpState.Phase = v1alpha1.OperationTerminating
+		}
+		m.syncAppState(ctx, destApp, destOpState, false)
+		destState.Phase = destOpState.Phase
+		destState.Message = destOpState.Message
+		destState.SyncResult = destOpState.SyncResult
+	}
+	state.SyncResult = state.Destinations[0].SyncResult
+
+	completed := 0
+	var failures []string
+	for _, destState := range state.Destinations {
+		if !destState.Phase.Completed() {
+			continue
+		}
+		completed++
+		if !destState.Phase.Successful() {
+			failures = append(failures, fmt.Sprintf("%s/%s: %s", destState.Destination.Server, destState.Destination.Namespace, destState.Message))
+		}
+	}
+	switch {
+	case completed < len(state.Destinations):
+		if state.Phase != v1alpha1.OperationTerminating {
+			state.Phase = v1alpha1.OperationRunning
+		}
+		state.Message = fmt.Sprintf("%d of %d destinations completed", completed, len(state.Destinations))
+	case len(failures) > 0:
+		state.Phase = v1alpha1.OperationFailed
+		state.Message = fmt.Sprintf("sync failed for %d of %d destinations: %s", len(failures), len(state.Destinations), strings.Join(failures, "; "))
+	default:
+		state.Phase = v1alpha1.OperationSucceeded
+		state.Message = fmt.Sprintf("successfully synced %d destinations", len(state.Destinations))
+		if !syncOp.DryRun && len(syncOp.Resources) == 0 && state.SyncResult != nil {
+			err := m.persistRevisionHistory(app, state.SyncResult.Revision, state.SyncResult.Source)
+			if err != nil {
+				state.Phase = v1alpha1.OperationError
+				state.Message = fmt.Sprintf("failed to record sync to history: %v", err)
+			}
+		}
+	}
+}
+
 // sync has performs the actual apply or hook based sync
 func (sc *syncContext) sync() {
 	sc.log.WithFields(log.Fields{"isSelectiveSync": sc.isSelectiveSync(), "skipHooks": sc.skipHooks(), "started": sc.started()}).Info("syncing")
@@ -252,6 +367,11 @@ func (sc *syncContext) sync() {
 			}
 
 		} else {
+			// resources of later waves might depend on CRDs and webhooks the API server can't serve yet
+			if operationState, message := sc.getTaskReadiness(task); operationState != v1alpha1.OperationSucceeded {
+				sc.setResourceResult(task, task.syncStatus, operationState, message)
+				continue
+			}
 			// this must be calculated on the live object
 			healthStatus, err := health.GetResourceHealth(task.liveObj, sc.resourceOverrides)
 			if err == nil {
@@ -315,7 +435,14 @@ func (sc *syncContext) sync() {
 	sc.setOperationPhase(v1alpha1.OperationRunning, "one or more tasks are running")
 
 	sc.log.WithFields(log.Fields{"tasks": tasks}).Debug("wet-run")
+	_, span := tracing.StartSpanFromContext(sc.ctx, "sync wave")
+	span.SetBaggageItem("phase", phase)
+	span.SetBaggageItem("wave", wave)
 	runState := sc.runTasks(tasks, false)
+	if runState == failed {
+		span.SetError(fmt.Errorf("one or more objects failed to apply"))
+	}
+	span.Finish()
 	switch runState {
 	case failed:
 		sc.setOperationFailed(syncFailTasks, "one or more objects failed to apply")
@@ -751,6 +878,11 @@ func (sc *syncContext) runTasks(tasks syncTasks, dryRun bool) runState {
 					logCtx.Debug("applying")
 					validate := !(sc.syncOp.SyncOptions.HasOption("Validate=false") || resource.HasAnnotationOption(t.targetObj, common.AnnotationSyncOptions, "Validate=false"))
 					result, message := sc.applyObject(t.targetObj, dryRun, sc.syncOp.SyncStrategy.Force(), validate)
+					if result == v1alpha1.ResultCodeSynced && !dryRun && sc.promoteFull(t.targetObj) {
+						if err := sc.promoteFullRollout(t.targetObj); err != nil {
+							result, message = v1alpha1.ResultCodeSyncFailed, fmt.Sprintf("failed to fully promote rollout: %v", err)
+						}
+					}
 					if result == v1alpha1.ResultCodeSyncFailed {
 						logCtx.WithField("message", message).Info("apply failed")
 						runState = failed
@@ -807,6 +939,20 @@ func (sc *syncContext) setResourceResult(task *syncTask, syncStatus v1alpha1.Res
 		SyncPhase: task.phase,
 	}
 
+	if existing != nil {
+		res.AppliedAt, res.FinishedAt = existing.AppliedAt, existing.FinishedAt
+	}
+	// the timeline is recorded for the objects which are actually applied, pruned or created
+	if !sc.syncOp.DryRun {
+		now := metav1.Now()
+		if res.AppliedAt == nil && (res.Status == v1alpha1.ResultCodeSynced || res.Status == v1alpha1.ResultCodePruned) {
+			res.AppliedAt = &now
+		}
+		if res.AppliedAt != nil && res.FinishedAt == nil && res.HookPhase.Completed() {
+			res.FinishedAt = &now
+		}
+	}
+
 	logCtx := sc.log.WithFields(log.Fields{"namespace": task.namespace(), "kind": task.kind(), "name": task.name(), "phase": task.phase})
 
 	if existing != nil {
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+const (
+	// readinessTimeout is how long a sync waits for a synced CRD or webhook to be ready before it fails
+	readinessTimeout = 5 * time.Minute
+)
+
+var endpointsResource = schema.GroupVersionResource{Version: "v1", Resource: "endpoints"}
+
+// serviceRef is a reference to the service of a webhook
+type serviceRef struct {
+	namespace string
+	name      string
+}
+
+// getReadiness returns whether the API server is ready to serve resources which depend on a synced resource. CRDs must be
+// established, and the services of their conversion webhooks and of the webhooks of webhook configurations must have
+// ready endpoints, before the custom resources of later waves are applied. Otherwise the custom resources fail to apply,
+// or are rejected by webhooks which can't be reached yet.
+func (sc *syncContext) getReadiness(obj *unstructured.Unstructured) (v1alpha1.OperationPhase, string) {
+	var services []serviceRef
+	if kube.IsCRD(obj) {
+		if phase, message := getCRDEstablishment(obj); phase != v1alpha1.OperationSucceeded {
+			return phase, message
+		}
+		services = getCRDConversionServices(obj)
+	} else if isWebhookConfiguration(obj) {
+		services = getWebhookServices(obj)
+	}
+	for _, service := range services {
+		ready, err := sc.hasReadyEndpoints(service)
+		if err != nil {
+			return v1alpha1.OperationRunning, fmt.Sprintf("failed to get endpoints of webhook service %s/%s: %v", service.namespace, service.name, err)
+		}
+		if !ready {
+			return v1alpha1.OperationRunning, fmt.Sprintf("waiting for endpoints of webhook service %s/%s", service.namespace, service.name)
+		}
+	}
+	return v1alpha1.OperationSucceeded, ""
+}
+
+// getTaskReadiness returns the readiness of the live object of a synced task. It fails once the object isn't ready
+// readinessTimeout after it was applied, so that a CRD which is never established, or a webhook whose service never
+// gets endpoints, doesn't block the sync forever.
+func (sc *syncContext) getTaskReadiness(task *syncTask) (v1alpha1.OperationPhase, string) {
+	phase, message := sc.getReadiness(task.liveObj)
+	if phase != v1alpha1.OperationRunning {
+		return phase, message
+	}
+	_, result := sc.syncRes.Resources.Find(task.group(), task.kind(), task.namespace(), task.name(), task.phase)
+	if result != nil && result.AppliedAt != nil && time.Since(result.AppliedAt.Time) > readinessTimeout {
+		return v1alpha1.OperationFailed, fmt.Sprintf("not ready after %v: %s", readinessTimeout, message)
+	}
+	return phase, message
+}
+
+// getCRDEstablishment returns whether a CRD is established. It fails if the names of the CRD conflict with other CRDs.
+func getCRDEstablishment(obj *unstructured.Unstructured) (v1alpha1.OperationPhase, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	established := false
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		message, _ := condition["message"].(string)
+		switch condition["type"] {
+		case "NamesAccepted":
+			if status == "False" {
+				return v1alpha1.OperationFailed, fmt.Sprintf("names of CRD are not accepted: %s", message)
+			}
+		case "Established":
+			established = status == "True"
+		}
+	}
+	if !established {
+		return v1alpha1.OperationRunning, "waiting for CRD to be established"
+	}
+	return v1alpha1.OperationSucceeded, ""
+}
+
+// getCRDConversionServices returns the service of the conversion webhook of a v1 or v1beta1 CRD, if any
+func getCRDConversionServices(obj *unstructured.Unstructured) []serviceRef {
+	if strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "conversion", "strategy"); strategy != "Webhook" {
+		return nil
+	}
+	for _, path := range [][]string{
+		{"spec", "conversion", "webhook", "clientConfig", "service"},
+		{"spec", "conversion", "webhookClientConfig", "service"},
+	} {
+		if service, ok, _ := unstructured.NestedMap(obj.Object, path...); ok {
+			return []serviceRef{newServiceRef(service)}
+		}
+	}
+	return nil
+}
+
+func isWebhookConfiguration(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == "admissionregistration.k8s.io" && (gvk.Kind == "ValidatingWebhookConfiguration" || gvk.Kind == "MutatingWebhookConfiguration")
+}
+
+// getWebhookServices returns the services of the webhooks of a webhook configuration. Webhooks with URLs are ignored, and
+// so are webhooks whose failure policy is Ignore, since the API server admits requests while they can't be reached.
+func getWebhookServices(obj *unstructured.Unstructured) []serviceRef {
+	webhooks, _, _ := unstructured.NestedSlice(obj.Object, "webhooks")
+	var services []serviceRef
+	for _, item := range webhooks {
+		webhook, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if failurePolicy, _ := webhook["failurePolicy"].(string); failurePolicy == "Ignore" {
+			continue
+		}
+		if service, ok, _ := unstructured.NestedMap(webhook, "clientConfig", "service"); ok {
+			services = append(services, newServiceRef(service))
+		}
+	}
+	return services
+}
+
+func newServiceRef(service map[string]interface{}) serviceRef {
+	namespace, _ := service["namespace"].(string)
+	name, _ := service["name"].(string)
+	return serviceRef{namespace: namespace, name: name}
+}
+
+// hasReadyEndpoints returns whether a service has ready endpoints. Services which don't exist yet have no endpoints.
+func (sc *syncContext) hasReadyEndpoints(service serviceRef) (bool, error) {
+	endpoints, err := sc.dynamicIf.Resource(endpointsResource).Namespace(service.namespace).Get(service.name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+	for _, item := range subsets {
+		subset, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if addresses, _, _ := unstructured.NestedSlice(subset, "addresses"); len(addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
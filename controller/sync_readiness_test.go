@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/argoproj/argo-cd/common"
+	. "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/test"
+)
+
+func newEndpoints(ready bool) *unstructured.Unstructured {
+	addresses := "notReadyAddresses"
+	if ready {
+		addresses = "addresses"
+	}
+	return test.Unstructured(`apiVersion: v1
+kind: Endpoints
+metadata:
+  name: webhook
+  namespace: ` + test.FakeArgoCDNamespace + `
+subsets:
+- ` + addresses + `:
+  - ip: 10.0.0.1`)
+}
+
+func newWebhookConfiguration() *unstructured.Unstructured {
+	return test.Unstructured(`apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: webhook
+webhooks:
+- name: webhook.argoproj.io
+  clientConfig:
+    service:
+      name: webhook
+      namespace: ` + test.FakeArgoCDNamespace + `
+- name: url.argoproj.io
+  clientConfig:
+    url: https://webhook.argoproj.io`)
+}
+
+func TestGetCRDEstablishment(t *testing.T) {
+	crd := test.NewCRD()
+	phase, message := getCRDEstablishment(crd)
+	assert.Equal(t, OperationRunning, phase)
+	assert.Equal(t, "waiting for CRD to be established", message)
+
+	assert.NoError(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions"))
+	phase, _ = getCRDEstablishment(crd)
+	assert.Equal(t, OperationSucceeded, phase)
+
+	assert.NoError(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "False", "message": "\"testcrds\" is already in use"},
+	}, "status", "conditions"))
+	phase, message = getCRDEstablishment(crd)
+	assert.Equal(t, OperationFailed, phase)
+	assert.Equal(t, "names of CRD are not accepted: \"testcrds\" is already in use", message)
+}
+
+func TestGetCRDConversionServices(t *testing.T) {
+	crd := test.NewCRD()
+	assert.Empty(t, getCRDConversionServices(crd))
+
+	assert.NoError(t, unstructured.SetNestedMap(crd.Object, map[string]interface{}{
+		"strategy":            "Webhook",
+		"webhookClientConfig": map[string]interface{}{"service": map[string]interface{}{"namespace": "default", "name": "webhook"}},
+	}, "spec", "conversion"))
+	assert.Equal(t, []serviceRef{{namespace: "default", name: "webhook"}}, getCRDConversionServices(crd))
+}
+
+func TestGetWebhookServices_IgnoreFailurePolicy(t *testing.T) {
+	webhook := newWebhookConfiguration()
+	webhooks, _, _ := unstructured.NestedSlice(webhook.Object, "webhooks")
+	webhooks[0].(map[string]interface{})["failurePolicy"] = "Ignore"
+	assert.NoError(t, unstructured.SetNestedSlice(webhook.Object, webhooks, "webhooks"))
+	assert.Empty(t, getWebhookServices(webhook))
+}
+
+func TestGetReadiness(t *testing.T) {
+	syncCtx := newTestSyncCtx()
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	phase, message := syncCtx.getReadiness(newWebhookConfiguration())
+	assert.Equal(t, OperationRunning, phase)
+	assert.Equal(t, "waiting for endpoints of webhook service "+test.FakeArgoCDNamespace+"/webhook", message)
+
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme(), newEndpoints(false))
+	phase, _ = syncCtx.getReadiness(newWebhookConfiguration())
+	assert.Equal(t, OperationRunning, phase)
+
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme(), newEndpoints(true))
+	phase, _ = syncCtx.getReadiness(newWebhookConfiguration())
+	assert.Equal(t, OperationSucceeded, phase)
+
+	phase, _ = syncCtx.getReadiness(test.NewPod())
+	assert.Equal(t, OperationSucceeded, phase)
+}
+
+func TestSyncWaitsForWebhookBeforeLaterWaves(t *testing.T) {
+	syncCtx := newTestSyncCtx(&v1.APIResourceList{
+		GroupVersion: "admissionregistration.k8s.io/v1beta1",
+		APIResources: []v1.APIResource{
+			{Kind: "ValidatingWebhookConfiguration", Group: "admissionregistration.k8s.io", Version: "v1beta1"},
+		},
+	})
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme())
+	webhook := newWebhookConfiguration()
+	pod := test.Annotate(test.NewPod(), common.AnnotationSyncWave, "1")
+	pod.SetNamespace(test.FakeArgoCDNamespace)
+	syncCtx.compareResult = &comparisonResult{
+		managedResources: []managedResource{{Target: webhook}, {Target: pod}},
+	}
+
+	// the webhook configuration is applied in the first wave
+	syncCtx.sync()
+	assert.Equal(t, OperationRunning, syncCtx.opState.Phase)
+	assert.Len(t, syncCtx.syncRes.Resources, 1)
+	assert.Equal(t, "ValidatingWebhookConfiguration", syncCtx.syncRes.Resources[0].Kind)
+
+	// the pod isn't applied while the webhook has no endpoints
+	syncCtx.compareResult.managedResources[0].Live = webhook
+	syncCtx.sync()
+	assert.Equal(t, OperationRunning, syncCtx.opState.Phase)
+	assert.Len(t, syncCtx.syncRes.Resources, 1)
+	assert.Equal(t, "waiting for endpoints of webhook service "+test.FakeArgoCDNamespace+"/webhook", syncCtx.syncRes.Resources[0].Message)
+
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme(), newEndpoints(true))
+	syncCtx.sync()
+	assert.Equal(t, OperationSucceeded, syncCtx.opState.Phase)
+	assert.Len(t, syncCtx.syncRes.Resources, 2)
+}
+
+func TestSyncFailsIfWebhookIsNotReadyInTime(t *testing.T) {
+	syncCtx := newTestSyncCtx(&v1.APIResourceList{
+		GroupVersion: "admissionregistration.k8s.io/v1beta1",
+		APIResources: []v1.APIResource{
+			{Kind: "ValidatingWebhookConfiguration", Group: "admissionregistration.k8s.io", Version: "v1beta1"},
+		},
+	})
+	syncCtx.dynamicIf = fake.NewSimpleDynamicClient(runtime.NewScheme())
+	webhook := newWebhookConfiguration()
+	pod := test.Annotate(test.NewPod(), common.AnnotationSyncWave, "1")
+	pod.SetNamespace(test.FakeArgoCDNamespace)
+	syncCtx.compareResult = &comparisonResult{
+		managedResources: []managedResource{{Target: webhook}, {Target: pod}},
+	}
+
+	syncCtx.sync()
+	assert.Equal(t, OperationRunning, syncCtx.opState.Phase)
+	assert.Len(t, syncCtx.syncRes.Resources, 1)
+
+	// the webhook configuration was applied longer than the readiness timeout ago
+	appliedAt := v1.NewTime(time.Now().Add(-readinessTimeout - time.Minute))
+	syncCtx.syncRes.Resources[0].AppliedAt = &appliedAt
+	syncCtx.compareResult.managedResources[0].Live = webhook
+	syncCtx.sync()
+	assert.Equal(t, OperationFailed, syncCtx.opState.Phase)
+	assert.Len(t, syncCtx.syncRes.Resources, 1)
+	assert.Equal(t, OperationFailed, syncCtx.syncRes.Resources[0].HookPhase)
+	assert.Equal(t, "not ready after 5m0s: waiting for endpoints of webhook service "+test.FakeArgoCDNamespace+"/webhook", syncCtx.syncRes.Resources[0].Message)
+}
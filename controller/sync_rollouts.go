@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/util/kube"
+	"github.com/argoproj/argo-cd/util/resource"
+)
+
+// syncOptionPromoteFull is the sync option which fully promotes the Argo Rollouts applied by a sync, skipping their
+// remaining steps, pauses and analyses
+const syncOptionPromoteFull = "PromoteFull=true"
+
+func isRollout(obj *unstructured.Unstructured) bool {
+	return obj != nil && obj.GroupVersionKind().Group == "argoproj.io" && obj.GetKind() == "Rollout"
+}
+
+// promoteFull returns true if a Rollout should be fully promoted once applied
+func (sc *syncContext) promoteFull(obj *unstructured.Unstructured) bool {
+	return isRollout(obj) && (sc.syncOp.SyncOptions.HasOption(syncOptionPromoteFull) || resource.HasAnnotationOption(obj, common.AnnotationSyncOptions, syncOptionPromoteFull))
+}
+
+// promoteFullRollout requests the full promotion of an applied Rollout from the Argo Rollouts controller
+func (sc *syncContext) promoteFullRollout(obj *unstructured.Unstructured) error {
+	return kube.PatchStatus(sc.kubectl, sc.config, obj.GroupVersionKind(), obj.GetName(), obj.GetNamespace(), []byte(`{"status":{"promoteFull":true}}`))
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/common"
+	. "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/kube/kubetest"
+)
+
+var rolloutResources = &v1.APIResourceList{
+	GroupVersion: "argoproj.io/v1alpha1",
+	APIResources: []v1.APIResource{
+		{Kind: "Rollout", Group: "argoproj.io", Version: "v1alpha1", Namespaced: true},
+	},
+}
+
+const rolloutManifest = `
+apiVersion: argoproj.io/v1alpha1
+kind: Rollout
+metadata:
+  name: guestbook
+  namespace: ` + test.FakeArgoCDNamespace + `
+spec:
+  strategy:
+    canary:
+      steps:
+      - setWeight: 20
+      - pause: {}
+`
+
+func TestSyncPromoteFull(t *testing.T) {
+	tests := []struct {
+		name          string
+		syncOptions   SyncOptions
+		annotationVal string
+		want          bool
+	}{
+		{"Empty", nil, "", false},
+		{"SyncOption", SyncOptions{"PromoteFull=true"}, "", true},
+		{"Annotation", nil, "PromoteFull=true", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncCtx := newTestSyncCtx(rolloutResources)
+			syncCtx.syncOp.SyncOptions = tt.syncOptions
+			rollout := test.Unstructured(rolloutManifest)
+			rollout.SetAnnotations(map[string]string{common.AnnotationSyncOptions: tt.annotationVal})
+			syncCtx.compareResult = &comparisonResult{managedResources: []managedResource{{Target: rollout}}}
+
+			syncCtx.sync()
+
+			assert.Equal(t, OperationSucceeded, syncCtx.opState.Phase)
+			kubectl := syncCtx.kubectl.(*kubetest.MockKubectlCmd)
+			if tt.want {
+				assert.Equal(t, []kubetest.Patch{{Name: "guestbook", Patch: `{"status":{"promoteFull":true}}`, Subresources: []string{"status"}}}, kubectl.Patches)
+			} else {
+				assert.Empty(t, kubectl.Patches)
+			}
+		})
+	}
+}
+
+func TestSyncPromoteFullDryRun(t *testing.T) {
+	syncCtx := newTestSyncCtx(rolloutResources)
+	syncCtx.syncOp.DryRun = true
+	syncCtx.syncOp.SyncOptions = SyncOptions{"PromoteFull=true"}
+	syncCtx.compareResult = &comparisonResult{managedResources: []managedResource{{Target: test.Unstructured(rolloutManifest)}}}
+
+	syncCtx.sync()
+
+	assert.Empty(t, syncCtx.kubectl.(*kubetest.MockKubectlCmd).Patches)
+}
@@ -1,23 +1,27 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	fakedisco "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/rest"
 	testcore "k8s.io/client-go/testing"
 
 	"github.com/argoproj/argo-cd/common"
+	mockstatecache "github.com/argoproj/argo-cd/controller/cache/mocks"
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	. "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
@@ -72,6 +76,7 @@ func newTestSyncCtx(resources ...*v1.APIResourceList) *syncContext {
 		opState: &v1alpha1.OperationState{},
 		disco:   fakeDisco,
 		log:     log.WithFields(log.Fields{"application": "fake-app"}),
+		ctx:     context.Background(),
 	}
 	sc.kubectl = &kubetest.MockKubectlCmd{}
 	return &sc
@@ -205,12 +210,16 @@ func TestSyncSuccessfully(t *testing.T) {
 	assert.Len(t, syncCtx.syncRes.Resources, 2)
 	for i := range syncCtx.syncRes.Resources {
 		result := syncCtx.syncRes.Resources[i]
+		assert.NotNil(t, result.AppliedAt)
 		if result.Kind == "Pod" {
 			assert.Equal(t, v1alpha1.ResultCodePruned, result.Status)
 			assert.Equal(t, "pruned", result.Message)
+			assert.NotNil(t, result.FinishedAt)
 		} else if result.Kind == "Service" {
 			assert.Equal(t, v1alpha1.ResultCodeSynced, result.Status)
 			assert.Equal(t, "", result.Message)
+			// the resources of the last wave are not waited for
+			assert.Nil(t, result.FinishedAt)
 		} else {
 			t.Error("Resource isn't a pod or a service")
 		}
@@ -544,6 +553,110 @@ func TestPersistRevisionHistoryRollback(t *testing.T) {
 	assert.Equal(t, "abc123", updatedApp.Status.History[0].Revision)
 }
 
+func TestSyncAppStateToDestinations(t *testing.T) {
+	app := newFakeApp()
+	app.Status.OperationState = nil
+	app.Status.History = nil
+	app.Spec.Destinations = []v1alpha1.ApplicationDestination{{Server: app.Spec.Destination.Server, Namespace: "other"}}
+	defaultProject := &v1alpha1.AppProject{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: test.FakeArgoCDNamespace,
+			Name:      "default",
+		},
+	}
+	data := fakeData{
+		apps: []runtime.Object{app, defaultProject},
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+	}
+	ctrl := newFakeController(&data)
+
+	opState := &v1alpha1.OperationState{Operation: v1alpha1.Operation{
+		Sync: &v1alpha1.SyncOperation{},
+	}}
+	ctrl.appStateManager.SyncAppState(app, opState)
+
+	assert.Equal(t, v1alpha1.OperationSucceeded, opState.Phase)
+	assert.Equal(t, "successfully synced 2 destinations", opState.Message)
+	assert.Len(t, opState.Destinations, 2)
+	for i, destination := range app.Spec.GetDestinations() {
+		assert.Equal(t, destination, opState.Destinations[i].Destination)
+		assert.Equal(t, v1alpha1.OperationSucceeded, opState.Destinations[i].Phase)
+		assert.Equal(t, "abc123", opState.Destinations[i].SyncResult.Revision)
+	}
+	assert.Equal(t, "abc123", opState.SyncResult.Revision)
+
+	updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(app.Namespace).Get(app.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(updatedApp.Status.History))
+	assert.Equal(t, "abc123", updatedApp.Status.History[0].Revision)
+}
+
+// TestSyncAppStateToDestinationsSameCluster tests that the destinations of an application on the same cluster don't
+// consider the resources of each other as extraneous
+func TestSyncAppStateToDestinationsSameCluster(t *testing.T) {
+	app := newFakeApp()
+	app.Status.OperationState = nil
+	app.Status.History = nil
+	app.Spec.Destinations = []v1alpha1.ApplicationDestination{{Server: app.Spec.Destination.Server, Namespace: "other"}}
+	data := fakeData{
+		apps: []runtime.Object{app, &defaultProj},
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{test.PodManifest},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+	}
+	ctrl := newFakeController(&data)
+	livePods := make(map[kube.ResourceKey]*unstructured.Unstructured)
+	for _, destination := range app.Spec.GetDestinations() {
+		pod := test.NewPod()
+		pod.SetNamespace(destination.Namespace)
+		pod.SetUID(types.UID(destination.Namespace))
+		livePods[kube.GetResourceKey(pod)] = pod
+	}
+	// like the live state cache, return the live resources of the application in every namespace of the cluster
+	mockStateCache := mockstatecache.LiveStateCache{}
+	mockStateCache.On("IsNamespaced", mock.Anything, mock.Anything).Return(true, nil)
+	mockStateCache.On("GetManagedLiveObjs", mock.Anything, mock.Anything).Return(func(*v1alpha1.Application, []*unstructured.Unstructured) map[kube.ResourceKey]*unstructured.Unstructured {
+		res := make(map[kube.ResourceKey]*unstructured.Unstructured)
+		for k, v := range livePods {
+			res[k] = v
+		}
+		return res
+	}, nil)
+	mockStateCache.On("GetVersionsInfo", mock.Anything).Return("v1.2.3", nil, nil)
+	ctrl.appStateManager.(*appStateManager).liveStateCache = &mockStateCache
+
+	primary := ctrl.appStateManager.CompareAppState(context.Background(), app, &defaultProj, "", app.Spec.Source, false, nil)
+	assert.Len(t, primary.managedResources, 1)
+	statuses, syncStatus, _ := ctrl.compareAppDestinations(context.Background(), app, &defaultProj, primary, false, nil)
+	assert.Equal(t, v1alpha1.SyncStatusCodeSynced, syncStatus.Status)
+	for _, status := range statuses {
+		assert.Equal(t, v1alpha1.SyncStatusCodeSynced, status.Sync)
+	}
+
+	opState := &v1alpha1.OperationState{Operation: v1alpha1.Operation{
+		Sync: &v1alpha1.SyncOperation{Prune: true},
+	}}
+	ctrl.appStateManager.SyncAppState(app, opState)
+	// the fake cluster can't be reached, so only the tasks of the destinations are checked: none prunes the pod of the
+	// other destination
+	for i, destination := range app.Spec.GetDestinations() {
+		if assert.Len(t, opState.Destinations[i].SyncResult.Resources, 1) {
+			res := opState.Destinations[i].SyncResult.Resources[0]
+			assert.Equal(t, destination.Namespace, res.Namespace)
+			assert.NotEqual(t, v1alpha1.ResultCodePruned, res.Status)
+		}
+	}
+}
+
 func TestSyncFailureHookWithSuccessfulSync(t *testing.T) {
 	syncCtx := newTestSyncCtx()
 	syncCtx.syncOp.SyncStrategy.Apply = nil
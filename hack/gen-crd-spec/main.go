@@ -18,8 +18,12 @@ import (
 
 var (
 	kindToCRDPath = map[string]string{
-		application.ApplicationFullName: "manifests/crds/application-crd.yaml",
-		application.AppProjectFullName:  "manifests/crds/appproject-crd.yaml",
+		application.ApplicationFullName:            "manifests/crds/application-crd.yaml",
+		application.AppProjectFullName:             "manifests/crds/appproject-crd.yaml",
+		application.ApplicationSetFullName:         "manifests/crds/applicationset-crd.yaml",
+		application.ApplicationTemplateFullName:    "manifests/crds/applicationtemplate-crd.yaml",
+		application.RepositoryRegistrationFullName: "manifests/crds/repositoryregistration-crd.yaml",
+		application.ClusterRegistrationFullName:    "manifests/crds/clusterregistration-crd.yaml",
 	}
 )
 
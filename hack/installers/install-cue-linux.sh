@@ -0,0 +1,12 @@
+#!/bin/bash
+set -eux -o pipefail
+
+CUE_VERSION=${CUE_VERSION:-0.2.2}
+DL=$DOWNLOADS/cue-${CUE_VERSION}.tar.gz
+URL=https://github.com/cuelang/cue/releases/download/v${CUE_VERSION}/cue_${CUE_VERSION}_Linux_x86_64.tar.gz
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+tar -C /tmp -xf $DL cue
+cp /tmp/cue $BIN/cue
+chmod +x $BIN/cue
+cue version
@@ -0,0 +1,11 @@
+#!/bin/bash
+set -eux -o pipefail
+
+HELMFILE_VERSION=${HELMFILE_VERSION:-0.125.0}
+DL=$DOWNLOADS/helmfile-${HELMFILE_VERSION}
+URL=https://github.com/roboll/helmfile/releases/download/v${HELMFILE_VERSION}/helmfile_linux_amd64
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+cp $DL $BIN/helmfile
+chmod +x $BIN/helmfile
+helmfile --version
@@ -0,0 +1,11 @@
+#!/bin/bash
+set -eux -o pipefail
+
+JB_VERSION=${JB_VERSION:-0.4.0}
+DL=$DOWNLOADS/jb-${JB_VERSION}
+URL=https://github.com/jsonnet-bundler/jsonnet-bundler/releases/download/v${JB_VERSION}/jb-linux-amd64
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+cp $DL $BIN/jb
+chmod +x $BIN/jb
+jb --version
@@ -0,0 +1,12 @@
+#!/bin/bash
+set -eux -o pipefail
+
+ORAS_VERSION=${ORAS_VERSION:-1.0.0}
+DL=$DOWNLOADS/oras-${ORAS_VERSION}.tar.gz
+URL=https://github.com/oras-project/oras/releases/download/v${ORAS_VERSION}/oras_${ORAS_VERSION}_linux_amd64.tar.gz
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+tar -C /tmp -xf $DL oras
+cp /tmp/oras $BIN/oras
+chmod +x $BIN/oras
+oras version
@@ -0,0 +1,12 @@
+#!/bin/bash
+set -eux -o pipefail
+
+RCLONE_VERSION=${RCLONE_VERSION:-1.53.1}
+DL=$DOWNLOADS/rclone-${RCLONE_VERSION}.zip
+URL=https://github.com/rclone/rclone/releases/download/v${RCLONE_VERSION}/rclone-v${RCLONE_VERSION}-linux-amd64.zip
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+unzip -o -j -d /tmp $DL rclone-v${RCLONE_VERSION}-linux-amd64/rclone
+cp /tmp/rclone $BIN/rclone
+chmod +x $BIN/rclone
+rclone version
@@ -0,0 +1,11 @@
+#!/bin/bash
+set -eux -o pipefail
+
+SOPS_VERSION=${SOPS_VERSION:-3.7.3}
+DL=$DOWNLOADS/sops-${SOPS_VERSION}
+URL=https://github.com/mozilla/sops/releases/download/v${SOPS_VERSION}/sops-v${SOPS_VERSION}.linux.amd64
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+cp $DL $BIN/sops
+chmod +x $BIN/sops
+sops --version
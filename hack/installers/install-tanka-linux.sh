@@ -0,0 +1,11 @@
+#!/bin/bash
+set -eux -o pipefail
+
+TANKA_VERSION=${TANKA_VERSION:-0.11.1}
+DL=$DOWNLOADS/tk-${TANKA_VERSION}
+URL=https://github.com/grafana/tanka/releases/download/v${TANKA_VERSION}/tk-linux-amd64
+
+[ -e $DL ] || curl -sLf --retry 3 -o $DL $URL
+cp $DL $BIN/tk
+chmod +x $BIN/tk
+tk --version
@@ -28,6 +28,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	accountpkg "github.com/argoproj/argo-cd/pkg/apiclient/account"
 	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
+	applicationtemplatepkg "github.com/argoproj/argo-cd/pkg/apiclient/applicationtemplate"
 	certificatepkg "github.com/argoproj/argo-cd/pkg/apiclient/certificate"
 	clusterpkg "github.com/argoproj/argo-cd/pkg/apiclient/cluster"
 	projectpkg "github.com/argoproj/argo-cd/pkg/apiclient/project"
@@ -39,6 +40,7 @@ import (
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/fips"
 	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/localconfig"
 	oidcutil "github.com/argoproj/argo-cd/util/oidc"
@@ -59,7 +61,7 @@ const (
 type Client interface {
 	ClientOptions() ClientOptions
 	HTTPClient() (*http.Client, error)
-	OIDCConfig(context.Context, *settingspkg.Settings) (*oauth2.Config, *oidc.Provider, error)
+	OIDCConfig(context.Context, *settingspkg.Settings, string) (*oauth2.Config, *oidc.Provider, error)
 	NewRepoClient() (io.Closer, repositorypkg.RepositoryServiceClient, error)
 	NewRepoClientOrDie() (io.Closer, repositorypkg.RepositoryServiceClient)
 	NewRepoCredsClient() (io.Closer, repocredspkg.RepoCredsServiceClient, error)
@@ -70,6 +72,8 @@ type Client interface {
 	NewClusterClientOrDie() (io.Closer, clusterpkg.ClusterServiceClient)
 	NewApplicationClient() (io.Closer, applicationpkg.ApplicationServiceClient, error)
 	NewApplicationClientOrDie() (io.Closer, applicationpkg.ApplicationServiceClient)
+	NewApplicationTemplateClient() (io.Closer, applicationtemplatepkg.ApplicationTemplateServiceClient, error)
+	NewApplicationTemplateClientOrDie() (io.Closer, applicationtemplatepkg.ApplicationTemplateServiceClient)
 	NewSessionClient() (io.Closer, sessionpkg.SessionServiceClient, error)
 	NewSessionClientOrDie() (io.Closer, sessionpkg.SessionServiceClient)
 	NewSettingsClient() (io.Closer, settingspkg.SettingsServiceClient, error)
@@ -97,6 +101,8 @@ type ClientOptions struct {
 	PortForward          bool
 	PortForwardNamespace string
 	Headers              []string
+	// Core indicates the client talks to an API server started in the CLI process, so the local config is not used
+	Core bool
 }
 
 type client struct {
@@ -119,9 +125,13 @@ type client struct {
 // NewClient creates a new API client from a set of config options.
 func NewClient(opts *ClientOptions) (Client, error) {
 	var c client
-	localCfg, err := localconfig.ReadLocalConfig(opts.ConfigPath)
-	if err != nil {
-		return nil, err
+	var localCfg *localconfig.LocalConfig
+	var err error
+	if !opts.Core {
+		localCfg, err = localconfig.ReadLocalConfig(opts.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
 	}
 	c.proxyMutex = &sync.Mutex{}
 	var ctxName string
@@ -156,7 +166,7 @@ func NewClient(opts *ClientOptions) (Client, error) {
 		c.ServerAddr = serverFromEnv
 	}
 	if opts.PortForward || opts.PortForwardNamespace != "" {
-		port, err := portForward("app.kubernetes.io/name=argocd-server", opts.PortForwardNamespace)
+		port, err := PortForward(common.DefaultPortAPIServer, "app.kubernetes.io/name=argocd-server", opts.PortForwardNamespace)
 		if err != nil {
 			return nil, err
 		}
@@ -170,10 +180,8 @@ func NewClient(opts *ClientOptions) (Client, error) {
 	if c.ServerAddr == "" {
 		return nil, errors.New("Argo CD server address unspecified")
 	}
-	if parts := strings.Split(c.ServerAddr, ":"); len(parts) == 1 {
-		// If port is unspecified, assume the most likely port
-		c.ServerAddr += ":443"
-	}
+	// If port is unspecified, assume the most likely port
+	c.ServerAddr = util.EnsurePort(c.ServerAddr, "443")
 	// Override auth-token if specified in env variable or CLI flag
 	if authFromEnv := os.Getenv(EnvArgoCDAuthToken); authFromEnv != "" {
 		c.AuthToken = authFromEnv
@@ -210,20 +218,54 @@ func NewClient(opts *ClientOptions) (Client, error) {
 	return &c, nil
 }
 
-// OIDCConfig returns OAuth2 client config and a OpenID Provider based on Argo CD settings
+// OIDCProviderConfig returns the configuration of the OIDC provider with the given name. An empty
+// name selects the provider configured in oidc.config, which is nil if the server uses Dex.
+func OIDCProviderConfig(set *settingspkg.Settings, providerName string) (*settingspkg.OIDCConfig, error) {
+	if providerName == "" && set.OIDCConfig == nil && (set.DexConfig == nil || len(set.DexConfig.Connectors) == 0) && len(set.AdditionalOIDCConfigs) > 0 {
+		// neither oidc.config nor Dex are configured, so the first additional provider is the default one
+		return set.AdditionalOIDCConfigs[0], nil
+	}
+	if providerName == "" || (set.OIDCConfig != nil && set.OIDCConfig.Name == providerName) {
+		return set.OIDCConfig, nil
+	}
+	for _, oidcConfig := range set.AdditionalOIDCConfigs {
+		if oidcConfig.Name == providerName {
+			return oidcConfig, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown OIDC provider '%s'", providerName)
+}
+
+// OIDCProviderNameForIssuer returns the name of the additional OIDC provider with the given issuer
+// URL, or an empty string if the issuer is not one of the additional providers
+func OIDCProviderNameForIssuer(set *settingspkg.Settings, issuer string) string {
+	for _, oidcConfig := range set.AdditionalOIDCConfigs {
+		if oidcConfig.Issuer == issuer {
+			return oidcConfig.Name
+		}
+	}
+	return ""
+}
+
+// OIDCConfig returns OAuth2 client config and a OpenID Provider based on Argo CD settings, for the
+// OIDC provider with the given name (see OIDCProviderConfig).
 // ctx can hold an appropriate http.Client to use for the exchange
-func (c *client) OIDCConfig(ctx context.Context, set *settingspkg.Settings) (*oauth2.Config, *oidc.Provider, error) {
+func (c *client) OIDCConfig(ctx context.Context, set *settingspkg.Settings, providerName string) (*oauth2.Config, *oidc.Provider, error) {
 	var clientID string
 	var issuerURL string
 	var scopes []string
-	if set.OIDCConfig != nil && set.OIDCConfig.Issuer != "" {
-		if set.OIDCConfig.CLIClientID != "" {
-			clientID = set.OIDCConfig.CLIClientID
+	oidcConfig, err := OIDCProviderConfig(set, providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if oidcConfig != nil && oidcConfig.Issuer != "" {
+		if oidcConfig.CLIClientID != "" {
+			clientID = oidcConfig.CLIClientID
 		} else {
-			clientID = set.OIDCConfig.ClientID
+			clientID = oidcConfig.ClientID
 		}
-		issuerURL = set.OIDCConfig.Issuer
-		scopes = set.OIDCConfig.Scopes
+		issuerURL = oidcConfig.Issuer
+		scopes = oidcConfig.Scopes
 	} else if set.DexConfig != nil && len(set.DexConfig.Connectors) > 0 {
 		clientID = common.ArgoCDCLIClientAppID
 		issuerURL = fmt.Sprintf("%s%s", set.URL, common.DexAPIEndpoint)
@@ -294,7 +336,7 @@ func (c *client) refreshAuthToken(localCfg *localconfig.LocalConfig, ctxName, co
 	}
 
 	log.Debug("Auth token no longer valid. Refreshing")
-	rawIDToken, refreshToken, err := c.redeemRefreshToken()
+	rawIDToken, refreshToken, err := c.redeemRefreshToken(claims.Issuer)
 	if err != nil {
 		return err
 	}
@@ -313,7 +355,7 @@ func (c *client) refreshAuthToken(localCfg *localconfig.LocalConfig, ctxName, co
 }
 
 // redeemRefreshToken performs the exchange of a refresh_token for a new id_token and refresh_token
-func (c *client) redeemRefreshToken() (string, string, error) {
+func (c *client) redeemRefreshToken(issuer string) (string, string, error) {
 	setConn, setIf, err := c.NewSettingsClient()
 	if err != nil {
 		return "", "", err
@@ -328,7 +370,7 @@ func (c *client) redeemRefreshToken() (string, string, error) {
 	if err != nil {
 		return "", "", err
 	}
-	oauth2conf, _, err := c.OIDCConfig(ctx, acdSet)
+	oauth2conf, _, err := c.OIDCConfig(ctx, acdSet, OIDCProviderNameForIssuer(acdSet, issuer))
 	if err != nil {
 		return "", "", err
 	}
@@ -440,6 +482,7 @@ func (c *client) tlsConfig() (*tls.Config, error) {
 	if c.Insecure {
 		tlsConfig.InsecureSkipVerify = true
 	}
+	fips.ConfigureTLS(&tlsConfig)
 	return &tlsConfig, nil
 }
 
@@ -537,6 +580,23 @@ func (c *client) NewApplicationClientOrDie() (io.Closer, applicationpkg.Applicat
 	return conn, repoIf
 }
 
+func (c *client) NewApplicationTemplateClient() (io.Closer, applicationtemplatepkg.ApplicationTemplateServiceClient, error) {
+	conn, closer, err := c.newConn()
+	if err != nil {
+		return nil, nil, err
+	}
+	templateIf := applicationtemplatepkg.NewApplicationTemplateServiceClient(conn)
+	return closer, templateIf, nil
+}
+
+func (c *client) NewApplicationTemplateClientOrDie() (io.Closer, applicationtemplatepkg.ApplicationTemplateServiceClient) {
+	conn, templateIf, err := c.NewApplicationTemplateClient()
+	if err != nil {
+		log.Fatalf("Failed to establish connection to %s: %v", c.ServerAddr, err)
+	}
+	return conn, templateIf
+}
+
 func (c *client) NewSessionClient() (io.Closer, sessionpkg.SessionServiceClient, error) {
 	conn, closer, err := c.newConn()
 	if err != nil {
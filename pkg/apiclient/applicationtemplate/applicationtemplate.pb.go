@@ -0,0 +1,963 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: server/applicationtemplate/applicationtemplate.proto
+
+// ApplicationTemplate Service
+//
+// ApplicationTemplate Service API lists application templates and instantiates them to create applications
+
+package applicationtemplate
+
+import (
+	context "context"
+	fmt "fmt"
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ApplicationTemplateQuery is a query for ApplicationTemplate resources
+type ApplicationTemplateQuery struct {
+	// the template's name
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationTemplateQuery) Reset()         { *m = ApplicationTemplateQuery{} }
+func (m *ApplicationTemplateQuery) String() string { return proto.CompactTextString(m) }
+func (*ApplicationTemplateQuery) ProtoMessage()    {}
+func (*ApplicationTemplateQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c5a8db86c16663, []int{0}
+}
+func (m *ApplicationTemplateQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationTemplateQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationTemplateQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationTemplateQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationTemplateQuery.Merge(m, src)
+}
+func (m *ApplicationTemplateQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationTemplateQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationTemplateQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationTemplateQuery proto.InternalMessageInfo
+
+func (m *ApplicationTemplateQuery) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ApplicationTemplateInstantiateRequest is a request for creating an application from a template
+type ApplicationTemplateInstantiateRequest struct {
+	// the template's name
+	Template string `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	// the name of the application
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// the values of the parameters of the template
+	Parameters map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// dryRun renders and returns the application without creating it
+	DryRun               bool     `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationTemplateInstantiateRequest) Reset()         { *m = ApplicationTemplateInstantiateRequest{} }
+func (m *ApplicationTemplateInstantiateRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplicationTemplateInstantiateRequest) ProtoMessage()    {}
+func (*ApplicationTemplateInstantiateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c5a8db86c16663, []int{1}
+}
+func (m *ApplicationTemplateInstantiateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationTemplateInstantiateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationTemplateInstantiateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationTemplateInstantiateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationTemplateInstantiateRequest.Merge(m, src)
+}
+func (m *ApplicationTemplateInstantiateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationTemplateInstantiateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationTemplateInstantiateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationTemplateInstantiateRequest proto.InternalMessageInfo
+
+func (m *ApplicationTemplateInstantiateRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *ApplicationTemplateInstantiateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApplicationTemplateInstantiateRequest) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *ApplicationTemplateInstantiateRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*ApplicationTemplateQuery)(nil), "applicationtemplate.ApplicationTemplateQuery")
+	proto.RegisterType((*ApplicationTemplateInstantiateRequest)(nil), "applicationtemplate.ApplicationTemplateInstantiateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "applicationtemplate.ApplicationTemplateInstantiateRequest.ParametersEntry")
+}
+
+func init() { proto.RegisterFile("server/applicationtemplate/applicationtemplate.proto", fileDescriptor_06c5a8db86c16663) }
+
+var fileDescriptor_06c5a8db86c16663 = []byte{
+	// 478 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x41, 0x6b, 0x13, 0x41,
+	0x14, 0x66, 0x92, 0x58, 0xea, 0xf4, 0xa0, 0x8c, 0x45, 0xc2, 0x52, 0x42, 0x58, 0x2d, 0x04, 0xa5,
+	0x33, 0xa4, 0xf5, 0x50, 0x02, 0x15, 0x14, 0x54, 0xaa, 0x22, 0xba, 0x7a, 0xf2, 0x22, 0xd3, 0xcd,
+	0x63, 0x3b, 0xcd, 0x66, 0x66, 0x9d, 0x99, 0x5d, 0x08, 0xd2, 0x8b, 0x7f, 0xc1, 0x9b, 0x7f, 0x42,
+	0xfc, 0x17, 0x1e, 0x15, 0xc1, 0xb3, 0x04, 0x7f, 0x88, 0xec, 0x64, 0x37, 0x09, 0x32, 0x94, 0x58,
+	0x72, 0xfb, 0xde, 0xbe, 0x79, 0xdf, 0x7c, 0xdf, 0xbe, 0x6f, 0xf0, 0x3d, 0x03, 0xba, 0x00, 0xcd,
+	0x78, 0x96, 0xa5, 0x22, 0xe6, 0x56, 0x28, 0x69, 0x61, 0x9c, 0xa5, 0xdc, 0x82, 0xef, 0x1b, 0xcd,
+	0xb4, 0xb2, 0x8a, 0xdc, 0xf0, 0xb4, 0x82, 0xed, 0x44, 0x25, 0xca, 0xf5, 0x59, 0x89, 0x66, 0x47,
+	0x83, 0x9d, 0x44, 0xa9, 0x24, 0x2d, 0xc9, 0x04, 0xe3, 0x52, 0x2a, 0xeb, 0x86, 0x4c, 0xd5, 0x3d,
+	0x4e, 0x84, 0x3d, 0xcd, 0x4f, 0x68, 0xac, 0xc6, 0x8c, 0x6b, 0x37, 0x7e, 0xe6, 0xc0, 0x5e, 0x3c,
+	0x64, 0xd9, 0x28, 0x29, 0xc7, 0xcc, 0xb2, 0x10, 0x56, 0xf4, 0x79, 0x9a, 0x9d, 0xf2, 0x3e, 0x4b,
+	0x40, 0x82, 0xe6, 0x16, 0x86, 0x33, 0xaa, 0x90, 0xe2, 0xf6, 0x83, 0xc5, 0xb9, 0x37, 0x95, 0xaa,
+	0x57, 0x39, 0xe8, 0x09, 0x21, 0xb8, 0x25, 0xf9, 0x18, 0xda, 0xa8, 0x8b, 0x7a, 0x57, 0x23, 0x87,
+	0xc3, 0xcf, 0x0d, 0xbc, 0xeb, 0x19, 0x38, 0x96, 0xc6, 0x72, 0x69, 0x05, 0xb7, 0x10, 0xc1, 0xfb,
+	0x1c, 0x8c, 0x25, 0x01, 0xde, 0xac, 0x4d, 0x56, 0x0c, 0xf3, 0x7a, 0xce, 0xdc, 0x58, 0x30, 0x93,
+	0x33, 0x8c, 0x33, 0xae, 0xf9, 0x18, 0x2c, 0x68, 0xd3, 0x6e, 0x76, 0x9b, 0xbd, 0xad, 0xfd, 0xa7,
+	0xd4, 0xf7, 0x37, 0x57, 0xba, 0x9f, 0xbe, 0x9c, 0x93, 0x3d, 0x92, 0x56, 0x4f, 0xa2, 0x25, 0x76,
+	0x72, 0x13, 0x6f, 0x0c, 0xf5, 0x24, 0xca, 0x65, 0xbb, 0xd5, 0x45, 0xbd, 0xcd, 0xa8, 0xaa, 0x82,
+	0x23, 0x7c, 0xed, 0x9f, 0x31, 0x72, 0x1d, 0x37, 0x47, 0x30, 0xa9, 0x1c, 0x94, 0x90, 0x6c, 0xe3,
+	0x2b, 0x05, 0x4f, 0xf3, 0x5a, 0xfd, 0xac, 0x18, 0x34, 0x0e, 0xd1, 0xfe, 0xaf, 0x16, 0x0e, 0x3c,
+	0xe2, 0x5e, 0x83, 0x2e, 0x44, 0x0c, 0xe4, 0x0b, 0xc2, 0xad, 0xe7, 0xc2, 0x58, 0xb2, 0xb7, 0xaa,
+	0x2d, 0xb7, 0x87, 0x20, 0xa2, 0x8b, 0x7d, 0xd3, 0x7a, 0xdf, 0x0e, 0xbc, 0x8b, 0x87, 0x34, 0x1b,
+	0x25, 0xb4, 0xdc, 0xf7, 0x32, 0x27, 0xad, 0xf7, 0xed, 0x23, 0x2d, 0x25, 0x84, 0xb7, 0x3f, 0xfe,
+	0xfc, 0xf3, 0xa9, 0xd1, 0x21, 0x3b, 0x2e, 0x62, 0x45, 0xdf, 0x17, 0x5b, 0x43, 0xbe, 0x22, 0xdc,
+	0x7c, 0x02, 0xff, 0x2d, 0xf8, 0xc5, 0x7a, 0x05, 0x87, 0x77, 0x9d, 0xd8, 0x5d, 0x72, 0xeb, 0x22,
+	0xb1, 0xec, 0x43, 0x19, 0xa3, 0x73, 0xf2, 0x03, 0xe1, 0xad, 0xa5, 0x38, 0x90, 0xc1, 0xe5, 0x33,
+	0x14, 0x3c, 0x5e, 0x8f, 0x91, 0xf0, 0xbe, 0x33, 0x70, 0x18, 0x1e, 0x5c, 0x6c, 0xa0, 0x86, 0xe7,
+	0x4c, 0x2c, 0xb4, 0x0c, 0xd0, 0x9d, 0x87, 0xcf, 0xbe, 0x4d, 0x3b, 0xe8, 0xfb, 0xb4, 0x83, 0x7e,
+	0x4f, 0x3b, 0xe8, 0xed, 0xd1, 0x0a, 0xcf, 0x3f, 0x4e, 0x05, 0x48, 0xeb, 0xbb, 0xe7, 0x64, 0xc3,
+	0xbd, 0xfc, 0x83, 0xbf, 0x03, 0x00, 0x89, 0x70, 0x16, 0x9e, 0xc5, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ApplicationTemplateServiceClient is the client API for ApplicationTemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ApplicationTemplateServiceClient interface {
+	// List returns the list of application templates
+	List(ctx context.Context, in *ApplicationTemplateQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationTemplateList, error)
+	// Get returns an application template by name
+	Get(ctx context.Context, in *ApplicationTemplateQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationTemplate, error)
+	// Instantiate creates an application from an application template
+	Instantiate(ctx context.Context, in *ApplicationTemplateInstantiateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error)
+}
+
+type applicationTemplateServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApplicationTemplateServiceClient(cc *grpc.ClientConn) ApplicationTemplateServiceClient {
+	return &applicationTemplateServiceClient{cc}
+}
+
+func (c *applicationTemplateServiceClient) List(ctx context.Context, in *ApplicationTemplateQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationTemplateList, error) {
+	out := new(v1alpha1.ApplicationTemplateList)
+	err := c.cc.Invoke(ctx, "/applicationtemplate.ApplicationTemplateService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationTemplateServiceClient) Get(ctx context.Context, in *ApplicationTemplateQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationTemplate, error) {
+	out := new(v1alpha1.ApplicationTemplate)
+	err := c.cc.Invoke(ctx, "/applicationtemplate.ApplicationTemplateService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationTemplateServiceClient) Instantiate(ctx context.Context, in *ApplicationTemplateInstantiateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	out := new(v1alpha1.Application)
+	err := c.cc.Invoke(ctx, "/applicationtemplate.ApplicationTemplateService/Instantiate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplicationTemplateServiceServer is the server API for ApplicationTemplateService service.
+type ApplicationTemplateServiceServer interface {
+	// List returns the list of application templates
+	List(context.Context, *ApplicationTemplateQuery) (*v1alpha1.ApplicationTemplateList, error)
+	// Get returns an application template by name
+	Get(context.Context, *ApplicationTemplateQuery) (*v1alpha1.ApplicationTemplate, error)
+	// Instantiate creates an application from an application template
+	Instantiate(context.Context, *ApplicationTemplateInstantiateRequest) (*v1alpha1.Application, error)
+}
+
+// UnimplementedApplicationTemplateServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedApplicationTemplateServiceServer struct {
+}
+
+func (*UnimplementedApplicationTemplateServiceServer) List(ctx context.Context, req *ApplicationTemplateQuery) (*v1alpha1.ApplicationTemplateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedApplicationTemplateServiceServer) Get(ctx context.Context, req *ApplicationTemplateQuery) (*v1alpha1.ApplicationTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedApplicationTemplateServiceServer) Instantiate(ctx context.Context, req *ApplicationTemplateInstantiateRequest) (*v1alpha1.Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Instantiate not implemented")
+}
+
+func RegisterApplicationTemplateServiceServer(s *grpc.Server, srv ApplicationTemplateServiceServer) {
+	s.RegisterService(&_ApplicationTemplateService_serviceDesc, srv)
+}
+
+func _ApplicationTemplateService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationTemplateQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationTemplateServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/applicationtemplate.ApplicationTemplateService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationTemplateServiceServer).List(ctx, req.(*ApplicationTemplateQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationTemplateService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationTemplateQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationTemplateServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/applicationtemplate.ApplicationTemplateService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationTemplateServiceServer).Get(ctx, req.(*ApplicationTemplateQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationTemplateService_Instantiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationTemplateInstantiateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationTemplateServiceServer).Instantiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/applicationtemplate.ApplicationTemplateService/Instantiate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationTemplateServiceServer).Instantiate(ctx, req.(*ApplicationTemplateInstantiateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ApplicationTemplateService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "applicationtemplate.ApplicationTemplateService",
+	HandlerType: (*ApplicationTemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _ApplicationTemplateService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ApplicationTemplateService_Get_Handler,
+		},
+		{
+			MethodName: "Instantiate",
+			Handler:    _ApplicationTemplateService_Instantiate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server/applicationtemplate/applicationtemplate.proto",
+}
+
+func (m *ApplicationTemplateQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationTemplateQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationTemplateQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintApplicationtemplate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplicationTemplateInstantiateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationTemplateInstantiateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationTemplateInstantiateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Parameters) > 0 {
+		for k := range m.Parameters {
+			v := m.Parameters[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintApplicationtemplate(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintApplicationtemplate(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintApplicationtemplate(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintApplicationtemplate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Template) > 0 {
+		i -= len(m.Template)
+		copy(dAtA[i:], m.Template)
+		i = encodeVarintApplicationtemplate(dAtA, i, uint64(len(m.Template)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintApplicationtemplate(dAtA []byte, offset int, v uint64) int {
+	offset -= sovApplicationtemplate(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ApplicationTemplateQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApplicationtemplate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplicationTemplateInstantiateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Template)
+	if l > 0 {
+		n += 1 + l + sovApplicationtemplate(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApplicationtemplate(uint64(l))
+	}
+	if len(m.Parameters) > 0 {
+		for k, v := range m.Parameters {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovApplicationtemplate(uint64(len(k))) + 1 + len(v) + sovApplicationtemplate(uint64(len(v)))
+			n += mapEntrySize + 1 + sovApplicationtemplate(uint64(mapEntrySize))
+		}
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovApplicationtemplate(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozApplicationtemplate(x uint64) (n int) {
+	return sovApplicationtemplate(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ApplicationTemplateQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplicationtemplate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationTemplateQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationTemplateQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplicationtemplate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplicationTemplateInstantiateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplicationtemplate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationTemplateInstantiateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationTemplateInstantiateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Template = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Parameters == nil {
+				m.Parameters = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApplicationtemplate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApplicationtemplate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthApplicationtemplate
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthApplicationtemplate
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApplicationtemplate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthApplicationtemplate
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthApplicationtemplate
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipApplicationtemplate(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthApplicationtemplate
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Parameters[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplicationtemplate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApplicationtemplate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipApplicationtemplate(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowApplicationtemplate
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowApplicationtemplate
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthApplicationtemplate
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupApplicationtemplate
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthApplicationtemplate
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthApplicationtemplate        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowApplicationtemplate          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupApplicationtemplate = fmt.Errorf("proto: unexpected end of group")
+)
@@ -19,6 +19,7 @@ import (
 
 	argocderrors "github.com/argoproj/argo-cd/errors"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/fips"
 	"github.com/argoproj/argo-cd/util/rand"
 )
 
@@ -71,8 +72,10 @@ func (c *client) executeRequest(fullMethodName string, msg []byte, md metadata.M
 	}
 	req.Header.Set("content-type", "application/grpc-web+proto")
 
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.Insecure}
+	fips.ConfigureTLS(tlsConfig)
 	client := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.Insecure},
+		TLSClientConfig: tlsConfig,
 	}}
 
 	resp, err := client.Do(req)
@@ -14,48 +14,72 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 )
 
-func portForward(podSelector string, namespace string) (int, error) {
+// PortForward forwards a random local port to the given port of the first pod matching the selector, and returns the
+// local port. The current kubeconfig context is used, as well as its namespace if none is given.
+func PortForward(targetPort int, podSelector string, namespace string) (int, error) {
+	config, clientSet, namespace, err := portForwardClient(namespace)
+	if err != nil {
+		return -1, err
+	}
+
+	pods, err := clientSet.CoreV1().Pods(namespace).List(v1.ListOptions{
+		LabelSelector: podSelector,
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	if len(pods.Items) == 0 {
+		return -1, fmt.Errorf("cannot find pod with selector: %s", podSelector)
+	}
+	return portForwardPod(config, clientSet, pods.Items[0].Name, pods.Items[0].Namespace, targetPort)
+}
+
+// PortForwardPod forwards a random local port to the given port of a pod, and returns the local port. The current
+// kubeconfig context is used, as well as its namespace if none is given.
+func PortForwardPod(targetPort int, podName string, namespace string) (int, error) {
+	config, clientSet, namespace, err := portForwardClient(namespace)
+	if err != nil {
+		return -1, err
+	}
+	return portForwardPod(config, clientSet, podName, namespace, targetPort)
+}
+
+func portForwardClient(namespace string) (*rest.Config, kubernetes.Interface, string, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
 	overrides := clientcmd.ConfigOverrides{}
 	clientConfig := clientcmd.NewInteractiveDeferredLoadingClientConfig(loadingRules, &overrides, os.Stdin)
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
-		return -1, err
+		return nil, nil, "", err
 	}
 
 	if namespace == "" {
 		namespace, _, err = clientConfig.Namespace()
 		if err != nil {
-			return -1, err
+			return nil, nil, "", err
 		}
 	}
 
 	clientSet, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return -1, err
-	}
-
-	pods, err := clientSet.CoreV1().Pods(namespace).List(v1.ListOptions{
-		LabelSelector: podSelector,
-	})
-	if err != nil {
-		return -1, err
-	}
-
-	if len(pods.Items) == 0 {
-		return -1, fmt.Errorf("cannot find argocd-server pod")
+		return nil, nil, "", err
 	}
+	return config, clientSet, namespace, nil
+}
 
+func portForwardPod(config *rest.Config, clientSet kubernetes.Interface, podName string, namespace string, targetPort int) (int, error) {
 	url := clientSet.CoreV1().RESTClient().Post().
 		Resource("pods").
-		Namespace(pods.Items[0].Namespace).
-		Name(pods.Items[0].Name).
+		Namespace(namespace).
+		Name(podName).
 		SubResource("portforward").URL()
 
 	transport, upgrader, err := spdy.RoundTripperFor(config)
@@ -75,7 +99,7 @@ func portForward(podSelector string, namespace string) (int, error) {
 	port := ln.Addr().(*net.TCPAddr).Port
 	util.Close(ln)
 
-	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("%d:8080", port)}, context.Background().Done(), readyChan, out, errOut)
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("%d:%d", port, targetPort)}, context.Background().Done(), readyChan, out, errOut)
 	if err != nil {
 		return -1, err
 	}
@@ -93,9 +93,11 @@ func (m *ProjectCreateRequest) GetUpsert() bool {
 
 // ProjectTokenCreateRequest defines project token deletion parameters.
 type ProjectTokenDeleteRequest struct {
-	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
-	Iat                  int64    `protobuf:"varint,3,opt,name=iat,proto3" json:"iat,omitempty"`
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Role    string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Iat     int64  `protobuf:"varint,3,opt,name=iat,proto3" json:"iat,omitempty"`
+	// id identifies the token instead of iat, if set
+	Id                   string   `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -155,6 +157,160 @@ func (m *ProjectTokenDeleteRequest) GetIat() int64 {
 	return 0
 }
 
+func (m *ProjectTokenDeleteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// ProjectTokenRotateRequest defines the project token which is replaced by a new token.
+type ProjectTokenRotateRequest struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Role    string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// id identifies the rotated token. Tokens without ID are identified by iat.
+	Id  string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Iat int64  `protobuf:"varint,4,opt,name=iat,proto3" json:"iat,omitempty"`
+	// expiresIn is the lifetime of the new token in seconds. Defaults to the lifetime of the rotated token.
+	ExpiresIn int64 `protobuf:"varint,5,opt,name=expiresIn,proto3" json:"expiresIn,omitempty"`
+	// gracePeriod is the duration in seconds during which the rotated token remains valid
+	GracePeriod          int64    `protobuf:"varint,6,opt,name=gracePeriod,proto3" json:"gracePeriod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectTokenRotateRequest) Reset()         { *m = ProjectTokenRotateRequest{} }
+func (m *ProjectTokenRotateRequest) String() string { return proto.CompactTextString(m) }
+func (*ProjectTokenRotateRequest) ProtoMessage()    {}
+func (*ProjectTokenRotateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f0a51496972c9e2, []int{2}
+}
+func (m *ProjectTokenRotateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProjectTokenRotateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProjectTokenRotateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProjectTokenRotateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectTokenRotateRequest.Merge(m, src)
+}
+func (m *ProjectTokenRotateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProjectTokenRotateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectTokenRotateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectTokenRotateRequest proto.InternalMessageInfo
+
+func (m *ProjectTokenRotateRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ProjectTokenRotateRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ProjectTokenRotateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ProjectTokenRotateRequest) GetIat() int64 {
+	if m != nil {
+		return m.Iat
+	}
+	return 0
+}
+
+func (m *ProjectTokenRotateRequest) GetExpiresIn() int64 {
+	if m != nil {
+		return m.ExpiresIn
+	}
+	return 0
+}
+
+func (m *ProjectTokenRotateRequest) GetGracePeriod() int64 {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return 0
+}
+
+// ProjectTokensRevokeRequest defines the project role whose tokens are revoked.
+type ProjectTokensRevokeRequest struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectTokensRevokeRequest) Reset()         { *m = ProjectTokensRevokeRequest{} }
+func (m *ProjectTokensRevokeRequest) String() string { return proto.CompactTextString(m) }
+func (*ProjectTokensRevokeRequest) ProtoMessage()    {}
+func (*ProjectTokensRevokeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f0a51496972c9e2, []int{3}
+}
+func (m *ProjectTokensRevokeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProjectTokensRevokeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProjectTokensRevokeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProjectTokensRevokeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectTokensRevokeRequest.Merge(m, src)
+}
+func (m *ProjectTokensRevokeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProjectTokensRevokeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectTokensRevokeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectTokensRevokeRequest proto.InternalMessageInfo
+
+func (m *ProjectTokensRevokeRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ProjectTokensRevokeRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
 // ProjectTokenCreateRequest defines project token creation parameters.
 type ProjectTokenCreateRequest struct {
 	Project     string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
@@ -171,7 +327,7 @@ func (m *ProjectTokenCreateRequest) Reset()         { *m = ProjectTokenCreateReq
 func (m *ProjectTokenCreateRequest) String() string { return proto.CompactTextString(m) }
 func (*ProjectTokenCreateRequest) ProtoMessage()    {}
 func (*ProjectTokenCreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{2}
+	return fileDescriptor_5f0a51496972c9e2, []int{4}
 }
 func (m *ProjectTokenCreateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -240,7 +396,7 @@ func (m *ProjectTokenResponse) Reset()         { *m = ProjectTokenResponse{} }
 func (m *ProjectTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*ProjectTokenResponse) ProtoMessage()    {}
 func (*ProjectTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{3}
+	return fileDescriptor_5f0a51496972c9e2, []int{5}
 }
 func (m *ProjectTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -288,7 +444,7 @@ func (m *ProjectQuery) Reset()         { *m = ProjectQuery{} }
 func (m *ProjectQuery) String() string { return proto.CompactTextString(m) }
 func (*ProjectQuery) ProtoMessage()    {}
 func (*ProjectQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{4}
+	return fileDescriptor_5f0a51496972c9e2, []int{6}
 }
 func (m *ProjectQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -335,7 +491,7 @@ func (m *ProjectUpdateRequest) Reset()         { *m = ProjectUpdateRequest{} }
 func (m *ProjectUpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*ProjectUpdateRequest) ProtoMessage()    {}
 func (*ProjectUpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{5}
+	return fileDescriptor_5f0a51496972c9e2, []int{7}
 }
 func (m *ProjectUpdateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -381,7 +537,7 @@ func (m *EmptyResponse) Reset()         { *m = EmptyResponse{} }
 func (m *EmptyResponse) String() string { return proto.CompactTextString(m) }
 func (*EmptyResponse) ProtoMessage()    {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{6}
+	return fileDescriptor_5f0a51496972c9e2, []int{8}
 }
 func (m *EmptyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -421,7 +577,7 @@ func (m *SyncWindowsQuery) Reset()         { *m = SyncWindowsQuery{} }
 func (m *SyncWindowsQuery) String() string { return proto.CompactTextString(m) }
 func (*SyncWindowsQuery) ProtoMessage()    {}
 func (*SyncWindowsQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{7}
+	return fileDescriptor_5f0a51496972c9e2, []int{9}
 }
 func (m *SyncWindowsQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -468,7 +624,7 @@ func (m *SyncWindowsResponse) Reset()         { *m = SyncWindowsResponse{} }
 func (m *SyncWindowsResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncWindowsResponse) ProtoMessage()    {}
 func (*SyncWindowsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5f0a51496972c9e2, []int{8}
+	return fileDescriptor_5f0a51496972c9e2, []int{10}
 }
 func (m *SyncWindowsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -507,6 +663,8 @@ func (m *SyncWindowsResponse) GetWindows() []*v1alpha1.SyncWindow {
 func init() {
 	proto.RegisterType((*ProjectCreateRequest)(nil), "project.ProjectCreateRequest")
 	proto.RegisterType((*ProjectTokenDeleteRequest)(nil), "project.ProjectTokenDeleteRequest")
+	proto.RegisterType((*ProjectTokenRotateRequest)(nil), "project.ProjectTokenRotateRequest")
+	proto.RegisterType((*ProjectTokensRevokeRequest)(nil), "project.ProjectTokensRevokeRequest")
 	proto.RegisterType((*ProjectTokenCreateRequest)(nil), "project.ProjectTokenCreateRequest")
 	proto.RegisterType((*ProjectTokenResponse)(nil), "project.ProjectTokenResponse")
 	proto.RegisterType((*ProjectQuery)(nil), "project.ProjectQuery")
@@ -519,58 +677,65 @@ func init() {
 func init() { proto.RegisterFile("server/project/project.proto", fileDescriptor_5f0a51496972c9e2) }
 
 var fileDescriptor_5f0a51496972c9e2 = []byte{
-	// 803 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xdd, 0x6a, 0x23, 0x37,
-	0x14, 0x46, 0x71, 0xea, 0xd4, 0x72, 0x7f, 0x82, 0xf2, 0x53, 0xc7, 0x75, 0x5c, 0x33, 0xa5, 0xc1,
-	0xa4, 0x8d, 0x84, 0x93, 0x16, 0x42, 0x7b, 0x51, 0xfa, 0x13, 0x42, 0xa0, 0x17, 0xad, 0xd3, 0xd2,
-	0xd2, 0x5e, 0x04, 0x65, 0x7c, 0x98, 0x4c, 0x6c, 0x8f, 0xd4, 0x91, 0x3c, 0xa9, 0x31, 0xbe, 0x09,
-	0xa5, 0xd0, 0xf6, 0x62, 0x2f, 0xf6, 0x11, 0xf6, 0x6a, 0xdf, 0x64, 0x2f, 0x17, 0xf6, 0x05, 0x96,
-	0xb0, 0x0f, 0xb2, 0x48, 0xf3, 0x63, 0x3b, 0xce, 0x2c, 0xbb, 0xac, 0xd9, 0x2b, 0x9f, 0x91, 0x8e,
-	0xce, 0xf7, 0x7d, 0xe7, 0x1c, 0x1d, 0x19, 0xd7, 0x14, 0x84, 0x11, 0x84, 0x4c, 0x86, 0xe2, 0x12,
-	0x5c, 0x9d, 0xfe, 0x52, 0x19, 0x0a, 0x2d, 0xc8, 0x4a, 0xf2, 0x59, 0x5d, 0xf7, 0x84, 0x27, 0xec,
-	0x1a, 0x33, 0x56, 0xbc, 0x5d, 0xad, 0x79, 0x42, 0x78, 0x3d, 0x60, 0x5c, 0xfa, 0x8c, 0x07, 0x81,
-	0xd0, 0x5c, 0xfb, 0x22, 0x50, 0xc9, 0xae, 0xd3, 0x3d, 0x54, 0xd4, 0x17, 0x76, 0xd7, 0x15, 0x21,
-	0xb0, 0xa8, 0xc5, 0x3c, 0x08, 0x20, 0xe4, 0x1a, 0x3a, 0x89, 0xcf, 0xe7, 0x13, 0x9f, 0x3e, 0x77,
-	0x2f, 0xfc, 0x00, 0xc2, 0x21, 0x93, 0x5d, 0xcf, 0x2c, 0x28, 0xd6, 0x07, 0xcd, 0xef, 0x3a, 0x75,
-	0xe2, 0xf9, 0xfa, 0x62, 0x70, 0x4e, 0x5d, 0xd1, 0x67, 0x3c, 0xb4, 0xc4, 0x2e, 0xad, 0xb1, 0xe7,
-	0x76, 0x26, 0xa7, 0xb9, 0x94, 0x3d, 0xdf, 0xb5, 0x94, 0x58, 0xd4, 0xe2, 0x3d, 0x79, 0xc1, 0xe7,
-	0x42, 0x39, 0xf7, 0x10, 0x5e, 0xff, 0x31, 0x16, 0xf9, 0x5d, 0x08, 0x5c, 0x43, 0x1b, 0xfe, 0x1c,
-	0x80, 0xd2, 0xe4, 0x0c, 0xa7, 0xe2, 0x2b, 0xa8, 0x81, 0x9a, 0xe5, 0xfd, 0x23, 0x3a, 0x41, 0xa5,
-	0x29, 0xaa, 0x35, 0xce, 0xdc, 0x0e, 0x95, 0x5d, 0x8f, 0x1a, 0x54, 0x3a, 0x85, 0x4a, 0x53, 0x54,
-	0xfa, 0x8d, 0x94, 0x09, 0x48, 0x3b, 0x8d, 0x4a, 0x36, 0x71, 0x71, 0x20, 0x15, 0x84, 0xba, 0xb2,
-	0xd4, 0x40, 0xcd, 0xb7, 0xdb, 0xc9, 0x97, 0xf3, 0x07, 0xde, 0x4a, 0x7c, 0x7f, 0x16, 0x5d, 0x08,
-	0xbe, 0x87, 0x1e, 0x4c, 0x58, 0x55, 0x66, 0x59, 0x95, 0x26, 0xe1, 0x08, 0x5e, 0x0e, 0x45, 0x0f,
-	0x6c, 0xb0, 0x52, 0xdb, 0xda, 0x64, 0x15, 0x17, 0x7c, 0xae, 0x2b, 0x85, 0x06, 0x6a, 0x16, 0xda,
-	0xc6, 0x74, 0xfe, 0x45, 0xb3, 0xd1, 0x67, 0x35, 0xe7, 0x47, 0x6f, 0xe0, 0x72, 0x07, 0x94, 0x1b,
-	0xfa, 0xd2, 0x08, 0x4b, 0x40, 0xa6, 0x97, 0x32, 0xfc, 0xc2, 0x14, 0x7e, 0x0d, 0x97, 0xe0, 0x2f,
-	0xe9, 0x87, 0xa0, 0x4e, 0x82, 0xca, 0xb2, 0x65, 0x31, 0x59, 0x70, 0x3e, 0xcb, 0x32, 0x6f, 0xa9,
-	0xb4, 0x41, 0x49, 0x11, 0x28, 0x20, 0xeb, 0xf8, 0x2d, 0x6d, 0x16, 0x12, 0x0e, 0xf1, 0x87, 0xe3,
-	0xe0, 0x77, 0x12, 0xef, 0x9f, 0x06, 0x10, 0x0e, 0x0d, 0x5e, 0xc0, 0xfb, 0x90, 0x38, 0x59, 0xdb,
-	0xb9, 0xca, 0x22, 0xfe, 0x22, 0x3b, 0x6f, 0xb0, 0x96, 0xce, 0xfb, 0xf8, 0xdd, 0xa3, 0xbe, 0xd4,
-	0xc3, 0x54, 0x83, 0xb3, 0x83, 0x57, 0x4f, 0x87, 0x81, 0xfb, 0xab, 0x1f, 0x74, 0xc4, 0x95, 0xca,
-	0x67, 0x1c, 0xe1, 0xb5, 0x29, 0xbf, 0x2c, 0x05, 0x67, 0x78, 0xe5, 0x2a, 0x5e, 0xaa, 0xa0, 0x46,
-	0xe1, 0x35, 0x09, 0x4f, 0x00, 0xda, 0x69, 0xd4, 0xfd, 0x87, 0x25, 0xfc, 0x5e, 0xa2, 0xe2, 0x14,
-	0xc2, 0xc8, 0x77, 0x81, 0xfc, 0x87, 0x70, 0x39, 0x6e, 0x07, 0x5b, 0x0e, 0xe2, 0xd0, 0x74, 0x16,
-	0xe4, 0x36, 0x4c, 0x75, 0xfb, 0x4e, 0x9f, 0x2c, 0x0b, 0x87, 0xd7, 0x4f, 0x9e, 0xdd, 0x5f, 0xda,
-	0x77, 0xf6, 0xec, 0x0c, 0x88, 0x5a, 0xe9, 0x74, 0x51, 0x6c, 0x94, 0x58, 0x63, 0x66, 0x1a, 0x45,
-	0xb1, 0x91, 0xf9, 0x19, 0x33, 0x5b, 0xea, 0x2f, 0xd1, 0x2e, 0xf9, 0x07, 0xe1, 0x72, 0xdc, 0xf9,
-	0x2f, 0x22, 0x33, 0x73, 0x37, 0xaa, 0x9b, 0x99, 0xcf, 0x6c, 0x2d, 0xbe, 0xb2, 0x2c, 0xbe, 0xd8,
-	0x3d, 0x78, 0x25, 0x16, 0x6c, 0xe4, 0x73, 0x3d, 0x26, 0xff, 0x23, 0x5c, 0x8c, 0x35, 0x93, 0x39,
-	0xb1, 0xb3, 0xb9, 0x58, 0x4c, 0x4f, 0x39, 0x1f, 0x5a, 0xb6, 0x1b, 0xce, 0xea, 0x6d, 0xb6, 0x26,
-	0x2d, 0xd7, 0x08, 0x2f, 0xff, 0xe0, 0x2b, 0x4d, 0x36, 0x6e, 0x73, 0xb1, 0x2d, 0x56, 0x3d, 0x59,
-	0x08, 0x07, 0x83, 0xe0, 0x54, 0x2c, 0x0f, 0x42, 0xe6, 0x78, 0x90, 0xbf, 0x11, 0x2e, 0x1c, 0x43,
-	0x2e, 0x87, 0x05, 0xe5, 0xe1, 0x23, 0x8b, 0xbf, 0x45, 0x3e, 0x98, 0xaf, 0x9a, 0xb9, 0x39, 0x63,
-	0xf2, 0x00, 0xe1, 0x62, 0x7c, 0xcd, 0xe7, 0x2b, 0x33, 0x73, 0xfd, 0x17, 0xc5, 0xe8, 0xc0, 0x32,
-	0xda, 0xab, 0x36, 0x73, 0xfb, 0x88, 0x9a, 0x07, 0xab, 0xc3, 0x35, 0xa7, 0x96, 0xa2, 0xa9, 0xd8,
-	0x6f, 0xb8, 0x18, 0x77, 0x69, 0x5e, 0xba, 0xf2, 0xba, 0x36, 0xd1, 0xbf, 0x9b, 0xab, 0xff, 0x12,
-	0x63, 0x53, 0xa8, 0xa3, 0x08, 0x02, 0xad, 0xf2, 0xa2, 0x6f, 0xd3, 0xf8, 0x81, 0x35, 0x0a, 0xa9,
-	0x79, 0x84, 0x69, 0xd4, 0xa2, 0xf6, 0x88, 0x2d, 0xf2, 0x8e, 0x05, 0x69, 0x90, 0x7a, 0x0e, 0x08,
-	0x83, 0x38, 0xfa, 0x08, 0xaf, 0x1d, 0x83, 0x9e, 0x9a, 0x54, 0xa7, 0xda, 0xe4, 0x7d, 0x2b, 0x03,
-	0xbd, 0x3d, 0xec, 0xaa, 0xb5, 0xbb, 0xb6, 0x32, 0x71, 0x9f, 0x5a, 0xdc, 0x4f, 0xc8, 0xc7, 0x79,
-	0xb8, 0x6a, 0x18, 0xb8, 0xc9, 0xac, 0xfa, 0xf6, 0xeb, 0x47, 0x37, 0x75, 0xf4, 0xf8, 0xa6, 0x8e,
-	0x9e, 0xde, 0xd4, 0xd1, 0xef, 0xad, 0x97, 0x78, 0xfb, 0xdd, 0x9e, 0x0f, 0x41, 0xf6, 0x5f, 0xe6,
-	0xbc, 0x68, 0x9f, 0xfa, 0x83, 0xe7, 0x01, 0x00, 0x00, 0xff, 0xff, 0x56, 0xfb, 0x73, 0x4b, 0xec,
-	0x08, 0x00, 0x00,
+	// 921 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xdf, 0x6e, 0x1b, 0xc5,
+	0x17, 0xd6, 0xc4, 0xae, 0xfb, 0xcb, 0xb8, 0xbf, 0x12, 0x4d, 0xd3, 0xb2, 0x31, 0x69, 0xb0, 0xa6,
+	0xa2, 0x8a, 0x02, 0x99, 0xc5, 0x49, 0x41, 0x05, 0x2e, 0x2a, 0xfe, 0x44, 0x55, 0x10, 0x17, 0x65,
+	0x03, 0x02, 0x71, 0x13, 0x4d, 0x77, 0x8f, 0x36, 0x13, 0xdb, 0x3b, 0xcb, 0xcc, 0x64, 0x83, 0x15,
+	0xe5, 0xa6, 0x20, 0x24, 0xe0, 0x02, 0x21, 0x1e, 0x81, 0x4b, 0x1e, 0x83, 0x1b, 0x2e, 0x91, 0x78,
+	0x01, 0x14, 0xf1, 0x20, 0x68, 0x66, 0x77, 0x6d, 0xaf, 0x9d, 0x2d, 0xa4, 0x44, 0x5c, 0xf9, 0xec,
+	0x99, 0xb3, 0xe7, 0xfb, 0xce, 0x9f, 0xfd, 0x3c, 0x78, 0x55, 0x83, 0xca, 0x40, 0xf9, 0xa9, 0x92,
+	0x87, 0x10, 0x9a, 0xf2, 0x97, 0xa5, 0x4a, 0x1a, 0x49, 0xae, 0x16, 0x8f, 0x9d, 0xe5, 0x58, 0xc6,
+	0xd2, 0xf9, 0x7c, 0x6b, 0xe5, 0xc7, 0x9d, 0xd5, 0x58, 0xca, 0x78, 0x00, 0x3e, 0x4f, 0x85, 0xcf,
+	0x93, 0x44, 0x1a, 0x6e, 0x84, 0x4c, 0x74, 0x71, 0x4a, 0xfb, 0xf7, 0x35, 0x13, 0xd2, 0x9d, 0x86,
+	0x52, 0x81, 0x9f, 0xf5, 0xfc, 0x18, 0x12, 0x50, 0xdc, 0x40, 0x54, 0xc4, 0xdc, 0x9b, 0xc4, 0x0c,
+	0x79, 0x78, 0x20, 0x12, 0x50, 0x23, 0x3f, 0xed, 0xc7, 0xd6, 0xa1, 0xfd, 0x21, 0x18, 0x7e, 0xde,
+	0x5b, 0xbb, 0xb1, 0x30, 0x07, 0x47, 0x8f, 0x59, 0x28, 0x87, 0x3e, 0x57, 0x8e, 0xd8, 0xa1, 0x33,
+	0x36, 0xc3, 0x68, 0xf2, 0x36, 0x4f, 0xd3, 0x81, 0x08, 0x1d, 0x25, 0x3f, 0xeb, 0xf1, 0x41, 0x7a,
+	0xc0, 0xe7, 0x52, 0xd1, 0xef, 0x11, 0x5e, 0x7e, 0x94, 0x17, 0xf9, 0xae, 0x02, 0x6e, 0x20, 0x80,
+	0xcf, 0x8f, 0x40, 0x1b, 0xb2, 0x8f, 0xcb, 0xe2, 0x3d, 0xd4, 0x45, 0xeb, 0xed, 0xad, 0x1d, 0x36,
+	0x41, 0x65, 0x25, 0xaa, 0x33, 0xf6, 0xc3, 0x88, 0xa5, 0xfd, 0x98, 0x59, 0x54, 0x36, 0x85, 0xca,
+	0x4a, 0x54, 0xf6, 0x76, 0x9a, 0x16, 0x20, 0x41, 0x99, 0x95, 0xdc, 0xc2, 0xad, 0xa3, 0x54, 0x83,
+	0x32, 0xde, 0x42, 0x17, 0xad, 0xff, 0x2f, 0x28, 0x9e, 0x68, 0x1f, 0xaf, 0x14, 0xb1, 0x1f, 0xc9,
+	0x3e, 0x24, 0xef, 0xc1, 0x00, 0x26, 0xac, 0xbc, 0x2a, 0xab, 0xc5, 0x49, 0x3a, 0x82, 0x9b, 0x4a,
+	0x0e, 0xc0, 0x25, 0x5b, 0x0c, 0x9c, 0x4d, 0x96, 0x70, 0x43, 0x70, 0xe3, 0x35, 0xba, 0x68, 0xbd,
+	0x11, 0x58, 0x93, 0x5c, 0xc7, 0x0b, 0x22, 0xf2, 0x9a, 0x2e, 0x66, 0x41, 0x44, 0xf4, 0x67, 0x54,
+	0x45, 0x0b, 0xec, 0x0c, 0x9f, 0x11, 0x2d, 0xcf, 0xdd, 0x28, 0x73, 0x97, 0xe8, 0xcd, 0x09, 0xfa,
+	0x2a, 0x5e, 0x84, 0x2f, 0x52, 0xa1, 0x40, 0xef, 0x26, 0xde, 0x15, 0xe7, 0x9f, 0x38, 0x48, 0x17,
+	0xb7, 0x63, 0xc5, 0x43, 0x78, 0x04, 0x4a, 0xc8, 0xc8, 0x6b, 0xb9, 0xf3, 0x69, 0x17, 0x7d, 0x1f,
+	0x77, 0xa6, 0xc9, 0xea, 0x00, 0x32, 0xd9, 0x7f, 0x36, 0xb6, 0xf4, 0x9b, 0x99, 0xca, 0xab, 0xd3,
+	0xaf, 0xcf, 0xd5, 0xc5, 0xed, 0x08, 0x74, 0xa8, 0x44, 0x6a, 0x47, 0x5c, 0xa4, 0x9c, 0x76, 0x8d,
+	0xd1, 0x1a, 0x53, 0xbd, 0xa9, 0x54, 0xde, 0x9c, 0xa9, 0x9c, 0xbe, 0x82, 0x97, 0xa7, 0xa9, 0x04,
+	0xa0, 0x53, 0x99, 0x68, 0x20, 0xcb, 0xf8, 0x8a, 0xb1, 0x8e, 0x82, 0x43, 0xfe, 0x40, 0x29, 0xbe,
+	0x56, 0x44, 0x7f, 0x78, 0x04, 0x6a, 0x64, 0xf1, 0x12, 0x3e, 0x84, 0x22, 0xc8, 0xd9, 0xf4, 0x78,
+	0x9c, 0xf1, 0xe3, 0x34, 0xfa, 0x0f, 0xb7, 0x9a, 0x3e, 0x87, 0xff, 0xbf, 0x33, 0x4c, 0xcd, 0xa8,
+	0xac, 0x81, 0xde, 0xc5, 0x4b, 0x7b, 0xa3, 0x24, 0xfc, 0x44, 0x24, 0x91, 0x3c, 0xd6, 0xf5, 0x8c,
+	0x33, 0x7c, 0x63, 0x2a, 0x6e, 0xdc, 0x82, 0x7d, 0x7c, 0xf5, 0x38, 0x77, 0x79, 0xa8, 0xdb, 0xf8,
+	0x97, 0x84, 0x27, 0x00, 0x41, 0x99, 0x75, 0xeb, 0x97, 0x36, 0xbe, 0x5e, 0x54, 0xb1, 0x07, 0x2a,
+	0x13, 0x21, 0x90, 0x6f, 0x11, 0x6e, 0xe7, 0xeb, 0xe0, 0xc6, 0x41, 0x28, 0x2b, 0x55, 0xb1, 0x76,
+	0x61, 0x3a, 0xb7, 0xcf, 0x8d, 0x19, 0x77, 0xe1, 0xfe, 0x93, 0xdf, 0xff, 0xfc, 0x71, 0x61, 0x8b,
+	0x6e, 0x3a, 0x35, 0xcc, 0x7a, 0xa5, 0xce, 0x6a, 0xff, 0xa4, 0xb0, 0x4e, 0x7d, 0xbb, 0x28, 0xda,
+	0x3f, 0xb1, 0x3f, 0xa7, 0xbe, 0x1b, 0xf5, 0x9b, 0x68, 0x83, 0x7c, 0x8d, 0x70, 0x3b, 0xd7, 0x80,
+	0xa7, 0x91, 0xa9, 0xa8, 0x44, 0xe7, 0xd6, 0x38, 0xa6, 0x3a, 0x8b, 0xb7, 0x1c, 0x8b, 0xd7, 0x36,
+	0xb6, 0x2f, 0xc4, 0xc2, 0x3f, 0x11, 0xdc, 0x9c, 0x92, 0x1f, 0x10, 0x6e, 0xe7, 0xf2, 0xf0, 0x34,
+	0x22, 0x15, 0x01, 0xf9, 0xbb, 0xae, 0x3c, 0x70, 0x7c, 0xde, 0xa0, 0xf7, 0x2e, 0xc6, 0x47, 0x39,
+	0x0c, 0xdb, 0x9c, 0x2f, 0x11, 0xbe, 0x96, 0x8b, 0x40, 0x2e, 0x08, 0xe4, 0xce, 0xb9, 0x80, 0x55,
+	0xa1, 0xa8, 0x6d, 0xcf, 0xeb, 0x8e, 0xce, 0xab, 0x1b, 0xec, 0x42, 0x74, 0x34, 0xf9, 0x0e, 0xe1,
+	0x56, 0xbe, 0x0d, 0x64, 0xae, 0xe0, 0xea, 0x96, 0x5c, 0xce, 0xd7, 0x46, 0x5f, 0x70, 0x44, 0x6f,
+	0xd2, 0xa5, 0x59, 0xa2, 0xb6, 0x27, 0x4f, 0x10, 0x6e, 0x7e, 0x20, 0xb4, 0x21, 0x37, 0x67, 0xb9,
+	0xb8, 0x8f, 0xaf, 0xb3, 0x7b, 0x29, 0x1c, 0x2c, 0x02, 0xf5, 0x1c, 0x0f, 0x42, 0xe6, 0x78, 0x90,
+	0xaf, 0x10, 0x6e, 0x3c, 0x84, 0x5a, 0x0e, 0x97, 0xd4, 0x87, 0x17, 0x1d, 0xfe, 0x0a, 0x79, 0x7e,
+	0x7e, 0x60, 0x56, 0x53, 0x4e, 0xc9, 0x4f, 0x08, 0xb7, 0x72, 0x01, 0x9c, 0x9f, 0x4c, 0x45, 0x18,
+	0x2f, 0x8b, 0xd1, 0xb6, 0x63, 0xb4, 0xd9, 0x59, 0xaf, 0x5d, 0x21, 0x66, 0x2f, 0x35, 0x11, 0x37,
+	0x9c, 0x39, 0x8a, 0x76, 0x62, 0x9f, 0xe2, 0x56, 0xfe, 0xfd, 0xd6, 0xb5, 0xab, 0x6e, 0x61, 0x8b,
+	0xfa, 0x37, 0x6a, 0xeb, 0x3f, 0xc4, 0xd8, 0x0e, 0x6a, 0x27, 0x83, 0xc4, 0xe8, 0xba, 0xec, 0xb7,
+	0x59, 0x7e, 0x09, 0xb3, 0x15, 0xb2, 0x50, 0x2a, 0x60, 0x59, 0x8f, 0xb9, 0x57, 0xdc, 0x90, 0xef,
+	0x3a, 0x90, 0x2e, 0x59, 0xab, 0x01, 0xf1, 0x21, 0xcf, 0x7e, 0x82, 0x6f, 0x3c, 0x04, 0x33, 0xa5,
+	0xe1, 0x7b, 0xf6, 0x2b, 0x25, 0x2b, 0x63, 0xd0, 0xd9, 0xbf, 0x81, 0xce, 0xea, 0x79, 0x47, 0xe3,
+	0xe2, 0x5e, 0x76, 0xb8, 0x2f, 0x91, 0x3b, 0x75, 0xb8, 0x7a, 0x94, 0x84, 0x85, 0x8a, 0xbf, 0xf3,
+	0xe0, 0xd7, 0xb3, 0x35, 0xf4, 0xdb, 0xd9, 0x1a, 0xfa, 0xe3, 0x6c, 0x0d, 0x7d, 0xd6, 0xfb, 0x07,
+	0xf7, 0xc3, 0x70, 0x20, 0x20, 0x19, 0xdf, 0x77, 0x1f, 0xb7, 0xdc, 0x75, 0x70, 0xfb, 0xaf, 0x01,
+	0x00, 0x8a, 0x7b, 0x05, 0x4a, 0x10, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -589,6 +754,10 @@ type ProjectServiceClient interface {
 	CreateToken(ctx context.Context, in *ProjectTokenCreateRequest, opts ...grpc.CallOption) (*ProjectTokenResponse, error)
 	// Delete a new project token.
 	DeleteToken(ctx context.Context, in *ProjectTokenDeleteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// RotateToken replaces a project token by a new token of the same role.
+	RotateToken(ctx context.Context, in *ProjectTokenRotateRequest, opts ...grpc.CallOption) (*ProjectTokenResponse, error)
+	// RevokeTokens deletes all tokens of a project role and revokes them immediately
+	RevokeTokens(ctx context.Context, in *ProjectTokensRevokeRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	// Create a new project.
 	Create(ctx context.Context, in *ProjectCreateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error)
 	// List returns list of projects
@@ -631,6 +800,24 @@ func (c *projectServiceClient) DeleteToken(ctx context.Context, in *ProjectToken
 	return out, nil
 }
 
+func (c *projectServiceClient) RotateToken(ctx context.Context, in *ProjectTokenRotateRequest, opts ...grpc.CallOption) (*ProjectTokenResponse, error) {
+	out := new(ProjectTokenResponse)
+	err := c.cc.Invoke(ctx, "/project.ProjectService/RotateToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) RevokeTokens(ctx context.Context, in *ProjectTokensRevokeRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/project.ProjectService/RevokeTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) Create(ctx context.Context, in *ProjectCreateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
 	out := new(v1alpha1.AppProject)
 	err := c.cc.Invoke(ctx, "/project.ProjectService/Create", in, out, opts...)
@@ -700,6 +887,10 @@ type ProjectServiceServer interface {
 	CreateToken(context.Context, *ProjectTokenCreateRequest) (*ProjectTokenResponse, error)
 	// Delete a new project token.
 	DeleteToken(context.Context, *ProjectTokenDeleteRequest) (*EmptyResponse, error)
+	// RotateToken replaces a project token by a new token of the same role.
+	RotateToken(context.Context, *ProjectTokenRotateRequest) (*ProjectTokenResponse, error)
+	// RevokeTokens deletes all tokens of a project role and revokes them immediately
+	RevokeTokens(context.Context, *ProjectTokensRevokeRequest) (*EmptyResponse, error)
 	// Create a new project.
 	Create(context.Context, *ProjectCreateRequest) (*v1alpha1.AppProject, error)
 	// List returns list of projects
@@ -726,6 +917,12 @@ func (*UnimplementedProjectServiceServer) CreateToken(ctx context.Context, req *
 func (*UnimplementedProjectServiceServer) DeleteToken(ctx context.Context, req *ProjectTokenDeleteRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteToken not implemented")
 }
+func (*UnimplementedProjectServiceServer) RotateToken(ctx context.Context, req *ProjectTokenRotateRequest) (*ProjectTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateToken not implemented")
+}
+func (*UnimplementedProjectServiceServer) RevokeTokens(ctx context.Context, req *ProjectTokensRevokeRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeTokens not implemented")
+}
 func (*UnimplementedProjectServiceServer) Create(ctx context.Context, req *ProjectCreateRequest) (*v1alpha1.AppProject, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
@@ -788,6 +985,42 @@ func _ProjectService_DeleteToken_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_RotateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectTokenRotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RotateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/project.ProjectService/RotateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RotateToken(ctx, req.(*ProjectTokenRotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_RevokeTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectTokensRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RevokeTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/project.ProjectService/RevokeTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RevokeTokens(ctx, req.(*ProjectTokensRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProjectCreateRequest)
 	if err := dec(in); err != nil {
@@ -926,6 +1159,14 @@ var _ProjectService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteToken",
 			Handler:    _ProjectService_DeleteToken_Handler,
 		},
+		{
+			MethodName: "RotateToken",
+			Handler:    _ProjectService_RotateToken_Handler,
+		},
+		{
+			MethodName: "RevokeTokens",
+			Handler:    _ProjectService_RevokeTokens_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _ProjectService_Create_Handler,
@@ -1032,6 +1273,13 @@ func (m *ProjectTokenDeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Iat != 0 {
 		i = encodeVarintProject(dAtA, i, uint64(m.Iat))
 		i--
@@ -1054,6 +1302,110 @@ func (m *ProjectTokenDeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *ProjectTokenRotateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProjectTokenRotateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProjectTokenRotateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GracePeriod != 0 {
+		i = encodeVarintProject(dAtA, i, uint64(m.GracePeriod))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ExpiresIn != 0 {
+		i = encodeVarintProject(dAtA, i, uint64(m.ExpiresIn))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Iat != 0 {
+		i = encodeVarintProject(dAtA, i, uint64(m.Iat))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Project) > 0 {
+		i -= len(m.Project)
+		copy(dAtA[i:], m.Project)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Project)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProjectTokensRevokeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProjectTokensRevokeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProjectTokensRevokeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Project) > 0 {
+		i -= len(m.Project)
+		copy(dAtA[i:], m.Project)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.Project)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ProjectTokenCreateRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1363,7 +1715,64 @@ func (m *ProjectTokenDeleteRequest) Size() (n int) {
 	if m.Iat != 0 {
 		n += 1 + sovProject(uint64(m.Iat))
 	}
-	if m.XXX_unrecognized != nil {
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProjectTokenRotateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Project)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	if m.Iat != 0 {
+		n += 1 + sovProject(uint64(m.Iat))
+	}
+	if m.ExpiresIn != 0 {
+		n += 1 + sovProject(uint64(m.ExpiresIn))
+	}
+	if m.GracePeriod != 0 {
+		n += 1 + sovProject(uint64(m.GracePeriod))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProjectTokensRevokeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Project)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
@@ -1718,6 +2127,363 @@ func (m *ProjectTokenDeleteRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProject(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProject
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProject
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProjectTokenRotateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProject
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProjectTokenRotateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProjectTokenRotateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Project = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Iat", wireType)
+			}
+			m.Iat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Iat |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresIn", wireType)
+			}
+			m.ExpiresIn = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresIn |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriod", wireType)
+			}
+			m.GracePeriod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GracePeriod |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProject(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProject
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProject
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProjectTokensRevokeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProject
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProjectTokensRevokeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProjectTokensRevokeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Project = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProject(dAtA[iNdEx:])
@@ -320,7 +320,9 @@ type RepoAccessQuery struct {
 	// The type of the repo
 	Type string `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
 	// The name of the repo
-	Name                 string   `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether to authenticate to the registry of an OCI repo with the identity of the pods of Argo CD
+	CloudIdentity        bool     `protobuf:"varint,11,opt,name=cloudIdentity,proto3" json:"cloudIdentity,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -422,6 +424,13 @@ func (m *RepoAccessQuery) GetName() string {
 	return ""
 }
 
+func (m *RepoAccessQuery) GetCloudIdentity() bool {
+	if m != nil {
+		return m.CloudIdentity
+	}
+	return false
+}
+
 type RepoResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -590,66 +599,70 @@ func init() {
 func init() { proto.RegisterFile("server/repository/repository.proto", fileDescriptor_8d38260443475705) }
 
 var fileDescriptor_8d38260443475705 = []byte{
-	// 935 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcf, 0x6f, 0x1c, 0x35,
-	0x14, 0xd6, 0xa4, 0xed, 0x36, 0x71, 0x92, 0xb2, 0x75, 0x4b, 0xb5, 0x6c, 0xb7, 0x69, 0x64, 0x4a,
-	0x15, 0xa2, 0x32, 0xc3, 0x2e, 0x20, 0xa1, 0x22, 0x84, 0x92, 0x0d, 0x82, 0x88, 0x48, 0xc0, 0x54,
-	0x45, 0x82, 0x0b, 0x72, 0x67, 0x5f, 0x76, 0x4d, 0x66, 0xc7, 0xc6, 0xf6, 0x0e, 0x5a, 0x45, 0xbd,
-	0x70, 0x82, 0x23, 0x20, 0x6e, 0x5c, 0x2a, 0x71, 0xe0, 0x4f, 0xe1, 0x88, 0xc4, 0x3f, 0x80, 0x22,
-	0xfe, 0x0f, 0x90, 0xed, 0xf9, 0xb5, 0xd9, 0x1f, 0x6d, 0x45, 0xc8, 0xcd, 0xfe, 0xfc, 0xfc, 0xbe,
-	0xcf, 0x9f, 0x9f, 0x9f, 0x8c, 0x88, 0x02, 0x99, 0x82, 0x0c, 0x24, 0x08, 0xae, 0x98, 0xe6, 0x72,
-	0x5c, 0x19, 0xfa, 0x42, 0x72, 0xcd, 0x31, 0x2a, 0x91, 0xe6, 0xf5, 0x3e, 0xef, 0x73, 0x0b, 0x07,
-	0x66, 0xe4, 0x22, 0x9a, 0xad, 0x3e, 0xe7, 0xfd, 0x18, 0x02, 0x2a, 0x58, 0x40, 0x93, 0x84, 0x6b,
-	0xaa, 0x19, 0x4f, 0x54, 0xb6, 0x4a, 0x8e, 0xde, 0x56, 0x3e, 0xe3, 0x76, 0x35, 0xe2, 0x12, 0x82,
-	0xb4, 0x1d, 0xf4, 0x21, 0x01, 0x49, 0x35, 0xf4, 0xb2, 0x98, 0xfd, 0x3e, 0xd3, 0x83, 0xd1, 0x23,
-	0x3f, 0xe2, 0xc3, 0x80, 0x4a, 0x4b, 0xf1, 0x95, 0x1d, 0xbc, 0x16, 0xf5, 0x02, 0x71, 0xd4, 0x37,
-	0x9b, 0x55, 0x40, 0x85, 0x88, 0x59, 0x64, 0x93, 0x07, 0x69, 0x9b, 0xc6, 0x62, 0x40, 0xa7, 0x53,
-	0xed, 0x2e, 0x4a, 0x65, 0x8f, 0xf2, 0xd4, 0x23, 0x93, 0xf7, 0xd0, 0x7a, 0x08, 0x82, 0xef, 0x08,
-	0xa1, 0x3e, 0x1d, 0x81, 0x1c, 0x63, 0x8c, 0x2e, 0x9a, 0xa0, 0x86, 0xb7, 0xe9, 0x6d, 0xad, 0x84,
-	0x76, 0x8c, 0x9b, 0x68, 0x59, 0x42, 0xca, 0x14, 0xe3, 0x49, 0x63, 0xc9, 0xe2, 0xc5, 0x9c, 0xb4,
-	0xd1, 0xe5, 0x1d, 0x21, 0xf6, 0x93, 0x43, 0x6e, 0xb6, 0xea, 0xb1, 0x80, 0x7c, 0xab, 0x19, 0x1b,
-	0x4c, 0x50, 0x3d, 0xc8, 0xb6, 0xd9, 0x31, 0x39, 0x46, 0xd7, 0x32, 0xce, 0x3d, 0xd0, 0x94, 0xc5,
-	0x19, 0x73, 0x0f, 0xd5, 0x14, 0x1f, 0xc9, 0xc8, 0x25, 0x58, 0xed, 0x1c, 0xf8, 0xe5, 0xf9, 0xfc,
-	0xfc, 0x7c, 0x76, 0xf0, 0x65, 0xd4, 0xf3, 0xc5, 0x51, 0xdf, 0x37, 0x56, 0xf9, 0x15, 0xab, 0xfc,
-	0xdc, 0x2a, 0x7f, 0xa7, 0x04, 0x1f, 0xd8, 0x9c, 0x61, 0x96, 0x9b, 0xbc, 0x8b, 0xea, 0xf9, 0x81,
-	0x43, 0x50, 0x82, 0x27, 0x0a, 0xf0, 0xab, 0xe8, 0x12, 0xd3, 0x30, 0x54, 0x0d, 0x6f, 0xf3, 0xc2,
-	0xd6, 0x6a, 0xe7, 0x9a, 0x5f, 0xb1, 0x29, 0x3b, 0x5c, 0xe8, 0x22, 0x48, 0x17, 0xad, 0x98, 0xed,
-	0xf3, 0xbd, 0x22, 0x68, 0xed, 0x90, 0x1b, 0x42, 0x38, 0x94, 0xa0, 0xdc, 0xc1, 0x97, 0xc3, 0x09,
-	0x8c, 0x3c, 0x59, 0x42, 0x2f, 0x58, 0x11, 0x51, 0x04, 0x6a, 0xb1, 0xef, 0x23, 0x05, 0x32, 0xa1,
-	0x43, 0xc8, 0x7d, 0xcf, 0xe7, 0x66, 0x4d, 0x50, 0xa5, 0xbe, 0xe1, 0xb2, 0xd7, 0xb8, 0xe0, 0xd6,
-	0xf2, 0x39, 0xbe, 0x83, 0xd6, 0x95, 0x1a, 0x7c, 0x22, 0x59, 0x4a, 0x35, 0x7c, 0x04, 0xe3, 0xc6,
-	0x45, 0x1b, 0x30, 0x09, 0x9a, 0x0c, 0x2c, 0x51, 0x10, 0x8d, 0x24, 0x34, 0x2e, 0x59, 0x95, 0xc5,
-	0x1c, 0xdf, 0x43, 0x57, 0x75, 0xac, 0xba, 0x31, 0x83, 0x44, 0x77, 0x41, 0xea, 0x3d, 0xaa, 0x69,
-	0xa3, 0x66, 0xb3, 0x4c, 0x2f, 0xe0, 0x6d, 0x54, 0x9f, 0x00, 0x0d, 0xe5, 0x65, 0x1b, 0x3c, 0x85,
-	0x17, 0x45, 0xb2, 0x32, 0x59, 0x24, 0xf6, 0x8c, 0xc8, 0x61, 0x66, 0x4c, 0xae, 0xa0, 0x35, 0x63,
-	0x51, 0x7e, 0x47, 0xe4, 0x57, 0x0f, 0x5d, 0x35, 0x40, 0x57, 0x02, 0xd5, 0x10, 0xc2, 0xd7, 0x23,
-	0x50, 0x1a, 0x7f, 0x5e, 0x71, 0x6d, 0xb5, 0xf3, 0xfe, 0x7f, 0xa8, 0x98, 0xb0, 0xb8, 0xf2, 0xcc,
-	0xfc, 0x1b, 0xa8, 0x36, 0x12, 0x0a, 0xa4, 0xce, 0xae, 0x30, 0x9b, 0xe1, 0x16, 0x5a, 0x89, 0x24,
-	0xf4, 0xd4, 0xc7, 0x49, 0x3c, 0xb6, 0xce, 0x2f, 0x87, 0x25, 0x40, 0x12, 0xa7, 0xf2, 0xa1, 0xe8,
-	0x9d, 0x8b, 0xca, 0xce, 0x3f, 0x6b, 0x8e, 0xd0, 0x81, 0x0f, 0x40, 0xa6, 0x2c, 0x02, 0xfc, 0xbd,
-	0x87, 0x2e, 0x1e, 0x30, 0xa5, 0xf1, 0x8b, 0xd5, 0x52, 0x2e, 0x0a, 0xb7, 0xb9, 0x7f, 0x26, 0x12,
-	0x0c, 0x03, 0xb9, 0xfd, 0xed, 0x9f, 0x7f, 0xff, 0xb4, 0x74, 0x03, 0x5f, 0xb7, 0x5d, 0x2f, 0x6d,
-	0x97, 0x2d, 0x86, 0x81, 0xfa, 0x6e, 0xc9, 0xc3, 0x3f, 0x7a, 0xa8, 0x6e, 0x22, 0xc3, 0x0a, 0x7e,
-	0x0e, 0xba, 0x5a, 0x8b, 0x74, 0xe1, 0x21, 0x5a, 0x36, 0x51, 0xa6, 0x0b, 0xe0, 0x97, 0x4e, 0x6b,
-	0x29, 0x9a, 0x61, 0xb3, 0x35, 0x6b, 0xa9, 0x28, 0xc9, 0x2d, 0x4b, 0x41, 0xf0, 0xe6, 0x2c, 0x8a,
-	0xe0, 0xd8, 0xcc, 0x1e, 0x9b, 0x4e, 0xae, 0xf0, 0x0f, 0x1e, 0x5a, 0xff, 0x00, 0x74, 0xd9, 0xf1,
-	0xf0, 0xed, 0x19, 0x99, 0xab, 0xdd, 0xb0, 0x49, 0xe6, 0x07, 0x14, 0x02, 0xde, 0xb1, 0x02, 0xde,
-	0x22, 0xaf, 0xcf, 0x16, 0xe0, 0x3a, 0x9e, 0xcd, 0xf3, 0x30, 0x3c, 0xb0, 0x52, 0x7a, 0x2e, 0xc3,
-	0x7d, 0x6f, 0x1b, 0xa7, 0x56, 0xd2, 0x87, 0x10, 0x0f, 0xbb, 0x03, 0x2a, 0xf5, 0xdc, 0x3b, 0xd9,
-	0xa8, 0xc2, 0x65, 0x78, 0x21, 0xc2, 0xb7, 0x22, 0xb6, 0xf0, 0xdd, 0x45, 0x2e, 0x0c, 0x20, 0x1e,
-	0x46, 0x8e, 0xe6, 0x67, 0x0f, 0xd5, 0xdc, 0x23, 0xc6, 0xb7, 0x4e, 0x33, 0x4e, 0x3c, 0xee, 0xe6,
-	0xd9, 0x3c, 0x14, 0xf2, 0x8a, 0x15, 0xd8, 0x22, 0x33, 0x2b, 0xe1, 0xbe, 0x7d, 0x46, 0xa6, 0x4e,
-	0x7f, 0xf1, 0x50, 0x3d, 0xe7, 0xcf, 0xf7, 0x9e, 0x93, 0x42, 0xf2, 0x74, 0x85, 0xf8, 0x89, 0x87,
-	0x6a, 0xae, 0xab, 0x4c, 0x8b, 0x9a, 0xe8, 0x36, 0x67, 0x25, 0xaa, 0xed, 0xee, 0xb5, 0xb9, 0xa0,
-	0xba, 0xad, 0x8e, 0xc7, 0xa5, 0x85, 0xbf, 0x79, 0xa8, 0x9e, 0x6b, 0x99, 0x6f, 0xe1, 0xff, 0xa2,
-	0xd6, 0x7f, 0x3e, 0xb5, 0x98, 0xa2, 0xda, 0x1e, 0xc4, 0xa0, 0x61, 0x5e, 0xd9, 0x37, 0x4e, 0xc3,
-	0x45, 0xc1, 0xdf, 0xb5, 0x54, 0xb7, 0xb6, 0x6f, 0x2e, 0x28, 0x78, 0xe3, 0xc6, 0x00, 0xd5, 0x1d,
-	0x45, 0xc5, 0x8c, 0xe7, 0x26, 0x7b, 0xf9, 0x19, 0xc8, 0xf0, 0x31, 0xba, 0xf2, 0x19, 0x8d, 0x99,
-	0xb1, 0xd5, 0x7d, 0x29, 0xf0, 0xcd, 0xa9, 0xee, 0x51, 0x7e, 0x35, 0x16, 0xb0, 0x75, 0x2c, 0xdb,
-	0x3d, 0x72, 0x67, 0xd1, 0x5b, 0x4e, 0x33, 0x2a, 0xe7, 0xe4, 0xee, 0xee, 0xef, 0x27, 0x1b, 0xde,
-	0x1f, 0x27, 0x1b, 0xde, 0x5f, 0x27, 0x1b, 0xde, 0x17, 0x6f, 0x3e, 0xc3, 0xf7, 0x36, 0xb2, 0x1f,
-	0x82, 0xca, 0x5f, 0xf4, 0x51, 0xcd, 0x7e, 0x46, 0xdf, 0xf8, 0x37, 0x00, 0x00, 0xff, 0xff, 0xfd,
-	0xa5, 0xac, 0x6d, 0xa5, 0x0b, 0x00, 0x00,
+	// 1004 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xd6, 0xa6, 0xa9, 0x9b, 0x4c, 0x9a, 0xd6, 0x99, 0x96, 0xca, 0xb8, 0x6e, 0x1a, 0x4d, 0x43,
+	0x49, 0xa3, 0xb2, 0x8b, 0x0d, 0x48, 0xa8, 0x08, 0xa1, 0xc4, 0x41, 0x25, 0x22, 0x12, 0x65, 0xab,
+	0x22, 0xc1, 0x05, 0x4d, 0x77, 0x5f, 0xec, 0x21, 0xeb, 0x9d, 0x61, 0x66, 0x6c, 0x64, 0x45, 0xbd,
+	0x70, 0x2a, 0x47, 0x40, 0xdc, 0xb8, 0x20, 0xf5, 0xc0, 0x95, 0xbf, 0x02, 0x8e, 0x48, 0xfc, 0x03,
+	0x28, 0xe2, 0x0f, 0x41, 0x33, 0xfb, 0xcb, 0x8e, 0xed, 0x6d, 0x2b, 0x4c, 0x6e, 0x33, 0xdf, 0xcc,
+	0xbc, 0xef, 0x7b, 0xdf, 0xbc, 0x7d, 0xbb, 0x8b, 0x88, 0x02, 0x39, 0x00, 0xe9, 0x49, 0x10, 0x5c,
+	0x31, 0xcd, 0xe5, 0x70, 0x64, 0xe8, 0x0a, 0xc9, 0x35, 0xc7, 0xa8, 0x40, 0xea, 0x57, 0x3b, 0xbc,
+	0xc3, 0x2d, 0xec, 0x99, 0x51, 0xb2, 0xa3, 0xde, 0xe8, 0x70, 0xde, 0x89, 0xc0, 0xa3, 0x82, 0x79,
+	0x34, 0x8e, 0xb9, 0xa6, 0x9a, 0xf1, 0x58, 0xa5, 0xab, 0xe4, 0xe8, 0x5d, 0xe5, 0x32, 0x6e, 0x57,
+	0x03, 0x2e, 0xc1, 0x1b, 0x34, 0xbd, 0x0e, 0xc4, 0x20, 0xa9, 0x86, 0x30, 0xdd, 0xb3, 0xdf, 0x61,
+	0xba, 0xdb, 0x7f, 0xec, 0x06, 0xbc, 0xe7, 0x51, 0x69, 0x29, 0xbe, 0xb2, 0x83, 0x37, 0x82, 0xd0,
+	0x13, 0x47, 0x1d, 0x73, 0x58, 0x79, 0x54, 0x88, 0x88, 0x05, 0x36, 0xb8, 0x37, 0x68, 0xd2, 0x48,
+	0x74, 0xe9, 0x64, 0xa8, 0xdd, 0xb2, 0x50, 0x36, 0x95, 0xe7, 0xa6, 0x4c, 0x3e, 0x40, 0xab, 0x3e,
+	0x08, 0xbe, 0x23, 0x84, 0xfa, 0xb4, 0x0f, 0x72, 0x88, 0x31, 0x5a, 0x34, 0x9b, 0x6a, 0xce, 0x86,
+	0xb3, 0xb5, 0xec, 0xdb, 0x31, 0xae, 0xa3, 0x25, 0x09, 0x03, 0xa6, 0x18, 0x8f, 0x6b, 0x0b, 0x16,
+	0xcf, 0xe7, 0xa4, 0x89, 0x2e, 0xec, 0x08, 0xb1, 0x1f, 0x1f, 0x72, 0x73, 0x54, 0x0f, 0x05, 0x64,
+	0x47, 0xcd, 0xd8, 0x60, 0x82, 0xea, 0x6e, 0x7a, 0xcc, 0x8e, 0xc9, 0x31, 0xba, 0x92, 0x72, 0xee,
+	0x81, 0xa6, 0x2c, 0x4a, 0x99, 0x43, 0x54, 0x51, 0xbc, 0x2f, 0x83, 0x24, 0xc0, 0x4a, 0xeb, 0xc0,
+	0x2d, 0xf2, 0x73, 0xb3, 0xfc, 0xec, 0xe0, 0xcb, 0x20, 0x74, 0xc5, 0x51, 0xc7, 0x35, 0x56, 0xb9,
+	0x23, 0x56, 0xb9, 0x99, 0x55, 0xee, 0x4e, 0x01, 0x3e, 0xb4, 0x31, 0xfd, 0x34, 0x36, 0x79, 0x1f,
+	0x55, 0xb3, 0x84, 0x7d, 0x50, 0x82, 0xc7, 0x0a, 0xf0, 0x1d, 0x74, 0x9e, 0x69, 0xe8, 0xa9, 0x9a,
+	0xb3, 0x71, 0x6e, 0x6b, 0xa5, 0x75, 0xc5, 0x1d, 0xb1, 0x29, 0x4d, 0xce, 0x4f, 0x76, 0x90, 0x36,
+	0x5a, 0x36, 0xc7, 0x67, 0x7b, 0x45, 0xd0, 0xc5, 0x43, 0x6e, 0x08, 0xe1, 0x50, 0x82, 0x4a, 0x12,
+	0x5f, 0xf2, 0xc7, 0x30, 0xf2, 0xfb, 0x02, 0xba, 0x6c, 0x45, 0x04, 0x01, 0xa8, 0x72, 0xdf, 0xfb,
+	0x0a, 0x64, 0x4c, 0x7b, 0x90, 0xf9, 0x9e, 0xcd, 0xcd, 0x9a, 0xa0, 0x4a, 0x7d, 0xc3, 0x65, 0x58,
+	0x3b, 0x97, 0xac, 0x65, 0x73, 0xbc, 0x89, 0x56, 0x95, 0xea, 0x3e, 0x90, 0x6c, 0x40, 0x35, 0x7c,
+	0x0c, 0xc3, 0xda, 0xa2, 0xdd, 0x30, 0x0e, 0x9a, 0x08, 0x2c, 0x56, 0x10, 0xf4, 0x25, 0xd4, 0xce,
+	0x5b, 0x95, 0xf9, 0x1c, 0xdf, 0x45, 0x6b, 0x3a, 0x52, 0xed, 0x88, 0x41, 0xac, 0xdb, 0x20, 0xf5,
+	0x1e, 0xd5, 0xb4, 0x56, 0xb1, 0x51, 0x26, 0x17, 0xf0, 0x36, 0xaa, 0x8e, 0x81, 0x86, 0xf2, 0x82,
+	0xdd, 0x3c, 0x81, 0xe7, 0x45, 0xb2, 0x3c, 0x5e, 0x24, 0x36, 0x47, 0x94, 0x60, 0x36, 0xbf, 0x4d,
+	0xb4, 0x1a, 0x44, 0xbc, 0x1f, 0xee, 0x87, 0x10, 0x6b, 0xa6, 0x87, 0xb5, 0x15, 0x2b, 0x71, 0x1c,
+	0x24, 0x97, 0xd0, 0x45, 0x63, 0x64, 0x76, 0x93, 0xe4, 0x99, 0x83, 0xd6, 0x0c, 0xd0, 0x96, 0x40,
+	0x35, 0xf8, 0xf0, 0x75, 0x1f, 0x94, 0xc6, 0x9f, 0x8f, 0x78, 0xbb, 0xd2, 0xfa, 0xf0, 0x3f, 0xd4,
+	0x95, 0x9f, 0x17, 0x46, 0x7a, 0x45, 0xd7, 0x50, 0xa5, 0x2f, 0x14, 0x48, 0x9d, 0x5e, 0x74, 0x3a,
+	0xc3, 0x0d, 0xb4, 0x1c, 0x48, 0x08, 0xd5, 0x27, 0x71, 0x34, 0xb4, 0xf7, 0xb3, 0xe4, 0x17, 0x00,
+	0x89, 0x13, 0x95, 0x8f, 0x44, 0x78, 0x26, 0x2a, 0x5b, 0xbf, 0x5d, 0x46, 0x6b, 0x05, 0xf8, 0x10,
+	0xe4, 0x80, 0x05, 0x80, 0xbf, 0x73, 0xd0, 0xe2, 0x01, 0x53, 0x1a, 0xbf, 0x32, 0x5a, 0xf0, 0x79,
+	0x79, 0xd7, 0xf7, 0xe7, 0x22, 0xc1, 0x30, 0x90, 0x9b, 0xdf, 0xfe, 0xf5, 0xcf, 0x8f, 0x0b, 0xd7,
+	0xf0, 0x55, 0xdb, 0x1b, 0x07, 0xcd, 0xa2, 0x11, 0x31, 0x50, 0x4f, 0x17, 0x1c, 0xfc, 0x83, 0x83,
+	0xaa, 0x66, 0xa7, 0x3f, 0x82, 0x9f, 0x81, 0xae, 0x46, 0x99, 0x2e, 0xfc, 0xd4, 0x41, 0xe7, 0xee,
+	0xc3, 0x4c, 0x7f, 0xe6, 0x73, 0x45, 0xe4, 0x96, 0xd5, 0x70, 0x03, 0x5f, 0x9f, 0xa6, 0xc1, 0x3b,
+	0x36, 0xb3, 0x27, 0xf8, 0x99, 0x83, 0x96, 0xf6, 0x18, 0xed, 0xc4, 0x5c, 0xc1, 0x2c, 0x3d, 0x0f,
+	0xe6, 0xa2, 0x27, 0x65, 0xd1, 0x2c, 0x50, 0xc4, 0xb3, 0xd2, 0xee, 0xe0, 0xd7, 0x4b, 0xa4, 0x79,
+	0x61, 0x71, 0x00, 0xf7, 0xd0, 0x92, 0xf1, 0xd5, 0x74, 0x57, 0xfc, 0xea, 0x69, 0x95, 0xf9, 0x4b,
+	0xa6, 0xde, 0x98, 0xb6, 0x94, 0x3f, 0xc4, 0x5b, 0x96, 0x95, 0xe0, 0x8d, 0x32, 0x56, 0x6a, 0x28,
+	0xbe, 0x77, 0xd0, 0xea, 0x7d, 0xd0, 0xc5, 0x9b, 0x04, 0xdf, 0x9c, 0x12, 0x79, 0xf4, 0x2d, 0x53,
+	0x27, 0xb3, 0x37, 0xe4, 0x02, 0xde, 0xb3, 0x02, 0xde, 0x21, 0x6f, 0x4e, 0x17, 0x90, 0xbc, 0x49,
+	0x6c, 0x9c, 0x47, 0xfe, 0x81, 0x95, 0x12, 0x26, 0x11, 0xee, 0x39, 0xdb, 0x78, 0x60, 0x25, 0x7d,
+	0x04, 0x51, 0xaf, 0xdd, 0xa5, 0x52, 0xcf, 0xac, 0xe2, 0xf5, 0x51, 0xb8, 0xd8, 0x9e, 0x8b, 0x70,
+	0xad, 0x88, 0x2d, 0x7c, 0xbb, 0xcc, 0x85, 0x2e, 0x44, 0xbd, 0x20, 0xa1, 0xf9, 0xc9, 0x41, 0x95,
+	0xa4, 0xed, 0xe1, 0x1b, 0xa7, 0x19, 0xc7, 0xda, 0xe1, 0xbc, 0xea, 0xf6, 0x35, 0x2b, 0xb0, 0x41,
+	0xa6, 0x3e, 0x3b, 0xf7, 0x6c, 0xe3, 0x31, 0x4f, 0xf6, 0xcf, 0x0e, 0xaa, 0x66, 0xfc, 0xd9, 0xd9,
+	0x33, 0x52, 0x48, 0x9e, 0xaf, 0x10, 0xff, 0xe2, 0xa0, 0x4a, 0xd2, 0x87, 0x27, 0x45, 0x8d, 0xf5,
+	0xe7, 0x79, 0x89, 0x6a, 0x26, 0xf7, 0x5a, 0x2f, 0xa9, 0x6e, 0xab, 0xe3, 0x49, 0x61, 0xe1, 0xaf,
+	0x0e, 0xaa, 0x66, 0x5a, 0x66, 0x5b, 0xf8, 0xbf, 0xa8, 0x75, 0x5f, 0x4e, 0x2d, 0xa6, 0xa8, 0xb2,
+	0x07, 0x11, 0xe8, 0x99, 0x4d, 0xaa, 0x76, 0x1a, 0xce, 0x0b, 0xfe, 0x76, 0xd2, 0x07, 0xb7, 0xcb,
+	0xfa, 0xa0, 0x71, 0xa3, 0x8b, 0xaa, 0x09, 0xc5, 0x88, 0x19, 0x2f, 0x4d, 0x76, 0xeb, 0x05, 0xc8,
+	0xf0, 0x31, 0xba, 0xf4, 0x19, 0x8d, 0x98, 0xb1, 0x35, 0xf9, 0x54, 0xc3, 0xd7, 0x27, 0xba, 0x47,
+	0xf1, 0x09, 0x57, 0xc2, 0xd6, 0xb2, 0x6c, 0x77, 0xc9, 0x66, 0xd9, 0xb3, 0x3c, 0x48, 0xa9, 0x12,
+	0x27, 0x77, 0x77, 0xff, 0x38, 0x59, 0x77, 0xfe, 0x3c, 0x59, 0x77, 0xfe, 0x3e, 0x59, 0x77, 0xbe,
+	0x78, 0xfb, 0x05, 0x7e, 0x1b, 0x02, 0xfb, 0xa1, 0x55, 0xc4, 0x1e, 0x3e, 0xae, 0xd8, 0x8f, 0xfc,
+	0xb7, 0xfe, 0x1d, 0x00, 0xad, 0x6c, 0x51, 0xd5, 0xfd, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -668,6 +681,10 @@ type RepositoryServiceClient interface {
 	List(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryList, error)
 	// ListRepositories gets a list of all configured repositories
 	ListRepositories(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryList, error)
+	// Get returns a repository and the state of its connection
+	Get(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.Repository, error)
+	// Diagnose checks step by step the connection to a repository
+	Diagnose(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryDiagnostics, error)
 	// ListApps returns list of apps in the repo
 	ListApps(ctx context.Context, in *RepoAppsQuery, opts ...grpc.CallOption) (*RepoAppsResponse, error)
 	// GetAppDetails returns application details by given path
@@ -716,6 +733,24 @@ func (c *repositoryServiceClient) ListRepositories(ctx context.Context, in *Repo
 	return out, nil
 }
 
+func (c *repositoryServiceClient) Get(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	out := new(v1alpha1.Repository)
+	err := c.cc.Invoke(ctx, "/repository.RepositoryService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoryServiceClient) Diagnose(ctx context.Context, in *RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryDiagnostics, error) {
+	out := new(v1alpha1.RepositoryDiagnostics)
+	err := c.cc.Invoke(ctx, "/repository.RepositoryService/Diagnose", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *repositoryServiceClient) ListApps(ctx context.Context, in *RepoAppsQuery, opts ...grpc.CallOption) (*RepoAppsResponse, error) {
 	out := new(RepoAppsResponse)
 	err := c.cc.Invoke(ctx, "/repository.RepositoryService/ListApps", in, out, opts...)
@@ -815,6 +850,10 @@ type RepositoryServiceServer interface {
 	List(context.Context, *RepoQuery) (*v1alpha1.RepositoryList, error)
 	// ListRepositories gets a list of all configured repositories
 	ListRepositories(context.Context, *RepoQuery) (*v1alpha1.RepositoryList, error)
+	// Get returns a repository and the state of its connection
+	Get(context.Context, *RepoQuery) (*v1alpha1.Repository, error)
+	// Diagnose checks step by step the connection to a repository
+	Diagnose(context.Context, *RepoQuery) (*v1alpha1.RepositoryDiagnostics, error)
 	// ListApps returns list of apps in the repo
 	ListApps(context.Context, *RepoAppsQuery) (*RepoAppsResponse, error)
 	// GetAppDetails returns application details by given path
@@ -846,6 +885,12 @@ func (*UnimplementedRepositoryServiceServer) List(ctx context.Context, req *Repo
 func (*UnimplementedRepositoryServiceServer) ListRepositories(ctx context.Context, req *RepoQuery) (*v1alpha1.RepositoryList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRepositories not implemented")
 }
+func (*UnimplementedRepositoryServiceServer) Get(ctx context.Context, req *RepoQuery) (*v1alpha1.Repository, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedRepositoryServiceServer) Diagnose(ctx context.Context, req *RepoQuery) (*v1alpha1.RepositoryDiagnostics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diagnose not implemented")
+}
 func (*UnimplementedRepositoryServiceServer) ListApps(ctx context.Context, req *RepoAppsQuery) (*RepoAppsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListApps not implemented")
 }
@@ -917,6 +962,42 @@ func _RepositoryService_ListRepositories_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoryService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.RepositoryService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).Get(ctx, req.(*RepoQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoryService_Diagnose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).Diagnose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.RepositoryService/Diagnose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).Diagnose(ctx, req.(*RepoQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RepositoryService_ListApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RepoAppsQuery)
 	if err := dec(in); err != nil {
@@ -1109,6 +1190,14 @@ var _RepositoryService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListRepositories",
 			Handler:    _RepositoryService_ListRepositories_Handler,
 		},
+		{
+			MethodName: "Get",
+			Handler:    _RepositoryService_Get_Handler,
+		},
+		{
+			MethodName: "Diagnose",
+			Handler:    _RepositoryService_Diagnose_Handler,
+		},
 		{
 			MethodName: "ListApps",
 			Handler:    _RepositoryService_ListApps_Handler,
@@ -1384,6 +1473,16 @@ func (m *RepoAccessQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.CloudIdentity {
+		i--
+		if m.CloudIdentity {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -1723,6 +1822,9 @@ func (m *RepoAccessQuery) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRepository(uint64(l))
 	}
+	if m.CloudIdentity {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2610,6 +2712,26 @@ func (m *RepoAccessQuery) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CloudIdentity", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CloudIdentity = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRepository(dAtA[iNdEx:])
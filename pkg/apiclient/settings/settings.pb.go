@@ -84,12 +84,14 @@ type Settings struct {
 	GoogleAnalytics    *GoogleAnalyticsConfig                `protobuf:"bytes,7,opt,name=googleAnalytics,proto3" json:"googleAnalytics,omitempty"`
 	KustomizeOptions   *v1alpha1.KustomizeOptions            `protobuf:"bytes,8,opt,name=kustomizeOptions,proto3" json:"kustomizeOptions,omitempty"`
 	// Help settings
-	Help                 *Help     `protobuf:"bytes,9,opt,name=help,proto3" json:"help,omitempty"`
-	Plugins              []*Plugin `protobuf:"bytes,10,rep,name=plugins,proto3" json:"plugins,omitempty"`
-	UserLoginsDisabled   bool      `protobuf:"varint,11,opt,name=userLoginsDisabled,proto3" json:"userLoginsDisabled,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Help               *Help     `protobuf:"bytes,9,opt,name=help,proto3" json:"help,omitempty"`
+	Plugins            []*Plugin `protobuf:"bytes,10,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	UserLoginsDisabled bool      `protobuf:"varint,11,opt,name=userLoginsDisabled,proto3" json:"userLoginsDisabled,omitempty"`
+	// OIDC providers users may log in with besides the one in oidcConfig
+	AdditionalOIDCConfigs []*OIDCConfig `protobuf:"bytes,12,rep,name=additionalOIDCConfigs,proto3" json:"additionalOIDCConfigs,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}      `json:"-"`
+	XXX_unrecognized      []byte        `json:"-"`
+	XXX_sizecache         int32         `json:"-"`
 }
 
 func (m *Settings) Reset()         { *m = Settings{} }
@@ -202,6 +204,13 @@ func (m *Settings) GetUserLoginsDisabled() bool {
 	return false
 }
 
+func (m *Settings) GetAdditionalOIDCConfigs() []*OIDCConfig {
+	if m != nil {
+		return m.AdditionalOIDCConfigs
+	}
+	return nil
+}
+
 type GoogleAnalyticsConfig struct {
 	TrackingID           string   `protobuf:"bytes,1,opt,name=trackingID,proto3" json:"trackingID,omitempty"`
 	AnonymizeUsers       bool     `protobuf:"varint,2,opt,name=anonymizeUsers,proto3" json:"anonymizeUsers,omitempty"`
@@ -467,15 +476,16 @@ func (m *Connector) GetType() string {
 }
 
 type OIDCConfig struct {
-	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Issuer               string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
-	ClientID             string                 `protobuf:"bytes,3,opt,name=clientID,proto3" json:"clientID,omitempty"`
-	CLIClientID          string                 `protobuf:"bytes,4,opt,name=cliClientID,proto3" json:"cliClientID,omitempty"`
-	Scopes               []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
-	IDTokenClaims        map[string]*oidc.Claim `protobuf:"bytes,6,rep,name=idTokenClaims,proto3" json:"idTokenClaims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Name                     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Issuer                   string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientID                 string                 `protobuf:"bytes,3,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	CLIClientID              string                 `protobuf:"bytes,4,opt,name=cliClientID,proto3" json:"cliClientID,omitempty"`
+	Scopes                   []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	IDTokenClaims            map[string]*oidc.Claim `protobuf:"bytes,6,rep,name=idTokenClaims,proto3" json:"idTokenClaims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EnablePKCEAuthentication bool                   `protobuf:"varint,7,opt,name=enablePKCEAuthentication,proto3" json:"enablePKCEAuthentication,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}               `json:"-"`
+	XXX_unrecognized         []byte                 `json:"-"`
+	XXX_sizecache            int32                  `json:"-"`
 }
 
 func (m *OIDCConfig) Reset()         { *m = OIDCConfig{} }
@@ -553,471 +563,807 @@ func (m *OIDCConfig) GetIDTokenClaims() map[string]*oidc.Claim {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*SettingsQuery)(nil), "cluster.SettingsQuery")
-	proto.RegisterType((*Settings)(nil), "cluster.Settings")
-	proto.RegisterMapType((map[string]*v1alpha1.ResourceOverride)(nil), "cluster.Settings.ResourceOverridesEntry")
-	proto.RegisterType((*GoogleAnalyticsConfig)(nil), "cluster.GoogleAnalyticsConfig")
-	proto.RegisterType((*Help)(nil), "cluster.Help")
-	proto.RegisterType((*Plugin)(nil), "cluster.Plugin")
-	proto.RegisterType((*DexConfig)(nil), "cluster.DexConfig")
-	proto.RegisterType((*Connector)(nil), "cluster.Connector")
-	proto.RegisterType((*OIDCConfig)(nil), "cluster.OIDCConfig")
-	proto.RegisterMapType((map[string]*oidc.Claim)(nil), "cluster.OIDCConfig.IdTokenClaimsEntry")
+func (m *OIDCConfig) GetEnablePKCEAuthentication() bool {
+	if m != nil {
+		return m.EnablePKCEAuthentication
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("server/settings/settings.proto", fileDescriptor_a480d494da040caa) }
+// RBACPolicyValidateRequest is a request to validate an RBAC policy
+type RBACPolicyValidateRequest struct {
+	// the CSV policy to validate, the policy configured in argocd-rbac-cm if empty
+	Policy               string   `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-var fileDescriptor_a480d494da040caa = []byte{
-	// 877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0x23, 0x45,
-	0x10, 0xd6, 0xc4, 0x59, 0xff, 0x94, 0xc9, 0x3a, 0x69, 0x20, 0x1a, 0xac, 0x95, 0x6d, 0xe6, 0xb0,
-	0x32, 0x07, 0x66, 0x88, 0xf7, 0x00, 0x42, 0x20, 0xc0, 0xf6, 0x6a, 0xd7, 0x24, 0x28, 0xd0, 0xbb,
-	0xe1, 0x80, 0x84, 0xa2, 0xce, 0x4c, 0x33, 0x69, 0x3c, 0xe9, 0x1e, 0x75, 0xf7, 0x98, 0x35, 0x47,
-	0x6e, 0x1c, 0x11, 0x47, 0x5e, 0x88, 0x23, 0x12, 0x77, 0x0b, 0x59, 0x3c, 0x08, 0x9a, 0x9e, 0x9f,
-	0x4c, 0x6c, 0x2b, 0x42, 0xda, 0x5b, 0x75, 0x7d, 0xf5, 0x55, 0x55, 0x57, 0x57, 0x57, 0x41, 0x4f,
-	0x51, 0xb9, 0xa0, 0xd2, 0x53, 0x54, 0x6b, 0xc6, 0x43, 0x55, 0x0a, 0x6e, 0x2c, 0x85, 0x16, 0xa8,
-	0xe1, 0x47, 0x89, 0xd2, 0x54, 0x76, 0xdf, 0x0a, 0x45, 0x28, 0x8c, 0xce, 0x4b, 0xa5, 0x0c, 0xee,
-	0x3e, 0x0a, 0x85, 0x08, 0x23, 0xea, 0x91, 0x98, 0x79, 0x84, 0x73, 0xa1, 0x89, 0x66, 0x82, 0xe7,
-	0xe4, 0xee, 0x2c, 0x64, 0xfa, 0x3a, 0xb9, 0x72, 0x7d, 0x71, 0xe3, 0x11, 0x69, 0xe8, 0x3f, 0x1a,
-	0xe1, 0x7d, 0x3f, 0xf0, 0xe2, 0x79, 0x98, 0xd2, 0x94, 0x47, 0xe2, 0x38, 0x62, 0xbe, 0x21, 0x7a,
-	0x8b, 0x13, 0x12, 0xc5, 0xd7, 0xe4, 0xc4, 0x0b, 0x29, 0xa7, 0x92, 0x68, 0x1a, 0xe4, 0xae, 0x3e,
-	0xbd, 0xcf, 0xd5, 0xe6, 0x1d, 0x04, 0x0b, 0x7c, 0xcf, 0x8f, 0x08, 0xbb, 0xc9, 0x33, 0x71, 0x3a,
-	0x70, 0xf0, 0x22, 0x47, 0xbf, 0x49, 0xa8, 0x5c, 0x3a, 0x7f, 0xd4, 0xa1, 0x59, 0x68, 0xd0, 0x3b,
-	0x50, 0x4b, 0x64, 0x64, 0x5b, 0x03, 0x6b, 0xd8, 0x1a, 0x37, 0xd6, 0xab, 0x7e, 0xed, 0x02, 0x9f,
-	0xe1, 0x54, 0x87, 0x3e, 0x80, 0x56, 0x40, 0x5f, 0x4d, 0x04, 0xff, 0x81, 0x85, 0xf6, 0xde, 0xc0,
-	0x1a, 0xb6, 0x47, 0xc8, 0xcd, 0x6b, 0xe2, 0x4e, 0x0b, 0x04, 0xdf, 0x1a, 0xa1, 0x09, 0x40, 0x1a,
-	0x3f, 0xa7, 0xd4, 0x0c, 0xe5, 0xcd, 0x92, 0x72, 0x3e, 0x9b, 0x4e, 0x32, 0x68, 0xfc, 0x70, 0xbd,
-	0xea, 0xc3, 0xed, 0x19, 0x57, 0x68, 0x68, 0x00, 0x6d, 0x12, 0xc7, 0x67, 0xe4, 0x8a, 0x46, 0xa7,
-	0x74, 0x69, 0xef, 0xa7, 0x99, 0xe1, 0xaa, 0x0a, 0x7d, 0x0b, 0x47, 0x92, 0x2a, 0x91, 0x48, 0x9f,
-	0x9e, 0x2f, 0xa8, 0x94, 0x2c, 0xa0, 0xca, 0x7e, 0x30, 0xa8, 0x0d, 0xdb, 0xa3, 0x61, 0x19, 0xad,
-	0xb8, 0xa1, 0x8b, 0x37, 0x4d, 0x9f, 0x72, 0x2d, 0x97, 0x78, 0xdb, 0x05, 0x72, 0x01, 0x29, 0x4d,
-	0x74, 0xa2, 0xc6, 0x24, 0x08, 0xe9, 0x53, 0x4e, 0xae, 0x22, 0x1a, 0xd8, 0xf5, 0x81, 0x35, 0x6c,
-	0xe2, 0x1d, 0x08, 0x7a, 0x0e, 0x9d, 0xac, 0x07, 0xbe, 0xe0, 0x24, 0x5a, 0x6a, 0xe6, 0x2b, 0xbb,
-	0x61, 0xee, 0xdc, 0x2b, 0xb3, 0x78, 0x76, 0x17, 0xcf, 0xaf, 0xbb, 0x49, 0x43, 0x3f, 0xc1, 0xe1,
-	0x3c, 0x51, 0x5a, 0xdc, 0xb0, 0x9f, 0xe9, 0x79, 0x6c, 0xfa, 0xc8, 0x6e, 0x1a, 0x57, 0xa7, 0xee,
-	0xed, 0xeb, 0xbb, 0xc5, 0xeb, 0x1b, 0xe1, 0xd2, 0x0f, 0xdc, 0x78, 0x1e, 0xba, 0x69, 0x23, 0xb9,
-	0x95, 0x46, 0x72, 0x8b, 0x46, 0x72, 0x4f, 0x37, 0x5c, 0xe2, 0xad, 0x20, 0xe8, 0x5d, 0xd8, 0xbf,
-	0xa6, 0x51, 0x6c, 0xb7, 0x4c, 0xb0, 0x83, 0x32, 0xef, 0xe7, 0x34, 0x8a, 0xb1, 0x81, 0xd0, 0x7b,
-	0xd0, 0x88, 0xa3, 0x24, 0x64, 0x5c, 0xd9, 0x60, 0x6a, 0xdc, 0x29, 0xad, 0xbe, 0x36, 0x7a, 0x5c,
-	0xe0, 0x69, 0x01, 0x13, 0x45, 0xe5, 0x99, 0x48, 0x4f, 0x53, 0xa6, 0xb2, 0x02, 0xb6, 0xb3, 0x02,
-	0x6e, 0x23, 0xdd, 0xdf, 0x2c, 0x38, 0xde, 0xfd, 0x3c, 0xe8, 0x10, 0x6a, 0x73, 0xba, 0xcc, 0xfa,
-	0x12, 0xa7, 0x22, 0x22, 0xf0, 0x60, 0x41, 0xa2, 0x84, 0xe6, 0xad, 0xf8, 0x3a, 0x85, 0xd9, 0x8c,
-	0x89, 0x33, 0xcf, 0x1f, 0xef, 0x7d, 0x64, 0x39, 0x97, 0xf0, 0xf6, 0xce, 0x47, 0x43, 0x3d, 0x00,
-	0x2d, 0x89, 0x3f, 0x67, 0x3c, 0x9c, 0x4d, 0xf3, 0xc4, 0x2a, 0x1a, 0xf4, 0x18, 0x1e, 0x12, 0x2e,
-	0xf8, 0x32, 0x2d, 0xef, 0x85, 0xa2, 0x52, 0x99, 0x44, 0x9b, 0x78, 0x43, 0xeb, 0x7c, 0x02, 0xfb,
-	0x69, 0x75, 0x91, 0x0d, 0x0d, 0xff, 0x9a, 0xe8, 0x8b, 0xe2, 0xf7, 0xe1, 0xe2, 0x88, 0xba, 0xd0,
-	0x4c, 0xc5, 0x97, 0xf4, 0x95, 0x36, 0x3e, 0x5a, 0xb8, 0x3c, 0x3b, 0x8f, 0xa0, 0x9e, 0x55, 0x1d,
-	0x21, 0xd8, 0xe7, 0xe4, 0x86, 0xe6, 0x64, 0x23, 0x3b, 0x9f, 0x41, 0xab, 0xfc, 0x98, 0x68, 0x04,
-	0xe0, 0x0b, 0xce, 0xa9, 0xaf, 0x85, 0x54, 0xb6, 0x65, 0xde, 0xee, 0xf6, 0x03, 0x4f, 0x0a, 0x08,
-	0x57, 0xac, 0x9c, 0x27, 0xd0, 0x2a, 0x81, 0x5d, 0x11, 0x52, 0x9d, 0x5e, 0xc6, 0x34, 0xcf, 0xcb,
-	0xc8, 0xce, 0xaf, 0x35, 0xa8, 0x7c, 0xe6, 0x9d, 0xb4, 0x63, 0xa8, 0x33, 0xa5, 0x12, 0x2a, 0x73,
-	0x62, 0x7e, 0x42, 0x43, 0x68, 0xfa, 0x11, 0xa3, 0x5c, 0xcf, 0xa6, 0x66, 0x5e, 0xb4, 0xc6, 0x6f,
-	0xac, 0x57, 0xfd, 0xe6, 0x24, 0xd7, 0xe1, 0x12, 0x45, 0x27, 0xd0, 0xf6, 0x23, 0x56, 0x00, 0xd9,
-	0x58, 0x18, 0x77, 0xd6, 0xab, 0x7e, 0x7b, 0x72, 0x36, 0x2b, 0xed, 0xab, 0x36, 0x69, 0x50, 0xe5,
-	0x8b, 0x38, 0x1f, 0x0e, 0x2d, 0x9c, 0x9f, 0xd0, 0x25, 0x1c, 0xb0, 0xe0, 0xa5, 0x98, 0x53, 0x3e,
-	0x31, 0x83, 0xd2, 0xae, 0x9b, 0xda, 0x3c, 0xde, 0x31, 0xa9, 0xdc, 0x59, 0xd5, 0xd0, 0xb4, 0xe6,
-	0xf8, 0x68, 0xbd, 0xea, 0x1f, 0xcc, 0xa6, 0x15, 0x3d, 0xbe, 0xeb, 0xaf, 0xbb, 0x04, 0xb4, 0xcd,
-	0xdb, 0xd1, 0xd2, 0x5f, 0xdd, 0x6d, 0xe9, 0x0f, 0xef, 0x6d, 0xe9, 0x6c, 0xd2, 0xbb, 0xe5, 0x92,
-	0x4a, 0x47, 0xa6, 0x6b, 0xfc, 0x57, 0xda, 0x77, 0xf4, 0x3d, 0x74, 0x8a, 0xc9, 0xf7, 0x82, 0xca,
-	0x05, 0xf3, 0x29, 0xfa, 0x12, 0x6a, 0xcf, 0xa8, 0x46, 0xc7, 0x5b, 0xa3, 0xd1, 0xac, 0x83, 0xee,
-	0xd1, 0x96, 0xde, 0xb1, 0x7f, 0xf9, 0xfb, 0xdf, 0xdf, 0xf7, 0x10, 0x3a, 0x34, 0xcb, 0x6d, 0x71,
-	0x52, 0xae, 0x97, 0xf1, 0xe7, 0x7f, 0xae, 0x7b, 0xd6, 0x5f, 0xeb, 0x9e, 0xf5, 0xcf, 0xba, 0x67,
-	0x7d, 0x37, 0xfa, 0x1f, 0x4b, 0x2e, 0x7b, 0xc0, 0xd2, 0xc3, 0x55, 0xdd, 0x6c, 0xa5, 0x27, 0xff,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x47, 0xbe, 0x4d, 0x7e, 0x07, 0x00, 0x00,
+func (m *RBACPolicyValidateRequest) Reset()         { *m = RBACPolicyValidateRequest{} }
+func (m *RBACPolicyValidateRequest) String() string { return proto.CompactTextString(m) }
+func (*RBACPolicyValidateRequest) ProtoMessage()    {}
+func (*RBACPolicyValidateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{8}
+}
+func (m *RBACPolicyValidateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RBACPolicyValidateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RBACPolicyValidateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RBACPolicyValidateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACPolicyValidateRequest.Merge(m, src)
+}
+func (m *RBACPolicyValidateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RBACPolicyValidateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACPolicyValidateRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_RBACPolicyValidateRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *RBACPolicyValidateRequest) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
 
-// SettingsServiceClient is the client API for SettingsService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SettingsServiceClient interface {
-	// Get returns Argo CD settings
-	Get(ctx context.Context, in *SettingsQuery, opts ...grpc.CallOption) (*Settings, error)
+// RBACPolicyError is an error of a line of an RBAC policy
+type RBACPolicyError struct {
+	// the line of the policy, 0 for errors which do not relate to a single line
+	Line                 int32    `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type settingsServiceClient struct {
-	cc *grpc.ClientConn
+func (m *RBACPolicyError) Reset()         { *m = RBACPolicyError{} }
+func (m *RBACPolicyError) String() string { return proto.CompactTextString(m) }
+func (*RBACPolicyError) ProtoMessage()    {}
+func (*RBACPolicyError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{9}
+}
+func (m *RBACPolicyError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RBACPolicyError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RBACPolicyError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RBACPolicyError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACPolicyError.Merge(m, src)
+}
+func (m *RBACPolicyError) XXX_Size() int {
+	return m.Size()
+}
+func (m *RBACPolicyError) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACPolicyError.DiscardUnknown(m)
 }
 
-func NewSettingsServiceClient(cc *grpc.ClientConn) SettingsServiceClient {
-	return &settingsServiceClient{cc}
+var xxx_messageInfo_RBACPolicyError proto.InternalMessageInfo
+
+func (m *RBACPolicyError) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
 }
 
-func (c *settingsServiceClient) Get(ctx context.Context, in *SettingsQuery, opts ...grpc.CallOption) (*Settings, error) {
-	out := new(Settings)
-	err := c.cc.Invoke(ctx, "/cluster.SettingsService/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RBACPolicyError) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return out, nil
+	return ""
 }
 
-// SettingsServiceServer is the server API for SettingsService service.
-type SettingsServiceServer interface {
-	// Get returns Argo CD settings
-	Get(context.Context, *SettingsQuery) (*Settings, error)
+type RBACPolicyValidateResponse struct {
+	Errors               []*RBACPolicyError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-// UnimplementedSettingsServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedSettingsServiceServer struct {
+func (m *RBACPolicyValidateResponse) Reset()         { *m = RBACPolicyValidateResponse{} }
+func (m *RBACPolicyValidateResponse) String() string { return proto.CompactTextString(m) }
+func (*RBACPolicyValidateResponse) ProtoMessage()    {}
+func (*RBACPolicyValidateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{10}
+}
+func (m *RBACPolicyValidateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RBACPolicyValidateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RBACPolicyValidateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RBACPolicyValidateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACPolicyValidateResponse.Merge(m, src)
+}
+func (m *RBACPolicyValidateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RBACPolicyValidateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACPolicyValidateResponse.DiscardUnknown(m)
 }
 
-func (*UnimplementedSettingsServiceServer) Get(ctx context.Context, req *SettingsQuery) (*Settings, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+var xxx_messageInfo_RBACPolicyValidateResponse proto.InternalMessageInfo
+
+func (m *RBACPolicyValidateResponse) GetErrors() []*RBACPolicyError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
 }
 
-func RegisterSettingsServiceServer(s *grpc.Server, srv SettingsServiceServer) {
-	s.RegisterService(&_SettingsService_serviceDesc, srv)
+// RBACPolicyCanRequest asks whether a subject may perform an action on an object of a resource
+type RBACPolicyCanRequest struct {
+	// the CSV policy to enforce, the policy configured in argocd-rbac-cm if empty
+	Policy string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// the default role to enforce, ignored unless policy is set
+	DefaultRole string `protobuf:"bytes,2,opt,name=defaultRole,proto3" json:"defaultRole,omitempty"`
+	// the user, group or role to check the permissions of
+	Subject              string   `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Resource             string   `protobuf:"bytes,4,opt,name=resource,proto3" json:"resource,omitempty"`
+	Action               string   `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Object               string   `protobuf:"bytes,6,opt,name=object,proto3" json:"object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _SettingsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SettingsQuery)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SettingsServiceServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cluster.SettingsService/Get",
+func (m *RBACPolicyCanRequest) Reset()         { *m = RBACPolicyCanRequest{} }
+func (m *RBACPolicyCanRequest) String() string { return proto.CompactTextString(m) }
+func (*RBACPolicyCanRequest) ProtoMessage()    {}
+func (*RBACPolicyCanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{11}
+}
+func (m *RBACPolicyCanRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RBACPolicyCanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RBACPolicyCanRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SettingsServiceServer).Get(ctx, req.(*SettingsQuery))
+}
+func (m *RBACPolicyCanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACPolicyCanRequest.Merge(m, src)
+}
+func (m *RBACPolicyCanRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RBACPolicyCanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACPolicyCanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RBACPolicyCanRequest proto.InternalMessageInfo
+
+func (m *RBACPolicyCanRequest) GetPolicy() string {
+	if m != nil {
+		return m.Policy
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _SettingsService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cluster.SettingsService",
-	HandlerType: (*SettingsServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Get",
-			Handler:    _SettingsService_Get_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "server/settings/settings.proto",
+func (m *RBACPolicyCanRequest) GetDefaultRole() string {
+	if m != nil {
+		return m.DefaultRole
+	}
+	return ""
 }
 
-func (m *SettingsQuery) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RBACPolicyCanRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SettingsQuery) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RBACPolicyCanRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
 }
 
-func (m *SettingsQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *RBACPolicyCanRequest) GetAction() string {
+	if m != nil {
+		return m.Action
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *Settings) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RBACPolicyCanRequest) GetObject() string {
+	if m != nil {
+		return m.Object
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Settings) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RBACPolicyCanResponse struct {
+	Allowed              bool     `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Settings) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.UserLoginsDisabled {
-		i--
-		if m.UserLoginsDisabled {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x58
-	}
-	if len(m.Plugins) > 0 {
-		for iNdEx := len(m.Plugins) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Plugins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintSettings(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x52
+func (m *RBACPolicyCanResponse) Reset()         { *m = RBACPolicyCanResponse{} }
+func (m *RBACPolicyCanResponse) String() string { return proto.CompactTextString(m) }
+func (*RBACPolicyCanResponse) ProtoMessage()    {}
+func (*RBACPolicyCanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{12}
+}
+func (m *RBACPolicyCanResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RBACPolicyCanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RBACPolicyCanResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Help != nil {
-		{
-			size, err := m.Help.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSettings(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x4a
-	}
-	if m.KustomizeOptions != nil {
-		{
-			size, err := m.KustomizeOptions.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSettings(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
-	}
-	if m.GoogleAnalytics != nil {
-		{
-			size, err := m.GoogleAnalytics.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSettings(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
-	}
-	if m.StatusBadgeEnabled {
-		i--
-		if m.StatusBadgeEnabled {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x30
-	}
-	if len(m.ResourceOverrides) > 0 {
-		for k := range m.ResourceOverrides {
-			v := m.ResourceOverrides[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintSettings(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintSettings(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintSettings(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.AppLabelKey) > 0 {
-		i -= len(m.AppLabelKey)
-		copy(dAtA[i:], m.AppLabelKey)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.AppLabelKey)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.OIDCConfig != nil {
-		{
-			size, err := m.OIDCConfig.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSettings(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.DexConfig != nil {
-		{
-			size, err := m.DexConfig.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSettings(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.URL) > 0 {
-		i -= len(m.URL)
-		copy(dAtA[i:], m.URL)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.URL)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+}
+func (m *RBACPolicyCanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACPolicyCanResponse.Merge(m, src)
+}
+func (m *RBACPolicyCanResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RBACPolicyCanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACPolicyCanResponse.DiscardUnknown(m)
 }
 
-func (m *GoogleAnalyticsConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RBACPolicyCanResponse proto.InternalMessageInfo
+
+func (m *RBACPolicyCanResponse) GetAllowed() bool {
+	if m != nil {
+		return m.Allowed
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *GoogleAnalyticsConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// LogLevelsQuery is a query for the log levels of the components
+type LogLevelsQuery struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GoogleAnalyticsConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.AnonymizeUsers {
-		i--
-		if m.AnonymizeUsers {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *LogLevelsQuery) Reset()         { *m = LogLevelsQuery{} }
+func (m *LogLevelsQuery) String() string { return proto.CompactTextString(m) }
+func (*LogLevelsQuery) ProtoMessage()    {}
+func (*LogLevelsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{13}
+}
+func (m *LogLevelsQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogLevelsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogLevelsQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.TrackingID) > 0 {
-		i -= len(m.TrackingID)
-		copy(dAtA[i:], m.TrackingID)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.TrackingID)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *Help) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogLevelsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelsQuery.Merge(m, src)
 }
-
-func (m *Help) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogLevelsQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogLevelsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelsQuery.DiscardUnknown(m)
 }
 
-func (m *Help) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ChatText) > 0 {
-		i -= len(m.ChatText)
-		copy(dAtA[i:], m.ChatText)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.ChatText)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ChatUrl) > 0 {
-		i -= len(m.ChatUrl)
-		copy(dAtA[i:], m.ChatUrl)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.ChatUrl)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_LogLevelsQuery proto.InternalMessageInfo
+
+type LogLevels struct {
+	// the levels overriding the levels set by the flags of each component, e.g. info,git=debug
+	Components map[string]string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the modules whose level can be set independently of the level of the component
+	Modules              []string `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Plugin) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogLevels) Reset()         { *m = LogLevels{} }
+func (m *LogLevels) String() string { return proto.CompactTextString(m) }
+func (*LogLevels) ProtoMessage()    {}
+func (*LogLevels) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{14}
+}
+func (m *LogLevels) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogLevels) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogLevels.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *Plugin) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogLevels) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevels.Merge(m, src)
+}
+func (m *LogLevels) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogLevels) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevels.DiscardUnknown(m)
 }
 
-func (m *Plugin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_LogLevels proto.InternalMessageInfo
+
+func (m *LogLevels) GetComponents() map[string]string {
+	if m != nil {
+		return m.Components
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *LogLevels) GetModules() []string {
+	if m != nil {
+		return m.Modules
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DexConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
+// LogLevelsUpdateRequest overrides the log levels of a component
+type LogLevelsUpdateRequest struct {
+	// one of server, repo-server or application-controller
+	Component string `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	// the level of the component and the levels of modules, e.g. info,git=debug. Restores the levels set by the flags if empty.
+	Levels               string   `protobuf:"bytes,2,opt,name=levels,proto3" json:"levels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogLevelsUpdateRequest) Reset()         { *m = LogLevelsUpdateRequest{} }
+func (m *LogLevelsUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*LogLevelsUpdateRequest) ProtoMessage()    {}
+func (*LogLevelsUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a480d494da040caa, []int{15}
+}
+func (m *LogLevelsUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogLevelsUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogLevelsUpdateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogLevelsUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelsUpdateRequest.Merge(m, src)
+}
+func (m *LogLevelsUpdateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogLevelsUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelsUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLevelsUpdateRequest proto.InternalMessageInfo
+
+func (m *LogLevelsUpdateRequest) GetComponent() string {
+	if m != nil {
+		return m.Component
+	}
+	return ""
+}
+
+func (m *LogLevelsUpdateRequest) GetLevels() string {
+	if m != nil {
+		return m.Levels
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SettingsQuery)(nil), "cluster.SettingsQuery")
+	proto.RegisterType((*Settings)(nil), "cluster.Settings")
+	proto.RegisterMapType((map[string]*v1alpha1.ResourceOverride)(nil), "cluster.Settings.ResourceOverridesEntry")
+	proto.RegisterType((*GoogleAnalyticsConfig)(nil), "cluster.GoogleAnalyticsConfig")
+	proto.RegisterType((*Help)(nil), "cluster.Help")
+	proto.RegisterType((*Plugin)(nil), "cluster.Plugin")
+	proto.RegisterType((*DexConfig)(nil), "cluster.DexConfig")
+	proto.RegisterType((*Connector)(nil), "cluster.Connector")
+	proto.RegisterType((*OIDCConfig)(nil), "cluster.OIDCConfig")
+	proto.RegisterMapType((map[string]*oidc.Claim)(nil), "cluster.OIDCConfig.IdTokenClaimsEntry")
+	proto.RegisterType((*RBACPolicyValidateRequest)(nil), "cluster.RBACPolicyValidateRequest")
+	proto.RegisterType((*RBACPolicyError)(nil), "cluster.RBACPolicyError")
+	proto.RegisterType((*RBACPolicyValidateResponse)(nil), "cluster.RBACPolicyValidateResponse")
+	proto.RegisterType((*RBACPolicyCanRequest)(nil), "cluster.RBACPolicyCanRequest")
+	proto.RegisterType((*RBACPolicyCanResponse)(nil), "cluster.RBACPolicyCanResponse")
+	proto.RegisterType((*LogLevelsQuery)(nil), "cluster.LogLevelsQuery")
+	proto.RegisterType((*LogLevels)(nil), "cluster.LogLevels")
+	proto.RegisterMapType((map[string]string)(nil), "cluster.LogLevels.ComponentsEntry")
+	proto.RegisterType((*LogLevelsUpdateRequest)(nil), "cluster.LogLevelsUpdateRequest")
+}
+
+func init() { proto.RegisterFile("server/settings/settings.proto", fileDescriptor_a480d494da040caa) }
+
+var fileDescriptor_a480d494da040caa = []byte{
+	// 1336 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5d, 0x6f, 0x1b, 0x45,
+	0x17, 0xd6, 0xc6, 0xf9, 0xb0, 0x4f, 0x9a, 0x3a, 0x9d, 0xb7, 0xc9, 0xbb, 0xb1, 0xf2, 0xda, 0x7e,
+	0xb7, 0xa8, 0x4a, 0x8b, 0x58, 0x37, 0xe9, 0x05, 0xa8, 0xa2, 0x2a, 0xb1, 0x13, 0xb5, 0x26, 0xa1,
+	0x2d, 0xd3, 0xa6, 0x42, 0xdc, 0x44, 0x93, 0xdd, 0xe9, 0x66, 0x9b, 0xc9, 0xce, 0xb2, 0x33, 0xeb,
+	0xd6, 0x20, 0x2e, 0xe0, 0x8a, 0x5b, 0xc4, 0x9f, 0xe0, 0x17, 0x70, 0xc1, 0x2f, 0xe0, 0x12, 0x89,
+	0x7b, 0x0b, 0x19, 0x7e, 0x08, 0x9a, 0xd9, 0x0f, 0x6f, 0xec, 0x4d, 0x41, 0xe2, 0x6e, 0xce, 0xe7,
+	0x73, 0xf6, 0xcc, 0x33, 0x67, 0x66, 0xa1, 0x29, 0x68, 0x34, 0xa0, 0x51, 0x47, 0x50, 0x29, 0xfd,
+	0xc0, 0x13, 0xf9, 0xc2, 0x0e, 0x23, 0x2e, 0x39, 0x5a, 0x72, 0x58, 0x2c, 0x24, 0x8d, 0x1a, 0xd7,
+	0x3d, 0xee, 0x71, 0xad, 0xeb, 0xa8, 0x55, 0x62, 0x6e, 0x6c, 0x7a, 0x9c, 0x7b, 0x8c, 0x76, 0x48,
+	0xe8, 0x77, 0x48, 0x10, 0x70, 0x49, 0xa4, 0xcf, 0x83, 0x34, 0xb8, 0xd1, 0xf7, 0x7c, 0x79, 0x1a,
+	0x9f, 0xd8, 0x0e, 0x3f, 0xef, 0x90, 0x48, 0x87, 0xbf, 0xd2, 0x8b, 0xf7, 0x1c, 0xb7, 0x13, 0x9e,
+	0x79, 0x2a, 0x4c, 0x74, 0x48, 0x18, 0x32, 0xdf, 0xd1, 0x81, 0x9d, 0xc1, 0x36, 0x61, 0xe1, 0x29,
+	0xd9, 0xee, 0x78, 0x34, 0xa0, 0x11, 0x91, 0xd4, 0x4d, 0x53, 0xdd, 0x7f, 0x5b, 0xaa, 0xe9, 0x6f,
+	0xe0, 0xbe, 0xeb, 0x74, 0x1c, 0x46, 0xfc, 0xf3, 0xb4, 0x12, 0xab, 0x0e, 0x2b, 0xcf, 0x52, 0xeb,
+	0xa7, 0x31, 0x8d, 0x86, 0xd6, 0x37, 0x4b, 0x50, 0xcd, 0x34, 0x68, 0x03, 0x2a, 0x71, 0xc4, 0x4c,
+	0xa3, 0x6d, 0x6c, 0xd5, 0xba, 0x4b, 0xe3, 0x51, 0xab, 0x72, 0x84, 0x0f, 0xb1, 0xd2, 0xa1, 0x3b,
+	0x50, 0x73, 0xe9, 0x9b, 0x1e, 0x0f, 0x5e, 0xfa, 0x9e, 0x39, 0xd7, 0x36, 0xb6, 0x96, 0x77, 0x90,
+	0x9d, 0xf6, 0xc4, 0xde, 0xcb, 0x2c, 0x78, 0xe2, 0x84, 0x7a, 0x00, 0x0a, 0x3f, 0x0d, 0xa9, 0xe8,
+	0x90, 0xff, 0xe4, 0x21, 0x4f, 0xfa, 0x7b, 0xbd, 0xc4, 0xd4, 0xbd, 0x3a, 0x1e, 0xb5, 0x60, 0x22,
+	0xe3, 0x42, 0x18, 0x6a, 0xc3, 0x32, 0x09, 0xc3, 0x43, 0x72, 0x42, 0xd9, 0x01, 0x1d, 0x9a, 0xf3,
+	0xaa, 0x32, 0x5c, 0x54, 0xa1, 0x17, 0x70, 0x2d, 0xa2, 0x82, 0xc7, 0x91, 0x43, 0x9f, 0x0c, 0x68,
+	0x14, 0xf9, 0x2e, 0x15, 0xe6, 0x42, 0xbb, 0xb2, 0xb5, 0xbc, 0xb3, 0x95, 0xa3, 0x65, 0x5f, 0x68,
+	0xe3, 0x69, 0xd7, 0xfd, 0x40, 0x46, 0x43, 0x3c, 0x9b, 0x02, 0xd9, 0x80, 0x84, 0x24, 0x32, 0x16,
+	0x5d, 0xe2, 0x7a, 0x74, 0x3f, 0x20, 0x27, 0x8c, 0xba, 0xe6, 0x62, 0xdb, 0xd8, 0xaa, 0xe2, 0x12,
+	0x0b, 0x7a, 0x04, 0xf5, 0x84, 0x03, 0xbb, 0x01, 0x61, 0x43, 0xe9, 0x3b, 0xc2, 0x5c, 0xd2, 0xdf,
+	0xdc, 0xcc, 0xab, 0x78, 0x78, 0xd1, 0x9e, 0x7e, 0xee, 0x74, 0x18, 0x7a, 0x0d, 0xab, 0x67, 0xb1,
+	0x90, 0xfc, 0xdc, 0xff, 0x92, 0x3e, 0x09, 0x35, 0x8f, 0xcc, 0xaa, 0x4e, 0x75, 0x60, 0x4f, 0x76,
+	0xdf, 0xce, 0x76, 0x5f, 0x2f, 0x8e, 0x1d, 0xd7, 0x0e, 0xcf, 0x3c, 0x5b, 0x11, 0xc9, 0x2e, 0x10,
+	0xc9, 0xce, 0x88, 0x64, 0x1f, 0x4c, 0xa5, 0xc4, 0x33, 0x20, 0xe8, 0xff, 0x30, 0x7f, 0x4a, 0x59,
+	0x68, 0xd6, 0x34, 0xd8, 0x4a, 0x5e, 0xf7, 0x23, 0xca, 0x42, 0xac, 0x4d, 0xe8, 0x16, 0x2c, 0x85,
+	0x2c, 0xf6, 0xfc, 0x40, 0x98, 0xa0, 0x7b, 0x5c, 0xcf, 0xbd, 0x9e, 0x6a, 0x3d, 0xce, 0xec, 0xaa,
+	0x81, 0xb1, 0xa0, 0xd1, 0x21, 0x57, 0xd2, 0x9e, 0x2f, 0x92, 0x06, 0x2e, 0x27, 0x0d, 0x9c, 0xb5,
+	0x20, 0x17, 0xd6, 0x88, 0xeb, 0xfa, 0xaa, 0x14, 0xc2, 0x26, 0x74, 0x10, 0xe6, 0x95, 0x76, 0xe5,
+	0x32, 0xea, 0x6c, 0x8c, 0x47, 0xad, 0xb5, 0xdd, 0xb2, 0x28, 0x5c, 0x9e, 0xac, 0xf1, 0xbd, 0x01,
+	0xeb, 0xe5, 0x24, 0x40, 0xab, 0x50, 0x39, 0xa3, 0xc3, 0x84, 0xfd, 0x58, 0x2d, 0x11, 0x81, 0x85,
+	0x01, 0x61, 0x31, 0x35, 0xe7, 0xfe, 0x75, 0xfb, 0xa7, 0x31, 0x71, 0x92, 0xf9, 0xde, 0xdc, 0x07,
+	0x86, 0x75, 0x0c, 0x6b, 0xa5, 0xd4, 0x40, 0x4d, 0x00, 0x19, 0x11, 0xe7, 0xcc, 0x0f, 0xbc, 0xfe,
+	0x5e, 0x5a, 0x58, 0x41, 0x83, 0x6e, 0xc2, 0x55, 0x12, 0xf0, 0x60, 0xa8, 0x36, 0xf1, 0x48, 0xd0,
+	0x48, 0xe8, 0x42, 0xab, 0x78, 0x4a, 0x6b, 0x7d, 0x08, 0xf3, 0x6a, 0x0f, 0x91, 0x09, 0x4b, 0xce,
+	0x29, 0x91, 0x47, 0xd9, 0x19, 0xc7, 0x99, 0x88, 0x1a, 0x50, 0x55, 0xcb, 0xe7, 0xf4, 0x8d, 0xd4,
+	0x39, 0x6a, 0x38, 0x97, 0xad, 0x4d, 0x58, 0x4c, 0xf6, 0x16, 0x21, 0x98, 0x0f, 0xc8, 0x39, 0x4d,
+	0x83, 0xf5, 0xda, 0x7a, 0x00, 0xb5, 0xfc, 0xf8, 0xa3, 0x1d, 0x00, 0x87, 0x07, 0x01, 0x75, 0x24,
+	0x8f, 0x84, 0x69, 0xb4, 0x2b, 0x17, 0xc6, 0x44, 0x2f, 0x33, 0xe1, 0x82, 0x97, 0x75, 0x17, 0x6a,
+	0xb9, 0xa1, 0x0c, 0x41, 0xe9, 0xe4, 0x30, 0xa4, 0x69, 0x5d, 0x7a, 0x6d, 0xfd, 0x51, 0x81, 0xc2,
+	0xc8, 0x28, 0x0d, 0x5b, 0x87, 0x45, 0x5f, 0x88, 0x98, 0x46, 0x69, 0x60, 0x2a, 0xa1, 0x2d, 0xa8,
+	0x3a, 0xcc, 0xa7, 0x81, 0xec, 0xef, 0xe9, 0xa9, 0x54, 0xeb, 0x5e, 0x19, 0x8f, 0x5a, 0xd5, 0x5e,
+	0xaa, 0xc3, 0xb9, 0x15, 0x6d, 0xc3, 0xb2, 0xc3, 0xfc, 0xcc, 0x90, 0x0c, 0x9f, 0x6e, 0x7d, 0x3c,
+	0x6a, 0x2d, 0xf7, 0x0e, 0xfb, 0xb9, 0x7f, 0xd1, 0x47, 0x81, 0x0a, 0x87, 0x87, 0xe9, 0x08, 0xaa,
+	0xe1, 0x54, 0x42, 0xc7, 0xb0, 0xe2, 0xbb, 0xcf, 0xf9, 0x19, 0x0d, 0x7a, 0x7a, 0x1c, 0x9b, 0x8b,
+	0xba, 0x37, 0x37, 0x4b, 0x48, 0x6d, 0xf7, 0x8b, 0x8e, 0x9a, 0x9a, 0xdd, 0x6b, 0xe3, 0x51, 0x6b,
+	0xa5, 0xbf, 0x57, 0xd0, 0xe3, 0x8b, 0xf9, 0xd0, 0x67, 0x60, 0x52, 0x3d, 0x89, 0x9e, 0x1e, 0xf4,
+	0xf6, 0x77, 0x63, 0x79, 0x4a, 0x03, 0x99, 0x92, 0x50, 0xcf, 0xa1, 0x6a, 0x77, 0x73, 0x3c, 0x6a,
+	0x99, 0xfb, 0x97, 0xf8, 0xe0, 0x4b, 0xa3, 0x1b, 0x43, 0x40, 0xb3, 0x15, 0x95, 0x1c, 0x96, 0x4f,
+	0x2e, 0x1e, 0x96, 0xf7, 0xdf, 0x7a, 0x58, 0x92, 0x9b, 0xca, 0xce, 0x2f, 0x59, 0x35, 0xf2, 0x6d,
+	0x9d, 0xbf, 0x78, 0x30, 0xee, 0xc2, 0x06, 0xee, 0xee, 0xf6, 0x9e, 0x72, 0xe6, 0x3b, 0xc3, 0x17,
+	0x84, 0xf9, 0x2e, 0x91, 0x14, 0xd3, 0x2f, 0x62, 0x2a, 0xa4, 0x6a, 0x75, 0xa8, 0x0d, 0x69, 0x11,
+	0xa9, 0x64, 0x3d, 0x80, 0xfa, 0x24, 0x68, 0x3f, 0x8a, 0x12, 0x56, 0x31, 0x3f, 0x48, 0xe8, 0xb1,
+	0x80, 0xf5, 0x5a, 0x9d, 0x85, 0x73, 0x2a, 0x04, 0xf1, 0x32, 0x62, 0x65, 0xa2, 0xf5, 0x18, 0x1a,
+	0x65, 0xa8, 0x22, 0xe4, 0x81, 0xa0, 0xe8, 0x0e, 0x2c, 0xd2, 0x28, 0x9a, 0xd0, 0xdb, 0xcc, 0xb7,
+	0x70, 0x0a, 0x15, 0xa7, 0x7e, 0xd6, 0x4f, 0x06, 0x5c, 0x9f, 0xd8, 0x7a, 0x24, 0xf8, 0x9b, 0x2f,
+	0x50, 0x97, 0x9e, 0x4b, 0x5f, 0x92, 0x98, 0x49, 0xcc, 0x59, 0x56, 0x5e, 0x51, 0xa5, 0x8a, 0x17,
+	0xf1, 0xc9, 0x2b, 0xea, 0xc8, 0x84, 0xc2, 0x38, 0x13, 0xd5, 0x41, 0xce, 0xee, 0xb2, 0xf4, 0xb6,
+	0xcc, 0x65, 0x85, 0x47, 0x1c, 0xcd, 0x88, 0x85, 0x04, 0x2f, 0x91, 0x94, 0x9e, 0x27, 0xc9, 0x16,
+	0x13, 0x7d, 0x22, 0x59, 0xdb, 0xb0, 0x36, 0x55, 0x77, 0xda, 0x03, 0x13, 0x96, 0x08, 0x63, 0xfc,
+	0x35, 0x75, 0x75, 0xe5, 0x55, 0x9c, 0x89, 0xd6, 0x2a, 0x5c, 0x3d, 0xe4, 0xde, 0x21, 0x1d, 0x50,
+	0x96, 0x3e, 0x30, 0x7e, 0x34, 0xa0, 0x96, 0xab, 0x50, 0x57, 0x0d, 0x88, 0xf3, 0x90, 0x07, 0x34,
+	0x90, 0x59, 0x07, 0xad, 0xbc, 0x83, 0xb9, 0x9f, 0xdd, 0xcb, 0x9d, 0x92, 0x0b, 0xba, 0x10, 0xa5,
+	0x77, 0x8e, 0xbb, 0x31, 0xa3, 0x6a, 0xdc, 0x55, 0xf4, 0xce, 0x25, 0x62, 0xe3, 0x3e, 0xd4, 0xa7,
+	0x02, 0x4b, 0x78, 0x7a, 0xbd, 0xc8, 0xd3, 0x5a, 0x91, 0x6e, 0x8f, 0x61, 0x3d, 0xaf, 0xe0, 0x28,
+	0x2c, 0x72, 0x6d, 0x13, 0x6a, 0x79, 0x01, 0x69, 0xae, 0x89, 0x42, 0xf5, 0x8f, 0xe9, 0xa0, 0x6c,
+	0xd2, 0x24, 0xd2, 0xce, 0xcf, 0xf3, 0x50, 0xcf, 0x5e, 0x1e, 0xcf, 0x68, 0x34, 0xf0, 0x1d, 0x8a,
+	0x3e, 0x86, 0xca, 0x43, 0x2a, 0xd1, 0xfa, 0xcc, 0xd3, 0x44, 0x77, 0xab, 0x71, 0x6d, 0x46, 0x6f,
+	0x99, 0xdf, 0xfe, 0xf6, 0xe7, 0x0f, 0x73, 0x08, 0xad, 0xea, 0xc7, 0xe5, 0x60, 0x3b, 0x7f, 0xde,
+	0xa1, 0xef, 0x0c, 0x40, 0x39, 0x3f, 0xf3, 0x8d, 0x42, 0x56, 0x09, 0x23, 0xa7, 0x0e, 0x4f, 0xe3,
+	0xc6, 0x5b, 0x7d, 0x92, 0x6d, 0xb6, 0x6e, 0x69, 0xe4, 0x1b, 0x56, 0x73, 0x1a, 0xb9, 0x13, 0x9d,
+	0x10, 0xa7, 0x33, 0x48, 0xfd, 0xef, 0x19, 0xb7, 0x91, 0x84, 0x15, 0x45, 0x90, 0x49, 0x11, 0xff,
+	0x2b, 0x01, 0x98, 0x50, 0xbf, 0xd1, 0xbc, 0xcc, 0x9c, 0x42, 0xbf, 0xa3, 0xa1, 0x9b, 0xd6, 0x46,
+	0x39, 0xb4, 0x43, 0x02, 0x85, 0x7a, 0x0c, 0x57, 0x1e, 0x52, 0x39, 0x61, 0xd7, 0x7f, 0x67, 0x99,
+	0x94, 0xb4, 0x15, 0xcd, 0x1a, 0x2c, 0x4b, 0x43, 0x6c, 0xa2, 0xc6, 0x0c, 0x04, 0x53, 0x77, 0xb1,
+	0x4e, 0x38, 0x84, 0x7a, 0x42, 0x84, 0x09, 0x46, 0x6b, 0x36, 0xd5, 0x05, 0xae, 0x94, 0x62, 0x6d,
+	0x6b, 0xac, 0x77, 0x1b, 0x37, 0x2f, 0xc7, 0xea, 0x7c, 0x95, 0x13, 0xea, 0xeb, 0x7b, 0xc6, 0xed,
+	0xee, 0x47, 0xbf, 0x8c, 0x9b, 0xc6, 0xaf, 0xe3, 0xa6, 0xf1, 0xfb, 0xb8, 0x69, 0x7c, 0xbe, 0xf3,
+	0x0f, 0xfe, 0x20, 0x92, 0x7b, 0x2b, 0x4f, 0x7d, 0xb2, 0xa8, 0x9f, 0xfc, 0x77, 0xff, 0x1a, 0x00,
+	0xd4, 0x47, 0x0a, 0x27, 0xdb, 0x0c, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SettingsServiceClient is the client API for SettingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SettingsServiceClient interface {
+	// Get returns Argo CD settings
+	Get(ctx context.Context, in *SettingsQuery, opts ...grpc.CallOption) (*Settings, error)
+	// ValidateRBACPolicy reports the syntax errors, unknown resources and unknown actions of an RBAC policy
+	ValidateRBACPolicy(ctx context.Context, in *RBACPolicyValidateRequest, opts ...grpc.CallOption) (*RBACPolicyValidateResponse, error)
+	// CanRBACPolicy returns whether an RBAC policy permits a subject to perform an action on an object
+	CanRBACPolicy(ctx context.Context, in *RBACPolicyCanRequest, opts ...grpc.CallOption) (*RBACPolicyCanResponse, error)
+	// GetLogLevels returns the log levels overriding the levels set by the flags of the components
+	GetLogLevels(ctx context.Context, in *LogLevelsQuery, opts ...grpc.CallOption) (*LogLevels, error)
+	// UpdateLogLevels overrides the log levels of a component at runtime
+	UpdateLogLevels(ctx context.Context, in *LogLevelsUpdateRequest, opts ...grpc.CallOption) (*LogLevels, error)
+}
+
+type settingsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSettingsServiceClient(cc *grpc.ClientConn) SettingsServiceClient {
+	return &settingsServiceClient{cc}
+}
+
+func (c *settingsServiceClient) Get(ctx context.Context, in *SettingsQuery, opts ...grpc.CallOption) (*Settings, error) {
+	out := new(Settings)
+	err := c.cc.Invoke(ctx, "/cluster.SettingsService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingsServiceClient) ValidateRBACPolicy(ctx context.Context, in *RBACPolicyValidateRequest, opts ...grpc.CallOption) (*RBACPolicyValidateResponse, error) {
+	out := new(RBACPolicyValidateResponse)
+	err := c.cc.Invoke(ctx, "/cluster.SettingsService/ValidateRBACPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingsServiceClient) CanRBACPolicy(ctx context.Context, in *RBACPolicyCanRequest, opts ...grpc.CallOption) (*RBACPolicyCanResponse, error) {
+	out := new(RBACPolicyCanResponse)
+	err := c.cc.Invoke(ctx, "/cluster.SettingsService/CanRBACPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingsServiceClient) GetLogLevels(ctx context.Context, in *LogLevelsQuery, opts ...grpc.CallOption) (*LogLevels, error) {
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, "/cluster.SettingsService/GetLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingsServiceClient) UpdateLogLevels(ctx context.Context, in *LogLevelsUpdateRequest, opts ...grpc.CallOption) (*LogLevels, error) {
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, "/cluster.SettingsService/UpdateLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SettingsServiceServer is the server API for SettingsService service.
+type SettingsServiceServer interface {
+	// Get returns Argo CD settings
+	Get(context.Context, *SettingsQuery) (*Settings, error)
+	// ValidateRBACPolicy reports the syntax errors, unknown resources and unknown actions of an RBAC policy
+	ValidateRBACPolicy(context.Context, *RBACPolicyValidateRequest) (*RBACPolicyValidateResponse, error)
+	// CanRBACPolicy returns whether an RBAC policy permits a subject to perform an action on an object
+	CanRBACPolicy(context.Context, *RBACPolicyCanRequest) (*RBACPolicyCanResponse, error)
+	// GetLogLevels returns the log levels overriding the levels set by the flags of the components
+	GetLogLevels(context.Context, *LogLevelsQuery) (*LogLevels, error)
+	// UpdateLogLevels overrides the log levels of a component at runtime
+	UpdateLogLevels(context.Context, *LogLevelsUpdateRequest) (*LogLevels, error)
+}
+
+// UnimplementedSettingsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSettingsServiceServer struct {
+}
+
+func (*UnimplementedSettingsServiceServer) Get(ctx context.Context, req *SettingsQuery) (*Settings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedSettingsServiceServer) ValidateRBACPolicy(ctx context.Context, req *RBACPolicyValidateRequest) (*RBACPolicyValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRBACPolicy not implemented")
+}
+func (*UnimplementedSettingsServiceServer) CanRBACPolicy(ctx context.Context, req *RBACPolicyCanRequest) (*RBACPolicyCanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanRBACPolicy not implemented")
+}
+func (*UnimplementedSettingsServiceServer) GetLogLevels(ctx context.Context, req *LogLevelsQuery) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (*UnimplementedSettingsServiceServer) UpdateLogLevels(ctx context.Context, req *LogLevelsUpdateRequest) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLogLevels not implemented")
+}
+
+func RegisterSettingsServiceServer(s *grpc.Server, srv SettingsServiceServer) {
+	s.RegisterService(&_SettingsService_serviceDesc, srv)
+}
+
+func _SettingsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster.SettingsService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingsServiceServer).Get(ctx, req.(*SettingsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingsService_ValidateRBACPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RBACPolicyValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingsServiceServer).ValidateRBACPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster.SettingsService/ValidateRBACPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingsServiceServer).ValidateRBACPolicy(ctx, req.(*RBACPolicyValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingsService_CanRBACPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RBACPolicyCanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingsServiceServer).CanRBACPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster.SettingsService/CanRBACPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingsServiceServer).CanRBACPolicy(ctx, req.(*RBACPolicyCanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingsService_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingsServiceServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster.SettingsService/GetLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingsServiceServer).GetLogLevels(ctx, req.(*LogLevelsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingsService_UpdateLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelsUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingsServiceServer).UpdateLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster.SettingsService/UpdateLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingsServiceServer).UpdateLogLevels(ctx, req.(*LogLevelsUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SettingsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cluster.SettingsService",
+	HandlerType: (*SettingsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _SettingsService_Get_Handler,
+		},
+		{
+			MethodName: "ValidateRBACPolicy",
+			Handler:    _SettingsService_ValidateRBACPolicy_Handler,
+		},
+		{
+			MethodName: "CanRBACPolicy",
+			Handler:    _SettingsService_CanRBACPolicy_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _SettingsService_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "UpdateLogLevels",
+			Handler:    _SettingsService_UpdateLogLevels_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server/settings/settings.proto",
+}
+
+func (m *SettingsQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
@@ -1026,12 +1372,12 @@ func (m *DexConfig) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DexConfig) MarshalTo(dAtA []byte) (int, error) {
+func (m *SettingsQuery) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DexConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SettingsQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1040,24 +1386,10 @@ func (m *DexConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Connectors) > 0 {
-		for iNdEx := len(m.Connectors) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Connectors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintSettings(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Connector) Marshal() (dAtA []byte, err error) {
+func (m *Settings) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1067,12 +1399,12 @@ func (m *Connector) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Connector) MarshalTo(dAtA []byte) (int, error) {
+func (m *Settings) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Connector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Settings) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1081,50 +1413,93 @@ func (m *Connector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
+	if len(m.AdditionalOIDCConfigs) > 0 {
+		for iNdEx := len(m.AdditionalOIDCConfigs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AdditionalOIDCConfigs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSettings(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.UserLoginsDisabled {
 		i--
-		dAtA[i] = 0xa
+		if m.UserLoginsDisabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *OIDCConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(m.Plugins) > 0 {
+		for iNdEx := len(m.Plugins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Plugins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSettings(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *OIDCConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *OIDCConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Help != nil {
+		{
+			size, err := m.Help.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSettings(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
 	}
-	if len(m.IDTokenClaims) > 0 {
-		for k := range m.IDTokenClaims {
-			v := m.IDTokenClaims[k]
+	if m.KustomizeOptions != nil {
+		{
+			size, err := m.KustomizeOptions.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSettings(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.GoogleAnalytics != nil {
+		{
+			size, err := m.GoogleAnalytics.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSettings(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.StatusBadgeEnabled {
+		i--
+		if m.StatusBadgeEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.ResourceOverrides) > 0 {
+		for k := range m.ResourceOverrides {
+			v := m.ResourceOverrides[k]
 			baseI := i
 			if v != nil {
 				{
@@ -1145,284 +1520,2087 @@ func (m *OIDCConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0xa
 			i = encodeVarintSettings(dAtA, i, uint64(baseI-i))
 			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if len(m.Scopes) > 0 {
-		for iNdEx := len(m.Scopes) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Scopes[iNdEx])
-			copy(dAtA[i:], m.Scopes[iNdEx])
-			i = encodeVarintSettings(dAtA, i, uint64(len(m.Scopes[iNdEx])))
-			i--
 			dAtA[i] = 0x2a
 		}
 	}
-	if len(m.CLIClientID) > 0 {
-		i -= len(m.CLIClientID)
-		copy(dAtA[i:], m.CLIClientID)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.CLIClientID)))
+	if len(m.AppLabelKey) > 0 {
+		i -= len(m.AppLabelKey)
+		copy(dAtA[i:], m.AppLabelKey)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.AppLabelKey)))
 		i--
 		dAtA[i] = 0x22
 	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.ClientID)))
+	if m.OIDCConfig != nil {
+		{
+			size, err := m.OIDCConfig.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSettings(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x1a
 	}
-	if len(m.Issuer) > 0 {
-		i -= len(m.Issuer)
-		copy(dAtA[i:], m.Issuer)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.Issuer)))
+	if m.DexConfig != nil {
+		{
+			size, err := m.DexConfig.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSettings(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
+	if len(m.URL) > 0 {
+		i -= len(m.URL)
+		copy(dAtA[i:], m.URL)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.URL)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintSettings(dAtA []byte, offset int, v uint64) int {
-	offset -= sovSettings(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *GoogleAnalyticsConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *SettingsQuery) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *GoogleAnalyticsConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GoogleAnalyticsConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.AnonymizeUsers {
+		i--
+		if m.AnonymizeUsers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TrackingID) > 0 {
+		i -= len(m.TrackingID)
+		copy(dAtA[i:], m.TrackingID)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.TrackingID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Settings) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Help) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Help) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Help) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.URL)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.DexConfig != nil {
-		l = m.DexConfig.Size()
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.OIDCConfig != nil {
-		l = m.OIDCConfig.Size()
-		n += 1 + l + sovSettings(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.AppLabelKey)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
+	if len(m.ChatText) > 0 {
+		i -= len(m.ChatText)
+		copy(dAtA[i:], m.ChatText)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.ChatText)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.ResourceOverrides) > 0 {
-		for k, v := range m.ResourceOverrides {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovSettings(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
-		}
-	}
-	if m.StatusBadgeEnabled {
-		n += 2
-	}
-	if m.GoogleAnalytics != nil {
-		l = m.GoogleAnalytics.Size()
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.KustomizeOptions != nil {
-		l = m.KustomizeOptions.Size()
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.Help != nil {
-		l = m.Help.Size()
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if len(m.Plugins) > 0 {
-		for _, e := range m.Plugins {
-			l = e.Size()
-			n += 1 + l + sovSettings(uint64(l))
-		}
-	}
-	if m.UserLoginsDisabled {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ChatUrl) > 0 {
+		i -= len(m.ChatUrl)
+		copy(dAtA[i:], m.ChatUrl)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.ChatUrl)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GoogleAnalyticsConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.TrackingID)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.AnonymizeUsers {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Plugin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Help) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ChatUrl)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	l = len(m.ChatText)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *Plugin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Plugin) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Plugin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *DexConfig) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DexConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DexConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DexConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
 	if len(m.Connectors) > 0 {
-		for _, e := range m.Connectors {
-			l = e.Size()
-			n += 1 + l + sovSettings(uint64(l))
+		for iNdEx := len(m.Connectors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Connectors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSettings(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Connector) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Connector) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Connector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Connector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *OIDCConfig) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *OIDCConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *OIDCConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OIDCConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	l = len(m.Issuer)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	l = len(m.ClientID)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
-	}
-	l = len(m.CLIClientID)
-	if l > 0 {
-		n += 1 + l + sovSettings(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Scopes) > 0 {
-		for _, s := range m.Scopes {
-			l = len(s)
-			n += 1 + l + sovSettings(uint64(l))
+	if m.EnablePKCEAuthentication {
+		i--
+		if m.EnablePKCEAuthentication {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x38
 	}
 	if len(m.IDTokenClaims) > 0 {
-		for k, v := range m.IDTokenClaims {
-			_ = k
-			_ = v
-			l = 0
+		for k := range m.IDTokenClaims {
+			v := m.IDTokenClaims[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintSettings(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintSettings(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintSettings(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Scopes) > 0 {
+		for iNdEx := len(m.Scopes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Scopes[iNdEx])
+			copy(dAtA[i:], m.Scopes[iNdEx])
+			i = encodeVarintSettings(dAtA, i, uint64(len(m.Scopes[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.CLIClientID) > 0 {
+		i -= len(m.CLIClientID)
+		copy(dAtA[i:], m.CLIClientID)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.CLIClientID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Issuer) > 0 {
+		i -= len(m.Issuer)
+		copy(dAtA[i:], m.Issuer)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Issuer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RBACPolicyValidateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RBACPolicyValidateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RBACPolicyValidateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Policy) > 0 {
+		i -= len(m.Policy)
+		copy(dAtA[i:], m.Policy)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Policy)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RBACPolicyError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RBACPolicyError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RBACPolicyError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Line != 0 {
+		i = encodeVarintSettings(dAtA, i, uint64(m.Line))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RBACPolicyValidateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RBACPolicyValidateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RBACPolicyValidateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Errors) > 0 {
+		for iNdEx := len(m.Errors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Errors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSettings(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RBACPolicyCanRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RBACPolicyCanRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RBACPolicyCanRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Resource) > 0 {
+		i -= len(m.Resource)
+		copy(dAtA[i:], m.Resource)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Resource)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Subject) > 0 {
+		i -= len(m.Subject)
+		copy(dAtA[i:], m.Subject)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Subject)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.DefaultRole) > 0 {
+		i -= len(m.DefaultRole)
+		copy(dAtA[i:], m.DefaultRole)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.DefaultRole)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Policy) > 0 {
+		i -= len(m.Policy)
+		copy(dAtA[i:], m.Policy)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Policy)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RBACPolicyCanResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RBACPolicyCanResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RBACPolicyCanResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Allowed {
+		i--
+		if m.Allowed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogLevelsQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogLevelsQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogLevelsQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogLevels) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogLevels) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogLevels) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Modules) > 0 {
+		for iNdEx := len(m.Modules) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Modules[iNdEx])
+			copy(dAtA[i:], m.Modules[iNdEx])
+			i = encodeVarintSettings(dAtA, i, uint64(len(m.Modules[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Components) > 0 {
+		for k := range m.Components {
+			v := m.Components[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintSettings(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintSettings(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintSettings(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogLevelsUpdateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogLevelsUpdateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogLevelsUpdateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Levels) > 0 {
+		i -= len(m.Levels)
+		copy(dAtA[i:], m.Levels)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Levels)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Component) > 0 {
+		i -= len(m.Component)
+		copy(dAtA[i:], m.Component)
+		i = encodeVarintSettings(dAtA, i, uint64(len(m.Component)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSettings(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSettings(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SettingsQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Settings) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.DexConfig != nil {
+		l = m.DexConfig.Size()
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.OIDCConfig != nil {
+		l = m.OIDCConfig.Size()
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.AppLabelKey)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if len(m.ResourceOverrides) > 0 {
+		for k, v := range m.ResourceOverrides {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovSettings(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
+		}
+	}
+	if m.StatusBadgeEnabled {
+		n += 2
+	}
+	if m.GoogleAnalytics != nil {
+		l = m.GoogleAnalytics.Size()
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.KustomizeOptions != nil {
+		l = m.KustomizeOptions.Size()
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.Help != nil {
+		l = m.Help.Size()
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if len(m.Plugins) > 0 {
+		for _, e := range m.Plugins {
+			l = e.Size()
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if m.UserLoginsDisabled {
+		n += 2
+	}
+	if len(m.AdditionalOIDCConfigs) > 0 {
+		for _, e := range m.AdditionalOIDCConfigs {
+			l = e.Size()
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GoogleAnalyticsConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TrackingID)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.AnonymizeUsers {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Help) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChatUrl)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.ChatText)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Plugin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DexConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Connectors) > 0 {
+		for _, e := range m.Connectors {
+			l = e.Size()
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Connector) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OIDCConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.CLIClientID)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if len(m.Scopes) > 0 {
+		for _, s := range m.Scopes {
+			l = len(s)
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if len(m.IDTokenClaims) > 0 {
+		for k, v := range m.IDTokenClaims {
+			_ = k
+			_ = v
+			l = 0
 			if v != nil {
 				l = v.Size()
 				l += 1 + sovSettings(uint64(l))
 			}
-			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
+			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
+		}
+	}
+	if m.EnablePKCEAuthentication {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RBACPolicyValidateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Policy)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RBACPolicyError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Line != 0 {
+		n += 1 + sovSettings(uint64(m.Line))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RBACPolicyValidateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, e := range m.Errors {
+			l = e.Size()
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RBACPolicyCanRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Policy)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.DefaultRole)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Resource)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RBACPolicyCanResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Allowed {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LogLevelsQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LogLevels) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Components) > 0 {
+		for k, v := range m.Components {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + 1 + len(v) + sovSettings(uint64(len(v)))
+			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Modules) > 0 {
+		for _, s := range m.Modules {
+			l = len(s)
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LogLevelsUpdateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Component)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	l = len(m.Levels)
+	if l > 0 {
+		n += 1 + l + sovSettings(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovSettings(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSettings(x uint64) (n int) {
+	return sovSettings(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SettingsQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SettingsQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SettingsQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Settings) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Settings: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Settings: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DexConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DexConfig == nil {
+				m.DexConfig = &DexConfig{}
+			}
+			if err := m.DexConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OIDCConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OIDCConfig == nil {
+				m.OIDCConfig = &OIDCConfig{}
+			}
+			if err := m.OIDCConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppLabelKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppLabelKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceOverrides", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceOverrides == nil {
+				m.ResourceOverrides = make(map[string]*v1alpha1.ResourceOverride)
+			}
+			var mapkey string
+			var mapvalue *v1alpha1.ResourceOverride
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSettings
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &v1alpha1.ResourceOverride{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipSettings(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ResourceOverrides[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusBadgeEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StatusBadgeEnabled = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleAnalytics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GoogleAnalytics == nil {
+				m.GoogleAnalytics = &GoogleAnalyticsConfig{}
+			}
+			if err := m.GoogleAnalytics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KustomizeOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KustomizeOptions == nil {
+				m.KustomizeOptions = &v1alpha1.KustomizeOptions{}
+			}
+			if err := m.KustomizeOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Help", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Help == nil {
+				m.Help = &Help{}
+			}
+			if err := m.Help.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Plugins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Plugins = append(m.Plugins, &Plugin{})
+			if err := m.Plugins[len(m.Plugins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserLoginsDisabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UserLoginsDisabled = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdditionalOIDCConfigs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdditionalOIDCConfigs = append(m.AdditionalOIDCConfigs, &OIDCConfig{})
+			if err := m.AdditionalOIDCConfigs[len(m.AdditionalOIDCConfigs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GoogleAnalyticsConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GoogleAnalyticsConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GoogleAnalyticsConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrackingID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrackingID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AnonymizeUsers", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AnonymizeUsers = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Help) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Help: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Help: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChatUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChatUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChatText", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChatText = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Plugin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Plugin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Plugin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DexConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DexConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DexConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connectors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Connectors = append(m.Connectors, &Connector{})
+			if err := m.Connectors[len(m.Connectors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovSettings(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozSettings(x uint64) (n int) {
-	return sovSettings(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *SettingsQuery) Unmarshal(dAtA []byte) error {
+func (m *Connector) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1445,12 +3623,76 @@ func (m *SettingsQuery) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SettingsQuery: wiretype end group for non-group")
+			return fmt.Errorf("proto: Connector: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SettingsQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Connector: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -1476,7 +3718,7 @@ func (m *SettingsQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Settings) Unmarshal(dAtA []byte) error {
+func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1499,15 +3741,15 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Settings: wiretype end group for non-group")
+			return fmt.Errorf("proto: OIDCConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Settings: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OIDCConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1535,13 +3777,13 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.URL = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DexConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -1551,33 +3793,29 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSettings
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSettings
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DexConfig == nil {
-				m.DexConfig = &DexConfig{}
-			}
-			if err := m.DexConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Issuer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OIDCConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -1587,31 +3825,27 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSettings
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSettings
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OIDCConfig == nil {
-				m.OIDCConfig = &OIDCConfig{}
-			}
-			if err := m.OIDCConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppLabelKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CLIClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1639,11 +3873,43 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppLabelKey = string(dAtA[iNdEx:postIndex])
+			m.CLIClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceOverrides", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scopes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scopes = append(m.Scopes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IDTokenClaims", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1670,11 +3936,11 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ResourceOverrides == nil {
-				m.ResourceOverrides = make(map[string]*v1alpha1.ResourceOverride)
+			if m.IDTokenClaims == nil {
+				m.IDTokenClaims = make(map[string]*oidc.Claim)
 			}
 			var mapkey string
-			var mapvalue *v1alpha1.ResourceOverride
+			var mapvalue *oidc.Claim
 			for iNdEx < postIndex {
 				entryPreIndex := iNdEx
 				var wire uint64
@@ -1748,7 +4014,7 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 					if postmsgIndex > l {
 						return io.ErrUnexpectedEOF
 					}
-					mapvalue = &v1alpha1.ResourceOverride{}
+					mapvalue = &oidc.Claim{}
 					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
 						return err
 					}
@@ -1768,11 +4034,11 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.ResourceOverrides[mapkey] = mapvalue
+			m.IDTokenClaims[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 6:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StatusBadgeEnabled", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnablePKCEAuthentication", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -1784,53 +4050,71 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.StatusBadgeEnabled = bool(v != 0)
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleAnalytics", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.EnablePKCEAuthentication = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthSettings
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthSettings
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.GoogleAnalytics == nil {
-				m.GoogleAnalytics = &GoogleAnalyticsConfig{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RBACPolicyValidateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
 			}
-			if err := m.GoogleAnalytics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 8:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RBACPolicyValidateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RBACPolicyValidateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KustomizeOptions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -1840,33 +4124,102 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSettings
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSettings
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KustomizeOptions == nil {
-				m.KustomizeOptions = &v1alpha1.KustomizeOptions{}
-			}
-			if err := m.KustomizeOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Policy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 9:
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RBACPolicyError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RBACPolicyError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RBACPolicyError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Line", wireType)
+			}
+			m.Line = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Line |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Help", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -1876,31 +4229,81 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSettings
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSettings
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Help == nil {
-				m.Help = &Help{}
-			}
-			if err := m.Help.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 10:
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RBACPolicyValidateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RBACPolicyValidateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RBACPolicyValidateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Plugins", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1927,31 +4330,11 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Plugins = append(m.Plugins, &Plugin{})
-			if err := m.Plugins[len(m.Plugins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Errors = append(m.Errors, &RBACPolicyError{})
+			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserLoginsDisabled", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.UserLoginsDisabled = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -1977,7 +4360,7 @@ func (m *Settings) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GoogleAnalyticsConfig) Unmarshal(dAtA []byte) error {
+func (m *RBACPolicyCanRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2000,15 +4383,47 @@ func (m *GoogleAnalyticsConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GoogleAnalyticsConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: RBACPolicyCanRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GoogleAnalyticsConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RBACPolicyCanRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TrackingID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Policy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultRole", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2036,13 +4451,13 @@ func (m *GoogleAnalyticsConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TrackingID = string(dAtA[iNdEx:postIndex])
+			m.DefaultRole = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AnonymizeUsers", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -2052,69 +4467,59 @@ func (m *GoogleAnalyticsConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.AnonymizeUsers = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSettings(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSettings
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthSettings
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Help) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSettings
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Help: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Help: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Resource = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChatUrl", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2142,11 +4547,11 @@ func (m *Help) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChatUrl = string(dAtA[iNdEx:postIndex])
+			m.Action = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChatText", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2174,7 +4579,7 @@ func (m *Help) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChatText = string(dAtA[iNdEx:postIndex])
+			m.Object = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2201,7 +4606,7 @@ func (m *Help) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Plugin) Unmarshal(dAtA []byte) error {
+func (m *RBACPolicyCanResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2224,17 +4629,17 @@ func (m *Plugin) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Plugin: wiretype end group for non-group")
+			return fmt.Errorf("proto: RBACPolicyCanResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Plugin: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RBACPolicyCanResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allowed", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -2244,24 +4649,12 @@ func (m *Plugin) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Allowed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -2287,7 +4680,7 @@ func (m *Plugin) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DexConfig) Unmarshal(dAtA []byte) error {
+func (m *LogLevelsQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2310,46 +4703,12 @@ func (m *DexConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DexConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogLevelsQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DexConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogLevelsQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Connectors", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Connectors = append(m.Connectors, &Connector{})
-			if err := m.Connectors[len(m.Connectors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -2375,7 +4734,7 @@ func (m *DexConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Connector) Unmarshal(dAtA []byte) error {
+func (m *LogLevels) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2398,17 +4757,17 @@ func (m *Connector) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Connector: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogLevels: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Connector: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogLevels: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Components", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSettings
@@ -2418,27 +4777,122 @@ func (m *Connector) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Components == nil {
+				m.Components = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSettings
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipSettings(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Components[mapkey] = mapvalue
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Modules", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2466,7 +4920,7 @@ func (m *Connector) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.Modules = append(m.Modules, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2493,7 +4947,7 @@ func (m *Connector) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
+func (m *LogLevelsUpdateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2516,15 +4970,15 @@ func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OIDCConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogLevelsUpdateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OIDCConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogLevelsUpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Component", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2552,107 +5006,11 @@ func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Component = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Issuer = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CLIClientID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CLIClientID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scopes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Levels", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2680,136 +5038,7 @@ func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Scopes = append(m.Scopes, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDTokenClaims", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSettings
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSettings
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthSettings
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.IDTokenClaims == nil {
-				m.IDTokenClaims = make(map[string]*oidc.Claim)
-			}
-			var mapkey string
-			var mapvalue *oidc.Claim
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowSettings
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowSettings
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthSettings
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthSettings
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowSettings
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthSettings
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthSettings
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &oidc.Claim{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipSettings(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthSettings
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.IDTokenClaims[mapkey] = mapvalue
+			m.Levels = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17,4 +17,32 @@ const (
 	AppProjectPlural    string = "appprojects"
 	AppProjectShortName string = "appproject"
 	AppProjectFullName  string = AppProjectPlural + "." + Group
+
+	// ApplicationSet constants
+	ApplicationSetKind      string = "ApplicationSet"
+	ApplicationSetSingular  string = "applicationset"
+	ApplicationSetPlural    string = "applicationsets"
+	ApplicationSetShortName string = "appset"
+	ApplicationSetFullName  string = ApplicationSetPlural + "." + Group
+
+	// ApplicationTemplate constants
+	ApplicationTemplateKind      string = "ApplicationTemplate"
+	ApplicationTemplateSingular  string = "applicationtemplate"
+	ApplicationTemplatePlural    string = "applicationtemplates"
+	ApplicationTemplateShortName string = "apptemplate"
+	ApplicationTemplateFullName  string = ApplicationTemplatePlural + "." + Group
+
+	// RepositoryRegistration constants
+	RepositoryRegistrationKind      string = "RepositoryRegistration"
+	RepositoryRegistrationSingular  string = "repositoryregistration"
+	RepositoryRegistrationPlural    string = "repositoryregistrations"
+	RepositoryRegistrationShortName string = "reporeg"
+	RepositoryRegistrationFullName  string = RepositoryRegistrationPlural + "." + Group
+
+	// ClusterRegistration constants
+	ClusterRegistrationKind      string = "ClusterRegistration"
+	ClusterRegistrationSingular  string = "clusterregistration"
+	ClusterRegistrationPlural    string = "clusterregistrations"
+	ClusterRegistrationShortName string = "clusterreg"
+	ClusterRegistrationFullName  string = ClusterRegistrationPlural + "." + Group
 )
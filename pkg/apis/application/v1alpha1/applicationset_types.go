@@ -0,0 +1,407 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationSet is a set of Application resources, which are rendered from a template for every set of parameters
+// produced by the generators of the ApplicationSet
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=applicationsets,shortName=appset;appsets
+type ApplicationSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              ApplicationSetSpec   `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status            ApplicationSetStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ApplicationSetSpec represents the desired state of an ApplicationSet
+type ApplicationSetSpec struct {
+	// Generators produce the parameter sets the template is rendered with, one application per parameter set
+	Generators []ApplicationSetGenerator `json:"generators" protobuf:"bytes,1,rep,name=generators"`
+	// Template is the application template. Parameters are referenced as {{name}} in any of its string fields, or as
+	// {{.name}} if GoTemplate is set.
+	Template ApplicationSetTemplate `json:"template" protobuf:"bytes,2,opt,name=template"`
+	// Strategy is the strategy of updating the applications. Defaults to updating all of them at once.
+	Strategy *ApplicationSetStrategy `json:"strategy,omitempty" protobuf:"bytes,3,opt,name=strategy"`
+	// GoTemplate renders the string fields of the template as Go templates. Parameters with dots in their names are
+	// nested, e.g. {{.path.basename}}, and a parameter which is also a prefix of other parameters is nested under its
+	// own name, e.g. {{.path.path}}.
+	GoTemplate bool `json:"goTemplate,omitempty" protobuf:"varint,4,opt,name=goTemplate"`
+	// TemplatePatch is a Go template of a YAML patch, which is merged into the rendered template of every application.
+	// It requires GoTemplate, and is used to add fields depending on the parameters.
+	TemplatePatch *string `json:"templatePatch,omitempty" protobuf:"bytes,5,opt,name=templatePatch"`
+	// SyncPolicy controls which changes the controller makes to the applications
+	SyncPolicy *ApplicationSetSyncPolicy `json:"syncPolicy,omitempty" protobuf:"bytes,6,opt,name=syncPolicy"`
+	// IgnoreApplicationDifferences are fields of the applications which the controller doesn't revert when they differ
+	// from the rendered template
+	IgnoreApplicationDifferences []ApplicationSetIgnoreDifferences `json:"ignoreApplicationDifferences,omitempty" protobuf:"bytes,7,rep,name=ignoreApplicationDifferences"`
+}
+
+// ApplicationsSyncPolicy controls which changes the controller makes to the applications of an ApplicationSet
+type ApplicationsSyncPolicy = string
+
+const (
+	// ApplicationsSyncPolicyCreateOnly only creates applications
+	ApplicationsSyncPolicyCreateOnly ApplicationsSyncPolicy = "create-only"
+	// ApplicationsSyncPolicyCreateUpdate creates and updates applications, but doesn't delete them
+	ApplicationsSyncPolicyCreateUpdate ApplicationsSyncPolicy = "create-update"
+	// ApplicationsSyncPolicyCreateDelete creates and deletes applications, but doesn't update them
+	ApplicationsSyncPolicyCreateDelete ApplicationsSyncPolicy = "create-delete"
+	// ApplicationsSyncPolicySync creates, updates and deletes applications
+	ApplicationsSyncPolicySync ApplicationsSyncPolicy = "sync"
+)
+
+// ApplicationSetSyncPolicy controls which changes the controller makes to the applications of an ApplicationSet
+type ApplicationSetSyncPolicy struct {
+	// ApplicationsSync is either create-only, create-update, create-delete or sync (the default)
+	ApplicationsSync ApplicationsSyncPolicy `json:"applicationsSync,omitempty" protobuf:"bytes,1,opt,name=applicationsSync"`
+}
+
+// ApplicationSetIgnoreDifferences are fields of applications which keep their values when they differ from the
+// rendered template, e.g. a target revision which is pinned temporarily
+type ApplicationSetIgnoreDifferences struct {
+	// Name is the name of the application the fields are ignored of. Defaults to all applications.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// JSONPointers are JSON pointers to the fields, e.g. /spec/source/targetRevision
+	JSONPointers []string `json:"jsonPointers" protobuf:"bytes,2,rep,name=jsonPointers"`
+}
+
+// ApplicationSetStrategyType is the type of the update strategy of an ApplicationSet
+type ApplicationSetStrategyType = string
+
+const (
+	// ApplicationSetStrategyTypeAllAtOnce creates and updates all the applications at once
+	ApplicationSetStrategyTypeAllAtOnce ApplicationSetStrategyType = "AllAtOnce"
+	// ApplicationSetStrategyTypeRollingSync creates and updates the applications step by step
+	ApplicationSetStrategyTypeRollingSync ApplicationSetStrategyType = "RollingSync"
+)
+
+// ApplicationSetStrategy is the strategy of updating the applications of an ApplicationSet
+type ApplicationSetStrategy struct {
+	// Type is either AllAtOnce (the default) or RollingSync
+	Type ApplicationSetStrategyType `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+	// RollingSync contains the steps of the RollingSync strategy
+	RollingSync *ApplicationSetRolloutStrategy `json:"rollingSync,omitempty" protobuf:"bytes,2,opt,name=rollingSync"`
+}
+
+// ApplicationSetRolloutStrategy creates and updates the applications of an ApplicationSet step by step. The changes of
+// the applications of a step are only made once all the applications of the previous steps are synced and healthy.
+type ApplicationSetRolloutStrategy struct {
+	// Steps are the steps of the rollout, in order. Applications which match none of the steps are updated last.
+	Steps []ApplicationSetRolloutStep `json:"steps" protobuf:"bytes,1,rep,name=steps"`
+}
+
+// ApplicationSetRolloutStep is a step of a rollout
+type ApplicationSetRolloutStep struct {
+	// Selector selects the applications of the step by their labels. An application belongs to the first step which
+	// selects it.
+	Selector metav1.LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
+}
+
+// ApplicationSetGenerator produces parameter sets. Exactly one of the generators must be set.
+type ApplicationSetGenerator struct {
+	// List generates a parameter set for each of the elements of the list
+	List *ListGenerator `json:"list,omitempty" protobuf:"bytes,1,opt,name=list"`
+	// Clusters generates a parameter set for each of the clusters managed by Argo CD which match the selector
+	Clusters *ClusterGenerator `json:"clusters,omitempty" protobuf:"bytes,2,opt,name=clusters"`
+	// Git generates a parameter set for each of the directories or files of a git repository which match its paths
+	Git *GitGenerator `json:"git,omitempty" protobuf:"bytes,3,opt,name=git"`
+	// Matrix generates a parameter set for each combination of the parameter sets of its generators
+	Matrix *MatrixGenerator `json:"matrix,omitempty" protobuf:"bytes,4,opt,name=matrix"`
+	// Merge generates the parameter sets of its first generator, merged with the parameter sets of its other generators
+	// which have the same values of the merge keys
+	Merge *MergeGenerator `json:"merge,omitempty" protobuf:"bytes,5,opt,name=merge"`
+	// SCMProvider generates a parameter set for each repository of an organization of an SCM provider which matches
+	// its filters
+	SCMProvider *SCMProviderGenerator `json:"scmProvider,omitempty" protobuf:"bytes,6,opt,name=scmProvider"`
+	// PullRequest generates a parameter set for each open pull request of a repository which matches any of its
+	// filters
+	PullRequest *PullRequestGenerator `json:"pullRequest,omitempty" protobuf:"bytes,7,opt,name=pullRequest"`
+	// ClusterDecisionResource generates a parameter set for each of the clusters listed in the status of a resource of
+	// any kind, e.g. the placement decisions of an external placement controller
+	ClusterDecisionResource *ClusterDecisionResourceGenerator `json:"clusterDecisionResource,omitempty" protobuf:"bytes,8,opt,name=clusterDecisionResource"`
+}
+
+// ApplicationSetNestedGenerator is a generator of a matrix or merge generator. Exactly one of the generators must be
+// set. Matrix and merge generators cannot be nested.
+type ApplicationSetNestedGenerator struct {
+	List                    *ListGenerator                    `json:"list,omitempty" protobuf:"bytes,1,opt,name=list"`
+	Clusters                *ClusterGenerator                 `json:"clusters,omitempty" protobuf:"bytes,2,opt,name=clusters"`
+	Git                     *GitGenerator                     `json:"git,omitempty" protobuf:"bytes,3,opt,name=git"`
+	SCMProvider             *SCMProviderGenerator             `json:"scmProvider,omitempty" protobuf:"bytes,4,opt,name=scmProvider"`
+	PullRequest             *PullRequestGenerator             `json:"pullRequest,omitempty" protobuf:"bytes,5,opt,name=pullRequest"`
+	ClusterDecisionResource *ClusterDecisionResourceGenerator `json:"clusterDecisionResource,omitempty" protobuf:"bytes,6,opt,name=clusterDecisionResource"`
+}
+
+// MatrixGenerator generates a parameter set for each combination of the parameter sets of its generators, which
+// contains the parameters of all the parameter sets of the combination
+type MatrixGenerator struct {
+	Generators []ApplicationSetNestedGenerator `json:"generators" protobuf:"bytes,1,rep,name=generators"`
+}
+
+// MergeGenerator generates the parameter sets of its first generator. The parameters of the parameter sets of the other
+// generators are added to, or override the parameters of, the parameter sets which have the same values of all the
+// merge keys.
+type MergeGenerator struct {
+	Generators []ApplicationSetNestedGenerator `json:"generators" protobuf:"bytes,1,rep,name=generators"`
+	// MergeKeys are the names of the parameters which identify the parameter sets to merge
+	MergeKeys []string `json:"mergeKeys" protobuf:"bytes,2,rep,name=mergeKeys"`
+}
+
+// ListGenerator generates a parameter set for each of its elements, with the parameters cluster, url and
+// values.<key> for each of the values of the element
+type ListGenerator struct {
+	Elements []ListGeneratorElement `json:"elements" protobuf:"bytes,1,rep,name=elements"`
+}
+
+// ListGeneratorElement is an element of a list generator
+type ListGeneratorElement struct {
+	// Cluster is the name of the cluster of the element
+	Cluster string `json:"cluster" protobuf:"bytes,1,opt,name=cluster"`
+	// URL is the API server URL of the cluster of the element
+	URL string `json:"url" protobuf:"bytes,2,opt,name=url"`
+	// Values are additional parameters of the element
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,3,rep,name=values"`
+}
+
+// ClusterGenerator generates a parameter set for each cluster matching the selector, with the parameters name, server,
+// metadata.labels.<key> and metadata.annotations.<key> for each of the labels and annotations of the cluster secret, and
+// values.<key> for each of the values of the generator
+type ClusterGenerator struct {
+	// Selector selects the clusters by the labels of their secrets. All clusters are selected if the selector is empty,
+	// including the cluster Argo CD runs in.
+	Selector metav1.LabelSelector `json:"selector,omitempty" protobuf:"bytes,1,opt,name=selector"`
+	// Values are additional parameters added to the parameter set of every cluster
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,2,rep,name=values"`
+}
+
+// GitGenerator generates a parameter set for each directory matching one of its directory paths, with the parameters
+// path and path.basename, or for each object of the JSON or YAML files matching one of its file paths, with the fields
+// of the object as parameters. Nested fields are flattened into dot separated parameter names.
+type GitGenerator struct {
+	// RepoURL is the URL of the git repository
+	RepoURL string `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
+	// Revision is the git revision the directories and files are read from. Defaults to HEAD.
+	Revision string `json:"revision,omitempty" protobuf:"bytes,2,opt,name=revision"`
+	// Directories are the paths of the directories to generate parameter sets for
+	Directories []GitDirectoryGeneratorItem `json:"directories,omitempty" protobuf:"bytes,3,rep,name=directories"`
+	// Files are the paths of the JSON or YAML files to generate parameter sets from
+	Files []GitFileGeneratorItem `json:"files,omitempty" protobuf:"bytes,4,rep,name=files"`
+}
+
+// GitDirectoryGeneratorItem is a glob pattern of directory paths, relative to the root of the repository
+type GitDirectoryGeneratorItem struct {
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Exclude excludes the directories matching the path from the directories matched by the other paths
+	Exclude bool `json:"exclude,omitempty" protobuf:"varint,2,opt,name=exclude"`
+}
+
+// GitFileGeneratorItem is a glob pattern of file paths, relative to the root of the repository
+type GitFileGeneratorItem struct {
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+}
+
+// SCMProviderGenerator generates a parameter set for each branch of the repositories of an organization of an SCM
+// provider which match any of its filters, with the parameters organization, repository, url, branch, sha, labels
+// (comma separated) and values.<key> for each of the values of the generator. Exactly one provider must be set.
+type SCMProviderGenerator struct {
+	// Github scans the repositories of a GitHub organization
+	Github *SCMProviderGeneratorGithub `json:"github,omitempty" protobuf:"bytes,1,opt,name=github"`
+	// Gitlab scans the projects of a GitLab group
+	Gitlab *SCMProviderGeneratorGitlab `json:"gitlab,omitempty" protobuf:"bytes,2,opt,name=gitlab"`
+	// Filters select the repositories. A repository is selected if it matches any of the filters, or if there are no
+	// filters.
+	Filters []SCMProviderGeneratorFilter `json:"filters,omitempty" protobuf:"bytes,3,rep,name=filters"`
+	// CloneProtocol is the protocol of the url parameter, either https (the default) or ssh
+	CloneProtocol string `json:"cloneProtocol,omitempty" protobuf:"bytes,4,opt,name=cloneProtocol"`
+	// AllBranches generates a parameter set for every branch of the repositories instead of just the default branch
+	AllBranches bool `json:"allBranches,omitempty" protobuf:"varint,5,opt,name=allBranches"`
+	// RequeueAfterSeconds is the minimum time between scans of the provider. Defaults to 30 minutes.
+	RequeueAfterSeconds *int64 `json:"requeueAfterSeconds,omitempty" protobuf:"varint,6,opt,name=requeueAfterSeconds"`
+	// Values are additional parameters added to the parameter set of every repository
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,7,rep,name=values"`
+}
+
+// SCMProviderGeneratorGithub is a GitHub organization
+type SCMProviderGeneratorGithub struct {
+	Organization string `json:"organization" protobuf:"bytes,1,opt,name=organization"`
+	// API is the URL of the GitHub API. Defaults to https://api.github.com.
+	API string `json:"api,omitempty" protobuf:"bytes,2,opt,name=api"`
+	// TokenRef is the secret key of the access token. Public repositories are scanned without a token.
+	TokenRef *SecretKeyRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+}
+
+// SCMProviderGeneratorGitlab is a GitLab group
+type SCMProviderGeneratorGitlab struct {
+	Group string `json:"group" protobuf:"bytes,1,opt,name=group"`
+	// API is the URL of the GitLab server. Defaults to https://gitlab.com.
+	API string `json:"api,omitempty" protobuf:"bytes,2,opt,name=api"`
+	// TokenRef is the secret key of the access token. Public projects are scanned without a token.
+	TokenRef *SecretKeyRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	// IncludeSubgroups scans the projects of the subgroups of the group as well
+	IncludeSubgroups bool `json:"includeSubgroups,omitempty" protobuf:"varint,4,opt,name=includeSubgroups"`
+}
+
+// SecretKeyRef is a key of a secret in the namespace of Argo CD
+type SecretKeyRef struct {
+	SecretName string `json:"secretName" protobuf:"bytes,1,opt,name=secretName"`
+	Key        string `json:"key" protobuf:"bytes,2,opt,name=key"`
+}
+
+// SCMProviderGeneratorFilter selects repositories. All of the conditions which are set must be met.
+type SCMProviderGeneratorFilter struct {
+	// RepositoryMatch is a regular expression matching the name of the repository
+	RepositoryMatch *string `json:"repositoryMatch,omitempty" protobuf:"bytes,1,opt,name=repositoryMatch"`
+	// LabelMatch is a regular expression matching any of the labels (GitHub topics or GitLab tags) of the repository
+	LabelMatch *string `json:"labelMatch,omitempty" protobuf:"bytes,2,opt,name=labelMatch"`
+	// PathsExist are paths which must exist in the branch of the repository
+	PathsExist []string `json:"pathsExist,omitempty" protobuf:"bytes,3,rep,name=pathsExist"`
+	// BranchMatch is a regular expression matching the name of the branch
+	BranchMatch *string `json:"branchMatch,omitempty" protobuf:"bytes,4,opt,name=branchMatch"`
+}
+
+// PullRequestGenerator generates a parameter set for each open pull request of a repository which matches any of its
+// filters, with the parameters number, branch, branch_slug (the branch name made usable in resource names), head_sha,
+// head_short_sha and values.<key> for each of the values of the generator. Exactly one provider must be set.
+type PullRequestGenerator struct {
+	// Github lists the pull requests of a GitHub repository
+	Github *PullRequestGeneratorGithub `json:"github,omitempty" protobuf:"bytes,1,opt,name=github"`
+	// Gitlab lists the merge requests of a GitLab project
+	Gitlab *PullRequestGeneratorGitlab `json:"gitlab,omitempty" protobuf:"bytes,2,opt,name=gitlab"`
+	// Filters select the pull requests. A pull request is selected if it matches any of the filters, or if there are
+	// no filters.
+	Filters []PullRequestGeneratorFilter `json:"filters,omitempty" protobuf:"bytes,3,rep,name=filters"`
+	// RequeueAfterSeconds is the minimum time between listings of the pull requests. Defaults to 5 minutes.
+	RequeueAfterSeconds *int64 `json:"requeueAfterSeconds,omitempty" protobuf:"varint,4,opt,name=requeueAfterSeconds"`
+	// Values are additional parameters added to the parameter set of every pull request
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,5,rep,name=values"`
+}
+
+// PullRequestGeneratorGithub is a GitHub repository
+type PullRequestGeneratorGithub struct {
+	Owner string `json:"owner" protobuf:"bytes,1,opt,name=owner"`
+	Repo  string `json:"repo" protobuf:"bytes,2,opt,name=repo"`
+	// API is the URL of the GitHub API. Defaults to https://api.github.com.
+	API string `json:"api,omitempty" protobuf:"bytes,3,opt,name=api"`
+	// TokenRef is the secret key of the access token. The pull requests of public repositories are listed without a
+	// token.
+	TokenRef *SecretKeyRef `json:"tokenRef,omitempty" protobuf:"bytes,4,opt,name=tokenRef"`
+	// Labels are labels which the pull requests must all have
+	Labels []string `json:"labels,omitempty" protobuf:"bytes,5,rep,name=labels"`
+}
+
+// PullRequestGeneratorGitlab is a GitLab project
+type PullRequestGeneratorGitlab struct {
+	// Project is the full path or ID of the project
+	Project string `json:"project" protobuf:"bytes,1,opt,name=project"`
+	// API is the URL of the GitLab server. Defaults to https://gitlab.com.
+	API string `json:"api,omitempty" protobuf:"bytes,2,opt,name=api"`
+	// TokenRef is the secret key of the access token. The merge requests of public projects are listed without a token.
+	TokenRef *SecretKeyRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	// Labels are labels which the merge requests must all have
+	Labels []string `json:"labels,omitempty" protobuf:"bytes,4,rep,name=labels"`
+}
+
+// PullRequestGeneratorFilter selects pull requests. All of the conditions which are set must be met.
+type PullRequestGeneratorFilter struct {
+	// BranchMatch is a regular expression matching the name of the source branch
+	BranchMatch *string `json:"branchMatch,omitempty" protobuf:"bytes,1,opt,name=branchMatch"`
+}
+
+// ClusterDecisionResourceGenerator generates a parameter set for each of the clusters listed in the status of the
+// resources it selects, with the parameters name and server of the cluster, the fields of the list item of the cluster
+// and values.<key> for each of the values of the generator. The kind of the resources, the key of the list in their
+// status and the field of the list items containing the cluster name are configured by a config map.
+type ClusterDecisionResourceGenerator struct {
+	// ConfigMapRef is the name of the config map in the namespace of Argo CD which configures the apiVersion and kind of
+	// the resources, the statusListKey of the list in their status, and the matchKey of the field of the list items
+	// containing the cluster name (defaults to clusterName)
+	ConfigMapRef string `json:"configMapRef" protobuf:"bytes,1,opt,name=configMapRef"`
+	// Name is the name of the resource in the namespace of Argo CD
+	Name string `json:"name,omitempty" protobuf:"bytes,2,opt,name=name"`
+	// LabelSelector selects the resources in the namespace of Argo CD by their labels, if no name is set
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty" protobuf:"bytes,3,opt,name=labelSelector"`
+	// Values are additional parameters added to the parameter set of every cluster
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,4,rep,name=values"`
+}
+
+// ApplicationSetTemplate is the template of the applications of an ApplicationSet
+type ApplicationSetTemplate struct {
+	ApplicationSetTemplateMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec                       ApplicationSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ApplicationSetTemplateMeta is the metadata of the applications of an ApplicationSet. The applications are always
+// created in the namespace of the ApplicationSet.
+type ApplicationSetTemplateMeta struct {
+	Name        string            `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	Labels      map[string]string `json:"labels,omitempty" protobuf:"bytes,2,rep,name=labels"`
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,3,rep,name=annotations"`
+	Finalizers  []string          `json:"finalizers,omitempty" protobuf:"bytes,4,rep,name=finalizers"`
+}
+
+// ApplicationSetStatus contains the observed state of an ApplicationSet
+type ApplicationSetStatus struct {
+	// Conditions is a list of the errors and rollouts which prevent the applications of the ApplicationSet from being
+	// up to date
+	Conditions []ApplicationSetCondition `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// ApplicationSetConditionType represents the type of an ApplicationSet condition
+type ApplicationSetConditionType = string
+
+const (
+	// ApplicationSetConditionErrorOccurred indicates that the applications could not be generated or updated
+	ApplicationSetConditionErrorOccurred ApplicationSetConditionType = "ErrorOccurred"
+	// ApplicationSetConditionRolloutProgressing indicates that the changes of some applications are withheld until the
+	// applications of a previous rollout step are synced and healthy
+	ApplicationSetConditionRolloutProgressing ApplicationSetConditionType = "RolloutProgressing"
+	// ApplicationSetConditionRolloutPaused indicates that the changes of some applications are withheld because an
+	// application of a previous rollout step failed
+	ApplicationSetConditionRolloutPaused ApplicationSetConditionType = "RolloutPaused"
+)
+
+// ApplicationSetCondition contains details about the state of an ApplicationSet
+type ApplicationSetCondition struct {
+	// Type is the type of the condition
+	Type ApplicationSetConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// Message contains human-readable message indicating details about condition
+	Message string `json:"message" protobuf:"bytes,2,opt,name=message"`
+	// LastTransitionTime is the time the condition was first observed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+}
+
+// ApplicationSetList is list of ApplicationSet resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApplicationSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ApplicationSet `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// LeafGenerators returns the generators of an ApplicationSet, with its matrix and merge generators replaced by the
+// generators they combine
+func (spec *ApplicationSetSpec) LeafGenerators() []ApplicationSetNestedGenerator {
+	var res []ApplicationSetNestedGenerator
+	for _, generator := range spec.Generators {
+		switch {
+		case generator.Matrix != nil:
+			res = append(res, generator.Matrix.Generators...)
+		case generator.Merge != nil:
+			res = append(res, generator.Merge.Generators...)
+		default:
+			res = append(res, ApplicationSetNestedGenerator{
+				List:                    generator.List,
+				Clusters:                generator.Clusters,
+				Git:                     generator.Git,
+				SCMProvider:             generator.SCMProvider,
+				PullRequest:             generator.PullRequest,
+				ClusterDecisionResource: generator.ClusterDecisionResource,
+			})
+		}
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationTemplateNameParameter is the parameter which holds the name requested for an application instantiated
+// from an ApplicationTemplate. It is always set, and templates can't declare it.
+const ApplicationTemplateNameParameter = "name"
+
+// ApplicationTemplate is a parameterized application spec, which users instantiate with their own parameter values to
+// create applications
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=applicationtemplates,shortName=apptemplate;apptemplates
+type ApplicationTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              ApplicationTemplateSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ApplicationTemplateSpec represents the desired state of an ApplicationTemplate
+type ApplicationTemplateSpec struct {
+	// Description describes the applications instantiated from the template
+	Description string `json:"description,omitempty" protobuf:"bytes,1,opt,name=description"`
+	// Parameters are the parameters users provide values for when instantiating the template
+	Parameters []ApplicationTemplateParameter `json:"parameters,omitempty" protobuf:"bytes,2,rep,name=parameters"`
+	// Template is the application template. Parameters are referenced as {{name}} in any of its string fields. The name
+	// of the application defaults to the name requested when instantiating the template.
+	Template ApplicationSetTemplate `json:"template" protobuf:"bytes,3,opt,name=template"`
+}
+
+// ApplicationTemplateParameter is a parameter of an ApplicationTemplate
+type ApplicationTemplateParameter struct {
+	// Name is the name of the parameter
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Description describes the parameter
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+	// Required parameters must be provided a value when instantiating the template
+	Required bool `json:"required,omitempty" protobuf:"varint,3,opt,name=required"`
+	// Default is the value of the parameter if none is provided
+	Default string `json:"default,omitempty" protobuf:"bytes,4,opt,name=default"`
+	// Enum restricts the value of the parameter to one of its values
+	Enum []string `json:"enum,omitempty" protobuf:"bytes,5,rep,name=enum"`
+	// Pattern is a regular expression the whole value of the parameter must match
+	Pattern string `json:"pattern,omitempty" protobuf:"bytes,6,opt,name=pattern"`
+}
+
+// ApplicationTemplateList is list of ApplicationTemplate resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApplicationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ApplicationTemplate `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ResolveParameters validates the parameter values provided to instantiate the template, and returns the values of all
+// the parameters of the template, including the defaults of the parameters which have not been provided a value and the
+// name of the application.
+func (spec *ApplicationTemplateSpec) ResolveParameters(name string, values map[string]string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	declared := make(map[string]bool)
+	for _, param := range spec.Parameters {
+		declared[param.Name] = true
+	}
+	var unknown []string
+	for key := range values {
+		if !declared[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameters: %s", strings.Join(unknown, ", "))
+	}
+
+	res := map[string]string{ApplicationTemplateNameParameter: name}
+	for _, param := range spec.Parameters {
+		if param.Name == ApplicationTemplateNameParameter {
+			return nil, fmt.Errorf("parameter '%s' is reserved for the application name", param.Name)
+		}
+		value, ok := values[param.Name]
+		if !ok {
+			if param.Required {
+				return nil, fmt.Errorf("parameter '%s' is required", param.Name)
+			}
+			value = param.Default
+		}
+		if err := param.validate(value); err != nil {
+			return nil, err
+		}
+		res[param.Name] = value
+	}
+	return res, nil
+}
+
+func (param ApplicationTemplateParameter) validate(value string) error {
+	if len(param.Enum) > 0 {
+		allowed := false
+		for _, item := range param.Enum {
+			if item == value {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("parameter '%s' must be one of %s", param.Name, strings.Join(param.Enum, ", "))
+		}
+	}
+	if param.Pattern != "" {
+		pattern, err := regexp.Compile("^(?:" + param.Pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("parameter '%s' has an invalid pattern: %v", param.Name, err)
+		}
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("parameter '%s' must match %s", param.Name, param.Pattern)
+		}
+	}
+	return nil
+}
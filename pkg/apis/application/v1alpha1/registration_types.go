@@ -0,0 +1,168 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RepositoryRegistration registers a repository declaratively, as an alternative to the repositories of argocd-cm.
+// The application controller registers the repository, checks the connection to it and reports the outcome in the
+// conditions of the status.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=repositoryregistrations,shortName=reporeg;reporegs
+type RepositoryRegistration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              RepositoryRegistrationSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status            RegistrationStatus         `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// RepositoryRegistrationSpec represents a repository to register
+type RepositoryRegistrationSpec struct {
+	// Repo is the URL of the repository
+	Repo string `json:"repo" protobuf:"bytes,1,opt,name=repo"`
+	// Type is the type of the repository, "git", "helm", "oci" or "bucket". Defaults to "git".
+	Type string `json:"type,omitempty" protobuf:"bytes,2,opt,name=type"`
+	// Name is the name of Helm repositories
+	Name string `json:"name,omitempty" protobuf:"bytes,3,opt,name=name"`
+	// Insecure skips the verification of the server of the repository
+	Insecure bool `json:"insecure,omitempty" protobuf:"varint,4,opt,name=insecure"`
+	// EnableLFS enables the support of git-lfs
+	EnableLFS bool `json:"enableLfs,omitempty" protobuf:"varint,5,opt,name=enableLfs"`
+	// CredentialsSecret is the name of a secret in the namespace of Argo CD with the credentials of the repository,
+	// in its username, password, sshPrivateKey, tlsClientCertData and tlsClientCertKey keys
+	CredentialsSecret string `json:"credentialsSecret,omitempty" protobuf:"bytes,6,opt,name=credentialsSecret"`
+	// CloudIdentity authenticates to the ECR, Artifact Registry or Azure Container Registry registry of an OCI
+	// repository with the identity of the pods of Argo CD
+	CloudIdentity bool `json:"cloudIdentity,omitempty" protobuf:"varint,7,opt,name=cloudIdentity"`
+}
+
+// RepositoryRegistrationList is list of RepositoryRegistration resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RepositoryRegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []RepositoryRegistration `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ClusterRegistration registers a cluster declaratively, as an alternative to the labeled cluster secrets. The
+// application controller registers the cluster, checks the connection to it and reports the outcome in the conditions
+// of the status.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=clusterregistrations,shortName=clusterreg;clusterregs
+type ClusterRegistration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              ClusterRegistrationSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status            RegistrationStatus      `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ClusterRegistrationSpec represents a cluster to register
+type ClusterRegistrationSpec struct {
+	// Server is the API server URL of the cluster
+	Server string `json:"server" protobuf:"bytes,1,opt,name=server"`
+	// Name is the name of the cluster. Defaults to the server URL.
+	Name string `json:"name,omitempty" protobuf:"bytes,2,opt,name=name"`
+	// Namespaces are the namespaces which are accessible in the cluster. Cluster level resources are ignored if the
+	// list is not empty, unless ClusterResources is set.
+	Namespaces []string `json:"namespaces,omitempty" protobuf:"bytes,3,rep,name=namespaces"`
+	// ClusterResources manages the cluster level resources even if the list of namespaces is not empty
+	ClusterResources bool `json:"clusterResources,omitempty" protobuf:"varint,4,opt,name=clusterResources"`
+	// Shard is the shard of the application controller which manages the cluster. If omitted, the shard is derived
+	// from the server URL.
+	Shard *int64 `json:"shard,omitempty" protobuf:"varint,5,opt,name=shard"`
+	// ConfigSecret is the name of a secret in the namespace of Argo CD with the configuration of the connection to the
+	// cluster, in the JSON format of the config key of cluster secrets
+	ConfigSecret string `json:"configSecret,omitempty" protobuf:"bytes,6,opt,name=configSecret"`
+	// MaxConcurrentSyncs is the maximum number of sync operations to the cluster which run at the same time. Zero
+	// means unlimited.
+	MaxConcurrentSyncs int64 `json:"maxConcurrentSyncs,omitempty" protobuf:"varint,7,opt,name=maxConcurrentSyncs"`
+}
+
+// ClusterRegistrationList is list of ClusterRegistration resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterRegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ClusterRegistration `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// RegistrationStatus contains the observed state of a RepositoryRegistration or a ClusterRegistration
+type RegistrationStatus struct {
+	// Registered is the URL of the repository or the server of the cluster which is registered from the resource.
+	// Repositories and clusters which were registered otherwise are not taken over.
+	Registered string `json:"registered,omitempty" protobuf:"bytes,1,opt,name=registered"`
+	// Conditions report whether the repository or cluster is registered and whether the connection to it succeeded
+	Conditions []RegistrationCondition `json:"conditions,omitempty" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+// RegistrationConditionType represents the type of a registration condition
+type RegistrationConditionType = string
+
+const (
+	// RegistrationConditionRegistered indicates whether the repository or cluster is registered
+	RegistrationConditionRegistered RegistrationConditionType = "Registered"
+	// RegistrationConditionConnected indicates whether the last check of the connection to the repository or cluster
+	// succeeded
+	RegistrationConditionConnected RegistrationConditionType = "Connected"
+)
+
+// RegistrationConditionStatus is the status of a registration condition, "True" or "False"
+type RegistrationConditionStatus = string
+
+const (
+	RegistrationConditionStatusTrue  RegistrationConditionStatus = "True"
+	RegistrationConditionStatusFalse RegistrationConditionStatus = "False"
+)
+
+// RegistrationCondition contains details about the state of a registration
+type RegistrationCondition struct {
+	// Type is the type of the condition
+	Type RegistrationConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// Status is the status of the condition, "True" or "False"
+	Status RegistrationConditionStatus `json:"status" protobuf:"bytes,2,opt,name=status"`
+	// Message contains human-readable message indicating details about condition
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// LastTransitionTime is the time the status of the condition changed at last
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+// GetCondition returns the condition of a type, or nil
+func (status *RegistrationStatus) GetCondition(conditionType RegistrationConditionType) *RegistrationCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets the status and message of a condition. The time of the last transition is kept unless the status
+// changes.
+func (status *RegistrationStatus) SetCondition(conditionType RegistrationConditionType, conditionStatus RegistrationConditionStatus, message string) {
+	condition := status.GetCondition(conditionType)
+	if condition == nil {
+		status.Conditions = append(status.Conditions, RegistrationCondition{Type: conditionType})
+		condition = &status.Conditions[len(status.Conditions)-1]
+	}
+	if condition.Status != conditionStatus || condition.LastTransitionTime == nil {
+		now := metav1.Now()
+		condition.LastTransitionTime = &now
+	}
+	condition.Status = conditionStatus
+	condition.Message = message
+}
+
+// RemoveCondition removes the condition of a type
+func (status *RegistrationStatus) RemoveCondition(conditionType RegistrationConditionType) {
+	var conditions []RegistrationCondition
+	for _, condition := range status.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	status.Conditions = conditions
+}
@@ -1,8 +1,11 @@
 package v1alpha1
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -19,6 +22,7 @@ import (
 	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -28,9 +32,11 @@ import (
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/util/bucket"
 	"github.com/argoproj/argo-cd/util/cert"
 	"github.com/argoproj/argo-cd/util/git"
 	"github.com/argoproj/argo-cd/util/helm"
+	"github.com/argoproj/argo-cd/util/oci"
 )
 
 // Application is a definition of Application resource.
@@ -66,6 +72,15 @@ type ApplicationSpec struct {
 	// Increasing will increase the space used to store the history, so we do not recommend increasing it.
 	// Default is 10.
 	RevisionHistoryLimit *int64 `json:"revisionHistoryLimit,omitempty" protobuf:"bytes,7,name=revisionHistoryLimit"`
+	// Destinations are additional destinations the application is synced to, e.g. the clusters of a fleet. The same
+	// manifests are applied to Destination and to every additional destination, and the sync and health status of each
+	// destination is tracked in status.destinations.
+	Destinations []ApplicationDestination `json:"destinations,omitempty" protobuf:"bytes,8,name=destinations"`
+}
+
+// GetDestinations returns the destination and the additional destinations of the application
+func (spec ApplicationSpec) GetDestinations() []ApplicationDestination {
+	return append([]ApplicationDestination{spec.Destination}, spec.Destinations...)
 }
 
 // ResourceIgnoreDifferences contains resource filter and list of json paths which should be ignored during comparison with live state.
@@ -122,7 +137,8 @@ type ApplicationSource struct {
 	// Path is a directory path within the Git repository
 	Path string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
 	// TargetRevision defines the commit, tag, or branch in which to sync the application to.
-	// If omitted, will sync to HEAD
+	// If omitted, will sync to HEAD. For OCI repositories, it is the tag or digest of the artifact (default latest).
+	// Buckets have no history, so it must be omitted for buckets.
 	TargetRevision string `json:"targetRevision,omitempty" protobuf:"bytes,4,opt,name=targetRevision"`
 	// Helm holds helm specific options
 	Helm *ApplicationSourceHelm `json:"helm,omitempty" protobuf:"bytes,7,opt,name=helm"`
@@ -136,12 +152,28 @@ type ApplicationSource struct {
 	Plugin *ApplicationSourcePlugin `json:"plugin,omitempty" protobuf:"bytes,11,opt,name=plugin"`
 	// Chart is a Helm chart name
 	Chart string `json:"chart,omitempty" protobuf:"bytes,12,opt,name=chart"`
+	// CUE holds CUE specific options
+	CUE *ApplicationSourceCUE `json:"cue,omitempty" protobuf:"bytes,13,opt,name=cue"`
+	// Tanka holds Tanka specific options
+	Tanka *ApplicationSourceTanka `json:"tanka,omitempty" protobuf:"bytes,14,opt,name=tanka"`
+	// Helmfile holds helmfile specific options
+	Helmfile *ApplicationSourceHelmfile `json:"helmfile,omitempty" protobuf:"bytes,15,opt,name=helmfile"`
 }
 
 func (a *ApplicationSource) IsHelm() bool {
 	return a.Chart != ""
 }
 
+// IsOCI returns true if the source is an artifact of an OCI repository rather than a Git repository
+func (a *ApplicationSource) IsOCI() bool {
+	return !a.IsHelm() && oci.IsOCIURL(a.RepoURL)
+}
+
+// IsBucket returns true if the source is the objects under a prefix of an S3 or GCS bucket rather than a Git repository
+func (a *ApplicationSource) IsBucket() bool {
+	return !a.IsHelm() && bucket.IsBucketURL(a.RepoURL)
+}
+
 func (a *ApplicationSource) IsZero() bool {
 	return a == nil ||
 		a.RepoURL == "" &&
@@ -151,7 +183,10 @@ func (a *ApplicationSource) IsZero() bool {
 			a.Kustomize.IsZero() &&
 			a.Ksonnet.IsZero() &&
 			a.Directory.IsZero() &&
-			a.Plugin.IsZero()
+			a.Plugin.IsZero() &&
+			a.CUE.IsZero() &&
+			a.Tanka.IsZero() &&
+			a.Helmfile.IsZero()
 }
 
 type ApplicationSourceType string
@@ -162,6 +197,9 @@ const (
 	ApplicationSourceTypeKsonnet   ApplicationSourceType = "Ksonnet"
 	ApplicationSourceTypeDirectory ApplicationSourceType = "Directory"
 	ApplicationSourceTypePlugin    ApplicationSourceType = "Plugin"
+	ApplicationSourceTypeCUE       ApplicationSourceType = "CUE"
+	ApplicationSourceTypeTanka     ApplicationSourceType = "Tanka"
+	ApplicationSourceTypeHelmfile  ApplicationSourceType = "Helmfile"
 )
 
 type RefreshType string
@@ -378,6 +416,63 @@ func (d *ApplicationSourceDirectory) IsZero() bool {
 	return d == nil || !d.Recurse && d.Jsonnet.IsZero()
 }
 
+// ApplicationSourceCUE holds CUE specific options
+type ApplicationSourceCUE struct {
+	// Package is the CUE package to export, e.g. ./prod. Defaults to the package of the application path.
+	Package string `json:"package,omitempty" protobuf:"bytes,1,opt,name=package"`
+	// Expression is the expression to export, e.g. the field holding the objects. Defaults to the whole package.
+	Expression string `json:"expression,omitempty" protobuf:"bytes,2,opt,name=expression"`
+	// Tags are the values of @tag() attributes of the package
+	Tags []CUETag `json:"tags,omitempty" protobuf:"bytes,3,rep,name=tags"`
+}
+
+// CUETag is the value of a @tag() attribute
+type CUETag struct {
+	Name  string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+}
+
+// NewCUETag parses a tag of the form name=value
+func NewCUETag(text string) (*CUETag, error) {
+	parts := strings.SplitN(text, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("Expected CUE tag of the form: name=value. Received: %s", text)
+	}
+	return &CUETag{Name: parts[0], Value: parts[1]}, nil
+}
+
+func (c *ApplicationSourceCUE) IsZero() bool {
+	return c == nil || c.Package == "" && c.Expression == "" && len(c.Tags) == 0
+}
+
+// ApplicationSourceTanka holds Tanka specific options
+type ApplicationSourceTanka struct {
+	// Name is the name of the inline environment to show, which is required if the path has several inline environments
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Jsonnet holds the external variables and top-level arguments of the environment
+	Jsonnet ApplicationSourceJsonnet `json:"jsonnet,omitempty" protobuf:"bytes,2,opt,name=jsonnet"`
+}
+
+func (t *ApplicationSourceTanka) IsZero() bool {
+	return t == nil || t.Name == "" && t.Jsonnet.IsZero()
+}
+
+// ApplicationSourceHelmfile holds helmfile specific options
+type ApplicationSourceHelmfile struct {
+	// Environment is the helmfile environment to render
+	Environment string `json:"environment,omitempty" protobuf:"bytes,1,opt,name=environment"`
+	// Selectors select the releases to render by their labels, e.g. tier=frontend
+	Selectors []string `json:"selectors,omitempty" protobuf:"bytes,2,rep,name=selectors"`
+	// StateValueFiles are files of state values, relative to the application path
+	StateValueFiles []string `json:"stateValueFiles,omitempty" protobuf:"bytes,3,rep,name=stateValueFiles"`
+	// StateValues are state values of the form key=value
+	StateValues []string `json:"stateValues,omitempty" protobuf:"bytes,4,rep,name=stateValues"`
+}
+
+func (h *ApplicationSourceHelmfile) IsZero() bool {
+	return h == nil || h.Environment == "" && len(h.Selectors) == 0 && len(h.StateValueFiles) == 0 && len(h.StateValues) == 0
+}
+
 // ApplicationSourcePlugin holds config management plugin specific options
 type ApplicationSourcePlugin struct {
 	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
@@ -410,6 +505,18 @@ type ApplicationStatus struct {
 	ObservedAt *metav1.Time          `json:"observedAt,omitempty" protobuf:"bytes,8,opt,name=observedAt"`
 	SourceType ApplicationSourceType `json:"sourceType,omitempty" protobuf:"bytes,9,opt,name=sourceType"`
 	Summary    ApplicationSummary    `json:"summary,omitempty" protobuf:"bytes,10,opt,name=summary"`
+	// Destinations are the sync and health status of each destination of applications with additional destinations.
+	// The sync and health status of the application are the aggregated status of its destinations then.
+	Destinations []ApplicationDestinationStatus `json:"destinations,omitempty" protobuf:"bytes,11,opt,name=destinations"`
+}
+
+// ApplicationDestinationStatus is the sync and health status of a destination of an application
+type ApplicationDestinationStatus struct {
+	Destination ApplicationDestination `json:"destination" protobuf:"bytes,1,opt,name=destination"`
+	Sync        SyncStatusCode         `json:"sync,omitempty" protobuf:"bytes,2,opt,name=sync,casttype=SyncStatusCode"`
+	Health      HealthStatusCode       `json:"health,omitempty" protobuf:"bytes,3,opt,name=health"`
+	// Message is the error of the comparison of the destination, if any
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
 }
 
 // OperationInitiator holds information about the operation initiator
@@ -424,6 +531,8 @@ type OperationInitiator struct {
 type Operation struct {
 	Sync        *SyncOperation     `json:"sync,omitempty" protobuf:"bytes,1,opt,name=sync"`
 	InitiatedBy OperationInitiator `json:"initiatedBy,omitempty" protobuf:"bytes,2,opt,name=initiatedBy"`
+	// CorrelationID identifies the request which initiated the operation in the logs of the components
+	CorrelationID string `json:"correlationID,omitempty" protobuf:"bytes,3,opt,name=correlationID"`
 }
 
 // SyncOperationResource contains resources to sync.
@@ -444,6 +553,16 @@ func (in RevisionHistories) Trunc(n int) RevisionHistories {
 	return in
 }
 
+// CompressSources compresses the sources of all but the newest n entries
+func (in RevisionHistories) CompressSources(n int) error {
+	for i := 0; i < len(in)-n; i++ {
+		if err := in[i].CompressSource(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // HasIdentity determines whether a sync operation is identified by a manifest.
 func (r SyncOperationResource) HasIdentity(name string, gvk schema.GroupVersionKind) bool {
 	if name == r.Name && gvk.Kind == r.Kind && gvk.Group == r.Group {
@@ -481,6 +600,7 @@ func (o *SyncOperation) IsApplyStrategy() bool {
 type OperationPhase string
 
 const (
+	OperationQueued      OperationPhase = "Queued"
 	OperationRunning     OperationPhase = "Running"
 	OperationTerminating OperationPhase = "Terminating"
 	OperationFailed      OperationPhase = "Failed"
@@ -522,6 +642,23 @@ type OperationState struct {
 	StartedAt metav1.Time `json:"startedAt" protobuf:"bytes,6,opt,name=startedAt"`
 	// FinishedAt contains time of operation completion
 	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,7,opt,name=finishedAt"`
+	// Destinations are the states of the sync operation of each destination of applications with additional
+	// destinations. The destinations are synced independently, and the operation completes once all of them completed.
+	Destinations []DestinationOperationState `json:"destinations,omitempty" protobuf:"bytes,8,opt,name=destinations"`
+	// QueuePosition is the position of the operation in the queue of the sync operations which wait for the
+	// concurrency limits of the project or of the destination clusters of the application
+	QueuePosition int64 `json:"queuePosition,omitempty" protobuf:"varint,9,opt,name=queuePosition"`
+}
+
+// DestinationOperationState is the state of the sync operation of a destination of an application
+type DestinationOperationState struct {
+	Destination ApplicationDestination `json:"destination" protobuf:"bytes,1,opt,name=destination"`
+	// Phase is the current phase of the sync operation of the destination
+	Phase OperationPhase `json:"phase" protobuf:"bytes,2,opt,name=phase"`
+	// Message hold any pertinent messages of the sync operation of the destination
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// SyncResult is the result of the sync operation of the destination
+	SyncResult *SyncOperationResult `json:"syncResult,omitempty" protobuf:"bytes,4,opt,name=syncResult"`
 }
 
 type Info struct {
@@ -712,6 +849,10 @@ type ResourceResult struct {
 	HookPhase OperationPhase `json:"hookPhase,omitempty" protobuf:"bytes,9,opt,name=hookPhase"`
 	// indicates the particular phase of the sync that this is for
 	SyncPhase SyncPhase `json:"syncPhase,omitempty" protobuf:"bytes,10,opt,name=syncPhase"`
+	// AppliedAt is the time the resource was applied or pruned, or the hook was created
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty" protobuf:"bytes,11,opt,name=appliedAt"`
+	// FinishedAt is the time the resource became healthy or degraded, the hook completed, or the pruning completed
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,12,opt,name=finishedAt"`
 }
 
 func (r *ResourceResult) GroupVersionKind() schema.GroupVersionKind {
@@ -751,12 +892,150 @@ func (r ResourceResults) PruningRequired() (num int) {
 	return num
 }
 
+// SyncTimeline is the timeline of the resources and hooks of a sync operation
+type SyncTimeline struct {
+	// Phase is the current phase of the operation
+	Phase OperationPhase `json:"phase" protobuf:"bytes,1,opt,name=phase"`
+	// StartedAt contains time of operation start
+	StartedAt metav1.Time `json:"startedAt" protobuf:"bytes,2,opt,name=startedAt"`
+	// FinishedAt contains time of operation completion
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,3,opt,name=finishedAt"`
+	// Revision holds the revision of the sync
+	Revision string `json:"revision,omitempty" protobuf:"bytes,4,opt,name=revision"`
+	// Entries are the resources and hooks of the sync ordered by the time they were applied
+	Entries []SyncTimelineEntry `json:"entries,omitempty" protobuf:"bytes,5,rep,name=entries"`
+}
+
+// SyncTimelineEntry is the timeline of a resource or hook of a sync operation
+type SyncTimelineEntry struct {
+	Group     string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	Kind      string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+	Name      string `json:"name" protobuf:"bytes,4,opt,name=name"`
+	// Destination is the server and namespace of the destination the resource was synced to, set for applications
+	// with additional destinations only
+	Destination string         `json:"destination,omitempty" protobuf:"bytes,5,opt,name=destination"`
+	SyncPhase   SyncPhase      `json:"syncPhase,omitempty" protobuf:"bytes,6,opt,name=syncPhase"`
+	HookType    HookType       `json:"hookType,omitempty" protobuf:"bytes,7,opt,name=hookType"`
+	Status      ResultCode     `json:"status,omitempty" protobuf:"bytes,8,opt,name=status"`
+	HookPhase   OperationPhase `json:"hookPhase,omitempty" protobuf:"bytes,9,opt,name=hookPhase"`
+	// AppliedAt is the time the resource was applied or pruned, or the hook was created
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty" protobuf:"bytes,10,opt,name=appliedAt"`
+	// FinishedAt is the time the resource became healthy or degraded, the hook completed, or the pruning completed
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,11,opt,name=finishedAt"`
+	// WaitSeconds is the number of seconds from the start of the operation until the resource was applied
+	WaitSeconds int64 `json:"waitSeconds,omitempty" protobuf:"varint,12,opt,name=waitSeconds"`
+	// DurationSeconds is the number of seconds from the apply of the resource until it finished
+	DurationSeconds int64 `json:"durationSeconds,omitempty" protobuf:"varint,13,opt,name=durationSeconds"`
+}
+
+// GetSyncTimeline returns the timeline of the resources and hooks of the sync operation, of all its destinations,
+// ordered by the time they were applied. The resources which were not applied yet come last.
+func (s *OperationState) GetSyncTimeline() *SyncTimeline {
+	res := &SyncTimeline{Phase: s.Phase, StartedAt: s.StartedAt, FinishedAt: s.FinishedAt}
+	if s.SyncResult != nil {
+		res.Revision = s.SyncResult.Revision
+	}
+	add := func(syncResult *SyncOperationResult, destination string) {
+		if syncResult == nil {
+			return
+		}
+		for _, r := range syncResult.Resources {
+			entry := SyncTimelineEntry{
+				Group:       r.Group,
+				Kind:        r.Kind,
+				Namespace:   r.Namespace,
+				Name:        r.Name,
+				Destination: destination,
+				SyncPhase:   r.SyncPhase,
+				HookType:    r.HookType,
+				Status:      r.Status,
+				HookPhase:   r.HookPhase,
+				AppliedAt:   r.AppliedAt,
+				FinishedAt:  r.FinishedAt,
+			}
+			if r.AppliedAt != nil {
+				entry.WaitSeconds = int64(r.AppliedAt.Sub(s.StartedAt.Time).Seconds())
+				if r.FinishedAt != nil {
+					entry.DurationSeconds = int64(r.FinishedAt.Sub(r.AppliedAt.Time).Seconds())
+				}
+			}
+			res.Entries = append(res.Entries, entry)
+		}
+	}
+	if len(s.Destinations) == 0 {
+		add(s.SyncResult, "")
+	}
+	for _, d := range s.Destinations {
+		add(d.SyncResult, fmt.Sprintf("%s/%s", d.Destination.Server, d.Destination.Namespace))
+	}
+	sort.SliceStable(res.Entries, func(i, j int) bool {
+		a, b := res.Entries[i].AppliedAt, res.Entries[j].AppliedAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(b)
+	})
+	return res
+}
+
 // RevisionHistory contains information relevant to an application deployment
 type RevisionHistory struct {
 	Revision   string            `json:"revision" protobuf:"bytes,2,opt,name=revision"`
 	DeployedAt metav1.Time       `json:"deployedAt" protobuf:"bytes,4,opt,name=deployedAt"`
 	ID         int64             `json:"id" protobuf:"bytes,5,opt,name=id"`
 	Source     ApplicationSource `json:"source,omitempty" protobuf:"bytes,6,opt,name=source"`
+	// CompressedSource is the gzipped JSON of the full source of an older history entry. When it is set, Source only
+	// holds the repository, path, chart and target revision of the entry.
+	CompressedSource []byte `json:"compressedSource,omitempty" protobuf:"bytes,7,opt,name=compressedSource"`
+}
+
+// CompressSource stores the source of the history entry in compressed form, and truncates Source to its metadata
+func (h *RevisionHistory) CompressSource() error {
+	if h.CompressedSource != nil || h.Source.IsZero() {
+		return nil
+	}
+	data, err := json.Marshal(h.Source)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	h.CompressedSource = buf.Bytes()
+	h.Source = ApplicationSource{
+		RepoURL:        h.Source.RepoURL,
+		Path:           h.Source.Path,
+		Chart:          h.Source.Chart,
+		TargetRevision: h.Source.TargetRevision,
+	}
+	return nil
+}
+
+// FullSource returns the full source of the history entry, decompressing it if necessary
+func (h *RevisionHistory) FullSource() (ApplicationSource, error) {
+	if h.CompressedSource == nil {
+		return h.Source, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(h.CompressedSource))
+	if err != nil {
+		return ApplicationSource{}, err
+	}
+	defer func() { _ = r.Close() }()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return ApplicationSource{}, err
+	}
+	var source ApplicationSource
+	if err = json.Unmarshal(data, &source); err != nil {
+		return ApplicationSource{}, err
+	}
+	return source, nil
 }
 
 // ApplicationWatchEvent contains information about application change.
@@ -821,6 +1100,8 @@ const (
 	ApplicationConditionExcludedResourceWarning = "ExcludedResourceWarning"
 	// ApplicationConditionOrphanedResourceWarning indicates that application has orphaned resources
 	ApplicationConditionOrphanedResourceWarning = "OrphanedResourceWarning"
+	// ApplicationConditionQuotaExceededError indicates that application exceeds the quota of its project
+	ApplicationConditionQuotaExceededError = "QuotaExceededError"
 )
 
 // ApplicationCondition contains details about current application condition
@@ -968,6 +1249,9 @@ type ResourceStatus struct {
 	Health          *HealthStatus  `json:"health,omitempty" protobuf:"bytes,7,opt,name=health"`
 	Hook            bool           `json:"hook,omitempty" protobuf:"bytes,8,opt,name=hook"`
 	RequiresPruning bool           `json:"requiresPruning,omitempty" protobuf:"bytes,9,opt,name=requiresPruning"`
+	// ProgressingSince is the time since which the resource is progressing, if it is progressing or degraded because
+	// it exceeded the progressing deadline of its kind
+	ProgressingSince *metav1.Time `json:"progressingSince,omitempty" protobuf:"bytes,10,opt,name=progressingSince"`
 }
 
 func (r *ResourceStatus) GroupVersionKind() schema.GroupVersionKind {
@@ -994,12 +1278,43 @@ type ResourceDiff struct {
 	PredictedLiveState string `json:"predictedLiveState,omitempty" protobuf:"bytes,10,opt,name=predictedLiveState"`
 }
 
+// ResourceDiffDetails are the differences of the fields of a managed resource between its target and live state, and
+// the differences which are ignored because of normalizations
+type ResourceDiffDetails struct {
+	Group     string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	Kind      string `json:"kind,omitempty" protobuf:"bytes,2,opt,name=kind"`
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+	Name      string `json:"name,omitempty" protobuf:"bytes,4,opt,name=name"`
+	// Modified is whether the normalized live state differs from the target state, i.e. whether the resource is OutOfSync
+	Modified bool `json:"modified,omitempty" protobuf:"varint,5,opt,name=modified"`
+	// Diffs are the fields which differ between the target and the normalized live state. They are not listed for
+	// resources which are missing or not in the target state.
+	Diffs []FieldDiff `json:"diffs,omitempty" protobuf:"bytes,6,rep,name=diffs"`
+	// Suppressed are the fields which differ, but whose differences are ignored because of normalizations
+	Suppressed []FieldDiff `json:"suppressed,omitempty" protobuf:"bytes,7,rep,name=suppressed"`
+}
+
+// FieldDiff is a difference of a field between the target and the live state of a resource
+type FieldDiff struct {
+	// Path is the JSON pointer of the field
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// TargetValue is the JSON value of the field in the target state, empty if the field is not set
+	TargetValue string `json:"targetValue,omitempty" protobuf:"bytes,2,opt,name=targetValue"`
+	// LiveValue is the JSON value of the field in the live state, empty if the field is not set
+	LiveValue string `json:"liveValue,omitempty" protobuf:"bytes,3,opt,name=liveValue"`
+	// SuppressedBy is the normalization which ignores the difference: a rule of spec.ignoreDifferences, a resource
+	// customization of argocd-cm or a built-in normalization
+	SuppressedBy string `json:"suppressedBy,omitempty" protobuf:"bytes,4,opt,name=suppressedBy"`
+}
+
 // ConnectionStatus represents connection status
 type ConnectionStatus = string
 
 const (
 	ConnectionStatusSuccessful = "Successful"
 	ConnectionStatusFailed     = "Failed"
+	// ConnectionStatusSkipped is the status of the steps of a diagnosis which were not run
+	ConnectionStatusSkipped = "Skipped"
 )
 
 // ConnectionState contains information about remote resource connection state
@@ -1023,6 +1338,60 @@ type Cluster struct {
 	ServerVersion string `json:"serverVersion,omitempty" protobuf:"bytes,5,opt,name=serverVersion"`
 	// Holds list of namespaces which are accessible in that cluster. Cluster level resources would be ignored if namespace list if not empty.
 	Namespaces []string `json:"namespaces,omitempty" protobuf:"bytes,6,opt,name=namespaces"`
+	// Indicates if cluster level resources should be managed. This setting is used only if the namespace list is not empty.
+	ClusterResources bool `json:"clusterResources,omitempty" protobuf:"bytes,7,opt,name=clusterResources"`
+	// Shard is the shard of the application controller which manages the cluster. If omitted, the shard is derived from the server URL.
+	Shard *int64 `json:"shard,omitempty" protobuf:"bytes,8,opt,name=shard"`
+	// Info is the state of the cluster reported by the application controller which manages it
+	Info ClusterInfo `json:"info,omitempty" protobuf:"bytes,9,opt,name=info"`
+	// MaxConcurrentSyncs is the maximum number of sync operations to the cluster which run at the same time. The
+	// operations which exceed it are queued. Zero means unlimited.
+	MaxConcurrentSyncs int64 `json:"maxConcurrentSyncs,omitempty" protobuf:"varint,10,opt,name=maxConcurrentSyncs"`
+}
+
+// ClusterInfo is the state of a cluster reported by the application controller which manages it
+type ClusterInfo struct {
+	// Shard is the shard of the application controller which manages the cluster
+	Shard int64 `json:"shard" protobuf:"bytes,1,opt,name=shard"`
+	// Replica is the name of the application controller replica which manages the cluster
+	Replica string `json:"replica,omitempty" protobuf:"bytes,2,opt,name=replica"`
+	// CacheInfo is the state of the cache of the resources of the cluster
+	CacheInfo ClusterCacheInfo `json:"cacheInfo,omitempty" protobuf:"bytes,3,opt,name=cacheInfo"`
+	// ApplicationsCount is the number of applications deployed to the cluster
+	ApplicationsCount int64 `json:"applicationsCount" protobuf:"bytes,4,opt,name=applicationsCount"`
+	// ReportedAt is the time the application controller reported the state
+	ReportedAt *metav1.Time `json:"reportedAt,omitempty" protobuf:"bytes,5,opt,name=reportedAt"`
+}
+
+// ClusterCacheStatus is the status of the cache of the resources of a cluster
+type ClusterCacheStatus = string
+
+const (
+	// ClusterCacheStatusSynced is the status of caches which hold all the resources of their cluster
+	ClusterCacheStatusSynced ClusterCacheStatus = "Synced"
+	// ClusterCacheStatusFailed is the status of caches whose last sync failed
+	ClusterCacheStatusFailed ClusterCacheStatus = "Failed"
+	// ClusterCacheStatusNotCached is the status of clusters whose resources are not cached, because no application
+	// has been deployed to them yet, or because their cache was invalidated
+	ClusterCacheStatusNotCached ClusterCacheStatus = "NotCached"
+)
+
+// ClusterCacheInfo is the state of the cache of the resources of a cluster
+type ClusterCacheInfo struct {
+	// Status is the status of the cache
+	Status ClusterCacheStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status"`
+	// Message is the error of the last sync of the cache, if it failed
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// ResourcesCount is the number of cached resources
+	ResourcesCount int64 `json:"resourcesCount,omitempty" protobuf:"bytes,3,opt,name=resourcesCount"`
+	// APIsCount is the number of watched APIs
+	APIsCount int64 `json:"apisCount,omitempty" protobuf:"bytes,4,opt,name=apisCount"`
+	// LastCacheSyncTime is the time of the last full sync of the cache
+	LastCacheSyncTime *metav1.Time `json:"lastCacheSyncTime,omitempty" protobuf:"bytes,5,opt,name=lastCacheSyncTime"`
+	// WatchErrorsCount is the number of errors of the watches of the resources since the last full sync of the cache
+	WatchErrorsCount int64 `json:"watchErrorsCount,omitempty" protobuf:"bytes,6,opt,name=watchErrorsCount"`
+	// LastWatchError is the last error of the watches of the resources
+	LastWatchError string `json:"lastWatchError,omitempty" protobuf:"bytes,7,opt,name=lastWatchError"`
 }
 
 // ClusterList is a collection of Clusters.
@@ -1083,6 +1452,12 @@ type ResourceOverride struct {
 	HealthLua         string `json:"health.lua,omitempty" protobuf:"bytes,1,opt,name=healthLua"`
 	Actions           string `json:"actions,omitempty" protobuf:"bytes,3,opt,name=actions"`
 	IgnoreDifferences string `json:"ignoreDifferences,omitempty" protobuf:"bytes,2,opt,name=ignoreDifferences"`
+	// IgnoreResourceUpdates are the JSON pointers of the fields whose updates don't trigger the refresh of the
+	// applications of the resources, if the ignored resource updates are enabled
+	IgnoreResourceUpdates string `json:"ignoreResourceUpdates,omitempty" protobuf:"bytes,4,opt,name=ignoreResourceUpdates"`
+	// ProgressingDeadline is the duration, e.g. "10m", after which the resources which are still progressing are
+	// reported degraded
+	ProgressingDeadline string `json:"progressingDeadline,omitempty" protobuf:"bytes,5,opt,name=progressingDeadline"`
 }
 
 func (o *ResourceOverride) GetActions() (ResourceActions, error) {
@@ -1131,8 +1506,23 @@ type RepoCreds struct {
 	TLSClientCertData string `json:"tlsClientCertData,omitempty" protobuf:"bytes,5,opt,name=tlsClientCertData"`
 	// TLS client cert key for authenticating at the repo server
 	TLSClientCertKey string `json:"tlsClientCertKey,omitempty" protobuf:"bytes,6,opt,name=tlsClientCertKey"`
+	// MatchType is how the URL matches the URLs of repositories: "prefix" (default), "glob" or "regex"
+	MatchType string `json:"matchType,omitempty" protobuf:"bytes,7,opt,name=matchType"`
+	// Priority of the credentials over other matching credentials. The credentials with the highest priority, and
+	// the longest URL among those of the same priority, are used.
+	Priority int64 `json:"priority,omitempty" protobuf:"varint,8,opt,name=priority"`
 }
 
+const (
+	// RepoCredsMatchTypePrefix matches the URLs of repositories which start with the URL of the credentials
+	RepoCredsMatchTypePrefix = "prefix"
+	// RepoCredsMatchTypeGlob matches the URLs of repositories with a glob pattern, in which * matches any characters
+	// but /, ** matches any characters and ? matches a single character but /
+	RepoCredsMatchTypeGlob = "glob"
+	// RepoCredsMatchTypeRegex matches the whole URLs of repositories with a regular expression
+	RepoCredsMatchTypeRegex = "regex"
+)
+
 // Repository is a repository holding application configurations
 type Repository struct {
 	// URL of the repo
@@ -1157,12 +1547,15 @@ type Repository struct {
 	TLSClientCertData string `json:"tlsClientCertData,omitempty" protobuf:"bytes,9,opt,name=tlsClientCertData"`
 	// TLS client cert key for authenticating at the repo server
 	TLSClientCertKey string `json:"tlsClientCertKey,omitempty" protobuf:"bytes,10,opt,name=tlsClientCertKey"`
-	// type of the repo, maybe "git or "helm, "git" is assumed if empty or absent
+	// type of the repo, maybe "git", "helm", "oci" or "bucket", "git" is assumed if empty or absent
 	Type string `json:"type,omitempty" protobuf:"bytes,11,opt,name=type"`
 	// only for Helm repos
 	Name string `json:"name,omitempty" protobuf:"bytes,12,opt,name=name"`
 	// Whether credentials were inherited from a credential set
 	InheritedCreds bool `json:"inheritedCreds,omitempty" protobuf:"bytes,13,opt,name=inheritedCreds"`
+	// CloudIdentity authenticates to the ECR, Artifact Registry or Azure Container Registry registry of an OCI
+	// repository with the identity of the pods of Argo CD, instead of a username and password
+	CloudIdentity bool `json:"cloudIdentity,omitempty" protobuf:"bytes,14,opt,name=cloudIdentity"`
 }
 
 // IsInsecure returns true if receiver has been configured to skip server verification
@@ -1177,7 +1570,7 @@ func (repo *Repository) IsLFSEnabled() bool {
 
 // HasCredentials returns true when the receiver has been configured any credentials
 func (m *Repository) HasCredentials() bool {
-	return m.Username != "" || m.Password != "" || m.SSHPrivateKey != "" || m.TLSClientCertData != ""
+	return m.Username != "" || m.Password != "" || m.SSHPrivateKey != "" || m.TLSClientCertData != "" || m.CloudIdentity
 }
 
 func (repo *Repository) CopyCredentialsFromRepo(source *Repository) {
@@ -1235,12 +1628,34 @@ func (repo *Repository) GetGitCreds() git.Creds {
 }
 
 func (repo *Repository) GetHelmCreds() helm.Creds {
+	caPath := getCAPath(repo.Repo)
+	if helm.IsOCIURL(repo.Repo) {
+		caPath = getCAPath("https://" + strings.TrimPrefix(repo.Repo, oci.URLPrefix))
+	}
 	return helm.Creds{
+		Username:      repo.Username,
+		Password:      repo.Password,
+		CAPath:        caPath,
+		CertData:      []byte(repo.TLSClientCertData),
+		KeyData:       []byte(repo.TLSClientCertKey),
+		CloudIdentity: repo.CloudIdentity,
+	}
+}
+
+func (repo *Repository) GetOCICreds() oci.Creds {
+	return oci.Creds{
+		Username:      repo.Username,
+		Password:      repo.Password,
+		CAPath:        getCAPath("https://" + strings.TrimPrefix(repo.Repo, oci.URLPrefix)),
+		Insecure:      repo.IsInsecure(),
+		CloudIdentity: repo.CloudIdentity,
+	}
+}
+
+func (repo *Repository) GetBucketCreds() bucket.Creds {
+	return bucket.Creds{
 		Username: repo.Username,
 		Password: repo.Password,
-		CAPath:   getCAPath(repo.Repo),
-		CertData: []byte(repo.TLSClientCertData),
-		KeyData:  []byte(repo.TLSClientCertKey),
 	}
 }
 
@@ -1290,6 +1705,47 @@ type RepositoryList struct {
 	Items           Repositories `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
 
+// RepositoryDiagnostics are the results of the steps of the diagnosis of the connection to a repository
+type RepositoryDiagnostics struct {
+	// Repo is the URL of the repository
+	Repo string `json:"repo" protobuf:"bytes,1,opt,name=repo"`
+	// Proxy is the URL of the proxy the repository is connected through, if any
+	Proxy string `json:"proxy,omitempty" protobuf:"bytes,2,opt,name=proxy"`
+	// Steps are the results of the steps of the diagnosis, in the order they were run
+	Steps []RepositoryDiagnosticStep `json:"steps" protobuf:"bytes,3,rep,name=steps"`
+}
+
+// RepositoryDiagnosticStep is the result of a step of the diagnosis of the connection to a repository
+type RepositoryDiagnosticStep struct {
+	// Name of the step, e.g. DNS, TCP, TLS, Authentication or LsRemote
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Status is Successful, Failed or Skipped
+	Status ConnectionStatus `json:"status" protobuf:"bytes,2,opt,name=status"`
+	// Message summarizes the result of the step
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// Details of the result, e.g. the resolved addresses or the certificates of the TLS chain
+	Details []string `json:"details,omitempty" protobuf:"bytes,4,rep,name=details"`
+}
+
+const (
+	RepositoryDiagnosticStepDNS            = "DNS"
+	RepositoryDiagnosticStepTCP            = "TCP"
+	RepositoryDiagnosticStepTLS            = "TLS"
+	RepositoryDiagnosticStepAuthentication = "Authentication"
+	RepositoryDiagnosticStepLsRemote       = "LsRemote"
+	RepositoryDiagnosticStepAccess         = "Access"
+)
+
+// Failed returns whether a step of the diagnosis failed
+func (d *RepositoryDiagnostics) Failed() bool {
+	for _, step := range d.Steps {
+		if step.Status == ConnectionStatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
 // RepositoryList is a collection of Repositories.
 type RepoCredsList struct {
 	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
@@ -1365,6 +1821,20 @@ func (p *AppProject) GetJWTToken(roleName string, issuedAt int64) (*JWTToken, in
 	return nil, -1, fmt.Errorf("JWT token for role '%s' issued at '%d' does not exist in project '%s'", role.Name, issuedAt, p.Name)
 }
 
+// GetJWTTokenByID looks up the index of a JWTToken in a project by its ID
+func (p *AppProject) GetJWTTokenByID(roleName string, id string) (*JWTToken, int, error) {
+	role, _, err := p.GetRoleByName(roleName)
+	if err != nil {
+		return nil, -1, err
+	}
+	for i, token := range role.JWTTokens {
+		if id == token.ID {
+			return &token, i, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("JWT token for role '%s' with id '%s' does not exist in project '%s'", role.Name, id, p.Name)
+}
+
 func (p *AppProject) ValidateProject() error {
 	destKeys := make(map[string]bool)
 	for _, dest := range p.Spec.Destinations {
@@ -1413,6 +1883,33 @@ func (p *AppProject) ValidateProject() error {
 		roleNames[role.Name] = true
 	}
 
+	destServiceAccounts := make(map[string]bool)
+	for _, item := range p.Spec.DestinationServiceAccounts {
+		key := fmt.Sprintf("%s/%s", item.Server, item.Namespace)
+		if _, ok := destServiceAccounts[key]; ok {
+			return status.Errorf(codes.InvalidArgument, "service account of destination '%s' already added", key)
+		}
+		if item.Server == "" {
+			return status.Errorf(codes.InvalidArgument, "destination server of service account '%s' is required", item.DefaultServiceAccount)
+		}
+		if err := validateServiceAccountName(item.DefaultServiceAccount); err != nil {
+			return err
+		}
+		destServiceAccounts[key] = true
+	}
+
+	if quota := p.Spec.Quota; quota != nil {
+		if quota.MaxApplications < 0 || quota.MaxDestinations < 0 || quota.MaxResourcesPerApplication < 0 || quota.MaxConcurrentSyncs < 0 {
+			return status.Errorf(codes.InvalidArgument, "quota limits must not be negative")
+		}
+	}
+
+	if limits := p.Spec.ManifestGenerationLimits; limits != nil {
+		if err := limits.Validate(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
 	if p.Spec.SyncWindows.HasWindows() {
 		existingWindows := make(map[string]bool)
 		for _, window := range p.Spec.SyncWindows {
@@ -1441,6 +1938,8 @@ var validActions = map[string]bool{
 	"delete":   true,
 	"sync":     true,
 	"override": true,
+	"exec":     true,
+	"logs":     true,
 	"*":        true,
 }
 
@@ -1516,6 +2015,26 @@ func validateGroupName(name string) error {
 	return nil
 }
 
+var (
+	serviceAccountNameRegexp      = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+	serviceAccountNamespaceRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// validateServiceAccountName validates a service account name of the form <namespace>:<name> or <name>
+func validateServiceAccountName(serviceAccount string) error {
+	name := serviceAccount
+	if parts := strings.SplitN(serviceAccount, ":", 2); len(parts) == 2 {
+		if !serviceAccountNamespaceRegexp.MatchString(parts[0]) {
+			return status.Errorf(codes.InvalidArgument, "invalid namespace of service account '%s'", serviceAccount)
+		}
+		name = parts[1]
+	}
+	if !serviceAccountNameRegexp.MatchString(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid service account name '%s'", serviceAccount)
+	}
+	return nil
+}
+
 // AddGroupToRole adds an OIDC group to a role
 func (p *AppProject) AddGroupToRole(roleName, group string) (bool, error) {
 	role, roleIndex, err := p.GetRoleByName(roleName)
@@ -1600,6 +2119,86 @@ type AppProjectSpec struct {
 	OrphanedResources *OrphanedResourcesMonitorSettings `json:"orphanedResources,omitempty" protobuf:"bytes,7,opt,name=orphanedResources"`
 	// SyncWindows controls when syncs can be run for apps in this project
 	SyncWindows SyncWindows `json:"syncWindows,omitempty" protobuf:"bytes,8,opt,name=syncWindows"`
+	// DestinationServiceAccounts holds the service accounts the controller impersonates when syncing apps of this project to a destination
+	DestinationServiceAccounts []ApplicationDestinationServiceAccount `json:"destinationServiceAccounts,omitempty" protobuf:"bytes,9,rep,name=destinationServiceAccounts"`
+	// Quota limits the number of apps of this project, the destinations they use and the resources they manage
+	Quota *ProjectQuota `json:"quota,omitempty" protobuf:"bytes,10,opt,name=quota"`
+	// SyncFreeze blocks the manual and automated syncs of all apps of this project while it is set
+	SyncFreeze *SyncFreeze `json:"syncFreeze,omitempty" protobuf:"bytes,11,opt,name=syncFreeze"`
+	// NotificationServices contains list of notification services (glob patterns) the apps of this project may subscribe to with annotations. Any service is allowed if it is empty.
+	NotificationServices []string `json:"notificationServices,omitempty" protobuf:"bytes,12,rep,name=notificationServices"`
+	// DecryptSOPS permits the repo server to decrypt the SOPS-encrypted files of the directory sources of the apps of this project
+	DecryptSOPS bool `json:"decryptSOPS,omitempty" protobuf:"varint,13,opt,name=decryptSOPS"`
+	// ManifestGenerationLimits limits the time, CPU and memory the repo server may use to generate the manifests of an app of this project
+	ManifestGenerationLimits *ManifestGenerationLimits `json:"manifestGenerationLimits,omitempty" protobuf:"bytes,14,opt,name=manifestGenerationLimits"`
+	// VaultPaths contains list of Vault secret paths (glob patterns) the secret placeholders of the apps of this project may reference. No placeholders are resolved if it is empty.
+	VaultPaths []string `json:"vaultPaths,omitempty" protobuf:"bytes,15,rep,name=vaultPaths"`
+}
+
+// ManifestGenerationLimits limits the resources the repo server uses to generate the manifests of an app. A zero limit means unlimited.
+type ManifestGenerationLimits struct {
+	// TimeoutSeconds is the maximum duration of the manifest generation
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty" protobuf:"varint,1,opt,name=timeoutSeconds"`
+	// CPUSeconds is the maximum CPU time of each command run to generate the manifests, e.g. helm, kustomize or a plugin
+	CPUSeconds int64 `json:"cpuSeconds,omitempty" protobuf:"varint,2,opt,name=cpuSeconds"`
+	// Memory is the maximum virtual memory of each command run to generate the manifests, as a quantity, e.g. 1Gi
+	Memory string `json:"memory,omitempty" protobuf:"bytes,3,opt,name=memory"`
+}
+
+// MemoryBytes returns the memory limit in bytes, 0 if it is unlimited
+func (l *ManifestGenerationLimits) MemoryBytes() (int64, error) {
+	if l.Memory == "" {
+		return 0, nil
+	}
+	quantity, err := resource.ParseQuantity(l.Memory)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit '%s': %v", l.Memory, err)
+	}
+	return quantity.Value(), nil
+}
+
+// Validate returns an error if a limit is negative or the memory limit is not a quantity
+func (l *ManifestGenerationLimits) Validate() error {
+	if l.TimeoutSeconds < 0 || l.CPUSeconds < 0 {
+		return fmt.Errorf("manifest generation limits must not be negative")
+	}
+	memory, err := l.MemoryBytes()
+	if err != nil {
+		return err
+	}
+	if memory < 0 {
+		return fmt.Errorf("manifest generation limits must not be negative")
+	}
+	return nil
+}
+
+// SyncFreeze freezes the syncs of the apps of a project, e.g. during an incident or a change freeze
+type SyncFreeze struct {
+	// Reason explains why the syncs are frozen
+	Reason string `json:"reason,omitempty" protobuf:"bytes,1,opt,name=reason"`
+}
+
+// ProjectQuota limits the capacity the apps of a project may use. A zero limit means unlimited.
+type ProjectQuota struct {
+	// MaxApplications is the maximum number of apps in the project
+	MaxApplications int64 `json:"maxApplications,omitempty" protobuf:"varint,1,opt,name=maxApplications"`
+	// MaxDestinations is the maximum number of distinct destinations the apps of the project deploy to
+	MaxDestinations int64 `json:"maxDestinations,omitempty" protobuf:"varint,2,opt,name=maxDestinations"`
+	// MaxResourcesPerApplication is the maximum number of resources an app of the project may manage
+	MaxResourcesPerApplication int64 `json:"maxResourcesPerApplication,omitempty" protobuf:"varint,3,opt,name=maxResourcesPerApplication"`
+	// MaxConcurrentSyncs is the maximum number of sync operations of the apps of the project which run at the same
+	// time. The operations which exceed it are queued.
+	MaxConcurrentSyncs int64 `json:"maxConcurrentSyncs,omitempty" protobuf:"varint,4,opt,name=maxConcurrentSyncs"`
+}
+
+// ApplicationDestinationServiceAccount is a service account the controller impersonates when syncing apps to a destination
+type ApplicationDestinationServiceAccount struct {
+	// Server is the URL of the destination cluster, which may be a glob pattern
+	Server string `json:"server" protobuf:"bytes,1,opt,name=server"`
+	// Namespace is the destination namespace, which may be a glob pattern
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+	// DefaultServiceAccount is the service account to impersonate, as <namespace>:<name>, or as <name> of a service account in the destination namespace
+	DefaultServiceAccount string `json:"defaultServiceAccount" protobuf:"bytes,3,opt,name=defaultServiceAccount"`
 }
 
 // SyncWindows is a collection of sync windows in this project
@@ -1754,8 +2353,15 @@ func (w *SyncWindows) Matches(app *Application) *SyncWindows {
 }
 
 func (w *SyncWindows) CanSync(isManual bool) bool {
+	canSync, _ := w.CanSyncWithReason(isManual)
+	return canSync
+}
+
+// CanSyncWithReason returns whether a manual or automated sync is permitted by the windows, along with a human readable
+// explanation of the decision
+func (w *SyncWindows) CanSyncWithReason(isManual bool) (bool, string) {
 	if !w.HasWindows() {
-		return true
+		return true, "no sync windows are assigned"
 	}
 
 	var allowActive, denyActive, manualEnabled bool
@@ -1766,18 +2372,16 @@ func (w *SyncWindows) CanSync(isManual bool) bool {
 	if !denyActive {
 		if !allowActive {
 			if isManual && w.InactiveAllows().manualEnabled() {
-				return true
+				return true, "no allow window is active, but manual sync is enabled on an allow window"
 			}
-		} else {
-			return true
-		}
-	} else {
-		if isManual && manualEnabled {
-			return true
+			return false, "no allow window is active"
 		}
+		return true, "an allow window is active"
 	}
-
-	return false
+	if isManual && manualEnabled {
+		return true, "a deny window is active, but manual sync is enabled on all active deny windows"
+	}
+	return false, "a deny window is active"
 }
 
 func (w *SyncWindows) hasDeny() (bool, bool) {
@@ -1905,6 +2509,13 @@ type ProjectRole struct {
 type JWTToken struct {
 	IssuedAt  int64 `json:"iat" protobuf:"int64,1,opt,name=iat"`
 	ExpiresAt int64 `json:"exp,omitempty" protobuf:"int64,2,opt,name=exp"`
+	// ID is the unique identifier (jti) of the token. Tokens issued by older versions have no ID.
+	ID string `json:"id,omitempty" protobuf:"bytes,3,opt,name=id"`
+}
+
+// IsExpired returns whether or not the token has expired by the given time
+func (t *JWTToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt > 0 && now.Unix() >= t.ExpiresAt
 }
 
 // Command holds binary path and arguments list
@@ -2056,6 +2667,27 @@ func (source *ApplicationSource) Equals(other ApplicationSource) bool {
 	return reflect.DeepEqual(*source, other)
 }
 
+// MergeOverrides merges the helm parameters and kustomize images of parameter overrides into the source, e.g. of the
+// overrides written back to the repository by an image updater
+func (source *ApplicationSource) MergeOverrides(overrides ApplicationSource) {
+	if overrides.Helm != nil {
+		if source.Helm == nil {
+			source.Helm = &ApplicationSourceHelm{}
+		}
+		for _, p := range overrides.Helm.Parameters {
+			source.Helm.AddParameter(p)
+		}
+	}
+	if overrides.Kustomize != nil {
+		if source.Kustomize == nil {
+			source.Kustomize = &ApplicationSourceKustomize{}
+		}
+		for _, image := range overrides.Kustomize.Images {
+			source.Kustomize.MergeImage(image)
+		}
+	}
+}
+
 func (source *ApplicationSource) ExplicitType() (*ApplicationSourceType, error) {
 	var appTypes []ApplicationSourceType
 	if source.Kustomize != nil {
@@ -2073,6 +2705,15 @@ func (source *ApplicationSource) ExplicitType() (*ApplicationSourceType, error)
 	if source.Plugin != nil {
 		appTypes = append(appTypes, ApplicationSourceTypePlugin)
 	}
+	if source.CUE != nil {
+		appTypes = append(appTypes, ApplicationSourceTypeCUE)
+	}
+	if source.Tanka != nil {
+		appTypes = append(appTypes, ApplicationSourceTypeTanka)
+	}
+	if source.Helmfile != nil {
+		appTypes = append(appTypes, ApplicationSourceTypeHelmfile)
+	}
 	if len(appTypes) == 0 {
 		return nil, nil
 	}
@@ -2162,6 +2803,123 @@ func (proj AppProject) IsSourcePermitted(src ApplicationSource) bool {
 	return false
 }
 
+// GetDestinationServiceAccount returns the Kubernetes user name of the service account which the controller
+// impersonates when syncing to the destination, i.e. of the first matching destination service account
+func (proj AppProject) GetDestinationServiceAccount(dst ApplicationDestination) (string, bool) {
+	for _, item := range proj.Spec.DestinationServiceAccounts {
+		if globMatch(item.Server, dst.Server) && globMatch(item.Namespace, dst.Namespace) {
+			namespace, name := dst.Namespace, item.DefaultServiceAccount
+			if parts := strings.SplitN(item.DefaultServiceAccount, ":", 2); len(parts) == 2 {
+				namespace, name = parts[0], parts[1]
+			}
+			return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name), true
+		}
+	}
+	return "", false
+}
+
+// SyncFreezeMessage returns the message explaining why the syncs of the apps of the project are frozen,
+// or an empty string if they are not
+func (proj AppProject) SyncFreezeMessage() string {
+	if proj.Spec.SyncFreeze == nil {
+		return ""
+	}
+	if proj.Spec.SyncFreeze.Reason == "" {
+		return fmt.Sprintf("Syncs of project '%s' are frozen", proj.Name)
+	}
+	return fmt.Sprintf("Syncs of project '%s' are frozen: %s", proj.Name, proj.Spec.SyncFreeze.Reason)
+}
+
+// IsNotificationServicePermitted returns whether the apps of the project may subscribe to the notification service
+// with annotations
+func (proj AppProject) IsNotificationServicePermitted(service string) bool {
+	if len(proj.Spec.NotificationServices) == 0 {
+		return true
+	}
+	for _, item := range proj.Spec.NotificationServices {
+		if globMatch(item, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateApplicationQuota returns an error if admitting the app to the project, which already has the given
+// apps, would exceed the application or destination quota of the project
+func (proj AppProject) ValidateApplicationQuota(app *Application, apps []*Application) error {
+	quota := proj.Spec.Quota
+	if quota == nil || (quota.MaxApplications == 0 && quota.MaxDestinations == 0) {
+		return nil
+	}
+	count := int64(1)
+	destinations := map[string]bool{
+		fmt.Sprintf("%s/%s", app.Spec.Destination.Server, app.Spec.Destination.Namespace): true,
+	}
+	for _, a := range apps {
+		if a.Spec.GetProject() != proj.Name || a.Name == app.Name {
+			continue
+		}
+		count++
+		destinations[fmt.Sprintf("%s/%s", a.Spec.Destination.Server, a.Spec.Destination.Namespace)] = true
+	}
+	if quota.MaxApplications > 0 && count > quota.MaxApplications {
+		return status.Errorf(codes.ResourceExhausted, "project '%s' is limited to %d applications", proj.Name, quota.MaxApplications)
+	}
+	if quota.MaxDestinations > 0 && int64(len(destinations)) > quota.MaxDestinations {
+		return status.Errorf(codes.ResourceExhausted, "project '%s' is limited to %d destinations", proj.Name, quota.MaxDestinations)
+	}
+	return nil
+}
+
+// ValidateAdmittedApplicationQuota returns an error if the app exceeds the application or destination quota of the
+// project, which has the given apps, e.g. since it has been created without the API server. The apps are admitted in the
+// order of their creation, so that only the apps created after the quota has been reached exceed it.
+func (proj AppProject) ValidateAdmittedApplicationQuota(app *Application, apps []*Application) error {
+	quota := proj.Spec.Quota
+	if quota == nil || (quota.MaxApplications == 0 && quota.MaxDestinations == 0) {
+		return nil
+	}
+	projApps := []*Application{app}
+	for _, a := range apps {
+		if a.Spec.GetProject() == proj.Name && a.Name != app.Name {
+			projApps = append(projApps, a)
+		}
+	}
+	sort.Slice(projApps, func(i, j int) bool {
+		if !projApps[i].CreationTimestamp.Equal(&projApps[j].CreationTimestamp) {
+			return projApps[i].CreationTimestamp.Before(&projApps[j].CreationTimestamp)
+		}
+		return projApps[i].Name < projApps[j].Name
+	})
+	count := int64(0)
+	destinations := map[string]bool{}
+	for _, a := range projApps {
+		destination := fmt.Sprintf("%s/%s", a.Spec.Destination.Server, a.Spec.Destination.Namespace)
+		var err error
+		if quota.MaxApplications > 0 && count >= quota.MaxApplications {
+			err = fmt.Errorf("project '%s' is limited to %d applications", proj.Name, quota.MaxApplications)
+		} else if quota.MaxDestinations > 0 && !destinations[destination] && int64(len(destinations)) >= quota.MaxDestinations {
+			err = fmt.Errorf("project '%s' is limited to %d destinations", proj.Name, quota.MaxDestinations)
+		}
+		if a == app {
+			return err
+		}
+		if err == nil {
+			count++
+			destinations[destination] = true
+		}
+	}
+	return nil
+}
+
+// ValidateResourceQuota returns an error if the number of resources of an app exceeds the quota of the project
+func (proj AppProject) ValidateResourceQuota(count int) error {
+	if quota := proj.Spec.Quota; quota != nil && quota.MaxResourcesPerApplication > 0 && int64(count) > quota.MaxResourcesPerApplication {
+		return fmt.Errorf("application has %d resources, but project '%s' is limited to %d resources per application", count, proj.Name, quota.MaxResourcesPerApplication)
+	}
+	return nil
+}
+
 // IsDestinationPermitted validates if the provided application's destination is one of the allowed destinations for the project
 func (proj AppProject) IsDestinationPermitted(dst ApplicationDestination) bool {
 	for _, item := range proj.Spec.Destinations {
@@ -217,6 +217,123 @@ func TestAppProject_ValidateRoleName(t *testing.T) {
 	}
 }
 
+func TestAppProject_ValidateDestinationServiceAccounts(t *testing.T) {
+	p := newTestProject()
+	p.Spec.DestinationServiceAccounts = []ApplicationDestinationServiceAccount{{Server: "https://cluster", Namespace: "guestbook", DefaultServiceAccount: "deployer"}}
+	assert.NoError(t, p.ValidateProject())
+	p.Spec.DestinationServiceAccounts[0].DefaultServiceAccount = "argocd:deployer"
+	assert.NoError(t, p.ValidateProject())
+
+	for _, badName := range []string{"", "Deployer", "argocd:", ":deployer", "argo_cd:deployer"} {
+		p.Spec.DestinationServiceAccounts[0].DefaultServiceAccount = badName
+		assert.Error(t, p.ValidateProject(), badName)
+	}
+	p.Spec.DestinationServiceAccounts = []ApplicationDestinationServiceAccount{{Namespace: "guestbook", DefaultServiceAccount: "deployer"}}
+	assert.Error(t, p.ValidateProject())
+	p.Spec.DestinationServiceAccounts = []ApplicationDestinationServiceAccount{
+		{Server: "https://cluster", Namespace: "guestbook", DefaultServiceAccount: "deployer"},
+		{Server: "https://cluster", Namespace: "guestbook", DefaultServiceAccount: "other"},
+	}
+	assert.Error(t, p.ValidateProject())
+}
+
+func TestAppProject_GetDestinationServiceAccount(t *testing.T) {
+	p := newTestProject()
+	p.Spec.DestinationServiceAccounts = []ApplicationDestinationServiceAccount{
+		{Server: "https://cluster", Namespace: "kube-*", DefaultServiceAccount: "argocd:system-deployer"},
+		{Server: "*", Namespace: "*", DefaultServiceAccount: "deployer"},
+	}
+	sa, ok := p.GetDestinationServiceAccount(ApplicationDestination{Server: "https://cluster", Namespace: "kube-system"})
+	assert.True(t, ok)
+	assert.Equal(t, "system:serviceaccount:argocd:system-deployer", sa)
+	sa, ok = p.GetDestinationServiceAccount(ApplicationDestination{Server: "https://other", Namespace: "guestbook"})
+	assert.True(t, ok)
+	assert.Equal(t, "system:serviceaccount:guestbook:deployer", sa)
+
+	p.Spec.DestinationServiceAccounts = nil
+	_, ok = p.GetDestinationServiceAccount(ApplicationDestination{Server: "https://cluster", Namespace: "guestbook"})
+	assert.False(t, ok)
+}
+
+func TestAppProject_ValidateApplicationQuota(t *testing.T) {
+	p := newTestProject()
+	newApp := func(name, namespace string) *Application {
+		return &Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ApplicationSpec{Project: p.Name, Destination: ApplicationDestination{Server: "https://cluster", Namespace: namespace}},
+		}
+	}
+	apps := []*Application{newApp("a", "ns1"), newApp("b", "ns2")}
+	assert.NoError(t, p.ValidateApplicationQuota(newApp("c", "ns3"), apps))
+
+	p.Spec.Quota = &ProjectQuota{MaxApplications: 2}
+	assert.Error(t, p.ValidateApplicationQuota(newApp("c", "ns1"), apps))
+	assert.NoError(t, p.ValidateApplicationQuota(newApp("b", "ns3"), apps))
+	other := newApp("c", "ns1")
+	other.Spec.Project = "other"
+	assert.NoError(t, p.ValidateApplicationQuota(newApp("d", "ns1"), []*Application{apps[0], other}))
+
+	p.Spec.Quota = &ProjectQuota{MaxDestinations: 2}
+	assert.NoError(t, p.ValidateApplicationQuota(newApp("c", "ns1"), apps))
+	assert.Error(t, p.ValidateApplicationQuota(newApp("c", "ns3"), apps))
+	assert.NoError(t, p.ValidateApplicationQuota(newApp("b", "ns3"), apps))
+
+	p.Spec.Quota = &ProjectQuota{MaxResourcesPerApplication: 2}
+	assert.NoError(t, p.ValidateResourceQuota(2))
+	assert.Error(t, p.ValidateResourceQuota(3))
+
+	assert.NoError(t, p.ValidateProject())
+	p.Spec.Quota = &ProjectQuota{MaxApplications: -1}
+	assert.Error(t, p.ValidateProject())
+}
+
+func TestAppProject_ValidateAdmittedApplicationQuota(t *testing.T) {
+	p := newTestProject()
+	newApp := func(name, namespace string, created int64) *Application {
+		return &Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.Unix(created, 0)},
+			Spec:       ApplicationSpec{Project: p.Name, Destination: ApplicationDestination{Server: "https://cluster", Namespace: namespace}},
+		}
+	}
+	apps := []*Application{newApp("c", "ns3", 3), newApp("a", "ns1", 1), newApp("b", "ns2", 2)}
+	assert.NoError(t, p.ValidateAdmittedApplicationQuota(apps[0], apps))
+
+	// the apps created after the quota has been reached exceed it
+	p.Spec.Quota = &ProjectQuota{MaxApplications: 2}
+	assert.NoError(t, p.ValidateAdmittedApplicationQuota(apps[1], apps))
+	assert.NoError(t, p.ValidateAdmittedApplicationQuota(apps[2], apps))
+	assert.EqualError(t, p.ValidateAdmittedApplicationQuota(apps[0], apps), "project 'my-proj' is limited to 2 applications")
+	other := newApp("d", "ns1", 0)
+	other.Spec.Project = "other"
+	assert.NoError(t, p.ValidateAdmittedApplicationQuota(apps[2], append(apps, other)))
+
+	// apps of admitted destinations don't exceed the quota, and apps which exceed it don't use it up
+	p.Spec.Quota = &ProjectQuota{MaxDestinations: 2}
+	assert.EqualError(t, p.ValidateAdmittedApplicationQuota(apps[0], apps), "project 'my-proj' is limited to 2 destinations")
+	apps = append(apps, newApp("e", "ns1", 4))
+	assert.NoError(t, p.ValidateAdmittedApplicationQuota(apps[3], apps))
+	p.Spec.Quota = &ProjectQuota{MaxApplications: 2, MaxDestinations: 3}
+	assert.Error(t, p.ValidateAdmittedApplicationQuota(apps[3], apps))
+}
+
+func TestAppProject_SyncFreezeMessage(t *testing.T) {
+	p := newTestProject()
+	assert.Empty(t, p.SyncFreezeMessage())
+	p.Spec.SyncFreeze = &SyncFreeze{}
+	assert.Equal(t, "Syncs of project 'my-proj' are frozen", p.SyncFreezeMessage())
+	p.Spec.SyncFreeze.Reason = "change freeze"
+	assert.Equal(t, "Syncs of project 'my-proj' are frozen: change freeze", p.SyncFreezeMessage())
+}
+
+func TestAppProject_IsNotificationServicePermitted(t *testing.T) {
+	p := newTestProject()
+	assert.True(t, p.IsNotificationServicePermitted("slack"))
+	p.Spec.NotificationServices = []string{"slack", "webhook-*"}
+	assert.True(t, p.IsNotificationServicePermitted("slack"))
+	assert.True(t, p.IsNotificationServicePermitted("webhook-jira"))
+	assert.False(t, p.IsNotificationServicePermitted("email"))
+}
+
 // TestValidateGroupName tests for an invalid group name
 func TestAppProject_ValidateGroupName(t *testing.T) {
 	p := newTestProject()
@@ -347,6 +464,25 @@ func TestExplicitType(t *testing.T) {
 	assert.Equal(t, *explicitType, ApplicationSourceTypeHelm)
 }
 
+func TestApplicationSource_MergeOverrides(t *testing.T) {
+	src := ApplicationSource{
+		Helm: &ApplicationSourceHelm{
+			ValueFiles: []string{"values.yaml"},
+			Parameters: []HelmParameter{{Name: "image.tag", Value: "v1"}, {Name: "replicas", Value: "2"}},
+		},
+	}
+	src.MergeOverrides(ApplicationSource{Helm: &ApplicationSourceHelm{Parameters: []HelmParameter{{Name: "image.tag", Value: "v2"}}}})
+	assert.Equal(t, []string{"values.yaml"}, src.Helm.ValueFiles)
+	assert.Equal(t, []HelmParameter{{Name: "image.tag", Value: "v2"}, {Name: "replicas", Value: "2"}}, src.Helm.Parameters)
+	assert.Nil(t, src.Kustomize)
+
+	src = ApplicationSource{}
+	src.MergeOverrides(ApplicationSource{Kustomize: &ApplicationSourceKustomize{Images: KustomizeImages{"guestbook:v2"}}})
+	src.MergeOverrides(ApplicationSource{Kustomize: &ApplicationSourceKustomize{Images: KustomizeImages{"guestbook:v3", "redis:6"}}})
+	assert.Equal(t, KustomizeImages{"guestbook:v3", "redis:6"}, src.Kustomize.Images)
+	assert.Nil(t, src.Helm)
+}
+
 func TestExplicitTypeWithDirectory(t *testing.T) {
 	src := ApplicationSource{
 		Ksonnet: &ApplicationSourceKsonnet{
@@ -1068,6 +1204,32 @@ func TestApplicationSourceKustomize_MergeImage(t *testing.T) {
 	})
 }
 
+func TestRevisionHistories_CompressSources(t *testing.T) {
+	source := ApplicationSource{
+		RepoURL:        "https://github.com/argoproj/argocd-example-apps",
+		Path:           "helm-guestbook",
+		TargetRevision: "HEAD",
+		Helm:           &ApplicationSourceHelm{Values: "replicaCount: 2", ValueFiles: []string{"values-prod.yaml"}},
+	}
+	history := RevisionHistories{{ID: 0, Source: source}, {ID: 1, Source: source}, {ID: 2}}
+	assert.NoError(t, history.CompressSources(2))
+
+	assert.NotNil(t, history[0].CompressedSource)
+	assert.Equal(t, ApplicationSource{RepoURL: source.RepoURL, Path: source.Path, TargetRevision: source.TargetRevision}, history[0].Source)
+	fullSource, err := history[0].FullSource()
+	assert.NoError(t, err)
+	assert.Equal(t, source, fullSource)
+
+	assert.Nil(t, history[1].CompressedSource)
+	assert.Equal(t, source, history[1].Source)
+
+	// compressing again leaves the entries unchanged
+	compressed := history[0].CompressedSource
+	assert.NoError(t, history.CompressSources(3))
+	assert.Equal(t, compressed, history[0].CompressedSource)
+	assert.Nil(t, history[2].CompressedSource)
+}
+
 func TestSyncWindows_HasWindows(t *testing.T) {
 	t.Run("True", func(t *testing.T) {
 		proj := newTestProjectWithSyncWindows()
@@ -1316,6 +1478,40 @@ func TestSyncWindows_CanSync(t *testing.T) {
 	})
 }
 
+func TestSyncWindows_CanSyncWithReason(t *testing.T) {
+	t.Run("NoWindows", func(t *testing.T) {
+		var windows *SyncWindows
+		canSync, reason := windows.CanSyncWithReason(false)
+		assert.True(t, canSync)
+		assert.Equal(t, "no sync windows are assigned", reason)
+	})
+	t.Run("ActiveAllow", func(t *testing.T) {
+		proj := newTestProjectWithSyncWindows()
+		canSync, reason := proj.Spec.SyncWindows.CanSyncWithReason(false)
+		assert.True(t, canSync)
+		assert.Equal(t, "an allow window is active", reason)
+	})
+	t.Run("InactiveAllowWithManualSyncEnabled", func(t *testing.T) {
+		proj := newTestProjectWithSyncWindows()
+		proj.Spec.SyncWindows[0].Schedule = "0 0 1 * *"
+		proj.Spec.SyncWindows[0].Duration = "1m"
+		proj.Spec.SyncWindows[0].ManualSync = true
+		canSync, reason := proj.Spec.SyncWindows.CanSyncWithReason(true)
+		assert.True(t, canSync)
+		assert.Contains(t, reason, "manual sync is enabled")
+		canSync, reason = proj.Spec.SyncWindows.CanSyncWithReason(false)
+		assert.False(t, canSync)
+		assert.Equal(t, "no allow window is active", reason)
+	})
+	t.Run("ActiveDeny", func(t *testing.T) {
+		proj := newTestProjectWithSyncWindows()
+		proj.Spec.SyncWindows[0].Kind = "deny"
+		canSync, reason := proj.Spec.SyncWindows.CanSyncWithReason(true)
+		assert.False(t, canSync)
+		assert.Equal(t, "a deny window is active", reason)
+	})
+}
+
 func TestSyncWindows_hasDeny(t *testing.T) {
 	t.Run("True", func(t *testing.T) {
 		proj := newTestProjectWithSyncWindows()
@@ -1695,3 +1891,71 @@ func TestApplicationSpec_GetRevisionHistoryLimit(t *testing.T) {
 	n := int64(11)
 	assert.Equal(t, 11, ApplicationSpec{RevisionHistoryLimit: &n}.GetRevisionHistoryLimit())
 }
+
+func TestApplicationTemplateSpec_ResolveParameters(t *testing.T) {
+	spec := ApplicationTemplateSpec{Parameters: []ApplicationTemplateParameter{
+		{Name: "image", Required: true},
+		{Name: "replicas", Default: "1", Pattern: "[0-9]+"},
+		{Name: "env", Default: "dev", Enum: []string{"dev", "prod"}},
+	}}
+
+	params, err := spec.ResolveParameters("my-app", map[string]string{"image": "nginx", "replicas": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "my-app", "image": "nginx", "replicas": "3", "env": "dev"}, params)
+
+	_, err = spec.ResolveParameters("", map[string]string{"image": "nginx"})
+	assert.EqualError(t, err, "application name is required")
+	_, err = spec.ResolveParameters("my-app", map[string]string{})
+	assert.EqualError(t, err, "parameter 'image' is required")
+	_, err = spec.ResolveParameters("my-app", map[string]string{"image": "nginx", "replicas": "3a"})
+	assert.EqualError(t, err, "parameter 'replicas' must match [0-9]+")
+	_, err = spec.ResolveParameters("my-app", map[string]string{"image": "nginx", "env": "staging"})
+	assert.EqualError(t, err, "parameter 'env' must be one of dev, prod")
+	_, err = spec.ResolveParameters("my-app", map[string]string{"image": "nginx", "tag": "1", "name": "other"})
+	assert.EqualError(t, err, "unknown parameters: name, tag")
+
+	spec.Parameters = append(spec.Parameters, ApplicationTemplateParameter{Name: "name"})
+	_, err = spec.ResolveParameters("my-app", map[string]string{"image": "nginx"})
+	assert.EqualError(t, err, "parameter 'name' is reserved for the application name")
+}
+
+func TestOperationState_GetSyncTimeline(t *testing.T) {
+	start := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	at := func(seconds int) *metav1.Time {
+		t := metav1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+		return &t
+	}
+	state := OperationState{
+		Phase:     OperationRunning,
+		StartedAt: start,
+		SyncResult: &SyncOperationResult{Revision: "abc", Resources: ResourceResults{
+			{Kind: "Deployment", Name: "web", Status: ResultCodeSynced, HookPhase: OperationRunning, SyncPhase: SyncPhaseSync, AppliedAt: at(70)},
+			{Kind: "Pod", Name: "pending", SyncPhase: SyncPhasePostSync},
+			{Kind: "Job", Name: "migrate", HookType: HookTypePreSync, HookPhase: OperationSucceeded, SyncPhase: SyncPhasePreSync, AppliedAt: at(5), FinishedAt: at(65)},
+		}},
+	}
+
+	timeline := state.GetSyncTimeline()
+	assert.Equal(t, "abc", timeline.Revision)
+	assert.Equal(t, OperationRunning, timeline.Phase)
+	if assert.Len(t, timeline.Entries, 3) {
+		assert.Equal(t, "migrate", timeline.Entries[0].Name)
+		assert.Equal(t, int64(5), timeline.Entries[0].WaitSeconds)
+		assert.Equal(t, int64(60), timeline.Entries[0].DurationSeconds)
+		assert.Equal(t, "web", timeline.Entries[1].Name)
+		assert.Equal(t, int64(70), timeline.Entries[1].WaitSeconds)
+		assert.Equal(t, int64(0), timeline.Entries[1].DurationSeconds)
+		assert.Equal(t, "pending", timeline.Entries[2].Name)
+		assert.Nil(t, timeline.Entries[2].AppliedAt)
+	}
+
+	state.Destinations = []DestinationOperationState{
+		{Destination: ApplicationDestination{Server: "https://a", Namespace: "ns"}, SyncResult: state.SyncResult},
+		{Destination: ApplicationDestination{Server: "https://b", Namespace: "ns"}, SyncResult: &SyncOperationResult{Resources: ResourceResults{{Kind: "Service", Name: "web", AppliedAt: at(1)}}}},
+	}
+	timeline = state.GetSyncTimeline()
+	if assert.Len(t, timeline.Entries, 4) {
+		assert.Equal(t, "https://b/ns", timeline.Entries[0].Destination)
+		assert.Equal(t, "https://a/ns", timeline.Entries[1].Destination)
+	}
+}
@@ -12,6 +12,10 @@ type ArgoprojV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AppProjectsGetter
 	ApplicationsGetter
+	ApplicationSetsGetter
+	ApplicationTemplatesGetter
+	ClusterRegistrationsGetter
+	RepositoryRegistrationsGetter
 }
 
 // ArgoprojV1alpha1Client is used to interact with features provided by the argoproj.io group.
@@ -27,6 +31,22 @@ func (c *ArgoprojV1alpha1Client) Applications(namespace string) ApplicationInter
 	return newApplications(c, namespace)
 }
 
+func (c *ArgoprojV1alpha1Client) ApplicationSets(namespace string) ApplicationSetInterface {
+	return newApplicationSets(c, namespace)
+}
+
+func (c *ArgoprojV1alpha1Client) ApplicationTemplates(namespace string) ApplicationTemplateInterface {
+	return newApplicationTemplates(c, namespace)
+}
+
+func (c *ArgoprojV1alpha1Client) ClusterRegistrations(namespace string) ClusterRegistrationInterface {
+	return newClusterRegistrations(c, namespace)
+}
+
+func (c *ArgoprojV1alpha1Client) RepositoryRegistrations(namespace string) RepositoryRegistrationInterface {
+	return newRepositoryRegistrations(c, namespace)
+}
+
 // NewForConfig creates a new ArgoprojV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*ArgoprojV1alpha1Client, error) {
 	config := *c
@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	scheme "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ApplicationTemplatesGetter has a method to return a ApplicationTemplateInterface.
+// A group's client should implement this interface.
+type ApplicationTemplatesGetter interface {
+	ApplicationTemplates(namespace string) ApplicationTemplateInterface
+}
+
+// ApplicationTemplateInterface has methods to work with ApplicationTemplate resources.
+type ApplicationTemplateInterface interface {
+	Create(*v1alpha1.ApplicationTemplate) (*v1alpha1.ApplicationTemplate, error)
+	Update(*v1alpha1.ApplicationTemplate) (*v1alpha1.ApplicationTemplate, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.ApplicationTemplate, error)
+	List(opts v1.ListOptions) (*v1alpha1.ApplicationTemplateList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ApplicationTemplate, err error)
+	ApplicationTemplateExpansion
+}
+
+// applicationTemplates implements ApplicationTemplateInterface
+type applicationTemplates struct {
+	client rest.Interface
+	ns     string
+}
+
+// newApplicationTemplates returns a ApplicationTemplates
+func newApplicationTemplates(c *ArgoprojV1alpha1Client, namespace string) *applicationTemplates {
+	return &applicationTemplates{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the applicationTemplate, and returns the corresponding applicationTemplate object, and an error if there is any.
+func (c *applicationTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.ApplicationTemplate, err error) {
+	result = &v1alpha1.ApplicationTemplate{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ApplicationTemplates that match those selectors.
+func (c *applicationTemplates) List(opts v1.ListOptions) (result *v1alpha1.ApplicationTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ApplicationTemplateList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested applicationTemplates.
+func (c *applicationTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a applicationTemplate and creates it.  Returns the server's representation of the applicationTemplate, and an error, if there is any.
+func (c *applicationTemplates) Create(applicationTemplate *v1alpha1.ApplicationTemplate) (result *v1alpha1.ApplicationTemplate, err error) {
+	result = &v1alpha1.ApplicationTemplate{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		Body(applicationTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a applicationTemplate and updates it. Returns the server's representation of the applicationTemplate, and an error, if there is any.
+func (c *applicationTemplates) Update(applicationTemplate *v1alpha1.ApplicationTemplate) (result *v1alpha1.ApplicationTemplate, err error) {
+	result = &v1alpha1.ApplicationTemplate{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		Name(applicationTemplate.Name).
+		Body(applicationTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the applicationTemplate and deletes it. Returns an error if one occurs.
+func (c *applicationTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *applicationTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched applicationTemplate.
+func (c *applicationTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ApplicationTemplate, err error) {
+	result = &v1alpha1.ApplicationTemplate{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("applicationtemplates").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	scheme "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterRegistrationsGetter has a method to return a ClusterRegistrationInterface.
+// A group's client should implement this interface.
+type ClusterRegistrationsGetter interface {
+	ClusterRegistrations(namespace string) ClusterRegistrationInterface
+}
+
+// ClusterRegistrationInterface has methods to work with ClusterRegistration resources.
+type ClusterRegistrationInterface interface {
+	Create(*v1alpha1.ClusterRegistration) (*v1alpha1.ClusterRegistration, error)
+	Update(*v1alpha1.ClusterRegistration) (*v1alpha1.ClusterRegistration, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.ClusterRegistration, error)
+	List(opts v1.ListOptions) (*v1alpha1.ClusterRegistrationList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterRegistration, err error)
+	ClusterRegistrationExpansion
+}
+
+// clusterRegistrations implements ClusterRegistrationInterface
+type clusterRegistrations struct {
+	client rest.Interface
+	ns     string
+}
+
+// newClusterRegistrations returns a ClusterRegistrations
+func newClusterRegistrations(c *ArgoprojV1alpha1Client, namespace string) *clusterRegistrations {
+	return &clusterRegistrations{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the clusterRegistration, and returns the corresponding clusterRegistration object, and an error if there is any.
+func (c *clusterRegistrations) Get(name string, options v1.GetOptions) (result *v1alpha1.ClusterRegistration, err error) {
+	result = &v1alpha1.ClusterRegistration{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterRegistrations that match those selectors.
+func (c *clusterRegistrations) List(opts v1.ListOptions) (result *v1alpha1.ClusterRegistrationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterRegistrationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRegistrations.
+func (c *clusterRegistrations) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a clusterRegistration and creates it.  Returns the server's representation of the clusterRegistration, and an error, if there is any.
+func (c *clusterRegistrations) Create(clusterRegistration *v1alpha1.ClusterRegistration) (result *v1alpha1.ClusterRegistration, err error) {
+	result = &v1alpha1.ClusterRegistration{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		Body(clusterRegistration).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterRegistration and updates it. Returns the server's representation of the clusterRegistration, and an error, if there is any.
+func (c *clusterRegistrations) Update(clusterRegistration *v1alpha1.ClusterRegistration) (result *v1alpha1.ClusterRegistration, err error) {
+	result = &v1alpha1.ClusterRegistration{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		Name(clusterRegistration.Name).
+		Body(clusterRegistration).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterRegistration and deletes it. Returns an error if one occurs.
+func (c *clusterRegistrations) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterRegistrations) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterRegistration.
+func (c *clusterRegistrations) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterRegistration, err error) {
+	result = &v1alpha1.ClusterRegistration{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("clusterregistrations").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,112 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeApplicationTemplates implements ApplicationTemplateInterface
+type FakeApplicationTemplates struct {
+	Fake *FakeArgoprojV1alpha1
+	ns   string
+}
+
+var applicationtemplatesResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applicationtemplates"}
+
+var applicationtemplatesKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ApplicationTemplate"}
+
+// Get takes name of the applicationTemplate, and returns the corresponding applicationTemplate object, and an error if there is any.
+func (c *FakeApplicationTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.ApplicationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(applicationtemplatesResource, c.ns, name), &v1alpha1.ApplicationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ApplicationTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of ApplicationTemplates that match those selectors.
+func (c *FakeApplicationTemplates) List(opts v1.ListOptions) (result *v1alpha1.ApplicationTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(applicationtemplatesResource, applicationtemplatesKind, c.ns, opts), &v1alpha1.ApplicationTemplateList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ApplicationTemplateList{ListMeta: obj.(*v1alpha1.ApplicationTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ApplicationTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested applicationTemplates.
+func (c *FakeApplicationTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(applicationtemplatesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a applicationTemplate and creates it.  Returns the server's representation of the applicationTemplate, and an error, if there is any.
+func (c *FakeApplicationTemplates) Create(applicationTemplate *v1alpha1.ApplicationTemplate) (result *v1alpha1.ApplicationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(applicationtemplatesResource, c.ns, applicationTemplate), &v1alpha1.ApplicationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ApplicationTemplate), err
+}
+
+// Update takes the representation of a applicationTemplate and updates it. Returns the server's representation of the applicationTemplate, and an error, if there is any.
+func (c *FakeApplicationTemplates) Update(applicationTemplate *v1alpha1.ApplicationTemplate) (result *v1alpha1.ApplicationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(applicationtemplatesResource, c.ns, applicationTemplate), &v1alpha1.ApplicationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ApplicationTemplate), err
+}
+
+// Delete takes name of the applicationTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeApplicationTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(applicationtemplatesResource, c.ns, name), &v1alpha1.ApplicationTemplate{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeApplicationTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(applicationtemplatesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ApplicationTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched applicationTemplate.
+func (c *FakeApplicationTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ApplicationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(applicationtemplatesResource, c.ns, name, pt, data, subresources...), &v1alpha1.ApplicationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ApplicationTemplate), err
+}
@@ -0,0 +1,112 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterRegistrations implements ClusterRegistrationInterface
+type FakeClusterRegistrations struct {
+	Fake *FakeArgoprojV1alpha1
+	ns   string
+}
+
+var clusterregistrationsResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "clusterregistrations"}
+
+var clusterregistrationsKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ClusterRegistration"}
+
+// Get takes name of the clusterRegistration, and returns the corresponding clusterRegistration object, and an error if there is any.
+func (c *FakeClusterRegistrations) Get(name string, options v1.GetOptions) (result *v1alpha1.ClusterRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(clusterregistrationsResource, c.ns, name), &v1alpha1.ClusterRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRegistration), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterRegistrations that match those selectors.
+func (c *FakeClusterRegistrations) List(opts v1.ListOptions) (result *v1alpha1.ClusterRegistrationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(clusterregistrationsResource, clusterregistrationsKind, c.ns, opts), &v1alpha1.ClusterRegistrationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterRegistrationList{ListMeta: obj.(*v1alpha1.ClusterRegistrationList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterRegistrationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRegistrations.
+func (c *FakeClusterRegistrations) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(clusterregistrationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a clusterRegistration and creates it.  Returns the server's representation of the clusterRegistration, and an error, if there is any.
+func (c *FakeClusterRegistrations) Create(clusterRegistration *v1alpha1.ClusterRegistration) (result *v1alpha1.ClusterRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(clusterregistrationsResource, c.ns, clusterRegistration), &v1alpha1.ClusterRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRegistration), err
+}
+
+// Update takes the representation of a clusterRegistration and updates it. Returns the server's representation of the clusterRegistration, and an error, if there is any.
+func (c *FakeClusterRegistrations) Update(clusterRegistration *v1alpha1.ClusterRegistration) (result *v1alpha1.ClusterRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(clusterregistrationsResource, c.ns, clusterRegistration), &v1alpha1.ClusterRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRegistration), err
+}
+
+// Delete takes name of the clusterRegistration and deletes it. Returns an error if one occurs.
+func (c *FakeClusterRegistrations) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(clusterregistrationsResource, c.ns, name), &v1alpha1.ClusterRegistration{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterRegistrations) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(clusterregistrationsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterRegistrationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterRegistration.
+func (c *FakeClusterRegistrations) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(clusterregistrationsResource, c.ns, name, pt, data, subresources...), &v1alpha1.ClusterRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRegistration), err
+}
@@ -0,0 +1,112 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRepositoryRegistrations implements RepositoryRegistrationInterface
+type FakeRepositoryRegistrations struct {
+	Fake *FakeArgoprojV1alpha1
+	ns   string
+}
+
+var repositoryregistrationsResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "repositoryregistrations"}
+
+var repositoryregistrationsKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "RepositoryRegistration"}
+
+// Get takes name of the repositoryRegistration, and returns the corresponding repositoryRegistration object, and an error if there is any.
+func (c *FakeRepositoryRegistrations) Get(name string, options v1.GetOptions) (result *v1alpha1.RepositoryRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(repositoryregistrationsResource, c.ns, name), &v1alpha1.RepositoryRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RepositoryRegistration), err
+}
+
+// List takes label and field selectors, and returns the list of RepositoryRegistrations that match those selectors.
+func (c *FakeRepositoryRegistrations) List(opts v1.ListOptions) (result *v1alpha1.RepositoryRegistrationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(repositoryregistrationsResource, repositoryregistrationsKind, c.ns, opts), &v1alpha1.RepositoryRegistrationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.RepositoryRegistrationList{ListMeta: obj.(*v1alpha1.RepositoryRegistrationList).ListMeta}
+	for _, item := range obj.(*v1alpha1.RepositoryRegistrationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested repositoryRegistrations.
+func (c *FakeRepositoryRegistrations) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(repositoryregistrationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a repositoryRegistration and creates it.  Returns the server's representation of the repositoryRegistration, and an error, if there is any.
+func (c *FakeRepositoryRegistrations) Create(repositoryRegistration *v1alpha1.RepositoryRegistration) (result *v1alpha1.RepositoryRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(repositoryregistrationsResource, c.ns, repositoryRegistration), &v1alpha1.RepositoryRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RepositoryRegistration), err
+}
+
+// Update takes the representation of a repositoryRegistration and updates it. Returns the server's representation of the repositoryRegistration, and an error, if there is any.
+func (c *FakeRepositoryRegistrations) Update(repositoryRegistration *v1alpha1.RepositoryRegistration) (result *v1alpha1.RepositoryRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(repositoryregistrationsResource, c.ns, repositoryRegistration), &v1alpha1.RepositoryRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RepositoryRegistration), err
+}
+
+// Delete takes name of the repositoryRegistration and deletes it. Returns an error if one occurs.
+func (c *FakeRepositoryRegistrations) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(repositoryregistrationsResource, c.ns, name), &v1alpha1.RepositoryRegistration{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRepositoryRegistrations) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(repositoryregistrationsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.RepositoryRegistrationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched repositoryRegistration.
+func (c *FakeRepositoryRegistrations) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.RepositoryRegistration, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(repositoryregistrationsResource, c.ns, name, pt, data, subresources...), &v1alpha1.RepositoryRegistration{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RepositoryRegistration), err
+}
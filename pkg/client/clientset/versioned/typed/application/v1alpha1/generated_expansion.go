@@ -5,3 +5,11 @@ package v1alpha1
 type AppProjectExpansion interface{}
 
 type ApplicationExpansion interface{}
+
+type ApplicationSetExpansion interface{}
+
+type ApplicationTemplateExpansion interface{}
+
+type ClusterRegistrationExpansion interface{}
+
+type RepositoryRegistrationExpansion interface{}
@@ -12,6 +12,14 @@ type Interface interface {
 	AppProjects() AppProjectInformer
 	// Applications returns a ApplicationInformer.
 	Applications() ApplicationInformer
+	// ApplicationSets returns a ApplicationSetInformer.
+	ApplicationSets() ApplicationSetInformer
+	// ApplicationTemplates returns a ApplicationTemplateInformer.
+	ApplicationTemplates() ApplicationTemplateInformer
+	// ClusterRegistrations returns a ClusterRegistrationInformer.
+	ClusterRegistrations() ClusterRegistrationInformer
+	// RepositoryRegistrations returns a RepositoryRegistrationInformer.
+	RepositoryRegistrations() RepositoryRegistrationInformer
 }
 
 type version struct {
@@ -34,3 +42,23 @@ func (v *version) AppProjects() AppProjectInformer {
 func (v *version) Applications() ApplicationInformer {
 	return &applicationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ApplicationSets returns a ApplicationSetInformer.
+func (v *version) ApplicationSets() ApplicationSetInformer {
+	return &applicationSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ApplicationTemplates returns a ApplicationTemplateInformer.
+func (v *version) ApplicationTemplates() ApplicationTemplateInformer {
+	return &applicationTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterRegistrations returns a ClusterRegistrationInformer.
+func (v *version) ClusterRegistrations() ClusterRegistrationInformer {
+	return &clusterRegistrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RepositoryRegistrations returns a RepositoryRegistrationInformer.
+func (v *version) RepositoryRegistrations() RepositoryRegistrationInformer {
+	return &repositoryRegistrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
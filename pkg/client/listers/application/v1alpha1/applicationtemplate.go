@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ApplicationTemplateLister helps list ApplicationTemplates.
+type ApplicationTemplateLister interface {
+	// List lists all ApplicationTemplates in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.ApplicationTemplate, err error)
+	// ApplicationTemplates returns an object that can list and get ApplicationTemplates.
+	ApplicationTemplates(namespace string) ApplicationTemplateNamespaceLister
+	ApplicationTemplateListerExpansion
+}
+
+// applicationTemplateLister implements the ApplicationTemplateLister interface.
+type applicationTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewApplicationTemplateLister returns a new ApplicationTemplateLister.
+func NewApplicationTemplateLister(indexer cache.Indexer) ApplicationTemplateLister {
+	return &applicationTemplateLister{indexer: indexer}
+}
+
+// List lists all ApplicationTemplates in the indexer.
+func (s *applicationTemplateLister) List(selector labels.Selector) (ret []*v1alpha1.ApplicationTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ApplicationTemplate))
+	})
+	return ret, err
+}
+
+// ApplicationTemplates returns an object that can list and get ApplicationTemplates.
+func (s *applicationTemplateLister) ApplicationTemplates(namespace string) ApplicationTemplateNamespaceLister {
+	return applicationTemplateNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ApplicationTemplateNamespaceLister helps list and get ApplicationTemplates.
+type ApplicationTemplateNamespaceLister interface {
+	// List lists all ApplicationTemplates in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.ApplicationTemplate, err error)
+	// Get retrieves the ApplicationTemplate from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.ApplicationTemplate, error)
+	ApplicationTemplateNamespaceListerExpansion
+}
+
+// applicationTemplateNamespaceLister implements the ApplicationTemplateNamespaceLister
+// interface.
+type applicationTemplateNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ApplicationTemplates in the indexer for a given namespace.
+func (s applicationTemplateNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ApplicationTemplate, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ApplicationTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the ApplicationTemplate from the indexer for a given namespace and name.
+func (s applicationTemplateNamespaceLister) Get(name string) (*v1alpha1.ApplicationTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("applicationtemplate"), name)
+	}
+	return obj.(*v1alpha1.ApplicationTemplate), nil
+}
@@ -17,3 +17,35 @@ type ApplicationListerExpansion interface{}
 // ApplicationNamespaceListerExpansion allows custom methods to be added to
 // ApplicationNamespaceLister.
 type ApplicationNamespaceListerExpansion interface{}
+
+// ApplicationSetListerExpansion allows custom methods to be added to
+// ApplicationSetLister.
+type ApplicationSetListerExpansion interface{}
+
+// ApplicationSetNamespaceListerExpansion allows custom methods to be added to
+// ApplicationSetNamespaceLister.
+type ApplicationSetNamespaceListerExpansion interface{}
+
+// ApplicationTemplateListerExpansion allows custom methods to be added to
+// ApplicationTemplateLister.
+type ApplicationTemplateListerExpansion interface{}
+
+// ApplicationTemplateNamespaceListerExpansion allows custom methods to be added to
+// ApplicationTemplateNamespaceLister.
+type ApplicationTemplateNamespaceListerExpansion interface{}
+
+// ClusterRegistrationListerExpansion allows custom methods to be added to
+// ClusterRegistrationLister.
+type ClusterRegistrationListerExpansion interface{}
+
+// ClusterRegistrationNamespaceListerExpansion allows custom methods to be added to
+// ClusterRegistrationNamespaceLister.
+type ClusterRegistrationNamespaceListerExpansion interface{}
+
+// RepositoryRegistrationListerExpansion allows custom methods to be added to
+// RepositoryRegistrationLister.
+type RepositoryRegistrationListerExpansion interface{}
+
+// RepositoryRegistrationNamespaceListerExpansion allows custom methods to be added to
+// RepositoryRegistrationNamespaceLister.
+type RepositoryRegistrationNamespaceListerExpansion interface{}
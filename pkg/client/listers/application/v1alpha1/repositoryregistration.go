@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RepositoryRegistrationLister helps list RepositoryRegistrations.
+type RepositoryRegistrationLister interface {
+	// List lists all RepositoryRegistrations in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.RepositoryRegistration, err error)
+	// RepositoryRegistrations returns an object that can list and get RepositoryRegistrations.
+	RepositoryRegistrations(namespace string) RepositoryRegistrationNamespaceLister
+	RepositoryRegistrationListerExpansion
+}
+
+// repositoryRegistrationLister implements the RepositoryRegistrationLister interface.
+type repositoryRegistrationLister struct {
+	indexer cache.Indexer
+}
+
+// NewRepositoryRegistrationLister returns a new RepositoryRegistrationLister.
+func NewRepositoryRegistrationLister(indexer cache.Indexer) RepositoryRegistrationLister {
+	return &repositoryRegistrationLister{indexer: indexer}
+}
+
+// List lists all RepositoryRegistrations in the indexer.
+func (s *repositoryRegistrationLister) List(selector labels.Selector) (ret []*v1alpha1.RepositoryRegistration, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RepositoryRegistration))
+	})
+	return ret, err
+}
+
+// RepositoryRegistrations returns an object that can list and get RepositoryRegistrations.
+func (s *repositoryRegistrationLister) RepositoryRegistrations(namespace string) RepositoryRegistrationNamespaceLister {
+	return repositoryRegistrationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RepositoryRegistrationNamespaceLister helps list and get RepositoryRegistrations.
+type RepositoryRegistrationNamespaceLister interface {
+	// List lists all RepositoryRegistrations in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.RepositoryRegistration, err error)
+	// Get retrieves the RepositoryRegistration from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.RepositoryRegistration, error)
+	RepositoryRegistrationNamespaceListerExpansion
+}
+
+// repositoryRegistrationNamespaceLister implements the RepositoryRegistrationNamespaceLister
+// interface.
+type repositoryRegistrationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RepositoryRegistrations in the indexer for a given namespace.
+func (s repositoryRegistrationNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.RepositoryRegistration, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RepositoryRegistration))
+	})
+	return ret, err
+}
+
+// Get retrieves the RepositoryRegistration from the indexer for a given namespace and name.
+func (s repositoryRegistrationNamespaceLister) Get(name string) (*v1alpha1.RepositoryRegistration, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("repositoryregistration"), name)
+	}
+	return obj.(*v1alpha1.RepositoryRegistration), nil
+}
@@ -1,22 +1,32 @@
 package apiclient
 
 import (
+	"context"
 	"crypto/tls"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	versionpkg "github.com/argoproj/argo-cd/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/fips"
 	argogrpc "github.com/argoproj/argo-cd/util/grpc"
+	"github.com/argoproj/argo-cd/util/tracing"
 )
 
+// MaxGRPCMessageSize contains max grpc message size, which permits uploading the files of applications
+const MaxGRPCMessageSize = 100 * 1024 * 1024
+
 // Clientset represets repository server api clients
 type Clientset interface {
 	NewRepoServerClient() (util.Closer, RepoServerServiceClient, error)
+	// NewVersionClient returns a client of the version service of the repo server, e.g. to check that it is reachable
+	NewVersionClient() (util.Closer, versionpkg.VersionServiceClient, error)
 }
 
 type clientSet struct {
@@ -25,29 +35,61 @@ type clientSet struct {
 }
 
 func (c *clientSet) NewRepoServerClient() (util.Closer, RepoServerServiceClient, error) {
+	conn, err := c.newConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewRepoServerServiceClient(conn), nil
+}
+
+func (c *clientSet) NewVersionClient() (util.Closer, versionpkg.VersionServiceClient, error) {
+	conn, err := c.newConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, versionpkg.NewVersionServiceClient(conn), nil
+}
+
+func (c *clientSet) newConnection() (*grpc.ClientConn, error) {
 	retryOpts := []grpc_retry.CallOption{
 		grpc_retry.WithMax(3),
 		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(1000 * time.Millisecond)),
 	}
-	unaryInterceptors := []grpc.UnaryClientInterceptor{grpc_retry.UnaryClientInterceptor(retryOpts...)}
+	unaryInterceptors := []grpc.UnaryClientInterceptor{tracing.UnaryClientInterceptor(), argogrpc.CorrelationIDUnaryClientInterceptor(), grpc_retry.UnaryClientInterceptor(retryOpts...)}
 	if c.timeoutSeconds > 0 {
 		unaryInterceptors = append(unaryInterceptors, argogrpc.WithTimeout(time.Duration(c.timeoutSeconds)*time.Second))
 	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	fips.ConfigureTLS(tlsConfig)
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})),
-		grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor(retryOpts...)),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(tracing.StreamClientInterceptor(), argogrpc.CorrelationIDStreamClientInterceptor(), grpc_retry.StreamClientInterceptor(retryOpts...))),
 		grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(unaryInterceptors...)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGRPCMessageSize), grpc.MaxCallSendMsgSize(MaxGRPCMessageSize)),
 	}
 
 	conn, err := grpc.Dial(c.address, opts...)
 	if err != nil {
 		log.Errorf("Unable to connect to repository service with address %s", c.address)
-		return nil, nil, err
+		return nil, err
 	}
-	return conn, NewRepoServerServiceClient(conn), nil
+	return conn, nil
 }
 
 // NewRepoServerClientset creates new instance of repo server Clientset
 func NewRepoServerClientset(address string, timeoutSeconds int) Clientset {
 	return &clientSet{address: address, timeoutSeconds: timeoutSeconds}
 }
+
+// Ping returns an error unless the repo server answers a version request within the timeout
+func Ping(clientset Clientset, timeout time.Duration) error {
+	conn, versionIf, err := clientset.NewVersionClient()
+	if err != nil {
+		return err
+	}
+	defer util.Close(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err = versionIf.Version(ctx, &empty.Empty{})
+	return err
+}
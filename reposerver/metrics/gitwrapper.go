@@ -1,6 +1,10 @@
 package metrics
 
-import "github.com/argoproj/argo-cd/util/git"
+import (
+	"time"
+
+	"github.com/argoproj/argo-cd/util/git"
+)
 
 type gitClientWrapper struct {
 	repo          string
@@ -14,14 +18,19 @@ func WrapGitClient(repo string, metricsServer *MetricsServer, client git.Client)
 
 func (w *gitClientWrapper) Fetch() error {
 	w.metricsServer.IncGitRequest(w.repo, GitRequestTypeFetch)
-	return w.client.Fetch()
+	start := time.Now()
+	err := w.client.Fetch()
+	w.metricsServer.ObserveGitRequest(w.repo, GitRequestTypeFetch, time.Since(start), err)
+	return err
 }
 
 func (w *gitClientWrapper) LsRemote(revision string) (string, error) {
+	start := time.Now()
 	sha, err := w.client.LsRemote(revision)
 	if sha != revision {
 		// This is true only if specified revision is a tag, branch or HEAD and client had to use 'ls-remote'
 		w.metricsServer.IncGitRequest(w.repo, GitRequestTypeLsRemote)
+		w.metricsServer.ObserveGitRequest(w.repo, GitRequestTypeLsRemote, time.Since(start), err)
 	}
 	return sha, err
 }
@@ -35,7 +44,11 @@ func (w *gitClientWrapper) LsLargeFiles() ([]string, error) {
 }
 
 func (w *gitClientWrapper) Checkout(revision string) error {
-	return w.client.Checkout(revision)
+	w.metricsServer.IncGitRequest(w.repo, GitRequestTypeCheckout)
+	start := time.Now()
+	err := w.client.Checkout(revision)
+	w.metricsServer.ObserveGitRequest(w.repo, GitRequestTypeCheckout, time.Since(start), err)
+	return err
 }
 
 func (w *gitClientWrapper) CommitSHA() (string, error) {
@@ -53,3 +66,11 @@ func (w *gitClientWrapper) Init() error {
 func (w *gitClientWrapper) RevisionMetadata(revision string) (*git.RevisionMetadata, error) {
 	return w.client.RevisionMetadata(revision)
 }
+
+func (w *gitClientWrapper) CommitAndPush(branch, message, authorName, authorEmail string) (string, error) {
+	w.metricsServer.IncGitRequest(w.repo, GitRequestTypePush)
+	start := time.Now()
+	sha, err := w.client.CommitAndPush(branch, message, authorName, authorEmail)
+	w.metricsServer.ObserveGitRequest(w.repo, GitRequestTypePush, time.Since(start), err)
+	return sha, err
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/helm"
+)
+
+type helmClientWrapper struct {
+	repo          string
+	client        helm.Client
+	metricsServer *MetricsServer
+}
+
+func WrapHelmClient(repo string, metricsServer *MetricsServer, client helm.Client) helm.Client {
+	return &helmClientWrapper{repo: repo, client: client, metricsServer: metricsServer}
+}
+
+func (w *helmClientWrapper) CleanChartCache(chart string, version *semver.Version) error {
+	return w.client.CleanChartCache(chart, version)
+}
+
+func (w *helmClientWrapper) ExtractChart(chart string, version *semver.Version) (string, util.Closer, error) {
+	start := time.Now()
+	path, closer, err := w.client.ExtractChart(chart, version)
+	w.metricsServer.ObserveHelmRequest(w.repo, HelmRequestTypeFetch, time.Since(start), err)
+	return path, closer, err
+}
+
+func (w *helmClientWrapper) GetIndex() (*helm.Index, error) {
+	return w.client.GetIndex()
+}
+
+type helmAppWrapper struct {
+	repo          string
+	app           helm.Helm
+	metricsServer *MetricsServer
+}
+
+func WrapHelmApp(repo string, metricsServer *MetricsServer, app helm.Helm) helm.Helm {
+	return &helmAppWrapper{repo: repo, app: app, metricsServer: metricsServer}
+}
+
+func (w *helmAppWrapper) Template(opts *helm.TemplateOpts) (string, error) {
+	start := time.Now()
+	out, err := w.app.Template(opts)
+	w.metricsServer.ObserveHelmRequest(w.repo, HelmRequestTypeTemplate, time.Since(start), err)
+	return out, err
+}
+
+func (w *helmAppWrapper) GetParameters(valuesFiles []string) (map[string]string, error) {
+	return w.app.GetParameters(valuesFiles)
+}
+
+func (w *helmAppWrapper) DependencyBuild() error {
+	return w.app.DependencyBuild()
+}
+
+func (w *helmAppWrapper) Init() error {
+	return w.app.Init()
+}
+
+func (w *helmAppWrapper) Dispose() {
+	w.app.Dispose()
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -10,6 +11,11 @@ import (
 type MetricsServer struct {
 	handler                  http.Handler
 	gitRequestCounter        *prometheus.CounterVec
+	gitRequestHistogram      *prometheus.HistogramVec
+	gitFailedRequestCounter  *prometheus.CounterVec
+	helmRequestCounter       *prometheus.CounterVec
+	helmRequestHistogram     *prometheus.HistogramVec
+	helmFailedRequestCounter *prometheus.CounterVec
 	repoPendingRequestsGauge *prometheus.GaugeVec
 }
 
@@ -18,8 +24,21 @@ type GitRequestType string
 const (
 	GitRequestTypeLsRemote = "ls-remote"
 	GitRequestTypeFetch    = "fetch"
+	GitRequestTypeCheckout = "checkout"
+	GitRequestTypePush     = "push"
 )
 
+type HelmRequestType string
+
+const (
+	HelmRequestTypeFetch    = "fetch"
+	HelmRequestTypeTemplate = "template"
+)
+
+// Buckets of the durations of git and helm requests, which are usually below a second but may take minutes for large
+// repositories
+var requestDurationBuckets = []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32, 64}
+
 // NewMetricsServer returns a new prometheus server which collects application metrics
 func NewMetricsServer() *MetricsServer {
 	registry := prometheus.NewRegistry()
@@ -35,6 +54,53 @@ func NewMetricsServer() *MetricsServer {
 	)
 	registry.MustRegister(gitRequestCounter)
 
+	gitRequestHistogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "argocd_git_request_duration_seconds",
+			Help:    "Duration of git requests performed by repo server",
+			Buckets: requestDurationBuckets,
+		},
+		[]string{"repo", "request_type"},
+	)
+	registry.MustRegister(gitRequestHistogram)
+
+	gitFailedRequestCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_git_request_failed_total",
+			Help: "Number of git requests performed by repo server which failed",
+		},
+		[]string{"repo", "request_type"},
+	)
+	registry.MustRegister(gitFailedRequestCounter)
+
+	helmRequestCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_helm_request_total",
+			Help: "Number of helm requests performed by repo server",
+		},
+		[]string{"repo", "request_type"},
+	)
+	registry.MustRegister(helmRequestCounter)
+
+	helmRequestHistogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "argocd_helm_request_duration_seconds",
+			Help:    "Duration of helm requests performed by repo server",
+			Buckets: requestDurationBuckets,
+		},
+		[]string{"repo", "request_type"},
+	)
+	registry.MustRegister(helmRequestHistogram)
+
+	helmFailedRequestCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_helm_request_failed_total",
+			Help: "Number of helm requests performed by repo server which failed",
+		},
+		[]string{"repo", "request_type"},
+	)
+	registry.MustRegister(helmFailedRequestCounter)
+
 	repoPendingRequestsGauge := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "argocd_repo_pending_request_total",
@@ -47,6 +113,11 @@ func NewMetricsServer() *MetricsServer {
 	return &MetricsServer{
 		handler:                  promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
 		gitRequestCounter:        gitRequestCounter,
+		gitRequestHistogram:      gitRequestHistogram,
+		gitFailedRequestCounter:  gitFailedRequestCounter,
+		helmRequestCounter:       helmRequestCounter,
+		helmRequestHistogram:     helmRequestHistogram,
+		helmFailedRequestCounter: helmFailedRequestCounter,
 		repoPendingRequestsGauge: repoPendingRequestsGauge,
 	}
 }
@@ -60,6 +131,23 @@ func (m *MetricsServer) IncGitRequest(repo string, requestType GitRequestType) {
 	m.gitRequestCounter.WithLabelValues(repo, string(requestType)).Inc()
 }
 
+// ObserveGitRequest records the duration of a git request, and counts it as failed if it returned an error
+func (m *MetricsServer) ObserveGitRequest(repo string, requestType GitRequestType, duration time.Duration, err error) {
+	m.gitRequestHistogram.WithLabelValues(repo, string(requestType)).Observe(duration.Seconds())
+	if err != nil {
+		m.gitFailedRequestCounter.WithLabelValues(repo, string(requestType)).Inc()
+	}
+}
+
+// ObserveHelmRequest counts a helm request and records its duration, and counts it as failed if it returned an error
+func (m *MetricsServer) ObserveHelmRequest(repo string, requestType HelmRequestType, duration time.Duration, err error) {
+	m.helmRequestCounter.WithLabelValues(repo, string(requestType)).Inc()
+	m.helmRequestHistogram.WithLabelValues(repo, string(requestType)).Observe(duration.Seconds())
+	if err != nil {
+		m.helmFailedRequestCounter.WithLabelValues(repo, string(requestType)).Inc()
+	}
+}
+
 func (m *MetricsServer) IncPendingRepoRequest(repo string) {
 	m.repoPendingRequestsGauge.WithLabelValues(repo).Inc()
 }
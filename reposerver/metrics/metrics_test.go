@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	gitmocks "github.com/argoproj/argo-cd/util/git/mocks"
+	helmmocks "github.com/argoproj/argo-cd/util/helm/mocks"
+)
+
+func getMetrics(t *testing.T, metricsServer *MetricsServer) string {
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	metricsServer.GetHandler().ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body, err := ioutil.ReadAll(rr.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func assertMetricsPrinted(t *testing.T, expectedLines, body string) {
+	for _, line := range strings.Split(expectedLines, "\n") {
+		if line == "" {
+			continue
+		}
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestGitClientWrapper(t *testing.T) {
+	metricsServer := NewMetricsServer()
+	client := &gitmocks.Client{}
+	client.On("Fetch").Return(nil)
+	client.On("Checkout", "abc").Return(fmt.Errorf("reference not found"))
+	client.On("LsRemote", "master").Return("abc", nil)
+	wrapper := WrapGitClient("https://github.com/argoproj/argocd-example-apps.git", metricsServer, client)
+
+	assert.NoError(t, wrapper.Fetch())
+	assert.Error(t, wrapper.Checkout("abc"))
+	_, err := wrapper.LsRemote("master")
+	assert.NoError(t, err)
+
+	assertMetricsPrinted(t, `
+argocd_git_request_total{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="checkout"} 1
+argocd_git_request_total{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="fetch"} 1
+argocd_git_request_total{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="ls-remote"} 1
+argocd_git_request_duration_seconds_count{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="fetch"} 1
+argocd_git_request_duration_seconds_count{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="ls-remote"} 1
+argocd_git_request_failed_total{repo="https://github.com/argoproj/argocd-example-apps.git",request_type="checkout"} 1
+`, getMetrics(t, metricsServer))
+}
+
+func TestHelmClientWrapper(t *testing.T) {
+	metricsServer := NewMetricsServer()
+	client := &helmmocks.Client{}
+	client.On("ExtractChart", "my-chart", mock.Anything).Return("", nil, fmt.Errorf("chart not found"))
+	wrapper := WrapHelmClient("https://argoproj.github.io/argo-helm", metricsServer, client)
+
+	_, _, err := wrapper.ExtractChart("my-chart", nil)
+	assert.Error(t, err)
+
+	assertMetricsPrinted(t, `
+argocd_helm_request_total{repo="https://argoproj.github.io/argo-helm",request_type="fetch"} 1
+argocd_helm_request_duration_seconds_count{repo="https://argoproj.github.io/argo-helm",request_type="fetch"} 1
+argocd_helm_request_failed_total{repo="https://argoproj.github.io/argo-helm",request_type="fetch"} 1
+`, getMetrics(t, metricsServer))
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	"github.com/stretchr/testify/mock"
 
+	"github.com/argoproj/argo-cd/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/util"
 )
@@ -45,3 +46,35 @@ func (_m *Clientset) NewRepoServerClient() (util.Closer, apiclient.RepoServerSer
 
 	return r0, r1, r2
 }
+
+// NewVersionClient provides a mock function with given fields:
+func (_m *Clientset) NewVersionClient() (util.Closer, version.VersionServiceClient, error) {
+	ret := _m.Called()
+
+	var r0 util.Closer
+	if rf, ok := ret.Get(0).(func() util.Closer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(util.Closer)
+		}
+	}
+
+	var r1 version.VersionServiceClient
+	if rf, ok := ret.Get(1).(func() version.VersionServiceClient); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(version.VersionServiceClient)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
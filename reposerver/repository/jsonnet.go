@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/google/go-jsonnet"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	executil "github.com/argoproj/argo-cd/util/exec"
+)
+
+// jsonnetEvaluationEnv is set for the child processes which evaluate jsonnet, see EvaluateJsonnetIfRequested
+const jsonnetEvaluationEnv = "ARGOCD_JSONNET_EVALUATION"
+
+// jsonnetEvaluation is a jsonnet file to evaluate in a child process
+type jsonnetEvaluation struct {
+	Filename string                            `json:"filename"`
+	Snippet  string                            `json:"snippet"`
+	JPaths   []string                          `json:"jpaths"`
+	Jsonnet  v1alpha1.ApplicationSourceJsonnet `json:"jsonnet"`
+	Env      *v1alpha1.Env                     `json:"env"`
+}
+
+// EvaluateJsonnetIfRequested evaluates the jsonnet file read from stdin, writes the JSON to stdout and exits, if the
+// process is a child process started to evaluate jsonnet. It must be called at the start of the main function of the
+// binaries which generate manifests within limits, i.e. of the repo server.
+func EvaluateJsonnetIfRequested() {
+	if os.Getenv(jsonnetEvaluationEnv) == "" {
+		return
+	}
+	if err := evaluateJsonnetFromStdin(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func evaluateJsonnetFromStdin() error {
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	var evaluation jsonnetEvaluation
+	if err := json.Unmarshal(data, &evaluation); err != nil {
+		return err
+	}
+	out, err := evaluateJsonnet(evaluation)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.WriteString(out)
+	return err
+}
+
+func evaluateJsonnet(evaluation jsonnetEvaluation) (string, error) {
+	vm := makeJsonnetVm(evaluation.Jsonnet, evaluation.Env)
+	vm.Importer(&jsonnet.FileImporter{
+		JPaths: evaluation.JPaths,
+	})
+	return vm.EvaluateSnippet(evaluation.Filename, evaluation.Snippet)
+}
+
+// evaluateJsonnetInChildProcess evaluates jsonnet in a child process of the running binary, so that the evaluation is
+// killed when the time limit of the manifest generation is exceeded and is bounded by the CPU and memory limits, like
+// the commands run to generate manifests
+func evaluateJsonnetInChildProcess(appPath string, evaluation jsonnetEvaluation) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(evaluation)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(executable)
+	cmd.Dir = appPath
+	cmd.Env = append(os.Environ(), jsonnetEvaluationEnv+"=true")
+	cmd.Stdin = bytes.NewReader(data)
+	return executil.Run(cmd)
+}
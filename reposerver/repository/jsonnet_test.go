@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/reposerver/apiclient"
+)
+
+func TestMain(m *testing.M) {
+	// the test binary evaluates jsonnet in its child processes like the repo server
+	EvaluateJsonnetIfRequested()
+	os.Exit(m.Run())
+}
+
+func TestGenerateJsonnetManifestInDir_Limits(t *testing.T) {
+	service := newService(".")
+	newRequest := func(path string, limits *argoappv1.ManifestGenerationLimits) *apiclient.ManifestRequest {
+		return &apiclient.ManifestRequest{
+			Repo: &argoappv1.Repository{},
+			ApplicationSource: &argoappv1.ApplicationSource{
+				Path: path,
+				Directory: &argoappv1.ApplicationSourceDirectory{
+					Jsonnet: argoappv1.ApplicationSourceJsonnet{
+						ExtVars: []argoappv1.JsonnetVar{{Name: "extVarString", Value: "extVarString"}, {Name: "extVarCode", Value: "\"extVarCode\"", Code: true}},
+						TLAs:    []argoappv1.JsonnetVar{{Name: "tlaString", Value: "tlaString"}, {Name: "tlaCode", Value: "\"tlaCode\"", Code: true}},
+					},
+				},
+			},
+			NoCache: true,
+			Limits:  limits,
+		}
+	}
+
+	t.Run("ChildProcess", func(t *testing.T) {
+		res, err := service.GenerateManifest(context.Background(), newRequest("./testdata/jsonnet", &argoappv1.ManifestGenerationLimits{TimeoutSeconds: 60, Memory: "1Gi"}))
+		assert.NoError(t, err)
+		assert.Len(t, res.Manifests, 2)
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		started := time.Now()
+		_, err := service.GenerateManifest(context.Background(), newRequest("./testdata/jsonnet-slow", &argoappv1.ManifestGenerationLimits{TimeoutSeconds: 1}))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.True(t, time.Since(started) < 5*time.Second)
+	})
+}
@@ -9,8 +9,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	executil "github.com/argoproj/argo-cd/util/exec"
 	"github.com/argoproj/argo-cd/util/security"
@@ -35,12 +38,22 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/app/discovery"
 	argopath "github.com/argoproj/argo-cd/util/app/path"
+	"github.com/argoproj/argo-cd/util/attestation"
+	"github.com/argoproj/argo-cd/util/bucket"
+	"github.com/argoproj/argo-cd/util/cue"
 	"github.com/argoproj/argo-cd/util/git"
 	"github.com/argoproj/argo-cd/util/helm"
+	"github.com/argoproj/argo-cd/util/helmfile"
 	"github.com/argoproj/argo-cd/util/ksonnet"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/kustomize"
+	"github.com/argoproj/argo-cd/util/oci"
+	"github.com/argoproj/argo-cd/util/sops"
+	"github.com/argoproj/argo-cd/util/tanka"
 	"github.com/argoproj/argo-cd/util/text"
+	"github.com/argoproj/argo-cd/util/tgz"
+	"github.com/argoproj/argo-cd/util/tracing"
+	"github.com/argoproj/argo-cd/util/vault"
 )
 
 // Service implements ManifestService interface
@@ -51,10 +64,16 @@ type Service struct {
 	metricsServer             *metrics.MetricsServer
 	newGitClient              func(rawRepoURL string, creds git.Creds, insecure bool, enableLfs bool) (git.Client, error)
 	newHelmClient             func(repoURL string, creds helm.Creds) helm.Client
+	newOCIClient              func(repoURL string, creds oci.Creds) oci.Client
+	newBucketClient           func(repoURL string, creds bucket.Creds) (bucket.Client, error)
+	// secretReader resolves the secret placeholders of the manifests, if configured
+	secretReader vault.SecretReader
+	// signer signs the provenance attestations of the generated manifests, if configured
+	signer *attestation.Signer
 }
 
 // NewService returns a new instance of the Manifest service
-func NewService(metricsServer *metrics.MetricsServer, cache *reposervercache.Cache, parallelismLimit int64) *Service {
+func NewService(metricsServer *metrics.MetricsServer, cache *reposervercache.Cache, parallelismLimit int64, secretReader vault.SecretReader, signer *attestation.Signer) *Service {
 	var parallelismLimitSemaphore *semaphore.Weighted
 	if parallelismLimit > 0 {
 		parallelismLimitSemaphore = semaphore.NewWeighted(parallelismLimit)
@@ -65,10 +84,14 @@ func NewService(metricsServer *metrics.MetricsServer, cache *reposervercache.Cac
 		repoLock:                  repoLock,
 		cache:                     cache,
 		metricsServer:             metricsServer,
+		secretReader:              secretReader,
+		signer:                    signer,
 		newGitClient:              git.NewClient,
 		newHelmClient: func(repoURL string, creds helm.Creds) helm.Client {
 			return helm.NewClientWithLock(repoURL, creds, repoLock)
 		},
+		newOCIClient:    oci.NewClient,
+		newBucketClient: bucket.NewClient,
 	}
 }
 
@@ -89,7 +112,7 @@ func (s *Service) ListApps(ctx context.Context, q *apiclient.ListAppsRequest) (*
 	s.repoLock.Lock(gitClient.Root())
 	defer s.repoLock.Unlock(gitClient.Root())
 
-	commitSHA, err = checkoutRevision(gitClient, commitSHA)
+	commitSHA, err = checkoutRevision(ctx, gitClient, commitSHA)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +133,7 @@ type operationSettings struct {
 	noCache bool
 }
 
-// runRepoOperation downloads either git folder or helm chart and executes specified operation
+// runRepoOperation downloads either git folder, helm chart, OCI artifact or bucket objects and executes specified operation
 func (s *Service) runRepoOperation(
 	ctx context.Context,
 	revision string,
@@ -122,6 +145,8 @@ func (s *Service) runRepoOperation(
 
 	var gitClient git.Client
 	var helmClient helm.Client
+	var ociClient oci.Client
+	var bucketClient bucket.Client
 	var err error
 	revision = util.FirstNonEmpty(revision, source.TargetRevision)
 	if source.IsHelm() {
@@ -129,6 +154,16 @@ func (s *Service) runRepoOperation(
 		if err != nil {
 			return err
 		}
+	} else if source.IsOCI() {
+		ociClient, revision, err = s.newOCIClientResolveRevision(repo, revision)
+		if err != nil {
+			return err
+		}
+	} else if source.IsBucket() {
+		bucketClient, revision, err = s.newBucketClientResolveRevision(repo, revision)
+		if err != nil {
+			return err
+		}
 	} else {
 		gitClient, revision, err = s.newClientResolveRevision(repo, revision)
 		if err != nil {
@@ -168,6 +203,29 @@ func (s *Service) runRepoOperation(
 		}
 		defer util.Close(closer)
 		return operation(chartPath, chartPath, revision)
+	} else if source.IsOCI() {
+		// artifacts are immutable, so every operation pulls the artifact into its own directory
+		root, closer, err := ociClient.Pull(revision)
+		if err != nil {
+			return err
+		}
+		defer util.Close(closer)
+		appPath, err := argopath.Path(root, source.Path)
+		if err != nil {
+			return err
+		}
+		return operation(appPath, root, revision)
+	} else if source.IsBucket() {
+		root, closer, err := bucketClient.Download(revision)
+		if err != nil {
+			return err
+		}
+		defer util.Close(closer)
+		appPath, err := argopath.Path(root, source.Path)
+		if err != nil {
+			return err
+		}
+		return operation(appPath, root, revision)
 	} else {
 		s.repoLock.Lock(gitClient.Root())
 		defer s.repoLock.Unlock(gitClient.Root())
@@ -175,7 +233,7 @@ func (s *Service) runRepoOperation(
 		if !settings.noCache && getCached(revision) {
 			return nil
 		}
-		revision, err = checkoutRevision(gitClient, revision)
+		revision, err = checkoutRevision(ctx, gitClient, revision)
 		if err != nil {
 			return err
 		}
@@ -191,7 +249,7 @@ func (s *Service) GenerateManifest(ctx context.Context, q *apiclient.ManifestReq
 	res := &apiclient.ManifestResponse{}
 
 	getCached := func(revision string) bool {
-		err := s.cache.GetManifests(revision, q.ApplicationSource, q.Namespace, q.AppLabelKey, q.AppLabelValue, &res)
+		err := s.cache.GetManifests(revision, q.ApplicationSource, q.Namespace, q.AppLabelKey, q.AppLabelValue, getSOPSPolicy(q) == sopsDecrypt, &res)
 		if err == nil {
 			log.Infof("manifest cache hit: %s/%s", q.ApplicationSource.String(), revision)
 			return true
@@ -204,19 +262,163 @@ func (s *Service) GenerateManifest(ctx context.Context, q *apiclient.ManifestReq
 		return false
 	}
 	err := s.runRepoOperation(ctx, q.Revision, q.Repo, q.ApplicationSource, getCached, func(appPath, repoRoot, revision string) error {
+		startedOn := time.Now()
 		var err error
-		res, err = GenerateManifests(appPath, repoRoot, revision, q)
+		res, err = generateManifestsWithLimits(ctx, appPath, repoRoot, revision, q, s.metricsServer)
 		if err != nil {
 			return err
 		}
 		res.Revision = revision
-		err = s.cache.SetManifests(revision, q.ApplicationSource, q.Namespace, q.AppLabelKey, q.AppLabelValue, &res)
+		if err := s.attest(q, res, startedOn); err != nil {
+			return err
+		}
+		err = s.cache.SetManifests(revision, q.ApplicationSource, q.Namespace, q.AppLabelKey, q.AppLabelValue, getSOPSPolicy(q) == sopsDecrypt, &res)
 		if err != nil {
 			log.Warnf("manifest cache set error %s/%s: %v", q.ApplicationSource.String(), revision, err)
 		}
 		return nil
 	}, operationSettings{sem: s.parallelismLimitSemaphore, noCache: q.NoCache})
-	return res, err
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveSecrets(q, res)
+}
+
+// attest sets the attestation of the provenance of generated manifests, if the repo server signs attestations. The
+// attestation covers the manifests as they are cached, i.e. before their secret placeholders are resolved.
+func (s *Service) attest(q *apiclient.ManifestRequest, res *apiclient.ManifestResponse, startedOn time.Time) error {
+	if s.signer == nil {
+		return nil
+	}
+	source := q.ApplicationSource.DeepCopy()
+	if res.SourceOverrides != nil {
+		source.MergeOverrides(*res.SourceOverrides)
+	}
+	material := attestation.Material{URI: source.RepoURL}
+	entryPoint := source.Path
+	if source.Chart != "" {
+		entryPoint = source.Chart
+	}
+	if git.IsCommitSHA(res.Revision) {
+		material.Digest = map[string]string{"sha1": res.Revision}
+	} else {
+		material.URI = fmt.Sprintf("%s/%s@%s", strings.TrimSuffix(source.RepoURL, "/"), entryPoint, res.Revision)
+	}
+	definedInMaterial := 0
+	finishedOn := time.Now()
+	statement, err := attestation.NewStatement(res.Manifests, attestation.Provenance{
+		Builder: attestation.Builder{ID: attestation.BuilderID},
+		Recipe: attestation.Recipe{
+			Type:              attestation.RecipeTypePrefix + res.SourceType,
+			DefinedInMaterial: &definedInMaterial,
+			EntryPoint:        entryPoint,
+			Arguments:         source,
+			Environment:       toolVersions(v1alpha1.ApplicationSourceType(res.SourceType), source),
+		},
+		Metadata:  attestation.Metadata{BuildStartedOn: &startedOn, BuildFinishedOn: &finishedOn},
+		Materials: []attestation.Material{material},
+	})
+	if err != nil {
+		return err
+	}
+	envelope, err := s.signer.Sign(statement)
+	if err != nil {
+		return fmt.Errorf("failed to sign the attestation of the manifests: %v", err)
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	res.Attestation = string(data)
+	return nil
+}
+
+var (
+	toolVersionsLock sync.Mutex
+	toolVersionCache = make(map[string]string)
+)
+
+// toolVersions returns the versions of Argo CD and of the config management tool of a source type, which are
+// recorded in the attestations. The tool versions are looked up once, since they don't change while the repo server
+// runs.
+func toolVersions(sourceType v1alpha1.ApplicationSourceType, source *v1alpha1.ApplicationSource) map[string]string {
+	res := map[string]string{"argocd": common.GetVersion().Version}
+	var tool string
+	var version func() (string, error)
+	switch sourceType {
+	case v1alpha1.ApplicationSourceTypeHelm:
+		tool, version = "helm", helm.Version
+	case v1alpha1.ApplicationSourceTypeKustomize:
+		tool, version = "kustomize", kustomize.Version
+	case v1alpha1.ApplicationSourceTypeKsonnet:
+		tool, version = "ksonnet", ksonnet.Version
+	case v1alpha1.ApplicationSourceTypePlugin:
+		if source.Plugin != nil {
+			res["plugin"] = source.Plugin.Name
+		}
+		return res
+	default:
+		return res
+	}
+	toolVersionsLock.Lock()
+	defer toolVersionsLock.Unlock()
+	if _, ok := toolVersionCache[tool]; !ok {
+		v, err := version()
+		if err != nil {
+			log.Warnf("Failed to get the version of %s: %v", tool, err)
+			return res
+		}
+		toolVersionCache[tool] = v
+	}
+	res[tool] = toolVersionCache[tool]
+	return res
+}
+
+// resolveSecrets returns a copy of the response with the secret placeholders of the manifests resolved if requested.
+// The cached responses keep the placeholders, so secret values are never stored in the cache.
+func (s *Service) resolveSecrets(q *apiclient.ManifestRequest, res *apiclient.ManifestResponse) (*apiclient.ManifestResponse, error) {
+	if !q.ResolveSecrets || s.secretReader == nil {
+		return res, nil
+	}
+	manifests, err := vault.ResolveManifests(s.secretReader, q.VaultPaths, res.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *res
+	resolved.Manifests = manifests
+	return &resolved, nil
+}
+
+// GenerateManifestWithFiles generates the manifests of the application from the uploaded files of its directory
+// rather than from the repository, so that local changes are rendered with the tools of the repo server
+func (s *Service) GenerateManifestWithFiles(ctx context.Context, q *apiclient.ManifestRequestWithFiles) (*apiclient.ManifestResponse, error) {
+	if q.Request == nil || q.Request.ApplicationSource == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "manifest request is required")
+	}
+	if s.parallelismLimitSemaphore != nil {
+		if err := s.parallelismLimitSemaphore.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		defer s.parallelismLimitSemaphore.Release(1)
+	}
+	appPath, err := ioutil.TempDir("", "manifest-files")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(appPath); err != nil {
+			log.Warnf("Failed to remove uploaded files %s: %v", appPath, err)
+		}
+	}()
+	if err := tgz.Extract(q.Files, appPath); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to extract uploaded files: %v", err)
+	}
+	res, err := generateManifestsWithLimits(ctx, appPath, appPath, q.Request.Revision, q.Request, s.metricsServer)
+	if err != nil {
+		return nil, err
+	}
+	res.Revision = q.Request.Revision
+	return s.resolveSecrets(q.Request, res)
 }
 
 func getHelmRepos(repositories []*v1alpha1.Repository) []helm.HelmRepository {
@@ -227,7 +429,7 @@ func getHelmRepos(repositories []*v1alpha1.Repository) []helm.HelmRepository {
 	return repos
 }
 
-func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclient.ManifestRequest) ([]*unstructured.Unstructured, error) {
+func helmTemplate(ctx context.Context, appPath string, repoRoot string, env *v1alpha1.Env, q *apiclient.ManifestRequest, metricsServer *metrics.MetricsServer) ([]*unstructured.Unstructured, error) {
 	templateOpts := &helm.TemplateOpts{
 		Name:        q.AppLabelValue,
 		Namespace:   q.Namespace,
@@ -314,11 +516,21 @@ func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclie
 		return nil, err
 	}
 	defer h.Dispose()
+	if metricsServer != nil && q.Repo != nil {
+		h = metrics.WrapHelmApp(q.Repo.Repo, metricsServer, h)
+	}
 	err = h.Init()
 	if err != nil {
 		return nil, err
 	}
-	out, err := h.Template(templateOpts)
+	template := func() (string, error) {
+		_, span := tracing.StartSpanFromContext(ctx, "helm template")
+		out, err := h.Template(templateOpts)
+		span.SetError(err)
+		span.Finish()
+		return out, err
+	}
+	out, err := template()
 	if err != nil {
 		if !helm.IsMissingDependencyErr(err) {
 			return nil, err
@@ -327,7 +539,7 @@ func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclie
 		if err != nil {
 			return nil, err
 		}
-		out, err = h.Template(templateOpts)
+		out, err = template()
 		if err != nil {
 			return nil, err
 		}
@@ -336,10 +548,61 @@ func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclie
 }
 
 // GenerateManifests generates manifests from a path
-func GenerateManifests(appPath, repoRoot, revision string, q *apiclient.ManifestRequest) (*apiclient.ManifestResponse, error) {
+func GenerateManifests(ctx context.Context, appPath, repoRoot, revision string, q *apiclient.ManifestRequest) (*apiclient.ManifestResponse, error) {
+	return generateManifests(ctx, appPath, repoRoot, revision, q, nil)
+}
+
+// generateManifestsWithLimits generates manifests from a path within the limits of the project of the app. The CPU and
+// memory limits apply to every command run in the repository, e.g. helm or a plugin, and to the child processes which
+// evaluate jsonnet. The time limit applies to the whole generation: the commands still running when it is exceeded are
+// killed, and the commands run afterwards fail immediately, so the generation returns shortly after the time limit.
+func generateManifestsWithLimits(ctx context.Context, appPath, repoRoot, revision string, q *apiclient.ManifestRequest, metricsServer *metrics.MetricsServer) (*apiclient.ManifestResponse, error) {
+	limits := q.Limits
+	if limits == nil {
+		return generateManifests(ctx, appPath, repoRoot, revision, q, metricsServer)
+	}
+	memory, err := limits.MemoryBytes()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	execLimits := executil.Limits{CPUSeconds: limits.CPUSeconds, MemoryBytes: memory}
+	if limits.TimeoutSeconds > 0 {
+		execLimits.Deadline = time.Now().Add(time.Duration(limits.TimeoutSeconds) * time.Second)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, execLimits.Deadline)
+		defer cancel()
+	}
+	defer executil.SetLimits(repoRoot, execLimits)()
+	res, err := generateManifests(ctx, appPath, repoRoot, revision, q, metricsServer)
+	if !execLimits.Deadline.IsZero() && !time.Now().Before(execLimits.Deadline) {
+		return nil, status.Errorf(codes.DeadlineExceeded, "manifest generation exceeded the time limit of %ds of the project", limits.TimeoutSeconds)
+	}
+	return res, err
+}
+
+// generateManifests generates manifests from a path, and records the durations of the tools in the metrics server if
+// it isn't nil
+func generateManifests(ctx context.Context, appPath, repoRoot, revision string, q *apiclient.ManifestRequest, metricsServer *metrics.MetricsServer) (res *apiclient.ManifestResponse, err error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "generate manifests")
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
 	var targetObjs []*unstructured.Unstructured
 	var dest *v1alpha1.ApplicationDestination
 
+	overrides, err := readSourceOverrides(appPath, q.AppLabelValue)
+	if err != nil {
+		return nil, err
+	}
+	if overrides != nil {
+		source := q.ApplicationSource.DeepCopy()
+		source.MergeOverrides(*overrides)
+		withOverrides := *q
+		withOverrides.ApplicationSource = source
+		q = &withOverrides
+	}
+
 	appSourceType, err := GetAppSourceType(q.ApplicationSource, appPath)
 	if err != nil {
 		return nil, err
@@ -354,18 +617,24 @@ func GenerateManifests(appPath, repoRoot, revision string, q *apiclient.Manifest
 	case v1alpha1.ApplicationSourceTypeKsonnet:
 		targetObjs, dest, err = ksShow(q.AppLabelKey, appPath, q.ApplicationSource.Ksonnet)
 	case v1alpha1.ApplicationSourceTypeHelm:
-		targetObjs, err = helmTemplate(appPath, repoRoot, env, q)
+		targetObjs, err = helmTemplate(ctx, appPath, repoRoot, env, q, metricsServer)
 	case v1alpha1.ApplicationSourceTypeKustomize:
 		k := kustomize.NewKustomizeApp(appPath, q.Repo.GetGitCreds(), repoURL)
 		targetObjs, _, err = k.Build(q.ApplicationSource.Kustomize, q.KustomizeOptions)
 	case v1alpha1.ApplicationSourceTypePlugin:
 		targetObjs, err = runConfigManagementPlugin(appPath, env, q, q.Repo.GetGitCreds())
+	case v1alpha1.ApplicationSourceTypeCUE:
+		targetObjs, err = cueExport(appPath, env, q)
+	case v1alpha1.ApplicationSourceTypeTanka:
+		targetObjs, err = tankaShow(appPath, repoRoot, env, q, q.Repo.GetGitCreds())
+	case v1alpha1.ApplicationSourceTypeHelmfile:
+		targetObjs, err = helmfileTemplate(appPath, repoRoot, env, q)
 	case v1alpha1.ApplicationSourceTypeDirectory:
 		var directory *v1alpha1.ApplicationSourceDirectory
 		if directory = q.ApplicationSource.Directory; directory == nil {
 			directory = &v1alpha1.ApplicationSourceDirectory{}
 		}
-		targetObjs, err = findManifests(appPath, env, *directory)
+		targetObjs, err = findManifests(appPath, env, *directory, getSOPSPolicy(q), q.Limits != nil)
 	}
 	if err != nil {
 		return nil, err
@@ -407,15 +676,16 @@ func GenerateManifests(appPath, repoRoot, revision string, q *apiclient.Manifest
 		}
 	}
 
-	res := apiclient.ManifestResponse{
-		Manifests:  manifests,
-		SourceType: string(appSourceType),
+	res = &apiclient.ManifestResponse{
+		Manifests:       manifests,
+		SourceType:      string(appSourceType),
+		SourceOverrides: overrides,
 	}
 	if dest != nil {
 		res.Namespace = dest.Namespace
 		res.Server = dest.Server
 	}
-	return &res, nil
+	return res, nil
 }
 
 func newEnv(q *apiclient.ManifestRequest, revision string) *v1alpha1.Env {
@@ -499,8 +769,108 @@ func ksShow(appLabelKey, appPath string, ksonnetOpts *v1alpha1.ApplicationSource
 
 var manifestFile = regexp.MustCompile(`^.*\.(yaml|yml|json|jsonnet)$`)
 
-// findManifests looks at all yaml files in a directory and unmarshals them into a list of unstructured objects
-func findManifests(appPath string, env *v1alpha1.Env, directory v1alpha1.ApplicationSourceDirectory) ([]*unstructured.Unstructured, error) {
+const (
+	// sourceOverridesFilePrefix is the prefix of the files of parameter overrides, which are not manifests
+	sourceOverridesFilePrefix = ".argocd-source"
+	// sourceOverridesFile holds the parameter overrides of all applications of a path
+	sourceOverridesFile = sourceOverridesFilePrefix + ".yaml"
+	// appSourceOverridesFile holds the parameter overrides of a single application, e.g. written back by an image updater
+	appSourceOverridesFile = sourceOverridesFilePrefix + "-%s.yaml"
+)
+
+// sourceOverrides is the content of the files of parameter overrides
+type sourceOverrides struct {
+	Helm      *v1alpha1.ApplicationSourceHelm      `json:"helm,omitempty"`
+	Kustomize *v1alpha1.ApplicationSourceKustomize `json:"kustomize,omitempty"`
+}
+
+func readSourceOverridesFile(path string) (*sourceOverrides, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var overrides sourceOverrides
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to unmarshal %q: %v", filepath.Base(path), err)
+	}
+	return &overrides, nil
+}
+
+// readSourceOverrides returns the helm parameters and kustomize images of the .argocd-source files of an application
+// path, or nil if there are none. The overrides of the file of the application take precedence.
+func readSourceOverrides(appPath, appName string) (*v1alpha1.ApplicationSource, error) {
+	files := []string{sourceOverridesFile}
+	if appName != "" {
+		files = append(files, fmt.Sprintf(appSourceOverridesFile, appName))
+	}
+	var res *v1alpha1.ApplicationSource
+	for _, file := range files {
+		overrides, err := readSourceOverridesFile(filepath.Join(appPath, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = &v1alpha1.ApplicationSource{}
+		}
+		res.MergeOverrides(v1alpha1.ApplicationSource{Helm: overrides.Helm, Kustomize: overrides.Kustomize})
+	}
+	return res, nil
+}
+
+// writeSourceOverrides merges overrides into the .argocd-source file of an application. It returns false if the file
+// already contained them.
+func writeSourceOverrides(appPath, appName string, overrides v1alpha1.ApplicationSource) (bool, error) {
+	path := filepath.Join(appPath, fmt.Sprintf(appSourceOverridesFile, appName))
+	existing, err := readSourceOverridesFile(path)
+	if os.IsNotExist(err) {
+		existing = &sourceOverrides{}
+	} else if err != nil {
+		return false, err
+	}
+	source := v1alpha1.ApplicationSource{Helm: existing.Helm.DeepCopy(), Kustomize: existing.Kustomize.DeepCopy()}
+	source.MergeOverrides(overrides)
+	merged := sourceOverrides{Helm: source.Helm, Kustomize: source.Kustomize}
+	if reflect.DeepEqual(*existing, merged) {
+		return false, nil
+	}
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return false, err
+	}
+	return true, ioutil.WriteFile(path, data, 0644)
+}
+
+// sopsPolicy is the handling of the SOPS-encrypted files of directory sources
+type sopsPolicy int
+
+const (
+	// sopsKeepEncrypted keeps the files encrypted, e.g. for the manifests returned by the API server
+	sopsKeepEncrypted sopsPolicy = iota
+	// sopsDecrypt decrypts the files
+	sopsDecrypt
+	// sopsDeny fails the generation, since the project of the application does not permit the decryption
+	sopsDeny
+)
+
+// getSOPSPolicy returns the handling of SOPS-encrypted files. Only the manifests generated for the application
+// controller are decrypted, the same as the secret placeholders.
+func getSOPSPolicy(q *apiclient.ManifestRequest) sopsPolicy {
+	switch {
+	case !q.ResolveSecrets:
+		return sopsKeepEncrypted
+	case q.DecryptSOPS:
+		return sopsDecrypt
+	default:
+		return sopsDeny
+	}
+}
+
+// findManifests looks at all yaml files in a directory and unmarshals them into a list of unstructured objects. Jsonnet
+// files are evaluated in a child process if jsonnetInChildProcess is set, i.e. if the manifest generation is limited.
+func findManifests(appPath string, env *v1alpha1.Env, directory v1alpha1.ApplicationSourceDirectory, sopsPolicy sopsPolicy, jsonnetInChildProcess bool) ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
 	err := filepath.Walk(appPath, func(path string, f os.FileInfo, err error) error {
 		if err != nil {
@@ -514,13 +884,21 @@ func findManifests(appPath string, env *v1alpha1.Env, directory v1alpha1.Applica
 			}
 		}
 
-		if !manifestFile.MatchString(f.Name()) {
+		if !manifestFile.MatchString(f.Name()) || strings.HasPrefix(f.Name(), sourceOverridesFilePrefix) {
 			return nil
 		}
 		out, err := utfutil.ReadFile(path, utfutil.UTF8)
 		if err != nil {
 			return err
 		}
+		if !strings.HasSuffix(f.Name(), ".jsonnet") && sopsPolicy != sopsKeepEncrypted && sops.IsEncrypted(out) {
+			if sopsPolicy == sopsDeny {
+				return status.Errorf(codes.FailedPrecondition, "%q is encrypted by SOPS, but the project does not permit its decryption", f.Name())
+			}
+			if out, err = sops.Decrypt(path); err != nil {
+				return status.Errorf(codes.FailedPrecondition, "Failed to decrypt %q: %v", f.Name(), err)
+			}
+		}
 		if strings.HasSuffix(f.Name(), ".json") {
 			var obj unstructured.Unstructured
 			err = json.Unmarshal(out, &obj)
@@ -529,11 +907,13 @@ func findManifests(appPath string, env *v1alpha1.Env, directory v1alpha1.Applica
 			}
 			objs = append(objs, &obj)
 		} else if strings.HasSuffix(f.Name(), ".jsonnet") {
-			vm := makeJsonnetVm(directory.Jsonnet, env)
-			vm.Importer(&jsonnet.FileImporter{
-				JPaths: []string{appPath},
-			})
-			jsonStr, err := vm.EvaluateSnippet(f.Name(), string(out))
+			evaluation := jsonnetEvaluation{Filename: f.Name(), Snippet: string(out), JPaths: []string{appPath}, Jsonnet: directory.Jsonnet, Env: env}
+			var jsonStr string
+			if jsonnetInChildProcess {
+				jsonStr, err = evaluateJsonnetInChildProcess(appPath, evaluation)
+			} else {
+				jsonStr, err = evaluateJsonnet(evaluation)
+			}
 			if err != nil {
 				return status.Errorf(codes.FailedPrecondition, "Failed to evaluate jsonnet %q: %v", f.Name(), err)
 			}
@@ -618,6 +998,60 @@ func findPlugin(plugins []*v1alpha1.ConfigManagementPlugin, name string) *v1alph
 	return nil
 }
 
+// cueExport exports the CUE package of an application, substituting the build environment in the values of its tags
+func cueExport(appPath string, env *v1alpha1.Env, q *apiclient.ManifestRequest) ([]*unstructured.Unstructured, error) {
+	opts := q.ApplicationSource.CUE.DeepCopy()
+	if opts != nil {
+		for i := range opts.Tags {
+			opts.Tags[i].Value = env.Envsubst(opts.Tags[i].Value)
+		}
+	}
+	return cue.Export(appPath, opts, map[string]string{
+		cue.TagAppName:      q.AppLabelValue,
+		cue.TagAppNamespace: q.Namespace,
+	})
+}
+
+// helmfileTemplate renders the helmfile of an application, whose state value files must be within the repository
+func helmfileTemplate(appPath, repoRoot string, env *v1alpha1.Env, q *apiclient.ManifestRequest) ([]*unstructured.Unstructured, error) {
+	opts := q.ApplicationSource.Helmfile
+	if opts != nil {
+		absRepoPath, err := filepath.Abs(repoRoot)
+		if err != nil {
+			return nil, err
+		}
+		absWorkDir, err := filepath.Abs(appPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range opts.StateValueFiles {
+			path := file
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(absWorkDir, path)
+			}
+			if _, err := security.EnforceToCurrentRoot(absRepoPath, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return helmfile.Template(appPath, opts, getHelmRepos(q.Repos), env)
+}
+
+// tankaShow renders the Tanka environment of an application, installing the dependencies of its project with the
+// credentials of the repository if they are not committed
+func tankaShow(appPath, repoRoot string, env *v1alpha1.Env, q *apiclient.ManifestRequest, creds git.Creds) ([]*unstructured.Unstructured, error) {
+	var environ []string
+	if creds != nil {
+		closer, credsEnviron, err := creds.Environ()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = closer.Close() }()
+		environ = credsEnviron
+	}
+	return tanka.Show(appPath, repoRoot, q.ApplicationSource.Tanka, env, environ)
+}
+
 func runConfigManagementPlugin(appPath string, envVars *v1alpha1.Env, q *apiclient.ManifestRequest, creds git.Creds) ([]*unstructured.Unstructured, error) {
 	plugin := findPlugin(q.Plugins, q.ApplicationSource.Plugin.Name)
 	if plugin == nil {
@@ -788,7 +1222,7 @@ func (s *Service) GetRevisionMetadata(ctx context.Context, q *apiclient.RepoServ
 	s.repoLock.Lock(gitClient.Root())
 	defer s.repoLock.Unlock(gitClient.Root())
 
-	_, err = checkoutRevision(gitClient, q.Revision)
+	_, err = checkoutRevision(ctx, gitClient, q.Revision)
 	if err != nil {
 		return nil, err
 	}
@@ -841,7 +1275,7 @@ func (s *Service) newClientResolveRevision(repo *v1alpha1.Repository, revision s
 }
 
 func (s *Service) newHelmClientResolveRevision(repo *v1alpha1.Repository, revision string, chart string) (helm.Client, string, error) {
-	helmClient := s.newHelmClient(repo.Repo, repo.GetHelmCreds())
+	helmClient := metrics.WrapHelmClient(repo.Repo, s.metricsServer, s.newHelmClient(repo.Repo, repo.GetHelmCreds()))
 	if helm.IsVersion(revision) {
 		return helmClient, revision, nil
 	}
@@ -864,18 +1298,117 @@ func (s *Service) newHelmClientResolveRevision(repo *v1alpha1.Repository, revisi
 	return helmClient, version.String(), nil
 }
 
+// newOCIClientResolveRevision resolves the tag of an OCI artifact to the digest of its manifest
+func (s *Service) newOCIClientResolveRevision(repo *v1alpha1.Repository, revision string) (oci.Client, string, error) {
+	ociClient := s.newOCIClient(repo.Repo, repo.GetOCICreds())
+	digest, err := ociClient.ResolveDigest(revision)
+	if err != nil {
+		return nil, "", err
+	}
+	return ociClient, digest, nil
+}
+
+// newBucketClientResolveRevision resolves the revision of a bucket to the fingerprint of its current objects
+func (s *Service) newBucketClientResolveRevision(repo *v1alpha1.Repository, revision string) (bucket.Client, string, error) {
+	bucketClient, err := s.newBucketClient(repo.Repo, repo.GetBucketCreds())
+	if err != nil {
+		return nil, "", err
+	}
+	revision, err = bucketClient.ResolveRevision(revision)
+	if err != nil {
+		return nil, "", err
+	}
+	return bucketClient, revision, nil
+}
+
+const (
+	// writeBackAttempts is the number of attempts to push a write-back, which fails if the branch was updated concurrently
+	writeBackAttempts        = 3
+	defaultWriteBackAuthor   = "Argo CD"
+	defaultWriteBackEmail    = "argocd@localhost"
+	defaultWriteBackMessageF = "Update parameters of application %s"
+)
+
+// WriteBack merges parameter overrides into the .argocd-source file of an application and pushes the commit to the branch
+// the application tracks, using the credentials of the repository. If the branch was updated concurrently, the overrides
+// are merged again on top of the new head of the branch.
+func (s *Service) WriteBack(ctx context.Context, q *apiclient.WriteBackRequest) (*apiclient.WriteBackResponse, error) {
+	if q.Repo == nil || q.AppName == "" || q.Overrides == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "repository, application name and overrides are required")
+	}
+	if q.Branch == "" || q.Branch == "HEAD" || git.IsCommitSHA(q.Branch) {
+		return nil, status.Errorf(codes.InvalidArgument, "write-back requires the application to track a branch, not '%s'", q.Branch)
+	}
+	message, authorName, authorEmail := q.Message, q.AuthorName, q.AuthorEmail
+	if message == "" {
+		message = fmt.Sprintf(defaultWriteBackMessageF, q.AppName)
+	}
+	if authorName == "" {
+		authorName = defaultWriteBackAuthor
+	}
+	if authorEmail == "" {
+		authorEmail = defaultWriteBackEmail
+	}
+	gitClient, err := s.newClient(q.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metricsServer.IncPendingRepoRequest(q.Repo.Repo)
+	defer s.metricsServer.DecPendingRepoRequest(q.Repo.Repo)
+
+	s.repoLock.Lock(gitClient.Root())
+	defer s.repoLock.Unlock(gitClient.Root())
+
+	var pushErr error
+	for attempt := 0; attempt < writeBackAttempts; attempt++ {
+		// remote-tracking refs only exist for branches, so tags are rejected
+		revision, err := checkoutRevision(ctx, gitClient, "origin/"+q.Branch)
+		if err != nil {
+			return nil, err
+		}
+		appPath, err := argopath.Path(gitClient.Root(), q.Path)
+		if err != nil {
+			return nil, err
+		}
+		changed, err := writeSourceOverrides(appPath, q.AppName, *q.Overrides)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return &apiclient.WriteBackResponse{Revision: revision}, nil
+		}
+		commitSHA, err := gitClient.CommitAndPush(q.Branch, message, authorName, authorEmail)
+		if err == nil {
+			log.Infof("wrote back overrides of application %s to %s/%s: %s", q.AppName, q.Repo.Repo, q.Branch, commitSHA)
+			return &apiclient.WriteBackResponse{Revision: commitSHA, Changed: true}, nil
+		}
+		pushErr = err
+		log.Warnf("failed to write back overrides of application %s to %s/%s (attempt %d): %v", q.AppName, q.Repo.Repo, q.Branch, attempt+1, err)
+	}
+	return nil, status.Errorf(codes.Internal, "Failed to push to branch %s: %v", q.Branch, pushErr)
+}
+
 // checkoutRevision is a convenience function to initialize a repo, fetch, and checkout a revision
 // Returns the 40 character commit SHA after the checkout has been performed
-func checkoutRevision(gitClient git.Client, commitSHA string) (string, error) {
+func checkoutRevision(ctx context.Context, gitClient git.Client, commitSHA string) (string, error) {
 	err := gitClient.Init()
 	if err != nil {
 		return "", status.Errorf(codes.Internal, "Failed to initialize git repo: %v", err)
 	}
+	_, span := tracing.StartSpanFromContext(ctx, "git fetch")
+	span.SetBaggageItem("repo", gitClient.Root())
 	err = gitClient.Fetch()
+	span.SetError(err)
+	span.Finish()
 	if err != nil {
 		return "", status.Errorf(codes.Internal, "Failed to fetch git repo: %v", err)
 	}
+	_, span = tracing.StartSpanFromContext(ctx, "git checkout")
+	span.SetBaggageItem("revision", commitSHA)
 	err = gitClient.Checkout(commitSHA)
+	span.SetError(err)
+	span.Finish()
 	if err != nil {
 		return "", status.Errorf(codes.Internal, "Failed to checkout %s: %v", commitSHA, err)
 	}
@@ -899,3 +1432,82 @@ func (s *Service) GetHelmCharts(ctx context.Context, q *apiclient.HelmChartsRequ
 	}
 	return &res, nil
 }
+
+// GetGitDirectories returns the directories of the repository which match the requested path
+func (s *Service) GetGitDirectories(ctx context.Context, q *apiclient.GitFilesRequest) (*apiclient.GitDirectoriesResponse, error) {
+	res := apiclient.GitDirectoriesResponse{}
+	err := s.walkGitPaths(ctx, q.Repo, q.Revision, q.Path, func(relPath string, path string, info os.FileInfo) error {
+		if info.IsDir() {
+			res.Paths = append(res.Paths, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetGitFiles returns the contents of the files of the repository which match the requested path
+func (s *Service) GetGitFiles(ctx context.Context, q *apiclient.GitFilesRequest) (*apiclient.GitFilesResponse, error) {
+	res := apiclient.GitFilesResponse{Files: make(map[string][]byte)}
+	err := s.walkGitPaths(ctx, q.Repo, q.Revision, q.Path, func(relPath string, path string, info os.FileInfo) error {
+		// symlinks are not followed, since they might point outside of the repository
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		res.Files[relPath] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// walkGitPaths checks out a revision of a repository and calls the callback for every path of the repository, relative
+// to its root, which matches the glob pattern
+func (s *Service) walkGitPaths(ctx context.Context, repo *v1alpha1.Repository, revision string, pattern string, callback func(relPath string, path string, info os.FileInfo) error) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid path '%s': %v", pattern, err)
+	}
+	gitClient, commitSHA, err := s.newClientResolveRevision(repo, revision)
+	if err != nil {
+		return err
+	}
+
+	s.metricsServer.IncPendingRepoRequest(repo.Repo)
+	defer s.metricsServer.DecPendingRepoRequest(repo.Repo)
+
+	s.repoLock.Lock(gitClient.Root())
+	defer s.repoLock.Unlock(gitClient.Root())
+
+	_, err = checkoutRevision(ctx, gitClient, commitSHA)
+	if err != nil {
+		return err
+	}
+	root := gitClient.Root()
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if ok, _ := filepath.Match(pattern, relPath); ok {
+			return callback(relPath, path, info)
+		}
+		return nil
+	})
+}
@@ -2,9 +2,16 @@ package repository
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -13,6 +20,8 @@ import (
 	"github.com/Masterminds/semver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -22,18 +31,22 @@ import (
 	"github.com/argoproj/argo-cd/reposerver/cache"
 	"github.com/argoproj/argo-cd/reposerver/metrics"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/attestation"
+	"github.com/argoproj/argo-cd/util/bucket"
 	cacheutil "github.com/argoproj/argo-cd/util/cache"
 	"github.com/argoproj/argo-cd/util/git"
 	gitmocks "github.com/argoproj/argo-cd/util/git/mocks"
 	"github.com/argoproj/argo-cd/util/helm"
 	helmmocks "github.com/argoproj/argo-cd/util/helm/mocks"
+	"github.com/argoproj/argo-cd/util/oci"
+	"github.com/argoproj/argo-cd/util/tgz"
 )
 
 func newServiceWithMocks(root string) (*Service, *gitmocks.Client) {
 	service := NewService(metrics.NewMetricsServer(), cache.NewCache(
 		cacheutil.NewCache(cacheutil.NewInMemoryCache(1*time.Minute)),
 		1*time.Minute,
-	), 1)
+	), 1, nil, nil)
 	helmClient := &helmmocks.Client{}
 	gitClient := &gitmocks.Client{}
 	root, err := filepath.Abs(root)
@@ -84,11 +97,31 @@ func TestGenerateYamlManifestInDir(t *testing.T) {
 	assert.Equal(t, countOfManifests, len(res1.Manifests))
 
 	// this will test concatenated manifests to verify we split YAMLs correctly
-	res2, err := GenerateManifests("./testdata/concatenated", "/", "", &q)
+	res2, err := GenerateManifests(context.Background(), "./testdata/concatenated", "/", "", &q)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(res2.Manifests))
 }
 
+func TestGenerateManifestWithFiles(t *testing.T) {
+	service := newService(".")
+	files, err := tgz.Compress("./testdata/concatenated")
+	assert.NoError(t, err)
+
+	src := argoappv1.ApplicationSource{Path: "concatenated"}
+	q := apiclient.ManifestRequestWithFiles{
+		Request: &apiclient.ManifestRequest{Repo: &argoappv1.Repository{}, ApplicationSource: &src, Revision: "abc"},
+		Files:   files,
+	}
+	res, err := service.GenerateManifestWithFiles(context.Background(), &q)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(res.Manifests))
+	assert.Equal(t, "abc", res.Revision)
+
+	q.Files = []byte("not an archive")
+	_, err = service.GenerateManifestWithFiles(context.Background(), &q)
+	assert.Error(t, err)
+}
+
 // ensure we can use a semver constraint range (>= 1.0.0) and get back the correct chart (1.0.0)
 func TestHelmManifestFromChartRepo(t *testing.T) {
 	service := newService(".")
@@ -106,6 +139,96 @@ func TestHelmManifestFromChartRepo(t *testing.T) {
 	}, response)
 }
 
+// fakeOCIClient serves the artifact with the digest from a directory
+type fakeOCIClient struct {
+	digest string
+	dir    string
+}
+
+func (c *fakeOCIClient) ResolveDigest(tag string) (string, error) {
+	if tag != "v1.0.0" && tag != c.digest {
+		return "", fmt.Errorf("tag %s not found", tag)
+	}
+	return c.digest, nil
+}
+
+func (c *fakeOCIClient) Pull(digest string) (string, util.Closer, error) {
+	if digest != c.digest {
+		return "", nil, fmt.Errorf("digest %s not found", digest)
+	}
+	return c.dir, util.NopCloser, nil
+}
+
+func (c *fakeOCIClient) ListTags() ([]string, error) {
+	return []string{"v1.0.0"}, nil
+}
+
+func TestGenerateManifestsFromOCIArtifact(t *testing.T) {
+	service := newService(".")
+	digest := "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"
+	dir, err := filepath.Abs("./testdata")
+	assert.NoError(t, err)
+	service.newOCIClient = func(repoURL string, creds oci.Creds) oci.Client {
+		return &fakeOCIClient{digest: digest, dir: dir}
+	}
+	source := &argoappv1.ApplicationSource{RepoURL: "oci://registry.example.com/org/manifests", Path: "recurse", TargetRevision: "v1.0.0"}
+	repo := &argoappv1.Repository{Repo: source.RepoURL, Type: "oci"}
+
+	res, err := service.GenerateManifest(context.Background(), &apiclient.ManifestRequest{Repo: repo, ApplicationSource: source, NoCache: true})
+	assert.NoError(t, err)
+	assert.Equal(t, digest, res.Revision)
+	assert.Equal(t, "Directory", res.SourceType)
+	assert.Len(t, res.Manifests, 1)
+
+	source.TargetRevision = "v2.0.0"
+	_, err = service.GenerateManifest(context.Background(), &apiclient.ManifestRequest{Repo: repo, ApplicationSource: source, NoCache: true})
+	assert.EqualError(t, err, "tag v2.0.0 not found")
+
+	source.TargetRevision = "v1.0.0"
+	source.Path = "../.."
+	_, err = service.GenerateManifest(context.Background(), &apiclient.ManifestRequest{Repo: repo, ApplicationSource: source, NoCache: true})
+	assert.Error(t, err)
+}
+
+// fakeBucketClient serves the objects of the current revision from a directory
+type fakeBucketClient struct {
+	revision string
+	dir      string
+}
+
+func (c *fakeBucketClient) ResolveRevision(revision string) (string, error) {
+	if revision != "" && revision != c.revision {
+		return "", fmt.Errorf("revision %s is not the current revision", revision)
+	}
+	return c.revision, nil
+}
+
+func (c *fakeBucketClient) Download(revision string) (string, util.Closer, error) {
+	if _, err := c.ResolveRevision(revision); err != nil {
+		return "", nil, err
+	}
+	return c.dir, util.NopCloser, nil
+}
+
+func TestGenerateManifestsFromBucket(t *testing.T) {
+	service := newService(".")
+	dir, err := filepath.Abs("./testdata")
+	assert.NoError(t, err)
+	service.newBucketClient = func(repoURL string, creds bucket.Creds) (bucket.Client, error) {
+		return &fakeBucketClient{revision: "1234", dir: dir}, nil
+	}
+	source := &argoappv1.ApplicationSource{RepoURL: "s3://my-bucket/manifests", Path: "recurse", Directory: &argoappv1.ApplicationSourceDirectory{Recurse: true}}
+	repo := &argoappv1.Repository{Repo: source.RepoURL, Type: "bucket"}
+
+	res, err := service.GenerateManifest(context.Background(), &apiclient.ManifestRequest{Repo: repo, ApplicationSource: source, NoCache: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", res.Revision)
+	assert.Len(t, res.Manifests, 2)
+
+	_, err = service.GenerateManifest(context.Background(), &apiclient.ManifestRequest{Repo: repo, ApplicationSource: source, Revision: "5678", NoCache: true})
+	assert.EqualError(t, err, "revision 5678 is not the current revision")
+}
+
 func TestGenerateManifestsUseExactRevision(t *testing.T) {
 	service, gitClient := newServiceWithMocks(".")
 
@@ -481,11 +604,66 @@ func TestGenerateFromUTF16(t *testing.T) {
 		Repo:              &argoappv1.Repository{},
 		ApplicationSource: &argoappv1.ApplicationSource{},
 	}
-	res1, err := GenerateManifests("./testdata/utf-16", "/", "", &q)
+	res1, err := GenerateManifests(context.Background(), "./testdata/utf-16", "/", "", &q)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(res1.Manifests))
 }
 
+func TestGenerateSOPSEncryptedManifests(t *testing.T) {
+	q := apiclient.ManifestRequest{
+		Repo:              &argoappv1.Repository{},
+		ApplicationSource: &argoappv1.ApplicationSource{},
+	}
+	// the manifests returned by the API server keep the encrypted files
+	res, err := GenerateManifests(context.Background(), "./testdata/sops", "/", "", &q)
+	assert.NoError(t, err)
+	assert.Len(t, res.Manifests, 1)
+	assert.Contains(t, res.Manifests[0], "ENC[AES256_GCM")
+
+	q.ResolveSecrets = true
+	_, err = GenerateManifests(context.Background(), "./testdata/sops", "/", "", &q)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the project does not permit its decryption")
+
+	t.Run("Decrypt", func(t *testing.T) {
+		if _, err := exec.LookPath("sops"); err != nil {
+			t.Skip("sops is not installed")
+		}
+		keyFile, err := filepath.Abs("./testdata/sops-age-key.txt")
+		assert.NoError(t, err)
+		dir, err := ioutil.TempDir("", "sops")
+		assert.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret.yaml"), []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: database
+stringData:
+  password: s3cr3t
+`), 0644))
+		cmd := exec.Command("sops", "--encrypt", "--in-place", "--age", "age1sq68ra9996sxlfmexyqxcr426nu5jg9ds5ugs9lt4japzua9yvdq087d2j", "--encrypted-regex", "^(data|stringData)$", "secret.yaml")
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			return
+		}
+		encrypted, err := ioutil.ReadFile(filepath.Join(dir, "secret.yaml"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(encrypted), "s3cr3t")
+
+		assert.NoError(t, os.Setenv("SOPS_AGE_KEY_FILE", keyFile))
+		defer func() { _ = os.Unsetenv("SOPS_AGE_KEY_FILE") }()
+		q.DecryptSOPS = true
+		res, err := GenerateManifests(context.Background(), dir, "/", "", &q)
+		assert.NoError(t, err)
+		if assert.Len(t, res.Manifests, 1) {
+			assert.Contains(t, res.Manifests[0], "s3cr3t")
+			assert.NotContains(t, res.Manifests[0], "ENC[AES256_GCM")
+			assert.NotContains(t, res.Manifests[0], "sops")
+		}
+	})
+}
+
 func TestListApps(t *testing.T) {
 	service := newService("./testdata")
 
@@ -622,3 +800,238 @@ func TestService_newHelmClientResolveRevision(t *testing.T) {
 		assert.EqualError(t, err, "invalid revision '???': improper constraint: ???")
 	})
 }
+
+func TestGetGitDirectories(t *testing.T) {
+	service := newService("./testdata")
+
+	res, err := service.GetGitDirectories(context.Background(), &apiclient.GitFilesRequest{Repo: &argoappv1.Repository{}, Path: "my-chart*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my-chart", "my-chart-2"}, res.Paths)
+
+	res, err = service.GetGitDirectories(context.Background(), &apiclient.GitFilesRequest{Repo: &argoappv1.Repository{}, Path: "my-chart/*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my-chart/templates"}, res.Paths)
+
+	_, err = service.GetGitDirectories(context.Background(), &apiclient.GitFilesRequest{Repo: &argoappv1.Repository{}, Path: "["})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetGitFiles(t *testing.T) {
+	service := newService("./testdata")
+
+	res, err := service.GetGitFiles(context.Background(), &apiclient.GitFilesRequest{Repo: &argoappv1.Repository{}, Path: "*/Chart.yaml"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Files, 3)
+	assert.Contains(t, string(res.Files["my-chart/Chart.yaml"]), "name: my-chart")
+	assert.Contains(t, res.Files, "invalid-helm/Chart.yaml")
+}
+
+func TestSourceOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source-overrides")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	overrides, err := readSourceOverrides(dir, "guestbook")
+	assert.NoError(t, err)
+	assert.Nil(t, overrides)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, sourceOverridesFile), []byte(`
+helm:
+  parameters:
+  - name: image.tag
+    value: v1
+  - name: replicas
+    value: "2"
+`), 0644))
+	changed, err := writeSourceOverrides(dir, "guestbook", argoappv1.ApplicationSource{
+		Helm: &argoappv1.ApplicationSourceHelm{Parameters: []argoappv1.HelmParameter{{Name: "image.tag", Value: "v2"}}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = writeSourceOverrides(dir, "guestbook", argoappv1.ApplicationSource{
+		Helm: &argoappv1.ApplicationSourceHelm{Parameters: []argoappv1.HelmParameter{{Name: "image.tag", Value: "v2"}}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	overrides, err = readSourceOverrides(dir, "guestbook")
+	assert.NoError(t, err)
+	assert.Equal(t, []argoappv1.HelmParameter{{Name: "image.tag", Value: "v2"}, {Name: "replicas", Value: "2"}}, overrides.Helm.Parameters)
+	assert.Nil(t, overrides.Kustomize)
+
+	// the overrides of other applications of the path are ignored
+	overrides, err = readSourceOverrides(dir, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, []argoappv1.HelmParameter{{Name: "image.tag", Value: "v1"}, {Name: "replicas", Value: "2"}}, overrides.Helm.Parameters)
+}
+
+func TestGenerateManifestsSkipsSourceOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source-overrides")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	data, err := ioutil.ReadFile("./testdata/concatenated/concatenated.yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "concatenated.yaml"), data, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf(appSourceOverridesFile, "guestbook")), []byte(`{}`), 0644))
+
+	q := apiclient.ManifestRequest{Repo: &argoappv1.Repository{}, AppLabelValue: "guestbook", ApplicationSource: &argoappv1.ApplicationSource{}}
+	res, err := GenerateManifests(context.Background(), dir, "/", "", &q)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(res.Manifests))
+	assert.NotNil(t, res.SourceOverrides)
+}
+
+func TestWriteBack(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-back")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "guestbook"), 0755))
+	service, gitClient := newServiceWithMocks(dir)
+	gitClient.On("CommitAndPush", "master", "update image", "argocd", "argocd@example.com").Return("abc", nil)
+
+	q := apiclient.WriteBackRequest{
+		Repo:    &argoappv1.Repository{},
+		Branch:  "master",
+		Path:    "guestbook",
+		AppName: "guestbook",
+		Overrides: &argoappv1.ApplicationSource{
+			Kustomize: &argoappv1.ApplicationSourceKustomize{Images: argoappv1.KustomizeImages{"nginx:1.17"}},
+		},
+		Message:     "update image",
+		AuthorName:  "argocd",
+		AuthorEmail: "argocd@example.com",
+	}
+	res, err := service.WriteBack(context.Background(), &q)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", res.Revision)
+	assert.True(t, res.Changed)
+	gitClient.AssertCalled(t, "Checkout", "origin/master")
+	overrides, err := readSourceOverrides(filepath.Join(dir, "guestbook"), "guestbook")
+	assert.NoError(t, err)
+	assert.Equal(t, argoappv1.KustomizeImages{"nginx:1.17"}, overrides.Kustomize.Images)
+
+	// nothing is pushed if the overrides are unchanged
+	res, err = service.WriteBack(context.Background(), &q)
+	assert.NoError(t, err)
+	assert.False(t, res.Changed)
+	gitClient.AssertNumberOfCalls(t, "CommitAndPush", 1)
+
+	q.Branch = "HEAD"
+	_, err = service.WriteBack(context.Background(), &q)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGenerateHelmfileWithValueFileOutsideRepo(t *testing.T) {
+	_, err := helmfileTemplate("./testdata/my-chart", "./testdata/my-chart", &argoappv1.Env{}, &apiclient.ManifestRequest{
+		Repo: &argoappv1.Repository{},
+		ApplicationSource: &argoappv1.ApplicationSource{
+			Helmfile: &argoappv1.ApplicationSourceHelmfile{StateValueFiles: []string{"../minio/values.yaml"}},
+		},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "should be on or under current directory")
+	}
+}
+
+func TestRunCustomTool_Limits(t *testing.T) {
+	service := newService(".")
+	newRequest := func(script string, limits *argoappv1.ManifestGenerationLimits) *apiclient.ManifestRequest {
+		return &apiclient.ManifestRequest{
+			AppLabelValue: "test-app",
+			ApplicationSource: &argoappv1.ApplicationSource{
+				Plugin: &argoappv1.ApplicationSourcePlugin{Name: "test"},
+			},
+			Plugins: []*argoappv1.ConfigManagementPlugin{{
+				Name:     "test",
+				Generate: argoappv1.Command{Command: []string{"sh", "-c"}, Args: []string{script}},
+			}},
+			Repo:    &argoappv1.Repository{},
+			NoCache: true,
+			Limits:  limits,
+		}
+	}
+
+	t.Run("CPUAndMemory", func(t *testing.T) {
+		res, err := service.GenerateManifest(context.Background(), newRequest(
+			`echo "{\"kind\": \"FakeObject\", \"metadata\": {\"name\": \"limits-$(ulimit -t)-$(ulimit -v)\"}}"`,
+			&argoappv1.ManifestGenerationLimits{CPUSeconds: 10, Memory: "512Mi"}))
+		assert.NoError(t, err)
+		assert.Len(t, res.Manifests, 1)
+		obj := &unstructured.Unstructured{}
+		assert.NoError(t, json.Unmarshal([]byte(res.Manifests[0]), obj))
+		assert.Equal(t, "limits-10-524288", obj.GetName())
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		started := time.Now()
+		_, err := service.GenerateManifest(context.Background(), newRequest("sleep 10", &argoappv1.ManifestGenerationLimits{TimeoutSeconds: 1}))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.True(t, time.Since(started) < 5*time.Second)
+	})
+	t.Run("InvalidMemory", func(t *testing.T) {
+		_, err := service.GenerateManifest(context.Background(), newRequest("true", &argoappv1.ManifestGenerationLimits{Memory: "lots"}))
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+type fakeSecretReader map[string]map[string]interface{}
+
+func (r fakeSecretReader) ReadSecret(path string) (map[string]interface{}, error) {
+	return r[path], nil
+}
+
+func TestGenerateManifest_ResolveSecrets(t *testing.T) {
+	service := newService(".")
+	service.secretReader = fakeSecretReader{"secret/data/my-team/app": {"password": "s3cr3t"}}
+	q := &apiclient.ManifestRequest{
+		AppLabelValue: "test-app",
+		ApplicationSource: &argoappv1.ApplicationSource{
+			Plugin: &argoappv1.ApplicationSourcePlugin{Name: "test"},
+		},
+		Plugins: []*argoappv1.ConfigManagementPlugin{{
+			Name:     "test",
+			Generate: argoappv1.Command{Command: []string{"sh", "-c"}, Args: []string{`echo '{"kind": "Secret", "apiVersion": "v1", "metadata": {"name": "db"}, "stringData": {"password": "<vault:secret/data/my-team/app#password>"}}'`}},
+		}},
+		Repo:           &argoappv1.Repository{},
+		NoCache:        true,
+		ResolveSecrets: true,
+	}
+
+	// the secret is not permitted by the project
+	_, err := service.GenerateManifest(context.Background(), q)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "vault secret secret/data/my-team/app is not permitted by the project")
+	}
+
+	q.VaultPaths = []string{"secret/data/my-team/*"}
+	res, err := service.GenerateManifest(context.Background(), q)
+	assert.NoError(t, err)
+	if assert.Len(t, res.Manifests, 1) {
+		assert.Contains(t, res.Manifests[0], `"password":"s3cr3t"`)
+	}
+}
+
+func TestGenerateManifest_Attestation(t *testing.T) {
+	service := newService(".")
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	service.signer, err = attestation.NewSigner(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+
+	src := argoappv1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: "./testdata/concatenated"}
+	q := apiclient.ManifestRequest{Repo: &argoappv1.Repository{}, ApplicationSource: &src}
+	res, err := service.GenerateManifest(context.Background(), &q)
+	assert.NoError(t, err)
+
+	var envelope attestation.Envelope
+	assert.NoError(t, json.Unmarshal([]byte(res.Attestation), &envelope))
+	statement, err := attestation.Verify(&envelope, key.Public())
+	assert.NoError(t, err)
+	assert.NoError(t, statement.VerifyManifests(res.Manifests, nil))
+	assert.Equal(t, attestation.RecipeTypePrefix+string(argoappv1.ApplicationSourceTypeDirectory), statement.Predicate.Recipe.Type)
+	assert.Equal(t, "./testdata/concatenated", statement.Predicate.Recipe.EntryPoint)
+	assert.Contains(t, statement.Predicate.Recipe.Environment, "argocd")
+	assert.Len(t, statement.Predicate.Materials, 1)
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/argoproj/argo-cd/reposerver/metrics"
 	"github.com/argoproj/argo-cd/reposerver/repository"
 	"github.com/argoproj/argo-cd/server/version"
+	"github.com/argoproj/argo-cd/util/attestation"
 	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	tlsutil "github.com/argoproj/argo-cd/util/tls"
+	"github.com/argoproj/argo-cd/util/tracing"
+	"github.com/argoproj/argo-cd/util/vault"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
@@ -27,10 +30,12 @@ type ArgoCDRepoServer struct {
 	cache            *reposervercache.Cache
 	opts             []grpc.ServerOption
 	parallelismLimit int64
+	secretReader     vault.SecretReader
+	signer           *attestation.Signer
 }
 
 // NewServer returns a new instance of the Argo CD Repo server
-func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cache, tlsConfCustomizer tlsutil.ConfigCustomizer, parallelismLimit int64) (*ArgoCDRepoServer, error) {
+func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cache, tlsConfCustomizer tlsutil.ConfigCustomizer, parallelismLimit int64, secretReader vault.SecretReader, signer *attestation.Signer) (*ArgoCDRepoServer, error) {
 	// generate TLS cert
 	hosts := []string{
 		"localhost",
@@ -50,18 +55,22 @@ func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cach
 	tlsConfCustomizer(tlsConfig)
 
 	serverLog := log.NewEntry(log.StandardLogger())
-	streamInterceptors := []grpc.StreamServerInterceptor{grpc_logrus.StreamServerInterceptor(serverLog), grpc_util.PanicLoggerStreamServerInterceptor(serverLog)}
-	unaryInterceptors := []grpc.UnaryServerInterceptor{grpc_logrus.UnaryServerInterceptor(serverLog), grpc_util.PanicLoggerUnaryServerInterceptor(serverLog)}
+	streamInterceptors := []grpc.StreamServerInterceptor{tracing.StreamServerInterceptor(), grpc_util.CorrelationIDStreamServerInterceptor(false), grpc_logrus.StreamServerInterceptor(serverLog), grpc_util.PanicLoggerStreamServerInterceptor(serverLog)}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor(), grpc_util.CorrelationIDUnaryServerInterceptor(false), grpc_logrus.UnaryServerInterceptor(serverLog), grpc_util.PanicLoggerUnaryServerInterceptor(serverLog)}
 
 	return &ArgoCDRepoServer{
 		log:              serverLog,
 		metricsServer:    metricsServer,
 		cache:            cache,
 		parallelismLimit: parallelismLimit,
+		secretReader:     secretReader,
+		signer:           signer,
 		opts: []grpc.ServerOption{
 			grpc.Creds(credentials.NewTLS(tlsConfig)),
 			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 			grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+			grpc.MaxRecvMsgSize(apiclient.MaxGRPCMessageSize),
+			grpc.MaxSendMsgSize(apiclient.MaxGRPCMessageSize),
 		},
 	}, nil
 }
@@ -70,7 +79,7 @@ func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cach
 func (a *ArgoCDRepoServer) CreateGRPC() *grpc.Server {
 	server := grpc.NewServer(a.opts...)
 	versionpkg.RegisterVersionServiceServer(server, &version.Server{})
-	manifestService := repository.NewService(a.metricsServer, a.cache, a.parallelismLimit)
+	manifestService := repository.NewService(a.metricsServer, a.cache, a.parallelismLimit, a.secretReader, a.signer)
 	apiclient.RegisterRepoServerServiceServer(server, manifestService)
 
 	// Register reflection service on gRPC server.
@@ -1,5 +1,8 @@
 actions = {}
 actions["resume"] = {["disabled"] = false}
+actions["pause"] = {["disabled"] = false}
+actions["promote-full"] = {["disabled"] = false}
+actions["abort"] = {["disabled"] = false}
 
 local paused = false
 
@@ -13,5 +16,16 @@ if paused then
 else
     actions["resume"]["disabled"] = true
 end
+if obj.spec.paused ~= nil and obj.spec.paused then
+    actions["pause"]["disabled"] = true
+end
+
+local aborted = obj.status ~= nil and obj.status.abort ~= nil and obj.status.abort
+if aborted then
+    actions["abort"]["disabled"] = true
+end
+if aborted or (obj.status ~= nil and obj.status.promoteFull ~= nil and obj.status.promoteFull) then
+    actions["promote-full"]["disabled"] = true
+end
 
 return actions
@@ -0,0 +1,10 @@
+if obj.status == nil then
+    obj.status = {}
+end
+obj.status.promoteFull = true
+
+if obj.spec.paused ~= nil and obj.spec.paused then
+    obj.spec.paused = false
+end
+
+return obj
@@ -46,6 +46,18 @@ function checkPaused(obj)
   return nil
 end
 
+-- observedGeneration is the generation of the spec the controller reconciled, or a hash of the spec before v0.10
+function isGenerationObserved(obj)
+  if obj.metadata.generation == nil or obj.status.observedGeneration == nil then
+    return true
+  end
+  observedGeneration = tonumber(obj.status.observedGeneration)
+  if observedGeneration == nil or observedGeneration > obj.metadata.generation then
+    return true
+  end
+  return observedGeneration == obj.metadata.generation
+end
+
 hs = {}
 if obj.status ~= nil then
   if obj.status.conditions ~= nil then
@@ -67,6 +79,11 @@ if obj.status ~= nil then
       end
     end
   end
+  if not isGenerationObserved(obj) then
+    hs.status = "Progressing"
+    hs.message = "Waiting for rollout spec update to be observed"
+    return hs
+  end
   if obj.status.currentPodHash ~= nil then
     if obj.spec.strategy.blueGreen ~= nil then
       isPaused = checkPaused(obj)
@@ -2,6 +2,7 @@ package account
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 	"k8s.io/kubernetes/pkg/util/slice"
 
+	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/pkg/apiclient/account"
 	"github.com/argoproj/argo-cd/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/util/password"
@@ -20,6 +22,9 @@ import (
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
+// accountNameRegex restricts account names to characters which can be used in argocd-cm and argocd-secret keys
+var accountNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
 // Server provides a Session service
 type Server struct {
 	sessionMgr  *session.SessionManager
@@ -92,14 +97,18 @@ func (s *Server) CanI(ctx context.Context, r *account.CanIRequest) (*account.Can
 	}
 }
 
-func toApiAccount(name string, a settings.Account) *account.Account {
+func (s *Server) toApiAccount(name string, a settings.Account) *account.Account {
 	var capabilities []string
 	for _, c := range a.Capabilities {
 		capabilities = append(capabilities, string(c))
 	}
 	var tokens []*account.Token
 	for _, t := range a.Tokens {
-		tokens = append(tokens, &account.Token{Id: t.ID, ExpiresAt: t.ExpiresAt, IssuedAt: t.IssuedAt})
+		token := &account.Token{Id: t.ID, Name: t.Name, ExpiresAt: t.ExpiresAt, IssuedAt: t.IssuedAt}
+		if lastUsed := s.sessionMgr.GetTokenLastUsed(t.ID); lastUsed != nil {
+			token.LastUsedAt = lastUsed.Unix()
+		}
+		tokens = append(tokens, token)
 	}
 	sort.Slice(tokens, func(i, j int) bool {
 		return tokens[i].IssuedAt > tokens[j].IssuedAt
@@ -131,7 +140,7 @@ func (s *Server) ListAccounts(ctx context.Context, r *account.ListAccountRequest
 	}
 	for name, a := range accounts {
 		if err := s.ensureHasAccountPermission(ctx, rbacpolicy.ActionGet, name); err == nil {
-			resp.Items = append(resp.Items, toApiAccount(name, a))
+			resp.Items = append(resp.Items, s.toApiAccount(name, a))
 		}
 	}
 	sort.Slice(resp.Items, func(i, j int) bool {
@@ -148,7 +157,101 @@ func (s *Server) GetAccount(ctx context.Context, r *account.GetAccountRequest) (
 	if err != nil {
 		return nil, err
 	}
-	return toApiAccount(r.Name, *a), nil
+	return s.toApiAccount(r.Name, *a), nil
+}
+
+func parseCapabilities(capabilities []string) ([]settings.AccountCapability, error) {
+	var res []settings.AccountCapability
+	for _, c := range capabilities {
+		switch capability := settings.AccountCapability(c); capability {
+		case settings.AccountCapabilityLogin, settings.AccountCapabilityApiKey:
+			res = append(res, capability)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown account capability '%s'", c)
+		}
+	}
+	if len(res) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "account must have at least one capability")
+	}
+	return res, nil
+}
+
+// CreateAccount creates a new local account
+func (s *Server) CreateAccount(ctx context.Context, r *account.CreateAccountRequest) (*account.Account, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionCreate, r.Name); err != nil {
+		return nil, err
+	}
+	if !accountNameRegex.MatchString(r.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account name '%s': must consist of alphanumeric characters, '-' or '_'", r.Name)
+	}
+	capabilities, err := parseCapabilities(r.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+	a := settings.Account{
+		Enabled:      !r.Disabled,
+		Capabilities: capabilities,
+		Tokens:       []settings.Token{},
+	}
+	if r.Password != "" {
+		hashedPassword, err := password.HashPassword(r.Password)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		a.PasswordHash = hashedPassword
+		a.PasswordMtime = &now
+	}
+	if err := s.settingsMgr.AddAccount(r.Name, a); err != nil {
+		return nil, err
+	}
+	log.Infof("user '%s' created account '%s'", session.Username(ctx), r.Name)
+	return s.toApiAccount(r.Name, a), nil
+}
+
+// UpdateAccount updates the capabilities of a local account and enables or disables it
+func (s *Server) UpdateAccount(ctx context.Context, r *account.UpdateAccountRequest) (*account.Account, error) {
+	// unlike other account operations, accounts are not allowed to update themselves so that they
+	// cannot grant capabilities to themselves
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionUpdate, r.Name); err != nil {
+		return nil, err
+	}
+	capabilities, err := parseCapabilities(r.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+	var updated settings.Account
+	err = s.settingsMgr.UpdateAccount(r.Name, func(a *settings.Account) error {
+		requested := settings.Account{Capabilities: capabilities}
+		if r.Name == common.ArgoCDAdminUsername && requested.FormatCapabilities() != a.FormatCapabilities() {
+			return status.Errorf(codes.InvalidArgument, "capabilities of account '%s' cannot be changed", r.Name)
+		}
+		a.Enabled = r.Enabled
+		a.Capabilities = capabilities
+		updated = *a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("user '%s' updated account '%s'", session.Username(ctx), r.Name)
+	return s.toApiAccount(r.Name, updated), nil
+}
+
+// DeleteAccount deletes a local account and all of its tokens
+func (s *Server) DeleteAccount(ctx context.Context, r *account.DeleteAccountRequest) (*account.EmptyResponse, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionDelete, r.Name); err != nil {
+		return nil, err
+	}
+	if err := s.settingsMgr.DeleteAccount(r.Name); err != nil {
+		return nil, err
+	}
+	// sessions of the account must not become valid again if an account with the same name is created later on
+	if err := s.sessionMgr.RevokeSessions(r.Name); err != nil {
+		log.Warnf("Failed to revoke sessions of deleted account %s: %v", r.Name, err)
+	}
+	log.Infof("user '%s' deleted account '%s'", session.Username(ctx), r.Name)
+	return &account.EmptyResponse{}, nil
 }
 
 func (s *Server) CreateToken(ctx context.Context, r *account.CreateTokenRequest) (*account.CreateTokenResponse, error) {
@@ -161,6 +264,9 @@ func (s *Server) CreateToken(ctx context.Context, r *account.CreateTokenRequest)
 		if !account.HasCapability(settings.AccountCapabilityApiKey) {
 			return fmt.Errorf("account '%s' does not have %s capability", r.Name, settings.AccountCapabilityApiKey)
 		}
+		if r.TokenName != "" && account.TokenIndexByName(r.TokenName) > -1 {
+			return status.Errorf(codes.AlreadyExists, "account '%s' already has token with name '%s'", r.Name, r.TokenName)
+		}
 
 		var err error
 		uniqueId, err := uuid.NewRandom()
@@ -180,6 +286,7 @@ func (s *Server) CreateToken(ctx context.Context, r *account.CreateTokenRequest)
 		}
 		account.Tokens = append(account.Tokens, settings.Token{
 			ID:        id,
+			Name:      r.TokenName,
 			IssuedAt:  now.Unix(),
 			ExpiresAt: expiresAt,
 		})
@@ -196,8 +303,14 @@ func (s *Server) DeleteToken(ctx context.Context, r *account.DeleteTokenRequest)
 		return nil, err
 	}
 
+	var deleted settings.Token
 	err := s.settingsMgr.UpdateAccount(r.Name, func(account *settings.Account) error {
-		if index := account.TokenIndex(r.Id); index > -1 {
+		index := account.TokenIndex(r.Id)
+		if index == -1 {
+			index = account.TokenIndexByName(r.Id)
+		}
+		if index > -1 {
+			deleted = account.Tokens[index]
 			account.Tokens = append(account.Tokens[:index], account.Tokens[index+1:]...)
 			return nil
 		}
@@ -206,5 +319,59 @@ func (s *Server) DeleteToken(ctx context.Context, r *account.DeleteTokenRequest)
 	if err != nil {
 		return nil, err
 	}
+	// the deleted token is revoked right away, rather than once all API servers noticed the deletion
+	if err := s.sessionMgr.RevokeToken(deleted.ID, deleted.ExpiresAt); err != nil {
+		log.Warnf("Failed to revoke token %s of account %s: %v", deleted.ID, r.Name, err)
+	}
+	return &account.EmptyResponse{}, nil
+}
+
+// ListSessions lists the active login sessions of a local account
+func (s *Server) ListSessions(ctx context.Context, r *account.ListSessionsRequest) (*account.SessionsList, error) {
+	if err := s.ensureHasAccountPermission(ctx, rbacpolicy.ActionGet, r.Name); err != nil {
+		return nil, err
+	}
+	if _, err := s.settingsMgr.GetAccount(r.Name); err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionMgr.GetLoginSessions(r.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions of account %s: %v", r.Name, err)
+	}
+	resp := account.SessionsList{}
+	for _, sess := range sessions {
+		item := &account.Session{Id: sess.ID, IssuedAt: sess.IssuedAt, ExpiresAt: sess.ExpiresAt}
+		if lastUsed := s.sessionMgr.GetTokenLastUsed(sess.ID); lastUsed != nil {
+			item.LastUsedAt = lastUsed.Unix()
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return &resp, nil
+}
+
+// DeleteSession deletes a login session of a local account, which is rejected right away
+func (s *Server) DeleteSession(ctx context.Context, r *account.DeleteSessionRequest) (*account.EmptyResponse, error) {
+	if err := s.ensureHasAccountPermission(ctx, rbacpolicy.ActionUpdate, r.Name); err != nil {
+		return nil, err
+	}
+	if err := s.sessionMgr.DeleteLoginSession(r.Name, r.Id); err != nil {
+		return nil, err
+	}
+	log.Infof("user '%s' deleted session %s of account '%s'", session.Username(ctx), r.Id, r.Name)
+	return &account.EmptyResponse{}, nil
+}
+
+// RevokeSessions revokes all login sessions and tokens issued to the account so far
+func (s *Server) RevokeSessions(ctx context.Context, r *account.RevokeSessionsRequest) (*account.EmptyResponse, error) {
+	if err := s.ensureHasAccountPermission(ctx, rbacpolicy.ActionUpdate, r.Name); err != nil {
+		return nil, err
+	}
+	if _, err := s.settingsMgr.GetAccount(r.Name); err != nil {
+		return nil, err
+	}
+	if err := s.sessionMgr.RevokeSessions(r.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions of account %s: %v", r.Name, err)
+	}
+	log.Infof("Revoked all sessions of account %s", r.Name)
 	return &account.EmptyResponse{}, nil
 }
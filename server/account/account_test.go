@@ -3,6 +3,7 @@ package account
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
@@ -16,7 +17,10 @@ import (
 	"github.com/argoproj/argo-cd/errors"
 	"github.com/argoproj/argo-cd/pkg/apiclient/account"
 	sessionpkg "github.com/argoproj/argo-cd/pkg/apiclient/session"
+	servercache "github.com/argoproj/argo-cd/server/cache"
 	"github.com/argoproj/argo-cd/server/session"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 	"github.com/argoproj/argo-cd/util/password"
 	"github.com/argoproj/argo-cd/util/rbac"
 	sessionutil "github.com/argoproj/argo-cd/util/session"
@@ -65,6 +69,10 @@ func newTestAccountServer(ctx context.Context, opts ...func(cm *v1.ConfigMap, se
 	return NewServer(sessionMgr, settingsMgr, enforcer), session.NewServer(sessionMgr, nil)
 }
 
+func newTestCache() *servercache.Cache {
+	return servercache.NewCache(appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewInMemoryCache(time.Hour)), time.Hour), time.Hour, time.Hour)
+}
+
 func getAdminAccount(mgr *settings.SettingsManager) (*settings.Account, error) {
 	accounts, err := mgr.GetAccounts()
 	if err != nil {
@@ -201,3 +209,148 @@ func TestDeleteToken_SuccessfullyRemoved(t *testing.T) {
 
 	assert.Len(t, acc.Tokens, 0)
 }
+
+func TestRevokeSessions(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, _ := newTestAccountServer(ctx, func(cm *v1.ConfigMap, secret *v1.Secret) {
+		cm.Data["accounts.account1"] = "apiKey"
+	})
+	accountServer.sessionMgr.SetTokenRevocationStore(newTestCache())
+
+	token, err := accountServer.sessionMgr.Create("account1", 0, "")
+	assert.NoError(t, err)
+
+	_, err = accountServer.RevokeSessions(ctx, &account.RevokeSessionsRequest{Name: "account1"})
+	assert.NoError(t, err)
+
+	_, err = accountServer.sessionMgr.VerifyToken(token)
+	assert.Error(t, err)
+
+	_, err = accountServer.RevokeSessions(ctx, &account.RevokeSessionsRequest{Name: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestListSessions_DeleteSession(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, sessionServer := newTestAccountServer(ctx, func(cm *v1.ConfigMap, secret *v1.Secret) {
+		cm.Data["sessions.maxPerAccount"] = "2"
+	})
+	cache := newTestCache()
+	accountServer.sessionMgr.SetTokenUsageStore(cache)
+	accountServer.sessionMgr.SetLoginSessionStore(cache)
+
+	var tokens []string
+	for i := 0; i < 3; i++ {
+		resp, err := sessionServer.Create(ctx, &sessionpkg.SessionCreateRequest{Username: "admin", Password: "oldpassword"})
+		assert.NoError(t, err)
+		tokens = append(tokens, resp.Token)
+	}
+	// the oldest session exceeds the maximum number of sessions
+	_, err := accountServer.sessionMgr.VerifyToken(tokens[0])
+	assert.Error(t, err)
+
+	sessions, err := accountServer.ListSessions(ctx, &account.ListSessionsRequest{Name: "admin"})
+	assert.NoError(t, err)
+	if assert.Len(t, sessions.Items, 2) {
+		// the most recent session is listed first
+		_, err = accountServer.DeleteSession(ctx, &account.DeleteSessionRequest{Name: "admin", Id: sessions.Items[0].Id})
+		assert.NoError(t, err)
+	}
+	_, err = accountServer.sessionMgr.VerifyToken(tokens[1])
+	assert.NoError(t, err)
+	_, err = accountServer.sessionMgr.VerifyToken(tokens[2])
+	assert.Error(t, err)
+
+	_, err = accountServer.DeleteSession(ctx, &account.DeleteSessionRequest{Name: "admin", Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = accountServer.ListSessions(ctx, &account.ListSessionsRequest{Name: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestCreateAccount(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, _ := newTestAccountServer(ctx)
+
+	acc, err := accountServer.CreateAccount(ctx, &account.CreateAccountRequest{Name: "account1", Capabilities: []string{"apiKey"}, Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, &account.Account{Name: "account1", Capabilities: []string{"apiKey"}, Enabled: true}, acc)
+	assert.NoError(t, accountServer.sessionMgr.VerifyUsernamePassword("account1", "password"))
+
+	_, err = accountServer.CreateAccount(ctx, &account.CreateAccountRequest{Name: "account1", Capabilities: []string{"apiKey"}})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = accountServer.CreateAccount(ctx, &account.CreateAccountRequest{Name: "bad.name", Capabilities: []string{"apiKey"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = accountServer.CreateAccount(ctx, &account.CreateAccountRequest{Name: "account2", Capabilities: []string{"unknown"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = accountServer.CreateAccount(ctx, &account.CreateAccountRequest{Name: "account2"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateAccount(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, _ := newTestAccountServer(ctx, func(cm *v1.ConfigMap, secret *v1.Secret) {
+		cm.Data["accounts.account1"] = "apiKey"
+	})
+	token, err := accountServer.CreateToken(ctx, &account.CreateTokenRequest{Name: "account1"})
+	assert.NoError(t, err)
+
+	acc, err := accountServer.UpdateAccount(ctx, &account.UpdateAccountRequest{Name: "account1", Enabled: false, Capabilities: []string{"login", "apiKey"}})
+	assert.NoError(t, err)
+	assert.False(t, acc.Enabled)
+	assert.Equal(t, []string{"login", "apiKey"}, acc.Capabilities)
+
+	// tokens of disabled accounts are rejected
+	_, err = accountServer.sessionMgr.VerifyToken(token.Token)
+	assert.Error(t, err)
+
+	_, err = accountServer.UpdateAccount(ctx, &account.UpdateAccountRequest{Name: "admin", Enabled: true, Capabilities: []string{"apiKey"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = accountServer.UpdateAccount(ctx, &account.UpdateAccountRequest{Name: "unknown", Enabled: true, Capabilities: []string{"apiKey"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeleteAccount(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, _ := newTestAccountServer(ctx, func(cm *v1.ConfigMap, secret *v1.Secret) {
+		cm.Data["accounts.account1"] = "apiKey"
+	})
+	accountServer.sessionMgr.SetTokenRevocationStore(newTestCache())
+
+	_, err := accountServer.DeleteAccount(ctx, &account.DeleteAccountRequest{Name: "account1"})
+	assert.NoError(t, err)
+	_, err = accountServer.GetAccount(ctx, &account.GetAccountRequest{Name: "account1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = accountServer.DeleteAccount(ctx, &account.DeleteAccountRequest{Name: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreateToken_Named(t *testing.T) {
+	ctx := adminContext(context.Background())
+	accountServer, _ := newTestAccountServer(ctx, func(cm *v1.ConfigMap, secret *v1.Secret) {
+		cm.Data["accounts.account1"] = "apiKey"
+	})
+	accountServer.sessionMgr.SetTokenUsageStore(newTestCache())
+
+	token, err := accountServer.CreateToken(ctx, &account.CreateTokenRequest{Name: "account1", TokenName: "ci"})
+	assert.NoError(t, err)
+	_, err = accountServer.CreateToken(ctx, &account.CreateTokenRequest{Name: "account1", TokenName: "ci"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = accountServer.sessionMgr.VerifyToken(token.Token)
+	assert.NoError(t, err)
+
+	acc, err := accountServer.GetAccount(ctx, &account.GetAccountRequest{Name: "account1"})
+	assert.NoError(t, err)
+	if assert.Len(t, acc.Tokens, 1) {
+		assert.Equal(t, "ci", acc.Tokens[0].Name)
+		assert.NotZero(t, acc.Tokens[0].LastUsedAt)
+	}
+
+	// tokens can be deleted by name
+	_, err = accountServer.DeleteToken(ctx, &account.DeleteTokenRequest{Name: "account1", Id: "ci"})
+	assert.NoError(t, err)
+	acc, err = accountServer.GetAccount(ctx, &account.GetAccountRequest{Name: "account1"})
+	assert.NoError(t, err)
+	assert.Len(t, acc.Tokens, 0)
+}
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -24,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -40,12 +44,17 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/argo"
 	argoutil "github.com/argoproj/argo-cd/util/argo"
+	"github.com/argoproj/argo-cd/util/bucket"
 	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/deeplink"
 	"github.com/argoproj/argo-cd/util/diff"
 	"github.com/argoproj/argo-cd/util/git"
+	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/helm"
+	"github.com/argoproj/argo-cd/util/hook"
 	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/lua"
+	"github.com/argoproj/argo-cd/util/oci"
 	"github.com/argoproj/argo-cd/util/rbac"
 	"github.com/argoproj/argo-cd/util/session"
 	"github.com/argoproj/argo-cd/util/settings"
@@ -57,6 +66,7 @@ type Server struct {
 	kubeclientset kubernetes.Interface
 	appclientset  appclientset.Interface
 	appLister     applisters.ApplicationNamespaceLister
+	projLister    applisters.AppProjectNamespaceLister
 	repoClientset apiclient.Clientset
 	kubectl       kube.Kubectl
 	db            db.ArgoDB
@@ -73,6 +83,7 @@ func NewServer(
 	kubeclientset kubernetes.Interface,
 	appclientset appclientset.Interface,
 	appLister applisters.ApplicationNamespaceLister,
+	projLister applisters.AppProjectNamespaceLister,
 	repoClientset apiclient.Clientset,
 	cache *servercache.Cache,
 	kubectl kube.Kubectl,
@@ -86,6 +97,7 @@ func NewServer(
 		ns:            namespace,
 		appclientset:  appclientset,
 		appLister:     appLister,
+		projLister:    projLister,
 		kubeclientset: kubeclientset,
 		cache:         cache,
 		db:            db,
@@ -119,16 +131,32 @@ func (s *Server) List(ctx context.Context, q *application.ApplicationQuery) (*ap
 			newItems = append(newItems, *a)
 		}
 	}
-	newItems = argoutil.FilterByProjects(newItems, q.Projects)
+	newItems = filterApps(newItems, q)
 	sort.Slice(newItems, func(i, j int) bool {
 		return newItems[i].Name < newItems[j].Name
 	})
+	newItems, continueToken, remaining, err := argoutil.Paginate(newItems, q.Limit, q.Continue)
+	if err != nil {
+		return nil, err
+	}
 	appList := appv1.ApplicationList{
 		Items: newItems,
 	}
+	appList.Continue = continueToken
+	if remaining > 0 {
+		appList.RemainingItemCount = &remaining
+	}
 	return &appList, nil
 }
 
+// filterApps applies the server-side selectors of the query which cannot be expressed as a label selector
+func filterApps(apps []appv1.Application, q *application.ApplicationQuery) []appv1.Application {
+	apps = argoutil.FilterByProjects(apps, q.Projects)
+	apps = argoutil.FilterByHealthStatuses(apps, q.HealthStatuses)
+	apps = argoutil.FilterBySyncStatuses(apps, q.SyncStatuses)
+	return argoutil.FilterByDestination(apps, q.DestServer, q.DestNamespace)
+}
+
 // Create creates an application
 func (s *Server) Create(ctx context.Context, q *application.ApplicationCreateRequest) (*appv1.Application, error) {
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionCreate, appRBACName(q.Application)); err != nil {
@@ -187,7 +215,84 @@ func (s *Server) GetManifests(ctx context.Context, q *application.ApplicationMan
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
 		return nil, err
 	}
-	repo, err := s.db.GetRepository(ctx, a.Spec.Source.RepoURL)
+	revision := a.Spec.Source.TargetRevision
+	if q.Revision != "" {
+		revision = q.Revision
+	}
+	source := a.Spec.Source.DeepCopy()
+	if err := setParameterOverrides(source, a.Status.SourceType, q.Parameters); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	manifestInfo, err := s.generateManifests(ctx, a, source, revision)
+	if err != nil {
+		return nil, err
+	}
+	return hideManifestSecretData(manifestInfo)
+}
+
+// setParameterOverrides overrides parameters of a Helm or Ksonnet source, or of a source of the given type if its
+// type is not explicit
+func setParameterOverrides(source *appv1.ApplicationSource, sourceType appv1.ApplicationSourceType, parameters []string) error {
+	if len(parameters) == 0 {
+		return nil
+	}
+	if st, _ := source.ExplicitType(); st != nil {
+		sourceType = *st
+	}
+	switch sourceType {
+	case appv1.ApplicationSourceTypeHelm:
+		if source.Helm == nil {
+			source.Helm = &appv1.ApplicationSourceHelm{}
+		}
+		for _, p := range parameters {
+			param, err := appv1.NewHelmParameter(p, false)
+			if err != nil {
+				return err
+			}
+			source.Helm.AddParameter(*param)
+		}
+	case appv1.ApplicationSourceTypeKsonnet:
+		if source.Ksonnet == nil {
+			source.Ksonnet = &appv1.ApplicationSourceKsonnet{}
+		}
+		for _, p := range parameters {
+			parts := strings.SplitN(p, "=", 3)
+			if len(parts) != 3 {
+				return fmt.Errorf("expected ksonnet parameter of the form: component=param=value, received: %s", p)
+			}
+			param := appv1.KsonnetParameter{Component: parts[0], Name: parts[1], Value: parts[2]}
+			found := false
+			for i, cp := range source.Ksonnet.Parameters {
+				if cp.Component == param.Component && cp.Name == param.Name {
+					source.Ksonnet.Parameters[i] = param
+					found = true
+					break
+				}
+			}
+			if !found {
+				source.Ksonnet.Parameters = append(source.Ksonnet.Parameters, param)
+			}
+		}
+	default:
+		return fmt.Errorf("parameters can only be overridden for Ksonnet or Helm applications")
+	}
+	return nil
+}
+
+// GetManifestsWithFiles returns the manifests generated by the repo server from the uploaded files of the
+// application directory instead of its source repository
+func (s *Server) GetManifestsWithFiles(ctx context.Context, q *application.ApplicationManifestQueryWithFiles) (*apiclient.ManifestResponse, error) {
+	a, err := s.appLister.Get(*q.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	if len(q.Files) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "files are required")
+	}
+	req, err := s.newManifestRequest(ctx, a, &a.Spec.Source, a.Spec.Source.TargetRevision)
 	if err != nil {
 		return nil, err
 	}
@@ -196,9 +301,55 @@ func (s *Server) GetManifests(ctx context.Context, q *application.ApplicationMan
 		return nil, err
 	}
 	defer util.Close(conn)
-	revision := a.Spec.Source.TargetRevision
-	if q.Revision != "" {
-		revision = q.Revision
+	manifestInfo, err := repoClient.GenerateManifestWithFiles(ctx, &apiclient.ManifestRequestWithFiles{Request: req, Files: q.Files})
+	if err != nil {
+		return nil, err
+	}
+	return hideManifestSecretData(manifestInfo)
+}
+
+// hideManifestSecretData replaces the data of the secrets of the manifests, which must not be disclosed
+func hideManifestSecretData(manifestInfo *apiclient.ManifestResponse) (*apiclient.ManifestResponse, error) {
+	for i, manifest := range manifestInfo.Manifests {
+		obj := &unstructured.Unstructured{}
+		err := json.Unmarshal([]byte(manifest), obj)
+		if err != nil {
+			return nil, err
+		}
+		if obj.GetKind() == kube.SecretKind && obj.GroupVersionKind().Group == "" {
+			obj, _, err = diff.HideSecretData(obj, nil)
+			if err != nil {
+				return nil, err
+			}
+			data, err := json.Marshal(obj)
+			if err != nil {
+				return nil, err
+			}
+			manifestInfo.Manifests[i] = string(data)
+		}
+	}
+	return manifestInfo, nil
+}
+
+// generateManifests generates the manifests of the given source of the application at the given revision
+func (s *Server) generateManifests(ctx context.Context, a *appv1.Application, source *appv1.ApplicationSource, revision string) (*apiclient.ManifestResponse, error) {
+	req, err := s.newManifestRequest(ctx, a, source, revision)
+	if err != nil {
+		return nil, err
+	}
+	conn, repoClient, err := s.repoClientset.NewRepoServerClient()
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(conn)
+	return repoClient.GenerateManifest(ctx, req)
+}
+
+// newManifestRequest returns the request to generate the manifests of the given source of the application
+func (s *Server) newManifestRequest(ctx context.Context, a *appv1.Application, source *appv1.ApplicationSource, revision string) (*apiclient.ManifestRequest, error) {
+	repo, err := s.db.GetRepository(ctx, source.RepoURL)
+	if err != nil {
+		return nil, err
 	}
 	appInstanceLabelKey, err := s.settingsMgr.GetAppInstanceLabelKey()
 	if err != nil {
@@ -229,41 +380,23 @@ func (s *Server) GetManifests(ctx context.Context, q *application.ApplicationMan
 	if err != nil {
 		return nil, err
 	}
-	manifestInfo, err := repoClient.GenerateManifest(ctx, &apiclient.ManifestRequest{
+	proj, err := s.projLister.Get(a.Spec.GetProject())
+	if err != nil {
+		return nil, err
+	}
+	return &apiclient.ManifestRequest{
 		Repo:              repo,
 		Revision:          revision,
 		AppLabelKey:       appInstanceLabelKey,
 		AppLabelValue:     a.Name,
 		Namespace:         a.Spec.Destination.Namespace,
-		ApplicationSource: &a.Spec.Source,
+		ApplicationSource: source,
 		Repos:             helmRepos,
 		Plugins:           plugins,
 		KustomizeOptions:  &kustomizeOptions,
 		KubeVersion:       cluster.ServerVersion,
-	})
-	if err != nil {
-		return nil, err
-	}
-	for i, manifest := range manifestInfo.Manifests {
-		obj := &unstructured.Unstructured{}
-		err = json.Unmarshal([]byte(manifest), obj)
-		if err != nil {
-			return nil, err
-		}
-		if obj.GetKind() == kube.SecretKind && obj.GroupVersionKind().Group == "" {
-			obj, _, err = diff.HideSecretData(obj, nil)
-			if err != nil {
-				return nil, err
-			}
-			data, err := json.Marshal(obj)
-			if err != nil {
-				return nil, err
-			}
-			manifestInfo.Manifests[i] = string(data)
-		}
-	}
-
-	return manifestInfo, nil
+		Limits:            proj.Spec.ManifestGenerationLimits,
+	}, nil
 }
 
 // Get returns an application by name
@@ -484,7 +617,7 @@ func (s *Server) Patch(ctx context.Context, q *application.ApplicationPatchReque
 	var patchApp []byte
 
 	switch q.PatchType {
-	case "json", "":
+	case "json", "json6902", "":
 		patch, err := jsonpatch.DecodePatch([]byte(q.Patch))
 		if err != nil {
 			return nil, err
@@ -498,6 +631,11 @@ func (s *Server) Patch(ctx context.Context, q *application.ApplicationPatchReque
 		if err != nil {
 			return nil, err
 		}
+	case "strategic":
+		patchApp, err = strategicpatch.StrategicMergePatch(jsonApp, []byte(q.Patch), appv1.Application{})
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Patch type '%s' is not supported", q.PatchType))
 	}
@@ -575,6 +713,10 @@ func (s *Server) Watch(q *application.ApplicationQuery, ws application.Applicati
 			// do not emit apps user does not have accessing
 			return nil
 		}
+		if len(filterApps([]appv1.Application{a}, q)) == 0 {
+			// do not emit apps which do not match the query selectors
+			return nil
+		}
 		err := ws.Send(&appv1.ApplicationWatchEvent{
 			Type:        eventType,
 			Application: a,
@@ -664,6 +806,16 @@ func (s *Server) validateAndNormalizeApp(ctx context.Context, app *appv1.Applica
 		}
 	}
 
+	if proj.Spec.Quota != nil {
+		apps, err := s.appLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		if err := proj.ValidateApplicationQuota(app, apps); err != nil {
+			return err
+		}
+	}
+
 	buildOptions, err := s.settingsMgr.GetKustomizeBuildOptions()
 	if err != nil {
 		return err
@@ -766,7 +918,7 @@ func (s *Server) getAppResource(ctx context.Context, action string, q *applicati
 }
 
 func (s *Server) GetResource(ctx context.Context, q *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error) {
-	res, config, _, err := s.getAppResource(ctx, rbacpolicy.ActionGet, q)
+	res, config, a, err := s.getAppResource(ctx, rbacpolicy.ActionGet, q)
 	if err != nil {
 		return nil, err
 	}
@@ -782,7 +934,52 @@ func (s *Server) GetResource(ctx context.Context, q *application.ApplicationReso
 	if err != nil {
 		return nil, err
 	}
-	return &application.ApplicationResourceResponse{Manifest: string(data)}, nil
+	links, err := s.getLinks(settings.ResourceDeepLinksKey, map[string]interface{}{deeplink.AppKey: a, deeplink.ResourceKey: obj.Object})
+	if err != nil {
+		return nil, err
+	}
+	return &application.ApplicationResourceResponse{Manifest: string(data), Links: links}, nil
+}
+
+// ListLinks returns the deep links of an application
+func (s *Server) ListLinks(ctx context.Context, q *application.ListAppLinksRequest) (*application.LinksResponse, error) {
+	a, err := s.appLister.Get(*q.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	links, err := s.getLinks(settings.ApplicationDeepLinksKey, map[string]interface{}{deeplink.AppKey: a})
+	if err != nil {
+		return nil, err
+	}
+	return &application.LinksResponse{Items: links}, nil
+}
+
+// getLinks returns the deep links of the given settings key which apply to the objects. The links which fail to be
+// rendered are logged rather than failing the request.
+func (s *Server) getLinks(key string, objects map[string]interface{}) ([]application.LinkInfo, error) {
+	links, err := s.settingsMgr.GetDeepLinks(key)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]application.LinkInfo, 0)
+	if len(links) == 0 {
+		return res, nil
+	}
+	values, err := deeplink.Values(objects)
+	if err != nil {
+		return nil, err
+	}
+	rendered, errs := deeplink.Evaluate(links, values)
+	for _, err := range errs {
+		log.Warnf("Failed to render the deep links of %s: %v", key, err)
+	}
+	for _, link := range rendered {
+		res = append(res, application.LinkInfo{Title: link.Title, Url: link.URL, Description: link.Description, IconClass: link.IconClass})
+	}
+	return res, nil
 }
 
 func replaceSecretValues(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
@@ -926,18 +1123,170 @@ func (s *Server) ManagedResources(ctx context.Context, q *application.ResourcesQ
 	return res, nil
 }
 
-func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.ApplicationService_PodLogsServer) error {
-	pod, config, _, err := s.getAppResource(ws.Context(), rbacpolicy.ActionGet, &application.ApplicationResourceRequest{
-		Name:         q.Name,
-		Namespace:    q.Namespace,
-		Kind:         kube.PodKind,
-		Group:        "",
-		Version:      "v1",
-		ResourceName: *q.PodName,
+// ResourceDiffDetails returns the field by field differences between the target and the live state of the managed
+// resources of an application, and the differences which are ignored because of normalizations
+func (s *Server) ResourceDiffDetails(ctx context.Context, q *application.ResourcesQuery) (*application.ResourceDiffDetailsResponse, error) {
+	a, err := s.appLister.Get(*q.ApplicationName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	managedResources := make([]*appv1.ResourceDiff, 0)
+	err = s.getCachedAppState(ctx, a, func() error {
+		return s.cache.GetAppManagedResources(a.Name, &managedResources)
 	})
-
 	if err != nil {
-		return err
+		return nil, err
+	}
+	overrides, err := s.settingsMgr.GetResourceOverrides()
+	if err != nil {
+		return nil, err
+	}
+	hideGeneratedSecretData, err := s.settingsMgr.GetHideGeneratedSecretData()
+	if err != nil {
+		return nil, err
+	}
+	res := &application.ResourceDiffDetailsResponse{}
+	for _, item := range managedResources {
+		if item.Hook || !isMatchingResource(q, kube.ResourceKey{Name: item.Name, Namespace: item.Namespace, Kind: item.Kind, Group: item.Group}) {
+			continue
+		}
+		target, err := unmarshalResourceState(item.TargetState)
+		if err != nil {
+			return nil, err
+		}
+		live, err := unmarshalResourceState(item.LiveState)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil && live == nil {
+			continue
+		}
+		details, err := argo.GetResourceDiffDetails(target, live, a.Spec.IgnoreDifferences, overrides, hideGeneratedSecretData)
+		if err != nil {
+			return nil, err
+		}
+		details.Group, details.Kind, details.Namespace, details.Name = item.Group, item.Kind, item.Namespace, item.Name
+		res.Items = append(res.Items, details)
+	}
+	return res, nil
+}
+
+// unmarshalResourceState returns the resource of the JSON state of a managed resource, or nil if there is none
+func unmarshalResourceState(state string) (*unstructured.Unstructured, error) {
+	if state == "" || state == "null" {
+		return nil, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal([]byte(state), obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// getLogPods returns the pods of the application tree whose logs are requested: the pods which are
+// (transitive) children of the resource specified by group, kind and name, or all pods of the
+// application if no resource is specified
+func getLogPods(tree *appv1.ApplicationTree, group string, kind string, namespace string, name string) []appv1.ResourceNode {
+	childrenByParent := make(map[kube.ResourceKey][]appv1.ResourceNode)
+	var queue []appv1.ResourceNode
+	for _, n := range tree.Nodes {
+		for _, parent := range n.ParentRefs {
+			key := kube.NewResourceKey(parent.Group, parent.Kind, parent.Namespace, parent.Name)
+			childrenByParent[key] = append(childrenByParent[key], n)
+		}
+		if namespace != "" && n.Namespace != namespace {
+			continue
+		}
+		if kind == "" && name == "" {
+			if n.Group == "" && n.Kind == kube.PodKind {
+				queue = append(queue, n)
+			}
+		} else if n.Group == group && n.Kind == kind && (name == "" || n.Name == name) {
+			queue = append(queue, n)
+		}
+	}
+	var pods []appv1.ResourceNode
+	visited := make(map[kube.ResourceKey]bool)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		key := kube.NewResourceKey(n.Group, n.Kind, n.Namespace, n.Name)
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		if n.Group == "" && n.Kind == kube.PodKind {
+			pods = append(pods, n)
+		}
+		queue = append(queue, childrenByParent[key]...)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods
+}
+
+// PodLogs streams the logs of the specified pod, or of all pods of the specified resource
+func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.ApplicationService_PodLogsServer) error {
+	var filter *regexp.Regexp
+	if q.Filter != "" {
+		var err error
+		filter, err = regexp.Compile(q.Filter)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid filter '%s': %v", q.Filter, err)
+		}
+	}
+	var selector labels.Selector
+	if q.LabelSelector != "" {
+		var err error
+		selector, err = labels.Parse(q.LabelSelector)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid label selector '%s': %v", q.LabelSelector, err)
+		}
+	}
+
+	var pods []appv1.ResourceNode
+	var config *rest.Config
+	if podName := q.GetPodName(); podName != "" {
+		pod, podConfig, _, err := s.getAppResource(ws.Context(), rbacpolicy.ActionLogs, &application.ApplicationResourceRequest{
+			Name:         q.Name,
+			Namespace:    q.Namespace,
+			Kind:         kube.PodKind,
+			Group:        "",
+			Version:      "v1",
+			ResourceName: podName,
+		})
+		if err != nil {
+			return err
+		}
+		pods = []appv1.ResourceNode{*pod}
+		config = podConfig
+	} else {
+		a, err := s.appLister.Get(*q.Name)
+		if err != nil {
+			return err
+		}
+		if err := s.enf.EnforceErr(ws.Context().Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionLogs, appRBACName(*a)); err != nil {
+			return err
+		}
+		tree, err := s.getAppResources(ws.Context(), a)
+		if err != nil {
+			return err
+		}
+		pods = getLogPods(tree, q.Group, q.Kind, q.Namespace, q.ResourceName)
+		if q.Kind != "" && len(pods) == 0 && tree.FindNode(q.Group, q.Kind, q.Namespace, q.ResourceName) == nil {
+			return status.Errorf(codes.InvalidArgument, "%s %s %s not found as part of application %s", q.Kind, q.Group, q.ResourceName, *q.Name)
+		}
+		config, err = s.getApplicationClusterConfig(*q.Name)
+		if err != nil {
+			return err
+		}
 	}
 
 	kubeClientset, err := kubernetes.NewForConfig(config)
@@ -945,6 +1294,31 @@ func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.
 		return err
 	}
 
+	if selector != nil {
+		matching := make(map[kube.ResourceKey]bool)
+		namespaces := make(map[string]bool)
+		for _, pod := range pods {
+			if namespaces[pod.Namespace] {
+				continue
+			}
+			namespaces[pod.Namespace] = true
+			podList, err := kubeClientset.CoreV1().Pods(pod.Namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return err
+			}
+			for _, p := range podList.Items {
+				matching[kube.NewResourceKey("", kube.PodKind, p.Namespace, p.Name)] = true
+			}
+		}
+		var selected []appv1.ResourceNode
+		for _, pod := range pods {
+			if matching[kube.NewResourceKey("", kube.PodKind, pod.Namespace, pod.Name)] {
+				selected = append(selected, pod)
+			}
+		}
+		pods = selected
+	}
+
 	var sinceSeconds, tailLines *int64
 	if q.SinceSeconds > 0 {
 		sinceSeconds = &q.SinceSeconds
@@ -952,59 +1326,90 @@ func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.
 	if q.TailLines > 0 {
 		tailLines = &q.TailLines
 	}
-	stream, err := kubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(*q.PodName, &v1.PodLogOptions{
+	logOptions := v1.PodLogOptions{
 		Container:    q.Container,
 		Follow:       q.Follow,
+		Previous:     q.Previous,
 		Timestamps:   true,
 		SinceSeconds: sinceSeconds,
 		SinceTime:    q.SinceTime,
 		TailLines:    tailLines,
-	}).Stream()
-	if err != nil {
-		return err
 	}
+
 	logCtx := log.WithField("application", q.Name)
-	defer util.Close(stream)
-	done := make(chan bool)
-	gracefulExit := false
-	go func() {
-		scanner := bufio.NewScanner(stream)
-		for scanner.Scan() {
-			line := scanner.Text()
-			parts := strings.Split(line, " ")
-			logTime, err := time.Parse(time.RFC3339, parts[0])
-			metaLogTime := metav1.NewTime(logTime)
-			if err == nil {
-				lines := strings.Join(parts[1:], " ")
-				for _, line := range strings.Split(lines, "\r") {
-					if line != "" {
-						err = ws.Send(&application.LogEntry{
-							Content:   line,
-							TimeStamp: metaLogTime,
-						})
-						if err != nil {
-							logCtx.Warnf("Unable to send stream message: %v", err)
+	// all pod log scanners write to the same channel, so that messages are sent by a single goroutine
+	entries := make(chan *application.LogEntry)
+	closed := make(chan bool)
+	defer close(closed)
+	var streams []io.ReadCloser
+	defer func() {
+		for _, stream := range streams {
+			util.Close(stream)
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := range pods {
+		pod := pods[i]
+		stream, err := kubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &logOptions).Stream()
+		if err != nil {
+			if len(pods) == 1 {
+				return err
+			}
+			logCtx.Warnf("Unable to get logs of pod %s: %v", pod.Name, err)
+			continue
+		}
+		streams = append(streams, stream)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			podLogCtx := logCtx.WithField("pod", pod.Name)
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				line := scanner.Text()
+				parts := strings.Split(line, " ")
+				logTime, err := time.Parse(time.RFC3339, parts[0])
+				metaLogTime := metav1.NewTime(logTime)
+				if err == nil {
+					lines := strings.Join(parts[1:], " ")
+					for _, line := range strings.Split(lines, "\r") {
+						if line == "" || filter != nil && !filter.MatchString(line) {
+							continue
+						}
+						select {
+						case entries <- &application.LogEntry{Content: line, TimeStamp: metaLogTime, PodName: pod.Name}:
+						case <-closed:
+							podLogCtx.Info("k8s pod logs scanner completed due to closed grpc context")
+							return
 						}
 					}
 				}
 			}
-		}
-		if gracefulExit {
-			logCtx.Info("k8s pod logs scanner completed due to closed grpc context")
-		} else if err := scanner.Err(); err != nil {
-			logCtx.Warnf("k8s pod logs scanner failed with error: %v", err)
-		} else {
-			logCtx.Info("k8s pod logs scanner completed with EOF")
-		}
+			if err := scanner.Err(); err != nil {
+				podLogCtx.Warnf("k8s pod logs scanner failed with error: %v", err)
+			} else {
+				podLogCtx.Info("k8s pod logs scanner completed with EOF")
+			}
+		}()
+	}
+	done := make(chan bool)
+	go func() {
+		wg.Wait()
 		close(done)
 	}()
-	select {
-	case <-ws.Context().Done():
-		logCtx.Info("client pod logs grpc context closed")
-		gracefulExit = true
-	case <-done:
+	for {
+		select {
+		case <-ws.Context().Done():
+			logCtx.Info("client pod logs grpc context closed")
+			// closing the log streams unblocks the scanners
+			return nil
+		case entry := <-entries:
+			if err := ws.Send(entry); err != nil {
+				logCtx.Warnf("Unable to send stream message: %v", err)
+			}
+		case <-done:
+			return nil
+		}
 	}
-	return nil
 }
 
 func (s *Server) getApplicationDestination(name string) (string, string, error) {
@@ -1031,10 +1436,14 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 		}
 		return a, err
 	}
+	proj = argo.GetProjectWithGlobalProjects(proj, s.projLister, s.settingsMgr)
 
 	if !proj.Spec.SyncWindows.Matches(a).CanSync(true) {
 		return a, status.Errorf(codes.PermissionDenied, "Cannot sync: Blocked by sync window")
 	}
+	if msg := proj.SyncFreezeMessage(); msg != "" {
+		return a, status.Errorf(codes.FailedPrecondition, "Cannot sync: %s", msg)
+	}
 
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionSync, appRBACName(*a)); err != nil {
 		return nil, err
@@ -1073,7 +1482,8 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 			Resources:    syncReq.Resources,
 			Manifests:    syncReq.Manifests,
 		},
-		InitiatedBy: appv1.OperationInitiator{Username: session.Username(ctx)},
+		InitiatedBy:   appv1.OperationInitiator{Username: session.Username(ctx)},
+		CorrelationID: grpc_util.CorrelationID(ctx),
 	}
 	a, err = argo.SetAppOperation(appIf, *syncReq.Name, &op)
 	if err == nil {
@@ -1101,21 +1511,17 @@ func (s *Server) Rollback(ctx context.Context, rollbackReq *application.Applicat
 	if a.Spec.SyncPolicy != nil && a.Spec.SyncPolicy.Automated != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "rollback cannot be initiated when auto-sync is enabled")
 	}
-
-	var deploymentInfo *appv1.RevisionHistory
-	for _, info := range a.Status.History {
-		if info.ID == rollbackReq.ID {
-			deploymentInfo = &info
-			break
-		}
+	proj, err := s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Get(a.Spec.GetProject(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
 	}
-	if deploymentInfo == nil {
-		return nil, status.Errorf(codes.InvalidArgument, "application %s does not have deployment with id %v", a.Name, rollbackReq.ID)
+	if msg := proj.SyncFreezeMessage(); msg != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "Cannot rollback: %s", msg)
 	}
-	if deploymentInfo.Source.IsZero() {
-		// Since source type was introduced to history starting with v0.12, and is now required for
-		// rollback, we cannot support rollback to revisions deployed using Argo CD v0.11 or below
-		return nil, status.Errorf(codes.FailedPrecondition, "cannot rollback to revision deployed with Argo CD v0.11 or lower. sync to revision instead.")
+
+	deploymentInfo, err := getRollbackHistory(a, rollbackReq.ID)
+	if err != nil {
+		return nil, err
 	}
 
 	var syncOptions appv1.SyncOptions
@@ -1133,6 +1539,7 @@ func (s *Server) Rollback(ctx context.Context, rollbackReq *application.Applicat
 			SyncStrategy: &appv1.SyncStrategy{Apply: &appv1.SyncStrategyApply{}},
 			Source:       &deploymentInfo.Source,
 		},
+		CorrelationID: grpc_util.CorrelationID(ctx),
 	}
 	a, err = argo.SetAppOperation(appIf, *rollbackReq.Name, &op)
 	if err == nil {
@@ -1141,6 +1548,268 @@ func (s *Server) Rollback(ctx context.Context, rollbackReq *application.Applicat
 	return a, err
 }
 
+// WriteBack commits parameter overrides to the .argocd-source file of the application in the branch it tracks, so that
+// tools like image updaters can update an application without changing its spec
+func (s *Server) WriteBack(ctx context.Context, q *application.ApplicationWriteBackRequest) (*application.ApplicationWriteBackResponse, error) {
+	a, err := s.appLister.Get(*q.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionUpdate, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	if a.Spec.Source.IsHelm() || a.Spec.Source.IsOCI() || a.Spec.Source.IsBucket() {
+		return nil, status.Errorf(codes.FailedPrecondition, "parameters of applications of helm or OCI repositories or of buckets cannot be written back")
+	}
+	if len(q.HelmParameters) == 0 && len(q.KustomizeImages) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "helm parameters or kustomize images are required")
+	}
+	overrides := appv1.ApplicationSource{}
+	if len(q.HelmParameters) > 0 {
+		overrides.Helm = &appv1.ApplicationSourceHelm{Parameters: q.HelmParameters}
+	}
+	if len(q.KustomizeImages) > 0 {
+		overrides.Kustomize = &appv1.ApplicationSourceKustomize{}
+		for _, image := range q.KustomizeImages {
+			overrides.Kustomize.Images = append(overrides.Kustomize.Images, appv1.KustomizeImage(image))
+		}
+	}
+	repo, err := s.db.GetRepository(ctx, a.Spec.Source.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	conn, repoClient, err := s.repoClientset.NewRepoServerClient()
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(conn)
+	res, err := repoClient.WriteBack(ctx, &apiclient.WriteBackRequest{
+		Repo:       repo,
+		Branch:     a.Spec.Source.TargetRevision,
+		Path:       a.Spec.Source.Path,
+		AppName:    a.Name,
+		Overrides:  &overrides,
+		Message:    q.Message,
+		AuthorName: session.Username(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Changed {
+		s.logAppEvent(a, ctx, argo.EventReasonResourceUpdated, fmt.Sprintf("wrote back parameters in revision %s", res.Revision))
+		if _, err := argoutil.RefreshApp(s.appclientset.ArgoprojV1alpha1().Applications(s.ns), a.Name, appv1.RefreshTypeNormal); err != nil {
+			log.Warnf("Failed to refresh application %s: %v", a.Name, err)
+		}
+	}
+	return &application.ApplicationWriteBackResponse{Revision: res.Revision, Changed: res.Changed}, nil
+}
+
+// getRevisionHistory returns the history entry of the application with the given id, with its source decompressed
+func getRevisionHistory(a *appv1.Application, id int64) (*appv1.RevisionHistory, error) {
+	for _, h := range a.Status.History {
+		if h.ID == id {
+			source, err := h.FullSource()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to decompress source of deployment %v: %v", id, err)
+			}
+			h.Source = source
+			h.CompressedSource = nil
+			return &h, nil
+		}
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "application %s does not have deployment with id %v", a.Name, id)
+}
+
+// getRollbackHistory returns the history entry of the application which a rollback to the given id deploys
+func getRollbackHistory(a *appv1.Application, id int64) (*appv1.RevisionHistory, error) {
+	deploymentInfo, err := getRevisionHistory(a, id)
+	if err != nil {
+		return nil, err
+	}
+	if deploymentInfo.Source.IsZero() {
+		// Since source type was introduced to history starting with v0.12, and is now required for
+		// rollback, we cannot support rollback to revisions deployed using Argo CD v0.11 or below
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot rollback to revision deployed with Argo CD v0.11 or lower. sync to revision instead.")
+	}
+	return deploymentInfo, nil
+}
+
+// RevisionHistory returns a deployment history entry of an application, including its full source
+func (s *Server) RevisionHistory(ctx context.Context, q *application.RevisionHistoryQuery) (*appv1.RevisionHistory, error) {
+	a, err := s.appLister.Get(q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	return getRevisionHistory(a, q.GetId())
+}
+
+// SyncTimeline returns the timeline of the resources and hooks of the last sync operation of an application
+func (s *Server) SyncTimeline(ctx context.Context, q *application.SyncTimelineQuery) (*appv1.SyncTimeline, error) {
+	a, err := s.appLister.Get(q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	if a.Status.OperationState == nil || a.Status.OperationState.Operation.Sync == nil {
+		return nil, status.Errorf(codes.NotFound, "application %s has not been synced", a.Name)
+	}
+	return a.Status.OperationState.GetSyncTimeline(), nil
+}
+
+// RollbackPreview returns the differences between the live state of the application and the
+// manifests of a history entry, i.e. the changes a rollback to the history entry makes
+func (s *Server) RollbackPreview(ctx context.Context, q *application.ApplicationRollbackRequest) (*application.ManagedResourcesResponse, error) {
+	a, err := s.appLister.Get(*q.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	deploymentInfo, err := getRollbackHistory(a, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	manifestInfo, err := s.generateManifests(ctx, a, &deploymentInfo.Source, deploymentInfo.Revision)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]*unstructured.Unstructured, len(manifestInfo.Manifests))
+	for i, manifest := range manifestInfo.Manifests {
+		targets[i] = &unstructured.Unstructured{}
+		if err := json.Unmarshal([]byte(manifest), targets[i]); err != nil {
+			return nil, err
+		}
+	}
+	managedResources := make([]*appv1.ResourceDiff, 0)
+	err = s.getCachedAppState(ctx, a, func() error {
+		return s.cache.GetAppManagedResources(a.Name, &managedResources)
+	})
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.settingsMgr.GetResourceOverrides()
+	if err != nil {
+		return nil, err
+	}
+	normalizer, err := argo.NewDiffNormalizer(a.Spec.IgnoreDifferences, overrides)
+	if err != nil {
+		return nil, err
+	}
+	items, err := rollbackPreviewDiffs(targets, managedResources, a.Spec.Destination.Namespace, q.Prune, normalizer)
+	if err != nil {
+		return nil, err
+	}
+	return &application.ManagedResourcesResponse{Items: items}, nil
+}
+
+// rollbackPreviewDiffs compares the target manifests with the live state of the managed resources and
+// returns the resources which differ. Live resources without manifest are only returned if they are pruned.
+func rollbackPreviewDiffs(targets []*unstructured.Unstructured, managedResources []*appv1.ResourceDiff, namespace string, prune bool, normalizer diff.Normalizer) ([]*appv1.ResourceDiff, error) {
+	lives := make(map[kube.ResourceKey]*appv1.ResourceDiff)
+	for i := range managedResources {
+		res := managedResources[i]
+		if res.Hook || res.LiveState == "" || res.LiveState == "null" {
+			continue
+		}
+		lives[kube.NewResourceKey(res.Group, res.Kind, res.Namespace, res.Name)] = res
+	}
+
+	var items []*appv1.ResourceDiff
+	addItem := func(key kube.ResourceKey, target *unstructured.Unstructured, live *unstructured.Unstructured) error {
+		// live secrets are cached with hidden data, so their data cannot be compared with the target manifests
+		if key.Kind == kube.SecretKind && key.Group == "" && target != nil && live != nil {
+			return nil
+		}
+		res, err := diff.Diff(target, live, normalizer)
+		if err != nil {
+			return err
+		}
+		if !res.Modified && target != nil && live != nil {
+			return nil
+		}
+		item := &appv1.ResourceDiff{
+			Group:               key.Group,
+			Kind:                key.Kind,
+			Namespace:           key.Namespace,
+			Name:                key.Name,
+			PredictedLiveState:  string(res.PredictedLive),
+			NormalizedLiveState: string(res.NormalizedLive),
+		}
+		if target != nil && key.Kind == kube.SecretKind && key.Group == "" {
+			target, _, err = diff.HideSecretData(target, nil)
+			if err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(target)
+		if err != nil {
+			return err
+		}
+		item.TargetState = string(data)
+		data, err = json.Marshal(live)
+		if err != nil {
+			return err
+		}
+		item.LiveState = string(data)
+		item.Diff, err = res.JSONFormat()
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	}
+
+	for _, target := range targets {
+		if hook.IsHook(target) {
+			continue
+		}
+		key := kube.GetResourceKey(target)
+		live, ok := lives[key]
+		if !ok && key.Namespace == "" {
+			// namespaced resources without namespace are deployed to the destination namespace
+			key.Namespace = namespace
+			live, ok = lives[key]
+			if !ok {
+				key.Namespace = ""
+			}
+		}
+		var liveObj *unstructured.Unstructured
+		if ok {
+			delete(lives, key)
+			liveObj = &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(live.LiveState), liveObj); err != nil {
+				return nil, err
+			}
+		}
+		if err := addItem(key, target, liveObj); err != nil {
+			return nil, err
+		}
+	}
+	if prune {
+		for key, live := range lives {
+			liveObj := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(live.LiveState), liveObj); err != nil {
+				return nil, err
+			}
+			if err := addItem(key, nil, liveObj); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		keyI := kube.NewResourceKey(items[i].Group, items[i].Kind, items[i].Namespace, items[i].Name)
+		keyJ := kube.NewResourceKey(items[j].Group, items[j].Kind, items[j].Namespace, items[j].Name)
+		return keyI.String() < keyJ.String()
+	})
+	return items, nil
+}
+
 // resolveRevision resolves the revision specified either in the sync request, or the
 // application source, into a concrete revision that will be used for a sync operation.
 func (s *Server) resolveRevision(ctx context.Context, app *appv1.Application, syncReq *application.ApplicationSyncRequest) (string, string, error) {
@@ -1175,6 +1844,33 @@ func (s *Server) resolveRevision(ctx context.Context, app *appv1.Application, sy
 			return "", "", err
 		}
 		return version.String(), fmt.Sprintf("%v (%v)", ambiguousRevision, version.String()), nil
+	} else if app.Spec.Source.IsOCI() {
+		if oci.IsDigest(ambiguousRevision) {
+			return ambiguousRevision, ambiguousRevision, nil
+		}
+		repo, err := s.db.GetRepository(ctx, app.Spec.Source.RepoURL)
+		if err != nil {
+			return "", "", err
+		}
+		revision, err = oci.NewClient(repo.Repo, repo.GetOCICreds()).ResolveDigest(ambiguousRevision)
+		if err != nil {
+			return "", "", err
+		}
+		return revision, fmt.Sprintf("%s (%s)", util.FirstNonEmpty(ambiguousRevision, oci.DefaultTag), revision), nil
+	} else if app.Spec.Source.IsBucket() {
+		repo, err := s.db.GetRepository(ctx, app.Spec.Source.RepoURL)
+		if err != nil {
+			return "", "", err
+		}
+		client, err := bucket.NewClient(repo.Repo, repo.GetBucketCreds())
+		if err != nil {
+			return "", "", err
+		}
+		revision, err = client.ResolveRevision(ambiguousRevision)
+		if err != nil {
+			return "", "", err
+		}
+		return revision, fmt.Sprintf("%s (%s)", util.FirstNonEmpty(ambiguousRevision, "HEAD"), revision), nil
 	} else {
 		if git.IsCommitSHA(ambiguousRevision) {
 			// If it's already a commit SHA, then no need to look it up
@@ -1204,6 +1900,9 @@ func (s *Server) TerminateOperation(ctx context.Context, termOpReq *application.
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionSync, appRBACName(*a)); err != nil {
 		return nil, err
 	}
+	if termOpReq.Force {
+		return s.forceTerminateOperation(ctx, a)
+	}
 
 	for i := 0; i < 10; i++ {
 		if a.Operation == nil || a.Status.OperationState == nil {
@@ -1229,6 +1928,57 @@ func (s *Server) TerminateOperation(ctx context.Context, termOpReq *application.
 	return nil, status.Errorf(codes.Internal, "Failed to terminate app. Too many conflicts")
 }
 
+// forceTerminateOperation terminates the operation of the application without the help of the controller, which is
+// needed when an operation is stuck, e.g. because the controller crashed while running it. The hook resources created by
+// the operation are deleted, and the operation is marked as failed.
+func (s *Server) forceTerminateOperation(ctx context.Context, a *appv1.Application) (*application.OperationTerminateResponse, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionDelete, appRBACName(*a)); err != nil {
+		return nil, err
+	}
+	state := a.Status.OperationState
+	if a.Operation == nil && (state == nil || state.Phase.Completed()) {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to terminate operation. No operation is in progress")
+	}
+
+	deletedHooks := 0
+	if state != nil && state.SyncResult != nil {
+		config, err := s.getApplicationClusterConfig(a.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range state.SyncResult.Resources {
+			if res.HookType == "" {
+				continue
+			}
+			err := s.kubectl.DeleteResource(config, res.GroupVersionKind(), res.Name, res.Namespace, true)
+			if err != nil && !apierr.IsNotFound(err) {
+				return nil, status.Errorf(codes.Internal, "failed to delete hook %s %s/%s: %v", res.Kind, res.Namespace, res.Name, err)
+			}
+			deletedHooks++
+		}
+	}
+
+	patch := map[string]interface{}{"operation": nil}
+	if state != nil && !state.Phase.Completed() {
+		state = state.DeepCopy()
+		now := metav1.Now()
+		state.Phase = appv1.OperationFailed
+		state.Message = fmt.Sprintf("operation was force terminated by %s", session.Username(ctx))
+		state.FinishedAt = &now
+		patch["status"] = map[string]interface{}{"operationState": state}
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Patch(a.Name, types.MergePatchType, patchJSON)
+	if err != nil {
+		return nil, err
+	}
+	s.logAppEvent(a, ctx, argo.EventReasonResourceUpdated, fmt.Sprintf("force terminated running operation, deleting %d hooks", deletedHooks))
+	return &application.OperationTerminateResponse{}, nil
+}
+
 func (s *Server) logAppEvent(a *appv1.Application, ctx context.Context, reason string, action string) {
 	eventInfo := argo.EventInfo{Type: v1.EventTypeNormal, Reason: reason}
 	user := session.Username(ctx)
@@ -1349,10 +2099,32 @@ func (s *Server) RunResourceAction(ctx context.Context, q *application.ResourceA
 		return &application.ApplicationResponse{}, nil
 	}
 
-	_, err = s.kubectl.PatchResource(config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, diffBytes)
-	if err != nil {
+	// changes to the status, e.g. to abort a Rollout, are patched separately since the status is usually a subresource
+	var patch map[string]interface{}
+	if err := json.Unmarshal(diffBytes, &patch); err != nil {
 		return nil, err
 	}
+	statusPatch, hasStatusPatch := patch["status"]
+	delete(patch, "status")
+	if len(patch) > 0 {
+		patchBytes, err := json.Marshal(patch)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.kubectl.PatchResource(config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, patchBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if hasStatusPatch {
+		patchBytes, err := json.Marshal(map[string]interface{}{"status": statusPatch})
+		if err != nil {
+			return nil, err
+		}
+		if err := kube.PatchStatus(s.kubectl, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), patchBytes); err != nil {
+			return nil, err
+		}
+	}
 	return &application.ApplicationResponse{}, nil
 }
 
@@ -1388,6 +2160,7 @@ func (s *Server) GetApplicationSyncWindows(ctx context.Context, q *application.A
 	if err != nil {
 		return nil, err
 	}
+	proj = argo.GetProjectWithGlobalProjects(proj, s.projLister, s.settingsMgr)
 
 	windows := proj.Spec.SyncWindows.Matches(a)
 	sync := windows.CanSync(true)
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	coreerrors "errors"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
@@ -32,9 +34,11 @@ import (
 	"github.com/argoproj/argo-cd/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/test"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/argo"
 	"github.com/argoproj/argo-cd/util/assets"
 	"github.com/argoproj/argo-cd/util/cache"
 	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/kube"
 	"github.com/argoproj/argo-cd/util/kube/kubetest"
 	"github.com/argoproj/argo-cd/util/rbac"
 	"github.com/argoproj/argo-cd/util/settings"
@@ -103,7 +107,11 @@ func newTestAppServer(objects ...runtime.Object) *Server {
 	mockRepoServiceClient := mocks.RepoServerServiceClient{}
 	mockRepoServiceClient.On("ListApps", mock.Anything, mock.Anything).Return(fakeAppList(), nil)
 	mockRepoServiceClient.On("GenerateManifest", mock.Anything, mock.Anything).Return(&apiclient.ManifestResponse{}, nil)
+	mockRepoServiceClient.On("GenerateManifestWithFiles", mock.Anything, mock.Anything).Return(&apiclient.ManifestResponse{
+		Manifests: []string{`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"my-secret"},"data":{"password":"c2VjcmV0"}}`},
+	}, nil)
 	mockRepoServiceClient.On("GetAppDetails", mock.Anything, mock.Anything).Return(&apiclient.RepoAppDetailsResponse{}, nil)
+	mockRepoServiceClient.On("WriteBack", mock.Anything, mock.Anything).Return(&apiclient.WriteBackResponse{Revision: "abc", Changed: true}, nil)
 
 	mockRepoClient := &mockrepo.Clientset{}
 	mockRepoClient.On("NewRepoServerClient").Return(&fakeCloser{}, &mockRepoServiceClient, nil)
@@ -156,7 +164,9 @@ func newTestAppServer(objects ...runtime.Object) *Server {
 	// TODO(jessesuen): probably should return cancel function so tests can stop background informer
 	//ctx, cancel := context.WithCancel(context.Background())
 	go appInformer.Run(ctx.Done())
-	if !k8scache.WaitForCacheSync(ctx.Done(), appInformer.HasSynced) {
+	projInformer := factory.Argoproj().V1alpha1().AppProjects().Informer()
+	go projInformer.Run(ctx.Done())
+	if !k8scache.WaitForCacheSync(ctx.Done(), appInformer.HasSynced, projInformer.HasSynced) {
 		panic("Timed out waiting forfff caches to sync")
 	}
 
@@ -165,6 +175,7 @@ func newTestAppServer(objects ...runtime.Object) *Server {
 		kubeclientset,
 		fakeAppsClientset,
 		factory.Argoproj().V1alpha1().Applications().Lister().Applications(testNamespace),
+		fakeProjLister,
 		mockRepoClient,
 		nil,
 		&kubetest.MockKubectlCmd{},
@@ -224,6 +235,73 @@ func TestListApps(t *testing.T) {
 	assert.Equal(t, []string{"abc", "bcd", "def"}, names)
 }
 
+func TestListAppsWithFilters(t *testing.T) {
+	appServer := newTestAppServer(newTestApp(func(app *appsv1.Application) {
+		app.Name = "abc"
+		app.Status.Health.Status = "Healthy"
+		app.Status.Sync.Status = appsv1.SyncStatusCodeSynced
+	}), newTestApp(func(app *appsv1.Application) {
+		app.Name = "bcd"
+		app.Status.Health.Status = "Degraded"
+		app.Status.Sync.Status = appsv1.SyncStatusCodeOutOfSync
+	}), newTestApp(func(app *appsv1.Application) {
+		app.Name = "def"
+		app.Status.Health.Status = "Degraded"
+		app.Status.Sync.Status = appsv1.SyncStatusCodeSynced
+		app.Spec.Destination.Namespace = "other"
+	}))
+
+	names := func(res *appsv1.ApplicationList) []string {
+		var names []string
+		for i := range res.Items {
+			names = append(names, res.Items[i].Name)
+		}
+		return names
+	}
+
+	res, err := appServer.List(context.Background(), &application.ApplicationQuery{HealthStatuses: []string{"degraded"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bcd", "def"}, names(res))
+
+	res, err = appServer.List(context.Background(), &application.ApplicationQuery{SyncStatuses: []string{"Synced"}, HealthStatuses: []string{"Degraded"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"def"}, names(res))
+
+	res, err = appServer.List(context.Background(), &application.ApplicationQuery{DestNamespace: "other"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"def"}, names(res))
+}
+
+func TestListAppsPagination(t *testing.T) {
+	appServer := newTestAppServer(newTestApp(func(app *appsv1.Application) {
+		app.Name = "bcd"
+	}), newTestApp(func(app *appsv1.Application) {
+		app.Name = "abc"
+	}), newTestApp(func(app *appsv1.Application) {
+		app.Name = "def"
+	}))
+
+	res, err := appServer.List(context.Background(), &application.ApplicationQuery{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 2)
+	assert.Equal(t, "abc", res.Items[0].Name)
+	assert.Equal(t, "bcd", res.Items[1].Name)
+	assert.NotEmpty(t, res.Continue)
+	if assert.NotNil(t, res.RemainingItemCount) {
+		assert.Equal(t, int64(1), *res.RemainingItemCount)
+	}
+
+	res, err = appServer.List(context.Background(), &application.ApplicationQuery{Limit: 2, Continue: res.Continue})
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 1)
+	assert.Equal(t, "def", res.Items[0].Name)
+	assert.Empty(t, res.Continue)
+	assert.Nil(t, res.RemainingItemCount)
+
+	_, err = appServer.List(context.Background(), &application.ApplicationQuery{Continue: "&&&"})
+	assert.Error(t, err)
+}
+
 func TestCreateApp(t *testing.T) {
 	testApp := newTestApp()
 	appServer := newTestAppServer()
@@ -349,6 +427,35 @@ func TestSyncAndTerminate(t *testing.T) {
 	assert.Equal(t, appsv1.OperationTerminating, app.Status.OperationState.Phase)
 }
 
+func TestForceTerminateOperation(t *testing.T) {
+	ctx := context.Background()
+	testApp := newTestApp()
+	testApp.Operation = &appsv1.Operation{Sync: &appsv1.SyncOperation{Revision: "abc"}}
+	testApp.Status.OperationState = &appsv1.OperationState{
+		Operation: *testApp.Operation,
+		Phase:     appsv1.OperationRunning,
+		StartedAt: metav1.NewTime(time.Now()),
+		SyncResult: &appsv1.SyncOperationResult{Resources: appsv1.ResourceResults{
+			{Group: "batch", Version: "v1", Kind: "Job", Namespace: "default", Name: "pre-sync", HookType: appsv1.HookTypePreSync},
+			{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "guestbook"},
+		}},
+	}
+	appServer := newTestAppServer(testApp)
+
+	_, err := appServer.TerminateOperation(ctx, &application.OperationTerminateRequest{Name: &testApp.Name, Force: true})
+	assert.NoError(t, err)
+
+	app, err := appServer.appclientset.ArgoprojV1alpha1().Applications(appServer.ns).Get(testApp.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, app.Operation)
+	assert.Equal(t, appsv1.OperationFailed, app.Status.OperationState.Phase)
+	assert.NotNil(t, app.Status.OperationState.FinishedAt)
+	assert.Contains(t, app.Status.OperationState.Message, "force terminated")
+
+	_, err = appServer.TerminateOperation(ctx, &application.OperationTerminateRequest{Name: &testApp.Name, Force: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestSyncHelm(t *testing.T) {
 	ctx := context.Background()
 	appServer := newTestAppServer()
@@ -393,6 +500,120 @@ func TestRollbackApp(t *testing.T) {
 	assert.Equal(t, "abc", updatedApp.Operation.Sync.Revision)
 }
 
+func TestWriteBack(t *testing.T) {
+	testApp := newTestApp()
+	testApp.Spec.Source.TargetRevision = "master"
+	appServer := newTestAppServer(testApp)
+
+	res, err := appServer.WriteBack(context.Background(), &application.ApplicationWriteBackRequest{
+		Name:           &testApp.Name,
+		HelmParameters: []appsv1.HelmParameter{{Name: "image.tag", Value: "v2"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", res.Revision)
+	events, err := appServer.kubeclientset.CoreV1().Events(appServer.ns).List(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Unknown user wrote back parameters in revision abc", events.Items[0].Message)
+
+	_, err = appServer.WriteBack(context.Background(), &application.ApplicationWriteBackRequest{Name: &testApp.Name})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRollbackCompressedHistory(t *testing.T) {
+	testApp := newTestApp()
+	source := *testApp.Spec.Source.DeepCopy()
+	source.Helm = &appsv1.ApplicationSourceHelm{Values: "replicaCount: 2"}
+	history := appsv1.RevisionHistory{ID: 1, Revision: "abc", Source: *source.DeepCopy()}
+	assert.NoError(t, history.CompressSource())
+	testApp.Status.History = []appsv1.RevisionHistory{history}
+	appServer := newTestAppServer(testApp)
+
+	id := int64(1)
+	entry, err := appServer.RevisionHistory(context.Background(), &application.RevisionHistoryQuery{Name: &testApp.Name, Id: &id})
+	assert.NoError(t, err)
+	assert.Equal(t, source, entry.Source)
+	assert.Nil(t, entry.CompressedSource)
+
+	id = 2
+	_, err = appServer.RevisionHistory(context.Background(), &application.RevisionHistoryQuery{Name: &testApp.Name, Id: &id})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updatedApp, err := appServer.Rollback(context.Background(), &application.ApplicationRollbackRequest{
+		Name: &testApp.Name,
+		ID:   1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, source, *updatedApp.Operation.Sync.Source)
+}
+
+func TestGetManifests_ParameterOverrides(t *testing.T) {
+	testApp := newTestApp()
+	appServer := newTestAppServer(testApp)
+
+	_, err := appServer.GetManifests(context.Background(), &application.ApplicationManifestQuery{Name: &testApp.Name, Revision: "feature", Parameters: []string{"image.tag=v2"}})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = expected ksonnet parameter of the form: component=param=value, received: image.tag=v2")
+
+	_, err = appServer.GetManifests(context.Background(), &application.ApplicationManifestQuery{Name: &testApp.Name, Revision: "feature", Parameters: []string{"guestbook=replicas=2"}})
+	assert.NoError(t, err)
+	app, err := appServer.appclientset.ArgoprojV1alpha1().Applications(testNamespace).Get(testApp.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, app.Spec.Source.Ksonnet.Parameters)
+}
+
+func TestSetParameterOverrides(t *testing.T) {
+	source := &appsv1.ApplicationSource{Helm: &appsv1.ApplicationSourceHelm{Parameters: []appsv1.HelmParameter{{Name: "a", Value: "1"}}}}
+	assert.NoError(t, setParameterOverrides(source, "", []string{"a=2", "b=3"}))
+	assert.Equal(t, []appsv1.HelmParameter{{Name: "a", Value: "2"}, {Name: "b", Value: "3"}}, source.Helm.Parameters)
+	assert.Error(t, setParameterOverrides(source, "", []string{"a"}))
+
+	source = &appsv1.ApplicationSource{}
+	assert.NoError(t, setParameterOverrides(source, appsv1.ApplicationSourceTypeKsonnet, []string{"guestbook=replicas=2"}))
+	assert.Equal(t, []appsv1.KsonnetParameter{{Component: "guestbook", Name: "replicas", Value: "2"}}, source.Ksonnet.Parameters)
+	assert.Error(t, setParameterOverrides(source, appsv1.ApplicationSourceTypeKsonnet, []string{"replicas=2"}))
+
+	assert.NoError(t, setParameterOverrides(&appsv1.ApplicationSource{}, "", nil))
+	assert.Error(t, setParameterOverrides(&appsv1.ApplicationSource{}, appsv1.ApplicationSourceTypeKustomize, []string{"a=1"}))
+}
+
+func TestGetManifestsWithFiles(t *testing.T) {
+	testApp := newTestApp()
+	appServer := newTestAppServer(testApp)
+
+	_, err := appServer.GetManifestsWithFiles(context.Background(), &application.ApplicationManifestQueryWithFiles{Name: &testApp.Name})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = files are required")
+
+	res, err := appServer.GetManifestsWithFiles(context.Background(), &application.ApplicationManifestQueryWithFiles{Name: &testApp.Name, Files: []byte("files")})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Manifests, 1) {
+		assert.NotContains(t, res.Manifests[0], "c2VjcmV0")
+	}
+}
+
+func TestRollbackFrozenProject(t *testing.T) {
+	frozenProj := &appsv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "frozen", Namespace: "default"},
+		Spec: appsv1.AppProjectSpec{
+			SourceRepos:  []string{"*"},
+			Destinations: []appsv1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+			SyncFreeze:   &appsv1.SyncFreeze{Reason: "incident"},
+		},
+	}
+	testApp := newTestApp()
+	testApp.Spec.Project = frozenProj.Name
+	testApp.Status.History = []appsv1.RevisionHistory{{
+		ID:       1,
+		Revision: "abc",
+		Source:   *testApp.Spec.Source.DeepCopy(),
+	}}
+	appServer := newTestAppServer(testApp, frozenProj)
+
+	_, err := appServer.Rollback(context.Background(), &application.ApplicationRollbackRequest{
+		Name: &testApp.Name,
+		ID:   1,
+	})
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = Cannot rollback: Syncs of project 'frozen' are frozen: incident")
+}
+
 func TestUpdateAppProject(t *testing.T) {
 	testApp := newTestApp()
 	ctx := context.Background()
@@ -478,6 +699,23 @@ func TestAppMergePatch(t *testing.T) {
 	assert.Equal(t, "foo", app.Spec.Source.Path)
 }
 
+func TestAppStrategicMergePatch(t *testing.T) {
+	testApp := newTestApp()
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, "claims", &jwt.StandardClaims{Subject: "admin"})
+	appServer := newTestAppServer(testApp)
+	appServer.enf.SetDefaultRole("")
+
+	app, err := appServer.Patch(ctx, &application.ApplicationPatchRequest{
+		Name: &testApp.Name, Patch: `{"spec": { "source": { "path": "foo" } }}`, PatchType: "strategic"})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", app.Spec.Source.Path)
+	assert.Equal(t, testApp.Spec.Source.RepoURL, app.Spec.Source.RepoURL)
+
+	_, err = appServer.Patch(ctx, &application.ApplicationPatchRequest{Name: &testApp.Name, Patch: `{}`, PatchType: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestServer_GetApplicationSyncWindowsState(t *testing.T) {
 	t.Run("Active", func(t *testing.T) {
 		testApp := newTestApp()
@@ -508,6 +746,34 @@ func TestServer_GetApplicationSyncWindowsState(t *testing.T) {
 	})
 }
 
+func TestListLinks(t *testing.T) {
+	testApp := newTestApp()
+	appServer := newTestAppServer(testApp)
+	appServer.settingsMgr = settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "argocd-cm",
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of": "argocd",
+			},
+		},
+		Data: map[string]string{"application.links": `
+- title: Dashboard
+  url: https://grafana.example.com/d/apps?var-namespace={{.app.spec.destination.namespace}}
+- title: Runbook
+  url: https://runbooks.example.com
+  if: app.spec.project == 'other'
+`},
+	}, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"server.secretkey": []byte("test")},
+	}), testNamespace)
+
+	links, err := appServer.ListLinks(context.Background(), &application.ListAppLinksRequest{Name: &testApp.Name})
+	assert.NoError(t, err)
+	assert.Equal(t, []application.LinkInfo{{Title: "Dashboard", Url: "https://grafana.example.com/d/apps?var-namespace=" + test.FakeDestNamespace}}, links.Items)
+}
+
 func TestGetCachedAppState(t *testing.T) {
 	testApp := newTestApp()
 	testApp.Spec.Project = "none"
@@ -558,3 +824,95 @@ func TestGetCachedAppState(t *testing.T) {
 		assert.Equal(t, randomError, err)
 	})
 }
+
+func TestGetLogPods(t *testing.T) {
+	deploy := appsv1.ResourceRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "guestbook"}
+	rs := appsv1.ResourceRef{Group: "apps", Version: "v1", Kind: "ReplicaSet", Namespace: "default", Name: "guestbook-589bc"}
+	tree := &appsv1.ApplicationTree{Nodes: []appsv1.ResourceNode{
+		{ResourceRef: deploy},
+		{ResourceRef: rs, ParentRefs: []appsv1.ResourceRef{deploy}},
+		{ResourceRef: appsv1.ResourceRef{Version: "v1", Kind: "Pod", Namespace: "default", Name: "guestbook-589bc-2"}, ParentRefs: []appsv1.ResourceRef{rs}},
+		{ResourceRef: appsv1.ResourceRef{Version: "v1", Kind: "Pod", Namespace: "default", Name: "guestbook-589bc-1"}, ParentRefs: []appsv1.ResourceRef{rs}},
+		{ResourceRef: appsv1.ResourceRef{Version: "v1", Kind: "Pod", Namespace: "other", Name: "standalone"}},
+	}}
+	podNames := func(pods []appsv1.ResourceNode) []string {
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	t.Run("AllPods", func(t *testing.T) {
+		assert.Equal(t, []string{"guestbook-589bc-1", "guestbook-589bc-2", "standalone"}, podNames(getLogPods(tree, "", "", "", "")))
+	})
+	t.Run("Namespace", func(t *testing.T) {
+		assert.Equal(t, []string{"standalone"}, podNames(getLogPods(tree, "", "", "other", "")))
+	})
+	t.Run("ResourceChildren", func(t *testing.T) {
+		assert.Equal(t, []string{"guestbook-589bc-1", "guestbook-589bc-2"}, podNames(getLogPods(tree, "apps", "Deployment", "default", "guestbook")))
+	})
+	t.Run("NoMatchingResource", func(t *testing.T) {
+		assert.Empty(t, getLogPods(tree, "apps", "Deployment", "default", "missing"))
+	})
+}
+
+func TestRollbackPreviewDiffs(t *testing.T) {
+	configMap := func(namespace string, value string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "my-config"},
+			"data":       map[string]interface{}{"key": value},
+		}}
+		if namespace != "" {
+			obj.SetNamespace(namespace)
+		}
+		return obj
+	}
+	managedResource := func(obj *unstructured.Unstructured) *appsv1.ResourceDiff {
+		data, err := json.Marshal(obj)
+		errors.CheckError(err)
+		key := kube.GetResourceKey(obj)
+		return &appsv1.ResourceDiff{Group: key.Group, Kind: key.Kind, Namespace: key.Namespace, Name: key.Name, LiveState: string(data)}
+	}
+	extra := configMap("default", "extra")
+	extra.SetName("extra")
+	managed := []*appsv1.ResourceDiff{managedResource(configMap("default", "new")), managedResource(extra)}
+	normalizer, err := argo.NewDiffNormalizer(nil, nil)
+	assert.NoError(t, err)
+
+	t.Run("Modified", func(t *testing.T) {
+		items, err := rollbackPreviewDiffs([]*unstructured.Unstructured{configMap("", "old")}, managed, "default", false, normalizer)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "my-config", items[0].Name)
+			assert.Equal(t, "default", items[0].Namespace)
+			assert.Contains(t, items[0].PredictedLiveState, "old")
+			assert.Contains(t, items[0].NormalizedLiveState, "new")
+		}
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		items, err := rollbackPreviewDiffs([]*unstructured.Unstructured{configMap("default", "new")}, managed, "default", false, normalizer)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+	t.Run("Prune", func(t *testing.T) {
+		items, err := rollbackPreviewDiffs([]*unstructured.Unstructured{configMap("default", "new")}, managed, "default", true, normalizer)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "extra", items[0].Name)
+			assert.Equal(t, "null", items[0].TargetState)
+		}
+	})
+	t.Run("Created", func(t *testing.T) {
+		created := configMap("default", "created")
+		created.SetName("created")
+		items, err := rollbackPreviewDiffs([]*unstructured.Unstructured{created}, managed, "default", false, normalizer)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "created", items[0].Name)
+			assert.Equal(t, "null", items[0].LiveState)
+		}
+	})
+}
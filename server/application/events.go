@@ -0,0 +1,229 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/pkg/apiclient/application"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+// eventSource is a namespace of a cluster which contains events of an application
+type eventSource struct {
+	kubeClientset kubernetes.Interface
+	namespace     string
+}
+
+// treeEventsFilter matches the events which involve the application or one of the resources of its tree
+type treeEventsFilter struct {
+	uids map[types.UID]bool
+	keys map[kube.ResourceKey]bool
+}
+
+func newTreeEventsFilter(a *appv1.Application, tree *appv1.ApplicationTree) *treeEventsFilter {
+	filter := &treeEventsFilter{
+		uids: map[types.UID]bool{a.UID: true},
+		keys: map[kube.ResourceKey]bool{
+			kube.NewResourceKey(appv1.ApplicationSchemaGroupVersionKind.Group, appv1.ApplicationSchemaGroupVersionKind.Kind, a.Namespace, a.Name): true,
+		},
+	}
+	for _, n := range append(tree.Nodes, tree.OrphanedNodes...) {
+		if n.UID != "" {
+			filter.uids[types.UID(n.UID)] = true
+		}
+		filter.keys[kube.NewResourceKey(n.Group, n.Kind, n.Namespace, n.Name)] = true
+	}
+	return filter
+}
+
+func (f *treeEventsFilter) matches(event *v1.Event) bool {
+	ref := event.InvolvedObject
+	if ref.UID != "" {
+		return f.uids[ref.UID]
+	}
+	return f.keys[kube.NewResourceKey(ref.GroupVersionKind().Group, ref.Kind, ref.Namespace, ref.Name)]
+}
+
+// eventTime returns the time an event was last observed
+func eventTime(event *v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// sortEvents sorts events by the time they were last observed
+func sortEvents(events []v1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).Before(eventTime(&events[j]))
+	})
+}
+
+// getTreeEventSources returns the namespaces which contain the events of the application and of the
+// resources of its tree, and the filter matching these events
+func (s *Server) getTreeEventSources(ctx context.Context, q *application.ApplicationTreeEventsQuery) ([]eventSource, *treeEventsFilter, error) {
+	a, err := s.appLister.Get(*q.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, appRBACName(*a)); err != nil {
+		return nil, nil, err
+	}
+	if _, err := fields.ParseSelector(q.FieldSelector); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid field selector '%s': %v", q.FieldSelector, err)
+	}
+	tree, err := s.getAppResources(ctx, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := s.getApplicationClusterConfig(*q.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubeClientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaces := make(map[string]bool)
+	for _, n := range append(tree.Nodes, tree.OrphanedNodes...) {
+		if q.ResourceNamespace != "" && n.Namespace != q.ResourceNamespace {
+			continue
+		}
+		namespace := n.Namespace
+		if namespace == "" {
+			// events of cluster scoped resources are recorded in the default namespace
+			namespace = metav1.NamespaceDefault
+		}
+		namespaces[namespace] = true
+	}
+	var sources []eventSource
+	inCluster := a.Spec.Destination.Server == common.KubernetesInternalAPIServerAddr
+	if q.ResourceNamespace == "" && !(inCluster && namespaces[a.Namespace]) {
+		sources = append(sources, eventSource{kubeClientset: s.kubeclientset, namespace: a.Namespace})
+	}
+	var sortedNamespaces []string
+	for namespace := range namespaces {
+		sortedNamespaces = append(sortedNamespaces, namespace)
+	}
+	sort.Strings(sortedNamespaces)
+	for _, namespace := range sortedNamespaces {
+		sources = append(sources, eventSource{kubeClientset: kubeClientset, namespace: namespace})
+	}
+	return sources, newTreeEventsFilter(a, tree), nil
+}
+
+// listTreeEvents lists the events of the sources which match the filter and returns the resource
+// version of every source
+func listTreeEvents(sources []eventSource, filter *treeEventsFilter, fieldSelector string) ([]v1.Event, []string, error) {
+	var events []v1.Event
+	resourceVersions := make([]string, len(sources))
+	seen := make(map[types.UID]bool)
+	for i, src := range sources {
+		list, err := src.kubeClientset.CoreV1().Events(src.namespace).List(metav1.ListOptions{FieldSelector: fieldSelector})
+		if err != nil {
+			return nil, nil, err
+		}
+		resourceVersions[i] = list.ResourceVersion
+		for j := range list.Items {
+			event := list.Items[j]
+			if filter.matches(&event) && !seen[event.UID] {
+				seen[event.UID] = true
+				events = append(events, event)
+			}
+		}
+	}
+	sortEvents(events)
+	return events, resourceVersions, nil
+}
+
+// ListTreeEvents returns the events of the application and of all resources in its resource tree
+func (s *Server) ListTreeEvents(ctx context.Context, q *application.ApplicationTreeEventsQuery) (*v1.EventList, error) {
+	sources, filter, err := s.getTreeEventSources(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	events, _, err := listTreeEvents(sources, filter, q.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EventList{Items: events}, nil
+}
+
+// WatchTreeEvents streams the events of the application and of all resources in its resource tree.
+// The existing events are sent first. Resources added to the tree after the stream was opened are
+// not included.
+func (s *Server) WatchTreeEvents(q *application.ApplicationTreeEventsQuery, ws application.ApplicationService_WatchTreeEventsServer) error {
+	ctx := ws.Context()
+	logCtx := log.WithField("application", q.Name)
+	sources, filter, err := s.getTreeEventSources(ctx, q)
+	if err != nil {
+		return err
+	}
+	events, resourceVersions, err := listTreeEvents(sources, filter, q.FieldSelector)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := ws.Send(&application.ApplicationTreeEventWatchEvent{Type: string(watch.Added), Event: event}); err != nil {
+			logCtx.Warnf("Unable to send stream message: %v", err)
+			return err
+		}
+	}
+
+	updates := make(chan *application.ApplicationTreeEventWatchEvent)
+	closed := make(chan bool, len(sources))
+	for i, src := range sources {
+		w, err := src.kubeClientset.CoreV1().Events(src.namespace).Watch(metav1.ListOptions{FieldSelector: q.FieldSelector, ResourceVersion: resourceVersions[i]})
+		if err != nil {
+			return err
+		}
+		defer w.Stop()
+		go func() {
+			for next := range w.ResultChan() {
+				event, ok := next.Object.(*v1.Event)
+				if !ok || !filter.matches(event) {
+					continue
+				}
+				select {
+				case updates <- &application.ApplicationTreeEventWatchEvent{Type: string(next.Type), Event: *event}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			closed <- true
+		}()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			logCtx.Info("client events grpc context closed")
+			return nil
+		case <-closed:
+			logCtx.Info("k8s events watch closed")
+			return nil
+		case update := <-updates:
+			if err := ws.Send(update); err != nil {
+				logCtx.Warnf("Unable to send stream message: %v", err)
+				return err
+			}
+		}
+	}
+}
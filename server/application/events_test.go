@@ -0,0 +1,52 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	appsv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func newEvent(namespace string, name string, involved v1.ObjectReference, lastTimestamp time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name)},
+		InvolvedObject: involved,
+		LastTimestamp:  metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestListTreeEvents(t *testing.T) {
+	app := &appsv1.Application{ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: "argocd", UID: "app-uid"}}
+	tree := &appsv1.ApplicationTree{Nodes: []appsv1.ResourceNode{
+		{ResourceRef: appsv1.ResourceRef{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook", UID: "deploy-uid"}},
+		{ResourceRef: appsv1.ResourceRef{Kind: "Pod", Namespace: "other", Name: "guestbook-1", UID: "pod-uid"}},
+	}}
+	filter := newTreeEventsFilter(app, tree)
+	now := time.Now()
+
+	kubeClientset := fake.NewSimpleClientset(
+		newEvent("argocd", "app-event", v1.ObjectReference{Kind: "Application", Namespace: "argocd", Name: "guestbook", UID: "app-uid"}, now.Add(-time.Minute)),
+		newEvent("default", "deploy-event", v1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "guestbook", UID: "deploy-uid"}, now.Add(-2*time.Minute)),
+		newEvent("default", "other-event", v1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "other", UID: "other-uid"}, now),
+		newEvent("other", "pod-event", v1.ObjectReference{Kind: "Pod", Namespace: "other", Name: "guestbook-1"}, now),
+	)
+	sources := []eventSource{
+		{kubeClientset: kubeClientset, namespace: "argocd"},
+		{kubeClientset: kubeClientset, namespace: "default"},
+		{kubeClientset: kubeClientset, namespace: "other"},
+	}
+	events, resourceVersions, err := listTreeEvents(sources, filter, "")
+	assert.NoError(t, err)
+	assert.Len(t, resourceVersions, 3)
+	var names []string
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+	assert.Equal(t, []string{"deploy-event", "app-event", "pod-event"}, names)
+}
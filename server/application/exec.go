@@ -0,0 +1,199 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/argoproj/argo-cd/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/argo"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+// defaultExecCommand is the command executed if an exec session does not specify one
+var defaultExecCommand = []string{"sh"}
+
+// execStream adapts the gRPC stream of an exec session to the stdin, stdout, stderr and terminal
+// size queue of a remote command
+type execStream struct {
+	stream   application.ApplicationService_PodExecServer
+	stdin    []byte
+	sizes    chan remotecommand.TerminalSize
+	sizeLock sync.Mutex
+	closed   bool
+	sendLock sync.Mutex
+}
+
+func newExecStream(stream application.ApplicationService_PodExecServer, first *application.ApplicationPodExecRequest) *execStream {
+	s := &execStream{stream: stream, sizes: make(chan remotecommand.TerminalSize, 1)}
+	s.handle(first)
+	return s
+}
+
+func (s *execStream) handle(msg *application.ApplicationPodExecRequest) {
+	s.stdin = append(s.stdin, msg.Stdin...)
+	if msg.Rows > 0 && msg.Cols > 0 {
+		s.sizeLock.Lock()
+		defer s.sizeLock.Unlock()
+		if s.closed {
+			return
+		}
+		// only the latest terminal size is relevant, so replace a size which has not been consumed yet
+		select {
+		case <-s.sizes:
+		default:
+		}
+		s.sizes <- remotecommand.TerminalSize{Width: uint16(msg.Cols), Height: uint16(msg.Rows)}
+	}
+}
+
+func (s *execStream) closeSizes() {
+	s.sizeLock.Lock()
+	defer s.sizeLock.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.sizes)
+	}
+}
+
+// Read reads the stdin of the session
+func (s *execStream) Read(p []byte) (int, error) {
+	for len(s.stdin) == 0 {
+		msg, err := s.stream.Recv()
+		if err != nil {
+			s.closeSizes()
+			return 0, err
+		}
+		s.handle(msg)
+	}
+	n := copy(p, s.stdin)
+	s.stdin = s.stdin[n:]
+	return n, nil
+}
+
+// Next returns the new size of the terminal, or nil once the session is closed
+func (s *execStream) Next() *remotecommand.TerminalSize {
+	size, ok := <-s.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+func (s *execStream) send(res *application.ApplicationPodExecResponse) error {
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+	return s.stream.Send(res)
+}
+
+type execOutput struct {
+	stream *execStream
+	stderr bool
+}
+
+func (o *execOutput) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	res := &application.ApplicationPodExecResponse{Stdout: data}
+	if o.stderr {
+		res = &application.ApplicationPodExecResponse{Stderr: data}
+	}
+	if err := o.stream.send(res); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// PodExec opens an interactive exec session in a pod of the application
+func (s *Server) PodExec(ws application.ApplicationService_PodExecServer) error {
+	q, err := ws.Recv()
+	if err != nil {
+		return err
+	}
+	if q.GetName() == "" || q.PodName == "" {
+		return status.Errorf(codes.InvalidArgument, "application name and pod name are required")
+	}
+	ctx := ws.Context()
+	pod, config, a, err := s.getAppResource(ctx, rbacpolicy.ActionExec, &application.ApplicationResourceRequest{
+		Name:         q.Name,
+		Namespace:    q.Namespace,
+		Kind:         kube.PodKind,
+		Group:        "",
+		Version:      "v1",
+		ResourceName: q.PodName,
+	})
+	if err != nil {
+		return err
+	}
+	command := q.Command
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+	kubeClientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	req := kubeClientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: q.Container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    !q.Tty,
+			TTY:       q.Tty,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	target := pod.Name
+	if q.Container != "" {
+		target = fmt.Sprintf("%s/%s", pod.Name, q.Container)
+	}
+	logCtx := log.WithFields(log.Fields{"application": a.Name, "pod": pod.Name, "container": q.Container})
+	logCtx.Infof("Starting exec session: %s", strings.Join(command, " "))
+	s.logAppEvent(a, ctx, argo.EventReasonResourceActionRan, fmt.Sprintf("started exec session in pod %s: %s", target, strings.Join(command, " ")))
+	s.logResourceEvent(pod, ctx, argo.EventReasonResourceActionRan, fmt.Sprintf("started exec session: %s", strings.Join(command, " ")))
+
+	stream := newExecStream(ws, q)
+	defer stream.closeSizes()
+	opts := remotecommand.StreamOptions{
+		Stdin:  stream,
+		Stdout: &execOutput{stream: stream},
+		Tty:    q.Tty,
+	}
+	if q.Tty {
+		opts.TerminalSizeQueue = stream
+	} else {
+		opts.Stderr = &execOutput{stream: stream, stderr: true}
+	}
+	var exitCode int32
+	err = executor.Stream(opts)
+	if err != nil {
+		exitErr, ok := err.(utilexec.ExitError)
+		if !ok || !exitErr.Exited() {
+			logCtx.Warnf("Exec session failed: %v", err)
+			s.logAppEvent(a, ctx, argo.EventReasonResourceActionRan, fmt.Sprintf("exec session in pod %s failed: %v", target, err))
+			return err
+		}
+		exitCode = int32(exitErr.ExitStatus())
+	}
+	logCtx.Infof("Exec session completed with exit code %d", exitCode)
+	s.logAppEvent(a, ctx, argo.EventReasonResourceActionRan, fmt.Sprintf("ended exec session in pod %s with exit code %d", target, exitCode))
+	return stream.send(&application.ApplicationPodExecResponse{ExitCode: &exitCode})
+}
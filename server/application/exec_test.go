@@ -0,0 +1,71 @@
+package application
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/argoproj/argo-cd/pkg/apiclient/application"
+)
+
+type fakeExecServer struct {
+	grpc.ServerStream
+	requests  []*application.ApplicationPodExecRequest
+	responses []*application.ApplicationPodExecResponse
+}
+
+func (s *fakeExecServer) Recv() (*application.ApplicationPodExecRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeExecServer) Send(res *application.ApplicationPodExecResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+func TestExecStream(t *testing.T) {
+	t.Run("Stdin", func(t *testing.T) {
+		server := &fakeExecServer{requests: []*application.ApplicationPodExecRequest{
+			{Stdin: []byte("ls ")},
+			{Rows: 10},
+			{Stdin: []byte("-l\n")},
+		}}
+		stream := newExecStream(server, &application.ApplicationPodExecRequest{Stdin: []byte("cd /tmp\n")})
+		data, err := ioutil.ReadAll(stream)
+		assert.NoError(t, err)
+		assert.Equal(t, "cd /tmp\nls -l\n", string(data))
+		assert.Nil(t, stream.Next())
+	})
+
+	t.Run("TerminalSize", func(t *testing.T) {
+		server := &fakeExecServer{requests: []*application.ApplicationPodExecRequest{
+			{Rows: 30, Cols: 120},
+			{Rows: 40, Cols: 160},
+			{Stdin: []byte("exit\n")},
+		}}
+		stream := newExecStream(server, &application.ApplicationPodExecRequest{Rows: 24, Cols: 80})
+		assert.Equal(t, &remotecommand.TerminalSize{Width: 80, Height: 24}, stream.Next())
+		_, err := stream.Read(make([]byte, 10))
+		assert.NoError(t, err)
+		assert.Equal(t, &remotecommand.TerminalSize{Width: 160, Height: 40}, stream.Next())
+	})
+
+	t.Run("Output", func(t *testing.T) {
+		server := &fakeExecServer{}
+		stream := newExecStream(server, &application.ApplicationPodExecRequest{})
+		_, err := (&execOutput{stream: stream}).Write([]byte("out"))
+		assert.NoError(t, err)
+		_, err = (&execOutput{stream: stream, stderr: true}).Write([]byte("err"))
+		assert.NoError(t, err)
+		assert.Equal(t, []*application.ApplicationPodExecResponse{{Stdout: []byte("out")}, {Stderr: []byte("err")}}, server.responses)
+	})
+}
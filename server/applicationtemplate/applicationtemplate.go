@@ -0,0 +1,147 @@
+package applicationtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/common"
+	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
+	applicationtemplatepkg "github.com/argoproj/argo-cd/pkg/apiclient/applicationtemplate"
+	"github.com/argoproj/argo-cd/pkg/apis/application"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/rbac"
+)
+
+var paramRegex = regexp.MustCompile(`{{\s*([\w.\-]+)\s*}}`)
+
+// Server provides an ApplicationTemplate service
+type Server struct {
+	ns           string
+	appclientset appclientset.Interface
+	appService   applicationpkg.ApplicationServiceServer
+	enf          *rbac.Enforcer
+}
+
+// NewServer returns a new instance of the ApplicationTemplate service. Applications are created by the application
+// service, so creating an application from a template requires the same permissions as creating it directly.
+func NewServer(
+	namespace string,
+	appclientset appclientset.Interface,
+	appService applicationpkg.ApplicationServiceServer,
+	enf *rbac.Enforcer,
+) *Server {
+	return &Server{
+		ns:           namespace,
+		appclientset: appclientset,
+		appService:   appService,
+		enf:          enf,
+	}
+}
+
+// List returns the list of application templates
+func (s *Server) List(ctx context.Context, q *applicationtemplatepkg.ApplicationTemplateQuery) (*appv1.ApplicationTemplateList, error) {
+	list, err := s.appclientset.ArgoprojV1alpha1().ApplicationTemplates(s.ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]appv1.ApplicationTemplate, 0)
+	for _, tmpl := range list.Items {
+		if s.enf.Enforce(ctx.Value("claims"), rbacpolicy.ResourceApplicationTemplates, rbacpolicy.ActionGet, tmpl.Name) {
+			items = append(items, tmpl)
+		}
+	}
+	list.Items = items
+	return list, nil
+}
+
+// Get returns an application template by name
+func (s *Server) Get(ctx context.Context, q *applicationtemplatepkg.ApplicationTemplateQuery) (*appv1.ApplicationTemplate, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceApplicationTemplates, rbacpolicy.ActionGet, q.Name); err != nil {
+		return nil, err
+	}
+	tmpl, err := s.appclientset.ArgoprojV1alpha1().ApplicationTemplates(s.ns).Get(q.Name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "application template '%s' not found", q.Name)
+	}
+	return tmpl, err
+}
+
+// Instantiate renders an application template with the provided parameter values, and creates the application unless
+// a dry run is requested
+func (s *Server) Instantiate(ctx context.Context, q *applicationtemplatepkg.ApplicationTemplateInstantiateRequest) (*appv1.Application, error) {
+	tmpl, err := s.Get(ctx, &applicationtemplatepkg.ApplicationTemplateQuery{Name: q.Template})
+	if err != nil {
+		return nil, err
+	}
+	params, err := tmpl.Spec.ResolveParameters(q.Name, q.Parameters)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	app, err := renderApplication(tmpl, params)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to render application template '%s': %v", tmpl.Name, err)
+	}
+	app.Namespace = s.ns
+	if q.DryRun {
+		return app, nil
+	}
+	return s.appService.Create(ctx, &applicationpkg.ApplicationCreateRequest{Application: *app})
+}
+
+// renderApplication replaces the references to parameters in the string fields of the application template of an
+// ApplicationTemplate with their values. The name of the application defaults to the requested name.
+func renderApplication(tmpl *appv1.ApplicationTemplate, params map[string]string) (*appv1.Application, error) {
+	data, err := json.Marshal(tmpl.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var unknown error
+	data = paramRegex.ReplaceAllFunc(data, func(ref []byte) []byte {
+		name := string(paramRegex.FindSubmatch(ref)[1])
+		value, ok := params[name]
+		if !ok {
+			unknown = fmt.Errorf("reference to undeclared parameter '%s'", name)
+			return ref
+		}
+		// the reference is always inside of a JSON string, so the value is escaped without its quotes
+		escaped, _ := json.Marshal(value)
+		return escaped[1 : len(escaped)-1]
+	})
+	if unknown != nil {
+		return nil, unknown
+	}
+	var rendered appv1.ApplicationSetTemplate
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		return nil, err
+	}
+	if rendered.Name == "" {
+		rendered.Name = params[appv1.ApplicationTemplateNameParameter]
+	}
+	annotations := make(map[string]string)
+	for k, v := range rendered.Annotations {
+		annotations[k] = v
+	}
+	annotations[common.AnnotationKeyApplicationTemplate] = tmpl.Name
+	return &appv1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       application.ApplicationKind,
+			APIVersion: appv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        rendered.Name,
+			Labels:      rendered.Labels,
+			Annotations: annotations,
+			Finalizers:  rendered.Finalizers,
+		},
+		Spec: rendered.Spec,
+	}, nil
+}
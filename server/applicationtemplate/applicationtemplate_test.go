@@ -0,0 +1,137 @@
+package applicationtemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/common"
+	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
+	applicationtemplatepkg "github.com/argoproj/argo-cd/pkg/apiclient/applicationtemplate"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	apps "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-cd/util/rbac"
+)
+
+const testNamespace = "default"
+
+type fakeAppService struct {
+	applicationpkg.ApplicationServiceServer
+	created []appv1.Application
+}
+
+func (s *fakeAppService) Create(_ context.Context, q *applicationpkg.ApplicationCreateRequest) (*appv1.Application, error) {
+	s.created = append(s.created, q.Application)
+	return &q.Application, nil
+}
+
+func newTemplate(name string) *appv1.ApplicationTemplate {
+	return &appv1.ApplicationTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: appv1.ApplicationTemplateSpec{
+			Parameters: []appv1.ApplicationTemplateParameter{
+				{Name: "image", Required: true},
+				{Name: "env", Default: "dev", Enum: []string{"dev", "prod"}},
+			},
+			Template: appv1.ApplicationSetTemplate{
+				ApplicationSetTemplateMeta: appv1.ApplicationSetTemplateMeta{
+					Labels: map[string]string{"env": "{{env}}"},
+				},
+				Spec: appv1.ApplicationSpec{
+					Project: "platform",
+					Source: appv1.ApplicationSource{
+						RepoURL: "https://github.com/example/charts",
+						Path:    "web",
+						Helm: &appv1.ApplicationSourceHelm{
+							Parameters: []appv1.HelmParameter{{Name: "image", Value: "{{image}}"}},
+						},
+					},
+					Destination: appv1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "{{name}}-{{env}}"},
+				},
+			},
+		},
+	}
+}
+
+func newTestServer(objects ...*appv1.ApplicationTemplate) (*Server, *fakeAppService) {
+	enforcer := rbac.NewEnforcer(fake.NewSimpleClientset(), testNamespace, common.ArgoCDRBACConfigMapName, nil)
+	_ = enforcer.SetBuiltinPolicy("p, role:test, applicationtemplates, get, web, allow")
+	enforcer.SetDefaultRole("role:test")
+	var clientObjects []runtime.Object
+	for _, obj := range objects {
+		clientObjects = append(clientObjects, obj)
+	}
+	appService := &fakeAppService{}
+	return NewServer(testNamespace, apps.NewSimpleClientset(clientObjects...), appService, enforcer), appService
+}
+
+func TestList(t *testing.T) {
+	server, _ := newTestServer(newTemplate("web"), newTemplate("database"))
+	list, err := server.List(context.Background(), &applicationtemplatepkg.ApplicationTemplateQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "web", list.Items[0].Name)
+
+	_, err = server.Get(context.Background(), &applicationtemplatepkg.ApplicationTemplateQuery{Name: "database"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestInstantiate(t *testing.T) {
+	server, appService := newTestServer(newTemplate("web"))
+
+	app, err := server.Instantiate(context.Background(), &applicationtemplatepkg.ApplicationTemplateInstantiateRequest{
+		Template:   "web",
+		Name:       "shop",
+		Parameters: map[string]string{"image": "nginx:1.19"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, appService.created, 1)
+	assert.Equal(t, "shop", app.Name)
+	assert.Equal(t, testNamespace, app.Namespace)
+	assert.Equal(t, "web", app.Annotations[common.AnnotationKeyApplicationTemplate])
+	assert.Equal(t, map[string]string{"env": "dev"}, app.Labels)
+	assert.Equal(t, "platform", app.Spec.Project)
+	assert.Equal(t, "shop-dev", app.Spec.Destination.Namespace)
+	assert.Equal(t, "nginx:1.19", app.Spec.Source.Helm.Parameters[0].Value)
+
+	t.Run("DryRun", func(t *testing.T) {
+		app, err := server.Instantiate(context.Background(), &applicationtemplatepkg.ApplicationTemplateInstantiateRequest{
+			Template:   "web",
+			Name:       "shop",
+			Parameters: map[string]string{"image": "nginx:1.19", "env": "prod"},
+			DryRun:     true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "shop-prod", app.Spec.Destination.Namespace)
+		assert.Len(t, appService.created, 1)
+	})
+
+	t.Run("InvalidParameters", func(t *testing.T) {
+		for _, params := range []map[string]string{
+			{},
+			{"image": "nginx:1.19", "env": "staging"},
+			{"image": "nginx:1.19", "replicas": "2"},
+		} {
+			_, err := server.Instantiate(context.Background(), &applicationtemplatepkg.ApplicationTemplateInstantiateRequest{
+				Template:   "web",
+				Name:       "shop",
+				Parameters: params,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+		assert.Len(t, appService.created, 1)
+	})
+}
+
+func TestRenderApplication_UndeclaredParameter(t *testing.T) {
+	tmpl := newTemplate("web")
+	tmpl.Spec.Template.Spec.Source.Path = "{{path}}"
+	_, err := renderApplication(tmpl, map[string]string{"name": "shop", "image": "nginx", "env": "dev"})
+	assert.EqualError(t, err, "reference to undeclared parameter 'path'")
+}
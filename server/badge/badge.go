@@ -1,9 +1,14 @@
 package badge
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,6 +16,7 @@ import (
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-cd/util/assets"
+	"github.com/argoproj/argo-cd/util/health"
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
@@ -28,6 +34,8 @@ type Handler struct {
 
 var (
 	svgWidthPattern          = regexp.MustCompile(`^<svg width="([^"]*)"`)
+	svgSizePattern           = regexp.MustCompile(`^<svg width="([^"]*)" height="([^"]*)"`)
+	cornerRadiusPattern      = regexp.MustCompile(`rx="([^"]*)"`)
 	displayNonePattern       = regexp.MustCompile(`display="none"`)
 	leftRectColorPattern     = regexp.MustCompile(`id="leftRect" fill="([^"]*)"`)
 	rightRectColorPattern    = regexp.MustCompile(`id="rightRect" fill="([^"]*)"`)
@@ -38,9 +46,49 @@ var (
 )
 
 const (
+	svgWidth             = 131
 	svgWidthWithRevision = 192
+	svgHeight            = 20
+	// maxSVGWidth is the maximum width of badges requested with the width parameter
+	maxSVGWidth = 1000
+
+	// StyleFlat is the default style of badges, with rounded corners
+	StyleFlat = "flat"
+	// StyleFlatSquare is the style of badges with square corners
+	StyleFlatSquare = "flat-square"
 )
 
+// ApplicationToken returns the token of the badge of an application, signed by the server secret key
+func ApplicationToken(key []byte, name string) string {
+	return sign(key, "application:"+name)
+}
+
+// ProjectToken returns the token of the aggregate badge of the applications of projects, signed by the server
+// secret key. The projects are given in the order of the project parameters of the badge URL.
+func ProjectToken(key []byte, projects ...string) string {
+	return sign(key, "project:"+strings.Join(projects, ","))
+}
+
+func sign(key []byte, subject string) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(subject))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validToken returns whether the token parameter of a badge request is the token of its application or projects
+func validToken(key []byte, query map[string][]string) bool {
+	var expected string
+	if name, ok := query["name"]; ok {
+		expected = ApplicationToken(key, name[0])
+	} else if projects, ok := query["project"]; ok {
+		expected = ProjectToken(key, projects...)
+	} else {
+		return false
+	}
+	token, ok := query["token"]
+	return ok && hmac.Equal([]byte(token[0]), []byte(expected))
+}
+
 func replaceFirstGroupSubMatch(re *regexp.Regexp, str string, repl string) string {
 	result := ""
 	lastIndex := 0
@@ -58,8 +106,8 @@ func replaceFirstGroupSubMatch(re *regexp.Regexp, str string, repl string) strin
 	return result + str[lastIndex:]
 }
 
-//ServeHTTP returns badge with health and sync status for application
-//(or an error badge if wrong query or application name is given)
+//ServeHTTP returns badge with health and sync status for application, or the aggregate status of the applications
+//of projects (or an error badge if wrong query or application name is given)
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	health := appv1.HealthStatusUnknown
 	status := appv1.SyncStatusCodeUnknown
@@ -67,24 +115,37 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	revisionEnabled := false
 	enabled := false
 	notFound := false
+	query := r.URL.Query()
 	if sets, err := h.settingsMgr.GetSettings(); err == nil {
 		enabled = sets.StatusBadgeEnabled
+		// requests without a valid token get the same badge as when badges are disabled, so that they don't reveal
+		// which applications exist
+		if enabled && sets.StatusBadgeTokenRequired {
+			enabled = validToken(sets.ServerSignature, query)
+		}
 	}
 
 	//Sample url: http://localhost:8080/api/badge?name=123
-	if name, ok := r.URL.Query()["name"]; ok && enabled {
+	if name, ok := query["name"]; ok && enabled {
 		if app, err := h.appClientset.ArgoprojV1alpha1().Applications(h.namespace).Get(name[0], v1.GetOptions{}); err == nil {
 			health = app.Status.Health.Status
 			status = app.Status.Sync.Status
-			revision = app.Status.OperationState.SyncResult.Revision
+			revision = app.Status.Sync.Revision
+			if app.Status.OperationState != nil && app.Status.OperationState.SyncResult != nil {
+				revision = app.Status.OperationState.SyncResult.Revision
+			}
 		} else if errors.IsNotFound(err) {
 			notFound = true
 		}
-	}
-
-	//Sample url: http://localhost:8080/api/badge?name=123&revision=true
-	if _, ok := r.URL.Query()["revision"]; ok && enabled {
-		revisionEnabled = true
+		//Sample url: http://localhost:8080/api/badge?name=123&revision=true
+		if _, ok := query["revision"]; ok && revision != "" {
+			revisionEnabled = true
+		}
+	} else if projects, ok := query["project"]; ok && enabled {
+		//Sample url: http://localhost:8080/api/badge?project=default&project=infra
+		if apps, err := h.appClientset.ArgoprojV1alpha1().Applications(h.namespace).List(v1.ListOptions{}); err == nil {
+			health, status, notFound = aggregateStatus(apps.Items, projects)
+		}
 	}
 
 	leftColorString := ""
@@ -115,12 +176,32 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	badge = replaceFirstGroupSubMatch(leftTextPattern, badge, leftText)
 	badge = replaceFirstGroupSubMatch(rightTextPattern, badge, rightText)
 
+	width := svgWidth
 	if !notFound && revisionEnabled {
+		if len(revision) > 7 {
+			revision = revision[:7]
+		}
 		// Increase width of SVG and enable display of revision components
+		width = svgWidthWithRevision
 		badge = svgWidthPattern.ReplaceAllString(badge, fmt.Sprintf(`<svg width="%d" $2`, svgWidthWithRevision))
 		badge = displayNonePattern.ReplaceAllString(badge, `display="inline"`)
 		badge = revisionRectColorPattern.ReplaceAllString(badge, fmt.Sprintf(`id="revisionRect" fill="%s" $2`, rightColorString))
-		badge = replaceFirstGroupSubMatch(revisionTextPattern, badge, fmt.Sprintf("(%s)", revision[:7]))
+		badge = replaceFirstGroupSubMatch(revisionTextPattern, badge, fmt.Sprintf("(%s)", revision))
+	}
+
+	//Sample url: http://localhost:8080/api/badge?name=123&style=flat-square
+	if style, ok := query["style"]; ok && style[0] == StyleFlatSquare {
+		badge = cornerRadiusPattern.ReplaceAllString(badge, `rx="0"`)
+	}
+
+	//Sample url: http://localhost:8080/api/badge?name=123&width=300
+	// the badge is scaled to the requested width, keeping its aspect ratio
+	if value, ok := query["width"]; ok {
+		if requestedWidth, err := strconv.Atoi(value[0]); err == nil && requestedWidth > 0 && requestedWidth <= maxSVGWidth {
+			height := float64(svgHeight) * float64(requestedWidth) / float64(width)
+			badge = svgSizePattern.ReplaceAllString(badge, fmt.Sprintf(`<svg width="%d" height="%s" viewBox="0 0 %d %d"`,
+				requestedWidth, strconv.FormatFloat(height, 'f', -1, 64), width, svgHeight))
+		}
 	}
 
 	w.Header().Set("Content-Type", "image/svg+xml")
@@ -130,3 +211,42 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte(badge))
 }
+
+// aggregateStatus returns the worst health of the applications of the projects, and whether they are all synced. The
+// aggregate status is not found if the projects have no applications.
+func aggregateStatus(apps []appv1.Application, projects []string) (appv1.HealthStatusCode, appv1.SyncStatusCode, bool) {
+	aggregateHealth := appv1.HealthStatusHealthy
+	aggregateSync := appv1.SyncStatusCodeSynced
+	found := false
+	for _, app := range apps {
+		if !containsProject(projects, app.Spec.GetProject()) {
+			continue
+		}
+		found = true
+		if health.IsWorse(aggregateHealth, app.Status.Health.Status) {
+			aggregateHealth = app.Status.Health.Status
+		}
+		switch app.Status.Sync.Status {
+		case appv1.SyncStatusCodeSynced:
+		case appv1.SyncStatusCodeOutOfSync:
+			aggregateSync = appv1.SyncStatusCodeOutOfSync
+		default:
+			if aggregateSync == appv1.SyncStatusCodeSynced {
+				aggregateSync = appv1.SyncStatusCodeUnknown
+			}
+		}
+	}
+	if !found {
+		return appv1.HealthStatusUnknown, appv1.SyncStatusCodeUnknown, true
+	}
+	return aggregateHealth, aggregateSync, false
+}
+
+func containsProject(projects []string, project string) bool {
+	for _, p := range projects {
+		if p == project {
+			return true
+		}
+	}
+	return false
+}
@@ -109,3 +109,85 @@ func TestHandlerFeatureIsDisabled(t *testing.T) {
 	assert.Equal(t, "Unknown", leftTextPattern.FindStringSubmatch(response)[1])
 	assert.Equal(t, "Unknown", rightTextPattern.FindStringSubmatch(response)[1])
 }
+
+func TestHandlerProjectBadge(t *testing.T) {
+	outOfSyncApp := testApp.DeepCopy()
+	outOfSyncApp.Name = "outOfSyncApp"
+	outOfSyncApp.Status.Sync.Status = v1alpha1.SyncStatusCodeOutOfSync
+	outOfSyncApp.Status.Health.Status = v1alpha1.HealthStatusProgressing
+	otherProjectApp := testApp.DeepCopy()
+	otherProjectApp.Name = "otherProjectApp"
+	otherProjectApp.Spec.Project = "other"
+	otherProjectApp.Status.Health.Status = v1alpha1.HealthStatusDegraded
+
+	settingsMgr := settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(&argoCDCm, &argoCDSecret), "default")
+	handler := NewHandler(appclientset.NewSimpleClientset(&testApp, outOfSyncApp, otherProjectApp), settingsMgr, "default")
+
+	for query, expected := range map[string][]string{
+		"project=default":               {"Progressing", "OutOfSync"},
+		"project=other":                 {"Degraded", "Synced"},
+		"project=default&project=other": {"Degraded", "OutOfSync"},
+		"project=none":                  {"Not Found", ""},
+	} {
+		req, err := http.NewRequest("GET", "/api/badge?"+query, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		response := rr.Body.String()
+		assert.Equal(t, expected[0], leftTextPattern.FindStringSubmatch(response)[1], query)
+		assert.Equal(t, expected[1], rightTextPattern.FindStringSubmatch(response)[1], query)
+	}
+}
+
+func TestHandlerTokenIsRequired(t *testing.T) {
+	argoCDCmTokenRequired := argoCDCm.DeepCopy()
+	argoCDCmTokenRequired.Data["statusbadge.requireToken"] = "true"
+	settingsMgr := settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(argoCDCmTokenRequired, &argoCDSecret), "default")
+	handler := NewHandler(appclientset.NewSimpleClientset(&testApp), settingsMgr, "default")
+
+	for query, expected := range map[string]string{
+		"name=testApp":               "Unknown",
+		"name=testApp&token=invalid": "Unknown",
+		"name=testApp&token=" + ProjectToken([]byte("test"), "default"):     "Unknown",
+		"name=testApp&token=" + ApplicationToken([]byte("test"), "testApp"): "Healthy",
+		"project=default&token=" + ProjectToken([]byte("test"), "default"):  "Healthy",
+	} {
+		req, err := http.NewRequest("GET", "/api/badge?"+query, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, expected, leftTextPattern.FindStringSubmatch(rr.Body.String())[1], query)
+	}
+}
+
+func TestHandlerWidthAndStyle(t *testing.T) {
+	settingsMgr := settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(&argoCDCm, &argoCDSecret), "default")
+	handler := NewHandler(appclientset.NewSimpleClientset(&testApp), settingsMgr, "default")
+	req, err := http.NewRequest("GET", "/api/badge?name=testApp&width=262&style=flat-square", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	response := rr.Body.String()
+	assert.Contains(t, response, `<svg width="262" height="40" viewBox="0 0 131 20"`)
+	assert.Contains(t, response, `rx="0"`)
+	assert.NotContains(t, response, `rx="3"`)
+}
+
+func TestHandlerRevisionWithoutOperation(t *testing.T) {
+	app := testApp.DeepCopy()
+	app.Status.OperationState = nil
+	app.Status.Sync.Revision = "6b0d1e8bb0b4a3e798dfb2b52a7e72b0e4b1e4a5"
+	settingsMgr := settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(&argoCDCm, &argoCDSecret), "default")
+	handler := NewHandler(appclientset.NewSimpleClientset(app), settingsMgr, "default")
+	req, err := http.NewRequest("GET", "/api/badge?name=testApp&revision=true", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Contains(t, rr.Body.String(), "(6b0d1e8)")
+}
@@ -2,11 +2,13 @@ package cache
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
 	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 )
 
@@ -29,6 +31,12 @@ func NewCache(
 type OIDCState struct {
 	// ReturnURL is the URL in which to redirect a user back to after completing an OAuth2 login
 	ReturnURL string `json:"returnURL"`
+	// CodeVerifier is the PKCE code verifier of the login flow, if PKCE is used
+	CodeVerifier string `json:"codeVerifier,omitempty"`
+	// Nonce is the nonce which the ID token issued for the login flow must hold, if any
+	Nonce string `json:"nonce,omitempty"`
+	// Provider is the name of the additional OIDC provider the login flow was started with, if any
+	Provider string `json:"provider,omitempty"`
 }
 
 type ClusterInfo struct {
@@ -55,6 +63,11 @@ func AddCacheFlagsToCmd(cmd *cobra.Command) func() (*Cache, error) {
 	}
 }
 
+// Ping returns an error if the cache is unreachable
+func (c *Cache) Ping() error {
+	return c.cache.Ping()
+}
+
 func (c *Cache) GetAppResourcesTree(appName string, res *appv1.ApplicationTree) error {
 	return c.cache.GetAppResourcesTree(appName, res)
 }
@@ -73,6 +86,11 @@ func (c *Cache) GetClusterInfo(server string) (ClusterInfo, error) {
 	return res, err
 }
 
+// GetControllerClusterInfo returns the state of a cluster reported by the application controller which manages it
+func (c *Cache) GetControllerClusterInfo(server string, res *appv1.ClusterInfo) error {
+	return c.cache.GetClusterInfo(server, res)
+}
+
 func (c *Cache) SetRepoConnectionState(repo string, state *appv1.ConnectionState) error {
 	return c.cache.SetItem(repoConnectionStateKey(repo), &state, c.connectionStatusCacheExpiration, state == nil)
 }
@@ -104,3 +122,133 @@ func (c *Cache) GetOIDCState(key string) (*OIDCState, error) {
 func (c *Cache) SetOIDCState(key string, state *OIDCState) error {
 	return c.cache.SetItem(oidcStateKey(key), state, c.oidcCacheExpiration, state == nil)
 }
+
+func revokedTokenKey(id string) string {
+	return fmt.Sprintf("revoked-token|%s", id)
+}
+
+// RevokeToken adds the token with the given ID (jti) to the revocation list for the given
+// duration. A negative expiration keeps the token revoked forever.
+func (c *Cache) RevokeToken(id string, expiration time.Duration) error {
+	return c.cache.SetItem(revokedTokenKey(id), true, expiration, false)
+}
+
+// IsTokenRevoked returns whether or not the token with the given ID (jti) has been revoked
+func (c *Cache) IsTokenRevoked(id string) (bool, error) {
+	var revoked bool
+	err := c.cache.GetItem(revokedTokenKey(id), &revoked)
+	if err == ErrCacheMiss {
+		return false, nil
+	}
+	return revoked, err
+}
+
+func revokedSessionsKey(subject string) string {
+	return fmt.Sprintf("revoked-sessions|%s", subject)
+}
+
+// RevokeSessions revokes all tokens of the subject which have been issued until revokedAt. The time is stored in
+// nanoseconds, so that tokens issued in the second of the revocation, but after it, can be told apart.
+func (c *Cache) RevokeSessions(subject string, revokedAt time.Time) error {
+	return c.cache.SetItem(revokedSessionsKey(subject), revokedAt.UnixNano(), cacheutil.NoExpiration, false)
+}
+
+// GetSessionsRevokedAt returns the time until which all tokens of the subject have been revoked,
+// or nil if they were never revoked
+func (c *Cache) GetSessionsRevokedAt(subject string) (*time.Time, error) {
+	var revokedAt int64
+	err := c.cache.GetItem(revokedSessionsKey(subject), &revokedAt)
+	if err == ErrCacheMiss {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := time.Unix(0, revokedAt)
+	return &res, nil
+}
+
+func tokenLastUsedKey(id string) string {
+	return fmt.Sprintf("token-last-used|%s", id)
+}
+
+// SetTokenLastUsed records when the token with the given ID (jti) has been used the last time
+func (c *Cache) SetTokenLastUsed(id string, usedAt time.Time) error {
+	return c.cache.SetItem(tokenLastUsedKey(id), usedAt.Unix(), cacheutil.NoExpiration, false)
+}
+
+// GetTokenLastUsed returns when the token with the given ID (jti) has been used the last time, or
+// nil if it has never been used
+func (c *Cache) GetTokenLastUsed(id string) (*time.Time, error) {
+	var usedAt int64
+	err := c.cache.GetItem(tokenLastUsedKey(id), &usedAt)
+	if err == ErrCacheMiss {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := time.Unix(usedAt, 0)
+	return &res, nil
+}
+
+// LoginSession is a login session of a local account
+type LoginSession struct {
+	// ID is the ID (jti) of the token of the session
+	ID string `json:"id"`
+	// IssuedAt is the time the session has been created
+	IssuedAt int64 `json:"issuedAt"`
+	// ExpiresAt is the time the session expires, or zero if it never expires
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+}
+
+func loginSessionsKey(account string) string {
+	return fmt.Sprintf("login-sessions-by-id|%s", account)
+}
+
+// AddLoginSession records a login session of the account. The sessions of an account are stored as the fields of a
+// hash, so that concurrent logins do not overwrite each other's sessions. Expired sessions are deleted.
+func (c *Cache) AddLoginSession(account string, session LoginSession) error {
+	if err := c.cache.SetHashItem(loginSessionsKey(account), session.ID, session); err != nil {
+		return err
+	}
+	var sessions map[string]LoginSession
+	if err := c.cache.GetHashItems(loginSessionsKey(account), &sessions); err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	var expired []string
+	for id, session := range sessions {
+		if session.ExpiresAt != 0 && session.ExpiresAt <= now {
+			expired = append(expired, id)
+		}
+	}
+	return c.cache.DeleteHashItems(loginSessionsKey(account), expired...)
+}
+
+// GetLoginSessions returns the login sessions of the account which have not expired
+func (c *Cache) GetLoginSessions(account string) ([]LoginSession, error) {
+	var sessions map[string]LoginSession
+	if err := c.cache.GetHashItems(loginSessionsKey(account), &sessions); err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	var res []LoginSession
+	for _, session := range sessions {
+		if session.ExpiresAt == 0 || session.ExpiresAt > now {
+			res = append(res, session)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].IssuedAt != res[j].IssuedAt {
+			return res[i].IssuedAt < res[j].IssuedAt
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+// DeleteLoginSession removes the login session with the given ID (jti) from the sessions of the account
+func (c *Cache) DeleteLoginSession(account string, id string) error {
+	return c.cache.DeleteHashItems(loginSessionsKey(account), id)
+}
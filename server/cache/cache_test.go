@@ -1,13 +1,15 @@
 package cache
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
-	. "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	cacheutil "github.com/argoproj/argo-cd/util/cache"
 	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 )
@@ -33,7 +35,7 @@ func TestCache_GetClusterConnectionState(t *testing.T) {
 	_, err := cache.GetClusterInfo("my-server")
 	assert.Equal(t, ErrCacheMiss, err)
 	// populate cache
-	err = cache.SetClusterInfo("my-server", &ClusterInfo{ConnectionState: ConnectionState{Status: "my-state"}})
+	err = cache.SetClusterInfo("my-server", &ClusterInfo{ConnectionState: appv1.ConnectionState{Status: "my-state"}})
 	assert.NoError(t, err)
 	// cache miss
 	_, err = cache.GetClusterInfo("other-server")
@@ -41,7 +43,22 @@ func TestCache_GetClusterConnectionState(t *testing.T) {
 	// cache hit
 	value, err := cache.GetClusterInfo("my-server")
 	assert.NoError(t, err)
-	assert.Equal(t, ClusterInfo{ConnectionState: ConnectionState{Status: "my-state"}}, value)
+	assert.Equal(t, ClusterInfo{ConnectionState: appv1.ConnectionState{Status: "my-state"}}, value)
+}
+
+func TestCache_GetControllerClusterInfo(t *testing.T) {
+	cache := newFixtures().Cache
+	// cache miss
+	value := &appv1.ClusterInfo{}
+	err := cache.GetControllerClusterInfo("my-server", value)
+	assert.Equal(t, ErrCacheMiss, err)
+	// populate cache
+	err = cache.cache.SetClusterInfo("my-server", &appv1.ClusterInfo{Shard: 2})
+	assert.NoError(t, err)
+	// cache hit
+	err = cache.GetControllerClusterInfo("my-server", value)
+	assert.NoError(t, err)
+	assert.Equal(t, &appv1.ClusterInfo{Shard: 2}, value)
 }
 
 func TestCache_GetRepoConnectionState(t *testing.T) {
@@ -50,7 +67,7 @@ func TestCache_GetRepoConnectionState(t *testing.T) {
 	_, err := cache.GetRepoConnectionState("my-repo")
 	assert.Equal(t, ErrCacheMiss, err)
 	// populate cache
-	err = cache.SetRepoConnectionState("my-repo", &ConnectionState{Status: "my-state"})
+	err = cache.SetRepoConnectionState("my-repo", &appv1.ConnectionState{Status: "my-state"})
 	assert.NoError(t, err)
 	// cache miss
 	_, err = cache.GetRepoConnectionState("other-repo")
@@ -58,7 +75,7 @@ func TestCache_GetRepoConnectionState(t *testing.T) {
 	// cache hit
 	value, err := cache.GetRepoConnectionState("my-repo")
 	assert.NoError(t, err)
-	assert.Equal(t, ConnectionState{Status: "my-state"}, value)
+	assert.Equal(t, appv1.ConnectionState{Status: "my-state"}, value)
 }
 
 func TestCache_GetOIDCState(t *testing.T) {
@@ -84,3 +101,82 @@ func TestAddCacheFlagsToCmd(t *testing.T) {
 	assert.Equal(t, 1*time.Hour, cache.connectionStatusCacheExpiration)
 	assert.Equal(t, 3*time.Minute, cache.oidcCacheExpiration)
 }
+
+func TestCache_RevokeToken(t *testing.T) {
+	cache := newFixtures().Cache
+	revoked, err := cache.IsTokenRevoked("my-token")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	err = cache.RevokeToken("my-token", time.Minute)
+	assert.NoError(t, err)
+	revoked, err = cache.IsTokenRevoked("my-token")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestCache_RevokeSessions(t *testing.T) {
+	cache := newFixtures().Cache
+	revokedAt, err := cache.GetSessionsRevokedAt("my-account")
+	assert.NoError(t, err)
+	assert.Nil(t, revokedAt)
+	now := time.Now()
+	err = cache.RevokeSessions("my-account", now)
+	assert.NoError(t, err)
+	revokedAt, err = cache.GetSessionsRevokedAt("my-account")
+	assert.NoError(t, err)
+	if assert.NotNil(t, revokedAt) {
+		assert.Equal(t, now.UnixNano(), revokedAt.UnixNano())
+	}
+}
+
+func TestCache_TokenLastUsed(t *testing.T) {
+	cache := newFixtures().Cache
+	usedAt, err := cache.GetTokenLastUsed("my-token")
+	assert.NoError(t, err)
+	assert.Nil(t, usedAt)
+	now := time.Unix(time.Now().Unix(), 0)
+	err = cache.SetTokenLastUsed("my-token", now)
+	assert.NoError(t, err)
+	usedAt, err = cache.GetTokenLastUsed("my-token")
+	assert.NoError(t, err)
+	if assert.NotNil(t, usedAt) {
+		assert.Equal(t, now.Unix(), usedAt.Unix())
+	}
+}
+
+func TestCache_LoginSessions(t *testing.T) {
+	cache := newFixtures().Cache
+	sessions, err := cache.GetLoginSessions("my-account")
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+	now := time.Now().Unix()
+	assert.NoError(t, cache.AddLoginSession("my-account", LoginSession{ID: "expired", IssuedAt: now - 120, ExpiresAt: now - 60}))
+	assert.NoError(t, cache.AddLoginSession("my-account", LoginSession{ID: "session-1", IssuedAt: now}))
+	assert.NoError(t, cache.AddLoginSession("my-account", LoginSession{ID: "session-2", IssuedAt: now, ExpiresAt: now + 60}))
+	sessions, err = cache.GetLoginSessions("my-account")
+	assert.NoError(t, err)
+	assert.Equal(t, []LoginSession{{ID: "session-1", IssuedAt: now}, {ID: "session-2", IssuedAt: now, ExpiresAt: now + 60}}, sessions)
+
+	assert.NoError(t, cache.DeleteLoginSession("my-account", "session-1"))
+	assert.NoError(t, cache.DeleteLoginSession("my-account", "session-2"))
+	sessions, err = cache.GetLoginSessions("my-account")
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+	assert.NoError(t, cache.DeleteLoginSession("my-account", "session-2"))
+}
+
+func TestCache_LoginSessions_Concurrent(t *testing.T) {
+	cache := newFixtures().Cache
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, cache.AddLoginSession("my-account", LoginSession{ID: fmt.Sprintf("session-%d", i), IssuedAt: time.Now().Unix()}))
+		}(i)
+	}
+	wg.Wait()
+	sessions, err := cache.GetLoginSessions("my-account")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 20)
+}
@@ -105,6 +105,7 @@ func (s *Server) List(ctx context.Context, q *cluster.ClusterQuery) (*appv1.Clus
 			clust.ConnectionState = state
 			clust.ServerVersion = serverVersion
 		}
+		s.setControllerInfo(&clust)
 		items[i] = *redact(&clust)
 		return nil
 	})
@@ -162,9 +163,18 @@ func (s *Server) Get(ctx context.Context, q *cluster.ClusterQuery) (*appv1.Clust
 	if err != nil {
 		return nil, err
 	}
+	s.setControllerInfo(c)
 	return redact(c), nil
 }
 
+// setControllerInfo sets the state of a cluster reported by the application controller which manages it, if any
+// controller reported it recently
+func (s *Server) setControllerInfo(clust *appv1.Cluster) {
+	if err := s.cache.GetControllerClusterInfo(clust.Server, &clust.Info); err != nil && err != servercache.ErrCacheMiss {
+		log.Warnf("Failed to get the state of cluster %s reported by the application controller: %v", clust.Server, err)
+	}
+}
+
 // Update updates a cluster
 func (s *Server) Update(ctx context.Context, q *cluster.ClusterUpdateRequest) (*appv1.Cluster, error) {
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceClusters, rbacpolicy.ActionUpdate, q.Cluster.Server); err != nil {
@@ -0,0 +1,167 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/rbac"
+	"github.com/argoproj/argo-cd/util/session"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+const (
+	// URLPrefix is the path prefix of the requests which are proxied to extensions
+	URLPrefix = "/extensions/"
+
+	// HeaderArgoCDApplicationName is the request header which names the application a request is made for
+	HeaderArgoCDApplicationName = "Argocd-Application-Name"
+	// HeaderArgoCDProjectName is the header which carries the project of the application
+	HeaderArgoCDProjectName = "Argocd-Project-Name"
+	// HeaderArgoCDTargetClusterURL is the header which carries the destination cluster of the application
+	HeaderArgoCDTargetClusterURL = "Argocd-Target-Cluster-URL"
+	// HeaderArgoCDTargetNamespace is the header which carries the destination namespace of the application
+	HeaderArgoCDTargetNamespace = "Argocd-Target-Namespace"
+	// HeaderArgoCDUsername is the header which carries the name of the user making the request
+	HeaderArgoCDUsername = "Argocd-Username"
+)
+
+// AuthenticateFunc authenticates a request and returns a context holding the claims of the caller
+type AuthenticateFunc func(r *http.Request) (context.Context, error)
+
+type extensionProxy struct {
+	config settings.ProxyExtension
+	proxy  *httputil.ReverseProxy
+}
+
+// Handler proxies the requests of authenticated users to the configured extension backends
+type Handler struct {
+	settingsMgr  *settings.SettingsManager
+	appLister    applisters.ApplicationNamespaceLister
+	enf          *rbac.Enforcer
+	authenticate AuthenticateFunc
+	lock         sync.Mutex
+	proxies      map[string]*extensionProxy
+}
+
+// NewHandler returns a new extensions proxy handler
+func NewHandler(settingsMgr *settings.SettingsManager, appLister applisters.ApplicationNamespaceLister, enf *rbac.Enforcer, authenticate AuthenticateFunc) *Handler {
+	return &Handler{
+		settingsMgr:  settingsMgr,
+		appLister:    appLister,
+		enf:          enf,
+		authenticate: authenticate,
+		proxies:      make(map[string]*extensionProxy),
+	}
+}
+
+func newExtensionProxy(config settings.ProxyExtension) (*extensionProxy, error) {
+	target, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, err
+	}
+	prefix := URLPrefix + config.Name
+	director := func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		req.URL.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, prefix), "/")
+		req.URL.RawPath = ""
+		req.Host = target.Host
+		for _, header := range config.Headers {
+			req.Header.Set(header.Name, header.Value)
+		}
+	}
+	return &extensionProxy{config: config, proxy: &httputil.ReverseProxy{Director: director}}, nil
+}
+
+// getProxy returns the proxy of the named extension, or nil if the extension is not configured
+func (h *Handler) getProxy(name string) (*extensionProxy, error) {
+	extensions, err := h.settingsMgr.GetProxyExtensions()
+	if err != nil {
+		return nil, err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, config := range extensions {
+		if config.Name != name {
+			continue
+		}
+		if p, ok := h.proxies[name]; ok && reflect.DeepEqual(p.config, config) {
+			return p, nil
+		}
+		p, err := newExtensionProxy(config)
+		if err != nil {
+			return nil, err
+		}
+		h.proxies[name] = p
+		return p, nil
+	}
+	delete(h.proxies, name)
+	return nil, nil
+}
+
+// ServeHTTP proxies a request to /extensions/<name>/<path> to the path of the backend of the
+// extension, if the caller is permitted to invoke the extension and to get the application the
+// request is made for
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.SplitN(strings.TrimPrefix(r.URL.Path, URLPrefix), "/", 2)[0]
+	if name == "" {
+		http.NotFound(w, r)
+		return
+	}
+	ctx, err := h.authenticate(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	claims := ctx.Value("claims")
+
+	ext, err := h.getProxy(name)
+	if err != nil {
+		log.Warnf("Failed to load configuration of extension '%s': %v", name, err)
+		http.Error(w, "Failed to load extension configuration", http.StatusInternalServerError)
+		return
+	}
+	if ext == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !h.enf.Enforce(claims, rbacpolicy.ResourceExtensions, rbacpolicy.ActionInvoke, name) {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+
+	appName := r.Header.Get(HeaderArgoCDApplicationName)
+	if appName == "" {
+		http.Error(w, fmt.Sprintf("Missing %s header", HeaderArgoCDApplicationName), http.StatusBadRequest)
+		return
+	}
+	// do not disclose whether an application exists to callers which are not permitted to get it
+	app, err := h.appLister.Get(appName)
+	if err != nil || !h.enf.Enforce(claims, rbacpolicy.ResourceApplications, rbacpolicy.ActionGet, fmt.Sprintf("%s/%s", app.Spec.GetProject(), app.Name)) {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+	if project := r.Header.Get(HeaderArgoCDProjectName); project != "" && project != app.Spec.GetProject() {
+		http.Error(w, fmt.Sprintf("Application '%s' does not belong to project '%s'", app.Name, project), http.StatusBadRequest)
+		return
+	}
+
+	// the credentials of the caller must not be forwarded to the extension backend
+	r.Header.Del("Authorization")
+	r.Header.Del("Cookie")
+	r.Header.Set(HeaderArgoCDProjectName, app.Spec.GetProject())
+	r.Header.Set(HeaderArgoCDTargetClusterURL, app.Spec.Destination.Server)
+	r.Header.Set(HeaderArgoCDTargetNamespace, app.Spec.Destination.Namespace)
+	r.Header.Set(HeaderArgoCDUsername, session.Username(ctx))
+	ext.proxy.ServeHTTP(w, r)
+}
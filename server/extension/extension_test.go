@@ -0,0 +1,136 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8scache "k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-cd/common"
+	appsv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/assets"
+	"github.com/argoproj/argo-cd/util/rbac"
+	"github.com/argoproj/argo-cd/util/session"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+const testNamespace = "argocd"
+
+func newTestHandler(t *testing.T, backendURL string) *Handler {
+	kubeclientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDConfigMapName,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string]string{"extension.config": fmt.Sprintf(`
+extensions:
+- name: metrics
+  url: %s/api
+  headers:
+  - name: X-Backend-Token
+    value: $extension.token`, backendURL)},
+	}, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDSecretName,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string][]byte{"extension.token": []byte("secret")},
+	})
+	settingsMgr := settings.NewSettingsManager(context.Background(), kubeclientset, testNamespace)
+
+	appIndexer := k8scache.NewIndexer(k8scache.MetaNamespaceKeyFunc, k8scache.Indexers{})
+	assert.NoError(t, appIndexer.Add(&appsv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: testNamespace},
+		Spec: appsv1.ApplicationSpec{
+			Project:     "default",
+			Destination: appsv1.ApplicationDestination{Server: "https://cluster", Namespace: "guestbook"},
+		},
+	}))
+	projIndexer := k8scache.NewIndexer(k8scache.MetaNamespaceKeyFunc, k8scache.Indexers{})
+	assert.NoError(t, projIndexer.Add(&appsv1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace}}))
+
+	enf := rbac.NewEnforcer(kubeclientset, testNamespace, common.ArgoCDRBACConfigMapName, nil)
+	assert.NoError(t, enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV))
+	assert.NoError(t, enf.SetUserPolicy("g, viewer, role:readonly"))
+	enf.SetClaimsEnforcerFunc(rbacpolicy.NewRBACPolicyEnforcer(enf, applisters.NewAppProjectLister(projIndexer).AppProjects(testNamespace)).EnforceClaims)
+
+	authenticate := func(r *http.Request) (context.Context, error) {
+		user := r.Header.Get("Authorization")
+		if user == "" {
+			return nil, errors.New("no session")
+		}
+		return context.WithValue(r.Context(), "claims", jwt.MapClaims{"sub": user, "iss": session.SessionManagerClaimsIssuer}), nil
+	}
+	return NewHandler(settingsMgr, applisters.NewApplicationLister(appIndexer).Applications(testNamespace), enf, authenticate)
+}
+
+func TestHandler(t *testing.T) {
+	var backendRequest *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backendRequest = r
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	handler := newTestHandler(t, backend.URL)
+
+	request := func(path string, user string, app string) *httptest.ResponseRecorder {
+		backendRequest = nil
+		r := httptest.NewRequest("GET", path, nil)
+		if user != "" {
+			r.Header.Set("Authorization", user)
+		}
+		if app != "" {
+			r.Header.Set(HeaderArgoCDApplicationName, app)
+		}
+		r.Header.Set("Cookie", "argocd.token=abc")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("Proxied", func(t *testing.T) {
+		w := request("/extensions/metrics/dashboards/1?range=1h", "admin", "guestbook")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+		if assert.NotNil(t, backendRequest) {
+			assert.Equal(t, "/api/dashboards/1", backendRequest.URL.Path)
+			assert.Equal(t, "1h", backendRequest.URL.Query().Get("range"))
+			assert.Equal(t, "secret", backendRequest.Header.Get("X-Backend-Token"))
+			assert.Equal(t, "default", backendRequest.Header.Get(HeaderArgoCDProjectName))
+			assert.Equal(t, "https://cluster", backendRequest.Header.Get(HeaderArgoCDTargetClusterURL))
+			assert.Equal(t, "guestbook", backendRequest.Header.Get(HeaderArgoCDTargetNamespace))
+			assert.Equal(t, "admin", backendRequest.Header.Get(HeaderArgoCDUsername))
+			assert.Empty(t, backendRequest.Header.Get("Authorization"))
+			assert.Empty(t, backendRequest.Header.Get("Cookie"))
+		}
+	})
+	t.Run("Unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request("/extensions/metrics/", "", "guestbook").Code)
+	})
+	t.Run("UnknownExtension", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request("/extensions/logs/", "admin", "guestbook").Code)
+	})
+	t.Run("PermissionDenied", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request("/extensions/metrics/", "viewer", "guestbook").Code)
+	})
+	t.Run("MissingApplication", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, request("/extensions/metrics/", "admin", "").Code)
+	})
+	t.Run("UnknownApplication", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request("/extensions/metrics/", "admin", "unknown").Code)
+	})
+	assert.Nil(t, backendRequest)
+}
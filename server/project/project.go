@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
@@ -84,10 +87,31 @@ func (s *Server) CreateToken(ctx context.Context, q *project.ProjectTokenCreateR
 			return nil, err
 		}
 	}
-	tokenName := fmt.Sprintf(JWTTokenSubFormat, q.Project, q.Role)
-	jwtToken, err := s.sessionMgr.Create(tokenName, q.ExpiresIn, "")
+	jwtToken, token, err := s.issueToken(q.Project, q.Role, q.ExpiresIn)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
+	}
+
+	prj.Spec.Roles[index].JWTTokens = append(prj.Spec.Roles[index].JWTTokens, *token)
+	_, err = s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Update(prj)
+	if err != nil {
+		return nil, err
+	}
+	s.logEvent(prj, ctx, argo.EventReasonResourceCreated, fmt.Sprintf("created token %s for role %s%s", token.ID, q.Role, formatTokenExpiry(token)))
+	return &project.ProjectTokenResponse{Token: jwtToken}, nil
+
+}
+
+// issueToken signs a new token with a unique ID for the project role
+func (s *Server) issueToken(proj string, role string, expiresIn int64) (string, *v1alpha1.JWTToken, error) {
+	uniqueId, err := uuid.NewRandom()
+	if err != nil {
+		return "", nil, err
+	}
+	id := uniqueId.String()
+	jwtToken, err := s.sessionMgr.Create(fmt.Sprintf(JWTTokenSubFormat, proj, role), expiresIn, id)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	parser := &jwt.Parser{
 		SkipClaimsValidation: true,
@@ -95,19 +119,101 @@ func (s *Server) CreateToken(ctx context.Context, q *project.ProjectTokenCreateR
 	claims := jwt.StandardClaims{}
 	_, _, err = parser.ParseUnverified(jwtToken, &claims)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return jwtToken, &v1alpha1.JWTToken{IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt, ID: id}, nil
+}
+
+func formatTokenExpiry(token *v1alpha1.JWTToken) string {
+	if token.ExpiresAt == 0 {
+		return " without expiry"
+	}
+	return fmt.Sprintf(" expiring at %s", time.Unix(token.ExpiresAt, 0).UTC().Format(time.RFC3339))
+}
+
+// findToken returns the index of the token of the role with the given ID, or with the given issue
+// time if the ID is empty
+func findToken(prj *v1alpha1.AppProject, role string, id string, iat int64) (*v1alpha1.JWTToken, int, error) {
+	if id != "" {
+		return prj.GetJWTTokenByID(role, id)
+	}
+	return prj.GetJWTToken(role, iat)
+}
+
+// RotateToken replaces a token of a project role by a new token with the same role bindings. The
+// rotated token remains valid for the requested grace period so that its users can switch over.
+func (s *Server) RotateToken(ctx context.Context, q *project.ProjectTokenRotateRequest) (*project.ProjectTokenResponse, error) {
+	if q.GracePeriod < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "grace period must not be negative")
+	}
+	prj, err := s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Get(q.Project, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = validateProject(prj)
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectLock.Lock(q.Project)
+	defer s.projectLock.Unlock(q.Project)
+
+	role, roleIndex, err := prj.GetRoleByName(q.Role)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "project '%s' does not have role '%s'", q.Project, q.Role)
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceProjects, rbacpolicy.ActionUpdate, q.Project); err != nil {
+		if !jwtutil.IsMember(jwtutil.Claims(ctx.Value("claims")), role.Groups) {
+			return nil, err
+		}
+	}
+	oldToken, oldTokenIndex, err := findToken(prj, q.Role, q.Id, q.Iat)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	now := time.Now()
+	if oldToken.IsExpired(now) {
+		return nil, status.Errorf(codes.FailedPrecondition, "token has already expired")
+	}
+	expiresIn := q.ExpiresIn
+	if expiresIn == 0 && oldToken.ExpiresAt > 0 {
+		// the new token gets the same lifetime as the rotated one
+		expiresIn = oldToken.ExpiresAt - oldToken.IssuedAt
+	}
+	jwtToken, token, err := s.issueToken(q.Project, q.Role, expiresIn)
+	if err != nil {
+		return nil, err
 	}
-	issuedAt := claims.IssuedAt
-	expiresAt := claims.ExpiresAt
 
-	prj.Spec.Roles[index].JWTTokens = append(prj.Spec.Roles[index].JWTTokens, v1alpha1.JWTToken{IssuedAt: issuedAt, ExpiresAt: expiresAt})
+	tokens := prj.Spec.Roles[roleIndex].JWTTokens
+	rotated := *oldToken
+	if q.GracePeriod > 0 {
+		if graceExpiresAt := now.Add(time.Duration(q.GracePeriod) * time.Second).Unix(); rotated.ExpiresAt == 0 || graceExpiresAt < rotated.ExpiresAt {
+			rotated.ExpiresAt = graceExpiresAt
+		}
+		tokens[oldTokenIndex] = rotated
+	} else {
+		tokens = append(tokens[:oldTokenIndex], tokens[oldTokenIndex+1:]...)
+	}
+	prj.Spec.Roles[roleIndex].JWTTokens = append(tokens, *token)
 	_, err = s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Update(prj)
 	if err != nil {
 		return nil, err
 	}
-	s.logEvent(prj, ctx, argo.EventReasonResourceCreated, "created token")
+	if q.GracePeriod == 0 && rotated.ID != "" {
+		if err := s.sessionMgr.RevokeToken(rotated.ID, rotated.ExpiresAt); err != nil {
+			log.Warnf("Failed to revoke rotated token %s: %v", rotated.ID, err)
+		}
+	}
+	s.logEvent(prj, ctx, argo.EventReasonResourceUpdated, fmt.Sprintf("rotated token %s of role %s, replaced by token %s%s", tokenDescription(&rotated), q.Role, token.ID, formatTokenExpiry(token)))
 	return &project.ProjectTokenResponse{Token: jwtToken}, nil
+}
 
+func tokenDescription(token *v1alpha1.JWTToken) string {
+	if token.ID != "" {
+		return token.ID
+	}
+	return fmt.Sprintf("issued at %d", token.IssuedAt)
 }
 
 // DeleteToken deletes a token in a project
@@ -133,17 +239,60 @@ func (s *Server) DeleteToken(ctx context.Context, q *project.ProjectTokenDeleteR
 			return nil, err
 		}
 	}
-	_, jwtTokenIndex, err := prj.GetJWTToken(q.Role, q.Iat)
+	jwtToken, jwtTokenIndex, err := findToken(prj, q.Role, q.Id, q.Iat)
 	if err != nil {
 		return &project.EmptyResponse{}, nil
 	}
+	deleted := *jwtToken
 	prj.Spec.Roles[roleIndex].JWTTokens[jwtTokenIndex] = prj.Spec.Roles[roleIndex].JWTTokens[len(prj.Spec.Roles[roleIndex].JWTTokens)-1]
 	prj.Spec.Roles[roleIndex].JWTTokens = prj.Spec.Roles[roleIndex].JWTTokens[:len(prj.Spec.Roles[roleIndex].JWTTokens)-1]
 	_, err = s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Update(prj)
 	if err != nil {
 		return nil, err
 	}
-	s.logEvent(prj, ctx, argo.EventReasonResourceDeleted, "deleted token")
+	if deleted.ID != "" {
+		if err := s.sessionMgr.RevokeToken(deleted.ID, deleted.ExpiresAt); err != nil {
+			log.Warnf("Failed to revoke deleted token %s: %v", deleted.ID, err)
+		}
+	}
+	s.logEvent(prj, ctx, argo.EventReasonResourceDeleted, fmt.Sprintf("deleted token %s of role %s", tokenDescription(&deleted), q.Role))
+	return &project.EmptyResponse{}, nil
+}
+
+// RevokeTokens deletes all tokens of a project role and revokes them immediately
+func (s *Server) RevokeTokens(ctx context.Context, q *project.ProjectTokensRevokeRequest) (*project.EmptyResponse, error) {
+	prj, err := s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Get(q.Project, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = validateProject(prj)
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectLock.Lock(q.Project)
+	defer s.projectLock.Unlock(q.Project)
+
+	role, roleIndex, err := prj.GetRoleByName(q.Role)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "project '%s' does not have role '%s'", q.Project, q.Role)
+	}
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceProjects, rbacpolicy.ActionUpdate, q.Project); err != nil {
+		if !jwtutil.IsMember(jwtutil.Claims(ctx.Value("claims")), role.Groups) {
+			return nil, err
+		}
+	}
+	if err := s.sessionMgr.RevokeSessions(fmt.Sprintf(JWTTokenSubFormat, q.Project, q.Role)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke tokens of role '%s': %v", q.Role, err)
+	}
+	if len(prj.Spec.Roles[roleIndex].JWTTokens) > 0 {
+		prj.Spec.Roles[roleIndex].JWTTokens = nil
+		_, err = s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).Update(prj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.logEvent(prj, ctx, argo.EventReasonResourceDeleted, fmt.Sprintf("revoked tokens of role %s", q.Role))
 	return &project.EmptyResponse{}, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
@@ -19,8 +20,11 @@ import (
 	"github.com/argoproj/argo-cd/pkg/apiclient/project"
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	apps "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
+	servercache "github.com/argoproj/argo-cd/server/cache"
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/assets"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
 	jwtutil "github.com/argoproj/argo-cd/util/jwt"
 	"github.com/argoproj/argo-cd/util/rbac"
 	"github.com/argoproj/argo-cd/util/session"
@@ -348,6 +352,105 @@ func TestProjectServer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("TestRevokeTokensSuccessfullyWithGroup", func(t *testing.T) {
+		sessionMgr := session.NewSessionManager(settingsMgr, "")
+		sessionMgr.SetTokenRevocationStore(servercache.NewCache(appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewInMemoryCache(time.Hour)), time.Hour), time.Hour, time.Hour))
+		projWithToken := existingProj.DeepCopy()
+		token := v1alpha1.ProjectRole{Name: tokenName, Groups: []string{"my-group"}, JWTTokens: []v1alpha1.JWTToken{{IssuedAt: 1}, {IssuedAt: 2}}}
+		projWithToken.Spec.Roles = append(projWithToken.Spec.Roles, token)
+
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(projWithToken), enforcer, util.NewKeyLock(), sessionMgr)
+		_, err := projectServer.RevokeTokens(ctx, &project.ProjectTokensRevokeRequest{Project: projWithToken.Name, Role: tokenName})
+		assert.NoError(t, err)
+		projWithoutToken, err := projectServer.appclientset.ArgoprojV1alpha1().AppProjects(projectServer.ns).Get(projWithToken.Name, v1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, projWithoutToken.Spec.Roles[0].JWTTokens, 0)
+
+		jwtToken, err := sessionMgr.Create(fmt.Sprintf(JWTTokenSubFormat, projWithToken.Name, tokenName), 0, "")
+		assert.NoError(t, err)
+		_, err = sessionMgr.VerifyToken(jwtToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("TestCreateTokenHasID", func(t *testing.T) {
+		sessionMgr := session.NewSessionManager(settingsMgr, "")
+		projectWithRole := existingProj.DeepCopy()
+		projectWithRole.Spec.Roles = []v1alpha1.ProjectRole{{Name: tokenName, Groups: []string{"my-group"}}}
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(projectWithRole), enforcer, util.NewKeyLock(), sessionMgr)
+		tokenResponse, err := projectServer.CreateToken(ctx, &project.ProjectTokenCreateRequest{Project: projectWithRole.Name, Role: tokenName, ExpiresIn: 100})
+		assert.NoError(t, err)
+		claims, err := sessionMgr.Parse(tokenResponse.Token)
+		assert.NoError(t, err)
+
+		proj, err := projectServer.appclientset.ArgoprojV1alpha1().AppProjects(projectServer.ns).Get(projectWithRole.Name, v1.GetOptions{})
+		assert.NoError(t, err)
+		mapClaims, err := jwtutil.MapClaims(claims)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, mapClaims["jti"])
+		assert.Equal(t, mapClaims["jti"], proj.Spec.Roles[0].JWTTokens[0].ID)
+	})
+
+	t.Run("TestRotateTokenWithGracePeriod", func(t *testing.T) {
+		sessionMgr := session.NewSessionManager(settingsMgr, "")
+		projWithToken := existingProj.DeepCopy()
+		issuedAt := time.Now().Unix()
+		token := v1alpha1.ProjectRole{Name: tokenName, Groups: []string{"my-group"}, JWTTokens: []v1alpha1.JWTToken{{IssuedAt: issuedAt, ExpiresAt: issuedAt + 3600, ID: "old"}}}
+		projWithToken.Spec.Roles = append(projWithToken.Spec.Roles, token)
+
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(projWithToken), enforcer, util.NewKeyLock(), sessionMgr)
+		tokenResponse, err := projectServer.RotateToken(ctx, &project.ProjectTokenRotateRequest{Project: projWithToken.Name, Role: tokenName, Id: "old", GracePeriod: 60})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokenResponse.Token)
+
+		proj, err := projectServer.appclientset.ArgoprojV1alpha1().AppProjects(projectServer.ns).Get(projWithToken.Name, v1.GetOptions{})
+		assert.NoError(t, err)
+		tokens := proj.Spec.Roles[0].JWTTokens
+		if assert.Len(t, tokens, 2) {
+			assert.Equal(t, "old", tokens[0].ID)
+			assert.True(t, tokens[0].ExpiresAt <= time.Now().Unix()+60)
+			assert.NotEqual(t, "old", tokens[1].ID)
+			// the new token gets the lifetime of the rotated one
+			assert.Equal(t, int64(3600), tokens[1].ExpiresAt-tokens[1].IssuedAt)
+		}
+	})
+
+	t.Run("TestRotateTokenWithoutGracePeriod", func(t *testing.T) {
+		sessionMgr := session.NewSessionManager(settingsMgr, "")
+		projWithToken := existingProj.DeepCopy()
+		token := v1alpha1.ProjectRole{Name: tokenName, Groups: []string{"my-group"}, JWTTokens: []v1alpha1.JWTToken{{IssuedAt: 1}}}
+		projWithToken.Spec.Roles = append(projWithToken.Spec.Roles, token)
+
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(projWithToken), enforcer, util.NewKeyLock(), sessionMgr)
+		_, err := projectServer.RotateToken(ctx, &project.ProjectTokenRotateRequest{Project: projWithToken.Name, Role: tokenName, Iat: 1})
+		assert.NoError(t, err)
+
+		proj, err := projectServer.appclientset.ArgoprojV1alpha1().AppProjects(projectServer.ns).Get(projWithToken.Name, v1.GetOptions{})
+		assert.NoError(t, err)
+		tokens := proj.Spec.Roles[0].JWTTokens
+		if assert.Len(t, tokens, 1) {
+			assert.NotEmpty(t, tokens[0].ID)
+			assert.Zero(t, tokens[0].ExpiresAt)
+		}
+
+		_, err = projectServer.RotateToken(ctx, &project.ProjectTokenRotateRequest{Project: projWithToken.Name, Role: tokenName, Id: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("TestDeleteTokenByID", func(t *testing.T) {
+		sessionMgr := session.NewSessionManager(settingsMgr, "")
+		projWithToken := existingProj.DeepCopy()
+		token := v1alpha1.ProjectRole{Name: tokenName, Groups: []string{"my-group"}, JWTTokens: []v1alpha1.JWTToken{{IssuedAt: 1, ID: "first"}, {IssuedAt: 1, ID: "second"}}}
+		projWithToken.Spec.Roles = append(projWithToken.Spec.Roles, token)
+
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(projWithToken), enforcer, util.NewKeyLock(), sessionMgr)
+		_, err := projectServer.DeleteToken(ctx, &project.ProjectTokenDeleteRequest{Project: projWithToken.Name, Role: tokenName, Id: "second"})
+		assert.NoError(t, err)
+
+		proj, err := projectServer.appclientset.ArgoprojV1alpha1().AppProjects(projectServer.ns).Get(projWithToken.Name, v1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []v1alpha1.JWTToken{{IssuedAt: 1, ID: "first"}}, proj.Spec.Roles[0].JWTTokens)
+	})
+
 	_ = enforcer.SetBuiltinPolicy(`p, role:admin, projects, get, *, allow
 p, role:admin, projects, update, *, allow`)
 
@@ -1,25 +1,32 @@
 package rbacpolicy
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/casbin/casbin/util"
 	jwt "github.com/dgrijalva/jwt-go"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	applister "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/argo"
 	jwtutil "github.com/argoproj/argo-cd/util/jwt"
 	"github.com/argoproj/argo-cd/util/rbac"
+	"github.com/argoproj/argo-cd/util/settings"
 )
 
 const (
 	// please add new items to Resources
-	ResourceClusters     = "clusters"
-	ResourceProjects     = "projects"
-	ResourceApplications = "applications"
-	ResourceRepositories = "repositories"
-	ResourceCertificates = "certificates"
-	ResourceAccounts     = "accounts"
+	ResourceClusters             = "clusters"
+	ResourceProjects             = "projects"
+	ResourceApplications         = "applications"
+	ResourceApplicationTemplates = "applicationtemplates"
+	ResourceRepositories         = "repositories"
+	ResourceCertificates         = "certificates"
+	ResourceAccounts             = "accounts"
+	ResourceExtensions           = "extensions"
 
 	// please add new items to Actions
 	ActionGet      = "get"
@@ -29,6 +36,9 @@ const (
 	ActionSync     = "sync"
 	ActionOverride = "override"
 	ActionAction   = "action"
+	ActionExec     = "exec"
+	ActionLogs     = "logs"
+	ActionInvoke   = "invoke"
 )
 
 var (
@@ -37,8 +47,11 @@ var (
 		ResourceClusters,
 		ResourceProjects,
 		ResourceApplications,
+		ResourceApplicationTemplates,
 		ResourceRepositories,
 		ResourceCertificates,
+		ResourceAccounts,
+		ResourceExtensions,
 	}
 	Actions = []string{
 		ActionGet,
@@ -47,16 +60,54 @@ var (
 		ActionDelete,
 		ActionSync,
 		ActionOverride,
+		ActionExec,
+		ActionLogs,
+		ActionInvoke,
 	}
 )
 
+// ValidatePolicy reports the syntax errors of a CSV policy, and the policies of unknown resources or actions
+func ValidatePolicy(policy string) []rbac.PolicyError {
+	return rbac.LintPolicy(policy, func(fields []string) error {
+		if fields[0] != "p" {
+			return nil
+		}
+		resource, action := fields[2], fields[3]
+		if !matchesAny(resource, Resources) {
+			return fmt.Errorf("unknown resource '%s', expected one of %v", resource, Resources)
+		}
+		if !strings.HasPrefix(action, ActionAction+"/") && !matchesAny(action, Actions) {
+			return fmt.Errorf("unknown action '%s', expected one of %v or %s/<group>/<kind>/<name>", action, Actions, ActionAction)
+		}
+		return nil
+	})
+}
+
+// matchesAny returns whether the policy pattern matches any of the values
+func matchesAny(pattern string, values []string) bool {
+	for _, value := range values {
+		if util.KeyMatch(value, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // RBACPolicyEnforcer provides an RBAC Claims Enforcer which additionally consults AppProject
 // roles, jwt tokens, and groups. It is backed by a AppProject informer/lister cache and does not
 // make any API calls during enforcement.
 type RBACPolicyEnforcer struct {
-	enf        *rbac.Enforcer
-	projLister applister.AppProjectNamespaceLister
-	scopes     []string
+	enf          *rbac.Enforcer
+	projLister   applister.AppProjectNamespaceLister
+	scopes       []string
+	settingsMgr  *settings.SettingsManager
+	syncedGroups SyncedGroups
+}
+
+// SyncedGroups returns the groups of a user which are synchronized from its identity provider, rather than embedded in
+// the claims of its token
+type SyncedGroups interface {
+	GetGroups(claims jwt.MapClaims) []string
 }
 
 // NewRBACPolicyEnforcer returns a new RBAC Enforcer for the Argo CD API Server
@@ -72,6 +123,18 @@ func (p *RBACPolicyEnforcer) SetScopes(scopes []string) {
 	p.scopes = scopes
 }
 
+// SetSettingsManager sets the settings manager which configures the global projects whose roles are
+// inherited by other projects
+func (p *RBACPolicyEnforcer) SetSettingsManager(settingsMgr *settings.SettingsManager) {
+	p.settingsMgr = settingsMgr
+}
+
+// SetSyncedGroups sets the source of the synchronized groups, which are evaluated in addition to the groups of the
+// tokens
+func (p *RBACPolicyEnforcer) SetSyncedGroups(syncedGroups SyncedGroups) {
+	p.syncedGroups = syncedGroups
+}
+
 func IsProjectSubject(subject string) bool {
 	return strings.HasPrefix(subject, "proj:")
 }
@@ -95,24 +158,19 @@ func (p *RBACPolicyEnforcer) EnforceClaims(claims jwt.Claims, rvals ...interface
 		runtimePolicy = proj.ProjectPoliciesString()
 	}
 
-	// Check the subject. This is typically the 'admin' case.
-	// NOTE: the call to EnforceRuntimePolicy will also consider the default role
-	vals := append([]interface{}{subject}, rvals[1:]...)
-	if p.enf.EnforceRuntimePolicy(runtimePolicy, vals...) {
-		return true
-	}
-
 	scopes := p.scopes
 	if scopes == nil {
 		scopes = defaultScopes
 	}
-	// Finally check if any of the user's groups grant them permissions
-	groups := jwtutil.GetScopeValues(mapClaims, scopes)
-	for _, group := range groups {
-		vals := append([]interface{}{group}, rvals[1:]...)
-		if p.enf.EnforceRuntimePolicy(runtimePolicy, vals...) {
-			return true
-		}
+	// Check the subject, which is typically the 'admin' case, and then if any of the user's groups
+	// grant them permissions. An explicit deny of the subject or any of the groups takes precedence.
+	// NOTE: the call to EnforceRuntimePolicySubjects will also consider the default role
+	subjects := append([]string{subject}, jwtutil.GetScopeValues(mapClaims, scopes)...)
+	if p.syncedGroups != nil {
+		subjects = append(subjects, p.syncedGroups.GetGroups(mapClaims)...)
+	}
+	if p.enf.EnforceRuntimePolicySubjects(runtimePolicy, subjects, rvals[1:]...) {
+		return true
 	}
 	logCtx := log.WithField("claims", claims).WithField("rval", rvals)
 	logCtx.Debug("enforce failed")
@@ -130,7 +188,7 @@ func (p *RBACPolicyEnforcer) getProjectFromRequest(rvals ...interface{}) *v1alph
 		if err != nil {
 			return nil
 		}
-		return proj
+		return argo.GetProjectWithGlobalProjects(proj, p.projLister, p.settingsMgr)
 	}
 	if res, ok := rvals[1].(string); ok {
 		if obj, ok := rvals[3].(string); ok {
@@ -159,15 +217,30 @@ func (p *RBACPolicyEnforcer) enforceProjectToken(subject string, claims jwt.MapC
 		// this should never happen (we generated a project token for a different project)
 		return false
 	}
-	iat, err := jwtutil.GetIssuedAt(claims)
-	if err != nil {
-		return false
+	var token *v1alpha1.JWTToken
+	var err error
+	if id := jwtutil.GetField(claims, "jti"); id != "" {
+		token, _, err = proj.GetJWTTokenByID(roleName, id)
+	} else {
+		var iat int64
+		iat, err = jwtutil.GetIssuedAt(claims)
+		if err != nil {
+			return false
+		}
+		token, _, err = proj.GetJWTToken(roleName, iat)
+		if err == nil && token.ID != "" {
+			// tokens with ID always carry it in the jti claim
+			return false
+		}
 	}
-	_, _, err = proj.GetJWTToken(roleName, iat)
 	if err != nil {
 		// if we get here the token is still valid, but has been revoked (no longer exists in the project)
 		return false
 	}
+	if token.IsExpired(time.Now()) {
+		// the token has been rotated and its grace period is over
+		return false
+	}
 	vals := append([]interface{}{subject}, rvals[1:]...)
 	return p.enf.EnforceRuntimePolicy(proj.ProjectPoliciesString(), vals...)
 
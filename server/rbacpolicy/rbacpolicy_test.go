@@ -3,6 +3,7 @@ package rbacpolicy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/assets"
 	"github.com/argoproj/argo-cd/util/rbac"
 )
 
@@ -76,6 +78,29 @@ func TestEnforceAllPolicies(t *testing.T) {
 	assert.True(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
 }
 
+func TestEnforceProjectTokenByID(t *testing.T) {
+	kubeclientset := fake.NewSimpleClientset(test.NewFakeConfigMap())
+	proj := newFakeProj()
+	proj.Spec.Roles[0].JWTTokens = []argoappv1.JWTToken{
+		{IssuedAt: 1234, ID: "current"},
+		{IssuedAt: 1234, ID: "rotated", ExpiresAt: time.Now().Add(-time.Minute).Unix()},
+		{IssuedAt: 1234, ID: "in-grace-period", ExpiresAt: time.Now().Add(time.Minute).Unix()},
+	}
+	projLister := test.NewFakeProjLister(proj)
+	enf := rbac.NewEnforcer(kubeclientset, test.FakeArgoCDNamespace, common.ArgoCDConfigMapName, nil)
+	rbacEnf := NewRBACPolicyEnforcer(enf, projLister)
+	enf.SetClaimsEnforcerFunc(rbacEnf.EnforceClaims)
+
+	claims := jwt.MapClaims{"sub": "proj:my-proj:my-role", "iat": 1234, "jti": "current"}
+	assert.True(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+	claims = jwt.MapClaims{"sub": "proj:my-proj:my-role", "iat": 1234, "jti": "in-grace-period"}
+	assert.True(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+	claims = jwt.MapClaims{"sub": "proj:my-proj:my-role", "iat": 1234, "jti": "rotated"}
+	assert.False(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+	claims = jwt.MapClaims{"sub": "proj:my-proj:my-role", "iat": 1234, "jti": "deleted"}
+	assert.False(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+}
+
 func TestEnforceActionActions(t *testing.T) {
 	kubeclientset := fake.NewSimpleClientset(test.NewFakeConfigMap())
 	projLister := test.NewFakeProjLister(newFakeProj())
@@ -108,3 +133,66 @@ p, cam, applications, %s/argoproj.io/Rollout/resume, my-proj/*, allow
 	claims = jwt.MapClaims{"sub": "eve"}
 	assert.False(t, enf.Enforce(claims, "applications", ActionAction+"/argoproj.io/Rollout/resume", "my-proj/my-app"))
 }
+
+func TestValidatePolicy(t *testing.T) {
+	assert.Empty(t, ValidatePolicy(assets.BuiltinPolicyCSV))
+	assert.Empty(t, ValidatePolicy(`p, alice, applications, action/argoproj.io/Rollout/*, my-proj/*, allow
+p, bob, *, *, *, allow
+p, cam, repo*, get, *, deny
+g, dan, role:admin`))
+
+	errs := ValidatePolicy(`p, alice, applicatons, get, my-proj/*, allow
+p, bob, applications, restart, my-proj/*, allow
+p, cam, applications, get, my-proj/*`)
+	if assert.Len(t, errs, 3) {
+		assert.Equal(t, 1, errs[0].Line)
+		assert.Contains(t, errs[0].Message, "unknown resource 'applicatons'")
+		assert.Equal(t, 2, errs[1].Line)
+		assert.Contains(t, errs[1].Message, "unknown action 'restart'")
+		assert.Equal(t, 3, errs[2].Line)
+	}
+}
+
+func TestEnforceGroupDeny(t *testing.T) {
+	kubeclientset := fake.NewSimpleClientset(test.NewFakeConfigMap())
+	projLister := test.NewFakeProjLister(newFakeProj())
+	enf := rbac.NewEnforcer(kubeclientset, test.FakeArgoCDNamespace, common.ArgoCDConfigMapName, nil)
+	_ = enf.SetUserPolicy(`p, alice, applications, sync, my-proj/*, allow
+p, my-org:contractors, applications, sync, my-proj/prod-app, deny`)
+	rbacEnf := NewRBACPolicyEnforcer(enf, projLister)
+	enf.SetClaimsEnforcerFunc(rbacEnf.EnforceClaims)
+
+	claims := jwt.MapClaims{"sub": "alice", "groups": []string{"my-org:contractors"}}
+	assert.True(t, enf.Enforce(claims, "applications", "sync", "my-proj/my-app"))
+	assert.False(t, enf.Enforce(claims, "applications", "sync", "my-proj/prod-app"))
+	claims = jwt.MapClaims{"sub": "alice"}
+	assert.True(t, enf.Enforce(claims, "applications", "sync", "my-proj/prod-app"))
+}
+
+type fakeSyncedGroups map[string][]string
+
+func (g fakeSyncedGroups) GetGroups(claims jwt.MapClaims) []string {
+	email, _ := claims["email"].(string)
+	return g[email]
+}
+
+func TestEnforceSyncedGroups(t *testing.T) {
+	kubeclientset := fake.NewSimpleClientset(test.NewFakeConfigMap())
+	projLister := test.NewFakeProjLister(newFakeProj())
+	enf := rbac.NewEnforcer(kubeclientset, test.FakeArgoCDNamespace, common.ArgoCDConfigMapName, nil)
+	_ = enf.SetBuiltinPolicy(`p, my-org:admins, applications, get, */*, allow`)
+	rbacEnf := NewRBACPolicyEnforcer(enf, projLister)
+	enf.SetClaimsEnforcerFunc(rbacEnf.EnforceClaims)
+
+	claims := jwt.MapClaims{"sub": "alice", "email": "alice@example.com"}
+	assert.False(t, enf.Enforce(claims, "applications", "get", "my-proj/my-app"))
+	assert.False(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+
+	rbacEnf.SetSyncedGroups(fakeSyncedGroups{"alice@example.com": {"my-org:admins", "my-org:my-team"}})
+	// a synchronized group granted by the global policy
+	assert.True(t, enf.Enforce(claims, "applications", "get", "my-proj/my-app"))
+	// a synchronized group bound to a project role
+	assert.True(t, enf.Enforce(claims, "applications", "create", "my-proj/my-app"))
+	claims = jwt.MapClaims{"sub": "bob", "email": "bob@example.com"}
+	assert.False(t, enf.Enforce(claims, "applications", "get", "my-proj/my-app"))
+}
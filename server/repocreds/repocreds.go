@@ -48,14 +48,16 @@ func (s *Server) ListRepositoryCredentials(ctx context.Context, q *repocredspkg.
 	items := make([]appsv1.RepoCreds, 0)
 	for _, url := range urls {
 		if s.enf.Enforce(ctx.Value("claims"), rbacpolicy.ResourceRepositories, rbacpolicy.ActionGet, url) {
-			repo, err := s.db.GetRepositoryCredentials(ctx, url)
+			repo, err := s.db.GetRepositoryCredentialsByURL(ctx, url)
 			if err != nil {
 				return nil, err
 			}
 			if repo != nil {
 				items = append(items, appsv1.RepoCreds{
-					URL:      url,
-					Username: repo.Username,
+					URL:       url,
+					Username:  repo.Username,
+					MatchType: repo.MatchType,
+					Priority:  repo.Priority,
 				})
 			}
 		}
@@ -78,7 +80,7 @@ func (s *Server) CreateRepositoryCredentials(ctx context.Context, q *repocredspk
 	_, err := s.db.CreateRepositoryCredentials(ctx, r)
 	if status.Convert(err).Code() == codes.AlreadyExists {
 		// act idempotent if existing spec matches new spec
-		existing, getErr := s.db.GetRepositoryCredentials(ctx, r.URL)
+		existing, getErr := s.db.GetRepositoryCredentialsByURL(ctx, r.URL)
 		if getErr != nil {
 			return nil, status.Errorf(codes.Internal, "unable to check existing repository credentials details: %v", getErr)
 		}
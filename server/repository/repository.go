@@ -19,6 +19,7 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/argo"
 	"github.com/argoproj/argo-cd/util/db"
+	"github.com/argoproj/argo-cd/util/git"
 	"github.com/argoproj/argo-cd/util/rbac"
 	"github.com/argoproj/argo-cd/util/settings"
 )
@@ -101,12 +102,13 @@ func (s *Server) ListRepositories(ctx context.Context, q *repositorypkg.RepoQuer
 			}
 			// remove secrets
 			items = append(items, &appsv1.Repository{
-				Repo:      repo.Repo,
-				Type:      rType,
-				Name:      repo.Name,
-				Username:  repo.Username,
-				Insecure:  repo.IsInsecure(),
-				EnableLFS: repo.EnableLFS,
+				Repo:          repo.Repo,
+				Type:          rType,
+				Name:          repo.Name,
+				Username:      repo.Username,
+				Insecure:      repo.IsInsecure(),
+				EnableLFS:     repo.EnableLFS,
+				CloudIdentity: repo.CloudIdentity,
 			})
 		}
 	}
@@ -120,6 +122,50 @@ func (s *Server) ListRepositories(ctx context.Context, q *repositorypkg.RepoQuer
 	return &appsv1.RepositoryList{Items: items}, nil
 }
 
+// Get returns a configured repository, without its secrets, and the state of its connection
+func (s *Server) Get(ctx context.Context, q *repositorypkg.RepoQuery) (*appsv1.Repository, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceRepositories, rbacpolicy.ActionGet, q.Repo); err != nil {
+		return nil, err
+	}
+	repos, err := s.db.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, repo := range repos {
+		if !git.SameURL(repo.Repo, q.Repo) {
+			continue
+		}
+		rType := repo.Type
+		if rType == "" {
+			rType = common.DefaultRepoType
+		}
+		return &appsv1.Repository{
+			Repo:            repo.Repo,
+			Type:            rType,
+			Name:            repo.Name,
+			Username:        repo.Username,
+			Insecure:        repo.IsInsecure(),
+			EnableLFS:       repo.EnableLFS,
+			CloudIdentity:   repo.CloudIdentity,
+			ConnectionState: s.getConnectionState(ctx, repo.Repo, q.ForceRefresh),
+		}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "repository '%s' not found", q.Repo)
+}
+
+// Diagnose checks step by step the connection to a repository with its configured credentials, or the ones of the
+// matching credential template, so that the cause of a failed connection can be told
+func (s *Server) Diagnose(ctx context.Context, q *repositorypkg.RepoQuery) (*appsv1.RepositoryDiagnostics, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceRepositories, rbacpolicy.ActionGet, q.Repo); err != nil {
+		return nil, err
+	}
+	repo, err := s.db.GetRepository(ctx, q.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return argo.DiagnoseRepo(repo), nil
+}
+
 // ListApps returns list of apps in the repo
 func (s *Server) ListApps(ctx context.Context, q *repositorypkg.RepoAppsQuery) (*repositorypkg.RepoAppsResponse, error) {
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceRepositories, rbacpolicy.ActionGet, q.Repo); err != nil {
@@ -309,6 +355,7 @@ func (s *Server) ValidateAccess(ctx context.Context, q *repositorypkg.RepoAccess
 		Insecure:          q.Insecure,
 		TLSClientCertData: q.TlsClientCertData,
 		TLSClientCertKey:  q.TlsClientCertKey,
+		CloudIdentity:     q.CloudIdentity,
 	}
 
 	var repoCreds *appsv1.RepoCreds
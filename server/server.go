@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"github.com/soheilhy/cmux"
@@ -31,6 +33,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v2"
@@ -46,6 +49,7 @@ import (
 	"github.com/argoproj/argo-cd/pkg/apiclient"
 	accountpkg "github.com/argoproj/argo-cd/pkg/apiclient/account"
 	applicationpkg "github.com/argoproj/argo-cd/pkg/apiclient/application"
+	applicationtemplatepkg "github.com/argoproj/argo-cd/pkg/apiclient/applicationtemplate"
 	certificatepkg "github.com/argoproj/argo-cd/pkg/apiclient/certificate"
 	clusterpkg "github.com/argoproj/argo-cd/pkg/apiclient/cluster"
 	projectpkg "github.com/argoproj/argo-cd/pkg/apiclient/project"
@@ -61,10 +65,12 @@ import (
 	repoapiclient "github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/server/account"
 	"github.com/argoproj/argo-cd/server/application"
+	"github.com/argoproj/argo-cd/server/applicationtemplate"
 	"github.com/argoproj/argo-cd/server/badge"
 	servercache "github.com/argoproj/argo-cd/server/cache"
 	"github.com/argoproj/argo-cd/server/certificate"
 	"github.com/argoproj/argo-cd/server/cluster"
+	"github.com/argoproj/argo-cd/server/extension"
 	"github.com/argoproj/argo-cd/server/project"
 	"github.com/argoproj/argo-cd/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/server/repocreds"
@@ -77,18 +83,24 @@ import (
 	"github.com/argoproj/argo-cd/util/db"
 	"github.com/argoproj/argo-cd/util/dex"
 	dexutil "github.com/argoproj/argo-cd/util/dex"
+	"github.com/argoproj/argo-cd/util/fips"
+	"github.com/argoproj/argo-cd/util/groupsync"
 	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/healthz"
 	httputil "github.com/argoproj/argo-cd/util/http"
 	jsonutil "github.com/argoproj/argo-cd/util/json"
+	jwtutil "github.com/argoproj/argo-cd/util/jwt"
 	"github.com/argoproj/argo-cd/util/jwt/zjwt"
 	"github.com/argoproj/argo-cd/util/kube"
+	logutils "github.com/argoproj/argo-cd/util/log"
 	"github.com/argoproj/argo-cd/util/oidc"
 	"github.com/argoproj/argo-cd/util/rbac"
 	util_session "github.com/argoproj/argo-cd/util/session"
 	settings_util "github.com/argoproj/argo-cd/util/settings"
+	"github.com/argoproj/argo-cd/util/settings/validation"
 	"github.com/argoproj/argo-cd/util/swagger"
 	tlsutil "github.com/argoproj/argo-cd/util/tls"
+	"github.com/argoproj/argo-cd/util/tracing"
 	"github.com/argoproj/argo-cd/util/webhook"
 )
 
@@ -120,24 +132,31 @@ var (
 type ArgoCDServer struct {
 	ArgoCDServerOpts
 
-	ssoClientApp   *oidc.ClientApp
+	ssoClientApp   *oidc.ClientAppSet
 	settings       *settings_util.ArgoCDSettings
 	log            *log.Entry
 	sessionMgr     *util_session.SessionManager
 	settingsMgr    *settings_util.SettingsManager
+	settingsCheck  *validation.Watcher
+	groupSyncer    *groupsync.Syncer
 	enf            *rbac.Enforcer
 	projInformer   cache.SharedIndexInformer
+	projLister     applisters.AppProjectNamespaceLister
 	policyEnforcer *rbacpolicy.RBACPolicyEnforcer
 	appInformer    cache.SharedIndexInformer
 	appLister      applisters.ApplicationNamespaceLister
+	rateLimiter    *grpc_util.RateLimiter
 
 	// stopCh is the channel which when closed, will shutdown the Argo CD server
 	stopCh chan struct{}
 }
 
 type ArgoCDServerOpts struct {
-	DisableAuth         bool
-	Insecure            bool
+	DisableAuth bool
+	Insecure    bool
+	// ListenHost is the host the API and metrics servers listen on, e.g. an IPv4 or IPv6 address. All IPv4 and IPv6
+	// interfaces are used if empty.
+	ListenHost          string
 	ListenPort          int
 	MetricsPort         int
 	Namespace           string
@@ -150,6 +169,12 @@ type ArgoCDServerOpts struct {
 	Cache               *servercache.Cache
 	TLSConfigCustomizer tlsutil.ConfigCustomizer
 	XFrameOptions       string
+	// RateLimitQPS is the number of API requests per second allowed for every account, and for every client IP address of unauthenticated requests. Zero disables rate limiting.
+	RateLimitQPS float64
+	// RateLimitBurst is the maximum number of API requests an account or client IP address may issue at once
+	RateLimitBurst int
+	// WebhookParallelism is the number of workers which process the queued webhook events
+	WebhookParallelism int
 }
 
 // initializeDefaultProject creates the default project if it does not already exist
@@ -175,9 +200,18 @@ func NewServer(ctx context.Context, opts ArgoCDServerOpts) *ArgoCDServer {
 	settingsMgr := settings_util.NewSettingsManager(ctx, opts.KubeClientset, opts.Namespace)
 	settings, err := settingsMgr.InitializeSettings(opts.Insecure)
 	errors.CheckError(err)
+	if !opts.Insecure {
+		// the certificate of the API server must be FIPS-approved too
+		errors.CheckError(fips.ValidateCertificate(settings.Certificate))
+	}
 	err = initializeDefaultProject(opts)
 	errors.CheckError(err)
 	sessionMgr := util_session.NewSessionManager(settingsMgr, opts.DexServerAddr)
+	if opts.Cache != nil {
+		sessionMgr.SetTokenRevocationStore(opts.Cache)
+		sessionMgr.SetTokenUsageStore(opts.Cache)
+		sessionMgr.SetLoginSessionStore(opts.Cache)
+	}
 
 	factory := appinformer.NewFilteredSharedInformerFactory(opts.AppClientset, 0, opts.Namespace, func(options *metav1.ListOptions) {})
 	projInformer := factory.Argoproj().V1alpha1().AppProjects().Informer()
@@ -193,6 +227,9 @@ func NewServer(ctx context.Context, opts ArgoCDServerOpts) *ArgoCDServer {
 	enf.EnableLog(os.Getenv(common.EnvVarRBACDebug) == "1")
 
 	policyEnf := rbacpolicy.NewRBACPolicyEnforcer(enf, projLister)
+	policyEnf.SetSettingsManager(settingsMgr)
+	groupSyncer := groupsync.NewSyncer(settingsMgr)
+	policyEnf.SetSyncedGroups(groupSyncer)
 	enf.SetClaimsEnforcerFunc(policyEnf.EnforceClaims)
 
 	return &ArgoCDServer{
@@ -201,11 +238,15 @@ func NewServer(ctx context.Context, opts ArgoCDServerOpts) *ArgoCDServer {
 		settings:         settings,
 		sessionMgr:       sessionMgr,
 		settingsMgr:      settingsMgr,
+		settingsCheck:    validation.NewWatcher(settingsMgr),
+		groupSyncer:      groupSyncer,
 		enf:              enf,
 		projInformer:     projInformer,
+		projLister:       projLister,
 		appInformer:      appInformer,
 		appLister:        appLister,
 		policyEnforcer:   policyEnf,
+		rateLimiter:      grpc_util.NewRateLimiter(opts.RateLimitQPS, opts.RateLimitBurst, rateLimitKey),
 	}
 }
 
@@ -224,13 +265,16 @@ func (a *ArgoCDServer) Run(ctx context.Context, port int, metricsPort int) {
 	} else {
 		httpS = a.newHTTPServer(ctx, port, grpcWebS)
 	}
-	metricsServ := newAPIServerMetricsServer(metricsPort)
+	metricsServ := newAPIServerMetricsServer(a.ListenHost, metricsPort)
+	if err := prometheus.Register(a.settingsCheck); err != nil {
+		log.Warnf("Failed to register the metrics of the settings validation: %v", err)
+	}
 
 	// Start listener
 	var conn net.Listener
 	var realErr error
 	_ = wait.ExponentialBackoff(backoff, func() (bool, error) {
-		conn, realErr = net.Listen("tcp", fmt.Sprintf(":%d", port))
+		conn, realErr = net.Listen("tcp", net.JoinHostPort(a.ListenHost, strconv.Itoa(port)))
 		if realErr != nil {
 			a.log.Warnf("failed listen: %v", realErr)
 			return false, nil
@@ -282,6 +326,8 @@ func (a *ArgoCDServer) Run(ctx context.Context, port int, metricsPort int) {
 	}
 	go a.watchSettings()
 	go a.rbacPolicyLoader(ctx)
+	go a.settingsCheck.Run(ctx)
+	go a.groupSyncer.Run(ctx)
 	go func() { a.checkServeErr("tcpm", tcpm.Serve()) }()
 	go func() { a.checkServeErr("metrics", metricsServ.ListenAndServe()) }()
 	if !cache.WaitForCacheSync(ctx.Done(), a.projInformer.HasSynced, a.appInformer.HasSynced) {
@@ -325,6 +371,7 @@ func (a *ArgoCDServer) watchSettings() {
 
 	prevURL := a.settings.URL
 	prevOIDCConfig := a.settings.OIDCConfigRAW
+	prevOIDCProviders := a.settings.OIDCProvidersRAW
 	prevDexCfgBytes, err := dex.GenerateDexConfigYAML(a.settings)
 	errors.CheckError(err)
 	prevGitHubSecret := a.settings.WebhookGitHubSecret
@@ -336,10 +383,12 @@ func (a *ArgoCDServer) watchSettings() {
 	if a.settings.Certificate != nil && !a.ArgoCDServerOpts.Insecure {
 		prevCert, prevCertKey = tlsutil.EncodeX509KeyPairString(*a.settings.Certificate)
 	}
+	overrideLogLevels(a.settings)
 
 	for {
 		newSettings := <-updateCh
 		a.settings = newSettings
+		overrideLogLevels(a.settings)
 		newDexCfgBytes, err := dex.GenerateDexConfigYAML(a.settings)
 		errors.CheckError(err)
 		if string(newDexCfgBytes) != string(prevDexCfgBytes) {
@@ -350,6 +399,10 @@ func (a *ArgoCDServer) watchSettings() {
 			log.Infof("odic config modified. restarting")
 			break
 		}
+		if prevOIDCProviders != a.settings.OIDCProvidersRAW {
+			log.Infof("oidc providers modified. restarting")
+			break
+		}
 		if prevURL != a.settings.URL {
 			log.Infof("url modified. restarting")
 			break
@@ -391,6 +444,13 @@ func (a *ArgoCDServer) watchSettings() {
 	close(updateCh)
 }
 
+// overrideLogLevels applies the log levels of the API server configured in argocd-cm
+func overrideLogLevels(argoSettings *settings_util.ArgoCDSettings) {
+	if err := logutils.OverrideLevels(argoSettings.LogLevels[settings_util.LogLevelComponentServer]); err != nil {
+		log.Warnf("Invalid log levels of the API server: %v", err)
+	}
+}
+
 func (a *ArgoCDServer) rbacPolicyLoader(ctx context.Context) {
 	err := a.enf.RunPolicyLoader(ctx, func(cm *v1.ConfigMap) error {
 		var scopes []string
@@ -441,10 +501,13 @@ func (a *ArgoCDServer) newGRPCServer() *grpc.Server {
 	// NOTE: notice we do not configure the gRPC server here with TLS (e.g. grpc.Creds(creds))
 	// This is because TLS handshaking occurs in cmux handling
 	sOpts = append(sOpts, grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+		tracing.StreamServerInterceptor(),
+		grpc_util.CorrelationIDStreamServerInterceptor(true),
 		grpc_logrus.StreamServerInterceptor(a.log),
 		grpc_prometheus.StreamServerInterceptor,
-		grpc_auth.StreamServerInterceptor(a.Authenticate),
+		grpc_auth.StreamServerInterceptor(a.authenticateRateLimited),
 		grpc_util.UserAgentStreamServerInterceptor(common.ArgoCDUserAgentName, clientConstraint),
+		grpc_util.RateLimitStreamServerInterceptor(a.rateLimiter),
 		grpc_util.PayloadStreamServerInterceptor(a.log, true, func(ctx netCtx.Context, fullMethodName string, servingObject interface{}) bool {
 			return !sensitiveMethods[fullMethodName]
 		}),
@@ -453,10 +516,13 @@ func (a *ArgoCDServer) newGRPCServer() *grpc.Server {
 	)))
 	sOpts = append(sOpts, grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 		bug21955WorkaroundInterceptor,
+		tracing.UnaryServerInterceptor(),
+		grpc_util.CorrelationIDUnaryServerInterceptor(true),
 		grpc_logrus.UnaryServerInterceptor(a.log),
 		grpc_prometheus.UnaryServerInterceptor,
-		grpc_auth.UnaryServerInterceptor(a.Authenticate),
+		grpc_auth.UnaryServerInterceptor(a.authenticateRateLimited),
 		grpc_util.UserAgentUnaryServerInterceptor(common.ArgoCDUserAgentName, clientConstraint),
+		grpc_util.RateLimitUnaryServerInterceptor(a.rateLimiter),
 		grpc_util.PayloadUnaryServerInterceptor(a.log, true, func(ctx netCtx.Context, fullMethodName string, servingObject interface{}) bool {
 			return !sensitiveMethods[fullMethodName]
 		}),
@@ -471,14 +537,16 @@ func (a *ArgoCDServer) newGRPCServer() *grpc.Server {
 	repoCredsService := repocreds.NewServer(a.RepoClientset, db, a.enf, a.settingsMgr)
 	sessionService := session.NewServer(a.sessionMgr, a)
 	projectLock := util.NewKeyLock()
-	applicationService := application.NewServer(a.Namespace, a.KubeClientset, a.AppClientset, a.appLister, a.RepoClientset, a.Cache, kubectl, db, a.enf, projectLock, a.settingsMgr)
+	applicationService := application.NewServer(a.Namespace, a.KubeClientset, a.AppClientset, a.appLister, a.projLister, a.RepoClientset, a.Cache, kubectl, db, a.enf, projectLock, a.settingsMgr)
+	applicationTemplateService := applicationtemplate.NewServer(a.Namespace, a.AppClientset, applicationService, a.enf)
 	projectService := project.NewServer(a.Namespace, a.KubeClientset, a.AppClientset, a.enf, projectLock, a.sessionMgr)
-	settingsService := settings.NewServer(a.settingsMgr)
+	settingsService := settings.NewServer(a.settingsMgr, a.enf, a)
 	accountService := account.NewServer(a.sessionMgr, a.settingsMgr, a.enf)
 	certificateService := certificate.NewServer(a.RepoClientset, db, a.enf)
 	versionpkg.RegisterVersionServiceServer(grpcS, &version.Server{})
 	clusterpkg.RegisterClusterServiceServer(grpcS, clusterService)
 	applicationpkg.RegisterApplicationServiceServer(grpcS, applicationService)
+	applicationtemplatepkg.RegisterApplicationTemplateServiceServer(grpcS, applicationTemplateService)
 	repositorypkg.RegisterRepositoryServiceServer(grpcS, repoService)
 	repocredspkg.RegisterRepoCredsServiceServer(grpcS, repoCredsService)
 	sessionpkg.RegisterSessionServiceServer(grpcS, sessionService)
@@ -560,11 +628,13 @@ func (a *ArgoCDServer) newHTTPServer(ctx context.Context, port int, grpcWebHandl
 	// we use our own Marshaler
 	gwMuxOpts := runtime.WithMarshalerOption(runtime.MIMEWildcard, new(jsonutil.JSONMarshaler))
 	gwCookieOpts := runtime.WithForwardResponseOption(a.translateGrpcCookieHeader)
-	gwmux := runtime.NewServeMux(gwMuxOpts, gwCookieOpts)
+	gwErrorOpts := runtime.WithProtoErrorHandler(rateLimitHTTPError)
+	gwmux := runtime.NewServeMux(gwMuxOpts, gwCookieOpts, gwErrorOpts)
 	mux.Handle("/api/", gwmux)
 	mustRegisterGWHandler(versionpkg.RegisterVersionServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
 	mustRegisterGWHandler(clusterpkg.RegisterClusterServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
 	mustRegisterGWHandler(applicationpkg.RegisterApplicationServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
+	mustRegisterGWHandler(applicationtemplatepkg.RegisterApplicationTemplateServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
 	mustRegisterGWHandler(repositorypkg.RegisterRepositoryServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
 	mustRegisterGWHandler(repocredspkg.RegisterRepoCredsServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
 	mustRegisterGWHandler(sessionpkg.RegisterSessionServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dOpts)
@@ -575,16 +645,28 @@ func (a *ArgoCDServer) newHTTPServer(ctx context.Context, port int, grpcWebHandl
 
 	// Swagger UI
 	swagger.ServeSwaggerUI(mux, assets.SwaggerJSON, "/swagger-ui")
-	healthz.ServeHealthCheck(mux, func() error {
+	checkKubernetes := func() error {
 		_, err := a.KubeClientset.(*kubernetes.Clientset).ServerVersion()
 		return err
-	})
+	}
+	healthz.ServeHealthCheck(mux, checkKubernetes,
+		healthz.Check{Name: healthz.CheckKubernetes, Check: checkKubernetes},
+		healthz.Check{Name: healthz.CheckRedis, Check: a.Cache.Ping},
+		healthz.Check{Name: healthz.CheckConfig, Check: a.settingsCheck.Check},
+		healthz.Check{Name: healthz.CheckRepoServer, Check: func() error {
+			return repoapiclient.Ping(a.RepoClientset, healthz.CheckTimeout)
+		}},
+	)
 
 	// Dex reverse proxy and client app and OAuth2 login/callback
 	a.registerDexHandlers(mux)
 
+	// Proxy extensions
+	mux.Handle(extension.URLPrefix, extension.NewHandler(a.settingsMgr, a.appLister, a.enf, a.authenticateHTTPRequest))
+
 	// Webhook handler for git events
 	acdWebhookHandler := webhook.NewHandler(a.Namespace, a.AppClientset, a.settings)
+	go acdWebhookHandler.Run(ctx, a.WebhookParallelism)
 	mux.HandleFunc("/api/webhook", acdWebhookHandler.Handler)
 
 	// Serve cli binaries directly from API server
@@ -609,7 +691,7 @@ func (a *ArgoCDServer) registerDexHandlers(mux *http.ServeMux) {
 		tlsConfig := a.settings.TLSConfig()
 		tlsConfig.InsecureSkipVerify = true
 	}
-	a.ssoClientApp, err = oidc.NewClientApp(a.settings, a.Cache, a.DexServerAddr, a.BaseHRef)
+	a.ssoClientApp, err = oidc.NewClientAppSet(a.settings, a.Cache, a.DexServerAddr, a.BaseHRef)
 	errors.CheckError(err)
 	mux.HandleFunc(common.LoginEndpoint, a.ssoClientApp.HandleLogin)
 	mux.HandleFunc(common.CallbackEndpoint, a.ssoClientApp.HandleCallback)
@@ -618,7 +700,7 @@ func (a *ArgoCDServer) registerDexHandlers(mux *http.ServeMux) {
 // newRedirectServer returns an HTTP server which does a 307 redirect to the HTTPS server
 func newRedirectServer(port int) *http.Server {
 	return &http.Server{
-		Addr: fmt.Sprintf("localhost:%d", port),
+		Addr: net.JoinHostPort("localhost", strconv.Itoa(port)),
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			target := "https://" + req.Host + req.URL.Path
 			if len(req.URL.RawQuery) > 0 {
@@ -688,11 +770,11 @@ func indexFilePath(srcPath string, baseHRef string) (string, error) {
 }
 
 // newAPIServerMetricsServer returns HTTP server which serves prometheus metrics on gRPC requests
-func newAPIServerMetricsServer(port int) *http.Server {
+func newAPIServerMetricsServer(host string, port int) *http.Server {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
 	return &http.Server{
-		Addr:    fmt.Sprintf("0.0.0.0:%d", port),
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
 		Handler: mux,
 	}
 }
@@ -773,6 +855,92 @@ func (a *ArgoCDServer) Authenticate(ctx context.Context) (context.Context, error
 	return ctx, nil
 }
 
+// authenticateHTTPRequest authenticates a plain HTTP request using the token of its Authorization header or cookie
+func (a *ArgoCDServer) authenticateHTTPRequest(r *http.Request) (context.Context, error) {
+	md := metadata.MD{
+		"authorization":      r.Header["Authorization"],
+		"grpcgateway-cookie": r.Header["Cookie"],
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md["x-forwarded-for"] = []string{host}
+	}
+	return a.Authenticate(metadata.NewIncomingContext(r.Context(), md))
+}
+
+// authenticateRateLimited authenticates a request like Authenticate, and counts requests which fail to authenticate
+// against the rate limit of their client IP address, since they never reach the rate limit interceptor
+func (a *ArgoCDServer) authenticateRateLimited(ctx context.Context) (context.Context, error) {
+	newCtx, err := a.Authenticate(ctx)
+	if err != nil && a.rateLimiter.Enabled() {
+		fullMethod, _ := grpc.Method(ctx)
+		if limitErr := a.rateLimiter.Enforce(clientIPRateLimitKey(ctx), fullMethod); limitErr != nil {
+			return newCtx, limitErr
+		}
+	}
+	return newCtx, err
+}
+
+// rateLimitKey identifies the caller of an API request by the subject of its token, i.e. by account, so that logging in
+// again or creating another token does not reset the limit. Unauthenticated requests, e.g. logins, are limited by
+// their client IP address.
+func rateLimitKey(ctx context.Context) string {
+	if claims, ok := ctx.Value("claims").(jwt.Claims); ok && claims != nil {
+		if mapClaims, err := jwtutil.MapClaims(claims); err == nil {
+			if sub := jwtutil.GetField(mapClaims, "sub"); sub != "" {
+				return sub
+			}
+		}
+	}
+	return clientIPRateLimitKey(ctx)
+}
+
+// clientIPRateLimitKey returns the rate limit key of the client IP address of a request. Requests of the gRPC gateway
+// and of the HTTP handlers of the API server come from the loopback address, so the last address of their
+// X-Forwarded-For header is used, which is the address the request was received from rather than one the client may
+// have set. Behind a proxy, this is the address of the proxy.
+func clientIPRateLimitKey(ctx context.Context) string {
+	var ip net.IP
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := p.Addr.(*net.TCPAddr); ok {
+			ip = addr.IP
+		}
+	}
+	if ip == nil || ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if fwd := md.Get("x-forwarded-for"); len(fwd) > 0 {
+				addrs := strings.Split(fwd[len(fwd)-1], ",")
+				if forwarded := net.ParseIP(strings.TrimSpace(addrs[len(addrs)-1])); forwarded != nil {
+					ip = forwarded
+				}
+			}
+		}
+	}
+	if ip == nil {
+		return ""
+	}
+	return "ip:" + ip.String()
+}
+
+// tooManyRequestsResponseWriter replaces the status code of the response with 429 Too Many Requests
+type tooManyRequestsResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *tooManyRequestsResponseWriter) WriteHeader(int) {
+	w.Header().Set("Retry-After", "1")
+	w.ResponseWriter.WriteHeader(http.StatusTooManyRequests)
+}
+
+// rateLimitHTTPError responds with 429 Too Many Requests to requests rejected by the rate limiter.
+// grpc-gateway would otherwise translate ResourceExhausted errors to 403 Forbidden. Other errors are
+// handled by the default handler, so that the error bodies stay the same.
+func rateLimitHTTPError(ctx netCtx.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if s, ok := status.FromError(err); ok && s.Code() == codes.ResourceExhausted {
+		w = &tooManyRequestsResponseWriter{ResponseWriter: w}
+	}
+	runtime.DefaultHTTPError(ctx, mux, marshaler, w, r, err)
+}
+
 func (a *ArgoCDServer) getClaims(ctx context.Context) (jwt.Claims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,8 +16,12 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -28,6 +33,7 @@ import (
 	"github.com/argoproj/argo-cd/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/test"
 	"github.com/argoproj/argo-cd/util/assets"
+	grpc_util "github.com/argoproj/argo-cd/util/grpc"
 	"github.com/argoproj/argo-cd/util/rbac"
 )
 
@@ -604,3 +610,52 @@ func TestTranslateGrpcCookieHeader(t *testing.T) {
 	})
 
 }
+
+func TestRateLimitKey(t *testing.T) {
+	assert.Equal(t, "", rateLimitKey(context.Background()))
+
+	ctx := context.WithValue(context.Background(), "claims", &jwt.StandardClaims{Subject: "admin"})
+	assert.Equal(t, "admin", rateLimitKey(ctx))
+
+	// the tokens and login sessions of an account share its limit
+	ctx = context.WithValue(context.Background(), "claims", jwt.MapClaims{"sub": "ci", "jti": "build"})
+	assert.Equal(t, "ci", rateLimitKey(ctx))
+
+	t.Run("UnauthenticatedByPeer", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "192.168.0.1"))
+		assert.Equal(t, "ip:10.0.0.1", rateLimitKey(ctx))
+	})
+	t.Run("UnauthenticatedByGateway", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000}})
+		// the gateway appends the address of the connection to the header sent by the client
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "192.168.0.1, 10.0.0.2"))
+		assert.Equal(t, "ip:10.0.0.2", rateLimitKey(ctx))
+	})
+}
+
+func TestAuthenticateRateLimited(t *testing.T) {
+	s := fakeServer()
+	s.DisableAuth = false
+	s.rateLimiter = grpc_util.NewRateLimiter(0.001, 1, rateLimitKey)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer invalid"))
+
+	_, err := s.authenticateRateLimited(ctx)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = s.authenticateRateLimited(ctx)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimitHTTPError(t *testing.T) {
+	marshaler := &runtime.JSONPb{}
+
+	w := httptest.NewRecorder()
+	rateLimitHTTPError(context.Background(), runtime.NewServeMux(), marshaler, w, httptest.NewRequest("GET", "/api/v1/applications", nil), status.Errorf(codes.ResourceExhausted, "rate limit exceeded"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	rateLimitHTTPError(context.Background(), runtime.NewServeMux(), marshaler, w, httptest.NewRequest("GET", "/api/v1/applications", nil), status.Errorf(codes.PermissionDenied, "permission denied"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -3,20 +3,34 @@ package settings
 import (
 	"github.com/ghodss/yaml"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/argoproj/argo-cd/common"
 	settingspkg "github.com/argoproj/argo-cd/pkg/apiclient/settings"
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	logutils "github.com/argoproj/argo-cd/util/log"
+	"github.com/argoproj/argo-cd/util/rbac"
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
+// Authenticator authenticates the requests of the methods of the service which require authentication
+type Authenticator interface {
+	Authenticate(ctx context.Context) (context.Context, error)
+}
+
 // Server provides a Settings service
 type Server struct {
-	mgr *settings.SettingsManager
+	mgr           *settings.SettingsManager
+	enf           *rbac.Enforcer
+	authenticator Authenticator
 }
 
 // NewServer returns a new instance of the Settings service
-func NewServer(mgr *settings.SettingsManager) *Server {
-	return &Server{mgr: mgr}
+func NewServer(mgr *settings.SettingsManager, enf *rbac.Enforcer, authenticator Authenticator) *Server {
+	return &Server{mgr: mgr, enf: enf, authenticator: authenticator}
 }
 
 // Get returns Argo CD settings
@@ -89,20 +103,31 @@ func (s *Server) Get(ctx context.Context, q *settingspkg.SettingsQuery) (*settin
 		}
 	}
 	if oidcConfig := argoCDSettings.OIDCConfig(); oidcConfig != nil {
-		set.OIDCConfig = &settingspkg.OIDCConfig{
-			Name:        oidcConfig.Name,
-			Issuer:      oidcConfig.Issuer,
-			ClientID:    oidcConfig.ClientID,
-			CLIClientID: oidcConfig.CLIClientID,
-			Scopes:      oidcConfig.RequestedScopes,
-		}
-		if len(argoCDSettings.OIDCConfig().RequestedIDTokenClaims) > 0 {
-			set.OIDCConfig.IDTokenClaims = argoCDSettings.OIDCConfig().RequestedIDTokenClaims
-		}
+		set.OIDCConfig = toOIDCConfig(oidcConfig)
+	}
+	additionalOIDCConfigs := argoCDSettings.AdditionalOIDCConfigs()
+	for i := range additionalOIDCConfigs {
+		set.AdditionalOIDCConfigs = append(set.AdditionalOIDCConfigs, toOIDCConfig(&additionalOIDCConfigs[i]))
 	}
 	return &set, nil
 }
 
+// toOIDCConfig returns the public part of an OIDC provider configuration
+func toOIDCConfig(oidcConfig *settings.OIDCConfig) *settingspkg.OIDCConfig {
+	res := &settingspkg.OIDCConfig{
+		Name:                     oidcConfig.Name,
+		Issuer:                   oidcConfig.Issuer,
+		ClientID:                 oidcConfig.ClientID,
+		CLIClientID:              oidcConfig.CLIClientID,
+		Scopes:                   oidcConfig.RequestedScopes,
+		EnablePKCEAuthentication: oidcConfig.EnablePKCEAuthentication,
+	}
+	if len(oidcConfig.RequestedIDTokenClaims) > 0 {
+		res.IDTokenClaims = oidcConfig.RequestedIDTokenClaims
+	}
+	return res
+}
+
 func (s *Server) plugins() ([]*settingspkg.Plugin, error) {
 	in, err := s.mgr.GetConfigManagementPlugins()
 	if err != nil {
@@ -116,7 +141,118 @@ func (s *Server) plugins() ([]*settingspkg.Plugin, error) {
 	return out, nil
 }
 
-// AuthFuncOverride disables authentication for settings service
+// getRBACPolicy returns the policy and the default role configured in argocd-rbac-cm
+func (s *Server) getRBACPolicy() (string, string, error) {
+	cm, err := s.mgr.GetConfigMapByName(common.ArgoCDRBACConfigMapName)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	return cm.Data[rbac.ConfigMapPolicyCSVKey], cm.Data[rbac.ConfigMapPolicyDefaultKey], nil
+}
+
+// ValidateRBACPolicy reports the syntax errors, unknown resources and unknown actions of an RBAC policy
+func (s *Server) ValidateRBACPolicy(ctx context.Context, q *settingspkg.RBACPolicyValidateRequest) (*settingspkg.RBACPolicyValidateResponse, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionUpdate, "*"); err != nil {
+		return nil, err
+	}
+	policy := q.Policy
+	if policy == "" {
+		var err error
+		if policy, _, err = s.getRBACPolicy(); err != nil {
+			return nil, err
+		}
+	}
+	res := &settingspkg.RBACPolicyValidateResponse{}
+	for _, err := range rbacpolicy.ValidatePolicy(policy) {
+		res.Errors = append(res.Errors, &settingspkg.RBACPolicyError{Line: int32(err.Line), Message: err.Message})
+	}
+	return res, nil
+}
+
+// CanRBACPolicy returns whether an RBAC policy permits a subject to perform an action on an object
+func (s *Server) CanRBACPolicy(ctx context.Context, q *settingspkg.RBACPolicyCanRequest) (*settingspkg.RBACPolicyCanResponse, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionUpdate, "*"); err != nil {
+		return nil, err
+	}
+	if q.Subject == "" || q.Resource == "" || q.Action == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject, resource and action are required")
+	}
+	policy, defaultRole := q.Policy, q.DefaultRole
+	if policy == "" {
+		var err error
+		if policy, defaultRole, err = s.getRBACPolicy(); err != nil {
+			return nil, err
+		}
+	}
+	allowed, err := s.enf.EnforcePolicy(policy, defaultRole, q.Subject, q.Resource, q.Action, q.Object)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &settingspkg.RBACPolicyCanResponse{Allowed: allowed}, nil
+}
+
+// GetLogLevels returns the log levels overriding the levels set by the flags of the components
+func (s *Server) GetLogLevels(ctx context.Context, q *settingspkg.LogLevelsQuery) (*settingspkg.LogLevels, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionUpdate, "*"); err != nil {
+		return nil, err
+	}
+	return s.getLogLevels()
+}
+
+func (s *Server) getLogLevels() (*settingspkg.LogLevels, error) {
+	components, err := s.mgr.GetLogLevels()
+	if err != nil {
+		return nil, err
+	}
+	return &settingspkg.LogLevels{Components: components, Modules: logutils.Modules}, nil
+}
+
+// UpdateLogLevels overrides the log levels of a component at runtime. The API server and the application controller
+// apply them when they are notified of the change of argocd-cm, the repo server when the kubelet updates its mount.
+func (s *Server) UpdateLogLevels(ctx context.Context, q *settingspkg.LogLevelsUpdateRequest) (*settingspkg.LogLevels, error) {
+	if err := s.enf.EnforceErr(ctx.Value("claims"), rbacpolicy.ResourceAccounts, rbacpolicy.ActionUpdate, "*"); err != nil {
+		return nil, err
+	}
+	if !isLogLevelComponent(q.Component) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown component '%s', expected one of %v", q.Component, settings.LogLevelComponents)
+	}
+	if _, err := logutils.ParseLevels(q.Levels); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.mgr.SaveLogLevels(q.Component, q.Levels); err != nil {
+		return nil, err
+	}
+	res, err := s.getLogLevels()
+	if err != nil {
+		return nil, err
+	}
+	// the informer of argocd-cm might not have seen the update yet
+	if q.Levels == "" {
+		delete(res.Components, q.Component)
+	} else {
+		res.Components[q.Component] = q.Levels
+	}
+	return res, nil
+}
+
+func isLogLevelComponent(component string) bool {
+	for _, c := range settings.LogLevelComponents {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthFuncOverride disables authentication for settings service, except for the RBAC policy and log level methods
 func (s *Server) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	switch fullMethodName {
+	case "/cluster.SettingsService/ValidateRBACPolicy", "/cluster.SettingsService/CanRBACPolicy",
+		"/cluster.SettingsService/GetLogLevels", "/cluster.SettingsService/UpdateLogLevels":
+		return s.authenticator.Authenticate(ctx)
+	}
 	return ctx, nil
 }
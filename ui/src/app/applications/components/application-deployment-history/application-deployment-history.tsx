@@ -68,14 +68,19 @@ export const ApplicationDeploymentHistory = ({
                                     source={{...recentDeployments[index].source, targetRevision: recentDeployments[index].revision}}
                                 />
                                 <DataLoader
-                                    input={{...recentDeployments[index].source, targetRevision: recentDeployments[index].revision}}
-                                    load={src => services.repos.appDetails(src)}>
-                                    {(details: models.RepoAppDetails) => (
+                                    input={recentDeployments[index]}
+                                    load={async info => {
+                                        // the source of older entries is stored compressed, so the full source is loaded from the API
+                                        const source = info.compressedSource ? (await services.applications.revisionHistory(app.metadata.name, info.id)).source : info.source;
+                                        const details = await services.repos.appDetails({...source, targetRevision: info.revision});
+                                        return {source, details};
+                                    }}>
+                                    {({source, details}: {source: models.ApplicationSource; details: models.RepoAppDetails}) => (
                                         <div>
                                             <ApplicationParameters
                                                 application={{
                                                     ...app,
-                                                    spec: {...app.spec, source: recentDeployments[index].source}
+                                                    spec: {...app.spec, source}
                                                 }}
                                                 details={details}
                                             />
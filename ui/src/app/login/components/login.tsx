@@ -47,7 +47,9 @@ export class Login extends React.Component<RouteComponentProps<{}>, State> {
 
     public render() {
         const authSettings = this.state.authSettings;
-        const ssoConfigured = authSettings && ((authSettings.dexConfig && (authSettings.dexConfig.connectors || []).length > 0) || authSettings.oidcConfig);
+        const additionalProviders = (authSettings && authSettings.additionalOIDCConfigs) || [];
+        const defaultSSOConfigured = authSettings && ((authSettings.dexConfig && (authSettings.dexConfig.connectors || []).length > 0) || authSettings.oidcConfig);
+        const ssoConfigured = defaultSSOConfigured || additionalProviders.length > 0;
         return (
             <div className='login'>
                 <div className='login__content'>
@@ -60,14 +62,26 @@ export class Login extends React.Component<RouteComponentProps<{}>, State> {
                     </div>
                     {ssoConfigured && (
                         <div className='login__box_saml width-control'>
-                            <a href={`auth/login?return_url=${encodeURIComponent(this.state.returnUrl)}`}>
-                                <button className='argo-button argo-button--base argo-button--full-width argo-button--xlg'>
-                                    {(authSettings.oidcConfig && <span>Login via {authSettings.oidcConfig.name}</span>) ||
-                                        (authSettings.dexConfig.connectors.length === 1 && <span>Login via {authSettings.dexConfig.connectors[0].name}</span>) || (
-                                            <span>SSO Login</span>
-                                        )}
-                                </button>
-                            </a>
+                            {defaultSSOConfigured && (
+                                <a href={`auth/login?return_url=${encodeURIComponent(this.state.returnUrl)}`}>
+                                    <button className='argo-button argo-button--base argo-button--full-width argo-button--xlg'>
+                                        {(authSettings.oidcConfig && <span>Login via {authSettings.oidcConfig.name}</span>) ||
+                                            (authSettings.dexConfig.connectors.length === 1 && <span>Login via {authSettings.dexConfig.connectors[0].name}</span>) || (
+                                                <span>SSO Login</span>
+                                            )}
+                                    </button>
+                                </a>
+                            )}
+                            {additionalProviders.map(provider => (
+                                <a
+                                    key={provider.name}
+                                    className='login__provider'
+                                    href={`auth/login?provider=${encodeURIComponent(provider.name)}&return_url=${encodeURIComponent(this.state.returnUrl)}`}>
+                                    <button className='argo-button argo-button--base argo-button--full-width argo-button--xlg'>
+                                        <span>Login via {provider.name}</span>
+                                    </button>
+                                </a>
+                            ))}
                             {this.state.ssoLoginError && <div className='argo-form-row__error-msg'>{this.state.ssoLoginError}</div>}
                             {authSettings && !authSettings.userLoginsDisabled && (
                                 <div className='login__saml-separator'>
@@ -37,9 +37,9 @@ export const ProjectRoleJWTTokens = (props: ProjectRoleJWTTokensProps) => {
                                 </div>
                             </div>
                             {props.tokens.map((jwtToken: JwtToken) => (
-                                <div className='argo-table-list__row' key={`${jwtToken.iat}`}>
+                                <div className='argo-table-list__row' key={jwtToken.id || `${jwtToken.iat}`}>
                                     <div className='row'>
-                                        <div className='columns small-3'>{jwtToken.iat}</div>
+                                        <div className='columns small-3'>{jwtToken.id || jwtToken.iat}</div>
                                         <div className='columns small-4'>{new Date(jwtToken.iat * 1000).toISOString()}</div>
                                         <div className='columns small-4'>{jwtToken.exp == null ? 'None' : new Date(jwtToken.exp * 1000).toISOString()}</div>
                                         <div className='columns small-1'>
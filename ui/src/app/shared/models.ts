@@ -243,6 +243,7 @@ export interface RevisionHistory {
     id: number;
     revision: string;
     source: ApplicationSource;
+    compressedSource?: string;
     deployedAt: models.Time;
 }
 
@@ -365,6 +366,7 @@ export interface ApplicationStatus {
 export interface LogEntry {
     content: string;
     timeStamp: models.Time;
+    podName?: string;
 }
 
 // describes plugin settings
@@ -388,6 +390,9 @@ export interface AuthSettings {
     oidcConfig: {
         name: string;
     };
+    additionalOIDCConfigs: {
+        name: string;
+    }[];
     help: {
         chatUrl: string;
         chatText: string;
@@ -575,6 +580,7 @@ export interface ProjectRole {
 
 export interface JwtToken {
     iat: number;
+    id?: string;
     exp: number;
 }
 
@@ -592,6 +598,25 @@ export interface ProjectSpec {
     namespaceResourceBlacklist: GroupKind[];
     orphanedResources?: {warn?: boolean};
     syncWindows?: SyncWindows;
+    destinationServiceAccounts?: ApplicationDestinationServiceAccount[];
+    quota?: ProjectQuota;
+    syncFreeze?: SyncFreeze;
+}
+
+export interface SyncFreeze {
+    reason?: string;
+}
+
+export interface ProjectQuota {
+    maxApplications?: number;
+    maxDestinations?: number;
+    maxResourcesPerApplication?: number;
+}
+
+export interface ApplicationDestinationServiceAccount {
+    server: string;
+    namespace: string;
+    defaultServiceAccount: string;
 }
 
 export type SyncWindows = SyncWindow[];
@@ -653,8 +678,10 @@ export interface VersionMessage {
 
 export interface Token {
     id: string;
+    name?: string;
     issuedAt: number;
     expiresAt: number;
+    lastUsedAt?: number;
 }
 
 export interface Account {
@@ -50,6 +50,10 @@ export class ApplicationsService {
         return requests.get(`/applications/${name}/revisions/${revision || 'HEAD'}/metadata`).then(res => res.body as models.RevisionMetadata);
     }
 
+    public revisionHistory(name: string, id: number): Promise<models.RevisionHistory> {
+        return requests.get(`/applications/${name}/history/${id}`).then(res => res.body as models.RevisionHistory);
+    }
+
     public resourceTree(name: string): Promise<models.ApplicationTree> {
         return requests.get(`/applications/${name}/resource-tree`).then(res => res.body as models.ApplicationTree);
     }
@@ -5,7 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/argoproj/argo-cd/util/helmfile"
 	"github.com/argoproj/argo-cd/util/kustomize"
+	"github.com/argoproj/argo-cd/util/tanka"
 )
 
 func Discover(root string) (map[string]string, error) {
@@ -31,6 +33,18 @@ func Discover(root string) (map[string]string, error) {
 		if kustomize.IsKustomization(base) {
 			apps[dir] = "Kustomize"
 		}
+		if base == "module.cue" && filepath.Base(dir) == "cue.mod" {
+			apps[filepath.Dir(dir)] = "CUE"
+		}
+		if tanka.IsEnvironment(path) {
+			apps[dir] = "Tanka"
+		}
+		if base == helmfile.File {
+			apps[dir] = "Helmfile"
+		}
+		if filepath.Base(dir) == helmfile.DirName {
+			apps[filepath.Dir(dir)] = "Helmfile"
+		}
 		return nil
 	})
 	return apps, err
@@ -10,9 +10,12 @@ func TestDiscover(t *testing.T) {
 	apps, err := Discover("./testdata")
 	assert.NoError(t, err)
 	assert.Equal(t, map[string]string{
-		"foo": "Kustomize",
-		"bar": "Ksonnet",
-		"baz": "Helm",
+		"foo":   "Kustomize",
+		"bar":   "Ksonnet",
+		"baz":   "Helm",
+		"qux":   "CUE",
+		"quux":  "Tanka",
+		"corge": "Helmfile",
 	}, apps)
 }
 
@@ -29,6 +32,18 @@ func TestAppType(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Helm", appType)
 
+	appType, err = AppType("./testdata/qux")
+	assert.NoError(t, err)
+	assert.Equal(t, "CUE", appType)
+
+	appType, err = AppType("./testdata/quux")
+	assert.NoError(t, err)
+	assert.Equal(t, "Tanka", appType)
+
+	appType, err = AppType("./testdata/corge")
+	assert.NoError(t, err)
+	assert.Equal(t, "Helmfile", appType)
+
 	appType, err = AppType("./testdata")
 	assert.NoError(t, err)
 	assert.Equal(t, "Directory", appType)
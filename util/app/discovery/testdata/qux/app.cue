@@ -0,0 +1,7 @@
+package qux
+
+configMap: qux: {
+	apiVersion: "v1"
+	kind:       "ConfigMap"
+	metadata: name: "qux"
+}
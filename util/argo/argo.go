@@ -2,8 +2,10 @@ package argo
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
@@ -23,10 +26,13 @@ import (
 	applicationsv1 "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/bucket"
 	"github.com/argoproj/argo-cd/util/db"
 	"github.com/argoproj/argo-cd/util/git"
 	"github.com/argoproj/argo-cd/util/helm"
 	"github.com/argoproj/argo-cd/util/kube"
+	"github.com/argoproj/argo-cd/util/oci"
+	"github.com/argoproj/argo-cd/util/settings"
 )
 
 const (
@@ -62,6 +68,86 @@ func FilterByProjects(apps []argoappv1.Application, projects []string) []argoapp
 
 }
 
+// FilterByHealthStatuses returns applications whose health status is one of the specified statuses
+func FilterByHealthStatuses(apps []argoappv1.Application, statuses []string) []argoappv1.Application {
+	if len(statuses) == 0 {
+		return apps
+	}
+	items := make([]argoappv1.Application, 0)
+	for i := 0; i < len(apps); i++ {
+		if containsFold(statuses, apps[i].Status.Health.Status) {
+			items = append(items, apps[i])
+		}
+	}
+	return items
+}
+
+// FilterBySyncStatuses returns applications whose sync status is one of the specified statuses
+func FilterBySyncStatuses(apps []argoappv1.Application, statuses []string) []argoappv1.Application {
+	if len(statuses) == 0 {
+		return apps
+	}
+	items := make([]argoappv1.Application, 0)
+	for i := 0; i < len(apps); i++ {
+		if containsFold(statuses, string(apps[i].Status.Sync.Status)) {
+			items = append(items, apps[i])
+		}
+	}
+	return items
+}
+
+// FilterByDestination returns applications which are deployed to the specified destination, as their primary or one
+// of their additional destinations. Empty server or namespace matches any value.
+func FilterByDestination(apps []argoappv1.Application, server string, namespace string) []argoappv1.Application {
+	if server == "" && namespace == "" {
+		return apps
+	}
+	items := make([]argoappv1.Application, 0)
+	for i := 0; i < len(apps); i++ {
+		for _, dest := range apps[i].Spec.GetDestinations() {
+			if server != "" && dest.Server != server {
+				continue
+			}
+			if namespace != "" && dest.Namespace != namespace {
+				continue
+			}
+			items = append(items, apps[i])
+			break
+		}
+	}
+	return items
+}
+
+// Paginate returns at most limit applications of the list which, sorted by name, follow the application referenced
+// by the continue token. The returned token should be supplied to get the next page and is empty on the last page.
+func Paginate(apps []argoappv1.Application, limit int64, continueToken string) ([]argoappv1.Application, string, int64, error) {
+	start := 0
+	if continueToken != "" {
+		last, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err != nil {
+			return nil, "", 0, status.Errorf(codes.InvalidArgument, "invalid continue token: %v", err)
+		}
+		start = sort.Search(len(apps), func(i int) bool {
+			return apps[i].Name > string(last)
+		})
+	}
+	apps = apps[start:]
+	if limit <= 0 || int64(len(apps)) <= limit {
+		return apps, "", 0, nil
+	}
+	page := apps[:limit]
+	return page, base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1].Name)), int64(len(apps)) - limit, nil
+}
+
+func containsFold(items []string, item string) bool {
+	for i := range items {
+		if strings.EqualFold(items[i], item) {
+			return true
+		}
+	}
+	return false
+}
+
 // RefreshApp updates the refresh annotation of an application to coerce the controller to process it
 func RefreshApp(appIf v1alpha1.ApplicationInterface, name string, refreshType argoappv1.RefreshType) (*argoappv1.Application, error) {
 	metadata := map[string]interface{}{
@@ -123,10 +209,15 @@ func WaitForRefresh(ctx context.Context, appIf v1alpha1.ApplicationInterface, na
 	return nil, fmt.Errorf("application refresh deadline exceeded")
 }
 
-func TestRepoWithKnownType(repo *argoappv1.Repository, isHelm bool) error {
+// TestRepoWithKnownType tests the access to the repository of an application source, whose type is known from the source
+func TestRepoWithKnownType(repo *argoappv1.Repository, source *argoappv1.ApplicationSource) error {
 	repo = repo.DeepCopy()
-	if isHelm {
+	if source.IsHelm() {
 		repo.Type = "helm"
+	} else if source.IsOCI() {
+		repo.Type = "oci"
+	} else if source.IsBucket() {
+		repo.Type = "bucket"
 	} else {
 		repo.Type = "git"
 	}
@@ -139,9 +230,31 @@ func TestRepo(repo *argoappv1.Repository) error {
 			return git.TestRepo(repo.Repo, repo.GetGitCreds(), repo.IsInsecure(), repo.IsLFSEnabled())
 		},
 		"helm": func() error {
+			if helm.IsOCIURL(repo.Repo) {
+				return helm.TestOCIRepository(repo.Repo, repo.GetHelmCreds())
+			}
 			_, err := helm.NewClient(repo.Repo, repo.GetHelmCreds()).GetIndex()
 			return err
 		},
+		"oci": func() error {
+			_, err := oci.NewClient(repo.Repo, repo.GetOCICreds()).ListTags()
+			return err
+		},
+		"bucket": func() error {
+			client, err := bucket.NewClient(repo.Repo, repo.GetBucketCreds())
+			if err != nil {
+				return err
+			}
+			_, err = client.ResolveRevision("")
+			return err
+		},
+	}
+	if repo.Type == "" && oci.IsOCIURL(repo.Repo) {
+		repo = repo.DeepCopy()
+		repo.Type = "oci"
+	} else if repo.Type == "" && bucket.IsBucketURL(repo.Repo) {
+		repo = repo.DeepCopy()
+		repo.Type = "bucket"
 	}
 	if check, ok := checks[repo.Type]; ok {
 		return check()
@@ -185,7 +298,7 @@ func ValidateRepo(
 	}
 
 	repoAccessible := false
-	err = TestRepoWithKnownType(repo, app.Spec.Source.IsHelm())
+	err = TestRepoWithKnownType(repo, &app.Spec.Source)
 	if err != nil {
 		conditions = append(conditions, argoappv1.ApplicationCondition{
 			Type:    argoappv1.ApplicationConditionInvalidSpecError,
@@ -285,34 +398,125 @@ func ValidatePermissions(ctx context.Context, spec *argoappv1.ApplicationSpec, p
 		})
 	}
 
-	if spec.Destination.Server != "" && spec.Destination.Namespace != "" {
-		if !proj.IsDestinationPermitted(spec.Destination) {
+	for _, destination := range spec.GetDestinations() {
+		if destination.Server == "" || destination.Namespace == "" {
+			conditions = append(conditions, argoappv1.ApplicationCondition{Type: argoappv1.ApplicationConditionInvalidSpecError, Message: errDestinationMissing})
+			continue
+		}
+		if !proj.IsDestinationPermitted(destination) {
 			conditions = append(conditions, argoappv1.ApplicationCondition{
 				Type:    argoappv1.ApplicationConditionInvalidSpecError,
-				Message: fmt.Sprintf("application destination %v is not permitted in project '%s'", spec.Destination, spec.Project),
+				Message: fmt.Sprintf("application destination %v is not permitted in project '%s'", destination, spec.Project),
 			})
 		}
 		// Ensure the k8s cluster the app is referencing, is configured in Argo CD
-		_, err := db.GetCluster(ctx, spec.Destination.Server)
+		_, err := db.GetCluster(ctx, destination.Server)
 		if err != nil {
 			if errStatus, ok := status.FromError(err); ok && errStatus.Code() == codes.NotFound {
 				conditions = append(conditions, argoappv1.ApplicationCondition{
 					Type:    argoappv1.ApplicationConditionInvalidSpecError,
-					Message: fmt.Sprintf("cluster '%s' has not been configured", spec.Destination.Server),
+					Message: fmt.Sprintf("cluster '%s' has not been configured", destination.Server),
 				})
 			} else {
 				return nil, err
 			}
 		}
-	} else {
-		conditions = append(conditions, argoappv1.ApplicationCondition{Type: argoappv1.ApplicationConditionInvalidSpecError, Message: errDestinationMissing})
 	}
 	return conditions, nil
 }
 
-// GetAppProject returns a project from an application
-func GetAppProject(spec *argoappv1.ApplicationSpec, projLister applicationsv1.AppProjectLister, ns string) (*argoappv1.AppProject, error) {
-	return projLister.AppProjects(ns).Get(spec.GetProject())
+// GetAppProject returns a project from an application, including what it inherits from global projects
+func GetAppProject(spec *argoappv1.ApplicationSpec, projLister applicationsv1.AppProjectLister, ns string, settingsMgr *settings.SettingsManager) (*argoappv1.AppProject, error) {
+	proj, err := projLister.AppProjects(ns).Get(spec.GetProject())
+	if err != nil {
+		return nil, err
+	}
+	return GetProjectWithGlobalProjects(proj, projLister.AppProjects(ns), settingsMgr), nil
+}
+
+// GetGlobalProjects returns the global projects whose label selectors match the given project
+func GetGlobalProjects(proj *argoappv1.AppProject, projLister applicationsv1.AppProjectNamespaceLister, settingsMgr *settings.SettingsManager) []argoappv1.AppProject {
+	globalProjects := make([]argoappv1.AppProject, 0)
+	if settingsMgr == nil {
+		return globalProjects
+	}
+	gps, err := settingsMgr.GetGlobalProjectsSettings()
+	if err != nil {
+		log.Warnf("Failed to get global projects settings: %v", err)
+		return globalProjects
+	}
+	for _, gp := range gps {
+		// a project does not inherit from itself
+		if gp.ProjectName == proj.Name {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&gp.LabelSelector)
+		if err != nil {
+			log.Warnf("Invalid label selector of global project '%s': %v", gp.ProjectName, err)
+			continue
+		}
+		if !selector.Matches(labels.Set(proj.Labels)) {
+			continue
+		}
+		globalProj, err := projLister.Get(gp.ProjectName)
+		if err != nil {
+			log.Warnf("Failed to get global project '%s': %v", gp.ProjectName, err)
+			continue
+		}
+		globalProjects = append(globalProjects, *globalProj)
+	}
+	return globalProjects
+}
+
+// GetProjectWithGlobalProjects returns a copy of the project which additionally has the roles, sync windows
+// and resource lists of the global projects matching it. Roles of the project take precedence over inherited
+// roles of the same name. The JWT tokens of inherited roles are not valid for the project.
+func GetProjectWithGlobalProjects(proj *argoappv1.AppProject, projLister applicationsv1.AppProjectNamespaceLister, settingsMgr *settings.SettingsManager) *argoappv1.AppProject {
+	globalProjects := GetGlobalProjects(proj, projLister, settingsMgr)
+	if len(globalProjects) == 0 {
+		return proj
+	}
+	merged := proj.DeepCopy()
+	roles := make(map[string]bool)
+	for _, role := range proj.Spec.Roles {
+		roles[role.Name] = true
+	}
+	for _, gp := range globalProjects {
+		for _, role := range gp.Spec.Roles {
+			if roles[role.Name] {
+				continue
+			}
+			roles[role.Name] = true
+			inherited := argoappv1.ProjectRole{Name: role.Name, Description: role.Description, Groups: role.Groups}
+			for _, policy := range role.Policies {
+				inherited.Policies = append(inherited.Policies, inheritPolicy(policy, gp.Name, proj.Name))
+			}
+			merged.Spec.Roles = append(merged.Spec.Roles, inherited)
+		}
+		merged.Spec.SyncWindows = append(merged.Spec.SyncWindows, gp.Spec.SyncWindows.DeepCopy()...)
+		merged.Spec.ClusterResourceWhitelist = append(merged.Spec.ClusterResourceWhitelist, gp.Spec.ClusterResourceWhitelist...)
+		merged.Spec.NamespaceResourceBlacklist = append(merged.Spec.NamespaceResourceBlacklist, gp.Spec.NamespaceResourceBlacklist...)
+	}
+	return merged
+}
+
+// inheritPolicy rewrites a policy of a role of a global project to refer to the role and the applications of
+// the inheriting project
+func inheritPolicy(policy string, globalProjName string, projName string) string {
+	fields := strings.Split(policy, ",")
+	if len(fields) != 6 {
+		return policy
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	if subjectPrefix := fmt.Sprintf("proj:%s:", globalProjName); strings.HasPrefix(fields[1], subjectPrefix) {
+		fields[1] = fmt.Sprintf("proj:%s:%s", projName, strings.TrimPrefix(fields[1], subjectPrefix))
+	}
+	if objectPrefix := globalProjName + "/"; strings.HasPrefix(fields[4], objectPrefix) {
+		fields[4] = projName + "/" + strings.TrimPrefix(fields[4], objectPrefix)
+	}
+	return strings.Join(fields, ", ")
 }
 
 // verifyGenerateManifests verifies a repo path can generate manifests
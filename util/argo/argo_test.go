@@ -7,17 +7,21 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
 	testcore "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/argoproj/argo-cd/common"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-cd/pkg/client/informers/externalversions/application/v1alpha1"
 	applisters "github.com/argoproj/argo-cd/pkg/client/listers/application/v1alpha1"
 	"github.com/argoproj/argo-cd/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/util/settings"
 )
 
 func TestRefreshApp(t *testing.T) {
@@ -52,11 +56,71 @@ func TestGetAppProjectWithNoProjDefined(t *testing.T) {
 	informer := v1alpha1.NewAppProjectInformer(appClientset, namespace, 0, indexers)
 	go informer.Run(ctx.Done())
 	cache.WaitForCacheSync(ctx.Done(), informer.HasSynced)
-	proj, err := GetAppProject(&testApp.Spec, applisters.NewAppProjectLister(informer.GetIndexer()), namespace)
+	proj, err := GetAppProject(&testApp.Spec, applisters.NewAppProjectLister(informer.GetIndexer()), namespace, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, proj.Name, projName)
 }
 
+func TestGetProjectWithGlobalProjects(t *testing.T) {
+	namespace := "argocd"
+	globalProj := &argoappv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: namespace},
+		Spec: argoappv1.AppProjectSpec{
+			Roles: []argoappv1.ProjectRole{{
+				Name:      "viewer",
+				Policies:  []string{"p, proj:global:viewer, applications, get, global/*, allow"},
+				Groups:    []string{"org-viewers"},
+				JWTTokens: []argoappv1.JWTToken{{IssuedAt: 1}},
+			}, {
+				Name: "admin",
+			}},
+			SyncWindows:                argoappv1.SyncWindows{{Kind: "deny", Schedule: "0 22 * * *", Duration: "1h"}},
+			ClusterResourceWhitelist:   []metav1.GroupKind{{Group: "", Kind: "Namespace"}},
+			NamespaceResourceBlacklist: []metav1.GroupKind{{Group: "", Kind: "ResourceQuota"}},
+		},
+	}
+	proj := &argoappv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace, Labels: map[string]string{"tier": "prod"}},
+		Spec: argoappv1.AppProjectSpec{
+			Roles: []argoappv1.ProjectRole{{Name: "admin", Policies: []string{"p, proj:team:admin, applications, *, team/*, allow"}}},
+		},
+	}
+	otherProj := &argoappv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, p := range []*argoappv1.AppProject{globalProj, proj, otherProj} {
+		assert.NoError(t, indexer.Add(p))
+	}
+	projLister := applisters.NewAppProjectLister(indexer).AppProjects(namespace)
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDConfigMapName,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string]string{"globalProjects": `
+- projectName: global
+  labelSelector:
+    matchLabels:
+      tier: prod`},
+	})
+	settingsMgr := settings.NewSettingsManager(context.Background(), kubeClient, namespace)
+
+	merged := GetProjectWithGlobalProjects(proj, projLister, settingsMgr)
+	assert.Equal(t, []argoappv1.ProjectRole{
+		{Name: "admin", Policies: []string{"p, proj:team:admin, applications, *, team/*, allow"}},
+		{Name: "viewer", Policies: []string{"p, proj:team:viewer, applications, get, team/*, allow"}, Groups: []string{"org-viewers"}},
+	}, merged.Spec.Roles)
+	assert.Len(t, merged.Spec.SyncWindows, 1)
+	assert.Equal(t, globalProj.Spec.ClusterResourceWhitelist, merged.Spec.ClusterResourceWhitelist)
+	assert.Equal(t, globalProj.Spec.NamespaceResourceBlacklist, merged.Spec.NamespaceResourceBlacklist)
+	assert.Len(t, proj.Spec.Roles, 1)
+
+	assert.Equal(t, otherProj, GetProjectWithGlobalProjects(otherProj, projLister, settingsMgr))
+	assert.Equal(t, globalProj, GetProjectWithGlobalProjects(globalProj, projLister, settingsMgr))
+}
+
 func TestWaitForRefresh(t *testing.T) {
 	appClientset := appclientset.NewSimpleClientset()
 
@@ -210,3 +274,86 @@ func Test_enrichSpec(t *testing.T) {
 		assert.Equal(t, "my-namespace", spec.Destination.Namespace)
 	})
 }
+
+func TestFilterByHealthAndSyncStatuses(t *testing.T) {
+	apps := []argoappv1.Application{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a"},
+		Status: argoappv1.ApplicationStatus{
+			Health: argoappv1.HealthStatus{Status: "Healthy"},
+			Sync:   argoappv1.SyncStatus{Status: argoappv1.SyncStatusCodeSynced},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "b"},
+		Status: argoappv1.ApplicationStatus{
+			Health: argoappv1.HealthStatus{Status: "Progressing"},
+			Sync:   argoappv1.SyncStatus{Status: argoappv1.SyncStatusCodeOutOfSync},
+		},
+	}}
+	assert.Len(t, FilterByHealthStatuses(apps, nil), 2)
+	res := FilterByHealthStatuses(apps, []string{"progressing"})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "b", res[0].Name)
+	res = FilterBySyncStatuses(apps, []string{"Synced"})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "a", res[0].Name)
+}
+
+func TestFilterByDestination(t *testing.T) {
+	apps := []argoappv1.Application{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a"},
+		Spec:       argoappv1.ApplicationSpec{Destination: argoappv1.ApplicationDestination{Server: "https://one", Namespace: "default"}},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "b"},
+		Spec:       argoappv1.ApplicationSpec{Destination: argoappv1.ApplicationDestination{Server: "https://two", Namespace: "default"}},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "c"},
+		Spec: argoappv1.ApplicationSpec{
+			Destination:  argoappv1.ApplicationDestination{Server: "https://one", Namespace: "kube-system"},
+			Destinations: []argoappv1.ApplicationDestination{{Server: "https://three", Namespace: "default"}},
+		},
+	}}
+	assert.Len(t, FilterByDestination(apps, "", ""), 3)
+	assert.Len(t, FilterByDestination(apps, "", "default"), 3)
+	res := FilterByDestination(apps, "https://two", "")
+	assert.Len(t, res, 1)
+	assert.Equal(t, "b", res[0].Name)
+	assert.Len(t, FilterByDestination(apps, "https://two", "kube-system"), 0)
+	res = FilterByDestination(apps, "https://three", "")
+	assert.Len(t, res, 1)
+	assert.Equal(t, "c", res[0].Name)
+	assert.Len(t, FilterByDestination(apps, "https://three", "kube-system"), 0)
+}
+
+func TestPaginate(t *testing.T) {
+	var apps []argoappv1.Application
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		apps = append(apps, argoappv1.Application{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	page, token, remaining, err := Paginate(apps, 0, "")
+	assert.NoError(t, err)
+	assert.Len(t, page, 5)
+	assert.Empty(t, token)
+	assert.Equal(t, int64(0), remaining)
+
+	var names []string
+	token = ""
+	for {
+		page, token, _, err = Paginate(apps, 2, token)
+		assert.NoError(t, err)
+		for _, a := range page {
+			names = append(names, a.Name)
+		}
+		if token == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+
+	_, _, remaining, err = Paginate(apps, 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), remaining)
+
+	_, _, _, err = Paginate(apps, 2, "not base64!")
+	assert.Error(t, err)
+}
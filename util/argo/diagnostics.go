@@ -0,0 +1,287 @@
+package argo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+
+	"github.com/argoproj/argo-cd/common"
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/bucket"
+	certutil "github.com/argoproj/argo-cd/util/cert"
+	"github.com/argoproj/argo-cd/util/fips"
+	"github.com/argoproj/argo-cd/util/git"
+	"github.com/argoproj/argo-cd/util/oci"
+)
+
+const (
+	// diagnosticsTimeout bounds each network step of the diagnosis of a repository
+	diagnosticsTimeout = 10 * time.Second
+	// skippedAfterFailure is the message of the steps which are skipped because a previous step failed
+	skippedAfterFailure = "skipped because of a previous failure"
+)
+
+// repoEndpoint is the network endpoint of a repository
+type repoEndpoint struct {
+	scheme string
+	host   string
+	port   string
+}
+
+// getRepoEndpoint returns the network endpoint of a repository URL, including SCP-like SSH URLs
+func getRepoEndpoint(repoURL string) (*repoEndpoint, error) {
+	if oci.IsOCIURL(repoURL) {
+		repoURL = "https://" + strings.TrimPrefix(repoURL, oci.URLPrefix)
+	} else if ok, _ := git.IsSSHURL(repoURL); ok && !strings.Contains(repoURL, "://") {
+		repoURL = "ssh://" + strings.Replace(repoURL, ":", "/", 1)
+	}
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &repoEndpoint{scheme: parsed.Scheme, host: parsed.Hostname(), port: parsed.Port()}
+	if endpoint.port == "" {
+		switch endpoint.scheme {
+		case "https":
+			endpoint.port = "443"
+		case "http":
+			endpoint.port = "80"
+		case "ssh":
+			endpoint.port = "22"
+		case "git":
+			endpoint.port = "9418"
+		}
+	}
+	if endpoint.host == "" || endpoint.port == "" {
+		return nil, fmt.Errorf("unsupported repository URL '%s'", repoURL)
+	}
+	return endpoint, nil
+}
+
+// DiagnoseRepo checks step by step the connection to a repository, i.e. the resolution of its host name, the TCP
+// connection, the TLS handshake, the authentication and the listing of its revisions. The steps following a failed
+// step are skipped.
+func DiagnoseRepo(repo *argoappv1.Repository) *argoappv1.RepositoryDiagnostics {
+	d := &diagnosis{res: argoappv1.RepositoryDiagnostics{Repo: repo.Repo}}
+
+	repoType := repo.Type
+	if oci.IsOCIURL(repo.Repo) {
+		repoType = "oci"
+	} else if bucket.IsBucketURL(repo.Repo) {
+		repoType = "bucket"
+	} else if repoType == "" {
+		repoType = common.DefaultRepoType
+	}
+
+	// the endpoints of buckets are the ones of the clouds, which the SDKs pick
+	if repoType != "bucket" {
+		endpoint, err := getRepoEndpoint(repo.Repo)
+		if err != nil {
+			d.add(argoappv1.RepositoryDiagnosticStepDNS, err, "")
+		} else {
+			d.network(repo, endpoint)
+		}
+	}
+
+	if d.failed {
+		if repoType != "git" {
+			d.skip(argoappv1.RepositoryDiagnosticStepAccess, skippedAfterFailure)
+		} else {
+			d.skip(argoappv1.RepositoryDiagnosticStepAuthentication, skippedAfterFailure)
+			d.skip(argoappv1.RepositoryDiagnosticStepLsRemote, skippedAfterFailure)
+		}
+		return &d.res
+	}
+	if repoType != "git" {
+		d.add(argoappv1.RepositoryDiagnosticStepAccess, TestRepo(repo), fmt.Sprintf("%s repository is accessible", repoType))
+		return &d.res
+	}
+
+	client, err := git.NewClient(repo.Repo, repo.GetGitCreds(), repo.IsInsecure(), repo.IsLFSEnabled())
+	var revision string
+	if err == nil {
+		revision, err = client.LsRemote("HEAD")
+	}
+	method := authMethod(repo)
+	switch {
+	case err == nil:
+		d.add(argoappv1.RepositoryDiagnosticStepAuthentication, nil, fmt.Sprintf("authenticated with %s", method))
+	case isAuthError(err):
+		d.add(argoappv1.RepositoryDiagnosticStepAuthentication, fmt.Errorf("authentication with %s failed: %v", method, err), "")
+	default:
+		d.skip(argoappv1.RepositoryDiagnosticStepAuthentication, fmt.Sprintf("using %s, the listing of the revisions failed before the authentication could be checked", method))
+	}
+	d.add(argoappv1.RepositoryDiagnosticStepLsRemote, err, fmt.Sprintf("HEAD resolves to %s", revision))
+	return &d.res
+}
+
+// diagnosis collects the results of the steps of a diagnosis
+type diagnosis struct {
+	res    argoappv1.RepositoryDiagnostics
+	failed bool
+}
+
+// add adds the result of a step, which is skipped if a previous step failed
+func (d *diagnosis) add(name string, err error, message string, details ...string) {
+	if d.failed {
+		d.skip(name, skippedAfterFailure)
+		return
+	}
+	step := argoappv1.RepositoryDiagnosticStep{Name: name, Status: argoappv1.ConnectionStatusSuccessful, Message: message, Details: details}
+	if err != nil {
+		step.Status = argoappv1.ConnectionStatusFailed
+		step.Message = err.Error()
+		d.failed = true
+	}
+	d.res.Steps = append(d.res.Steps, step)
+}
+
+// skip adds a step which was not run
+func (d *diagnosis) skip(name string, message string) {
+	d.res.Steps = append(d.res.Steps, argoappv1.RepositoryDiagnosticStep{Name: name, Status: argoappv1.ConnectionStatusSkipped, Message: message})
+}
+
+// network resolves the endpoint of the repository, connects to it and runs the TLS handshake of HTTPS endpoints. The
+// endpoint of the proxy the repository is connected through, if any, is checked instead.
+func (d *diagnosis) network(repo *argoappv1.Repository, endpoint *repoEndpoint) {
+	host, port := endpoint.host, endpoint.port
+	var proxy *url.URL
+	if endpoint.scheme == "https" || endpoint.scheme == "http" {
+		proxy, _ = http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: endpoint.scheme, Host: net.JoinHostPort(host, port)}})
+	}
+	via := ""
+	if proxy != nil {
+		d.res.Proxy = redactedURL(proxy)
+		proxyEndpoint, err := getRepoEndpoint(proxy.String())
+		if err != nil {
+			d.add(argoappv1.RepositoryDiagnosticStepDNS, fmt.Errorf("invalid proxy '%s': %v", d.res.Proxy, err), "")
+			return
+		}
+		host, port = proxyEndpoint.host, proxyEndpoint.port
+		via = " (proxy)"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	d.add(argoappv1.RepositoryDiagnosticStepDNS, err, fmt.Sprintf("%s%s resolves to %d addresses", host, via, len(addrs)), addrs...)
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), diagnosticsTimeout)
+	if err == nil {
+		defer func() { _ = conn.Close() }()
+		d.add(argoappv1.RepositoryDiagnosticStepTCP, nil, fmt.Sprintf("connected to %s%s in %v", conn.RemoteAddr(), via, time.Since(start).Round(time.Millisecond)))
+	} else {
+		d.add(argoappv1.RepositoryDiagnosticStepTCP, err, "")
+	}
+
+	switch {
+	case endpoint.scheme != "https":
+	case proxy != nil:
+		d.skip(argoappv1.RepositoryDiagnosticStepTLS, "the TLS connection is tunneled through the proxy")
+	case conn == nil:
+		d.skip(argoappv1.RepositoryDiagnosticStepTLS, skippedAfterFailure)
+	default:
+		details, err := diagnoseTLS(repo, conn, endpoint.host)
+		message := "the certificate chain is trusted"
+		if repo.IsInsecure() {
+			message = "the verification of the certificate chain is disabled"
+		}
+		d.add(argoappv1.RepositoryDiagnosticStepTLS, err, message, details...)
+	}
+}
+
+// diagnoseTLS runs the TLS handshake of a connection and verifies the certificate chain of the server, with the
+// certificates configured for the server if any. It returns the certificates of the chain.
+func diagnoseTLS(repo *argoappv1.Repository, conn net.Conn, serverName string) ([]string, error) {
+	config := &tls.Config{
+		ServerName: serverName,
+		// the chain is verified below, so that it can be reported even if it is not trusted
+		InsecureSkipVerify: true,
+	}
+	if repo.TLSClientCertData != "" && repo.TLSClientCertKey != "" {
+		cert, err := tls.X509KeyPair([]byte(repo.TLSClientCertData), []byte(repo.TLSClientCertKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	fips.ConfigureTLS(config)
+	tlsConn := tls.Client(conn, config)
+	_ = tlsConn.SetDeadline(time.Now().Add(diagnosticsTimeout))
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	chain := tlsConn.ConnectionState().PeerCertificates
+	details := make([]string, len(chain))
+	for i, cert := range chain {
+		details[i] = fmt.Sprintf("%s (issuer: %s, expires: %s)", cert.Subject, cert.Issuer, cert.NotAfter.Format(time.RFC3339))
+	}
+	if repo.IsInsecure() || len(chain) == 0 {
+		return details, nil
+	}
+
+	opts := x509.VerifyOptions{DNSName: serverName, Intermediates: x509.NewCertPool()}
+	for _, cert := range chain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if certs, err := certutil.GetCertificateForConnect(serverName); err == nil && len(certs) > 0 {
+		opts.Roots = certutil.GetCertPoolFromPEMData(certs)
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return details, fmt.Errorf("the certificate chain is not trusted: %v", err)
+	}
+	return details, nil
+}
+
+// authMethod describes the credentials used to authenticate at a repository
+func authMethod(repo *argoappv1.Repository) string {
+	var methods []string
+	if repo.SSHPrivateKey != "" {
+		methods = append(methods, "SSH private key")
+	}
+	if repo.Username != "" || repo.Password != "" {
+		methods = append(methods, fmt.Sprintf("username '%s' and password", repo.Username))
+	}
+	if repo.TLSClientCertData != "" {
+		methods = append(methods, "TLS client certificate")
+	}
+	if len(methods) == 0 {
+		return "no credentials"
+	}
+	return strings.Join(methods, ", ")
+}
+
+// isAuthError returns whether an error of the listing of the revisions of a Git repository is caused by its
+// credentials
+func isAuthError(err error) bool {
+	if errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed) {
+		return true
+	}
+	message := err.Error()
+	for _, s := range []string{"unable to authenticate", "permission denied", "invalid credentials"} {
+		if strings.Contains(strings.ToLower(message), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedURL returns a URL without the password of its user info
+func redactedURL(u *url.URL) string {
+	if _, ok := u.User.Password(); !ok {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = url.UserPassword(u.User.Username(), "xxxxx")
+	return redacted.String()
+}
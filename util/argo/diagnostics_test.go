@@ -0,0 +1,86 @@
+package argo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestGetRepoEndpoint(t *testing.T) {
+	for repoURL, expected := range map[string]repoEndpoint{
+		"https://github.com/argoproj/argo-cd":        {scheme: "https", host: "github.com", port: "443"},
+		"http://git.example.com:8080/repo.git":       {scheme: "http", host: "git.example.com", port: "8080"},
+		"git@github.com:argoproj/argo-cd.git":        {scheme: "ssh", host: "github.com", port: "22"},
+		"ssh://git@git.example.com:2222/repo.git":    {scheme: "ssh", host: "git.example.com", port: "2222"},
+		"oci://ghcr.io/argoproj/manifests":           {scheme: "https", host: "ghcr.io", port: "443"},
+		"git://git.example.com/argoproj/argo-cd.git": {scheme: "git", host: "git.example.com", port: "9418"},
+	} {
+		endpoint, err := getRepoEndpoint(repoURL)
+		if assert.NoError(t, err, repoURL) {
+			assert.Equal(t, expected, *endpoint, repoURL)
+		}
+	}
+	_, err := getRepoEndpoint("file:///tmp/repo")
+	assert.Error(t, err)
+}
+
+func stepStatuses(diagnostics *argoappv1.RepositoryDiagnostics) map[string]string {
+	res := make(map[string]string)
+	for _, step := range diagnostics.Steps {
+		res[step.Name] = step.Status
+	}
+	return res
+}
+
+func TestDiagnoseRepo(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	t.Run("Authentication", func(t *testing.T) {
+		diagnostics := DiagnoseRepo(&argoappv1.Repository{Repo: ts.URL + "/repo.git", Username: "git", Password: "wrong", Insecure: true})
+		assert.Equal(t, ts.URL+"/repo.git", diagnostics.Repo)
+		assert.Empty(t, diagnostics.Proxy)
+		assert.True(t, diagnostics.Failed())
+		assert.Equal(t, map[string]string{
+			argoappv1.RepositoryDiagnosticStepDNS:            argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepTCP:            argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepTLS:            argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepAuthentication: argoappv1.ConnectionStatusFailed,
+			argoappv1.RepositoryDiagnosticStepLsRemote:       argoappv1.ConnectionStatusSkipped,
+		}, stepStatuses(diagnostics))
+		assert.Equal(t, []string{"127.0.0.1"}, diagnostics.Steps[0].Details)
+		assert.Len(t, diagnostics.Steps[2].Details, 1)
+		assert.Contains(t, diagnostics.Steps[3].Message, "username 'git' and password")
+	})
+
+	t.Run("UntrustedCertificate", func(t *testing.T) {
+		diagnostics := DiagnoseRepo(&argoappv1.Repository{Repo: ts.URL + "/repo.git"})
+		assert.Equal(t, map[string]string{
+			argoappv1.RepositoryDiagnosticStepDNS:            argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepTCP:            argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepTLS:            argoappv1.ConnectionStatusFailed,
+			argoappv1.RepositoryDiagnosticStepAuthentication: argoappv1.ConnectionStatusSkipped,
+			argoappv1.RepositoryDiagnosticStepLsRemote:       argoappv1.ConnectionStatusSkipped,
+		}, stepStatuses(diagnostics))
+		assert.Contains(t, diagnostics.Steps[2].Message, "the certificate chain is not trusted")
+		// the chain is reported, so that it can be compared with the expected one
+		assert.Len(t, diagnostics.Steps[2].Details, 1)
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		diagnostics := DiagnoseRepo(&argoappv1.Repository{Repo: closed.URL + "/charts", Type: "helm"})
+		assert.Equal(t, map[string]string{
+			argoappv1.RepositoryDiagnosticStepDNS:    argoappv1.ConnectionStatusSuccessful,
+			argoappv1.RepositoryDiagnosticStepTCP:    argoappv1.ConnectionStatusFailed,
+			argoappv1.RepositoryDiagnosticStepAccess: argoappv1.ConnectionStatusSkipped,
+		}, stepStatuses(diagnostics))
+	})
+}
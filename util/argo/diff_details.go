@@ -0,0 +1,168 @@
+package argo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/diff"
+)
+
+const (
+	// builtInNormalization is the source of the differences ignored by the normalizations of Argo CD itself, e.g. of
+	// the stringData of Secrets or of empty rules of Roles
+	builtInNormalization = "built-in normalization"
+	// generatedSecretNormalization is the source of the differences of the data of Secrets generated by controllers
+	generatedSecretNormalization = "generated secret data"
+)
+
+// GetResourceDiffDetails compares the target and the live state of a resource field by field, like the comparison of
+// the application controller does, and attributes the differences which the normalizations ignore to the rules of the
+// ignored differences of the application, to the resource overrides or to the built-in normalizations
+func GetResourceDiffDetails(target, live *unstructured.Unstructured, ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride, hideGeneratedSecretData bool) (*v1alpha1.ResourceDiffDetails, error) {
+	obj := live
+	if obj == nil {
+		obj = target
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("both live and target are null objects")
+	}
+	res := &v1alpha1.ResourceDiffDetails{
+		Group:     obj.GroupVersionKind().Group,
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	rules, err := getNormalizationRules(ignore, overrides)
+	if err != nil {
+		return nil, err
+	}
+	normalizer, err := NewDiffNormalizer(ignore, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	diffTarget, diffLive := target, live
+	generated := hideGeneratedSecretData && isGeneratedSecret(live)
+	if generated {
+		diffTarget, diffLive = HideGeneratedSecretData(target, live)
+	}
+	normalized, err := diff.Diff(diffTarget, diffLive, normalizer)
+	if err != nil {
+		return nil, err
+	}
+	res.Modified = normalized.Modified
+	if target == nil || live == nil {
+		return res, nil
+	}
+	res.Diffs, err = getFieldDiffs(normalized.PredictedLive, normalized.NormalizedLive)
+	if err != nil {
+		return nil, err
+	}
+
+	unnormalized, err := diff.UnnormalizedDiff(target, live)
+	if err != nil {
+		return nil, err
+	}
+	unnormalizedDiffs, err := getFieldDiffs(unnormalized.PredictedLive, unnormalized.NormalizedLive)
+	if err != nil {
+		return nil, err
+	}
+	diffPaths := make(map[string]bool, len(res.Diffs))
+	for _, fieldDiff := range res.Diffs {
+		diffPaths[fieldDiff.Path] = true
+	}
+	for _, fieldDiff := range unnormalizedDiffs {
+		if diffPaths[fieldDiff.Path] {
+			continue
+		}
+		fieldDiff.SuppressedBy = suppressedBy(fieldDiff.Path, obj, rules, generated)
+		res.Suppressed = append(res.Suppressed, fieldDiff)
+	}
+	return res, nil
+}
+
+// suppressedBy returns the normalization which ignores the differences of a field of a resource
+func suppressedBy(path string, obj *unstructured.Unstructured, rules []normalizationRule, generatedSecret bool) string {
+	if generatedSecret && (hasPathPrefix(path, "/data") || hasPathPrefix(path, "/stringData")) {
+		return generatedSecretNormalization
+	}
+	for _, rule := range rules {
+		if rule.matches(obj) && hasPathPrefix(path, rule.jsonPointer) {
+			return fmt.Sprintf("%s: %s", rule.source, rule.jsonPointer)
+		}
+	}
+	return builtInNormalization
+}
+
+// hasPathPrefix returns whether a JSON pointer is the one of a field or of one of its children
+func hasPathPrefix(path string, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// getFieldDiffs compares two JSON documents leaf by leaf and returns the differing leaves ordered by their path
+func getFieldDiffs(targetData, liveData []byte) ([]v1alpha1.FieldDiff, error) {
+	var target, live interface{}
+	if err := json.Unmarshal(targetData, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(liveData, &live); err != nil {
+		return nil, err
+	}
+	targetFields := make(map[string]string)
+	flattenJSON("", target, targetFields)
+	liveFields := make(map[string]string)
+	flattenJSON("", live, liveFields)
+
+	paths := make(map[string]bool)
+	for path := range targetFields {
+		paths[path] = true
+	}
+	for path := range liveFields {
+		paths[path] = true
+	}
+	var res []v1alpha1.FieldDiff
+	for path := range paths {
+		if targetFields[path] != liveFields[path] {
+			res = append(res, v1alpha1.FieldDiff{Path: path, TargetValue: targetFields[path], LiveValue: liveFields[path]})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+	return res, nil
+}
+
+// flattenJSON adds the JSON values of the leaves of a document to fields by the JSON pointer of the leaves. Empty
+// objects and arrays are leaves.
+func flattenJSON(path string, value interface{}, fields map[string]string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if len(value) > 0 {
+			for key, child := range value {
+				flattenJSON(path+"/"+strings.NewReplacer("~", "~0", "/", "~1").Replace(key), child, fields)
+			}
+			return
+		}
+	case []interface{}:
+		if len(value) > 0 {
+			for i, child := range value {
+				flattenJSON(fmt.Sprintf("%s/%d", path, i), child, fields)
+			}
+			return
+		}
+	case nil:
+		// null fields are not set
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	fields[path] = string(data)
+}
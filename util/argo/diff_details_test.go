@@ -0,0 +1,95 @@
+package argo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/test"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+func TestGetResourceDiffDetails(t *testing.T) {
+	target := kube.MustToUnstructured(test.DemoDeployment())
+	live := target.DeepCopy()
+	assert.NoError(t, unstructured.SetNestedField(live.Object, int64(3), "spec", "replicas"))
+	containers, _, _ := unstructured.NestedSlice(live.Object, "spec", "template", "spec", "containers")
+	containers[0].(map[string]interface{})["image"] = "nginx:1.17"
+	assert.NoError(t, unstructured.SetNestedSlice(live.Object, containers, "spec", "template", "spec", "containers"))
+
+	t.Run("NotIgnored", func(t *testing.T) {
+		details, err := GetResourceDiffDetails(target, live, nil, nil, false)
+		assert.NoError(t, err)
+		assert.True(t, details.Modified)
+		assert.Equal(t, "Deployment", details.Kind)
+		assert.Equal(t, "apps", details.Group)
+		paths := make([]string, len(details.Diffs))
+		for i, d := range details.Diffs {
+			paths[i] = d.Path
+		}
+		assert.Contains(t, paths, "/spec/replicas")
+		assert.Contains(t, paths, "/spec/template/spec/containers/0/image")
+		assert.Empty(t, details.Suppressed)
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		details, err := GetResourceDiffDetails(target, live, []v1alpha1.ResourceIgnoreDifferences{{
+			Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"},
+		}}, map[string]v1alpha1.ResourceOverride{
+			"apps/Deployment": {IgnoreDifferences: `jsonPointers: ["/spec/template/spec/containers"]`},
+		}, false)
+		assert.NoError(t, err)
+		assert.False(t, details.Modified)
+		assert.Empty(t, details.Diffs)
+		suppressedBy := make(map[string]string)
+		for _, d := range details.Suppressed {
+			suppressedBy[d.Path] = d.SuppressedBy
+		}
+		assert.Equal(t, "spec.ignoreDifferences[0]: /spec/replicas", suppressedBy["/spec/replicas"])
+		assert.Equal(t, "resource.customizations apps/Deployment: /spec/template/spec/containers", suppressedBy["/spec/template/spec/containers/0/image"])
+	})
+
+	t.Run("BuiltIn", func(t *testing.T) {
+		target := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1", "kind": "Secret", "metadata": map[string]interface{}{"name": "my-secret", "namespace": "default"},
+			"stringData": map[string]interface{}{"password": "secret"},
+		}}
+		live := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1", "kind": "Secret", "metadata": map[string]interface{}{"name": "my-secret", "namespace": "default"},
+			"data": map[string]interface{}{"password": "c2VjcmV0"},
+		}}
+		details, err := GetResourceDiffDetails(target, live, nil, nil, false)
+		assert.NoError(t, err)
+		assert.False(t, details.Modified)
+		assert.Empty(t, details.Diffs)
+		if assert.NotEmpty(t, details.Suppressed) {
+			for _, d := range details.Suppressed {
+				assert.Equal(t, builtInNormalization, d.SuppressedBy, d.Path)
+			}
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		details, err := GetResourceDiffDetails(target, nil, nil, nil, false)
+		assert.NoError(t, err)
+		assert.True(t, details.Modified)
+		assert.Empty(t, details.Diffs)
+	})
+}
+
+func TestGetFieldDiffs(t *testing.T) {
+	diffs, err := getFieldDiffs([]byte(`{"a":{"b/c":1,"d":[1,2]},"e":{},"f":null}`), []byte(`{"a":{"b/c":2,"d":[1]},"e":{"g":"h"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []v1alpha1.FieldDiff{
+		{Path: "/a/b~1c", TargetValue: "1", LiveValue: "2"},
+		{Path: "/a/d/1", TargetValue: "2"},
+		{Path: "/e", TargetValue: "{}"},
+		{Path: "/e/g", LiveValue: `"h"`},
+	}, diffs)
+
+	assert.True(t, hasPathPrefix("/spec/replicas", "/spec"))
+	assert.True(t, hasPathPrefix("/spec", "/spec/"))
+	assert.False(t, hasPathPrefix("/specification", "/spec"))
+}
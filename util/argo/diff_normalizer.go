@@ -2,10 +2,13 @@ package argo
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util/diff"
+	"github.com/argoproj/argo-cd/util/kube"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	log "github.com/sirupsen/logrus"
@@ -15,10 +18,29 @@ import (
 )
 
 type normalizerPatch struct {
-	groupKind schema.GroupKind
-	namespace string
-	name      string
-	patch     jsonpatch.Patch
+	rule  normalizationRule
+	patch jsonpatch.Patch
+}
+
+// normalizationRule is a JSON pointer of the ignored differences of an application or of a resource override
+type normalizationRule struct {
+	groupKind   schema.GroupKind
+	namespace   string
+	name        string
+	jsonPointer string
+	// source describes where the rule is configured
+	source string
+	// wildcard permits "*" as the group or the kind of the rule to match any group or kind
+	wildcard bool
+}
+
+// matches returns whether the rule applies to a resource
+func (r *normalizationRule) matches(un *unstructured.Unstructured) bool {
+	gk := un.GroupVersionKind().GroupKind()
+	return (gk.Group == r.groupKind.Group || r.wildcard && r.groupKind.Group == "*") &&
+		(gk.Kind == r.groupKind.Kind || r.wildcard && r.groupKind.Kind == "*") &&
+		(r.name == "" || r.name == un.GetName()) &&
+		(r.namespace == "" || r.namespace == un.GetNamespace())
 }
 
 type normalizer struct {
@@ -29,48 +51,98 @@ type overrideIgnoreDiff struct {
 	JSONPointers []string `yaml:"jsonPointers"`
 }
 
-// NewDiffNormalizer creates diff normalizer which removes ignored fields according to given application spec and resource overrides
-func NewDiffNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride) (diff.Normalizer, error) {
-	for key, override := range overrides {
+// getNormalizationRules returns the rules of the ignored differences of an application and of the resource overrides
+func getNormalizationRules(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride) ([]normalizationRule, error) {
+	var rules []normalizationRule
+	for i := range ignore {
+		for _, path := range ignore[i].JSONPointers {
+			rules = append(rules, normalizationRule{
+				groupKind:   schema.GroupKind{Group: ignore[i].Group, Kind: ignore[i].Kind},
+				name:        ignore[i].Name,
+				namespace:   ignore[i].Namespace,
+				jsonPointer: path,
+				source:      fmt.Sprintf("spec.ignoreDifferences[%d]", i),
+			})
+		}
+	}
+	overrideRules, err := getOverrideRules(overrides, func(override v1alpha1.ResourceOverride) string {
+		return override.IgnoreDifferences
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(rules, overrideRules...), nil
+}
+
+// getOverrideRules returns the rules of the JSON pointers of the resource overrides which a function returns, in the
+// order of the keys of the overrides
+func getOverrideRules(overrides map[string]v1alpha1.ResourceOverride, ignoredFields func(override v1alpha1.ResourceOverride) string, wildcard bool) ([]normalizationRule, error) {
+	var rules []normalizationRule
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields := ignoredFields(overrides[key])
 		parts := strings.Split(key, "/")
-		if len(parts) < 2 {
+		if len(parts) < 2 || fields == "" {
 			continue
 		}
 		group := parts[0]
 		kind := parts[1]
-		if override.IgnoreDifferences != "" {
-			ignoreSettings := overrideIgnoreDiff{}
-			err := yaml.Unmarshal([]byte(override.IgnoreDifferences), &ignoreSettings)
-			if err != nil {
-				return nil, err
-			}
-
-			ignore = append(ignore, v1alpha1.ResourceIgnoreDifferences{
-				Group:        group,
-				Kind:         kind,
-				JSONPointers: ignoreSettings.JSONPointers,
+		ignoreSettings := overrideIgnoreDiff{}
+		err := yaml.Unmarshal([]byte(fields), &ignoreSettings)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range ignoreSettings.JSONPointers {
+			rules = append(rules, normalizationRule{
+				groupKind:   schema.GroupKind{Group: group, Kind: kind},
+				jsonPointer: path,
+				source:      fmt.Sprintf("resource.customizations %s", key),
+				wildcard:    wildcard,
 			})
 		}
 	}
+	return rules, nil
+}
+
+// NewIgnoreResourceUpdatesNormalizer creates a normalizer which removes the fields of resources whose updates the
+// resource overrides ignore when the application controller decides whether to refresh applications. Unlike the keys
+// of the ignored differences, the keys of the ignored updates may use "*" as any group or kind, e.g. "*/*".
+func NewIgnoreResourceUpdatesNormalizer(overrides map[string]v1alpha1.ResourceOverride) (diff.Normalizer, error) {
+	rules, err := getOverrideRules(overrides, func(override v1alpha1.ResourceOverride) string {
+		return override.IgnoreResourceUpdates
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	return newNormalizer(rules)
+}
+
+// NewDiffNormalizer creates diff normalizer which removes ignored fields according to given application spec and resource overrides
+func NewDiffNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride) (diff.Normalizer, error) {
+	rules, err := getNormalizationRules(ignore, overrides)
+	if err != nil {
+		return nil, err
+	}
+	return newNormalizer(rules)
+}
+
+// newNormalizer creates a normalizer which removes the fields of the rules
+func newNormalizer(rules []normalizationRule) (diff.Normalizer, error) {
 	patches := make([]normalizerPatch, 0)
-	for i := range ignore {
-		for _, path := range ignore[i].JSONPointers {
-			patchData, err := json.Marshal([]map[string]string{{"op": "remove", "path": path}})
-			if err != nil {
-				return nil, err
-			}
-			patch, err := jsonpatch.DecodePatch(patchData)
-			if err != nil {
-				return nil, err
-			}
-			patches = append(patches, normalizerPatch{
-				groupKind: schema.GroupKind{Group: ignore[i].Group, Kind: ignore[i].Kind},
-				name:      ignore[i].Name,
-				namespace: ignore[i].Namespace,
-				patch:     patch,
-			})
+	for _, rule := range rules {
+		patchData, err := json.Marshal([]map[string]string{{"op": "remove", "path": rule.jsonPointer}})
+		if err != nil {
+			return nil, err
 		}
-
+		patch, err := jsonpatch.DecodePatch(patchData)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, normalizerPatch{rule: rule, patch: patch})
 	}
 	return &normalizer{patches: patches}, nil
 }
@@ -79,11 +151,7 @@ func NewDiffNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides ma
 func (n *normalizer) Normalize(un *unstructured.Unstructured) error {
 	matched := make([]normalizerPatch, 0)
 	for _, patch := range n.patches {
-		groupKind := un.GroupVersionKind().GroupKind()
-		if groupKind == patch.groupKind &&
-			(patch.name == "" || patch.name == un.GetName()) &&
-			(patch.namespace == "" || patch.namespace == un.GetNamespace()) {
-
+		if patch.rule.matches(un) {
 			matched = append(matched, patch)
 		}
 	}
@@ -111,3 +179,42 @@ func (n *normalizer) Normalize(un *unstructured.Unstructured) error {
 	}
 	return nil
 }
+
+// secretGenerators are the kinds whose controllers generate the data of the Secrets they own
+var secretGenerators = map[schema.GroupKind]bool{
+	{Group: "bitnami.com", Kind: "SealedSecret"}:           true,
+	{Group: "external-secrets.io", Kind: "ExternalSecret"}: true,
+}
+
+// isGeneratedSecret returns whether the data of a live Secret is generated by the controller of its owner
+func isGeneratedSecret(live *unstructured.Unstructured) bool {
+	if live == nil || live.GetKind() != kube.SecretKind || live.GroupVersionKind().Group != "" {
+		return false
+	}
+	for _, ref := range live.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && secretGenerators[schema.GroupKind{Group: gv.Group, Kind: ref.Kind}] {
+			return true
+		}
+	}
+	return false
+}
+
+// HideGeneratedSecretData returns copies of the target and live state of a Secret without data if the live data is
+// generated by a controller, e.g. of a SealedSecret, so the generated data neither causes diffs nor is exposed
+func HideGeneratedSecretData(target, live *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured) {
+	if !isGeneratedSecret(live) {
+		return target, live
+	}
+	return withoutSecretData(target), withoutSecretData(live)
+}
+
+func withoutSecretData(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "data")
+	unstructured.RemoveNestedField(obj.Object, "stringData")
+	return obj
+}
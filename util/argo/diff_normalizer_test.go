@@ -5,6 +5,8 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
@@ -93,6 +95,37 @@ spec:
   scope: Namespaced
   version: v1alpha1`
 
+func TestNewIgnoreResourceUpdatesNormalizer(t *testing.T) {
+	normalizer, err := NewIgnoreResourceUpdatesNormalizer(map[string]v1alpha1.ResourceOverride{
+		"*/*":              {IgnoreResourceUpdates: "jsonPointers:\n- /status"},
+		"apps/Deployment":  {IgnoreResourceUpdates: "jsonPointers:\n- /metadata/annotations/noisy"},
+		"batch/Job":        {IgnoreDifferences: "jsonPointers:\n- /spec"},
+		"apps/StatefulSet": {IgnoreResourceUpdates: "jsonPointers:\n- /spec"},
+	})
+	assert.NoError(t, err)
+
+	deployment := kube.MustToUnstructured(test.DemoDeployment())
+	deployment.SetAnnotations(map[string]string{"noisy": "1", "other": "2"})
+	assert.NoError(t, unstructured.SetNestedField(deployment.Object, int64(1), "status", "replicas"))
+	assert.NoError(t, normalizer.Normalize(deployment))
+	_, has, err := unstructured.NestedMap(deployment.Object, "status")
+	assert.NoError(t, err)
+	assert.False(t, has)
+	assert.Equal(t, map[string]string{"other": "2"}, deployment.GetAnnotations())
+	_, has, err = unstructured.NestedMap(deployment.Object, "spec")
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	// the ignored differences are not ignored updates, and the wildcards only apply to the ignored updates
+	diffNormalizer, err := NewDiffNormalizer(nil, map[string]v1alpha1.ResourceOverride{"*/*": {IgnoreDifferences: "jsonPointers:\n- /spec"}})
+	assert.NoError(t, err)
+	deployment = kube.MustToUnstructured(test.DemoDeployment())
+	assert.NoError(t, diffNormalizer.Normalize(deployment))
+	_, has, err = unstructured.NestedMap(deployment.Object, "spec")
+	assert.NoError(t, err)
+	assert.True(t, has)
+}
+
 func TestNormalizeMissingJsonPointer(t *testing.T) {
 	normalizer, err := NewDiffNormalizer([]v1alpha1.ResourceIgnoreDifferences{}, map[string]v1alpha1.ResourceOverride{
 		"apps/Deployment": {
@@ -116,3 +149,32 @@ func TestNormalizeMissingJsonPointer(t *testing.T) {
 	err = normalizer.Normalize(&crd)
 	assert.NoError(t, err)
 }
+
+func TestHideGeneratedSecretData(t *testing.T) {
+	target := kube.MustToUnstructured(&v1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "database"},
+		StringData: map[string]string{"password": "placeholder"},
+	})
+	live := kube.MustToUnstructured(&v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "database", OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "external-secrets.io/v1alpha1", Kind: "ExternalSecret", Name: "database",
+		}}},
+		Data: map[string][]byte{"password": []byte("s3cr3t")},
+	})
+
+	hiddenTarget, hiddenLive := HideGeneratedSecretData(target, live)
+	_, has, _ := unstructured.NestedMap(hiddenTarget.Object, "stringData")
+	assert.False(t, has)
+	_, has, _ = unstructured.NestedMap(hiddenLive.Object, "data")
+	assert.False(t, has)
+	// the originals are unchanged
+	_, has, _ = unstructured.NestedMap(live.Object, "data")
+	assert.True(t, has)
+
+	live.SetOwnerReferences(nil)
+	hiddenTarget, hiddenLive = HideGeneratedSecretData(target, live)
+	assert.Equal(t, target, hiddenTarget)
+	assert.Equal(t, live, hiddenLive)
+}
@@ -0,0 +1,306 @@
+// Package attestation records the provenance of generated manifests in signed in-toto statements with a SLSA
+// provenance predicate, wrapped in DSSE envelopes, so that deployed manifests can be traced back to their sources.
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// StatementType is the type of in-toto statements
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// PredicateType is the type of the SLSA provenance predicate
+	PredicateType = "https://slsa.dev/provenance/v0.1"
+	// PayloadType is the type of the payload of the DSSE envelopes
+	PayloadType = "application/vnd.in-toto+json"
+	// BuilderID identifies the repo server as the builder of the manifests
+	BuilderID = "https://argoproj.github.io/argo-cd/argocd-repo-server"
+	// RecipeTypePrefix is the prefix of the recipe type, followed by the source type of the application
+	RecipeTypePrefix = "https://argoproj.github.io/argo-cd/manifest-generation/"
+)
+
+// Statement is an in-toto statement about the generated manifests
+type Statement struct {
+	Type          string     `json:"_type"`
+	PredicateType string     `json:"predicateType"`
+	Subject       []Subject  `json:"subject"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is a generated manifest, named group/kind/namespace/name
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance is a SLSA provenance predicate recording how the manifests were generated
+type Provenance struct {
+	Builder   Builder    `json:"builder"`
+	Recipe    Recipe     `json:"recipe"`
+	Metadata  Metadata   `json:"metadata"`
+	Materials []Material `json:"materials"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Recipe is the tool and parameters the manifests were generated with
+type Recipe struct {
+	Type string `json:"type"`
+	// DefinedInMaterial is the index of the material containing the source of the application
+	DefinedInMaterial *int `json:"definedInMaterial,omitempty"`
+	// EntryPoint is the path of the application in the repository, or the chart of a Helm repository
+	EntryPoint string `json:"entryPoint,omitempty"`
+	// Arguments are the parameters of the manifest generation, i.e. the source of the application
+	Arguments interface{} `json:"arguments,omitempty"`
+	// Environment are the versions of Argo CD and of the config management tool
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+type Metadata struct {
+	BuildStartedOn  *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn *time.Time `json:"buildFinishedOn,omitempty"`
+}
+
+// Material is a source of the manifests, e.g. a Git repository at a commit
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Envelope is a DSSE envelope of a signed statement
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// NewStatement returns a statement about manifests, with a subject per manifest
+func NewStatement(manifests []string, provenance Provenance) (*Statement, error) {
+	subjects := make([]Subject, 0, len(manifests))
+	for _, manifest := range manifests {
+		name, err := subjectName(manifest)
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, Subject{Name: name, Digest: digest(manifest)})
+	}
+	return &Statement{Type: StatementType, PredicateType: PredicateType, Subject: subjects, Predicate: provenance}, nil
+}
+
+func subjectName(manifest string) (string, error) {
+	var obj unstructured.Unstructured
+	if err := json.Unmarshal([]byte(manifest), &obj); err != nil {
+		return "", err
+	}
+	gvk := obj.GroupVersionKind()
+	return fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()), nil
+}
+
+func digest(manifest string) map[string]string {
+	sum := sha256.Sum256([]byte(manifest))
+	return map[string]string{"sha256": hex.EncodeToString(sum[:])}
+}
+
+// VerifyManifests returns an error if the manifests are not the subjects of the statement. The manifests for which
+// skip returns true are only required to have a subject of the same name, e.g. secrets whose data is hidden.
+func (s *Statement) VerifyManifests(manifests []string, skip func(manifest string) bool) error {
+	digests := make(map[string]string)
+	for _, subject := range s.Subject {
+		digests[subject.Name] = subject.Digest["sha256"]
+	}
+	if len(manifests) != len(s.Subject) {
+		return fmt.Errorf("%d manifests were generated, but %d are attested", len(manifests), len(s.Subject))
+	}
+	for _, manifest := range manifests {
+		name, err := subjectName(manifest)
+		if err != nil {
+			return err
+		}
+		expected, ok := digests[name]
+		if !ok {
+			return fmt.Errorf("manifest %s is not attested", name)
+		}
+		if skip != nil && skip(manifest) {
+			continue
+		}
+		if actual := digest(manifest)["sha256"]; actual != expected {
+			return fmt.Errorf("the digest of manifest %s is %s, but %s is attested", name, actual, expected)
+		}
+	}
+	return nil
+}
+
+// Signer signs statements with a private key
+type Signer struct {
+	key   crypto.Signer
+	keyID string
+}
+
+// NewSigner returns a signer of the PEM encoded PKCS #8 or EC private key. ECDSA, Ed25519 and RSA keys are supported.
+func NewSigner(keyPEM []byte) (*Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("the signing key is not PEM encoded")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the signing key: %v", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key of type %T", key)
+	}
+	keyID, err := KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: signer, keyID: keyID}, nil
+}
+
+// KeyID returns the ID of a public key, which is the SHA-256 of its PKIX encoding
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Sign returns the envelope of a statement signed by the signer
+func (s *Signer) Sign(statement *Statement) (*Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+	message := pae(PayloadType, payload)
+	var sig []byte
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		sig, err = s.key.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(message)
+		sig, err = s.key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyID: s.keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX public key
+func ParsePublicKey(keyPEM []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("the public key is not PEM encoded")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// Verify returns the statement of an envelope if it is signed by the public key
+func Verify(envelope *Envelope, publicKey crypto.PublicKey) (*Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("unexpected payload type %s", envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	message := pae(envelope.PayloadType, payload)
+	sum := sha256.Sum256(message)
+	verified := false
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		switch key := publicKey.(type) {
+		case *ecdsa.PublicKey:
+			verified = ecdsa.VerifyASN1(key, sum[:], sig)
+		case ed25519.PublicKey:
+			verified = ed25519.Verify(key, message, sig)
+		case *rsa.PublicKey:
+			verified = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) == nil
+		default:
+			return nil, fmt.Errorf("unsupported public key of type %T", publicKey)
+		}
+		if verified {
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("the attestation is not signed by the public key")
+	}
+	return envelope.Statement()
+}
+
+// Statement returns the statement of an envelope without verifying its signature
+func (e *Envelope) Statement() (*Statement, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, err
+	}
+	var statement Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// pae returns the pre-authentication encoding of a payload, which is what DSSE envelopes sign
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// AddAttestationFlagsToCmd adds the flags of the signing key of attestations to a command, and returns a function
+// returning the signer, which is nil if attestations are disabled
+func AddAttestationFlagsToCmd(cmd *cobra.Command) func() (*Signer, error) {
+	var keyPath string
+	cmd.Flags().StringVar(&keyPath, "attestation-signing-key", "", "Path of the PEM encoded private key to sign the provenance attestations of generated manifests with. Attestations are disabled if empty.")
+	return func() (*Signer, error) {
+		if strings.TrimSpace(keyPath) == "" {
+			return nil, nil
+		}
+		keyPEM, err := ioutil.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		return NewSigner(keyPEM)
+	}
+}
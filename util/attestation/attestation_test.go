@@ -0,0 +1,86 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	configMap = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-config","namespace":"default"}}`
+	secret    = `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"my-secret","namespace":"default"},"data":{"key":"dmFsdWU="}}`
+)
+
+func newTestSigner(t *testing.T, key crypto.Signer) *Signer {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	signer, err := NewSigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+	return signer
+}
+
+func TestSignVerify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	statement, err := NewStatement([]string{configMap}, Provenance{
+		Builder:   Builder{ID: BuilderID},
+		Materials: []Material{{URI: "https://github.com/argoproj/argocd-example-apps", Digest: map[string]string{"sha1": "8c1f5e2"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/ConfigMap/default/my-config", statement.Subject[0].Name)
+
+	for _, key := range []crypto.Signer{ecdsaKey, ed25519Key} {
+		envelope, err := newTestSigner(t, key).Sign(statement)
+		assert.NoError(t, err)
+		keyID, err := KeyID(key.Public())
+		assert.NoError(t, err)
+		assert.Equal(t, keyID, envelope.Signatures[0].KeyID)
+
+		verified, err := Verify(envelope, key.Public())
+		assert.NoError(t, err)
+		assert.Equal(t, statement, verified)
+
+		_, err = Verify(envelope, otherKey.Public())
+		assert.EqualError(t, err, "the attestation is not signed by the public key")
+
+		tampered := *envelope
+		tampered.Payload = envelope.Payload[:len(envelope.Payload)-4]
+		_, err = Verify(&tampered, key.Public())
+		assert.Error(t, err)
+	}
+}
+
+func TestNewSigner_Invalid(t *testing.T) {
+	_, err := NewSigner([]byte("not a key"))
+	assert.EqualError(t, err, "the signing key is not PEM encoded")
+	_, err = NewSigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")}))
+	assert.Error(t, err)
+}
+
+func TestVerifyManifests(t *testing.T) {
+	statement, err := NewStatement([]string{configMap, secret}, Provenance{})
+	assert.NoError(t, err)
+	assert.NoError(t, statement.VerifyManifests([]string{secret, configMap}, nil))
+
+	hiddenSecret := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"my-secret","namespace":"default"},"data":{"key":"++++++++"}}`
+	assert.Error(t, statement.VerifyManifests([]string{configMap, hiddenSecret}, nil))
+	assert.NoError(t, statement.VerifyManifests([]string{configMap, hiddenSecret}, func(manifest string) bool {
+		return manifest == hiddenSecret
+	}))
+
+	assert.EqualError(t, statement.VerifyManifests([]string{configMap}, nil), "1 manifests were generated, but 2 are attested")
+	changed := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-config","namespace":"default"},"data":{"a":"b"}}`
+	assert.Error(t, statement.VerifyManifests([]string{changed, secret}, nil))
+}
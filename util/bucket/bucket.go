@@ -0,0 +1,216 @@
+package bucket
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/util"
+	executil "github.com/argoproj/argo-cd/util/exec"
+)
+
+const (
+	// SchemeS3 is the scheme of the URLs of S3 (or S3 compatible) buckets, e.g. s3://my-bucket/manifests?region=eu-west-1
+	SchemeS3 = "s3"
+	// SchemeGCS is the scheme of the URLs of Google Cloud Storage buckets, e.g. gs://my-bucket/manifests
+	SchemeGCS = "gs"
+)
+
+// IsBucketURL returns true if the URL is the URL of an S3 or GCS bucket
+func IsBucketURL(url string) bool {
+	return strings.HasPrefix(url, SchemeS3+"://") || strings.HasPrefix(url, SchemeGCS+"://")
+}
+
+// Creds are the keys of a bucket. The credentials of the environment, e.g. of the IAM role or of the workload identity
+// of the pod, are used if they are empty.
+type Creds struct {
+	// Username is the access key ID of S3 buckets
+	Username string
+	// Password is the secret access key of S3 buckets, or the JSON key of a service account of GCS buckets
+	Password string
+}
+
+// Client downloads the objects under the prefix of a bucket. Since the objects of buckets have no history, the revision
+// of a bucket is a fingerprint of the paths, sizes, modification times and hashes (i.e. the ETags) of its objects,
+// which changes whenever an object changes.
+type Client interface {
+	// ResolveRevision returns the current revision of the bucket. An empty revision or HEAD is resolved to the current
+	// revision, other revisions must be the current revision.
+	ResolveRevision(revision string) (string, error)
+	// Download downloads the objects of the revision into a temporary directory which is removed by the closer. It fails
+	// if the revision is not the current revision any more.
+	Download(revision string) (string, util.Closer, error)
+}
+
+func NewClient(repoURL string, creds Creds) (Client, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("bucket of %s is missing", repoURL)
+	}
+	c := &rcloneClient{
+		repoURL: repoURL,
+		creds:   creds,
+		path:    parsedURL.Host,
+	}
+	if prefix := strings.Trim(parsedURL.Path, "/"); prefix != "" {
+		c.path += "/" + prefix
+	}
+	query := parsedURL.Query()
+	switch parsedURL.Scheme {
+	case SchemeS3:
+		c.backend = "s3"
+		endpoint := query.Get("endpoint")
+		c.flags = []string{"--s3-env-auth"}
+		if endpoint != "" {
+			c.flags = append(c.flags, "--s3-provider", "Other", "--s3-endpoint", endpoint)
+		} else {
+			c.flags = append(c.flags, "--s3-provider", "AWS")
+		}
+		if region := query.Get("region"); region != "" {
+			c.flags = append(c.flags, "--s3-region", region)
+		}
+	case SchemeGCS:
+		c.backend = "gcs"
+	default:
+		return nil, fmt.Errorf("unsupported bucket URL %s, expected one of: %s://|%s://", repoURL, SchemeS3, SchemeGCS)
+	}
+	return c, nil
+}
+
+// rcloneClient runs the rclone CLI with an on the fly remote of the bucket
+type rcloneClient struct {
+	repoURL string
+	creds   Creds
+	backend string
+	// path is the bucket and the prefix of the objects
+	path  string
+	flags []string
+}
+
+// object is an object of the output of rclone lsjson
+type object struct {
+	Path    string            `json:"Path"`
+	Size    int64             `json:"Size"`
+	ModTime string            `json:"ModTime"`
+	Hashes  map[string]string `json:"Hashes,omitempty"`
+}
+
+// environ returns the environment of rclone with the credentials of the bucket, so they never show up in the arguments
+// of the process
+func (c *rcloneClient) environ() []string {
+	env := os.Environ()
+	switch c.backend {
+	case "s3":
+		if c.creds.Username != "" || c.creds.Password != "" {
+			env = append(env, "RCLONE_S3_ACCESS_KEY_ID="+c.creds.Username, "RCLONE_S3_SECRET_ACCESS_KEY="+c.creds.Password)
+		}
+	case "gcs":
+		if c.creds.Password != "" {
+			env = append(env, "RCLONE_GCS_SERVICE_ACCOUNT_CREDENTIALS="+c.creds.Password)
+		}
+	}
+	return env
+}
+
+func (c *rcloneClient) run(dir string, args ...string) (string, error) {
+	args = append(append(args, "--config", os.DevNull), c.flags...)
+	cmd := exec.Command("rclone", args...)
+	cmd.Dir = dir
+	cmd.Env = c.environ()
+	out, err := executil.Run(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to run rclone %s on %s: %v", args[0], c.repoURL, err)
+	}
+	return out, nil
+}
+
+func (c *rcloneClient) remote() string {
+	return fmt.Sprintf(":%s:%s", c.backend, c.path)
+}
+
+func (c *rcloneClient) list() ([]object, error) {
+	out, err := c.run("", "lsjson", c.remote(), "--recursive", "--files-only", "--hash", "--no-mimetype")
+	if err != nil {
+		return nil, err
+	}
+	var objects []object
+	if err := json.Unmarshal([]byte(out), &objects); err != nil {
+		return nil, fmt.Errorf("failed to parse objects of %s: %v", c.repoURL, err)
+	}
+	return objects, nil
+}
+
+// fingerprint returns the revision of the objects
+func fingerprint(objects []object) string {
+	objects = append([]object{}, objects...)
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Path < objects[j].Path
+	})
+	h := sha256.New()
+	for _, o := range objects {
+		var hashes []string
+		for name, hash := range o.Hashes {
+			hashes = append(hashes, name+"="+hash)
+		}
+		sort.Strings(hashes)
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00%s\x00%s\n", o.Path, o.Size, o.ModTime, strings.Join(hashes, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *rcloneClient) currentRevision() (string, error) {
+	objects, err := c.list()
+	if err != nil {
+		return "", err
+	}
+	return fingerprint(objects), nil
+}
+
+func (c *rcloneClient) ResolveRevision(revision string) (string, error) {
+	current, err := c.currentRevision()
+	if err != nil {
+		return "", err
+	}
+	if revision != "" && revision != "HEAD" && revision != current {
+		return "", fmt.Errorf("revision %s of %s is not the current revision %s, the objects of buckets have no history", revision, c.repoURL, current)
+	}
+	return current, nil
+}
+
+func (c *rcloneClient) Download(revision string) (string, util.Closer, error) {
+	revision, err := c.ResolveRevision(revision)
+	if err != nil {
+		return "", nil, err
+	}
+	tempDir, err := ioutil.TempDir("", "bucket")
+	if err != nil {
+		return "", nil, err
+	}
+	closer := util.NewCloser(func() error {
+		return os.RemoveAll(tempDir)
+	})
+	if _, err := c.run(tempDir, "copy", c.remote(), "."); err != nil {
+		util.Close(closer)
+		return "", nil, err
+	}
+	// the objects might have been updated while they were downloaded
+	current, err := c.currentRevision()
+	if err == nil && current != revision {
+		err = fmt.Errorf("objects of %s changed during the download of revision %s", c.repoURL, revision)
+	}
+	if err != nil {
+		util.Close(closer)
+		return "", nil, err
+	}
+	return tempDir, closer, nil
+}
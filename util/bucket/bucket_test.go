@@ -0,0 +1,96 @@
+package bucket
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient("s3://my-bucket/manifests/?region=eu-west-1", Creds{})
+	assert.NoError(t, err)
+	c := client.(*rcloneClient)
+	assert.Equal(t, ":s3:my-bucket/manifests", c.remote())
+	assert.Equal(t, []string{"--s3-env-auth", "--s3-provider", "AWS", "--s3-region", "eu-west-1"}, c.flags)
+
+	client, err = NewClient("s3://my-bucket?endpoint=https://minio:9000", Creds{})
+	assert.NoError(t, err)
+	c = client.(*rcloneClient)
+	assert.Equal(t, ":s3:my-bucket", c.remote())
+	assert.Equal(t, []string{"--s3-env-auth", "--s3-provider", "Other", "--s3-endpoint", "https://minio:9000"}, c.flags)
+
+	client, err = NewClient("gs://my-bucket/manifests", Creds{Password: "{}"})
+	assert.NoError(t, err)
+	c = client.(*rcloneClient)
+	assert.Equal(t, ":gcs:my-bucket/manifests", c.remote())
+	assert.Contains(t, c.environ(), "RCLONE_GCS_SERVICE_ACCOUNT_CREDENTIALS={}")
+
+	_, err = NewClient("s3:///manifests", Creds{})
+	assert.Error(t, err)
+	_, err = NewClient("azure://my-bucket", Creds{})
+	assert.Error(t, err)
+}
+
+func TestFingerprint(t *testing.T) {
+	a := object{Path: "a.yaml", Size: 10, ModTime: "2020-09-01T10:00:00Z", Hashes: map[string]string{"md5": "1"}}
+	b := object{Path: "b.yaml", Size: 10, ModTime: "2020-09-01T10:00:00Z", Hashes: map[string]string{"md5": "2"}}
+	assert.Equal(t, fingerprint([]object{a, b}), fingerprint([]object{b, a}))
+	changed := b
+	changed.Hashes = map[string]string{"md5": "3"}
+	assert.NotEqual(t, fingerprint([]object{a, b}), fingerprint([]object{a, changed}))
+	assert.NotEqual(t, fingerprint([]object{a, b}), fingerprint([]object{a}))
+}
+
+// fakeRclone lists a single object, whose hash is read from a file so that tests can change it, and copies it
+const fakeRclone = `#!/bin/sh
+case "$1" in
+lsjson) echo "[{\"Path\":\"pod.yaml\",\"Size\":9,\"ModTime\":\"2020-09-01T10:00:00Z\",\"Hashes\":{\"md5\":\"$(cat $HASH_FILE)\"}}]" ;;
+copy) echo "kind: Pod" > pod.yaml ;;
+*) exit 1 ;;
+esac
+`
+
+func TestClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rclone")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "rclone"), []byte(fakeRclone), 0755))
+	hashFile := filepath.Join(dir, "hash")
+	assert.NoError(t, ioutil.WriteFile(hashFile, []byte("1"), 0644))
+	path := os.Getenv("PATH")
+	assert.NoError(t, os.Setenv("PATH", dir+string(os.PathListSeparator)+path))
+	assert.NoError(t, os.Setenv("HASH_FILE", hashFile))
+	defer func() {
+		_ = os.Setenv("PATH", path)
+		_ = os.Unsetenv("HASH_FILE")
+	}()
+
+	client, err := NewClient("s3://my-bucket/manifests", Creds{Username: "id", Password: "secret"})
+	assert.NoError(t, err)
+	revision, err := client.ResolveRevision("")
+	assert.NoError(t, err)
+	assert.Len(t, revision, 64)
+	head, err := client.ResolveRevision("HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, revision, head)
+
+	root, closer, err := client.Download(revision)
+	assert.NoError(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(root, "pod.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: Pod\n", string(data))
+	assert.NoError(t, closer.Close())
+	_, err = os.Stat(root)
+	assert.True(t, os.IsNotExist(err))
+
+	// the object changed
+	assert.NoError(t, ioutil.WriteFile(hashFile, []byte("2"), 0644))
+	_, _, err = client.Download(revision)
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "is not the current revision"))
+	}
+}
@@ -42,6 +42,11 @@ func AddCacheFlagsToCmd(cmd *cobra.Command) func() (*Cache, error) {
 	}
 }
 
+// Ping returns an error if the cache is unreachable
+func (c *Cache) Ping() error {
+	return c.cache.Ping()
+}
+
 func (c *Cache) GetItem(key string, item interface{}) error {
 	return c.cache.GetItem(key, item)
 }
@@ -50,6 +55,18 @@ func (c *Cache) SetItem(key string, item interface{}, expiration time.Duration,
 	return c.cache.SetItem(key, item, expiration, delete)
 }
 
+func (c *Cache) SetHashItem(key string, field string, item interface{}) error {
+	return c.cache.SetHashItem(key, field, item)
+}
+
+func (c *Cache) GetHashItems(key string, items interface{}) error {
+	return c.cache.GetHashItems(key, items)
+}
+
+func (c *Cache) DeleteHashItems(key string, fields ...string) error {
+	return c.cache.DeleteHashItems(key, fields...)
+}
+
 func appManagedResourcesKey(appName string) string {
 	return fmt.Sprintf("app|managed-resources|%s", appName)
 }
@@ -75,3 +92,50 @@ func (c *Cache) GetAppResourcesTree(appName string, res *appv1.ApplicationTree)
 func (c *Cache) SetAppResourcesTree(appName string, resourcesTree *appv1.ApplicationTree) error {
 	return c.SetItem(appResourcesTreeKey(appName), resourcesTree, c.appStateCacheExpiration, resourcesTree == nil)
 }
+
+// AppDiffs are the results of the comparison of the target and the live state of the resources of an application
+type AppDiffs struct {
+	// Key identifies the compared state, i.e. the manifests, the comparison settings and the versions of the live
+	// resources
+	Key   string
+	Diffs []ResourceDiffResult
+}
+
+// ResourceDiffResult is the result of the comparison of the target and the live state of a resource
+type ResourceDiffResult struct {
+	Modified       bool
+	PredictedLive  []byte
+	NormalizedLive []byte
+}
+
+func appDiffsKey(appName string, destination appv1.ApplicationDestination) string {
+	return fmt.Sprintf("app|diffs|%s|%s|%s", appName, destination.Server, destination.Namespace)
+}
+
+// GetAppDiffs returns the last results of the comparison of the resources of an application with a destination
+func (c *Cache) GetAppDiffs(appName string, destination appv1.ApplicationDestination, res *AppDiffs) error {
+	return c.GetItem(appDiffsKey(appName, destination), &res)
+}
+
+// SetAppDiffs records the results of the comparison of the resources of an application with a destination
+func (c *Cache) SetAppDiffs(appName string, destination appv1.ApplicationDestination, diffs *AppDiffs) error {
+	return c.SetItem(appDiffsKey(appName, destination), diffs, c.appStateCacheExpiration, diffs == nil)
+}
+
+// clusterInfoCacheExpiration is the time the state of a cluster reported by the application controller is kept, so
+// that the state of clusters which are no longer managed by any controller expires
+const clusterInfoCacheExpiration = time.Minute
+
+func clusterInfoKey(server string) string {
+	return fmt.Sprintf("cluster|info|%s", server)
+}
+
+// GetClusterInfo returns the state of a cluster reported by the application controller which manages it
+func (c *Cache) GetClusterInfo(server string, res *appv1.ClusterInfo) error {
+	return c.GetItem(clusterInfoKey(server), &res)
+}
+
+// SetClusterInfo records the state of a cluster reported by the application controller which manages it
+func (c *Cache) SetClusterInfo(server string, info *appv1.ClusterInfo) error {
+	return c.SetItem(clusterInfoKey(server), info, clusterInfoCacheExpiration, info == nil)
+}
@@ -63,3 +63,38 @@ func TestAddCacheFlagsToCmd(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1*time.Hour, cache.appStateCacheExpiration)
 }
+
+func TestCache_GetClusterInfo(t *testing.T) {
+	cache := newFixtures().Cache
+	// cache miss
+	value := &ClusterInfo{}
+	err := cache.GetClusterInfo("https://kubernetes.default.svc", value)
+	assert.Equal(t, ErrCacheMiss, err)
+	// populate cache
+	err = cache.SetClusterInfo("https://kubernetes.default.svc", &ClusterInfo{Shard: 1, ApplicationsCount: 3})
+	assert.NoError(t, err)
+	// cache hit
+	err = cache.GetClusterInfo("https://kubernetes.default.svc", value)
+	assert.NoError(t, err)
+	assert.Equal(t, &ClusterInfo{Shard: 1, ApplicationsCount: 3}, value)
+}
+
+func TestCache_GetAppDiffs(t *testing.T) {
+	cache := newFixtures().Cache
+	destination := ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "default"}
+	// cache miss
+	value := &AppDiffs{}
+	err := cache.GetAppDiffs("my-appname", destination, value)
+	assert.Equal(t, ErrCacheMiss, err)
+	// populate cache
+	diffs := &AppDiffs{Key: "my-key", Diffs: []ResourceDiffResult{{Modified: true, PredictedLive: []byte("{}"), NormalizedLive: []byte("null")}}}
+	err = cache.SetAppDiffs("my-appname", destination, diffs)
+	assert.NoError(t, err)
+	// cache miss
+	err = cache.GetAppDiffs("my-appname", ApplicationDestination{Server: destination.Server, Namespace: "other"}, value)
+	assert.Equal(t, ErrCacheMiss, err)
+	// cache hit
+	err = cache.GetAppDiffs("my-appname", destination, value)
+	assert.NoError(t, err)
+	assert.Equal(t, diffs, value)
+}
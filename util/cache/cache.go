@@ -1,7 +1,11 @@
 package cache
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -9,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/util/fips"
 )
 
 const (
@@ -20,40 +25,114 @@ func NewCache(client CacheClient) *Cache {
 	return &Cache{client}
 }
 
+// redisTLSOptions are the options of TLS connections to Redis
+type redisTLSOptions struct {
+	enabled            bool
+	caCertificatePath  string
+	certificatePath    string
+	keyPath            string
+	insecureSkipVerify bool
+}
+
+// newTLSConfig returns the TLS config of the connections to the Redis servers, or nil if TLS is disabled
+func (o redisTLSOptions) newTLSConfig() (*tls.Config, error) {
+	if !o.enabled {
+		return nil, nil
+	}
+	config := &tls.Config{InsecureSkipVerify: o.insecureSkipVerify}
+	fips.ConfigureTLS(config)
+	if o.caCertificatePath != "" {
+		caCert, err := ioutil.ReadFile(o.caCertificatePath)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in %s", o.caCertificatePath)
+		}
+		config.RootCAs = pool
+	}
+	if o.certificatePath != "" || o.keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(o.certificatePath, o.keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
 // AddCacheFlagsToCmd adds flags which control caching to the specified command
 func AddCacheFlagsToCmd(cmd *cobra.Command) func() (*Cache, error) {
 	redisAddress := ""
 	sentinelAddresses := make([]string, 0)
 	sentinelMaster := ""
+	clusterAddresses := make([]string, 0)
 	redisDB := 0
+	var tlsOptions redisTLSOptions
 	var defaultCacheExpiration time.Duration
+	fallbackMaxItems := 0
 
 	cmd.Flags().StringVar(&redisAddress, "redis", "", "Redis server hostname and port (e.g. argocd-redis:6379). ")
 	cmd.Flags().IntVar(&redisDB, "redisdb", 0, "Redis database.")
 	cmd.Flags().StringArrayVar(&sentinelAddresses, "sentinel", []string{}, "Redis sentinel hostname and port (e.g. argocd-redis-ha-announce-0:6379). ")
 	cmd.Flags().StringVar(&sentinelMaster, "sentinelmaster", "master", "Redis sentinel master group name.")
+	cmd.Flags().StringArrayVar(&clusterAddresses, "redis-cluster", []string{}, "Redis cluster node hostname and port (e.g. redis-cluster-0:6379). The other nodes of the cluster are discovered.")
+	cmd.Flags().BoolVar(&tlsOptions.enabled, "redis-use-tls", false, "Use TLS when connecting to Redis.")
+	cmd.Flags().StringVar(&tlsOptions.caCertificatePath, "redis-ca-certificate", "", "Path of the CA certificate of the Redis servers (default the CA certificates of the system).")
+	cmd.Flags().StringVar(&tlsOptions.certificatePath, "redis-client-certificate", "", "Path of the client certificate of TLS connections to Redis.")
+	cmd.Flags().StringVar(&tlsOptions.keyPath, "redis-client-key", "", "Path of the key of the client certificate of TLS connections to Redis.")
+	cmd.Flags().BoolVar(&tlsOptions.insecureSkipVerify, "redis-insecure-skip-tls-verify", false, "Skip the verification of the certificates of the Redis servers.")
 	cmd.Flags().DurationVar(&defaultCacheExpiration, "default-cache-expiration", 24*time.Hour, "Cache expiration default")
+	cmd.Flags().IntVar(&fallbackMaxItems, "redis-fallback-max-items", 0, "Maximum number of items of the in-memory cache which is used while Redis is unavailable, rather than failing requests (default 0, i.e. requests fail while Redis is unavailable).")
 	return func() (*Cache, error) {
+		newCache := func(client redis.UniversalClient) *Cache {
+			cacheClient := NewRedisCache(client, defaultCacheExpiration)
+			if fallbackMaxItems > 0 {
+				cacheClient = NewFallbackCache(cacheClient, fallbackMaxItems, defaultCacheExpiration)
+			}
+			return NewCache(cacheClient)
+		}
 		password := os.Getenv(envRedisPassword)
+		tlsConfig, err := tlsOptions.newTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if len(sentinelAddresses) > 0 && len(clusterAddresses) > 0 {
+			return nil, fmt.Errorf("redis sentinels and redis cluster nodes are mutually exclusive")
+		}
 		if len(sentinelAddresses) > 0 {
 			client := redis.NewFailoverClient(&redis.FailoverOptions{
 				MasterName:    sentinelMaster,
 				SentinelAddrs: sentinelAddresses,
 				DB:            redisDB,
 				Password:      password,
+				TLSConfig:     tlsConfig,
 			})
-			return NewCache(NewRedisCache(client, defaultCacheExpiration)), nil
+			return newCache(client), nil
+		}
+		if len(clusterAddresses) > 0 {
+			if redisDB != 0 {
+				return nil, fmt.Errorf("redis cluster supports database 0 only")
+			}
+			client := redis.NewClusterClient(&redis.ClusterOptions{
+				Addrs:     clusterAddresses,
+				Password:  password,
+				TLSConfig: tlsConfig,
+			})
+			return newCache(client), nil
 		}
 
 		if redisAddress == "" {
 			redisAddress = common.DefaultRedisAddr
 		}
 		client := redis.NewClient(&redis.Options{
-			Addr:     redisAddress,
-			Password: password,
-			DB:       redisDB,
+			Addr:      redisAddress,
+			Password:  password,
+			DB:        redisDB,
+			TLSConfig: tlsConfig,
 		})
-		return NewCache(NewRedisCache(client, defaultCacheExpiration)), nil
+		return newCache(client), nil
 	}
 }
 
@@ -62,6 +141,11 @@ type Cache struct {
 	client CacheClient
 }
 
+// Ping returns an error if the cache is unreachable
+func (c *Cache) Ping() error {
+	return c.client.Ping()
+}
+
 func (c *Cache) SetItem(key string, item interface{}, expiration time.Duration, delete bool) error {
 	if item == nil {
 		return fmt.Errorf("cannot set item to nil for key %s", key)
@@ -81,3 +165,45 @@ func (c *Cache) GetItem(key string, item interface{}) error {
 	key = fmt.Sprintf("%s|%s", key, common.CacheVersion)
 	return c.client.Get(key, item)
 }
+
+// SetHashItem sets a field of the hash stored at the key to the item, without modifying the other fields of the hash,
+// so that concurrent updates of different fields do not overwrite each other
+func (c *Cache) SetHashItem(key string, field string, item interface{}) error {
+	if item == nil {
+		return fmt.Errorf("cannot set item to nil for key %s", key)
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	key = fmt.Sprintf("%s|%s", key, common.CacheVersion)
+	return c.client.SetHashField(key, field, data)
+}
+
+// GetHashItems gets the fields of the hash stored at the key into items, which must be a pointer to a map of the fields
+// to the type of the items
+func (c *Cache) GetHashItems(key string, items interface{}) error {
+	if items == nil {
+		return fmt.Errorf("cannot get items into a nil for key %s", key)
+	}
+	key = fmt.Sprintf("%s|%s", key, common.CacheVersion)
+	hash, err := c.client.GetHash(key)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]json.RawMessage, len(hash))
+	for field, data := range hash {
+		fields[field] = data
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, items)
+}
+
+// DeleteHashItems deletes fields of the hash stored at the key, without modifying its other fields
+func (c *Cache) DeleteHashItems(key string, fields ...string) error {
+	key = fmt.Sprintf("%s|%s", key, common.CacheVersion)
+	return c.client.DeleteHashFields(key, fields...)
+}
@@ -1,11 +1,17 @@
 package cache
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/go-redis/redis"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+
+	certutil "github.com/argoproj/argo-cd/util/tls"
 )
 
 func TestAddCacheFlagsToCmd(t *testing.T) {
@@ -13,3 +19,57 @@ func TestAddCacheFlagsToCmd(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 24*time.Hour, cache.client.(*redisCache).expiration)
 }
+
+func TestAddCacheFlagsToCmd_Cluster(t *testing.T) {
+	cmd := &cobra.Command{}
+	factory := AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis-cluster", "redis-0:6379", "--redis-cluster", "redis-1:6379"}))
+	cache, err := factory()
+	assert.NoError(t, err)
+	client, ok := cache.client.(*redisCache).client.(*redis.ClusterClient)
+	if assert.True(t, ok) {
+		assert.Equal(t, []string{"redis-0:6379", "redis-1:6379"}, client.Options().Addrs)
+	}
+
+	cmd = &cobra.Command{}
+	factory = AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis-cluster", "redis-0:6379", "--sentinel", "sentinel-0:26379"}))
+	_, err = factory()
+	assert.EqualError(t, err, "redis sentinels and redis cluster nodes are mutually exclusive")
+
+	cmd = &cobra.Command{}
+	factory = AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis-cluster", "redis-0:6379", "--redisdb", "1"}))
+	_, err = factory()
+	assert.EqualError(t, err, "redis cluster supports database 0 only")
+}
+
+func TestAddCacheFlagsToCmd_TLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redis-tls")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	cert, err := certutil.GenerateX509KeyPair(certutil.CertOptions{Hosts: []string{"redis"}, Organization: "Argo CD", IsCA: true})
+	assert.NoError(t, err)
+	certPEM, keyPEM := certutil.EncodeX509KeyPair(*cert)
+	certPath, keyPath := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, ioutil.WriteFile(certPath, certPEM, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyPath, keyPEM, 0600))
+
+	cmd := &cobra.Command{}
+	factory := AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis", "redis:6379", "--redis-use-tls", "--redis-ca-certificate", certPath,
+		"--redis-client-certificate", certPath, "--redis-client-key", keyPath}))
+	cache, err := factory()
+	assert.NoError(t, err)
+	client := cache.client.(*redisCache).client.(*redis.Client)
+	if assert.NotNil(t, client.Options().TLSConfig) {
+		assert.NotNil(t, client.Options().TLSConfig.RootCAs)
+		assert.Len(t, client.Options().TLSConfig.Certificates, 1)
+	}
+
+	cmd = &cobra.Command{}
+	factory = AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis-use-tls", "--redis-ca-certificate", keyPath}))
+	_, err = factory()
+	assert.Error(t, err)
+}
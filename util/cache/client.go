@@ -7,10 +7,13 @@ import (
 
 var ErrCacheMiss = errors.New("cache: key is missing")
 
+// NoExpiration is the expiration of items which are kept in the cache until they are deleted
+const NoExpiration time.Duration = -1
+
 type Item struct {
 	Key    string
 	Object interface{}
-	// Expiration is the cache expiration time.
+	// Expiration is the cache expiration time. Zero selects the default expiration of the cache.
 	Expiration time.Duration
 }
 
@@ -18,4 +21,12 @@ type CacheClient interface {
 	Set(item *Item) error
 	Get(key string, obj interface{}) error
 	Delete(key string) error
+	// SetHashField sets a field of the hash stored at the key, without modifying its other fields
+	SetHashField(key string, field string, data []byte) error
+	// GetHash returns the fields of the hash stored at the key, or an empty map if there is no such hash
+	GetHash(key string) (map[string][]byte, error)
+	// DeleteHashFields deletes fields of the hash stored at the key, without modifying its other fields
+	DeleteHashFields(key string, fields ...string) error
+	// Ping returns an error if the cache is unreachable
+	Ping() error
 }
@@ -0,0 +1,318 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultFallbackRetryInterval is the interval to check whether an unavailable cache is available again
+	defaultFallbackRetryInterval = 10 * time.Second
+)
+
+// NewFallbackCache returns a cache which uses a bounded in-memory cache of at most maxItems items while the primary cache
+// is unavailable, rather than failing the requests of the components, and which uses the primary cache again once it is
+// available.
+func NewFallbackCache(primary CacheClient, maxItems int, expiration time.Duration) CacheClient {
+	return &fallbackCache{
+		primary:       primary,
+		fallback:      newBoundedInMemoryCache(maxItems, expiration),
+		retryInterval: defaultFallbackRetryInterval,
+		now:           time.Now,
+	}
+}
+
+type fallbackCache struct {
+	primary       CacheClient
+	fallback      *boundedInMemoryCache
+	retryInterval time.Duration
+	now           func() time.Time
+
+	lock sync.Mutex
+	// degraded is true while the primary cache is unavailable
+	degraded bool
+	// retryAt is the time the availability of the primary cache is checked again
+	retryAt time.Time
+	// lastErr is the error of the last request of the primary cache
+	lastErr error
+}
+
+// isUnavailable returns true if an error indicates that the cache cannot be reached, as opposed to e.g. a cache miss
+func isUnavailable(err error) bool {
+	if err == nil || err == ErrCacheMiss {
+		return false
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return err == io.EOF || strings.Contains(err.Error(), "connection pool timeout") || strings.Contains(err.Error(), "client is closed")
+}
+
+// client returns the client the requests are served by. The availability of the primary cache is checked by a single
+// request at a time.
+func (c *fallbackCache) client() CacheClient {
+	c.lock.Lock()
+	if !c.degraded {
+		c.lock.Unlock()
+		return c.primary
+	}
+	if c.now().Before(c.retryAt) {
+		c.lock.Unlock()
+		return c.fallback
+	}
+	c.retryAt = c.now().Add(c.retryInterval)
+	c.lock.Unlock()
+
+	if err := c.primary.Ping(); err != nil {
+		c.setLastErr(err)
+		return c.fallback
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.degraded {
+		c.degraded = false
+		c.lastErr = nil
+		// the items set while the primary cache was unavailable might be outdated once it is unavailable again
+		c.fallback.Flush()
+		log.Info("Cache is available again, stopped using the in-memory cache")
+	}
+	return c.primary
+}
+
+func (c *fallbackCache) setLastErr(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lastErr = err
+}
+
+func (c *fallbackCache) degrade(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lastErr = err
+	if c.degraded {
+		return
+	}
+	c.degraded = true
+	c.retryAt = c.now().Add(c.retryInterval)
+	log.Warnf("Cache is unavailable, using an in-memory cache until it is available again: %v. The cached state is not "+
+		"shared with other components and replicas in the meantime, so e.g. the API server cannot show the resource trees "+
+		"of applications, and every repo server generates manifests again.", err)
+}
+
+// do runs an action against the client the requests are served by, and against the fallback cache if the primary cache
+// turns out to be unavailable
+func (c *fallbackCache) do(action func(client CacheClient) error) error {
+	client := c.client()
+	err := action(client)
+	if client == c.primary && isUnavailable(err) {
+		c.degrade(err)
+		return action(c.fallback)
+	}
+	return err
+}
+
+func (c *fallbackCache) Set(item *Item) error {
+	return c.do(func(client CacheClient) error {
+		return client.Set(item)
+	})
+}
+
+func (c *fallbackCache) Get(key string, obj interface{}) error {
+	return c.do(func(client CacheClient) error {
+		return client.Get(key, obj)
+	})
+}
+
+func (c *fallbackCache) Delete(key string) error {
+	return c.do(func(client CacheClient) error {
+		return client.Delete(key)
+	})
+}
+
+func (c *fallbackCache) SetHashField(key string, field string, data []byte) error {
+	return c.do(func(client CacheClient) error {
+		return client.SetHashField(key, field, data)
+	})
+}
+
+func (c *fallbackCache) GetHash(key string) (map[string][]byte, error) {
+	var res map[string][]byte
+	err := c.do(func(client CacheClient) error {
+		var err error
+		res, err = client.GetHash(key)
+		return err
+	})
+	return res, err
+}
+
+func (c *fallbackCache) DeleteHashFields(key string, fields ...string) error {
+	return c.do(func(client CacheClient) error {
+		return client.DeleteHashFields(key, fields...)
+	})
+}
+
+// Ping returns the error of the primary cache while it is unavailable, so that health checks report the outage
+func (c *fallbackCache) Ping() error {
+	if c.client() == c.primary {
+		return c.primary.Ping()
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return fmt.Errorf("%v (using an in-memory cache)", c.lastErr)
+}
+
+// boundedInMemoryCache is an in-memory cache which evicts the least recently used items above a maximum number of items
+type boundedInMemoryCache struct {
+	maxItems   int
+	expiration time.Duration
+	now        func() time.Time
+
+	lock  sync.Mutex
+	items map[string]*list.Element
+	// lru is the list of the items, the most recently used first
+	lru *list.List
+}
+
+type boundedItem struct {
+	key     string
+	data    []byte
+	expires time.Time
+	// hash holds the fields of the item if it is a hash
+	hash map[string][]byte
+}
+
+func newBoundedInMemoryCache(maxItems int, expiration time.Duration) *boundedInMemoryCache {
+	return &boundedInMemoryCache{
+		maxItems:   maxItems,
+		expiration: expiration,
+		now:        time.Now,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (b *boundedInMemoryCache) Set(item *Item) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item.Object); err != nil {
+		return err
+	}
+	expiration := item.Expiration
+	if expiration == 0 {
+		expiration = b.expiration
+	}
+	var expires time.Time
+	if expiration > 0 {
+		expires = b.now().Add(expiration)
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.put(&boundedItem{key: item.Key, data: buf.Bytes(), expires: expires})
+	return nil
+}
+
+func (b *boundedInMemoryCache) Get(key string, obj interface{}) error {
+	b.lock.Lock()
+	element, ok := b.items[key]
+	if !ok {
+		b.lock.Unlock()
+		return ErrCacheMiss
+	}
+	item := element.Value.(*boundedItem)
+	if !item.expires.IsZero() && !b.now().Before(item.expires) {
+		b.lru.Remove(element)
+		delete(b.items, key)
+		b.lock.Unlock()
+		return ErrCacheMiss
+	}
+	b.lru.MoveToFront(element)
+	b.lock.Unlock()
+	return gob.NewDecoder(bytes.NewReader(item.data)).Decode(obj)
+}
+
+func (b *boundedInMemoryCache) Delete(key string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if element, ok := b.items[key]; ok {
+		b.lru.Remove(element)
+		delete(b.items, key)
+	}
+	return nil
+}
+
+// put stores an item as the most recently used one and evicts the least recently used items above the maximum
+func (b *boundedInMemoryCache) put(item *boundedItem) {
+	if element, ok := b.items[item.key]; ok {
+		b.lru.Remove(element)
+	}
+	b.items[item.key] = b.lru.PushFront(item)
+	for b.lru.Len() > b.maxItems {
+		oldest := b.lru.Back()
+		b.lru.Remove(oldest)
+		delete(b.items, oldest.Value.(*boundedItem).key)
+	}
+}
+
+// getHash returns a copy of the fields of the hash stored at the key
+func (b *boundedInMemoryCache) getHash(key string) map[string][]byte {
+	res := map[string][]byte{}
+	if element, ok := b.items[key]; ok {
+		b.lru.MoveToFront(element)
+		for field, data := range element.Value.(*boundedItem).hash {
+			res[field] = data
+		}
+	}
+	return res
+}
+
+func (b *boundedInMemoryCache) SetHashField(key string, field string, data []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	hash := b.getHash(key)
+	hash[field] = data
+	b.put(&boundedItem{key: key, hash: hash})
+	return nil
+}
+
+func (b *boundedInMemoryCache) GetHash(key string) (map[string][]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.getHash(key), nil
+}
+
+func (b *boundedInMemoryCache) DeleteHashFields(key string, fields ...string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	hash := b.getHash(key)
+	for _, field := range fields {
+		delete(hash, field)
+	}
+	if len(hash) == 0 {
+		if element, ok := b.items[key]; ok {
+			b.lru.Remove(element)
+			delete(b.items, key)
+		}
+		return nil
+	}
+	b.put(&boundedItem{key: key, hash: hash})
+	return nil
+}
+
+func (b *boundedInMemoryCache) Ping() error {
+	return nil
+}
+
+func (b *boundedInMemoryCache) Flush() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.items = make(map[string]*list.Element)
+	b.lru.Init()
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// unavailableCache is a cache which fails all requests with an error while it is unavailable
+type unavailableCache struct {
+	CacheClient
+	err   error
+	pings int
+}
+
+func (c *unavailableCache) Set(item *Item) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.CacheClient.Set(item)
+}
+
+func (c *unavailableCache) Get(key string, obj interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.CacheClient.Get(key, obj)
+}
+
+func (c *unavailableCache) Delete(key string) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.CacheClient.Delete(key)
+}
+
+func (c *unavailableCache) Ping() error {
+	c.pings++
+	return c.err
+}
+
+func TestFallbackCache(t *testing.T) {
+	primary := &unavailableCache{CacheClient: NewInMemoryCache(time.Hour)}
+	now := time.Now()
+	cache := NewFallbackCache(primary, 10, time.Hour).(*fallbackCache)
+	cache.now = func() time.Time {
+		return now
+	}
+	obj := &foo{}
+
+	assert.NoError(t, cache.Set(&Item{Key: "my-key", Object: &foo{Bar: "primary"}}))
+
+	// the in-memory cache is used while the primary cache is unavailable
+	primary.err = io.EOF
+	assert.Equal(t, ErrCacheMiss, cache.Get("my-key", obj))
+	assert.NoError(t, cache.Set(&Item{Key: "my-key", Object: &foo{Bar: "fallback"}}))
+	assert.NoError(t, cache.Get("my-key", obj))
+	assert.Equal(t, &foo{Bar: "fallback"}, obj)
+	assert.EqualError(t, cache.Ping(), "EOF (using an in-memory cache)")
+	assert.Equal(t, 0, primary.pings)
+
+	// the primary cache is checked again after the retry interval
+	now = now.Add(defaultFallbackRetryInterval)
+	assert.NoError(t, cache.Get("my-key", obj))
+	assert.Equal(t, &foo{Bar: "fallback"}, obj)
+	assert.Equal(t, 1, primary.pings)
+
+	// the primary cache is used again once it is available, and the in-memory cache is flushed
+	primary.err = nil
+	now = now.Add(defaultFallbackRetryInterval)
+	assert.NoError(t, cache.Get("my-key", obj))
+	assert.Equal(t, &foo{Bar: "primary"}, obj)
+	assert.Equal(t, 2, primary.pings)
+	assert.Equal(t, 0, cache.fallback.lru.Len())
+	assert.NoError(t, cache.Ping())
+}
+
+func TestFallbackCache_OtherErrors(t *testing.T) {
+	primary := &unavailableCache{CacheClient: NewInMemoryCache(time.Hour), err: errors.New("WRONGTYPE")}
+	cache := NewFallbackCache(primary, 10, time.Hour).(*fallbackCache)
+
+	assert.EqualError(t, cache.Get("my-key", &foo{}), "WRONGTYPE")
+	assert.False(t, cache.degraded)
+}
+
+func TestBoundedInMemoryCache(t *testing.T) {
+	now := time.Now()
+	cache := newBoundedInMemoryCache(2, time.Hour)
+	cache.now = func() time.Time {
+		return now
+	}
+	obj := &foo{}
+
+	assert.NoError(t, cache.Set(&Item{Key: "a", Object: &foo{Bar: "a"}}))
+	assert.NoError(t, cache.Set(&Item{Key: "b", Object: &foo{Bar: "b"}}))
+	// a is more recently used than b
+	assert.NoError(t, cache.Get("a", obj))
+	assert.NoError(t, cache.Set(&Item{Key: "c", Object: &foo{Bar: "c"}, Expiration: time.Minute}))
+	assert.Equal(t, ErrCacheMiss, cache.Get("b", obj))
+	assert.NoError(t, cache.Get("a", obj))
+	assert.Equal(t, &foo{Bar: "a"}, obj)
+	assert.NoError(t, cache.Get("c", obj))
+	assert.Equal(t, &foo{Bar: "c"}, obj)
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, ErrCacheMiss, cache.Get("c", obj))
+	assert.NoError(t, cache.Get("a", obj))
+
+	assert.NoError(t, cache.Delete("a"))
+	assert.Equal(t, ErrCacheMiss, cache.Get("a", obj))
+}
+
+func TestBoundedInMemoryCache_Hash(t *testing.T) {
+	cache := newBoundedInMemoryCache(2, time.Hour)
+	assert.NoError(t, cache.SetHashField("a", "x", []byte("x")))
+	assert.NoError(t, cache.SetHashField("a", "y", []byte("y")))
+	hash, err := cache.GetHash("a")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"x": []byte("x"), "y": []byte("y")}, hash)
+
+	assert.NoError(t, cache.DeleteHashFields("a", "x"))
+	hash, err = cache.GetHash("a")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"y": []byte("y")}, hash)
+
+	// hashes are evicted like other items
+	assert.NoError(t, cache.Set(&Item{Key: "b", Object: &foo{Bar: "b"}}))
+	assert.NoError(t, cache.Set(&Item{Key: "c", Object: &foo{Bar: "c"}}))
+	hash, err = cache.GetHash("a")
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+}
+
+func TestAddCacheFlagsToCmd_Fallback(t *testing.T) {
+	cmd := &cobra.Command{}
+	factory := AddCacheFlagsToCmd(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--redis-fallback-max-items", "100"}))
+	cache, err := factory()
+	assert.NoError(t, err)
+	client, ok := cache.client.(*fallbackCache)
+	if assert.True(t, ok) {
+		assert.IsType(t, &redisCache{}, client.primary)
+		assert.Equal(t, 100, client.fallback.maxItems)
+	}
+}
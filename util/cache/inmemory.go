@@ -3,6 +3,7 @@ package cache
 import (
 	"bytes"
 	"encoding/gob"
+	"sync"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -16,6 +17,8 @@ func NewInMemoryCache(expiration time.Duration) *InMemoryCache {
 
 type InMemoryCache struct {
 	memCache *gocache.Cache
+	// hashLock serializes the updates of hashes, which are replaced by updated copies
+	hashLock sync.Mutex
 }
 
 func (i *InMemoryCache) Set(item *Item) error {
@@ -42,6 +45,57 @@ func (i *InMemoryCache) Delete(key string) error {
 	return nil
 }
 
+func (i *InMemoryCache) getHash(key string) map[string][]byte {
+	hash, found := i.memCache.Get(key)
+	if !found {
+		return map[string][]byte{}
+	}
+	if hash, ok := hash.(map[string][]byte); ok {
+		return hash
+	}
+	return map[string][]byte{}
+}
+
+func (i *InMemoryCache) SetHashField(key string, field string, data []byte) error {
+	i.hashLock.Lock()
+	defer i.hashLock.Unlock()
+	hash := i.getHash(key)
+	res := make(map[string][]byte, len(hash)+1)
+	for f, d := range hash {
+		res[f] = d
+	}
+	res[field] = data
+	i.memCache.Set(key, res, gocache.NoExpiration)
+	return nil
+}
+
+func (i *InMemoryCache) GetHash(key string) (map[string][]byte, error) {
+	return i.getHash(key), nil
+}
+
+func (i *InMemoryCache) DeleteHashFields(key string, fields ...string) error {
+	i.hashLock.Lock()
+	defer i.hashLock.Unlock()
+	hash := i.getHash(key)
+	res := make(map[string][]byte, len(hash))
+	for f, d := range hash {
+		res[f] = d
+	}
+	for _, field := range fields {
+		delete(res, field)
+	}
+	if len(res) == 0 {
+		i.memCache.Delete(key)
+	} else {
+		i.memCache.Set(key, res, gocache.NoExpiration)
+	}
+	return nil
+}
+
+func (i *InMemoryCache) Ping() error {
+	return nil
+}
+
 func (i *InMemoryCache) Flush() {
 	i.memCache.Flush()
 }
@@ -25,3 +25,19 @@ func TestInMemoryCache(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, &foo{Bar: "bar"}, obj)
 }
+
+func TestInMemoryCache_Hash(t *testing.T) {
+	cache := NewInMemoryCache(1 * time.Hour)
+	hash, err := cache.GetHash("my-key")
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+	assert.NoError(t, cache.SetHashField("my-key", "a", []byte("a")))
+	assert.NoError(t, cache.SetHashField("my-key", "b", []byte("b")))
+	hash, err = cache.GetHash("my-key")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a": []byte("a"), "b": []byte("b")}, hash)
+	assert.NoError(t, cache.DeleteHashFields("my-key", "a", "b"))
+	hash, err = cache.GetHash("my-key")
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+}
@@ -9,8 +9,9 @@ import (
 	"github.com/vmihailenco/msgpack"
 )
 
-func NewRedisCache(client *redis.Client, expiration time.Duration) CacheClient {
+func NewRedisCache(client redis.UniversalClient, expiration time.Duration) CacheClient {
 	return &redisCache{
+		client:     client,
 		expiration: expiration,
 		codec: &rediscache.Codec{
 			Redis: client,
@@ -25,6 +26,7 @@ func NewRedisCache(client *redis.Client, expiration time.Duration) CacheClient {
 }
 
 type redisCache struct {
+	client     redis.UniversalClient
 	expiration time.Duration
 	codec      *rediscache.Codec
 }
@@ -65,3 +67,41 @@ func (r *redisCache) Get(key string, obj interface{}) error {
 func (r *redisCache) Delete(key string) error {
 	return r.codec.Delete(key)
 }
+
+func (r *redisCache) SetHashField(key string, field string, data []byte) error {
+	return doWithRetry(func() error {
+		return r.client.HSet(key, field, data).Err()
+	})
+}
+
+func (r *redisCache) GetHash(key string) (map[string][]byte, error) {
+	var fields map[string]string
+	err := doWithRetry(func() error {
+		var err error
+		fields, err = r.client.HGetAll(key).Result()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]byte, len(fields))
+	for field, data := range fields {
+		res[field] = []byte(data)
+	}
+	return res, nil
+}
+
+func (r *redisCache) DeleteHashFields(key string, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return doWithRetry(func() error {
+		return r.client.HDel(key, fields...).Err()
+	})
+}
+
+func (r *redisCache) Ping() error {
+	return doWithRetry(func() error {
+		return r.client.Ping().Err()
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/util"
 )
 
 // A struct representing an entry in the list of SSH known hosts.
@@ -295,7 +296,7 @@ func SSHFingerprintSHA256(key ssh.PublicKey) string {
 
 // Remove possible port number from hostname and return just the FQDN
 func ServerNameWithoutPort(serverName string) string {
-	return strings.Split(serverName, ":")[0]
+	return util.HostWithoutPort(serverName)
 }
 
 // Load certificate data from a file. If the file does not exist, we do not
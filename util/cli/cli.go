@@ -23,6 +23,7 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/errors"
 	"github.com/argoproj/argo-cd/util"
+	logutils "github.com/argoproj/argo-cd/util/log"
 )
 
 // NewVersionCmd returns a new `version` command to be used as a sub-command to root
@@ -137,16 +138,32 @@ func ReadAndConfirmPassword() (string, error) {
 	}
 }
 
-// SetLogLevel parses and sets a logrus log level
+// SetLogLevel parses and sets the logrus log level and the levels of the modules, e.g. info,git=debug
 func SetLogLevel(logLevel string) {
-	level, err := log.ParseLevel(logLevel)
-	errors.CheckError(err)
-	log.SetLevel(level)
+	errors.CheckError(logutils.SetLevels(logLevel))
 	if os.Getenv("FORCE_LOG_COLORS") == "1" {
 		log.SetFormatter(&log.TextFormatter{ForceColors: true})
 	}
 }
 
+const (
+	// LogFormatText is the default format of the logs, logfmt unless the output is a terminal
+	LogFormatText = "text"
+	// LogFormatJSON formats the logs as JSON objects, one per line
+	LogFormatJSON = "json"
+)
+
+// SetLogFormat sets the logrus format, text or json
+func SetLogFormat(logFormat string) {
+	switch strings.ToLower(logFormat) {
+	case LogFormatJSON:
+		log.SetFormatter(&log.JSONFormatter{})
+	case LogFormatText:
+	default:
+		errors.CheckError(fmt.Errorf("unknown log format '%s', expected %s or %s", logFormat, LogFormatText, LogFormatJSON))
+	}
+}
+
 // SetGLogLevel set the glog level for the k8s go-client
 func SetGLogLevel(glogLevel int) {
 	klog.InitFlags(nil)
@@ -0,0 +1,25 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := KeyFromPassphrase([]byte("my-key"))
+	assert.Len(t, key, 32)
+
+	encrypted, err := Encrypt([]byte("secret"), key)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := Decrypt(encrypted, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(decrypted))
+
+	_, err = Decrypt(encrypted, KeyFromPassphrase([]byte("other-key")))
+	assert.Error(t, err)
+	_, err = Decrypt([]byte("short"), key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package cue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	executil "github.com/argoproj/argo-cd/util/exec"
+)
+
+const (
+	// TagAppName is the tag injected with the name of the application, if the package declares it
+	TagAppName = "argocd_app_name"
+	// TagAppNamespace is the tag injected with the destination namespace of the application, if the package declares it
+	TagAppNamespace = "argocd_app_namespace"
+)
+
+// Export exports a CUE package of the application path to objects. The injected tags are only passed to cue if a file of
+// the package declares them, as cue rejects undeclared tags.
+func Export(appPath string, opts *v1alpha1.ApplicationSourceCUE, injectedTags map[string]string) ([]*unstructured.Unstructured, error) {
+	if opts == nil {
+		opts = &v1alpha1.ApplicationSourceCUE{}
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "."
+	}
+	if clean := filepath.ToSlash(filepath.Clean(pkg)); filepath.IsAbs(pkg) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return nil, fmt.Errorf("CUE package %s must be relative to the application path", pkg)
+	}
+	args := []string{"export", pkg, "--out", "json"}
+	if opts.Expression != "" {
+		args = append(args, "--expression", opts.Expression)
+	}
+	declared := declaredTags(filepath.Join(appPath, pkg))
+	names := make([]string, 0, len(injectedTags))
+	for name := range injectedTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if declared[name] {
+			args = append(args, "--inject", fmt.Sprintf("%s=%s", name, injectedTags[name]))
+		}
+	}
+	for _, tag := range opts.Tags {
+		args = append(args, "--inject", fmt.Sprintf("%s=%s", tag.Name, tag.Value))
+	}
+	cmd := exec.Command("cue", args...)
+	cmd.Dir = appPath
+	out, err := executil.Run(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return ParseObjects([]byte(out))
+}
+
+// declaredTags returns the names of the injected tags declared by @tag() attributes of the files of a package directory
+func declaredTags(dir string) map[string]bool {
+	res := make(map[string]bool)
+	files, err := filepath.Glob(filepath.Join(dir, "*.cue"))
+	if err != nil {
+		return res
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, name := range []string{TagAppName, TagAppNamespace} {
+			if strings.Contains(string(data), "@tag("+name) {
+				res[name] = true
+			}
+		}
+	}
+	return res
+}
+
+// ParseObjects returns the objects of the JSON output of cue export, which is either an object, a list of objects or
+// a struct of objects, e.g. keyed by kind and name. Structs are parsed in the order of their keys.
+func ParseObjects(out []byte) ([]*unstructured.Unstructured, error) {
+	var value interface{}
+	if err := json.Unmarshal(out, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse output of cue export: %v", err)
+	}
+	var objs []*unstructured.Unstructured
+	if err := appendObjects(value, &objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func appendObjects(value interface{}, objs *[]*unstructured.Unstructured) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := appendObjects(item, objs); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if _, ok := v["kind"]; ok {
+			*objs = append(*objs, &unstructured.Unstructured{Object: v})
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := appendObjects(v[key], objs); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected value %v in output of cue export, expected objects", value)
+	}
+	return nil
+}
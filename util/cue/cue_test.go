@@ -0,0 +1,49 @@
+package cue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestParseObjects(t *testing.T) {
+	objs, err := ParseObjects([]byte(`{"kind":"ConfigMap","metadata":{"name":"a"}}`))
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+
+	objs, err = ParseObjects([]byte(`{
+  "deployment": {"guestbook": {"kind": "Deployment", "metadata": {"name": "guestbook"}}},
+  "configMap": {"guestbook": {"kind": "ConfigMap", "metadata": {"name": "guestbook"}}},
+  "list": [{"kind": "Service", "metadata": {"name": "guestbook"}}]
+}`))
+	assert.NoError(t, err)
+	if assert.Len(t, objs, 3) {
+		assert.Equal(t, "ConfigMap", objs[0].GetKind())
+		assert.Equal(t, "Deployment", objs[1].GetKind())
+		assert.Equal(t, "Service", objs[2].GetKind())
+	}
+
+	_, err = ParseObjects([]byte(`{"replicas": 2}`))
+	assert.Error(t, err)
+}
+
+func TestDeclaredTags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cue")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.cue"), []byte(`package app
+
+name: string @tag(argocd_app_name)
+`), 0644))
+	assert.Equal(t, map[string]bool{TagAppName: true}, declaredTags(dir))
+}
+
+func TestExportRejectsPackagesOutsideAppPath(t *testing.T) {
+	_, err := Export(".", &v1alpha1.ApplicationSourceCUE{Package: "../other"}, nil)
+	assert.Error(t, err)
+}
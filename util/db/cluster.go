@@ -7,6 +7,7 @@ import (
 	"hash/fnv"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/context"
@@ -248,7 +249,8 @@ func serverToSecretName(server string) (string, error) {
 	}
 	h := fnv.New32a()
 	_, _ = h.Write([]byte(server))
-	host := strings.ToLower(strings.Split(serverURL.Host, ":")[0])
+	// the colons of IPv6 addresses are not allowed in secret names
+	host := strings.Replace(strings.ToLower(serverURL.Hostname()), ":", "-", -1)
 	return fmt.Sprintf("cluster-%s-%v", host, h.Sum32()), nil
 }
 
@@ -264,6 +266,15 @@ func clusterToData(c *appv1.Cluster) map[string][]byte {
 	if len(c.Namespaces) != 0 {
 		data["namespaces"] = []byte(strings.Join(c.Namespaces, ","))
 	}
+	if c.ClusterResources {
+		data["clusterResources"] = []byte("true")
+	}
+	if c.Shard != nil {
+		data["shard"] = []byte(strconv.FormatInt(*c.Shard, 10))
+	}
+	if c.MaxConcurrentSyncs > 0 {
+		data["maxConcurrentSyncs"] = []byte(strconv.FormatInt(c.MaxConcurrentSyncs, 10))
+	}
 	configBytes, err := json.Marshal(c.Config)
 	if err != nil {
 		panic(err)
@@ -287,10 +298,17 @@ func secretToCluster(s *apiv1.Secret) *appv1.Cluster {
 	}
 
 	cluster := appv1.Cluster{
-		Server:     string(s.Data["server"]),
-		Name:       string(s.Data["name"]),
-		Namespaces: namespaces,
-		Config:     config,
+		Server:           string(s.Data["server"]),
+		Name:             string(s.Data["name"]),
+		Namespaces:       namespaces,
+		ClusterResources: string(s.Data["clusterResources"]) == "true",
+		Config:           config,
+	}
+	if shard, err := strconv.ParseInt(string(s.Data["shard"]), 10, 64); err == nil {
+		cluster.Shard = &shard
+	}
+	if maxConcurrentSyncs, err := strconv.ParseInt(string(s.Data["maxConcurrentSyncs"]), 10, 64); err == nil {
+		cluster.MaxConcurrentSyncs = maxConcurrentSyncs
 	}
 	return &cluster
 }
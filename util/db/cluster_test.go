@@ -4,10 +4,45 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 )
 
 func Test_serverToSecretName(t *testing.T) {
 	name, err := serverToSecretName("http://foo")
 	assert.NoError(t, err)
 	assert.Equal(t, "cluster-foo-752281925", name)
+
+	name, err = serverToSecretName("https://[fd00::1]:6443")
+	assert.NoError(t, err)
+	assert.Regexp(t, "^cluster-fd00--1-[0-9]+$", name)
+}
+
+func Test_clusterToSecret(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		Server:           "https://kubernetes.default.svc",
+		Name:             "in-cluster",
+		Namespaces:       []string{"default", "kube-system"},
+		ClusterResources: true,
+	}
+	data := clusterToData(cluster)
+	assert.Equal(t, "default,kube-system", string(data["namespaces"]))
+	assert.Equal(t, "true", string(data["clusterResources"]))
+
+	actual := secretToCluster(&corev1.Secret{Data: data})
+	assert.Equal(t, cluster.Namespaces, actual.Namespaces)
+	assert.True(t, actual.ClusterResources)
+	assert.Nil(t, actual.Shard)
+
+	shard := int64(2)
+	cluster.Shard = &shard
+	data = clusterToData(cluster)
+	assert.Equal(t, "2", string(data["shard"]))
+	assert.Equal(t, &shard, secretToCluster(&corev1.Secret{Data: data}).Shard)
+
+	cluster.MaxConcurrentSyncs = 3
+	data = clusterToData(cluster)
+	assert.Equal(t, "3", string(data["maxConcurrentSyncs"]))
+	assert.Equal(t, int64(3), secretToCluster(&corev1.Secret{Data: data}).MaxConcurrentSyncs)
 }
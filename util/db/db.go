@@ -40,6 +40,8 @@ type ArgoDB interface {
 	ListRepositoryCredentials(ctx context.Context) ([]string, error)
 	// GetRepoCredentials gets repo credentials for given URL
 	GetRepositoryCredentials(ctx context.Context, name string) (*appv1.RepoCreds, error)
+	// GetRepositoryCredentialsByURL gets the repo credentials configured with the given URL pattern
+	GetRepositoryCredentialsByURL(ctx context.Context, url string) (*appv1.RepoCreds, error)
 	// CreateRepoCredentials creates a repository credential set
 	CreateRepositoryCredentials(ctx context.Context, r *appv1.RepoCreds) (*appv1.RepoCreds, error)
 	// UpdateRepoCredentials updates a repository credential set
@@ -69,6 +69,28 @@ func TestCreateRepository(t *testing.T) {
 	assert.Nil(t, secret.Data[sshPrivateKey])
 }
 
+func TestCreateRepositoryWithCloudIdentity(t *testing.T) {
+	clientset := getClientset(nil)
+	db := NewDB(testNamespace, settings.NewSettingsManager(context.Background(), clientset, testNamespace), clientset)
+
+	_, err := db.CreateRepository(context.Background(), &v1alpha1.Repository{
+		Repo:          "oci://123456789012.dkr.ecr.eu-west-1.amazonaws.com/manifests",
+		Type:          "oci",
+		CloudIdentity: true,
+	})
+	assert.NoError(t, err)
+	repo, err := db.GetRepository(context.Background(), "oci://123456789012.dkr.ecr.eu-west-1.amazonaws.com/manifests")
+	assert.NoError(t, err)
+	assert.True(t, repo.CloudIdentity)
+
+	repo.CloudIdentity = false
+	_, err = db.UpdateRepository(context.Background(), repo)
+	assert.NoError(t, err)
+	repo, err = db.GetRepository(context.Background(), "oci://123456789012.dkr.ecr.eu-west-1.amazonaws.com/manifests")
+	assert.NoError(t, err)
+	assert.False(t, repo.CloudIdentity)
+}
+
 func TestCreateRepoCredentials(t *testing.T) {
 	clientset := getClientset(nil)
 	db := NewDB(testNamespace, settings.NewSettingsManager(context.Background(), clientset, testNamespace), clientset)
@@ -105,6 +127,50 @@ func TestCreateRepoCredentials(t *testing.T) {
 	assert.Equal(t, "test-password", repo.Password)
 }
 
+func TestCreateRepoCredentials_Patterns(t *testing.T) {
+	clientset := getClientset(map[string]string{
+		"repository.credentials": `
+- url: https://github.com/
+  usernameSecret:
+    name: managed-secret
+    key: username
+`,
+	}, newManagedSecret())
+	db := NewDB(testNamespace, settings.NewSettingsManager(context.Background(), clientset, testNamespace), clientset)
+
+	// a template nested in an existing one is not a duplicate
+	_, err := db.CreateRepositoryCredentials(context.Background(), &v1alpha1.RepoCreds{URL: "https://github.com/argoproj/", Username: "test-username"})
+	assert.NoError(t, err)
+	_, err = db.CreateRepositoryCredentials(context.Background(), &v1alpha1.RepoCreds{URL: "https://github.com/argoproj/", Username: "test-username"})
+	assert.Equal(t, codes.AlreadyExists, status.Convert(err).Code())
+
+	_, err = db.CreateRepositoryCredentials(context.Background(), &v1alpha1.RepoCreds{URL: "https://github.com/*/*-infra", MatchType: "glob", Priority: 10, Username: "infra-username"})
+	assert.NoError(t, err)
+	_, err = db.CreateRepositoryCredentials(context.Background(), &v1alpha1.RepoCreds{URL: "https://github.com/(", MatchType: "regex"})
+	assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	// Give the informer of the fake K8s clientset time to see the created secrets.
+	time.Sleep(1 * time.Second)
+
+	creds, err := db.GetRepositoryCredentials(context.Background(), "https://github.com/argoproj/argo-infra")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/*/*-infra", creds.URL)
+	assert.Equal(t, "infra-username", creds.Username)
+	creds, err = db.GetRepositoryCredentials(context.Background(), "https://github.com/argoproj/argo-cd")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/argoproj/", creds.URL)
+
+	creds, err = db.GetRepositoryCredentialsByURL(context.Background(), "https://github.com/*/*-infra")
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.RepoCredsMatchTypeGlob, creds.MatchType)
+	assert.Equal(t, int64(10), creds.Priority)
+
+	assert.NoError(t, db.DeleteRepositoryCredentials(context.Background(), "https://github.com/argoproj/"))
+	creds, err = db.GetRepositoryCredentialsByURL(context.Background(), "https://github.com/")
+	assert.NoError(t, err)
+	assert.NotNil(t, creds)
+}
+
 func TestCreateExistingRepository(t *testing.T) {
 	clientset := getClientset(map[string]string{
 		"repositories": `- url: https://github.com/argoproj/argocd-example-apps`,
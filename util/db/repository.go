@@ -3,7 +3,6 @@ package db
 import (
 	"fmt"
 	"hash/fnv"
-	"strings"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -66,6 +65,7 @@ func (db *db) CreateRepository(ctx context.Context, r *appsv1.Repository) (*apps
 		InsecureIgnoreHostKey: r.IsInsecure(),
 		Insecure:              r.IsInsecure(),
 		EnableLFS:             r.EnableLFS,
+		CloudIdentity:         r.CloudIdentity,
 	}
 	err = db.updateRepositorySecrets(&repoInfo, r)
 	if err != nil {
@@ -147,6 +147,7 @@ func (db *db) credentialsToRepository(repoInfo settings.Repository) (*appsv1.Rep
 		InsecureIgnoreHostKey: repoInfo.InsecureIgnoreHostKey,
 		Insecure:              repoInfo.Insecure,
 		EnableLFS:             repoInfo.EnableLFS,
+		CloudIdentity:         repoInfo.CloudIdentity,
 	}
 	err := db.unmarshalFromSecretsStr(map[*string]*apiv1.SecretKeySelector{
 		&repo.Username:          repoInfo.UsernameSecret,
@@ -160,7 +161,9 @@ func (db *db) credentialsToRepository(repoInfo settings.Repository) (*appsv1.Rep
 
 func (db *db) credentialsToRepositoryCredentials(repoInfo settings.RepositoryCredentials) (*appsv1.RepoCreds, error) {
 	creds := &appsv1.RepoCreds{
-		URL: repoInfo.URL,
+		URL:       repoInfo.URL,
+		MatchType: repoInfo.MatchType,
+		Priority:  repoInfo.Priority,
 	}
 	err := db.unmarshalFromSecretsStr(map[*string]*apiv1.SecretKeySelector{
 		&creds.Username:          repoInfo.UsernameSecret,
@@ -194,6 +197,7 @@ func (db *db) UpdateRepository(ctx context.Context, r *appsv1.Repository) (*apps
 	repoInfo.InsecureIgnoreHostKey = r.IsInsecure()
 	repoInfo.Insecure = r.IsInsecure()
 	repoInfo.EnableLFS = r.EnableLFS
+	repoInfo.CloudIdentity = r.CloudIdentity
 
 	repos[index] = repoInfo
 	err = db.settingsMgr.SaveRepositories(repos)
@@ -262,6 +266,19 @@ func (db *db) GetRepositoryCredentials(ctx context.Context, repoURL string) (*ap
 	return credential, err
 }
 
+// GetRepositoryCredentialsByURL retrieves the repository credential set with the given URL pattern
+func (db *db) GetRepositoryCredentialsByURL(ctx context.Context, url string) (*appsv1.RepoCreds, error) {
+	repoCredentials, err := db.settingsMgr.GetRepositoryCredentials()
+	if err != nil {
+		return nil, err
+	}
+	index := getRepositoryCredentialIndexByURL(repoCredentials, url)
+	if index < 0 {
+		return nil, nil
+	}
+	return db.credentialsToRepositoryCredentials(repoCredentials[index])
+}
+
 // CreateRepositoryCredentials creates a repository credential set
 func (db *db) CreateRepositoryCredentials(ctx context.Context, r *appsv1.RepoCreds) (*appsv1.RepoCreds, error) {
 	creds, err := db.settingsMgr.GetRepositoryCredentials()
@@ -269,13 +286,18 @@ func (db *db) CreateRepositoryCredentials(ctx context.Context, r *appsv1.RepoCre
 		return nil, err
 	}
 
-	index := getRepositoryCredentialIndex(creds, r.URL)
+	index := getRepositoryCredentialIndexByURL(creds, r.URL)
 	if index > -1 {
 		return nil, status.Errorf(codes.AlreadyExists, "repository credentials for '%s' already exists", r.URL)
 	}
 
 	repoInfo := settings.RepositoryCredentials{
-		URL: r.URL,
+		URL:       r.URL,
+		MatchType: r.MatchType,
+		Priority:  r.Priority,
+	}
+	if err := repoInfo.ValidateURL(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
 	err = db.updateCredentialsSecret(&repoInfo, r)
@@ -298,12 +320,17 @@ func (db *db) UpdateRepositoryCredentials(ctx context.Context, r *appsv1.RepoCre
 		return nil, err
 	}
 
-	index := getRepositoryCredentialIndex(repos, r.URL)
+	index := getRepositoryCredentialIndexByURL(repos, r.URL)
 	if index < 0 {
 		return nil, status.Errorf(codes.NotFound, "repository credentials '%s' not found", r.URL)
 	}
 
 	repoInfo := repos[index]
+	repoInfo.MatchType = r.MatchType
+	repoInfo.Priority = r.Priority
+	if err := repoInfo.ValidateURL(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	err = db.updateCredentialsSecret(&repoInfo, r)
 	if err != nil {
 		return nil, err
@@ -326,7 +353,7 @@ func (db *db) DeleteRepositoryCredentials(ctx context.Context, name string) erro
 		return err
 	}
 
-	index := getRepositoryCredentialIndex(repos, name)
+	index := getRepositoryCredentialIndexByURL(repos, name)
 	if index < 0 {
 		return status.Errorf(codes.NotFound, "repository credentials '%s' not found", name)
 	}
@@ -473,22 +500,37 @@ func getRepositoryIndex(repos []settings.Repository, repoURL string) int {
 }
 
 // getRepositoryCredentialIndex returns the index of the best matching repository credential
-// configuration, i.e. the one with the longest match
+// configuration, i.e. the one with the highest priority and the longest URL among those of the
+// same priority
 func getRepositoryCredentialIndex(repoCredentials []settings.RepositoryCredentials, repoURL string) int {
-	var max, idx int = 0, -1
-	repoURL = git.NormalizeGitURL(repoURL)
+	idx := -1
 	for i, cred := range repoCredentials {
-		credUrl := git.NormalizeGitURL(cred.URL)
-		if strings.HasPrefix(repoURL, credUrl) {
-			if len(credUrl) > max {
-				max = len(credUrl)
-				idx = i
-			}
+		if !cred.Matches(repoURL) {
+			continue
+		}
+		if idx == -1 {
+			idx = i
+			continue
+		}
+		best := repoCredentials[idx]
+		if cred.Priority > best.Priority || (cred.Priority == best.Priority && len(cred.URL) > len(best.URL)) {
+			idx = i
 		}
 	}
 	return idx
 }
 
+// getRepositoryCredentialIndexByURL returns the index of the repository credential configuration
+// with the given URL pattern
+func getRepositoryCredentialIndexByURL(repoCredentials []settings.RepositoryCredentials, url string) int {
+	for i, cred := range repoCredentials {
+		if cred.SameURL(url) {
+			return i
+		}
+	}
+	return -1
+}
+
 // repoURLToSecretName hashes repo URL to a secret name using a formula. This is used when
 // repositories are _imperatively_ created and need its credentials to be stored in a secret.
 // NOTE: this formula should not be considered stable and may change in future releases.
@@ -3,6 +3,8 @@ package db
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
@@ -65,3 +67,34 @@ func Test_getRepositoryCredentialIndex(t *testing.T) {
 		})
 	}
 }
+
+func Test_getRepositoryCredentialIndex_Patterns(t *testing.T) {
+	repositoryCredentials := []settings.RepositoryCredentials{
+		{URL: "https://github.com/argoproj"},
+		{URL: "https://github.com/*/*-infra", MatchType: "glob"},
+		{URL: `https://github\.com/(argoproj|argoproj-labs)/.*-infra`, MatchType: "regex", Priority: 10},
+		{URL: "https://github.com/**", MatchType: "glob", Priority: -1},
+		{URL: "https://github.com/[", MatchType: "regex", Priority: 20},
+	}
+	tests := []struct {
+		repoURL string
+		want    int
+	}{
+		{"https://github.com/argoproj/argo-cd", 0},
+		{"https://github.com/foo/bar-infra", 1},
+		{"https://github.com/argoproj/argo-infra", 2},
+		{"https://github.com/argoproj-labs/argo-infra", 2},
+		{"https://github.com/foo/bar", 3},
+		{"https://github.com/foo/bar/baz-infra", 3},
+		{"https://gitlab.com/foo/bar-infra", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repoURL, func(t *testing.T) {
+			assert.Equal(t, tt.want, getRepositoryCredentialIndex(repositoryCredentials, tt.repoURL))
+		})
+	}
+
+	assert.Equal(t, 0, getRepositoryCredentialIndexByURL(repositoryCredentials, "https://github.com/argoproj.git"))
+	assert.Equal(t, 1, getRepositoryCredentialIndexByURL(repositoryCredentials, "https://github.com/*/*-infra"))
+	assert.Equal(t, -1, getRepositoryCredentialIndexByURL(repositoryCredentials, "https://github.com/argoproj/argo-cd"))
+}
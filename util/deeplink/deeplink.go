@@ -0,0 +1,100 @@
+// Package deeplink renders the deep links of applications and resources configured in argocd-cm
+package deeplink
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/util/expr"
+	"github.com/argoproj/argo-cd/util/gotemplate"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+const (
+	// AppKey is the name of the application in the values of deep links
+	AppKey = "app"
+	// ResourceKey is the name of the resource in the values of deep links
+	ResourceKey = "resource"
+)
+
+// Values returns the values of the templates and conditions of deep links. The objects, e.g. the application and the
+// resource, are converted to their unstructured JSON representation, so links refer to their fields like in manifests,
+// e.g. {{.app.spec.destination.namespace}}.
+func Values(objects map[string]interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(objects))
+	for name, obj := range objects {
+		switch obj := obj.(type) {
+		case nil:
+		case map[string]interface{}:
+			values[name] = obj
+		default:
+			unstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+			values[name] = unstructured
+		}
+	}
+	return values, nil
+}
+
+// Evaluate returns the deep links whose condition is met by the values, with their URLs rendered. The links which
+// fail to be rendered are skipped and their errors returned.
+func Evaluate(links []settings.DeepLink, values map[string]interface{}) ([]settings.DeepLink, []error) {
+	res := make([]settings.DeepLink, 0)
+	var errs []error
+	for _, link := range links {
+		if link.Condition != "" {
+			matches, err := expr.Evaluate(link.Condition, values)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("link '%s': %v", link.Title, err))
+				continue
+			}
+			if !matches {
+				continue
+			}
+		}
+		url, err := render(link.URL, values)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("link '%s': %v", link.Title, err))
+			continue
+		}
+		link.URL = url
+		link.Condition = ""
+		res = append(res, link)
+	}
+	return res, errs
+}
+
+// Validate returns an error if the URL template or the condition of a deep link cannot be parsed
+func Validate(link settings.DeepLink) error {
+	if link.Title == "" || link.URL == "" {
+		return fmt.Errorf("links require a title and a url")
+	}
+	if _, err := template.New("").Funcs(gotemplate.FuncMap()).Parse(link.URL); err != nil {
+		return fmt.Errorf("link '%s': failed to parse url %q: %v", link.Title, link.URL, err)
+	}
+	if link.Condition != "" {
+		if err := expr.Validate(link.Condition); err != nil {
+			return fmt.Errorf("link '%s': %v", link.Title, err)
+		}
+	}
+	return nil
+}
+
+// render renders the URL of a link. A reference to a missing field is an error rather than an empty string, which
+// would result in broken links.
+func render(text string, values map[string]interface{}) (string, error) {
+	tmpl, err := template.New("").Funcs(gotemplate.FuncMap()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("failed to render url %q: %v", text, err)
+	}
+	return buf.String(), nil
+}
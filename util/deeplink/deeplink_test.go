@@ -0,0 +1,43 @@
+package deeplink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+func TestEvaluate(t *testing.T) {
+	app := &appv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook"},
+		Spec:       appv1.ApplicationSpec{Destination: appv1.ApplicationDestination{Namespace: "web"}},
+	}
+	values, err := Values(map[string]interface{}{
+		AppKey:      app,
+		ResourceKey: map[string]interface{}{"kind": "Pod", "metadata": map[string]interface{}{"name": "guestbook-ui-7c5d9"}},
+	})
+	assert.NoError(t, err)
+
+	links, errs := Evaluate([]settings.DeepLink{
+		{Title: "Logs", URL: "https://logs.example.com/{{.app.spec.destination.namespace}}/{{.resource.metadata.name}}", Condition: "resource.kind == 'Pod'"},
+		{Title: "Rollout", URL: "https://rollouts.example.com", Condition: "resource.kind == 'Rollout'"},
+		{Title: "Runbook", URL: "https://runbooks.example.com/{{.app.metadata.name | upper}}", IconClass: "fa-book"},
+		{Title: "Broken", URL: "https://example.com/{{.resource.metadata.uid}}"},
+		{Title: "Invalid", URL: "https://example.com", Condition: "resource.kind =="},
+	}, values)
+	assert.Equal(t, []settings.DeepLink{
+		{Title: "Logs", URL: "https://logs.example.com/web/guestbook-ui-7c5d9"},
+		{Title: "Runbook", URL: "https://runbooks.example.com/GUESTBOOK", IconClass: "fa-book"},
+	}, links)
+	assert.Len(t, errs, 2)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(settings.DeepLink{Title: "Logs", URL: "https://logs.example.com/{{.resource.metadata.name}}", Condition: "resource.kind == 'Pod'"}))
+	assert.Error(t, Validate(settings.DeepLink{Title: "Logs"}))
+	assert.Error(t, Validate(settings.DeepLink{Title: "Logs", URL: "https://logs.example.com/{{.resource"}))
+	assert.Error(t, Validate(settings.DeepLink{Title: "Logs", URL: "https://logs.example.com", Condition: "resource.kind =="}))
+}
@@ -47,17 +47,29 @@ type Normalizer interface {
 // Diff performs a diff on two unstructured objects. If the live object happens to have a
 // "kubectl.kubernetes.io/last-applied-configuration", then perform a three way diff.
 func Diff(config, live *unstructured.Unstructured, normalizer Normalizer) (*DiffResult, error) {
+	return diffWithNormalizer(config, live, func(un *unstructured.Unstructured) {
+		Normalize(un, normalizer)
+	})
+}
+
+// UnnormalizedDiff performs a diff on two unstructured objects like Diff, but without any normalization, neither
+// the built-in ones nor the ones of a normalizer, so that the differences the normalizations ignore can be told
+func UnnormalizedDiff(config, live *unstructured.Unstructured) (*DiffResult, error) {
+	return diffWithNormalizer(config, live, func(un *unstructured.Unstructured) {})
+}
+
+func diffWithNormalizer(config, live *unstructured.Unstructured, normalize func(un *unstructured.Unstructured)) (*DiffResult, error) {
 	if config != nil {
 		config = remarshal(config)
-		Normalize(config, normalizer)
+		normalize(config)
 	}
 	if live != nil {
 		live = remarshal(live)
-		Normalize(live, normalizer)
+		normalize(live)
 	}
 	orig := GetLastAppliedConfigAnnotation(live)
 	if orig != nil && config != nil {
-		Normalize(orig, normalizer)
+		normalize(orig)
 		dr, err := ThreeWayDiff(orig, config, live)
 		if err == nil {
 			return dr, nil
@@ -277,6 +289,28 @@ func DiffArray(configArray, liveArray []*unstructured.Unstructured, normalizer N
 	return &diffResultList, nil
 }
 
+// NewDiffResult returns a result of the comparison of a resource from its predicted and normalized live state, e.g.
+// of a cached comparison. Either state is "null" if the resource is missing on that side.
+func NewDiffResult(modified bool, predictedLive, normalizedLive []byte) (*DiffResult, error) {
+	var predictedLiveObj, liveObj map[string]interface{}
+	if err := json.Unmarshal(predictedLive, &predictedLiveObj); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(normalizedLive, &liveObj); err != nil {
+		return nil, err
+	}
+	if predictedLiveObj == nil && liveObj != nil {
+		// like the legacy diff of the live resources which are missing in the target state
+		liveObj = map[string]interface{}{}
+	}
+	return &DiffResult{
+		Modified:       modified,
+		PredictedLive:  predictedLive,
+		NormalizedLive: normalizedLive,
+		Diff:           gojsondiff.New().CompareObjects(liveObj, predictedLiveObj),
+	}, nil
+}
+
 // JSONFormat returns the diff as a JSON string
 func (d *DiffResult) JSONFormat() (string, error) {
 	if !d.Diff.Modified() {
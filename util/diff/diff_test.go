@@ -165,6 +165,33 @@ func TestDiffArrayModification(t *testing.T) {
 	assert.True(t, diffResList.Modified)
 }
 
+func TestNewDiffResult(t *testing.T) {
+	leftDep := test.DemoDeployment()
+	rightDep := leftDep.DeepCopy()
+	ten := int32(10)
+	rightDep.Spec.Replicas = &ten
+
+	res := diff(t, mustToUnstructured(leftDep), mustToUnstructured(rightDep))
+	newRes, err := NewDiffResult(res.Modified, res.PredictedLive, res.NormalizedLive)
+	assert.NoError(t, err)
+	assert.True(t, newRes.Modified)
+	expected, err := res.JSONFormat()
+	assert.NoError(t, err)
+	actual, err := newRes.JSONFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	for _, res := range []*DiffResult{diff(t, nil, mustToUnstructured(leftDep)), diff(t, mustToUnstructured(leftDep), nil)} {
+		newRes, err := NewDiffResult(res.Modified, res.PredictedLive, res.NormalizedLive)
+		assert.NoError(t, err)
+		assert.Equal(t, res.Modified, newRes.Modified)
+		assert.Equal(t, res.Diff.Modified(), newRes.Diff.Modified())
+	}
+
+	_, err = NewDiffResult(false, []byte("{"), []byte("null"))
+	assert.Error(t, err)
+}
+
 // TestThreeWayDiff will perform a diff when there is a kubectl.kubernetes.io/last-applied-configuration
 // present in the live object.
 func TestThreeWayDiff(t *testing.T) {
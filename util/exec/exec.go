@@ -35,6 +35,12 @@ func RunWithRedactor(cmd *exec.Cmd, redactor func(text string) string) (string,
 	span.SetBaggageItem("args", fmt.Sprintf("%v", cmd.Args))
 	defer span.Finish()
 	opts := argoexec.CmdOpts{Timeout: timeout}
+	if limits, ok := getLimits(cmd.Dir); ok {
+		var err error
+		if opts.Timeout, err = applyLimits(cmd, limits, timeout); err != nil {
+			return "", err
+		}
+	}
 	if redactor != nil {
 		opts.Redactor = redactor
 	}
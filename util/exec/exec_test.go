@@ -1,8 +1,10 @@
 package exec
 
 import (
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -27,3 +29,52 @@ func TestRun(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, out)
 }
+
+func TestRun_Limits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "limits")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	subDir := filepath.Join(dir, "app")
+	assert.NoError(t, os.Mkdir(subDir, 0755))
+	newCmd := func(script string) *exec.Cmd {
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Dir = subDir
+		return cmd
+	}
+
+	t.Run("CPUAndMemory", func(t *testing.T) {
+		defer SetLimits(dir, Limits{CPUSeconds: 10, MemoryBytes: 512 * 1024 * 1024})()
+		out, err := Run(newCmd("ulimit -t; ulimit -v"))
+		assert.NoError(t, err)
+		assert.Equal(t, "10\n524288", out)
+	})
+	t.Run("Deadline", func(t *testing.T) {
+		defer SetLimits(dir, Limits{Deadline: time.Now().Add(100 * time.Millisecond)})()
+		_, err := Run(newCmd("sleep 5"))
+		assert.Error(t, err)
+		_, err = Run(newCmd("true"))
+		assert.EqualError(t, err, "sh was not run, the time limit is exceeded")
+	})
+	t.Run("Unset", func(t *testing.T) {
+		SetLimits(dir, Limits{CPUSeconds: 10})()
+		out, err := Run(newCmd("ulimit -t"))
+		assert.NoError(t, err)
+		assert.Equal(t, "unlimited", out)
+	})
+	t.Run("OtherDirectory", func(t *testing.T) {
+		defer SetLimits(subDir, Limits{CPUSeconds: 10})()
+		cmd := exec.Command("sh", "-c", "ulimit -t")
+		cmd.Dir = dir
+		out, err := Run(cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, "unlimited", out)
+	})
+	t.Run("Replaced", func(t *testing.T) {
+		unset := SetLimits(dir, Limits{CPUSeconds: 10})
+		defer SetLimits(dir, Limits{CPUSeconds: 20})()
+		unset()
+		out, err := Run(newCmd("ulimit -t"))
+		assert.NoError(t, err)
+		assert.Equal(t, "20", out)
+	})
+}
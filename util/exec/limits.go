@@ -0,0 +1,92 @@
+package exec
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits are the resource limits of the commands run in a directory. A zero limit means unlimited.
+type Limits struct {
+	// Deadline is the time by which the commands must have completed
+	Deadline time.Time
+	// CPUSeconds is the maximum CPU time of each command
+	CPUSeconds int64
+	// MemoryBytes is the maximum virtual memory of each command
+	MemoryBytes int64
+}
+
+var (
+	limitsLock sync.RWMutex
+	dirLimits  = map[string]*Limits{}
+)
+
+// SetLimits limits the commands run in a directory or its subdirectories until the returned function is called, e.g.
+// the tools generating the manifests of an app in its repository. Setting the limits of a directory again replaces
+// them, and the function returned by the former call then leaves the new limits in place.
+func SetLimits(dir string, limits Limits) func() {
+	dir = filepath.Clean(dir)
+	l := &limits
+	limitsLock.Lock()
+	dirLimits[dir] = l
+	limitsLock.Unlock()
+	return func() {
+		limitsLock.Lock()
+		if dirLimits[dir] == l {
+			delete(dirLimits, dir)
+		}
+		limitsLock.Unlock()
+	}
+}
+
+func getLimits(dir string) (Limits, bool) {
+	if dir == "" {
+		return Limits{}, false
+	}
+	dir = filepath.Clean(dir)
+	limitsLock.RLock()
+	defer limitsLock.RUnlock()
+	for limitsDir, limits := range dirLimits {
+		if dir == limitsDir || strings.HasPrefix(dir, limitsDir+string(filepath.Separator)) {
+			return *limits, true
+		}
+	}
+	return Limits{}, false
+}
+
+// applyLimits returns the timeout of a command, which is the default timeout unless the deadline of the limits is
+// earlier, and makes the command run with the CPU and memory limits of a shell
+func applyLimits(cmd *exec.Cmd, limits Limits, defaultTimeout time.Duration) (time.Duration, error) {
+	timeout := defaultTimeout
+	if !limits.Deadline.IsZero() {
+		remaining := time.Until(limits.Deadline)
+		if remaining <= 0 {
+			return 0, fmt.Errorf("%s was not run, the time limit is exceeded", cmd.Args[0])
+		}
+		if timeout == 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	var ulimits []string
+	if limits.CPUSeconds > 0 {
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -t %d", limits.CPUSeconds))
+	}
+	if limits.MemoryBytes > 0 {
+		// ulimit -v is in KiB
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -v %d", (limits.MemoryBytes+1023)/1024))
+	}
+	if len(ulimits) > 0 {
+		sh, err := exec.LookPath("sh")
+		if err != nil {
+			return 0, err
+		}
+		// the shell replaces itself with the command, so that killing the process on timeout kills the command
+		script := strings.Join(ulimits, " && ") + ` && exec "$0" "$@"`
+		cmd.Args = append([]string{"sh", "-c", script, cmd.Path}, cmd.Args[1:]...)
+		cmd.Path = sh
+	}
+	return timeout, nil
+}
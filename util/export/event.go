@@ -0,0 +1,57 @@
+package export
+
+import (
+	"time"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+// Event is a deployment of an application, i.e. a completed sync operation
+type Event struct {
+	// Application is the name of the application
+	Application string `json:"application"`
+	// Project is the project of the application
+	Project string `json:"project"`
+	// Server and Namespace are the destination of the application
+	Server    string `json:"server"`
+	Namespace string `json:"namespace"`
+	// RepoURL is the repository of the application
+	RepoURL string `json:"repoURL"`
+	// Revision is the revision which was synced
+	Revision string `json:"revision,omitempty"`
+	// Images are the images of the resources of the application
+	Images []string `json:"images,omitempty"`
+	// Result is the phase of the sync operation, e.g. Succeeded or Failed
+	Result string `json:"result"`
+	// Message is the message of the sync operation
+	Message string `json:"message,omitempty"`
+	// StartedAt and FinishedAt are the times the sync operation started and finished at
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// DurationSeconds is the duration of the sync operation
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// NewEvent returns the deployment event of a completed operation of an application
+func NewEvent(app *appv1.Application, state *appv1.OperationState) Event {
+	event := Event{
+		Application: app.Name,
+		Project:     app.Spec.GetProject(),
+		Server:      app.Spec.Destination.Server,
+		Namespace:   app.Spec.Destination.Namespace,
+		RepoURL:     app.Spec.Source.RepoURL,
+		Images:      app.Status.Summary.Images,
+		Result:      string(state.Phase),
+		Message:     state.Message,
+		StartedAt:   state.StartedAt.Time,
+		FinishedAt:  time.Now(),
+	}
+	if state.FinishedAt != nil {
+		event.FinishedAt = state.FinishedAt.Time
+	}
+	event.DurationSeconds = event.FinishedAt.Sub(event.StartedAt).Seconds()
+	if state.SyncResult != nil {
+		event.Revision = state.SyncResult.Revision
+	}
+	return event
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// Exporter publishes the deployment events of applications to the sinks configured in argocd-cm
+type Exporter struct {
+	namespace   string
+	settingsMgr *settings.SettingsManager
+}
+
+// NewExporter returns a new exporter of deployment events
+func NewExporter(namespace string, settingsMgr *settings.SettingsManager) *Exporter {
+	return &Exporter{namespace: namespace, settingsMgr: settingsMgr}
+}
+
+// Export publishes the deployment event of a completed operation to all sinks in the background. Failures are logged.
+func (e *Exporter) Export(app *appv1.Application, state *appv1.OperationState) {
+	sinks, err := e.settingsMgr.GetDeploymentEventSinks()
+	if err != nil {
+		log.Warnf("Failed to get deployment event sinks: %v", err)
+		return
+	}
+	if len(sinks) == 0 {
+		return
+	}
+	event := NewEvent(app, state)
+	for i := range sinks {
+		cfg := sinks[i]
+		logCtx := log.WithField("application", app.Name).WithField("sink", cfg.Name)
+		token, err := e.token(cfg)
+		if err != nil {
+			logCtx.Warnf("Failed to get the token of the deployment event sink: %v", err)
+			continue
+		}
+		sink, err := NewSink(cfg, token)
+		if err != nil {
+			logCtx.Warnf("Invalid deployment event sink: %v", err)
+			continue
+		}
+		go func() {
+			if err := sink.Publish(event); err != nil {
+				logCtx.Warnf("Failed to publish deployment event: %v", err)
+			}
+		}()
+	}
+}
+
+// token returns the token of a sink, if it has a token secret
+func (e *Exporter) token(cfg settings.DeploymentEventSink) (string, error) {
+	if cfg.TokenSecret == nil {
+		return "", nil
+	}
+	secrets, err := e.settingsMgr.GetSecretsLister()
+	if err != nil {
+		return "", err
+	}
+	secret, err := secrets.Secrets(e.namespace).Get(cfg.TokenSecret.Name)
+	if err != nil {
+		return "", err
+	}
+	token, ok := secret.Data[cfg.TokenSecret.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", cfg.TokenSecret.Name, cfg.TokenSecret.Key)
+	}
+	return string(token), nil
+}
@@ -0,0 +1,228 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+const (
+	// SinkTypeHTTP posts the events as JSON to an HTTP endpoint
+	SinkTypeHTTP = "http"
+	// SinkTypeNATS publishes the events to a NATS subject
+	SinkTypeNATS = "nats"
+	// SinkTypeKafka produces the events to a Kafka topic through a Kafka REST proxy
+	SinkTypeKafka = "kafka"
+	// SinkTypeGrafana creates Grafana annotations of the events, which mark the deployments on dashboards
+	SinkTypeGrafana = "grafana"
+
+	defaultNATSPort = "4222"
+	sinkTimeout     = 10 * time.Second
+)
+
+// Sink publishes deployment events to an external system
+type Sink interface {
+	Publish(event Event) error
+}
+
+// NewSink returns the sink of a configuration. The token is the resolved token secret, if any.
+func NewSink(cfg settings.DeploymentEventSink, token string) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("sink %s has no url", cfg.Name)
+	}
+	client := &http.Client{Timeout: sinkTimeout}
+	switch cfg.Type {
+	case SinkTypeHTTP:
+		return &httpSink{url: cfg.URL, headers: cfg.Headers, token: token, client: client}, nil
+	case SinkTypeNATS:
+		if cfg.Subject == "" {
+			return nil, fmt.Errorf("sink %s has no subject", cfg.Name)
+		}
+		return &natsSink{url: cfg.URL, subject: cfg.Subject, token: token}, nil
+	case SinkTypeKafka:
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("sink %s has no topic", cfg.Name)
+		}
+		return &kafkaSink{url: strings.TrimSuffix(cfg.URL, "/"), topic: cfg.Topic, token: token, client: client}, nil
+	case SinkTypeGrafana:
+		return &grafanaSink{url: strings.TrimSuffix(cfg.URL, "/"), tags: cfg.Tags, token: token, client: client}, nil
+	default:
+		return nil, fmt.Errorf("sink %s has unknown type '%s'", cfg.Name, cfg.Type)
+	}
+}
+
+type httpSink struct {
+	url     string
+	headers map[string]string
+	token   string
+	client  *http.Client
+}
+
+func (s *httpSink) Publish(event Event) error {
+	return post(s.client, s.url, "application/json", s.headers, s.token, event)
+}
+
+// kafkaSink produces records with the Confluent REST proxy API v2, keyed by the name of the application
+type kafkaSink struct {
+	url    string
+	topic  string
+	token  string
+	client *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+func (s *kafkaSink) Publish(event Event) error {
+	body := map[string][]kafkaRecord{"records": {{Key: event.Application, Value: event}}}
+	return post(s.client, fmt.Sprintf("%s/topics/%s", s.url, url.PathEscape(s.topic)), "application/vnd.kafka.json.v2+json", nil, s.token, body)
+}
+
+// grafanaSink creates region annotations spanning the sync operations with the HTTP API of Grafana
+type grafanaSink struct {
+	url    string
+	tags   []string
+	token  string
+	client *http.Client
+}
+
+type grafanaAnnotation struct {
+	Time    int64    `json:"time"`
+	TimeEnd int64    `json:"timeEnd"`
+	Tags    []string `json:"tags"`
+	Text    string   `json:"text"`
+}
+
+func (s *grafanaSink) Publish(event Event) error {
+	text := fmt.Sprintf("Sync of %s to %s: %s", event.Application, event.Revision, event.Result)
+	if event.Message != "" {
+		text = fmt.Sprintf("%s\n%s", text, event.Message)
+	}
+	annotation := grafanaAnnotation{
+		Time:    event.StartedAt.UnixNano() / int64(time.Millisecond),
+		TimeEnd: event.FinishedAt.UnixNano() / int64(time.Millisecond),
+		Tags:    append([]string{"argocd", "app:" + event.Application, "project:" + event.Project, "result:" + event.Result}, s.tags...),
+		Text:    text,
+	}
+	return post(s.client, s.url+"/api/annotations", "application/json", nil, s.token, annotation)
+}
+
+func post(client *http.Client, url string, contentType string, headers map[string]string, token string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("POST %s failed with status %d: %s", req.URL.Path, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// natsSink publishes messages with the text protocol of NATS, on a new connection for each event
+type natsSink struct {
+	url     string
+	subject string
+	token   string
+}
+
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func (s *natsSink) Publish(event Event) error {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), defaultNATSPort)
+	}
+	var conn net.Conn
+	dialer := &net.Dialer{Timeout: sinkTimeout}
+	if u.Scheme == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(sinkTimeout))
+	reader := bufio.NewReader(conn)
+	// the server greets clients with its INFO
+	if line, err := reader.ReadString('\n'); err != nil {
+		return err
+	} else if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting of NATS server: %s", strings.TrimSpace(line))
+	}
+	connect := natsConnect{Name: "argocd", AuthToken: s.token}
+	if u.User != nil {
+		connect.User = u.User.Username()
+		connect.Pass, _ = u.User.Password()
+	}
+	connectJSON, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "CONNECT %s\r\n", connectJSON)
+	_, _ = fmt.Fprintf(&buf, "PUB %s %d\r\n%s\r\n", s.subject, len(payload), payload)
+	// the PONG reply to the PING confirms that the server processed the message
+	buf.WriteString("PING\r\n")
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.HasPrefix(line, "PONG"):
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server rejected the message: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
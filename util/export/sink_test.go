@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+func newFakeEvent() Event {
+	return Event{Application: "guestbook", Project: "default", Revision: "abc", Result: "Succeeded"}
+}
+
+func TestNewEvent(t *testing.T) {
+	startedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	finishedAt := metav1.NewTime(startedAt.Add(90 * time.Second))
+	app := &appv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook"},
+		Spec: appv1.ApplicationSpec{
+			Source:      appv1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps"},
+			Destination: appv1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "guestbook"},
+		},
+		Status: appv1.ApplicationStatus{Summary: appv1.ApplicationSummary{Images: []string{"guestbook:v1"}}},
+	}
+	event := NewEvent(app, &appv1.OperationState{
+		Phase:      appv1.OperationFailed,
+		Message:    "boom",
+		StartedAt:  metav1.NewTime(startedAt),
+		FinishedAt: &finishedAt,
+		SyncResult: &appv1.SyncOperationResult{Revision: "abc"},
+	})
+	assert.Equal(t, Event{
+		Application:     "guestbook",
+		Project:         "default",
+		Server:          "https://kubernetes.default.svc",
+		Namespace:       "guestbook",
+		RepoURL:         "https://github.com/argoproj/argocd-example-apps",
+		Revision:        "abc",
+		Images:          []string{"guestbook:v1"},
+		Result:          "Failed",
+		Message:         "boom",
+		StartedAt:       startedAt,
+		FinishedAt:      finishedAt.Time,
+		DurationSeconds: 90,
+	}, event)
+}
+
+func TestHTTPAndKafkaSinks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.URL.Path, r.Header.Get("Content-Type"), body))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(settings.DeploymentEventSink{Name: "dora", Type: SinkTypeHTTP, URL: server.URL + "/events"}, "secret")
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Publish(newFakeEvent()))
+	sink, err = NewSink(settings.DeploymentEventSink{Name: "cmdb", Type: SinkTypeKafka, URL: server.URL + "/", Topic: "deployments"}, "secret")
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Publish(newFakeEvent()))
+	if assert.Len(t, requests, 2) {
+		assert.True(t, strings.HasPrefix(requests[0], `/events application/json {"application":"guestbook","project":"default"`), requests[0])
+		assert.True(t, strings.HasPrefix(requests[1], `/topics/deployments application/vnd.kafka.json.v2+json {"records":[{"key":"guestbook","value":{"application":"guestbook"`), requests[1])
+	}
+
+	sink, err = NewSink(settings.DeploymentEventSink{Name: "dora", Type: SinkTypeHTTP, URL: server.URL + "/fail"}, "secret")
+	assert.NoError(t, err)
+	assert.EqualError(t, sink.Publish(newFakeEvent()), "POST /fail failed with status 400: ")
+}
+
+func TestGrafanaSink(t *testing.T) {
+	var annotation grafanaAnnotation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/annotations", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotation))
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(settings.DeploymentEventSink{Name: "grafana", Type: SinkTypeGrafana, URL: server.URL + "/", Tags: []string{"production"}}, "secret")
+	assert.NoError(t, err)
+	event := newFakeEvent()
+	event.StartedAt = time.Unix(1600000000, 0)
+	event.FinishedAt = time.Unix(1600000090, 0)
+	assert.NoError(t, sink.Publish(event))
+	assert.Equal(t, grafanaAnnotation{
+		Time:    1600000000000,
+		TimeEnd: 1600000090000,
+		Tags:    []string{"argocd", "app:guestbook", "project:default", "result:Succeeded", "production"},
+		Text:    "Sync of guestbook to abc: Succeeded",
+	}, annotation)
+}
+
+func TestNATSSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = listener.Close() }()
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+		reader := bufio.NewReader(conn)
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			if line == "PING" {
+				_, _ = fmt.Fprint(conn, "PONG\r\n")
+				received <- lines
+				return
+			}
+			lines = append(lines, line)
+		}
+	}()
+
+	sink, err := NewSink(settings.DeploymentEventSink{Name: "nats", Type: SinkTypeNATS, URL: "nats://argocd:pass@" + listener.Addr().String(), Subject: "deployments"}, "")
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Publish(newFakeEvent()))
+	lines := <-received
+	if assert.Len(t, lines, 3) {
+		var connect natsConnect
+		assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[0], "CONNECT ")), &connect))
+		assert.Equal(t, natsConnect{Name: "argocd", User: "argocd", Pass: "pass"}, connect)
+		payload, _ := json.Marshal(newFakeEvent())
+		assert.Equal(t, fmt.Sprintf("PUB deployments %d", len(payload)), lines[1])
+		assert.Equal(t, string(payload), lines[2])
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink(settings.DeploymentEventSink{Name: "nats", Type: SinkTypeNATS, URL: "nats://nats"}, "")
+	assert.EqualError(t, err, "sink nats has no subject")
+	_, err = NewSink(settings.DeploymentEventSink{Name: "other", Type: "sqs", URL: "https://sqs"}, "")
+	assert.EqualError(t, err, "sink other has unknown type 'sqs'")
+}
@@ -0,0 +1,76 @@
+// Package expr evaluates the expressions of the settings of Argo CD, like the conditions of notification triggers
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+)
+
+// tokenRegex matches the string literals and escaped variables of an expression, which are kept as they are, and the
+// variables with dots in their names, which need to be escaped
+var tokenRegex = regexp.MustCompile(`'[^']*'|"[^"]*"|\[[^\]]*\]|[A-Za-z_]\w*(?:\.\w+)+`)
+
+// vars resolves the variables of expressions as dot separated paths in nested maps. Missing fields are nil.
+type vars map[string]interface{}
+
+func (v vars) Get(name string) (interface{}, error) {
+	var value interface{} = map[string]interface{}(v)
+	for _, field := range strings.Split(name, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		value = m[field]
+	}
+	return value, nil
+}
+
+// Evaluate evaluates a boolean expression, e.g. app.status.operationState.phase in ('Error', 'Failed'). Variables are
+// fields of the values, like app.status.health.status.
+func Evaluate(expression string, values map[string]interface{}) (bool, error) {
+	res, err := EvaluateValue(expression, values)
+	if err != nil {
+		return false, err
+	}
+	matches, ok := res.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %s evaluates to %v instead of a boolean", expression, res)
+	}
+	return matches, nil
+}
+
+// EvaluateValue evaluates an expression of any type, e.g. app.status.sync.revision
+func EvaluateValue(expression string, values map[string]interface{}) (interface{}, error) {
+	expr, err := parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	res, err := expr.Eval(vars(values))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expression %s: %v", expression, err)
+	}
+	return res, nil
+}
+
+// Validate returns an error if an expression cannot be parsed
+func Validate(expression string) error {
+	_, err := parse(expression)
+	return err
+}
+
+func parse(expression string) (*govaluate.EvaluableExpression, error) {
+	escaped := tokenRegex.ReplaceAllStringFunc(expression, func(token string) string {
+		if strings.ContainsAny(token[:1], `'"[`) {
+			return token
+		}
+		return "[" + token + "]"
+	})
+	expr, err := govaluate.NewEvaluableExpression(escaped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression %s: %v", expression, err)
+	}
+	return expr, nil
+}
@@ -0,0 +1,35 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	values := map[string]interface{}{"app": map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "guestbook"},
+		"status":   map[string]interface{}{"health": map[string]interface{}{"status": "Degraded"}, "resources": 3.0},
+	}}
+	for expression, expected := range map[string]bool{
+		"app.status.health.status == 'Degraded'":                   true,
+		"app.status.health.status in ('Healthy', 'Progressing')":   false,
+		"app.status.operationState.phase == 'Failed'":              false,
+		"app.status.resources > 2 && app.metadata.name != 'other'": true,
+		`app.metadata.name == "guestbook"`:                         true,
+	} {
+		res, err := Evaluate(expression, values)
+		assert.NoError(t, err, expression)
+		assert.Equal(t, expected, res, expression)
+	}
+
+	_, err := Evaluate("app.metadata.name", values)
+	assert.EqualError(t, err, "expression app.metadata.name evaluates to guestbook instead of a boolean")
+	_, err = Evaluate("app.metadata.name ==", values)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("resource.kind == 'Pod' && resource.spec.replicas > 1"))
+	assert.Error(t, Validate("resource.kind =="))
+}
@@ -0,0 +1,147 @@
+// Package fips restricts the cryptography of the Argo CD components and of the CLI to FIPS 140-2 approved algorithms.
+//
+// FIPS mode is enabled by building the binaries with the fips build tag (which requires a BoringCrypto Go toolchain,
+// see fips_boring.go) or by setting the ARGOCD_FIPS_MODE environment variable to true.
+package fips
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/argoproj/argo-cd/common"
+)
+
+const (
+	// MinTLSVersion is the minimum TLS version of FIPS mode
+	MinTLSVersion = tls.VersionTLS12
+	// MaxTLSVersion is the maximum TLS version of FIPS mode, since the cipher suites of TLS 1.3 can't be restricted by
+	// crypto/tls, i.e. ChaCha20-Poly1305 could be negotiated
+	MaxTLSVersion = tls.VersionTLS12
+	// MinRSABits is the minimum size of RSA keys of FIPS mode
+	MinRSABits = 2048
+)
+
+var (
+	// builtWithFIPS is true if the binary is built with the fips build tag
+	builtWithFIPS = false
+
+	// CipherSuites are the FIPS-approved TLS 1.2 cipher suites
+	CipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	}
+	// CurvePreferences are the FIPS-approved elliptic curves of TLS key exchanges, i.e. X25519 is not used
+	CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+	// sshOptions are the options of the ssh command which restrict the algorithms of SSH connections to FIPS-approved ones
+	sshOptions = map[string]string{
+		"Ciphers":                "aes256-gcm@openssh.com,aes128-gcm@openssh.com,aes256-ctr,aes192-ctr,aes128-ctr",
+		"MACs":                   "hmac-sha2-512,hmac-sha2-256",
+		"KexAlgorithms":          "ecdh-sha2-nistp521,ecdh-sha2-nistp384,ecdh-sha2-nistp256,diffie-hellman-group16-sha512,diffie-hellman-group14-sha256",
+		"HostKeyAlgorithms":      "ecdsa-sha2-nistp521,ecdsa-sha2-nistp384,ecdsa-sha2-nistp256,rsa-sha2-512,rsa-sha2-256",
+		"PubkeyAcceptedKeyTypes": "ecdsa-sha2-nistp521,ecdsa-sha2-nistp384,ecdsa-sha2-nistp256,rsa-sha2-512,rsa-sha2-256",
+	}
+	// sshKeyTypes are the FIPS-approved types of SSH keys
+	sshKeyTypes = map[string]bool{
+		"ssh-rsa":             true,
+		"ecdsa-sha2-nistp256": true,
+		"ecdsa-sha2-nistp384": true,
+		"ecdsa-sha2-nistp521": true,
+	}
+)
+
+// Enabled returns whether FIPS mode is enabled
+func Enabled() bool {
+	return builtWithFIPS || os.Getenv(common.EnvFIPSMode) == "true"
+}
+
+// ConfigureTLS restricts the versions, cipher suites and curves of a TLS config to FIPS-approved ones if FIPS mode is
+// enabled
+func ConfigureTLS(config *tls.Config) {
+	if !Enabled() {
+		return
+	}
+	if config.MinVersion < MinTLSVersion {
+		config.MinVersion = MinTLSVersion
+	}
+	if config.MaxVersion == 0 || config.MaxVersion > MaxTLSVersion {
+		config.MaxVersion = MaxTLSVersion
+	}
+	config.CipherSuites = CipherSuites
+	config.CurvePreferences = CurvePreferences
+}
+
+// ValidateTLSVersions returns an error if FIPS mode is enabled and the minimum or maximum TLS version (zero if unset) is
+// lower than TLS 1.2
+func ValidateTLSVersions(minVersion uint16, maxVersion uint16) error {
+	if !Enabled() {
+		return nil
+	}
+	if (minVersion != 0 && minVersion < MinTLSVersion) || (maxVersion != 0 && maxVersion < MinTLSVersion) {
+		return fmt.Errorf("TLS versions lower than 1.2 are not allowed in FIPS mode")
+	}
+	return nil
+}
+
+// ValidateRSABits returns an error if FIPS mode is enabled and the size of RSA keys is too small
+func ValidateRSABits(bits int) error {
+	if Enabled() && bits < MinRSABits {
+		return fmt.Errorf("RSA keys of %d bits are not allowed in FIPS mode, the minimum is %d bits", bits, MinRSABits)
+	}
+	return nil
+}
+
+// ValidateCertificate returns an error if FIPS mode is enabled and the key of a certificate is neither an RSA key of
+// at least 2048 bits nor an ECDSA key of an approved curve
+func ValidateCertificate(cert *tls.Certificate) error {
+	if !Enabled() || cert == nil || len(cert.Certificate) == 0 {
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	switch key := leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return ValidateRSABits(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		for _, curve := range []string{"P-256", "P-384", "P-521"} {
+			if key.Curve.Params().Name == curve {
+				return nil
+			}
+		}
+		return fmt.Errorf("ECDSA keys of curve %s are not allowed in FIPS mode", key.Curve.Params().Name)
+	default:
+		return fmt.Errorf("%s keys are not allowed in FIPS mode", leaf.PublicKeyAlgorithm)
+	}
+}
+
+// SSHOptions returns the options of the ssh command which restrict the algorithms of SSH connections to FIPS-approved
+// ones if FIPS mode is enabled
+func SSHOptions() []string {
+	if !Enabled() {
+		return nil
+	}
+	var args []string
+	for _, name := range []string{"Ciphers", "MACs", "KexAlgorithms", "HostKeyAlgorithms", "PubkeyAcceptedKeyTypes"} {
+		args = append(args, "-o", fmt.Sprintf("%s=%s", name, sshOptions[name]))
+	}
+	return args
+}
+
+// ValidateSSHKeyType returns an error if FIPS mode is enabled and the type of an SSH key (e.g. ssh-ed25519) is not
+// approved
+func ValidateSSHKeyType(keyType string) error {
+	if Enabled() && !sshKeyTypes[keyType] {
+		return fmt.Errorf("SSH keys of type %s are not allowed in FIPS mode", keyType)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build fips
+// +build fips
+
+package fips
+
+import (
+	// restricts crypto/tls to FIPS-approved settings, requires a BoringCrypto Go toolchain
+	_ "crypto/tls/fipsonly"
+)
+
+func init() {
+	builtWithFIPS = true
+}
@@ -0,0 +1,81 @@
+package fips_test
+
+import (
+	"crypto/tls"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/common"
+	. "github.com/argoproj/argo-cd/util/fips"
+	tlsutil "github.com/argoproj/argo-cd/util/tls"
+)
+
+func enableFIPSMode(t *testing.T) func() {
+	assert.NoError(t, os.Setenv(common.EnvFIPSMode, "true"))
+	return func() {
+		_ = os.Unsetenv(common.EnvFIPSMode)
+	}
+}
+
+func TestConfigureTLS(t *testing.T) {
+	config := &tls.Config{MinVersion: tls.VersionTLS10}
+	ConfigureTLS(config)
+	assert.Equal(t, uint16(tls.VersionTLS10), config.MinVersion)
+	assert.Nil(t, config.CipherSuites)
+
+	defer enableFIPSMode(t)()
+	ConfigureTLS(config)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MaxVersion)
+	assert.Equal(t, CipherSuites, config.CipherSuites)
+	assert.Equal(t, CurvePreferences, config.CurvePreferences)
+
+	// TLS 1.3 is disabled, since it would negotiate cipher suites which are not restricted
+	config = &tls.Config{MaxVersion: tls.VersionTLS13}
+	ConfigureTLS(config)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MaxVersion)
+}
+
+func TestValidateTLSVersions(t *testing.T) {
+	assert.NoError(t, ValidateTLSVersions(tls.VersionTLS10, 0))
+
+	defer enableFIPSMode(t)()
+	assert.NoError(t, ValidateTLSVersions(0, 0))
+	assert.NoError(t, ValidateTLSVersions(tls.VersionTLS12, tls.VersionTLS12))
+	assert.Error(t, ValidateTLSVersions(tls.VersionTLS11, 0))
+	assert.Error(t, ValidateTLSVersions(0, tls.VersionTLS11))
+}
+
+func TestValidateCertificate(t *testing.T) {
+	newCert := func(opts tlsutil.CertOptions) *tls.Certificate {
+		opts.Hosts = []string{"localhost"}
+		opts.Organization = "Argo CD"
+		cert, err := tlsutil.GenerateX509KeyPair(opts)
+		assert.NoError(t, err)
+		return cert
+	}
+	rsaCert := newCert(tlsutil.CertOptions{})
+	ecdsaCert := newCert(tlsutil.CertOptions{ECDSACurve: "P256"})
+	p224Cert := newCert(tlsutil.CertOptions{ECDSACurve: "P224"})
+	assert.NoError(t, ValidateCertificate(p224Cert))
+
+	defer enableFIPSMode(t)()
+	assert.NoError(t, ValidateCertificate(rsaCert))
+	assert.NoError(t, ValidateCertificate(ecdsaCert))
+	assert.EqualError(t, ValidateCertificate(p224Cert), "ECDSA keys of curve P-224 are not allowed in FIPS mode")
+	assert.Error(t, ValidateRSABits(1024))
+}
+
+func TestSSH(t *testing.T) {
+	assert.Empty(t, SSHOptions())
+	assert.NoError(t, ValidateSSHKeyType("ssh-ed25519"))
+
+	defer enableFIPSMode(t)()
+	options := SSHOptions()
+	assert.Contains(t, options, "Ciphers=aes256-gcm@openssh.com,aes128-gcm@openssh.com,aes256-ctr,aes192-ctr,aes128-ctr")
+	assert.Len(t, options, 10)
+	assert.NoError(t, ValidateSSHKeyType("ecdsa-sha2-nistp256"))
+	assert.EqualError(t, ValidateSSHKeyType("ssh-ed25519"), "SSH keys of type ssh-ed25519 are not allowed in FIPS mode")
+}
@@ -13,7 +13,6 @@ import (
 	"strings"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
 	"gopkg.in/src-d/go-git.v4"
@@ -27,6 +26,8 @@ import (
 	"github.com/argoproj/argo-cd/common"
 	certutil "github.com/argoproj/argo-cd/util/cert"
 	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/fips"
+	logutils "github.com/argoproj/argo-cd/util/log"
 )
 
 type RevisionMetadata struct {
@@ -47,6 +48,7 @@ type Client interface {
 	LsLargeFiles() ([]string, error)
 	CommitSHA() (string, error)
 	RevisionMetadata(revision string) (*RevisionMetadata, error)
+	CommitAndPush(branch, message, authorName, authorEmail string) (string, error)
 }
 
 // nativeGitClient implements Client interface using git CLI
@@ -65,6 +67,7 @@ type nativeGitClient struct {
 
 var (
 	maxAttemptsCount = 1
+	logger           = logutils.Module(logutils.ModuleGit)
 )
 
 func init() {
@@ -132,7 +135,7 @@ func GetRepoHTTPClient(repoURL string, insecure bool, creds Creds) *http.Client
 		if httpsCreds.clientCertData != "" && httpsCreds.clientCertKey != "" {
 			cert, err = tls.X509KeyPair([]byte(httpsCreds.clientCertData), []byte(httpsCreds.clientCertKey))
 			if err != nil {
-				log.Errorf("Could not load Client Certificate: %v", err)
+				logger.Errorf("Could not load Client Certificate: %v", err)
 				return &cert, nil
 			}
 		}
@@ -175,6 +178,9 @@ func GetRepoHTTPClient(repoURL string, insecure bool, creds Creds) *http.Client
 			}
 		}
 	}
+	if transport, ok := customHTTPClient.Transport.(*http.Transport); ok {
+		fips.ConfigureTLS(transport.TLSClientConfig)
+	}
 
 	return customHTTPClient
 }
@@ -190,6 +196,9 @@ func newAuth(repoURL string, creds Creds) (transport.AuthMethod, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := fips.ValidateSSHKeyType(signer.PublicKey().Type()); err != nil {
+			return nil, err
+		}
 		auth := &ssh2.PublicKeys{User: sshUser, Signer: signer}
 		if creds.insecure {
 			auth.HostKeyCallback = ssh.InsecureIgnoreHostKey()
@@ -198,7 +207,7 @@ func newAuth(repoURL string, creds Creds) (transport.AuthMethod, error) {
 			// file.
 			auth.HostKeyCallback, err = knownhosts.New(certutil.GetSSHKnownHostsDataPath())
 			if err != nil {
-				log.Errorf("Could not set-up SSH known hosts callback: %v", err)
+				logger.Errorf("Could not set-up SSH known hosts callback: %v", err)
 			}
 		}
 		return auth, nil
@@ -222,7 +231,7 @@ func (m *nativeGitClient) Init() error {
 	if err != git.ErrRepositoryNotExists {
 		return err
 	}
-	log.Infof("Initializing %s to %s", m.repoURL, m.root)
+	logger.Infof("Initializing %s to %s", m.repoURL, m.root)
 	_, err = executil.Run(exec.Command("rm", "-rf", m.root))
 	if err != nil {
 		return fmt.Errorf("unable to clean repo at %s: %v", m.root, err)
@@ -375,10 +384,10 @@ func (m *nativeGitClient) lsRemote(revision string) (string, error) {
 		if ref.Type() == plumbing.HashReference {
 			refToHash[refName] = hash
 		}
-		//log.Debugf("%s\t%s", hash, refName)
+		//logger.Debugf("%s\t%s", hash, refName)
 		if ref.Name().Short() == revision {
 			if ref.Type() == plumbing.HashReference {
-				log.Debugf("revision '%s' resolved to '%s'", revision, hash)
+				logger.Debugf("revision '%s' resolved to '%s'", revision, hash)
 				return hash, nil
 			}
 			if ref.Type() == plumbing.SymbolicReference {
@@ -390,13 +399,13 @@ func (m *nativeGitClient) lsRemote(revision string) (string, error) {
 		// If refToResolve is non-empty, we are resolving symbolic reference (e.g. HEAD).
 		// It should exist in our refToHash map
 		if hash, ok := refToHash[refToResolve]; ok {
-			log.Debugf("symbolic reference '%s' (%s) resolved to '%s'", revision, refToResolve, hash)
+			logger.Debugf("symbolic reference '%s' (%s) resolved to '%s'", revision, refToResolve, hash)
 			return hash, nil
 		}
 	}
 	// We support the ability to use a truncated commit-SHA (e.g. first 7 characters of a SHA)
 	if IsTruncatedCommitSHA(revision) {
-		log.Debugf("revision '%s' assumed to be commit sha", revision)
+		logger.Debugf("revision '%s' assumed to be commit sha", revision)
 		return revision, nil
 	}
 	// If we get here, revision string had non hexadecimal characters (indicating its a branch, tag,
@@ -436,6 +445,26 @@ func (m *nativeGitClient) RevisionMetadata(revision string) (*RevisionMetadata,
 	return &RevisionMetadata{author, time.Unix(authorDateUnixTimestamp, 0), tags, message}, nil
 }
 
+// CommitAndPush commits all changes of the working tree and pushes the commit to a branch of origin. It returns the
+// commit SHA.
+func (m *nativeGitClient) CommitAndPush(branch, message, authorName, authorEmail string) (string, error) {
+	if _, err := m.runCmd("add", "--all"); err != nil {
+		return "", err
+	}
+	// $HOME is not set, so the author and committer are configured per command
+	if _, err := m.runCmd("-c", "user.name="+authorName, "-c", "user.email="+authorEmail, "commit", "--message", message); err != nil {
+		return "", err
+	}
+	commitSHA, err := m.CommitSHA()
+	if err != nil {
+		return "", err
+	}
+	if err := m.runCredentialedCmd("git", "push", "origin", "HEAD:refs/heads/"+branch); err != nil {
+		return "", err
+	}
+	return commitSHA, nil
+}
+
 // runCmd is a convenience function to run a command in a given directory and return its output
 func (m *nativeGitClient) runCmd(args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
@@ -475,7 +504,7 @@ func (m *nativeGitClient) runCmdOutput(cmd *exec.Cmd) (string, error) {
 			// We don't fail if we cannot parse the URL, but log a warning in that
 			// case. And we execute the command in a verbatim way.
 			if err != nil {
-				log.Warnf("runCmdOutput: Could not parse repo URL '%s'", m.repoURL)
+				logger.Warnf("runCmdOutput: Could not parse repo URL '%s'", m.repoURL)
 			} else {
 				caPath, err := certutil.GetCertBundlePathForRepository(parsedURL.Host)
 				if err == nil && caPath != "" {
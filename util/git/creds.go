@@ -7,10 +7,9 @@ import (
 	"os"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
-
 	"github.com/argoproj/argo-cd/util"
 	certutil "github.com/argoproj/argo-cd/util/cert"
+	"github.com/argoproj/argo-cd/util/fips"
 )
 
 type Creds interface {
@@ -84,7 +83,7 @@ func (c HTTPSCreds) Environ() (io.Closer, []string, error) {
 			if err != nil {
 				removeErr := os.Remove(certFile.Name())
 				if removeErr != nil {
-					log.Errorf("Could not remove previously created tempfile %s: %v", certFile.Name(), removeErr)
+					logger.Errorf("Could not remove previously created tempfile %s: %v", certFile.Name(), removeErr)
 				}
 				return NopCloser{}, nil, err
 			}
@@ -142,7 +141,7 @@ func (f authFilePaths) Close() error {
 	for _, path := range f {
 		err := os.Remove(path)
 		if err != nil {
-			log.Errorf("HTTPSCreds.Close(): Could not remove temp file %s: %v", path, err)
+			logger.Errorf("HTTPSCreds.Close(): Could not remove temp file %s: %v", path, err)
 			retErr = err
 		}
 	}
@@ -168,7 +167,7 @@ func (c SSHCreds) Environ() (io.Closer, []string, error) {
 		env = append(env, fmt.Sprintf("GIT_SSL_CAINFO=%s", c.caPath))
 	}
 	if c.insecure {
-		log.Warn("temporarily disabling strict host key checking (i.e. '-o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null'), please don't use in production")
+		logger.Warn("temporarily disabling strict host key checking (i.e. '-o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null'), please don't use in production")
 		// StrictHostKeyChecking will add the host to the knownhosts file,  we don't want that - a security issue really,
 		// UserKnownHostsFile=/dev/null is therefore used so we write the new insecure host to /dev/null
 		args = append(args, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
@@ -176,6 +175,7 @@ func (c SSHCreds) Environ() (io.Closer, []string, error) {
 		knownHostsFile := certutil.GetSSHKnownHostsDataPath()
 		args = append(args, "-o", "StrictHostKeyChecking=yes", "-o", fmt.Sprintf("UserKnownHostsFile=%s", knownHostsFile))
 	}
+	args = append(args, fips.SSHOptions()...)
 	env = append(env, []string{fmt.Sprintf("GIT_SSH_COMMAND=%s", strings.Join(args, " "))}...)
 	return sshPrivateKeyFile(file.Name()), env, nil
 }
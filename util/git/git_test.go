@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -329,3 +330,36 @@ func TestNewFactory(t *testing.T) {
 		assert.Equal(t, commitSHA, commitSHA2)
 	}
 }
+
+func TestCommitAndPush(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "git-client-push-test-")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	remote := filepath.Join(tempDir, "remote.git")
+	seed := filepath.Join(tempDir, "seed")
+	for _, args := range [][]string{
+		{"init", "--bare", remote},
+		{"init", seed},
+		{"-C", seed, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "--message", "initial"},
+		{"-C", seed, "push", remote, "HEAD:refs/heads/main"},
+	} {
+		assert.NoError(t, exec.Command("git", args...).Run(), "git %v", args)
+	}
+
+	client, err := NewClientExt("file://"+remote, filepath.Join(tempDir, "clone"), NopCreds{}, false, false)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Init())
+	assert.NoError(t, client.Fetch())
+	assert.NoError(t, client.Checkout("origin/main"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(client.Root(), "values.yaml"), []byte("image: v2\n"), 0644))
+
+	commitSHA, err := client.CommitAndPush("main", "Update image", "Argo CD", "argocd@example.com")
+	assert.NoError(t, err)
+	remoteSHA, err := client.LsRemote("main")
+	assert.NoError(t, err)
+	assert.Equal(t, commitSHA, remoteSHA)
+	metadata, err := client.RevisionMetadata(commitSHA)
+	assert.NoError(t, err)
+	assert.Equal(t, "Argo CD <argocd@example.com>", metadata.Author)
+	assert.Equal(t, "Update image", metadata.Message)
+}
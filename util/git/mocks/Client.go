@@ -27,6 +27,27 @@ func (_m *Client) Checkout(revision string) error {
 	return r0
 }
 
+// CommitAndPush provides a mock function with given fields: branch, message, authorName, authorEmail
+func (_m *Client) CommitAndPush(branch string, message string, authorName string, authorEmail string) (string, error) {
+	ret := _m.Called(branch, message, authorName, authorEmail)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, string, string) string); ok {
+		r0 = rf(branch, message, authorName, authorEmail)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(branch, message, authorName, authorEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CommitSHA provides a mock function with given fields:
 func (_m *Client) CommitSHA() (string, error) {
 	ret := _m.Called()
@@ -0,0 +1,152 @@
+package gotemplate
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+)
+
+// FuncMap returns the functions of Go templates rendered by Argo CD. They follow the names and argument order of the
+// corresponding functions of the sprig library, so that the last argument can be piped.
+func FuncMap() template.FuncMap {
+	res := make(template.FuncMap, len(funcs))
+	for name, f := range funcs {
+		res[name] = f
+	}
+	return res
+}
+
+var funcs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"title":      strings.Title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old string, new string, s string) string { return strings.Replace(s, old, new, -1) },
+	"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+	"trunc":      trunc,
+	"quote":      func(v interface{}) string { return strconv.Quote(fmt.Sprint(v)) },
+	"squote":     func(v interface{}) string { return "'" + fmt.Sprint(v) + "'" },
+	"splitList":  func(sep string, s string) []string { return strings.Split(s, sep) },
+	"join":       join,
+	"default":    defaultValue,
+	"empty":      empty,
+	"ternary": func(trueValue interface{}, falseValue interface{}, condition bool) interface{} {
+		if condition {
+			return trueValue
+		}
+		return falseValue
+	},
+	"list": func(items ...interface{}) []interface{} { return items },
+	"dict": dict,
+	"atoi": func(s string) int {
+		i, _ := strconv.Atoi(s)
+		return i
+	},
+	"toJson":          toJSON,
+	"toYaml":          toYAML,
+	"b64enc":          func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec":          b64dec,
+	"sha256sum":       sha256sum,
+	"regexMatch":      func(regex string, s string) (bool, error) { return regexp.MatchString(regex, s) },
+	"regexReplaceAll": regexReplaceAll,
+}
+
+// trunc returns the first n characters of a string, or the last -n characters if n is negative
+func trunc(n int, s string) string {
+	switch {
+	case n >= 0 && len(s) > n:
+		return s[:n]
+	case n < 0 && len(s) > -n:
+		return s[len(s)+n:]
+	}
+	return s
+}
+
+// join joins the items of a list, formatted as strings
+func join(sep string, list interface{}) string {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+	items := make([]string, value.Len())
+	for i := range items {
+		items[i] = fmt.Sprint(value.Index(i).Interface())
+	}
+	return strings.Join(items, sep)
+}
+
+// defaultValue returns the given value, or the default value if the given value is empty or missing
+func defaultValue(def interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || empty(given[0]) {
+		return def
+	}
+	return given[0]
+}
+
+// empty returns whether a value is nil, the zero value of its type or an empty collection
+func empty(v interface{}) bool {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+	return reflect.DeepEqual(v, reflect.Zero(value.Type()).Interface())
+}
+
+// dict returns a map of alternating keys and values
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict requires an even number of arguments")
+	}
+	res := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		res[fmt.Sprint(pairs[i])] = pairs[i+1]
+	}
+	return res, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func toYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	return strings.TrimSuffix(string(data), "\n"), err
+}
+
+func b64dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	return string(data), err
+}
+
+func sha256sum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func regexReplaceAll(regex string, s string, repl string) (string, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(s, repl), nil
+}
@@ -0,0 +1,30 @@
+package gotemplate
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	for text, expected := range map[string]string{
+		`{{"Feature/One" | lower | replace "/" "-"}}`:         "feature-one",
+		`{{"abcdef" | trunc 3}}-{{"abcdef" | trunc -2}}`:      "abc-ef",
+		`{{splitList "," "a,b,c" | join "-"}}`:                "a-b-c",
+		`{{dict "a" "b" | toJson}}`:                           `{"a":"b"}`,
+		`{{"x" | b64enc | b64dec | quote}}`:                   `"x"`,
+		`{{regexReplaceAll "[^a-z]+" "a_b.c" "-"}}`:           "a-b-c",
+		`{{ternary "yes" "no" (hasPrefix "feat" "feature")}}`: "yes",
+		`{{"" | default "none"}}-{{list | empty}}`:            "none-true",
+	} {
+		tmpl, err := template.New("").Funcs(FuncMap()).Parse(text)
+		if !assert.NoError(t, err, text) {
+			continue
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Execute(&buf, nil), text)
+		assert.Equal(t, expected, buf.String(), text)
+	}
+}
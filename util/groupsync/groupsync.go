@@ -0,0 +1,200 @@
+// Package groupsync periodically synchronizes the group memberships of the users from identity providers which can't
+// embed them in the claims of their tokens, so that RBAC evaluates the synchronized groups of the users in addition to
+// the groups of their tokens.
+package groupsync
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	log "github.com/sirupsen/logrus"
+
+	jwtutil "github.com/argoproj/argo-cd/util/jwt"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// retryInterval is the interval of retrying a failed synchronization
+const retryInterval = time.Minute
+
+// Source lists the group memberships of the users of an identity provider
+type Source interface {
+	Name() string
+	// GetMemberships returns the groups of the users, by user
+	GetMemberships(ctx context.Context) (map[string][]string, error)
+}
+
+// Syncer caches the group memberships synchronized from the sources configured in argocd-cm
+type Syncer struct {
+	mgr        *settings.SettingsManager
+	newSources func(config *settings.GroupSyncConfig) []Source
+
+	lock        sync.RWMutex
+	config      *settings.GroupSyncConfig
+	memberships map[string][]string
+}
+
+// NewSyncer returns a syncer of the group memberships configured in the settings
+func NewSyncer(mgr *settings.SettingsManager) *Syncer {
+	return &Syncer{mgr: mgr, newSources: newSources}
+}
+
+func newSources(config *settings.GroupSyncConfig) []Source {
+	var sources []Source
+	if config.Keycloak != nil {
+		sources = append(sources, newKeycloakSource(*config.Keycloak))
+	}
+	if config.LDAP != nil {
+		sources = append(sources, newLDAPSource(*config.LDAP))
+	}
+	return sources
+}
+
+// GetGroups returns the synchronized groups of the user identified by the claims, matched case insensitively. Only the
+// claims of the tokens of the configured issuer identify users, and the email claim only if it is verified.
+func (s *Syncer) GetGroups(claims jwt.MapClaims) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.config == nil || jwtutil.GetField(claims, "iss") != s.config.Issuer {
+		return nil
+	}
+	userClaim := s.config.GetUserClaim()
+	if userClaim == "email" && !isEmailVerified(claims) {
+		return nil
+	}
+	user := jwtutil.GetField(claims, userClaim)
+	if user == "" {
+		return nil
+	}
+	return s.memberships[strings.ToLower(user)]
+}
+
+// isEmailVerified returns whether the email_verified claim is true. Some identity providers encode it as a string.
+func isEmailVerified(claims jwt.MapClaims) bool {
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		return verified
+	case string:
+		return verified == "true"
+	}
+	return false
+}
+
+// Sync synchronizes the memberships of a configuration, or clears them if the groups are not synchronized. The
+// previous memberships are kept if a source fails.
+func (s *Syncer) Sync(ctx context.Context, config *settings.GroupSyncConfig) error {
+	if config == nil {
+		s.lock.Lock()
+		s.config, s.memberships = nil, nil
+		s.lock.Unlock()
+		return nil
+	}
+	memberships := make(map[string][]string)
+	for _, source := range s.newSources(config) {
+		started := time.Now()
+		res, err := source.GetMemberships(ctx)
+		if err != nil {
+			log.WithField("source", source.Name()).Warnf("Failed to synchronize groups: %v", err)
+			return err
+		}
+		for user, groups := range res {
+			user = strings.ToLower(user)
+			memberships[user] = append(memberships[user], groups...)
+		}
+		log.WithField("source", source.Name()).Infof("Synchronized the groups of %d users in %v", len(res), time.Since(started).Round(time.Millisecond))
+	}
+	for user, groups := range memberships {
+		memberships[user] = uniqueSorted(groups)
+	}
+	s.lock.Lock()
+	s.config, s.memberships = config, memberships
+	s.lock.Unlock()
+	return nil
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	res := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// Run synchronizes the memberships at the configured interval, and whenever their configuration changes, until the
+// context is done
+func (s *Syncer) Run(ctx context.Context) {
+	updateCh := make(chan *settings.ArgoCDSettings, 1)
+	s.mgr.Subscribe(updateCh)
+	defer s.mgr.Unsubscribe(updateCh)
+
+	// the settings manager blocks while notifying its subscribers, so the updates are received independently
+	// of the synchronization, which uses the settings manager
+	changed := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-updateCh:
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	var synced *settings.GroupSyncConfig
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+			config, err := s.mgr.GetGroupSyncConfig()
+			if err != nil || reflect.DeepEqual(config, synced) {
+				continue
+			}
+		case <-timer.C:
+		}
+		config, err := s.mgr.GetGroupSyncConfig()
+		if err != nil {
+			log.Warnf("Failed to get the group synchronization settings: %v", err)
+			resetTimer(timer, retryInterval)
+			continue
+		}
+		if err := s.Sync(ctx, config); err != nil {
+			resetTimer(timer, retryInterval)
+			continue
+		}
+		synced = config
+		if config != nil {
+			resetTimer(timer, config.GetInterval())
+		} else {
+			// the configuration is only read again when the settings change
+			stopTimer(timer)
+		}
+	}
+}
+
+func stopTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+}
+
+func resetTimer(timer *time.Timer, d time.Duration) {
+	stopTimer(timer)
+	timer.Reset(d)
+}
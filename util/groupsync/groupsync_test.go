@@ -0,0 +1,193 @@
+package groupsync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/util/ldap"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+type fakeSource struct {
+	memberships map[string][]string
+	err         error
+}
+
+func (s *fakeSource) Name() string {
+	return "fake"
+}
+
+func (s *fakeSource) GetMemberships(_ context.Context) (map[string][]string, error) {
+	return s.memberships, s.err
+}
+
+func TestSyncer(t *testing.T) {
+	sources := []Source{
+		&fakeSource{memberships: map[string][]string{"Alice@example.com": {"devs", "admins"}}},
+		&fakeSource{memberships: map[string][]string{"alice@example.com": {"devs", "ops"}, "bob@example.com": {"ops"}}},
+	}
+	syncer := &Syncer{newSources: func(_ *settings.GroupSyncConfig) []Source { return sources }}
+
+	claims := func(claims jwt.MapClaims) jwt.MapClaims {
+		claims["iss"] = "https://dex.example.com"
+		claims["email_verified"] = true
+		return claims
+	}
+	assert.Nil(t, syncer.GetGroups(claims(jwt.MapClaims{"email": "alice@example.com"})))
+
+	config := &settings.GroupSyncConfig{Issuer: "https://dex.example.com"}
+	assert.NoError(t, syncer.Sync(context.Background(), config))
+	assert.Equal(t, []string{"admins", "devs", "ops"}, syncer.GetGroups(claims(jwt.MapClaims{"email": "ALICE@example.com"})))
+	assert.Equal(t, []string{"ops"}, syncer.GetGroups(claims(jwt.MapClaims{"email": "bob@example.com"})))
+	assert.Nil(t, syncer.GetGroups(claims(jwt.MapClaims{"email": "carol@example.com"})))
+	assert.Nil(t, syncer.GetGroups(claims(jwt.MapClaims{"sub": "alice@example.com"})))
+
+	t.Run("OtherIssuer", func(t *testing.T) {
+		assert.Nil(t, syncer.GetGroups(jwt.MapClaims{"iss": "https://other.example.com", "email": "bob@example.com", "email_verified": true}))
+		assert.Nil(t, syncer.GetGroups(jwt.MapClaims{"email": "bob@example.com", "email_verified": true}))
+	})
+	t.Run("UnverifiedEmail", func(t *testing.T) {
+		assert.Nil(t, syncer.GetGroups(jwt.MapClaims{"iss": "https://dex.example.com", "email": "bob@example.com"}))
+		assert.Nil(t, syncer.GetGroups(jwt.MapClaims{"iss": "https://dex.example.com", "email": "bob@example.com", "email_verified": false}))
+		assert.Equal(t, []string{"ops"}, syncer.GetGroups(jwt.MapClaims{"iss": "https://dex.example.com", "email": "bob@example.com", "email_verified": "true"}))
+	})
+	t.Run("UserClaim", func(t *testing.T) {
+		assert.NoError(t, syncer.Sync(context.Background(), &settings.GroupSyncConfig{Issuer: "https://dex.example.com", UserClaim: "preferred_username"}))
+		assert.Equal(t, []string{"ops"}, syncer.GetGroups(jwt.MapClaims{"iss": "https://dex.example.com", "preferred_username": "bob@example.com"}))
+	})
+	t.Run("FailedSource", func(t *testing.T) {
+		sources = append(sources, &fakeSource{err: errors.New("unavailable")})
+		assert.Error(t, syncer.Sync(context.Background(), config))
+		// the previous memberships are kept
+		assert.Equal(t, []string{"ops"}, syncer.GetGroups(jwt.MapClaims{"iss": "https://dex.example.com", "preferred_username": "bob@example.com"}))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, syncer.Sync(context.Background(), nil))
+		assert.Nil(t, syncer.GetGroups(claims(jwt.MapClaims{"email": "bob@example.com"})))
+	})
+}
+
+func TestKeycloakSource(t *testing.T) {
+	members := map[string][]keycloakUser{
+		"1":   {{ID: "u1", Username: "alice", Email: "alice@example.com"}},
+		"1-1": {{ID: "u2", Username: "bob", Email: "bob@example.com"}},
+		"2":   {{ID: "u1", Username: "alice", Email: "alice@example.com"}, {ID: "u3", Username: "carol"}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON := func(v interface{}) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(v)
+		}
+		switch {
+		case r.URL.Path == "/realms/argo/protocol/openid-connect/token":
+			_ = r.ParseForm()
+			if r.Form.Get("client_secret") != "secret" || r.Form.Get("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			writeJSON(map[string]string{"access_token": "token"})
+			return
+		case r.Header.Get("Authorization") != "Bearer token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/admin/realms/argo/groups":
+			if r.URL.Query().Get("first") != "0" {
+				writeJSON([]keycloakGroup{})
+				return
+			}
+			writeJSON([]keycloakGroup{
+				{ID: "1", Name: "devs", Path: "/devs", SubGroups: []keycloakGroup{{ID: "1-1", Name: "frontend", Path: "/devs/frontend"}}},
+				{ID: "2", Name: "ops", Path: "/ops", SubGroupCount: 1},
+			})
+		case r.URL.Path == "/admin/realms/argo/groups/2/children":
+			writeJSON([]keycloakGroup{{ID: "2-1", Name: "oncall", Path: "/ops/oncall"}})
+		case strings.HasPrefix(r.URL.Path, "/admin/realms/argo/groups/") && strings.HasSuffix(r.URL.Path, "/members"):
+			writeJSON(members[strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/realms/argo/groups/"), "/members")])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	config := settings.KeycloakGroupSyncConfig{URL: ts.URL + "/", Realm: "argo", ClientID: "argocd", ClientSecret: "secret"}
+	memberships, err := newKeycloakSource(config).GetMemberships(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"alice@example.com": {"devs", "ops"}, "bob@example.com": {"frontend"}}, memberships)
+
+	config.UserAttribute = "username"
+	config.FullPath = true
+	memberships, err = newKeycloakSource(config).GetMemberships(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"alice": {"/devs", "/ops"}, "bob": {"/devs/frontend"}, "carol": {"/ops"}}, memberships)
+
+	config.ClientSecret = "wrong"
+	_, err = newKeycloakSource(config).GetMemberships(context.Background())
+	assert.Error(t, err)
+}
+
+type fakeLDAPConn struct {
+	entries  map[string][]ldap.Entry
+	bound    string
+	startTLS bool
+}
+
+func (c *fakeLDAPConn) StartTLS(_ *tls.Config) error {
+	c.startTLS = true
+	return nil
+}
+
+func (c *fakeLDAPConn) Bind(dn, _ string) error {
+	c.bound = dn
+	return nil
+}
+
+func (c *fakeLDAPConn) Search(baseDN, _ string, _ []string) ([]ldap.Entry, error) {
+	return c.entries[baseDN], nil
+}
+
+func (c *fakeLDAPConn) Close() error {
+	return nil
+}
+
+func TestLDAPSource(t *testing.T) {
+	conn := &fakeLDAPConn{entries: map[string][]ldap.Entry{
+		"ou=people,dc=example,dc=org": {
+			{DN: "uid=alice,ou=people,dc=example,dc=org", Attributes: map[string][]string{"mail": {"alice@example.com"}}},
+			{DN: "uid=bob,ou=people,dc=example,dc=org", Attributes: map[string][]string{"mail": {"bob@example.com"}}},
+		},
+		"ou=groups,dc=example,dc=org": {
+			{DN: "cn=devs,ou=groups,dc=example,dc=org", Attributes: map[string][]string{"cn": {"devs"}, "member": {"UID=alice, ou=people, dc=example, dc=org", "uid=unknown,ou=people,dc=example,dc=org"}}},
+			{DN: "cn=ops,ou=groups,dc=example,dc=org", Attributes: map[string][]string{"cn": {"ops"}, "member": {"uid=alice,ou=people,dc=example,dc=org", "uid=bob,ou=people,dc=example,dc=org"}}},
+		},
+		"ou=posix,dc=example,dc=org": {
+			{DN: "cn=admins,ou=posix,dc=example,dc=org", Attributes: map[string][]string{"cn": {"admins"}, "memberUid": {"alice"}}},
+		},
+	}}
+	source := newLDAPSource(settings.LDAPGroupSyncConfig{BindDN: "cn=argocd,dc=example,dc=org", UserBaseDN: "ou=people,dc=example,dc=org", GroupBaseDN: "ou=groups,dc=example,dc=org"})
+	source.dial = func() (ldapConn, error) { return conn, nil }
+	memberships, err := source.GetMemberships(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "cn=argocd,dc=example,dc=org", conn.bound)
+	assert.Equal(t, map[string][]string{"alice@example.com": {"devs", "ops"}, "bob@example.com": {"ops"}}, memberships)
+
+	source = newLDAPSource(settings.LDAPGroupSyncConfig{GroupBaseDN: "ou=posix,dc=example,dc=org", MemberAttribute: "memberUid"})
+	source.dial = func() (ldapConn, error) { return conn, nil }
+	memberships, err = source.GetMemberships(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"alice": {"admins"}}, memberships)
+	assert.False(t, conn.startTLS)
+
+	source = newLDAPSource(settings.LDAPGroupSyncConfig{StartTLS: true, GroupBaseDN: "ou=posix,dc=example,dc=org", MemberAttribute: "memberUid"})
+	source.dial = func() (ldapConn, error) { return conn, nil }
+	_, err = source.GetMemberships(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, conn.startTLS)
+}
@@ -0,0 +1,191 @@
+package groupsync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// keycloakPageSize is the number of groups or members requested per page
+const keycloakPageSize = 100
+
+// keycloakSource lists the members of the groups of a Keycloak realm with the admin REST API, authenticated with the
+// service account of a client
+type keycloakSource struct {
+	config settings.KeycloakGroupSyncConfig
+	client *http.Client
+}
+
+type keycloakGroup struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Path          string          `json:"path"`
+	SubGroupCount int             `json:"subGroupCount"`
+	SubGroups     []keycloakGroup `json:"subGroups"`
+}
+
+type keycloakUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func newKeycloakSource(config settings.KeycloakGroupSyncConfig) *keycloakSource {
+	return &keycloakSource{
+		config: config,
+		client: &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify},
+			},
+		},
+	}
+}
+
+func (s *keycloakSource) Name() string {
+	return "keycloak"
+}
+
+func (s *keycloakSource) GetMemberships(ctx context.Context) (map[string][]string, error) {
+	token, err := s.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.listGroups(ctx, token, "groups", true)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make(map[string][]string)
+	var visit func(groups []keycloakGroup) error
+	visit = func(groups []keycloakGroup) error {
+		for _, group := range groups {
+			name := group.Name
+			if s.config.FullPath {
+				name = group.Path
+			}
+			users, err := s.listMembers(ctx, token, group.ID)
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				if key := s.userKey(user); key != "" {
+					memberships[key] = append(memberships[key], name)
+				}
+			}
+			subGroups := group.SubGroups
+			if len(subGroups) == 0 && group.SubGroupCount > 0 {
+				// Keycloak 23 and later only return the subgroups of a group with the children endpoint
+				if subGroups, err = s.listGroups(ctx, token, fmt.Sprintf("groups/%s/children", url.PathEscape(group.ID)), false); err != nil {
+					return err
+				}
+			}
+			if err := visit(subGroups); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(groups); err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (s *keycloakSource) userKey(user keycloakUser) string {
+	switch s.config.UserAttribute {
+	case "username":
+		return user.Username
+	case "id":
+		return user.ID
+	default:
+		return user.Email
+	}
+}
+
+// getToken returns an access token of the service account of the client
+func (s *keycloakSource) getToken(ctx context.Context) (string, error) {
+	tokenURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", strings.TrimSuffix(s.config.URL, "/"), url.PathEscape(s.config.Realm))
+	form := url.Values{"grant_type": {"client_credentials"}, "client_id": {s.config.ClientID}, "client_secret": {s.config.ClientSecret}}
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := s.do(req.WithContext(ctx), &res); err != nil {
+		return "", fmt.Errorf("failed to get a token of client %s: %v", s.config.ClientID, err)
+	}
+	return res.AccessToken, nil
+}
+
+// listGroups lists all pages of the groups of an endpoint of the admin API of the realm
+func (s *keycloakSource) listGroups(ctx context.Context, token string, path string, brief bool) ([]keycloakGroup, error) {
+	var groups []keycloakGroup
+	for first := 0; ; first += keycloakPageSize {
+		var page []keycloakGroup
+		query := url.Values{"first": {fmt.Sprint(first)}, "max": {fmt.Sprint(keycloakPageSize)}}
+		if brief {
+			query.Set("briefRepresentation", "true")
+		}
+		if err := s.get(ctx, token, path, query, &page); err != nil {
+			return nil, err
+		}
+		groups = append(groups, page...)
+		if len(page) < keycloakPageSize {
+			return groups, nil
+		}
+	}
+}
+
+// listMembers lists all pages of the direct members of a group
+func (s *keycloakSource) listMembers(ctx context.Context, token string, groupID string) ([]keycloakUser, error) {
+	var users []keycloakUser
+	for first := 0; ; first += keycloakPageSize {
+		var page []keycloakUser
+		query := url.Values{"first": {fmt.Sprint(first)}, "max": {fmt.Sprint(keycloakPageSize)}, "briefRepresentation": {"true"}}
+		if err := s.get(ctx, token, fmt.Sprintf("groups/%s/members", url.PathEscape(groupID)), query, &page); err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < keycloakPageSize {
+			return users, nil
+		}
+	}
+}
+
+func (s *keycloakSource) get(ctx context.Context, token string, path string, query url.Values, res interface{}) error {
+	reqURL := fmt.Sprintf("%s/admin/realms/%s/%s?%s", strings.TrimSuffix(s.config.URL, "/"), url.PathEscape(s.config.Realm), path, query.Encode())
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return s.do(req.WithContext(ctx), res)
+}
+
+func (s *keycloakSource) do(req *http.Request, res interface{}) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, res)
+}
@@ -0,0 +1,115 @@
+package groupsync
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/ldap"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// ldapTimeout bounds each operation of the LDAP synchronization
+const ldapTimeout = time.Minute
+
+// ldapConn is a connection to an LDAP server
+type ldapConn interface {
+	StartTLS(tlsConfig *tls.Config) error
+	Bind(dn, password string) error
+	Search(baseDN, filter string, attributes []string) ([]ldap.Entry, error)
+	Close() error
+}
+
+// ldapSource lists the members of the groups of an LDAP directory
+type ldapSource struct {
+	config settings.LDAPGroupSyncConfig
+	dial   func() (ldapConn, error)
+}
+
+func newLDAPSource(config settings.LDAPGroupSyncConfig) *ldapSource {
+	return &ldapSource{
+		config: config,
+		dial: func() (ldapConn, error) {
+			return ldap.Dial(config.URL, &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}, ldapTimeout)
+		},
+	}
+}
+
+func (s *ldapSource) Name() string {
+	return "ldap"
+}
+
+func (s *ldapSource) GetMemberships(_ context.Context) (map[string][]string, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if s.config.StartTLS {
+		if err := conn.StartTLS(&tls.Config{InsecureSkipVerify: s.config.InsecureSkipVerify}); err != nil {
+			return nil, err
+		}
+	}
+	if s.config.BindDN != "" {
+		if err := conn.Bind(s.config.BindDN, s.config.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	// the user attribute of the members, by normalized DN
+	var users map[string]string
+	if s.config.UserBaseDN != "" {
+		userAttribute := util.FirstNonEmpty(s.config.UserAttribute, "mail")
+		entries, err := conn.Search(s.config.UserBaseDN, util.FirstNonEmpty(s.config.UserFilter, "(objectClass=person)"), []string{userAttribute})
+		if err != nil {
+			return nil, err
+		}
+		users = make(map[string]string, len(entries))
+		for _, entry := range entries {
+			if values := entry.GetAttributeValues(userAttribute); len(values) > 0 {
+				users[normalizeDN(entry.DN)] = values[0]
+			}
+		}
+	}
+
+	nameAttribute := util.FirstNonEmpty(s.config.GroupNameAttribute, "cn")
+	memberAttribute := util.FirstNonEmpty(s.config.MemberAttribute, "member")
+	groups, err := conn.Search(s.config.GroupBaseDN, util.FirstNonEmpty(s.config.GroupFilter, "(objectClass=groupOfNames)"), []string{nameAttribute, memberAttribute})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make(map[string][]string)
+	for _, group := range groups {
+		names := group.GetAttributeValues(nameAttribute)
+		if len(names) == 0 {
+			continue
+		}
+		for _, member := range group.GetAttributeValues(memberAttribute) {
+			key := member
+			if users != nil {
+				var ok bool
+				if key, ok = users[normalizeDN(member)]; !ok {
+					continue
+				}
+			}
+			memberships[key] = append(memberships[key], names[0])
+		}
+	}
+	return memberships, nil
+}
+
+// normalizeDN returns a DN in lower case without the spaces around the separators of its RDNs, so that DNs written
+// differently by the directory can be compared
+func normalizeDN(dn string) string {
+	rdns := strings.Split(dn, ",")
+	for i, rdn := range rdns {
+		parts := strings.SplitN(rdn, "=", 2)
+		for j := range parts {
+			parts[j] = strings.TrimSpace(parts[j])
+		}
+		rdns[i] = strings.Join(parts, "=")
+	}
+	return strings.ToLower(strings.Join(rdns, ","))
+}
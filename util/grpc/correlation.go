@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// CorrelationIDMetadataKey is the gRPC metadata propagating the correlation ID of a request to other components
+	CorrelationIDMetadataKey = "x-correlation-id"
+	// CorrelationIDLogField is the log field of correlation IDs
+	CorrelationIDLogField = "correlation_id"
+)
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a new correlation ID
+func NewCorrelationID() string {
+	return uuid.New().String()
+}
+
+// ContextWithCorrelationID returns a context carrying a correlation ID, which is propagated by gRPC calls
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	if correlationID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID of a context, or an empty string if there is none
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// incomingCorrelationContext returns a context carrying the correlation ID of the metadata of an incoming call, or a new
+// correlation ID if generate is true and the call has none. The correlation ID is added to the fields of the gRPC logs.
+func incomingCorrelationContext(ctx context.Context, generate bool) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[CorrelationIDMetadataKey]) > 0 {
+		id = md[CorrelationIDMetadataKey][0]
+	}
+	if id == "" && generate {
+		id = NewCorrelationID()
+	}
+	if id == "" {
+		return ctx, ""
+	}
+	ctx = ContextWithCorrelationID(ctx, id)
+	return ctxlogrus.ToContext(ctx, log.WithField(CorrelationIDLogField, id)), id
+}
+
+// CorrelationIDUnaryServerInterceptor returns a UnaryServerInterceptor which adds the correlation ID of calls to their
+// context and logs, and returns it in the response headers. Calls without a correlation ID get a new one if generate is
+// true.
+func CorrelationIDUnaryServerInterceptor(generate bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := incomingCorrelationContext(ctx, generate)
+		if id != "" {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIDMetadataKey, id))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CorrelationIDStreamServerInterceptor returns a StreamServerInterceptor which adds the correlation ID of calls to
+// their context and logs, and returns it in the response headers. Calls without a correlation ID get a new one if
+// generate is true.
+func CorrelationIDStreamServerInterceptor(generate bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := incomingCorrelationContext(stream.Context(), generate)
+		if id != "" {
+			_ = stream.SetHeader(metadata.Pairs(CorrelationIDMetadataKey, id))
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+func outgoingCorrelationContext(ctx context.Context) context.Context {
+	if id := CorrelationID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, CorrelationIDMetadataKey, id)
+	}
+	return ctx
+}
+
+// CorrelationIDUnaryClientInterceptor returns a UnaryClientInterceptor which propagates the correlation ID of the
+// context to the called component
+func CorrelationIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingCorrelationContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// CorrelationIDStreamClientInterceptor returns a StreamClientInterceptor which propagates the correlation ID of the
+// context to the called component
+func CorrelationIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingCorrelationContext(ctx), desc, cc, method, opts...)
+	}
+}
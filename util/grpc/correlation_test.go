@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCorrelationIDUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/application.ApplicationService/Sync"}
+	var id string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id = CorrelationID(ctx)
+		return "ok", nil
+	}
+
+	// the correlation ID of the caller is kept
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(CorrelationIDMetadataKey, "abc"))
+	_, err := CorrelationIDUnaryServerInterceptor(true)(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", id)
+
+	// calls without a correlation ID get a new one
+	_, err = CorrelationIDUnaryServerInterceptor(true)(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+	assert.NotEqual(t, "abc", id)
+
+	_, err = CorrelationIDUnaryServerInterceptor(false)(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	assert.Empty(t, id)
+}
+
+func TestCorrelationIDUnaryClientInterceptor(t *testing.T) {
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := ContextWithCorrelationID(context.Background(), "abc")
+	err := CorrelationIDUnaryClientInterceptor()(ctx, "/repository.RepoServerService/GenerateManifest", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, md[CorrelationIDMetadataKey])
+
+	err = CorrelationIDUnaryClientInterceptor()(context.Background(), "/repository.RepoServerService/GenerateManifest", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Empty(t, md[CorrelationIDMetadataKey])
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/tls"
 	"net"
 	"runtime/debug"
-	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -13,6 +12,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-cd/util"
 )
 
 // PanicLoggerUnaryServerInterceptor returns a new unary server interceptor for recovering from panics and returning error
@@ -115,10 +116,8 @@ type TLSTestResult struct {
 }
 
 func TestTLS(address string) (*TLSTestResult, error) {
-	if parts := strings.Split(address, ":"); len(parts) == 1 {
-		// If port is unspecified, assume the most likely port
-		address += ":443"
-	}
+	// If port is unspecified, assume the most likely port
+	address = util.EnsurePort(address, "443")
 	var testResult TLSTestResult
 	var tlsConfig tls.Config
 	tlsConfig.InsecureSkipVerify = true
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimiterIdleTimeout is the duration after which the limiter of a caller which has not made any
+// requests is discarded
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+var rateLimitedRequestsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "argocd_api_server_rate_limited_requests_total",
+		Help: "Number of API requests rejected because the caller exceeded its rate limit.",
+	},
+	[]string{"grpc_method"},
+)
+
+func init() {
+	prometheus.MustRegister(rateLimitedRequestsCounter)
+}
+
+// RateLimitKeyFunc returns the key which identifies the caller of a request (e.g. account or JWT
+// subject). Requests for which an empty key is returned are not rate limited.
+type RateLimitKeyFunc func(ctx context.Context) string
+
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter enforces a token bucket rate limit on requests, separately for every caller. The buckets are held in
+// memory, so every API server replica limits the requests it receives on its own.
+type RateLimiter struct {
+	limit     rate.Limit
+	burst     int
+	keyFunc   RateLimitKeyFunc
+	lock      sync.Mutex
+	callers   map[string]*callerLimiter
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter returns a rate limiter which allows each caller up to qps requests per second,
+// with bursts of up to burst requests. A non-positive qps disables rate limiting.
+func NewRateLimiter(qps float64, burst int, keyFunc RateLimitKeyFunc) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		limit:   rate.Limit(qps),
+		burst:   burst,
+		keyFunc: keyFunc,
+		callers: make(map[string]*callerLimiter),
+		now:     time.Now,
+	}
+}
+
+// Enabled returns whether or not the limiter rejects any requests
+func (l *RateLimiter) Enabled() bool {
+	return l != nil && l.limit > 0
+}
+
+// Allow reports whether a request made by the caller with the given key may proceed
+func (l *RateLimiter) Allow(key string) bool {
+	if !l.Enabled() || key == "" {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	if now.Sub(l.lastPrune) > rateLimiterIdleTimeout {
+		for k, c := range l.callers {
+			if now.Sub(c.lastSeen) > rateLimiterIdleTimeout {
+				delete(l.callers, k)
+			}
+		}
+		l.lastPrune = now
+	}
+	c, ok := l.callers[key]
+	if !ok {
+		c = &callerLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.callers[key] = c
+	}
+	c.lastSeen = now
+	return c.limiter.AllowN(now, 1)
+}
+
+func (l *RateLimiter) enforce(ctx context.Context, fullMethod string) error {
+	if !l.Enabled() {
+		return nil
+	}
+	return l.Enforce(l.keyFunc(ctx), fullMethod)
+}
+
+// Enforce returns a ResourceExhausted error if the caller with the given key has exceeded its rate limit, e.g. for
+// requests which are rejected before the interceptors run
+func (l *RateLimiter) Enforce(key string, fullMethod string) error {
+	if l.Allow(key) {
+		return nil
+	}
+	rateLimitedRequestsCounter.WithLabelValues(fullMethod).Inc()
+	log.Warnf("Rate limit exceeded by '%s' calling %s", key, fullMethod)
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry later")
+}
+
+// RateLimitUnaryServerInterceptor returns a UnaryServerInterceptor which rejects requests of callers
+// exceeding their rate limit with a ResourceExhausted error (HTTP 429 via grpc-gateway)
+func RateLimitUnaryServerInterceptor(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := limiter.enforce(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamServerInterceptor returns a StreamServerInterceptor which rejects requests of callers
+// exceeding their rate limit with a ResourceExhausted error (HTTP 429 via grpc-gateway)
+func RateLimitStreamServerInterceptor(limiter *RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limiter.enforce(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
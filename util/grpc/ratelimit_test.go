@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(1, 2, nil)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	// every caller gets its own burst
+	assert.True(t, limiter.Allow("alice"))
+	assert.True(t, limiter.Allow("alice"))
+	assert.False(t, limiter.Allow("alice"))
+	assert.True(t, limiter.Allow("bob"))
+
+	// requests without a caller are never limited
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow(""))
+	}
+
+	// tokens refill at the configured rate
+	now = now.Add(time.Second)
+	assert.True(t, limiter.Allow("alice"))
+	assert.False(t, limiter.Allow("alice"))
+
+	// idle callers are forgotten
+	now = now.Add(2 * rateLimiterIdleTimeout)
+	assert.True(t, limiter.Allow("alice"))
+	assert.Len(t, limiter.callers, 1)
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := NewRateLimiter(0, 0, nil)
+	assert.False(t, limiter.Enabled())
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow("alice"))
+	}
+}
+
+func TestRateLimitUnaryServerInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(1, 1, func(ctx context.Context) string {
+		return "alice"
+	})
+	interceptor := RateLimitUnaryServerInterceptor(limiter)
+	info := &grpc.UnaryServerInfo{FullMethod: "/application.ApplicationService/Sync"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	resp, err := interceptor(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
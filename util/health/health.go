@@ -3,6 +3,7 @@ package health
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -11,7 +12,9 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	coreV1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	apiregistrationv1beta1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1beta1"
@@ -52,6 +55,54 @@ func SetApplicationHealth(resStatuses []appv1.ResourceStatus, liveObjs []*unstru
 	return &appHealth, savedErr
 }
 
+// SetProgressingDeadlines records the time since which the resources are progressing and reports the resources which
+// are progressing for longer than the progressing deadline of their kind as degraded. The times are carried over from
+// the previous statuses of the resources. It returns the application health, degraded if such a resource affects it.
+func SetProgressingDeadlines(resStatuses []appv1.ResourceStatus, prevStatuses []appv1.ResourceStatus, liveObjs []*unstructured.Unstructured, resourceOverrides map[string]appv1.ResourceOverride, appHealth *appv1.HealthStatus, now metav1.Time) (*appv1.HealthStatus, error) {
+	progressingSince := make(map[string]*metav1.Time)
+	for _, prev := range prevStatuses {
+		if prev.ProgressingSince != nil {
+			progressingSince[progressingKey(prev)] = prev.ProgressingSince
+		}
+	}
+	var savedErr error
+	res := appHealth.DeepCopy()
+	for i, liveObj := range liveObjs {
+		resStatus := &resStatuses[i]
+		if liveObj == nil || resStatus.Health == nil || resStatus.Health.Status != appv1.HealthStatusProgressing {
+			continue
+		}
+		since, ok := progressingSince[progressingKey(*resStatus)]
+		if !ok {
+			since = now.DeepCopy()
+		}
+		resStatus.ProgressingSince = since
+		deadline, err := GetProgressingDeadline(liveObj.GroupVersionKind().GroupKind(), resourceOverrides)
+		if err != nil {
+			if savedErr == nil {
+				savedErr = err
+			}
+			continue
+		}
+		if deadline == 0 || now.Sub(since.Time) <= deadline {
+			continue
+		}
+		message := fmt.Sprintf("Progressing for more than %s", deadline)
+		if resStatus.Health.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, resStatus.Health.Message)
+		}
+		resStatus.Health = &appv1.HealthStatus{Status: appv1.HealthStatusDegraded, Message: message}
+		if !ignoreLiveObjectHealth(liveObj, *resStatus.Health) && IsWorse(res.Status, appv1.HealthStatusDegraded) {
+			res.Status = appv1.HealthStatusDegraded
+		}
+	}
+	return res, savedErr
+}
+
+func progressingKey(resStatus appv1.ResourceStatus) string {
+	return fmt.Sprintf("%s/%s/%s/%s", resStatus.Group, resStatus.Kind, resStatus.Namespace, resStatus.Name)
+}
+
 // ignoreLiveObjectHealth determines if we should not allow the live object to affect the overall
 // health of the application (e.g. hooks, missing child applications)
 func ignoreLiveObjectHealth(liveObj *unstructured.Unstructured, resHealth appv1.HealthStatus) bool {
@@ -152,6 +203,24 @@ func GetResourceHealth(obj *unstructured.Unstructured, resourceOverrides map[str
 	return health, err
 }
 
+// GetProgressingDeadline returns the progressing deadline of the resources of a kind configured in the resource
+// overrides, or zero if there is none
+func GetProgressingDeadline(gk schema.GroupKind, resourceOverrides map[string]appv1.ResourceOverride) (time.Duration, error) {
+	key := gk.Kind
+	if gk.Group != "" {
+		key = fmt.Sprintf("%s/%s", gk.Group, gk.Kind)
+	}
+	override, ok := resourceOverrides[key]
+	if !ok || override.ProgressingDeadline == "" {
+		return 0, nil
+	}
+	deadline, err := time.ParseDuration(override.ProgressingDeadline)
+	if err != nil {
+		return 0, fmt.Errorf("invalid progressing deadline of %s: %v", key, err)
+	}
+	return deadline, nil
+}
+
 // healthOrder is a list of health codes in order of most healthy to least healthy
 var healthOrder = []appv1.HealthStatusCode{
 	appv1.HealthStatusHealthy,
@@ -3,11 +3,13 @@ package health
 import (
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/argoproj/argo-cd/common"
 	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
@@ -190,6 +192,80 @@ func TestSetApplicationHealth(t *testing.T) {
 	assert.Equal(t, appv1.HealthStatusHealthy, healthStatus.Status)
 }
 
+func TestSetProgressingDeadlines(t *testing.T) {
+	yamlBytes, err := ioutil.ReadFile("./testdata/deployment-progressing.yaml")
+	assert.Nil(t, err)
+	var deployment unstructured.Unstructured
+	err = yaml.Unmarshal(yamlBytes, &deployment)
+	assert.Nil(t, err)
+
+	liveObjs := []*unstructured.Unstructured{&deployment}
+	overrides := map[string]appv1.ResourceOverride{"extensions/Deployment": {ProgressingDeadline: "10m"}}
+	newResources := func() []appv1.ResourceStatus {
+		resources := []appv1.ResourceStatus{{Group: "extensions", Version: "v1", Kind: "Deployment", Name: deployment.GetName()}}
+		_, err := SetApplicationHealth(resources, liveObjs, overrides, noFilter)
+		assert.NoError(t, err)
+		return resources
+	}
+	now := metav1.Now()
+	progressing := &appv1.HealthStatus{Status: appv1.HealthStatusProgressing}
+
+	// the resource starts progressing
+	resources := newResources()
+	healthStatus, err := SetProgressingDeadlines(resources, nil, liveObjs, overrides, progressing, now)
+	assert.NoError(t, err)
+	assert.Equal(t, appv1.HealthStatusProgressing, healthStatus.Status)
+	assert.Equal(t, appv1.HealthStatusProgressing, resources[0].Health.Status)
+	assert.Equal(t, now.Time, resources[0].ProgressingSince.Time)
+
+	// the resource is progressing for longer than the deadline
+	prevResources := resources
+	resources = newResources()
+	healthStatus, err = SetProgressingDeadlines(resources, prevResources, liveObjs, overrides, progressing, metav1.NewTime(now.Add(11*time.Minute)))
+	assert.NoError(t, err)
+	assert.Equal(t, appv1.HealthStatusDegraded, healthStatus.Status)
+	assert.Equal(t, appv1.HealthStatusDegraded, resources[0].Health.Status)
+	assert.Contains(t, resources[0].Health.Message, "Progressing for more than 10m0s")
+	assert.Equal(t, now.Time, resources[0].ProgressingSince.Time)
+
+	// hooks don't affect the health of the application
+	deployment.SetAnnotations(map[string]string{common.AnnotationKeyHook: "PreSync"})
+	resources = newResources()
+	healthStatus, err = SetProgressingDeadlines(resources, prevResources, liveObjs, overrides, progressing, metav1.NewTime(now.Add(11*time.Minute)))
+	assert.NoError(t, err)
+	assert.Equal(t, appv1.HealthStatusProgressing, healthStatus.Status)
+	assert.Equal(t, appv1.HealthStatusDegraded, resources[0].Health.Status)
+	deployment.SetAnnotations(nil)
+
+	// the resource is not progressing anymore
+	resources = []appv1.ResourceStatus{{Group: "extensions", Version: "v1", Kind: "Deployment", Name: deployment.GetName(), Health: &appv1.HealthStatus{Status: appv1.HealthStatusHealthy}}}
+	healthStatus, err = SetProgressingDeadlines(resources, prevResources, liveObjs, overrides, &appv1.HealthStatus{Status: appv1.HealthStatusHealthy}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, appv1.HealthStatusHealthy, healthStatus.Status)
+	assert.Nil(t, resources[0].ProgressingSince)
+
+	// invalid deadlines are reported
+	resources = newResources()
+	_, err = SetProgressingDeadlines(resources, nil, liveObjs, map[string]appv1.ResourceOverride{"extensions/Deployment": {ProgressingDeadline: "ten minutes"}}, progressing, now)
+	assert.Error(t, err)
+}
+
+func TestGetProgressingDeadline(t *testing.T) {
+	overrides := map[string]appv1.ResourceOverride{
+		"apps/Deployment": {ProgressingDeadline: "10m"},
+		"Pod":             {ProgressingDeadline: "1h"},
+	}
+	deadline, err := GetProgressingDeadline(schema.GroupKind{Group: "apps", Kind: "Deployment"}, overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, deadline)
+	deadline, err = GetProgressingDeadline(schema.GroupKind{Kind: "Pod"}, overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, deadline)
+	deadline, err = GetProgressingDeadline(schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), deadline)
+}
+
 func TestAPIService(t *testing.T) {
 	assertAppHealth(t, "./testdata/apiservice-v1-true.yaml", appv1.HealthStatusHealthy)
 	assertAppHealth(t, "./testdata/apiservice-v1-false.yaml", appv1.HealthStatusProgressing)
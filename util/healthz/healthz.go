@@ -1,16 +1,77 @@
 package healthz
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// CheckKubernetes is the check of the access to the Kubernetes API
+	CheckKubernetes = "kubernetes"
+	// CheckRedis is the check of the connectivity to Redis
+	CheckRedis = "redis"
+	// CheckRepoServer is the check of the reachability of the repo server
+	CheckRepoServer = "repo-server"
+	// CheckConfig is the check of the validity of the settings of the argocd-cm, argocd-rbac-cm and argocd-secret
+	CheckConfig = "config"
+
+	// CheckTimeout is the timeout of the checks of dependencies which do not have a timeout of their own
+	CheckTimeout = 5 * time.Second
+)
+
+// Check is a check of a dependency of a component
+type Check struct {
+	Name  string
+	Check func() error
+}
+
+// CheckResult is the result of the check of a dependency
+type CheckResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// Status is the health of a component together with the results of the checks of its dependencies
+type Status struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []CheckResult `json:"checks"`
+}
+
+// RunChecks runs the checks of the dependencies of a component
+func RunChecks(checks []Check) Status {
+	status := Status{Healthy: true, Checks: make([]CheckResult, 0)}
+	for _, check := range checks {
+		result := CheckResult{Name: check.Name, Healthy: true}
+		if err := check.Check(); err != nil {
+			result.Healthy = false
+			result.Message = err.Error()
+			status.Healthy = false
+		}
+		status.Checks = append(status.Checks, result)
+	}
+	return status
+}
+
 // ServeHealthCheck serves the health check endpoint.
 // ServeHealthCheck relies on the provided function to return an error if unhealthy and nil otherwise.
-func ServeHealthCheck(mux *http.ServeMux, f func() error) {
+// Requests with the full=true query parameter run the checks of the dependencies instead, and get their results as JSON,
+// so that liveness probes do not restart a component because a dependency is unavailable.
+func ServeHealthCheck(mux *http.ServeMux, f func() error, dependencies ...Check) {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("full") == "true" {
+			status := RunChecks(dependencies)
+			w.Header().Set("Content-Type", "application/json")
+			if !status.Healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			_ = json.NewEncoder(w).Encode(status)
+			return
+		}
 		if err := f(); err != nil {
 			w.WriteHeader(http.StatusServiceUnavailable)
 			log.Errorln(w, err)
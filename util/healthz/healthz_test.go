@@ -1,10 +1,14 @@
 package healthz
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -57,3 +61,32 @@ func TestHealthCheck(t *testing.T) {
 	}
 
 }
+
+func TestFullHealthCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	redisErr := fmt.Errorf("connection refused")
+	ServeHealthCheck(mux, func() error { return nil },
+		Check{Name: CheckKubernetes, Check: func() error { return nil }},
+		Check{Name: CheckRedis, Check: func() error { return redisErr }},
+	)
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", "/healthz?full=true", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var status Status
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, Status{Checks: []CheckResult{
+		{Name: CheckKubernetes, Healthy: true},
+		{Name: CheckRedis, Message: "connection refused"},
+	}}, status)
+
+	// the dependencies are not checked without full=true
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	redisErr = nil
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", "/healthz?full=true", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
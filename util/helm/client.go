@@ -17,15 +17,19 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver"
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
 	"github.com/argoproj/argo-cd/util"
 	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/fips"
+	logutils "github.com/argoproj/argo-cd/util/log"
+	"github.com/argoproj/argo-cd/util/oci"
 )
 
 var (
 	globalLock = util.NewKeyLock()
+	logger     = logutils.Module(logutils.ModuleHelm)
 )
 
 type Creds struct {
@@ -34,6 +38,15 @@ type Creds struct {
 	CAPath   string
 	CertData []byte
 	KeyData  []byte
+	// CloudIdentity authenticates to the ECR, Artifact Registry or Azure Container Registry registry of an OCI
+	// repository with the identity of the pod instead of the username and password
+	CloudIdentity bool
+}
+
+// IsOCIURL returns true if the URL is the URL of an OCI registry of charts, e.g. oci://registry.example.com/charts,
+// rather than of an HTTP chart repository
+func IsOCIURL(repoURL string) bool {
+	return oci.IsOCIURL(repoURL)
 }
 
 type Client interface {
@@ -121,7 +134,11 @@ func (c *nativeHelmChart) ExtractChart(chart string, version *semver.Version) (s
 			return "", nil, err
 		}
 		defer func() { _ = os.RemoveAll(tempDest) }()
-		_, err = helmCmd.Fetch(c.repoURL, chart, version.String(), tempDest, c.creds)
+		if IsOCIURL(c.repoURL) {
+			err = c.pullOCI(helmCmd, chart, version.String(), tempDest)
+		} else {
+			_, err = helmCmd.Fetch(c.repoURL, chart, version.String(), tempDest, c.creds)
+		}
 		if err != nil {
 			return "", nil, err
 		}
@@ -156,7 +173,36 @@ func (c *nativeHelmChart) ExtractChart(chart string, version *semver.Version) (s
 	}), nil
 }
 
+// pullOCI pulls a chart of an OCI registry, after logging in to the registry if the repository has credentials
+func (c *nativeHelmChart) pullOCI(helmCmd *Cmd, chart string, version string, destination string) error {
+	if err := helmCmd.LoginOCIRegistry(c.repoURL, c.creds); err != nil {
+		return err
+	}
+	_, err := helmCmd.PullOCI(c.repoURL, chart, version, destination, c.creds)
+	return err
+}
+
+// TestOCIRepository tests the credentials of an OCI registry of charts by logging in to it
+func TestOCIRepository(repoURL string, creds Creds) error {
+	tempDir, err := ioutil.TempDir("", "helm")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	helmCmd, err := NewCmdWithVersion(tempDir, HelmV3)
+	if err != nil {
+		return err
+	}
+	defer helmCmd.Close()
+	return helmCmd.LoginOCIRegistry(repoURL, creds)
+}
+
+// GetIndex returns the index of the charts of an HTTP chart repository. OCI registries have no index, so the versions
+// of their charts must be exact versions rather than constraints.
 func (c *nativeHelmChart) GetIndex() (*Index, error) {
+	if IsOCIURL(c.repoURL) {
+		return nil, fmt.Errorf("OCI registry %s has no index of its charts, chart versions must be exact versions", c.repoURL)
+	}
 	start := time.Now()
 
 	data, err := c.loadRepoIndex()
@@ -170,7 +216,7 @@ func (c *nativeHelmChart) GetIndex() (*Index, error) {
 		return nil, err
 	}
 
-	log.WithFields(log.Fields{"seconds": time.Since(start).Seconds()}).Info("took to get index")
+	logger.WithFields(logrus.Fields{"seconds": time.Since(start).Seconds()}).Info("took to get index")
 
 	return index, nil
 }
@@ -214,6 +260,7 @@ func (c *nativeHelmChart) loadRepoIndex() ([]byte, error) {
 
 func newTLSConfig(creds Creds) (*tls.Config, error) {
 	tlsConfig := &tls.Config{InsecureSkipVerify: false}
+	fips.ConfigureTLS(tlsConfig)
 
 	if creds.CAPath != "" {
 		caData, err := ioutil.ReadFile(creds.CAPath)
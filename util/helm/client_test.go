@@ -16,6 +16,11 @@ func TestIndex(t *testing.T) {
 		_, err := client.GetIndex()
 		assert.Error(t, err)
 	})
+	t.Run("OCI", func(t *testing.T) {
+		client := NewClient("oci://registry.example.com/charts", Creds{})
+		_, err := client.GetIndex()
+		assert.EqualError(t, err, "OCI registry oci://registry.example.com/charts has no index of its charts, chart versions must be exact versions")
+	})
 	t.Run("Stable", func(t *testing.T) {
 		client := NewClient("https://argoproj.github.io/argo-helm", Creds{})
 		index, err := client.GetIndex()
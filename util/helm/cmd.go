@@ -7,9 +7,11 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 
 	"github.com/argoproj/argo-cd/util"
 	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/oci"
 )
 
 // A thin wrapper around the "helm" command, adding logging and error translation.
@@ -40,15 +42,23 @@ var redactor = func(text string) string {
 	return regexp.MustCompile("(--username|--password) [^ ]*").ReplaceAllString(text, "$1 ******")
 }
 
-func (c Cmd) run(args ...string) (string, error) {
-	cmd := exec.Command(c.binaryName, args...)
-	cmd.Dir = c.WorkDir
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env,
+// Environ returns the environment variables which point helm to the home of the command, e.g. to use the repositories
+// added by the command in other tools running helm
+func (c Cmd) Environ() []string {
+	return []string{
 		fmt.Sprintf("XDG_CACHE_HOME=%s/cache", c.helmHome),
 		fmt.Sprintf("XDG_CONFIG_HOME=%s/config", c.helmHome),
 		fmt.Sprintf("XDG_DATA_HOME=%s/data", c.helmHome),
-		fmt.Sprintf("HELM_HOME=%s", c.helmHome))
+		fmt.Sprintf("HELM_HOME=%s", c.helmHome),
+		// OCI registries are experimental in Helm before 3.8
+		"HELM_EXPERIMENTAL_OCI=1",
+	}
+}
+
+func (c Cmd) run(args ...string) (string, error) {
+	cmd := exec.Command(c.binaryName, args...)
+	cmd.Dir = c.WorkDir
+	cmd.Env = append(os.Environ(), c.Environ()...)
 	return executil.RunWithRedactor(cmd, redactor)
 }
 
@@ -136,28 +146,91 @@ func (c *Cmd) Fetch(repo, chartName, version, destination string, creds Creds) (
 	if creds.Password != "" {
 		args = append(args, "--password", creds.Password)
 	}
+	tlsArgs, closer, err := tlsArgs(creds)
+	if err != nil {
+		return "", err
+	}
+	defer util.Close(closer)
+	args = append(args, tlsArgs...)
+
+	args = append(args, "--repo", repo, chartName)
+	return c.run(args...)
+}
+
+// LoginOCIRegistry logs in to the registry of an OCI URL, so that the charts pulled by the command are pulled with the
+// credentials, or with the token of the registry for the identity of the pod. The login is stored in the home of the
+// command. Charts are pulled anonymously from registries without credentials.
+func (c *Cmd) LoginOCIRegistry(repoURL string, creds Creds) error {
+	host := strings.SplitN(strings.TrimPrefix(repoURL, oci.URLPrefix), "/", 2)[0]
+	if creds.CloudIdentity {
+		var err error
+		creds.Username, creds.Password, err = oci.GetCloudIdentityCreds(host)
+		if err != nil {
+			return err
+		}
+	}
+	if creds.Username == "" && creds.Password == "" {
+		return nil
+	}
+	_, err := c.registryLogin(host, creds)
+	return err
+}
+
+func (c *Cmd) registryLogin(host string, creds Creds) (string, error) {
+	args := []string{"registry", "login", host, "--username", creds.Username, "--password", creds.Password}
+	tlsArgs, closer, err := tlsArgs(creds)
+	if err != nil {
+		return "", err
+	}
+	defer util.Close(closer)
+	args = append(args, tlsArgs...)
+	return c.run(args...)
+}
+
+// PullOCI pulls a chart of an OCI registry, e.g. of oci://registry.example.com/charts
+func (c *Cmd) PullOCI(repo, chartName, version, destination string, creds Creds) (string, error) {
+	args := []string{"pull", strings.TrimSuffix(repo, "/") + "/" + chartName, "--version", version, "--destination", destination}
+	tlsArgs, closer, err := tlsArgs(creds)
+	if err != nil {
+		return "", err
+	}
+	defer util.Close(closer)
+	args = append(args, tlsArgs...)
+	return c.run(args...)
+}
+
+// tlsArgs returns the arguments of the CA and of the client certificate of the credentials. The files of the client
+// certificate are deleted by the closer.
+func tlsArgs(creds Creds) ([]string, io.Closer, error) {
+	var args []string
+	var closers []io.Closer
+	closer := util.NewCloser(func() error {
+		for _, closer := range closers {
+			util.Close(closer)
+		}
+		return nil
+	})
 	if creds.CAPath != "" {
 		args = append(args, "--ca-file", creds.CAPath)
 	}
 	if len(creds.CertData) > 0 {
-		filePath, closer, err := writeToTmp(creds.CertData)
+		filePath, certCloser, err := writeToTmp(creds.CertData)
 		if err != nil {
-			return "", err
+			return nil, nil, err
 		}
-		defer util.Close(closer)
+		closers = append(closers, certCloser)
 		args = append(args, "--cert-file", filePath)
 	}
 	if len(creds.KeyData) > 0 {
-		filePath, closer, err := writeToTmp(creds.KeyData)
+		filePath, keyCloser, err := writeToTmp(creds.KeyData)
 		if err != nil {
-			return "", err
+			util.Close(closer)
+			return nil, nil, err
 		}
-		defer util.Close(closer)
+		closers = append(closers, keyCloser)
 		args = append(args, "--key-file", filePath)
 	}
-
-	args = append(args, "--repo", repo, chartName)
-	return c.run(args...)
+	return args, closer, nil
 }
 
 func (c *Cmd) dependencyBuild() (string, error) {
@@ -1,6 +1,7 @@
 package helm
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +22,25 @@ func TestCmd_template_kubeVersion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, s)
 }
+
+func TestCmd_LoginOCIRegistry_Anonymous(t *testing.T) {
+	cmd, err := NewCmdWithVersion(".", HelmV3)
+	assert.NoError(t, err)
+	defer cmd.Close()
+	// registries without credentials are pulled from anonymously, without running helm
+	assert.NoError(t, cmd.LoginOCIRegistry("oci://registry.example.com/charts", Creds{}))
+}
+
+func Test_tlsArgs(t *testing.T) {
+	args, closer, err := tlsArgs(Creds{CAPath: "/ca.crt", CertData: []byte("cert"), KeyData: []byte("key")})
+	assert.NoError(t, err)
+	if assert.Len(t, args, 6) {
+		assert.Equal(t, []string{"--ca-file", "/ca.crt", "--cert-file"}, args[:3])
+		assert.Equal(t, "--key-file", args[4])
+		_, err = os.Stat(args[3])
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, closer.Close())
+	_, err = os.Stat(args[3])
+	assert.True(t, os.IsNotExist(err))
+}
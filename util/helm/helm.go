@@ -64,7 +64,13 @@ func (h *helm) Template(templateOpts *TemplateOpts) (string, error) {
 
 func (h *helm) DependencyBuild() error {
 	for _, repo := range h.repos {
-		_, err := h.cmd.RepoAdd(repo.Name, repo.Repo, repo.Creds)
+		var err error
+		if IsOCIURL(repo.Repo) {
+			// the dependencies of OCI registries are pulled by their URLs rather than by repository names
+			err = h.cmd.LoginOCIRegistry(repo.Repo, repo.Creds)
+		} else {
+			_, err = h.cmd.RepoAdd(repo.Name, repo.Repo, repo.Creds)
+		}
 
 		if err != nil {
 			return err
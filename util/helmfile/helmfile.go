@@ -0,0 +1,109 @@
+package helmfile
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/helm"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+// helmfile files which identify a helmfile application
+const (
+	File    = "helmfile.yaml"
+	DirName = "helmfile.d"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// credsEnvPrefix returns the prefix of the environment variables of the credentials of a helm repository, e.g.
+// HELM_REPO_MY_CHARTS_ for the repository my-charts
+func credsEnvPrefix(repoName string) string {
+	return "HELM_REPO_" + strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToUpper(repoName), "_"), "_") + "_"
+}
+
+// credsEnviron returns the credentials of the named helm repositories as environment variables, which helmfiles can
+// reference in the credentials of their repositories, e.g. {{ requiredEnv "HELM_REPO_MY_CHARTS_PASSWORD" }}
+func credsEnviron(repos []helm.HelmRepository) []string {
+	var environ []string
+	for _, repo := range repos {
+		if repo.Name == "" || repo.Creds.Username == "" && repo.Creds.Password == "" {
+			continue
+		}
+		prefix := credsEnvPrefix(repo.Name)
+		environ = append(environ, prefix+"USERNAME="+repo.Creds.Username, prefix+"PASSWORD="+repo.Creds.Password)
+	}
+	return environ
+}
+
+// args returns the arguments of helmfile template
+func args(opts *v1alpha1.ApplicationSourceHelmfile, env *v1alpha1.Env) []string {
+	args := []string{"--no-color", "--quiet"}
+	if opts.Environment != "" {
+		args = append(args, "--environment", opts.Environment)
+	}
+	for _, selector := range opts.Selectors {
+		args = append(args, "--selector", selector)
+	}
+	for _, file := range opts.StateValueFiles {
+		args = append(args, "--state-values-file", file)
+	}
+	for _, value := range opts.StateValues {
+		args = append(args, "--state-values-set", env.Envsubst(value))
+	}
+	return append(args, "template")
+}
+
+// Template renders the releases of the helmfile of an application path with helmfile template. The helm repositories
+// of Argo CD are added to the isolated helm home helmfile runs helm with, so releases can reference charts of private
+// repositories by the names of the repositories, and their credentials are passed as environment variables too.
+func Template(appPath string, opts *v1alpha1.ApplicationSourceHelmfile, repos []helm.HelmRepository, env *v1alpha1.Env) ([]*unstructured.Unstructured, error) {
+	if opts == nil {
+		opts = &v1alpha1.ApplicationSourceHelmfile{}
+	}
+	helmCmd, err := helm.NewCmdWithVersion(appPath, helm.HelmV3)
+	if err != nil {
+		return nil, err
+	}
+	defer helmCmd.Close()
+	for _, repo := range repos {
+		if helm.IsOCIURL(repo.Repo) {
+			if err := helmCmd.LoginOCIRegistry(repo.Repo, repo.Creds); err != nil {
+				return nil, fmt.Errorf("failed to log in to helm OCI registry %s: %v", repo.Repo, err)
+			}
+			continue
+		}
+		if repo.Name == "" {
+			continue
+		}
+		if _, err := helmCmd.RepoAdd(repo.Name, repo.Repo, repo.Creds); err != nil {
+			return nil, fmt.Errorf("failed to add helm repository %s: %v", repo.Name, err)
+		}
+	}
+
+	cmd := exec.Command("helmfile", args(opts, env)...)
+	cmd.Dir = appPath
+	cmd.Env = append(os.Environ(), env.Environ()...)
+	cmd.Env = append(cmd.Env, helmCmd.Environ()...)
+	credsEnv := credsEnviron(repos)
+	cmd.Env = append(cmd.Env, credsEnv...)
+	out, err := executil.RunWithRedactor(cmd, func(text string) string {
+		for _, entry := range credsEnv {
+			if value := entry[strings.Index(entry, "=")+1:]; value != "" {
+				text = strings.ReplaceAll(text, value, "******")
+			}
+		}
+		return text
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kube.SplitYAML(out)
+}
@@ -0,0 +1,36 @@
+package helmfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/helm"
+)
+
+func TestArgs(t *testing.T) {
+	env := &v1alpha1.Env{&v1alpha1.EnvEntry{Name: "ARGOCD_APP_NAME", Value: "guestbook"}}
+	assert.Equal(t, []string{"--no-color", "--quiet", "template"}, args(&v1alpha1.ApplicationSourceHelmfile{}, env))
+	assert.Equal(t, []string{
+		"--no-color", "--quiet",
+		"--environment", "prod",
+		"--selector", "tier=frontend",
+		"--state-values-file", "values/prod.yaml",
+		"--state-values-set", "app=guestbook",
+		"template",
+	}, args(&v1alpha1.ApplicationSourceHelmfile{
+		Environment:     "prod",
+		Selectors:       []string{"tier=frontend"},
+		StateValueFiles: []string{"values/prod.yaml"},
+		StateValues:     []string{"app=$ARGOCD_APP_NAME"},
+	}, env))
+}
+
+func TestCredsEnviron(t *testing.T) {
+	assert.Equal(t, []string{"HELM_REPO_MY_CHARTS_USERNAME=admin", "HELM_REPO_MY_CHARTS_PASSWORD=secret"}, credsEnviron([]helm.HelmRepository{
+		{Name: "my-charts", Repo: "https://charts.example.com", Creds: helm.Creds{Username: "admin", Password: "secret"}},
+		{Name: "public", Repo: "https://kubernetes-charts.storage.googleapis.com"},
+		{Repo: "https://unnamed.example.com", Creds: helm.Creds{Username: "admin", Password: "secret"}},
+	}))
+}
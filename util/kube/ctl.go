@@ -10,7 +10,8 @@ import (
 	"regexp"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,15 +30,18 @@ import (
 	"github.com/argoproj/argo-cd/util"
 	"github.com/argoproj/argo-cd/util/diff"
 	executil "github.com/argoproj/argo-cd/util/exec"
+	logutils "github.com/argoproj/argo-cd/util/log"
 	"github.com/argoproj/argo-cd/util/tracing"
 )
 
+var logger = logutils.Module(logutils.ModuleKube)
+
 type Kubectl interface {
 	ApplyResource(config *rest.Config, obj *unstructured.Unstructured, namespace string, dryRun, force, validate bool) (string, error)
 	ConvertToVersion(obj *unstructured.Unstructured, group, version string) (*unstructured.Unstructured, error)
 	DeleteResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, forceDelete bool) error
 	GetResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string) (*unstructured.Unstructured, error)
-	PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte) (*unstructured.Unstructured, error)
+	PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte, subresources ...string) (*unstructured.Unstructured, error)
 	GetAPIResources(config *rest.Config, resourceFilter ResourceFilter) ([]APIResourceInfo, error)
 	GetAPIGroups(config *rest.Config) ([]metav1.APIGroup, error)
 	GetServerVersion(config *rest.Config) (string, error)
@@ -68,7 +72,7 @@ func filterAPIResources(config *rest.Config, resourceFilter ResourceFilter, filt
 		if len(serverResources) == 0 {
 			return nil, err
 		}
-		log.Warnf("Partial success when performing preferred resource discovery: %v", err)
+		logger.Warnf("Partial success when performing preferred resource discovery: %v", err)
 	}
 	apiResIfs := make([]APIResourceInfo, 0)
 	for _, apiResourcesList := range serverResources {
@@ -157,8 +161,8 @@ func (k *KubectlCmd) GetResource(config *rest.Config, gvk schema.GroupVersionKin
 	return resourceIf.Get(name, metav1.GetOptions{})
 }
 
-// PatchResource patches resource
-func (k *KubectlCmd) PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte) (*unstructured.Unstructured, error) {
+// PatchResource patches resource, or its subresources if any are specified
+func (k *KubectlCmd) PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte, subresources ...string) (*unstructured.Unstructured, error) {
 	span := tracing.StartSpan("PatchResource")
 	span.SetBaggageItem("kind", gvk.Kind)
 	span.SetBaggageItem("name", name)
@@ -177,7 +181,17 @@ func (k *KubectlCmd) PatchResource(config *rest.Config, gvk schema.GroupVersionK
 	}
 	resource := gvk.GroupVersion().WithResource(apiResource.Name)
 	resourceIf := ToResourceInterface(dynamicIf, apiResource, resource, namespace)
-	return resourceIf.Patch(name, patchType, patchBytes, metav1.PatchOptions{})
+	return resourceIf.Patch(name, patchType, patchBytes, metav1.PatchOptions{}, subresources...)
+}
+
+// PatchStatus applies a merge patch to the status subresource of a resource, or to the resource itself if its status is
+// not a subresource, e.g. of custom resources whose definitions don't enable the status subresource
+func PatchStatus(k Kubectl, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchBytes []byte) error {
+	_, err := k.PatchResource(config, gvk, name, namespace, types.MergePatchType, patchBytes, "status")
+	if apierr.IsNotFound(err) {
+		_, err = k.PatchResource(config, gvk, name, namespace, types.MergePatchType, patchBytes)
+	}
+	return err
 }
 
 // DeleteResource deletes resource
@@ -217,7 +231,7 @@ func (k *KubectlCmd) ApplyResource(config *rest.Config, obj *unstructured.Unstru
 	span.SetBaggageItem("kind", obj.GetKind())
 	span.SetBaggageItem("name", obj.GetName())
 	defer span.Finish()
-	log.Infof("Applying resource %s/%s in cluster: %s, namespace: %s", obj.GetKind(), obj.GetName(), config.Host, namespace)
+	logger.Infof("Applying resource %s/%s in cluster: %s, namespace: %s", obj.GetKind(), obj.GetName(), config.Host, namespace)
 	f, err := ioutil.TempFile(util.TempDir, "")
 	if err != nil {
 		return "", fmt.Errorf("Failed to generate temp file for kubeconfig: %v", err)
@@ -245,7 +259,7 @@ func (k *KubectlCmd) ApplyResource(config *rest.Config, obj *unstructured.Unstru
 	defer util.DeleteFile(manifestFile.Name())
 
 	// log manifest
-	if log.IsLevelEnabled(log.DebugLevel) {
+	if logger.IsLevelEnabled(logrus.DebugLevel) {
 		var obj unstructured.Unstructured
 		err := json.Unmarshal(manifestBytes, &obj)
 		if err != nil {
@@ -259,7 +273,7 @@ func (k *KubectlCmd) ApplyResource(config *rest.Config, obj *unstructured.Unstru
 		if err != nil {
 			return "", err
 		}
-		log.Debug(string(redactedBytes))
+		logger.Debug(string(redactedBytes))
 	}
 
 	var out []string
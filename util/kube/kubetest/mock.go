@@ -18,6 +18,13 @@ type KubectlOutput struct {
 	Err    error
 }
 
+// Patch is a patch of a resource
+type Patch struct {
+	Name         string
+	Patch        string
+	Subresources []string
+}
+
 type MockKubectlCmd struct {
 	APIResources  []kube.APIResourceInfo
 	Commands      map[string]KubectlOutput
@@ -25,6 +32,8 @@ type MockKubectlCmd struct {
 	LastValidate  bool
 	Version       string
 	DynamicClient dynamic.Interface
+	// Patches records the patches of PatchResource
+	Patches []Patch
 }
 
 func (k *MockKubectlCmd) NewDynamicClient(config *rest.Config) (dynamic.Interface, error) {
@@ -39,7 +48,8 @@ func (k *MockKubectlCmd) GetResource(config *rest.Config, gvk schema.GroupVersio
 	return nil, nil
 }
 
-func (k *MockKubectlCmd) PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte) (*unstructured.Unstructured, error) {
+func (k *MockKubectlCmd) PatchResource(config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte, subresources ...string) (*unstructured.Unstructured, error) {
+	k.Patches = append(k.Patches, Patch{Name: name, Patch: string(patchBytes), Subresources: subresources})
 	return nil, nil
 }
 
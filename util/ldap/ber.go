@@ -0,0 +1,213 @@
+package ldap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// the tags of the BER encoded elements of LDAP messages (RFC 4511)
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	tagBindRequest           = 0x60
+	tagBindResponse          = 0x61
+	tagUnbindRequest         = 0x42
+	tagSearchRequest         = 0x63
+	tagSearchResultEntry     = 0x64
+	tagSearchResultDone      = 0x65
+	tagSearchResultReference = 0x73
+	tagExtendedRequest       = 0x77
+	tagExtendedResponse      = 0x78
+	tagExtendedRequestName   = 0x80
+	tagControls              = 0xa0
+	tagSimpleAuthentication  = 0x80
+
+	classContextConstructed = 0xa0
+	classContextPrimitive   = 0x80
+	constructedBit          = 0x20
+
+	// maxElementLength bounds the length of the elements read from a server
+	maxElementLength = 64 * 1024 * 1024
+)
+
+// element is a BER encoded element, with either a value or children depending on whether it is constructed
+type element struct {
+	tag      byte
+	value    []byte
+	children []*element
+}
+
+func newPrimitive(tag byte, value []byte) *element {
+	return &element{tag: tag, value: value}
+}
+
+func newConstructed(tag byte, children ...*element) *element {
+	return &element{tag: tag, children: children}
+}
+
+func newOctetString(value string) *element {
+	return newPrimitive(tagOctetString, []byte(value))
+}
+
+func newInteger(tag byte, value int64) *element {
+	// two's complement, big endian, minimal length
+	var b []byte
+	for {
+		b = append([]byte{byte(value)}, b...)
+		if (value < 128 && value >= -128) || len(b) == 8 {
+			break
+		}
+		value >>= 8
+	}
+	return newPrimitive(tag, b)
+}
+
+func newBoolean(value bool) *element {
+	if value {
+		return newPrimitive(tagBoolean, []byte{0xff})
+	}
+	return newPrimitive(tagBoolean, []byte{0})
+}
+
+func (e *element) constructed() bool {
+	return e.tag&constructedBit != 0
+}
+
+func (e *element) bytes() []byte {
+	content := e.value
+	if e.constructed() {
+		content = nil
+		for _, child := range e.children {
+			content = append(content, child.bytes()...)
+		}
+	}
+	return append(append([]byte{e.tag}, encodeLength(len(content))...), content...)
+}
+
+func encodeLength(length int) []byte {
+	if length < 128 {
+		return []byte{byte(length)}
+	}
+	var b []byte
+	for l := length; l > 0; l >>= 8 {
+		b = append([]byte{byte(l)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+func (e *element) str() string {
+	return string(e.value)
+}
+
+func (e *element) int() int64 {
+	var value int64
+	for i, b := range e.value {
+		if i == 0 && b&0x80 != 0 {
+			value = -1
+		}
+		value = value<<8 | int64(b)
+	}
+	return value
+}
+
+// child returns the child at an index, or an error if the element has fewer children
+func (e *element) child(i int) (*element, error) {
+	if i >= len(e.children) {
+		return nil, fmt.Errorf("malformed LDAP message: element 0x%02x has %d children, expected more than %d", e.tag, len(e.children), i)
+	}
+	return e.children[i], nil
+}
+
+// readElement reads an element from a reader
+func readElement(r *bufio.Reader) (*element, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	length, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+	return decodeElement(tag, content)
+}
+
+func readLength(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b < 0x80 {
+		return int(b), nil
+	}
+	n := int(b & 0x7f)
+	if n == 0 || n > 4 {
+		return 0, fmt.Errorf("unsupported BER length of %d bytes", n)
+	}
+	length := 0
+	for i := 0; i < n; i++ {
+		if b, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		length = length<<8 | int(b)
+	}
+	if length > maxElementLength {
+		return 0, fmt.Errorf("BER element of %d bytes exceeds the maximum length", length)
+	}
+	return length, nil
+}
+
+// decodeElement decodes the content of an element, and of its children if it is constructed
+func decodeElement(tag byte, content []byte) (*element, error) {
+	e := &element{tag: tag}
+	if !e.constructed() {
+		e.value = content
+		return e, nil
+	}
+	for len(content) > 0 {
+		if len(content) < 2 {
+			return nil, fmt.Errorf("truncated BER element")
+		}
+		childTag := content[0]
+		length, n, err := decodeLength(content[1:])
+		if err != nil {
+			return nil, err
+		}
+		start := 1 + n
+		if start+length > len(content) {
+			return nil, fmt.Errorf("truncated BER element")
+		}
+		child, err := decodeElement(childTag, content[start:start+length])
+		if err != nil {
+			return nil, err
+		}
+		e.children = append(e.children, child)
+		content = content[start+length:]
+	}
+	return e, nil
+}
+
+// decodeLength returns the length encoded at the start of b, and the number of bytes encoding it
+func decodeLength(b []byte) (int, int, error) {
+	if b[0] < 0x80 {
+		return int(b[0]), 1, nil
+	}
+	n := int(b[0] & 0x7f)
+	if n == 0 || n > 4 || len(b) < 1+n {
+		return 0, 0, fmt.Errorf("unsupported BER length")
+	}
+	length := 0
+	for _, c := range b[1 : 1+n] {
+		length = length<<8 | int(c)
+	}
+	return length, 1 + n, nil
+}
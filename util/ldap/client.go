@@ -0,0 +1,352 @@
+// Package ldap is a minimal LDAP v3 client (RFC 4511), which binds with a password and searches the entries of a
+// directory with the simple paged results control (RFC 2696). Passwords are only sent over TLS, i.e. to ldaps:// servers
+// or after StartTLS (RFC 4513).
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// pagedResultsControlType is the OID of the simple paged results control
+	pagedResultsControlType = "1.2.840.113556.1.4.319"
+	// pageSize is the number of entries requested per page of search results
+	pageSize = 500
+	// startTLSRequestName is the OID of the StartTLS extended operation
+	startTLSRequestName = "1.3.6.1.4.1.1466.20037"
+
+	scopeWholeSubtree = 2
+	derefNever        = 0
+
+	resultSuccess = 0
+)
+
+// Entry is an entry of the directory returned by a search
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// GetAttributeValues returns the values of an attribute of the entry. Attribute names are case insensitive.
+func (e *Entry) GetAttributeValues(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Error is an unsuccessful result of an LDAP operation
+type Error struct {
+	ResultCode int64
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("LDAP result code %d", e.ResultCode)
+	}
+	return fmt.Sprintf("LDAP result code %d: %s", e.ResultCode, e.Message)
+}
+
+// Conn is a connection to an LDAP server. It sends one request at a time, and is not safe for concurrent use.
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int64
+	timeout   time.Duration
+	// serverName is the host name the TLS certificate of the server is verified against after StartTLS
+	serverName string
+	// secure is true if the connection is encrypted with TLS
+	secure bool
+}
+
+// Dial connects to the server of an ldap:// or ldaps:// URL. The port defaults to 389 and 636 respectively.
+func Dial(serverURL string, tlsConfig *tls.Config, timeout time.Duration) (*Conn, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "ldap":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "389")
+		}
+		conn, err = dialer.Dial("tcp", host)
+	case "ldaps":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		if tlsConfig.ServerName == "" && !tlsConfig.InsecureSkipVerify {
+			tlsConfig = tlsConfig.Clone()
+			tlsConfig.ServerName = u.Hostname()
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported LDAP URL scheme '%s', expected ldap or ldaps", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := NewConn(conn, timeout)
+	res.serverName = u.Hostname()
+	return res, nil
+}
+
+// NewConn returns an LDAP connection over a network connection. Each operation must complete within the timeout,
+// unless it is 0.
+func NewConn(conn net.Conn, timeout time.Duration) *Conn {
+	_, secure := conn.(*tls.Conn)
+	return &Conn{conn: conn, reader: bufio.NewReader(conn), timeout: timeout, secure: secure}
+}
+
+// StartTLS encrypts the connection with TLS (RFC 4511 section 4.14). The certificate of the server is verified against
+// the host name of the URL the connection was dialed to, unless the TLS configuration names a server or skips the
+// verification.
+func (c *Conn) StartTLS(tlsConfig *tls.Config) error {
+	if c.secure {
+		return fmt.Errorf("the connection is already encrypted with TLS")
+	}
+	id, err := c.sendRequest(newConstructed(tagExtendedRequest, newPrimitive(tagExtendedRequestName, []byte(startTLSRequestName))), nil)
+	if err != nil {
+		return err
+	}
+	op, _, err := c.readResponse(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != tagExtendedResponse {
+		return fmt.Errorf("unexpected response 0x%02x to a StartTLS request", op.tag)
+	}
+	if err := resultError(op); err != nil {
+		return err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" && !tlsConfig.InsecureSkipVerify {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = c.serverName
+	}
+	conn := tls.Client(c.conn, tlsConfig)
+	if c.timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+	c.conn, c.reader, c.secure = conn, bufio.NewReader(conn), true
+	return nil
+}
+
+// Close unbinds and closes the connection
+func (c *Conn) Close() error {
+	_, _ = c.sendRequest(newPrimitive(tagUnbindRequest, nil), nil)
+	return c.conn.Close()
+}
+
+// Bind authenticates with the password of a DN. The password is only sent over an encrypted connection.
+func (c *Conn) Bind(dn, password string) error {
+	if !c.secure {
+		return fmt.Errorf("refusing to send the bind password over an unencrypted connection, use an ldaps:// URL or StartTLS")
+	}
+	id, err := c.sendRequest(newConstructed(tagBindRequest,
+		newInteger(tagInteger, 3),
+		newOctetString(dn),
+		newPrimitive(tagSimpleAuthentication, []byte(password)),
+	), nil)
+	if err != nil {
+		return err
+	}
+	op, _, err := c.readResponse(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != tagBindResponse {
+		return fmt.Errorf("unexpected response 0x%02x to a bind request", op.tag)
+	}
+	return resultError(op)
+}
+
+// Search returns the entries of the subtree of a base DN which match a filter (RFC 4515), with the given attributes
+func (c *Conn) Search(baseDN, filter string, attributes []string) ([]Entry, error) {
+	compiledFilter, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	var cookie []byte
+	for {
+		attrs := newConstructed(tagSequence)
+		for _, attr := range attributes {
+			attrs.children = append(attrs.children, newOctetString(attr))
+		}
+		id, err := c.sendRequest(newConstructed(tagSearchRequest,
+			newOctetString(baseDN),
+			newInteger(tagEnumerated, scopeWholeSubtree),
+			newInteger(tagEnumerated, derefNever),
+			newInteger(tagInteger, 0),
+			newInteger(tagInteger, 0),
+			newBoolean(false),
+			compiledFilter,
+			attrs,
+		), newPagedResultsControl(cookie))
+		if err != nil {
+			return nil, err
+		}
+		for {
+			op, controls, err := c.readResponse(id)
+			if err != nil {
+				return nil, err
+			}
+			switch op.tag {
+			case tagSearchResultEntry:
+				entry, err := parseEntry(op)
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, entry)
+				continue
+			case tagSearchResultReference:
+				// referrals to other servers are not followed
+				continue
+			case tagSearchResultDone:
+				if err := resultError(op); err != nil {
+					return nil, err
+				}
+				cookie = pagedResultsCookie(controls)
+			default:
+				return nil, fmt.Errorf("unexpected response 0x%02x to a search request", op.tag)
+			}
+			break
+		}
+		if len(cookie) == 0 {
+			return entries, nil
+		}
+	}
+}
+
+func newPagedResultsControl(cookie []byte) *element {
+	value := newConstructed(tagSequence, newInteger(tagInteger, pageSize), newPrimitive(tagOctetString, cookie))
+	return newConstructed(tagSequence, newOctetString(pagedResultsControlType), newBoolean(false), newPrimitive(tagOctetString, value.bytes()))
+}
+
+// pagedResultsCookie returns the cookie of the next page of search results, nil if it is the last page or if the
+// server does not page the results
+func pagedResultsCookie(controls *element) []byte {
+	if controls == nil {
+		return nil
+	}
+	for _, control := range controls.children {
+		if len(control.children) < 2 || control.children[0].str() != pagedResultsControlType {
+			continue
+		}
+		encoded := control.children[len(control.children)-1]
+		value, err := decodeElement(tagSequence, unwrapSequence(encoded.value))
+		if err != nil || len(value.children) < 2 {
+			return nil
+		}
+		return value.children[1].value
+	}
+	return nil
+}
+
+// unwrapSequence returns the content of a BER encoded sequence
+func unwrapSequence(b []byte) []byte {
+	if len(b) < 2 || b[0] != tagSequence {
+		return nil
+	}
+	length, n, err := decodeLength(b[1:])
+	if err != nil || 1+n+length > len(b) {
+		return nil
+	}
+	return b[1+n : 1+n+length]
+}
+
+func parseEntry(op *element) (Entry, error) {
+	dn, err := op.child(0)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{DN: dn.str(), Attributes: make(map[string][]string)}
+	attrs, err := op.child(1)
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, attr := range attrs.children {
+		name, err := attr.child(0)
+		if err != nil {
+			return Entry{}, err
+		}
+		values, err := attr.child(1)
+		if err != nil {
+			return Entry{}, err
+		}
+		for _, value := range values.children {
+			entry.Attributes[name.str()] = append(entry.Attributes[name.str()], value.str())
+		}
+	}
+	return entry, nil
+}
+
+// resultError returns the error of an unsuccessful LDAP result
+func resultError(op *element) error {
+	code, err := op.child(0)
+	if err != nil {
+		return err
+	}
+	if code.int() == resultSuccess {
+		return nil
+	}
+	res := &Error{ResultCode: code.int()}
+	if message, err := op.child(2); err == nil {
+		res.Message = message.str()
+	}
+	return res
+}
+
+// sendRequest sends an operation with an optional control, and returns the ID of its message
+func (c *Conn) sendRequest(op *element, control *element) (int64, error) {
+	c.messageID++
+	msg := newConstructed(tagSequence, newInteger(tagInteger, c.messageID), op)
+	if control != nil {
+		msg.children = append(msg.children, newConstructed(tagControls, control))
+	}
+	if c.timeout > 0 {
+		_ = c.conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+	_, err := c.conn.Write(msg.bytes())
+	return c.messageID, err
+}
+
+// readResponse reads the next response to a request, and returns its operation and controls
+func (c *Conn) readResponse(id int64) (*element, *element, error) {
+	msg, err := readElement(c.reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if msg.tag != tagSequence || len(msg.children) < 2 {
+		return nil, nil, fmt.Errorf("malformed LDAP message")
+	}
+	if msgID := msg.children[0].int(); msgID != id {
+		return nil, nil, fmt.Errorf("unexpected response to LDAP message %d, expected %d", msgID, id)
+	}
+	var controls *element
+	if len(msg.children) > 2 && msg.children[2].tag == tagControls {
+		controls = msg.children[2]
+	}
+	return msg.children[1], controls, nil
+}
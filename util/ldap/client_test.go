@@ -0,0 +1,227 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	certutil "github.com/argoproj/argo-cd/util/tls"
+)
+
+// fakeServer serves the requests of a connection with a handler, which returns the operations responding to a request
+// and the controls of the last one
+func fakeServer(t *testing.T, handler func(op *element, controls *element) ([]*element, *element)) *Conn {
+	client, server := net.Pipe()
+	go func() {
+		defer func() { _ = server.Close() }()
+		reader := bufio.NewReader(server)
+		for {
+			msg, err := readElement(reader)
+			if err != nil {
+				return
+			}
+			var controls *element
+			if len(msg.children) > 2 {
+				controls = msg.children[2]
+			}
+			ops, resControl := handler(msg.children[1], controls)
+			for i, op := range ops {
+				res := newConstructed(tagSequence, newInteger(tagInteger, msg.children[0].int()), op)
+				if i == len(ops)-1 && resControl != nil {
+					res.children = append(res.children, newConstructed(tagControls, resControl))
+				}
+				if _, err := server.Write(res.bytes()); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return NewConn(client, 5*time.Second)
+}
+
+func newResult(tag byte, code int64, message string) *element {
+	return newConstructed(tag, newInteger(tagEnumerated, code), newOctetString(""), newOctetString(message))
+}
+
+func newEntry(dn string, attrs map[string][]string) *element {
+	attributes := newConstructed(tagSequence)
+	for name, values := range attrs {
+		set := newConstructed(tagSet)
+		for _, value := range values {
+			set.children = append(set.children, newOctetString(value))
+		}
+		attributes.children = append(attributes.children, newConstructed(tagSequence, newOctetString(name), set))
+	}
+	return newConstructed(tagSearchResultEntry, newOctetString(dn), attributes)
+}
+
+func TestBind(t *testing.T) {
+	conn := fakeServer(t, func(op *element, _ *element) ([]*element, *element) {
+		if op.tag != tagBindRequest {
+			return nil, nil
+		}
+		if op.children[1].str() == "cn=admin,dc=example,dc=org" && op.children[2].str() == "secret" {
+			return []*element{newResult(tagBindResponse, 0, "")}, nil
+		}
+		return []*element{newResult(tagBindResponse, 49, "invalid credentials")}, nil
+	})
+	defer func() { _ = conn.Close() }()
+	conn.secure = true
+
+	assert.NoError(t, conn.Bind("cn=admin,dc=example,dc=org", "secret"))
+	err := conn.Bind("cn=admin,dc=example,dc=org", "wrong")
+	assert.EqualError(t, err, "LDAP result code 49: invalid credentials")
+	assert.Equal(t, int64(49), err.(*Error).ResultCode)
+}
+
+func TestBind_Unencrypted(t *testing.T) {
+	conn := fakeServer(t, func(op *element, _ *element) ([]*element, *element) {
+		return []*element{newResult(tagBindResponse, 0, "")}, nil
+	})
+	defer func() { _ = conn.Close() }()
+
+	assert.EqualError(t, conn.Bind("cn=admin,dc=example,dc=org", "secret"), "refusing to send the bind password over an unencrypted connection, use an ldaps:// URL or StartTLS")
+}
+
+func TestStartTLS(t *testing.T) {
+	cert, err := certutil.GenerateX509KeyPair(certutil.CertOptions{Hosts: []string{"ldap.example.org"}, Organization: "Argo CD", IsCA: true})
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	client, server := net.Pipe()
+	go func() {
+		defer func() { _ = server.Close() }()
+		msg, err := readElement(bufio.NewReader(server))
+		if err != nil || msg.children[1].tag != tagExtendedRequest || msg.children[1].children[0].str() != startTLSRequestName {
+			return
+		}
+		res := newConstructed(tagSequence, newInteger(tagInteger, msg.children[0].int()), newResult(tagExtendedResponse, 0, ""))
+		if _, err := server.Write(res.bytes()); err != nil {
+			return
+		}
+		tlsServer := tls.Server(server, &tls.Config{Certificates: []tls.Certificate{*cert}})
+		reader := bufio.NewReader(tlsServer)
+		for {
+			msg, err := readElement(reader)
+			if err != nil || msg.children[1].tag != tagBindRequest {
+				return
+			}
+			res := newConstructed(tagSequence, newInteger(tagInteger, msg.children[0].int()), newResult(tagBindResponse, 0, ""))
+			if _, err := tlsServer.Write(res.bytes()); err != nil {
+				return
+			}
+		}
+	}()
+	conn := NewConn(client, 5*time.Second)
+	conn.serverName = "ldap.example.org"
+	defer func() { _ = conn.Close() }()
+
+	assert.NoError(t, conn.StartTLS(&tls.Config{RootCAs: pool}))
+	assert.NoError(t, conn.Bind("cn=admin,dc=example,dc=org", "secret"))
+	assert.EqualError(t, conn.StartTLS(nil), "the connection is already encrypted with TLS")
+}
+
+func TestSearch(t *testing.T) {
+	var filters []*element
+	conn := fakeServer(t, func(op *element, controls *element) ([]*element, *element) {
+		if op.tag != tagSearchRequest {
+			return nil, nil
+		}
+		filters = append(filters, op.children[6])
+		cookie := pagedResultsCookie(controls)
+		if len(cookie) == 0 {
+			// first page
+			return []*element{
+				newEntry("cn=admins,ou=groups,dc=example,dc=org", map[string][]string{"cn": {"admins"}, "member": {"uid=alice,ou=people,dc=example,dc=org", "uid=bob,ou=people,dc=example,dc=org"}}),
+				newPrimitive(tagSearchResultReference, nil),
+				newResult(tagSearchResultDone, 0, ""),
+			}, newPagedResultsControl([]byte("page-2"))
+		}
+		assert.Equal(t, "page-2", string(cookie))
+		return []*element{
+			newEntry("cn=devs,ou=groups,dc=example,dc=org", map[string][]string{"cn": {"devs"}}),
+			newResult(tagSearchResultDone, 0, ""),
+		}, newPagedResultsControl(nil)
+	})
+	defer func() { _ = conn.Close() }()
+
+	entries, err := conn.Search("ou=groups,dc=example,dc=org", "(objectClass=groupOfNames)", []string{"cn", "member"})
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "cn=admins,ou=groups,dc=example,dc=org", entries[0].DN)
+		assert.Equal(t, []string{"uid=alice,ou=people,dc=example,dc=org", "uid=bob,ou=people,dc=example,dc=org"}, entries[0].GetAttributeValues("Member"))
+		assert.Equal(t, []string{"devs"}, entries[1].GetAttributeValues("cn"))
+	}
+	assert.Len(t, filters, 2)
+
+	_, err = conn.Search("ou=groups,dc=example,dc=org", "(objectClass=groupOfNames", nil)
+	assert.Error(t, err)
+}
+
+func TestSearch_Error(t *testing.T) {
+	conn := fakeServer(t, func(op *element, _ *element) ([]*element, *element) {
+		return []*element{newResult(tagSearchResultDone, 32, "no such object")}, nil
+	})
+	defer func() { _ = conn.Close() }()
+
+	_, err := conn.Search("ou=missing,dc=example,dc=org", "(objectClass=*)", nil)
+	assert.EqualError(t, err, "LDAP result code 32: no such object")
+}
+
+func TestCompileFilter(t *testing.T) {
+	e, err := compileFilter("(&(objectClass=groupOfNames)(!(cn=test*))(|(ou=a\\2ab)(owner=*)))")
+	assert.NoError(t, err)
+	assert.Equal(t, byte(classContextConstructed|filterAnd), e.tag)
+	if assert.Len(t, e.children, 3) {
+		equality := e.children[0]
+		assert.Equal(t, byte(classContextConstructed|filterEqualityMatch), equality.tag)
+		assert.Equal(t, "objectClass", equality.children[0].str())
+		assert.Equal(t, "groupOfNames", equality.children[1].str())
+
+		not := e.children[1]
+		assert.Equal(t, byte(classContextConstructed|filterNot), not.tag)
+		substrings := not.children[0]
+		assert.Equal(t, byte(classContextConstructed|filterSubstrings), substrings.tag)
+		assert.Equal(t, byte(classContextPrimitive|substringInitial), substrings.children[1].children[0].tag)
+		assert.Equal(t, "test", substrings.children[1].children[0].str())
+
+		or := e.children[2]
+		assert.Equal(t, byte(classContextConstructed|filterOr), or.tag)
+		assert.Equal(t, "a*b", or.children[0].children[1].str())
+		assert.Equal(t, byte(classContextPrimitive|filterPresent), or.children[1].tag)
+		assert.Equal(t, "owner", or.children[1].str())
+	}
+
+	e, err = compileFilter("uidNumber>=1000")
+	assert.NoError(t, err)
+	assert.Equal(t, byte(classContextConstructed|filterGreaterOrEqual), e.tag)
+	assert.Equal(t, "uidNumber", e.children[0].str())
+
+	for _, filter := range []string{"", "(cn=a", "(&)", "(cn=a)(cn=b)", "(=a)", "(cn:dn:=a)", "(cn=\\zz)"} {
+		_, err := compileFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestBER(t *testing.T) {
+	for _, value := range []int64{0, 1, 127, 128, 255, 256, 65535, -1, -129} {
+		e, err := decodeElement(tagInteger, newInteger(tagInteger, value).value)
+		assert.NoError(t, err)
+		assert.Equal(t, value, e.int())
+	}
+	long := newOctetString(string(make([]byte, 300)))
+	encoded := long.bytes()
+	assert.Equal(t, []byte{tagOctetString, 0x82, 0x01, 0x2c}, encoded[:4])
+	e, err := readElement(bufio.NewReader(bytes.NewReader(encoded)))
+	assert.NoError(t, err)
+	assert.Len(t, e.value, 300)
+}
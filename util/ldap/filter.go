@@ -0,0 +1,178 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// the context specific tags of the filter choices
+const (
+	filterAnd            = 0
+	filterOr             = 1
+	filterNot            = 2
+	filterEqualityMatch  = 3
+	filterSubstrings     = 4
+	filterGreaterOrEqual = 5
+	filterLessOrEqual    = 6
+	filterPresent        = 7
+	filterApproxMatch    = 8
+
+	substringInitial = 0
+	substringAny     = 1
+	substringFinal   = 2
+)
+
+// compileFilter compiles a string filter (RFC 4515), e.g. (&(objectClass=groupOfNames)(cn=argocd-*)). Extensible
+// matches are not supported.
+func compileFilter(filter string) (*element, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, fmt.Errorf("the filter is empty")
+	}
+	if !strings.HasPrefix(filter, "(") {
+		filter = "(" + filter + ")"
+	}
+	e, rest, err := parseFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %s: %v", filter, err)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("invalid filter %s: unexpected %s", filter, rest)
+	}
+	return e, nil
+}
+
+// parseFilter parses the parenthesized filter at the start of s, and returns the rest of s
+func parseFilter(s string) (*element, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("expected ( at %s", s)
+	}
+	s = s[1:]
+	if s == "" {
+		return nil, "", fmt.Errorf("unexpected end of filter")
+	}
+	switch s[0] {
+	case '&', '|':
+		op := s[0]
+		tag := byte(classContextConstructed | filterAnd)
+		if op == '|' {
+			tag = classContextConstructed | filterOr
+		}
+		e := newConstructed(tag)
+		s = s[1:]
+		for strings.HasPrefix(s, "(") {
+			child, rest, err := parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			e.children = append(e.children, child)
+			s = rest
+		}
+		if len(e.children) == 0 {
+			return nil, "", fmt.Errorf("%c requires at least one filter", op)
+		}
+		return closeFilter(e, s)
+	case '!':
+		child, rest, err := parseFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		return closeFilter(newConstructed(classContextConstructed|filterNot, child), rest)
+	}
+	end := strings.IndexByte(s, ')')
+	if end < 0 {
+		return nil, "", fmt.Errorf("expected ) after %s", s)
+	}
+	e, err := parseItem(s[:end])
+	if err != nil {
+		return nil, "", err
+	}
+	return e, s[end+1:], nil
+}
+
+func closeFilter(e *element, s string) (*element, string, error) {
+	if !strings.HasPrefix(s, ")") {
+		return nil, "", fmt.Errorf("expected ) at %s", s)
+	}
+	return e, s[1:], nil
+}
+
+// parseItem parses a filter comparing an attribute, e.g. cn=argocd-*
+func parseItem(item string) (*element, error) {
+	i := strings.IndexByte(item, '=')
+	if i <= 0 {
+		return nil, fmt.Errorf("expected attribute=value in %s", item)
+	}
+	attr, value := item[:i], item[i+1:]
+	tag := byte(filterEqualityMatch)
+	switch attr[len(attr)-1] {
+	case '>':
+		tag = filterGreaterOrEqual
+	case '<':
+		tag = filterLessOrEqual
+	case '~':
+		tag = filterApproxMatch
+	case ':':
+		return nil, fmt.Errorf("extensible matches are not supported")
+	}
+	if tag != filterEqualityMatch {
+		attr = attr[:len(attr)-1]
+	}
+	if attr == "" {
+		return nil, fmt.Errorf("expected attribute=value in %s", item)
+	}
+	if tag == filterEqualityMatch && value == "*" {
+		return newPrimitive(classContextPrimitive|filterPresent, []byte(attr)), nil
+	}
+	if tag == filterEqualityMatch && strings.Contains(value, "*") {
+		parts := strings.Split(value, "*")
+		substrings := newConstructed(tagSequence)
+		for j, part := range parts {
+			if part == "" {
+				continue
+			}
+			unescaped, err := unescapeValue(part)
+			if err != nil {
+				return nil, err
+			}
+			partTag := byte(substringAny)
+			if j == 0 {
+				partTag = substringInitial
+			} else if j == len(parts)-1 {
+				partTag = substringFinal
+			}
+			substrings.children = append(substrings.children, newPrimitive(classContextPrimitive|partTag, []byte(unescaped)))
+		}
+		return newConstructed(classContextConstructed|filterSubstrings, newOctetString(attr), substrings), nil
+	}
+	unescaped, err := unescapeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return newConstructed(classContextConstructed|tag, newOctetString(attr), newOctetString(unescaped)), nil
+}
+
+// unescapeValue replaces the \XX hex escapes of a filter value
+func unescapeValue(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			sb.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", fmt.Errorf("invalid escape in %s", value)
+		}
+		b, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("invalid escape in %s", value)
+		}
+		sb.Write(b)
+		i += 2
+	}
+	return sb.String(), nil
+}
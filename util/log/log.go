@@ -0,0 +1,186 @@
+package log
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ModuleGit is the module of the logs of the git client
+	ModuleGit = "git"
+	// ModuleHelm is the module of the logs of the helm client
+	ModuleHelm = "helm"
+	// ModuleKube is the module of the logs of the kubectl wrapper applying resources
+	ModuleKube = "kube"
+)
+
+// Modules are the modules whose log level can be set independently of the level of the component
+var Modules = []string{ModuleGit, ModuleHelm, ModuleKube}
+
+var (
+	lock         sync.Mutex
+	modules      = make(map[string]*logrus.Logger)
+	moduleLevels = make(map[string]logrus.Level)
+	// defaultLevels are the levels set by the flags of the component, which the overridden levels are applied on top of
+	defaultLevels  string
+	overrideLevels string
+)
+
+// standardOutput writes the logs of the modules to the output of the standard logger
+type standardOutput struct{}
+
+func (standardOutput) Write(p []byte) (int, error) {
+	return logrus.StandardLogger().Out.Write(p)
+}
+
+// standardFormatter formats the logs of the modules like the standard logger, so the format set by the flags of the
+// component applies to the modules too
+type standardFormatter struct{}
+
+func (standardFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return logrus.StandardLogger().Formatter.Format(entry)
+}
+
+// Module returns the logger of a module, which logs like the standard logger at the level of the module if one is set,
+// or the level of the standard logger otherwise
+func Module(name string) *logrus.Logger {
+	lock.Lock()
+	defer lock.Unlock()
+	if logger, ok := modules[name]; ok {
+		return logger
+	}
+	logger := logrus.New()
+	logger.Out = standardOutput{}
+	logger.Formatter = standardFormatter{}
+	logger.SetLevel(moduleLevel(name))
+	modules[name] = logger
+	return logger
+}
+
+// moduleLevel returns the level of a module. The lock must be held.
+func moduleLevel(name string) logrus.Level {
+	if level, ok := moduleLevels[name]; ok {
+		return level
+	}
+	return logrus.GetLevel()
+}
+
+// ParseLevels parses log levels like "info,git=debug": an optional level of the component and the levels of modules.
+// Later levels override earlier ones. The level of the component is returned as the empty module.
+func ParseLevels(levels string) (map[string]logrus.Level, error) {
+	res := make(map[string]logrus.Level)
+	for _, item := range strings.Split(levels, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		module, levelName := "", item
+		if parts := strings.SplitN(item, "=", 2); len(parts) == 2 {
+			module, levelName = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			if !isModule(module) {
+				return nil, fmt.Errorf("unknown log module '%s', expected one of %v", module, Modules)
+			}
+		}
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return nil, err
+		}
+		res[module] = level
+	}
+	return res, nil
+}
+
+func isModule(name string) bool {
+	for _, module := range Modules {
+		if module == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLevels sets the default levels of the component, e.g. from its flags: the level of the standard logger if the levels
+// include one and the levels of the modules. Modules without a level log at the level of the standard logger.
+func SetLevels(levels string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if err := applyLevels(levels + "," + overrideLevels); err != nil {
+		return err
+	}
+	defaultLevels = levels
+	return nil
+}
+
+// OverrideLevels overrides the default levels of the component at runtime, or restores them if levels is empty
+func OverrideLevels(levels string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if levels == overrideLevels {
+		return nil
+	}
+	if err := applyLevels(defaultLevels + "," + levels); err != nil {
+		return err
+	}
+	overrideLevels = levels
+	logrus.Infof("Log levels set to %s", currentLevels())
+	return nil
+}
+
+// applyLevels applies levels on top of the level of the standard logger. The lock must be held.
+func applyLevels(levels string) error {
+	parsed, err := ParseLevels(levels)
+	if err != nil {
+		return err
+	}
+	if level, ok := parsed[""]; ok {
+		logrus.SetLevel(level)
+		delete(parsed, "")
+	}
+	moduleLevels = parsed
+	for name, logger := range modules {
+		logger.SetLevel(moduleLevel(name))
+	}
+	return nil
+}
+
+// GetLevels returns the current levels of the standard logger and of the modules with their own level, formatted like
+// the input of SetLevels
+func GetLevels() string {
+	lock.Lock()
+	defer lock.Unlock()
+	return currentLevels()
+}
+
+func currentLevels() string {
+	items := []string{logrus.GetLevel().String()}
+	var names []string
+	for name := range moduleLevels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("%s=%s", name, moduleLevels[name]))
+	}
+	return strings.Join(items, ",")
+}
+
+// WatchLevelsFile overrides the levels of the component by the content of a file, e.g. a key of a ConfigMap mounted as
+// a volume, and checks the file for changes at the given interval. A missing file restores the default levels.
+func WatchLevelsFile(path string, interval time.Duration) {
+	for {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			logrus.Warnf("Failed to read log levels from %s: %v", path, err)
+		} else if err := OverrideLevels(strings.TrimSpace(string(data))); err != nil {
+			logrus.Warnf("Invalid log levels in %s: %v", path, err)
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevels(t *testing.T) {
+	levels, err := ParseLevels("info, git=debug,helm=warn,git=error")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]logrus.Level{"": logrus.InfoLevel, ModuleGit: logrus.ErrorLevel, ModuleHelm: logrus.WarnLevel}, levels)
+
+	_, err = ParseLevels("foo=debug")
+	assert.Error(t, err)
+	_, err = ParseLevels("git=verbose")
+	assert.Error(t, err)
+}
+
+func TestModuleLevels(t *testing.T) {
+	var out bytes.Buffer
+	logrus.SetOutput(&out)
+	defer func() {
+		logrus.SetOutput(logrus.New().Out)
+		_ = SetLevels("info")
+		_ = OverrideLevels("")
+	}()
+	git := Module(ModuleGit)
+	helm := Module(ModuleHelm)
+
+	assert.NoError(t, SetLevels("warn"))
+	helm.Info("helm hidden")
+	assert.Equal(t, logrus.WarnLevel, git.Level)
+
+	// the overridden levels apply on top of the default levels
+	assert.NoError(t, OverrideLevels("git=debug"))
+	assert.Equal(t, "warning,git=debug", GetLevels())
+	git.Debug("git visible")
+	helm.Info("helm hidden")
+
+	// restores the default levels
+	assert.NoError(t, OverrideLevels(""))
+	assert.Equal(t, "warning", GetLevels())
+	git.Debug("git hidden")
+
+	assert.Contains(t, out.String(), "git visible")
+	assert.NotContains(t, out.String(), "hidden")
+}
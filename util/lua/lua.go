@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/gobuffalo/packr"
@@ -40,6 +41,14 @@ type VM struct {
 	UseOpenLibs bool
 }
 
+// ValidateScript reports the syntax errors of a Lua script, without running it
+func ValidateScript(script string) error {
+	l := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer l.Close()
+	_, err := l.LoadString(script)
+	return err
+}
+
 func (vm VM) runLua(obj *unstructured.Unstructured, script string) (*lua.LState, error) {
 	l := lua.NewState(lua.Options{
 		SkipOpenLibs: !vm.UseOpenLibs,
@@ -230,6 +239,10 @@ func (vm VM) ExecuteResourceActionDiscovery(obj *unstructured.Unstructured, scri
 			}
 			availableActions = append(availableActions, resourceAction)
 		}
+		// the actions are returned in a stable order, as opposed to the order of the table
+		sort.Slice(availableActions, func(i, j int) bool {
+			return availableActions[i].Name < availableActions[j].Name
+		})
 		return availableActions, err
 	}
 
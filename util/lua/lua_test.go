@@ -243,9 +243,7 @@ func TestExecuteResourceActionDiscovery(t *testing.T) {
 			Name: "test",
 		},
 	}
-	for _, expectedAction := range expectedActions {
-		assert.Contains(t, actions, expectedAction)
-	}
+	assert.Equal(t, expectedActions, actions)
 }
 
 const discoveryLuaWithInvalidResourceAction = `
@@ -376,3 +374,8 @@ func TestCleanPatch(t *testing.T) {
 	assert.Equal(t, expectedObj, newObj)
 
 }
+
+func TestValidateScript(t *testing.T) {
+	assert.NoError(t, ValidateScript(newHealthStatusFunction))
+	assert.Error(t, ValidateScript(`hs = {`))
+}
@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	triggerKeyPrefix  = "trigger."
+	templateKeyPrefix = "template."
+	serviceKeyPrefix  = "service."
+	subscriptionsKey  = "subscriptions"
+	contextKey        = "context"
+)
+
+var secretRefRegex = regexp.MustCompile(`\$([\w\-]+)`)
+
+// Condition is a condition of a trigger. The notifications of the templates are sent when the condition becomes true.
+type Condition struct {
+	// When is an expression of the application, e.g. app.status.operationState.phase in ('Error', 'Failed')
+	When string `json:"when"`
+	// Send are the names of the templates of the notifications
+	Send []string `json:"send"`
+	// Description describes the condition
+	Description string `json:"description,omitempty"`
+	// OncePer is an expression of the application, e.g. app.status.sync.revision. If it is set, the notifications are
+	// sent once for each of its values, instead of each time the condition becomes true.
+	OncePer string `json:"oncePer,omitempty"`
+	// DedupWindow is the duration after a notification was sent during which it is not sent again, even if the
+	// condition became false and true again, e.g. 10m
+	DedupWindow string `json:"dedupWindow,omitempty"`
+}
+
+// Subscription subscribes recipients to the triggers of the applications matching its selector
+type Subscription struct {
+	// Recipients are the recipients of the notifications in the format <service>:<recipient>
+	Recipients []string `json:"recipients"`
+	// Triggers are the names of the triggers
+	Triggers []string `json:"triggers"`
+	// Selector is a label selector of the applications. Defaults to all applications.
+	Selector string `json:"selector,omitempty"`
+}
+
+// Config is the configuration of the notifications
+type Config struct {
+	// Triggers are the conditions of each trigger
+	Triggers map[string][]Condition
+	// Templates are the unrendered notifications of each template
+	Templates map[string]interface{}
+	// Services deliver the notifications to their recipients
+	Services map[string]Service
+	// Subscriptions are the default subscriptions of all applications
+	Subscriptions []Subscription
+	// Context contains additional values available to templates as .context, e.g. the URL of Argo CD
+	Context map[string]string
+	// RateLimits are the rate limits of the recipients of each service
+	RateLimits map[string]RateLimit
+
+	limiter rateLimiter
+}
+
+// rateLimitOptions are the rate limit options common to all services
+type rateLimitOptions struct {
+	RateLimit *struct {
+		Count    int    `json:"count"`
+		Interval string `json:"interval"`
+	} `json:"rateLimit,omitempty"`
+}
+
+// ParseConfig parses the notifications config map. References to the keys of the secret, like $slack-token, are
+// replaced by their values in the configurations of the services.
+func ParseConfig(cm *corev1.ConfigMap, secret *corev1.Secret) (*Config, error) {
+	cfg := &Config{
+		Triggers:   make(map[string][]Condition),
+		Templates:  make(map[string]interface{}),
+		Services:   make(map[string]Service),
+		Context:    make(map[string]string),
+		RateLimits: make(map[string]RateLimit),
+	}
+	for key, value := range cm.Data {
+		switch {
+		case strings.HasPrefix(key, triggerKeyPrefix):
+			var conditions []Condition
+			if err := yaml.Unmarshal([]byte(value), &conditions); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+			for _, condition := range conditions {
+				if err := condition.validate(); err != nil {
+					return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+				}
+			}
+			cfg.Triggers[strings.TrimPrefix(key, triggerKeyPrefix)] = conditions
+		case strings.HasPrefix(key, templateKeyPrefix):
+			var tmpl interface{}
+			if err := yaml.Unmarshal([]byte(value), &tmpl); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+			cfg.Templates[strings.TrimPrefix(key, templateKeyPrefix)] = tmpl
+		case strings.HasPrefix(key, serviceKeyPrefix):
+			// the service is named after its type, unless the key is service.<type>.<name>
+			parts := strings.SplitN(strings.TrimPrefix(key, serviceKeyPrefix), ".", 2)
+			name := parts[len(parts)-1]
+			data := []byte(expandSecretRefs(value, secret))
+			service, err := newService(parts[0], name, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+			cfg.Services[name] = service
+			var opts rateLimitOptions
+			if err := yaml.Unmarshal(data, &opts); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+			if opts.RateLimit != nil {
+				interval, err := time.ParseDuration(opts.RateLimit.Interval)
+				if err != nil || interval <= 0 || opts.RateLimit.Count <= 0 {
+					return nil, fmt.Errorf("failed to parse %s: rateLimit requires a positive count and interval", key)
+				}
+				cfg.RateLimits[name] = RateLimit{Count: opts.RateLimit.Count, Interval: interval}
+			}
+		case key == subscriptionsKey:
+			if err := yaml.Unmarshal([]byte(value), &cfg.Subscriptions); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+			for _, subscription := range cfg.Subscriptions {
+				if _, err := labels.Parse(subscription.Selector); err != nil {
+					return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+				}
+			}
+		case key == contextKey:
+			if err := yaml.Unmarshal([]byte(value), &cfg.Context); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// validate returns an error if the dedup window is not a duration, or is combined with oncePer
+func (c Condition) validate() error {
+	if c.DedupWindow == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(c.DedupWindow); err != nil {
+		return fmt.Errorf("invalid dedupWindow: %v", err)
+	}
+	if c.OncePer != "" {
+		return fmt.Errorf("dedupWindow and oncePer are mutually exclusive")
+	}
+	return nil
+}
+
+// dedupWindow returns the parsed dedup window, which was validated when the config was parsed
+func (c Condition) dedupWindow() time.Duration {
+	window, _ := time.ParseDuration(c.DedupWindow)
+	return window
+}
+
+// expandSecretRefs replaces references to the keys of a secret by their values. Unknown references are left as they
+// are.
+func expandSecretRefs(value string, secret *corev1.Secret) string {
+	if secret == nil {
+		return value
+	}
+	return secretRefRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		if data, ok := secret.Data[ref[1:]]; ok {
+			return string(data)
+		}
+		return ref
+	})
+}
+
+// newService returns a named service of a type, configured by YAML
+func newService(serviceType string, name string, data []byte) (Service, error) {
+	switch serviceType {
+	case "slack":
+		return newSlackService(data)
+	case "teams":
+		return newTeamsService(data)
+	case "email":
+		return newEmailService(data)
+	case "webhook":
+		return newWebhookService(name, data)
+	default:
+		return nil, fmt.Errorf("unknown service type %s", serviceType)
+	}
+}
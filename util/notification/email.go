@@ -0,0 +1,235 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultSMTPPort is the port of the SMTP submission service
+	DefaultSMTPPort = 587
+	// DefaultDigestSubject is the subject of digests
+	DefaultDigestSubject = "Argo CD notifications"
+)
+
+// EmailNotification contains the email specific fields of a notification
+type EmailNotification struct {
+	// Subject is the subject of the email
+	Subject string `json:"subject,omitempty"`
+	// Body is the body of the email. Defaults to the message of the notification.
+	Body string `json:"body,omitempty"`
+}
+
+// EmailOptions configure the email service
+type EmailOptions struct {
+	// Host is the host of the SMTP server
+	Host string `json:"host"`
+	// Port is the port of the SMTP server. Defaults to 587.
+	Port int `json:"port,omitempty"`
+	// From is the address of the sender
+	From string `json:"from"`
+	// Username and Password authenticate to the SMTP server, if the username is set
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// TLS connects to the SMTP server with TLS, usually on port 465. Otherwise the connection is upgraded with
+	// STARTTLS if the server supports it.
+	TLS bool `json:"tls,omitempty"`
+	// InsecureSkipVerify skips the verification of the certificate of the SMTP server
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// HTML sends the bodies as HTML instead of plain text
+	HTML bool `json:"html,omitempty"`
+	// DigestInterval, if set, batches the notifications of each recipient into one email per interval, e.g. 1h
+	DigestInterval string `json:"digestInterval,omitempty"`
+	// DigestSubject is the subject of digests. Defaults to "Argo CD notifications".
+	DigestSubject string `json:"digestSubject,omitempty"`
+}
+
+type email struct {
+	subject string
+	body    string
+}
+
+type emailService struct {
+	opts           EmailOptions
+	digestInterval time.Duration
+	// sendMail sends an email to a recipient, and is overridden by tests
+	sendMail func(recipient string, msg []byte) error
+
+	lock sync.Mutex
+	// digests are the emails of each recipient waiting for their digest to be sent
+	digests map[string][]email
+}
+
+func newEmailService(data []byte) (Service, error) {
+	var opts EmailOptions
+	if err := yaml.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if opts.From == "" {
+		return nil, fmt.Errorf("from is required")
+	}
+	return NewEmailService(opts)
+}
+
+// NewEmailService returns a service sending emails through an SMTP server. The recipients are email addresses.
+func NewEmailService(opts EmailOptions) (Service, error) {
+	if opts.Port == 0 {
+		opts.Port = DefaultSMTPPort
+	}
+	if opts.DigestSubject == "" {
+		opts.DigestSubject = DefaultDigestSubject
+	}
+	s := &emailService{opts: opts, digests: make(map[string][]email)}
+	if opts.DigestInterval != "" {
+		interval, err := time.ParseDuration(opts.DigestInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digestInterval: %v", err)
+		}
+		s.digestInterval = interval
+	}
+	s.sendMail = s.smtpSendMail
+	return s, nil
+}
+
+func (s *emailService) Send(notification Notification, recipient string) error {
+	e := email{body: notification.Message}
+	if notification.Email != nil {
+		e.subject = notification.Email.Subject
+		if notification.Email.Body != "" {
+			e.body = notification.Email.Body
+		}
+	}
+	if s.digestInterval <= 0 {
+		return s.sendMail(recipient, s.message(recipient, e.subject, e.body))
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.digests[recipient]) == 0 {
+		time.AfterFunc(s.digestInterval, func() {
+			if err := s.sendDigest(recipient); err != nil {
+				log.Warnf("Failed to send the digest of notifications to %s: %v", recipient, err)
+			}
+		})
+	}
+	s.digests[recipient] = append(s.digests[recipient], e)
+	return nil
+}
+
+// sendDigest sends the emails waiting for the digest of a recipient as one email
+func (s *emailService) sendDigest(recipient string) error {
+	s.lock.Lock()
+	emails := s.digests[recipient]
+	delete(s.digests, recipient)
+	s.lock.Unlock()
+	if len(emails) == 0 {
+		return nil
+	}
+	separator := "\n\n---\n\n"
+	if s.opts.HTML {
+		separator = "\n<hr>\n"
+	}
+	var parts []string
+	for _, e := range emails {
+		if e.subject == "" {
+			parts = append(parts, e.body)
+		} else if s.opts.HTML {
+			parts = append(parts, fmt.Sprintf("<h3>%s</h3>\n%s", e.subject, e.body))
+		} else {
+			parts = append(parts, e.subject+"\n\n"+e.body)
+		}
+	}
+	msg := s.message(recipient, s.opts.DigestSubject, strings.Join(parts, separator))
+	return withRetry(func() error {
+		return s.sendMail(recipient, msg)
+	})
+}
+
+// message returns the headers and the body of an email
+func (s *emailService) message(recipient string, subject string, body string) []byte {
+	contentType := "text/plain"
+	if s.opts.HTML {
+		contentType = "text/html"
+	}
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "From: %s\r\n", s.opts.From)
+	_, _ = fmt.Fprintf(&buf, "To: %s\r\n", recipient)
+	_, _ = fmt.Fprintf(&buf, "Subject: %s\r\n", strings.Replace(subject, "\n", " ", -1))
+	_, _ = fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	_, _ = fmt.Fprintf(&buf, "Content-Type: %s; charset=UTF-8\r\n\r\n", contentType)
+	buf.WriteString(body)
+	return buf.Bytes()
+}
+
+func (s *emailService) smtpSendMail(recipient string, msg []byte) error {
+	err := s.dialAndSend(recipient, msg)
+	// transient SMTP errors have 4xx codes
+	if protoErr, ok := err.(*textproto.Error); ok && protoErr.Code >= 400 && protoErr.Code < 500 {
+		return &temporaryError{err: err}
+	}
+	if _, ok := err.(net.Error); ok {
+		return &temporaryError{err: err}
+	}
+	return err
+}
+
+func (s *emailService) dialAndSend(recipient string, msg []byte) error {
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	tlsConfig := &tls.Config{ServerName: s.opts.Host, InsecureSkipVerify: s.opts.InsecureSkipVerify}
+	var conn net.Conn
+	var err error
+	if s.opts.TLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, 30*time.Second)
+	}
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, s.opts.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.opts.TLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if s.opts.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.opts.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeEmailService(t *testing.T, opts EmailOptions) (*emailService, *[]string) {
+	opts.Host = "smtp.example.com"
+	opts.From = "argocd@example.com"
+	service, err := NewEmailService(opts)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var sent []string
+	s := service.(*emailService)
+	s.sendMail = func(recipient string, msg []byte) error {
+		sent = append(sent, string(msg))
+		return nil
+	}
+	return s, &sent
+}
+
+func TestEmailService(t *testing.T) {
+	service, sent := newFakeEmailService(t, EmailOptions{})
+
+	assert.NoError(t, service.Send(Notification{
+		Message: "Application guestbook has degraded",
+		Email:   &EmailNotification{Subject: "guestbook\nhas degraded"},
+	}, "alice@example.com"))
+	assert.Equal(t, []string{"From: argocd@example.com\r\nTo: alice@example.com\r\nSubject: guestbook has degraded\r\n" +
+		"MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\nApplication guestbook has degraded"}, *sent)
+	assert.Equal(t, DefaultSMTPPort, service.opts.Port)
+}
+
+func TestEmailService_Digest(t *testing.T) {
+	service, sent := newFakeEmailService(t, EmailOptions{DigestInterval: "1h", HTML: true})
+
+	assert.NoError(t, service.Send(Notification{Message: "guestbook has degraded"}, "alice@example.com"))
+	assert.NoError(t, service.Send(Notification{
+		Message: "ignored",
+		Email:   &EmailNotification{Subject: "guestbook is synced", Body: "<p>revision abc</p>"},
+	}, "alice@example.com"))
+	assert.Empty(t, *sent)
+
+	assert.NoError(t, service.sendDigest("alice@example.com"))
+	assert.Equal(t, []string{"From: argocd@example.com\r\nTo: alice@example.com\r\nSubject: Argo CD notifications\r\n" +
+		"MIME-Version: 1.0\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n" +
+		"guestbook has degraded\n<hr>\n<h3>guestbook is synced</h3>\n<p>revision abc</p>"}, *sent)
+
+	// the digest is empty until other notifications are sent
+	assert.NoError(t, service.sendDigest("alice@example.com"))
+	assert.Len(t, *sent, 1)
+}
+
+func TestNewEmailService(t *testing.T) {
+	_, err := newEmailService([]byte("host: smtp.example.com"))
+	assert.EqualError(t, err, "from is required")
+	_, err = newEmailService([]byte("{host: smtp.example.com, from: argocd@example.com, digestInterval: daily}"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid digestInterval")
+	}
+}
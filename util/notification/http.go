@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// sendAttempts is the number of attempts to deliver a notification before giving up until the next reconciliation
+	sendAttempts = 3
+	// defaultRetryDelay is the delay before retrying a delivery, unless the service asked for another one
+	defaultRetryDelay = 2 * time.Second
+	// maxRetryDelay bounds the delays services ask for, so a notification doesn't block the others for too long
+	maxRetryDelay = 30 * time.Second
+)
+
+// retryDelay is overridden by tests
+var retryDelay = defaultRetryDelay
+
+// temporaryError is an error delivering a notification which is worth retrying, like an unavailable service or a
+// rejection because of a rate limit
+type temporaryError struct {
+	err error
+	// retryAfter is the delay the service asked for before retrying, if any
+	retryAfter time.Duration
+}
+
+func (e *temporaryError) Error() string {
+	return e.err.Error()
+}
+
+// withRetry delivers notifications, retrying temporary errors
+func withRetry(send func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := send()
+		tempErr, ok := err.(*temporaryError)
+		if !ok || attempt == sendAttempts {
+			return err
+		}
+		delay := retryDelay * time.Duration(attempt)
+		if tempErr.retryAfter > 0 {
+			delay = tempErr.retryAfter
+		}
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		time.Sleep(delay)
+	}
+}
+
+// postJSON posts a JSON body and unmarshals the JSON response into res, unless it is nil
+func postJSON(client *http.Client, url string, headers map[string]string, body interface{}, res interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return do(client, req, res)
+}
+
+// do sends a request and unmarshals the JSON response into res, unless it is nil. Network errors, rate limits and
+// server errors are temporary errors.
+func do(client *http.Client, req *http.Request, res interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return &temporaryError{err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("%s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+			return &temporaryError{err: err, retryAfter: time.Duration(seconds) * time.Second}
+		}
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
@@ -0,0 +1,228 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-cd/common"
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/expr"
+)
+
+// State contains the time each notification was sent at, keyed by the trigger, the index of the condition, the
+// destination and the value of the oncePer expression if any, as long as the condition is true or the notification is
+// within the dedup window of the condition
+type State map[string]int64
+
+// ParseState parses the state stored in the annotation of an application
+func ParseState(annotation string) State {
+	state := make(State)
+	if annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &state); err != nil {
+			log.Warnf("Failed to parse notification state %s: %v", annotation, err)
+		}
+	}
+	return state
+}
+
+// String returns the state as it is stored in the annotation of an application
+func (s State) String() string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// subscriptions returns the destinations subscribed to each trigger of an application, by the configured
+// subscriptions and by the subscription annotations of the project and of the application. The application may only
+// subscribe to the services its project permits.
+func (cfg *Config) subscriptions(app *appv1.Application, proj *appv1.AppProject) map[string][]Destination {
+	res := make(map[string][]Destination)
+	seen := make(map[string]bool)
+	add := func(trigger string, dest Destination) {
+		if key := trigger + ":" + dest.String(); !seen[key] {
+			seen[key] = true
+			res[trigger] = append(res[trigger], dest)
+		}
+	}
+	for _, subscription := range cfg.Subscriptions {
+		// the selectors were validated when the config was parsed
+		selector, _ := labels.Parse(subscription.Selector)
+		if !selector.Matches(labels.Set(app.Labels)) {
+			continue
+		}
+		for _, trigger := range subscription.Triggers {
+			for _, recipient := range subscription.Recipients {
+				dest, err := ParseDestination(recipient)
+				if err != nil {
+					log.Warnf("Invalid subscription: %v", err)
+					continue
+				}
+				add(trigger, dest)
+			}
+		}
+	}
+	logCtx := log.WithField("application", app.Name)
+	if proj != nil {
+		for _, sub := range annotationSubscriptions(proj.Annotations, logCtx) {
+			add(sub.trigger, sub.dest)
+		}
+	}
+	for _, sub := range annotationSubscriptions(app.Annotations, logCtx) {
+		if proj != nil && !proj.IsNotificationServicePermitted(sub.dest.Service) {
+			logCtx.Warnf("Ignoring the subscription of %s to %s: project %s does not permit service %s", sub.dest, sub.trigger, proj.Name, sub.dest.Service)
+			continue
+		}
+		add(sub.trigger, sub.dest)
+	}
+	return res
+}
+
+type annotationSubscription struct {
+	trigger string
+	dest    Destination
+}
+
+// annotationSubscriptions parses the subscription annotations of an application or project, in the format
+// notifications.argoproj.io/subscribe.<trigger>.<service>: <recipient>;<recipient>
+func annotationSubscriptions(annotations map[string]string, logCtx *log.Entry) []annotationSubscription {
+	var keys []string
+	for key := range annotations {
+		if strings.HasPrefix(key, common.AnnotationKeyNotificationSubscribePrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var res []annotationSubscription
+	for _, key := range keys {
+		// trigger names may contain dots, service names don't
+		name := strings.TrimPrefix(key, common.AnnotationKeyNotificationSubscribePrefix)
+		i := strings.LastIndex(name, ".")
+		if i <= 0 || i == len(name)-1 {
+			logCtx.Warnf("Invalid subscription annotation %s: expected %s<trigger>.<service>", key, common.AnnotationKeyNotificationSubscribePrefix)
+			continue
+		}
+		for _, recipient := range strings.Split(annotations[key], ";") {
+			if recipient = strings.TrimSpace(recipient); recipient != "" {
+				res = append(res, annotationSubscription{trigger: name[:i], dest: Destination{Service: name[i+1:], Recipient: recipient}})
+			}
+		}
+	}
+	return res
+}
+
+// Notify sends the notifications of the triggers an application is subscribed to, for the conditions which became
+// true since the state was last updated. The state is updated with the sent notifications. Notifications which failed
+// to be sent are retried the next time. The project of the application is nil if it doesn't exist.
+func (cfg *Config) Notify(app *appv1.Application, proj *appv1.AppProject, state State) error {
+	data, err := json.Marshal(app)
+	if err != nil {
+		return err
+	}
+	var appValues map[string]interface{}
+	if err := json.Unmarshal(data, &appValues); err != nil {
+		return err
+	}
+	values := map[string]interface{}{"app": appValues, "context": cfg.Context}
+
+	subscriptions := cfg.subscriptions(app, proj)
+	var triggers []string
+	for trigger := range subscriptions {
+		triggers = append(triggers, trigger)
+	}
+	sort.Strings(triggers)
+	var errs []string
+	for _, trigger := range triggers {
+		conditions, ok := cfg.Triggers[trigger]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("trigger %s is not configured", trigger))
+			continue
+		}
+		for i, condition := range conditions {
+			matches, err := expr.Evaluate(condition.When, values)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("trigger %s: %v", trigger, err))
+				continue
+			}
+			// the state of oncePer conditions is keyed by their value, which is kept until another one is notified
+			var oncePer string
+			if matches && condition.OncePer != "" {
+				value, err := expr.EvaluateValue(condition.OncePer, values)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("trigger %s: %v", trigger, err))
+					continue
+				}
+				oncePer = fmt.Sprintf("|%v", value)
+			}
+			for _, dest := range subscriptions[trigger] {
+				key := fmt.Sprintf("%s:[%d]:%s", trigger, i, dest)
+				if !matches {
+					if condition.OncePer == "" && !withinWindow(state[key], condition.dedupWindow()) {
+						delete(state, key)
+					}
+					continue
+				}
+				if _, ok := state[key+oncePer]; ok {
+					continue
+				}
+				logCtx := log.WithField("application", app.Name)
+				if limit, ok := cfg.RateLimits[dest.Service]; ok && !cfg.limiter.allow(dest.String(), limit, time.Now()) {
+					// the notification is dropped rather than delayed, so bursts don't reach the recipient later
+					logCtx.Warnf("Dropped notifications of trigger %s to %s: the rate limit of %d per %v is exceeded", trigger, dest, limit.Count, limit.Interval)
+				} else {
+					if err := cfg.send(condition.Send, values, dest); err != nil {
+						errs = append(errs, fmt.Sprintf("trigger %s: %v", trigger, err))
+						continue
+					}
+					logCtx.Infof("Sent notifications of trigger %s to %s", trigger, dest)
+				}
+				if oncePer != "" {
+					for k := range state {
+						if strings.HasPrefix(k, key+"|") {
+							delete(state, k)
+						}
+					}
+				}
+				state[key+oncePer] = time.Now().Unix()
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// withinWindow returns whether a notification sent at the given time is within a dedup window
+func withinWindow(sent int64, window time.Duration) bool {
+	return sent > 0 && time.Since(time.Unix(sent, 0)) < window
+}
+
+// send renders the notification templates and sends them to a destination
+func (cfg *Config) send(templates []string, values map[string]interface{}, dest Destination) error {
+	service, ok := cfg.Services[dest.Service]
+	if !ok {
+		return fmt.Errorf("service %s is not configured", dest.Service)
+	}
+	for _, name := range templates {
+		tmpl, ok := cfg.Templates[name]
+		if !ok {
+			return fmt.Errorf("template %s is not configured", name)
+		}
+		notification, err := render(tmpl, values)
+		if err != nil {
+			return fmt.Errorf("template %s: %v", name, err)
+		}
+		err = withRetry(func() error {
+			return service.Send(*notification, dest.Recipient)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send notification to %s: %v", dest, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package notification
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+type fakeService struct {
+	sent []string
+	err  error
+	// failures is the number of attempts which fail before the error is returned
+	failures int
+}
+
+func (s *fakeService) Send(notification Notification, recipient string) error {
+	if s.failures > 0 {
+		s.failures--
+		return &temporaryError{err: fmt.Errorf("unavailable")}
+	}
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, recipient+": "+notification.Message)
+	return nil
+}
+
+func newFakeConfig(service Service) *Config {
+	return &Config{
+		Triggers: map[string][]Condition{
+			"on-sync-failed": {{When: "app.status.operationState.phase in ('Error', 'Failed')", Send: []string{"app-sync-failed"}}},
+		},
+		Templates: map[string]interface{}{
+			"app-sync-failed": map[string]interface{}{"message": "{{.app.metadata.name}} failed: {{.app.status.operationState.message}} ({{.context.argocdUrl}})"},
+		},
+		Services: map[string]Service{"fake": service},
+		Subscriptions: []Subscription{
+			{Recipients: []string{"fake:team"}, Triggers: []string{"on-sync-failed"}},
+			{Recipients: []string{"fake:prod"}, Triggers: []string{"on-sync-failed"}, Selector: "env=prod"},
+		},
+		Context: map[string]string{"argocdUrl": "https://argocd.example.com"},
+	}
+}
+
+func newFakeApp(phase appv1.OperationPhase) *appv1.Application {
+	return &appv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Labels: map[string]string{"env": "staging"}},
+		Status:     appv1.ApplicationStatus{OperationState: &appv1.OperationState{Phase: phase, Message: "boom"}},
+	}
+}
+
+func TestNotify(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	state := make(State)
+
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Equal(t, []string{"team: guestbook failed: boom (https://argocd.example.com)"}, service.sent)
+	assert.Contains(t, state, "on-sync-failed:[0]:fake:team")
+
+	// the notification is sent once while the condition is true
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Len(t, service.sent, 1)
+
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationSucceeded), nil, state))
+	assert.Empty(t, state)
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationError), nil, state))
+	assert.Len(t, service.sent, 2)
+}
+
+func TestNotify_Failure(t *testing.T) {
+	service := &fakeService{err: fmt.Errorf("unavailable")}
+	cfg := newFakeConfig(service)
+	state := make(State)
+
+	err := cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state)
+	assert.EqualError(t, err, "trigger on-sync-failed: failed to send notification to fake:team: unavailable")
+	assert.Empty(t, state)
+
+	service.err = nil
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Len(t, service.sent, 1)
+}
+
+func TestNotify_SubscriptionAnnotations(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	cfg.Subscriptions = nil
+	app := newFakeApp(appv1.OperationFailed)
+	app.Annotations = map[string]string{
+		"notifications.argoproj.io/subscribe.on-sync-failed.fake": "alice; bob",
+		"notifications.argoproj.io/subscribe.invalid":             "carol",
+	}
+	state := make(State)
+
+	assert.NoError(t, cfg.Notify(app, nil, state))
+	assert.Equal(t, []string{
+		"alice: guestbook failed: boom (https://argocd.example.com)",
+		"bob: guestbook failed: boom (https://argocd.example.com)",
+	}, service.sent)
+	assert.Contains(t, state, "on-sync-failed:[0]:fake:bob")
+}
+
+func TestNotify_ProjectSubscriptions(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	cfg.Services["other"] = service
+	cfg.Subscriptions = nil
+	app := newFakeApp(appv1.OperationFailed)
+	app.Annotations = map[string]string{
+		"notifications.argoproj.io/subscribe.on-sync-failed.fake":  "alice",
+		"notifications.argoproj.io/subscribe.on-sync-failed.other": "bob",
+	}
+	proj := &appv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Annotations: map[string]string{
+			"notifications.argoproj.io/subscribe.on-sync-failed.other": "admins",
+		}},
+		Spec: appv1.AppProjectSpec{NotificationServices: []string{"fake"}},
+	}
+
+	// the project subscribes to any service, but the application only to the permitted ones
+	assert.NoError(t, cfg.Notify(app, proj, make(State)))
+	assert.Equal(t, []string{
+		"admins: guestbook failed: boom (https://argocd.example.com)",
+		"alice: guestbook failed: boom (https://argocd.example.com)",
+	}, service.sent)
+}
+
+func TestWithRetry(t *testing.T) {
+	retryDelay = 0
+	defer func() { retryDelay = defaultRetryDelay }()
+	send := func(service Service) func() error {
+		return func() error {
+			return service.Send(Notification{Message: "hello"}, "team")
+		}
+	}
+
+	service := &fakeService{failures: sendAttempts - 1}
+	assert.NoError(t, withRetry(send(service)))
+	assert.Equal(t, []string{"team: hello"}, service.sent)
+
+	service = &fakeService{failures: sendAttempts}
+	assert.EqualError(t, withRetry(send(service)), "unavailable")
+	assert.Empty(t, service.sent)
+
+	// other errors are not retried
+	service = &fakeService{err: fmt.Errorf("forbidden")}
+	assert.EqualError(t, withRetry(send(service)), "forbidden")
+}
+
+func TestState(t *testing.T) {
+	state := State{"on-sync-failed:[0]:slack:team": 1600000000}
+	assert.Equal(t, state, ParseState(state.String()))
+	assert.Empty(t, ParseState("invalid"))
+}
+
+func TestParseConfig(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{
+		"trigger.on-sync-failed": `
+- when: app.status.operationState.phase in ('Error', 'Failed')
+  send: [app-sync-failed]
+`,
+		"template.app-sync-failed": "message: Application {{.app.metadata.name}} failed",
+		"subscriptions": `
+- recipients: [slack:team]
+  triggers: [on-sync-failed]
+  selector: env=prod
+`,
+		"context":            "argocdUrl: https://argocd.example.com",
+		"service.slack.work": "token: $slack-token",
+	}}
+	cfg, err := ParseConfig(cm, &corev1.Secret{Data: map[string][]byte{"slack-token": []byte("secret")}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Condition{{When: "app.status.operationState.phase in ('Error', 'Failed')", Send: []string{"app-sync-failed"}}}, cfg.Triggers["on-sync-failed"])
+	assert.Equal(t, map[string]interface{}{"message": "Application {{.app.metadata.name}} failed"}, cfg.Templates["app-sync-failed"])
+	assert.Equal(t, []Subscription{{Recipients: []string{"slack:team"}, Triggers: []string{"on-sync-failed"}, Selector: "env=prod"}}, cfg.Subscriptions)
+	assert.Equal(t, "https://argocd.example.com", cfg.Context["argocdUrl"])
+	if assert.IsType(t, &slackService{}, cfg.Services["work"]) {
+		assert.Equal(t, "secret", cfg.Services["work"].(*slackService).opts.Token)
+	}
+
+	cm.Data["service.slack.work"] = "username: argocd"
+	_, err = ParseConfig(cm, nil)
+	assert.EqualError(t, err, "failed to parse service.slack.work: token is required")
+	delete(cm.Data, "service.slack.work")
+
+	cm.Data["service.slack.work"] = "{token: secret, rateLimit: {count: 10, interval: 1m}}"
+	cfg, err = ParseConfig(cm, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, RateLimit{Count: 10, Interval: time.Minute}, cfg.RateLimits["work"])
+	cm.Data["service.slack.work"] = "{token: secret, rateLimit: {count: 10}}"
+	_, err = ParseConfig(cm, nil)
+	assert.EqualError(t, err, "failed to parse service.slack.work: rateLimit requires a positive count and interval")
+	delete(cm.Data, "service.slack.work")
+
+	cm.Data["trigger.on-sync-failed"] = "[{when: 'true', send: [x], oncePer: app.status.sync.revision, dedupWindow: 1m}]"
+	_, err = ParseConfig(cm, nil)
+	assert.EqualError(t, err, "failed to parse trigger.on-sync-failed: dedupWindow and oncePer are mutually exclusive")
+	delete(cm.Data, "trigger.on-sync-failed")
+
+	cm.Data["service.unknown"] = "{}"
+	_, err = ParseConfig(cm, nil)
+	assert.EqualError(t, err, "failed to parse service.unknown: unknown service type unknown")
+}
+
+func TestExpandSecretRefs(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"slack-token": []byte("secret")}}
+	assert.Equal(t, "token: secret\nother: $missing", expandSecretRefs("token: $slack-token\nother: $missing", secret))
+}
+
+func TestNotify_OncePer(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	cfg.Triggers["on-sync-failed"][0].OncePer = "app.status.sync.revision"
+	state := make(State)
+	app := func(phase appv1.OperationPhase, revision string) *appv1.Application {
+		app := newFakeApp(phase)
+		app.Status.Sync.Revision = revision
+		return app
+	}
+
+	assert.NoError(t, cfg.Notify(app(appv1.OperationFailed, "abc"), nil, state))
+	assert.Contains(t, state, "on-sync-failed:[0]:fake:team|abc")
+	// the failure of the same revision is notified once, even if the condition flaps
+	assert.NoError(t, cfg.Notify(app(appv1.OperationSucceeded, "abc"), nil, state))
+	assert.NoError(t, cfg.Notify(app(appv1.OperationFailed, "abc"), nil, state))
+	assert.Len(t, service.sent, 1)
+
+	assert.NoError(t, cfg.Notify(app(appv1.OperationFailed, "def"), nil, state))
+	assert.Len(t, service.sent, 2)
+	assert.Equal(t, []string{"on-sync-failed:[0]:fake:team|def"}, stateKeys(state))
+}
+
+func TestNotify_DedupWindow(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	cfg.Triggers["on-sync-failed"][0].DedupWindow = "10m"
+	state := make(State)
+
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationSucceeded), nil, state))
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Len(t, service.sent, 1)
+
+	// the notification is sent again once the window has passed
+	state["on-sync-failed:[0]:fake:team"] = time.Now().Add(-time.Hour).Unix()
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationSucceeded), nil, state))
+	assert.Empty(t, state)
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Len(t, service.sent, 2)
+}
+
+func TestNotify_RateLimit(t *testing.T) {
+	service := &fakeService{}
+	cfg := newFakeConfig(service)
+	cfg.RateLimits = map[string]RateLimit{"fake": {Count: 1, Interval: time.Hour}}
+	state := make(State)
+
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationSucceeded), nil, state))
+	// the notification exceeding the limit is dropped, and not retried
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Contains(t, state, "on-sync-failed:[0]:fake:team")
+	assert.NoError(t, cfg.Notify(newFakeApp(appv1.OperationFailed), nil, state))
+	assert.Len(t, service.sent, 1)
+}
+
+func TestRateLimiter(t *testing.T) {
+	var limiter rateLimiter
+	limit := RateLimit{Count: 2, Interval: time.Minute}
+	now := time.Now()
+	assert.True(t, limiter.allow("slack:team", limit, now))
+	assert.True(t, limiter.allow("slack:team", limit, now.Add(10*time.Second)))
+	assert.False(t, limiter.allow("slack:team", limit, now.Add(20*time.Second)))
+	assert.True(t, limiter.allow("slack:other", limit, now.Add(20*time.Second)))
+	assert.True(t, limiter.allow("slack:team", limit, now.Add(61*time.Second)))
+}
+
+func stateKeys(state State) []string {
+	var keys []string
+	for key := range state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
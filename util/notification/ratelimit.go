@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit limits the number of notifications each recipient of a service receives per interval
+type RateLimit struct {
+	// Count is the maximum number of notifications per interval
+	Count int
+	// Interval is the sliding interval of the limit
+	Interval time.Duration
+}
+
+// rateLimiter remembers when the notifications of each destination were sent
+type rateLimiter struct {
+	lock sync.Mutex
+	sent map[string][]time.Time
+}
+
+// allow returns whether a notification may be sent to a destination at the given time, and records it if so
+func (l *rateLimiter) allow(dest string, limit RateLimit, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.sent == nil {
+		l.sent = make(map[string][]time.Time)
+	}
+	var recent []time.Time
+	for _, t := range l.sent[dest] {
+		if now.Sub(t) < limit.Interval {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= limit.Count {
+		l.sent[dest] = recent
+		return false
+	}
+	l.sent[dest] = append(recent, now)
+	return true
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Notification is a rendered notification template
+type Notification struct {
+	// Message is the text of the notification
+	Message string `json:"message,omitempty"`
+	// Slack contains the Slack specific fields of the notification
+	Slack *SlackNotification `json:"slack,omitempty"`
+	// Teams contains the Microsoft Teams specific fields of the notification
+	Teams *TeamsNotification `json:"teams,omitempty"`
+	// Email contains the email specific fields of the notification
+	Email *EmailNotification `json:"email,omitempty"`
+	// Webhook contains the requests of the notification for each webhook service, keyed by the name of the service
+	Webhook map[string]WebhookNotification `json:"webhook,omitempty"`
+}
+
+// Service delivers notifications to recipients
+type Service interface {
+	// Send delivers a notification to a recipient, e.g. a channel
+	Send(notification Notification, recipient string) error
+}
+
+// Destination is a recipient of a service
+type Destination struct {
+	Service   string
+	Recipient string
+}
+
+// String returns the destination in the format <service>:<recipient>
+func (d Destination) String() string {
+	return d.Service + ":" + d.Recipient
+}
+
+// ParseDestination parses a destination in the format <service>:<recipient>
+func ParseDestination(s string) (Destination, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Destination{}, fmt.Errorf("recipient %s is not in the format <service>:<recipient>", s)
+	}
+	return Destination{Service: parts[0], Recipient: parts[1]}, nil
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// DefaultSlackAPI is the URL of the Slack Web API
+const DefaultSlackAPI = "https://slack.com/api"
+
+// SlackNotification contains the Slack specific fields of a notification
+type SlackNotification struct {
+	// Blocks is a JSON list of the layout blocks of the message
+	Blocks string `json:"blocks,omitempty"`
+	// Attachments is a JSON list of the legacy attachments of the message
+	Attachments string `json:"attachments,omitempty"`
+}
+
+// SlackOptions configure the Slack service
+type SlackOptions struct {
+	// Token is the token of the bot user posting the messages
+	Token string `json:"token"`
+	// API is the URL of the Slack Web API. Defaults to https://slack.com/api.
+	API string `json:"api,omitempty"`
+	// Username overrides the name of the bot
+	Username string `json:"username,omitempty"`
+	// Icon overrides the icon of the bot, either an emoji like :rocket: or the URL of an image
+	Icon string `json:"icon,omitempty"`
+}
+
+type slackService struct {
+	opts   SlackOptions
+	client *http.Client
+}
+
+type slackMessage struct {
+	Channel     string          `json:"channel"`
+	Text        string          `json:"text,omitempty"`
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	Attachments json.RawMessage `json:"attachments,omitempty"`
+	Username    string          `json:"username,omitempty"`
+	IconEmoji   string          `json:"icon_emoji,omitempty"`
+	IconURL     string          `json:"icon_url,omitempty"`
+}
+
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+func newSlackService(data []byte) (Service, error) {
+	var opts SlackOptions
+	if err := yaml.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+	return NewSlackService(opts), nil
+}
+
+// NewSlackService returns a service posting messages to Slack channels. The recipients are the names or IDs of the
+// channels.
+func NewSlackService(opts SlackOptions) Service {
+	if opts.API == "" {
+		opts.API = DefaultSlackAPI
+	}
+	opts.API = strings.TrimSuffix(opts.API, "/")
+	return &slackService{opts: opts, client: http.DefaultClient}
+}
+
+func (s *slackService) Send(notification Notification, recipient string) error {
+	msg := slackMessage{Channel: recipient, Text: notification.Message, Username: s.opts.Username}
+	if strings.HasPrefix(s.opts.Icon, ":") {
+		msg.IconEmoji = s.opts.Icon
+	} else {
+		msg.IconURL = s.opts.Icon
+	}
+	if notification.Slack != nil {
+		if notification.Slack.Blocks != "" {
+			if !json.Valid([]byte(notification.Slack.Blocks)) {
+				return fmt.Errorf("slack blocks are not valid JSON: %s", notification.Slack.Blocks)
+			}
+			msg.Blocks = json.RawMessage(notification.Slack.Blocks)
+		}
+		if notification.Slack.Attachments != "" {
+			if !json.Valid([]byte(notification.Slack.Attachments)) {
+				return fmt.Errorf("slack attachments are not valid JSON: %s", notification.Slack.Attachments)
+			}
+			msg.Attachments = json.RawMessage(notification.Slack.Attachments)
+		}
+	}
+	var res slackResponse
+	if err := postJSON(s.client, s.opts.API+"/chat.postMessage", map[string]string{"Authorization": "Bearer " + s.opts.Token}, msg, &res); err != nil {
+		return err
+	}
+	// the Web API reports errors in the body of successful responses
+	if !res.OK {
+		err := fmt.Errorf("slack rejected the message: %s", res.Error)
+		if res.Error == "ratelimited" || res.Error == "service_unavailable" {
+			return &temporaryError{err: err}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackService(t *testing.T) {
+	var messages []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var msg map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		switch msg["channel"] {
+		case "missing":
+			_, _ = fmt.Fprint(w, `{"ok": false, "error": "channel_not_found"}`)
+		case "busy":
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			messages = append(messages, msg)
+			_, _ = fmt.Fprint(w, `{"ok": true}`)
+		}
+	}))
+	defer server.Close()
+	service := NewSlackService(SlackOptions{Token: "secret", API: server.URL + "/", Icon: ":rocket:"})
+
+	assert.NoError(t, service.Send(Notification{
+		Message: "Application guestbook has degraded",
+		Slack:   &SlackNotification{Blocks: `[{"type": "divider"}]`},
+	}, "deployments"))
+	assert.Equal(t, []map[string]interface{}{{
+		"channel":    "deployments",
+		"text":       "Application guestbook has degraded",
+		"blocks":     []interface{}{map[string]interface{}{"type": "divider"}},
+		"icon_emoji": ":rocket:",
+	}}, messages)
+
+	err := service.Send(Notification{Message: "hello", Slack: &SlackNotification{Attachments: "invalid"}}, "deployments")
+	assert.EqualError(t, err, "slack attachments are not valid JSON: invalid")
+
+	err = service.Send(Notification{Message: "hello"}, "missing")
+	assert.EqualError(t, err, "slack rejected the message: channel_not_found")
+
+	err = service.Send(Notification{Message: "hello"}, "busy")
+	if assert.IsType(t, &temporaryError{}, err) {
+		assert.Equal(t, "1s", err.(*temporaryError).retryAfter.String())
+	}
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ghodss/yaml"
+)
+
+// TeamsNotification contains the Microsoft Teams specific fields of a notification
+type TeamsNotification struct {
+	// Title is the title of the card
+	Title string `json:"title,omitempty"`
+	// ThemeColor is the hex color of the card, e.g. FF0000
+	ThemeColor string `json:"themeColor,omitempty"`
+	// Facts is a JSON list of the facts of the card, e.g. [{"name": "Sync Status", "value": "Synced"}]
+	Facts string `json:"facts,omitempty"`
+	// Sections is a JSON list of additional sections of the card
+	Sections string `json:"sections,omitempty"`
+	// PotentialAction is a JSON list of the actions of the card, e.g. [{"@type": "OpenUri", "name": "Open", "targets": [...]}]
+	PotentialAction string `json:"potentialAction,omitempty"`
+}
+
+// TeamsOptions configure the Microsoft Teams service
+type TeamsOptions struct {
+	// RecipientURLs are the URLs of the incoming webhooks of each recipient
+	RecipientURLs map[string]string `json:"recipientUrls"`
+}
+
+type teamsService struct {
+	opts   TeamsOptions
+	client *http.Client
+}
+
+// teamsCard is a legacy actionable message card, which is the format incoming webhooks accept
+type teamsCard struct {
+	Type            string            `json:"@type"`
+	Context         string            `json:"@context"`
+	Summary         string            `json:"summary,omitempty"`
+	Title           string            `json:"title,omitempty"`
+	Text            string            `json:"text,omitempty"`
+	ThemeColor      string            `json:"themeColor,omitempty"`
+	Sections        []json.RawMessage `json:"sections,omitempty"`
+	PotentialAction json.RawMessage   `json:"potentialAction,omitempty"`
+}
+
+func newTeamsService(data []byte) (Service, error) {
+	var opts TeamsOptions
+	if err := yaml.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+	return NewTeamsService(opts), nil
+}
+
+// NewTeamsService returns a service posting cards to the incoming webhooks of Microsoft Teams channels. The recipients
+// are the names of the webhooks.
+func NewTeamsService(opts TeamsOptions) Service {
+	return &teamsService{opts: opts, client: http.DefaultClient}
+}
+
+func (s *teamsService) Send(notification Notification, recipient string) error {
+	url, ok := s.opts.RecipientURLs[recipient]
+	if !ok {
+		return fmt.Errorf("no webhook URL is configured for recipient %s", recipient)
+	}
+	card := teamsCard{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: notification.Message,
+		Text:    notification.Message,
+	}
+	if teams := notification.Teams; teams != nil {
+		card.Title = teams.Title
+		card.ThemeColor = teams.ThemeColor
+		if teams.Title != "" {
+			card.Summary = teams.Title
+		}
+		if teams.Facts != "" {
+			if !json.Valid([]byte(teams.Facts)) {
+				return fmt.Errorf("teams facts are not valid JSON: %s", teams.Facts)
+			}
+			section, _ := json.Marshal(map[string]json.RawMessage{"facts": json.RawMessage(teams.Facts)})
+			card.Sections = append(card.Sections, section)
+		}
+		if teams.Sections != "" {
+			var sections []json.RawMessage
+			if err := json.Unmarshal([]byte(teams.Sections), &sections); err != nil {
+				return fmt.Errorf("teams sections are not a valid JSON list: %v", err)
+			}
+			card.Sections = append(card.Sections, sections...)
+		}
+		if teams.PotentialAction != "" {
+			if !json.Valid([]byte(teams.PotentialAction)) {
+				return fmt.Errorf("teams potentialAction is not valid JSON: %s", teams.PotentialAction)
+			}
+			card.PotentialAction = json.RawMessage(teams.PotentialAction)
+		}
+	}
+	// incoming webhooks respond with a plain text body
+	return postJSON(s.client, url, nil, card, nil)
+}
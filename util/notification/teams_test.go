@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamsService(t *testing.T) {
+	var cards []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var card map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+		cards = append(cards, card)
+	}))
+	defer server.Close()
+	service := NewTeamsService(TeamsOptions{RecipientURLs: map[string]string{
+		"deployments": server.URL + "/deployments",
+		"unavailable": server.URL + "/unavailable",
+	}})
+
+	assert.NoError(t, service.Send(Notification{
+		Message: "Application guestbook has degraded",
+		Teams: &TeamsNotification{
+			Title:           "guestbook",
+			ThemeColor:      "FF0000",
+			Facts:           `[{"name": "Health", "value": "Degraded"}]`,
+			PotentialAction: `[{"@type": "OpenUri", "name": "Open"}]`,
+		},
+	}, "deployments"))
+	assert.Equal(t, []map[string]interface{}{{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    "guestbook",
+		"title":      "guestbook",
+		"text":       "Application guestbook has degraded",
+		"themeColor": "FF0000",
+		"sections": []interface{}{map[string]interface{}{
+			"facts": []interface{}{map[string]interface{}{"name": "Health", "value": "Degraded"}},
+		}},
+		"potentialAction": []interface{}{map[string]interface{}{"@type": "OpenUri", "name": "Open"}},
+	}}, cards)
+
+	err := service.Send(Notification{Message: "hello"}, "missing")
+	assert.EqualError(t, err, "no webhook URL is configured for recipient missing")
+
+	err = service.Send(Notification{Message: "hello"}, "unavailable")
+	assert.IsType(t, &temporaryError{}, err)
+}
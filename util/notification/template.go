@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/argoproj/argo-cd/util/gotemplate"
+)
+
+// render renders all the strings of an unrendered notification template as Go templates
+func render(tmpl interface{}, values map[string]interface{}) (*Notification, error) {
+	var renderValue func(value interface{}) (interface{}, error)
+	renderValue = func(value interface{}) (interface{}, error) {
+		switch value := value.(type) {
+		case string:
+			return execute(value, values)
+		case []interface{}:
+			res := make([]interface{}, len(value))
+			for i := range value {
+				item, err := renderValue(value[i])
+				if err != nil {
+					return nil, err
+				}
+				res[i] = item
+			}
+			return res, nil
+		case map[string]interface{}:
+			res := make(map[string]interface{}, len(value))
+			for k, v := range value {
+				item, err := renderValue(v)
+				if err != nil {
+					return nil, err
+				}
+				res[k] = item
+			}
+			return res, nil
+		default:
+			return value, nil
+		}
+	}
+	rendered, err := renderValue(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(rendered)
+	if err != nil {
+		return nil, err
+	}
+	var notification Notification
+	if err := json.Unmarshal(data, &notification); err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func execute(text string, values map[string]interface{}) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("").Funcs(gotemplate.FuncMap()).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %v", text, err)
+	}
+	return buf.String(), nil
+}
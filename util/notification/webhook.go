@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// DefaultWebhookSignatureHeader is the header containing the HMAC signature of the bodies of webhook requests
+const DefaultWebhookSignatureHeader = "X-Hub-Signature-256"
+
+// WebhookNotification is a request a notification sends to a webhook
+type WebhookNotification struct {
+	// Method is the method of the request. Defaults to POST.
+	Method string `json:"method,omitempty"`
+	// Body is the body of the request, usually JSON
+	Body string `json:"body,omitempty"`
+}
+
+// WebhookHeader is a header of webhook requests
+type WebhookHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookBasicAuth are the credentials of webhook requests
+type WebhookBasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// WebhookOptions configure a webhook service
+type WebhookOptions struct {
+	// URL is the base URL of the requests
+	URL string `json:"url"`
+	// Headers are added to all the requests, e.g. Content-Type: application/json
+	Headers []WebhookHeader `json:"headers,omitempty"`
+	// BasicAuth are the credentials of the requests, if any
+	BasicAuth *WebhookBasicAuth `json:"basicAuth,omitempty"`
+	// Secret is the key of the HMAC-SHA256 signatures of the bodies of the requests, if any
+	Secret string `json:"secret,omitempty"`
+	// SignatureHeader is the header of the signatures, in the format sha256=<hex digest>. Defaults to
+	// X-Hub-Signature-256.
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+}
+
+type webhookService struct {
+	name   string
+	opts   WebhookOptions
+	client *http.Client
+}
+
+func newWebhookService(name string, data []byte) (Service, error) {
+	var opts WebhookOptions
+	if err := yaml.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+	if opts.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	return NewWebhookService(name, opts), nil
+}
+
+// NewWebhookService returns a service sending the requests of notifications to a webhook. The requests are the
+// webhook notifications of the name of the service, and the recipients are paths relative to the URL of the webhook.
+func NewWebhookService(name string, opts WebhookOptions) Service {
+	if opts.SignatureHeader == "" {
+		opts.SignatureHeader = DefaultWebhookSignatureHeader
+	}
+	opts.URL = strings.TrimSuffix(opts.URL, "/")
+	return &webhookService{name: name, opts: opts, client: http.DefaultClient}
+}
+
+func (s *webhookService) Send(notification Notification, recipient string) error {
+	webhook, ok := notification.Webhook[s.name]
+	if !ok {
+		// the message is sent as it is to webhooks the template has no request for
+		data, err := json.Marshal(map[string]string{"message": notification.Message})
+		if err != nil {
+			return err
+		}
+		webhook = WebhookNotification{Body: string(data)}
+	}
+	method := webhook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	url := s.opts.URL
+	if recipient != "/" {
+		url += "/" + strings.TrimPrefix(recipient, "/")
+	}
+	req, err := http.NewRequest(method, url, strings.NewReader(webhook.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, header := range s.opts.Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+	if s.opts.BasicAuth != nil {
+		req.SetBasicAuth(s.opts.BasicAuth.Username, s.opts.BasicAuth.Password)
+	}
+	if s.opts.Secret != "" {
+		req.Header.Set(s.opts.SignatureHeader, "sha256="+sign(s.opts.Secret, []byte(webhook.Body)))
+	}
+	return do(s.client, req, nil)
+}
+
+// sign returns the hex encoded HMAC-SHA256 of data
+func sign(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "argocd:secret", username+":"+password)
+		assert.Equal(t, "deploy", r.Header.Get("X-Source"))
+		assert.Equal(t, "sha256="+sign("key", body), r.Header.Get("X-Signature"))
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+	}))
+	defer server.Close()
+	service := NewWebhookService("jira", WebhookOptions{
+		URL:             server.URL + "/api/",
+		Headers:         []WebhookHeader{{Name: "X-Source", Value: "deploy"}},
+		BasicAuth:       &WebhookBasicAuth{Username: "argocd", Password: "secret"},
+		Secret:          "key",
+		SignatureHeader: "X-Signature",
+	})
+
+	assert.NoError(t, service.Send(Notification{
+		Message: "Application guestbook has degraded",
+		Webhook: map[string]WebhookNotification{
+			"jira":  {Method: http.MethodPut, Body: `{"issue": "guestbook"}`},
+			"other": {Body: `{}`},
+		},
+	}, "/issues"))
+	assert.NoError(t, service.Send(Notification{Message: "hello"}, "/"))
+	assert.Equal(t, []string{
+		`PUT /api/issues {"issue": "guestbook"}`,
+		`POST /api {"message":"hello"}`,
+	}, requests)
+
+	err := service.Send(Notification{Message: "hello"}, "forbidden")
+	assert.EqualError(t, err, "POST /api/forbidden failed with status 403: ")
+}
+
+func TestSign(t *testing.T) {
+	// the example of the GitHub documentation of webhook signatures
+	assert.Equal(t, "757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17", sign("It's a Secret to Everybody", []byte("Hello, World!")))
+}
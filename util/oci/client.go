@@ -0,0 +1,187 @@
+package oci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/argoproj/argo-cd/util"
+	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/tgz"
+)
+
+const (
+	// URLPrefix is the prefix of the URLs of OCI repositories, e.g. oci://registry.example.com/org/manifests
+	URLPrefix = "oci://"
+	// DefaultTag is the tag of the artifact pulled if no target revision is set
+	DefaultTag = "latest"
+)
+
+var digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// IsOCIURL returns true if the URL is the URL of an OCI repository
+func IsOCIURL(url string) bool {
+	return strings.HasPrefix(url, URLPrefix)
+}
+
+// IsDigest returns true if the revision is the digest of a manifest rather than a tag
+func IsDigest(revision string) bool {
+	return digestRegexp.MatchString(revision)
+}
+
+type Creds struct {
+	Username string
+	Password string
+	CAPath   string
+	Insecure bool
+	// CloudIdentity authenticates to ECR, Artifact Registry or Azure Container Registry with the identity of the pod
+	// instead of the username and password
+	CloudIdentity bool
+}
+
+// Client pulls the artifacts of an OCI repository
+type Client interface {
+	// ResolveDigest returns the digest of the manifest of the artifact a tag refers to
+	ResolveDigest(tag string) (string, error)
+	// Pull pulls the artifact with the digest into a temporary directory which is removed by the closer. A single
+	// gzipped tarball in the artifact is extracted.
+	Pull(digest string) (string, util.Closer, error)
+	// ListTags returns the tags of the repository
+	ListTags() ([]string, error)
+}
+
+func NewClient(repoURL string, creds Creds) Client {
+	return &orasClient{reference: strings.TrimSuffix(strings.TrimPrefix(repoURL, URLPrefix), "/"), creds: creds}
+}
+
+// orasClient runs the ORAS CLI
+type orasClient struct {
+	reference string
+	creds     Creds
+}
+
+// run runs an oras command (e.g. "pull") with the credentials of the repository. The password is passed on stdin, so
+// it never shows up in the arguments of the process.
+func (c *orasClient) run(dir string, args ...string) (string, error) {
+	creds := c.creds
+	if creds.CloudIdentity {
+		var err error
+		creds.Username, creds.Password, err = defaultCloudIdentity.getCreds(strings.SplitN(c.reference, "/", 2)[0])
+		if err != nil {
+			return "", err
+		}
+	}
+	args = append(args, credsArgs(creds)...)
+	cmd := exec.Command("oras", args...)
+	cmd.Dir = dir
+	if creds.Password != "" {
+		cmd.Stdin = strings.NewReader(creds.Password)
+	}
+	out, err := executil.Run(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to run oras %s on %s: %v", args[0], c.reference, err)
+	}
+	return out, nil
+}
+
+func credsArgs(creds Creds) []string {
+	var args []string
+	if creds.Username != "" {
+		args = append(args, "--username", creds.Username)
+	}
+	if creds.Password != "" {
+		args = append(args, "--password-stdin")
+	}
+	if creds.CAPath != "" {
+		args = append(args, "--ca-file", creds.CAPath)
+	}
+	if creds.Insecure {
+		args = append(args, "--insecure")
+	}
+	return args
+}
+
+func (c *orasClient) ResolveDigest(tag string) (string, error) {
+	if tag == "" {
+		tag = DefaultTag
+	}
+	if IsDigest(tag) {
+		return tag, nil
+	}
+	out, err := c.run("", "manifest", "fetch", "--descriptor", c.reference+":"+tag)
+	if err != nil {
+		return "", err
+	}
+	var descriptor struct {
+		Digest string `json:"digest"`
+	}
+	if err := json.Unmarshal([]byte(out), &descriptor); err != nil {
+		return "", fmt.Errorf("failed to parse descriptor of %s:%s: %v", c.reference, tag, err)
+	}
+	if !IsDigest(descriptor.Digest) {
+		return "", fmt.Errorf("unexpected digest of %s:%s: %s", c.reference, tag, descriptor.Digest)
+	}
+	return descriptor.Digest, nil
+}
+
+func (c *orasClient) Pull(digest string) (string, util.Closer, error) {
+	if !IsDigest(digest) {
+		return "", nil, fmt.Errorf("invalid digest '%s'", digest)
+	}
+	tempDir, err := ioutil.TempDir("", "oci")
+	if err != nil {
+		return "", nil, err
+	}
+	closer := util.NewCloser(func() error {
+		return os.RemoveAll(tempDir)
+	})
+	if _, err := c.run(tempDir, "pull", c.reference+"@"+digest, "--output", "."); err != nil {
+		util.Close(closer)
+		return "", nil, err
+	}
+	if err := extractTarball(tempDir); err != nil {
+		util.Close(closer)
+		return "", nil, err
+	}
+	return tempDir, closer, nil
+}
+
+func (c *orasClient) ListTags() ([]string, error) {
+	out, err := c.run("", "repo", "tags", c.reference)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// extractTarball replaces a single gzipped tarball in the directory by its contents
+func extractTarball(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(infos) != 1 || !infos[0].Mode().IsRegular() {
+		return nil
+	}
+	name := infos[0].Name()
+	if !strings.HasSuffix(name, ".tar.gz") && !strings.HasSuffix(name, ".tgz") {
+		return nil
+	}
+	path := filepath.Join(dir, name)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if err := tgz.Extract(data, dir); err != nil {
+		return fmt.Errorf("failed to extract %s: %v", name, err)
+	}
+	return nil
+}
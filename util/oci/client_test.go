@@ -0,0 +1,67 @@
+package oci
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/util/tgz"
+)
+
+func TestIsDigest(t *testing.T) {
+	assert.True(t, IsDigest("sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"))
+	assert.False(t, IsDigest("sha256:a3ed95ca"))
+	assert.False(t, IsDigest("latest"))
+	assert.False(t, IsDigest("v1.0.0"))
+}
+
+func TestNewClient(t *testing.T) {
+	client := NewClient("oci://registry.example.com/org/manifests/", Creds{Username: "user", Password: "pass", Insecure: true}).(*orasClient)
+	assert.Equal(t, "registry.example.com/org/manifests", client.reference)
+	assert.Equal(t, []string{"--username", "user", "--password-stdin", "--insecure"}, credsArgs(client.creds))
+	assert.Empty(t, credsArgs(Creds{}))
+}
+
+func TestClient_ResolveDigest(t *testing.T) {
+	digest := "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"
+	// digests are not resolved, so no registry is needed
+	resolved, err := NewClient("oci://registry.example.com/org/manifests", Creds{}).ResolveDigest(digest)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, resolved)
+}
+
+func TestExtractTarball(t *testing.T) {
+	src, err := ioutil.TempDir("", "oci-src")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(src) }()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "kustomization.yaml"), []byte("resources: []"), 0644))
+	data, err := tgz.Compress(src)
+	assert.NoError(t, err)
+
+	t.Run("Tarball", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "oci")
+		assert.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifests.tar.gz"), data, 0644))
+
+		assert.NoError(t, extractTarball(dir))
+		_, err = os.Stat(filepath.Join(dir, "kustomization.yaml"))
+		assert.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, "manifests.tar.gz"))
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Files", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "oci")
+		assert.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifests.tgz"), data, 0644))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pod.yaml"), []byte("kind: Pod"), 0644))
+
+		assert.NoError(t, extractTarball(dir))
+		_, err = os.Stat(filepath.Join(dir, "manifests.tgz"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,402 @@
+package oci
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The registries whose tokens are exchanged for the identity of the pod in their cloud
+var (
+	ecrHostRegexp = regexp.MustCompile(`^\d{12}\.dkr\.ecr(-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+	garHostRegexp = regexp.MustCompile(`^([a-z0-9-]+-docker\.pkg\.dev|([a-z]+\.)?gcr\.io)$`)
+	acrHostRegexp = regexp.MustCompile(`^[a-z0-9-]+\.azurecr\.(io|cn|us)$`)
+)
+
+const (
+	// identityTokenRefreshMargin is the time before their expiry the tokens of the registries are refreshed at
+	identityTokenRefreshMargin = 5 * time.Minute
+	// gcpUsername and acrUsername are the usernames the tokens of Artifact Registry and Azure Container Registry are
+	// used with
+	gcpUsername = "oauth2accesstoken"
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+	// azureManagementResource is the resource of the Azure AD tokens which are exchanged for the tokens of registries
+	azureManagementResource = "https://management.azure.com/"
+)
+
+// registryToken is the username and password of a registry, which are valid until they expire
+type registryToken struct {
+	username  string
+	password  string
+	expiresAt time.Time
+}
+
+// cloudIdentity gets the tokens of the registries of ECR, Artifact Registry and Azure Container Registry for the
+// identity of the pod, i.e. the IAM role of its service account, its workload identity or its managed identity, and
+// caches them until shortly before they expire
+type cloudIdentity struct {
+	client *http.Client
+	getenv func(string) string
+	now    func() time.Time
+	// the endpoints of the cloud APIs, which are overridden by the tests
+	stsURL         func(region string) string
+	ecrURL         func(region string) string
+	gcpMetadataURL string
+	azureIMDSURL   string
+	acrURL         func(host string) string
+
+	// lock guards the cached tokens and the pending requests, and is never held during requests to the cloud APIs
+	lock   sync.Mutex
+	tokens map[string]registryToken
+	// pending are the requests of tokens in progress by registry host, which concurrent pulls from a registry wait for
+	pending map[string]*tokenRequest
+}
+
+// tokenRequest is a request of the token of a registry, whose result is available once done is closed
+type tokenRequest struct {
+	done  chan struct{}
+	token *registryToken
+	err   error
+}
+
+func newCloudIdentity() *cloudIdentity {
+	return &cloudIdentity{
+		client: &http.Client{Timeout: 30 * time.Second},
+		getenv: os.Getenv,
+		now:    time.Now,
+		stsURL: func(region string) string {
+			return fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+		},
+		ecrURL: func(region string) string {
+			return fmt.Sprintf("https://api.ecr.%s.amazonaws.com/", region)
+		},
+		gcpMetadataURL: "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token",
+		azureIMDSURL:   "http://169.254.169.254/metadata/identity/oauth2/token",
+		acrURL: func(host string) string {
+			return fmt.Sprintf("https://%s/oauth2/exchange", host)
+		},
+		tokens:  make(map[string]registryToken),
+		pending: make(map[string]*tokenRequest),
+	}
+}
+
+var defaultCloudIdentity = newCloudIdentity()
+
+// getCreds returns the username and password of a registry for the identity of the pod. Concurrent pulls from a registry
+// share one request of its token, while the tokens of other registries are requested independently.
+func (c *cloudIdentity) getCreds(host string) (string, string, error) {
+	c.lock.Lock()
+	if token, ok := c.tokens[host]; ok && c.now().Add(identityTokenRefreshMargin).Before(token.expiresAt) {
+		c.lock.Unlock()
+		return token.username, token.password, nil
+	}
+	req, ok := c.pending[host]
+	if !ok {
+		req = &tokenRequest{done: make(chan struct{})}
+		c.pending[host] = req
+	}
+	c.lock.Unlock()
+
+	if ok {
+		<-req.done
+	} else {
+		req.token, req.err = c.getToken(host)
+		c.lock.Lock()
+		if req.err == nil {
+			c.tokens[host] = *req.token
+		}
+		delete(c.pending, host)
+		c.lock.Unlock()
+		close(req.done)
+	}
+	if req.err != nil {
+		return "", "", req.err
+	}
+	return req.token.username, req.token.password, nil
+}
+
+// getToken requests the token of a registry from the API of its cloud
+func (c *cloudIdentity) getToken(host string) (*registryToken, error) {
+	var token *registryToken
+	var err error
+	if match := ecrHostRegexp.FindStringSubmatch(host); match != nil {
+		token, err = c.getECRToken(match[2])
+	} else if garHostRegexp.MatchString(host) {
+		token, err = c.getGCPToken()
+	} else if acrHostRegexp.MatchString(host) {
+		token, err = c.getACRToken(host)
+	} else {
+		return nil, fmt.Errorf("registry %s is neither an ECR, an Artifact Registry nor an Azure Container Registry registry", host)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the token of registry %s for the identity of the pod: %v", host, err)
+	}
+	return token, nil
+}
+
+// GetCloudIdentityCreds returns the username and password of an ECR, Artifact Registry or Azure Container Registry
+// registry for the identity of the pod, e.g. to pull the charts of Helm OCI registries
+func GetCloudIdentityCreds(host string) (string, string, error) {
+	return defaultCloudIdentity.getCreds(host)
+}
+
+// do sends a request and decodes its JSON response
+func (c *cloudIdentity) do(req *http.Request, res interface{}) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned %s: %s", req.Method, req.URL.Host, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if strings.Contains(resp.Header.Get("Content-Type"), "xml") {
+		return xml.Unmarshal(data, res)
+	}
+	return json.Unmarshal(data, res)
+}
+
+func (c *cloudIdentity) postForm(url string, form url.Values, res interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, res)
+}
+
+// awsCredentials are the credentials requests to the AWS APIs are signed with
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// getAWSCredentials returns the credentials of the IAM role of the service account of the pod, or the credentials of
+// the environment
+func (c *cloudIdentity) getAWSCredentials(region string) (*awsCredentials, error) {
+	roleARN, tokenFile := c.getenv("AWS_ROLE_ARN"), c.getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	if roleARN == "" || tokenFile == "" {
+		if accessKeyID := c.getenv("AWS_ACCESS_KEY_ID"); accessKeyID != "" {
+			return &awsCredentials{accessKeyID: accessKeyID, secretAccessKey: c.getenv("AWS_SECRET_ACCESS_KEY"), sessionToken: c.getenv("AWS_SESSION_TOKEN")}, nil
+		}
+		return nil, fmt.Errorf("the service account of the pod has no IAM role: AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE are not set")
+	}
+	webIdentityToken, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Credentials struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+			SessionToken    string `xml:"SessionToken"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	err = c.postForm(c.stsURL(region), url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {"argocd"},
+		"WebIdentityToken": {strings.TrimSpace(string(webIdentityToken))},
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &awsCredentials{accessKeyID: res.Credentials.AccessKeyID, secretAccessKey: res.Credentials.SecretAccessKey, sessionToken: res.Credentials.SessionToken}, nil
+}
+
+func (c *cloudIdentity) getECRToken(region string) (*registryToken, error) {
+	creds, err := c.getAWSCredentials(region)
+	if err != nil {
+		return nil, err
+	}
+	body := []byte("{}")
+	req, err := http.NewRequest(http.MethodPost, c.ecrURL(region), strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken")
+	signAWSRequest(req, body, creds, region, "ecr", c.now())
+	var res struct {
+		AuthorizationData []struct {
+			AuthorizationToken string  `json:"authorizationToken"`
+			ExpiresAt          float64 `json:"expiresAt"`
+		} `json:"authorizationData"`
+	}
+	if err := c.do(req, &res); err != nil {
+		return nil, err
+	}
+	if len(res.AuthorizationData) == 0 {
+		return nil, fmt.Errorf("no authorization token")
+	}
+	data, err := base64.StdEncoding.DecodeString(res.AuthorizationData[0].AuthorizationToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization token: %v", err)
+	}
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid authorization token")
+	}
+	return &registryToken{username: parts[0], password: parts[1], expiresAt: time.Unix(int64(res.AuthorizationData[0].ExpiresAt), 0)}, nil
+}
+
+// signAWSRequest signs a request with the signature version 4 of AWS
+func signAWSRequest(req *http.Request, body []byte, creds *awsCredentials, region string, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+	key := []byte("AWS4" + creds.secretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", creds.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// oauthToken is the response of the OAuth token endpoints of GCP and Azure. The managed identity endpoint of Azure
+// returns the expiry as a string.
+type oauthToken struct {
+	AccessToken string          `json:"access_token"`
+	ExpiresIn   json.RawMessage `json:"expires_in"`
+}
+
+func (t *oauthToken) expiresAt(now time.Time) time.Time {
+	seconds, err := strconv.ParseInt(strings.Trim(string(t.ExpiresIn), `"`), 10, 64)
+	if err != nil {
+		// the token is refreshed on the next pull
+		return now
+	}
+	return now.Add(time.Duration(seconds) * time.Second)
+}
+
+// getGCPToken returns the access token of the workload identity of the pod from the metadata server of GKE
+func (c *cloudIdentity) getGCPToken() (*registryToken, error) {
+	req, err := http.NewRequest(http.MethodGet, c.gcpMetadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var token oauthToken
+	if err := c.do(req, &token); err != nil {
+		return nil, err
+	}
+	return &registryToken{username: gcpUsername, password: token.AccessToken, expiresAt: token.expiresAt(c.now())}, nil
+}
+
+// getAzureToken returns an Azure AD token of the workload identity of the pod, or of its managed identity
+func (c *cloudIdentity) getAzureToken() (*oauthToken, error) {
+	var token oauthToken
+	if tokenFile := c.getenv("AZURE_FEDERATED_TOKEN_FILE"); tokenFile != "" {
+		assertion, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		authorityHost := c.getenv("AZURE_AUTHORITY_HOST")
+		if authorityHost == "" {
+			authorityHost = "https://login.microsoftonline.com/"
+		}
+		err = c.postForm(strings.TrimSuffix(authorityHost, "/")+"/"+c.getenv("AZURE_TENANT_ID")+"/oauth2/v2.0/token", url.Values{
+			"client_id":             {c.getenv("AZURE_CLIENT_ID")},
+			"client_assertion":      {strings.TrimSpace(string(assertion))},
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"grant_type":            {"client_credentials"},
+			"scope":                 {azureManagementResource + ".default"},
+		}, &token)
+		return &token, err
+	}
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {azureManagementResource}}
+	if clientID := c.getenv("AZURE_CLIENT_ID"); clientID != "" {
+		query.Set("client_id", clientID)
+	}
+	req, err := http.NewRequest(http.MethodGet, c.azureIMDSURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	err = c.do(req, &token)
+	return &token, err
+}
+
+// getACRToken exchanges the Azure AD token of the pod for a refresh token of the registry
+func (c *cloudIdentity) getACRToken(host string) (*registryToken, error) {
+	aadToken, err := c.getAzureToken()
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {host},
+		"access_token": {aadToken.AccessToken},
+	}
+	if tenantID := c.getenv("AZURE_TENANT_ID"); tenantID != "" {
+		form.Set("tenant", tenantID)
+	}
+	var res struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.postForm(c.acrURL(host), form, &res); err != nil {
+		return nil, err
+	}
+	return &registryToken{username: acrUsername, password: res.RefreshToken, expiresAt: aadToken.expiresAt(c.now())}, nil
+}
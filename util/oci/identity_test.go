@@ -0,0 +1,202 @@
+package oci
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCloudIdentity returns a cloudIdentity whose cloud APIs are served by the handler
+func newTestCloudIdentity(env map[string]string, handler http.HandlerFunc) (*cloudIdentity, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	c := newCloudIdentity()
+	c.getenv = func(name string) string { return env[name] }
+	c.stsURL = func(region string) string { return server.URL + "/sts/" + region }
+	c.ecrURL = func(region string) string { return server.URL + "/ecr/" + region }
+	c.gcpMetadataURL = server.URL + "/gcp"
+	c.azureIMDSURL = server.URL + "/imds"
+	c.acrURL = func(host string) string { return server.URL + "/acr/" + host }
+	return c, server
+}
+
+func writeTokenFile(t *testing.T, token string) (string, func()) {
+	dir, err := ioutil.TempDir("", "identity")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(token), 0600))
+	return path, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// the get-vanilla example of the signature version 4 test suite of AWS
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	assert.NoError(t, err)
+	creds := &awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
+
+func TestCloudIdentity_ECR(t *testing.T) {
+	tokenFile, cleanup := writeTokenFile(t, "web-identity-token")
+	defer cleanup()
+	requests := 0
+	c, server := newTestCloudIdentity(map[string]string{"AWS_ROLE_ARN": "arn:aws:iam::123456789012:role/argocd", "AWS_WEB_IDENTITY_TOKEN_FILE": tokenFile}, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/sts/eu-west-1":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+			assert.Equal(t, "arn:aws:iam::123456789012:role/argocd", r.Form.Get("RoleArn"))
+			assert.Equal(t, "web-identity-token", r.Form.Get("WebIdentityToken"))
+			w.Header().Set("Content-Type", "text/xml")
+			_, _ = fmt.Fprint(w, `<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>AKID</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>
+</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`)
+		case "/ecr/eu-west-1":
+			assert.Equal(t, "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken", r.Header.Get("X-Amz-Target"))
+			assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+			token := base64.StdEncoding.EncodeToString([]byte("AWS:password"))
+			_, _ = fmt.Fprintf(w, `{"authorizationData": [{"authorizationToken": "%s", "expiresAt": %d}]}`, token, time.Now().Add(12*time.Hour).Unix())
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	username, password, err := c.getCreds("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "AWS", username)
+	assert.Equal(t, "password", password)
+	assert.Equal(t, 2, requests)
+
+	// the token is cached until shortly before it expires
+	_, _, err = c.getCreds("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	c.now = func() time.Time { return time.Now().Add(12*time.Hour - time.Minute) }
+	_, _, err = c.getCreds("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, requests)
+}
+
+func TestCloudIdentity_ECRWithoutRole(t *testing.T) {
+	c, server := newTestCloudIdentity(nil, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+	defer server.Close()
+	_, _, err := c.getCreds("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	assert.EqualError(t, err, "failed to get the token of registry 123456789012.dkr.ecr.eu-west-1.amazonaws.com for the identity of the pod: the service account of the pod has no IAM role: AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE are not set")
+}
+
+func TestCloudIdentity_GCP(t *testing.T) {
+	c, server := newTestCloudIdentity(nil, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gcp", r.URL.Path)
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		_, _ = fmt.Fprint(w, `{"access_token": "access-token", "expires_in": 3599, "token_type": "Bearer"}`)
+	})
+	defer server.Close()
+	username, password, err := c.getCreds("europe-docker.pkg.dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "oauth2accesstoken", username)
+	assert.Equal(t, "access-token", password)
+	assert.True(t, c.tokens["europe-docker.pkg.dev"].expiresAt.After(time.Now().Add(time.Hour-time.Minute)))
+}
+
+func TestCloudIdentity_ACR(t *testing.T) {
+	t.Run("WorkloadIdentity", func(t *testing.T) {
+		tokenFile, cleanup := writeTokenFile(t, "federated-token")
+		defer cleanup()
+		env := map[string]string{"AZURE_FEDERATED_TOKEN_FILE": tokenFile, "AZURE_CLIENT_ID": "client", "AZURE_TENANT_ID": "tenant"}
+		c, server := newTestCloudIdentity(env, func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseForm())
+			switch r.URL.Path {
+			case "/tenant/oauth2/v2.0/token":
+				assert.Equal(t, "client", r.Form.Get("client_id"))
+				assert.Equal(t, "federated-token", r.Form.Get("client_assertion"))
+				_, _ = fmt.Fprint(w, `{"access_token": "aad-token", "expires_in": 3599}`)
+			case "/acr/registry.azurecr.io":
+				assert.Equal(t, "aad-token", r.Form.Get("access_token"))
+				assert.Equal(t, "registry.azurecr.io", r.Form.Get("service"))
+				assert.Equal(t, "tenant", r.Form.Get("tenant"))
+				_, _ = fmt.Fprint(w, `{"refresh_token": "refresh-token"}`)
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+		})
+		defer server.Close()
+		env["AZURE_AUTHORITY_HOST"] = server.URL + "/"
+		username, password, err := c.getCreds("registry.azurecr.io")
+		assert.NoError(t, err)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000000", username)
+		assert.Equal(t, "refresh-token", password)
+	})
+
+	t.Run("ManagedIdentity", func(t *testing.T) {
+		c, server := newTestCloudIdentity(nil, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/imds":
+				assert.Equal(t, "true", r.Header.Get("Metadata"))
+				assert.Equal(t, "https://management.azure.com/", r.URL.Query().Get("resource"))
+				_, _ = fmt.Fprint(w, `{"access_token": "aad-token", "expires_in": "3599"}`)
+			case "/acr/registry.azurecr.io":
+				assert.NoError(t, r.ParseForm())
+				assert.Equal(t, "aad-token", r.Form.Get("access_token"))
+				_, _ = fmt.Fprint(w, `{"refresh_token": "refresh-token"}`)
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+		})
+		defer server.Close()
+		_, password, err := c.getCreds("registry.azurecr.io")
+		assert.NoError(t, err)
+		assert.Equal(t, "refresh-token", password)
+		assert.True(t, c.tokens["registry.azurecr.io"].expiresAt.After(time.Now().Add(time.Hour-time.Minute)))
+	})
+}
+
+func TestCloudIdentity_UnknownRegistry(t *testing.T) {
+	_, _, err := newCloudIdentity().getCreds("registry.example.com")
+	assert.EqualError(t, err, "registry registry.example.com is neither an ECR, an Artifact Registry nor an Azure Container Registry registry")
+}
+
+func TestCloudIdentity_Concurrent(t *testing.T) {
+	var lock sync.Mutex
+	requests := 0
+	release := make(chan struct{})
+	c, server := newTestCloudIdentity(nil, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests++
+		lock.Unlock()
+		<-release
+		_, _ = fmt.Fprint(w, `{"access_token": "access-token", "expires_in": 3599, "token_type": "Bearer"}`)
+	})
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, password, err := c.getCreds("europe-docker.pkg.dev")
+			assert.NoError(t, err)
+			assert.Equal(t, "access-token", password)
+		}()
+	}
+	// the pending request of a registry does not block the other registries
+	_, _, err := c.getCreds("registry.example.com")
+	assert.Error(t, err)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 1, requests)
+}
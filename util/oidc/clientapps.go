@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+
+	servercache "github.com/argoproj/argo-cd/server/cache"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// ClientAppSet holds the client apps of all configured OIDC providers and routes login and
+// callback requests to the client app of the provider the user picked
+type ClientAppSet struct {
+	// defaultApp logs in with the provider configured in oidc.config (or Dex). If neither is
+	// configured, it is the client app of the first additional provider.
+	defaultApp *ClientApp
+	// apps holds the client apps of the additional OIDC providers by provider name
+	apps  map[string]*ClientApp
+	cache *servercache.Cache
+}
+
+// NewClientAppSet returns the client apps of all OIDC providers configured in Argo CD settings
+func NewClientAppSet(settings *settings.ArgoCDSettings, cache *servercache.Cache, dexServerAddr, baseHRef string) (*ClientAppSet, error) {
+	s := ClientAppSet{
+		apps:  make(map[string]*ClientApp),
+		cache: cache,
+	}
+	if settings.IsDexConfigured() || settings.OIDCConfig() != nil {
+		app, err := NewClientApp(settings, cache, dexServerAddr, baseHRef)
+		if err != nil {
+			return nil, err
+		}
+		s.defaultApp = app
+	}
+	for _, oidcConfig := range settings.AdditionalOIDCConfigs() {
+		oidcConfig := oidcConfig
+		app, err := NewProviderClientApp(settings, &oidcConfig, cache, baseHRef)
+		if err != nil {
+			return nil, err
+		}
+		s.apps[oidcConfig.Name] = app
+		if s.defaultApp == nil {
+			s.defaultApp = app
+		}
+	}
+	if s.defaultApp == nil {
+		return nil, fmt.Errorf("SSO is not configured")
+	}
+	return &s, nil
+}
+
+// app returns the client app of the provider with the given name. An empty name selects the default one.
+func (s *ClientAppSet) app(providerName string) *ClientApp {
+	if providerName == "" {
+		return s.defaultApp
+	}
+	return s.apps[providerName]
+}
+
+// HandleLogin starts the login flow with the provider selected by the 'provider' query parameter
+func (s *ClientAppSet) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	providerName := r.FormValue("provider")
+	app := s.app(providerName)
+	if app == nil {
+		http.Error(w, fmt.Sprintf("unknown OIDC provider '%s'", providerName), http.StatusBadRequest)
+		return
+	}
+	app.HandleLogin(w, r)
+}
+
+// HandleCallback completes the login flow with the provider the flow was started with
+func (s *ClientAppSet) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	app := s.defaultApp
+	if state := r.FormValue("state"); state != "" {
+		if appState, err := s.cache.GetOIDCState(state); err == nil && appState.Provider != "" {
+			if providerApp, ok := s.apps[appState.Provider]; ok {
+				app = providerApp
+			}
+		}
+	}
+	app.HandleCallback(w, r)
+}
@@ -1,6 +1,7 @@
 package oidc
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -37,6 +38,8 @@ type OIDCConfiguration struct {
 	ScopesSupported        []string `json:"scopes_supported"`
 	ResponseTypesSupported []string `json:"response_types_supported"`
 	GrantTypesSupported    []string `json:"grant_types_supported,omitempty"`
+	// CodeChallengeMethodsSupported lists the PKCE code challenge methods supported by the provider
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
 }
 
 type ClaimsRequest struct {
@@ -44,6 +47,11 @@ type ClaimsRequest struct {
 }
 
 type ClientApp struct {
+	// providerName is the name of the additional OIDC provider this application logs in with. Empty
+	// for the provider configured in oidc.config (or Dex).
+	providerName string
+	// oidcConfig is the configuration of the OIDC provider, nil if logging in with Dex
+	oidcConfig *settings.OIDCConfig
 	// OAuth2 client ID of this application (e.g. argo-cd)
 	clientID string
 	// OAuth2 client secret of this application
@@ -78,15 +86,28 @@ func GetScopesOrDefault(scopes []string) []string {
 // NewClientApp will register the Argo CD client app (either via Dex or external OIDC) and return an
 // object which has HTTP handlers for handling the HTTP responses for login and callback
 func NewClientApp(settings *settings.ArgoCDSettings, cache *servercache.Cache, dexServerAddr, baseHRef string) (*ClientApp, error) {
+	oidcConfig := settings.OIDCConfig()
+	useDex := settings.DexConfig != "" && settings.OIDCConfigRAW == ""
+	return newClientApp(settings, "", oidcConfig, settings.OAuth2ClientID(), settings.OAuth2ClientSecret(), settings.IssuerURL(), useDex, cache, dexServerAddr, baseHRef)
+}
+
+// NewProviderClientApp returns the client app which logs in with one of the additional OIDC providers
+func NewProviderClientApp(settings *settings.ArgoCDSettings, oidcConfig *settings.OIDCConfig, cache *servercache.Cache, baseHRef string) (*ClientApp, error) {
+	return newClientApp(settings, oidcConfig.Name, oidcConfig, oidcConfig.ClientID, oidcConfig.ClientSecret, oidcConfig.Issuer, false, cache, "", baseHRef)
+}
+
+func newClientApp(settings *settings.ArgoCDSettings, providerName string, oidcConfig *settings.OIDCConfig, clientID, clientSecret, issuerURL string, useDex bool, cache *servercache.Cache, dexServerAddr, baseHRef string) (*ClientApp, error) {
 	redirectURL, err := settings.RedirectURL()
 	if err != nil {
 		return nil, err
 	}
 	a := ClientApp{
-		clientID:     settings.OAuth2ClientID(),
-		clientSecret: settings.OAuth2ClientSecret(),
+		providerName: providerName,
+		oidcConfig:   oidcConfig,
+		clientID:     clientID,
+		clientSecret: clientSecret,
 		redirectURI:  redirectURL,
-		issuerURL:    settings.IssuerURL(),
+		issuerURL:    issuerURL,
 		baseHRef:     baseHRef,
 		cache:        cache,
 	}
@@ -111,7 +132,7 @@ func NewClientApp(settings *settings.ArgoCDSettings, cache *servercache.Cache, d
 			ExpectContinueTimeout: 1 * time.Second,
 		},
 	}
-	if settings.DexConfig != "" && settings.OIDCConfigRAW == "" {
+	if useDex {
 		a.client.Transport = dex.NewDexRewriteURLRoundTripper(dexServerAddr, a.client.Transport)
 	}
 	if os.Getenv(common.EnvVarSSODebug) == "1" {
@@ -119,7 +140,6 @@ func NewClientApp(settings *settings.ArgoCDSettings, cache *servercache.Cache, d
 	}
 
 	a.provider = NewOIDCProvider(a.issuerURL, a.client)
-	// NOTE: if we ever have replicas of Argo CD, this needs to switch to Redis cache
 	a.secureCookie = bool(u.Scheme == "https")
 	a.settings = settings
 	return &a, nil
@@ -139,13 +159,16 @@ func (a *ClientApp) oauth2Config(scopes []string) (*oauth2.Config, error) {
 	}, nil
 }
 
-// generateAppState creates an app state nonce
-func (a *ClientApp) generateAppState(returnURL string) string {
+// generateAppState creates an app state nonce. The PKCE code verifier and the nonce of the ID token
+// (if any) are kept along with the state until the authorization code is exchanged in the callback.
+// The state is kept in the shared cache, so that the callback may be handled by any replica of the
+// API server.
+func (a *ClientApp) generateAppState(returnURL string, codeVerifier string, nonce string) string {
 	randStr := rand.RandString(10)
 	if returnURL == "" {
 		returnURL = a.baseHRef
 	}
-	err := a.cache.SetOIDCState(randStr, &servercache.OIDCState{ReturnURL: returnURL})
+	err := a.cache.SetOIDCState(randStr, &servercache.OIDCState{ReturnURL: returnURL, CodeVerifier: codeVerifier, Nonce: nonce, Provider: a.providerName})
 	if err != nil {
 		// This should never happen with the in-memory cache
 		log.Errorf("Failed to set app state: %v", err)
@@ -176,9 +199,11 @@ func (a *ClientApp) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	scopes := make([]string, 0)
+	pkceEnabled := false
 	var opts []oauth2.AuthCodeOption
-	if config := a.settings.OIDCConfig(); config != nil {
+	if config := a.oidcConfig; config != nil {
 		scopes = config.RequestedScopes
+		pkceEnabled = config.EnablePKCEAuthentication
 		opts = AppendClaimsAuthenticationRequestParameter(opts, config.RequestedIDTokenClaims)
 	}
 	oauth2Config, err := a.oauth2Config(GetScopesOrDefault(scopes))
@@ -187,8 +212,23 @@ func (a *ClientApp) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	returnURL := r.FormValue("return_url")
-	stateNonce := a.generateAppState(returnURL)
 	grantType := InferGrantType(oidcConf)
+	codeVerifier := ""
+	nonce := ""
+	if grantType == GrantTypeAuthorizationCode {
+		if UsePKCE(oidcConf, pkceEnabled) {
+			codeVerifier, err = GenerateCodeVerifier()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			opts = AppendPKCEAuthCodeParameters(opts, codeVerifier)
+		}
+		// the ID token must be issued for this login request, rather than replayed from another one
+		nonce = rand.RandString(24)
+		opts = append(opts, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+	stateNonce := a.generateAppState(returnURL, codeVerifier, nonce)
 	var url string
 	switch grantType {
 	case GrantTypeAuthorizationCode:
@@ -203,6 +243,15 @@ func (a *ClientApp) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, url, http.StatusSeeOther)
 }
 
+// verifyNonce returns an error unless the ID token holds the nonce which has been sent with the login
+// request. States of login flows started before nonces were sent do not hold one.
+func verifyNonce(idToken *gooidc.IDToken, nonce string) error {
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return fmt.Errorf("nonce of the ID token does not match the login request")
+	}
+	return nil
+}
+
 // HandleCallback is the callback handler for an OAuth2 login flow
 func (a *ClientApp) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	oauth2Config, err := a.oauth2Config(nil)
@@ -228,7 +277,7 @@ func (a *ClientApp) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ctx := gooidc.ClientContext(r.Context(), a.client)
-	token, err := oauth2Config.Exchange(ctx, code)
+	token, err := ExchangeCode(ctx, oauth2Config, code, appState.CodeVerifier)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to get token: %v", err), http.StatusInternalServerError)
 		return
@@ -243,6 +292,10 @@ func (a *ClientApp) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("invalid session token: %v", err), http.StatusInternalServerError)
 		return
 	}
+	if err := verifyNonce(idToken, appState.Nonce); err != nil {
+		http.Error(w, fmt.Sprintf("invalid session token: %v", err), http.StatusUnauthorized)
+		return
+	}
 	flags := []string{"path=/"}
 	if a.secureCookie {
 		flags = append(flags, "Secure")
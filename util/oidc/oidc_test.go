@@ -1,16 +1,25 @@
 package oidc
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
+	gooidc "github.com/coreos/go-oidc"
 	"golang.org/x/oauth2"
 
 	"github.com/stretchr/testify/assert"
 
+	servercache "github.com/argoproj/argo-cd/server/cache"
 	"github.com/argoproj/argo-cd/server/settings/oidc"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
+	"github.com/argoproj/argo-cd/util/settings"
 )
 
 func TestInferGrantType(t *testing.T) {
@@ -65,3 +74,94 @@ func TestIDTokenClaims(t *testing.T) {
 
 	assert.Equal(t, "{\"id_token\":{\"groups\":{\"essential\":true}}}", values.Get("claims"))
 }
+
+func TestCodeChallenge(t *testing.T) {
+	assert.Equal(t, "zhxdSESTgqwVnrhG-CIX23Vu2_CdL0oIuG1QA2g2vNs", CodeChallenge("dBjftJeZ4CVP-mJ92K9CKMatm8Cl8GhKwzEIPm17kwfR"))
+
+	codeVerifier, err := GenerateCodeVerifier()
+	assert.NoError(t, err)
+	assert.Len(t, codeVerifier, 43)
+
+	opts := AppendPKCEAuthCodeParameters(nil, codeVerifier)
+	authCodeURL, err := url.Parse((&oauth2.Config{Endpoint: oauth2.Endpoint{AuthURL: "https://argocd-dev.onelogin.com/oidc/auth"}}).AuthCodeURL("TEST", opts...))
+	assert.NoError(t, err)
+	values, err := url.ParseQuery(authCodeURL.RawQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, CodeChallenge(codeVerifier), values.Get("code_challenge"))
+	assert.Equal(t, CodeChallengeMethodS256, values.Get("code_challenge_method"))
+}
+
+func TestUsePKCE(t *testing.T) {
+	assert.False(t, UsePKCE(&OIDCConfiguration{}, false))
+	assert.True(t, UsePKCE(&OIDCConfiguration{}, true))
+	assert.True(t, UsePKCE(&OIDCConfiguration{CodeChallengeMethodsSupported: []string{"plain", "S256"}}, false))
+	assert.False(t, UsePKCE(&OIDCConfiguration{CodeChallengeMethodsSupported: []string{"plain"}}, false))
+}
+
+func TestExchangeCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "abc", r.PostForm.Get("code"))
+		assert.Equal(t, "argo-cd-cli", r.PostForm.Get("client_id"))
+		assert.Equal(t, "verifier", r.PostForm.Get("code_verifier"))
+		_, _, hasBasicAuth := r.BasicAuth()
+		assert.False(t, hasBasicAuth)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access","token_type":"bearer","refresh_token":"refresh","expires_in":3600,"id_token":"id"}`))
+	}))
+	defer ts.Close()
+
+	oauth2Config := &oauth2.Config{
+		ClientID:    "argo-cd-cli",
+		Endpoint:    oauth2.Endpoint{TokenURL: ts.URL},
+		RedirectURL: "http://localhost:8085/auth/callback",
+	}
+	token, err := ExchangeCode(context.Background(), oauth2Config, "abc", "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.Equal(t, "id", token.Extra("id_token"))
+	assert.True(t, token.Expiry.After(time.Now()))
+}
+
+func TestClientAppSet(t *testing.T) {
+	cache := servercache.NewCache(
+		appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewInMemoryCache(time.Hour)), time.Hour),
+		time.Hour,
+		time.Hour,
+	)
+	argoCDSettings := &settings.ArgoCDSettings{
+		URL:              "https://argocd.example.com",
+		OIDCConfigRAW:    "name: Okta\nissuer: https://okta.example.com\nclientID: argo-cd\n",
+		OIDCProvidersRAW: "- name: Partner\n  issuer: https://partner.example.com\n  clientID: argo-cd-partner\n",
+	}
+	appSet, err := NewClientAppSet(argoCDSettings, cache, "", "/")
+	assert.NoError(t, err)
+	assert.Equal(t, "", appSet.app("").providerName)
+	assert.Equal(t, "Partner", appSet.app("Partner").providerName)
+	assert.Nil(t, appSet.app("Unknown"))
+
+	w := httptest.NewRecorder()
+	appSet.HandleLogin(w, httptest.NewRequest("GET", "/auth/login?provider=Unknown", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the state of the login flow remembers the provider to complete the flow with
+	state := appSet.app("Partner").generateAppState("/applications", "verifier", "nonce")
+	appState, err := cache.GetOIDCState(state)
+	assert.NoError(t, err)
+	assert.Equal(t, "Partner", appState.Provider)
+	assert.Equal(t, "verifier", appState.CodeVerifier)
+	assert.Equal(t, "nonce", appState.Nonce)
+
+	_, err = NewClientAppSet(&settings.ArgoCDSettings{URL: "https://argocd.example.com"}, cache, "", "/")
+	assert.Error(t, err)
+}
+
+func TestVerifyNonce(t *testing.T) {
+	assert.NoError(t, verifyNonce(&gooidc.IDToken{Nonce: "nonce"}, "nonce"))
+	assert.Error(t, verifyNonce(&gooidc.IDToken{Nonce: "other"}, "nonce"))
+	assert.Error(t, verifyNonce(&gooidc.IDToken{}, "nonce"))
+	// login flows started without a nonce
+	assert.NoError(t, verifyNonce(&gooidc.IDToken{}, ""))
+}
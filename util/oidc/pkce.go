@@ -0,0 +1,146 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// CodeChallengeMethodS256 is the PKCE code challenge method using a SHA-256 hash of the verifier
+	CodeChallengeMethodS256 = "S256"
+)
+
+// GenerateCodeVerifier returns a new PKCE code verifier (see https://tools.ietf.org/html/rfc7636#section-4.1)
+func GenerateCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the S256 PKCE code challenge for the code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AppendPKCEAuthCodeParameters appends the PKCE code challenge parameters derived from the code
+// verifier to `opts`
+func AppendPKCEAuthCodeParameters(opts []oauth2.AuthCodeOption, codeVerifier string) []oauth2.AuthCodeOption {
+	return append(opts,
+		oauth2.SetAuthURLParam("code_challenge", CodeChallenge(codeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", CodeChallengeMethodS256),
+	)
+}
+
+// UsePKCE returns whether or not the authorization code flow should be protected with PKCE. PKCE is
+// used if explicitly enabled, or if the provider advertises support for the S256 challenge method.
+func UsePKCE(oidcConf *OIDCConfiguration, enabled bool) bool {
+	if enabled {
+		return true
+	}
+	for _, method := range oidcConf.CodeChallengeMethodsSupported {
+		if method == CodeChallengeMethodS256 {
+			return true
+		}
+	}
+	return false
+}
+
+// ExchangeCode converts an authorization code into a token. It is an adaptation of
+// oauth2.Config::Exchange() which additionally supplies the PKCE code verifier, and omits the
+// client secret for public clients which do not have one.
+func ExchangeCode(ctx context.Context, c *oauth2.Config, code string, codeVerifier string) (*oauth2.Token, error) {
+	if codeVerifier == "" && c.ClientSecret != "" {
+		return c.Exchange(ctx, code)
+	}
+	v := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+		"client_id":  {c.ClientID},
+	}
+	if c.RedirectURL != "" {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+	if codeVerifier != "" {
+		v.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequest("POST", c.Endpoint.TokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	}
+	client := http.DefaultClient
+	if ctxClient, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && ctxClient != nil {
+		client = ctxClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: cannot fetch token: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: cannot fetch token: %v", err)
+	}
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		return nil, fmt.Errorf("oauth2: cannot fetch token: %v\nResponse: %s", resp.Status, body)
+	}
+	return parseTokenResponse(resp.Header.Get("Content-Type"), body)
+}
+
+func parseTokenResponse(contentType string, body []byte) (*oauth2.Token, error) {
+	var token *oauth2.Token
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-www-form-urlencoded", "text/plain":
+		vals, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		token = &oauth2.Token{
+			AccessToken:  vals.Get("access_token"),
+			TokenType:    vals.Get("token_type"),
+			RefreshToken: vals.Get("refresh_token"),
+		}
+		if expires, _ := strconv.Atoi(vals.Get("expires_in")); expires != 0 {
+			token.Expiry = time.Now().Add(time.Duration(expires) * time.Second)
+		}
+		token = token.WithExtra(vals)
+	default:
+		var raw map[string]interface{}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, err
+		}
+		token = &oauth2.Token{}
+		token.AccessToken, _ = raw["access_token"].(string)
+		token.TokenType, _ = raw["token_type"].(string)
+		token.RefreshToken, _ = raw["refresh_token"].(string)
+		if expires, ok := raw["expires_in"].(float64); ok && expires != 0 {
+			token.Expiry = time.Now().Add(time.Duration(expires) * time.Second)
+		}
+		token = token.WithExtra(raw)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2: server response missing access_token")
+	}
+	return token, nil
+}
@@ -1,10 +1,28 @@
 package password
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/argoproj/argo-cd/util/fips"
+)
+
+const (
+	// pbkdf2Prefix is the prefix of the hashes of the PBKDF2 hasher, which are formatted as
+	// $pbkdf2-sha256$<iterations>$<salt>$<hash>
+	pbkdf2Prefix = "$pbkdf2-sha256$"
+	// DefaultPbkdf2Iterations is the minimum number of iterations of the PBKDF2 hasher
+	DefaultPbkdf2Iterations = 100000
+	pbkdf2SaltLength        = 16
+	pbkdf2KeyLength         = 32
 )
 
 // PasswordHasher is an interface type to declare a general-purpose password management tool.
@@ -21,12 +39,21 @@ type BcryptPasswordHasher struct {
 	Cost int
 }
 
+// Pbkdf2PasswordHasher handles password hashing with PBKDF2-HMAC-SHA256, which is the hasher of FIPS mode.  The Iterations field represents work factor.
+type Pbkdf2PasswordHasher struct {
+	Iterations int
+}
+
 var _ PasswordHasher = DummyPasswordHasher{}
 var _ PasswordHasher = BcryptPasswordHasher{0}
+var _ PasswordHasher = Pbkdf2PasswordHasher{0}
 
-// PreferredHashers holds the list of preferred hashing algorithms, in order of most to least preferred.  Any password that does not validate with the primary algorithm will be considered "stale."  DO NOT ADD THE DUMMY HASHER FOR USE IN PRODUCTION.
-var preferredHashers = []PasswordHasher{
-	BcryptPasswordHasher{},
+// preferredHashers returns the list of preferred hashing algorithms, in order of most to least preferred.  Any password that does not validate with the primary algorithm will be considered "stale."  Bcrypt is not approved in FIPS mode, so PBKDF2 is preferred then.  DO NOT ADD THE DUMMY HASHER FOR USE IN PRODUCTION.
+func preferredHashers() []PasswordHasher {
+	if fips.Enabled() {
+		return []PasswordHasher{Pbkdf2PasswordHasher{}, BcryptPasswordHasher{}}
+	}
+	return []PasswordHasher{BcryptPasswordHasher{}, Pbkdf2PasswordHasher{}}
 }
 
 // HashPasswordWithHashers hashes an entered password using the first hasher in the provided list of hashers.
@@ -62,12 +89,12 @@ func verifyPasswordWithHashers(password, hashedPassword string, hashers []Passwo
 
 // HashPassword hashes against the current preferred hasher.
 func HashPassword(password string) (string, error) {
-	return hashPasswordWithHashers(password, preferredHashers)
+	return hashPasswordWithHashers(password, preferredHashers())
 }
 
 // VerifyPassword verifies an entered password against a hashed password and returns whether the hash is "stale" (i.e., was verified using the FIRST preferred hasher above).
 func VerifyPassword(password, hashedPassword string) (valid, stale bool) {
-	valid, stale = verifyPasswordWithHashers(password, hashedPassword, preferredHashers)
+	valid, stale = verifyPasswordWithHashers(password, hashedPassword, preferredHashers())
 	return
 }
 
@@ -99,3 +126,42 @@ func (h BcryptPasswordHasher) VerifyPassword(password, hashedPassword string) bo
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+// HashPassword creates a one-way digest ("hash") of a password.  A pseudorandom salt is included in the hash.  For security reasons, the work factor is always at _least_ DefaultPbkdf2Iterations.
+func (h Pbkdf2PasswordHasher) HashPassword(password string) (string, error) {
+	iterations := h.Iterations
+	if iterations < DefaultPbkdf2Iterations {
+		iterations = DefaultPbkdf2Iterations
+	}
+	salt := make([]byte, pbkdf2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := pbkdf2.Key([]byte(password), salt, iterations, pbkdf2KeyLength, sha256.New)
+	return fmt.Sprintf("%s%d$%s$%s", pbkdf2Prefix, iterations, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword validates whether a one-way digest ("hash") of a password was created from a given plaintext password.
+func (h Pbkdf2PasswordHasher) VerifyPassword(password, hashedPassword string) bool {
+	if !strings.HasPrefix(hashedPassword, pbkdf2Prefix) {
+		return false
+	}
+	parts := strings.Split(strings.TrimPrefix(hashedPassword, pbkdf2Prefix), "$")
+	if len(parts) != 3 {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	expectedKey, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil || len(expectedKey) == 0 {
+		return false
+	}
+	key := pbkdf2.Key([]byte(password), salt, iterations, len(expectedKey), sha256.New)
+	return subtle.ConstantTimeCompare(key, expectedKey) == 1
+}
@@ -1,7 +1,11 @@
 package password
 
 import (
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/argoproj/argo-cd/common"
 )
 
 func testPasswordHasher(t *testing.T, h PasswordHasher) {
@@ -25,6 +29,28 @@ func TestBcryptPasswordHasher(t *testing.T) {
 	testPasswordHasher(t, h)
 }
 
+func TestPbkdf2PasswordHasher(t *testing.T) {
+	h := Pbkdf2PasswordHasher{0}
+	testPasswordHasher(t, h)
+}
+
+func TestPasswordHashing_FIPS(t *testing.T) {
+	bcryptHash, _ := BcryptPasswordHasher{}.HashPassword("Hello, world!")
+	os.Setenv(common.EnvFIPSMode, "true")
+	defer os.Unsetenv(common.EnvFIPSMode)
+
+	hashedPassword, err := HashPassword("Hello, world!")
+	if err != nil || !strings.HasPrefix(hashedPassword, pbkdf2Prefix) {
+		t.Errorf("Password should have been hashed with PBKDF2 in FIPS mode, got %q: %v", hashedPassword, err)
+	}
+	if valid, stale := VerifyPassword("Hello, world!", hashedPassword); !valid || stale {
+		t.Errorf("Password should have validated against hash %q without being stale", hashedPassword)
+	}
+	if valid, stale := VerifyPassword("Hello, world!", bcryptHash); !valid || !stale {
+		t.Errorf("Password should have validated against the bcrypt hash %q and been stale", bcryptHash)
+	}
+}
+
 func TestDummyPasswordHasher(t *testing.T) {
 	h := DummyPasswordHasher{}
 	testPasswordHasher(t, h)
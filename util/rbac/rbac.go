@@ -5,6 +5,7 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -41,6 +42,8 @@ const (
 // * supports a custom JWT claims enforce function
 type Enforcer struct {
 	*casbin.Enforcer
+	// denyEnforcer fails the requests which are matched by an explicit deny policy
+	denyEnforcer       *casbin.Enforcer
 	adapter            *argocdAdapter
 	clientset          kubernetes.Interface
 	namespace          string
@@ -58,8 +61,11 @@ func NewEnforcer(clientset kubernetes.Interface, namespace, configmap string, cl
 	builtInModel := newBuiltInModel()
 	enf := casbin.NewEnforcer(builtInModel, adapter)
 	enf.EnableLog(false)
+	denyEnf := casbin.NewEnforcer(newDenyModel(), adapter)
+	denyEnf.EnableLog(false)
 	return &Enforcer{
 		Enforcer:           enf,
+		denyEnforcer:       denyEnf,
 		adapter:            adapter,
 		clientset:          clientset,
 		namespace:          namespace,
@@ -75,6 +81,12 @@ func (e *Enforcer) SetDefaultRole(roleName string) {
 	e.defaultRole = roleName
 }
 
+// EnableEnforce enables or disables the enforcement of the allows and the denies of the policies
+func (e *Enforcer) EnableEnforce(enable bool) {
+	e.Enforcer.EnableEnforce(enable)
+	e.denyEnforcer.EnableEnforce(enable)
+}
+
 // SetClaimsEnforcerFunc sets a claims enforce function during enforcement. The claims enforce function
 // can extract claims from JWT token and do the proper enforcement based on user, group or any information
 // available in the input parameter list
@@ -85,7 +97,7 @@ func (e *Enforcer) SetClaimsEnforcerFunc(claimsEnforcer ClaimsEnforcerFunc) {
 // Enforce is a wrapper around casbin.Enforce to additionally enforce a default role and a custom
 // claims function
 func (e *Enforcer) Enforce(rvals ...interface{}) bool {
-	return enforce(e.Enforcer, e.defaultRole, e.claimsEnforcerFunc, rvals...)
+	return enforce(e.Enforcer, e.denyEnforcer, e.defaultRole, e.claimsEnforcerFunc, rvals...)
 }
 
 // EnforceErr is a convenience helper to wrap a failed enforcement with a detailed error about the request
@@ -108,28 +120,57 @@ func (e *Enforcer) EnforceErr(rvals ...interface{}) error {
 // user-defined policy. This allows any explicit denies of the built-in, and user-defined policies
 // to override the run-time policy. Runs normal enforcement if run-time policy is empty.
 func (e *Enforcer) EnforceRuntimePolicy(policy string, rvals ...interface{}) bool {
-	var enf *casbin.Enforcer
-	var err error
-	if policy == "" {
-		enf = e.Enforcer
-	} else {
-		enf, err = casbin.NewEnforcerSafe(newBuiltInModel(), newAdapter(e.adapter.builtinPolicy, e.adapter.userDefinedPolicy, policy))
-		if err != nil {
-			log.Warnf("invalid runtime policy: %s", policy)
-			enf = e.Enforcer
-		}
-	}
-	return enforce(enf, e.defaultRole, e.claimsEnforcerFunc, rvals...)
+	enf, denyEnf := e.getRuntimeEnforcers(policy)
+	return enforce(enf, denyEnf, e.defaultRole, e.claimsEnforcerFunc, rvals...)
 }
 
-// enforce is a helper to additionally check a default role and invoke a custom claims enforcement function
-func enforce(enf *casbin.Enforcer, defaultRole string, claimsEnforcerFunc ClaimsEnforcerFunc, rvals ...interface{}) bool {
-	// check the default role
-	if defaultRole != "" && len(rvals) >= 2 {
-		if enf.Enforce(append([]interface{}{defaultRole}, rvals[1:]...)...) {
+// EnforceRuntimePolicySubjects enforces a request on behalf of any of the given subjects, e.g. of a
+// user and its groups, using a run-time policy like EnforceRuntimePolicy. An explicit deny of any of
+// the subjects takes precedence over the allows of all of them and of the default role.
+func (e *Enforcer) EnforceRuntimePolicySubjects(policy string, subjects []string, rvals ...interface{}) bool {
+	enf, denyEnf := e.getRuntimeEnforcers(policy)
+	for _, sub := range subjects {
+		if isDenied(denyEnf, append([]interface{}{sub}, rvals...)...) {
+			return false
+		}
+	}
+	for _, sub := range subjects {
+		if enforce(enf, denyEnf, e.defaultRole, e.claimsEnforcerFunc, append([]interface{}{sub}, rvals...)...) {
 			return true
 		}
 	}
+	return false
+}
+
+// getRuntimeEnforcers returns the enforcers of the allows and of the denies of the built-in,
+// user-defined and run-time policy
+func (e *Enforcer) getRuntimeEnforcers(policy string) (*casbin.Enforcer, *casbin.Enforcer) {
+	if policy == "" {
+		return e.Enforcer, e.denyEnforcer
+	}
+	adapter := newAdapter(e.adapter.builtinPolicy, e.adapter.userDefinedPolicy, policy)
+	enf, err := casbin.NewEnforcerSafe(newBuiltInModel(), adapter)
+	if err != nil {
+		log.Warnf("invalid runtime policy: %s", policy)
+		return e.Enforcer, e.denyEnforcer
+	}
+	denyEnf, err := casbin.NewEnforcerSafe(newDenyModel(), adapter)
+	if err != nil {
+		return e.Enforcer, e.denyEnforcer
+	}
+	return enf, denyEnf
+}
+
+// isDenied returns whether an explicit deny policy matches the request
+func isDenied(denyEnf *casbin.Enforcer, rvals ...interface{}) bool {
+	return denyEnf != nil && !denyEnf.Enforce(rvals...)
+}
+
+// enforce is a helper to additionally check a default role and invoke a custom claims enforcement function.
+// An explicit deny of a subject takes precedence over the allows of the default role. The claims
+// enforcement function, if set, is responsible for the default role and precedence of the subjects of
+// the claims.
+func enforce(enf *casbin.Enforcer, denyEnf *casbin.Enforcer, defaultRole string, claimsEnforcerFunc ClaimsEnforcerFunc, rvals ...interface{}) bool {
 	if len(rvals) == 0 {
 		return false
 	}
@@ -138,28 +179,48 @@ func enforce(enf *casbin.Enforcer, defaultRole string, claimsEnforcerFunc Claims
 	sub := rvals[0]
 	switch s := sub.(type) {
 	case string:
-		// noop
+		if isDenied(denyEnf, rvals...) {
+			return false
+		}
 	case jwt.Claims:
-		if claimsEnforcerFunc != nil && claimsEnforcerFunc(s, rvals...) {
-			return true
+		if claimsEnforcerFunc != nil {
+			if claimsEnforcerFunc(s, rvals...) {
+				return true
+			}
+			// allows of the default role must not override the denies found by the claims enforcement
+			return enf.Enforce(append([]interface{}{""}, rvals[1:]...)...)
 		}
 		rvals = append([]interface{}{""}, rvals[1:]...)
 	default:
 		rvals = append([]interface{}{""}, rvals[1:]...)
 	}
+	// check the default role
+	if defaultRole != "" && len(rvals) >= 2 {
+		if enf.Enforce(append([]interface{}{defaultRole}, rvals[1:]...)...) {
+			return true
+		}
+	}
 	return enf.Enforce(rvals...)
 }
 
 // SetBuiltinPolicy sets a built-in policy, which augments any user defined policies
 func (e *Enforcer) SetBuiltinPolicy(policy string) error {
 	e.adapter.builtinPolicy = policy
-	return e.LoadPolicy()
+	return e.loadPolicy()
 }
 
 // SetUserPolicy sets a user policy, augmenting the built-in policy
 func (e *Enforcer) SetUserPolicy(policy string) error {
 	e.adapter.userDefinedPolicy = policy
-	return e.LoadPolicy()
+	return e.loadPolicy()
+}
+
+// loadPolicy reloads the policies of the enforcers of allows and denies from the adapter
+func (e *Enforcer) loadPolicy() error {
+	if err := e.LoadPolicy(); err != nil {
+		return err
+	}
+	return e.denyEnforcer.LoadPolicy()
 }
 
 // newInformers returns an informer which watches updates on the rbac configmap
@@ -245,6 +306,87 @@ func ValidatePolicy(policy string) error {
 	return nil
 }
 
+// PolicyError is an error of a line of a CSV policy
+type PolicyError struct {
+	// Line is the line number of the policy, or zero if the error does not relate to a single line
+	Line    int
+	Message string
+}
+
+// LintPolicy reports the syntax errors of the lines of a CSV policy. The validate function, which may
+// be nil, additionally checks the fields of each well-formed line.
+func LintPolicy(policy string, validate func(fields []string) error) []PolicyError {
+	var errs []PolicyError
+	for i, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.TrimLeadingSpace = true
+		fields, err := reader.Read()
+		if err != nil {
+			errs = append(errs, PolicyError{Line: i + 1, Message: err.Error()})
+			continue
+		}
+		if err := lintPolicyFields(fields); err != nil {
+			errs = append(errs, PolicyError{Line: i + 1, Message: err.Error()})
+			continue
+		}
+		if validate != nil {
+			if err := validate(fields); err != nil {
+				errs = append(errs, PolicyError{Line: i + 1, Message: err.Error()})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		if err := ValidatePolicy(policy); err != nil {
+			errs = append(errs, PolicyError{Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+func lintPolicyFields(fields []string) error {
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return errors.New("policy has an empty field")
+		}
+	}
+	switch fields[0] {
+	case "p":
+		if len(fields) != 6 {
+			return fmt.Errorf("policy has %d fields instead of 6: p, <subject>, <resource>, <action>, <object>, <effect>", len(fields))
+		}
+		if effect := fields[5]; effect != "allow" && effect != "deny" {
+			return fmt.Errorf("policy effect '%s' is neither allow nor deny", effect)
+		}
+	case "g":
+		if len(fields) != 3 {
+			return fmt.Errorf("group has %d fields instead of 3: g, <subject>, <role>", len(fields))
+		}
+	default:
+		return fmt.Errorf("unknown policy type '%s', expected p or g", fields[0])
+	}
+	return nil
+}
+
+// EnforcePolicy enforces the built-in policy of the enforcer together with the given user-defined policy
+// and default role, without consulting the claims enforcement function. It allows checking a policy
+// before it is configured.
+func (e *Enforcer) EnforcePolicy(policy string, defaultRole string, rvals ...interface{}) (bool, error) {
+	adapter := newAdapter(e.adapter.builtinPolicy, policy, "")
+	enf, err := casbin.NewEnforcerSafe(newBuiltInModel(), adapter)
+	if err != nil {
+		return false, fmt.Errorf("policy syntax error: %v", err)
+	}
+	denyEnf, err := casbin.NewEnforcerSafe(newDenyModel(), adapter)
+	if err != nil {
+		return false, fmt.Errorf("policy syntax error: %v", err)
+	}
+	return enforce(enf, denyEnf, defaultRole, nil, rvals...), nil
+}
+
 // newBuiltInModel is a helper to return a brand new casbin model from the built-in model string.
 // This is needed because it is not safe to re-use the same casbin Model when instantiating new
 // casbin enforcers.
@@ -252,6 +394,14 @@ func newBuiltInModel() model.Model {
 	return casbin.NewModel(assets.ModelConf)
 }
 
+var policyEffectRegexp = regexp.MustCompile(`(?m)^e = .*$`)
+
+// newDenyModel returns a casbin model of the built-in model string whose enforcement fails if, and
+// only if, an explicit deny policy matches the request
+func newDenyModel() model.Model {
+	return casbin.NewModel(policyEffectRegexp.ReplaceAllString(assets.ModelConf, "e = !some(where (p.eft == deny))"))
+}
+
 // Casbin adapter which satisfies persist.Adapter interface
 type argocdAdapter struct {
 	builtinPolicy     string
@@ -2,6 +2,7 @@ package rbac
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -58,6 +59,7 @@ func TestBuiltinPolicyEnforcer(t *testing.T) {
 		{"admin", "applications", "get", "foo/bar"},
 		{"admin", "applications", "delete", "foo/bar"},
 		{"role:readonly", "applications", "get", "foo/bar"},
+		{"role:readonly", "applications", "logs", "foo/bar"},
 		{"role:admin", "applications", "get", "foo/bar"},
 		{"role:admin", "applications", "delete", "foo/bar"},
 	}
@@ -70,6 +72,7 @@ func TestBuiltinPolicyEnforcer(t *testing.T) {
 	disallowed := [][]interface{}{
 		{"role:readonly", "applications", "create", "foo/bar"},
 		{"role:readonly", "applications", "delete", "foo/bar"},
+		{"role:readonly", "applications", "exec", "foo/bar"},
 	}
 	for _, a := range disallowed {
 		if !assert.False(t, enf.Enforce(a...)) {
@@ -222,6 +225,30 @@ func TestDefaultRole(t *testing.T) {
 	assert.True(t, enf.Enforce("bob", "applications", "get", "foo/bar"))
 }
 
+// TestDenyPrecedence tests that explicit denies take precedence over allows, including those of the default role
+func TestDenyPrecedence(t *testing.T) {
+	kubeclientset := fake.NewSimpleClientset()
+	enf := NewEnforcer(kubeclientset, fakeNamespace, fakeConfgMapName, nil)
+	_ = enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV)
+	_ = enf.SetUserPolicy(`p, role:deployer, applications, sync, my-proj/*, allow
+p, role:deployer, applications, sync, my-proj/prod-payments, deny
+p, bob, applications, get, my-proj/prod-payments, deny
+g, alice, role:deployer
+g, payments-team, role:deployer`)
+	enf.SetDefaultRole("role:readonly")
+
+	assert.True(t, enf.Enforce("alice", "applications", "sync", "my-proj/guestbook"))
+	assert.False(t, enf.Enforce("alice", "applications", "sync", "my-proj/prod-payments"))
+	assert.True(t, enf.Enforce("bob", "applications", "get", "my-proj/guestbook"))
+	// the deny of bob overrides the allow of the default role
+	assert.False(t, enf.Enforce("bob", "applications", "get", "my-proj/prod-payments"))
+
+	// a deny of any of the subjects overrides the allows of the others
+	assert.True(t, enf.EnforceRuntimePolicySubjects("", []string{"bob", "payments-team"}, "applications", "sync", "my-proj/guestbook"))
+	assert.False(t, enf.EnforceRuntimePolicySubjects("", []string{"bob", "payments-team"}, "applications", "get", "my-proj/prod-payments"))
+	assert.False(t, enf.EnforceRuntimePolicySubjects("p, proj:my-proj:ops, applications, sync, my-proj/*, allow\ng, alice, proj:my-proj:ops", []string{"alice"}, "applications", "sync", "my-proj/prod-payments"))
+}
+
 // TestURLAsObjectName tests the ability to have a URL as an object name
 func TestURLAsObjectName(t *testing.T) {
 	kubeclientset := fake.NewSimpleClientset()
@@ -387,6 +414,53 @@ func TestValidatePolicy(t *testing.T) {
 	}
 }
 
+func TestLintPolicy(t *testing.T) {
+	policy := `# comment
+p, role:admin, projects, delete, *, allow
+p, role:admin, projects, delete, *
+p, role:admin, projects, delete, *, permit
+g, alice
+g, "alice, role:admin
+x, alice, role:admin
+p, role:admin, , delete, *, allow
+g, alice, role:admin`
+	errs := LintPolicy(policy, nil)
+	var lines []int
+	for _, err := range errs {
+		lines = append(lines, err.Line)
+	}
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8}, lines)
+
+	errs = LintPolicy("p, role:admin, projects, delete, *, allow\ng, alice, role:admin", func(fields []string) error {
+		if fields[0] == "g" {
+			return errors.New("no groups")
+		}
+		return nil
+	})
+	assert.Equal(t, []PolicyError{{Line: 2, Message: "no groups"}}, errs)
+}
+
+func TestEnforcePolicy(t *testing.T) {
+	kubeclientset := fake.NewSimpleClientset()
+	enf := NewEnforcer(kubeclientset, fakeNamespace, fakeConfgMapName, nil)
+	assert.NoError(t, enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV))
+
+	allowed, err := enf.EnforcePolicy("g, alice, role:admin", "", "alice", "applications", "delete", "foo/bar")
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = enf.EnforcePolicy("", "role:readonly", "bob", "applications", "get", "foo/bar")
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = enf.EnforcePolicy("", "role:readonly", "bob", "applications", "delete", "foo/bar")
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	_, err = enf.EnforcePolicy("this\ttoo", "", "bob", "applications", "get", "foo/bar")
+	assert.Error(t, err)
+
+	// the policy of the enforcer is not changed
+	assert.False(t, enf.Enforce("alice", "applications", "delete", "foo/bar"))
+}
+
 // TestEnforceErrorMessage ensures we give descriptive error message
 func TestEnforceErrorMessage(t *testing.T) {
 	kubeclientset := fake.NewSimpleClientset()
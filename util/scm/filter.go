@@ -0,0 +1,91 @@
+package scm
+
+import (
+	"context"
+	"regexp"
+)
+
+// Filter selects repositories. All of the conditions which are set must be met.
+type Filter struct {
+	// RepositoryMatch matches the name of the repository
+	RepositoryMatch *regexp.Regexp
+	// LabelMatch matches any of the labels (GitHub topics or GitLab tags) of the repository
+	LabelMatch *regexp.Regexp
+	// PathsExist are paths which must exist in the branch
+	PathsExist []string
+	// BranchMatch matches the name of the branch
+	BranchMatch *regexp.Regexp
+}
+
+func (f *Filter) matchesRepo(repo *Repository) bool {
+	if f.RepositoryMatch != nil && !f.RepositoryMatch.MatchString(repo.Repository) {
+		return false
+	}
+	if f.LabelMatch != nil {
+		matches := false
+		for _, label := range repo.Labels {
+			if f.LabelMatch.MatchString(label) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Filter) matchesBranch(ctx context.Context, provider Provider, branch *Repository) (bool, error) {
+	if f.BranchMatch != nil && !f.BranchMatch.MatchString(branch.Branch) {
+		return false, nil
+	}
+	for _, path := range f.PathsExist {
+		exists, err := provider.RepoHasPath(ctx, branch, path)
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ListRepos returns the branches of the repositories of a provider which match any of the filters, or all of them if
+// there are no filters. Only the default branches are considered unless allBranches is true.
+func ListRepos(ctx context.Context, provider Provider, filters []Filter, cloneProtocol string, allBranches bool) ([]*Repository, error) {
+	repos, err := provider.ListRepos(ctx, cloneProtocol)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		filters = []Filter{{}}
+	}
+	var res []*Repository
+	for _, repo := range repos {
+		var repoFilters []Filter
+		for _, filter := range filters {
+			if filter.matchesRepo(repo) {
+				repoFilters = append(repoFilters, filter)
+			}
+		}
+		if len(repoFilters) == 0 {
+			continue
+		}
+		branches, err := provider.GetBranches(ctx, repo, allBranches)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			for _, filter := range repoFilters {
+				matches, err := filter.matchesBranch(ctx, provider, branch)
+				if err != nil {
+					return nil, err
+				}
+				if matches {
+					res = append(res, branch)
+					break
+				}
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package scm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	repos    []*Repository
+	branches map[string][]string
+	paths    map[string]bool
+}
+
+func (p *fakeProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	return p.repos, nil
+}
+
+func (p *fakeProvider) GetBranches(ctx context.Context, repo *Repository, allBranches bool) ([]*Repository, error) {
+	var res []*Repository
+	for _, branch := range p.branches[repo.Repository] {
+		if allBranches || branch == repo.Branch {
+			r := *repo
+			r.Branch = branch
+			res = append(res, &r)
+		}
+	}
+	return res, nil
+}
+
+func (p *fakeProvider) RepoHasPath(ctx context.Context, repo *Repository, path string) (bool, error) {
+	return p.paths[repo.Repository+"/"+repo.Branch+"/"+path], nil
+}
+
+func TestListRepos(t *testing.T) {
+	provider := &fakeProvider{
+		repos: []*Repository{
+			{Repository: "guestbook", Branch: "master", Labels: []string{"demo"}},
+			{Repository: "helm-guestbook", Branch: "master"},
+			{Repository: "tools", Branch: "master"},
+		},
+		branches: map[string][]string{
+			"guestbook":      {"master", "feature"},
+			"helm-guestbook": {"master"},
+			"tools":          {"master"},
+		},
+		paths: map[string]bool{
+			"helm-guestbook/master/Chart.yaml": true,
+		},
+	}
+	names := func(repos []*Repository) []string {
+		var res []string
+		for _, repo := range repos {
+			res = append(res, repo.Repository+"@"+repo.Branch)
+		}
+		return res
+	}
+	ctx := context.Background()
+
+	repos, err := ListRepos(ctx, provider, nil, CloneProtocolHTTPS, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"guestbook@master", "helm-guestbook@master", "tools@master"}, names(repos))
+
+	repos, err = ListRepos(ctx, provider, []Filter{{RepositoryMatch: regexp.MustCompile("guestbook")}}, CloneProtocolHTTPS, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"guestbook@master", "guestbook@feature", "helm-guestbook@master"}, names(repos))
+
+	repos, err = ListRepos(ctx, provider, []Filter{
+		{LabelMatch: regexp.MustCompile("^demo$"), BranchMatch: regexp.MustCompile("^feature$")},
+		{PathsExist: []string{"Chart.yaml"}},
+	}, CloneProtocolHTTPS, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"guestbook@feature", "helm-guestbook@master"}, names(repos))
+}
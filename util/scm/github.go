@@ -0,0 +1,147 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGithubAPI is the API URL of github.com
+const DefaultGithubAPI = "https://api.github.com"
+
+type githubProvider struct {
+	api          string
+	organization string
+	client       *apiClient
+}
+
+type githubRepo struct {
+	Name          string   `json:"name"`
+	CloneURL      string   `json:"clone_url"`
+	SSHURL        string   `json:"ssh_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Topics        []string `json:"topics"`
+	Archived      bool     `json:"archived"`
+}
+
+type githubBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// NewGithubProvider returns a provider for the repositories of a GitHub organization. The API URL defaults to the one
+// of github.com, and the token is optional.
+func NewGithubProvider(api string, organization string, token string) Provider {
+	if api == "" {
+		api = DefaultGithubAPI
+	}
+	return &githubProvider{
+		api:          strings.TrimSuffix(api, "/"),
+		organization: organization,
+		client: &apiClient{
+			client: http.DefaultClient,
+			authorize: func(req *http.Request) {
+				req.Header.Set("Accept", "application/vnd.github.mercy-preview+json")
+				if token != "" {
+					req.Header.Set("Authorization", "token "+token)
+				}
+			},
+			rateLimitReset: githubRateLimitReset,
+		},
+	}
+}
+
+// githubRateLimitReset returns the rate limit reset time of responses rejected because the rate limit is exhausted
+func githubRateLimitReset(res *http.Response) (time.Time, bool) {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Now().Add(time.Minute), true
+	}
+	return time.Unix(reset, 0), true
+}
+
+func (p *githubProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	var res []*Repository
+	for page := 1; ; page++ {
+		var repos []githubRepo
+		found, err := p.client.get(ctx, fmt.Sprintf("%s/orgs/%s/repos?per_page=%d&page=%d", p.api, url.PathEscape(p.organization), pageSize, page), &repos)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("organization %s not found", p.organization)
+		}
+		for _, repo := range repos {
+			if repo.Archived {
+				continue
+			}
+			repoURL := repo.CloneURL
+			if cloneProtocol == CloneProtocolSSH {
+				repoURL = repo.SSHURL
+			}
+			res = append(res, &Repository{
+				Organization: p.organization,
+				Repository:   repo.Name,
+				URL:          repoURL,
+				Branch:       repo.DefaultBranch,
+				Labels:       repo.Topics,
+				id:           repo.Name,
+			})
+		}
+		if len(repos) < pageSize {
+			return res, nil
+		}
+	}
+}
+
+func (p *githubProvider) GetBranches(ctx context.Context, repo *Repository, allBranches bool) ([]*Repository, error) {
+	repoAPI := fmt.Sprintf("%s/repos/%s/%s", p.api, url.PathEscape(p.organization), url.PathEscape(repo.id))
+	var branches []githubBranch
+	if allBranches {
+		for page := 1; ; page++ {
+			var pageBranches []githubBranch
+			if _, err := p.client.get(ctx, fmt.Sprintf("%s/branches?per_page=%d&page=%d", repoAPI, pageSize, page), &pageBranches); err != nil {
+				return nil, err
+			}
+			branches = append(branches, pageBranches...)
+			if len(pageBranches) < pageSize {
+				break
+			}
+		}
+	} else {
+		var branch githubBranch
+		found, err := p.client.get(ctx, fmt.Sprintf("%s/branches/%s", repoAPI, url.PathEscape(repo.Branch)), &branch)
+		if err != nil {
+			return nil, err
+		}
+		// empty repositories have no default branch
+		if found {
+			branches = append(branches, branch)
+		}
+	}
+	var res []*Repository
+	for _, branch := range branches {
+		r := *repo
+		r.Branch = branch.Name
+		r.SHA = branch.Commit.SHA
+		res = append(res, &r)
+	}
+	return res, nil
+}
+
+func (p *githubProvider) RepoHasPath(ctx context.Context, repo *Repository, path string) (bool, error) {
+	return p.client.get(ctx, fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s",
+		p.api, url.PathEscape(p.organization), url.PathEscape(repo.id), strings.TrimPrefix(path, "/"), url.QueryEscape(repo.Branch)), nil)
+}
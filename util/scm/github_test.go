@@ -0,0 +1,89 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeGithubServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/orgs/argoproj/repos":
+			_, _ = fmt.Fprint(w, `[
+				{"name": "argo-cd", "clone_url": "https://github.com/argoproj/argo-cd.git", "ssh_url": "git@github.com:argoproj/argo-cd.git", "default_branch": "master", "topics": ["gitops"]},
+				{"name": "old", "clone_url": "https://github.com/argoproj/old.git", "default_branch": "master", "archived": true}
+			]`)
+		case "/repos/argoproj/argo-cd/branches/master":
+			_, _ = fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc"}}`)
+		case "/repos/argoproj/argo-cd/branches":
+			_, _ = fmt.Fprint(w, `[{"name": "master", "commit": {"sha": "abc"}}, {"name": "release-1.5", "commit": {"sha": "def"}}]`)
+		case "/repos/argoproj/argo-cd/contents/manifests":
+			assert.Equal(t, "master", r.URL.Query().Get("ref"))
+			_, _ = fmt.Fprint(w, `[]`)
+		case "/repos/argoproj/rate-limited/branches/master":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1600000000")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGithubProvider(t *testing.T) {
+	server := newFakeGithubServer(t)
+	defer server.Close()
+	provider := NewGithubProvider(server.URL, "argoproj", "secret")
+	ctx := context.Background()
+
+	repos, err := provider.ListRepos(ctx, CloneProtocolSSH)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 1) {
+		assert.Equal(t, "argo-cd", repos[0].Repository)
+		assert.Equal(t, "argoproj", repos[0].Organization)
+		assert.Equal(t, "git@github.com:argoproj/argo-cd.git", repos[0].URL)
+		assert.Equal(t, []string{"gitops"}, repos[0].Labels)
+	}
+
+	branches, err := provider.GetBranches(ctx, repos[0], false)
+	assert.NoError(t, err)
+	if assert.Len(t, branches, 1) {
+		assert.Equal(t, "abc", branches[0].SHA)
+	}
+	branches, err = provider.GetBranches(ctx, repos[0], true)
+	assert.NoError(t, err)
+	assert.Len(t, branches, 2)
+
+	exists, err := provider.RepoHasPath(ctx, repos[0], "manifests")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = provider.RepoHasPath(ctx, repos[0], "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGithubProvider_RateLimit(t *testing.T) {
+	server := newFakeGithubServer(t)
+	defer server.Close()
+	provider := NewGithubProvider(server.URL, "argoproj", "secret")
+
+	_, err := provider.GetBranches(context.Background(), &Repository{id: "rate-limited", Branch: "master"}, false)
+	if assert.IsType(t, &RateLimitError{}, err) {
+		assert.Equal(t, int64(1600000000), err.(*RateLimitError).Reset.Unix())
+	}
+}
+
+func TestGithubProvider_OrganizationNotFound(t *testing.T) {
+	server := newFakeGithubServer(t)
+	defer server.Close()
+	provider := NewGithubProvider(server.URL, "missing", "secret")
+
+	_, err := provider.ListRepos(context.Background(), CloneProtocolHTTPS)
+	assert.EqualError(t, err, "organization missing not found")
+}
@@ -0,0 +1,152 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGitlabAPI is the URL of gitlab.com
+const DefaultGitlabAPI = "https://gitlab.com"
+
+type gitlabProvider struct {
+	api              string
+	group            string
+	includeSubgroups bool
+	client           *apiClient
+}
+
+type gitlabProject struct {
+	ID            int      `json:"id"`
+	Path          string   `json:"path"`
+	HTTPURL       string   `json:"http_url_to_repo"`
+	SSHURL        string   `json:"ssh_url_to_repo"`
+	DefaultBranch string   `json:"default_branch"`
+	TagList       []string `json:"tag_list"`
+	Namespace     struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+type gitlabBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// NewGitlabProvider returns a provider for the projects of a GitLab group. The URL defaults to gitlab.com, and the
+// token is optional.
+func NewGitlabProvider(api string, group string, includeSubgroups bool, token string) Provider {
+	if api == "" {
+		api = DefaultGitlabAPI
+	}
+	return &gitlabProvider{
+		api:              strings.TrimSuffix(api, "/") + "/api/v4",
+		group:            group,
+		includeSubgroups: includeSubgroups,
+		client: &apiClient{
+			client: http.DefaultClient,
+			authorize: func(req *http.Request) {
+				if token != "" {
+					req.Header.Set("PRIVATE-TOKEN", token)
+				}
+			},
+			rateLimitReset: gitlabRateLimitReset,
+		},
+	}
+}
+
+// gitlabRateLimitReset returns the rate limit reset time of responses rejected because of the rate limit
+func gitlabRateLimitReset(res *http.Response) (time.Time, bool) {
+	if res.StatusCode != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(res.Header.Get("RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Now().Add(time.Minute), true
+	}
+	return time.Unix(reset, 0), true
+}
+
+func (p *gitlabProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	var res []*Repository
+	for page := 1; ; page++ {
+		var projects []gitlabProject
+		found, err := p.client.get(ctx, fmt.Sprintf("%s/groups/%s/projects?per_page=%d&page=%d&archived=false&include_subgroups=%t",
+			p.api, url.PathEscape(p.group), pageSize, page, p.includeSubgroups), &projects)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("group %s not found", p.group)
+		}
+		for _, project := range projects {
+			repoURL := project.HTTPURL
+			if cloneProtocol == CloneProtocolSSH {
+				repoURL = project.SSHURL
+			}
+			res = append(res, &Repository{
+				Organization: project.Namespace.FullPath,
+				Repository:   project.Path,
+				URL:          repoURL,
+				Branch:       project.DefaultBranch,
+				Labels:       project.TagList,
+				id:           strconv.Itoa(project.ID),
+			})
+		}
+		if len(projects) < pageSize {
+			return res, nil
+		}
+	}
+}
+
+func (p *gitlabProvider) GetBranches(ctx context.Context, repo *Repository, allBranches bool) ([]*Repository, error) {
+	branchesAPI := fmt.Sprintf("%s/projects/%s/repository/branches", p.api, repo.id)
+	var branches []gitlabBranch
+	if allBranches {
+		for page := 1; ; page++ {
+			var pageBranches []gitlabBranch
+			if _, err := p.client.get(ctx, fmt.Sprintf("%s?per_page=%d&page=%d", branchesAPI, pageSize, page), &pageBranches); err != nil {
+				return nil, err
+			}
+			branches = append(branches, pageBranches...)
+			if len(pageBranches) < pageSize {
+				break
+			}
+		}
+	} else if repo.Branch != "" {
+		var branch gitlabBranch
+		found, err := p.client.get(ctx, fmt.Sprintf("%s/%s", branchesAPI, url.PathEscape(repo.Branch)), &branch)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			branches = append(branches, branch)
+		}
+	}
+	var res []*Repository
+	for _, branch := range branches {
+		r := *repo
+		r.Branch = branch.Name
+		r.SHA = branch.Commit.ID
+		res = append(res, &r)
+	}
+	return res, nil
+}
+
+func (p *gitlabProvider) RepoHasPath(ctx context.Context, repo *Repository, path string) (bool, error) {
+	path = strings.Trim(path, "/")
+	// the files API only finds files, so directories are looked up in the repository tree
+	found, err := p.client.get(ctx, fmt.Sprintf("%s/projects/%s/repository/files/%s?ref=%s", p.api, repo.id, url.PathEscape(path), url.QueryEscape(repo.Branch)), nil)
+	if err != nil || found {
+		return found, err
+	}
+	var tree []interface{}
+	found, err = p.client.get(ctx, fmt.Sprintf("%s/projects/%s/repository/tree?path=%s&ref=%s&per_page=1", p.api, repo.id, url.QueryEscape(path), url.QueryEscape(repo.Branch)), &tree)
+	return found && len(tree) > 0, err
+}
@@ -0,0 +1,71 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitlabProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/api/v4/groups/example%2Fapps/projects":
+			assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+			_, _ = fmt.Fprint(w, `[{"id": 7, "path": "guestbook", "http_url_to_repo": "https://gitlab.com/example/apps/guestbook.git",
+				"ssh_url_to_repo": "git@gitlab.com:example/apps/guestbook.git", "default_branch": "main", "tag_list": ["demo"],
+				"namespace": {"full_path": "example/apps"}}]`)
+		case "/api/v4/projects/7/repository/branches/main":
+			_, _ = fmt.Fprint(w, `{"name": "main", "commit": {"id": "abc"}}`)
+		case "/api/v4/projects/7/repository/files/kustomization.yaml":
+			_, _ = fmt.Fprint(w, `{}`)
+		case "/api/v4/projects/7/repository/tree":
+			if r.URL.Query().Get("path") == "manifests" {
+				_, _ = fmt.Fprint(w, `[{"name": "deployment.yaml"}]`)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case "/api/v4/projects/8/repository/branches/main":
+			w.Header().Set("RateLimit-Reset", "1600000000")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	provider := NewGitlabProvider(server.URL, "example/apps", true, "secret")
+	ctx := context.Background()
+
+	repos, err := provider.ListRepos(ctx, CloneProtocolHTTPS)
+	assert.NoError(t, err)
+	if !assert.Len(t, repos, 1) {
+		return
+	}
+	assert.Equal(t, &Repository{
+		Organization: "example/apps",
+		Repository:   "guestbook",
+		URL:          "https://gitlab.com/example/apps/guestbook.git",
+		Branch:       "main",
+		Labels:       []string{"demo"},
+		id:           "7",
+	}, repos[0])
+
+	branches, err := provider.GetBranches(ctx, repos[0], false)
+	assert.NoError(t, err)
+	if assert.Len(t, branches, 1) {
+		assert.Equal(t, "abc", branches[0].SHA)
+	}
+
+	for path, exists := range map[string]bool{"kustomization.yaml": true, "manifests": true, "missing": false} {
+		found, err := provider.RepoHasPath(ctx, repos[0], path)
+		assert.NoError(t, err)
+		assert.Equal(t, exists, found, path)
+	}
+
+	_, err = provider.GetBranches(ctx, &Repository{id: "8", Branch: "main"}, false)
+	assert.IsType(t, &RateLimitError{}, err)
+}
@@ -0,0 +1,184 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PullRequest is an open pull request (or GitLab merge request) of a repository
+type PullRequest struct {
+	// Number is the number of the pull request, or the IID of the merge request
+	Number int
+	// Branch is the name of the source branch
+	Branch string
+	// HeadSHA is the SHA of the latest commit of the source branch
+	HeadSHA string
+	// Labels are the labels of the pull request
+	Labels []string
+}
+
+// PullRequestService lists the open pull requests of a repository
+type PullRequestService interface {
+	// List returns the open pull requests
+	List(ctx context.Context) ([]*PullRequest, error)
+}
+
+type githubPullRequestService struct {
+	api    string
+	owner  string
+	repo   string
+	labels []string
+	client *apiClient
+}
+
+type githubPullRequest struct {
+	Number int `json:"number"`
+	Head   struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// NewGithubPullRequestService returns a service for the pull requests of a GitHub repository which have all of the
+// labels. The API URL defaults to the one of github.com, and the token is optional.
+func NewGithubPullRequestService(api string, owner string, repo string, token string, labels []string) PullRequestService {
+	if api == "" {
+		api = DefaultGithubAPI
+	}
+	return &githubPullRequestService{
+		api:    strings.TrimSuffix(api, "/"),
+		owner:  owner,
+		repo:   repo,
+		labels: labels,
+		client: &apiClient{
+			client: http.DefaultClient,
+			authorize: func(req *http.Request) {
+				if token != "" {
+					req.Header.Set("Authorization", "token "+token)
+				}
+			},
+			rateLimitReset: githubRateLimitReset,
+		},
+	}
+}
+
+func (s *githubPullRequestService) List(ctx context.Context) ([]*PullRequest, error) {
+	var res []*PullRequest
+	for page := 1; ; page++ {
+		var pulls []githubPullRequest
+		found, err := s.client.get(ctx, fmt.Sprintf("%s/repos/%s/%s/pulls?state=open&per_page=%d&page=%d",
+			s.api, url.PathEscape(s.owner), url.PathEscape(s.repo), pageSize, page), &pulls)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("repository %s/%s not found", s.owner, s.repo)
+		}
+		for _, pull := range pulls {
+			var labels []string
+			for _, label := range pull.Labels {
+				labels = append(labels, label.Name)
+			}
+			if !hasLabels(labels, s.labels) {
+				continue
+			}
+			res = append(res, &PullRequest{
+				Number:  pull.Number,
+				Branch:  pull.Head.Ref,
+				HeadSHA: pull.Head.SHA,
+				Labels:  labels,
+			})
+		}
+		if len(pulls) < pageSize {
+			return res, nil
+		}
+	}
+}
+
+type gitlabPullRequestService struct {
+	api     string
+	project string
+	labels  []string
+	client  *apiClient
+}
+
+type gitlabMergeRequest struct {
+	IID          int      `json:"iid"`
+	SourceBranch string   `json:"source_branch"`
+	SHA          string   `json:"sha"`
+	Labels       []string `json:"labels"`
+}
+
+// NewGitlabPullRequestService returns a service for the merge requests of a GitLab project which have all of the
+// labels. The URL defaults to gitlab.com, and the token is optional.
+func NewGitlabPullRequestService(api string, project string, token string, labels []string) PullRequestService {
+	if api == "" {
+		api = DefaultGitlabAPI
+	}
+	return &gitlabPullRequestService{
+		api:     strings.TrimSuffix(api, "/") + "/api/v4",
+		project: project,
+		labels:  labels,
+		client: &apiClient{
+			client: http.DefaultClient,
+			authorize: func(req *http.Request) {
+				if token != "" {
+					req.Header.Set("PRIVATE-TOKEN", token)
+				}
+			},
+			rateLimitReset: gitlabRateLimitReset,
+		},
+	}
+}
+
+func (s *gitlabPullRequestService) List(ctx context.Context) ([]*PullRequest, error) {
+	var res []*PullRequest
+	for page := 1; ; page++ {
+		var mergeRequests []gitlabMergeRequest
+		query := fmt.Sprintf("state=opened&per_page=%d&page=%d", pageSize, page)
+		if len(s.labels) > 0 {
+			query += "&labels=" + url.QueryEscape(strings.Join(s.labels, ","))
+		}
+		found, err := s.client.get(ctx, fmt.Sprintf("%s/projects/%s/merge_requests?%s", s.api, url.PathEscape(s.project), query), &mergeRequests)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("project %s not found", s.project)
+		}
+		for _, mergeRequest := range mergeRequests {
+			res = append(res, &PullRequest{
+				Number:  mergeRequest.IID,
+				Branch:  mergeRequest.SourceBranch,
+				HeadSHA: mergeRequest.SHA,
+				Labels:  mergeRequest.Labels,
+			})
+		}
+		if len(mergeRequests) < pageSize {
+			return res, nil
+		}
+	}
+}
+
+// hasLabels returns whether all of the required labels are in the labels
+func hasLabels(labels []string, required []string) bool {
+	for _, r := range required {
+		found := false
+		for _, label := range labels {
+			if label == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubPullRequestService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/argoproj/argo-cd/pulls":
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			_, _ = fmt.Fprint(w, `[
+				{"number": 1, "head": {"ref": "feature/one", "sha": "abc"}, "labels": [{"name": "preview"}]},
+				{"number": 2, "head": {"ref": "feature/two", "sha": "def"}, "labels": []}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	pulls, err := NewGithubPullRequestService(server.URL, "argoproj", "argo-cd", "secret", nil).List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, pulls, 2)
+
+	pulls, err = NewGithubPullRequestService(server.URL, "argoproj", "argo-cd", "secret", []string{"preview"}).List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*PullRequest{{Number: 1, Branch: "feature/one", HeadSHA: "abc", Labels: []string{"preview"}}}, pulls)
+
+	_, err = NewGithubPullRequestService(server.URL, "argoproj", "missing", "secret", nil).List(ctx)
+	assert.EqualError(t, err, "repository argoproj/missing not found")
+}
+
+func TestGitlabPullRequestService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/example%2Fguestbook/merge_requests":
+			assert.Equal(t, "opened", r.URL.Query().Get("state"))
+			assert.Equal(t, "preview", r.URL.Query().Get("labels"))
+			_, _ = fmt.Fprint(w, `[{"iid": 3, "source_branch": "feature", "sha": "abc", "labels": ["preview"]}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	pulls, err := NewGitlabPullRequestService(server.URL, "example/guestbook", "secret", []string{"preview"}).List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*PullRequest{{Number: 3, Branch: "feature", HeadSHA: "abc", Labels: []string{"preview"}}}, pulls)
+}
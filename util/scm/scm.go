@@ -0,0 +1,90 @@
+package scm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	// CloneProtocolHTTPS selects the HTTPS clone URLs of repositories
+	CloneProtocolHTTPS = "https"
+	// CloneProtocolSSH selects the SSH clone URLs of repositories
+	CloneProtocolSSH = "ssh"
+
+	pageSize = 100
+)
+
+// Repository is a branch of a repository of an SCM provider
+type Repository struct {
+	Organization string
+	Repository   string
+	URL          string
+	Branch       string
+	SHA          string
+	Labels       []string
+	// id identifies the repository in the API of the provider
+	id string
+}
+
+// Provider lists the repositories of an organization of an SCM provider
+type Provider interface {
+	// ListRepos returns the default branches of the repositories of the organization, without their SHA
+	ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error)
+	// GetBranches returns all branches of a repository if allBranches is true, otherwise just its default branch
+	GetBranches(ctx context.Context, repo *Repository, allBranches bool) ([]*Repository, error)
+	// RepoHasPath returns whether a file or directory exists in the branch of a repository
+	RepoHasPath(ctx context.Context, repo *Repository, path string) (bool, error)
+}
+
+// RateLimitError is returned if the rate limit of the API of a provider is exceeded
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("API rate limit exceeded until %s", e.Reset.Format(time.RFC3339))
+}
+
+// apiClient makes authenticated requests to the REST API of a provider
+type apiClient struct {
+	client *http.Client
+	// authorize adds the credentials to a request
+	authorize func(req *http.Request)
+	// rateLimitReset returns the time the rate limit is reset, if a response was rejected because of the rate limit
+	rateLimitReset func(res *http.Response) (time.Time, bool)
+}
+
+// get unmarshals the JSON response of a GET request into res, and returns false if the resource does not exist
+func (c *apiClient) get(ctx context.Context, url string, res interface{}) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	c.authorize(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if reset, ok := c.rateLimitReset(resp); ok {
+		return false, &RateLimitError{Reset: reset}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("GET %s failed with status %d: %s", req.URL.Path, resp.StatusCode, string(body))
+	}
+	if res == nil {
+		return true, nil
+	}
+	return true, json.NewDecoder(resp.Body).Decode(res)
+}
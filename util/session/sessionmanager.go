@@ -6,16 +6,21 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/argoproj/argo-cd/server/rbacpolicy"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/argoproj/argo-cd/common"
+	servercache "github.com/argoproj/argo-cd/server/cache"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
 	"github.com/argoproj/argo-cd/util/dex"
 	httputil "github.com/argoproj/argo-cd/util/http"
 	jwtutil "github.com/argoproj/argo-cd/util/jwt"
@@ -29,6 +34,45 @@ type SessionManager struct {
 	settingsMgr *settings.SettingsManager
 	client      *http.Client
 	prov        oidcutil.Provider
+	// additionalProvs holds the providers of the additional OIDC issuers by issuer URL
+	additionalProvs     map[string]oidcutil.Provider
+	additionalProvsLock sync.Mutex
+	revocations         TokenRevocationStore
+	usage               TokenUsageStore
+	loginSessions       LoginSessionStore
+	// tokenLastUsed holds the time the usage of a token has been recorded the last time by token ID
+	tokenLastUsed     map[string]time.Time
+	tokenLastUsedLock sync.Mutex
+}
+
+// TokenRevocationStore keeps track of revoked tokens
+type TokenRevocationStore interface {
+	// RevokeToken revokes the token with the given ID (jti) for the given duration, or forever if negative
+	RevokeToken(id string, expiration time.Duration) error
+	// IsTokenRevoked returns whether or not the token with the given ID (jti) has been revoked
+	IsTokenRevoked(id string) (bool, error)
+	// RevokeSessions revokes all tokens of the subject which have been issued until revokedAt
+	RevokeSessions(subject string, revokedAt time.Time) error
+	// GetSessionsRevokedAt returns the time until which all tokens of the subject have been revoked, if ever
+	GetSessionsRevokedAt(subject string) (*time.Time, error)
+}
+
+// TokenUsageStore keeps track of when tokens have been used
+type TokenUsageStore interface {
+	// SetTokenLastUsed records when the token with the given ID (jti) has been used the last time
+	SetTokenLastUsed(id string, usedAt time.Time) error
+	// GetTokenLastUsed returns when the token with the given ID (jti) has been used the last time, if ever
+	GetTokenLastUsed(id string) (*time.Time, error)
+}
+
+// LoginSessionStore keeps track of the login sessions of local accounts
+type LoginSessionStore interface {
+	// AddLoginSession records a login session of the account
+	AddLoginSession(account string, session servercache.LoginSession) error
+	// GetLoginSessions returns the login sessions of the account which have not expired
+	GetLoginSessions(account string) ([]servercache.LoginSession, error)
+	// DeleteLoginSession removes the login session with the given ID (jti) from the sessions of the account
+	DeleteLoginSession(account string, id string) error
 }
 
 const (
@@ -39,12 +83,17 @@ const (
 	invalidLoginError  = "Invalid username or password"
 	blankPasswordError = "Blank passwords are not allowed"
 	accountDisabled    = "Account %s is disabled"
+
+	// tokenUsageResolution is the minimum duration between two recordings of the usage of a token
+	tokenUsageResolution = time.Minute
 )
 
 // NewSessionManager creates a new session manager from Argo CD settings
 func NewSessionManager(settingsMgr *settings.SettingsManager, dexServerAddr string) *SessionManager {
 	s := SessionManager{
-		settingsMgr: settingsMgr,
+		settingsMgr:     settingsMgr,
+		additionalProvs: make(map[string]oidcutil.Provider),
+		tokenLastUsed:   make(map[string]time.Time),
 	}
 	settings, err := settingsMgr.GetSettings()
 	if err != nil {
@@ -76,6 +125,128 @@ func NewSessionManager(settingsMgr *settings.SettingsManager, dexServerAddr stri
 	return &s
 }
 
+// SetTokenRevocationStore sets the store which keeps track of revoked tokens. Tokens cannot be
+// revoked, and are not checked for being revoked, unless a store is set.
+func (mgr *SessionManager) SetTokenRevocationStore(store TokenRevocationStore) {
+	mgr.revocations = store
+}
+
+// SetTokenUsageStore sets the store which keeps track of when tokens have been used. The usage of
+// tokens is not tracked unless a store is set.
+func (mgr *SessionManager) SetTokenUsageStore(store TokenUsageStore) {
+	mgr.usage = store
+}
+
+// SetLoginSessionStore sets the store which keeps track of the login sessions of local accounts. Login sessions are
+// neither tracked nor limited by their inactivity or number per account unless a store is set.
+func (mgr *SessionManager) SetLoginSessionStore(store LoginSessionStore) {
+	mgr.loginSessions = store
+}
+
+// GetTokenLastUsed returns when the token with the given ID (jti) has been used the last time, or
+// nil if unknown
+func (mgr *SessionManager) GetTokenLastUsed(id string) *time.Time {
+	if mgr.usage == nil {
+		return nil
+	}
+	usedAt, err := mgr.usage.GetTokenLastUsed(id)
+	if err != nil {
+		log.Warnf("Failed to get last usage of token %s: %v", id, err)
+		return nil
+	}
+	return usedAt
+}
+
+// recordTokenUsage records the usage of the token with the given ID (jti), at most once per
+// tokenUsageResolution to keep the load on the store low
+func (mgr *SessionManager) recordTokenUsage(id string) {
+	if mgr.usage == nil {
+		return
+	}
+	now := time.Now()
+	mgr.tokenLastUsedLock.Lock()
+	lastUsed, ok := mgr.tokenLastUsed[id]
+	if ok && now.Sub(lastUsed) < tokenUsageResolution {
+		mgr.tokenLastUsedLock.Unlock()
+		return
+	}
+	mgr.tokenLastUsed[id] = now
+	mgr.tokenLastUsedLock.Unlock()
+	if err := mgr.usage.SetTokenLastUsed(id, now); err != nil {
+		log.Warnf("Failed to record usage of token %s: %v", id, err)
+	}
+}
+
+// RevokeToken revokes the token with the given ID (jti) until it expires. Tokens which never expire
+// (expiresAt is zero) are revoked forever.
+func (mgr *SessionManager) RevokeToken(id string, expiresAt int64) error {
+	if mgr.revocations == nil {
+		return fmt.Errorf("token revocation is not supported")
+	}
+	expiration := cacheutil.NoExpiration
+	if expiresAt > 0 {
+		expiration = time.Until(time.Unix(expiresAt, 0))
+		if expiration <= 0 {
+			// the token has already expired
+			return nil
+		}
+	}
+	return mgr.revocations.RevokeToken(id, expiration)
+}
+
+// RevokeSessions revokes all tokens which have been issued for the subject so far, including login
+// sessions of local accounts and project role tokens
+func (mgr *SessionManager) RevokeSessions(subject string) error {
+	if mgr.revocations == nil {
+		return fmt.Errorf("token revocation is not supported")
+	}
+	return mgr.revocations.RevokeSessions(subject, time.Now())
+}
+
+// checkRevoked returns an error if the token with the given claims has been revoked. Tokens are deliberately rejected
+// while the revocation store can't be queried, i.e. while Redis is unavailable: accepting them would accept revoked
+// tokens as well, so Redis must be highly available when tokens are revoked.
+func (mgr *SessionManager) checkRevoked(claims jwt.Claims) error {
+	if mgr.revocations == nil {
+		return nil
+	}
+	mapClaims, err := jwtutil.MapClaims(claims)
+	if err != nil {
+		return err
+	}
+	if id := jwtutil.GetField(mapClaims, "jti"); id != "" {
+		revoked, err := mgr.revocations.IsTokenRevoked(id)
+		if err != nil {
+			return fmt.Errorf("failed to check if token %s has been revoked: %v", id, err)
+		} else if revoked {
+			return fmt.Errorf("token %s has been revoked", id)
+		}
+	}
+	if sub := jwtutil.GetField(mapClaims, "sub"); sub != "" {
+		revokedAt, err := mgr.revocations.GetSessionsRevokedAt(sub)
+		if err != nil {
+			return fmt.Errorf("failed to check if sessions of %s have been revoked: %v", sub, err)
+		} else if revokedAt != nil {
+			issuedAt, err := jwtutil.GetIssuedAt(mapClaims)
+			if err != nil || issuedBeforeRevocation(issuedAt, *revokedAt) {
+				return fmt.Errorf("sessions of %s have been revoked", sub)
+			}
+		}
+	}
+	return nil
+}
+
+// issuedBeforeRevocation returns whether a token issued at the given Unix time may have been issued before the sessions
+// were revoked. The issue time has a precision of seconds, so it is compared with the revocation time rounded up to the
+// next second: tokens issued in the second of the revocation may have been issued before it.
+func issuedBeforeRevocation(issuedAt int64, revokedAt time.Time) bool {
+	revokedAtSecond := revokedAt.Truncate(time.Second)
+	if revokedAtSecond.Before(revokedAt) {
+		revokedAtSecond = revokedAtSecond.Add(time.Second)
+	}
+	return issuedAt < revokedAtSecond.Unix()
+}
+
 // Create creates a new token for a given subject (user) and returns it as a string.
 // Passing a value of `0` for secondsBeforeExpiry creates a token that never expires.
 // The id parameter holds an optional unique JWT token identifier and stored as a standard claim "jti" in the JWT token.
@@ -98,6 +269,162 @@ func (mgr *SessionManager) Create(subject string, secondsBeforeExpiry int64, id
 	return mgr.signClaims(claims)
 }
 
+// CreateLoginSession creates a token for a new login session of a local account, which expires after the maximum
+// lifetime of sessions, if any. The oldest sessions of the account are deleted if it exceeds the maximum number of
+// concurrent sessions.
+func (mgr *SessionManager) CreateLoginSession(subject string) (string, error) {
+	sessionSettings, err := mgr.settingsMgr.GetSessionSettings()
+	if err != nil {
+		return "", err
+	}
+	secondsBeforeExpiry := int64(sessionSettings.MaxLifetime.Seconds())
+	if mgr.loginSessions == nil {
+		return mgr.Create(subject, secondsBeforeExpiry, "")
+	}
+	uniqueId, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	id := uniqueId.String()
+	now := time.Now()
+	session := servercache.LoginSession{ID: id, IssuedAt: now.Unix()}
+	if secondsBeforeExpiry > 0 {
+		session.ExpiresAt = now.Add(sessionSettings.MaxLifetime).Unix()
+	}
+	tokenString, err := mgr.Create(subject, secondsBeforeExpiry, id)
+	if err != nil {
+		return "", err
+	}
+	if err := mgr.loginSessions.AddLoginSession(subject, session); err != nil {
+		return "", err
+	}
+	if err := mgr.pruneLoginSessions(subject, id, sessionSettings.MaxPerAccount); err != nil {
+		log.Warnf("Failed to prune the login sessions of %s: %v", subject, err)
+	}
+	return tokenString, nil
+}
+
+// pruneLoginSessions deletes the login sessions of the account which are no longer active, and the oldest ones if
+// the account exceeds the maximum number of concurrent sessions, if any. The session with the given ID is kept.
+func (mgr *SessionManager) pruneLoginSessions(account string, keepID string, maxPerAccount int) error {
+	active, err := mgr.GetLoginSessions(account)
+	if err != nil {
+		return err
+	}
+	kept := map[string]bool{keepID: true}
+	for _, session := range active {
+		if maxPerAccount > 0 && len(kept) >= maxPerAccount {
+			break
+		}
+		kept[session.ID] = true
+	}
+	sessions, err := mgr.loginSessions.GetLoginSessions(account)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if kept[session.ID] {
+			continue
+		}
+		if err := mgr.loginSessions.DeleteLoginSession(account, session.ID); err != nil {
+			return err
+		}
+		log.Infof("Deleted login session %s of %s", session.ID, account)
+	}
+	return nil
+}
+
+// GetLoginSessions returns the active login sessions of a local account, most recent first. Sessions which have
+// expired, have been idle for too long or have been revoked are omitted.
+func (mgr *SessionManager) GetLoginSessions(account string) ([]servercache.LoginSession, error) {
+	if mgr.loginSessions == nil {
+		return nil, nil
+	}
+	sessionSettings, err := mgr.settingsMgr.GetSessionSettings()
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := mgr.loginSessions.GetLoginSessions(account)
+	if err != nil {
+		return nil, err
+	}
+	var revokedAt *time.Time
+	if mgr.revocations != nil {
+		if revokedAt, err = mgr.revocations.GetSessionsRevokedAt(account); err != nil {
+			return nil, err
+		}
+	}
+	var res []servercache.LoginSession
+	// the sessions are stored in the order of their creation, so that sessions created in the same second are listed
+	// most recent first as well
+	for i := len(sessions) - 1; i >= 0; i-- {
+		session := sessions[i]
+		if revokedAt != nil && issuedBeforeRevocation(session.IssuedAt, *revokedAt) {
+			continue
+		}
+		if mgr.checkLoginSessionLimits(session.ID, time.Unix(session.IssuedAt, 0), sessionSettings) != nil {
+			continue
+		}
+		res = append(res, session)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].IssuedAt > res[j].IssuedAt
+	})
+	return res, nil
+}
+
+// DeleteLoginSession deletes the login session with the given ID (jti) of a local account, so that its token is
+// rejected right away
+func (mgr *SessionManager) DeleteLoginSession(account string, id string) error {
+	if mgr.loginSessions == nil {
+		return fmt.Errorf("login sessions are not tracked")
+	}
+	if !mgr.hasLoginSession(account, id) {
+		return status.Errorf(codes.NotFound, "account %s does not have session with id %s", account, id)
+	}
+	return mgr.loginSessions.DeleteLoginSession(account, id)
+}
+
+// hasLoginSession returns whether the account has the login session with the given ID (jti). Like revoked tokens,
+// sessions are deliberately rejected while the login session store (Redis) is unavailable, since it cannot be told
+// whether they have been deleted.
+func (mgr *SessionManager) hasLoginSession(account string, id string) bool {
+	if mgr.loginSessions == nil {
+		return false
+	}
+	sessions, err := mgr.loginSessions.GetLoginSessions(account)
+	if err != nil {
+		log.Warnf("Failed to get the login sessions of %s, rejecting the session: %v", account, err)
+		return false
+	}
+	for _, session := range sessions {
+		if session.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLoginSessionLimits returns an error if a login session has exceeded the maximum lifetime of sessions, or if it
+// has not been used for longer than the idle timeout. The inactivity of sessions is only known if their usage is
+// tracked.
+func (mgr *SessionManager) checkLoginSessionLimits(id string, issuedAt time.Time, sessionSettings *settings.SessionSettings) error {
+	now := time.Now()
+	if sessionSettings.MaxLifetime > 0 && now.Sub(issuedAt) > sessionSettings.MaxLifetime {
+		return fmt.Errorf("session has exceeded the maximum lifetime of %v", sessionSettings.MaxLifetime)
+	}
+	if sessionSettings.IdleTimeout > 0 && id != "" && mgr.usage != nil {
+		lastUsed := issuedAt
+		if usedAt := mgr.GetTokenLastUsed(id); usedAt != nil && usedAt.After(lastUsed) {
+			lastUsed = *usedAt
+		}
+		if now.Sub(lastUsed) > sessionSettings.IdleTimeout {
+			return fmt.Errorf("session has been idle for longer than %v", sessionSettings.IdleTimeout)
+		}
+	}
+	return nil
+}
+
 func (mgr *SessionManager) signClaims(claims jwt.Claims) (string, error) {
 	log.Infof("Issuing claims: %v", claims)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -140,7 +467,14 @@ func (mgr *SessionManager) Parse(tokenString string) (jwt.Claims, error) {
 		return nil, err
 	}
 
-	if id := jwtutil.GetField(claims, "jti"); id != "" && account.TokenIndex(id) == -1 {
+	if !account.Enabled {
+		return nil, fmt.Errorf(accountDisabled, subject)
+	}
+
+	// tokens of local accounts are either API tokens of the account or login sessions
+	id := jwtutil.GetField(claims, "jti")
+	isAPIToken := id != "" && account.TokenIndex(id) > -1
+	if id != "" && !isAPIToken && !mgr.hasLoginSession(subject, id) {
 		return nil, fmt.Errorf("account %s does not have token with id %s", subject, id)
 	}
 
@@ -148,6 +482,16 @@ func (mgr *SessionManager) Parse(tokenString string) (jwt.Claims, error) {
 	if account.PasswordMtime != nil && issuedAt.Before(*account.PasswordMtime) {
 		return nil, fmt.Errorf("Account password has changed since token issued")
 	}
+
+	if !isAPIToken {
+		sessionSettings, err := mgr.settingsMgr.GetSessionSettings()
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.checkLoginSessionLimits(id, issuedAt, sessionSettings); err != nil {
+			return nil, err
+		}
+	}
 	return token.Claims, nil
 }
 
@@ -172,8 +516,24 @@ func (mgr *SessionManager) VerifyUsernamePassword(username string, password stri
 }
 
 // VerifyToken verifies if a token is correct. Tokens can be issued either from us or by an IDP.
-// We choose how to verify based on the issuer.
+// We choose how to verify based on the issuer. Revoked tokens are rejected.
 func (mgr *SessionManager) VerifyToken(tokenString string) (jwt.Claims, error) {
+	claims, err := mgr.verifyToken(tokenString)
+	if err != nil {
+		return claims, err
+	}
+	if err := mgr.checkRevoked(claims); err != nil {
+		return nil, err
+	}
+	if mapClaims, err := jwtutil.MapClaims(claims); err == nil && jwtutil.GetField(mapClaims, "iss") == SessionManagerClaimsIssuer {
+		if id := jwtutil.GetField(mapClaims, "jti"); id != "" {
+			mgr.recordTokenUsage(id)
+		}
+	}
+	return claims, nil
+}
+
+func (mgr *SessionManager) verifyToken(tokenString string) (jwt.Claims, error) {
 	parser := &jwt.Parser{
 		SkipClaimsValidation: true,
 	}
@@ -188,7 +548,7 @@ func (mgr *SessionManager) VerifyToken(tokenString string) (jwt.Claims, error) {
 		return mgr.Parse(tokenString)
 	default:
 		// IDP signed token
-		prov, err := mgr.provider()
+		prov, oidcConfig, err := mgr.providerForIssuer(claims.Issuer)
 		if err != nil {
 			return claims, err
 		}
@@ -198,10 +558,57 @@ func (mgr *SessionManager) VerifyToken(tokenString string) (jwt.Claims, error) {
 		}
 		var claims jwt.MapClaims
 		err = idToken.Claims(&claims)
+		if err == nil && oidcConfig != nil {
+			mapGroupsClaim(claims, oidcConfig.GroupsClaim, oidcConfig.GroupsPrefix)
+		}
 		return claims, err
 	}
 }
 
+// providerForIssuer returns the provider which verifies tokens of the given issuer, along with its
+// configuration. Tokens of issuers other than the additional OIDC providers are verified by the
+// provider configured in oidc.config (or Dex).
+func (mgr *SessionManager) providerForIssuer(issuer string) (oidcutil.Provider, *settings.OIDCConfig, error) {
+	argoCDSettings, err := mgr.settingsMgr.GetSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	oidcConfig := argoCDSettings.OIDCConfigByIssuer(issuer)
+	if primary := argoCDSettings.OIDCConfig(); oidcConfig == nil || (primary != nil && primary.Issuer == issuer) {
+		prov, err := mgr.provider()
+		return prov, oidcConfig, err
+	}
+	mgr.additionalProvsLock.Lock()
+	defer mgr.additionalProvsLock.Unlock()
+	prov, ok := mgr.additionalProvs[issuer]
+	if !ok {
+		prov = oidcutil.NewOIDCProvider(issuer, mgr.client)
+		mgr.additionalProvs[issuer] = prov
+	}
+	return prov, oidcConfig, nil
+}
+
+// mapGroupsClaim replaces the 'groups' claim, which is used for RBAC, with the groups found in the
+// groupsClaim claim. The groups are prepended with the groupsPrefix, if any.
+func mapGroupsClaim(claims jwt.MapClaims, groupsClaim string, groupsPrefix string) {
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	if groupsClaim == "groups" && groupsPrefix == "" {
+		return
+	}
+	groups := jwtutil.GetScopeValues(claims, []string{groupsClaim})
+	if len(groups) == 0 {
+		delete(claims, "groups")
+		return
+	}
+	res := make([]interface{}, len(groups))
+	for i, group := range groups {
+		res[i] = groupsPrefix + group
+	}
+	claims["groups"] = res
+}
+
 func (mgr *SessionManager) provider() (oidcutil.Provider, error) {
 	if mgr.prov != nil {
 		return mgr.prov, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,6 +18,10 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/argoproj/argo-cd/errors"
+	servercache "github.com/argoproj/argo-cd/server/cache"
+	cacheutil "github.com/argoproj/argo-cd/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/util/cache/appstate"
+	jwtutil "github.com/argoproj/argo-cd/util/jwt"
 	"github.com/argoproj/argo-cd/util/password"
 	"github.com/argoproj/argo-cd/util/settings"
 )
@@ -155,3 +160,205 @@ func TestVerifyUsernamePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestMapGroupsClaim(t *testing.T) {
+	claims := jwt.MapClaims{"groups": []interface{}{"admins"}, "roles": []interface{}{"viewers", "editors"}}
+	mapGroupsClaim(claims, "", "")
+	assert.Equal(t, []interface{}{"admins"}, claims["groups"])
+
+	mapGroupsClaim(claims, "roles", "partner:")
+	assert.Equal(t, []interface{}{"partner:viewers", "partner:editors"}, claims["groups"])
+
+	claims = jwt.MapClaims{"groups": []interface{}{"admins"}}
+	mapGroupsClaim(claims, "roles", "")
+	_, ok := claims["groups"]
+	assert.False(t, ok)
+}
+
+type fakeRevocationStore struct {
+	tokens   map[string]bool
+	sessions map[string]time.Time
+	err      error
+}
+
+func (s *fakeRevocationStore) RevokeToken(id string, _ time.Duration) error {
+	s.tokens[id] = true
+	return nil
+}
+
+func (s *fakeRevocationStore) IsTokenRevoked(id string) (bool, error) {
+	return s.tokens[id], s.err
+}
+
+func (s *fakeRevocationStore) RevokeSessions(subject string, revokedAt time.Time) error {
+	s.sessions[subject] = revokedAt
+	return nil
+}
+
+func (s *fakeRevocationStore) GetSessionsRevokedAt(subject string) (*time.Time, error) {
+	if revokedAt, ok := s.sessions[subject]; ok {
+		return &revokedAt, s.err
+	}
+	return nil, s.err
+}
+
+func newTestCache() *servercache.Cache {
+	return servercache.NewCache(appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewInMemoryCache(time.Hour)), time.Hour), time.Hour, time.Hour)
+}
+
+func TestRevokeToken(t *testing.T) {
+	settingsMgr := settings.NewSettingsManager(context.Background(), getKubeClient("pass", true), "argocd")
+	mgr := NewSessionManager(settingsMgr, "")
+
+	assert.Error(t, mgr.RevokeToken("abc", 0))
+
+	mgr.SetTokenRevocationStore(newTestCache())
+	revoked, err := mgr.Create("proj:default:ci", 0, "abc")
+	assert.NoError(t, err)
+	other, err := mgr.Create("proj:default:ci", 0, "def")
+	assert.NoError(t, err)
+
+	assert.NoError(t, mgr.RevokeToken("abc", 0))
+
+	_, err = mgr.VerifyToken(revoked)
+	assert.Error(t, err)
+	_, err = mgr.VerifyToken(other)
+	assert.NoError(t, err)
+}
+
+func TestRevokeSessions(t *testing.T) {
+	settingsMgr := settings.NewSettingsManager(context.Background(), getKubeClient("pass", true), "argocd")
+	mgr := NewSessionManager(settingsMgr, "")
+	cache := newTestCache()
+	mgr.SetTokenRevocationStore(cache)
+
+	adminToken, err := mgr.Create("admin", 0, "")
+	assert.NoError(t, err)
+	otherToken, err := mgr.Create("proj:default:ci", 0, "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, mgr.RevokeSessions("admin"))
+
+	_, err = mgr.VerifyToken(adminToken)
+	assert.Error(t, err)
+	_, err = mgr.VerifyToken(otherToken)
+	assert.NoError(t, err)
+
+	// tokens issued after the revocation are accepted
+	assert.NoError(t, cache.RevokeSessions("admin", time.Now().Add(-time.Minute)))
+	newToken, err := mgr.Create("admin", 0, "")
+	assert.NoError(t, err)
+	_, err = mgr.VerifyToken(newToken)
+	assert.NoError(t, err)
+
+	// tokens issued in the second of a revocation are accepted only if it was revoked at the start of that second
+	claims, err := mgr.Parse(newToken)
+	assert.NoError(t, err)
+	iat, err := jwtutil.GetIssuedAt(*claims.(*jwt.MapClaims))
+	assert.NoError(t, err)
+	issuedAt := time.Unix(iat, 0)
+	assert.NoError(t, cache.RevokeSessions("admin", issuedAt))
+	_, err = mgr.VerifyToken(newToken)
+	assert.NoError(t, err)
+	assert.NoError(t, cache.RevokeSessions("admin", issuedAt.Add(time.Millisecond)))
+	_, err = mgr.VerifyToken(newToken)
+	assert.Error(t, err)
+}
+
+func TestCheckRevoked_StoreUnavailable(t *testing.T) {
+	settingsMgr := settings.NewSettingsManager(context.Background(), getKubeClient("pass", true), "argocd")
+	mgr := NewSessionManager(settingsMgr, "")
+	store := &fakeRevocationStore{tokens: map[string]bool{}, sessions: map[string]time.Time{}}
+	mgr.SetTokenRevocationStore(store)
+	token, err := mgr.Create("proj:default:ci", 0, "abc")
+	assert.NoError(t, err)
+	_, err = mgr.VerifyToken(token)
+	assert.NoError(t, err)
+
+	store.err = status.Errorf(codes.Unavailable, "connection refused")
+	_, err = mgr.VerifyToken(token)
+	assert.Error(t, err)
+}
+
+func TestLoginSessions(t *testing.T) {
+	kubeClient := getKubeClient("pass", true)
+	cm, err := kubeClient.CoreV1().ConfigMaps("argocd").Get("argocd-cm", metav1.GetOptions{})
+	assert.NoError(t, err)
+	cm.Data["sessions.idleTimeout"] = "10m"
+	cm.Data["sessions.maxLifetime"] = "1h"
+	_, err = kubeClient.CoreV1().ConfigMaps("argocd").Update(cm)
+	assert.NoError(t, err)
+	settingsMgr := settings.NewSettingsManager(context.Background(), kubeClient, "argocd")
+	mgr := NewSessionManager(settingsMgr, "")
+	cache := newTestCache()
+	mgr.SetTokenUsageStore(cache)
+	mgr.SetLoginSessionStore(cache)
+
+	token, err := mgr.CreateLoginSession("admin")
+	assert.NoError(t, err)
+	claims, err := mgr.VerifyToken(token)
+	assert.NoError(t, err)
+	expiresAt := int64((*claims.(*jwt.MapClaims))["exp"].(float64))
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), expiresAt, 5)
+	sessions, err := mgr.GetLoginSessions("admin")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+
+	t.Run("UnknownSession", func(t *testing.T) {
+		token, err := mgr.Create("admin", 0, "unknown")
+		assert.NoError(t, err)
+		_, err = mgr.VerifyToken(token)
+		assert.Error(t, err)
+	})
+	t.Run("MaxLifetime", func(t *testing.T) {
+		token, err := mgr.signClaims(jwt.StandardClaims{Subject: "admin", Issuer: SessionManagerClaimsIssuer, IssuedAt: time.Now().Add(-2 * time.Hour).Unix()})
+		assert.NoError(t, err)
+		_, err = mgr.VerifyToken(token)
+		assert.EqualError(t, err, "session has exceeded the maximum lifetime of 1h0m0s")
+	})
+	t.Run("IdleTimeout", func(t *testing.T) {
+		issuedAt := time.Now().Add(-20 * time.Minute)
+		assert.NoError(t, cache.AddLoginSession("admin", servercache.LoginSession{ID: "idle", IssuedAt: issuedAt.Unix()}))
+		token, err := mgr.signClaims(jwt.StandardClaims{Subject: "admin", Issuer: SessionManagerClaimsIssuer, IssuedAt: issuedAt.Unix(), Id: "idle"})
+		assert.NoError(t, err)
+		_, err = mgr.VerifyToken(token)
+		assert.EqualError(t, err, "session has been idle for longer than 10m0s")
+		sessions, err := mgr.GetLoginSessions("admin")
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+
+		assert.NoError(t, cache.SetTokenLastUsed("idle", time.Now().Add(-5*time.Minute)))
+		_, err = mgr.VerifyToken(token)
+		assert.NoError(t, err)
+	})
+	t.Run("DeleteLoginSession", func(t *testing.T) {
+		assert.NoError(t, mgr.DeleteLoginSession("admin", sessions[0].ID))
+		_, err := mgr.VerifyToken(token)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(mgr.DeleteLoginSession("admin", sessions[0].ID)))
+	})
+}
+
+// unavailableLoginSessionStore is a login session store which fails all requests
+type unavailableLoginSessionStore struct {
+	*servercache.Cache
+}
+
+func (s *unavailableLoginSessionStore) GetLoginSessions(string) ([]servercache.LoginSession, error) {
+	return nil, status.Errorf(codes.Unavailable, "connection refused")
+}
+
+func TestLoginSessions_StoreUnavailable(t *testing.T) {
+	settingsMgr := settings.NewSettingsManager(context.Background(), getKubeClient("pass", true), "argocd")
+	mgr := NewSessionManager(settingsMgr, "")
+	cache := newTestCache()
+	mgr.SetLoginSessionStore(cache)
+	token, err := mgr.CreateLoginSession("admin")
+	assert.NoError(t, err)
+	_, err = mgr.VerifyToken(token)
+	assert.NoError(t, err)
+
+	mgr.SetLoginSessionStore(&unavailableLoginSessionStore{cache})
+	_, err = mgr.VerifyToken(token)
+	assert.Error(t, err)
+}
@@ -43,6 +43,7 @@ const (
 // Token holds the information about the generated auth token.
 type Token struct {
 	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
 	IssuedAt  int64  `json:"iat"`
 	ExpiresAt int64  `json:"exp,omitempty"`
 }
@@ -83,6 +84,16 @@ func (a *Account) TokenIndex(id string) int {
 	return -1
 }
 
+// TokenIndexByName return an index of a token with the given name or -1 if token not found.
+func (a *Account) TokenIndexByName(name string) int {
+	for i := range a.Tokens {
+		if a.Tokens[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // HasCapability return true if the account has the specified capability.
 func (a *Account) HasCapability(capability AccountCapability) bool {
 	for _, c := range a.Capabilities {
@@ -113,6 +124,22 @@ func (mgr *SettingsManager) AddAccount(name string, account Account) error {
 	return mgr.saveAccount(name, account)
 }
 
+// DeleteAccount removes the account with the given name and all of its tokens.
+func (mgr *SettingsManager) DeleteAccount(name string) error {
+	if name == common.ArgoCDAdminUsername {
+		return status.Errorf(codes.InvalidArgument, "account '%s' cannot be deleted", name)
+	}
+	if _, err := mgr.GetAccount(name); err != nil {
+		return err
+	}
+	return mgr.updateSecret(func(secret *v1.Secret) error {
+		return mgr.updateConfigMap(func(cm *v1.ConfigMap) error {
+			deleteAccount(secret, cm, name)
+			return nil
+		})
+	})
+}
+
 // GetAccount return an account info by the specified name.
 func (mgr *SettingsManager) GetAccount(name string) (*Account, error) {
 	accounts, err := mgr.GetAccounts()
@@ -199,6 +226,14 @@ func saveAccount(secret *v1.Secret, cm *v1.ConfigMap, name string, account Accou
 	return nil
 }
 
+func deleteAccount(secret *v1.Secret, cm *v1.ConfigMap, name string) {
+	for _, suffix := range []string{accountPasswordSuffix, accountPasswordMtimeSuffix, accountTokensSuffix} {
+		delete(secret.Data, fmt.Sprintf("%s.%s.%s", accountsKeyPrefix, name, suffix))
+	}
+	delete(cm.Data, fmt.Sprintf("%s.%s.%s", accountsKeyPrefix, name, accountEnabledSuffix))
+	delete(cm.Data, fmt.Sprintf("%s.%s", accountsKeyPrefix, name))
+}
+
 func parseAdminAccount(secret *v1.Secret, cm *v1.ConfigMap) (*Account, error) {
 	adminAccount := &Account{Enabled: true, Capabilities: []AccountCapability{AccountCapabilityLogin}}
 	if adminPasswordHash, ok := secret.Data[settingAdminPasswordHashKey]; ok {
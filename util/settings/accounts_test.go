@@ -211,3 +211,41 @@ func TestUpdateAccount_AccountDoesNotExist(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func TestTokenIndexByName(t *testing.T) {
+	acc := Account{Tokens: []Token{{ID: "123", Name: "ci"}, {ID: "456"}}}
+	assert.Equal(t, 0, acc.TokenIndexByName("ci"))
+	assert.Equal(t, -1, acc.TokenIndexByName("cd"))
+}
+
+func TestDeleteAccount_SuccessfullyDeleted(t *testing.T) {
+	clientset, settingsManager := fixtures(map[string]string{"accounts.test": "login", "accounts.test.enabled": "false"}, func(secret *v1.Secret) {
+		secret.Data["accounts.test.password"] = []byte("hash")
+		secret.Data["accounts.test.tokens"] = []byte(`[{"id":"123","iat":0}]`)
+	})
+
+	err := settingsManager.DeleteAccount("test")
+	assert.NoError(t, err)
+
+	cm, err := clientset.CoreV1().ConfigMaps("default").Get(common.ArgoCDConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, cm.Data, "accounts.test")
+	assert.NotContains(t, cm.Data, "accounts.test.enabled")
+
+	secret, err := clientset.CoreV1().Secrets("default").Get(common.ArgoCDSecretName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, secret.Data, "accounts.test.password")
+	assert.NotContains(t, secret.Data, "accounts.test.tokens")
+}
+
+func TestDeleteAccount_CannotDeleteAdmin(t *testing.T) {
+	_, settingsManager := fixtures(nil)
+	err := settingsManager.DeleteAccount("admin")
+	assert.Error(t, err)
+}
+
+func TestDeleteAccount_AccountDoesNotExist(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{"accounts.test": "login"})
+	err := settingsManager.DeleteAccount("test1")
+	assert.Error(t, err)
+}
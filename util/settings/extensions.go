@@ -0,0 +1,85 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/argoproj/argo-cd/common"
+)
+
+const (
+	// extensionConfigKey is the key of the proxy extensions configuration in argocd-cm
+	extensionConfigKey = "extension.config"
+)
+
+var extensionNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ExtensionConfig is the configuration of the proxy extensions
+type ExtensionConfig struct {
+	Extensions []ProxyExtension `json:"extensions,omitempty"`
+}
+
+// ProxyExtension is a backend service which the API server proxies the requests of the path
+// /extensions/<name>/ to
+type ProxyExtension struct {
+	// Name of the extension
+	Name string `json:"name"`
+	// URL of the backend service
+	URL string `json:"url"`
+	// Headers which are added to every proxied request. Values starting with $ reference a key of argocd-secret.
+	Headers []ExtensionHeader `json:"headers,omitempty"`
+}
+
+// ExtensionHeader is a header added to the requests proxied to an extension
+type ExtensionHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GetProxyExtensions returns the proxy extensions configured in argocd-cm, with secret references
+// of the header values replaced by the referenced values
+func (mgr *SettingsManager) GetProxyExtensions() ([]ProxyExtension, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := argoCDCM.Data[extensionConfigKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var config ExtensionConfig
+	if err := yaml.Unmarshal([]byte(value), &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", extensionConfigKey, err)
+	}
+	argoCDSecret, err := mgr.secrets.Secrets(mgr.namespace).Get(common.ArgoCDSecretName)
+	if err != nil {
+		return nil, err
+	}
+	secretValues := make(map[string]string, len(argoCDSecret.Data))
+	for k, v := range argoCDSecret.Data {
+		secretValues[k] = string(v)
+	}
+
+	names := make(map[string]bool)
+	for i := range config.Extensions {
+		ext := &config.Extensions[i]
+		if !extensionNameRegex.MatchString(ext.Name) {
+			return nil, fmt.Errorf("invalid extension name '%s'", ext.Name)
+		}
+		if names[ext.Name] {
+			return nil, fmt.Errorf("duplicate extension name '%s'", ext.Name)
+		}
+		names[ext.Name] = true
+		backendURL, err := url.Parse(ext.URL)
+		if err != nil || (backendURL.Scheme != "http" && backendURL.Scheme != "https") || backendURL.Host == "" {
+			return nil, fmt.Errorf("invalid URL '%s' of extension '%s'", ext.URL, ext.Name)
+		}
+		for j := range ext.Headers {
+			ext.Headers[j].Value = ReplaceStringSecret(ext.Headers[j].Value, secretValues)
+		}
+	}
+	return config.Extensions, nil
+}
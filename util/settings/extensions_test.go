@@ -0,0 +1,55 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetProxyExtensions(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{})
+		extensions, err := settingsManager.GetProxyExtensions()
+		assert.NoError(t, err)
+		assert.Empty(t, extensions)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			"extension.config": `
+extensions:
+- name: metrics
+  url: http://metrics.monitoring:8080/api
+  headers:
+  - name: Authorization
+    value: $extension.metrics.token
+  - name: X-Scope
+    value: argocd`,
+		}, func(secret *v1.Secret) {
+			secret.Data["extension.metrics.token"] = []byte("Bearer abc")
+		})
+		extensions, err := settingsManager.GetProxyExtensions()
+		assert.NoError(t, err)
+		assert.Equal(t, []ProxyExtension{{
+			Name: "metrics",
+			URL:  "http://metrics.monitoring:8080/api",
+			Headers: []ExtensionHeader{
+				{Name: "Authorization", Value: "Bearer abc"},
+				{Name: "X-Scope", Value: "argocd"},
+			},
+		}}, extensions)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, config := range []string{
+			"extensions:\n- name: Metrics\n  url: http://metrics",
+			"extensions:\n- name: metrics\n  url: ftp://metrics",
+			"extensions:\n- name: metrics\n  url: http://metrics\n- name: metrics\n  url: http://other",
+		} {
+			_, settingsManager := fixtures(map[string]string{"extension.config": config})
+			_, err := settingsManager.GetProxyExtensions()
+			assert.Error(t, err, config)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/argoproj/argo-cd/common"
+)
+
+const (
+	// groupSyncConfigKey is the key of the group synchronization configuration in argocd-cm
+	groupSyncConfigKey = "groups.sync"
+	// defaultGroupSyncInterval is the interval of the group synchronization unless it is configured
+	defaultGroupSyncInterval = 10 * time.Minute
+)
+
+// GroupSyncConfig configures the periodic synchronization of the group memberships of the users from the identity
+// providers which can't embed them in the claims of their tokens, e.g. because the users are members of too many groups.
+// The synchronized groups of a user are evaluated by RBAC in addition to the groups of its token.
+type GroupSyncConfig struct {
+	// Interval between synchronizations, e.g. 10m
+	Interval string `json:"interval,omitempty"`
+	// Issuer is the issuer (iss claim) of the tokens the synchronized groups apply to, since the users are only
+	// identified uniquely by the claims of the tokens of one issuer
+	Issuer string `json:"issuer"`
+	// UserClaim is the claim of the tokens which identifies the users in the synchronized memberships, email by default.
+	// The email claim is only trusted if the email_verified claim is true.
+	UserClaim string `json:"userClaim,omitempty"`
+	// Keycloak synchronizes the groups of a Keycloak realm
+	Keycloak *KeycloakGroupSyncConfig `json:"keycloak,omitempty"`
+	// LDAP synchronizes the groups of an LDAP directory
+	LDAP *LDAPGroupSyncConfig `json:"ldap,omitempty"`
+}
+
+// KeycloakGroupSyncConfig synchronizes the groups of a Keycloak realm with the admin REST API. The client must be
+// granted the view-users role of the realm-management client for its service account.
+type KeycloakGroupSyncConfig struct {
+	// URL of Keycloak, including the /auth path prefix of older Keycloak versions
+	URL string `json:"url"`
+	// Realm whose groups are synchronized
+	Realm string `json:"realm"`
+	// ClientID of the client with a service account
+	ClientID string `json:"clientID"`
+	// ClientSecret of the client. Values starting with $ reference a key of argocd-secret.
+	ClientSecret string `json:"clientSecret"`
+	// UserAttribute is the attribute of the users which is matched against the user claim: email, username or id.
+	// It is email by default.
+	UserAttribute string `json:"userAttribute,omitempty"`
+	// FullPath names the groups by their path, e.g. /parent/child, as the group mapper of Keycloak does by default,
+	// instead of their name
+	FullPath bool `json:"fullPath,omitempty"`
+	// InsecureSkipVerify disables the verification of the TLS certificate of Keycloak
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// LDAPGroupSyncConfig synchronizes the groups of an LDAP directory
+type LDAPGroupSyncConfig struct {
+	// URL of the LDAP server, e.g. ldaps://ldap.example.com
+	URL string `json:"url"`
+	// StartTLS encrypts the connection to an ldap:// server with StartTLS
+	StartTLS bool `json:"startTLS,omitempty"`
+	// InsecureSkipVerify disables the verification of the TLS certificate of the server
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// BindDN is the DN to bind with, anonymously if empty. Binding with a password requires an ldaps:// URL or StartTLS.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword is the password of the bind DN. Values starting with $ reference a key of argocd-secret.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// UserBaseDN is the base DN of the users. If it is empty, the values of the member attribute of the groups are
+	// matched against the user claim instead of the user attribute of the members, e.g. with the memberUid of posix
+	// groups.
+	UserBaseDN string `json:"userBaseDN,omitempty"`
+	// UserFilter selects the users, (objectClass=person) by default
+	UserFilter string `json:"userFilter,omitempty"`
+	// UserAttribute is the attribute of the users which is matched against the user claim, mail by default
+	UserAttribute string `json:"userAttribute,omitempty"`
+	// GroupBaseDN is the base DN of the groups
+	GroupBaseDN string `json:"groupBaseDN"`
+	// GroupFilter selects the groups, (objectClass=groupOfNames) by default
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// GroupNameAttribute is the attribute naming the groups in RBAC policies, cn by default
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+	// MemberAttribute is the attribute of the groups listing their members, member by default
+	MemberAttribute string `json:"memberAttribute,omitempty"`
+}
+
+// GetInterval returns the interval between synchronizations
+func (c *GroupSyncConfig) GetInterval() time.Duration {
+	if interval, err := time.ParseDuration(c.Interval); err == nil && interval > 0 {
+		return interval
+	}
+	return defaultGroupSyncInterval
+}
+
+// GetUserClaim returns the claim of the tokens which identifies the users
+func (c *GroupSyncConfig) GetUserClaim() string {
+	if c.UserClaim != "" {
+		return c.UserClaim
+	}
+	return "email"
+}
+
+func (c *GroupSyncConfig) validate() error {
+	if c.Keycloak == nil && c.LDAP == nil {
+		return fmt.Errorf("keycloak or ldap is required")
+	}
+	if c.Issuer == "" {
+		return fmt.Errorf("the issuer of the tokens is required")
+	}
+	if c.Interval != "" {
+		if interval, err := time.ParseDuration(c.Interval); err != nil || interval <= 0 {
+			return fmt.Errorf("invalid interval '%s'", c.Interval)
+		}
+	}
+	if kc := c.Keycloak; kc != nil {
+		if u, err := url.Parse(kc.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid Keycloak URL '%s'", kc.URL)
+		}
+		if kc.Realm == "" || kc.ClientID == "" || kc.ClientSecret == "" {
+			return fmt.Errorf("the realm, clientID and clientSecret of Keycloak are required")
+		}
+		switch kc.UserAttribute {
+		case "", "email", "username", "id":
+		default:
+			return fmt.Errorf("invalid Keycloak user attribute '%s', expected email, username or id", kc.UserAttribute)
+		}
+	}
+	if l := c.LDAP; l != nil {
+		u, err := url.Parse(l.URL)
+		if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+			return fmt.Errorf("invalid LDAP URL '%s'", l.URL)
+		}
+		if l.GroupBaseDN == "" {
+			return fmt.Errorf("the groupBaseDN of LDAP is required")
+		}
+		if l.BindDN != "" && u.Scheme == "ldap" && !l.StartTLS {
+			return fmt.Errorf("binding to LDAP requires an ldaps:// URL or startTLS, so that the bind password is not sent in cleartext")
+		}
+	}
+	return nil
+}
+
+// GetGroupSyncConfig returns the group synchronization configuration of argocd-cm, with the secret references of the
+// credentials replaced by the referenced values, or nil if the groups are not synchronized
+func (mgr *SettingsManager) GetGroupSyncConfig() (*GroupSyncConfig, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := argoCDCM.Data[groupSyncConfigKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var config GroupSyncConfig
+	if err := yaml.Unmarshal([]byte(value), &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", groupSyncConfigKey, err)
+	}
+	argoCDSecret, err := mgr.secrets.Secrets(mgr.namespace).Get(common.ArgoCDSecretName)
+	if err != nil {
+		return nil, err
+	}
+	secretValues := make(map[string]string, len(argoCDSecret.Data))
+	for k, v := range argoCDSecret.Data {
+		secretValues[k] = string(v)
+	}
+	if config.Keycloak != nil {
+		config.Keycloak.ClientSecret = ReplaceStringSecret(config.Keycloak.ClientSecret, secretValues)
+	}
+	if config.LDAP != nil {
+		config.LDAP.BindPassword = ReplaceStringSecret(config.LDAP.BindPassword, secretValues)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", groupSyncConfigKey, err)
+	}
+	return &config, nil
+}
@@ -0,0 +1,71 @@
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetGroupSyncConfig(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{})
+		config, err := settingsManager.GetGroupSyncConfig()
+		assert.NoError(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			"groups.sync": `
+interval: 5m
+issuer: https://argocd.example.com/api/dex
+keycloak:
+  url: https://keycloak.example.com
+  realm: argo
+  clientID: argocd-group-sync
+  clientSecret: $groups.sync.keycloak.clientSecret
+ldap:
+  url: ldaps://ldap.example.com
+  bindDN: cn=argocd,dc=example,dc=com
+  bindPassword: $groups.sync.ldap.bindPassword
+  groupBaseDN: ou=groups,dc=example,dc=com`,
+		}, func(secret *v1.Secret) {
+			secret.Data["groups.sync.keycloak.clientSecret"] = []byte("client-secret")
+			secret.Data["groups.sync.ldap.bindPassword"] = []byte("bind-password")
+		})
+		config, err := settingsManager.GetGroupSyncConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, config.GetInterval())
+		assert.Equal(t, "https://argocd.example.com/api/dex", config.Issuer)
+		assert.Equal(t, "email", config.GetUserClaim())
+		assert.Equal(t, "client-secret", config.Keycloak.ClientSecret)
+		assert.Equal(t, "bind-password", config.LDAP.BindPassword)
+		assert.Equal(t, "ou=groups,dc=example,dc=com", config.LDAP.GroupBaseDN)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, config := range []string{
+			"issuer: https://dex\ninterval: 5m",
+			"ldap:\n  url: ldaps://ldap\n  groupBaseDN: dc=example",
+			"issuer: https://dex\ninterval: often\nldap:\n  url: ldap://ldap\n  groupBaseDN: dc=example",
+			"issuer: https://dex\nldap:\n  url: http://ldap\n  groupBaseDN: dc=example",
+			"issuer: https://dex\nldap:\n  url: ldap://ldap",
+			"issuer: https://dex\nldap:\n  url: ldap://ldap\n  groupBaseDN: dc=example\n  bindDN: cn=argocd,dc=example",
+			"issuer: https://dex\nkeycloak:\n  url: https://keycloak\n  realm: argo\n  clientID: argocd",
+			"issuer: https://dex\nkeycloak:\n  url: https://keycloak\n  realm: argo\n  clientID: argocd\n  clientSecret: secret\n  userAttribute: phone",
+		} {
+			_, settingsManager := fixtures(map[string]string{"groups.sync": config})
+			_, err := settingsManager.GetGroupSyncConfig()
+			assert.Error(t, err, config)
+		}
+	})
+
+	t.Run("StartTLS", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{"groups.sync": "issuer: https://dex\nldap:\n  url: ldap://ldap\n  startTLS: true\n  groupBaseDN: dc=example\n  bindDN: cn=argocd,dc=example"})
+		config, err := settingsManager.GetGroupSyncConfig()
+		assert.NoError(t, err)
+		assert.True(t, config.LDAP.StartTLS)
+	})
+}
@@ -0,0 +1,81 @@
+package settings
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/util/git"
+)
+
+// urlRegexp returns the regular expression matching the repository URLs of glob and regex credentials, or nil for
+// prefix credentials
+func (c *RepositoryCredentials) urlRegexp() (*regexp.Regexp, error) {
+	switch c.MatchType {
+	case "", v1alpha1.RepoCredsMatchTypePrefix:
+		return nil, nil
+	case v1alpha1.RepoCredsMatchTypeGlob:
+		return regexp.Compile(globToRegexp(c.URL))
+	case v1alpha1.RepoCredsMatchTypeRegex:
+		return regexp.Compile("^(?:" + c.URL + ")$")
+	default:
+		return nil, fmt.Errorf("unknown match type '%s', expected %s, %s or %s", c.MatchType, v1alpha1.RepoCredsMatchTypePrefix, v1alpha1.RepoCredsMatchTypeGlob, v1alpha1.RepoCredsMatchTypeRegex)
+	}
+}
+
+// globToRegexp converts a glob pattern of repository URLs to a case insensitive regular expression
+func globToRegexp(pattern string) string {
+	var res strings.Builder
+	res.WriteString("(?i)^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				res.WriteString(".*")
+				i++
+			} else {
+				res.WriteString("[^/]*")
+			}
+		case '?':
+			res.WriteString("[^/]")
+		default:
+			res.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	res.WriteString("$")
+	return res.String()
+}
+
+// ValidateURL returns an error if the URL of the credentials is not a valid pattern of their match type
+func (c *RepositoryCredentials) ValidateURL() error {
+	if _, err := c.urlRegexp(); err != nil {
+		return fmt.Errorf("invalid URL pattern '%s': %v", c.URL, err)
+	}
+	return nil
+}
+
+// Matches returns whether the credentials apply to the repository URL. Prefixes are matched against the normalized
+// URL of the repository, glob patterns and regular expressions against the URL as it is.
+func (c *RepositoryCredentials) Matches(repoURL string) bool {
+	if c.URL == "" {
+		return false
+	}
+	re, err := c.urlRegexp()
+	if err != nil {
+		return false
+	}
+	if re != nil {
+		return re.MatchString(strings.TrimSpace(repoURL))
+	}
+	prefix := git.NormalizeGitURL(c.URL)
+	return prefix != "" && strings.HasPrefix(git.NormalizeGitURL(repoURL), prefix)
+}
+
+// SameURL returns whether the credentials are configured with the URL pattern
+func (c *RepositoryCredentials) SameURL(url string) bool {
+	if c.MatchType == "" || c.MatchType == v1alpha1.RepoCredsMatchTypePrefix {
+		return c.URL == url || git.SameURL(c.URL, url)
+	}
+	return c.URL == url
+}
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryCredentials_Matches(t *testing.T) {
+	tests := []struct {
+		cred    RepositoryCredentials
+		repoURL string
+		want    bool
+	}{
+		{RepositoryCredentials{URL: "https://github.com/argoproj"}, "https://github.com/argoproj/argo-cd.git", true},
+		{RepositoryCredentials{URL: "https://github.com/argoproj", MatchType: "prefix"}, "https://github.com/argoproj/argo-cd", true},
+		{RepositoryCredentials{URL: "https://github.com/argoproj"}, "https://gitlab.com/argoproj/argo-cd", false},
+		{RepositoryCredentials{URL: "", MatchType: "glob"}, "", false},
+		{RepositoryCredentials{URL: "https://github.com/*/*-infra", MatchType: "glob"}, "https://GitHub.com/foo/bar-infra", true},
+		{RepositoryCredentials{URL: "https://github.com/*/*-infra", MatchType: "glob"}, "https://github.com/foo/bar/baz-infra", false},
+		{RepositoryCredentials{URL: "https://github.com/**-infra", MatchType: "glob"}, "https://github.com/foo/bar/baz-infra", true},
+		{RepositoryCredentials{URL: "git@github.com:foo/repo?", MatchType: "glob"}, "git@github.com:foo/repo1", true},
+		{RepositoryCredentials{URL: "https://github.com/foo.bar/*", MatchType: "glob"}, "https://github.com/fooxbar/repo", false},
+		{RepositoryCredentials{URL: `https://github\.com/(a|b)/.*`, MatchType: "regex"}, "https://github.com/b/repo", true},
+		{RepositoryCredentials{URL: `https://github\.com/(a|b)/.*`, MatchType: "regex"}, "https://github.com/c/repo", false},
+		{RepositoryCredentials{URL: `github\.com/a/repo`, MatchType: "regex"}, "https://github.com/a/repo", false},
+		{RepositoryCredentials{URL: "https://github.com/(", MatchType: "regex"}, "https://github.com/(", false},
+		{RepositoryCredentials{URL: "https://github.com/", MatchType: "unknown"}, "https://github.com/a/repo", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.cred.Matches(tt.repoURL), "%s %s", tt.cred.URL, tt.repoURL)
+	}
+}
+
+func TestRepositoryCredentials_ValidateURL(t *testing.T) {
+	assert.NoError(t, (&RepositoryCredentials{URL: "https://github.com/"}).ValidateURL())
+	assert.NoError(t, (&RepositoryCredentials{URL: "https://github.com/(a|b)/*", MatchType: "glob"}).ValidateURL())
+	assert.NoError(t, (&RepositoryCredentials{URL: "https://github.com/.*", MatchType: "regex"}).ValidateURL())
+	assert.Error(t, (&RepositoryCredentials{URL: "https://github.com/(", MatchType: "regex"}).ValidateURL())
+	assert.Error(t, (&RepositoryCredentials{URL: "https://github.com/", MatchType: "exact"}).ValidateURL())
+}
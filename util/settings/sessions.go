@@ -0,0 +1,51 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// sessionsIdleTimeoutKey is the key of the duration after which idle login sessions expire in argocd-cm
+	sessionsIdleTimeoutKey = "sessions.idleTimeout"
+	// sessionsMaxLifetimeKey is the key of the maximum lifetime of login sessions in argocd-cm
+	sessionsMaxLifetimeKey = "sessions.maxLifetime"
+	// sessionsMaxPerAccountKey is the key of the maximum number of concurrent login sessions per account in argocd-cm
+	sessionsMaxPerAccountKey = "sessions.maxPerAccount"
+)
+
+// SessionSettings limits the login sessions of the local accounts. Zero values disable the respective limit.
+type SessionSettings struct {
+	// IdleTimeout is the duration after which login sessions which have not been used expire
+	IdleTimeout time.Duration
+	// MaxLifetime is the duration after which login sessions expire, regardless of their usage
+	MaxLifetime time.Duration
+	// MaxPerAccount is the maximum number of concurrent login sessions of an account. The oldest sessions are
+	// revoked when an account logs in once more.
+	MaxPerAccount int
+}
+
+// GetSessionSettings returns the limits of the login sessions configured in argocd-cm
+func (mgr *SettingsManager) GetSessionSettings() (*SessionSettings, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	var res SessionSettings
+	for key, duration := range map[string]*time.Duration{sessionsIdleTimeoutKey: &res.IdleTimeout, sessionsMaxLifetimeKey: &res.MaxLifetime} {
+		value, ok := argoCDCM.Data[key]
+		if !ok || value == "" {
+			continue
+		}
+		if *duration, err = time.ParseDuration(value); err != nil || *duration < 0 {
+			return nil, fmt.Errorf("invalid %s '%s': must be a positive duration, e.g. 1h", key, value)
+		}
+	}
+	if value, ok := argoCDCM.Data[sessionsMaxPerAccountKey]; ok && value != "" {
+		if res.MaxPerAccount, err = strconv.Atoi(value); err != nil || res.MaxPerAccount < 0 {
+			return nil, fmt.Errorf("invalid %s '%s': must be a positive number", sessionsMaxPerAccountKey, value)
+		}
+	}
+	return &res, nil
+}
@@ -0,0 +1,40 @@
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSessionSettings(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{})
+		sessionSettings, err := settingsManager.GetSessionSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, SessionSettings{}, *sessionSettings)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			"sessions.idleTimeout":   "30m",
+			"sessions.maxLifetime":   "12h",
+			"sessions.maxPerAccount": "3",
+		})
+		sessionSettings, err := settingsManager.GetSessionSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, SessionSettings{IdleTimeout: 30 * time.Minute, MaxLifetime: 12 * time.Hour, MaxPerAccount: 3}, *sessionSettings)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for key, value := range map[string]string{
+			"sessions.idleTimeout":   "often",
+			"sessions.maxLifetime":   "-1h",
+			"sessions.maxPerAccount": "many",
+		} {
+			_, settingsManager := fixtures(map[string]string{key: value})
+			_, err := settingsManager.GetSessionSettings()
+			assert.Error(t, err, key)
+		}
+	})
+}
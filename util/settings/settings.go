@@ -42,10 +42,14 @@ type ArgoCDSettings struct {
 	URL string `json:"url,omitempty"`
 	// Indicates if status badge is enabled or not.
 	StatusBadgeEnabled bool `json:"statusBadgeEnable"`
+	// StatusBadgeTokenRequired indicates if the status badges are only served to requests with a signed token
+	StatusBadgeTokenRequired bool `json:"statusBadgeTokenRequired,omitempty"`
 	// DexConfig contains portions of a dex config yaml
 	DexConfig string `json:"dexConfig,omitempty"`
 	// OIDCConfigRAW holds OIDC configuration as a raw string
 	OIDCConfigRAW string `json:"oidcConfig,omitempty"`
+	// OIDCProvidersRAW holds the configuration of additional OIDC providers as a raw string
+	OIDCProvidersRAW string `json:"oidcProviders,omitempty"`
 	// ServerSignature holds the key used to generate JWT tokens.
 	ServerSignature []byte `json:"serverSignature,omitempty"`
 	// Certificate holds the certificate/private key for the Argo CD API server.
@@ -67,6 +71,8 @@ type ArgoCDSettings struct {
 	KustomizeBuildOptions string `json:"kustomizeBuildOptions,omitempty"`
 	// Indicates if anonymous user is enabled or not
 	AnonymousUserEnabled bool `json:"anonymousUserEnabled,omitempty"`
+	// LogLevels holds the log levels overriding the levels set by the flags of the components, by component
+	LogLevels map[string]string `json:"logLevels,omitempty"`
 }
 
 type GoogleAnalytics struct {
@@ -90,6 +96,33 @@ type OIDCConfig struct {
 	CLIClientID            string                 `json:"cliClientID,omitempty"`
 	RequestedScopes        []string               `json:"requestedScopes,omitempty"`
 	RequestedIDTokenClaims map[string]*oidc.Claim `json:"requestedIDTokenClaims,omitempty"`
+	// EnablePKCEAuthentication protects the authorization code flow with PKCE, even if the provider does not advertise support for it
+	EnablePKCEAuthentication bool `json:"enablePKCEAuthentication,omitempty"`
+	// GroupsClaim is the name of the ID token claim which holds the groups of the user. Defaults to "groups".
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupsPrefix is prepended to the groups of the user, to tell apart equally named groups of different providers
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+}
+
+// GetLogLevels loads the log levels of the components from argocd-cm ConfigMap
+func (mgr *SettingsManager) GetLogLevels() (map[string]string, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	return getLogLevels(argoCDCM), nil
+}
+
+// SaveLogLevels saves the log levels of a component in argocd-cm ConfigMap, or removes them if levels is empty
+func (mgr *SettingsManager) SaveLogLevels(component string, levels string) error {
+	return mgr.updateConfigMap(func(argoCDCM *apiv1.ConfigMap) error {
+		if levels == "" {
+			delete(argoCDCM.Data, logLevelKeyPrefix+component)
+		} else {
+			argoCDCM.Data[logLevelKeyPrefix+component] = levels
+		}
+		return nil
+	})
 }
 
 // DEPRECATED. Helm repository credentials are now managed using RepoCredentials
@@ -102,6 +135,50 @@ type HelmRepoCredentials struct {
 	KeySecret      *apiv1.SecretKeySelector `json:"keySecret,omitempty"`
 }
 
+// GlobalProjectSettings names a global project, whose roles, sync windows and resource lists are
+// inherited by the projects matched by the label selector
+type GlobalProjectSettings struct {
+	ProjectName   string               `json:"projectName"`
+	LabelSelector metav1.LabelSelector `json:"labelSelector"`
+}
+
+// DeploymentEventSink is an external system the deployment events of applications are published to
+type DeploymentEventSink struct {
+	// Name identifies the sink in logs
+	Name string `json:"name"`
+	// Type is the type of the sink: http, nats, kafka or grafana
+	Type string `json:"type"`
+	// URL is the URL of the HTTP endpoint, of the NATS server (e.g. nats://nats:4222), of the Kafka REST proxy or of
+	// Grafana
+	URL string `json:"url"`
+	// Subject is the NATS subject the events are published to
+	Subject string `json:"subject,omitempty"`
+	// Topic is the Kafka topic the events are produced to
+	Topic string `json:"topic,omitempty"`
+	// Tags are added to the tags of the Grafana annotations
+	Tags []string `json:"tags,omitempty"`
+	// Headers are added to the requests of HTTP sinks
+	Headers map[string]string `json:"headers,omitempty"`
+	// TokenSecret is the secret storing the bearer token of HTTP, Kafka and Grafana requests, or the auth token of NATS
+	TokenSecret *apiv1.SecretKeySelector `json:"tokenSecret,omitempty"`
+}
+
+// DeepLink is a link to an external system, like a logging, metrics or runbook system, shown with the applications or
+// resources it applies to
+type DeepLink struct {
+	// Title is the title of the link
+	Title string `json:"title"`
+	// URL is the URL of the link. It is rendered as a Go template, e.g. https://grafana.example.com/d/pods?var-pod={{.resource.metadata.name}}
+	URL string `json:"url"`
+	// Description is a description of the link
+	Description string `json:"description,omitempty"`
+	// IconClass is the CSS class of the icon of the link in the UI, e.g. fa-book
+	IconClass string `json:"icon.class,omitempty"`
+	// Condition is an expression restricting the link to the applications or resources matching it, e.g.
+	// resource.kind == 'Pod'
+	Condition string `json:"if,omitempty"`
+}
+
 // Credentials for accessing a Git repository
 type Repository struct {
 	// The URL to the repository
@@ -122,6 +199,8 @@ type Repository struct {
 	Insecure bool `json:"insecure,omitempty"`
 	// Whether the repo is git-lfs enabled. Git only.
 	EnableLFS bool `json:"enableLfs,omitempty"`
+	// Whether to authenticate with the identity of the pods in the cloud of the registry. OCI only.
+	CloudIdentity bool `json:"cloudIdentity,omitempty"`
 	// Name of the secret storing the TLS client cert data
 	TLSClientCertDataSecret *apiv1.SecretKeySelector `json:"tlsClientCertDataSecret,omitempty"`
 	// Name of the secret storing the TLS client cert's key data
@@ -142,6 +221,10 @@ type RepositoryCredentials struct {
 	TLSClientCertDataSecret *apiv1.SecretKeySelector `json:"tlsClientCertDataSecret,omitempty"`
 	// Name of the secret storing the TLS client cert's key data
 	TLSClientCertKeySecret *apiv1.SecretKeySelector `json:"tlsClientCertKeySecret,omitempty"`
+	// MatchType is how the URL pattern matches the repository URL: prefix (default), glob or regex
+	MatchType string `json:"matchType,omitempty"`
+	// Priority of the credentials over other matching credentials
+	Priority int64 `json:"priority,omitempty"`
 }
 
 const (
@@ -171,8 +254,12 @@ const (
 	settingDexConfigKey = "dex.config"
 	// settingsOIDCConfigKey designates the key for OIDC config
 	settingsOIDCConfigKey = "oidc.config"
+	// settingsOIDCProvidersKey designates the key for the list of additional OIDC providers
+	settingsOIDCProvidersKey = "oidc.providers"
 	// statusBadgeEnabledKey holds the key which enables of disables status badge feature
 	statusBadgeEnabledKey = "statusbadge.enabled"
+	// statusBadgeTokenRequiredKey is the key which requires a signed token to get status badges
+	statusBadgeTokenRequiredKey = "statusbadge.requireToken"
 	// settingsWebhookGitHubSecret is the key for the GitHub shared webhook secret
 	settingsWebhookGitHubSecretKey = "webhook.github.secret"
 	// settingsWebhookGitLabSecret is the key for the GitLab shared webhook secret
@@ -187,6 +274,12 @@ const (
 	settingsApplicationInstanceLabelKey = "application.instanceLabelKey"
 	// resourcesCustomizationsKey is the key to the map of resource overrides
 	resourceCustomizationsKey = "resource.customizations"
+	// resourceHideGeneratedSecretDataKey is the key which disables hiding the data of the Secrets generated by
+	// controllers, e.g. of SealedSecrets, from diffs
+	resourceHideGeneratedSecretDataKey = "resource.hideGeneratedSecretData"
+	// resourceIgnoreResourceUpdatesEnabledKey is the key which enables ignoring the updates of the fields of resources
+	// configured in the ignoreResourceUpdates of the resource overrides
+	resourceIgnoreResourceUpdatesEnabledKey = "resource.ignoreResourceUpdatesEnabled"
 	// resourceExclusions is the key to the list of excluded resources
 	resourceExclusionsKey = "resource.exclusions"
 	// resourceInclusions is the key to the list of explicitly watched resources
@@ -197,8 +290,30 @@ const (
 	kustomizeBuildOptionsKey = "kustomize.buildOptions"
 	// anonymousUserEnabledKey is the key which enables or disables anonymous user
 	anonymousUserEnabledKey = "users.anonymous.enabled"
+	// globalProjectsKey is the key to the list of global projects and the projects which inherit them
+	globalProjectsKey = "globalProjects"
+	// deploymentEventSinksKey is the key to the list of sinks the deployment events of applications are published to
+	deploymentEventSinksKey = "deploymentEvents.sinks"
+	// ApplicationDeepLinksKey is the key to the list of deep links of applications
+	ApplicationDeepLinksKey = "application.links"
+	// ResourceDeepLinksKey is the key to the list of deep links of the resources of applications
+	ResourceDeepLinksKey = "resource.links"
+	// logLevelKeyPrefix is the prefix of the keys of the log levels of the components, e.g. loglevel.repo-server
+	logLevelKeyPrefix = "loglevel."
 )
 
+const (
+	// LogLevelComponentServer is the API server component of the log levels
+	LogLevelComponentServer = "server"
+	// LogLevelComponentRepoServer is the repo server component of the log levels
+	LogLevelComponentRepoServer = "repo-server"
+	// LogLevelComponentController is the application controller component of the log levels
+	LogLevelComponentController = "application-controller"
+)
+
+// LogLevelComponents are the components whose log levels can be overridden in argocd-cm
+var LogLevelComponents = []string{LogLevelComponentServer, LogLevelComponentRepoServer, LogLevelComponentController}
+
 // SettingsManager holds config info for a new manager with which to access Kubernetes ConfigMaps.
 type SettingsManager struct {
 	ctx        context.Context
@@ -404,10 +519,48 @@ func (mgr *SettingsManager) GetResourceOverrides() (map[string]v1alpha1.Resource
 			return nil, err
 		}
 	}
+	for key, override := range builtInResourceOverrides {
+		if _, ok := resourceOverrides[key]; !ok {
+			resourceOverrides[key] = override
+		}
+	}
 
 	return resourceOverrides, nil
 }
 
+// builtInResourceOverrides are the resource overrides of well known kinds, which the overrides of the same kinds in
+// argocd-cm replace
+var builtInResourceOverrides = map[string]v1alpha1.ResourceOverride{
+	// the controller of sealed secrets sets the status, and kubeseal adds a null creation timestamp to the template
+	"bitnami.com/SealedSecret": {
+		IgnoreDifferences: "jsonPointers:\n- /status\n- /spec/template/metadata/creationTimestamp\n",
+	},
+	"external-secrets.io/ExternalSecret": {
+		IgnoreDifferences: "jsonPointers:\n- /status\n",
+	},
+}
+
+// GetIgnoreResourceUpdatesEnabled returns whether the application controller ignores the updates of the fields of
+// resources configured in the ignoreResourceUpdates of the resource overrides, which it does only if enabled in
+// argocd-cm ConfigMap
+func (mgr *SettingsManager) GetIgnoreResourceUpdatesEnabled() (bool, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return false, err
+	}
+	return argoCDCM.Data[resourceIgnoreResourceUpdatesEnabledKey] == "true", nil
+}
+
+// GetHideGeneratedSecretData returns whether the data of the Secrets generated by controllers, e.g. of SealedSecrets or
+// ExternalSecrets, is hidden from diffs, which it is unless disabled in argocd-cm ConfigMap
+func (mgr *SettingsManager) GetHideGeneratedSecretData() (bool, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return false, err
+	}
+	return argoCDCM.Data[resourceHideGeneratedSecretDataKey] != "false", nil
+}
+
 // GetKustomizeBuildOptions loads the kustomize build options from argocd-cm ConfigMap
 func (mgr *SettingsManager) GetKustomizeBuildOptions() (string, error) {
 	argoCDCM, err := mgr.getConfigMap()
@@ -420,6 +573,52 @@ func (mgr *SettingsManager) GetKustomizeBuildOptions() (string, error) {
 	return "", nil
 }
 
+// GetGlobalProjectsSettings loads the global projects settings from argocd-cm ConfigMap
+func (mgr *SettingsManager) GetGlobalProjectsSettings() ([]GlobalProjectSettings, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	globalProjectSettings := make([]GlobalProjectSettings, 0)
+	if value, ok := argoCDCM.Data[globalProjectsKey]; ok {
+		if err := yaml.Unmarshal([]byte(value), &globalProjectSettings); err != nil {
+			return nil, err
+		}
+	}
+	return globalProjectSettings, nil
+}
+
+// GetDeploymentEventSinks loads the sinks of deployment events from argocd-cm ConfigMap
+func (mgr *SettingsManager) GetDeploymentEventSinks() ([]DeploymentEventSink, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	sinks := make([]DeploymentEventSink, 0)
+	if value, ok := argoCDCM.Data[deploymentEventSinksKey]; ok {
+		if err := yaml.Unmarshal([]byte(value), &sinks); err != nil {
+			return nil, err
+		}
+	}
+	return sinks, nil
+}
+
+// GetDeepLinks loads the deep links of the given key, ApplicationDeepLinksKey or ResourceDeepLinksKey, from argocd-cm
+// ConfigMap
+func (mgr *SettingsManager) GetDeepLinks(key string) ([]DeepLink, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	links := make([]DeepLink, 0)
+	if value, ok := argoCDCM.Data[key]; ok {
+		if err := yaml.Unmarshal([]byte(value), &links); err != nil {
+			return nil, err
+		}
+	}
+	return links, nil
+}
+
 // DEPRECATED. Helm repository credentials are now managed using RepoCredentials
 func (mgr *SettingsManager) GetHelmRepositories() ([]HelmRepoCredentials, error) {
 	argoCDCM, err := mgr.getConfigMap()
@@ -660,10 +859,23 @@ func (mgr *SettingsManager) ensureSynced(forceResync bool) error {
 func updateSettingsFromConfigMap(settings *ArgoCDSettings, argoCDCM *apiv1.ConfigMap) {
 	settings.DexConfig = argoCDCM.Data[settingDexConfigKey]
 	settings.OIDCConfigRAW = argoCDCM.Data[settingsOIDCConfigKey]
+	settings.OIDCProvidersRAW = argoCDCM.Data[settingsOIDCProvidersKey]
 	settings.URL = argoCDCM.Data[settingURLKey]
 	settings.KustomizeBuildOptions = argoCDCM.Data[kustomizeBuildOptionsKey]
 	settings.StatusBadgeEnabled = argoCDCM.Data[statusBadgeEnabledKey] == "true"
+	settings.StatusBadgeTokenRequired = argoCDCM.Data[statusBadgeTokenRequiredKey] == "true"
 	settings.AnonymousUserEnabled = argoCDCM.Data[anonymousUserEnabledKey] == "true"
+	settings.LogLevels = getLogLevels(argoCDCM)
+}
+
+func getLogLevels(argoCDCM *apiv1.ConfigMap) map[string]string {
+	logLevels := make(map[string]string)
+	for _, component := range LogLevelComponents {
+		if levels, ok := argoCDCM.Data[logLevelKeyPrefix+component]; ok {
+			logLevels[component] = levels
+		}
+	}
+	return logLevels
 }
 
 // updateSettingsFromSecret transfers settings from a Kubernetes secret into an ArgoCDSettings struct.
@@ -730,6 +942,11 @@ func (mgr *SettingsManager) SaveSettings(settings *ArgoCDSettings) error {
 		} else {
 			delete(argoCDCM.Data, settingsOIDCConfigKey)
 		}
+		if settings.OIDCProvidersRAW != "" {
+			argoCDCM.Data[settingsOIDCProvidersKey] = settings.OIDCProvidersRAW
+		} else {
+			delete(argoCDCM.Data, settingsOIDCProvidersKey)
+		}
 		return nil
 	})
 
@@ -843,6 +1060,9 @@ func (a *ArgoCDSettings) IsSSOConfigured() bool {
 	if a.OIDCConfig() != nil {
 		return true
 	}
+	if len(a.AdditionalOIDCConfigs()) > 0 {
+		return true
+	}
 	return false
 }
 
@@ -873,6 +1093,49 @@ func (a *ArgoCDSettings) OIDCConfig() *OIDCConfig {
 	return &oidcConfig
 }
 
+// AdditionalOIDCConfigs returns the OIDC providers which users may log in with besides the one
+// configured in oidc.config (or Dex). Providers are identified by their name, which must be unique.
+func (a *ArgoCDSettings) AdditionalOIDCConfigs() []OIDCConfig {
+	if a.OIDCProvidersRAW == "" {
+		return nil
+	}
+	var oidcConfigs []OIDCConfig
+	err := yaml.Unmarshal([]byte(a.OIDCProvidersRAW), &oidcConfigs)
+	if err != nil {
+		log.Warnf("invalid oidc providers: %v", err)
+		return nil
+	}
+	names := map[string]bool{}
+	if oidcConfig := a.OIDCConfig(); oidcConfig != nil {
+		names[oidcConfig.Name] = true
+	}
+	var res []OIDCConfig
+	for _, oidcConfig := range oidcConfigs {
+		if oidcConfig.Name == "" || names[oidcConfig.Name] {
+			log.Warnf("ignoring oidc provider with missing or duplicate name '%s'", oidcConfig.Name)
+			continue
+		}
+		names[oidcConfig.Name] = true
+		oidcConfig.ClientSecret = ReplaceStringSecret(oidcConfig.ClientSecret, a.Secrets)
+		res = append(res, oidcConfig)
+	}
+	return res
+}
+
+// OIDCConfigByIssuer returns the configuration of the OIDC provider with the given issuer URL, or
+// nil if the issuer is not one of the configured providers
+func (a *ArgoCDSettings) OIDCConfigByIssuer(issuer string) *OIDCConfig {
+	if oidcConfig := a.OIDCConfig(); oidcConfig != nil && oidcConfig.Issuer == issuer {
+		return oidcConfig
+	}
+	for _, oidcConfig := range a.AdditionalOIDCConfigs() {
+		if oidcConfig.Issuer == issuer {
+			return &oidcConfig
+		}
+	}
+	return nil
+}
+
 // TLSConfig returns a tls.Config with the configured certificates
 func (a *ArgoCDSettings) TLSConfig() *tls.Config {
 	if a.Certificate == nil {
@@ -52,6 +52,45 @@ func TestGetRepositories(t *testing.T) {
 	assert.Equal(t, []Repository{{URL: "http://foo"}}, filter)
 }
 
+func TestGetDeploymentEventSinks(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{
+		"deploymentEvents.sinks": "\n  - name: dora\n    type: http\n    url: http://foo\n",
+	})
+	sinks, err := settingsManager.GetDeploymentEventSinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []DeploymentEventSink{{Name: "dora", Type: "http", URL: "http://foo"}}, sinks)
+}
+
+func TestGetDeepLinks(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{
+		"resource.links": "\n  - title: Logs\n    url: https://logs.example.com/{{.resource.metadata.name}}\n    if: resource.kind == 'Pod'\n",
+	})
+	links, err := settingsManager.GetDeepLinks(ResourceDeepLinksKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []DeepLink{{Title: "Logs", URL: "https://logs.example.com/{{.resource.metadata.name}}", Condition: "resource.kind == 'Pod'"}}, links)
+
+	links, err = settingsManager.GetDeepLinks(ApplicationDeepLinksKey)
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+}
+
+func TestSaveLogLevels(t *testing.T) {
+	kubeClient, settingsManager := fixtures(map[string]string{
+		"loglevel.server": "debug",
+	})
+	err := settingsManager.SaveLogLevels(LogLevelComponentRepoServer, "info,git=debug")
+	assert.NoError(t, err)
+	cm, err := kubeClient.CoreV1().ConfigMaps("default").Get(common.ArgoCDConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "info,git=debug", cm.Data["loglevel.repo-server"])
+
+	err = settingsManager.SaveLogLevels(LogLevelComponentServer, "")
+	assert.NoError(t, err)
+	logLevels, err := settingsManager.GetLogLevels()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{LogLevelComponentRepoServer: "info,git=debug"}, logLevels)
+}
+
 func TestSaveRepositories(t *testing.T) {
 	kubeClient, settingsManager := fixtures(nil)
 	err := settingsManager.SaveRepositories([]Repository{{URL: "http://foo"}})
@@ -156,6 +195,44 @@ func TestGetResourceOverrides(t *testing.T) {
 	assert.Equal(t, v1alpha1.ResourceOverride{
 		IgnoreDifferences: "jsonPointers:\n- /webhooks/0/clientConfig/caBundle",
 	}, webHookOverrides)
+
+	// the built-in overrides apply unless replaced
+	assert.Contains(t, overrides["bitnami.com/SealedSecret"].IgnoreDifferences, "/status")
+
+	_, settingsManager = fixtures(map[string]string{
+		"resource.customizations": `
+    bitnami.com/SealedSecret:
+      ignoreDifferences: |
+        jsonPointers:
+        - /spec/template`,
+	})
+	overrides, err = settingsManager.GetResourceOverrides()
+	assert.NoError(t, err)
+	assert.Equal(t, "jsonPointers:\n- /spec/template", overrides["bitnami.com/SealedSecret"].IgnoreDifferences)
+}
+
+func TestSettingsManager_GetHideGeneratedSecretData(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{})
+	hide, err := settingsManager.GetHideGeneratedSecretData()
+	assert.NoError(t, err)
+	assert.True(t, hide)
+
+	_, settingsManager = fixtures(map[string]string{"resource.hideGeneratedSecretData": "false"})
+	hide, err = settingsManager.GetHideGeneratedSecretData()
+	assert.NoError(t, err)
+	assert.False(t, hide)
+}
+
+func TestSettingsManager_GetIgnoreResourceUpdatesEnabled(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{})
+	enabled, err := settingsManager.GetIgnoreResourceUpdatesEnabled()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	_, settingsManager = fixtures(map[string]string{"resource.ignoreResourceUpdatesEnabled": "true"})
+	enabled, err = settingsManager.GetIgnoreResourceUpdatesEnabled()
+	assert.NoError(t, err)
+	assert.True(t, enabled)
 }
 
 func TestSettingsManager_GetKustomizeBuildOptions(t *testing.T) {
@@ -265,3 +342,37 @@ func TestRedirectURL(t *testing.T) {
 		assert.Equal(t, expected[1], dexRedirectURL)
 	}
 }
+
+func TestAdditionalOIDCConfigs(t *testing.T) {
+	settings := ArgoCDSettings{
+		OIDCConfigRAW: "name: Okta\nissuer: https://okta.example.com\nclientID: argo-cd\n",
+		OIDCProvidersRAW: `
+- name: Partner
+  issuer: https://partner.example.com
+  clientID: argo-cd-partner
+  clientSecret: $oidc.partner.clientSecret
+  groupsClaim: roles
+  groupsPrefix: "partner:"
+- name: Okta
+  issuer: https://duplicate.example.com
+- issuer: https://unnamed.example.com
+`,
+		Secrets: map[string]string{"oidc.partner.clientSecret": "secret"},
+	}
+	assert.True(t, settings.IsSSOConfigured())
+
+	oidcConfigs := settings.AdditionalOIDCConfigs()
+	if assert.Len(t, oidcConfigs, 1) {
+		assert.Equal(t, "Partner", oidcConfigs[0].Name)
+		assert.Equal(t, "secret", oidcConfigs[0].ClientSecret)
+		assert.Equal(t, "roles", oidcConfigs[0].GroupsClaim)
+		assert.Equal(t, "partner:", oidcConfigs[0].GroupsPrefix)
+	}
+
+	assert.Equal(t, "Okta", settings.OIDCConfigByIssuer("https://okta.example.com").Name)
+	assert.Equal(t, "Partner", settings.OIDCConfigByIssuer("https://partner.example.com").Name)
+	assert.Nil(t, settings.OIDCConfigByIssuer("https://duplicate.example.com"))
+
+	assert.True(t, (&ArgoCDSettings{OIDCProvidersRAW: "- name: Partner\n  issuer: https://partner.example.com\n"}).IsSSOConfigured())
+	assert.False(t, (&ArgoCDSettings{}).IsSSOConfigured())
+}
@@ -0,0 +1,322 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/server/rbacpolicy"
+	"github.com/argoproj/argo-cd/util/argo"
+	"github.com/argoproj/argo-cd/util/deeplink"
+	"github.com/argoproj/argo-cd/util/dex"
+	"github.com/argoproj/argo-cd/util/lua"
+	"github.com/argoproj/argo-cd/util/rbac"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// Validator validates one group of settings, and returns a summary of them
+type Validator func(mgr *settings.SettingsManager) (string, error)
+
+// Validators are the validators of the groups of settings of the argocd-cm, argocd-rbac-cm and argocd-secret
+var Validators = map[string]Validator{
+	"general":            validateGeneralSettings,
+	"accounts":           validateAccounts,
+	"repositories":       validateRepositories,
+	"plugins":            validatePlugins,
+	"sso":                validateSSO,
+	"resource-overrides": validateResourceOverrides,
+	"deep-links":         validateDeepLinks,
+	"group-sync":         validateGroupSync,
+	"sessions":           validateSessions,
+	"rbac":               validateRBAC,
+}
+
+// Groups returns the sorted names of the groups of settings
+func Groups() []string {
+	var groups []string
+	for name := range Validators {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+func validateGeneralSettings(mgr *settings.SettingsManager) (string, error) {
+	argoSettings, err := mgr.GetSettings()
+	if err != nil {
+		return "", err
+	}
+	if _, err = mgr.GetResourcesFilter(); err != nil {
+		return "", err
+	}
+	if _, err = mgr.GetKustomizeBuildOptions(); err != nil {
+		return "", err
+	}
+	if argoSettings.URL == "" {
+		return "Argo CD URL is not set", nil
+	}
+	return fmt.Sprintf("Argo CD URL: %s", argoSettings.URL), nil
+}
+
+func validateAccounts(mgr *settings.SettingsManager) (string, error) {
+	accounts, err := mgr.GetAccounts()
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for name, account := range accounts {
+		if account.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%d accounts are enabled: %s", len(names), strings.Join(names, ", ")), nil
+}
+
+func validateRepositories(mgr *settings.SettingsManager) (string, error) {
+	repos, err := mgr.GetRepositories()
+	if err != nil {
+		return "", fmt.Errorf("invalid repositories: %v", err)
+	}
+	creds, err := mgr.GetRepositoryCredentials()
+	if err != nil {
+		return "", fmt.Errorf("invalid repository.credentials: %v", err)
+	}
+	helmRepos, err := mgr.GetHelmRepositories()
+	if err != nil {
+		return "", fmt.Errorf("invalid helm.repositories: %v", err)
+	}
+
+	secretsLister, err := mgr.GetSecretsLister()
+	if err != nil {
+		return "", err
+	}
+	secrets, err := secretsLister.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	secretKeys := make(map[string]map[string]bool)
+	for _, secret := range secrets {
+		keys := make(map[string]bool)
+		for key := range secret.Data {
+			keys[key] = true
+		}
+		secretKeys[secret.Name] = keys
+	}
+	var errs []string
+	checkSecrets := func(kind string, url string, selectors ...*corev1.SecretKeySelector) {
+		if url == "" {
+			errs = append(errs, fmt.Sprintf("the URL of a %s is missing", kind))
+			return
+		}
+		for _, selector := range selectors {
+			if selector == nil {
+				continue
+			}
+			if keys, ok := secretKeys[selector.Name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s %s: secret '%s' does not exist", kind, url, selector.Name))
+			} else if !keys[selector.Key] {
+				errs = append(errs, fmt.Sprintf("%s %s: secret '%s' has no key '%s'", kind, url, selector.Name, selector.Key))
+			}
+		}
+	}
+	for _, repo := range repos {
+		checkSecrets("repository", repo.URL, repo.UsernameSecret, repo.PasswordSecret, repo.SSHPrivateKeySecret, repo.TLSClientCertDataSecret, repo.TLSClientCertKeySecret)
+	}
+	for _, cred := range creds {
+		checkSecrets("repository credential", cred.URL, cred.UsernameSecret, cred.PasswordSecret, cred.SSHPrivateKeySecret, cred.TLSClientCertDataSecret, cred.TLSClientCertKeySecret)
+		if err := cred.ValidateURL(); err != nil {
+			errs = append(errs, fmt.Sprintf("repository credential %s: %v", cred.URL, err))
+		}
+	}
+	for _, repo := range helmRepos {
+		checkSecrets("Helm repository", repo.URL, repo.UsernameSecret, repo.PasswordSecret, repo.CertSecret, repo.KeySecret)
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return fmt.Sprintf("%d repositories, %d repository credentials, %d Helm repositories", len(repos), len(creds), len(helmRepos)), nil
+}
+
+func validatePlugins(mgr *settings.SettingsManager) (string, error) {
+	plugins, err := mgr.GetConfigManagementPlugins()
+	if err != nil {
+		return "", err
+	}
+	for _, plugin := range plugins {
+		if plugin.Name == "" {
+			return "", fmt.Errorf("the name of a config management plugin is missing")
+		}
+		if len(plugin.Generate.Command) == 0 {
+			return "", fmt.Errorf("config management plugin '%s' is missing a generate command", plugin.Name)
+		}
+	}
+	return fmt.Sprintf("%d plugins", len(plugins)), nil
+}
+
+func validateSSO(mgr *settings.SettingsManager) (string, error) {
+	argoSettings, err := mgr.GetSettings()
+	if err != nil {
+		return "", err
+	}
+	var summary []string
+	if argoSettings.OIDCConfigRAW != "" {
+		// unlike OIDCConfig(), fail instead of ignoring invalid configs
+		var oidcConfig settings.OIDCConfig
+		if err := yaml.Unmarshal([]byte(argoSettings.OIDCConfigRAW), &oidcConfig); err != nil {
+			return "", fmt.Errorf("invalid oidc.config: %v", err)
+		}
+		if oidcConfig.Issuer == "" || oidcConfig.ClientID == "" {
+			return "", fmt.Errorf("oidc.config requires an issuer and a clientID")
+		}
+		summary = append(summary, fmt.Sprintf("OIDC is configured with issuer %s", oidcConfig.Issuer))
+	}
+	if argoSettings.IsDexConfigured() {
+		if _, err := dex.GenerateDexConfigYAML(argoSettings); err != nil {
+			return "", err
+		}
+		summary = append(summary, "Dex is configured")
+	}
+	if argoSettings.OIDCProvidersRAW != "" {
+		var providers []settings.OIDCConfig
+		if err := yaml.Unmarshal([]byte(argoSettings.OIDCProvidersRAW), &providers); err != nil {
+			return "", fmt.Errorf("invalid additional OIDC providers: %v", err)
+		}
+		summary = append(summary, fmt.Sprintf("%d additional OIDC providers", len(argoSettings.AdditionalOIDCConfigs())))
+	}
+	if len(summary) == 0 {
+		return "SSO is not configured", nil
+	}
+	return strings.Join(summary, "\n"), nil
+}
+
+func validateResourceOverrides(mgr *settings.SettingsManager) (string, error) {
+	overrides, err := mgr.GetResourceOverrides()
+	if err != nil {
+		return "", err
+	}
+	var keys []string
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errs []string
+	for _, key := range keys {
+		override := overrides[key]
+		if override.HealthLua != "" {
+			if err := lua.ValidateScript(override.HealthLua); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: invalid health.lua: %v", key, err))
+			}
+		}
+		if override.Actions != "" {
+			actions, err := override.GetActions()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: invalid actions: %v", key, err))
+				continue
+			}
+			if err := lua.ValidateScript(actions.ActionDiscoveryLua); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: invalid discovery.lua: %v", key, err))
+			}
+			for _, action := range actions.Definitions {
+				if err := lua.ValidateScript(action.ActionLua); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: invalid action.lua of action '%s': %v", key, action.Name, err))
+				}
+			}
+		}
+	}
+	if _, err := argo.NewDiffNormalizer(nil, overrides); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid ignoreDifferences: %v", err))
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return fmt.Sprintf("%d resource overrides", len(overrides)), nil
+}
+
+func validateDeepLinks(mgr *settings.SettingsManager) (string, error) {
+	var errs []string
+	var summary []string
+	for _, key := range []string{settings.ApplicationDeepLinksKey, settings.ResourceDeepLinksKey} {
+		links, err := mgr.GetDeepLinks(key)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid %s: %v", key, err))
+			continue
+		}
+		for _, link := range links {
+			if err := deeplink.Validate(link); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			}
+		}
+		summary = append(summary, fmt.Sprintf("%d %s", len(links), key))
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return strings.Join(summary, ", "), nil
+}
+
+func validateGroupSync(mgr *settings.SettingsManager) (string, error) {
+	config, err := mgr.GetGroupSyncConfig()
+	if err != nil {
+		return "", err
+	}
+	if config == nil {
+		return "Groups are not synchronized", nil
+	}
+	var sources []string
+	if config.Keycloak != nil {
+		sources = append(sources, fmt.Sprintf("Keycloak realm %s", config.Keycloak.Realm))
+	}
+	if config.LDAP != nil {
+		sources = append(sources, fmt.Sprintf("LDAP %s", config.LDAP.URL))
+	}
+	return fmt.Sprintf("Groups are synchronized every %v from %s", config.GetInterval(), strings.Join(sources, " and ")), nil
+}
+
+func validateSessions(mgr *settings.SettingsManager) (string, error) {
+	sessionSettings, err := mgr.GetSessionSettings()
+	if err != nil {
+		return "", err
+	}
+	var limits []string
+	if sessionSettings.IdleTimeout > 0 {
+		limits = append(limits, fmt.Sprintf("expire after %v of inactivity", sessionSettings.IdleTimeout))
+	}
+	if sessionSettings.MaxLifetime > 0 {
+		limits = append(limits, fmt.Sprintf("expire after %v", sessionSettings.MaxLifetime))
+	}
+	if sessionSettings.MaxPerAccount > 0 {
+		limits = append(limits, fmt.Sprintf("are limited to %d per account", sessionSettings.MaxPerAccount))
+	}
+	if len(limits) == 0 {
+		return "Login sessions are not limited", nil
+	}
+	return fmt.Sprintf("Login sessions %s", strings.Join(limits, ", ")), nil
+}
+
+func validateRBAC(mgr *settings.SettingsManager) (string, error) {
+	cm, err := mgr.GetConfigMapByName(common.ArgoCDRBACConfigMapName)
+	if err != nil {
+		return "", err
+	}
+	var errs []string
+	for _, policyErr := range rbacpolicy.ValidatePolicy(cm.Data[rbac.ConfigMapPolicyCSVKey]) {
+		if policyErr.Line > 0 {
+			errs = append(errs, fmt.Sprintf("line %d: %s", policyErr.Line, policyErr.Message))
+		} else {
+			errs = append(errs, policyErr.Message)
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	if defaultRole := cm.Data[rbac.ConfigMapPolicyDefaultKey]; defaultRole != "" {
+		return fmt.Sprintf("Policy is valid, the default role is %s", defaultRole), nil
+	}
+	return "Policy is valid", nil
+}
@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+const testNamespace = "argocd"
+
+func newTestSettingsManager(cmData map[string]string, rbacCMData map[string]string, objects ...runtime.Object) *settings.SettingsManager {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		}
+	}
+	objects = append(objects,
+		&corev1.ConfigMap{ObjectMeta: objectMeta(common.ArgoCDConfigMapName), Data: cmData},
+		&corev1.ConfigMap{ObjectMeta: objectMeta(common.ArgoCDRBACConfigMapName), Data: rbacCMData},
+		&corev1.Secret{ObjectMeta: objectMeta(common.ArgoCDSecretName), Data: map[string][]byte{"server.secretkey": []byte("test")}})
+	return settings.NewSettingsManager(context.Background(), fake.NewSimpleClientset(objects...), testNamespace)
+}
+
+func TestValidateGeneralSettings(t *testing.T) {
+	summary, err := Validators["general"](newTestSettingsManager(map[string]string{"url": "https://argocd.example.com"}, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Argo CD URL: https://argocd.example.com", summary)
+}
+
+func TestValidateRepositories(t *testing.T) {
+	repoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"username": []byte("admin")},
+	}
+	summary, err := Validators["repositories"](newTestSettingsManager(map[string]string{"repositories": `
+- url: https://github.com/argoproj/argocd-example-apps
+  usernameSecret:
+    name: repo-secret
+    key: username
+`}, nil, repoSecret))
+	assert.NoError(t, err)
+	assert.Equal(t, "1 repositories, 0 repository credentials, 0 Helm repositories", summary)
+
+	_, err = Validators["repositories"](newTestSettingsManager(map[string]string{"repositories": `
+- url: https://github.com/argoproj/argocd-example-apps
+  usernameSecret:
+    name: repo-secret
+    key: username
+  passwordSecret:
+    name: repo-secret
+    key: password
+- sshPrivateKeySecret:
+    name: missing-secret
+    key: sshPrivateKey
+`, "helm.repositories": `
+- url: https://charts.example.com
+  name: example
+  caSecret:
+    name: missing-secret
+    key: ca
+  certSecret:
+    name: missing-secret
+    key: cert
+`}, nil, repoSecret))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "repository https://github.com/argoproj/argocd-example-apps: secret 'repo-secret' has no key 'password'")
+		assert.Contains(t, err.Error(), "the URL of a repository is missing")
+		assert.Contains(t, err.Error(), "Helm repository https://charts.example.com: secret 'missing-secret' does not exist")
+	}
+}
+
+func TestValidateResourceOverrides(t *testing.T) {
+	summary, err := Validators["resource-overrides"](newTestSettingsManager(map[string]string{"resource.customizations": `
+argoproj.io/Rollout:
+  health.lua: |
+    hs = {}
+    hs.status = "Healthy"
+    return hs
+`}, nil))
+	assert.NoError(t, err)
+	// the built-in overrides of SealedSecrets and ExternalSecrets are counted too
+	assert.Equal(t, "3 resource overrides", summary)
+
+	_, err = Validators["resource-overrides"](newTestSettingsManager(map[string]string{"resource.customizations": `
+argoproj.io/Rollout:
+  health.lua: |
+    hs = {
+  actions: |
+    definitions:
+    - name: restart
+      action.lua: return obj end
+`}, nil))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "argoproj.io/Rollout: invalid health.lua")
+		assert.Contains(t, err.Error(), "invalid action.lua of action 'restart'")
+	}
+}
+
+func TestValidateSSO(t *testing.T) {
+	summary, err := Validators["sso"](newTestSettingsManager(nil, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "SSO is not configured", summary)
+
+	_, err = Validators["sso"](newTestSettingsManager(map[string]string{"oidc.config": "name: Okta"}, nil))
+	assert.EqualError(t, err, "oidc.config requires an issuer and a clientID")
+}
+
+func TestValidateDeepLinks(t *testing.T) {
+	summary, err := Validators["deep-links"](newTestSettingsManager(map[string]string{"resource.links": `
+- title: Logs
+  url: https://logs.example.com/{{.resource.metadata.name}}
+  if: resource.kind == 'Pod'
+`}, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "0 application.links, 1 resource.links", summary)
+
+	_, err = Validators["deep-links"](newTestSettingsManager(map[string]string{"application.links": `
+- title: Dashboard
+  url: https://grafana.example.com/{{.app.metadata.name
+`}, nil))
+	assert.Error(t, err)
+}
+
+func TestValidateRBAC(t *testing.T) {
+	summary, err := Validators["rbac"](newTestSettingsManager(nil, map[string]string{"policy.csv": "p, role:dev, applications, get, */*, allow", "policy.default": "role:readonly"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Policy is valid, the default role is role:readonly", summary)
+
+	_, err = Validators["rbac"](newTestSettingsManager(nil, map[string]string{"policy.csv": "p, role:dev, applications, fly, */*, allow"}))
+	assert.Error(t, err)
+}
+
+func TestWatcher(t *testing.T) {
+	watcher := NewWatcher(newTestSettingsManager(nil, map[string]string{"policy.csv": "p, role:dev, applications, fly, */*, allow"}))
+	assert.NoError(t, watcher.Check())
+
+	watcher.Validate()
+	errors := watcher.Errors()
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors, "rbac")
+	if err := watcher.Check(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid settings: rbac: ")
+	}
+	assert.Equal(t, float64(1), testutil.ToFloat64(watcher.gauge.WithLabelValues("rbac")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(watcher.gauge.WithLabelValues("general")))
+}
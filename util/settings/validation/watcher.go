@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-cd/util/settings"
+)
+
+// defaultValidationInterval is the interval of revalidating the settings. Invalid settings don't notify the
+// subscribers of the settings manager, so fixing or breaking some settings is only detected by revalidating them.
+const defaultValidationInterval = time.Minute
+
+// Watcher validates the settings of a component when it starts and whenever they change. It logs the errors of the
+// invalid groups of settings, and exposes them as the argocd_config_error metric.
+type Watcher struct {
+	mgr      *settings.SettingsManager
+	interval time.Duration
+	gauge    *prometheus.GaugeVec
+
+	lock   sync.Mutex
+	errors map[string]string
+}
+
+// NewWatcher returns a watcher of the settings of the settings manager
+func NewWatcher(mgr *settings.SettingsManager) *Watcher {
+	return &Watcher{
+		mgr:      mgr,
+		interval: defaultValidationInterval,
+		gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "argocd_config_error",
+			Help: "Whether a group of settings of the argocd-cm, argocd-rbac-cm and argocd-secret is invalid (1) or valid (0).",
+		}, []string{"group"}),
+		errors: make(map[string]string),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (w *Watcher) Describe(ch chan<- *prometheus.Desc) {
+	w.gauge.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface
+func (w *Watcher) Collect(ch chan<- prometheus.Metric) {
+	w.gauge.Collect(ch)
+}
+
+// Errors returns the errors of the invalid groups of settings, by group
+func (w *Watcher) Errors() map[string]string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	res := make(map[string]string, len(w.errors))
+	for group, err := range w.errors {
+		res[group] = err
+	}
+	return res
+}
+
+// Validate validates all groups of settings. The errors are logged when they differ from the previous validation.
+func (w *Watcher) Validate() {
+	errors := make(map[string]string)
+	for _, group := range Groups() {
+		if _, err := Validators[group](w.mgr); err != nil {
+			errors[group] = err.Error()
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, group := range Groups() {
+		logCtx := log.WithField("group", group)
+		err, invalid := errors[group]
+		if prevErr, wasInvalid := w.errors[group]; invalid && (!wasInvalid || prevErr != err) {
+			logCtx.Errorf("Invalid %s settings: %s", group, err)
+		} else if !invalid && wasInvalid {
+			logCtx.Infof("The %s settings are valid again", group)
+		}
+		if invalid {
+			w.gauge.WithLabelValues(group).Set(1)
+		} else {
+			w.gauge.WithLabelValues(group).Set(0)
+		}
+	}
+	w.errors = errors
+}
+
+// Run validates the settings until the context is done
+func (w *Watcher) Run(ctx context.Context) {
+	updateCh := make(chan *settings.ArgoCDSettings, 1)
+	w.mgr.Subscribe(updateCh)
+	defer w.mgr.Unsubscribe(updateCh)
+
+	// the settings manager blocks while notifying its subscribers, so the updates are received independently
+	// of the validation, which uses the settings manager
+	changed := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-updateCh:
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	w.Validate()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+			w.Validate()
+		case <-ticker.C:
+			w.Validate()
+		}
+	}
+}
+
+// Check returns an error listing the invalid groups of settings, if any. It is meant to be a health check of the
+// dependencies of a component.
+func (w *Watcher) Check() error {
+	errors := w.Errors()
+	if len(errors) == 0 {
+		return nil
+	}
+	var groups []string
+	for group := range errors {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	var messages []string
+	for _, group := range groups {
+		messages = append(messages, fmt.Sprintf("%s: %s", group, errors[group]))
+	}
+	return fmt.Errorf("invalid settings: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,50 @@
+package sops
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	executil "github.com/argoproj/argo-cd/util/exec"
+)
+
+// IsEncrypted returns whether YAML or JSON data is a SOPS-encrypted file, which has the metadata of the encryption in
+// its top level sops key
+func IsEncrypted(data []byte) bool {
+	if !strings.Contains(string(data), "sops") {
+		return false
+	}
+	var obj map[string]interface{}
+	// only the first document of multi-document YAML is parsed, SOPS adds the metadata to every document
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return false
+	}
+	metadata, ok := obj["sops"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = metadata["mac"]
+	return ok
+}
+
+// Decrypt decrypts a SOPS-encrypted file with the sops binary, using the keys available to the repo server, e.g. age
+// keys referenced by SOPS_AGE_KEY_FILE, the GPG keyring or the cloud credentials of KMS keys. The decrypted data has the
+// format of the file.
+func Decrypt(path string) ([]byte, error) {
+	cmd := exec.Command("sops", "--decrypt", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+	args := strings.Join(cmd.Args, " ")
+	out, err := executil.RunWithRedactor(cmd, func(text string) string {
+		// the output is the decrypted file, which must not be logged
+		if text == args {
+			return text
+		}
+		return "<decrypted>"
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
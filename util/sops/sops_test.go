@@ -0,0 +1,28 @@
+package sops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEncrypted(t *testing.T) {
+	assert.True(t, IsEncrypted([]byte(`
+apiVersion: v1
+kind: Secret
+stringData:
+  password: ENC[AES256_GCM,data:Tr7o1lI=,type:str]
+sops:
+  mac: ENC[AES256_GCM,data:ZXhhbXBsZQ==,type:str]
+  version: 3.5.0
+`)))
+	assert.True(t, IsEncrypted([]byte(`{"data":"ENC[AES256_GCM,data:Tr7o1lI=,type:str]","sops":{"mac":"ENC[AES256_GCM,data:ZXhhbXBsZQ==,type:str]"}}`)))
+	assert.False(t, IsEncrypted([]byte(`
+apiVersion: v1
+kind: ConfigMap
+data:
+  sops: enabled
+`)))
+	assert.False(t, IsEncrypted([]byte(`{"sops":{"version":"3.5.0"}}`)))
+	assert.False(t, IsEncrypted([]byte(`- not a map`)))
+}
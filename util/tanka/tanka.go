@@ -0,0 +1,102 @@
+package tanka
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	executil "github.com/argoproj/argo-cd/util/exec"
+	"github.com/argoproj/argo-cd/util/kube"
+)
+
+const (
+	specFile        = "spec.json"
+	jsonnetfileFile = "jsonnetfile.json"
+	vendorDir       = "vendor"
+)
+
+// IsEnvironment returns whether a file is the spec.json of a Tanka environment
+func IsEnvironment(path string) bool {
+	if filepath.Base(path) != specFile {
+		return false
+	}
+	data, err := ioutil.ReadFile(path)
+	return err == nil && strings.Contains(string(data), "tanka.dev/")
+}
+
+// findProjectRoot returns the closest directory between the application path and the repository root with a
+// jsonnetfile.json, which is the root of the Tanka project, or an empty string if there is none
+func findProjectRoot(appPath, repoRoot string) string {
+	dir := filepath.Clean(appPath)
+	root := filepath.Clean(repoRoot)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, jsonnetfileFile)); err == nil {
+			return dir
+		}
+		if dir == root || !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// vendor installs the dependencies of a Tanka project with jsonnet-bundler, unless they are committed to its vendor
+// directory
+func vendor(projectRoot string, environ []string) error {
+	if _, err := os.Stat(filepath.Join(projectRoot, vendorDir)); err == nil {
+		return nil
+	}
+	cmd := exec.Command("jb", "install")
+	cmd.Dir = projectRoot
+	cmd.Env = append(os.Environ(), environ...)
+	if _, err := executil.Run(cmd); err != nil {
+		return fmt.Errorf("failed to install the dependencies of %s: %v", jsonnetfileFile, err)
+	}
+	return nil
+}
+
+// Show renders the Tanka environment of an application path with tk show. The build environment is substituted in the
+// values of the external variables and top-level arguments, and environ is passed to jsonnet-bundler, e.g. the
+// credentials of the repository.
+func Show(appPath, repoRoot string, opts *v1alpha1.ApplicationSourceTanka, env *v1alpha1.Env, environ []string) ([]*unstructured.Unstructured, error) {
+	if opts == nil {
+		opts = &v1alpha1.ApplicationSourceTanka{}
+	}
+	if projectRoot := findProjectRoot(appPath, repoRoot); projectRoot != "" {
+		if err := vendor(projectRoot, environ); err != nil {
+			return nil, err
+		}
+	}
+	// tk show refuses to write to pipes unless redirects are allowed
+	args := []string{"show", ".", "--dangerous-allow-redirect"}
+	if opts.Name != "" {
+		args = append(args, "--name", opts.Name)
+	}
+	for _, extVar := range opts.Jsonnet.ExtVars {
+		args = append(args, jsonnetFlag("ext", extVar, env)...)
+	}
+	for _, tla := range opts.Jsonnet.TLAs {
+		args = append(args, jsonnetFlag("tla", tla, env)...)
+	}
+	cmd := exec.Command("tk", args...)
+	cmd.Dir = appPath
+	out, err := executil.Run(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return kube.SplitYAML(out)
+}
+
+func jsonnetFlag(kind string, v v1alpha1.JsonnetVar, env *v1alpha1.Env) []string {
+	flag := fmt.Sprintf("--%s-str", kind)
+	if v.Code {
+		flag = fmt.Sprintf("--%s-code", kind)
+	}
+	return []string{flag, fmt.Sprintf("%s=%s", v.Name, env.Envsubst(v.Value))}
+}
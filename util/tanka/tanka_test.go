@@ -0,0 +1,40 @@
+package tanka
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+)
+
+func TestIsEnvironment(t *testing.T) {
+	assert.True(t, IsEnvironment("./testdata/environments/default/spec.json"))
+	assert.False(t, IsEnvironment("./testdata/environments/default/main.jsonnet"))
+	assert.False(t, IsEnvironment("./testdata/spec.json"))
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	repoRoot, err := filepath.Abs("./testdata")
+	assert.NoError(t, err)
+	assert.Equal(t, repoRoot, findProjectRoot(filepath.Join(repoRoot, "environments", "default"), repoRoot))
+	assert.Equal(t, "", findProjectRoot(filepath.Join(repoRoot, "environments", "default"), filepath.Join(repoRoot, "environments")))
+}
+
+func TestVendor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tanka")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	// jb is not run if the dependencies are committed
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, vendorDir), 0755))
+	assert.NoError(t, vendor(dir, nil))
+}
+
+func TestJsonnetFlag(t *testing.T) {
+	env := &v1alpha1.Env{&v1alpha1.EnvEntry{Name: "ARGOCD_APP_NAME", Value: "guestbook"}}
+	assert.Equal(t, []string{"--ext-str", "app=guestbook"}, jsonnetFlag("ext", v1alpha1.JsonnetVar{Name: "app", Value: "$ARGOCD_APP_NAME"}, env))
+	assert.Equal(t, []string{"--tla-code", "replicas=2"}, jsonnetFlag("tla", v1alpha1.JsonnetVar{Name: "replicas", Value: "2", Code: true}, env))
+}
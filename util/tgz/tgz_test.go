@@ -0,0 +1,77 @@
+package tgz
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressExtract(t *testing.T) {
+	src, err := ioutil.TempDir("", "tgz-src")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(src) }()
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "templates"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, ".git"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "Chart.yaml"), []byte("name: my-chart"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "templates", "pod.yaml"), []byte("kind: Pod"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref"), 0644))
+
+	data, err := Compress(src)
+	assert.NoError(t, err)
+
+	dst, err := ioutil.TempDir("", "tgz-dst")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dst) }()
+	assert.NoError(t, Extract(data, dst))
+
+	content, err := ioutil.ReadFile(filepath.Join(dst, "Chart.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "name: my-chart", string(content))
+	content, err = ioutil.ReadFile(filepath.Join(dst, "templates", "pod.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: Pod", string(content))
+	_, err = os.Stat(filepath.Join(dst, ".git"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractIllegalPath(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil.yaml", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+
+	dst, err := ioutil.TempDir("", "tgz-dst")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dst) }()
+	assert.Error(t, Extract(buf.Bytes(), dst))
+}
+
+func TestExtractCurrentDirectory(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755}))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "./pod.yaml", Typeflag: tar.TypeReg, Mode: 0644, Size: 9}))
+	_, err := tw.Write([]byte("kind: Pod"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+
+	dst, err := ioutil.TempDir("", "tgz-dst")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dst) }()
+	assert.NoError(t, Extract(buf.Bytes(), dst))
+	data, err := ioutil.ReadFile(filepath.Join(dst, "pod.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: Pod", string(data))
+}
@@ -16,7 +16,10 @@ import (
 	"os"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/util/fips"
 )
 
 const (
@@ -84,9 +87,16 @@ func AddTLSFlagsToCmd(cmd *cobra.Command) func() (ConfigCustomizer, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := fips.ValidateTLSVersions(minVersion, maxVersion); err != nil {
+			return nil, err
+		}
+		if fips.Enabled() {
+			log.Info("FIPS mode is enabled, TLS is restricted to FIPS-approved versions, cipher suites and curves")
+		}
 		return func(config *tls.Config) {
 			config.MinVersion = minVersion
 			config.MaxVersion = maxVersion
+			fips.ConfigureTLS(config)
 		}, nil
 	}
 }
@@ -131,6 +141,9 @@ func generate(opts CertOptions) ([]byte, crypto.PrivateKey, error) {
 		if opts.RSABits != 0 {
 			rsaBits = opts.RSABits
 		}
+		if err := fips.ValidateRSABits(rsaBits); err != nil {
+			return nil, nil, err
+		}
 		privateKey, err = rsa.GenerateKey(rand.Reader, rsaBits)
 	case "P224":
 		privateKey, err = ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
@@ -4,9 +4,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/common"
 	argocdtls "github.com/argoproj/argo-cd/util/tls"
 )
 
@@ -116,3 +120,29 @@ func TestEncodeX509KeyPairString(t *testing.T) {
 	}
 
 }
+
+func TestAddTLSFlagsToCmd_FIPS(t *testing.T) {
+	os.Setenv(common.EnvFIPSMode, "true")
+	defer os.Unsetenv(common.EnvFIPSMode)
+
+	cmd := &cobra.Command{}
+	customizerSrc := argocdtls.AddTLSFlagsToCmd(cmd)
+	if err := cmd.Flags().Parse([]string{"--tlsminversion", "1.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := customizerSrc(); err == nil {
+		t.Errorf("TLS 1.1 should not have been allowed in FIPS mode")
+	}
+
+	cmd = &cobra.Command{}
+	customizerSrc = argocdtls.AddTLSFlagsToCmd(cmd)
+	customizer, err := customizerSrc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := tls.Config{}
+	customizer(&config)
+	if config.MinVersion != tls.VersionTLS12 || len(config.CipherSuites) == 0 {
+		t.Errorf("TLS config should have been restricted in FIPS mode, got min version %x and cipher suites %v", config.MinVersion, config.CipherSuites)
+	}
+}
@@ -0,0 +1,194 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxQueuedSpans is the number of spans waiting for export beyond which new spans are dropped
+	maxQueuedSpans = 4096
+	// maxBatchSize is the maximum number of spans of an export request
+	maxBatchSize = 512
+	// exportInterval is the interval spans are exported at
+	exportInterval = 5 * time.Second
+)
+
+var (
+	exporterLock sync.RWMutex
+	exporter     *otlpExporter
+)
+
+func currentExporter() *otlpExporter {
+	exporterLock.RLock()
+	defer exporterLock.RUnlock()
+	return exporter
+}
+
+// otlpExporter exports spans in batches with the OTLP/HTTP protocol, JSON encoded
+type otlpExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	spans       chan *record
+}
+
+// Init starts exporting the spans of a component to an OpenTelemetry collector, e.g. http://otel-collector:4318.
+// Tracing stays disabled if the address is empty.
+func Init(serviceName string, address string) {
+	if address == "" {
+		return
+	}
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	e := &otlpExporter{
+		url:         strings.TrimSuffix(address, "/") + "/v1/traces",
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		spans:       make(chan *record, maxQueuedSpans),
+	}
+	exporterLock.Lock()
+	exporter = e
+	exporterLock.Unlock()
+	go e.run(exportInterval)
+	log.Infof("Exporting traces to %s", e.url)
+}
+
+func (e *otlpExporter) export(span *record) {
+	select {
+	case e.spans <- span:
+	default:
+		log.Debugf("Dropped span %s: the export queue is full", span.name)
+	}
+}
+
+func (e *otlpExporter) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var batch []*record
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			log.Warnf("Failed to export %d spans: %v", len(batch), err)
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type otlpAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+func attributes(values map[string]string) []otlpAttribute {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var res []otlpAttribute
+	for _, key := range keys {
+		attr := otlpAttribute{Key: key}
+		attr.Value.StringValue = values[key]
+		res = append(res, attr)
+	}
+	return res
+}
+
+// request returns the OTLP export request of spans
+func (e *otlpExporter) request(spans []*record) map[string]interface{} {
+	var res []otlpSpan
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           hex.EncodeToString(span.traceID[:]),
+			SpanID:            hex.EncodeToString(span.spanID[:]),
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		}
+		if span.parentID != [8]byte{} {
+			s.ParentSpanID = hex.EncodeToString(span.parentID[:])
+		}
+		span.lock.Lock()
+		s.Attributes = attributes(span.attributes)
+		if span.err != nil {
+			s.Status = otlpStatus{Code: 2, Message: span.err.Error()}
+		}
+		span.lock.Unlock()
+		res = append(res, s)
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": attributes(map[string]string{"service.name": e.serviceName}),
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]string{"name": "github.com/argoproj/argo-cd"},
+				"spans": res,
+			}},
+		}},
+	}
+}
+
+func (e *otlpExporter) send(spans []*record) error {
+	data, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("export failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
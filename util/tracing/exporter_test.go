@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// withExporter enables the export of spans by an exporter which isn't running, so the spans stay queued
+func withExporter(url string) *otlpExporter {
+	e := &otlpExporter{url: url, serviceName: "argocd-test", client: http.DefaultClient, spans: make(chan *record, 10)}
+	exporterLock.Lock()
+	exporter = e
+	exporterLock.Unlock()
+	return e
+}
+
+func withoutExporter() {
+	exporterLock.Lock()
+	exporter = nil
+	exporterLock.Unlock()
+}
+
+func TestStartSpanFromContext(t *testing.T) {
+	ctx, span := StartSpanFromContext(context.Background(), "disabled")
+	assert.Nil(t, span.record)
+	span.Finish()
+	assert.Empty(t, Traceparent(ctx))
+
+	e := withExporter("")
+	defer withoutExporter()
+	ctx, root := StartSpanFromContext(context.Background(), "root")
+	_, child := StartSpanFromContext(ctx, "child")
+	child.SetBaggageItem("repo", "https://github.com/argoproj/argo-cd")
+	child.SetError(fmt.Errorf("boom"))
+	child.Finish()
+	root.Finish()
+
+	assert.Equal(t, root.record.traceID, child.record.traceID)
+	assert.Equal(t, root.record.spanID, child.record.parentID)
+	assert.Equal(t, [8]byte{}, root.record.parentID)
+	assert.Len(t, e.spans, 2)
+
+	data, _ := json.Marshal(e.request([]*record{<-e.spans}))
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan
+			}
+		}
+	}
+	assert.NoError(t, json.Unmarshal(data, &req))
+	exported := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "child", exported.Name)
+	assert.Equal(t, otlpStatus{Code: 2, Message: "boom"}, exported.Status)
+	assert.Equal(t, "repo", exported.Attributes[0].Key)
+	assert.Len(t, exported.ParentSpanID, 16)
+}
+
+func TestTraceparent(t *testing.T) {
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := WithTraceparent(context.Background(), traceparent)
+	assert.Equal(t, traceparent, Traceparent(ctx))
+	assert.Empty(t, Traceparent(WithTraceparent(context.Background(), "00-invalid-00f067aa0ba902b7-01")))
+
+	withExporter("")
+	defer withoutExporter()
+	_, span := StartSpanFromContext(ctx, "child")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fmt.Sprintf("%x", span.record.traceID))
+	assert.Equal(t, "00f067aa0ba902b7", fmt.Sprintf("%x", span.record.parentID))
+}
+
+func TestInterceptors(t *testing.T) {
+	e := withExporter("")
+	defer withoutExporter()
+
+	// the client propagates its span to the server, whose span is its child
+	var outgoing metadata.MD
+	ctx, root := StartSpanFromContext(context.Background(), "root")
+	err := UnaryClientInterceptor()(ctx, "/repository.RepoServerService/GenerateManifest", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			outgoing, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	assert.NoError(t, err)
+	client := <-e.spans
+	assert.Equal(t, root.record.spanID, client.parentID)
+	assert.Equal(t, SpanKindClient, client.kind)
+
+	_, err = UnaryServerInterceptor()(metadata.NewIncomingContext(context.Background(), outgoing), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/repository.RepoServerService/GenerateManifest"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("failed")
+		})
+	assert.EqualError(t, err, "failed")
+	server := <-e.spans
+	assert.Equal(t, root.record.traceID, server.traceID)
+	assert.Equal(t, client.spanID, server.parentID)
+	assert.Equal(t, SpanKindServer, server.kind)
+	assert.EqualError(t, server.err, "failed")
+}
+
+func TestSend(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+	e := withExporter(server.URL + "/v1/traces")
+	defer withoutExporter()
+
+	_, span := StartSpanFromContext(context.Background(), "span")
+	span.Finish()
+	assert.NoError(t, e.send([]*record{<-e.spans}))
+	assert.Contains(t, body, "resourceSpans")
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor records a server span of each call, child of the span of the client if it propagated one
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startSpan(incomingContext(ctx), info.FullMethod, SpanKindServer)
+		res, err := handler(ctx, req)
+		span.SetError(err)
+		span.Finish()
+		return res, err
+	}
+}
+
+// StreamServerInterceptor records a server span of each stream, child of the span of the client if it propagated one
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startSpan(incomingContext(ss.Context()), info.FullMethod, SpanKindServer)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		span.SetError(err)
+		span.Finish()
+		return err
+	}
+}
+
+// UnaryClientInterceptor records a client span of each call, and propagates it to the server
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startSpan(ctx, method, SpanKindClient)
+		err := invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+		span.SetError(err)
+		span.Finish()
+		return err
+	}
+}
+
+// StreamClientInterceptor propagates the span of the context to the server of streams
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incomingContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TraceparentHeader); len(values) > 0 {
+			return WithTraceparent(ctx, values[0])
+		}
+	}
+	return ctx
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	if traceparent := Traceparent(ctx); traceparent != "" {
+		return metadata.AppendToOutgoingContext(ctx, TraceparentHeader, traceparent)
+	}
+	return ctx
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
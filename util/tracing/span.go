@@ -1,7 +1,13 @@
 package tracing
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -10,7 +16,8 @@ import (
 /*
 	Poor Mans OpenTracing.
 
-	Standardizes logging of operation duration.
+	Standardizes logging of operation duration. The spans started from a context are also exported to an
+	OpenTelemetry collector if an exporter was started with Init, and propagated to other components by gRPC calls.
 */
 
 var enabled = false
@@ -20,12 +27,49 @@ func init() {
 	enabled = os.Getenv("ARGOCD_TRACING_ENABLED") == "1"
 }
 
+// SpanKind is the kind of a span, as defined by OpenTelemetry
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// TraceparentHeader is the W3C Trace Context header propagating the trace and the parent span across calls
+const TraceparentHeader = "traceparent"
+
 type Span struct {
 	operationName string
 	baggage       map[string]interface{}
 	start         time.Time
+	// record is the exported span, if the span was started from a context and an exporter is running
+	record *record
+}
+
+// record is an exported span of a trace
+type record struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     SpanKind
+	start    time.Time
+	end      time.Time
+
+	lock       sync.Mutex
+	err        error
+	attributes map[string]string
+}
+
+// parent is the parent of new spans, possibly of another component
+type parent struct {
+	traceID [16]byte
+	spanID  [8]byte
 }
 
+type spanContextKey struct{}
+
 func (s Span) Finish() {
 	if enabled {
 		logger.WithFields(s.baggage).
@@ -33,12 +77,90 @@ func (s Span) Finish() {
 			WithField("time_ms", time.Since(s.start).Seconds()*1e3).
 			Info()
 	}
+	if s.record != nil {
+		s.record.end = time.Now()
+		if exporter := currentExporter(); exporter != nil {
+			exporter.export(s.record)
+		}
+	}
 }
 
 func (s Span) SetBaggageItem(key string, value interface{}) {
 	s.baggage[key] = value
+	if s.record != nil {
+		s.record.lock.Lock()
+		s.record.attributes[key] = fmt.Sprintf("%v", value)
+		s.record.lock.Unlock()
+	}
+}
+
+// SetError marks the span as failed
+func (s Span) SetError(err error) {
+	if s.record != nil && err != nil {
+		s.record.lock.Lock()
+		s.record.err = err
+		s.record.lock.Unlock()
+	}
 }
 
 func StartSpan(operationName string) Span {
-	return Span{operationName, make(map[string]interface{}), time.Now()}
+	return Span{operationName, make(map[string]interface{}), time.Now(), nil}
+}
+
+// StartSpanFromContext starts a span which is a child of the span of the context, if any, and returns a context
+// containing the new span
+func StartSpanFromContext(ctx context.Context, operationName string) (context.Context, Span) {
+	return startSpan(ctx, operationName, SpanKindInternal)
+}
+
+func startSpan(ctx context.Context, operationName string, kind SpanKind) (context.Context, Span) {
+	span := StartSpan(operationName)
+	if currentExporter() == nil {
+		return ctx, span
+	}
+	span.record = &record{name: operationName, kind: kind, start: span.start, attributes: make(map[string]string)}
+	if p, ok := parentOf(ctx); ok {
+		span.record.traceID = p.traceID
+		span.record.parentID = p.spanID
+	} else {
+		_, _ = rand.Read(span.record.traceID[:])
+	}
+	_, _ = rand.Read(span.record.spanID[:])
+	return context.WithValue(ctx, spanContextKey{}, parent{traceID: span.record.traceID, spanID: span.record.spanID}), span
+}
+
+// parentOf returns the trace and the span of a context, which are the parent of new spans
+func parentOf(ctx context.Context) (parent, bool) {
+	p, ok := ctx.Value(spanContextKey{}).(parent)
+	return p, ok
+}
+
+// Traceparent returns the traceparent header of the span of a context, or an empty string if there is none
+func Traceparent(ctx context.Context) string {
+	p, ok := parentOf(ctx)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(p.traceID[:]), hex.EncodeToString(p.spanID[:]))
+}
+
+// WithTraceparent returns a context whose spans are children of the span of a traceparent header. Invalid headers are
+// ignored.
+func WithTraceparent(ctx context.Context, traceparent string) context.Context {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return ctx
+	}
+	var p parent
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != len(p.traceID) {
+		return ctx
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != len(p.spanID) {
+		return ctx
+	}
+	copy(p.traceID[:], traceID)
+	copy(p.spanID[:], spanID)
+	return context.WithValue(ctx, spanContextKey{}, p)
 }
@@ -6,8 +6,10 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -135,6 +137,23 @@ func FirstNonEmpty(args ...string) string {
 	return ""
 }
 
+// EnsurePort returns an address with the default port if the address has no port, e.g. argocd.example.com:443 for
+// argocd.example.com and [fd00::1]:443 for fd00::1 or [fd00::1]
+func EnsurePort(address string, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(address, "["), "]"), defaultPort)
+}
+
+// HostWithoutPort returns the host of an address without the port, and without the brackets of IPv6 addresses
+func HostWithoutPort(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+}
+
 func RunAllAsync(count int, action func(i int) error) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
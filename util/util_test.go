@@ -21,3 +21,20 @@ func TestMakeSignature(t *testing.T) {
 		t.Logf("Generated token: %v", s)
 	}
 }
+
+func TestEnsurePort(t *testing.T) {
+	assert.Equal(t, "argocd.example.com:443", util.EnsurePort("argocd.example.com", "443"))
+	assert.Equal(t, "argocd.example.com:8080", util.EnsurePort("argocd.example.com:8080", "443"))
+	assert.Equal(t, "10.0.0.1:443", util.EnsurePort("10.0.0.1", "443"))
+	assert.Equal(t, "[fd00::1]:443", util.EnsurePort("fd00::1", "443"))
+	assert.Equal(t, "[fd00::1]:443", util.EnsurePort("[fd00::1]", "443"))
+	assert.Equal(t, "[fd00::1]:8080", util.EnsurePort("[fd00::1]:8080", "443"))
+}
+
+func TestHostWithoutPort(t *testing.T) {
+	assert.Equal(t, "argocd.example.com", util.HostWithoutPort("argocd.example.com"))
+	assert.Equal(t, "argocd.example.com", util.HostWithoutPort("argocd.example.com:8080"))
+	assert.Equal(t, "fd00::1", util.HostWithoutPort("fd00::1"))
+	assert.Equal(t, "fd00::1", util.HostWithoutPort("[fd00::1]"))
+	assert.Equal(t, "fd00::1", util.HostWithoutPort("[fd00::1]:8080"))
+}
@@ -0,0 +1,154 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const placeholderPrefix = "<vault:"
+
+// placeholderRegexp matches placeholders like <vault:secret/data/app#password>
+var placeholderRegexp = regexp.MustCompile(`<vault:([^#<>\s]+)#([^<>\s]+)>`)
+
+// SecretReader reads the data of the secret at a path
+type SecretReader interface {
+	ReadSecret(path string) (map[string]interface{}, error)
+}
+
+// permittedReader reads the secrets at the paths which match one of its glob patterns only
+type permittedReader struct {
+	reader SecretReader
+	paths  []string
+}
+
+func (r permittedReader) ReadSecret(secretPath string) (map[string]interface{}, error) {
+	for _, pattern := range r.paths {
+		if ok, err := path.Match(pattern, secretPath); ok && err == nil {
+			return r.reader.ReadSecret(secretPath)
+		}
+	}
+	return nil, fmt.Errorf("vault secret %s is not permitted by the project", secretPath)
+}
+
+// ResolveManifests replaces the <vault:path#key> placeholders in the string values of manifests by the values of the keys
+// of the secrets. Placeholders in the base64 encoded data of Secrets are resolved too, so charts may encode them. The
+// placeholders may only reference the secrets whose paths match one of the glob patterns of paths, i.e. of the project
+// of the app, so that apps can't read the secrets of other projects with the role of the repo server.
+func ResolveManifests(reader SecretReader, paths []string, manifests []string) ([]string, error) {
+	reader = permittedReader{reader: reader, paths: paths}
+	res := make([]string, len(manifests))
+	for i, manifest := range manifests {
+		resolved, err := resolveManifest(reader, manifest)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = resolved
+	}
+	return res, nil
+}
+
+func resolveManifest(reader SecretReader, manifest string) (string, error) {
+	var obj unstructured.Unstructured
+	if err := json.Unmarshal([]byte(manifest), &obj); err != nil {
+		return "", err
+	}
+	changed := false
+	if obj.GetKind() == "Secret" && obj.GroupVersionKind().Group == "" {
+		if data, ok := obj.Object["data"].(map[string]interface{}); ok {
+			for key, value := range data {
+				encoded, ok := value.(string)
+				if !ok {
+					continue
+				}
+				decoded, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil || !strings.Contains(string(decoded), placeholderPrefix) {
+					continue
+				}
+				resolved, err := resolveString(reader, string(decoded))
+				if err != nil {
+					return "", fmt.Errorf("failed to resolve placeholders of Secret %s: %v", obj.GetName(), err)
+				}
+				data[key] = base64.StdEncoding.EncodeToString([]byte(resolved))
+				changed = true
+			}
+		}
+	}
+	// the manifests are marshaled with HTML escaping, so the placeholders might start with \u003c rather than <
+	if strings.Contains(manifest, "vault:") {
+		resolved, err := resolveValue(reader, obj.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve placeholders of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		obj.Object = resolved.(map[string]interface{})
+		changed = true
+	}
+	if !changed {
+		return manifest, nil
+	}
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func resolveValue(reader SecretReader, value interface{}) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case string:
+		return resolveString(reader, v)
+	case map[string]interface{}:
+		for key, item := range v {
+			if v[key], err = resolveValue(reader, item); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if v[i], err = resolveValue(reader, item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+func resolveString(reader SecretReader, value string) (string, error) {
+	if !strings.Contains(value, placeholderPrefix) {
+		return value, nil
+	}
+	var resolveErr error
+	res := placeholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+		match := placeholderRegexp.FindStringSubmatch(placeholder)
+		path, key := match[1], match[2]
+		data, err := reader.ReadSecret(path)
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		item, ok := data[key]
+		if !ok {
+			resolveErr = fmt.Errorf("key %s not found in vault secret %s", key, path)
+			return placeholder
+		}
+		if s, ok := item.(string); ok {
+			return s
+		}
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		return string(encoded)
+	})
+	return res, resolveErr
+}
@@ -0,0 +1,279 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// AuthMethodKubernetes logs in with the service account token of the pod
+	AuthMethodKubernetes = "kubernetes"
+	// AuthMethodAppRole logs in with a role ID and a secret ID read from files, e.g. of a mounted secret
+	AuthMethodAppRole = "approle"
+
+	defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	requestTimeout                 = 10 * time.Second
+	// renewBefore is the remaining time to live of the token below which it is renewed before a request
+	renewBefore = time.Minute
+)
+
+// Config is the configuration of the Vault client
+type Config struct {
+	Address string
+	// Namespace is the Vault Enterprise namespace of the requests, if any
+	Namespace  string
+	CACertPath string
+	AuthMethod string
+	// AuthPath is the mount path of the auth method, which defaults to the name of the method
+	AuthPath string
+	// Role is the role of the kubernetes auth method
+	Role                string
+	KubernetesTokenPath string
+	RoleIDPath          string
+	SecretIDPath        string
+	// CacheTTL is the maximum duration secrets are cached for. Secrets with shorter leases are cached until their leases
+	// expire.
+	CacheTTL time.Duration
+}
+
+// Client reads secrets from Vault. It logs in lazily, renews its token before it expires (or logs in again if the token
+// can't be renewed) and caches the secrets it reads.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	now        func() time.Time
+
+	lock         sync.Mutex
+	token        string
+	tokenExpires time.Time
+	renewable    bool
+	secrets      map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	data    map[string]interface{}
+	expires time.Time
+}
+
+// NewClient returns a new Vault client
+func NewClient(config Config) (*Client, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+	switch config.AuthMethod {
+	case AuthMethodKubernetes:
+		if config.Role == "" {
+			return nil, fmt.Errorf("vault role is required by the %s auth method", AuthMethodKubernetes)
+		}
+		if config.KubernetesTokenPath == "" {
+			config.KubernetesTokenPath = defaultServiceAccountTokenPath
+		}
+	case AuthMethodAppRole:
+		if config.RoleIDPath == "" || config.SecretIDPath == "" {
+			return nil, fmt.Errorf("role ID and secret ID files are required by the %s auth method", AuthMethodAppRole)
+		}
+	default:
+		return nil, fmt.Errorf("unknown vault auth method '%s', expected one of: %s|%s", config.AuthMethod, AuthMethodKubernetes, AuthMethodAppRole)
+	}
+	if config.AuthPath == "" {
+		config.AuthPath = config.AuthMethod
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if config.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(config.CACertPath)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CACertPath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: requestTimeout, Transport: transport},
+		now:        time.Now,
+		secrets:    make(map[string]cachedSecret),
+	}, nil
+}
+
+// AddVaultFlagsToCmd adds the flags of the Vault client to a command and returns a function which creates the client,
+// or returns nil if no Vault address is configured
+func AddVaultFlagsToCmd(cmd *cobra.Command) func() (SecretReader, error) {
+	var config Config
+	cmd.Flags().StringVar(&config.Address, "vault-address", "", "Vault address to resolve <vault:path#key> placeholders of manifests with, e.g. https://vault:8200. Placeholders are not resolved if empty.")
+	cmd.Flags().StringVar(&config.Namespace, "vault-namespace", "", "Vault Enterprise namespace")
+	cmd.Flags().StringVar(&config.CACertPath, "vault-ca-cert", "", "Path of the CA certificate of Vault")
+	cmd.Flags().StringVar(&config.AuthMethod, "vault-auth-method", AuthMethodKubernetes, "Vault auth method. One of: kubernetes|approle")
+	cmd.Flags().StringVar(&config.AuthPath, "vault-auth-path", "", "Mount path of the Vault auth method (default the name of the auth method)")
+	cmd.Flags().StringVar(&config.Role, "vault-role", "", "Vault role of the kubernetes auth method")
+	cmd.Flags().StringVar(&config.KubernetesTokenPath, "vault-kubernetes-token-path", defaultServiceAccountTokenPath, "Path of the service account token of the kubernetes auth method")
+	cmd.Flags().StringVar(&config.RoleIDPath, "vault-approle-role-id-path", "", "Path of the file with the role ID of the approle auth method")
+	cmd.Flags().StringVar(&config.SecretIDPath, "vault-approle-secret-id-path", "", "Path of the file with the secret ID of the approle auth method")
+	cmd.Flags().DurationVar(&config.CacheTTL, "vault-cache-ttl", 5*time.Minute, "Maximum duration to cache secrets read from Vault for")
+	return func() (SecretReader, error) {
+		if config.Address == "" {
+			return nil, nil
+		}
+		client, err := NewClient(config)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+// vaultResponse is the response of Vault to both logins and reads
+type vaultResponse struct {
+	Data          map[string]interface{} `json:"data"`
+	LeaseDuration int64                  `json:"lease_duration"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+func (c *Client) request(method, path, token string, body interface{}) (*vaultResponse, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, c.config.Address+"/v1/"+strings.TrimPrefix(path, "/"), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var res vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil && resp.StatusCode < 300 {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		if len(res.Errors) > 0 {
+			return nil, fmt.Errorf("vault returned %s: %s", resp.Status, strings.Join(res.Errors, ", "))
+		}
+		return nil, fmt.Errorf("vault returned %s", resp.Status)
+	}
+	return &res, nil
+}
+
+// login logs in with the configured auth method. The lock must be held.
+func (c *Client) login() error {
+	var body map[string]string
+	switch c.config.AuthMethod {
+	case AuthMethodKubernetes:
+		jwt, err := ioutil.ReadFile(c.config.KubernetesTokenPath)
+		if err != nil {
+			return err
+		}
+		body = map[string]string{"role": c.config.Role, "jwt": strings.TrimSpace(string(jwt))}
+	case AuthMethodAppRole:
+		roleID, err := ioutil.ReadFile(c.config.RoleIDPath)
+		if err != nil {
+			return err
+		}
+		secretID, err := ioutil.ReadFile(c.config.SecretIDPath)
+		if err != nil {
+			return err
+		}
+		body = map[string]string{"role_id": strings.TrimSpace(string(roleID)), "secret_id": strings.TrimSpace(string(secretID))}
+	}
+	res, err := c.request(http.MethodPost, fmt.Sprintf("auth/%s/login", c.config.AuthPath), "", body)
+	if err != nil {
+		return fmt.Errorf("vault login failed: %v", err)
+	}
+	if res.Auth == nil || res.Auth.ClientToken == "" {
+		return fmt.Errorf("vault login returned no token")
+	}
+	c.setToken(res.Auth.ClientToken, res.Auth.LeaseDuration, res.Auth.Renewable)
+	log.Infof("Logged in to vault %s with the %s auth method", c.config.Address, c.config.AuthMethod)
+	return nil
+}
+
+// setToken stores a token. The lock must be held.
+func (c *Client) setToken(token string, leaseDuration int64, renewable bool) {
+	c.token = token
+	c.renewable = renewable
+	if leaseDuration > 0 {
+		c.tokenExpires = c.now().Add(time.Duration(leaseDuration) * time.Second)
+	} else {
+		c.tokenExpires = time.Time{}
+	}
+}
+
+// ensureToken logs in if there is no token yet or if it expires soon and can't be renewed. The lock must be held.
+func (c *Client) ensureToken() error {
+	if c.token == "" {
+		return c.login()
+	}
+	if c.tokenExpires.IsZero() || c.now().Add(renewBefore).Before(c.tokenExpires) {
+		return nil
+	}
+	if c.renewable {
+		res, err := c.request(http.MethodPost, "auth/token/renew-self", c.token, map[string]string{})
+		if err == nil && res.Auth != nil {
+			c.setToken(c.token, res.Auth.LeaseDuration, res.Auth.Renewable)
+			return nil
+		}
+		log.Warnf("Failed to renew vault token, logging in again: %v", err)
+	}
+	return c.login()
+}
+
+// ReadSecret returns the data of the secret at a path. The data of KV version 2 secrets, e.g. at secret/data/app, is
+// unwrapped.
+func (c *Client) ReadSecret(path string) (map[string]interface{}, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if cached, ok := c.secrets[path]; ok && c.now().Before(cached.expires) {
+		return cached.data, nil
+	}
+	if err := c.ensureToken(); err != nil {
+		return nil, err
+	}
+	res, err := c.request(http.MethodGet, path, c.token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %v", path, err)
+	}
+	data := res.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"].(map[string]interface{}); ok {
+			data = nested
+		}
+	}
+	ttl := c.config.CacheTTL
+	if lease := time.Duration(res.LeaseDuration) * time.Second; lease > 0 && lease < ttl {
+		ttl = lease
+	}
+	if ttl > 0 {
+		c.secrets[path] = cachedSecret{data: data, expires: c.now().Add(ttl)}
+	}
+	return data, nil
+}
@@ -0,0 +1,143 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVault struct {
+	logins int
+	renews int
+	reads  int
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/auth/kubernetes/login":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role"] != "argocd" || body["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		v.logins++
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"token","lease_duration":120,"renewable":true}}`))
+	case "/v1/auth/token/renew-self":
+		v.renews++
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"token","lease_duration":120,"renewable":true}}`))
+	case "/v1/secret/data/app":
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		v.reads++
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"s3cr3t","port":5432},"metadata":{"version":1}}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+	}
+}
+
+func newTestClient(t *testing.T, address string) *Client {
+	dir, err := ioutil.TempDir("", "vault")
+	assert.NoError(t, err)
+	tokenPath := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(tokenPath, []byte("sa-token\n"), 0600))
+	client, err := NewClient(Config{Address: address, AuthMethod: AuthMethodKubernetes, Role: "argocd", KubernetesTokenPath: tokenPath, CacheTTL: time.Minute})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestClient_ReadSecret(t *testing.T) {
+	fake := &fakeVault{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	defer func() { _ = os.RemoveAll(filepath.Dir(client.config.KubernetesTokenPath)) }()
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	data, err := client.ReadSecret("secret/data/app")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "s3cr3t", "port": float64(5432)}, data)
+	assert.Equal(t, 1, fake.logins)
+
+	// cached
+	_, err = client.ReadSecret("secret/data/app")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.reads)
+
+	// the cache expired and the token is renewed before it expires
+	now = now.Add(90 * time.Second)
+	_, err = client.ReadSecret("secret/data/app")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.reads)
+	assert.Equal(t, 1, fake.renews)
+	assert.Equal(t, 1, fake.logins)
+
+	_, err = client.ReadSecret("secret/data/missing")
+	assert.Error(t, err)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(Config{Address: "https://vault:8200", AuthMethod: AuthMethodKubernetes})
+	assert.Error(t, err)
+	_, err = NewClient(Config{Address: "https://vault:8200", AuthMethod: AuthMethodAppRole, RoleIDPath: "role-id"})
+	assert.Error(t, err)
+	_, err = NewClient(Config{Address: "https://vault:8200", AuthMethod: "ldap"})
+	assert.Error(t, err)
+	client, err := NewClient(Config{Address: "https://vault:8200/", AuthMethod: AuthMethodAppRole, RoleIDPath: "role-id", SecretIDPath: "secret-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "approle", client.config.AuthPath)
+	assert.Equal(t, "https://vault:8200", client.config.Address)
+}
+
+type fakeReader map[string]map[string]interface{}
+
+func (r fakeReader) ReadSecret(path string) (map[string]interface{}, error) {
+	return r[path], nil
+}
+
+func TestResolveManifests(t *testing.T) {
+	reader := fakeReader{"secret/data/app": {"password": "s3cr3t", "port": float64(5432)}}
+	encoded := base64.StdEncoding.EncodeToString([]byte("<vault:secret/data/app#password>"))
+	manifests := []string{
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"plain"},"data":{"key":"value"}}`,
+		`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"db"},"data":{"password":"` + encoded + `"},"stringData":{"url":"postgres://db:<vault:secret/data/app#port>"}}`,
+	}
+	resolved, err := ResolveManifests(reader, []string{"secret/data/*"}, manifests)
+	assert.NoError(t, err)
+	assert.Equal(t, manifests[0], resolved[0])
+	var secret struct {
+		Data       map[string]string `json:"data"`
+		StringData map[string]string `json:"stringData"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(resolved[1]), &secret))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("s3cr3t")), secret.Data["password"])
+	assert.Equal(t, "postgres://db:5432", secret.StringData["url"])
+
+	_, err = ResolveManifests(reader, []string{"secret/data/*"}, []string{`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"db"},"stringData":{"url":"<vault:secret/data/app#user>"}}`})
+	assert.EqualError(t, err, "failed to resolve placeholders of Secret db: key user not found in vault secret secret/data/app")
+}
+
+func TestResolveManifests_NotPermitted(t *testing.T) {
+	reader := fakeReader{"secret/data/other-team/app": {"password": "s3cr3t"}}
+	manifests := []string{`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"leak"},"data":{"password":"<vault:secret/data/other-team/app#password>"}}`}
+	for _, paths := range [][]string{nil, {"secret/data/my-team/*"}, {"secret/data/*"}} {
+		_, err := ResolveManifests(reader, paths, manifests)
+		assert.EqualError(t, err, "failed to resolve placeholders of ConfigMap leak: vault secret secret/data/other-team/app is not permitted by the project")
+	}
+	resolved, err := ResolveManifests(reader, []string{"secret/data/my-team/*", "secret/data/other-team/*"}, manifests)
+	assert.NoError(t, err)
+	assert.Contains(t, resolved[0], `"password":"s3cr3t"`)
+}
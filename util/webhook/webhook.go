@@ -1,10 +1,16 @@
 package webhook
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	gogsclient "github.com/gogits/go-gogs-client"
 	log "github.com/sirupsen/logrus"
@@ -14,16 +20,59 @@ import (
 	"gopkg.in/go-playground/webhooks.v5/gitlab"
 	"gopkg.in/go-playground/webhooks.v5/gogs"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 
+	"github.com/argoproj/argo-cd/common"
 	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned"
+	applicationv1alpha1 "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/typed/application/v1alpha1"
 	"github.com/argoproj/argo-cd/util/argo"
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
+const (
+	// maxQueuedEvents is the maximum number of queued push and pull request events. Further events are rejected until
+	// the workers caught up, so that a burst of events cannot exhaust the memory of the API server. The refreshes the
+	// events expand to are not counted, since there is at most one of each application and application set.
+	maxQueuedEvents = 1000
+)
+
+// pushEvent is a push of a revision to a repository. Identical push events are processed once while they are queued.
+type pushEvent struct {
+	webURL      string
+	revision    string
+	touchedHead bool
+}
+
+// appRefresh is a refresh of an application. An application is refreshed once while it is queued, even if it is
+// affected by several push events.
+type appRefresh struct {
+	name string
+}
+
+// appSetRefresh is a refresh of an application set
+type appSetRefresh struct {
+	name string
+}
+
+// pullRequestEvent is an event of a pull request of a GitHub repository, or of a merge request of a GitLab project,
+// e.g. a pull request was opened, closed or received new commits
+type pullRequestEvent struct {
+	// repo is the full name of the GitHub repository (owner/repo) or the path of the GitLab project (group/project)
+	repo string
+	// projectID is the ID of the GitLab project
+	projectID string
+}
+
 type ArgoCDWebhookHandler struct {
-	ns              string
-	appClientset    appclientset.Interface
+	ns           string
+	appClientset appclientset.Interface
+	// queue is the queue of the push events and of the refreshes of the applications and application sets they affect
+	queue workqueue.Interface
+	// queuedEvents are the push and pull request events in the queue, which are limited to maxQueuedEvents
+	queuedEvents    map[interface{}]bool
+	lock            sync.Mutex
 	github          *github.Webhook
 	gitlab          *gitlab.Webhook
 	bitbucket       *bitbucket.Webhook
@@ -57,6 +106,8 @@ func NewHandler(namespace string, appClientset appclientset.Interface, set *sett
 	acdWebhook := ArgoCDWebhookHandler{
 		ns:              namespace,
 		appClientset:    appClientset,
+		queue:           workqueue.NewNamed("webhook_queue"),
+		queuedEvents:    map[interface{}]bool{},
 		github:          githubWebhook,
 		gitlab:          gitlabWebhook,
 		bitbucket:       bitbucketWebhook,
@@ -136,63 +187,233 @@ func affectedRevisionInfo(payloadIf interface{}) ([]string, string, bool) {
 		webURLs = append(webURLs, payload.Repo.HTMLURL)
 		revision = parseRef(payload.Ref)
 		touchedHead = bool(payload.Repo.DefaultBranch == revision)
+	case github.RepositoryPayload:
+		// See: https://developer.github.com/v3/activity/events/types/#repositoryevent
+		// a repository was created, renamed or archived, which only affects SCM provider generators, so the event has
+		// no revision
+		webURLs = append(webURLs, payload.Repository.HTMLURL)
 	}
 	return webURLs, revision, touchedHead
 }
 
-// HandleEvent handles webhook events for repo push events
-func (a *ArgoCDWebhookHandler) HandleEvent(payload interface{}) {
+// affectedPullRequestInfo examines a payload from a webhook event, and extracts the repository of the pull request or
+// merge request it is about, if any
+func affectedPullRequestInfo(payloadIf interface{}) (*pullRequestEvent, bool) {
+	switch payload := payloadIf.(type) {
+	case github.PullRequestPayload:
+		// See: https://developer.github.com/v3/activity/events/types/#pullrequestevent
+		return &pullRequestEvent{repo: payload.Repository.FullName}, true
+	case gitlab.MergeRequestEventPayload:
+		// See: https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#merge-request-events
+		return &pullRequestEvent{
+			repo:      payload.Project.PathWithNamespace,
+			projectID: strconv.FormatInt(payload.ObjectAttributes.TargetProjectID, 10),
+		}, true
+	}
+	return nil, false
+}
+
+// HandleEvent queues the push and pull request events of a webhook event. It returns false if the queue is full.
+func (a *ArgoCDWebhookHandler) HandleEvent(payload interface{}) bool {
+	if event, ok := affectedPullRequestInfo(payload); ok {
+		log.Infof("Received pull request event repo: %s", event.repo)
+		return a.queueEvents(*event)
+	}
 	webURLs, revision, touchedHead := affectedRevisionInfo(payload)
 	// NOTE: the webURL does not include the .git extension
 	if len(webURLs) == 0 {
 		log.Info("Ignoring webhook event")
-		return
+		return true
 	}
+	var events []interface{}
 	for _, webURL := range webURLs {
 		log.Infof("Received push event repo: %s, revision: %s, touchedHead: %v", webURL, revision, touchedHead)
+		events = append(events, pushEvent{webURL: webURL, revision: revision, touchedHead: touchedHead})
+	}
+	return a.queueEvents(events...)
+}
+
+// queueEvents queues push or pull request events. It returns false, and queues none of them, if maxQueuedEvents
+// events are queued already.
+func (a *ArgoCDWebhookHandler) queueEvents(events ...interface{}) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if len(a.queuedEvents) >= maxQueuedEvents {
+		log.Warnf("Rejecting webhook event, %d events are queued already", len(a.queuedEvents))
+		return false
 	}
-	appIf := a.appClientset.ArgoprojV1alpha1().Applications(a.ns)
-	apps, err := appIf.List(metav1.ListOptions{})
+	for _, event := range events {
+		a.queuedEvents[event] = true
+		a.queue.Add(event)
+	}
+	return true
+}
+
+// Run processes the queued events with the given number of workers, at least one, until the context is done
+func (a *ArgoCDWebhookHandler) Run(ctx context.Context, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for a.processNextItem() {
+			}
+		}()
+	}
+	<-ctx.Done()
+	a.queue.ShutDown()
+}
+
+// processNextItem processes the next queued item. It returns false once the queue is shut down.
+func (a *ArgoCDWebhookHandler) processNextItem() bool {
+	item, shutdown := a.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer a.queue.Done(item)
+	switch item.(type) {
+	case pushEvent, pullRequestEvent:
+		a.lock.Lock()
+		delete(a.queuedEvents, item)
+		a.lock.Unlock()
+	}
+	switch item := item.(type) {
+	case pushEvent:
+		a.processPushEvent(item)
+	case pullRequestEvent:
+		a.processPullRequestEvent(item)
+	case appRefresh:
+		_, err := argo.RefreshApp(a.appClientset.ArgoprojV1alpha1().Applications(a.ns), item.name, v1alpha1.RefreshTypeNormal)
+		if err != nil {
+			log.Warnf("Failed to refresh app '%s' for controller reprocessing: %v", item.name, err)
+		}
+	case appSetRefresh:
+		if err := refreshApplicationSet(a.appClientset.ArgoprojV1alpha1().ApplicationSets(a.ns), item.name); err != nil {
+			log.Warnf("Failed to refresh application set '%s': %v", item.name, err)
+		}
+	}
+	return true
+}
+
+// processPushEvent queues the refreshes of the applications and application sets affected by a push event
+func (a *ArgoCDWebhookHandler) processPushEvent(event pushEvent) {
+	urlObj, err := url.Parse(event.webURL)
 	if err != nil {
-		log.Warnf("Failed to list applications: %v", err)
+		log.Warnf("Failed to parse repoURL '%s'", event.webURL)
+		return
+	}
+	regexpStr := `(?i)(http://|https://|\w+@|ssh://(\w+@)?)` + urlObj.Host + "(:[0-9]+|)[:/]" + urlObj.Path[1:] + "(\\.git)?"
+	repoRegexp, err := regexp.Compile(regexpStr)
+	if err != nil {
+		log.Warnf("Failed to compile regexp for repoURL '%s'", event.webURL)
 		return
 	}
 
-	for _, webURL := range webURLs {
-		urlObj, err := url.Parse(webURL)
-		if err != nil {
-			log.Warnf("Failed to parse repoURL '%s'", webURL)
+	apps, err := a.appClientset.ArgoprojV1alpha1().Applications(a.ns).List(metav1.ListOptions{})
+	if err != nil {
+		log.Warnf("Failed to list applications: %v", err)
+		return
+	}
+	for _, app := range apps.Items {
+		if !repoRegexp.MatchString(app.Spec.Source.RepoURL) {
+			log.Debugf("%s does not match", app.Spec.Source.RepoURL)
 			continue
 		}
-		regexpStr := `(?i)(http://|https://|\w+@|ssh://(\w+@)?)` + urlObj.Host + "(:[0-9]+|)[:/]" + urlObj.Path[1:] + "(\\.git)?"
-		repoRegexp, err := regexp.Compile(regexpStr)
-		if err != nil {
-			log.Warnf("Failed to compile regexp for repoURL '%s'", webURL)
+		if !revisionMatches(app.Spec.Source.TargetRevision, event.revision, event.touchedHead) {
 			continue
 		}
+		a.queue.Add(appRefresh{name: app.Name})
+	}
 
-		for _, app := range apps.Items {
-			if !repoRegexp.MatchString(app.Spec.Source.RepoURL) {
-				log.Debugf("%s does not match", app.Spec.Source.RepoURL)
-				continue
-			}
-			targetRev := app.Spec.Source.TargetRevision
-			if targetRev == "HEAD" || targetRev == "" {
-				if !touchedHead {
-					continue
-				}
-			} else if targetRev != revision {
-				continue
+	appSets, err := a.appClientset.ArgoprojV1alpha1().ApplicationSets(a.ns).List(metav1.ListOptions{})
+	if err != nil {
+		log.Warnf("Failed to list application sets: %v", err)
+		return
+	}
+	for _, appSet := range appSets.Items {
+		for _, generator := range appSet.Spec.LeafGenerators() {
+			if generator.Git != nil && repoRegexp.MatchString(generator.Git.RepoURL) && revisionMatches(generator.Git.Revision, event.revision, event.touchedHead) ||
+				generator.SCMProvider != nil && scmProviderMatches(generator.SCMProvider, urlObj.Path) {
+				a.queue.Add(appSetRefresh{name: appSet.Name})
+				break
 			}
-			_, err = argo.RefreshApp(appIf, app.ObjectMeta.Name, v1alpha1.RefreshTypeNormal)
-			if err != nil {
-				log.Warnf("Failed to refresh app '%s' for controller reprocessing: %v", app.ObjectMeta.Name, err)
-				continue
+		}
+	}
+}
+
+// processPullRequestEvent queues the refreshes of the application sets whose pull request generators list the pull
+// requests of the repository of a pull request event
+func (a *ArgoCDWebhookHandler) processPullRequestEvent(event pullRequestEvent) {
+	appSets, err := a.appClientset.ArgoprojV1alpha1().ApplicationSets(a.ns).List(metav1.ListOptions{})
+	if err != nil {
+		log.Warnf("Failed to list application sets: %v", err)
+		return
+	}
+	for _, appSet := range appSets.Items {
+		for _, generator := range appSet.Spec.LeafGenerators() {
+			if generator.PullRequest != nil && pullRequestMatches(generator.PullRequest, event) {
+				a.queue.Add(appSetRefresh{name: appSet.Name})
+				break
 			}
 		}
 	}
 }
 
+// revisionMatches returns whether a push event of a revision affects the target revision of an app or generator
+func revisionMatches(targetRev string, revision string, touchedHead bool) bool {
+	if targetRev == "HEAD" || targetRev == "" {
+		return touchedHead
+	}
+	return targetRev == revision
+}
+
+// scmProviderMatches returns whether a repository, given by the path of its URL, belongs to the organization of a
+// GitHub SCM provider generator, or to the group of a GitLab one. The host is not compared, since refreshing an
+// application set which is not affected is harmless.
+func scmProviderMatches(generator *v1alpha1.SCMProviderGenerator, repoPath string) bool {
+	parent := path.Dir(strings.Trim(repoPath, "/"))
+	switch {
+	case generator.Github != nil:
+		return strings.EqualFold(parent, generator.Github.Organization)
+	case generator.Gitlab != nil:
+		group := strings.Trim(generator.Gitlab.Group, "/")
+		return strings.EqualFold(parent, group) ||
+			generator.Gitlab.IncludeSubgroups && strings.HasPrefix(strings.ToLower(parent), strings.ToLower(group)+"/")
+	}
+	return false
+}
+
+// pullRequestMatches returns whether a pull request generator lists the pull requests of the repository of an event.
+// The project of a GitLab generator is either its ID or its path.
+func pullRequestMatches(generator *v1alpha1.PullRequestGenerator, event pullRequestEvent) bool {
+	switch {
+	case generator.Github != nil:
+		return strings.EqualFold(generator.Github.Owner+"/"+generator.Github.Repo, event.repo)
+	case generator.Gitlab != nil:
+		return generator.Gitlab.Project == event.projectID || strings.EqualFold(generator.Gitlab.Project, event.repo)
+	}
+	return false
+}
+
+// refreshApplicationSet requests the application set controller to regenerate the applications of an ApplicationSet
+func refreshApplicationSet(appSetIf applicationv1alpha1.ApplicationSetInterface, name string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				common.AnnotationKeyApplicationSetRefresh: time.Now().UTC().Format(time.RFC3339Nano),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = appSetIf.Patch(name, types.MergePatchType, patch)
+	if err == nil {
+		log.Infof("Requested application set '%s' refresh", name)
+	}
+	return err
+}
+
 func (a *ArgoCDWebhookHandler) Handler(w http.ResponseWriter, r *http.Request) {
 
 	var payload interface{}
@@ -203,9 +424,9 @@ func (a *ArgoCDWebhookHandler) Handler(w http.ResponseWriter, r *http.Request) {
 	case r.Header.Get("X-Gogs-Event") != "":
 		payload, err = a.gogs.Parse(r, gogs.PushEvent)
 	case r.Header.Get("X-GitHub-Event") != "":
-		payload, err = a.github.Parse(r, github.PushEvent)
+		payload, err = a.github.Parse(r, github.PushEvent, github.PullRequestEvent, github.RepositoryEvent)
 	case r.Header.Get("X-Gitlab-Event") != "":
-		payload, err = a.gitlab.Parse(r, gitlab.PushEvents, gitlab.TagEvents)
+		payload, err = a.gitlab.Parse(r, gitlab.PushEvents, gitlab.TagEvents, gitlab.MergeRequestEvents)
 	case r.Header.Get("X-Hook-UUID") != "":
 		payload, err = a.bitbucket.Parse(r, bitbucket.RepoPushEvent)
 	case r.Header.Get("X-Event-Key") != "":
@@ -220,5 +441,10 @@ func (a *ArgoCDWebhookHandler) Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.HandleEvent(payload)
+	if !a.HandleEvent(payload) {
+		http.Error(w, "Too many queued webhook events", http.StatusServiceUnavailable)
+		return
+	}
+	// the events are processed asynchronously, so that git providers don't time out while many applications are refreshed
+	w.WriteHeader(http.StatusAccepted)
 }
@@ -2,18 +2,32 @@ package webhook
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubetesting "k8s.io/client-go/testing"
 
+	"github.com/argoproj/argo-cd/common"
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-cd/util/settings"
 )
 
+// processQueue processes the queued items until the queue is empty
+func processQueue(h *ArgoCDWebhookHandler) {
+	for h.queue.Len() > 0 {
+		h.processNextItem()
+	}
+}
+
 func NewMockHandler() *ArgoCDWebhookHandler {
 	appClientset := appclientset.NewSimpleClientset()
 	return NewHandler("", appClientset, &settings.ArgoCDSettings{})
@@ -28,7 +42,7 @@ func TestGitHubCommitEvent(t *testing.T) {
 	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
 	w := httptest.NewRecorder()
 	h.Handler(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Code, http.StatusAccepted)
 	expectedLogResult := "Received push event repo: https://github.com/jessesuen/test-repo, revision: master, touchedHead: true"
 	assert.Equal(t, expectedLogResult, hook.LastEntry().Message)
 	hook.Reset()
@@ -44,7 +58,7 @@ func TestGitHubTagEvent(t *testing.T) {
 	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
 	w := httptest.NewRecorder()
 	h.Handler(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Code, http.StatusAccepted)
 	expectedLogResult := "Received push event repo: https://github.com/jessesuen/test-repo, revision: v1.0, touchedHead: false"
 	assert.Equal(t, expectedLogResult, hook.LastEntry().Message)
 	hook.Reset()
@@ -60,7 +74,7 @@ func TestBitbucketServerRepositoryReferenceChangedEvent(t *testing.T) {
 	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
 	w := httptest.NewRecorder()
 	h.Handler(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Code, http.StatusAccepted)
 	expectedLogResultSsh := "Received push event repo: ssh://git@bitbucketserver:7999/myproject/test-repo.git, revision: master, touchedHead: true"
 	assert.Equal(t, expectedLogResultSsh, hook.AllEntries()[len(hook.AllEntries())-2].Message)
 	expectedLogResultHttps := "Received push event repo: https://bitbucketserver/scm/myproject/test-repo.git, revision: master, touchedHead: true"
@@ -78,7 +92,7 @@ func TestGogsPushEvent(t *testing.T) {
 	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
 	w := httptest.NewRecorder()
 	h.Handler(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Code, http.StatusAccepted)
 	expectedLogResult := "Received push event repo: http://gogs-server/john/repo-test, revision: master, touchedHead: true"
 	assert.Equal(t, expectedLogResult, hook.LastEntry().Message)
 	hook.Reset()
@@ -94,8 +108,211 @@ func TestGitLabPushEvent(t *testing.T) {
 	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
 	w := httptest.NewRecorder()
 	h.Handler(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Code, http.StatusAccepted)
 	expectedLogResult := "Received push event repo: https://gitlab/group/name, revision: master, touchedHead: true"
 	assert.Equal(t, expectedLogResult, hook.LastEntry().Message)
 	hook.Reset()
 }
+
+func TestGitHubCommitEvent_RefreshesApplicationSets(t *testing.T) {
+	newAppSet := func(name string, repoURL string, revision string) *v1alpha1.ApplicationSet {
+		return &v1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ApplicationSetSpec{Generators: []v1alpha1.ApplicationSetGenerator{
+				{Git: &v1alpha1.GitGenerator{RepoURL: repoURL, Revision: revision}},
+			}},
+		}
+	}
+	appClientset := appclientset.NewSimpleClientset(
+		newAppSet("head", "https://github.com/jessesuen/test-repo.git", ""),
+		newAppSet("master", "git@github.com:jessesuen/test-repo.git", "master"),
+		newAppSet("other-revision", "https://github.com/jessesuen/test-repo.git", "v1.0"),
+		newAppSet("other-repo", "https://github.com/argoproj/argocd-example-apps.git", ""),
+		&v1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "matrix"},
+			Spec: v1alpha1.ApplicationSetSpec{Generators: []v1alpha1.ApplicationSetGenerator{{Matrix: &v1alpha1.MatrixGenerator{
+				Generators: []v1alpha1.ApplicationSetNestedGenerator{
+					{Clusters: &v1alpha1.ClusterGenerator{}},
+					{Git: &v1alpha1.GitGenerator{RepoURL: "https://github.com/jessesuen/test-repo.git"}},
+				},
+			}}}},
+		},
+	)
+	h := NewHandler("", appClientset, &settings.ArgoCDSettings{})
+	req := httptest.NewRequest("POST", "/api/webhook", nil)
+	req.Header.Set("X-GitHub-Event", "push")
+	eventJSON, err := ioutil.ReadFile("github-commit-event.json")
+	assert.NoError(t, err)
+	req.Body = ioutil.NopCloser(bytes.NewReader(eventJSON))
+	w := httptest.NewRecorder()
+	h.Handler(w, req)
+	assert.Equal(t, w.Code, http.StatusAccepted)
+	processQueue(h)
+
+	for name, refreshed := range map[string]bool{"head": true, "master": true, "other-revision": false, "other-repo": false, "matrix": true} {
+		appSet, err := appClientset.ArgoprojV1alpha1().ApplicationSets("").Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, ok := appSet.Annotations[common.AnnotationKeyApplicationSetRefresh]
+		assert.Equal(t, refreshed, ok, name)
+	}
+}
+
+func TestGitHubCommitEvent_RefreshesApplicationsOnce(t *testing.T) {
+	newApp := func(name string, repoURL string, revision string) *v1alpha1.Application {
+		return &v1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.ApplicationSpec{Source: v1alpha1.ApplicationSource{RepoURL: repoURL, TargetRevision: revision}},
+		}
+	}
+	appClientset := appclientset.NewSimpleClientset(
+		newApp("head", "https://github.com/jessesuen/test-repo.git", "HEAD"),
+		newApp("master", "git@github.com:jessesuen/test-repo.git", "master"),
+		newApp("other-revision", "https://github.com/jessesuen/test-repo.git", "v1.0"),
+		newApp("other-repo", "https://github.com/argoproj/argocd-example-apps.git", "HEAD"),
+	)
+	h := NewHandler("", appClientset, &settings.ArgoCDSettings{})
+	eventJSON, err := ioutil.ReadFile("github-commit-event.json")
+	assert.NoError(t, err)
+	// the same event is delivered twice before it is processed
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/api/webhook", bytes.NewReader(eventJSON))
+		req.Header.Set("X-GitHub-Event", "push")
+		w := httptest.NewRecorder()
+		h.Handler(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	}
+	assert.Equal(t, 1, h.queue.Len())
+	appClientset.ClearActions()
+	processQueue(h)
+
+	var patched []string
+	for _, action := range appClientset.Actions() {
+		if action.GetVerb() == "patch" {
+			patched = append(patched, action.(kubetesting.PatchAction).GetName())
+		}
+	}
+	assert.ElementsMatch(t, []string{"head", "master"}, patched)
+}
+
+func TestHandler_QueueFull(t *testing.T) {
+	eventJSON, err := ioutil.ReadFile("github-commit-event.json")
+	assert.NoError(t, err)
+	handle := func(h *ArgoCDWebhookHandler) int {
+		req := httptest.NewRequest("POST", "/api/webhook", bytes.NewReader(eventJSON))
+		req.Header.Set("X-GitHub-Event", "push")
+		w := httptest.NewRecorder()
+		h.Handler(w, req)
+		return w.Code
+	}
+
+	h := NewMockHandler()
+	for i := 0; i < maxQueuedEvents; i++ {
+		assert.True(t, h.queueEvents(pushEvent{webURL: fmt.Sprintf("https://github.com/org/repo-%d", i)}))
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, handle(h))
+	// processing an event makes room for another one
+	h.processNextItem()
+	assert.Equal(t, http.StatusAccepted, handle(h))
+
+	// queued refreshes do not count towards the limit
+	h = NewMockHandler()
+	for i := 0; i < maxQueuedEvents; i++ {
+		h.queue.Add(appRefresh{name: fmt.Sprintf("app-%d", i)})
+	}
+	assert.Equal(t, http.StatusAccepted, handle(h))
+}
+
+func TestHandler_RunWithoutWorkers(t *testing.T) {
+	h := NewMockHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx, 0)
+	assert.True(t, h.queueEvents(pushEvent{webURL: "https://github.com/org/repo"}))
+	// at least one worker processes the event
+	for i := 0; i < 100 && h.queue.Len() > 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Equal(t, 0, h.queue.Len())
+}
+
+func TestGitHubCommitEvent_RefreshesSCMProviderApplicationSets(t *testing.T) {
+	newAppSet := func(name string, generator v1alpha1.SCMProviderGenerator) *v1alpha1.ApplicationSet {
+		return &v1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.ApplicationSetSpec{Generators: []v1alpha1.ApplicationSetGenerator{{SCMProvider: &generator}}},
+		}
+	}
+	appClientset := appclientset.NewSimpleClientset(
+		newAppSet("github", v1alpha1.SCMProviderGenerator{Github: &v1alpha1.SCMProviderGeneratorGithub{Organization: "JesseSuen"}}),
+		newAppSet("other-org", v1alpha1.SCMProviderGenerator{Github: &v1alpha1.SCMProviderGeneratorGithub{Organization: "argoproj"}}),
+		newAppSet("gitlab", v1alpha1.SCMProviderGenerator{Gitlab: &v1alpha1.SCMProviderGeneratorGitlab{Group: "jessesuen"}}),
+	)
+	h := NewHandler("", appClientset, &settings.ArgoCDSettings{})
+	eventJSON, err := ioutil.ReadFile("github-commit-event.json")
+	assert.NoError(t, err)
+	req := httptest.NewRequest("POST", "/api/webhook", bytes.NewReader(eventJSON))
+	req.Header.Set("X-GitHub-Event", "push")
+	w := httptest.NewRecorder()
+	h.Handler(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	processQueue(h)
+
+	for name, refreshed := range map[string]bool{"github": true, "other-org": false, "gitlab": true} {
+		appSet, err := appClientset.ArgoprojV1alpha1().ApplicationSets("").Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, ok := appSet.Annotations[common.AnnotationKeyApplicationSetRefresh]
+		assert.Equal(t, refreshed, ok, name)
+	}
+}
+
+func TestGitHubPullRequestEvent_RefreshesApplicationSets(t *testing.T) {
+	newAppSet := func(name string, owner string, repo string) *v1alpha1.ApplicationSet {
+		return &v1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ApplicationSetSpec{Generators: []v1alpha1.ApplicationSetGenerator{{Merge: &v1alpha1.MergeGenerator{
+				Generators: []v1alpha1.ApplicationSetNestedGenerator{
+					{PullRequest: &v1alpha1.PullRequestGenerator{Github: &v1alpha1.PullRequestGeneratorGithub{Owner: owner, Repo: repo}}},
+				},
+			}}}},
+		}
+	}
+	appClientset := appclientset.NewSimpleClientset(
+		newAppSet("test-repo", "jessesuen", "test-repo"),
+		newAppSet("other-repo", "jessesuen", "other-repo"),
+	)
+	hook := test.NewGlobal()
+	h := NewHandler("", appClientset, &settings.ArgoCDSettings{})
+	req := httptest.NewRequest("POST", "/api/webhook", bytes.NewReader([]byte(`{"action": "opened", "number": 1,
+		"repository": {"full_name": "jessesuen/test-repo", "html_url": "https://github.com/jessesuen/test-repo"}}`)))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	w := httptest.NewRecorder()
+	h.Handler(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "Received pull request event repo: jessesuen/test-repo", hook.LastEntry().Message)
+	hook.Reset()
+	processQueue(h)
+
+	for name, refreshed := range map[string]bool{"test-repo": true, "other-repo": false} {
+		appSet, err := appClientset.ArgoprojV1alpha1().ApplicationSets("").Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, ok := appSet.Annotations[common.AnnotationKeyApplicationSetRefresh]
+		assert.Equal(t, refreshed, ok, name)
+	}
+}
+
+func TestPullRequestMatches(t *testing.T) {
+	event := pullRequestEvent{repo: "argoproj/argo-cd", projectID: "42"}
+	assert.True(t, pullRequestMatches(&v1alpha1.PullRequestGenerator{Gitlab: &v1alpha1.PullRequestGeneratorGitlab{Project: "42"}}, event))
+	assert.True(t, pullRequestMatches(&v1alpha1.PullRequestGenerator{Gitlab: &v1alpha1.PullRequestGeneratorGitlab{Project: "ArgoProj/argo-cd"}}, event))
+	assert.False(t, pullRequestMatches(&v1alpha1.PullRequestGenerator{Gitlab: &v1alpha1.PullRequestGeneratorGitlab{Project: "43"}}, event))
+	assert.False(t, pullRequestMatches(&v1alpha1.PullRequestGenerator{}, event))
+}
+
+func TestSCMProviderMatches(t *testing.T) {
+	subgroups := &v1alpha1.SCMProviderGenerator{Gitlab: &v1alpha1.SCMProviderGeneratorGitlab{Group: "argoproj", IncludeSubgroups: true}}
+	assert.True(t, scmProviderMatches(subgroups, "/argoproj/labs/argo-cd"))
+	assert.True(t, scmProviderMatches(subgroups, "/argoproj/argo-cd"))
+	assert.False(t, scmProviderMatches(subgroups, "/argoproj-labs/argo-cd"))
+	subgroups.Gitlab.IncludeSubgroups = false
+	assert.False(t, scmProviderMatches(subgroups, "/argoproj/labs/argo-cd"))
+}